@@ -11,7 +11,11 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/internal/cli"
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai/safety"
+	aitools "github.com/cloudbro-kube-ai/k13d/pkg/ai/tools"
 	"github.com/cloudbro-kube-ai/k13d/pkg/bench"
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/cloudbro-kube-ai/k13d/pkg/eval"
 )
 
@@ -28,6 +32,8 @@ func main() {
 	dryrunCmd := flag.NewFlagSet("dryrun", flag.ExitOnError)
 	analyzeCmd := flag.NewFlagSet("analyze", flag.ExitOnError)
 	listCmd := flag.NewFlagSet("list", flag.ExitOnError)
+	newCmd := flag.NewFlagSet("new", flag.ExitOnError)
+	validateCmd := flag.NewFlagSet("validate", flag.ExitOnError)
 
 	// Run subcommand flags
 	runTaskDir := runCmd.String("task-dir", defaultTaskDir, "Directory containing benchmark tasks")
@@ -58,6 +64,8 @@ func main() {
 	runLLMAPIKey := runCmd.String("llm-api-key", "", "LLM API key (optional, uses env)")
 	runEnableTools := runCmd.Bool("enable-tools", true, "Enable tool/function calling")
 	runAutoApprove := runCmd.Bool("auto-approve", true, "Auto-approve tool executions")
+	runApprovalMode := runCmd.String("approval-mode", "", "Tool approval mode: all, or reads (run read-only calls, decline the rest); empty follows --auto-approve")
+	runSafeTools := runCmd.Bool("safe-tools", false, "Restrict the built-in agent to read-only kubectl verbs")
 	// Output options
 	runQuiet := runCmd.Bool("quiet", false, "Suppress progress output")
 	runSaveTrace := runCmd.Bool("save-trace", false, "Save trace.yaml per task")
@@ -91,18 +99,29 @@ func main() {
 	listDifficulty := listCmd.String("difficulty", "", "Filter by difficulty")
 	listCategories := listCmd.String("categories", "", "Filter by categories")
 	listTags := listCmd.String("tags", "", "Filter by tags")
+	listTimeout := listCmd.String("timeout", defaultTimeout, "Default task timeout, shown for tasks without their own")
+
+	// New subcommand flags
+	newTaskDir := newCmd.String("task-dir", defaultTaskDir, "Directory containing benchmark tasks")
+	newID := newCmd.String("id", "", "Task ID and directory name (e.g., fix-crashloop)")
+	newDifficulty := newCmd.String("difficulty", string(bench.DifficultyMedium), "Task difficulty (easy, medium, hard)")
+	newCategory := newCmd.String("category", "troubleshooting", "Task category")
+	newDescription := newCmd.String("description", "", "One-line task description")
+
+	// Validate subcommand flags
+	validateTaskDir := validateCmd.String("task-dir", defaultTaskDir, "Directory containing benchmark tasks")
 
 	// Parse arguments
 	if len(os.Args) < 2 {
 		printUsage()
-		os.Exit(1)
+		os.Exit(cli.ExitConfig)
 	}
 
 	switch os.Args[1] {
 	case "run":
 		if err := runCmd.Parse(os.Args[2:]); err != nil {
 			fmt.Fprintf(os.Stderr, "Error parsing run flags: %v\n", err)
-			os.Exit(1)
+			os.Exit(cli.ExitConfig)
 		}
 		if err := executeRun(runCmd, runConfig{
 			taskDir:           *runTaskDir,
@@ -132,18 +151,20 @@ func main() {
 			llmAPIKey:         *runLLMAPIKey,
 			enableTools:       *runEnableTools,
 			autoApprove:       *runAutoApprove,
+			approvalMode:      *runApprovalMode,
+			safeTools:         *runSafeTools,
 			quiet:             *runQuiet,
 			saveTrace:         *runSaveTrace,
 			saveLog:           *runSaveLog,
 		}); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			os.Exit(cli.ExitCode(err))
 		}
 
 	case "dryrun", "dry-run":
 		if err := dryrunCmd.Parse(os.Args[2:]); err != nil {
 			fmt.Fprintf(os.Stderr, "Error parsing dryrun flags: %v\n", err)
-			os.Exit(1)
+			os.Exit(cli.ExitConfig)
 		}
 		if err := executeDryRun(dryrunConfig{
 			taskDir:     *dryrunTaskDir,
@@ -162,27 +183,52 @@ func main() {
 			autoApprove: *dryrunAutoApprove,
 		}); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			os.Exit(cli.ExitCode(err))
 		}
 
 	case "analyze":
 		if err := analyzeCmd.Parse(os.Args[2:]); err != nil {
 			fmt.Fprintf(os.Stderr, "Error parsing analyze flags: %v\n", err)
-			os.Exit(1)
+			os.Exit(cli.ExitConfig)
 		}
 		if err := executeAnalyze(*analyzeInputDir, *analyzeOutputFormat, *analyzeOutputFile, *analyzeShowFailures); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			os.Exit(cli.ExitCode(err))
 		}
 
 	case "list":
 		if err := listCmd.Parse(os.Args[2:]); err != nil {
 			fmt.Fprintf(os.Stderr, "Error parsing list flags: %v\n", err)
-			os.Exit(1)
+			os.Exit(cli.ExitConfig)
+		}
+		if err := executeList(*listTaskDir, *listDifficulty, *listCategories, *listTags, *listTimeout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(cli.ExitCode(err))
+		}
+
+	case "new":
+		if err := newCmd.Parse(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing new flags: %v\n", err)
+			os.Exit(cli.ExitConfig)
+		}
+		if err := executeNew(*newTaskDir, bench.ScaffoldOptions{
+			ID:          *newID,
+			Difficulty:  bench.TaskDifficulty(*newDifficulty),
+			Category:    *newCategory,
+			Description: *newDescription,
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(cli.ExitCode(err))
+		}
+
+	case "validate":
+		if err := validateCmd.Parse(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing validate flags: %v\n", err)
+			os.Exit(cli.ExitConfig)
 		}
-		if err := executeList(*listTaskDir, *listDifficulty, *listCategories, *listTags); err != nil {
+		if err := executeValidate(*validateTaskDir); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			os.Exit(cli.ExitCode(err))
 		}
 
 	case "help", "-h", "--help":
@@ -191,7 +237,7 @@ func main() {
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", os.Args[1])
 		printUsage()
-		os.Exit(1)
+		os.Exit(cli.ExitConfig)
 	}
 }
 
@@ -206,7 +252,8 @@ type runConfig struct {
 	agentMaxTurns, agentMaxTokens                      int
 	models                                             string
 	llmProvider, llmModel, llmEndpoint, llmAPIKey      string
-	enableTools, autoApprove                           bool
+	enableTools, autoApprove, safeTools                bool
+	approvalMode                                       string
 	quiet, saveTrace, saveLog                          bool
 }
 
@@ -235,11 +282,27 @@ func executeRun(cmd *flag.FlagSet, cfg runConfig) error {
 		cancel()
 	}()
 
+	switch cfg.approvalMode {
+	case "", config.AutoApproveReads, config.AutoApproveAll:
+	default:
+		return cli.WithExitCode(cli.ExitConfig, fmt.Errorf("invalid --approval-mode %q (want all or reads)", cfg.approvalMode))
+	}
+
+	// Auto-approve skips the approval prompt, not the tool guard, so safe
+	// tools still hold. External agent binaries run their own tools.
+	if cfg.safeTools {
+		safePolicy := config.ToolApprovalPolicy{SafeTools: true}
+		aitools.SetCommandGuard(safety.ToolGuard(func() config.ToolApprovalPolicy { return safePolicy }))
+	}
+
 	// Build LLM configs (support multiple models)
 	var llmConfigs []bench.LLMConfig
 	if cfg.models != "" {
 		// Parse --models flag: "openai:gpt-4,anthropic:claude-3"
 		llmConfigs = parseModelsFlag(cfg.models, cfg.llmEndpoint, cfg.llmAPIKey, cfg.enableTools, cfg.autoApprove)
+		for i := range llmConfigs {
+			llmConfigs[i].ApprovalMode = cfg.approvalMode
+		}
 	} else {
 		// Use single LLM config from individual flags
 		llmConfigs = []bench.LLMConfig{{
@@ -250,6 +313,7 @@ func executeRun(cmd *flag.FlagSet, cfg runConfig) error {
 			APIKey:        cfg.llmAPIKey,
 			EnableToolUse: cfg.enableTools,
 			AutoApprove:   cfg.autoApprove,
+			ApprovalMode:  cfg.approvalMode,
 		}}
 	}
 
@@ -284,7 +348,7 @@ func executeRun(cmd *flag.FlagSet, cfg runConfig) error {
 	// Create and run benchmark
 	runner, err := bench.NewRunner(runCfg)
 	if err != nil {
-		return fmt.Errorf("failed to create runner: %w", err)
+		return cli.WithExitCode(cli.ExitConfig, fmt.Errorf("failed to create runner: %w", err))
 	}
 
 	summary, err := runner.Run(ctx)
@@ -304,6 +368,9 @@ func executeRun(cmd *flag.FlagSet, cfg runConfig) error {
 	}
 	fmt.Printf("\nReport written to: %s\n", reportPath)
 
+	if failed := summary.FailCount + summary.ErrorCount; failed > 0 {
+		return cli.WithExitCode(cli.ExitPartial, fmt.Errorf("%d of %d tasks failed", failed, summary.TotalTasks))
+	}
 	return nil
 }
 
@@ -392,12 +459,12 @@ func executeDryRun(cfg dryrunConfig) error {
 
 	// Load tasks
 	if err := runner.LoadTasks(); err != nil {
-		return fmt.Errorf("failed to load tasks: %w", err)
+		return cli.WithExitCode(cli.ExitConfig, fmt.Errorf("failed to load tasks: %w", err))
 	}
 
 	tasks := runner.GetTasks()
 	if len(tasks) == 0 {
-		return fmt.Errorf("no tasks found in %s", cfg.taskDir)
+		return cli.WithExitCode(cli.ExitConfig, fmt.Errorf("no tasks found in %s", cfg.taskDir))
 	}
 
 	fmt.Printf("Loaded %d tasks for dry-run evaluation\n", len(tasks))
@@ -426,6 +493,9 @@ func executeDryRun(cfg dryrunConfig) error {
 		return fmt.Errorf("failed to save report: %w", err)
 	}
 
+	if report.Summary.FailedTasks > 0 {
+		return cli.WithExitCode(cli.ExitPartial, fmt.Errorf("%d of %d tasks failed", report.Summary.FailedTasks, report.Summary.TotalTasks))
+	}
 	return nil
 }
 
@@ -461,7 +531,7 @@ func executeAnalyze(inputDir, outputFormat, outputFile string, showFailures bool
 	return nil
 }
 
-func executeList(taskDir, difficulty, categories, tags string) error {
+func executeList(taskDir, difficulty, categories, tags, timeout string) error {
 	loader := bench.NewLoader(taskDir)
 	tasks, err := loader.LoadTasks()
 	if err != nil {
@@ -478,17 +548,60 @@ func executeList(taskDir, difficulty, categories, tags string) error {
 	}
 
 	fmt.Printf("Found %d tasks:\n\n", len(tasks))
-	fmt.Printf("%-25s %-10s %-15s %s\n", "ID", "DIFFICULTY", "CATEGORY", "DESCRIPTION")
-	fmt.Println(strings.Repeat("-", 80))
+	fmt.Printf("%-25s %-10s %-15s %-8s %s\n", "ID", "DIFFICULTY", "CATEGORY", "TIMEOUT", "DESCRIPTION")
+	fmt.Println(strings.Repeat("-", 89))
 
 	for _, task := range tasks {
 		desc := task.Description
 		if len(desc) > 30 {
 			desc = desc[:27] + "..."
 		}
-		fmt.Printf("%-25s %-10s %-15s %s\n", task.ID, task.Difficulty, task.Category, desc)
+		fmt.Printf("%-25s %-10s %-15s %-8s %s\n", task.ID, task.Difficulty, task.Category, task.EffectiveTimeout(timeout), desc)
+	}
+
+	return nil
+}
+
+func executeNew(taskDir string, opts bench.ScaffoldOptions) error {
+	if opts.ID == "" {
+		return cli.WithExitCode(cli.ExitConfig, fmt.Errorf("--id is required"))
 	}
 
+	dir, err := bench.ScaffoldTask(taskDir, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created task %s in %s\n\n", opts.ID, dir)
+	fmt.Println("Next steps:")
+	fmt.Println("  1. Write the agent prompt in task.yaml")
+	fmt.Println("  2. Fill in setup.sh, verify.sh, and cleanup.sh")
+	fmt.Println("  3. Remove 'disabled: true' from task.yaml to include it in runs")
+	fmt.Printf("  4. Check it loads: k13d-bench validate --task-dir %s\n", taskDir)
+	return nil
+}
+
+func executeValidate(taskDir string) error {
+	tasks, problems, skipped, err := bench.NewLoader(taskDir).ValidateTasks()
+	if err != nil {
+		return err
+	}
+
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if len(skipped) > 0 {
+		fmt.Printf("Skipped %d directories without task.yaml: %s\n", len(skipped), strings.Join(skipped, ", "))
+	}
+
+	invalid := make(map[string]bool)
+	for _, p := range problems {
+		invalid[p.File] = true
+	}
+	fmt.Printf("%d tasks valid, %d invalid (%d problems)\n", len(tasks), len(invalid), len(problems))
+	if len(problems) > 0 {
+		return cli.WithExitCode(cli.ExitConfig, fmt.Errorf("task validation failed"))
+	}
 	return nil
 }
 
@@ -503,6 +616,8 @@ COMMANDS:
     dryrun    Run dry-run benchmark (no cluster required)
     analyze   Analyze and report benchmark results
     list      List available benchmark tasks
+    new       Scaffold a new benchmark task directory
+    validate  Check task files for unknown or invalid fields without running them
     help      Show this help message
 
 EXAMPLES:
@@ -548,6 +663,20 @@ EXAMPLES:
     # List available tasks
     k13d-bench list --task-dir benchmarks/tasks
 
+    # Scaffold a new task
+    k13d-bench new --id fix-crashloop --difficulty medium
+
+    # Check every task file without running anything
+    k13d-bench validate --task-dir benchmarks/tasks
+
+EXIT CODES:
+    0  Success
+    1  Unexpected failure
+    2  Invalid flags or task files
+    3  Cluster or LLM endpoint unreachable
+    4  Credentials missing or rejected
+    5  Run completed, but some tasks failed
+
 Run 'k13d-bench <command> --help' for more information on a command.`)
 }
 
@@ -10,7 +10,9 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/internal/cli"
 	"github.com/cloudbro-kube-ai/k13d/pkg/ai/providers"
 	"github.com/cloudbro-kube-ai/k13d/pkg/eval"
 	"gopkg.in/yaml.v3"
@@ -33,6 +35,8 @@ func main() {
 	geminiAPIKey := flag.String("gemini-api-key", "", "Gemini API key")
 	solarAPIKey := flag.String("solar-api-key", "", "Solar (Upstage) API key")
 	solarEndpoint := flag.String("solar-endpoint", "https://api.upstage.ai/v1", "Solar API endpoint")
+	ping := flag.Bool("ping", true, "Send each model a one-line request before running tasks and skip the ones that do not answer")
+	pingTimeout := flag.Duration("ping-timeout", 30*time.Second, "How long --ping waits for each model")
 	verbose := flag.Bool("verbose", false, "Verbose output")
 	flag.Parse()
 
@@ -62,25 +66,25 @@ func main() {
 	if len(modelConfigs) == 0 {
 		fmt.Fprintln(os.Stderr, "Error: specify --models or --llm-provider + --llm-model")
 		flag.Usage()
-		os.Exit(1)
+		os.Exit(cli.ExitConfig)
 	}
 
 	// Load tasks
 	data, err := os.ReadFile(*tasksFile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading tasks file: %v\n", err)
-		os.Exit(1)
+		os.Exit(cli.ExitConfig)
 	}
 
 	var tl taskList
 	if err := yaml.Unmarshal(data, &tl); err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing tasks file: %v\n", err)
-		os.Exit(1)
+		os.Exit(cli.ExitConfig)
 	}
 
 	if len(tl.Tasks) == 0 {
 		fmt.Fprintln(os.Stderr, "Error: no tasks found in tasks file")
-		os.Exit(1)
+		os.Exit(cli.ExitConfig)
 	}
 
 	// Context with signal handling
@@ -99,23 +103,52 @@ func main() {
 	fmt.Printf("Tasks: %d\n", len(tl.Tasks))
 	fmt.Printf("Models: %d\n\n", len(modelConfigs))
 
-	var allReports []eval.ModelEvalReport
+	// Exit code of the last model that could not run
+	skippedCode := cli.ExitOK
 
+	// Set up every model before running any task, so a wrong key, model, or
+	// endpoint is reported now rather than halfway through the run
+	fmt.Println("--- Checking providers ---")
+	var ready []readyModel
 	for _, mc := range modelConfigs {
-		fmt.Printf("--- Evaluating: %s/%s ---\n", mc.providerName, mc.modelName)
-
-		// Create provider
 		provider, err := createProvider(mc)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "  Error creating provider %s/%s: %v\n\n", mc.providerName, mc.modelName, err)
+			fmt.Fprintf(os.Stderr, "  SKIP %s/%s: cannot create provider: %v\n", mc.providerName, mc.modelName, err)
+			skippedCode = cli.ExitConfig
 			continue
 		}
 
 		if !provider.IsReady() {
-			fmt.Fprintf(os.Stderr, "  Provider %s/%s is not ready (check API key)\n\n", mc.providerName, mc.modelName)
+			fmt.Fprintf(os.Stderr, "  SKIP %s/%s: provider is not ready (check API key)\n", mc.providerName, mc.modelName)
+			skippedCode = cli.ExitAuth
 			continue
 		}
 
+		if *ping {
+			elapsed, err := eval.PingProvider(ctx, provider, *pingTimeout)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "  SKIP %s/%s: unreachable: %v\n", mc.providerName, mc.modelName, err)
+				skippedCode = pingExitCode(err)
+				continue
+			}
+			fmt.Printf("  OK   %s/%s (%.1fs)\n", mc.providerName, mc.modelName, elapsed.Seconds())
+		} else {
+			fmt.Printf("  OK   %s/%s (not pinged)\n", mc.providerName, mc.modelName)
+		}
+		ready = append(ready, readyModel{modelConfig: mc, provider: provider})
+	}
+	if skipped := len(modelConfigs) - len(ready); skipped > 0 {
+		fmt.Printf("Skipping %d of %d models\n", skipped, len(modelConfigs))
+	}
+	fmt.Println()
+
+	var allReports []eval.ModelEvalReport
+	failedTasks := 0
+
+	for _, m := range ready {
+		mc, provider := m.modelConfig, m.provider
+		fmt.Printf("--- Evaluating: %s/%s ---\n", mc.providerName, mc.modelName)
+
 		// Run evaluation
 		var results []eval.EvalResult
 		for i, task := range tl.Tasks {
@@ -145,6 +178,7 @@ func main() {
 
 		report := eval.BuildModelReport(mc.providerName, mc.modelName, results)
 		allReports = append(allReports, report)
+		failedTasks += report.TotalTasks - report.PassedTasks
 
 		fmt.Printf("\n  Result: %d/%d passed (%.1f%%), avg score: %.2f, avg time: %.2fs\n\n",
 			report.PassedTasks, report.TotalTasks, report.PassRate,
@@ -169,10 +203,19 @@ func main() {
 	// Save reports
 	if err := eval.SaveComparisonReport(allReports, tl.Tasks, *outputDir); err != nil {
 		fmt.Fprintf(os.Stderr, "Error saving reports: %v\n", err)
-		os.Exit(1)
+		os.Exit(cli.ExitFailure)
 	}
 
 	fmt.Println("Done.")
+
+	// No model ran: exit with the reason. Otherwise skipped models and
+	// failed tasks count as partial success.
+	switch {
+	case len(allReports) == 0:
+		os.Exit(skippedCode)
+	case skippedCode != cli.ExitOK || failedTasks > 0:
+		os.Exit(cli.ExitPartial)
+	}
 }
 
 type modelConfig struct {
@@ -182,6 +225,21 @@ type modelConfig struct {
 	apiKey       string
 }
 
+// readyModel is a model whose provider was created and passed the checks
+type readyModel struct {
+	modelConfig
+	provider providers.Provider
+}
+
+// pingExitCode classifies a failed ping; anything that is not a rejected
+// key counts as an unreachable endpoint
+func pingExitCode(err error) int {
+	if code := cli.ExitCode(err); code != cli.ExitFailure {
+		return code
+	}
+	return cli.ExitConnection
+}
+
 func parseModelConfigs(models, provider, model, endpoint, apiKey string, apiKeys, endpointMap map[string]string) []modelConfig {
 	var configs []modelConfig
 
@@ -0,0 +1,30 @@
+//go:build !noai
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cloudbro-kube-ai/k13d/internal/cli"
+	k13dcli "github.com/cloudbro-kube-ai/k13d/pkg/cli"
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/log"
+)
+
+func runCLI(cfg *config.Config) {
+	defer cli.InitDB(cfg)()
+
+	ver := k13dcli.VersionInfo{
+		Version:   Version,
+		BuildTime: BuildTime,
+		GitCommit: GitCommit,
+	}
+
+	repl := k13dcli.New(cfg, ver)
+	if err := repl.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "CLI error: %v\n", err)
+		log.Errorf("CLI error: %v", err)
+		os.Exit(cli.ExitCode(err))
+	}
+}
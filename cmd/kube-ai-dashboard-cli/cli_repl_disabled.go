@@ -0,0 +1,18 @@
+//go:build noai
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cloudbro-kube-ai/k13d/internal/cli"
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+// runCLI is unavailable in builds with the noai tag: the REPL is built on
+// the AI client, which those builds leave out
+func runCLI(cfg *config.Config) {
+	fmt.Fprintln(os.Stderr, "CLI mode needs the AI assistant, which is not included in this build (noai)")
+	os.Exit(cli.ExitConfig)
+}
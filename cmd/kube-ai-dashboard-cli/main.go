@@ -8,12 +8,13 @@ import (
 	"os"
 	"os/signal"
 	"runtime/debug"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 	_ "time/tzdata"
 
 	"github.com/cloudbro-kube-ai/k13d/internal/cli"
-	k13dcli "github.com/cloudbro-kube-ai/k13d/pkg/cli"
 	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/cloudbro-kube-ai/k13d/pkg/log"
 	mcpserver "github.com/cloudbro-kube-ai/k13d/pkg/mcp/server"
@@ -34,16 +35,45 @@ func main() {
 	// Mode flags
 	webMode := flag.Bool("web", cli.EnvBoolDefault("K13D_WEB", false), "Start web server mode")
 	tuiMode := flag.Bool("tui", false, "Start TUI mode (default when no mode specified)")
-	mcpMode := flag.Bool("mcp", cli.EnvBoolDefault("K13D_MCP", false), "Start MCP server mode (stdio transport)")
+	mcpMode := flag.Bool("mcp", cli.EnvBoolDefault("K13D_MCP", false), "Start MCP server mode (stdio transport unless --mcp-transport is set)")
+	mcpTransport := flag.String("mcp-transport", cli.EnvDefault("K13D_MCP_TRANSPORT", "stdio"), "MCP server transport: stdio or http (SSE, used with --mcp)")
+	mcpPort := flag.Int("mcp-port", cli.EnvIntDefault("K13D_MCP_PORT", 8090), "MCP server port for --mcp-transport http")
 	cliMode := flag.Bool("cli", cli.EnvBoolDefault("K13D_CLI", false), "Start CLI REPL mode")
 	webPort := flag.Int("port", cli.EnvIntDefault("K13D_PORT", 8080), "Web server port (used with --web)")
 	configPath := flag.String("config", cli.EnvDefault("K13D_CONFIG", ""), "Config file path (default: platform XDG config dir + /k13d/config.yaml)")
+	profile := flag.String("profile", cli.EnvDefault("K13D_PROFILE", ""), "Named profile from the config file's profiles section to apply on top of it")
+	kubeconfig := flag.String("kubeconfig", cli.EnvDefault("K13D_KUBECONFIG", ""), "Path to kubeconfig file(s); overrides KUBECONFIG and in-cluster detection")
 
 	// Namespace flags (k9s compatible)
 	namespace := flag.String("namespace", cli.EnvDefault("K13D_NAMESPACE", ""), "Initial namespace (use 'all' for all namespaces)")
 	flag.StringVar(namespace, "n", "", "Initial namespace (short for --namespace)")
 	allNamespaces := flag.Bool("all-namespaces", cli.EnvBoolDefault("K13D_ALL_NAMESPACES", false), "Start with all namespaces")
 	flag.BoolVar(allNamespaces, "A", false, "Start with all namespaces (short for --all-namespaces)")
+	namespaceRegex := flag.String("namespace-regex", cli.EnvDefault("K13D_NAMESPACE_REGEX", ""), "Limit all-namespace views to namespaces matching this regular expression, e.g. '^team-.*-prod$'")
+
+	// Appearance flags
+	theme := flag.String("theme", cli.EnvDefault("K13D_THEME", ""), "Color theme: dark, light, high-contrast, or a custom skin name")
+
+	// Kubernetes API client flags
+	kubeQPS := flag.Float64("kube-qps", 0, "Kubernetes API client QPS limit (default: kubernetes.qps from config)")
+	kubeBurst := flag.Int("kube-burst", 0, "Kubernetes API client burst limit (default: kubernetes.burst from config)")
+	kubeMaxConcurrency := flag.Int("kube-max-concurrency", 0, "Namespaces listed in parallel by bulk queries (default: kubernetes.max_concurrency from config)")
+
+	// Logging flags
+	logLevel := flag.String("log-level", cli.EnvDefault("K13D_LOG_LEVEL", ""), "Log level: debug, info, warn, error (default: log_level from config)")
+	logFormat := flag.String("log-format", cli.EnvDefault("K13D_LOG_FORMAT", ""), "Log format: text or json (default: log_format from config)")
+
+	// AI tool flags
+	safeTools := flag.Bool("safe-tools", cli.EnvBoolDefault("K13D_SAFE_TOOLS", false), "Restrict AI tools to read-only kubectl verbs (no bash, no mutations)")
+	logLLMPayloads := flag.Bool("log-llm-payloads", cli.EnvBoolDefault("K13D_LLM_LOG_PAYLOADS", false), "Log redacted LLM request/response bodies at debug level")
+
+	// Subsystem flags for a lighter viewer
+	noAI := flag.Bool("no-ai", cli.EnvBoolDefault("K13D_NO_AI", false), "Disable the AI assistant and its LLM client")
+	noSecurity := flag.Bool("no-security", cli.EnvBoolDefault("K13D_NO_SECURITY", false), "Disable the security scanner, scheduled scans, and report security sections")
+	noFinOps := flag.Bool("no-finops", cli.EnvBoolDefault("K13D_NO_FINOPS", false), "Disable FinOps cost analysis in reports")
+
+	// Startup checks
+	preflight := flag.Bool("preflight", cli.EnvBoolDefault("K13D_PREFLIGHT", false), "Check cluster access, RBAC, the LLM provider, and the database before starting")
 
 	// Info flags
 	showVersion := flag.Bool("version", false, "Show version information")
@@ -55,11 +85,30 @@ func main() {
 	adminUser := flag.String("admin-user", cli.EnvDefault("K13D_USERNAME", ""), "Default admin username for local auth mode")
 	adminPass := flag.String("admin-password", cli.EnvDefault("K13D_PASSWORD", ""), "Default admin password for local auth mode")
 
+	// Web server TLS flags (env: K13D_TLS_CERT, K13D_TLS_KEY, K13D_TLS_SELF_SIGNED)
+	tlsCert := flag.String("tls-cert", cli.EnvDefault("K13D_TLS_CERT", ""), "TLS certificate file for the web server; reloaded when it changes (requires --tls-key)")
+	tlsKey := flag.String("tls-key", cli.EnvDefault("K13D_TLS_KEY", ""), "TLS private key file for the web server (requires --tls-cert)")
+	tlsSelfSigned := flag.Bool("tls-self-signed", cli.EnvBoolDefault("K13D_TLS_SELF_SIGNED", false), "Serve the web UI with a generated self-signed certificate (development only)")
+
+	// Web session flags (env: K13D_SESSION_IDLE_TIMEOUT, K13D_SESSION_MAX_LIFETIME)
+	sessionIdleTimeout := flag.String("session-idle-timeout", cli.EnvDefault("K13D_SESSION_IDLE_TIMEOUT", ""), "Log out web sessions after this long without user activity, e.g. 30m (default: disabled)")
+	sessionMaxLifetime := flag.String("session-max-lifetime", cli.EnvDefault("K13D_SESSION_MAX_LIFETIME", ""), "Require web users to log in again this long after login, e.g. 8h (default: 24h)")
+
 	// Storage flags
 	dbPath := flag.String("db-path", cli.EnvDefault("K13D_DB_PATH", ""), "SQLite database path (default: platform XDG config dir + /k13d/audit.db)")
 	disableDB := flag.Bool("no-db", cli.EnvBoolDefault("K13D_NO_DB", false), "Disable database persistence entirely")
 	showStorageInfo := flag.Bool("storage-info", false, "Show storage configuration and data locations")
 
+	// Config portability flags
+	exportConfig := flag.Bool("export-config", false, "Print the effective config (file, profile, and env overrides) as YAML with secrets redacted, then exit")
+	exportFile := flag.String("export-file", "", "Write --export-config output to this file instead of stdout")
+	includeSecrets := flag.Bool("include-secrets", false, "Include API keys, passwords, and tokens in --export-config output")
+	importConfig := flag.String("import-config", "", "Replace the config file with an exported config, keeping current values for redacted secrets, then exit")
+
+	// Report diff flags
+	reportDiff := flag.String("report-diff", "", "Compare two JSON reports given as OLD,NEW and print what changed, then exit")
+	reportDiffFormat := flag.String("report-diff-format", "markdown", "Output format for --report-diff: markdown or html")
+
 	// Experimental features flag
 	experimental := flag.Bool("experimental", cli.EnvBoolDefault("K13D_EXPERIMENTAL", false), "Enable experimental features (unstable, subject to change)")
 
@@ -68,6 +117,73 @@ func main() {
 	if *configPath != "" {
 		_ = os.Setenv("K13D_CONFIG", *configPath)
 	}
+	if *profile != "" {
+		_ = os.Setenv("K13D_PROFILE", *profile)
+	}
+	if *theme != "" {
+		_ = os.Setenv("K13D_THEME", *theme)
+	}
+	if *logLevel != "" {
+		if _, err := log.ParseLevel(*logLevel); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(cli.ExitConfig)
+		}
+		_ = os.Setenv("K13D_LOG_LEVEL", *logLevel)
+	}
+	if *logFormat != "" {
+		if _, err := log.ParseFormat(*logFormat); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(cli.ExitConfig)
+		}
+		_ = os.Setenv("K13D_LOG_FORMAT", *logFormat)
+	}
+	if *safeTools {
+		_ = os.Setenv("K13D_SAFE_TOOLS", "true")
+	}
+	if *logLLMPayloads {
+		_ = os.Setenv("K13D_LLM_LOG_PAYLOADS", "true")
+	}
+	if *noAI {
+		_ = os.Setenv("K13D_NO_AI", "true")
+	}
+	if *noSecurity {
+		_ = os.Setenv("K13D_NO_SECURITY", "true")
+	}
+	if *noFinOps {
+		_ = os.Setenv("K13D_NO_FINOPS", "true")
+	}
+	if *preflight {
+		_ = os.Setenv("K13D_PREFLIGHT", "true")
+	}
+	if *kubeQPS > 0 {
+		_ = os.Setenv("K13D_KUBE_QPS", strconv.FormatFloat(*kubeQPS, 'f', -1, 32))
+	}
+	if *kubeBurst > 0 {
+		_ = os.Setenv("K13D_KUBE_BURST", strconv.Itoa(*kubeBurst))
+	}
+	if *kubeMaxConcurrency > 0 {
+		_ = os.Setenv("K13D_KUBE_MAX_CONCURRENCY", strconv.Itoa(*kubeMaxConcurrency))
+	}
+	if *tlsCert != "" {
+		_ = os.Setenv("K13D_TLS_CERT", *tlsCert)
+	}
+	if *tlsKey != "" {
+		_ = os.Setenv("K13D_TLS_KEY", *tlsKey)
+	}
+	if *tlsSelfSigned {
+		_ = os.Setenv("K13D_TLS_SELF_SIGNED", "true")
+	}
+	if *sessionIdleTimeout != "" {
+		_ = os.Setenv("K13D_SESSION_IDLE_TIMEOUT", *sessionIdleTimeout)
+	}
+	if *sessionMaxLifetime != "" {
+		_ = os.Setenv("K13D_SESSION_MAX_LIFETIME", *sessionMaxLifetime)
+	}
+	if *kubeconfig != "" {
+		// KUBECONFIG is set too so kubectl, helm, and plugins spawned by k13d use the same file
+		_ = os.Setenv("K13D_KUBECONFIG", *kubeconfig)
+		_ = os.Setenv("KUBECONFIG", *kubeconfig)
+	}
 
 	// -tui flag is explicit TUI mode (useful for Docker)
 	_ = tuiMode // TUI is default when -web is not specified
@@ -92,6 +208,35 @@ func main() {
 		return
 	}
 
+	// Config export/import
+	if *exportConfig {
+		if err := runExportConfig(*exportFile, *includeSecrets); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(cli.ExitCode(err))
+		}
+		return
+	}
+	if *importConfig != "" {
+		path, warnings, err := config.ImportConfigFile(*importConfig)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to import %s: %v\n", *importConfig, err)
+			os.Exit(cli.ExitConfig)
+		}
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+		}
+		fmt.Printf("Imported %s into %s\n", *importConfig, path)
+		return
+	}
+
+	if *reportDiff != "" {
+		if err := runReportDiff(*reportDiff, *reportDiffFormat); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(cli.ExitCode(err))
+		}
+		return
+	}
+
 	// Initialize enterprise logger
 	if err := log.Init("k13d"); err != nil {
 		fmt.Printf("Warning: could not initialize logger: %v\n", err)
@@ -102,12 +247,19 @@ func main() {
 	// Load config
 	cfg, err := config.LoadConfig()
 	if err != nil {
+		// Only a bad --profile fails here; starting without it would
+		// silently drop the settings it locks in
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		log.Errorf("Failed to load config: %v", err)
-		cfg = config.NewDefaultConfig()
+		os.Exit(cli.ExitConfig)
+	}
+	if cfg.ActiveProfile != "" {
+		log.Infof("Using config profile %q", cfg.ActiveProfile)
 	}
 
-	// Apply log level from config
+	// Apply log level and format from config (--log-level/--log-format override via env)
 	log.SetLevel(cfg.LogLevel)
+	log.SetFormat(cfg.LogFormat)
 
 	// Apply timezone from config
 	if cfg.Timezone != "" && cfg.Timezone != "auto" {
@@ -128,9 +280,18 @@ func main() {
 		cfg.EnableAudit = false
 	}
 
+	// Startup checks, before any mode that needs the cluster; MCP clients
+	// see failures as tool errors instead
+	if cfg.Preflight.Enabled && !*mcpMode {
+		if err := runPreflight(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(cli.ExitCode(err))
+		}
+	}
+
 	// MCP server mode
 	if *mcpMode {
-		runMCPServer()
+		runMCPServer(cfg, *mcpTransport, *mcpPort)
 		return
 	}
 	// CLI REPL mode
@@ -141,6 +302,14 @@ func main() {
 
 	// Web mode
 	if *webMode {
+		if format, _ := log.ParseFormat(cfg.LogFormat); format == log.FormatJSON {
+			// Structured logs go to stderr too, where cluster log collectors read them
+			log.AddOutput(os.Stderr)
+		}
+		// web.auth_mode, e.g. from a profile, applies unless the mode is given
+		if !cli.FlagPassed(flag.CommandLine, "auth-mode") && os.Getenv("K13D_AUTH_MODE") == "" && cfg.Web.AuthMode != "" {
+			*authMode = cfg.Web.AuthMode
+		}
 		authOpts := &web.AuthOptions{
 			Mode:            *authMode,
 			Disabled:        *authDisabled,
@@ -157,17 +326,28 @@ func main() {
 	if *allNamespaces {
 		initialNS = "" // empty means all namespaces
 	}
-	runTUI(cfg, initialNS)
+	nsRegex, err := config.CompileNamespaceRegex(*namespaceRegex)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --namespace-regex: %v\n", err)
+		os.Exit(cli.ExitConfig)
+	}
+	// Restore the last session unless a namespace was asked for explicitly
+	restore := !cli.FlagPassed(flag.CommandLine, "namespace", "n", "all-namespaces", "A", "namespace-regex") &&
+		os.Getenv("K13D_NAMESPACE") == "" && os.Getenv("K13D_ALL_NAMESPACES") == "" && os.Getenv("K13D_NAMESPACE_REGEX") == ""
+	runTUI(cfg, ui.AppOptions{
+		Namespace:      initialNS,
+		NamespaceRegex: nsRegex,
+		RestoreSession: restore,
+		SaveSession:    true,
+	})
 }
 
-func runMCPServer() {
+func runMCPServer(cfg *config.Config, transport string, port int) {
 	// Create MCP server
 	server := mcpserver.New("k13d", Version)
 
-	// Register default tools
-	for _, tool := range mcpserver.DefaultTools() {
-		server.RegisterTool(tool)
-	}
+	// Register the tools mcp.serve allows
+	cli.RegisterMCPTools(server, cfg)
 
 	// Set up signal handling for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -181,10 +361,10 @@ func runMCPServer() {
 		cancel()
 	}()
 
-	// Run server (blocks until context is cancelled or EOF)
-	if err := server.Run(ctx); err != nil && err != context.Canceled {
+	// Serve (blocks until context is cancelled or, for stdio, EOF)
+	if err := cli.ServeMCP(ctx, server, cfg, transport, port); err != nil && err != context.Canceled {
 		fmt.Fprintf(os.Stderr, "MCP server error: %v\n", err)
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
 
@@ -202,7 +382,7 @@ func runWebServer(cfg *config.Config, port int, authOpts *web.AuthOptions) {
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: Failed to create web server: %v\n", err)
 		log.Errorf("Failed to create web server: %v", err)
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 
 	// Set up signal handling for graceful shutdown
@@ -233,46 +413,50 @@ func runWebServer(cfg *config.Config, port int, authOpts *web.AuthOptions) {
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: Web server stopped: %v\n", err)
 			log.Errorf("Web server error: %v", err)
-			os.Exit(1)
+			os.Exit(cli.ExitCode(err))
 		}
 	}
 }
 
-func runTUI(cfg *config.Config, initialNamespace string) {
+func runTUI(cfg *config.Config, opts ui.AppOptions) {
 	defer cli.InitDB(cfg)()
 
 	defer func() {
 		if r := recover(); r != nil {
 			log.Errorf("PANIC RECOVERED: %v\n%s", r, debug.Stack())
 			fmt.Fprintf(os.Stderr, "k13d crashed due to a panic. Details have been logged.\n")
-			os.Exit(1)
+			os.Exit(cli.ExitFailure)
 		}
 	}()
 
 	ui.Version = Version
-	app := ui.NewAppWithNamespace(initialNamespace)
+	app := ui.NewAppWithOptions(opts)
 	if err := app.Run(); err != nil {
 		log.Errorf("Application exited with error: %v", err)
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 	log.Infof("k13d application exited cleanly.")
 }
 
-func runCLI(cfg *config.Config) {
-	defer cli.InitDB(cfg)()
-
-	ver := k13dcli.VersionInfo{
-		Version:   Version,
-		BuildTime: BuildTime,
-		GitCommit: GitCommit,
+// runPreflight prints the startup checks to stderr. It returns the first
+// failure unless preflight.continue_on_failure is set.
+func runPreflight(cfg *config.Config) error {
+	if err := cfg.Preflight.Validate(); err != nil {
+		return cli.WithExitCode(cli.ExitConfig, err)
 	}
-
-	repl := k13dcli.New(cfg, ver)
-	if err := repl.Start(); err != nil {
-		fmt.Fprintf(os.Stderr, "CLI error: %v\n", err)
-		log.Errorf("CLI error: %v", err)
-		os.Exit(1)
+	results := cli.RunPreflight(context.Background(), cfg)
+	cli.PrintPreflight(os.Stderr, results)
+	for _, r := range results {
+		if r.Status == cli.PreflightWarn || r.Status == cli.PreflightFail {
+			log.Warnf("Preflight %s %s: %s", r.Check, r.Status, r.Detail)
+		}
 	}
+	err := cli.PreflightError(results)
+	if err != nil && cfg.Preflight.ContinueOnFailure {
+		fmt.Fprintln(os.Stderr, "Continuing despite failed checks (preflight.continue_on_failure)")
+		return nil
+	}
+	return err
 }
 
 // generateCompletion outputs shell completion script
@@ -286,7 +470,7 @@ func generateCompletion(shell string) {
 		fmt.Print(fishCompletion)
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown shell: %s. Supported: bash, zsh, fish\n", shell)
-		os.Exit(1)
+		os.Exit(cli.ExitConfig)
 	}
 }
 
@@ -298,7 +482,7 @@ _k13d_completions() {
     prev="${COMP_WORDS[COMP_CWORD-1]}"
 
     # Main options
-    opts="-n --namespace -A --web --cli --port --version --completion"
+    opts="-n --namespace -A --web --cli --mcp --mcp-transport --mcp-port --port --kubeconfig --profile --kube-qps --kube-burst --kube-max-concurrency --theme --log-level --log-format --safe-tools --log-llm-payloads --no-ai --no-security --no-finops --preflight --export-config --export-file --include-secrets --import-config --report-diff --report-diff-format --version --completion"
 
     # Complete namespace after -n or --namespace
     if [[ "${prev}" == "-n" ]] || [[ "${prev}" == "--namespace" ]]; then
@@ -307,6 +491,34 @@ _k13d_completions() {
         return 0
     fi
 
+    # Complete file after --kubeconfig and the config export/import flags
+    if [[ "${prev}" == "--kubeconfig" ]] || [[ "${prev}" == "--export-file" ]] || [[ "${prev}" == "--import-config" ]]; then
+        COMPREPLY=( $(compgen -f -- ${cur}) )
+        return 0
+    fi
+
+    # Complete theme after --theme
+    if [[ "${prev}" == "--theme" ]]; then
+        COMPREPLY=( $(compgen -W "dark light high-contrast" -- ${cur}) )
+        return 0
+    fi
+
+    # Complete log level and format
+    if [[ "${prev}" == "--log-level" ]]; then
+        COMPREPLY=( $(compgen -W "debug info warn error" -- ${cur}) )
+        return 0
+    fi
+    if [[ "${prev}" == "--log-format" ]]; then
+        COMPREPLY=( $(compgen -W "text json" -- ${cur}) )
+        return 0
+    fi
+
+    # Complete MCP transport
+    if [[ "${prev}" == "--mcp-transport" ]]; then
+        COMPREPLY=( $(compgen -W "stdio http" -- ${cur}) )
+        return 0
+    fi
+
     # Complete shell after --completion
     if [[ "${prev}" == "--completion" ]]; then
         COMPREPLY=( $(compgen -W "bash zsh fish" -- ${cur}) )
@@ -335,7 +547,29 @@ _k13d() {
 		'--web[Start web server mode]'
 		'--cli[Start CLI REPL mode]'
 		'--mcp[Start MCP server mode]'
+		'--mcp-transport[MCP server transport]:transport:(stdio http)'
+		'--mcp-port[MCP server HTTP port]:port:'
 		'--port[Web server port]:port:'
+        '--kubeconfig[Path to kubeconfig file]:kubeconfig:_files'
+        '--profile[Config profile to apply]:profile:'
+        '--kube-qps[Kubernetes API client QPS limit]:qps:'
+        '--kube-burst[Kubernetes API client burst limit]:burst:'
+        '--kube-max-concurrency[Namespaces listed in parallel]:count:'
+        '--theme[Color theme]:theme:(dark light high-contrast)'
+        '--log-level[Log level]:level:(debug info warn error)'
+        '--log-format[Log format]:format:(text json)'
+        '--safe-tools[Restrict AI tools to read-only kubectl verbs]'
+        '--log-llm-payloads[Log redacted LLM request/response bodies]'
+        '--no-ai[Disable the AI assistant]'
+        '--no-security[Disable the security scanner]'
+        '--no-finops[Disable FinOps cost analysis]'
+        '--preflight[Check cluster, RBAC, LLM, and database before starting]'
+        '--export-config[Print the effective config with secrets redacted]'
+        '--export-file[Write the exported config to a file]:file:_files'
+        '--include-secrets[Include secrets in the exported config]'
+        '--import-config[Replace the config file with an exported config]:file:_files'
+        '--report-diff[Compare two JSON reports given as OLD,NEW]:files:_files'
+        '--report-diff-format[Report diff output format]:format:(markdown html)'
         '--version[Show version information]'
         '--completion[Generate shell completion]:shell:(bash zsh fish)'
     )
@@ -367,7 +601,30 @@ complete -c k13d -f
 complete -c k13d -s n -l namespace -d 'Initial namespace' -xa '(__k13d_get_namespaces)'
 complete -c k13d -s A -d 'Start with all namespaces'
 complete -c k13d -l web -d 'Start web server mode'
+complete -c k13d -l mcp -d 'Start MCP server mode'
+complete -c k13d -l mcp-transport -d 'MCP server transport' -xa 'stdio http'
+complete -c k13d -l mcp-port -d 'MCP server HTTP port' -x
 complete -c k13d -l port -d 'Web server port'
+complete -c k13d -l kubeconfig -d 'Path to kubeconfig file' -rF
+complete -c k13d -l profile -d 'Config profile to apply' -x
+complete -c k13d -l kube-qps -d 'Kubernetes API client QPS limit' -x
+complete -c k13d -l kube-burst -d 'Kubernetes API client burst limit' -x
+complete -c k13d -l kube-max-concurrency -d 'Namespaces listed in parallel' -x
+complete -c k13d -l theme -d 'Color theme' -xa 'dark light high-contrast'
+complete -c k13d -l log-level -d 'Log level' -xa 'debug info warn error'
+complete -c k13d -l log-format -d 'Log format' -xa 'text json'
+complete -c k13d -l safe-tools -d 'Restrict AI tools to read-only kubectl verbs'
+complete -c k13d -l log-llm-payloads -d 'Log redacted LLM request/response bodies'
+complete -c k13d -l no-ai -d 'Disable the AI assistant'
+complete -c k13d -l no-security -d 'Disable the security scanner'
+complete -c k13d -l no-finops -d 'Disable FinOps cost analysis'
+complete -c k13d -l preflight -d 'Check cluster, RBAC, LLM, and database before starting'
+complete -c k13d -l export-config -d 'Print the effective config with secrets redacted'
+complete -c k13d -l export-file -d 'Write the exported config to a file' -rF
+complete -c k13d -l include-secrets -d 'Include secrets in the exported config'
+complete -c k13d -l import-config -d 'Replace the config file with an exported config' -rF
+complete -c k13d -l report-diff -d 'Compare two JSON reports given as OLD,NEW' -rF
+complete -c k13d -l report-diff-format -d 'Report diff output format' -xa 'markdown html'
 complete -c k13d -l version -d 'Show version information'
 complete -c k13d -l completion -d 'Generate shell completion' -xa 'bash zsh fish'
 
@@ -375,6 +632,61 @@ complete -c k13d -l completion -d 'Generate shell completion' -xa 'bash zsh fish
 # Or add to ~/.config/fish/config.fish: k13d --completion fish | source
 `
 
+// runExportConfig writes the effective config for --export-config to path,
+// or to stdout when path is empty
+func runExportConfig(path string, includeSecrets bool) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+	data, err := cfg.Export(includeSecrets)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported config to %s\n", path)
+	return nil
+}
+
+// runReportDiff prints what changed between the two JSON reports in spec,
+// given as OLD,NEW, as markdown or html
+func runReportDiff(spec, format string) error {
+	paths := strings.Split(spec, ",")
+	if len(paths) != 2 || strings.TrimSpace(paths[0]) == "" || strings.TrimSpace(paths[1]) == "" {
+		return cli.WithExitCode(cli.ExitConfig, fmt.Errorf("--report-diff takes two report files as OLD,NEW"))
+	}
+	if format != "markdown" && format != "html" {
+		return cli.WithExitCode(cli.ExitConfig, fmt.Errorf("unsupported --report-diff-format %q (use markdown or html)", format))
+	}
+	older, err := web.LoadReportFile(strings.TrimSpace(paths[0]))
+	if err != nil {
+		return cli.WithExitCode(cli.ExitConfig, err)
+	}
+	newer, err := web.LoadReportFile(strings.TrimSpace(paths[1]))
+	if err != nil {
+		return cli.WithExitCode(cli.ExitConfig, err)
+	}
+
+	diff := web.DiffReports(older, newer)
+	if format == "html" {
+		// The generator only supplies the theme colors of the config
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return cli.WithExitCode(cli.ExitConfig, err)
+		}
+		fmt.Print(web.NewStandaloneReportGenerator(cfg, nil).ExportDiffToHTML(diff))
+		return nil
+	}
+	fmt.Print(web.ExportDiffToMarkdown(diff))
+	return nil
+}
+
 func showStorageConfiguration() {
 	cfg, _ := config.LoadConfig()
 	if cfg == nil {
@@ -395,6 +707,7 @@ func showStorageConfiguration() {
 			info, _ := os.Stat(dbPath)
 			fmt.Printf("  Size:     %.2f MB\n", float64(info.Size())/1024/1024)
 			fmt.Printf("  Modified: %s\n", info.ModTime().Format("2006-01-02 15:04:05"))
+			fmt.Printf("  Schema:   %s\n", cli.SchemaVersionInfo(cfg))
 		} else {
 			fmt.Printf("  Status:   Not created yet\n")
 		}
@@ -402,6 +715,7 @@ func showStorageConfiguration() {
 		fmt.Printf("  Host:     %s:%d\n", cfg.Storage.DBHost, cfg.Storage.DBPort)
 		fmt.Printf("  Database: %s\n", cfg.Storage.DBName)
 		fmt.Printf("  User:     %s\n", cfg.Storage.DBUser)
+		fmt.Printf("  Schema:   %s\n", cli.SchemaVersionInfo(cfg))
 	}
 	fmt.Println()
 
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"fmt"
+
 	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/cloudbro-kube-ai/k13d/pkg/db"
 	"github.com/cloudbro-kube-ai/k13d/pkg/log"
@@ -16,17 +18,7 @@ func InitDB(cfg *config.Config) func() {
 		return noop
 	}
 
-	dbCfg := db.DBConfig{
-		Type:     db.DBType(cfg.Storage.DBType),
-		Path:     cfg.GetEffectiveDBPath(),
-		Host:     cfg.Storage.DBHost,
-		Port:     cfg.Storage.DBPort,
-		Database: cfg.Storage.DBName,
-		Username: cfg.Storage.DBUser,
-		Password: cfg.Storage.DBPassword,
-		SSLMode:  cfg.Storage.DBSSLMode,
-	}
-	if err := db.InitWithConfig(dbCfg); err != nil {
+	if err := db.InitWithConfig(DBConfig(cfg)); err != nil {
 		log.Errorf("Failed to initialize audit database: %v", err)
 		return noop
 	}
@@ -46,3 +38,36 @@ func InitDB(cfg *config.Config) func() {
 
 	return cleanup
 }
+
+// DBConfig returns the database settings from the storage config
+func DBConfig(cfg *config.Config) db.DBConfig {
+	return db.DBConfig{
+		Type:     db.DBType(cfg.Storage.DBType),
+		Path:     cfg.GetEffectiveDBPath(),
+		Host:     cfg.Storage.DBHost,
+		Port:     cfg.Storage.DBPort,
+		Database: cfg.Storage.DBName,
+		Username: cfg.Storage.DBUser,
+		Password: cfg.Storage.DBPassword,
+		SSLMode:  cfg.Storage.DBSSLMode,
+	}
+}
+
+// SchemaVersionInfo describes the database's schema version for
+// --storage-info, e.g. "2 (latest 2)", without migrating it
+func SchemaVersionInfo(cfg *config.Config) string {
+	version, err := db.ReadSchemaVersion(DBConfig(cfg))
+	if err != nil {
+		return fmt.Sprintf("unavailable (%v)", err)
+	}
+	latest := db.LatestSchemaVersion()
+	switch {
+	case version == 0:
+		return fmt.Sprintf("unversioned (migrates to %d on next start)", latest)
+	case version < latest:
+		return fmt.Sprintf("%d (migrates to %d on next start)", version, latest)
+	case version > latest:
+		return fmt.Sprintf("%d (newer than this k13d supports: %d)", version, latest)
+	}
+	return fmt.Sprintf("%d (latest)", version)
+}
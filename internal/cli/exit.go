@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Exit codes shared by k13d, k13d-bench, and k13d-eval, so scripts and CI
+// can tell a tooling failure from expected task failures.
+const (
+	ExitOK         = 0
+	ExitFailure    = 1 // Unexpected or unclassified failure
+	ExitConfig     = 2 // Invalid flags, config, or task files
+	ExitConnection = 3 // Kubernetes API or LLM endpoint unreachable
+	ExitAuth       = 4 // Credentials missing or rejected
+	ExitPartial    = 5 // The run completed, but some tasks failed
+)
+
+// ExitError is an error that carries the exit code its CLI should exit
+// with.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit code %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// WithExitCode tags err with an exit code. A nil err stays nil.
+func WithExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ExitError{Code: code, Err: err}
+}
+
+// ExitCode returns the exit code for err: the code of a wrapped ExitError,
+// otherwise ExitAuth or ExitConnection when err looks like a rejected
+// credential or an unreachable endpoint, and ExitFailure for the rest.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	if isAuthError(err) {
+		return ExitAuth
+	}
+	if isConnectionError(err) {
+		return ExitConnection
+	}
+	return ExitFailure
+}
+
+func isAuthError(err error) bool {
+	if apierrors.IsUnauthorized(err) || apierrors.IsForbidden(err) {
+		return true
+	}
+	// LLM providers report HTTP failures as "API error (status 401): ..."
+	msg := strings.ToLower(err.Error())
+	for _, marker := range []string{"status 401", "status 403", "unauthorized", "invalid api key"} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func isConnectionError(err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EHOSTUNREACH) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "connection refused") || strings.Contains(msg, "no such host")
+}
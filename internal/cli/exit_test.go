@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"plain", errors.New("boom"), ExitFailure},
+		{"tagged", WithExitCode(ExitPartial, errors.New("2 of 5 tasks failed")), ExitPartial},
+		{"wrapped tag", fmt.Errorf("run: %w", WithExitCode(ExitConfig, errors.New("bad flag"))), ExitConfig},
+		{"kubernetes unauthorized", apierrors.NewUnauthorized("token expired"), ExitAuth},
+		{"kubernetes forbidden", apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "nginx", errors.New("denied")), ExitAuth},
+		{"llm 401", errors.New("API error (status 401): invalid key"), ExitAuth},
+		{"dns", fmt.Errorf("request: %w", &net.DNSError{Err: "no such host", Name: "llm.invalid"}), ExitConnection},
+		{"dial", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("i/o timeout")}, ExitConnection},
+		{"refused message", errors.New("dial tcp 127.0.0.1:6443: connect: connection refused"), ExitConnection},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+
+	if WithExitCode(ExitConfig, nil) != nil {
+		t.Error("WithExitCode(code, nil) should be nil")
+	}
+}
@@ -0,0 +1,17 @@
+package cli
+
+import "flag"
+
+// FlagPassed reports whether any of the named flags was set on the command
+// line, as opposed to holding its default.
+func FlagPassed(fs *flag.FlagSet, names ...string) bool {
+	passed := false
+	fs.Visit(func(f *flag.Flag) {
+		for _, name := range names {
+			if f.Name == name {
+				passed = true
+			}
+		}
+	})
+	return passed
+}
@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestFlagPassed(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	ns := fs.String("namespace", "", "")
+	fs.StringVar(ns, "n", "", "")
+	fs.Bool("A", false, "")
+	if err := fs.Parse([]string{"-n", "kube-system"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !FlagPassed(fs, "namespace", "n") {
+		t.Error("FlagPassed(namespace, n) = false, want true")
+	}
+	if FlagPassed(fs, "A") {
+		t.Error("FlagPassed(A) = true, want false")
+	}
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/cloudbro-kube-ai/k13d/pkg/log"
+	mcpserver "github.com/cloudbro-kube-ai/k13d/pkg/mcp/server"
+	"github.com/cloudbro-kube-ai/k13d/pkg/web"
+)
+
+// MCPClusterTools returns the MCP tools that use a Kubernetes client rather
+// than kubectl: the read-only cluster tools and generate_report. The client
+// is configured like the TUI's. It fails when no cluster configuration is
+// available.
+func MCPClusterTools(cfg *config.Config) ([]*mcpserver.Tool, error) {
+	opts := k8s.ClientOptionsFromEnv()
+	opts.QPS = cfg.Kubernetes.QPS
+	opts.Burst = cfg.Kubernetes.Burst
+	opts.MaxConcurrency = cfg.Kubernetes.MaxConcurrency
+	client, err := k8s.NewClientWithOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rg := web.NewStandaloneReportGenerator(cfg, client)
+	report := mcpserver.ReportTool(func(ctx context.Context, sections, format string) (string, error) {
+		return rg.RenderReport(ctx, "mcp", sections, format)
+	})
+	return append(mcpserver.ClusterTools(client), report), nil
+}
+
+// RegisterMCPTools registers the default and cluster tools on server,
+// keeping only those allowed by the mcp.serve config
+func RegisterMCPTools(server *mcpserver.Server, cfg *config.Config) {
+	server.SetToolPolicy(mcpserver.ToolPolicy{
+		Allowed:  cfg.MCP.Serve.Tools,
+		Disabled: cfg.MCP.Serve.DisabledTools,
+		ReadOnly: cfg.MCP.Serve.ReadOnly,
+	})
+
+	tools := mcpserver.DefaultTools()
+	if clusterTools, err := MCPClusterTools(cfg); err != nil {
+		log.Warnf("MCP cluster and report tools unavailable: %v", err)
+	} else {
+		tools = append(tools, clusterTools...)
+	}
+	for _, tool := range tools {
+		if !server.RegisterTool(tool) {
+			log.Infof("MCP tool %s disabled by mcp.serve", tool.Name)
+		}
+	}
+	log.Infof("MCP tools: %s", strings.Join(server.ToolNames(), ", "))
+}
+
+// ServeMCP runs server over the given transport until ctx is done or, for
+// stdio, the client closes stdin. The http transport serves MCP over SSE on
+// port and always requires a bearer token: mcp.serve.token when one is set,
+// listening on all interfaces, and otherwise a generated token printed to
+// stderr, listening on localhost only.
+func ServeMCP(ctx context.Context, server *mcpserver.Server, cfg *config.Config, transport string, port int) error {
+	switch transport {
+	case "", "stdio":
+		return server.Run(ctx)
+	case "http":
+		token := cfg.MCP.Serve.Token
+		addr := fmt.Sprintf(":%d", port)
+		if token == "" {
+			token = mcpserver.GenerateToken()
+			addr = fmt.Sprintf("127.0.0.1:%d", port)
+			log.Warnf("MCP HTTP transport has no token (mcp.serve.token or K13D_MCP_TOKEN); generated one and listening on localhost only")
+			fmt.Fprintf(os.Stderr, "  MCP bearer token: %s\n", token)
+		} else {
+			log.Warnf("MCP HTTP transport serves plain HTTP; put TLS or a TLS-terminating proxy in front so the bearer token is not sent in clear text")
+		}
+		log.Infof("MCP server listening on http://%s/sse", addr)
+		return server.ServeSSE(ctx, addr, token)
+	default:
+		return fmt.Errorf("unknown MCP transport %q (use stdio or http)", transport)
+	}
+}
@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Preflight check outcomes
+const (
+	PreflightPass = "PASS"
+	PreflightWarn = "WARN" // k13d works, with something missing
+	PreflightFail = "FAIL"
+	PreflightSkip = "SKIP"
+)
+
+// PreflightResult is the outcome of one startup check
+type PreflightResult struct {
+	Check  string
+	Status string
+	Detail string
+	Hint   string // What to do about a WARN or FAIL
+	Err    error  // Set for FAIL
+}
+
+// preflightListChecks are the list calls the TUI and web UI depend on
+var preflightListChecks = []authzv1.ResourceAttributes{
+	{Verb: "list", Resource: "namespaces"},
+	{Verb: "list", Resource: "pods"},
+	{Verb: "list", Resource: "services"},
+	{Verb: "list", Resource: "events"},
+	{Verb: "list", Resource: "nodes"},
+	{Verb: "list", Group: "apps", Resource: "deployments"},
+}
+
+// RunPreflight runs the checks cfg.Preflight enables, in order. The RBAC
+// check is skipped when the cluster cannot be reached.
+func RunPreflight(ctx context.Context, cfg *config.Config) []PreflightResult {
+	timeout, err := cfg.Preflight.CheckTimeout()
+	if err != nil {
+		timeout = config.DefaultPreflightTimeout
+	}
+	withTimeout := func(check func(context.Context) PreflightResult) PreflightResult {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return check(ctx)
+	}
+
+	var (
+		results   []PreflightResult
+		clientset kubernetes.Interface
+		reachable bool
+	)
+	needCluster := cfg.Preflight.Runs(config.PreflightKubernetes) || cfg.Preflight.Runs(config.PreflightRBAC)
+	if needCluster {
+		opts := k8s.ClientOptionsFromEnv()
+		opts.QPS = cfg.Kubernetes.QPS
+		opts.Burst = cfg.Kubernetes.Burst
+		client, err := k8s.NewClientWithOptions(opts)
+		result := PreflightResult{Check: config.PreflightKubernetes}
+		if err != nil {
+			result = preflightFailure(config.PreflightKubernetes, "no cluster configuration", WithExitCode(ExitConfig, err),
+				"Point k13d at a kubeconfig with --kubeconfig or KUBECONFIG, or run it in a pod with a service account")
+		} else {
+			clientset = client.Clientset
+			contextName, _ := client.GetCurrentContext()
+			result = withTimeout(func(ctx context.Context) PreflightResult {
+				return preflightKubernetes(ctx, clientset, contextName)
+			})
+		}
+		reachable = result.Status == PreflightPass
+		if cfg.Preflight.Runs(config.PreflightKubernetes) {
+			results = append(results, result)
+		}
+	}
+
+	if cfg.Preflight.Runs(config.PreflightRBAC) {
+		if reachable {
+			results = append(results, withTimeout(func(ctx context.Context) PreflightResult {
+				return preflightRBAC(ctx, clientset)
+			}))
+		} else {
+			results = append(results, PreflightResult{Check: config.PreflightRBAC, Status: PreflightSkip, Detail: "cluster not reachable"})
+		}
+	}
+
+	if cfg.Preflight.Runs(config.PreflightLLM) {
+		results = append(results, withTimeout(func(ctx context.Context) PreflightResult {
+			return preflightLLM(ctx, cfg)
+		}))
+	}
+
+	if cfg.Preflight.Runs(config.PreflightDatabase) {
+		results = append(results, preflightDatabase(cfg))
+	}
+	return results
+}
+
+// preflightFailure builds a FAIL result
+func preflightFailure(check, detail string, err error, hint string) PreflightResult {
+	return PreflightResult{Check: check, Status: PreflightFail, Detail: fmt.Sprintf("%s: %v", detail, err), Hint: hint, Err: err}
+}
+
+// preflightKubernetes asks the API server for its version
+func preflightKubernetes(ctx context.Context, clientset kubernetes.Interface, contextName string) PreflightResult {
+	type versionResult struct {
+		version string
+		err     error
+	}
+	done := make(chan versionResult, 1)
+	go func() {
+		info, err := clientset.Discovery().ServerVersion()
+		if err != nil {
+			done <- versionResult{err: err}
+			return
+		}
+		done <- versionResult{version: info.GitVersion}
+	}()
+
+	where := "the API server"
+	if contextName != "" {
+		where = fmt.Sprintf("context %q", contextName)
+	}
+	var res versionResult
+	select {
+	case res = <-done:
+	case <-ctx.Done():
+		res.err = ctx.Err()
+	}
+
+	if res.err == nil {
+		return PreflightResult{Check: config.PreflightKubernetes, Status: PreflightPass, Detail: fmt.Sprintf("%s reachable (Kubernetes %s)", where, res.version)}
+	}
+	hint := "Check that the cluster is running and reachable from here (VPN, proxy, firewall): kubectl cluster-info"
+	switch {
+	case ExitCode(res.err) == ExitAuth:
+		hint = "The cluster rejected the credentials; refresh them, e.g. by logging in to your cloud provider again"
+	case errors.Is(res.err, context.DeadlineExceeded):
+		hint = "The API server did not answer in time; check the network path, or raise preflight.timeout"
+	}
+	return preflightFailure(config.PreflightKubernetes, "cannot reach "+where, res.err, hint)
+}
+
+// preflightRBAC checks the list permissions the views need, cluster-wide
+func preflightRBAC(ctx context.Context, clientset kubernetes.Interface) PreflightResult {
+	var denied []string
+	for _, attrs := range preflightListChecks {
+		attrs := attrs
+		review := &authzv1.SelfSubjectAccessReview{Spec: authzv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attrs}}
+		result, err := clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return preflightFailure(config.PreflightRBAC, "cannot check permissions", err,
+				"The SelfSubjectAccessReview API must be reachable; try: kubectl auth can-i list pods -A")
+		}
+		if !result.Status.Allowed {
+			denied = append(denied, attrs.Resource)
+		}
+	}
+	if len(denied) > 0 {
+		return PreflightResult{
+			Check:  config.PreflightRBAC,
+			Status: PreflightWarn,
+			Detail: "cannot list " + strings.Join(denied, ", ") + " in all namespaces",
+			Hint:   "Those views stay empty; ask for a ClusterRole with list on them, or start in a namespace you can read with -n <namespace>",
+		}
+	}
+	return PreflightResult{Check: config.PreflightRBAC, Status: PreflightPass, Detail: fmt.Sprintf("can list %d core resource types in all namespaces", len(preflightListChecks))}
+}
+
+// preflightDatabase opens the audit database without migrating it
+func preflightDatabase(cfg *config.Config) PreflightResult {
+	if !cfg.EnableAudit || !cfg.IsPersistenceEnabled() {
+		return PreflightResult{Check: config.PreflightDatabase, Status: PreflightSkip, Detail: "persistence disabled"}
+	}
+	dbCfg := DBConfig(cfg)
+	version, err := db.ReadSchemaVersion(dbCfg)
+	if err != nil {
+		return preflightFailure(config.PreflightDatabase, fmt.Sprintf("cannot open %s database", dbCfg.Type), err,
+			"Check the storage.db_* settings and that the database is running, or start with --no-db")
+	}
+	return PreflightResult{Check: config.PreflightDatabase, Status: PreflightPass, Detail: fmt.Sprintf("%s database reachable (schema version %d)", dbCfg.Type, version)}
+}
+
+// PrintPreflight writes one line per result, with the hint below WARN and
+// FAIL results
+func PrintPreflight(w io.Writer, results []PreflightResult) {
+	fmt.Fprintln(w, "Preflight checks:")
+	for _, r := range results {
+		fmt.Fprintf(w, "  [%s] %-10s %s\n", r.Status, r.Check, r.Detail)
+		if r.Hint != "" {
+			fmt.Fprintf(w, "  %18s -> %s\n", "", r.Hint)
+		}
+	}
+}
+
+// PreflightError returns the first failed check as an error tagged with its
+// exit code, or nil when no check failed
+func PreflightError(results []PreflightResult) error {
+	for _, r := range results {
+		if r.Status != PreflightFail {
+			continue
+		}
+		code := ExitCode(r.Err)
+		if code == ExitFailure {
+			code = ExitConnection
+		}
+		return WithExitCode(code, fmt.Errorf("preflight check %s failed: %s", r.Check, r.Detail))
+	}
+	return nil
+}
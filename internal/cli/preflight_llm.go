@@ -0,0 +1,41 @@
+//go:build !noai
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai"
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+// preflightLLM checks that the LLM provider is configured and, for providers
+// with a models endpoint, that it answers with the configured credentials.
+// No prompt is sent.
+func preflightLLM(ctx context.Context, cfg *config.Config) PreflightResult {
+	if !cfg.AIEnabled() {
+		return PreflightResult{Check: config.PreflightLLM, Status: PreflightSkip, Detail: "AI assistant disabled"}
+	}
+	client, err := ai.NewClient(&cfg.LLM)
+	if err != nil {
+		return preflightFailure(config.PreflightLLM, fmt.Sprintf("cannot create %s client", cfg.LLM.Provider), err,
+			"Check llm.provider and llm.endpoint in the config, or start with --no-ai")
+	}
+	if !client.IsReady() {
+		return PreflightResult{
+			Check:  config.PreflightLLM,
+			Status: PreflightWarn,
+			Detail: fmt.Sprintf("%s is not configured", cfg.LLM.Provider),
+			Hint:   "The AI assistant is unavailable until llm.api_key (or the provider's API key variable) is set; use --no-ai to hide it",
+		}
+	}
+	if _, err := client.ListModels(ctx); err != nil {
+		hint := fmt.Sprintf("Check that %s is reachable and llm.endpoint is right, or start with --no-ai", client.GetEndpoint())
+		if ExitCode(err) == ExitAuth {
+			hint = "The provider rejected the API key; check llm.api_key, or start with --no-ai"
+		}
+		return preflightFailure(config.PreflightLLM, fmt.Sprintf("%s not responding", client.GetProvider()), err, hint)
+	}
+	return PreflightResult{Check: config.PreflightLLM, Status: PreflightPass, Detail: fmt.Sprintf("%s ready (model %s)", client.GetProvider(), client.GetModel())}
+}
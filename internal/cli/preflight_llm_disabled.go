@@ -0,0 +1,15 @@
+//go:build noai
+
+package cli
+
+import (
+	"context"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+// preflightLLM is skipped in builds with the noai tag, which leave out the
+// LLM providers
+func preflightLLM(ctx context.Context, cfg *config.Config) PreflightResult {
+	return PreflightResult{Check: config.PreflightLLM, Status: PreflightSkip, Detail: "AI assistant not included in this build (noai)"}
+}
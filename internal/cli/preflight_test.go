@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	authzv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestPreflightKubernetes(t *testing.T) {
+	clientset := fake.NewClientset() //nolint:staticcheck
+	result := preflightKubernetes(context.Background(), clientset, "dev")
+	if result.Status != PreflightPass || !strings.Contains(result.Detail, `context "dev" reachable`) {
+		t.Errorf("preflightKubernetes() = %+v, want PASS for context dev", result)
+	}
+}
+
+func TestPreflightRBAC(t *testing.T) {
+	clientset := fake.NewClientset() //nolint:staticcheck
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authzv1.SelfSubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Resource != "nodes"
+		return true, review, nil
+	})
+
+	result := preflightRBAC(context.Background(), clientset)
+	if result.Status != PreflightWarn || result.Detail != "cannot list nodes in all namespaces" || result.Hint == "" {
+		t.Errorf("preflightRBAC() = %+v, want WARN naming nodes", result)
+	}
+}
+
+func TestPreflightLLM(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Disable.AI = true
+	if result := preflightLLM(context.Background(), cfg); result.Status != PreflightSkip {
+		t.Errorf("preflightLLM(disabled) = %+v, want SKIP", result)
+	}
+
+	cfg = config.NewDefaultConfig()
+	cfg.LLM.Provider = "openai"
+	cfg.LLM.APIKey = ""
+	if result := preflightLLM(context.Background(), cfg); result.Status != PreflightWarn {
+		t.Errorf("preflightLLM(no key) = %+v, want WARN", result)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer good" {
+			http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"gpt-4o"}]}`))
+	}))
+	defer server.Close()
+
+	cfg.LLM.Endpoint = server.URL
+	cfg.LLM.Model = "gpt-4o"
+	cfg.LLM.APIKey = "good"
+	if result := preflightLLM(context.Background(), cfg); result.Status != PreflightPass {
+		t.Errorf("preflightLLM(reachable) = %+v, want PASS", result)
+	}
+
+	cfg.LLM.APIKey = "bad"
+	result := preflightLLM(context.Background(), cfg)
+	if result.Status != PreflightFail || !strings.Contains(result.Hint, "rejected the API key") {
+		t.Errorf("preflightLLM(bad key) = %+v, want FAIL with an API key hint", result)
+	}
+}
+
+func TestPreflightDatabase(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.EnableAudit = false
+	if result := preflightDatabase(cfg); result.Status != PreflightSkip {
+		t.Errorf("preflightDatabase(disabled) = %+v, want SKIP", result)
+	}
+
+	cfg.EnableAudit = true
+	cfg.Storage.DBType = "sqlite"
+	cfg.Storage.DBPath = t.TempDir() + "/audit.db"
+	if result := preflightDatabase(cfg); result.Status != PreflightPass {
+		t.Errorf("preflightDatabase(sqlite) = %+v, want PASS", result)
+	}
+
+	// A regular file where the database directory should be
+	notDir := t.TempDir() + "/not-a-dir"
+	if err := os.WriteFile(notDir, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Storage.DBPath = notDir + "/audit.db"
+	if result := preflightDatabase(cfg); result.Status != PreflightFail || result.Hint == "" {
+		t.Errorf("preflightDatabase(bad path) = %+v, want FAIL with a hint", result)
+	}
+}
+
+func TestPreflightError(t *testing.T) {
+	ok := []PreflightResult{
+		{Check: config.PreflightKubernetes, Status: PreflightPass},
+		{Check: config.PreflightLLM, Status: PreflightWarn, Hint: "set a key"},
+	}
+	if err := PreflightError(ok); err != nil {
+		t.Errorf("PreflightError() = %v, want nil without failures", err)
+	}
+
+	failed := append(ok, PreflightResult{Check: config.PreflightDatabase, Status: PreflightFail, Detail: "down", Err: errors.New("boom")})
+	err := PreflightError(failed)
+	if err == nil || ExitCode(err) != ExitConnection || !strings.Contains(err.Error(), "database") {
+		t.Errorf("PreflightError() = %v (exit %d), want a database failure with ExitConnection", err, ExitCode(err))
+	}
+
+	var out bytes.Buffer
+	PrintPreflight(&out, failed)
+	for _, want := range []string{"[PASS] kubernetes", "[WARN] llm", "-> set a key", "[FAIL] database   down"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("PrintPreflight() output is missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -113,7 +113,7 @@ func New(cfg *Config) *Agent {
 	}
 
 	// Check if provider supports tools
-	if tp, ok := cfg.Provider.(providers.ToolProvider); ok {
+	if tp, ok := cfg.Provider.(providers.ToolProvider); ok && tp.Capabilities().Tools {
 		a.toolProvider = tp
 	}
 
@@ -217,7 +217,7 @@ func (a *Agent) SetProvider(p providers.Provider) {
 	a.configMu.Lock()
 	defer a.configMu.Unlock()
 	a.provider = p
-	if tp, ok := p.(providers.ToolProvider); ok {
+	if tp, ok := p.(providers.ToolProvider); ok && tp.Capabilities().Tools {
 		a.toolProvider = tp
 	} else {
 		a.toolProvider = nil
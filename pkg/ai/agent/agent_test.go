@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai/providers"
 )
 
 func TestStateTransitions(t *testing.T) {
@@ -643,6 +645,9 @@ func (m *mockBasicProvider) AskNonStreaming(ctx context.Context, prompt string)
 }
 func (m *mockBasicProvider) IsReady() bool                                    { return true }
 func (m *mockBasicProvider) ListModels(ctx context.Context) ([]string, error) { return nil, nil }
+func (m *mockBasicProvider) Capabilities() providers.Capabilities {
+	return providers.Capabilities{Streaming: true}
+}
 
 // testAgentListener is a configurable test listener for agent tests
 type testAgentListener struct {
@@ -800,6 +800,10 @@ func (m *mockProvider) ListModels(ctx context.Context) ([]string, error) {
 	return []string{"mock-model"}, nil
 }
 
+func (m *mockProvider) Capabilities() providers.Capabilities {
+	return providers.Capabilities{Streaming: true}
+}
+
 // mockToolProvider implements both Provider and ToolProvider
 type mockToolProvider struct {
 	streamResponse string
@@ -840,6 +844,10 @@ func (m *mockToolProvider) ListModels(ctx context.Context) ([]string, error) {
 	return []string{"mock-tool-model"}, nil
 }
 
+func (m *mockToolProvider) Capabilities() providers.Capabilities {
+	return providers.Capabilities{Streaming: true, Tools: true}
+}
+
 func (m *mockToolProvider) AskWithTools(ctx context.Context, prompt string, tools []providers.ToolDefinition, streamCallback func(string), toolCallback providers.ToolCallback) error {
 	if m.err != nil {
 		return m.err
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/cloudbro-kube-ai/k13d/pkg/ai/analyzers"
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai/providers"
 )
 
 func TestAnonymizerIntegration_Enabled(t *testing.T) {
@@ -202,6 +203,9 @@ func (p *promptCapturingProvider) AskNonStreaming(ctx context.Context, prompt st
 }
 func (p *promptCapturingProvider) IsReady() bool                                    { return true }
 func (p *promptCapturingProvider) ListModels(ctx context.Context) ([]string, error) { return nil, nil }
+func (p *promptCapturingProvider) Capabilities() providers.Capabilities {
+	return providers.Capabilities{Streaming: true}
+}
 
 // echoPlaceholderProvider echoes back the prompt with placeholder markers
 type echoPlaceholderProvider struct {
@@ -228,3 +232,6 @@ func (p *echoPlaceholderProvider) AskNonStreaming(ctx context.Context, prompt st
 }
 func (p *echoPlaceholderProvider) IsReady() bool                                    { return true }
 func (p *echoPlaceholderProvider) ListModels(ctx context.Context) ([]string, error) { return nil, nil }
+func (p *echoPlaceholderProvider) Capabilities() providers.Capabilities {
+	return providers.Capabilities{Streaming: true}
+}
@@ -3,12 +3,14 @@ package ai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/cloudbro-kube-ai/k13d/pkg/ai/providers"
 	"github.com/cloudbro-kube-ai/k13d/pkg/ai/tools"
 	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/log"
 )
 
 // Client wraps an LLM provider with additional functionality
@@ -16,27 +18,89 @@ type Client struct {
 	cfg          *config.LLMConfig
 	provider     providers.Provider
 	toolRegistry *tools.Registry
+	limiter      *requestLimiter
 }
 
 // NewClient creates a new AI client using the provider factory
 func NewClient(cfg *config.LLMConfig) (*Client, error) {
-	providerCfg := &providers.ProviderConfig{
+	// Generation parameters apply to the fallbacks too
+	temperature := cfg.Temperature
+	provider, err := newProvider(cfg, &providers.ProviderConfig{
 		Provider:        cfg.Provider,
 		Model:           cfg.Model,
 		Endpoint:        cfg.Endpoint,
 		APIKey:          cfg.APIKey,
 		Region:          cfg.Region,
+		Project:         cfg.Project,
 		AzureDeployment: cfg.AzureDeployment,
 		SkipTLSVerify:   cfg.SkipTLSVerify,
 		ReasoningEffort: cfg.ReasoningEffort,
 		MaxIterations:   cfg.MaxIterations,
+		LogPayloads:     cfg.LogPayloads,
+		KeepAlive:       cfg.KeepAlive,
+		Temperature:     &temperature,
+		MaxTokens:       cfg.MaxTokens,
 		Discovery:       cfg.Discovery,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create provider: %w", err)
+	}
+
+	// Chain configured fallbacks behind the primary. One that cannot be
+	// created is skipped so it never blocks the primary.
+	chain := []providers.Provider{provider}
+	for _, fb := range cfg.Fallbacks {
+		fallback, err := newProvider(cfg, &providers.ProviderConfig{
+			Provider:        fb.Provider,
+			Model:           fb.Model,
+			Endpoint:        fb.Endpoint,
+			APIKey:          fb.APIKey,
+			Region:          fb.Region,
+			Project:         fb.Project,
+			AzureDeployment: fb.AzureDeployment,
+			SkipTLSVerify:   fb.SkipTLSVerify,
+			MaxIterations:   cfg.MaxIterations,
+			LogPayloads:     cfg.LogPayloads,
+			KeepAlive:       cfg.KeepAlive,
+			Temperature:     &temperature,
+			MaxTokens:       cfg.MaxTokens,
+			Discovery:       cfg.Discovery,
+		})
+		if err != nil {
+			log.Warnf("Skipping LLM fallback provider %s: %v", fb.Provider, err)
+			continue
+		}
+		chain = append(chain, fallback)
 	}
 
-	factory := providers.GetFactory()
-	provider, err := factory.Create(providerCfg)
+	return &Client{
+		cfg:          cfg,
+		provider:     providers.CreateWithFallback(chain...),
+		toolRegistry: tools.NewRegistry(),
+		limiter:      newRequestLimiter(cfg.MaxConcurrentRequests),
+	}, nil
+}
+
+// NewSharedClient creates a client that many users share, like the web
+// server's. The default request limit is meant for one user, so it does not
+// apply: only an explicit llm.max_concurrent_requests limits the requests.
+func NewSharedClient(cfg *config.LLMConfig) (*Client, error) {
+	client, err := NewClient(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create provider: %w", err)
+		return nil, err
+	}
+	if cfg.MaxConcurrentRequests == 0 {
+		client.limiter = newRequestLimiter(-1)
+	}
+	return client, nil
+}
+
+// newProvider creates the provider for providerCfg, wrapped with the retry
+// logic configured in cfg.
+func newProvider(cfg *config.LLMConfig, providerCfg *providers.ProviderConfig) (providers.Provider, error) {
+	provider, err := providers.GetFactory().Create(providerCfg)
+	if err != nil {
+		return nil, err
 	}
 
 	// Wrap with retry logic if configured
@@ -54,12 +118,7 @@ func NewClient(cfg *config.LLMConfig) (*Client, error) {
 		}
 		provider = providers.CreateWithRetry(provider, retryCfg)
 	}
-
-	return &Client{
-		cfg:          cfg,
-		provider:     provider,
-		toolRegistry: tools.NewRegistry(),
-	}, nil
+	return provider, nil
 }
 
 // Ask sends a prompt to the AI provider and streams the response via callback
@@ -67,6 +126,11 @@ func (c *Client) Ask(ctx context.Context, prompt string, callback func(string))
 	if c.provider == nil {
 		return fmt.Errorf("AI provider not initialized")
 	}
+	release, err := c.limiter.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
 	return c.provider.Ask(ctx, prompt, callback)
 }
 
@@ -75,6 +139,11 @@ func (c *Client) AskNonStreaming(ctx context.Context, prompt string) (string, er
 	if c.provider == nil {
 		return "", fmt.Errorf("AI provider not initialized")
 	}
+	release, err := c.limiter.acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
 	return c.provider.AskNonStreaming(ctx, prompt)
 }
 
@@ -236,10 +305,15 @@ func (c *Client) AskWithToolsAndExecution(ctx context.Context, prompt string, ca
 	if c.provider == nil {
 		return fmt.Errorf("AI provider not initialized")
 	}
+	slot, err := c.limiter.hold(ctx)
+	if err != nil {
+		return err
+	}
+	defer slot.done()
 
 	// Check if provider supports tool calling
 	toolProvider, ok := c.provider.(providers.ToolProvider)
-	if !ok {
+	if !ok || !c.provider.Capabilities().Tools {
 		// Fallback to regular Ask if tool calling not supported
 		return c.provider.Ask(ctx, prompt, callback)
 	}
@@ -258,8 +332,14 @@ func (c *Client) AskWithToolsAndExecution(ctx context.Context, prompt string, ca
 		})
 	}
 
-	// Tool callback that requests approval before execution
-	toolCallback := func(call providers.ToolCall) providers.ToolResult {
+	// A model that keeps repeating a call is stopped before it is asked
+	// about or run again; cancelling ends the provider's tool loop
+	loopCtx, stopLoop := context.WithCancelCause(ctx)
+	defer stopLoop(nil)
+	guard := newToolLoopGuard(c.maxRepeatedToolCalls())
+
+	// runTool requests approval before execution
+	runTool := func(call providers.ToolCall) providers.ToolResult {
 		// Extract command from arguments
 		var args map[string]interface{}
 		_ = json.Unmarshal([]byte(call.Function.Arguments), &args)
@@ -268,6 +348,15 @@ func (c *Client) AskWithToolsAndExecution(ctx context.Context, prompt string, ca
 			command = cmd
 		}
 
+		if err := guard.check(call); err != nil {
+			stopLoop(err)
+			return providers.ToolResult{
+				ToolCallID: call.ID,
+				Content:    err.Error(),
+				IsError:    true,
+			}
+		}
+
 		// Request approval if callback provided
 		if toolApprovalCallback != nil {
 			if !toolApprovalCallback(call.Function.Name, call.Function.Arguments) {
@@ -317,7 +406,30 @@ func (c *Client) AskWithToolsAndExecution(ctx context.Context, prompt string, ca
 		}
 	}
 
-	return toolProvider.AskWithTools(ctx, prompt, toolDefs, callback, toolCallback)
+	// The slot only covers provider requests: it is given back while a tool
+	// call waits for approval and runs
+	toolCallback := func(call providers.ToolCall) providers.ToolResult {
+		slot.pause()
+		result := runTool(call)
+		if err := slot.resume(loopCtx); err != nil {
+			return providers.ToolResult{ToolCallID: call.ID, Content: err.Error(), IsError: true}
+		}
+		return result
+	}
+
+	err = toolProvider.AskWithTools(loopCtx, prompt, toolDefs, callback, toolCallback)
+	if cause := context.Cause(loopCtx); errors.Is(cause, ErrRepeatedToolCall) {
+		return cause
+	}
+	return err
+}
+
+// maxRepeatedToolCalls returns llm.max_repeated_tool_calls
+func (c *Client) maxRepeatedToolCalls() int {
+	if c.cfg == nil {
+		return 0
+	}
+	return c.cfg.MaxRepeatedToolCalls
 }
 
 func (c *Client) visibleTools() []*tools.Tool {
@@ -368,13 +480,21 @@ func (c *Client) getToolMetadata(toolName string) (toolType string, toolServerNa
 	return string(tool.Type), tool.ServerName
 }
 
+// Capabilities reports the features of the current provider and model
+func (c *Client) Capabilities() providers.Capabilities {
+	if c == nil || c.provider == nil {
+		return providers.Capabilities{}
+	}
+	return c.provider.Capabilities()
+}
+
 // SupportsTools returns true if the current provider supports tool calling
 func (c *Client) SupportsTools() bool {
-	if c.provider == nil {
+	if c == nil || c.provider == nil {
 		return false
 	}
 	_, ok := c.provider.(providers.ToolProvider)
-	return ok
+	return ok && c.provider.Capabilities().Tools
 }
 
 // GetToolRegistry returns the tool registry for external configuration
@@ -2,9 +2,14 @@ package ai
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/cloudbro-kube-ai/k13d/pkg/ai/providers"
 	"github.com/cloudbro-kube-ai/k13d/pkg/ai/tools"
@@ -22,6 +27,9 @@ func (m *capturingToolProvider) IsReady() bool    { return true }
 func (m *capturingToolProvider) ListModels(ctx context.Context) ([]string, error) {
 	return []string{"capture-model"}, nil
 }
+func (m *capturingToolProvider) Capabilities() providers.Capabilities {
+	return providers.Capabilities{Streaming: true, Tools: true}
+}
 func (m *capturingToolProvider) Ask(ctx context.Context, prompt string, callback func(string)) error {
 	return nil
 }
@@ -208,6 +216,41 @@ func TestClient_AskNonStreaming(t *testing.T) {
 	}
 }
 
+func TestNewClient_FallbackGenerationParams(t *testing.T) {
+	// The primary is down, so the request goes to the fallback
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"test-123","choices":[{"message":{"content":"Hello from fallback"},"finish_reason":"stop"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.LLMConfig{
+		Provider:    "openai",
+		Model:       "gpt-4",
+		Endpoint:    down.URL,
+		APIKey:      "test-key",
+		Temperature: 0.2,
+		MaxTokens:   321,
+		Fallbacks:   []config.LLMFallback{{Provider: "openai", Model: "gpt-4o", Endpoint: server.URL, APIKey: "test-key"}},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	response, err := client.AskNonStreaming(context.Background(), "Hello")
+	if err != nil || response != "Hello from fallback" {
+		t.Fatalf("AskNonStreaming() = %q, %v; want the fallback's answer", response, err)
+	}
+	if body["temperature"] != 0.2 || body["max_tokens"] != 321.0 {
+		t.Errorf("fallback request temperature = %v, max_tokens = %v; want 0.2 and 321", body["temperature"], body["max_tokens"])
+	}
+}
+
 func TestClient_AskNonStreaming_Error(t *testing.T) {
 	// Create a mock server that returns an error
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -333,6 +376,61 @@ func TestClientAskWithToolsAndExecutionCanExposeBashAndMCPTools(t *testing.T) {
 	}
 }
 
+// loopingToolProvider makes the same tool call until the context is
+// cancelled, like a model stuck in a loop
+type loopingToolProvider struct {
+	capturingToolProvider
+	calls []string // arguments of each call, cycled
+}
+
+func (m *loopingToolProvider) AskWithTools(ctx context.Context, prompt string, defs []providers.ToolDefinition, callback func(string), toolCallback providers.ToolCallback) error {
+	for i := 0; i < 10; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		toolCallback(providers.ToolCall{ID: "call", Function: providers.FunctionCall{Name: "kubectl", Arguments: m.calls[i%len(m.calls)]}})
+	}
+	return nil
+}
+
+func TestClientAskWithToolsStopsRepeatedToolCalls(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxRepeated   int
+		calls         []string
+		wantApprovals int
+		wantErr       bool
+	}{
+		{"default limit", 0, []string{`{"command":"get pods","namespace":"default"}`, `{"namespace": "default", "command": "get pods"}`}, 2, true},
+		{"alternating calls", 2, []string{`{"command":"get pods"}`, `{"command":"get svc"}`}, 2, true},
+		{"distinct calls", 0, []string{`{"command":"get pods"}`, `{"command":"get svc"}`, `{"command":"get deploy"}`, `{"command":"get nodes"}`, `{"command":"get ns"}`}, 10, false},
+		{"disabled", -1, []string{`{"command":"get pods"}`}, 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				cfg:          &config.LLMConfig{MaxRepeatedToolCalls: tt.maxRepeated},
+				provider:     &loopingToolProvider{calls: tt.calls},
+				toolRegistry: tools.NewRegistry(),
+			}
+
+			// Declining keeps the test from running kubectl
+			approvals := 0
+			err := client.AskWithToolsAndExecution(context.Background(), "why is the pod failing", nil, func(string, string) bool {
+				approvals++
+				return false
+			}, nil)
+
+			if approvals != tt.wantApprovals {
+				t.Errorf("approval requests = %d, want %d", approvals, tt.wantApprovals)
+			}
+			if got := errors.Is(err, ErrRepeatedToolCall); got != tt.wantErr {
+				t.Errorf("AskWithToolsAndExecution() error = %v, want repeated tool call error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestClient_TestConnection_Success(t *testing.T) {
 	// Create a mock server that returns OK
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -528,3 +626,124 @@ func TestConnectionStatus_Fields(t *testing.T) {
 		t.Errorf("ConnectionStatus.ResponseTime = %v, want 150", status.ResponseTime)
 	}
 }
+
+// blockingProvider holds each Ask until release is closed and counts the
+// requests running at once
+type blockingProvider struct {
+	capturingToolProvider
+	release  chan struct{}
+	mu       sync.Mutex
+	running  int
+	maxSeen  int
+	finished int
+}
+
+func (m *blockingProvider) Ask(ctx context.Context, prompt string, callback func(string)) error {
+	m.mu.Lock()
+	m.running++
+	m.maxSeen = max(m.maxSeen, m.running)
+	m.mu.Unlock()
+	<-m.release
+	m.mu.Lock()
+	m.running--
+	m.finished++
+	m.mu.Unlock()
+	return nil
+}
+
+func TestClientLimitsConcurrentRequests(t *testing.T) {
+	provider := &blockingProvider{release: make(chan struct{})}
+	client := &Client{cfg: &config.LLMConfig{}, provider: provider, limiter: newRequestLimiter(2)}
+
+	var (
+		wg     sync.WaitGroup
+		queued atomic.Int32
+	)
+	ctx := WithQueueNotice(context.Background(), func() { queued.Add(1) })
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Ask(ctx, "explain", nil); err != nil {
+				t.Errorf("Ask() error = %v", err)
+			}
+		}()
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for queued.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := queued.Load(); got != 2 {
+		t.Fatalf("%d requests were queued, want 2", got)
+	}
+
+	// A queued request gives up when its context ends
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := client.Ask(cancelled, "explain", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Ask() with a cancelled context error = %v, want context.Canceled", err)
+	}
+
+	close(provider.release)
+	wg.Wait()
+	if provider.maxSeen != 2 || provider.finished != 4 {
+		t.Errorf("max %d requests at once and %d finished, want 2 and 4", provider.maxSeen, provider.finished)
+	}
+
+	if unlimited := newRequestLimiter(-1); unlimited.slots != nil {
+		t.Error("newRequestLimiter(-1) should not limit requests")
+	}
+	if def := newRequestLimiter(0); cap(def.slots) != defaultMaxConcurrentRequests {
+		t.Errorf("newRequestLimiter(0) allows %d requests, want %d", cap(def.slots), defaultMaxConcurrentRequests)
+	}
+}
+
+// oneToolCallProvider makes one tool call in its tool loop
+type oneToolCallProvider struct {
+	capturingToolProvider
+}
+
+func (m *oneToolCallProvider) AskWithTools(ctx context.Context, prompt string, defs []providers.ToolDefinition, callback func(string), toolCallback providers.ToolCallback) error {
+	toolCallback(providers.ToolCall{ID: "call-1", Type: "function", Function: providers.FunctionCall{Name: "kubectl", Arguments: `{"command":"kubectl get pods"}`}})
+	return nil
+}
+
+func TestClientReleasesSlotDuringToolApproval(t *testing.T) {
+	client := &Client{cfg: &config.LLMConfig{}, provider: &oneToolCallProvider{}, toolRegistry: tools.NewRegistry(), limiter: newRequestLimiter(1)}
+
+	// While the tool call waits for approval, another question gets the
+	// only slot
+	approve := func(toolName, args string) bool {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := client.Ask(ctx, "explain", nil); err != nil {
+			t.Errorf("Ask() during a tool approval error = %v", err)
+		}
+		return false
+	}
+	if err := client.AskWithToolsAndExecution(context.Background(), "list pods", nil, approve, nil); err != nil {
+		t.Fatalf("AskWithToolsAndExecution() error = %v", err)
+	}
+	if len(client.limiter.slots) != 0 {
+		t.Errorf("%d slots still held after the tool loop, want 0", len(client.limiter.slots))
+	}
+}
+
+func TestNewSharedClientHasNoDefaultLimit(t *testing.T) {
+	cfg := &config.LLMConfig{Provider: "openai", Model: "gpt-4", APIKey: "test-key"}
+	client, err := NewSharedClient(cfg)
+	if err != nil {
+		t.Fatalf("NewSharedClient() error = %v", err)
+	}
+	if client.limiter.slots != nil {
+		t.Errorf("shared client allows %d requests, want no limit", cap(client.limiter.slots))
+	}
+
+	cfg.MaxConcurrentRequests = 5
+	if client, err = NewSharedClient(cfg); err != nil {
+		t.Fatalf("NewSharedClient() error = %v", err)
+	}
+	if cap(client.limiter.slots) != 5 {
+		t.Errorf("shared client allows %d requests, want llm.max_concurrent_requests 5", cap(client.limiter.slots))
+	}
+}
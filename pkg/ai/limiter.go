@@ -0,0 +1,100 @@
+package ai
+
+import "context"
+
+// defaultMaxConcurrentRequests is how many requests a client sends to its
+// provider at once; the rest wait for one to finish
+const defaultMaxConcurrentRequests = 3
+
+// requestLimiter bounds the provider requests of a client in flight
+type requestLimiter struct {
+	slots chan struct{} // nil when there is no limit
+}
+
+// newRequestLimiter returns a limiter for max requests at once. 0 uses the
+// default; a negative max turns the limit off.
+func newRequestLimiter(max int) *requestLimiter {
+	if max == 0 {
+		max = defaultMaxConcurrentRequests
+	}
+	if max < 0 {
+		return &requestLimiter{}
+	}
+	return &requestLimiter{slots: make(chan struct{}, max)}
+}
+
+type queueNoticeKey struct{}
+
+// WithQueueNotice returns a context whose AI requests call notice once
+// when they have to wait for other requests to finish first, e.g. to show
+// that the request is queued
+func WithQueueNotice(ctx context.Context, notice func()) context.Context {
+	return context.WithValue(ctx, queueNoticeKey{}, notice)
+}
+
+// acquire waits for a free slot and returns the function that gives it
+// back. It fails only when ctx ends while waiting.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil || l.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	default:
+	}
+
+	if notice, ok := ctx.Value(queueNoticeKey{}).(func()); ok && notice != nil {
+		notice()
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *requestLimiter) release() {
+	<-l.slots
+}
+
+// heldSlot is a slot held across a tool loop. The loop gives it back while a
+// tool call waits for approval and runs, so a question waiting on the user
+// does not hold up other requests.
+type heldSlot struct {
+	l       *requestLimiter
+	release func() // nil while the slot is given back
+}
+
+// hold acquires a slot like acquire
+func (l *requestLimiter) hold(ctx context.Context) (*heldSlot, error) {
+	release, err := l.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &heldSlot{l: l, release: release}, nil
+}
+
+// pause gives the slot back; done does the same when the loop ends
+func (s *heldSlot) pause() {
+	if s.release != nil {
+		s.release()
+		s.release = nil
+	}
+}
+
+func (s *heldSlot) done() { s.pause() }
+
+// resume waits for a slot again before the next provider request
+func (s *heldSlot) resume(ctx context.Context) error {
+	if s.release != nil {
+		return nil
+	}
+	release, err := s.l.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	s.release = release
+	return nil
+}
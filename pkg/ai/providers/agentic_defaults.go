@@ -3,6 +3,8 @@ package providers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"sort"
 	"strings"
 	"text/template"
@@ -222,6 +224,15 @@ type kubectlAIToolPromptData struct {
 	SessionIsInteractive bool
 }
 
+// ErrMaxIterations is returned by AskWithTools when the model is still
+// calling tools after the last allowed iteration
+var ErrMaxIterations = errors.New("exceeded maximum tool call iterations")
+
+func maxIterationsError(maxIterations int) error {
+	return fmt.Errorf("%w (%d): the model kept calling tools without a final answer; raise llm.max_iterations (up to %d) or narrow the request",
+		ErrMaxIterations, maxIterations, maximumToolLoopIterations)
+}
+
 func effectiveMaxIterations(cfg *ProviderConfig) int {
 	if cfg == nil || cfg.MaxIterations <= 0 {
 		return defaultToolLoopIterations
@@ -27,12 +27,13 @@ const anthropicDefaultMaxTokens = 4096
 // Anthropic request/response types
 
 type anthropicRequest struct {
-	Model     string             `json:"model"`
-	MaxTokens int                `json:"max_tokens"`
-	System    string             `json:"system,omitempty"`
-	Messages  []anthropicMessage `json:"messages"`
-	Stream    bool               `json:"stream,omitempty"`
-	Tools     []anthropicTool    `json:"tools,omitempty"`
+	Model       string             `json:"model"`
+	MaxTokens   int                `json:"max_tokens"`
+	System      string             `json:"system,omitempty"`
+	Messages    []anthropicMessage `json:"messages"`
+	Stream      bool               `json:"stream,omitempty"`
+	Tools       []anthropicTool    `json:"tools,omitempty"`
+	Temperature *float64           `json:"temperature,omitempty"`
 }
 
 type anthropicMessage struct {
@@ -102,7 +103,7 @@ func NewAnthropicProvider(cfg *ProviderConfig) (Provider, error) {
 
 	return &AnthropicProvider{
 		config:     cfg,
-		httpClient: newHTTPClient(cfg.SkipTLSVerify),
+		httpClient: newProviderHTTPClient(cfg),
 		endpoint:   endpoint,
 	}, nil
 }
@@ -115,6 +116,10 @@ func (p *AnthropicProvider) GetModel() string {
 	return p.config.Model
 }
 
+func (p *AnthropicProvider) Capabilities() Capabilities {
+	return CapabilitiesFor(p.Name(), p.GetModel())
+}
+
 func (p *AnthropicProvider) IsReady() bool {
 	return p.config != nil && p.config.APIKey != ""
 }
@@ -128,9 +133,10 @@ func (p *AnthropicProvider) setHeaders(req *http.Request) {
 // Ask sends a prompt and streams the response via callback
 func (p *AnthropicProvider) Ask(ctx context.Context, prompt string, callback func(string)) error {
 	reqBody := anthropicRequest{
-		Model:     p.config.Model,
-		MaxTokens: anthropicDefaultMaxTokens,
-		System:    "You are a helpful Kubernetes assistant. Help users manage Kubernetes clusters using natural language. When users ask to create resources, generate the appropriate kubectl commands.",
+		Model:       p.config.Model,
+		MaxTokens:   effectiveMaxTokens(p.config, anthropicDefaultMaxTokens),
+		Temperature: p.config.Temperature,
+		System:      "You are a helpful Kubernetes assistant. Help users manage Kubernetes clusters using natural language. When users ask to create resources, generate the appropriate kubectl commands.",
 		Messages: []anthropicMessage{
 			{Role: "user", Content: prompt},
 		},
@@ -143,9 +149,10 @@ func (p *AnthropicProvider) Ask(ctx context.Context, prompt string, callback fun
 // AskNonStreaming sends a prompt and returns the full response
 func (p *AnthropicProvider) AskNonStreaming(ctx context.Context, prompt string) (string, error) {
 	reqBody := anthropicRequest{
-		Model:     p.config.Model,
-		MaxTokens: anthropicDefaultMaxTokens,
-		System:    "You are a helpful Kubernetes assistant. Help users manage Kubernetes clusters using natural language. When users ask to create resources, generate the appropriate kubectl commands.",
+		Model:       p.config.Model,
+		MaxTokens:   effectiveMaxTokens(p.config, anthropicDefaultMaxTokens),
+		Temperature: p.config.Temperature,
+		System:      "You are a helpful Kubernetes assistant. Help users manage Kubernetes clusters using natural language. When users ask to create resources, generate the appropriate kubectl commands.",
 		Messages: []anthropicMessage{
 			{Role: "user", Content: prompt},
 		},
@@ -193,11 +200,12 @@ func (p *AnthropicProvider) AskWithTools(ctx context.Context, prompt string, too
 	systemPrompt := toolAgentSystemPrompt(maxIterations)
 	for i := 0; i < maxIterations; i++ {
 		reqBody := anthropicRequest{
-			Model:     p.config.Model,
-			MaxTokens: anthropicDefaultMaxTokens,
-			System:    systemPrompt,
-			Messages:  messages,
-			Tools:     anthropicTools,
+			Model:       p.config.Model,
+			MaxTokens:   effectiveMaxTokens(p.config, anthropicDefaultMaxTokens),
+			Temperature: p.config.Temperature,
+			System:      systemPrompt,
+			Messages:    messages,
+			Tools:       anthropicTools,
 		}
 
 		log.Debugf("Anthropic AskWithTools - Model: %s, Tools: %d, Iteration: %d", p.config.Model, len(anthropicTools), i+1)
@@ -282,7 +290,7 @@ func (p *AnthropicProvider) AskWithTools(ctx context.Context, prompt string, too
 		})
 	}
 
-	return fmt.Errorf("exceeded maximum iterations (%d)", maxIterations)
+	return maxIterationsError(maxIterations)
 }
 
 // doRequest sends a non-streaming request and returns the parsed response
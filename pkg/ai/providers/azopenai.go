@@ -32,7 +32,7 @@ func NewAzureOpenAIProvider(cfg *ProviderConfig) (Provider, error) {
 
 	return &AzureOpenAIProvider{
 		config:     cfg,
-		httpClient: newHTTPClient(cfg.SkipTLSVerify),
+		httpClient: newProviderHTTPClient(cfg),
 		endpoint:   strings.TrimSuffix(cfg.Endpoint, "/"),
 		deployment: deployment,
 	}, nil
@@ -46,6 +46,10 @@ func (p *AzureOpenAIProvider) GetModel() string {
 	return p.deployment
 }
 
+func (p *AzureOpenAIProvider) Capabilities() Capabilities {
+	return CapabilitiesFor(p.Name(), p.GetModel())
+}
+
 func (p *AzureOpenAIProvider) IsReady() bool {
 	return p.config != nil && p.config.APIKey != "" && p.endpoint != ""
 }
@@ -60,7 +64,8 @@ func (p *AzureOpenAIProvider) Ask(ctx context.Context, prompt string, callback f
 			{Role: "system", Content: "You are a helpful Kubernetes assistant. Help users manage Kubernetes clusters using natural language. When users ask to create resources, generate the appropriate kubectl commands."},
 			{Role: "user", Content: prompt},
 		},
-		Stream: true,
+		Stream:                 true,
+		openAIGenerationParams: openAIGeneration(p.config, p.deployment),
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -131,7 +136,8 @@ func (p *AzureOpenAIProvider) AskNonStreaming(ctx context.Context, prompt string
 			{Role: "system", Content: "You are a helpful Kubernetes assistant."},
 			{Role: "user", Content: prompt},
 		},
-		Stream: false,
+		Stream:                 false,
+		openAIGenerationParams: openAIGeneration(p.config, p.deployment),
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -195,9 +201,10 @@ func (p *AzureOpenAIProvider) AskWithTools(ctx context.Context, prompt string, t
 
 	for i := 0; i < maxIterations; i++ {
 		reqBody := azureOpenAIChatRequest{
-			Messages: messages,
-			Stream:   false,
-			Tools:    tools,
+			Messages:               messages,
+			Stream:                 false,
+			Tools:                  tools,
+			openAIGenerationParams: openAIGeneration(p.config, p.deployment),
 		}
 
 		jsonBody, err := json.Marshal(reqBody)
@@ -285,7 +292,7 @@ func (p *AzureOpenAIProvider) AskWithTools(ctx context.Context, prompt string, t
 		}
 	}
 
-	return fmt.Errorf("exceeded maximum iterations (%d)", maxIterations)
+	return maxIterationsError(maxIterations)
 }
 
 // azureOpenAIChatRequest extends the request with tools support
@@ -293,6 +300,7 @@ type azureOpenAIChatRequest struct {
 	Messages []ChatMessage    `json:"messages"`
 	Stream   bool             `json:"stream"`
 	Tools    []ToolDefinition `json:"tools,omitempty"`
+	openAIGenerationParams
 }
 
 // azureOpenAIChatResponse includes tool calls
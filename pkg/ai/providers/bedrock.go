@@ -22,9 +22,12 @@ type BedrockProvider struct {
 	region     string
 }
 
+const bedrockDefaultMaxTokens = 4096
+
 type bedrockClaudeRequest struct {
 	AnthropicVersion string             `json:"anthropic_version"`
 	MaxTokens        int                `json:"max_tokens"`
+	Temperature      *float64           `json:"temperature,omitempty"`
 	System           string             `json:"system,omitempty"`
 	Messages         []bedrockClaudeMsg `json:"messages"`
 }
@@ -62,7 +65,7 @@ func NewBedrockProvider(cfg *ProviderConfig) (Provider, error) {
 
 	return &BedrockProvider{
 		config:     &providerCfg,
-		httpClient: newHTTPClient(cfg.SkipTLSVerify),
+		httpClient: newProviderHTTPClient(cfg),
 		region:     region,
 	}, nil
 }
@@ -75,6 +78,10 @@ func (p *BedrockProvider) GetModel() string {
 	return p.config.Model
 }
 
+func (p *BedrockProvider) Capabilities() Capabilities {
+	return CapabilitiesFor(p.Name(), p.GetModel())
+}
+
 func (p *BedrockProvider) IsReady() bool {
 	// Check for AWS credentials (either in config or environment)
 	if p.config.APIKey != "" {
@@ -99,7 +106,8 @@ func (p *BedrockProvider) AskNonStreaming(ctx context.Context, prompt string) (s
 
 	reqBody := bedrockClaudeRequest{
 		AnthropicVersion: "bedrock-2023-05-31",
-		MaxTokens:        4096,
+		MaxTokens:        effectiveMaxTokens(p.config, bedrockDefaultMaxTokens),
+		Temperature:      p.config.Temperature,
 		System:           "You are a helpful Kubernetes assistant. Help users manage Kubernetes clusters using natural language. When users ask to create resources, generate the appropriate kubectl commands.",
 		Messages: []bedrockClaudeMsg{
 			{Role: "user", Content: prompt},
@@ -171,6 +179,7 @@ type bedrockTool struct {
 type bedrockClaudeToolRequest struct {
 	AnthropicVersion string                 `json:"anthropic_version"`
 	MaxTokens        int                    `json:"max_tokens"`
+	Temperature      *float64               `json:"temperature,omitempty"`
 	System           string                 `json:"system,omitempty"`
 	Messages         []bedrockClaudeMessage `json:"messages"`
 	Tools            []bedrockTool          `json:"tools,omitempty"`
@@ -233,7 +242,8 @@ func (p *BedrockProvider) AskWithTools(ctx context.Context, prompt string, tools
 	for i := 0; i < maxIterations; i++ {
 		reqBody := bedrockClaudeToolRequest{
 			AnthropicVersion: "bedrock-2023-05-31",
-			MaxTokens:        4096,
+			MaxTokens:        effectiveMaxTokens(p.config, bedrockDefaultMaxTokens),
+			Temperature:      p.config.Temperature,
 			System:           toolAgentSystemPrompt(maxIterations),
 			Messages:         messages,
 			Tools:            bedrockTools,
@@ -363,7 +373,7 @@ func (p *BedrockProvider) AskWithTools(ctx context.Context, prompt string, tools
 		})
 	}
 
-	return fmt.Errorf("exceeded maximum iterations (%d)", maxIterations)
+	return maxIterationsError(maxIterations)
 }
 
 // signRequest signs the request with AWS Signature V4
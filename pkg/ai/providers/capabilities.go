@@ -0,0 +1,77 @@
+package providers
+
+import "strings"
+
+// Capabilities reports which features a provider supports with its
+// configured model
+type Capabilities struct {
+	// Streaming is true when Ask delivers the response in chunks rather
+	// than in one piece at the end
+	Streaming bool `json:"streaming"`
+	// Tools is true when the provider implements ToolProvider, which the
+	// agentic flows (kubectl, bash and MCP tools) depend on
+	Tools bool `json:"tools"`
+	// Vision is true when the model accepts image input
+	Vision bool `json:"vision"`
+}
+
+// String lists the supported features, e.g. "streaming, tools", or "none"
+func (c Capabilities) String() string {
+	var features []string
+	if c.Streaming {
+		features = append(features, "streaming")
+	}
+	if c.Tools {
+		features = append(features, "tools")
+	}
+	if c.Vision {
+		features = append(features, "vision")
+	}
+	if len(features) == 0 {
+		return "none"
+	}
+	return strings.Join(features, ", ")
+}
+
+// visionModels holds lowercase substrings of model names that accept images,
+// per provider. Providers whose every current model does are not listed.
+var visionModels = map[string][]string{
+	"openai":  {"gpt-4o", "gpt-4.1", "gpt-4-turbo", "gpt-5", "o1", "o3", "o4"},
+	"bedrock": {"claude-3", "claude-sonnet-4", "claude-opus-4", "claude-haiku-4", "nova-pro", "nova-lite"},
+	"ollama":  {"llava", "vision", "gemma3", "qwen2.5vl", "minicpm-v", "moondream"},
+}
+
+// CapabilitiesFor returns the feature matrix entry for a provider name as
+// written in the config, and its model. It needs no provider instance, so
+// settings can show what a provider supports before it is saved. Unknown
+// providers report no features.
+func CapabilitiesFor(provider, model string) Capabilities {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	model = strings.ToLower(model)
+
+	visionFor := func(family string) bool {
+		for _, m := range visionModels[family] {
+			if strings.Contains(model, m) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch provider {
+	case "openai", "litellm", "openrouter", "azopenai", "azure":
+		return Capabilities{Streaming: true, Tools: true, Vision: visionFor("openai")}
+	case "solar", "upstage":
+		return Capabilities{Streaming: true, Tools: true}
+	case "anthropic", "gemini", "vertexai":
+		return Capabilities{Streaming: true, Tools: true, Vision: true}
+	case "bedrock":
+		// Ask waits for the whole response
+		return Capabilities{Tools: true, Vision: visionFor("bedrock")}
+	case "ollama":
+		// Tool calls also need a model with tools support, see the
+		// model hint shown in settings
+		return Capabilities{Streaming: true, Tools: true, Vision: visionFor("ollama")}
+	}
+	return Capabilities{}
+}
@@ -0,0 +1,51 @@
+package providers
+
+import "testing"
+
+func TestCapabilitiesFor(t *testing.T) {
+	tests := []struct {
+		provider, model string
+		want            Capabilities
+	}{
+		{"openai", "gpt-4o", Capabilities{Streaming: true, Tools: true, Vision: true}},
+		{"openai", "gpt-3.5-turbo", Capabilities{Streaming: true, Tools: true}},
+		{"upstage", "solar-pro2", Capabilities{Streaming: true, Tools: true}},
+		{"Anthropic", "claude-sonnet-4-20250514", Capabilities{Streaming: true, Tools: true, Vision: true}},
+		{"bedrock", "anthropic.claude-3-sonnet-20240229-v1:0", Capabilities{Tools: true, Vision: true}},
+		{"ollama", "gpt-oss:20b", Capabilities{Streaming: true, Tools: true}},
+		{"ollama", "llama3.2-vision", Capabilities{Streaming: true, Tools: true, Vision: true}},
+		{"unknown", "model", Capabilities{}},
+	}
+	for _, tt := range tests {
+		if got := CapabilitiesFor(tt.provider, tt.model); got != tt.want {
+			t.Errorf("CapabilitiesFor(%q, %q) = %+v, want %+v", tt.provider, tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestCapabilitiesString(t *testing.T) {
+	if got := (Capabilities{Streaming: true, Vision: true}).String(); got != "streaming, vision" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (Capabilities{}).String(); got != "none" {
+		t.Errorf("String() = %q, want none", got)
+	}
+}
+
+func TestWrappedProviderCapabilities(t *testing.T) {
+	plain := &mockProvider{name: "plain", ready: true}
+	tool := &mockToolProvider{mockProvider: mockProvider{name: "tool", ready: true}}
+
+	if caps := CreateWithRetry(plain, nil).Capabilities(); caps.Tools {
+		t.Error("retry wrapper of a provider without AskWithTools must not report tools")
+	}
+	if caps := CreateWithRetry(tool, nil).Capabilities(); !caps.Tools || !caps.Streaming {
+		t.Errorf("retry wrapper of a tool provider = %+v, want streaming and tools", caps)
+	}
+	if caps := CreateWithFallback(tool, plain).Capabilities(); !caps.Tools {
+		t.Error("fallback chain should report the primary provider's tools support")
+	}
+	if caps := CreateWithFallback(plain, tool).Capabilities(); caps.Tools {
+		t.Error("fallback chain with a plain primary must not report tools")
+	}
+}
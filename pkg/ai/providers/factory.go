@@ -1,17 +1,23 @@
 package providers
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"math"
 	"math/rand"
+	"net"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/log"
 )
 
 // ProviderFactory creates LLM providers based on configuration
@@ -39,6 +45,7 @@ func GetFactory() *ProviderFactory {
 		defaultFactory.Register("litellm", NewLiteLLMProvider)
 		defaultFactory.Register("ollama", NewOllamaProvider)
 		defaultFactory.Register("gemini", NewGeminiProvider)
+		defaultFactory.Register("vertexai", NewVertexAIProvider)
 		defaultFactory.Register("bedrock", NewBedrockProvider)
 		defaultFactory.Register("anthropic", NewAnthropicProvider)
 		defaultFactory.Register("azopenai", NewAzureOpenAIProvider)
@@ -104,6 +111,13 @@ func applyEnvFallbacks(cfg *ProviderConfig) *ProviderConfig {
 		if clone.APIKey == "" {
 			clone.APIKey = os.Getenv("GOOGLE_API_KEY")
 		}
+	case "vertexai":
+		if clone.Project == "" {
+			clone.Project = os.Getenv("GOOGLE_CLOUD_PROJECT")
+		}
+		if clone.Region == "" {
+			clone.Region = os.Getenv("GOOGLE_CLOUD_LOCATION")
+		}
 	case "azopenai", "azure":
 		if clone.APIKey == "" {
 			clone.APIKey = os.Getenv("AZURE_OPENAI_API_KEY")
@@ -182,6 +196,17 @@ func (r *retryProvider) ListModels(ctx context.Context) ([]string, error) {
 	return r.provider.ListModels(ctx)
 }
 
+// Capabilities reports the underlying provider's features. retryProvider
+// always has AskWithTools, so Tools also requires the underlying provider
+// to implement ToolProvider.
+func (r *retryProvider) Capabilities() Capabilities {
+	caps := r.provider.Capabilities()
+	if _, ok := r.provider.(ToolProvider); !ok {
+		caps.Tools = false
+	}
+	return caps
+}
+
 func (r *retryProvider) Ask(ctx context.Context, prompt string, callback func(string)) error {
 	var lastErr error
 	for attempt := 0; attempt < r.config.MaxAttempts; attempt++ {
@@ -289,8 +314,7 @@ func (r *retryProvider) AskWithTools(ctx context.Context, prompt string, tools [
 
 // SupportsTools returns true if the underlying provider supports tool calling
 func (r *retryProvider) SupportsTools() bool {
-	_, ok := r.provider.(ToolProvider)
-	return ok
+	return r.Capabilities().Tools
 }
 
 func (r *retryProvider) calculateBackoff(attempt int) time.Duration {
@@ -340,14 +364,198 @@ func isRetryableError(err error) bool {
 	return false
 }
 
-// newHTTPClient creates an HTTP client with optional TLS skip
-func newHTTPClient(skipTLS bool) *http.Client {
-	transport := &http.Transport{}
+// Connection pool limits for the transports provider clients share. Eval and
+// benchmark runs send hundreds of requests to one endpoint, often from
+// several workers at once, so keep more idle connections per host than the
+// net/http default of two.
+const (
+	maxIdleConns        = 100
+	maxIdleConnsPerHost = 16
+	idleConnTimeout     = 90 * time.Second
+)
+
+var (
+	transportOnce     sync.Once
+	pooledTransport   *http.Transport
+	insecureTransport *http.Transport
+)
+
+// sharedTransport returns the keep-alive transport shared by every provider
+// client with the same TLS setting, so providers created per task or per
+// request still reuse pooled connections and skip repeat TLS handshakes
+func sharedTransport(skipTLS bool) *http.Transport {
+	transportOnce.Do(func() {
+		pooledTransport = newPooledTransport(nil)
+		insecureTransport = newPooledTransport(&tls.Config{InsecureSkipVerify: true})
+	})
 	if skipTLS {
-		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		return insecureTransport
+	}
+	return pooledTransport
+}
+
+func newPooledTransport(tlsConfig *tls.Config) *http.Transport {
+	return &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       idleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
 	}
+}
+
+// newHTTPClient creates an HTTP client with optional TLS skip on top of the
+// shared connection pool. Requests still honor their context, so cancelling
+// one call never affects others using the pool.
+func newHTTPClient(skipTLS bool) *http.Client {
 	return &http.Client{
-		Transport: transport,
+		Transport: &timingTransport{base: sharedTransport(skipTLS)},
 		Timeout:   60 * time.Second,
 	}
 }
+
+// newProviderHTTPClient creates the HTTP client a provider uses for cfg, adding
+// payload logging when cfg.LogPayloads is set.
+func newProviderHTTPClient(cfg *ProviderConfig) *http.Client {
+	client := newHTTPClient(cfg.SkipTLSVerify)
+	if cfg.LogPayloads {
+		client.Transport = &payloadTransport{base: client.Transport, apiKey: cfg.APIKey}
+	}
+	return client
+}
+
+// timingTransport logs each provider request with its status and latency at
+// debug level. Time to response headers is logged; streamed bodies keep
+// flowing after that.
+type timingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *timingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		log.Debugf("LLM request %s %s%s failed after %s: %v", req.Method, req.URL.Host, req.URL.Path, elapsed, err)
+		return nil, err
+	}
+	log.Debugf("LLM request %s %s%s -> %d in %s", req.Method, req.URL.Host, req.URL.Path, resp.StatusCode, elapsed)
+	return resp, nil
+}
+
+// maxLoggedPayload caps how much of each request or response body is logged
+const maxLoggedPayload = 64 * 1024
+
+// payloadTransport logs provider request and response bodies at debug level
+// for diagnosing prompt and format issues. Credential headers are masked and
+// the API key is scrubbed wherever it appears in the URL or a body. Streamed
+// responses are logged once the caller closes the body.
+type payloadTransport struct {
+	base   http.RoundTripper
+	apiKey string
+}
+
+func (t *payloadTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		data, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = data
+		req.Body = io.NopCloser(bytes.NewReader(data))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}
+	}
+
+	target := t.redact(req.URL.Redacted())
+	log.Debugf("LLM request payload %s %s headers=%s body=%s",
+		req.Method, target, t.redact(redactHeaders(req.Header)), t.redact(truncatePayload(body)))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &payloadLogBody{
+		ReadCloser: resp.Body,
+		onClose: func(captured []byte, truncated bool) {
+			text := string(captured)
+			if truncated {
+				text += "...(truncated)"
+			}
+			log.Debugf("LLM response payload %s %s -> %d body=%s", req.Method, target, resp.StatusCode, t.redact(text))
+		},
+	}
+	return resp, nil
+}
+
+// redact scrubs the configured API key from s
+func (t *payloadTransport) redact(s string) string {
+	if t.apiKey == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, t.apiKey, "[REDACTED]")
+}
+
+// redactHeaders renders request headers with credential values masked
+func redactHeaders(h http.Header) string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		value := strings.Join(h[name], ",")
+		lower := strings.ToLower(name)
+		if strings.Contains(lower, "auth") || strings.Contains(lower, "key") ||
+			strings.Contains(lower, "token") || strings.Contains(lower, "secret") {
+			value = "[REDACTED]"
+		}
+		parts = append(parts, name+"="+value)
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+func truncatePayload(body []byte) string {
+	if len(body) > maxLoggedPayload {
+		return string(body[:maxLoggedPayload]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// payloadLogBody captures up to maxLoggedPayload bytes of a response body as
+// it is read and reports them once on Close.
+type payloadLogBody struct {
+	io.ReadCloser
+	buf       bytes.Buffer
+	truncated bool
+	once      sync.Once
+	onClose   func(captured []byte, truncated bool)
+}
+
+func (b *payloadLogBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		keep := n
+		if room := maxLoggedPayload - b.buf.Len(); keep > room {
+			keep, b.truncated = room, true
+		}
+		b.buf.Write(p[:keep])
+	}
+	return n, err
+}
+
+func (b *payloadLogBody) Close() error {
+	b.once.Do(func() { b.onClose(b.buf.Bytes(), b.truncated) })
+	return b.ReadCloser.Close()
+}
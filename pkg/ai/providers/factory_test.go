@@ -3,9 +3,15 @@ package providers
 import (
 	"context"
 	"errors"
+	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // mockProvider implements Provider for testing
@@ -21,6 +27,7 @@ func (m *mockProvider) Name() string                                     { retur
 func (m *mockProvider) GetModel() string                                 { return m.model }
 func (m *mockProvider) IsReady() bool                                    { return m.ready }
 func (m *mockProvider) ListModels(ctx context.Context) ([]string, error) { return nil, nil }
+func (m *mockProvider) Capabilities() Capabilities                       { return Capabilities{Streaming: true} }
 
 func (m *mockProvider) Ask(ctx context.Context, prompt string, callback func(string)) error {
 	if m.askErr != nil {
@@ -47,6 +54,10 @@ type mockToolProvider struct {
 	askWithToolsFn func(ctx context.Context, prompt string, tools []ToolDefinition, callback func(string), toolCallback ToolCallback) error
 }
 
+func (m *mockToolProvider) Capabilities() Capabilities {
+	return Capabilities{Streaming: true, Tools: true}
+}
+
 func (m *mockToolProvider) AskWithTools(ctx context.Context, prompt string, tools []ToolDefinition, callback func(string), toolCallback ToolCallback) error {
 	if m.askWithToolsFn != nil {
 		return m.askWithToolsFn(ctx, prompt, tools, callback, toolCallback)
@@ -1057,9 +1068,17 @@ func TestGeminiToolProviderInterface(t *testing.T) {
 	}
 }
 
+// baseTransport unwraps the debug timing transport installed by newHTTPClient
+func baseTransport(client *http.Client) http.RoundTripper {
+	if tt, ok := client.Transport.(*timingTransport); ok {
+		return tt.base
+	}
+	return client.Transport
+}
+
 func TestTLSSkipVerifyEnabled(t *testing.T) {
 	client := newHTTPClient(true)
-	transport, ok := client.Transport.(*http.Transport)
+	transport, ok := baseTransport(client).(*http.Transport)
 	if !ok {
 		t.Fatal("Expected *http.Transport")
 	}
@@ -1073,7 +1092,7 @@ func TestTLSSkipVerifyEnabled(t *testing.T) {
 
 func TestTLSSkipVerifyDisabled(t *testing.T) {
 	client := newHTTPClient(false)
-	transport, ok := client.Transport.(*http.Transport)
+	transport, ok := baseTransport(client).(*http.Transport)
 	if !ok {
 		t.Fatal("Expected *http.Transport")
 	}
@@ -1082,11 +1101,92 @@ func TestTLSSkipVerifyDisabled(t *testing.T) {
 	}
 }
 
+func TestHTTPClientsShareConnectionPool(t *testing.T) {
+	a, b := baseTransport(newHTTPClient(false)), baseTransport(newHTTPClient(false))
+	if a != b {
+		t.Error("clients with the same TLS setting should share one transport")
+	}
+	if a == baseTransport(newHTTPClient(true)) {
+		t.Error("TLS-skipping clients must not share the verifying transport")
+	}
+	if transport := a.(*http.Transport); transport.MaxIdleConnsPerHost < 2 || transport.IdleConnTimeout == 0 {
+		t.Errorf("pool settings = %d idle per host, %s idle timeout", transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+}
+
+// countingServer starts a copy of handler that counts the TCP connections it accepts
+func countingServer(t *testing.T, handler http.Handler) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var conns atomic.Int32
+	srv := httptest.NewUnstartedServer(handler)
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv, &conns
+}
+
+func TestProviderAskReusesConnections(t *testing.T) {
+	stream := newOpenAIStreamServer(t, []string{"Hello", " world"})
+	defer stream.Close()
+	srv, conns := countingServer(t, stream.Config.Handler)
+
+	// A fresh provider per task, as the benchmark runner creates, still
+	// draws from the shared pool
+	for i := 0; i < 3; i++ {
+		p, err := NewOpenAIProvider(&ProviderConfig{Provider: "openai", Model: "gpt-4", APIKey: "test-key", Endpoint: srv.URL})
+		if err != nil {
+			t.Fatalf("NewOpenAIProvider: %v", err)
+		}
+		for j := 0; j < 2; j++ {
+			if err := p.Ask(context.Background(), "say hello", func(string) {}); err != nil {
+				t.Fatalf("Ask %d.%d: %v", i, j, err)
+			}
+		}
+	}
+	if got := conns.Load(); got != 1 {
+		t.Errorf("6 sequential Ask calls opened %d connections, want 1", got)
+	}
+}
+
+func TestProviderAskCancelsWithSharedClient(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	// The handler never reads the body, so it may not notice the client
+	// going away; release it before Close waits on it
+	defer close(release)
+
+	p, err := NewOpenAIProvider(&ProviderConfig{Provider: "openai", Model: "gpt-4", APIKey: "test-key", Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = p.Ask(ctx, "hang", func(string) {})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Ask() error = %v, want context deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Ask() returned after %s, want prompt cancellation", elapsed)
+	}
+}
+
 // Test that all expected providers are registered
 func TestFactoryAllProvidersRegistered(t *testing.T) {
 	factory := GetFactory()
 
-	expectedProviders := []string{"solar", "upstage", "openai", "litellm", "ollama", "gemini", "bedrock", "azopenai", "azure"}
+	expectedProviders := []string{"solar", "upstage", "openai", "litellm", "ollama", "gemini", "vertexai", "bedrock", "azopenai", "azure"}
 
 	for _, name := range expectedProviders {
 		t.Run(name, func(t *testing.T) {
@@ -1097,3 +1197,98 @@ func TestFactoryAllProvidersRegistered(t *testing.T) {
 		})
 	}
 }
+
+func TestTimingTransportPassesResponseThrough(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	resp, err := newHTTPClient(false).Get(server.URL + "/v1/models")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTeapot {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusTeapot)
+	}
+}
+
+func TestNewProviderHTTPClientPayloadLogging(t *testing.T) {
+	if _, ok := newProviderHTTPClient(&ProviderConfig{}).Transport.(*payloadTransport); ok {
+		t.Error("payload logging should be off by default")
+	}
+
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		gotBody = string(data)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := newProviderHTTPClient(&ProviderConfig{APIKey: "sk-secret", LogPayloads: true})
+	if _, ok := client.Transport.(*payloadTransport); !ok {
+		t.Fatalf("Transport = %T, want *payloadTransport", client.Transport)
+	}
+
+	resp, err := client.Post(server.URL+"/v1/chat/completions", "application/json", strings.NewReader(`{"model":"m"}`))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if gotBody != `{"model":"m"}` {
+		t.Errorf("server received body %q, want the original request body", gotBody)
+	}
+	if string(data) != `{"ok":true}` {
+		t.Errorf("response body = %q, want it passed through unchanged", data)
+	}
+}
+
+func TestPayloadTransportRedaction(t *testing.T) {
+	tr := &payloadTransport{apiKey: "sk-secret"}
+	if got := tr.redact(`{"key":"sk-secret"}`); strings.Contains(got, "sk-secret") {
+		t.Errorf("redact() = %q, API key should be scrubbed", got)
+	}
+
+	h := http.Header{}
+	h.Set("Authorization", "Bearer sk-secret")
+	h.Set("X-Goog-Api-Key", "abc")
+	h.Set("Content-Type", "application/json")
+	got := redactHeaders(h)
+	if strings.Contains(got, "sk-secret") || strings.Contains(got, "abc") {
+		t.Errorf("redactHeaders() = %q, credential headers should be masked", got)
+	}
+	if !strings.Contains(got, "Content-Type=application/json") {
+		t.Errorf("redactHeaders() = %q, want non-credential headers kept", got)
+	}
+}
+
+func TestPayloadLogBodyCapturesOnClose(t *testing.T) {
+	payload := strings.Repeat("x", maxLoggedPayload+10)
+	var captured []byte
+	var truncated bool
+	calls := 0
+	body := &payloadLogBody{
+		ReadCloser: io.NopCloser(strings.NewReader(payload)),
+		onClose: func(b []byte, tr bool) {
+			calls++
+			captured, truncated = b, tr
+		},
+	}
+
+	n, err := io.Copy(io.Discard, body)
+	if err != nil || n != int64(len(payload)) {
+		t.Fatalf("read %d bytes (err %v), want the full %d", n, err, len(payload))
+	}
+	_ = body.Close()
+	_ = body.Close()
+
+	if calls != 1 {
+		t.Errorf("onClose called %d times, want 1", calls)
+	}
+	if len(captured) != maxLoggedPayload || !truncated {
+		t.Errorf("captured %d bytes (truncated=%v), want %d truncated", len(captured), truncated, maxLoggedPayload)
+	}
+}
@@ -0,0 +1,160 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/log"
+)
+
+// CreateWithFallback chains providers so that a request the first one cannot
+// serve because of a retryable or connection error is sent to the next one.
+// With a single provider it is returned unchanged.
+func CreateWithFallback(chain ...Provider) Provider {
+	if len(chain) == 1 {
+		return chain[0]
+	}
+	return &fallbackProvider{chain: chain}
+}
+
+// fallbackProvider tries an ordered list of providers. Every request starts
+// with the primary so the chain recovers on its own once it is back.
+type fallbackProvider struct {
+	chain []Provider
+}
+
+// Name returns the primary provider name, which is what the user configured
+func (f *fallbackProvider) Name() string {
+	return f.chain[0].Name()
+}
+
+func (f *fallbackProvider) GetModel() string {
+	return f.chain[0].GetModel()
+}
+
+// IsReady reports whether any provider in the chain is ready
+func (f *fallbackProvider) IsReady() bool {
+	for _, p := range f.chain {
+		if p.IsReady() {
+			return true
+		}
+	}
+	return false
+}
+
+// Capabilities reports the primary provider's features. A fallback that
+// lacks tool support answers tool requests with a plain Ask.
+func (f *fallbackProvider) Capabilities() Capabilities {
+	return f.chain[0].Capabilities()
+}
+
+func (f *fallbackProvider) ListModels(ctx context.Context) ([]string, error) {
+	return f.chain[0].ListModels(ctx)
+}
+
+func (f *fallbackProvider) Ask(ctx context.Context, prompt string, callback func(string)) error {
+	return f.try(ctx, func(i int, p Provider) (bool, error) {
+		var streamed atomic.Bool
+		err := p.Ask(ctx, prompt, f.announce(i, callback, &streamed))
+		return streamed.Load(), err
+	})
+}
+
+func (f *fallbackProvider) AskNonStreaming(ctx context.Context, prompt string) (string, error) {
+	var response string
+	err := f.try(ctx, func(_ int, p Provider) (bool, error) {
+		var err error
+		response, err = p.AskNonStreaming(ctx, prompt)
+		return false, err
+	})
+	return response, err
+}
+
+// AskWithTools falls back only while nothing has been streamed and no tool
+// has run, since tool calls have side effects that must not be repeated.
+func (f *fallbackProvider) AskWithTools(ctx context.Context, prompt string, tools []ToolDefinition, callback func(string), toolCallback ToolCallback) error {
+	return f.try(ctx, func(i int, p Provider) (bool, error) {
+		var streamed atomic.Bool
+		wrappedCallback := f.announce(i, callback, &streamed)
+
+		toolProvider, ok := p.(ToolProvider)
+		if !ok || !p.Capabilities().Tools {
+			err := p.Ask(ctx, prompt, wrappedCallback)
+			return streamed.Load(), err
+		}
+
+		var toolExecuted atomic.Bool
+		wrappedToolCallback := toolCallback
+		if toolCallback != nil {
+			wrappedToolCallback = func(call ToolCall) ToolResult {
+				toolExecuted.Store(true)
+				return toolCallback(call)
+			}
+		}
+		err := toolProvider.AskWithTools(ctx, prompt, tools, wrappedCallback, wrappedToolCallback)
+		return streamed.Load() || toolExecuted.Load(), err
+	})
+}
+
+// try runs call against each provider in order. call reports whether output
+// already reached the caller, in which case its error is final.
+func (f *fallbackProvider) try(ctx context.Context, call func(i int, p Provider) (bool, error)) error {
+	var errs []error
+	for i, p := range f.chain {
+		if !p.IsReady() {
+			errs = append(errs, fmt.Errorf("%s: provider not ready", p.Name()))
+			continue
+		}
+		committed, err := call(i, p)
+		if err == nil {
+			if i > 0 {
+				log.Infof("LLM request answered by fallback provider %s (%s)", p.Name(), p.GetModel())
+			}
+			return nil
+		}
+		if committed || ctx.Err() != nil || !isFallbackError(err) {
+			return err
+		}
+		log.Warnf("LLM provider %s (%s) unavailable, trying next: %v", p.Name(), p.GetModel(), err)
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+	}
+	return fmt.Errorf("all LLM providers failed: %w", errors.Join(errs...))
+}
+
+// announce wraps callback for provider i. A fallback provider's first chunk
+// is preceded by a note naming it, so the reader knows who answered.
+func (f *fallbackProvider) announce(i int, callback func(string), streamed *atomic.Bool) func(string) {
+	if callback == nil {
+		return nil
+	}
+	return func(chunk string) {
+		if !streamed.Swap(true) && i > 0 {
+			p := f.chain[i]
+			callback(fmt.Sprintf("_%s is unavailable; answered by %s (%s)._\n\n", f.chain[0].Name(), p.Name(), p.GetModel()))
+		}
+		callback(chunk)
+	}
+}
+
+// isFallbackError reports whether err means the provider could not serve the
+// request at all, as opposed to rejecting it.
+func isFallbackError(err error) bool {
+	if isRetryableError(err) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	errStr := strings.ToLower(err.Error())
+	for _, pattern := range []string{"no such host", "network is unreachable", "max retries exceeded"} {
+		if strings.Contains(errStr, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCreateWithFallback_SingleProviderUnwrapped(t *testing.T) {
+	primary := &mockProvider{name: "openai", ready: true}
+	if got := CreateWithFallback(primary); got != primary {
+		t.Fatalf("CreateWithFallback with one provider = %T, want the provider itself", got)
+	}
+}
+
+func TestFallbackProvider_Ask(t *testing.T) {
+	tests := []struct {
+		name       string
+		primaryErr error
+		wantErr    bool
+		wantOutput string
+	}{
+		{
+			name:       "primary answers",
+			wantOutput: "primary answer",
+		},
+		{
+			name:       "rate limited primary falls back",
+			primaryErr: errors.New("API error (status 429): rate limited"),
+			wantOutput: "_openai is unavailable; answered by ollama (qwen)._\n\nlocal answer",
+		},
+		{
+			name:       "unreachable primary falls back",
+			primaryErr: errors.New("dial tcp: lookup api.openai.com: no such host"),
+			wantOutput: "_openai is unavailable; answered by ollama (qwen)._\n\nlocal answer",
+		},
+		{
+			name:       "rejected request does not fall back",
+			primaryErr: errors.New("API error (status 400): invalid request"),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &mockProvider{name: "openai", model: "gpt-4o", ready: true, askErr: tt.primaryErr, askContent: "primary answer"}
+			local := &mockProvider{name: "ollama", model: "qwen", ready: true, askContent: "local answer"}
+			p := CreateWithFallback(primary, local)
+
+			var out strings.Builder
+			err := p.Ask(context.Background(), "hi", func(s string) { out.WriteString(s) })
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if out.String() != tt.wantOutput {
+				t.Errorf("output = %q, want %q", out.String(), tt.wantOutput)
+			}
+			if p.Name() != "openai" {
+				t.Errorf("Name() = %q, want the primary provider", p.Name())
+			}
+		})
+	}
+}
+
+func TestFallbackProvider_SkipsUnreadyAndReportsAllErrors(t *testing.T) {
+	primary := &mockProvider{name: "openai", ready: true, askErr: errors.New("status 503: unavailable")}
+	unready := &mockProvider{name: "anthropic", ready: false}
+	local := &mockProvider{name: "ollama", ready: true, askErr: errors.New("connection refused")}
+	p := CreateWithFallback(primary, unready, local)
+
+	_, err := p.AskNonStreaming(context.Background(), "hi")
+	if err == nil {
+		t.Fatal("expected error when every provider fails")
+	}
+	for _, name := range []string{"openai", "anthropic", "ollama"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error %q does not mention %s", err, name)
+		}
+	}
+}
+
+func TestFallbackProvider_AskWithToolsNoFallbackAfterToolRuns(t *testing.T) {
+	primary := &mockToolProvider{
+		mockProvider: mockProvider{name: "openai", ready: true},
+		askWithToolsFn: func(ctx context.Context, prompt string, tools []ToolDefinition, callback func(string), toolCallback ToolCallback) error {
+			toolCallback(ToolCall{ID: "1"})
+			return errors.New("status 503: unavailable")
+		},
+	}
+	local := &mockToolProvider{mockProvider: mockProvider{name: "ollama", ready: true}}
+	p := CreateWithFallback(primary, local).(ToolProvider)
+
+	err := p.AskWithTools(context.Background(), "hi", nil, func(string) {}, func(ToolCall) ToolResult { return ToolResult{} })
+	if err == nil {
+		t.Fatal("expected the primary error after a tool ran")
+	}
+	if local.toolCallsCount != 0 {
+		t.Errorf("fallback called %d times after a tool ran, want 0", local.toolCallsCount)
+	}
+}
@@ -16,6 +16,9 @@ type GeminiProvider struct {
 	config     *ProviderConfig
 	httpClient *http.Client
 	endpoint   string
+	// authorize sets the request credentials; nil sends the API key.
+	// Vertex AI uses it to send an OAuth access token instead.
+	authorize func(req *http.Request) error
 }
 
 type geminiContent struct {
@@ -57,6 +60,22 @@ type geminiRequest struct {
 	Contents          []geminiContent  `json:"contents"`
 	SystemInstruction *geminiContent   `json:"systemInstruction,omitempty"`
 	Tools             []geminiToolDecl `json:"tools,omitempty"`
+	GenerationConfig  *geminiGenConfig `json:"generationConfig,omitempty"`
+}
+
+// geminiGenConfig carries the configured generation parameters
+type geminiGenConfig struct {
+	Temperature     *float64 `json:"temperature,omitempty"`
+	MaxOutputTokens int      `json:"maxOutputTokens,omitempty"`
+}
+
+// generationConfig returns the configured generation parameters, or nil to
+// keep the model defaults
+func (p *GeminiProvider) generationConfig() *geminiGenConfig {
+	if p.config.Temperature == nil && p.config.MaxTokens <= 0 {
+		return nil
+	}
+	return &geminiGenConfig{Temperature: p.config.Temperature, MaxOutputTokens: effectiveMaxTokens(p.config, 0)}
 }
 
 type geminiResponse struct {
@@ -102,11 +121,20 @@ func NewGeminiProvider(cfg *ProviderConfig) (Provider, error) {
 
 	return &GeminiProvider{
 		config:     &providerCfg,
-		httpClient: newHTTPClient(cfg.SkipTLSVerify),
+		httpClient: newProviderHTTPClient(cfg),
 		endpoint:   endpoint,
 	}, nil
 }
 
+// setAuth adds the credentials to a request
+func (p *GeminiProvider) setAuth(req *http.Request) error {
+	if p.authorize != nil {
+		return p.authorize(req)
+	}
+	req.Header.Set("x-goog-api-key", p.config.APIKey)
+	return nil
+}
+
 func (p *GeminiProvider) Name() string {
 	return "gemini"
 }
@@ -115,6 +143,10 @@ func (p *GeminiProvider) GetModel() string {
 	return p.config.Model
 }
 
+func (p *GeminiProvider) Capabilities() Capabilities {
+	return CapabilitiesFor(p.Name(), p.GetModel())
+}
+
 func (p *GeminiProvider) IsReady() bool {
 	return p.config != nil && p.config.APIKey != ""
 }
@@ -133,6 +165,7 @@ func (p *GeminiProvider) Ask(ctx context.Context, prompt string, callback func(s
 				Parts: []geminiPart{{Text: prompt}},
 			},
 		},
+		GenerationConfig: p.generationConfig(),
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -146,7 +179,9 @@ func (p *GeminiProvider) Ask(ctx context.Context, prompt string, callback func(s
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-goog-api-key", p.config.APIKey)
+	if err := p.setAuth(req); err != nil {
+		return err
+	}
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
@@ -210,6 +245,7 @@ func (p *GeminiProvider) AskNonStreaming(ctx context.Context, prompt string) (st
 				Parts: []geminiPart{{Text: prompt}},
 			},
 		},
+		GenerationConfig: p.generationConfig(),
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -223,7 +259,9 @@ func (p *GeminiProvider) AskNonStreaming(ctx context.Context, prompt string) (st
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-goog-api-key", p.config.APIKey)
+	if err := p.setAuth(req); err != nil {
+		return "", err
+	}
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
@@ -259,7 +297,9 @@ func (p *GeminiProvider) ListModels(ctx context.Context) ([]string, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	req.Header.Set("x-goog-api-key", p.config.APIKey)
+	if err := p.setAuth(req); err != nil {
+		return nil, err
+	}
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
@@ -356,8 +396,9 @@ func (p *GeminiProvider) AskWithTools(ctx context.Context, prompt string, tools
 			SystemInstruction: &geminiContent{
 				Parts: []geminiPart{{Text: toolAgentSystemPrompt(maxIterations)}},
 			},
-			Contents: contents,
-			Tools:    geminiTools,
+			Contents:         contents,
+			Tools:            geminiTools,
+			GenerationConfig: p.generationConfig(),
 		}
 
 		jsonBody, err := json.Marshal(reqBody)
@@ -371,7 +412,9 @@ func (p *GeminiProvider) AskWithTools(ctx context.Context, prompt string, tools
 		}
 
 		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("x-goog-api-key", p.config.APIKey)
+		if err := p.setAuth(req); err != nil {
+			return err
+		}
 
 		resp, err := p.httpClient.Do(req)
 		if err != nil {
@@ -475,7 +518,7 @@ func (p *GeminiProvider) AskWithTools(ctx context.Context, prompt string, tools
 		})
 	}
 
-	return fmt.Errorf("exceeded maximum tool call iterations (%d)", maxIterations)
+	return maxIterationsError(maxIterations)
 }
 
 // validGeminiModelPrefixes lists known valid Gemini model name prefixes.
@@ -0,0 +1,43 @@
+package providers
+
+import "strings"
+
+// effectiveMaxTokens returns the configured output token cap, or def when
+// none is set
+func effectiveMaxTokens(cfg *ProviderConfig, def int) int {
+	if cfg == nil || cfg.MaxTokens <= 0 {
+		return def
+	}
+	return cfg.MaxTokens
+}
+
+// isOpenAIReasoningModel reports whether model is an OpenAI reasoning model
+// (o1, o3, o4-mini, gpt-5, ...). These accept only the default temperature
+// and take the output cap as max_completion_tokens.
+func isOpenAIReasoningModel(model string) bool {
+	m := strings.ToLower(model)
+	if len(m) >= 2 && m[0] == 'o' && m[1] >= '0' && m[1] <= '9' {
+		return true
+	}
+	return strings.HasPrefix(m, "gpt-5")
+}
+
+// openAIGenerationParams are the generation fields of an OpenAI-compatible
+// chat request; unset fields are omitted so the server default applies
+type openAIGenerationParams struct {
+	Temperature         *float64 `json:"temperature,omitempty"`
+	MaxTokens           int      `json:"max_tokens,omitempty"`
+	MaxCompletionTokens int      `json:"max_completion_tokens,omitempty"`
+}
+
+// openAIGeneration returns the configured temperature and output cap in the
+// fields model accepts
+func openAIGeneration(cfg *ProviderConfig, model string) openAIGenerationParams {
+	if cfg == nil {
+		return openAIGenerationParams{}
+	}
+	if isOpenAIReasoningModel(model) {
+		return openAIGenerationParams{MaxCompletionTokens: effectiveMaxTokens(cfg, 0)}
+	}
+	return openAIGenerationParams{Temperature: cfg.Temperature, MaxTokens: effectiveMaxTokens(cfg, 0)}
+}
@@ -0,0 +1,149 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// decodeGenerationBody unmarshals a captured request body into a generic map
+func decodeGenerationBody(t *testing.T, body []byte) map[string]interface{} {
+	t.Helper()
+	var m map[string]interface{}
+	if err := json.Unmarshal(body, &m); err != nil {
+		t.Fatalf("failed to decode request %s: %v", body, err)
+	}
+	return m
+}
+
+func TestProviders_SendGenerationParams(t *testing.T) {
+	temperature := 0.0
+	gen := func(cfg ProviderConfig) *ProviderConfig {
+		cfg.Temperature = &temperature
+		cfg.MaxTokens = 1234
+		return &cfg
+	}
+
+	t.Run("openai", func(t *testing.T) {
+		rc := newOpenAICaptureServer(t, "ok")
+		defer rc.Server.Close()
+		p, _ := NewOpenAIProvider(gen(ProviderConfig{Model: "gpt-4o", APIKey: "k", Endpoint: rc.Server.URL}))
+		if _, err := p.AskNonStreaming(context.Background(), "hi"); err != nil {
+			t.Fatalf("AskNonStreaming: %v", err)
+		}
+		body := decodeGenerationBody(t, rc.Body)
+		if body["temperature"] != 0.0 || body["max_tokens"] != 1234.0 || body["max_completion_tokens"] != nil {
+			t.Errorf("request = %s, want temperature 0 and max_tokens 1234", rc.Body)
+		}
+	})
+
+	t.Run("openai reasoning model", func(t *testing.T) {
+		rc := newOpenAICaptureServer(t, "ok")
+		defer rc.Server.Close()
+		p, _ := NewOpenAIProvider(gen(ProviderConfig{Model: "o3-mini", APIKey: "k", Endpoint: rc.Server.URL}))
+		if _, err := p.AskNonStreaming(context.Background(), "hi"); err != nil {
+			t.Fatalf("AskNonStreaming: %v", err)
+		}
+		body := decodeGenerationBody(t, rc.Body)
+		if _, ok := body["temperature"]; ok || body["max_tokens"] != nil || body["max_completion_tokens"] != 1234.0 {
+			t.Errorf("request = %s, want only max_completion_tokens 1234", rc.Body)
+		}
+	})
+
+	t.Run("openai unset", func(t *testing.T) {
+		rc := newOpenAICaptureServer(t, "ok")
+		defer rc.Server.Close()
+		p, _ := NewOpenAIProvider(&ProviderConfig{Model: "gpt-4o", APIKey: "k", Endpoint: rc.Server.URL})
+		if _, err := p.AskNonStreaming(context.Background(), "hi"); err != nil {
+			t.Fatalf("AskNonStreaming: %v", err)
+		}
+		body := decodeGenerationBody(t, rc.Body)
+		if _, ok := body["temperature"]; ok || body["max_tokens"] != nil {
+			t.Errorf("request = %s, want provider defaults", rc.Body)
+		}
+	})
+
+	t.Run("azopenai", func(t *testing.T) {
+		rc := newOpenAICaptureServer(t, "ok")
+		defer rc.Server.Close()
+		p, _ := NewAzureOpenAIProvider(gen(ProviderConfig{AzureDeployment: "gpt-4", APIKey: "k", Endpoint: rc.Server.URL}))
+		if _, err := p.AskNonStreaming(context.Background(), "hi"); err != nil {
+			t.Fatalf("AskNonStreaming: %v", err)
+		}
+		body := decodeGenerationBody(t, rc.Body)
+		if body["temperature"] != 0.0 || body["max_tokens"] != 1234.0 {
+			t.Errorf("request = %s, want temperature 0 and max_tokens 1234", rc.Body)
+		}
+	})
+
+	t.Run("anthropic", func(t *testing.T) {
+		rc := newAnthropicCaptureServer(t, "ok")
+		defer rc.Server.Close()
+		p, _ := NewAnthropicProvider(gen(ProviderConfig{Model: "claude-sonnet-4-20250514", APIKey: "k", Endpoint: rc.Server.URL}))
+		if _, err := p.AskNonStreaming(context.Background(), "hi"); err != nil {
+			t.Fatalf("AskNonStreaming: %v", err)
+		}
+		body := decodeGenerationBody(t, rc.Body)
+		if body["temperature"] != 0.0 || body["max_tokens"] != 1234.0 {
+			t.Errorf("request = %s, want temperature 0 and max_tokens 1234", rc.Body)
+		}
+	})
+
+	t.Run("gemini", func(t *testing.T) {
+		rc := newGeminiCaptureServer(t, "ok")
+		defer rc.Server.Close()
+		p, _ := NewGeminiProvider(gen(ProviderConfig{Model: "gemini-2.5-flash", APIKey: "k", Endpoint: rc.Server.URL}))
+		if _, err := p.AskNonStreaming(context.Background(), "hi"); err != nil {
+			t.Fatalf("AskNonStreaming: %v", err)
+		}
+		genCfg, _ := decodeGenerationBody(t, rc.Body)["generationConfig"].(map[string]interface{})
+		if genCfg["temperature"] != 0.0 || genCfg["maxOutputTokens"] != 1234.0 {
+			t.Errorf("request = %s, want generationConfig temperature 0 and maxOutputTokens 1234", rc.Body)
+		}
+	})
+
+	t.Run("ollama", func(t *testing.T) {
+		rc := newOllamaCaptureServer(t, "ok")
+		defer rc.Server.Close()
+		p, _ := NewOllamaProvider(gen(ProviderConfig{Endpoint: rc.Server.URL}))
+		if _, err := p.AskNonStreaming(context.Background(), "hi"); err != nil {
+			t.Fatalf("AskNonStreaming: %v", err)
+		}
+		options, _ := decodeGenerationBody(t, rc.Body)["options"].(map[string]interface{})
+		if options["temperature"] != 0.0 || options["num_predict"] != 1234.0 {
+			t.Errorf("request = %s, want options temperature 0 and num_predict 1234", rc.Body)
+		}
+	})
+
+	t.Run("bedrock", func(t *testing.T) {
+		t.Setenv("AWS_ACCESS_KEY_ID", "AKIATEST123")
+		t.Setenv("AWS_SECRET_ACCESS_KEY", "testsecret456")
+		p, err := NewBedrockProvider(gen(ProviderConfig{Model: "anthropic.claude-3-sonnet-20240229-v1:0", Region: "us-east-1"}))
+		if err != nil {
+			t.Fatalf("NewBedrockProvider: %v", err)
+		}
+		var captured []byte
+		p.(*BedrockProvider).httpClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			captured, _ = io.ReadAll(r.Body)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(bytes.NewBufferString(`{"content":[{"text":"ok"}],"stop_reason":"end_turn"}`)),
+			}, nil
+		})}
+		if _, err := p.AskNonStreaming(context.Background(), "hi"); err != nil {
+			t.Fatalf("AskNonStreaming: %v", err)
+		}
+		body := decodeGenerationBody(t, captured)
+		if body["temperature"] != 0.0 || body["max_tokens"] != 1234.0 {
+			t.Errorf("request = %s, want temperature 0 and max_tokens 1234", captured)
+		}
+	})
+}
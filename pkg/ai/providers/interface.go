@@ -24,6 +24,9 @@ type Provider interface {
 
 	// ListModels returns available models for this provider (optional)
 	ListModels(ctx context.Context) ([]string, error)
+
+	// Capabilities reports the features supported with the current model
+	Capabilities() Capabilities
 }
 
 // ToolProvider extends Provider with tool/function calling support
@@ -141,11 +144,20 @@ type ProviderConfig struct {
 	Model           string `yaml:"model" json:"model"`
 	Endpoint        string `yaml:"endpoint" json:"endpoint"`
 	APIKey          string `yaml:"api_key" json:"api_key"`
-	Region          string `yaml:"region" json:"region"`                     // For AWS Bedrock
+	Region          string `yaml:"region" json:"region"`                     // For AWS Bedrock; the location for Vertex AI
+	Project         string `yaml:"project" json:"project"`                   // For Vertex AI
 	AzureDeployment string `yaml:"azure_deployment" json:"azure_deployment"` // For Azure OpenAI
 	SkipTLSVerify   bool   `yaml:"skip_tls_verify" json:"skip_tls_verify"`
 	ReasoningEffort string `yaml:"reasoning_effort" json:"reasoning_effort"` // For Solar Pro2: "minimal" or "high"
 	MaxIterations   int    `yaml:"max_iterations" json:"max_iterations"`
+	LogPayloads     bool   `yaml:"log_payloads" json:"log_payloads"` // Log redacted request/response bodies at debug level
+	KeepAlive       string `yaml:"keep_alive" json:"keep_alive"`     // For Ollama: how long the model stays loaded, e.g. "30m" or "-1"
+	// Temperature is sent with every request when set; nil keeps the
+	// provider's default. OpenAI reasoning models never receive it.
+	Temperature *float64 `yaml:"temperature,omitempty" json:"temperature,omitempty"`
+	// MaxTokens caps the output tokens of each request; 0 keeps the
+	// provider's default
+	MaxTokens int `yaml:"max_tokens" json:"max_tokens"`
 	// Discovery indicates this provider is created only for model discovery (ListModels).
 	// Providers may use this to skip strict model validation or expensive setup.
 	Discovery bool `yaml:"-" json:"-"`
@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // OllamaProvider implements the Provider and ToolProvider interfaces for Ollama (local LLM)
@@ -19,10 +21,30 @@ type OllamaProvider struct {
 }
 
 type ollamaChatRequest struct {
-	Model    string           `json:"model"`
-	Messages []ChatMessage    `json:"messages"`
-	Stream   bool             `json:"stream"`
-	Tools    []ToolDefinition `json:"tools,omitempty"`
+	Model     string           `json:"model"`
+	Messages  []ollamaMessage  `json:"messages"`
+	Stream    bool             `json:"stream"`
+	Tools     []ToolDefinition `json:"tools,omitempty"`
+	KeepAlive json.RawMessage  `json:"keep_alive,omitempty"`
+	Options   *ollamaOptions   `json:"options,omitempty"`
+}
+
+// ollamaOptions carries the configured generation parameters; num_predict
+// is Ollama's output token cap
+type ollamaOptions struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	NumPredict  int      `json:"num_predict,omitempty"`
+}
+
+// ollamaMessage is a native /api/chat message. Tool results carry the name
+// of the tool they answer in tool_name, since Ollama matches results by name
+// and older versions return tool calls without IDs.
+type ollamaMessage struct {
+	Role       string     `json:"role"`
+	Content    string     `json:"content,omitempty"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+	ToolName   string     `json:"tool_name,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
 }
 
 type ollamaChatResponse struct {
@@ -54,17 +76,55 @@ func NewOllamaProvider(cfg *ProviderConfig) (Provider, error) {
 		model = "gpt-oss:20b" // Default Ollama model
 	}
 
+	if _, err := ollamaKeepAlive(cfg.KeepAlive); err != nil {
+		return nil, err
+	}
+
 	providerCfg := *cfg
 	providerCfg.Model = model
 	providerCfg.Endpoint = endpoint
 
 	return &OllamaProvider{
 		config:     &providerCfg,
-		httpClient: newHTTPClient(cfg.SkipTLSVerify),
+		httpClient: newProviderHTTPClient(cfg),
 		endpoint:   endpoint,
 	}, nil
 }
 
+// ollamaKeepAlive converts the keep_alive setting to Ollama's format. A bare
+// number of seconds such as "-1" (keep loaded) or "0" (unload at once) is
+// sent as a number and a duration such as "30m" as a string.
+func ollamaKeepAlive(v string) (json.RawMessage, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil, nil
+	}
+	if _, err := strconv.Atoi(v); err == nil {
+		return json.RawMessage(v), nil
+	}
+	if _, err := time.ParseDuration(v); err != nil {
+		return nil, fmt.Errorf("invalid Ollama keep_alive %q: use seconds (e.g. -1) or a duration (e.g. 30m)", v)
+	}
+	return json.Marshal(v)
+}
+
+// chatRequest builds an /api/chat request for the configured model
+func (p *OllamaProvider) chatRequest(messages []ollamaMessage, stream bool, tools []ToolDefinition) ollamaChatRequest {
+	// Validated in NewOllamaProvider
+	keepAlive, _ := ollamaKeepAlive(p.config.KeepAlive)
+	req := ollamaChatRequest{
+		Model:     p.config.Model,
+		Messages:  messages,
+		Stream:    stream,
+		Tools:     tools,
+		KeepAlive: keepAlive,
+	}
+	if p.config.Temperature != nil || p.config.MaxTokens > 0 {
+		req.Options = &ollamaOptions{Temperature: p.config.Temperature, NumPredict: effectiveMaxTokens(p.config, 0)}
+	}
+	return req
+}
+
 func (p *OllamaProvider) Name() string {
 	return "ollama"
 }
@@ -73,6 +133,10 @@ func (p *OllamaProvider) GetModel() string {
 	return p.config.Model
 }
 
+func (p *OllamaProvider) Capabilities() Capabilities {
+	return CapabilitiesFor(p.Name(), p.GetModel())
+}
+
 func (p *OllamaProvider) IsReady() bool {
 	return p.config != nil && p.endpoint != ""
 }
@@ -80,14 +144,10 @@ func (p *OllamaProvider) IsReady() bool {
 func (p *OllamaProvider) Ask(ctx context.Context, prompt string, callback func(string)) error {
 	endpoint := p.endpoint + "/api/chat"
 
-	reqBody := ollamaChatRequest{
-		Model: p.config.Model,
-		Messages: []ChatMessage{
-			{Role: "system", Content: ollamaSystemPrompt},
-			{Role: "user", Content: prompt},
-		},
-		Stream: true,
-	}
+	reqBody := p.chatRequest([]ollamaMessage{
+		{Role: "system", Content: ollamaSystemPrompt},
+		{Role: "user", Content: prompt},
+	}, true, nil)
 
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
@@ -142,14 +202,10 @@ func (p *OllamaProvider) Ask(ctx context.Context, prompt string, callback func(s
 func (p *OllamaProvider) AskNonStreaming(ctx context.Context, prompt string) (string, error) {
 	endpoint := p.endpoint + "/api/chat"
 
-	reqBody := ollamaChatRequest{
-		Model: p.config.Model,
-		Messages: []ChatMessage{
-			{Role: "system", Content: ollamaSystemPrompt},
-			{Role: "user", Content: prompt},
-		},
-		Stream: false,
-	}
+	reqBody := p.chatRequest([]ollamaMessage{
+		{Role: "system", Content: ollamaSystemPrompt},
+		{Role: "user", Content: prompt},
+	}, false, nil)
 
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
@@ -216,25 +272,21 @@ func (p *OllamaProvider) ListModels(ctx context.Context) ([]string, error) {
 	return models, nil
 }
 
-// AskWithTools implements ToolProvider for Ollama using the /api/chat endpoint with tools.
-// Ollama supports OpenAI-compatible tool calling since v0.3.0.
+// AskWithTools implements ToolProvider for Ollama using native tool calling:
+// the /api/chat tools field and message.tool_calls in responses (Ollama v0.3.0+).
+// Tool results are sent back as tool messages until the model answers in text.
 func (p *OllamaProvider) AskWithTools(ctx context.Context, prompt string, tools []ToolDefinition, callback func(string), toolCallback ToolCallback) error {
 	endpoint := p.endpoint + "/api/chat"
 	tools = sortedToolDefinitions(tools)
 	maxIterations := effectiveMaxIterations(p.config)
 
-	messages := []ChatMessage{
+	messages := []ollamaMessage{
 		{Role: "system", Content: toolAgentSystemPrompt(maxIterations)},
 		{Role: "user", Content: prompt},
 	}
 
 	for i := 0; i < maxIterations; i++ {
-		reqBody := ollamaChatRequest{
-			Model:    p.config.Model,
-			Messages: messages,
-			Stream:   false,
-			Tools:    tools,
-		}
+		reqBody := p.chatRequest(messages, false, tools)
 
 		jsonBody, err := json.Marshal(reqBody)
 		if err != nil {
@@ -277,8 +329,18 @@ func (p *OllamaProvider) AskWithTools(ctx context.Context, prompt string, tools
 			return nil
 		}
 
+		// Ollama may omit call IDs; assign them so results can be matched
+		for j := range toolCalls {
+			if toolCalls[j].ID == "" {
+				toolCalls[j].ID = fmt.Sprintf("ollama_%d_%d", i, j)
+			}
+			if toolCalls[j].Type == "" {
+				toolCalls[j].Type = "function"
+			}
+		}
+
 		// Add assistant message with tool calls to history
-		messages = append(messages, ChatMessage{
+		messages = append(messages, ollamaMessage{
 			Role:      "assistant",
 			Content:   content,
 			ToolCalls: toolCalls,
@@ -292,9 +354,10 @@ func (p *OllamaProvider) AskWithTools(ctx context.Context, prompt string, tools
 
 			result := toolCallback(tc)
 
-			messages = append(messages, ChatMessage{
+			messages = append(messages, ollamaMessage{
 				Role:       "tool",
 				Content:    result.Content,
+				ToolName:   tc.Function.Name,
 				ToolCallID: tc.ID,
 			})
 
@@ -312,5 +375,5 @@ func (p *OllamaProvider) AskWithTools(ctx context.Context, prompt string, tools
 		}
 	}
 
-	return fmt.Errorf("exceeded maximum tool call iterations (%d)", maxIterations)
+	return maxIterationsError(maxIterations)
 }
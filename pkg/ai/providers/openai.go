@@ -26,6 +26,7 @@ type openAIChatRequest struct {
 	Stream          bool             `json:"stream"`
 	Tools           []ToolDefinition `json:"tools,omitempty"`
 	ReasoningEffort string           `json:"reasoning_effort,omitempty"` // For Solar Pro2: "minimal" or "high"
+	openAIGenerationParams
 }
 
 type openAIChatResponse struct {
@@ -94,7 +95,7 @@ func NewOpenAIProvider(cfg *ProviderConfig) (Provider, error) {
 
 	return &OpenAIProvider{
 		config:     cfg,
-		httpClient: newHTTPClient(cfg.SkipTLSVerify),
+		httpClient: newProviderHTTPClient(cfg),
 		endpoint:   endpoint,
 	}, nil
 }
@@ -107,6 +108,10 @@ func (p *OpenAIProvider) GetModel() string {
 	return p.config.Model
 }
 
+func (p *OpenAIProvider) Capabilities() Capabilities {
+	return CapabilitiesFor(p.Name(), p.GetModel())
+}
+
 func (p *OpenAIProvider) IsReady() bool {
 	return p.config != nil && p.config.APIKey != ""
 }
@@ -120,8 +125,9 @@ func (p *OpenAIProvider) Ask(ctx context.Context, prompt string, callback func(s
 			{Role: "system", Content: "You are a helpful Kubernetes assistant. Help users manage Kubernetes clusters using natural language. When users ask to create resources, generate the appropriate kubectl commands."},
 			{Role: "user", Content: prompt},
 		},
-		Stream:          true,
-		ReasoningEffort: reasoningEffortForModel(p.config.Model, p.config.ReasoningEffort),
+		Stream:                 true,
+		ReasoningEffort:        reasoningEffortForModel(p.config.Model, p.config.ReasoningEffort),
+		openAIGenerationParams: openAIGeneration(p.config, p.config.Model),
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -192,8 +198,9 @@ func (p *OpenAIProvider) AskNonStreaming(ctx context.Context, prompt string) (st
 			{Role: "system", Content: "You are a helpful Kubernetes assistant. Help users manage Kubernetes clusters using natural language. When users ask to create resources, generate the appropriate kubectl commands."},
 			{Role: "user", Content: prompt},
 		},
-		Stream:          false,
-		ReasoningEffort: reasoningEffortForModel(p.config.Model, p.config.ReasoningEffort),
+		Stream:                 false,
+		ReasoningEffort:        reasoningEffortForModel(p.config.Model, p.config.ReasoningEffort),
+		openAIGenerationParams: openAIGeneration(p.config, p.config.Model),
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -294,11 +301,12 @@ func (p *OpenAIProvider) tryNativeToolCalling(ctx context.Context, endpoint, pro
 	toolCallMade := false
 	for i := 0; i < maxIterations; i++ {
 		reqBody := openAIChatRequest{
-			Model:           p.config.Model,
-			Messages:        messages,
-			Stream:          false, // Non-streaming for first request to detect tool support
-			Tools:           tools,
-			ReasoningEffort: reasoningEffortForModel(p.config.Model, p.config.ReasoningEffort),
+			Model:                  p.config.Model,
+			Messages:               messages,
+			Stream:                 false, // Non-streaming for first request to detect tool support
+			Tools:                  tools,
+			ReasoningEffort:        reasoningEffortForModel(p.config.Model, p.config.ReasoningEffort),
+			openAIGenerationParams: openAIGeneration(p.config, p.config.Model),
 		}
 
 		jsonBody, err := json.Marshal(reqBody)
@@ -435,10 +443,11 @@ func (p *OpenAIProvider) streamFinalResponse(ctx context.Context, endpoint strin
 	})
 
 	reqBody := openAIChatRequest{
-		Model:           p.config.Model,
-		Messages:        finalMessages,
-		Stream:          true,
-		ReasoningEffort: reasoningEffortForModel(p.config.Model, p.config.ReasoningEffort),
+		Model:                  p.config.Model,
+		Messages:               finalMessages,
+		Stream:                 true,
+		ReasoningEffort:        reasoningEffortForModel(p.config.Model, p.config.ReasoningEffort),
+		openAIGenerationParams: openAIGeneration(p.config, p.config.Model),
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -557,10 +566,11 @@ func (p *OpenAIProvider) askWithToolsShim(ctx context.Context, endpoint, prompt
 	for i := 0; i < maxIterations; i++ {
 		// Request without tools (using ReAct prompting instead)
 		reqBody := openAIChatRequest{
-			Model:           p.config.Model,
-			Messages:        messages,
-			Stream:          false, // Non-streaming for easier parsing
-			ReasoningEffort: reasoningEffortForModel(p.config.Model, p.config.ReasoningEffort),
+			Model:                  p.config.Model,
+			Messages:               messages,
+			Stream:                 false, // Non-streaming for easier parsing
+			ReasoningEffort:        reasoningEffortForModel(p.config.Model, p.config.ReasoningEffort),
+			openAIGenerationParams: openAIGeneration(p.config, p.config.Model),
 		}
 
 		jsonBody, err := json.Marshal(reqBody)
@@ -692,5 +702,5 @@ func (p *OpenAIProvider) askWithToolsShim(ctx context.Context, endpoint, prompt
 		}
 	}
 
-	return fmt.Errorf("exceeded maximum iterations (%d)", maxIterations)
+	return maxIterationsError(maxIterations)
 }
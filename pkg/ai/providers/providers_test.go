@@ -3,6 +3,7 @@ package providers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -855,7 +856,7 @@ func TestOllamaProvider_AskWithTools_RespectsConfiguredMaxIterations(t *testing.
 	if err == nil {
 		t.Fatal("expected iteration limit error, got nil")
 	}
-	if !strings.Contains(err.Error(), "exceeded maximum tool call iterations (2)") {
+	if !errors.Is(err, ErrMaxIterations) || !strings.Contains(err.Error(), "exceeded maximum tool call iterations (2)") {
 		t.Fatalf("error = %v, want configured iteration limit", err)
 	}
 	if got := atomic.LoadInt32(&callCount); got != 2 {
@@ -2453,6 +2454,131 @@ func TestOllamaProvider_AskWithTools_WithFunctionCall(t *testing.T) {
 	}
 }
 
+func TestOllamaProvider_AskWithTools_NativeMultiTurn(t *testing.T) {
+	// Native Ollama responses carry tool calls without IDs or types, and
+	// arguments as objects
+	responses := []string{
+		`{"message":{"role":"assistant","content":"","tool_calls":[{"function":{"index":0,"name":"kubectl","arguments":{"command":"kubectl get pods"}}},{"function":{"index":1,"name":"kubectl","arguments":{"command":"kubectl get nodes"}}}]},"done":true}`,
+		`{"message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"kubectl","arguments":{"command":"kubectl describe pod web"}}}]},"done":true}`,
+		`{"message":{"role":"assistant","content":"Pod web is crash looping."},"done":true}`,
+	}
+	var requests []ollamaChatRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaChatRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(responses[len(requests)-1]))
+	}))
+	defer srv.Close()
+
+	p, _ := NewOllamaProvider(&ProviderConfig{
+		Provider:  "ollama",
+		Model:     "qwen3",
+		Endpoint:  srv.URL,
+		KeepAlive: "30m",
+	})
+
+	var calls []ToolCall
+	var callbackContent string
+	err := p.(ToolProvider).AskWithTools(
+		context.Background(),
+		"why is web failing",
+		[]ToolDefinition{{
+			Type: "function",
+			Function: FunctionDef{
+				Name:        "kubectl",
+				Description: "Execute kubectl commands",
+				Parameters:  map[string]interface{}{"type": "object"},
+			},
+		}},
+		func(s string) { callbackContent += s },
+		func(call ToolCall) ToolResult {
+			calls = append(calls, call)
+			return ToolResult{ToolCallID: call.ID, Content: "result " + call.ID}
+		},
+	)
+	if err != nil {
+		t.Fatalf("AskWithTools: %v", err)
+	}
+	if len(requests) != 3 || len(calls) != 3 {
+		t.Fatalf("requests = %d, tool calls = %d; want 3 and 3", len(requests), len(calls))
+	}
+	if !strings.Contains(callbackContent, "Pod web is crash looping.") {
+		t.Errorf("callback should contain final answer, got %q", callbackContent)
+	}
+
+	ids := map[string]bool{}
+	for _, call := range calls {
+		if call.ID == "" || call.Type != "function" || ids[call.ID] {
+			t.Errorf("tool call %+v should get a unique ID and type", call)
+		}
+		ids[call.ID] = true
+	}
+	if calls[1].Function.Arguments != `{"command":"kubectl get nodes"}` {
+		t.Errorf("second call arguments = %q", calls[1].Function.Arguments)
+	}
+
+	for i, req := range requests {
+		if len(req.Tools) != 1 || req.Stream {
+			t.Errorf("request %d: tools = %d, stream = %v", i, len(req.Tools), req.Stream)
+		}
+		if string(req.KeepAlive) != `"30m"` {
+			t.Errorf("request %d: keep_alive = %s, want \"30m\"", i, req.KeepAlive)
+		}
+	}
+
+	// The last request replays both turns: each assistant tool call is
+	// followed by a tool message naming the tool it answers
+	msgs := requests[2].Messages
+	if len(msgs) != 7 {
+		t.Fatalf("final request has %d messages, want 7: %+v", len(msgs), msgs)
+	}
+	for i, want := range []string{"system", "user", "assistant", "tool", "tool", "assistant", "tool"} {
+		if msgs[i].Role != want {
+			t.Errorf("message %d role = %q, want %q", i, msgs[i].Role, want)
+		}
+	}
+	if m := msgs[4]; m.ToolName != "kubectl" || m.ToolCallID != calls[1].ID || m.Content != "result "+calls[1].ID {
+		t.Errorf("tool result message = %+v", m)
+	}
+}
+
+func TestOllamaProvider_KeepAlive(t *testing.T) {
+	rc := newOllamaCaptureServer(t, "ok")
+	defer rc.Server.Close()
+
+	tests := []struct {
+		keepAlive string
+		want      string
+	}{
+		{"", ""},
+		{"-1", "-1"},
+		{"0", "0"},
+		{"1h", `"1h"`},
+	}
+	for _, tt := range tests {
+		p, err := NewOllamaProvider(&ProviderConfig{Provider: "ollama", Endpoint: rc.Server.URL, KeepAlive: tt.keepAlive})
+		if err != nil {
+			t.Fatalf("NewOllamaProvider(%q): %v", tt.keepAlive, err)
+		}
+		if _, err := p.AskNonStreaming(context.Background(), "hi"); err != nil {
+			t.Fatalf("AskNonStreaming: %v", err)
+		}
+		var reqBody ollamaChatRequest
+		if err := json.Unmarshal(rc.Body, &reqBody); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if string(reqBody.KeepAlive) != tt.want {
+			t.Errorf("keep_alive %q sent as %s, want %s", tt.keepAlive, reqBody.KeepAlive, tt.want)
+		}
+	}
+
+	if _, err := NewOllamaProvider(&ProviderConfig{Provider: "ollama", KeepAlive: "forever"}); err == nil {
+		t.Error("expected an error for an invalid keep_alive")
+	}
+}
+
 func TestAzureOpenAIProvider_AskWithTools_WithFunctionCall(t *testing.T) {
 	var callCount int32
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
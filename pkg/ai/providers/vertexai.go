@@ -0,0 +1,138 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// vertexAIScope is the OAuth scope Vertex AI requests are authorized with
+const vertexAIScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// VertexAIProvider implements the Provider and ToolProvider interfaces for
+// Gemini models on Vertex AI. Requests and responses use the Gemini API
+// shapes; only the endpoint and the credentials differ.
+type VertexAIProvider struct {
+	*GeminiProvider
+	project  string
+	location string
+
+	tokenMu     sync.Mutex
+	tokenSource oauth2.TokenSource // Found on first use; nil until credentials are found
+}
+
+// NewVertexAIProvider creates a new Vertex AI provider. The project and
+// location come from the config or GOOGLE_CLOUD_PROJECT and
+// GOOGLE_CLOUD_LOCATION. An api_key is sent as an OAuth access token;
+// without one the Google Cloud credential chain is used.
+func NewVertexAIProvider(cfg *ProviderConfig) (Provider, error) {
+	project := cfg.Project
+	if project == "" && !cfg.Discovery {
+		return nil, fmt.Errorf("vertexai provider requires a Google Cloud project (set project or GOOGLE_CLOUD_PROJECT)")
+	}
+
+	location := cfg.Region
+	if location == "" {
+		location = "us-central1"
+	}
+
+	base := cfg.Endpoint
+	if base == "" {
+		base = vertexAIEndpoint(location)
+	}
+	base = strings.TrimSuffix(base, "/")
+
+	model := cfg.Model
+	if model == "" {
+		model = "gemini-2.5-flash"
+	}
+	if !cfg.Discovery {
+		if err := validateGeminiModel(model); err != nil {
+			return nil, err
+		}
+	}
+
+	providerCfg := *cfg
+	providerCfg.Model = model
+	providerCfg.Endpoint = base
+	providerCfg.Region = location
+
+	p := &VertexAIProvider{
+		GeminiProvider: &GeminiProvider{
+			config:     &providerCfg,
+			httpClient: newProviderHTTPClient(cfg),
+			// Gemini appends /models/{model}:{method} to the endpoint
+			endpoint: fmt.Sprintf("%s/projects/%s/locations/%s/publishers/google", base, project, location),
+		},
+		project:  project,
+		location: location,
+	}
+	if cfg.APIKey != "" {
+		p.tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: cfg.APIKey})
+	}
+	p.authorize = p.setBearerToken
+	return p, nil
+}
+
+// vertexAIEndpoint returns the regional Vertex AI API endpoint for location
+func vertexAIEndpoint(location string) string {
+	if location == "global" {
+		return "https://aiplatform.googleapis.com/v1"
+	}
+	return fmt.Sprintf("https://%s-aiplatform.googleapis.com/v1", location)
+}
+
+func (p *VertexAIProvider) Name() string {
+	return "vertexai"
+}
+
+func (p *VertexAIProvider) Capabilities() Capabilities {
+	return CapabilitiesFor(p.Name(), p.GetModel())
+}
+
+// IsReady reports whether a project is set; credentials are looked up on the
+// first request, and a missing credential fails that request
+func (p *VertexAIProvider) IsReady() bool {
+	return p.project != ""
+}
+
+// ListModels returns known Gemini models on Vertex AI
+func (p *VertexAIProvider) ListModels(ctx context.Context) ([]string, error) {
+	return []string{
+		"gemini-2.5-pro",
+		"gemini-2.5-flash",
+		"gemini-2.5-flash-lite",
+		"gemini-2.0-flash",
+		"gemini-2.0-flash-lite",
+	}, nil
+}
+
+// setBearerToken authorizes a request with an OAuth access token from the
+// credential chain: GOOGLE_APPLICATION_CREDENTIALS, the gcloud application
+// default credentials, then the metadata server on Google Cloud. Only found
+// credentials are kept, so a request after a login retries the chain.
+func (p *VertexAIProvider) setBearerToken(req *http.Request) error {
+	p.tokenMu.Lock()
+	if p.tokenSource == nil {
+		creds, err := google.FindDefaultCredentials(context.Background(), vertexAIScope)
+		if err != nil {
+			p.tokenMu.Unlock()
+			return fmt.Errorf("no Google Cloud credentials found (run 'gcloud auth application-default login' or set GOOGLE_APPLICATION_CREDENTIALS): %w", err)
+		}
+		p.tokenSource = creds.TokenSource
+	}
+	tokenSource := p.tokenSource
+	p.tokenMu.Unlock()
+
+	token, err := tokenSource.Token()
+	if err != nil {
+		return fmt.Errorf("failed to get Google Cloud access token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	return nil
+}
@@ -0,0 +1,207 @@
+package providers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewVertexAIProvider(t *testing.T) {
+	if _, err := NewVertexAIProvider(&ProviderConfig{Provider: "vertexai"}); err == nil {
+		t.Error("expected an error without a project")
+	}
+
+	p, err := NewVertexAIProvider(&ProviderConfig{Provider: "vertexai", Project: "my-proj", APIKey: "token"})
+	if err != nil {
+		t.Fatalf("NewVertexAIProvider: %v", err)
+	}
+	vp := p.(*VertexAIProvider)
+	want := "https://us-central1-aiplatform.googleapis.com/v1/projects/my-proj/locations/us-central1/publishers/google"
+	if vp.endpoint != want {
+		t.Errorf("endpoint = %q, want %q", vp.endpoint, want)
+	}
+	if p.Name() != "vertexai" || p.GetModel() != "gemini-2.5-flash" || !p.IsReady() {
+		t.Errorf("provider = %s/%s ready=%v", p.Name(), p.GetModel(), p.IsReady())
+	}
+	if !p.Capabilities().Tools {
+		t.Error("Vertex AI should support tools")
+	}
+
+	if got := vertexAIEndpoint("global"); got != "https://aiplatform.googleapis.com/v1" {
+		t.Errorf("global endpoint = %q", got)
+	}
+}
+
+func TestVertexAIProvider_EnvFallbacks(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "env-proj")
+	t.Setenv("GOOGLE_CLOUD_LOCATION", "asia-northeast3")
+
+	p, err := GetFactory().Create(&ProviderConfig{Provider: "vertexai", Model: "gemini-2.5-pro", APIKey: "token"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	vp := p.(*VertexAIProvider)
+	if vp.project != "env-proj" || vp.location != "asia-northeast3" {
+		t.Errorf("project/location = %s/%s, want env-proj/asia-northeast3", vp.project, vp.location)
+	}
+}
+
+func TestVertexAIProvider_RequestBuilding(t *testing.T) {
+	rc := newGeminiCaptureServer(t, "mock vertex answer")
+	defer rc.Server.Close()
+
+	p, err := NewVertexAIProvider(&ProviderConfig{
+		Provider: "vertexai",
+		Model:    "gemini-2.5-flash",
+		Project:  "my-proj",
+		Region:   "europe-west4",
+		APIKey:   "ya29.test-token",
+		Endpoint: rc.Server.URL + "/v1",
+	})
+	if err != nil {
+		t.Fatalf("NewVertexAIProvider: %v", err)
+	}
+
+	resp, err := p.AskNonStreaming(context.Background(), "show deployment drift")
+	if err != nil {
+		t.Fatalf("AskNonStreaming: %v", err)
+	}
+	if resp != "mock vertex answer" {
+		t.Fatalf("response = %q, want %q", resp, "mock vertex answer")
+	}
+
+	wantPath := "/v1/projects/my-proj/locations/europe-west4/publishers/google/models/gemini-2.5-flash:generateContent"
+	if rc.Path != wantPath {
+		t.Errorf("request path = %q, want %q", rc.Path, wantPath)
+	}
+	if got := rc.Headers.Get("Authorization"); got != "Bearer ya29.test-token" {
+		t.Errorf("Authorization = %q, want the bearer token", got)
+	}
+	if got := rc.Headers.Get("x-goog-api-key"); got != "" {
+		t.Errorf("x-goog-api-key = %q, want none", got)
+	}
+
+	var reqBody geminiRequest
+	if err := json.Unmarshal(rc.Body, &reqBody); err != nil {
+		t.Fatalf("failed to decode request: %v", err)
+	}
+	if len(reqBody.Contents) != 1 || reqBody.Contents[0].Parts[0].Text != "show deployment drift" {
+		t.Errorf("contents = %+v", reqBody.Contents)
+	}
+}
+
+func TestVertexAIProvider_AskWithTools(t *testing.T) {
+	var requests []geminiRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req geminiRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+
+		part := geminiPart{Text: "2 pods are running"}
+		if len(requests) == 1 {
+			part = geminiPart{FunctionCall: &geminiFuncCall{Name: "kubectl", Args: map[string]interface{}{"command": "get pods"}}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"candidates": []interface{}{
+				map[string]interface{}{"content": map[string]interface{}{"role": "model", "parts": []geminiPart{part}}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	p, err := NewVertexAIProvider(&ProviderConfig{Provider: "vertexai", Project: "my-proj", APIKey: "token", Endpoint: server.URL})
+	if err != nil {
+		t.Fatalf("NewVertexAIProvider: %v", err)
+	}
+
+	var called []string
+	var output strings.Builder
+	tools := []ToolDefinition{{Type: "function", Function: FunctionDef{Name: "kubectl", Description: "Run kubectl", Parameters: map[string]interface{}{"type": "object"}}}}
+	err = p.(ToolProvider).AskWithTools(context.Background(), "how many pods?", tools,
+		func(s string) { output.WriteString(s) },
+		func(call ToolCall) ToolResult {
+			called = append(called, call.Function.Arguments)
+			return ToolResult{ToolCallID: call.ID, Content: "pod-a\npod-b"}
+		})
+	if err != nil {
+		t.Fatalf("AskWithTools: %v", err)
+	}
+
+	if len(called) != 1 || !strings.Contains(called[0], "get pods") {
+		t.Errorf("tool calls = %v", called)
+	}
+	if !strings.Contains(output.String(), "2 pods are running") {
+		t.Errorf("output = %q", output.String())
+	}
+	if len(requests) != 2 || len(requests[0].Tools) != 1 {
+		t.Fatalf("requests = %+v", requests)
+	}
+	last := requests[1].Contents[len(requests[1].Contents)-1]
+	if len(last.Parts) != 1 || last.Parts[0].FunctionResponse == nil || last.Parts[0].FunctionResponse.Response["result"] != "pod-a\npod-b" {
+		t.Errorf("function response = %+v", last)
+	}
+}
+
+func TestVertexAIProvider_CredentialChain(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "jwt-bearer") {
+			t.Errorf("token request = %s, want a JWT bearer grant", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"sa-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	credentials, _ := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "my-proj",
+		"private_key_id": "key-1",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"client_email":   "k13d@my-proj.iam.gserviceaccount.com",
+		"token_uri":      tokenServer.URL,
+	})
+	path := filepath.Join(t.TempDir(), "sa.json")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", path)
+
+	rc := newGeminiCaptureServer(t, "ok")
+	defer rc.Server.Close()
+
+	p, err := NewVertexAIProvider(&ProviderConfig{Provider: "vertexai", Project: "my-proj", Endpoint: rc.Server.URL})
+	if err != nil {
+		t.Fatalf("NewVertexAIProvider: %v", err)
+	}
+
+	// Missing credentials fail the request but are looked up again on the next
+	if _, err := p.AskNonStreaming(context.Background(), "hello"); err == nil {
+		t.Fatal("AskNonStreaming without credentials should fail")
+	}
+	if err := os.WriteFile(path, credentials, 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := p.AskNonStreaming(context.Background(), "hello"); err != nil {
+		t.Fatalf("AskNonStreaming: %v", err)
+	}
+	if got := rc.Headers.Get("Authorization"); got != "Bearer sa-token" {
+		t.Errorf("Authorization = %q, want the service account token", got)
+	}
+}
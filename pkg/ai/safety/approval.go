@@ -0,0 +1,37 @@
+package safety
+
+import "github.com/cloudbro-kube-ai/k13d/pkg/config"
+
+// GateApproval applies an auto-approve mode (config.AutoApproveReads or
+// config.AutoApproveAll) to an allowed tool call. readOnly is whether the call
+// only reads; required is what the rest of the policy decided. With "reads",
+// anything that is not a read asks even when the policy would let it through.
+// Callers must not gate dangerous or interactive calls: those always ask.
+func GateApproval(mode string, readOnly, required bool) bool {
+	switch mode {
+	case config.AutoApproveAll:
+		return false
+	case config.AutoApproveReads:
+		return !readOnly
+	default:
+		return required
+	}
+}
+
+// IsRead reports whether the decision is for a read-only command that the
+// classifier did not also flag as dangerous.
+func (d *Decision) IsRead() bool {
+	if d == nil || d.Category != "read-only" {
+		return false
+	}
+	return d.Classification == nil || !d.Classification.IsDangerous
+}
+
+// alwaysAsks reports whether the decision needs approval whatever the
+// auto-approve mode: dangerous and interactive commands are never run unasked.
+func (d *Decision) alwaysAsks() bool {
+	if d.Category == "dangerous" || d.Category == "interactive" {
+		return true
+	}
+	return d.Classification != nil && d.Classification.IsDangerous
+}
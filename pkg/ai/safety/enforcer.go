@@ -80,6 +80,13 @@ func (e *PolicyEnforcer) Evaluate(command string) *Decision {
 		}
 	}
 
+	// Verb and resource restrictions block regardless of approval
+	if err := CheckToolRestrictions(e.policy, command); err != nil {
+		decision.Allowed = false
+		decision.BlockReason = err.Error()
+		return decision
+	}
+
 	// Apply policy based on category
 	switch classification.Category {
 	case "read-only":
@@ -111,6 +118,10 @@ func (e *PolicyEnforcer) Evaluate(command string) *Decision {
 		decision.RequiresApproval = e.policy.RequireApprovalForUnknown
 	}
 
+	if decision.Allowed && !decision.alwaysAsks() {
+		decision.RequiresApproval = GateApproval(e.policy.AutoApprove, decision.IsRead(), decision.RequiresApproval)
+	}
+
 	return decision
 }
 
@@ -198,6 +198,40 @@ func TestPolicyEnforcer_RequireApprovalForAll(t *testing.T) {
 	}
 }
 
+func TestPolicyEnforcer_AutoApproveModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   config.ToolApprovalPolicy
+		command  string
+		approval bool
+	}{
+		{"reads mode runs reads", config.ToolApprovalPolicy{AutoApprove: config.AutoApproveReads}, "kubectl get pods", false},
+		// Mutating calls ask even when the write flag would skip approval
+		{"reads mode asks for writes", config.ToolApprovalPolicy{AutoApprove: config.AutoApproveReads}, "kubectl scale deploy/web --replicas=3", true},
+		{"reads mode asks for unknown", config.ToolApprovalPolicy{AutoApprove: config.AutoApproveReads}, "./custom-script.sh", true},
+		{"reads mode asks for dangerous", config.ToolApprovalPolicy{AutoApprove: config.AutoApproveReads}, "kubectl delete pods --all", true},
+		{"all mode runs writes", config.ToolApprovalPolicy{AutoApprove: config.AutoApproveAll, RequireApprovalForWrite: true}, "kubectl apply -f x.yaml", false},
+		// Dangerous and interactive commands ask whatever the mode
+		{"all mode asks for dangerous", config.ToolApprovalPolicy{AutoApprove: config.AutoApproveAll}, "kubectl delete namespace prod", true},
+		{"all mode asks for interactive", config.ToolApprovalPolicy{AutoApprove: config.AutoApproveAll}, "kubectl exec -it web -- sh", true},
+		{"no mode keeps the flags", config.ToolApprovalPolicy{RequireApprovalForWrite: true}, "kubectl apply -f x.yaml", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := NewPolicyEnforcer(tt.policy).Evaluate(tt.command)
+			if !decision.Allowed || decision.RequiresApproval != tt.approval {
+				t.Errorf("Evaluate(%q) = allowed %v, approval %v; want approval %v", tt.command, decision.Allowed, decision.RequiresApproval, tt.approval)
+			}
+		})
+	}
+
+	// Blocked commands stay blocked in every mode
+	policy := config.ToolApprovalPolicy{AutoApprove: config.AutoApproveAll, BlockDangerous: true}
+	if decision := NewPolicyEnforcer(policy).Evaluate("kubectl delete pods --all"); decision.Allowed {
+		t.Error("auto_approve all should not unblock a blocked command")
+	}
+}
+
 func TestPolicyEnforcer_GetApprovalTimeout(t *testing.T) {
 	tests := []struct {
 		name            string
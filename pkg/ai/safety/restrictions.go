@@ -0,0 +1,293 @@
+package safety
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai/tools"
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+// ReadOnlyKubectlVerbs are the kubectl verbs the AI may run in safe tools mode
+var ReadOnlyKubectlVerbs = []string{
+	"get", "describe", "logs", "top", "events", "explain",
+	"api-resources", "api-versions", "cluster-info", "version",
+}
+
+// kubectlValueFlags are kubectl flags whose value is the next argument, so it
+// is not mistaken for the verb or resource.
+var kubectlValueFlags = map[string]bool{
+	// Global flags
+	"-n": true, "--namespace": true, "--context": true, "--cluster": true,
+	"--kubeconfig": true, "--user": true, "-s": true, "--server": true,
+	"--as": true, "--as-group": true, "--as-uid": true, "--token": true,
+	"--request-timeout": true, "--cache-dir": true, "--certificate-authority": true,
+	"--client-certificate": true, "--client-key": true, "--tls-server-name": true,
+	"--username": true, "--password": true, "--profile": true, "--profile-output": true,
+	"--log-file": true, "--log-dir": true, "-v": true, "--v": true, "--vmodule": true,
+	// Command flags
+	"-l": true, "--selector": true, "--field-selector": true, "-o": true,
+	"--output": true, "-c": true, "--container": true, "--filename": true,
+	"-k": true, "--kustomize": true, "-L": true, "--label-columns": true,
+	"--sort-by": true, "--template": true, "--chunk-size": true, "--tail": true,
+	"--since": true, "--since-time": true, "--limit-bytes": true,
+	"--max-log-requests": true, "--pod-running-timeout": true, "--replicas": true,
+	"--current-replicas": true, "--image": true, "-e": true, "--env": true,
+	"--restart": true, "--overrides": true, "--timeout": true, "--grace-period": true,
+	"--type": true, "--patch": true, "--patch-file": true, "--port": true,
+	"--target-port": true, "--name": true, "--revision": true, "--to-revision": true,
+	"--for": true, "--address": true, "--min": true, "--max": true,
+	"--cpu-percent": true, "--pod-selector": true, "--subresource": true,
+	"--raw": true, "--field-manager": true, "--resource-version": true,
+	"--api-group": true, "--api-version": true, "--from": true,
+}
+
+// kubectlBoolFlags are kubectl flags that take no separate value. Flags with
+// an optional value, such as --dry-run, only take it in the --flag=value form.
+var kubectlBoolFlags = map[string]bool{
+	// Global flags
+	"--insecure-skip-tls-verify": true, "--match-server-version": true,
+	"--warnings-as-errors": true, "--disable-compression": true,
+	// Command flags
+	"-A": true, "--all-namespaces": true, "--all": true, "-w": true, "--watch": true,
+	"--watch-only": true, "--show-labels": true, "--show-kind": true,
+	"--no-headers": true, "--show-managed-fields": true, "--output-watch-events": true,
+	"--ignore-not-found": true, "-i": true, "--stdin": true, "-t": true, "--tty": true,
+	"-q": true, "--quiet": true, "--follow": true, "--previous": true,
+	"--timestamps": true, "--prefix": true, "--all-containers": true,
+	"--ignore-errors": true, "-R": true, "--recursive": true, "--dry-run": true,
+	"--force": true, "--now": true, "--wait": true, "--cascade": true,
+	"--overwrite": true, "--local": true, "--record": true, "--server-side": true,
+	"--force-conflicts": true, "--validate": true, "--rm": true, "--command": true,
+	"--delete-emptydir-data": true, "--ignore-daemonsets": true,
+	"--disable-eviction": true, "--containers": true, "--use-protocol-buffers": true,
+	"--show-events": true, "--allow-missing-template-keys": true,
+	"--list": true, "-h": true, "--help": true,
+}
+
+// kubectlVerbFlags are short flags whose meaning depends on the verb: -f is
+// --follow for logs and --filename elsewhere, -p is --previous for logs and
+// --patch elsewhere.
+var kubectlVerbFlags = map[string]map[string]bool{
+	"-f": {"logs": false},
+	"-p": {"logs": false},
+}
+
+// resourcelessVerbs are kubectl verbs that do not act on a resource type
+var resourcelessVerbs = map[string]bool{
+	"api-resources": true, "api-versions": true, "cluster-info": true,
+	"version": true, "config": true, "completion": true, "plugin": true, "auth": true,
+}
+
+// podVerbs are kubectl verbs whose target is a pod unless given as type/name
+var podVerbs = map[string]bool{
+	"logs": true, "exec": true, "attach": true, "port-forward": true, "cp": true,
+}
+
+// subcommandVerbs are kubectl verbs followed by a subcommand before the resource
+var subcommandVerbs = map[string]bool{"rollout": true, "set": true}
+
+// resourceAliases maps kubectl short names and singulars to resource names
+var resourceAliases = map[string]string{
+	"po": "pods", "svc": "services", "deploy": "deployments", "rs": "replicasets",
+	"sts": "statefulsets", "ds": "daemonsets", "cj": "cronjobs", "cm": "configmaps",
+	"ns": "namespaces", "no": "nodes", "pv": "persistentvolumes",
+	"pvc": "persistentvolumeclaims", "sa": "serviceaccounts", "ing": "ingresses",
+	"ingress": "ingresses", "ep": "endpoints", "ev": "events",
+	"hpa": "horizontalpodautoscalers", "netpol": "networkpolicies",
+	"sc": "storageclasses", "crd": "customresourcedefinitions",
+	"pdb": "poddisruptionbudgets", "quota": "resourcequotas", "limits": "limitranges",
+}
+
+// CheckToolRestrictions reports whether policy lets the AI run command. It
+// returns nil when allowed and otherwise an error that explains which rule
+// rejected the command. Only kubectl commands are checked against the verb and
+// resource lists; in safe tools mode every other program is rejected.
+func CheckToolRestrictions(policy config.ToolApprovalPolicy, command string) error {
+	if !policy.HasToolRestrictions() {
+		return nil
+	}
+
+	args := strings.Fields(strings.TrimSpace(command))
+	if len(args) == 0 || args[0] != "kubectl" {
+		if policy.SafeTools {
+			return fmt.Errorf("only kubectl commands are allowed in safe tools mode")
+		}
+		return nil
+	}
+
+	verb, resources, err := kubectlTarget(args[1:])
+	if err != nil {
+		return err
+	}
+	if verb == "" {
+		return fmt.Errorf("kubectl command has no verb to check against the tool policy")
+	}
+	if policy.SafeTools && !containsFold(ReadOnlyKubectlVerbs, verb) {
+		return fmt.Errorf("kubectl %s is not allowed in safe tools mode (read-only verbs: %s)", verb, strings.Join(ReadOnlyKubectlVerbs, ", "))
+	}
+	if containsFold(policy.DeniedVerbs, verb) {
+		return fmt.Errorf("kubectl %s is denied by the tool policy", verb)
+	}
+	if len(policy.AllowedVerbs) > 0 && !containsFold(policy.AllowedVerbs, verb) {
+		return fmt.Errorf("kubectl %s is not in the tool policy's allowed verbs (%s)", verb, strings.Join(policy.AllowedVerbs, ", "))
+	}
+
+	if resourcelessVerbs[verb] || (len(policy.AllowedResources) == 0 && len(policy.DeniedResources) == 0) {
+		return nil
+	}
+	if len(resources) == 0 && len(policy.AllowedResources) > 0 {
+		return fmt.Errorf("kubectl %s does not name a resource type, so it cannot be checked against the tool policy's allowed resources", verb)
+	}
+	for _, resource := range resources {
+		if matchesResource(policy.DeniedResources, resource) {
+			return fmt.Errorf("kubectl %s on %s is denied by the tool policy", verb, resource)
+		}
+		if len(policy.AllowedResources) > 0 && !matchesResource(policy.AllowedResources, resource) {
+			return fmt.Errorf("kubectl %s on %s is not in the tool policy's allowed resources (%s)", verb, resource, strings.Join(policy.AllowedResources, ", "))
+		}
+	}
+	return nil
+}
+
+// kubectlTarget extracts the verb and the normalized resource types from
+// kubectl arguments (without the leading "kubectl"). A flag it cannot
+// classify is an error: guessing whether it takes a value would let the verb
+// or resource hide behind it.
+func kubectlTarget(args []string) (string, []string, error) {
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+		verb := ""
+		if len(positional) > 0 {
+			verb = strings.ToLower(positional[0])
+		}
+		takesValue, err := kubectlFlagTakesValue(arg, verb)
+		if err != nil {
+			return "", nil, err
+		}
+		if takesValue {
+			i++
+		}
+	}
+	if len(positional) == 0 {
+		return "", nil, nil
+	}
+
+	verb := strings.ToLower(positional[0])
+	rest := positional[1:]
+	if subcommandVerbs[verb] && len(rest) > 0 {
+		rest = rest[1:]
+	}
+
+	if podVerbs[verb] {
+		if len(rest) > 0 && strings.Contains(rest[0], "/") {
+			return verb, []string{normalizeResource(rest[0])}, nil
+		}
+		return verb, []string{"pods"}, nil
+	}
+	if len(rest) == 0 {
+		return verb, nil, nil
+	}
+
+	var resources []string
+	for _, r := range strings.Split(rest[0], ",") {
+		if r != "" {
+			resources = append(resources, normalizeResource(r))
+		}
+	}
+	return verb, resources, nil
+}
+
+// kubectlFlagTakesValue reports whether flag consumes the next argument as
+// its value. verb is the kubectl verb seen so far, or empty before it.
+func kubectlFlagTakesValue(flag, verb string) (bool, error) {
+	// --flag=value carries its value inline, whatever the flag
+	if strings.Contains(flag, "=") {
+		return false, nil
+	}
+	if kubectlValueFlags[flag] {
+		return true, nil
+	}
+	if kubectlBoolFlags[flag] {
+		return false, nil
+	}
+	if byVerb, ok := kubectlVerbFlags[flag]; ok {
+		if verb == "" {
+			return false, fmt.Errorf("kubectl flag %s before the verb is ambiguous for the tool policy check", flag)
+		}
+		takesValue, known := byVerb[verb]
+		return takesValue || !known, nil
+	}
+	if !strings.HasPrefix(flag, "--") && len(flag) > 2 {
+		// -oyaml carries its value inline
+		short := flag[:2]
+		if kubectlValueFlags[short] || kubectlVerbFlags[short] != nil {
+			return false, nil
+		}
+		// Combined boolean shorthands such as -it
+		combined := true
+		for _, c := range flag[1:] {
+			if !kubectlBoolFlags["-"+string(c)] {
+				combined = false
+				break
+			}
+		}
+		if combined {
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("kubectl flag %s is not known to the tool policy check; use the --flag=value form", flag)
+}
+
+// normalizeResource maps "deploy", "deployment", "deployment.apps", and
+// "deployments/web" all to "deployments".
+func normalizeResource(resource string) string {
+	r := strings.ToLower(resource)
+	r, _, _ = strings.Cut(r, "/")
+	r, _, _ = strings.Cut(r, ".")
+	if alias, ok := resourceAliases[r]; ok {
+		return alias
+	}
+	switch {
+	case strings.HasSuffix(r, "s"):
+		return r
+	case strings.HasSuffix(r, "y"):
+		return strings.TrimSuffix(r, "y") + "ies"
+	default:
+		return r + "s"
+	}
+}
+
+func matchesResource(list []string, resource string) bool {
+	for _, r := range list {
+		if normalizeResource(r) == resource {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToolGuard adapts CheckToolRestrictions for tools.SetCommandGuard. current is
+// called on every tool execution so policy changes apply immediately.
+func ToolGuard(current func() config.ToolApprovalPolicy) tools.CommandGuard {
+	return func(_ tools.ToolType, command string) error {
+		return CheckToolRestrictions(current(), command)
+	}
+}
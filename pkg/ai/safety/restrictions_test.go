@@ -0,0 +1,178 @@
+package safety
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+func TestCheckToolRestrictions(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  config.ToolApprovalPolicy
+		command string
+		wantErr string // empty means allowed
+	}{
+		{
+			name:    "no restrictions allows anything",
+			command: "kubectl delete ns prod",
+		},
+		{
+			name:    "safe tools allows get",
+			policy:  config.ToolApprovalPolicy{SafeTools: true},
+			command: "kubectl -n prod get pods -o wide",
+		},
+		{
+			name:    "safe tools skips namespace flag value when finding the verb",
+			policy:  config.ToolApprovalPolicy{SafeTools: true},
+			command: "kubectl -n delete get pods",
+		},
+		{
+			name:    "safe tools blocks delete",
+			policy:  config.ToolApprovalPolicy{SafeTools: true},
+			command: "kubectl --namespace prod delete pod web-0",
+			wantErr: "kubectl delete is not allowed in safe tools mode",
+		},
+		{
+			name:    "safe tools blocks bash",
+			policy:  config.ToolApprovalPolicy{SafeTools: true},
+			command: "curl -X DELETE https://example.com",
+			wantErr: "only kubectl commands",
+		},
+		{
+			name:    "denied verb",
+			policy:  config.ToolApprovalPolicy{DeniedVerbs: []string{"drain", "Delete"}},
+			command: "kubectl delete pod web-0",
+			wantErr: "kubectl delete is denied",
+		},
+		{
+			name:    "verb outside allowlist",
+			policy:  config.ToolApprovalPolicy{AllowedVerbs: []string{"get", "scale"}},
+			command: "kubectl apply -f deploy.yaml",
+			wantErr: "not in the tool policy's allowed verbs (get, scale)",
+		},
+		{
+			name:    "allowlist does not restrict bash",
+			policy:  config.ToolApprovalPolicy{AllowedVerbs: []string{"get"}},
+			command: "date",
+		},
+		{
+			name:    "denied resource matches short names",
+			policy:  config.ToolApprovalPolicy{DeniedResources: []string{"secret"}},
+			command: "kubectl get secrets,cm -n prod",
+			wantErr: "kubectl get on secrets is denied",
+		},
+		{
+			name:    "allowed resource matches type/name and groups",
+			policy:  config.ToolApprovalPolicy{AllowedResources: []string{"deploy"}},
+			command: "kubectl rollout restart deployments.apps/web",
+		},
+		{
+			name:    "resource outside allowlist",
+			policy:  config.ToolApprovalPolicy{AllowedResources: []string{"pods", "deployments"}},
+			command: "kubectl get nodes",
+			wantErr: "kubectl get on nodes is not in the tool policy's allowed resources",
+		},
+		{
+			name:    "logs targets pods",
+			policy:  config.ToolApprovalPolicy{AllowedResources: []string{"po"}},
+			command: "kubectl logs -c app web-0",
+		},
+		{
+			name:    "unnamed resource fails closed under a resource allowlist",
+			policy:  config.ToolApprovalPolicy{AllowedResources: []string{"pods"}},
+			command: "kubectl apply -f deploy.yaml",
+			wantErr: "does not name a resource type",
+		},
+		{
+			name:    "safe tools skips the --cache-dir value when finding the verb",
+			policy:  config.ToolApprovalPolicy{SafeTools: true},
+			command: "kubectl --cache-dir get delete pod x",
+			wantErr: "kubectl delete is not allowed in safe tools mode",
+		},
+		{
+			name:    "safe tools checks the verb after a global value flag",
+			policy:  config.ToolApprovalPolicy{SafeTools: true},
+			command: "kubectl --tls-server-name get delete ns prod",
+			wantErr: "kubectl delete is not allowed in safe tools mode",
+		},
+		{
+			name:    "denied verb after a global value flag",
+			policy:  config.ToolApprovalPolicy{DeniedVerbs: []string{"delete"}},
+			command: "kubectl --cache-dir /tmp delete pod x",
+			wantErr: "kubectl delete is denied",
+		},
+		{
+			name:    "unknown flag is rejected under restrictions",
+			policy:  config.ToolApprovalPolicy{DeniedVerbs: []string{"delete"}},
+			command: "kubectl --some-new-flag get delete pod x",
+			wantErr: "kubectl flag --some-new-flag is not known",
+		},
+		{
+			name:    "unknown flag in --flag=value form is allowed",
+			policy:  config.ToolApprovalPolicy{SafeTools: true},
+			command: "kubectl --some-new-flag=x get pods",
+		},
+		{
+			name:    "logs -f follows and inline shorthands carry their value",
+			policy:  config.ToolApprovalPolicy{SafeTools: true, DeniedResources: []string{"secrets"}},
+			command: "kubectl logs -f web-0 -nprod --tail 20",
+		},
+		{
+			name:    "apply -f takes a filename",
+			policy:  config.ToolApprovalPolicy{DeniedVerbs: []string{"delete"}},
+			command: "kubectl apply -f delete",
+		},
+		{
+			name:    "verb-dependent flag before the verb is rejected",
+			policy:  config.ToolApprovalPolicy{SafeTools: true},
+			command: "kubectl -p get delete pod x",
+			wantErr: "ambiguous",
+		},
+		{
+			name:    "combined boolean shorthands",
+			policy:  config.ToolApprovalPolicy{DeniedVerbs: []string{"delete"}},
+			command: "kubectl exec -it web-0 -- sh",
+		},
+		{
+			name:    "resourceless verbs skip resource checks",
+			policy:  config.ToolApprovalPolicy{AllowedResources: []string{"pods"}},
+			command: "kubectl version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckToolRestrictions(tt.policy, tt.command)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("CheckToolRestrictions(%q) = %v, want allowed", tt.command, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("CheckToolRestrictions(%q) = %v, want error containing %q", tt.command, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyEnforcer_RestrictionsOverrideAutoApprove(t *testing.T) {
+	enforcer := NewPolicyEnforcer(config.ToolApprovalPolicy{
+		AutoApproveReadOnly: true,
+		SafeTools:           true,
+	})
+
+	if d := enforcer.Evaluate("kubectl get pods"); !d.Allowed || d.RequiresApproval {
+		t.Fatalf("read-only command should be auto-approved, got %+v", d)
+	}
+
+	d := enforcer.Evaluate("kubectl scale deployment web --replicas=0")
+	if d.Allowed {
+		t.Fatal("write command should be blocked in safe tools mode")
+	}
+	if !strings.Contains(d.BlockReason, "safe tools") {
+		t.Fatalf("BlockReason = %q, want safe tools explanation", d.BlockReason)
+	}
+}
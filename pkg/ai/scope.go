@@ -0,0 +1,50 @@
+package ai
+
+import "strings"
+
+// DefaultScopePrompt tells the AI where the user is working so kubectl
+// commands target the right cluster and namespace without a clarifying
+// round-trip. llm.scope_prompt replaces it.
+const DefaultScopePrompt = "Current Kubernetes context: {context}. Namespace: {namespace}. Current view: {resource}. " +
+	"Run kubectl commands against this context and namespace unless the user names others."
+
+// ScopePromptOff disables the scope prompt when set as llm.scope_prompt
+const ScopePromptOff = "off"
+
+// Scope is the cluster context, namespace, and resource view a question is
+// asked from
+type Scope struct {
+	Context   string
+	Namespace string // empty for all namespaces
+	Resource  string
+}
+
+// ScopePrompt renders tmpl, or DefaultScopePrompt when tmpl is empty,
+// substituting {context}, {namespace}, and {resource}. It returns "" when
+// tmpl is ScopePromptOff.
+func ScopePrompt(tmpl string, scope Scope) string {
+	tmpl = strings.TrimSpace(tmpl)
+	switch {
+	case strings.EqualFold(tmpl, ScopePromptOff):
+		return ""
+	case tmpl == "":
+		tmpl = DefaultScopePrompt
+	}
+
+	namespace := scope.Namespace
+	if namespace == "" {
+		namespace = "all namespaces"
+	}
+	return strings.NewReplacer(
+		"{context}", valueOr(scope.Context, "unknown"),
+		"{namespace}", namespace,
+		"{resource}", valueOr(scope.Resource, "unknown"),
+	).Replace(tmpl)
+}
+
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
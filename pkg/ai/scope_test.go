@@ -0,0 +1,54 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScopePrompt(t *testing.T) {
+	scope := Scope{Context: "prod-eu", Namespace: "payments", Resource: "pods"}
+
+	tests := []struct {
+		name  string
+		tmpl  string
+		scope Scope
+		want  string
+	}{
+		{
+			name:  "default template",
+			scope: scope,
+			want:  "Current Kubernetes context: prod-eu. Namespace: payments. Current view: pods. ",
+		},
+		{
+			name:  "all namespaces and unknown context",
+			scope: Scope{Resource: "nodes"},
+			want:  "Current Kubernetes context: unknown. Namespace: all namespaces. Current view: nodes. ",
+		},
+		{
+			name:  "custom template",
+			tmpl:  "Cluster {context}, ns {namespace}",
+			scope: scope,
+			want:  "Cluster prod-eu, ns payments",
+		},
+		{
+			name:  "disabled",
+			tmpl:  " OFF ",
+			scope: scope,
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScopePrompt(tt.tmpl, tt.scope)
+			if tt.want == "" {
+				if got != "" {
+					t.Errorf("ScopePrompt() = %q, want empty", got)
+				}
+				return
+			}
+			if !strings.HasPrefix(got, tt.want) {
+				t.Errorf("ScopePrompt() = %q, want prefix %q", got, tt.want)
+			}
+		})
+	}
+}
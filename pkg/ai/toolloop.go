@@ -0,0 +1,59 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai/providers"
+)
+
+// defaultMaxRepeatedToolCalls is how many identical tool calls one question
+// may make before the tool loop is stopped
+const defaultMaxRepeatedToolCalls = 3
+
+// ErrRepeatedToolCall stops a tool loop in which the model keeps making the
+// same tool call
+var ErrRepeatedToolCall = errors.New("repeated identical tool call")
+
+// toolLoopGuard counts the tool calls made for one question
+type toolLoopGuard struct {
+	limit int // 0 or less turns the guard off
+	seen  map[string]int
+}
+
+func newToolLoopGuard(maxRepeated int) *toolLoopGuard {
+	if maxRepeated == 0 {
+		maxRepeated = defaultMaxRepeatedToolCalls
+	}
+	return &toolLoopGuard{limit: maxRepeated, seen: make(map[string]int)}
+}
+
+// check records call and returns ErrRepeatedToolCall, wrapped with the
+// tool name, once the same tool and arguments reach the limit
+func (g *toolLoopGuard) check(call providers.ToolCall) error {
+	if g.limit <= 0 {
+		return nil
+	}
+	key := call.Function.Name + "\x00" + canonicalToolArgs(call.Function.Arguments)
+	g.seen[key]++
+	if g.seen[key] < g.limit {
+		return nil
+	}
+	return fmt.Errorf("%w: the model called %s with the same arguments %d times, so the tool loop was stopped; set llm.max_repeated_tool_calls to change the limit",
+		ErrRepeatedToolCall, call.Function.Name, g.seen[key])
+}
+
+// canonicalToolArgs re-encodes JSON arguments so key order and spacing do
+// not hide a repeated call
+func canonicalToolArgs(args string) string {
+	var v interface{}
+	if err := json.Unmarshal([]byte(args), &v); err != nil {
+		return args
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return args
+	}
+	return string(data)
+}
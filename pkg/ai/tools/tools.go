@@ -77,6 +77,38 @@ type MCPToolExecutor interface {
 	CallTool(ctx context.Context, toolName string, args map[string]interface{}) (string, error)
 }
 
+// CommandGuard vets a tool call right before it runs, after any approval.
+// command is the kubectl command line (starting with "kubectl"), the bash
+// command, or the MCP tool name. A non-nil error rejects the call.
+type CommandGuard func(toolType ToolType, command string) error
+
+var (
+	guardMu      sync.RWMutex
+	commandGuard CommandGuard
+)
+
+// SetCommandGuard installs the guard every tool execution must pass, so policy
+// limits hold even when approval is automatic. A nil guard removes it.
+func SetCommandGuard(guard CommandGuard) {
+	guardMu.Lock()
+	defer guardMu.Unlock()
+	commandGuard = guard
+}
+
+// checkCommandGuard runs the installed guard, if any
+func checkCommandGuard(toolType ToolType, command string) error {
+	guardMu.RLock()
+	guard := commandGuard
+	guardMu.RUnlock()
+	if guard == nil {
+		return nil
+	}
+	if err := guard(toolType, command); err != nil {
+		return fmt.Errorf("blocked by tool policy: %w", err)
+	}
+	return nil
+}
+
 // Registry holds all available tools
 type Registry struct {
 	mu          sync.RWMutex
@@ -297,7 +329,9 @@ func (r *Registry) Execute(ctx context.Context, call *ToolCall) *ToolResult {
 			}
 		}
 
-		result, err = mcpExec.CallTool(ctx, call.Function.Name, args)
+		if err = checkCommandGuard(ToolTypeMCP, call.Function.Name); err == nil {
+			result, err = mcpExec.CallTool(ctx, call.Function.Name, args)
+		}
 	} else {
 		result, err = r.executor.Execute(ctx, tool, call.Function.Arguments)
 	}
@@ -394,6 +428,9 @@ func (e *Executor) executeKubectl(ctx context.Context, argsJSON string) (string,
 	if err := ValidateKubectlToolCommand(args.Command); err != nil {
 		return "", err
 	}
+	if err := checkCommandGuard(ToolTypeKubectl, "kubectl "+strings.TrimPrefix(strings.TrimSpace(args.Command), "kubectl ")); err != nil {
+		return "", err
+	}
 
 	// Parse the command into individual arguments
 	cmdStr := args.Command
@@ -434,6 +471,9 @@ func (e *Executor) executeBash(ctx context.Context, argsJSON string) (string, er
 	if err := ValidateBashToolCommand(args.Command); err != nil {
 		return "", err
 	}
+	if err := checkCommandGuard(ToolTypeBash, args.Command); err != nil {
+		return "", err
+	}
 
 	timeout := e.timeout
 	if args.Timeout > 0 {
@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -126,3 +127,28 @@ func TestResolveKubectlPathWithReturnsHelpfulError(t *testing.T) {
 		t.Fatalf("error = %q, want common locations guidance", err)
 	}
 }
+
+func TestRegistryExecuteAppliesCommandGuard(t *testing.T) {
+	var seen []string
+	SetCommandGuard(func(toolType ToolType, command string) error {
+		seen = append(seen, string(toolType)+":"+command)
+		return fmt.Errorf("not on the allowlist")
+	})
+	t.Cleanup(func() { SetCommandGuard(nil) })
+
+	registry := NewRegistry()
+	for _, call := range []ToolCall{
+		{ID: "1", Function: ToolCallFunc{Name: "kubectl", Arguments: `{"command":"delete pod api"}`}},
+		{ID: "2", Function: ToolCallFunc{Name: "bash", Arguments: `{"command":"rm -rf /tmp/x"}`}},
+	} {
+		result := registry.Execute(context.Background(), &call)
+		if !result.IsError || !strings.Contains(result.Content, "blocked by tool policy: not on the allowlist") {
+			t.Fatalf("Execute(%s) = %+v, want guard rejection", call.Function.Name, result)
+		}
+	}
+
+	want := []string{"kubectl:kubectl delete pod api", "bash:rm -rf /tmp/x"}
+	if strings.Join(seen, "|") != strings.Join(want, "|") {
+		t.Fatalf("guard saw %q, want %q", seen, want)
+	}
+}
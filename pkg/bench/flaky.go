@@ -0,0 +1,128 @@
+package bench
+
+import (
+	"context"
+	"sort"
+)
+
+// evaluateWithRetries runs a task up to 1+Retries times, stopping at the first
+// success. The returned result is the last attempt, annotated with every
+// attempt's outcome so a pass on retry is reported as flaky rather than as a
+// clean pass.
+func (r *Runner) evaluateWithRetries(ctx context.Context, task *Task, llmCfg LLMConfig) *EvalResult {
+	maxAttempts := 1 + r.config.Retries
+	var attempts []*EvalResult
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		result := r.evaluateTask(ctx, task, llmCfg)
+		result.Attempt = attempt
+		attempts = append(attempts, result)
+		if result.Result == ResultSuccess || ctx.Err() != nil {
+			break
+		}
+		if attempt < maxAttempts {
+			r.log("[↻] %s (%s) - %s, retrying (%d/%d)\n", task.ID, llmCfg.ID, result.Result, attempt+1, maxAttempts)
+		}
+	}
+	return mergeAttempts(attempts)
+}
+
+// mergeAttempts returns the final attempt with the history of all attempts.
+func mergeAttempts(attempts []*EvalResult) *EvalResult {
+	final := attempts[len(attempts)-1]
+	if len(attempts) == 1 {
+		return final
+	}
+
+	final.StartTime = attempts[0].StartTime
+	final.Attempts = make([]AttemptRecord, 0, len(attempts))
+	for _, a := range attempts {
+		final.Attempts = append(final.Attempts, AttemptRecord{
+			Attempt:  a.Attempt,
+			Result:   a.Result,
+			Error:    a.Error,
+			Duration: a.Duration,
+		})
+	}
+	final.Flaky = final.Result == ResultSuccess
+	return final
+}
+
+// firstAttemptResult returns the outcome of the first attempt of r.
+func firstAttemptResult(r *EvalResult) TaskResult {
+	if len(r.Attempts) > 0 {
+		return r.Attempts[0].Result
+	}
+	return r.Result
+}
+
+// summarizeFlakiness fills the flakiness fields of summary and recomputes
+// Pass@1 from first attempts, so retries cannot inflate it.
+func summarizeFlakiness(summary *BenchmarkSummary, results []*EvalResult) {
+	firstPass := 0
+	for _, result := range results {
+		if firstAttemptResult(result) == ResultSuccess {
+			firstPass++
+		}
+		if len(result.Attempts) == 0 {
+			continue
+		}
+
+		if summary.Flakiness == nil {
+			summary.Flakiness = make(map[string]*TaskFlakiness)
+		}
+		tf, ok := summary.Flakiness[result.TaskID]
+		if !ok {
+			tf = &TaskFlakiness{TaskID: result.TaskID}
+			summary.Flakiness[result.TaskID] = tf
+		}
+		tf.Runs++
+		tf.Attempts += len(result.Attempts)
+		for _, a := range result.Attempts {
+			if a.Result != ResultSuccess {
+				tf.FailedAttempts++
+			}
+		}
+		if result.Flaky {
+			tf.FlakyRuns++
+			summary.FlakyCount++
+		}
+	}
+
+	// Runs that never needed a retry still count towards the task's rate
+	for _, result := range results {
+		if tf, ok := summary.Flakiness[result.TaskID]; ok && len(result.Attempts) == 0 {
+			tf.Runs++
+			tf.Attempts++
+			if result.Result != ResultSuccess {
+				tf.FailedAttempts++
+			}
+		}
+	}
+	for _, tf := range summary.Flakiness {
+		if tf.Runs > 0 {
+			tf.FlakinessRate = float64(tf.FlakyRuns) / float64(tf.Runs) * 100
+		}
+	}
+
+	if summary.TotalTasks > 0 {
+		summary.PassAt1 = float64(firstPass) / float64(summary.TotalTasks) * 100
+	}
+}
+
+// FlakyTasks returns the tasks that passed only after a retry at least once,
+// most flaky first.
+func FlakyTasks(summary *BenchmarkSummary) []*TaskFlakiness {
+	var flaky []*TaskFlakiness
+	for _, tf := range summary.Flakiness {
+		if tf.FlakyRuns > 0 {
+			flaky = append(flaky, tf)
+		}
+	}
+	sort.Slice(flaky, func(i, j int) bool {
+		if flaky[i].FlakinessRate != flaky[j].FlakinessRate {
+			return flaky[i].FlakinessRate > flaky[j].FlakinessRate
+		}
+		return flaky[i].TaskID < flaky[j].TaskID
+	})
+	return flaky
+}
@@ -0,0 +1,102 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func attemptResult(n int, result TaskResult) *EvalResult {
+	return &EvalResult{TaskID: "task-1", Attempt: n, Result: result, Duration: time.Second}
+}
+
+func TestMergeAttempts(t *testing.T) {
+	tests := []struct {
+		name         string
+		attempts     []*EvalResult
+		wantResult   TaskResult
+		wantFlaky    bool
+		wantAttempts int
+	}{
+		{
+			name:         "single pass",
+			attempts:     []*EvalResult{attemptResult(1, ResultSuccess)},
+			wantResult:   ResultSuccess,
+			wantAttempts: 0,
+		},
+		{
+			name:         "fail then pass is flaky",
+			attempts:     []*EvalResult{attemptResult(1, ResultFail), attemptResult(2, ResultTimeout), attemptResult(3, ResultSuccess)},
+			wantResult:   ResultSuccess,
+			wantFlaky:    true,
+			wantAttempts: 3,
+		},
+		{
+			name:         "fail on every attempt",
+			attempts:     []*EvalResult{attemptResult(1, ResultFail), attemptResult(2, ResultFail)},
+			wantResult:   ResultFail,
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeAttempts(tt.attempts)
+			if got.Result != tt.wantResult {
+				t.Errorf("Result = %s, want %s", got.Result, tt.wantResult)
+			}
+			if got.Flaky != tt.wantFlaky {
+				t.Errorf("Flaky = %v, want %v", got.Flaky, tt.wantFlaky)
+			}
+			if len(got.Attempts) != tt.wantAttempts {
+				t.Errorf("len(Attempts) = %d, want %d", len(got.Attempts), tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestAnalyzer_Analyze_Flakiness(t *testing.T) {
+	flaky := mergeAttempts([]*EvalResult{attemptResult(1, ResultFail), attemptResult(2, ResultSuccess)})
+	flaky.LLMConfig = LLMConfig{ID: "gpt-4", Model: "gpt-4"}
+	results := []*EvalResult{
+		flaky,
+		{TaskID: "task-1", LLMConfig: LLMConfig{ID: "claude", Model: "claude-3"}, Result: ResultSuccess, Attempt: 1},
+		{TaskID: "task-2", LLMConfig: LLMConfig{ID: "gpt-4", Model: "gpt-4"}, Result: ResultSuccess, Attempt: 1},
+	}
+
+	analyzer := NewAnalyzer("", OutputMarkdown)
+	summary := analyzer.Analyze(results)
+
+	if summary.SuccessCount != 3 {
+		t.Errorf("SuccessCount = %d, want 3", summary.SuccessCount)
+	}
+	if summary.FlakyCount != 1 {
+		t.Errorf("FlakyCount = %d, want 1", summary.FlakyCount)
+	}
+	// Pass@1 only counts first attempts: 2 of 3
+	if summary.PassAt1 < 66.6 || summary.PassAt1 > 66.7 {
+		t.Errorf("PassAt1 = %.2f, want 66.67", summary.PassAt1)
+	}
+
+	tf := summary.Flakiness["task-1"]
+	if tf == nil {
+		t.Fatal("missing flakiness for task-1")
+	}
+	if tf.Runs != 2 || tf.FlakyRuns != 1 || tf.Attempts != 3 || tf.FailedAttempts != 1 || tf.FlakinessRate != 50 {
+		t.Errorf("task-1 flakiness = %+v", tf)
+	}
+	if _, ok := summary.Flakiness["task-2"]; ok {
+		t.Error("task-2 was never retried and should not be tracked")
+	}
+
+	data, err := analyzer.formatMarkdown(summary, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := string(data)
+	for _, want := range []string{"## Flaky Tasks", "| task-1 | 1 | 2 | 1 | 3 | 50.0% |", "(flaky)", "passed on attempt 2/2"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q", want)
+		}
+	}
+}
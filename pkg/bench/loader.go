@@ -1,13 +1,13 @@
 package bench
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
-
-	"gopkg.in/yaml.v3"
+	"time"
 )
 
 // Loader handles loading benchmark tasks from the filesystem
@@ -26,7 +26,9 @@ func NewLoader(baseDir string) *Loader {
 }
 
 // LoadTasks loads all tasks from the base directory
-// Tasks are expected to be in subdirectories with task.yaml files
+// Tasks are expected to be in subdirectories with task.yaml files. Task files
+// are parsed strictly: an unknown or invalid field fails the load with an
+// error naming the file and field.
 func (l *Loader) LoadTasks() ([]*Task, error) {
 	entries, err := os.ReadDir(l.baseDir)
 	if err != nil {
@@ -47,9 +49,13 @@ func (l *Loader) LoadTasks() ([]*Task, error) {
 			continue
 		}
 
-		task, err := l.loadTask(taskDir)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load task %s: %w", entry.Name(), err)
+		task, errs := decodeTask(taskDir)
+		if len(errs) > 0 {
+			joined := make([]error, len(errs))
+			for i, e := range errs {
+				joined[i] = e
+			}
+			return nil, fmt.Errorf("failed to load task %s: %w", entry.Name(), errors.Join(joined...))
 		}
 
 		// Set task ID to directory name if not specified
@@ -64,52 +70,6 @@ func (l *Loader) LoadTasks() ([]*Task, error) {
 	return tasks, nil
 }
 
-// LoadTask loads a single task from a directory
-func (l *Loader) loadTask(taskDir string) (*Task, error) {
-	taskFile := filepath.Join(taskDir, "task.yaml")
-	data, err := os.ReadFile(taskFile)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read task file: %w", err)
-	}
-
-	var task Task
-	if err := yaml.Unmarshal(data, &task); err != nil {
-		return nil, fmt.Errorf("failed to parse task YAML: %w", err)
-	}
-
-	// Handle legacy 'prompt' field by converting to script
-	if task.Prompt != "" && len(task.Script) == 0 {
-		task.Script = []Prompt{{Text: task.Prompt}}
-	}
-
-	// Load prompt files if specified
-	for i, prompt := range task.Script {
-		if prompt.File != "" {
-			promptPath := filepath.Join(taskDir, prompt.File)
-			content, err := os.ReadFile(promptPath)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read prompt file %s: %w", prompt.File, err)
-			}
-			task.Script[i].Text = string(content)
-		}
-	}
-
-	// Validate required fields
-	if len(task.Script) == 0 {
-		return nil, fmt.Errorf("task must have at least one prompt in script or prompt field")
-	}
-
-	// Set defaults
-	if task.Timeout == "" {
-		task.Timeout = "10m"
-	}
-	if task.Difficulty == "" {
-		task.Difficulty = DifficultyMedium
-	}
-
-	return &task, nil
-}
-
 // FilterTasks filters tasks based on the given criteria
 func (l *Loader) FilterTasks(tasks []*Task, opts FilterOptions) ([]*Task, error) {
 	var filtered []*Task
@@ -210,6 +170,20 @@ func (t *Task) GetArtifactsDir() string {
 	return filepath.Join(t.Dir, "artifacts")
 }
 
+// EffectiveTimeout returns the task's own timeout, or defaultTimeout when the
+// task declares none. Unparseable values fall back to 10 minutes.
+func (t *Task) EffectiveTimeout(defaultTimeout string) time.Duration {
+	if t.Timeout != "" {
+		if d, err := parseTaskTimeout(t.Timeout); err == nil {
+			return d
+		}
+	}
+	if d, err := parseTaskTimeout(defaultTimeout); err == nil {
+		return d
+	}
+	return 10 * time.Minute
+}
+
 // helper functions
 
 func containsString(slice []string, s string) bool {
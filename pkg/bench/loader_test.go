@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestLoader_LoadTasks(t *testing.T) {
@@ -245,3 +246,26 @@ func TestTask_GetTaskScript(t *testing.T) {
 		t.Errorf("GetTaskScript() = %s, want %s", got, expected)
 	}
 }
+
+func TestTask_EffectiveTimeout(t *testing.T) {
+	tests := []struct {
+		name           string
+		timeout        string
+		defaultTimeout string
+		want           time.Duration
+	}{
+		{"task override", "20m", "10m", 20 * time.Minute},
+		{"bare seconds", "90", "10m", 90 * time.Second},
+		{"unset uses default", "", "3m", 3 * time.Minute},
+		{"invalid uses default", "soon", "3m", 3 * time.Minute},
+		{"invalid default", "", "never", 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{Timeout: tt.timeout}
+			if got := task.EffectiveTimeout(tt.defaultTimeout); got != tt.want {
+				t.Errorf("EffectiveTimeout(%q) = %v, want %v", tt.defaultTimeout, got, tt.want)
+			}
+		})
+	}
+}
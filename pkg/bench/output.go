@@ -152,9 +152,7 @@ func (a *Analyzer) Analyze(results []*EvalResult) *BenchmarkSummary {
 	}
 
 	// Calculate rates
-	if summary.TotalTasks > 0 {
-		summary.PassAt1 = float64(summary.SuccessCount) / float64(summary.TotalTasks) * 100
-	}
+	summarizeFlakiness(summary, results)
 
 	for _, llmSummary := range summary.LLMResults {
 		if llmSummary.TotalTasks > 0 {
@@ -247,9 +245,23 @@ func (a *Analyzer) formatMarkdown(summary *BenchmarkSummary, results []*EvalResu
 	sb.WriteString(fmt.Sprintf("| Success | %d |\n", summary.SuccessCount))
 	sb.WriteString(fmt.Sprintf("| Failed | %d |\n", summary.FailCount))
 	sb.WriteString(fmt.Sprintf("| Errors | %d |\n", summary.ErrorCount))
+	sb.WriteString(fmt.Sprintf("| Flaky | %d |\n", summary.FlakyCount))
 	sb.WriteString(fmt.Sprintf("| Pass@1 | %.1f%% |\n", summary.PassAt1))
 	sb.WriteString("\n")
 
+	// Flaky tasks
+	if flaky := FlakyTasks(summary); len(flaky) > 0 {
+		sb.WriteString("## Flaky Tasks\n\n")
+		sb.WriteString("Tasks that failed and then passed on retry.\n\n")
+		sb.WriteString("| Task | Flaky Runs | Runs | Failed Attempts | Attempts | Flakiness |\n")
+		sb.WriteString("|------|------------|------|-----------------|----------|-----------|\n")
+		for _, tf := range flaky {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d | %.1f%% |\n",
+				tf.TaskID, tf.FlakyRuns, tf.Runs, tf.FailedAttempts, tf.Attempts, tf.FlakinessRate))
+		}
+		sb.WriteString("\n")
+	}
+
 	// Difficulty Breakdown
 	sb.WriteString("## Results by Difficulty\n\n")
 	sb.WriteString("| Difficulty | Success | Total | Rate |\n")
@@ -318,6 +330,9 @@ func (a *Analyzer) formatMarkdown(summary *BenchmarkSummary, results []*EvalResu
 		if taskRes[0].TaskName != "" {
 			sb.WriteString(fmt.Sprintf("**%s** (%s)\n\n", taskRes[0].TaskName, taskRes[0].Difficulty))
 		}
+		if taskRes[0].Timeout > 0 {
+			sb.WriteString(fmt.Sprintf("**Timeout:** %s\n\n", taskRes[0].Timeout))
+		}
 
 		sb.WriteString("| LLM | Result | Duration | Notes |\n")
 		sb.WriteString("|-----|--------|----------|-------|\n")
@@ -329,9 +344,16 @@ func (a *Analyzer) formatMarkdown(summary *BenchmarkSummary, results []*EvalResu
 			} else if len(r.Failures) > 0 {
 				notes = truncateString(r.Failures[0].Message, 50)
 			}
+			result := resultEmoji(r.Result)
+			if r.Flaky {
+				result += " (flaky)"
+				notes = fmt.Sprintf("passed on attempt %d/%d", r.Attempt, len(r.Attempts))
+			} else if len(r.Attempts) > 1 {
+				notes = fmt.Sprintf("%d attempts; %s", len(r.Attempts), notes)
+			}
 			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
 				r.LLMConfig.Model,
-				resultEmoji(r.Result),
+				result,
 				r.Duration.Round(time.Millisecond),
 				notes))
 		}
@@ -366,11 +388,27 @@ func PrintSummary(summary *BenchmarkSummary) {
 	fmt.Printf("Run ID:     %s\n", summary.RunID)
 	fmt.Printf("Duration:   %s\n", summary.Duration.Round(time.Second))
 	fmt.Printf("Total:      %d tasks\n", summary.TotalTasks)
-	fmt.Printf("Success:    %d (%.1f%%)\n", summary.SuccessCount, summary.PassAt1)
+	successRate := 0.0
+	if summary.TotalTasks > 0 {
+		successRate = float64(summary.SuccessCount) / float64(summary.TotalTasks) * 100
+	}
+	fmt.Printf("Success:    %d (%.1f%%)\n", summary.SuccessCount, successRate)
+	fmt.Printf("Pass@1:     %.1f%%\n", summary.PassAt1)
 	fmt.Printf("Failed:     %d\n", summary.FailCount)
 	fmt.Printf("Errors:     %d\n", summary.ErrorCount)
+	if summary.FlakyCount > 0 {
+		fmt.Printf("Flaky:      %d (passed only after retry)\n", summary.FlakyCount)
+	}
 	fmt.Println(strings.Repeat("=", 50))
 
+	if flaky := FlakyTasks(summary); len(flaky) > 0 {
+		fmt.Println("\nFlaky Tasks:")
+		for _, tf := range flaky {
+			fmt.Printf("  %s: %.1f%% (%d/%d runs, %d/%d attempts failed)\n",
+				tf.TaskID, tf.FlakinessRate, tf.FlakyRuns, tf.Runs, tf.FailedAttempts, tf.Attempts)
+		}
+	}
+
 	if len(summary.LLMResults) > 1 {
 		fmt.Println("\nPer-LLM Results:")
 		for id, llm := range summary.LLMResults {
@@ -3,6 +3,7 @@ package bench
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"time"
 
 	"github.com/cloudbro-kube-ai/k13d/pkg/ai"
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai/safety"
 	"github.com/cloudbro-kube-ai/k13d/pkg/bench/cluster"
 	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/google/uuid"
@@ -168,7 +170,7 @@ func (r *Runner) Run(ctx context.Context) (*BenchmarkSummary, error) {
 			sem <- struct{}{}        // Acquire
 			defer func() { <-sem }() // Release
 
-			result := r.evaluateTask(ctx, task, llmCfg)
+			result := r.evaluateWithRetries(ctx, task, llmCfg)
 			results <- result
 
 			// Save individual result
@@ -193,7 +195,11 @@ func (r *Runner) Run(ctx context.Context) (*BenchmarkSummary, error) {
 		if result.Result != ResultSuccess {
 			status = "✗"
 		}
-		r.log("[%s] %s (%s) - %s\n", status, result.TaskID, result.LLMConfig.ID, result.Result)
+		note := ""
+		if result.Flaky {
+			note = fmt.Sprintf(" (flaky, passed on attempt %d)", result.Attempt)
+		}
+		r.log("[%s] %s (%s) - %s%s\n", status, result.TaskID, result.LLMConfig.ID, result.Result, note)
 	}
 
 	// Generate summary
@@ -215,11 +221,8 @@ func (r *Runner) evaluateTask(ctx context.Context, task *Task, llmCfg LLMConfig)
 		Attempt:      1,
 	}
 
-	// Parse timeout
-	timeout, err := time.ParseDuration(task.Timeout)
-	if err != nil {
-		timeout, _ = time.ParseDuration(r.config.DefaultTimeout)
-	}
+	timeout := task.EffectiveTimeout(r.config.DefaultTimeout)
+	result.Timeout = timeout
 
 	taskCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
@@ -265,7 +268,7 @@ func (r *Runner) evaluateTask(ctx context.Context, task *Task, llmCfg LLMConfig)
 	if err != nil {
 		if taskCtx.Err() == context.DeadlineExceeded {
 			result.Result = ResultTimeout
-			result.Error = "task timed out"
+			result.Error = fmt.Sprintf("task timed out after %s", timeout)
 		} else {
 			result.Result = ResultError
 			result.Error = fmt.Sprintf("agent failed: %v", err)
@@ -396,10 +399,12 @@ func (r *Runner) runExternalAgent(ctx context.Context, task *Task, llmCfg LLMCon
 func (r *Runner) runBuiltinAgent(ctx context.Context, task *Task, llmCfg LLMConfig, kubeconfig, namespace string) (string, error) {
 	// Create AI client from LLM config
 	cfg := &config.LLMConfig{
-		Provider: llmCfg.Provider,
-		Model:    llmCfg.Model,
-		Endpoint: llmCfg.Endpoint,
-		APIKey:   llmCfg.APIKey,
+		Provider:    llmCfg.Provider,
+		Model:       llmCfg.Model,
+		Endpoint:    llmCfg.Endpoint,
+		APIKey:      llmCfg.APIKey,
+		Temperature: llmCfg.Temperature,
+		MaxTokens:   llmCfg.MaxTokens,
 	}
 
 	client, err := ai.NewClient(cfg)
@@ -425,9 +430,7 @@ Complete the following task:
 	// Run with tool support if enabled
 	var output strings.Builder
 	if llmCfg.EnableToolUse && client.SupportsTools() {
-		approvalCallback := func(toolName, args string) bool {
-			return llmCfg.AutoApprove // Auto-approve in benchmark mode
-		}
+		approvalCallback := llmCfg.approveToolCall
 		err = client.AskWithTools(ctx, fullPrompt, func(text string) {
 			output.WriteString(text)
 		}, approvalCallback)
@@ -444,6 +447,31 @@ Complete the following task:
 	return output.String(), nil
 }
 
+// approveToolCall decides a tool call for the built-in agent. Nobody can
+// answer a prompt during a benchmark, so "all" runs every call, dangerous ones
+// included, and a call the "reads" mode would ask about is declined; that
+// gating is the TUI's, through safety.PolicyEnforcer.
+func (c LLMConfig) approveToolCall(toolName, argsJSON string) bool {
+	switch c.ApprovalMode {
+	case "":
+		return c.AutoApprove
+	case config.AutoApproveAll:
+		return true
+	}
+
+	var args struct {
+		Command string `json:"command"`
+	}
+	_ = json.Unmarshal([]byte(argsJSON), &args)
+	command := strings.TrimSpace(args.Command)
+	if toolName == "kubectl" && !strings.HasPrefix(command, "kubectl ") {
+		command = "kubectl " + command
+	}
+
+	decision := safety.NewPolicyEnforcer(config.ToolApprovalPolicy{AutoApprove: c.ApprovalMode}).Evaluate(command)
+	return decision.Allowed && !decision.RequiresApproval
+}
+
 // runScript executes a shell script with the given environment
 func (r *Runner) runScript(ctx context.Context, scriptPath, kubeconfig, namespace, workDir string) (string, error) {
 	if _, err := os.Stat(scriptPath); os.IsNotExist(err) {
@@ -741,9 +769,7 @@ func (r *Runner) generateSummary(startTime, endTime time.Time) *BenchmarkSummary
 	}
 
 	// Calculate pass rates
-	if summary.TotalTasks > 0 {
-		summary.PassAt1 = float64(summary.SuccessCount) / float64(summary.TotalTasks) * 100
-	}
+	summarizeFlakiness(summary, r.results)
 
 	// Calculate per-LLM pass rates
 	for _, llmSummary := range summary.LLMResults {
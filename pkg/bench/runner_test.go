@@ -0,0 +1,31 @@
+package bench
+
+import "testing"
+
+func TestLLMConfig_ApproveToolCall(t *testing.T) {
+	read := `{"command":"get pods -n default"}`
+	write := `{"command":"scale deploy/web --replicas=3"}`
+	dangerous := `{"command":"delete pods --all -n default"}`
+
+	tests := []struct {
+		name      string
+		cfg       LLMConfig
+		args      string
+		wantAllow bool
+	}{
+		{"auto-approve runs writes", LLMConfig{AutoApprove: true}, write, true},
+		{"no auto-approve declines reads", LLMConfig{}, read, false},
+		{"reads mode runs reads", LLMConfig{AutoApprove: true, ApprovalMode: "reads"}, read, true},
+		{"reads mode declines writes", LLMConfig{AutoApprove: true, ApprovalMode: "reads"}, write, false},
+		{"all mode overrides auto-approve", LLMConfig{ApprovalMode: "all"}, write, true},
+		{"all mode runs dangerous calls", LLMConfig{ApprovalMode: "all"}, dangerous, true},
+		{"reads mode declines dangerous calls", LLMConfig{ApprovalMode: "reads"}, dangerous, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.approveToolCall("kubectl", tt.args); got != tt.wantAllow {
+				t.Errorf("approveToolCall(%s) = %v, want %v", tt.args, got, tt.wantAllow)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"text/template"
+)
+
+// taskIDPattern matches task directory names: lowercase words joined by dashes.
+var taskIDPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ScaffoldOptions describes a new benchmark task to generate
+type ScaffoldOptions struct {
+	ID          string         // Task ID, also used as the directory name
+	Difficulty  TaskDifficulty // easy, medium, hard (default: medium)
+	Category    string         // Task category (default: troubleshooting)
+	Description string         // One-line description (default: TODO placeholder)
+}
+
+// ValidDifficulty reports whether d is one of the supported difficulty levels
+func ValidDifficulty(d TaskDifficulty) bool {
+	switch d {
+	case DifficultyEasy, DifficultyMedium, DifficultyHard:
+		return true
+	}
+	return false
+}
+
+// ScaffoldTask creates <baseDir>/<id>/ with a task.yaml and setup, verify, and
+// cleanup script placeholders that the Loader can load as-is. It fails if the
+// ID is malformed, the difficulty is unknown, or a task with the same ID
+// already exists. It returns the created task directory.
+func ScaffoldTask(baseDir string, opts ScaffoldOptions) (string, error) {
+	if !taskIDPattern.MatchString(opts.ID) {
+		return "", fmt.Errorf("invalid task id %q: use lowercase letters, digits, and dashes (e.g. fix-crashloop)", opts.ID)
+	}
+	if opts.Difficulty == "" {
+		opts.Difficulty = DifficultyMedium
+	}
+	if !ValidDifficulty(opts.Difficulty) {
+		return "", fmt.Errorf("invalid difficulty %q: must be easy, medium, or hard", opts.Difficulty)
+	}
+	if opts.Category == "" {
+		opts.Category = "troubleshooting"
+	}
+	if opts.Description == "" {
+		opts.Description = "TODO: describe what the agent must accomplish"
+	}
+
+	taskDir := filepath.Join(baseDir, opts.ID)
+	if _, err := os.Stat(taskDir); err == nil {
+		return "", fmt.Errorf("task %q already exists at %s", opts.ID, taskDir)
+	}
+	if _, err := os.Stat(baseDir); err == nil {
+		tasks, err := NewLoader(baseDir).LoadTasks()
+		if err != nil {
+			return "", fmt.Errorf("failed to check existing task ids: %w", err)
+		}
+		for _, t := range tasks {
+			if t.ID == opts.ID {
+				return "", fmt.Errorf("task id %q is already used by %s", opts.ID, t.Dir)
+			}
+		}
+	}
+
+	files := []struct {
+		name string
+		tmpl string
+		mode os.FileMode
+	}{
+		{"task.yaml", scaffoldTaskYAML, 0644},
+		{"setup.sh", scaffoldSetupScript, 0755},
+		{"verify.sh", scaffoldVerifyScript, 0755},
+		{"cleanup.sh", scaffoldCleanupScript, 0755},
+	}
+
+	if err := os.MkdirAll(taskDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create task directory: %w", err)
+	}
+	for _, f := range files {
+		var buf bytes.Buffer
+		if err := template.Must(template.New(f.name).Funcs(scaffoldFuncs).Parse(f.tmpl)).Execute(&buf, opts); err != nil {
+			_ = os.RemoveAll(taskDir)
+			return "", fmt.Errorf("failed to render %s: %w", f.name, err)
+		}
+		if err := os.WriteFile(filepath.Join(taskDir, f.name), buf.Bytes(), f.mode); err != nil {
+			_ = os.RemoveAll(taskDir)
+			return "", fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+
+	return taskDir, nil
+}
+
+// scaffoldFuncs are available to the scaffold templates. Go-quoted strings are
+// valid YAML double-quoted scalars, so quote keeps free-form text safe.
+var scaffoldFuncs = template.FuncMap{"quote": strconv.Quote}
+
+const scaffoldTaskYAML = `id: {{.ID}}
+name: {{.ID}}
+description: {{quote .Description}}
+category: {{.Category}}
+difficulty: {{.Difficulty}}
+tags: []
+# Remove this line once setup.sh, verify.sh, and the prompt are filled in.
+disabled: true
+
+script:
+  - prompt: |
+      TODO: write the instruction sent to the AI agent.
+      Refer to the resources created by setup.sh in the current namespace.
+
+setup: setup.sh
+verifier: verify.sh
+cleanup: cleanup.sh
+timeout: 5m
+isolation: namespace
+
+# Expected criteria for the agent's final answer (regular expressions).
+# expect:
+#   - contains: "TODO"
+#   - notContains: "error"
+`
+
+const scaffoldSetupScript = `#!/bin/bash
+set -e
+
+echo "Setting up {{.ID}}..."
+
+# TODO: create the initial state the agent has to work with, e.g.
+# kubectl apply --namespace="${NAMESPACE}" -f "$(dirname "$0")/artifacts/"
+
+echo "Setup complete."
+`
+
+const scaffoldVerifyScript = `#!/bin/bash
+set -e
+
+echo "Verifying {{.ID}}..."
+
+# TODO: check that the cluster reached the expected state in "${NAMESPACE}".
+# Exit 0 when the task is solved, non-zero otherwise.
+echo "ERROR: verify.sh is not implemented yet"
+exit 1
+`
+
+const scaffoldCleanupScript = `#!/bin/bash
+
+echo "Cleaning up {{.ID}}..."
+
+# TODO: delete the resources created by setup.sh
+# kubectl delete deployment my-app --namespace="${NAMESPACE}" --ignore-not-found=true
+
+echo "Cleanup complete."
+`
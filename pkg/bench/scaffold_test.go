@@ -0,0 +1,98 @@
+package bench
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScaffoldTask(t *testing.T) {
+	tempDir := t.TempDir()
+
+	dir, err := ScaffoldTask(tempDir, ScaffoldOptions{
+		ID:          "fix-crashloop",
+		Difficulty:  DifficultyHard,
+		Description: `Fix the "api" pod`,
+	})
+	if err != nil {
+		t.Fatalf("ScaffoldTask() error = %v", err)
+	}
+	if dir != filepath.Join(tempDir, "fix-crashloop") {
+		t.Errorf("dir = %q", dir)
+	}
+
+	for _, name := range []string{"setup.sh", "verify.sh", "cleanup.sh"} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("%s not created: %v", name, err)
+		}
+		if info.Mode().Perm()&0100 == 0 {
+			t.Errorf("%s is not executable", name)
+		}
+	}
+
+	// The generated task must load with the same loader the runner uses
+	tasks, err := NewLoader(tempDir).LoadTasks()
+	if err != nil {
+		t.Fatalf("LoadTasks() error = %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("Expected 1 task, got %d", len(tasks))
+	}
+	task := tasks[0]
+	if task.ID != "fix-crashloop" || task.Difficulty != DifficultyHard || task.Category != "troubleshooting" {
+		t.Errorf("unexpected task: %+v", task)
+	}
+	if task.Description != `Fix the "api" pod` {
+		t.Errorf("Description = %q", task.Description)
+	}
+	if !task.Disabled {
+		t.Error("scaffolded task should be disabled until filled in")
+	}
+	if len(task.Script) != 1 || !strings.Contains(task.Script[0].Text, "TODO") {
+		t.Errorf("Script = %+v, want a TODO prompt", task.Script)
+	}
+	if task.Setup != "setup.sh" || task.Verifier != "verify.sh" || task.Cleanup != "cleanup.sh" {
+		t.Errorf("scripts = %q %q %q", task.Setup, task.Verifier, task.Cleanup)
+	}
+}
+
+func TestScaffoldTask_Validation(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// A task whose directory name differs from its declared ID
+	existing := filepath.Join(tempDir, "legacy-dir")
+	if err := os.MkdirAll(existing, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(existing, "task.yaml"), []byte("id: taken-id\nprompt: test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    ScaffoldOptions
+		wantErr string
+	}{
+		{"empty id", ScaffoldOptions{}, "invalid task id"},
+		{"uppercase id", ScaffoldOptions{ID: "Fix-Crash"}, "invalid task id"},
+		{"path in id", ScaffoldOptions{ID: "../escape"}, "invalid task id"},
+		{"bad difficulty", ScaffoldOptions{ID: "new-task", Difficulty: "extreme"}, "invalid difficulty"},
+		{"existing directory", ScaffoldOptions{ID: "legacy-dir"}, "already exists"},
+		{"existing id", ScaffoldOptions{ID: "taken-id"}, "already used"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ScaffoldTask(tempDir, tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ScaffoldTask() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, "new-task")); !os.IsNotExist(err) {
+		t.Error("invalid options should not create a directory")
+	}
+}
@@ -67,7 +67,7 @@ type Task struct {
 	Setup     string        `yaml:"setup,omitempty"`     // Setup script path (relative to task dir)
 	Verifier  string        `yaml:"verifier,omitempty"`  // Verifier script path
 	Cleanup   string        `yaml:"cleanup,omitempty"`   // Cleanup script path
-	Timeout   string        `yaml:"timeout,omitempty"`   // Task timeout (default: 10m)
+	Timeout   string        `yaml:"timeout,omitempty"`   // Task timeout (default: the run's defaultTimeout)
 	Isolation TaskIsolation `yaml:"isolation,omitempty"` // Isolation level
 
 	// Expectations
@@ -91,6 +91,9 @@ type LLMConfig struct {
 	EnableToolUse bool    `yaml:"enableToolUse,omitempty"` // Enable tool/function calling
 	EnableMCP     bool    `yaml:"enableMcp,omitempty"`     // Enable MCP integration
 	AutoApprove   bool    `yaml:"autoApprove,omitempty"`   // Auto-approve tool executions
+	// ApprovalMode narrows AutoApprove: "reads" runs read-only tool calls and
+	// declines the rest, "all" runs every call. Empty follows AutoApprove.
+	ApprovalMode string `yaml:"approvalMode,omitempty"`
 }
 
 // Failure represents a single test failure
@@ -121,6 +124,7 @@ type EvalResult struct {
 	StartTime time.Time     `json:"startTime"`
 	EndTime   time.Time     `json:"endTime"`
 	Duration  time.Duration `json:"duration"`
+	Timeout   time.Duration `json:"timeout,omitempty"` // Effective task timeout
 
 	// Output
 	Output     string `json:"output,omitempty"`     // AI agent output
@@ -133,12 +137,25 @@ type EvalResult struct {
 	LogPath   string      `json:"logPath,omitempty"`   // Path to log.txt
 	Trace     *AgentTrace `json:"trace,omitempty"`     // Agent trace data
 
+	// Retries: every attempt's outcome, oldest first. Flaky is set when an
+	// earlier attempt failed and a later one passed.
+	Attempts []AttemptRecord `json:"attempts,omitempty"`
+	Flaky    bool            `json:"flaky,omitempty"`
+
 	// Metadata
 	Attempt    int    `json:"attempt"`              // Attempt number (for retry)
 	RunID      string `json:"runId,omitempty"`      // Unique run identifier
 	Kubeconfig string `json:"kubeconfig,omitempty"` // Kubeconfig used
 }
 
+// AttemptRecord is the outcome of one attempt at a task when retries are enabled
+type AttemptRecord struct {
+	Attempt  int           `json:"attempt"`
+	Result   TaskResult    `json:"result"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
 // AgentTrace represents the trace of an agent execution (k8s-ai-bench compatible)
 type AgentTrace struct {
 	Steps      []TraceStep `json:"steps,omitempty"`      // Execution steps
@@ -184,6 +201,20 @@ type BenchmarkSummary struct {
 	// Pass rates
 	PassAt1 float64 `json:"passAt1"` // Single attempt pass rate
 	PassAt5 float64 `json:"passAt5"` // Pass within 5 attempts
+
+	// Flakiness (tasks that failed and then passed on retry)
+	FlakyCount int                       `json:"flakyCount"`
+	Flakiness  map[string]*TaskFlakiness `json:"flakiness,omitempty"` // Keyed by task ID
+}
+
+// TaskFlakiness aggregates retry behaviour for one task across all LLMs
+type TaskFlakiness struct {
+	TaskID         string  `json:"taskId"`
+	Runs           int     `json:"runs"`           // Task × LLM evaluations
+	FlakyRuns      int     `json:"flakyRuns"`      // Evaluations that passed only after a retry
+	Attempts       int     `json:"attempts"`       // Total attempts across evaluations
+	FailedAttempts int     `json:"failedAttempts"` // Attempts that did not pass
+	FlakinessRate  float64 `json:"flakinessRate"`  // FlakyRuns / Runs * 100
 }
 
 // LLMSummary provides per-LLM aggregated results
@@ -0,0 +1,223 @@
+package bench
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// unknownFieldPattern extracts the field name from yaml.v3's strict-mode error
+var unknownFieldPattern = regexp.MustCompile(`field (\S+) not found in type`)
+
+// TaskError describes one problem found in a task.yaml file
+type TaskError struct {
+	File  string // Path to the task.yaml file
+	Field string // Offending field, e.g. "script[1].timeout"; empty for file-level problems
+	Err   error
+}
+
+func (e *TaskError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %v", e.File, e.Err)
+	}
+	return fmt.Sprintf("%s: %s: %v", e.File, e.Field, e.Err)
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateTasks checks every task under the base directory without running
+// anything. Beyond what LoadTasks enforces, it also checks that the setup,
+// verifier, and cleanup scripts exist. It returns the tasks that loaded cleanly, every problem found
+// across all task files, and the subdirectories skipped for having no
+// task.yaml. The error is only set when the base directory can't be read.
+func (l *Loader) ValidateTasks() ([]*Task, []*TaskError, []string, error) {
+	entries, err := os.ReadDir(l.baseDir)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read task directory %s: %w", l.baseDir, err)
+	}
+
+	var tasks []*Task
+	var problems []*TaskError
+	var skipped []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		taskDir := filepath.Join(l.baseDir, entry.Name())
+		if _, err := os.Stat(filepath.Join(taskDir, "task.yaml")); os.IsNotExist(err) {
+			skipped = append(skipped, entry.Name())
+			continue
+		}
+
+		task, errs := decodeTask(taskDir)
+		if len(errs) == 0 {
+			errs = checkTaskScripts(task, taskDir)
+		}
+		if len(errs) > 0 {
+			problems = append(problems, errs...)
+			continue
+		}
+		if task.ID == "" {
+			task.ID = entry.Name()
+		}
+		task.Dir = taskDir
+		tasks = append(tasks, task)
+	}
+	return tasks, problems, skipped, nil
+}
+
+// decodeTask strictly parses taskDir/task.yaml, loads prompt files, validates
+// every field, and applies defaults. All problems are returned, not just the
+// first.
+func decodeTask(taskDir string) (*Task, []*TaskError) {
+	taskFile := filepath.Join(taskDir, "task.yaml")
+	data, err := os.ReadFile(taskFile)
+	if err != nil {
+		return nil, []*TaskError{{File: taskFile, Err: fmt.Errorf("failed to read task file: %w", err)}}
+	}
+
+	var task Task
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&task); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, []*TaskError{{File: taskFile, Err: errors.New("task file is empty")}}
+		}
+		return nil, yamlTaskErrors(taskFile, err)
+	}
+
+	// Handle legacy 'prompt' field by converting to script
+	if task.Prompt != "" && len(task.Script) == 0 {
+		task.Script = []Prompt{{Text: task.Prompt}}
+	}
+
+	var errs []*TaskError
+	add := func(field string, format string, args ...any) {
+		errs = append(errs, &TaskError{File: taskFile, Field: field, Err: fmt.Errorf(format, args...)})
+	}
+
+	if len(task.Script) == 0 {
+		add("script", "task must have at least one prompt in script or prompt field")
+	}
+	for i, prompt := range task.Script {
+		field := fmt.Sprintf("script[%d]", i)
+		switch {
+		case prompt.Text == "" && prompt.File == "":
+			add(field, "needs prompt or promptFile")
+		case prompt.Text != "" && prompt.File != "":
+			add(field, "set only one of prompt and promptFile")
+		case prompt.File != "":
+			content, err := os.ReadFile(filepath.Join(taskDir, prompt.File))
+			if err != nil {
+				add(field+".promptFile", "failed to read prompt file %s: %w", prompt.File, err)
+				break
+			}
+			task.Script[i].Text = string(content)
+		}
+		if prompt.Timeout != "" {
+			if _, err := parseTaskTimeout(prompt.Timeout); err != nil {
+				add(field+".timeout", "invalid duration %q", prompt.Timeout)
+			}
+		}
+	}
+
+	if task.Difficulty != "" && !ValidDifficulty(task.Difficulty) {
+		add("difficulty", "invalid value %q: must be easy, medium, or hard", task.Difficulty)
+	}
+	switch task.Isolation {
+	case IsolationNone, IsolationNamespace, IsolationCluster:
+	default:
+		add("isolation", "invalid value %q: must be namespace or cluster", task.Isolation)
+	}
+	if task.Timeout != "" {
+		if _, err := parseTaskTimeout(task.Timeout); err != nil {
+			add("timeout", "invalid duration %q", task.Timeout)
+		}
+	}
+
+	for i, exp := range task.Expect {
+		field := fmt.Sprintf("expect[%d]", i)
+		if exp.Contains == "" && exp.NotContains == "" && exp.ExitCode == nil {
+			add(field, "needs contains, notContains, or exitCode")
+		}
+		if exp.Contains != "" {
+			if _, err := regexp.Compile(exp.Contains); err != nil {
+				add(field+".contains", "invalid regex: %v", err)
+			}
+		}
+		if exp.NotContains != "" {
+			if _, err := regexp.Compile(exp.NotContains); err != nil {
+				add(field+".notContains", "invalid regex: %v", err)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	// Set defaults. An empty timeout is left for the runner's default.
+	if task.Difficulty == "" {
+		task.Difficulty = DifficultyMedium
+	}
+	return &task, nil
+}
+
+// parseTaskTimeout parses a task or prompt timeout. A bare number is taken as
+// seconds, so "120" means two minutes.
+func parseTaskTimeout(s string) (time.Duration, error) {
+	if secs, err := strconv.Atoi(strings.TrimSpace(s)); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// checkTaskScripts reports setup, verifier, and cleanup scripts that are
+// referenced by the task but missing from taskDir
+func checkTaskScripts(task *Task, taskDir string) []*TaskError {
+	taskFile := filepath.Join(taskDir, "task.yaml")
+	var errs []*TaskError
+	for _, script := range []struct{ field, path string }{
+		{"setup", task.Setup},
+		{"verifier", task.Verifier},
+		{"cleanup", task.Cleanup},
+	} {
+		if script.path == "" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(taskDir, script.path)); err != nil {
+			errs = append(errs, &TaskError{File: taskFile, Field: script.field, Err: fmt.Errorf("script %s not found", script.path)})
+		}
+	}
+	return errs
+}
+
+// yamlTaskErrors turns a YAML decode error into per-field task errors
+func yamlTaskErrors(taskFile string, err error) []*TaskError {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return []*TaskError{{File: taskFile, Err: fmt.Errorf("failed to parse task YAML: %w", err)}}
+	}
+
+	errs := make([]*TaskError, 0, len(typeErr.Errors))
+	for _, msg := range typeErr.Errors {
+		te := &TaskError{File: taskFile, Err: errors.New(msg)}
+		if m := unknownFieldPattern.FindStringSubmatch(msg); m != nil {
+			te.Field = m[1]
+			te.Err = fmt.Errorf("unknown field (%s)", strings.SplitN(msg, ":", 2)[0])
+		}
+		errs = append(errs, te)
+	}
+	return errs
+}
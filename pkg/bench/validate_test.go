@@ -0,0 +1,170 @@
+package bench
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTaskFile(t *testing.T, baseDir, id, content string) string {
+	t.Helper()
+	dir := filepath.Join(baseDir, id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "task.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestValidateTasks(t *testing.T) {
+	tempDir := t.TempDir()
+
+	writeTaskFile(t, tempDir, "good", `
+name: Good
+difficulty: easy
+timeout: 2m
+script:
+  - prompt: "List pods"
+expect:
+  - contains: "pod"
+`)
+	writeTaskFile(t, tempDir, "typo", `
+name: Typo
+difficulty: medum
+timeout: "120"
+scirpt:
+  - prompt: "List pods"
+`)
+	writeTaskFile(t, tempDir, "bad-fields", `
+isolation: vm
+script:
+  - prompt: "a"
+    promptFile: prompt.md
+  - timeout: soon
+expect:
+  - contains: "("
+  - {}
+`)
+	writeTaskFile(t, tempDir, "no-verifier", `
+verifier: verify.sh
+script:
+  - prompt: "List pods"
+`)
+	if err := os.MkdirAll(filepath.Join(tempDir, "no-task"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, problems, skipped, err := NewLoader(tempDir).ValidateTasks()
+	if err != nil {
+		t.Fatalf("ValidateTasks() error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "good" {
+		t.Errorf("valid tasks = %+v, want only 'good'", tasks)
+	}
+	if len(skipped) != 1 || skipped[0] != "no-task" {
+		t.Errorf("skipped = %v, want [no-task]", skipped)
+	}
+
+	got := make(map[string]bool)
+	for _, p := range problems {
+		got[filepath.Base(filepath.Dir(p.File))+":"+p.Field] = true
+	}
+	for _, want := range []string{
+		"typo:scirpt",
+		"bad-fields:isolation",
+		"no-verifier:verifier",
+		"bad-fields:script[0]",
+		"bad-fields:script[1]",
+		"bad-fields:script[1].timeout",
+		"bad-fields:expect[0].contains",
+		"bad-fields:expect[1]",
+	} {
+		if !got[want] {
+			t.Errorf("missing problem %s; got %v", want, got)
+		}
+	}
+	for _, p := range problems {
+		if !strings.HasPrefix(p.Error(), p.File+": ") {
+			t.Errorf("problem %q should start with its file", p.Error())
+		}
+	}
+}
+
+func TestValidateTasks_FieldChecksAfterParse(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTaskFile(t, tempDir, "slow", `
+difficulty: medum
+timeout: two minutes
+script:
+  - prompt: "List pods"
+`)
+
+	_, problems, _, err := NewLoader(tempDir).ValidateTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := make([]string, 0, len(problems))
+	for _, p := range problems {
+		fields = append(fields, p.Field)
+	}
+	if strings.Join(fields, ",") != "difficulty,timeout" {
+		t.Errorf("problem fields = %v, want [difficulty timeout]", fields)
+	}
+}
+
+func TestParseTaskTimeout(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "2m", want: 2 * time.Minute},
+		{in: "120", want: 2 * time.Minute},
+		{in: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseTaskTimeout(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseTaskTimeout(%q) = %v, %v; want %v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadTasks_RejectsUnknownField(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTaskFile(t, tempDir, "typo", `
+script:
+  - prompt: "List pods"
+expects:
+  - contains: "pod"
+`)
+
+	_, err := NewLoader(tempDir).LoadTasks()
+	if err == nil {
+		t.Fatal("LoadTasks() should fail on an unknown field")
+	}
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) || taskErr.Field != "expects" {
+		t.Errorf("error = %v, want a TaskError for field expects", err)
+	}
+	if !strings.Contains(err.Error(), filepath.Join("typo", "task.yaml")) {
+		t.Errorf("error %q should name the task file", err)
+	}
+}
+
+func TestValidateTasks_Scaffolded(t *testing.T) {
+	tempDir := t.TempDir()
+	if _, err := ScaffoldTask(tempDir, ScaffoldOptions{ID: "fix-crashloop"}); err != nil {
+		t.Fatalf("ScaffoldTask() error = %v", err)
+	}
+
+	_, problems, _, err := NewLoader(tempDir).ValidateTasks()
+	if err != nil || len(problems) > 0 {
+		t.Errorf("scaffolded task should validate cleanly, got %v %v", problems, err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/cloudbro-kube-ai/k13d/pkg/ai"
 	"github.com/cloudbro-kube-ai/k13d/pkg/ai/safety"
+	aitools "github.com/cloudbro-kube-ai/k13d/pkg/ai/tools"
 	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	"github.com/cloudbro-kube-ai/k13d/pkg/log"
@@ -49,12 +50,13 @@ func (c *CLI) Start() error {
 	}
 
 	// Initialize AI client
-	if c.cfg.LLM.Provider != "" {
+	if c.cfg.LLM.Provider != "" && c.cfg.AIEnabled() {
 		ac, err := ai.NewClient(&c.cfg.LLM)
 		if err == nil {
 			c.aiClient = ac
 		}
 	}
+	aitools.SetCommandGuard(safety.ToolGuard(c.toolApprovalPolicy))
 
 	// Initialize MCP client and connect to servers
 	c.initMCP()
@@ -189,6 +191,10 @@ func (c *CLI) handleModel(args []string) {
 		return
 	}
 	// Reinit AI client
+	if !c.cfg.AIEnabled() {
+		fmt.Printf("Switched to model: %s (AI assistant is disabled)\n", name)
+		return
+	}
 	ac, err := ai.NewClient(&c.cfg.LLM)
 	if err != nil {
 		fmt.Printf("Failed to initialize model '%s': %v\n", name, err)
@@ -451,6 +457,22 @@ func (c *CLI) handleAI(args []string) {
 	fmt.Println()
 }
 
+// toolApprovalPolicy returns the default approval policy with the command
+// restrictions (safe tools, verb and resource lists) from the config applied.
+func (c *CLI) toolApprovalPolicy() config.ToolApprovalPolicy {
+	policy := config.DefaultToolApprovalPolicy()
+	if c.cfg == nil {
+		return policy
+	}
+	restrictions := c.cfg.Authorization.ToolApproval
+	policy.SafeTools = restrictions.SafeTools
+	policy.AllowedVerbs = restrictions.AllowedVerbs
+	policy.DeniedVerbs = restrictions.DeniedVerbs
+	policy.AllowedResources = restrictions.AllowedResources
+	policy.DeniedResources = restrictions.DeniedResources
+	return policy
+}
+
 // toolApprovalCallback handles AI tool execution approval via stdin
 func (c *CLI) toolApprovalCallback(toolName string, argsJSON string) bool {
 	var args struct {
@@ -466,25 +488,25 @@ func (c *CLI) toolApprovalCallback(toolName string, argsJSON string) bool {
 		command = "kubectl " + command
 	}
 
-	enforcer := safety.NewDefaultPolicyEnforcer()
+	enforcer := safety.NewPolicyEnforcer(c.toolApprovalPolicy())
 	decision := enforcer.Evaluate(command)
 
+	// Blocked by policy; session auto-approve cannot override this
+	if !decision.Allowed {
+		fmt.Printf("\n[red]Command blocked: %s[-]\n", decision.BlockReason)
+		return false
+	}
+
 	// 세션 자동승인이 켜져 있으면 바로 승인
 	if c.sessionAutoApprove {
 		return true
 	}
 
 	// Auto-approve if policy says no approval needed
-	if decision.Allowed && !decision.RequiresApproval {
+	if !decision.RequiresApproval {
 		return true
 	}
 
-	// Blocked by policy
-	if !decision.Allowed {
-		fmt.Printf("\n[red]Command blocked: %s[-]\n", decision.BlockReason)
-		return false
-	}
-
 	// Requires approval - prompt user
 	fmt.Printf("\n[yellow]AI wants to execute:[-] [%s] %s\n", toolName, command)
 	for _, w := range decision.Warnings {
@@ -108,7 +108,7 @@ func (c *CLI) StartTUI() error {
 	if c.namespace == "" {
 		c.namespace = "default"
 	}
-	if c.cfg.LLM.Provider != "" {
+	if c.cfg.LLM.Provider != "" && c.cfg.AIEnabled() {
 		ac, err := ai.NewClient(&c.cfg.LLM)
 		if err == nil {
 			c.aiClient = ac
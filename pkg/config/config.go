@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/adrg/xdg"
 	"gopkg.in/yaml.v3"
@@ -28,8 +29,363 @@ type Config struct {
 	EnableAudit   bool                   `yaml:"enable_audit" json:"enable_audit"`
 	Language      string                 `yaml:"language" json:"language"`
 	BeginnerMode  bool                   `yaml:"beginner_mode" json:"beginner_mode"`
-	LogLevel      string                 `yaml:"log_level" json:"log_level"`
+	LogLevel      string                 `yaml:"log_level" json:"log_level"`   // debug, info, warn, error
+	LogFormat     string                 `yaml:"log_format" json:"log_format"` // text or json
 	Timezone      string                 `yaml:"timezone" json:"timezone"`
+	Theme         string                 `yaml:"theme" json:"theme"` // dark, light, high-contrast, or a skin name
+
+	// RestoreSession reopens the TUI at the last context, namespace, and
+	// resource view unless -n/-A is given. State lives in state.yaml.
+	RestoreSession bool `yaml:"restore_session" json:"restore_session"`
+
+	// RestorePortForwards saves the TUI's active port-forwards on exit, in
+	// port-forwards.yaml, and offers to start them again on the next launch.
+	RestorePortForwards bool `yaml:"restore_port_forwards" json:"restore_port_forwards"`
+
+	// ExcludedNamespaces are hidden from all-namespace views, the namespace
+	// cycler, and reports, e.g. [kube-system, "cattle-*"]; see
+	// NamespaceExcluded. They stay reachable with an explicit :ns.
+	ExcludedNamespaces []string `yaml:"excluded_namespaces,omitempty" json:"excluded_namespaces,omitempty"`
+
+	// BulkDeleteConfirmThreshold is the largest TUI multi-select delete that
+	// a button confirms; larger ones require typing the count. 0 uses
+	// DefaultBulkDeleteConfirmThreshold and a negative value never asks.
+	BulkDeleteConfirmThreshold int `yaml:"bulk_delete_confirm_threshold" json:"bulk_delete_confirm_threshold"`
+
+	// PauseRefreshInModals holds back watch-driven table refreshes while a
+	// modal or viewer such as logs or describe is open, and refreshes once
+	// when the last one closes
+	PauseRefreshInModals bool `yaml:"pause_refresh_in_modals" json:"pause_refresh_in_modals"`
+
+	// LogHighlight colors severities, HTTP 5xx statuses, and stack traces in
+	// the TUI log viewer
+	LogHighlight LogHighlightConfig `yaml:"log_highlight" json:"log_highlight"`
+
+	// RestartLogs configures the TUI shortcut that shows a pod's logs since
+	// its last restart next to the logs of the crashed container
+	RestartLogs RestartLogsConfig `yaml:"restart_logs" json:"restart_logs"`
+
+	// MultiCluster configures the :clusters fleet view
+	MultiCluster MultiClusterConfig `yaml:"multi_cluster" json:"multi_cluster"`
+
+	// Contexts groups and renames kubeconfig contexts in the TUI context
+	// switcher
+	Contexts ContextsConfig `yaml:"contexts" json:"contexts"`
+
+	// Reports tunes the web UI's cluster assessment reports
+	Reports ReportsConfig `yaml:"reports" json:"reports"`
+
+	// Drift compares a local manifest directory (e.g. a GitOps checkout)
+	// with the live cluster in the :drift view and the report
+	Drift DriftConfig `yaml:"drift" json:"drift"`
+
+	// Kubernetes tunes API client rate limiting and bulk listing
+	Kubernetes KubernetesConfig `yaml:"kubernetes" json:"kubernetes"`
+
+	// AuditReads records describe, YAML, and log views in the audit log
+	AuditReads AuditReadsConfig `yaml:"audit_reads" json:"audit_reads"`
+
+	// Web configures the web server started with --web
+	Web WebConfig `yaml:"web" json:"web"`
+
+	// SecurityScan schedules background security scans in web mode
+	SecurityScan SecurityScanConfig `yaml:"security_scan" json:"security_scan"`
+
+	// Disable turns the AI, security, and FinOps subsystems off for a
+	// lighter viewer; see AIEnabled, SecurityEnabled, and FinOpsEnabled
+	Disable DisableConfig `yaml:"disable" json:"disable"`
+
+	// Preflight runs startup checks of the cluster, RBAC, LLM, and database
+	// before the TUI or web server starts; see --preflight
+	Preflight PreflightConfig `yaml:"preflight" json:"preflight"`
+
+	// Profiles are named overlays of the settings above, selected with
+	// --profile or K13D_PROFILE; see ApplyProfile
+	Profiles map[string]yaml.Node `yaml:"profiles,omitempty" json:"-"`
+	// ActiveProfile is the profile applied at load time, if any
+	ActiveProfile string `yaml:"-" json:"active_profile,omitempty"`
+}
+
+// DefaultBulkDeleteConfirmThreshold is the BulkDeleteConfirmThreshold used
+// when none is set
+const DefaultBulkDeleteConfirmThreshold = 5
+
+// BulkDeleteNeedsTyping reports whether deleting count selected resources
+// must be confirmed by typing the count
+func (c *Config) BulkDeleteNeedsTyping(count int) bool {
+	threshold := DefaultBulkDeleteConfirmThreshold
+	if c != nil && c.BulkDeleteConfirmThreshold != 0 {
+		threshold = c.BulkDeleteConfirmThreshold
+	}
+	return threshold > 0 && count > threshold
+}
+
+// LogHighlightConfig configures log viewer highlighting
+type LogHighlightConfig struct {
+	// Disabled opens the log viewer without colors; c toggles them
+	Disabled bool `yaml:"disabled" json:"disabled"`
+	// Patterns are extra regular expressions to color, matched before the
+	// built-in ones
+	Patterns []LogHighlightPattern `yaml:"patterns,omitempty" json:"patterns,omitempty"`
+}
+
+// LogHighlightPattern colors the text matching Pattern
+type LogHighlightPattern struct {
+	Pattern string `yaml:"pattern" json:"pattern"`
+	// Color is a tview style such as "orange" or "white:red:b"; empty uses aqua
+	Color string `yaml:"color,omitempty" json:"color,omitempty"`
+}
+
+// DefaultRestartLogsShortcut opens the logs since the last restart when
+// RestartLogsConfig.Shortcut is empty
+const DefaultRestartLogsShortcut = "Shift-L"
+
+// DefaultRestartLogsPreviousLines is how much of the crashed container's log
+// is shown when RestartLogsConfig.PreviousLines is 0
+const DefaultRestartLogsPreviousLines = 100
+
+// RestartLogsConfig configures the "since last restart" log view
+type RestartLogsConfig struct {
+	// Shortcut opens the view from the pod list, e.g. "Shift-L" or "Alt-r";
+	// built-in keys take precedence
+	Shortcut string `yaml:"shortcut,omitempty" json:"shortcut,omitempty"`
+	// PreviousLines is how many of the crashed container's last lines to show
+	PreviousLines int64 `yaml:"previous_lines,omitempty" json:"previous_lines,omitempty"`
+	// Explain asks the AI assistant why the container crashed as soon as
+	// the logs load; x in the view asks on demand
+	Explain bool `yaml:"explain" json:"explain"`
+}
+
+// EffectiveShortcut returns Shortcut, or DefaultRestartLogsShortcut when unset
+func (c RestartLogsConfig) EffectiveShortcut() string {
+	if c.Shortcut != "" {
+		return c.Shortcut
+	}
+	return DefaultRestartLogsShortcut
+}
+
+// EffectivePreviousLines returns PreviousLines, or
+// DefaultRestartLogsPreviousLines when unset
+func (c RestartLogsConfig) EffectivePreviousLines() int64 {
+	if c.PreviousLines > 0 {
+		return c.PreviousLines
+	}
+	return DefaultRestartLogsPreviousLines
+}
+
+// WebConfig holds web server settings
+type WebConfig struct {
+	TLS     WebTLSConfig     `yaml:"tls" json:"tls"`
+	Session WebSessionConfig `yaml:"session" json:"session"`
+	// AuthMode is the default for --auth-mode: token, local, ldap, or oidc
+	AuthMode string `yaml:"auth_mode,omitempty" json:"auth_mode,omitempty"`
+}
+
+// WebTLSConfig serves the web UI over HTTPS. The certificate and key files
+// are reloaded when they change, so rotated certificates (e.g. from
+// cert-manager) are picked up without a restart.
+type WebTLSConfig struct {
+	// CertFile and KeyFile are PEM files; both must be set to enable TLS
+	CertFile string `yaml:"cert_file" json:"cert_file"`
+	KeyFile  string `yaml:"key_file" json:"key_file"`
+	// SelfSigned serves an in-memory self-signed certificate when no
+	// certificate files are set. Intended for local development only.
+	SelfSigned bool `yaml:"self_signed" json:"self_signed"`
+}
+
+// Enabled reports whether the web server should serve HTTPS
+func (c WebTLSConfig) Enabled() bool {
+	return (c.CertFile != "" && c.KeyFile != "") || c.SelfSigned
+}
+
+// WebSessionConfig bounds how long a web login stays valid. Both values are
+// Go durations such as "30m" or "8h".
+type WebSessionConfig struct {
+	// IdleTimeout ends a session after this long without user activity;
+	// empty or "0" disables it (default)
+	IdleTimeout string `yaml:"idle_timeout" json:"idle_timeout"`
+	// MaxLifetime ends a session this long after login, however active;
+	// empty uses the server default of 24h
+	MaxLifetime string `yaml:"max_lifetime" json:"max_lifetime"`
+}
+
+// Timeouts parses IdleTimeout and MaxLifetime, returning zero for values
+// that are not set
+func (c WebSessionConfig) Timeouts() (idle, maxLifetime time.Duration, err error) {
+	parse := func(name, v string) (time.Duration, error) {
+		if strings.TrimSpace(v) == "" {
+			return 0, nil
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(v))
+		if err != nil {
+			return 0, fmt.Errorf("invalid web session %s %q: %w", name, v, err)
+		}
+		if d < 0 {
+			return 0, fmt.Errorf("invalid web session %s %q: must not be negative", name, v)
+		}
+		return d, nil
+	}
+	if idle, err = parse("idle_timeout", c.IdleTimeout); err != nil {
+		return 0, 0, err
+	}
+	if maxLifetime, err = parse("max_lifetime", c.MaxLifetime); err != nil {
+		return 0, 0, err
+	}
+	return idle, maxLifetime, nil
+}
+
+// SecurityScanConfig schedules security scans in web mode. Results are stored
+// in the security_scans table, and reports reuse the latest one while it is
+// newer than Interval instead of scanning on demand.
+type SecurityScanConfig struct {
+	// Interval runs a scan this often, as a Go duration such as "6h"; empty
+	// or "0" disables scheduled scans (default). The minimum is 5m.
+	Interval string `yaml:"interval" json:"interval"`
+	// Full adds Trivy image vulnerability scanning; otherwise scheduled
+	// scans are quick scans
+	Full bool `yaml:"full" json:"full"`
+}
+
+// MinSecurityScanInterval is the shortest allowed scheduled scan interval
+const MinSecurityScanInterval = 5 * time.Minute
+
+// ScanInterval parses Interval, returning zero when scheduling is disabled
+func (c SecurityScanConfig) ScanInterval() (time.Duration, error) {
+	v := strings.TrimSpace(c.Interval)
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid security_scan interval %q: %w", c.Interval, err)
+	}
+	if d == 0 {
+		return 0, nil
+	}
+	if d < MinSecurityScanInterval {
+		return 0, fmt.Errorf("invalid security_scan interval %q: must be at least %s", c.Interval, MinSecurityScanInterval)
+	}
+	return d, nil
+}
+
+// AuditReadsConfig controls read-access auditing. Reads are recorded with
+// the "read" action type; list views are never recorded.
+type AuditReadsConfig struct {
+	// Enabled turns on read auditing (default: false)
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// Resources limits auditing to these resource types, e.g. [secrets];
+	// empty audits reads of every type
+	Resources []string `yaml:"resources" json:"resources"`
+}
+
+// Covers reports whether a read of the given resource type (plural, e.g.
+// "secrets") should be audited
+func (c AuditReadsConfig) Covers(resource string) bool {
+	if !c.Enabled {
+		return false
+	}
+	if len(c.Resources) == 0 {
+		return true
+	}
+	resource = strings.ToLower(resource)
+	for _, r := range c.Resources {
+		r = strings.ToLower(strings.TrimSpace(r))
+		if r == resource || r+"s" == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// KubernetesConfig controls how hard k13d drives the Kubernetes API server.
+// Zero values fall back to the client defaults.
+type KubernetesConfig struct {
+	// QPS is the client-side request rate limit per second (default: 50)
+	QPS float32 `yaml:"qps" json:"qps"`
+	// Burst is how many requests may exceed QPS momentarily (default: 100)
+	Burst int `yaml:"burst" json:"burst"`
+	// MaxConcurrency bounds how many namespaces bulk listing such as report
+	// generation queries at once (default: 8)
+	MaxConcurrency int `yaml:"max_concurrency" json:"max_concurrency"`
+}
+
+// MultiClusterConfig selects the clusters shown in the multi-cluster view
+type MultiClusterConfig struct {
+	// Contexts limits the view to these kubeconfig contexts; empty shows them all
+	Contexts []string `yaml:"contexts" json:"contexts"`
+	// TimeoutSeconds bounds how long each cluster may take to answer (default: 10)
+	TimeoutSeconds int `yaml:"timeout_seconds" json:"timeout_seconds"`
+}
+
+// DriftConfig locates the manifests drift detection compares against
+type DriftConfig struct {
+	// ManifestDir is scanned recursively for YAML and JSON manifests; empty
+	// disables the report's drift section
+	ManifestDir string `yaml:"manifest_dir,omitempty" json:"manifest_dir,omitempty"`
+	// DefaultNamespace applies to namespaced manifests without one (default: "default")
+	DefaultNamespace string `yaml:"default_namespace,omitempty" json:"default_namespace,omitempty"`
+}
+
+// ReportsConfig controls what cluster assessment reports include
+type ReportsConfig struct {
+	// EventLimit caps how many events a report lists; 0 lists them all
+	// (default: 50). Event category counts always cover every event.
+	EventLimit int `yaml:"event_limit" json:"event_limit"`
+	// IncludeNormalEvents lists Normal events after the Warning events
+	IncludeNormalEvents bool `yaml:"include_normal_events" json:"include_normal_events"`
+	// PageSize is the default number of pods, deployments, services, and
+	// images per JSON or CSV report page; 0 returns them all (default)
+	PageSize int `yaml:"page_size" json:"page_size"`
+	// HTML report display caps (defaults: 50 pods, 25 images, 25 events);
+	// 0 shows every item
+	HTMLPodLimit   int `yaml:"html_pod_limit" json:"html_pod_limit"`
+	HTMLImageLimit int `yaml:"html_image_limit" json:"html_image_limit"`
+	HTMLEventLimit int `yaml:"html_event_limit" json:"html_event_limit"`
+	// CostAllocationLabel is a pod label key, e.g. team or cost-center, whose
+	// values group FinOps cost across namespaces alongside the per-namespace
+	// breakdown. Pods without the label are counted as "unallocated".
+	CostAllocationLabel string `yaml:"cost_allocation_label,omitempty" json:"cost_allocation_label,omitempty"`
+	// DefaultSections are the sections a report includes when the request has
+	// no sections parameter, e.g. [nodes, namespaces, workloads]. Empty
+	// includes every section except security_full.
+	DefaultSections []string `yaml:"default_sections,omitempty" json:"default_sections,omitempty"`
+	// Branding replaces the HTML report's title, logo, and footer
+	Branding ReportBrandingConfig `yaml:"branding,omitempty" json:"branding"`
+	// Redact replaces these identifiers with hashed pseudonyms in every
+	// report format, for sharing assessments outside the team: any of ips,
+	// nodes, and namespaces. The redact query parameter overrides it.
+	Redact []string `yaml:"redact,omitempty" json:"redact,omitempty"`
+	// RedactionKey keys the pseudonym hashes so a name maps to the same
+	// pseudonym in every report. Empty uses a random key per server run.
+	RedactionKey string `yaml:"redaction_key,omitempty" json:"-"`
+	// Anomalies tunes the checks of the metrics history
+	Anomalies AnomalyConfig `yaml:"anomalies" json:"anomalies"`
+}
+
+// AnomalyConfig tunes the anomaly checks of the stored cluster metrics that
+// reports and the TUI briefing show. Zero thresholds use the defaults.
+type AnomalyConfig struct {
+	// MemoryGrowthPercent flags memory usage that rose steadily by at least
+	// this much over the checked window, a likely leak (default: 20)
+	MemoryGrowthPercent float64 `yaml:"memory_growth_percent,omitempty" json:"memory_growth_percent,omitempty"`
+	// PodDropPercent flags a fall in running pods between two samples of at
+	// least this much (default: 30)
+	PodDropPercent float64 `yaml:"pod_drop_percent,omitempty" json:"pod_drop_percent,omitempty"`
+	// CPUSaturationPercent and CPUSaturationMinutes flag CPU usage at or
+	// above this share of capacity for at least this long (defaults: 90, 15)
+	CPUSaturationPercent float64 `yaml:"cpu_saturation_percent,omitempty" json:"cpu_saturation_percent,omitempty"`
+	CPUSaturationMinutes int     `yaml:"cpu_saturation_minutes,omitempty" json:"cpu_saturation_minutes,omitempty"`
+	// Briefing adds the anomalies of the last hour to the TUI briefing
+	// alerts (default: true)
+	Briefing bool `yaml:"briefing" json:"briefing"`
+}
+
+// ReportBrandingConfig customizes the HTML report for client-facing
+// assessments. Empty fields keep the k13d defaults.
+type ReportBrandingConfig struct {
+	Title  string `yaml:"title,omitempty" json:"title,omitempty"`
+	Logo   string `yaml:"logo,omitempty" json:"logo,omitempty"` // https URL or data:image URI
+	Footer string `yaml:"footer,omitempty" json:"footer,omitempty"`
 }
 
 // RuntimeSourceInfo describes where runtime configuration came from.
@@ -127,14 +483,44 @@ type AuthorizationConfig struct {
 	Impersonation ImpersonationConfigYAML `yaml:"impersonation" json:"impersonation"`
 	// JWT configuration
 	JWT JWTConfigYAML `yaml:"jwt" json:"jwt"`
+	// LDAP configures the web server's LDAP login backend and group-to-role mapping
+	LDAP LDAPConfigYAML `yaml:"ldap" json:"ldap"`
 	// ToolApproval controls AI tool execution approval policy
 	ToolApproval ToolApprovalPolicy `yaml:"tool_approval" json:"tool_approval"`
+	// SecretReveal controls revealing decoded Secret values in the TUI YAML viewer
+	SecretReveal SecretRevealPolicy `yaml:"secret_reveal" json:"secret_reveal"`
+}
+
+// SecretRevealPolicy controls who may reveal decoded Secret values. Secret
+// data is masked until revealed, and every reveal is recorded in the audit log.
+type SecretRevealPolicy struct {
+	// Disabled blocks secret reveal for every role (default: false)
+	Disabled bool `yaml:"disabled" json:"disabled"`
+	// DeniedRoles lists roles that may not reveal secret values (e.g., ["viewer"])
+	DeniedRoles []string `yaml:"denied_roles" json:"denied_roles"`
+}
+
+// AllowsRole reports whether role may reveal secret values under this policy
+func (p SecretRevealPolicy) AllowsRole(role string) bool {
+	if p.Disabled {
+		return false
+	}
+	for _, denied := range p.DeniedRoles {
+		if strings.EqualFold(denied, role) {
+			return false
+		}
+	}
+	return true
 }
 
 // ToolApprovalPolicy controls which AI tool commands require user approval
 type ToolApprovalPolicy struct {
 	// AutoApproveReadOnly allows read-only commands without approval (default: false)
 	AutoApproveReadOnly bool `yaml:"auto_approve_read_only" json:"auto_approve_read_only"`
+	// AutoApprove overrides the flags below for allowed commands: "reads" runs read-only
+	// commands without approval and always asks for anything else, "all" asks only for
+	// dangerous and interactive commands. Empty leaves approval to the flags (default: "")
+	AutoApprove string `yaml:"auto_approve" json:"auto_approve"`
 	// RequireApprovalForWrite requires approval for write operations (default: true)
 	RequireApprovalForWrite bool `yaml:"require_approval_for_write" json:"require_approval_for_write"`
 	// RequireApprovalForUnknown requires approval for unknown/unrecognized commands (default: true)
@@ -145,6 +531,29 @@ type ToolApprovalPolicy struct {
 	BlockedPatterns []string `yaml:"blocked_patterns" json:"blocked_patterns"`
 	// ApprovalTimeoutSeconds is the timeout for waiting for user approval (default: 60)
 	ApprovalTimeoutSeconds int `yaml:"approval_timeout_seconds" json:"approval_timeout_seconds"`
+	// SafeTools limits the AI to read-only kubectl verbs and disables the bash tool (default: false)
+	SafeTools bool `yaml:"safe_tools" json:"safe_tools"`
+	// AllowedVerbs, when set, is the only kubectl verbs the AI may run (e.g., ["get", "describe", "logs"])
+	AllowedVerbs []string `yaml:"allowed_verbs" json:"allowed_verbs"`
+	// DeniedVerbs lists kubectl verbs the AI may never run (e.g., ["delete", "drain"])
+	DeniedVerbs []string `yaml:"denied_verbs" json:"denied_verbs"`
+	// AllowedResources, when set, is the only resource types kubectl commands may target
+	AllowedResources []string `yaml:"allowed_resources" json:"allowed_resources"`
+	// DeniedResources lists resource types kubectl commands may never target (e.g., ["secrets"])
+	DeniedResources []string `yaml:"denied_resources" json:"denied_resources"`
+}
+
+// Auto-approve modes for ToolApprovalPolicy.AutoApprove
+const (
+	AutoApproveReads = "reads"
+	AutoApproveAll   = "all"
+)
+
+// HasToolRestrictions reports whether the policy limits which commands the AI
+// may run, independent of approval.
+func (p ToolApprovalPolicy) HasToolRestrictions() bool {
+	return p.SafeTools || len(p.AllowedVerbs) > 0 || len(p.DeniedVerbs) > 0 ||
+		len(p.AllowedResources) > 0 || len(p.DeniedResources) > 0
 }
 
 // DefaultToolApprovalPolicy returns the default tool approval policy
@@ -192,6 +601,33 @@ type JWTConfigYAML struct {
 	RefreshWindow string `yaml:"refresh_window" json:"refresh_window"` // e.g., "15m"
 }
 
+// LDAPConfigYAML is the YAML-friendly LDAP config
+type LDAPConfigYAML struct {
+	Enabled           bool     `yaml:"enabled" json:"enabled"`
+	Host              string   `yaml:"host" json:"host"`
+	Port              int      `yaml:"port" json:"port"`
+	UseTLS            bool     `yaml:"use_tls" json:"use_tls"`
+	StartTLS          bool     `yaml:"start_tls" json:"start_tls"`
+	InsecureSkipTLS   bool     `yaml:"insecure_skip_tls" json:"insecure_skip_tls"`
+	BindDN            string   `yaml:"bind_dn" json:"bind_dn"`
+	BindPassword      string   `yaml:"bind_password" json:"-"` // never expose in JSON
+	BaseDN            string   `yaml:"base_dn" json:"base_dn"`
+	UserSearchFilter  string   `yaml:"user_search_filter" json:"user_search_filter"`
+	UserSearchBase    string   `yaml:"user_search_base" json:"user_search_base"`
+	GroupSearchBase   string   `yaml:"group_search_base" json:"group_search_base"`
+	GroupSearchFilter string   `yaml:"group_search_filter" json:"group_search_filter"`
+	AdminGroups       []string `yaml:"admin_groups" json:"admin_groups"`
+	UserGroups        []string `yaml:"user_groups" json:"user_groups"`
+	ViewerGroups      []string `yaml:"viewer_groups" json:"viewer_groups"`
+	// GroupMappings maps a group DN or CN to a k13d role (admin, user/editor, viewer, or a custom role)
+	GroupMappings map[string]string `yaml:"group_mappings" json:"group_mappings"`
+	// DefaultRole is assigned to authenticated users whose groups match no mapping (default: viewer)
+	DefaultRole     string `yaml:"default_role" json:"default_role"`
+	UsernameAttr    string `yaml:"username_attr" json:"username_attr"`
+	EmailAttr       string `yaml:"email_attr" json:"email_attr"`
+	DisplayNameAttr string `yaml:"display_name_attr" json:"display_name_attr"`
+}
+
 // PrometheusConfig holds Prometheus integration settings
 type PrometheusConfig struct {
 	// ExposeMetrics enables the /metrics endpoint for Prometheus scraping
@@ -242,7 +678,8 @@ type LLMConfig struct {
 	Model           string  `yaml:"model" json:"model"`
 	Endpoint        string  `yaml:"endpoint" json:"endpoint"`
 	APIKey          string  `yaml:"api_key" json:"api_key,omitempty"`
-	Region          string  `yaml:"region" json:"region"`                     // For AWS Bedrock
+	Region          string  `yaml:"region" json:"region"`                     // For AWS Bedrock; the location for Vertex AI
+	Project         string  `yaml:"project" json:"project,omitempty"`         // For Vertex AI: the Google Cloud project ID
 	AzureDeployment string  `yaml:"azure_deployment" json:"azure_deployment"` // For Azure OpenAI
 	SkipTLSVerify   bool    `yaml:"skip_tls_verify" json:"skip_tls_verify"`
 	RetryEnabled    bool    `yaml:"retry_enabled" json:"retry_enabled"`
@@ -250,16 +687,45 @@ type LLMConfig struct {
 	MaxBackoff      float64 `yaml:"max_backoff" json:"max_backoff"`           // seconds
 	UseJSONMode     bool    `yaml:"use_json_mode" json:"use_json_mode"`       // Fallback for models without tool calling
 	ReasoningEffort string  `yaml:"reasoning_effort" json:"reasoning_effort"` // For Solar Pro2: "minimal" (default) or "high"
-	Temperature     float64 `yaml:"temperature" json:"temperature"`           // LLM temperature (0.0-2.0)
-	MaxTokens       int     `yaml:"max_tokens" json:"max_tokens"`             // Max output tokens
+	Temperature     float64 `yaml:"temperature" json:"temperature"`           // LLM temperature (0.0-2.0), sent with every request
+	MaxTokens       int     `yaml:"max_tokens" json:"max_tokens"`             // Max output tokens per request (0 = provider default)
 	MaxIterations   int     `yaml:"max_iterations" json:"max_iterations"`     // Agent loop max iterations (1-30)
 	EnableBashTool  bool    `yaml:"enable_bash_tool" json:"enable_bash_tool"` // Expose bash tool to agentic AI (default: false)
 	EnableMCPTools  bool    `yaml:"enable_mcp_tools" json:"enable_mcp_tools"` // Expose configured MCP tools to agentic AI (default: false)
+	LogPayloads     bool    `yaml:"log_payloads" json:"log_payloads"`         // Log redacted provider request/response bodies at debug level (default: false)
+	KeepAlive       string  `yaml:"keep_alive" json:"keep_alive,omitempty"`   // Ollama: keep the model loaded between requests, e.g. "30m" or "-1" (forever)
+	// MaxRepeatedToolCalls stops a tool loop when the model makes the same
+	// tool call (same tool and arguments) this many times for one question.
+	// 0 uses the default of 3; -1 turns the check off.
+	MaxRepeatedToolCalls int `yaml:"max_repeated_tool_calls" json:"max_repeated_tool_calls,omitempty"`
+	// MaxConcurrentRequests is how many AI requests are sent at once; the
+	// rest wait their turn. 0 uses the default of 3, except for the web
+	// server's shared client, which has no limit; -1 turns the limit off.
+	MaxConcurrentRequests int `yaml:"max_concurrent_requests" json:"max_concurrent_requests,omitempty"`
+	// ScopePrompt is prepended to each AI question with {context},
+	// {namespace}, and {resource} filled in. Empty uses the built-in prompt;
+	// "off" sends none.
+	ScopePrompt string `yaml:"scope_prompt" json:"scope_prompt,omitempty"`
+	// Fallbacks are tried in order when the provider above fails with a
+	// retryable or connection error, e.g. a cloud model backed by local Ollama.
+	Fallbacks []LLMFallback `yaml:"fallbacks,omitempty" json:"fallbacks,omitempty"`
 	// Discovery indicates this config is used for model discovery (ListModels).
 	// It is not persisted to disk or exposed via JSON APIs.
 	Discovery bool `yaml:"-" json:"-"`
 }
 
+// LLMFallback is a provider tried when the primary LLM provider is unavailable
+type LLMFallback struct {
+	Provider        string `yaml:"provider" json:"provider"`
+	Model           string `yaml:"model" json:"model"`
+	Endpoint        string `yaml:"endpoint" json:"endpoint,omitempty"`
+	APIKey          string `yaml:"api_key" json:"-"`
+	Region          string `yaml:"region" json:"region,omitempty"`   // For AWS Bedrock; the location for Vertex AI
+	Project         string `yaml:"project" json:"project,omitempty"` // For Vertex AI
+	AzureDeployment string `yaml:"azure_deployment" json:"azure_deployment,omitempty"`
+	SkipTLSVerify   bool   `yaml:"skip_tls_verify" json:"skip_tls_verify,omitempty"`
+}
+
 // ModelProfile represents a saved LLM model configuration
 type ModelProfile struct {
 	Name            string `yaml:"name" json:"name"`                   // Profile name (e.g., "gpt-4-turbo", "claude-3")
@@ -267,7 +733,8 @@ type ModelProfile struct {
 	Model           string `yaml:"model" json:"model"`                 // Model identifier
 	Endpoint        string `yaml:"endpoint" json:"endpoint,omitempty"` // Custom endpoint
 	APIKey          string `yaml:"api_key" json:"api_key,omitempty"`   // API key (exposed for model profile management)
-	Region          string `yaml:"region" json:"region,omitempty"`     // For AWS Bedrock
+	Region          string `yaml:"region" json:"region,omitempty"`     // For AWS Bedrock; the location for Vertex AI
+	Project         string `yaml:"project" json:"project,omitempty"`   // For Vertex AI
 	AzureDeployment string `yaml:"azure_deployment" json:"azure_deployment,omitempty"`
 	SkipTLSVerify   bool   `yaml:"skip_tls_verify" json:"skip_tls_verify,omitempty"` // For self-signed certs
 	Description     string `yaml:"description" json:"description,omitempty"`         // User description
@@ -309,6 +776,22 @@ func (c *Config) NormalizeLegacyLLMProviders() bool {
 
 	normalizeLLMConfig(&c.LLM)
 
+	for i := range c.LLM.Fallbacks {
+		fb := &c.LLM.Fallbacks[i]
+		normalizedProvider := NormalizeLLMProvider(fb.Provider)
+		if normalizedProvider == fb.Provider {
+			continue
+		}
+		fb.Provider = normalizedProvider
+		if strings.TrimSpace(fb.Endpoint) == "" {
+			fb.Endpoint = DefaultOllamaEndpoint
+		}
+		if strings.TrimSpace(fb.Model) == "" {
+			fb.Model = DefaultOllamaModel
+		}
+		changed = true
+	}
+
 	for i := range c.Models {
 		normalizedProvider := NormalizeLLMProvider(c.Models[i].Provider)
 		if normalizedProvider == c.Models[i].Provider {
@@ -330,6 +813,23 @@ func (c *Config) NormalizeLegacyLLMProviders() bool {
 // MCPConfig holds MCP server configurations
 type MCPConfig struct {
 	Servers []MCPServer `yaml:"servers" json:"servers"`
+	// Serve limits the tools k13d exposes when it runs as an MCP server (--mcp)
+	Serve MCPServeConfig `yaml:"serve" json:"serve"`
+}
+
+// MCPServeConfig selects the tools k13d's own MCP server registers. A tool
+// is exposed when it is in Tools (or Tools is empty), not in DisabledTools,
+// and, with ReadOnly, cannot change the cluster.
+type MCPServeConfig struct {
+	// Tools, when set, is the only tools exposed, e.g. [list_resources, get_pod_logs]
+	Tools []string `yaml:"tools,omitempty" json:"tools,omitempty"`
+	// DisabledTools are never exposed, e.g. [bash]
+	DisabledTools []string `yaml:"disabled_tools,omitempty" json:"disabled_tools,omitempty"`
+	// ReadOnly drops tools that can mutate the cluster, such as kubectl and bash
+	ReadOnly bool `yaml:"read_only" json:"read_only"`
+	// Token is the bearer token HTTP clients must send with --mcp-transport
+	// http; without one the server listens on localhost only
+	Token string `yaml:"token,omitempty" json:"-"`
 }
 
 // MCPServer represents an MCP server configuration
@@ -387,8 +887,35 @@ func GetRuntimeSourceInfo() RuntimeSourceInfo {
 	}
 
 	for _, key := range []string{
+		"K13D_PROFILE",
 		"K13D_JWT_SECRET",
 		"K13D_DEFAULT_ROLE",
+		"K13D_DISABLE_SECRET_REVEAL",
+		"K13D_SAFE_TOOLS",
+		"K13D_LLM_LOG_PAYLOADS",
+		"K13D_LLM_KEEP_ALIVE",
+		"K13D_LLM_SCOPE_PROMPT",
+		"K13D_THEME",
+		"K13D_LOG_LEVEL",
+		"K13D_LOG_FORMAT",
+		"K13D_RESTORE_SESSION",
+		"K13D_EXCLUDED_NAMESPACES",
+		"K13D_NO_AI",
+		"K13D_NO_SECURITY",
+		"K13D_NO_FINOPS",
+		"K13D_PREFLIGHT",
+		"K13D_DRIFT_DIR",
+		"K13D_KUBE_QPS",
+		"K13D_KUBE_BURST",
+		"K13D_KUBE_MAX_CONCURRENCY",
+		"K13D_AUDIT_READS",
+		"K13D_MCP_READ_ONLY",
+		"K13D_MCP_TOKEN",
+		"K13D_TLS_CERT",
+		"K13D_TLS_KEY",
+		"K13D_TLS_SELF_SIGNED",
+		"K13D_SESSION_IDLE_TIMEOUT",
+		"K13D_SESSION_MAX_LIFETIME",
 		"K13D_GITHUB_AUTOMATION_REQUIRE_ORG_MEMBER",
 		"K13D_GITHUB_AUTOMATION_MENTION_ORG_MEMBERS",
 		"K13D_GITHUB_AUTOMATION_MENTION_MAX_MEMBERS",
@@ -585,9 +1112,19 @@ func NewDefaultConfig() *Config {
 		Language:     "ko",
 		BeginnerMode: true,
 		LogLevel:     "debug",
+		LogFormat:    "text",
 		Timezone:     "auto",
+		Theme:        ThemeDark,
 		ReportPath:   "report.md",
 		EnableAudit:  true,
+
+		RestoreSession:             true,
+		RestorePortForwards:        true,
+		BulkDeleteConfirmThreshold: DefaultBulkDeleteConfirmThreshold,
+		PauseRefreshInModals:       true,
+		MultiCluster:               MultiClusterConfig{TimeoutSeconds: 10},
+		Reports:                    ReportsConfig{EventLimit: 50, HTMLPodLimit: 50, HTMLImageLimit: 25, HTMLEventLimit: 25, Anomalies: AnomalyConfig{Briefing: true}},
+		Kubernetes:                 KubernetesConfig{QPS: 50, Burst: 100, MaxConcurrency: 8},
 	}
 }
 
@@ -615,6 +1152,7 @@ func (c *Config) SetActiveModel(name string) bool {
 			c.LLM.Endpoint = m.Endpoint
 			c.LLM.APIKey = m.APIKey
 			c.LLM.Region = m.Region
+			c.LLM.Project = m.Project
 			c.LLM.AzureDeployment = m.AzureDeployment
 			c.LLM.SkipTLSVerify = m.SkipTLSVerify
 			return true
@@ -636,6 +1174,7 @@ func (c *Config) SyncActiveModelProfileFromLLM() bool {
 		c.Models[i].Endpoint = c.LLM.Endpoint
 		c.Models[i].APIKey = c.LLM.APIKey
 		c.Models[i].Region = c.LLM.Region
+		c.Models[i].Project = c.LLM.Project
 		c.Models[i].AzureDeployment = c.LLM.AzureDeployment
 		c.Models[i].SkipTLSVerify = c.LLM.SkipTLSVerify
 		return true
@@ -758,27 +1297,23 @@ func (c *Config) IsPersistenceEnabled() bool {
 
 func LoadConfig() (*Config, error) {
 	path := ensurePrimaryConfigPath()
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		cfg := NewDefaultConfig()
-		applyEnvOverrides(cfg)
-		cfg.NormalizeLegacyLLMProviders()
-		return cfg, nil
-	}
-
-	data, err := os.ReadFile(path)
-	if err != nil {
-		cfg := NewDefaultConfig()
-		applyEnvOverrides(cfg)
-		cfg.NormalizeLegacyLLMProviders()
-		return cfg, nil // Fail gracefully to defaults
+	cfg := NewDefaultConfig()
+	if _, err := os.Stat(path); err == nil {
+		if data, err := os.ReadFile(path); err == nil { // Unreadable files fail gracefully to defaults
+			if err := yaml.Unmarshal(data, cfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: config file exists but failed to parse: %v (using defaults)\n", err)
+				cfg = NewDefaultConfig()
+			} else {
+				expandEnvPlaceholders(cfg)
+			}
+		}
 	}
 
-	cfg := NewDefaultConfig()
-	if err := yaml.Unmarshal(data, cfg); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: config file exists but failed to parse: %v (using defaults)\n", err)
-		cfg = NewDefaultConfig()
-	} else {
-		expandEnvPlaceholders(cfg)
+	// A profile sits between the file and environment overrides
+	if name := strings.TrimSpace(os.Getenv("K13D_PROFILE")); name != "" {
+		if err := cfg.ApplyProfile(name); err != nil {
+			return nil, err
+		}
 	}
 
 	applyEnvOverrides(cfg)
@@ -845,6 +1380,95 @@ func applyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("K13D_DEFAULT_ROLE"); v != "" {
 		cfg.Authorization.DefaultTUIRole = v
 	}
+	if v := os.Getenv("K13D_DISABLE_SECRET_REVEAL"); v != "" {
+		cfg.Authorization.SecretReveal.Disabled = strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "yes")
+	}
+	if v := os.Getenv("K13D_SAFE_TOOLS"); v != "" {
+		cfg.Authorization.ToolApproval.SafeTools = strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "yes")
+	}
+	if v := os.Getenv("K13D_LLM_LOG_PAYLOADS"); v != "" {
+		cfg.LLM.LogPayloads = strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "yes")
+	}
+	if v := os.Getenv("K13D_LLM_KEEP_ALIVE"); v != "" {
+		cfg.LLM.KeepAlive = v
+	}
+	if v := os.Getenv("K13D_LLM_SCOPE_PROMPT"); v != "" {
+		cfg.LLM.ScopePrompt = v
+	}
+	if v := os.Getenv("K13D_THEME"); v != "" {
+		cfg.Theme = v
+	}
+	if v := os.Getenv("K13D_LOG_LEVEL"); v != "" {
+		cfg.LogLevel = v
+	}
+	if v := os.Getenv("K13D_LOG_FORMAT"); v != "" {
+		cfg.LogFormat = v
+	}
+	if v := os.Getenv("K13D_RESTORE_SESSION"); v != "" {
+		cfg.RestoreSession = strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "yes")
+	}
+	if v := os.Getenv("K13D_EXCLUDED_NAMESPACES"); v != "" {
+		cfg.ExcludedNamespaces = nil
+		for _, ns := range strings.Split(v, ",") {
+			if ns = strings.TrimSpace(ns); ns != "" {
+				cfg.ExcludedNamespaces = append(cfg.ExcludedNamespaces, ns)
+			}
+		}
+	}
+	if v := os.Getenv("K13D_NO_AI"); v != "" {
+		cfg.Disable.AI = strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "yes")
+	}
+	if v := os.Getenv("K13D_NO_SECURITY"); v != "" {
+		cfg.Disable.Security = strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "yes")
+	}
+	if v := os.Getenv("K13D_NO_FINOPS"); v != "" {
+		cfg.Disable.FinOps = strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "yes")
+	}
+	if v := os.Getenv("K13D_PREFLIGHT"); v != "" {
+		cfg.Preflight.Enabled = strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "yes")
+	}
+	if v := os.Getenv("K13D_DRIFT_DIR"); v != "" {
+		cfg.Drift.ManifestDir = v
+	}
+	if v := os.Getenv("K13D_MCP_READ_ONLY"); v != "" {
+		cfg.MCP.Serve.ReadOnly = strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "yes")
+	}
+	if v := os.Getenv("K13D_MCP_TOKEN"); v != "" {
+		cfg.MCP.Serve.Token = v
+	}
+	if v := os.Getenv("K13D_AUDIT_READS"); v != "" {
+		cfg.AuditReads.Enabled = strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "yes")
+	}
+	if v := os.Getenv("K13D_TLS_CERT"); v != "" {
+		cfg.Web.TLS.CertFile = v
+	}
+	if v := os.Getenv("K13D_TLS_KEY"); v != "" {
+		cfg.Web.TLS.KeyFile = v
+	}
+	if v := os.Getenv("K13D_TLS_SELF_SIGNED"); v != "" {
+		cfg.Web.TLS.SelfSigned = strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "yes")
+	}
+	if v := os.Getenv("K13D_SESSION_IDLE_TIMEOUT"); v != "" {
+		cfg.Web.Session.IdleTimeout = v
+	}
+	if v := os.Getenv("K13D_SESSION_MAX_LIFETIME"); v != "" {
+		cfg.Web.Session.MaxLifetime = v
+	}
+	if v := os.Getenv("K13D_KUBE_QPS"); v != "" {
+		if f, err := strconv.ParseFloat(v, 32); err == nil && f > 0 {
+			cfg.Kubernetes.QPS = float32(f)
+		}
+	}
+	if v := os.Getenv("K13D_KUBE_BURST"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.Kubernetes.Burst = n
+		}
+	}
+	if v := os.Getenv("K13D_KUBE_MAX_CONCURRENCY"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.Kubernetes.MaxConcurrency = n
+		}
+	}
 	if v := os.Getenv("K13D_GITHUB_AUTOMATION_ENABLED"); v != "" {
 		cfg.GitHub.Enabled = strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "yes")
 	}
@@ -895,6 +1519,9 @@ func applyEnvOverrides(cfg *Config) {
 }
 
 func (c *Config) Save() error {
+	if c.ActiveProfile != "" {
+		return fmt.Errorf("config profile %q is active; settings changes apply to this session only and are not saved", c.ActiveProfile)
+	}
 	path := GetConfigPath()
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
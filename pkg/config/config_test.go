@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -177,6 +178,9 @@ func TestNormalizeLegacyLLMProviders(t *testing.T) {
 			Provider: "embedded",
 			Model:    "",
 			Endpoint: "",
+			Fallbacks: []LLMFallback{
+				{Provider: "embedded"},
+			},
 		},
 		Models: []ModelProfile{
 			{Name: "legacy-local", Provider: "embedded", Model: "", Endpoint: ""},
@@ -206,6 +210,9 @@ func TestNormalizeLegacyLLMProviders(t *testing.T) {
 	if cfg.Models[0].Endpoint != DefaultOllamaEndpoint {
 		t.Fatalf("Models[0].Endpoint = %q, want %q", cfg.Models[0].Endpoint, DefaultOllamaEndpoint)
 	}
+	if fb := cfg.LLM.Fallbacks[0]; fb.Provider != "ollama" || fb.Model != DefaultOllamaModel || fb.Endpoint != DefaultOllamaEndpoint {
+		t.Fatalf("Fallbacks[0] = %+v, want ollama with default model and endpoint", fb)
+	}
 }
 
 func TestGetActiveModelProfile(t *testing.T) {
@@ -1156,3 +1163,312 @@ func TestConfigSaveLoadRoundTrip(t *testing.T) {
 		t.Error("After switch to solar-pro2, SkipTLSVerify should be false")
 	}
 }
+
+func TestSecretRevealPolicy_AllowsRole(t *testing.T) {
+	policy := SecretRevealPolicy{DeniedRoles: []string{"viewer"}}
+	if !policy.AllowsRole("admin") {
+		t.Error("admin should be allowed when only viewer is denied")
+	}
+	if policy.AllowsRole("Viewer") {
+		t.Error("denied roles should match case-insensitively")
+	}
+
+	policy.Disabled = true
+	if policy.AllowsRole("admin") {
+		t.Error("Disabled should block every role")
+	}
+}
+
+func TestSecretRevealPolicy_EnvOverride(t *testing.T) {
+	cfg := NewDefaultConfig()
+	if cfg.Authorization.SecretReveal.Disabled {
+		t.Fatal("secret reveal should be enabled by default")
+	}
+
+	t.Setenv("K13D_DISABLE_SECRET_REVEAL", "true")
+	applyEnvOverrides(cfg)
+	if !cfg.Authorization.SecretReveal.Disabled {
+		t.Error("K13D_DISABLE_SECRET_REVEAL=true should disable secret reveal")
+	}
+}
+
+func TestToolApprovalPolicy_SafeToolsEnvOverride(t *testing.T) {
+	cfg := NewDefaultConfig()
+	if cfg.Authorization.ToolApproval.HasToolRestrictions() {
+		t.Fatal("default tool policy should not restrict commands")
+	}
+
+	t.Setenv("K13D_SAFE_TOOLS", "1")
+	applyEnvOverrides(cfg)
+	if !cfg.Authorization.ToolApproval.SafeTools {
+		t.Error("K13D_SAFE_TOOLS=1 should enable safe tools")
+	}
+	if !cfg.Authorization.ToolApproval.HasToolRestrictions() {
+		t.Error("safe tools should count as a tool restriction")
+	}
+}
+
+func TestLLMLogPayloadsEnvOverride(t *testing.T) {
+	cfg := NewDefaultConfig()
+	if cfg.LLM.LogPayloads {
+		t.Fatal("payload logging should be off by default")
+	}
+
+	t.Setenv("K13D_LLM_LOG_PAYLOADS", "true")
+	applyEnvOverrides(cfg)
+	if !cfg.LLM.LogPayloads {
+		t.Error("K13D_LLM_LOG_PAYLOADS=true should enable payload logging")
+	}
+}
+
+func TestLLMKeepAliveEnvOverride(t *testing.T) {
+	cfg := NewDefaultConfig()
+	t.Setenv("K13D_LLM_KEEP_ALIVE", "-1")
+	applyEnvOverrides(cfg)
+	if cfg.LLM.KeepAlive != "-1" {
+		t.Errorf("KeepAlive = %q, want -1 from K13D_LLM_KEEP_ALIVE", cfg.LLM.KeepAlive)
+	}
+}
+
+func TestApplyEnvOverrides_Kubernetes(t *testing.T) {
+	cfg := NewDefaultConfig()
+	if cfg.Kubernetes.QPS != 50 || cfg.Kubernetes.Burst != 100 || cfg.Kubernetes.MaxConcurrency != 8 {
+		t.Fatalf("defaults = %+v", cfg.Kubernetes)
+	}
+
+	t.Setenv("K13D_KUBE_QPS", "20")
+	t.Setenv("K13D_KUBE_BURST", "30")
+	t.Setenv("K13D_KUBE_MAX_CONCURRENCY", "0")
+
+	applyEnvOverrides(cfg)
+
+	if cfg.Kubernetes.QPS != 20 || cfg.Kubernetes.Burst != 30 {
+		t.Fatalf("QPS/Burst = %v/%d, want 20/30", cfg.Kubernetes.QPS, cfg.Kubernetes.Burst)
+	}
+	if cfg.Kubernetes.MaxConcurrency != 8 {
+		t.Fatalf("MaxConcurrency = %d, want 8 (non-positive values ignored)", cfg.Kubernetes.MaxConcurrency)
+	}
+}
+
+func TestAuditReadsConfig_Covers(t *testing.T) {
+	var c AuditReadsConfig
+	if c.Covers("secrets") {
+		t.Fatal("disabled config should cover nothing")
+	}
+
+	c.Enabled = true
+	if !c.Covers("pods") || !c.Covers("secrets") {
+		t.Fatal("enabled config without resources should cover every type")
+	}
+
+	c.Resources = []string{"Secret", "configmaps"}
+	for resource, want := range map[string]bool{"secrets": true, "configmaps": true, "pods": false} {
+		if got := c.Covers(resource); got != want {
+			t.Errorf("Covers(%q) = %v, want %v", resource, got, want)
+		}
+	}
+
+	t.Setenv("K13D_AUDIT_READS", "true")
+	cfg := NewDefaultConfig()
+	if cfg.AuditReads.Enabled {
+		t.Fatal("read auditing should be off by default")
+	}
+	applyEnvOverrides(cfg)
+	if !cfg.AuditReads.Enabled {
+		t.Fatal("K13D_AUDIT_READS=true should enable read auditing")
+	}
+}
+
+func TestApplyEnvOverrides_WebTLS(t *testing.T) {
+	cfg := NewDefaultConfig()
+	if cfg.Web.TLS.Enabled() {
+		t.Fatal("TLS should be off by default")
+	}
+
+	t.Setenv("K13D_TLS_CERT", "/etc/k13d/tls.crt")
+	applyEnvOverrides(cfg)
+	if cfg.Web.TLS.Enabled() {
+		t.Fatal("a certificate without a key should not enable TLS")
+	}
+
+	t.Setenv("K13D_TLS_KEY", "/etc/k13d/tls.key")
+	applyEnvOverrides(cfg)
+	if !cfg.Web.TLS.Enabled() || cfg.Web.TLS.CertFile != "/etc/k13d/tls.crt" {
+		t.Fatalf("Web.TLS = %+v, want certificate files from env", cfg.Web.TLS)
+	}
+
+	cfg = NewDefaultConfig()
+	t.Setenv("K13D_TLS_CERT", "")
+	t.Setenv("K13D_TLS_KEY", "")
+	t.Setenv("K13D_TLS_SELF_SIGNED", "true")
+	applyEnvOverrides(cfg)
+	if !cfg.Web.TLS.SelfSigned || !cfg.Web.TLS.Enabled() {
+		t.Fatal("K13D_TLS_SELF_SIGNED=true should enable a self-signed certificate")
+	}
+}
+
+func TestWebSessionConfig_Timeouts(t *testing.T) {
+	idle, maxLifetime, err := WebSessionConfig{IdleTimeout: "30m", MaxLifetime: "8h"}.Timeouts()
+	if err != nil || idle != 30*time.Minute || maxLifetime != 8*time.Hour {
+		t.Errorf("Timeouts() = %s, %s, %v; want 30m, 8h", idle, maxLifetime, err)
+	}
+	if idle, maxLifetime, err := (WebSessionConfig{}).Timeouts(); err != nil || idle != 0 || maxLifetime != 0 {
+		t.Errorf("Timeouts() of an empty config = %s, %s, %v; want zero", idle, maxLifetime, err)
+	}
+	for _, bad := range []WebSessionConfig{{IdleTimeout: "30"}, {MaxLifetime: "-1h"}} {
+		if _, _, err := bad.Timeouts(); err == nil {
+			t.Errorf("Timeouts(%+v) should fail", bad)
+		}
+	}
+
+	t.Setenv("K13D_SESSION_IDLE_TIMEOUT", "15m")
+	t.Setenv("K13D_SESSION_MAX_LIFETIME", "12h")
+	cfg := NewDefaultConfig()
+	applyEnvOverrides(cfg)
+	if cfg.Web.Session.IdleTimeout != "15m" || cfg.Web.Session.MaxLifetime != "12h" {
+		t.Errorf("env overrides = %+v, want 15m idle and 12h lifetime", cfg.Web.Session)
+	}
+}
+
+func TestSecurityScanConfig_ScanInterval(t *testing.T) {
+	if d, err := (SecurityScanConfig{Interval: "6h"}).ScanInterval(); err != nil || d != 6*time.Hour {
+		t.Errorf("ScanInterval() = %s, %v; want 6h", d, err)
+	}
+	for _, off := range []string{"", "0", "0s"} {
+		if d, err := (SecurityScanConfig{Interval: off}).ScanInterval(); err != nil || d != 0 {
+			t.Errorf("ScanInterval(%q) = %s, %v; want disabled", off, d, err)
+		}
+	}
+	for _, bad := range []string{"6", "1m", "-1h"} {
+		if _, err := (SecurityScanConfig{Interval: bad}).ScanInterval(); err == nil {
+			t.Errorf("ScanInterval(%q) should fail", bad)
+		}
+	}
+}
+
+func TestBulkDeleteNeedsTyping(t *testing.T) {
+	tests := []struct {
+		threshold int
+		count     int
+		want      bool
+	}{
+		{0, 5, false},
+		{0, 6, true},
+		{10, 6, false},
+		{10, 11, true},
+		{-1, 500, false},
+	}
+	for _, tt := range tests {
+		cfg := &Config{BulkDeleteConfirmThreshold: tt.threshold}
+		if got := cfg.BulkDeleteNeedsTyping(tt.count); got != tt.want {
+			t.Errorf("threshold %d: BulkDeleteNeedsTyping(%d) = %v, want %v", tt.threshold, tt.count, got, tt.want)
+		}
+	}
+	if !(*Config)(nil).BulkDeleteNeedsTyping(6) {
+		t.Error("a nil config should use the default threshold")
+	}
+}
+
+func TestLoadConfigAppliesProfile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("K13D_CONFIG", configPath)
+	t.Setenv("TEST_K13D_PROD_KEY", "prod-secret")
+
+	data := []byte(`
+llm:
+  provider: openai
+  model: gpt-4o
+models:
+  - name: openai
+    provider: openai
+    model: gpt-4o
+  - name: local
+    provider: ollama
+    model: qwen2.5
+reports:
+  event_limit: 10
+profiles:
+  prod-readonly:
+    models:
+      - name: prod
+        provider: anthropic
+        model: claude-sonnet
+        api_key: ${TEST_K13D_PROD_KEY}
+    active_model: prod
+    web:
+      auth_mode: oidc
+    authorization:
+      tool_approval:
+        safe_tools: true
+    reports:
+      default_sections: [nodes, workloads]
+  broken:
+    active_model: missing
+`)
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	t.Setenv("K13D_PROFILE", "prod-readonly")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.ActiveProfile != "prod-readonly" {
+		t.Errorf("ActiveProfile = %q", cfg.ActiveProfile)
+	}
+	if cfg.LLM.Provider != "anthropic" || cfg.LLM.Model != "claude-sonnet" || cfg.LLM.APIKey != "prod-secret" {
+		t.Errorf("LLM = %s/%s key %q, want the profile's active model", cfg.LLM.Provider, cfg.LLM.Model, cfg.LLM.APIKey)
+	}
+	if len(cfg.Models) != 1 || cfg.Models[0].Name != "prod" {
+		t.Errorf("Models = %+v, want only the profile's models", cfg.Models)
+	}
+	if cfg.Web.AuthMode != "oidc" || len(cfg.Reports.DefaultSections) != 2 {
+		t.Errorf("web.auth_mode = %q, reports.default_sections = %v", cfg.Web.AuthMode, cfg.Reports.DefaultSections)
+	}
+	if !cfg.Authorization.ToolApproval.SafeTools || !cfg.Authorization.ToolApproval.RequireApprovalForWrite {
+		t.Errorf("tool_approval = %+v, want safe_tools set and the other defaults kept", cfg.Authorization.ToolApproval)
+	}
+	if cfg.Reports.EventLimit != 10 {
+		t.Errorf("reports.event_limit = %d, want the base value kept", cfg.Reports.EventLimit)
+	}
+	if err := cfg.Save(); err == nil {
+		t.Error("Save() should refuse to write while a profile is active")
+	}
+
+	// Overrides still win over the profile
+	t.Setenv("K13D_LLM_MODEL", "claude-opus")
+	if cfg, err = LoadConfig(); err != nil || cfg.LLM.Model != "claude-opus" {
+		t.Errorf("env override after profile = %v, %v", cfg, err)
+	}
+
+	for _, name := range []string{"missing", "broken"} {
+		t.Setenv("K13D_PROFILE", name)
+		if _, err := LoadConfig(); err == nil {
+			t.Errorf("profile %q should fail to load", name)
+		}
+	}
+}
+
+func TestApplyProfileIsAtomic(t *testing.T) {
+	var cfg Config
+	if err := yaml.Unmarshal([]byte(`
+llm:
+  model: base
+profiles:
+  bad:
+    llm:
+      model: changed
+    reports:
+      event_limit: not-a-number
+`), &cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+
+	if err := cfg.ApplyProfile("bad"); err == nil {
+		t.Fatal("ApplyProfile() should reject an invalid value")
+	}
+	if cfg.LLM.Model != "base" || cfg.ActiveProfile != "" {
+		t.Errorf("config changed by a failed profile: model %q, profile %q", cfg.LLM.Model, cfg.ActiveProfile)
+	}
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"path"
+	"strings"
+)
+
+// ContextsConfig groups and renames kubeconfig contexts in the TUI context
+// switcher. The kubeconfig itself is not changed.
+type ContextsConfig struct {
+	// Aliases maps a context name to the name shown for it, e.g.
+	// "arn:aws:eks:eu-west-1:123456789012:cluster/prod": prod-eu
+	Aliases map[string]string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+	// Groups are listed in order. A context belongs to the first group with
+	// a matching pattern; the rest are listed last, ungrouped.
+	Groups []ContextGroup `yaml:"groups,omitempty" json:"groups,omitempty"`
+	// Safety sets how much the TUI confirms before changing a cluster. A
+	// context gets the level of the first rule with a matching pattern, and
+	// SafetyNormal when none matches.
+	Safety []ContextSafety `yaml:"safety,omitempty" json:"safety,omitempty"`
+}
+
+// Context safety levels
+const (
+	// SafetyRelaxed skips the confirmation of deletes, kills, restarts,
+	// cronjob triggers, and suspends; bulk and namespace deletes still ask
+	SafetyRelaxed = "relaxed"
+	// SafetyNormal asks before each change
+	SafetyNormal = "normal"
+	// SafetyStrict also requires typing the resource name, and confirms
+	// scaling too
+	SafetyStrict = "strict"
+)
+
+// ContextSafety is the safety level of a set of contexts
+type ContextSafety struct {
+	// Contexts are exact names or shell globs such as "kind-*", matched
+	// against the context name and its alias
+	Contexts []string `yaml:"contexts" json:"contexts"`
+	// Level is relaxed, normal, or strict; anything else counts as normal
+	Level string `yaml:"level" json:"level"`
+}
+
+// ContextGroup is a named set of contexts
+type ContextGroup struct {
+	Name string `yaml:"name" json:"name"`
+	// Contexts are exact names or shell globs such as "prod-*", matched
+	// against the context name and its alias
+	Contexts []string `yaml:"contexts" json:"contexts"`
+}
+
+// ContextAlias returns the alias of the named context, or name when it has
+// none
+func (c *Config) ContextAlias(name string) string {
+	if c == nil {
+		return name
+	}
+	if alias := strings.TrimSpace(c.Contexts.Aliases[name]); alias != "" {
+		return alias
+	}
+	return name
+}
+
+// ContextGroup returns the index and name of the first group the named
+// context belongs to, or -1 and "" when it is in none
+func (c *Config) ContextGroup(name string) (int, string) {
+	if c == nil {
+		return -1, ""
+	}
+	alias := c.ContextAlias(name)
+	for i, group := range c.Contexts.Groups {
+		for _, pattern := range group.Contexts {
+			pattern = strings.TrimSpace(pattern)
+			if contextMatches(pattern, name) || contextMatches(pattern, alias) {
+				return i, group.Name
+			}
+		}
+	}
+	return -1, ""
+}
+
+// ContextSafetyLevel returns the safety level of the named context:
+// SafetyRelaxed, SafetyNormal, or SafetyStrict
+func (c *Config) ContextSafetyLevel(name string) string {
+	if c == nil {
+		return SafetyNormal
+	}
+	alias := c.ContextAlias(name)
+	for _, rule := range c.Contexts.Safety {
+		for _, pattern := range rule.Contexts {
+			pattern = strings.TrimSpace(pattern)
+			if !contextMatches(pattern, name) && !contextMatches(pattern, alias) {
+				continue
+			}
+			switch level := strings.ToLower(strings.TrimSpace(rule.Level)); level {
+			case SafetyRelaxed, SafetyStrict:
+				return level
+			}
+			return SafetyNormal
+		}
+	}
+	return SafetyNormal
+}
+
+func contextMatches(pattern, name string) bool {
+	if pattern == name {
+		return true
+	}
+	ok, err := path.Match(pattern, name)
+	return err == nil && ok
+}
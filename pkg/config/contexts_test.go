@@ -0,0 +1,74 @@
+package config
+
+import "testing"
+
+func TestContextAliasAndGroup(t *testing.T) {
+	eks := "arn:aws:eks:eu-west-1:123456789012:cluster/payments"
+	cfg := &Config{Contexts: ContextsConfig{
+		Aliases: map[string]string{eks: "prod-payments", "kind-kind": " "},
+		Groups: []ContextGroup{
+			{Name: "prod", Contexts: []string{"prod-*"}},
+			{Name: "staging", Contexts: []string{"staging-*", "qa"}},
+			{Name: "catch-all", Contexts: []string{"*"}},
+		},
+	}}
+
+	if got := cfg.ContextAlias(eks); got != "prod-payments" {
+		t.Errorf("ContextAlias(eks) = %q, want prod-payments", got)
+	}
+	if got := cfg.ContextAlias("kind-kind"); got != "kind-kind" {
+		t.Errorf("blank alias = %q, want the context name", got)
+	}
+
+	tests := []struct {
+		name  string
+		index int
+		group string
+	}{
+		{eks, 0, "prod"}, // matched through its alias
+		{"prod-eu", 0, "prod"},
+		{"qa", 1, "staging"},
+		{"dev", 2, "catch-all"},
+	}
+	for _, tt := range tests {
+		if index, group := cfg.ContextGroup(tt.name); index != tt.index || group != tt.group {
+			t.Errorf("ContextGroup(%q) = %d, %q, want %d, %q", tt.name, index, group, tt.index, tt.group)
+		}
+	}
+
+	var nilCfg *Config
+	if index, _ := nilCfg.ContextGroup("prod-eu"); index != -1 || nilCfg.ContextAlias("prod-eu") != "prod-eu" {
+		t.Error("a nil config neither groups nor aliases")
+	}
+}
+
+func TestContextSafetyLevel(t *testing.T) {
+	cfg := &Config{Contexts: ContextsConfig{
+		Aliases: map[string]string{"arn:aws:eks:eu-west-1:123456789012:cluster/payments": "prod-payments"},
+		Safety: []ContextSafety{
+			{Contexts: []string{"kind-*", "minikube"}, Level: "relaxed"},
+			{Contexts: []string{"prod-*"}, Level: " Strict "},
+			{Contexts: []string{"staging"}, Level: "paranoid"},
+			{Contexts: []string{"*"}, Level: "relaxed"},
+		},
+	}}
+	tests := map[string]string{
+		"kind-dev": SafetyRelaxed,
+		"minikube": SafetyRelaxed,
+		"arn:aws:eks:eu-west-1:123456789012:cluster/payments": SafetyStrict, // through its alias
+		"staging":    SafetyNormal, // unknown level
+		"dev-laptop": SafetyRelaxed,
+	}
+	for name, want := range tests {
+		if got := cfg.ContextSafetyLevel(name); got != want {
+			t.Errorf("ContextSafetyLevel(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	if got := (&Config{}).ContextSafetyLevel("prod"); got != SafetyNormal {
+		t.Errorf("no rules: ContextSafetyLevel() = %q, want normal", got)
+	}
+	if got := (*Config)(nil).ContextSafetyLevel("prod"); got != SafetyNormal {
+		t.Errorf("nil config: ContextSafetyLevel() = %q, want normal", got)
+	}
+}
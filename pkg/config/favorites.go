@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// favoritesFile holds the TUI's pinned resources and views, next to
+// config.yaml.
+const favoritesFile = "favorites.yaml"
+
+// Favorite is a pinned resource or, when Name is empty, a pinned resource
+// view in a namespace.
+type Favorite struct {
+	Context   string `yaml:"context,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"` // Empty means all namespaces or cluster-scoped
+	Resource  string `yaml:"resource"`
+	Name      string `yaml:"name,omitempty"`
+}
+
+// String returns a short label such as "deployments payments/api" or
+// "pods in payments".
+func (f Favorite) String() string {
+	switch {
+	case f.Name != "" && f.Namespace != "":
+		return fmt.Sprintf("%s %s/%s", f.Resource, f.Namespace, f.Name)
+	case f.Name != "":
+		return fmt.Sprintf("%s %s", f.Resource, f.Name)
+	case f.Namespace != "":
+		return fmt.Sprintf("%s in %s", f.Resource, f.Namespace)
+	default:
+		return fmt.Sprintf("%s in all namespaces", f.Resource)
+	}
+}
+
+// FavoritesConfig is the favorites.yaml file, in pin order.
+type FavoritesConfig struct {
+	Favorites []Favorite `yaml:"favorites"`
+}
+
+// LoadFavorites reads the pinned favorites. A missing file yields an empty
+// list.
+func LoadFavorites() (*FavoritesConfig, error) {
+	configDir, err := getConfigDirFunc()
+	if err != nil {
+		return &FavoritesConfig{}, nil
+	}
+
+	data, err := os.ReadFile(resolveConfigReadPath(configDir, favoritesFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &FavoritesConfig{}, nil
+		}
+		return nil, err
+	}
+
+	var favorites FavoritesConfig
+	if err := yaml.Unmarshal(data, &favorites); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", favoritesFile, err)
+	}
+	return &favorites, nil
+}
+
+// SaveFavorites writes the favorites to the config directory.
+func SaveFavorites(favorites *FavoritesConfig) error {
+	configDir, err := getConfigDirFunc()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(favorites)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(configDir, favoritesFile), data, 0600)
+}
+
+// Toggle pins f, or unpins it if already pinned. It reports whether f is
+// pinned afterwards.
+func (c *FavoritesConfig) Toggle(f Favorite) bool {
+	for i, existing := range c.Favorites {
+		if existing == f {
+			c.Favorites = append(c.Favorites[:i], c.Favorites[i+1:]...)
+			return false
+		}
+	}
+	c.Favorites = append(c.Favorites, f)
+	return true
+}
+
+// Remove unpins the favorite at index i.
+func (c *FavoritesConfig) Remove(i int) {
+	if i < 0 || i >= len(c.Favorites) {
+		return
+	}
+	c.Favorites = append(c.Favorites[:i], c.Favorites[i+1:]...)
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFavorites_SaveAndLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	origGetConfigDir := getConfigDirFunc
+	getConfigDirFunc = func() (string, error) { return tmpDir, nil }
+	defer func() { getConfigDirFunc = origGetConfigDir }()
+
+	favs, err := LoadFavorites()
+	if err != nil || len(favs.Favorites) != 0 {
+		t.Fatalf("LoadFavorites() with no file = %+v, %v, want empty", favs, err)
+	}
+
+	api := Favorite{Context: "prod", Namespace: "payments", Resource: "deployments", Name: "api"}
+	view := Favorite{Context: "prod", Namespace: "payments", Resource: "pods"}
+	if !favs.Toggle(api) || !favs.Toggle(view) {
+		t.Fatal("Toggle() should pin new favorites")
+	}
+	if err := SaveFavorites(favs); err != nil {
+		t.Fatalf("SaveFavorites() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "favorites.yaml")); err != nil {
+		t.Fatalf("favorites.yaml not written: %v", err)
+	}
+
+	got, err := LoadFavorites()
+	if err != nil {
+		t.Fatalf("LoadFavorites() error = %v", err)
+	}
+	if len(got.Favorites) != 2 || got.Favorites[0] != api || got.Favorites[1] != view {
+		t.Errorf("LoadFavorites() = %+v, want [%+v %+v]", got.Favorites, api, view)
+	}
+
+	// Toggling a pinned favorite unpins it
+	if got.Toggle(api) {
+		t.Error("Toggle() of a pinned favorite should unpin it")
+	}
+	if len(got.Favorites) != 1 || got.Favorites[0] != view {
+		t.Errorf("after unpin = %+v, want [%+v]", got.Favorites, view)
+	}
+	got.Remove(0)
+	got.Remove(5)
+	if len(got.Favorites) != 0 {
+		t.Errorf("after Remove = %+v, want empty", got.Favorites)
+	}
+}
+
+func TestLoadFavorites_Corrupt(t *testing.T) {
+	tmpDir := t.TempDir()
+	origGetConfigDir := getConfigDirFunc
+	getConfigDirFunc = func() (string, error) { return tmpDir, nil }
+	defer func() { getConfigDirFunc = origGetConfigDir }()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "favorites.yaml"), []byte("favorites: [unclosed"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFavorites(); err == nil {
+		t.Error("LoadFavorites() with a corrupt file should fail")
+	}
+}
+
+func TestFavorite_String(t *testing.T) {
+	tests := []struct {
+		fav  Favorite
+		want string
+	}{
+		{Favorite{Namespace: "payments", Resource: "deployments", Name: "api"}, "deployments payments/api"},
+		{Favorite{Resource: "nodes", Name: "node-1"}, "nodes node-1"},
+		{Favorite{Namespace: "payments", Resource: "pods"}, "pods in payments"},
+		{Favorite{Resource: "pods"}, "pods in all namespaces"},
+	}
+	for _, tt := range tests {
+		if got := tt.fav.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+// DisableConfig turns subsystems off. Disabled subsystems are not
+// initialized: no AI client, security scanner, or scan schedule is created,
+// and their report sections, API endpoints, and views are unavailable.
+type DisableConfig struct {
+	// AI turns off the AI assistant, its LLM client, and report AI analysis
+	AI bool `yaml:"ai" json:"ai"`
+	// Security turns off the security scanner, scheduled scans, and the
+	// report security sections
+	Security bool `yaml:"security" json:"security"`
+	// FinOps turns off the FinOps report and the report cost section
+	FinOps bool `yaml:"finops" json:"finops"`
+}
+
+// AIEnabled reports whether the AI subsystem is on. Binaries built with the
+// noai tag never enable it.
+func (c *Config) AIEnabled() bool {
+	return !buildNoAI && (c == nil || !c.Disable.AI)
+}
+
+// SecurityEnabled reports whether the security subsystem is on. Binaries
+// built with the nosecurity tag never enable it.
+func (c *Config) SecurityEnabled() bool {
+	return !buildNoSecurity && (c == nil || !c.Disable.Security)
+}
+
+// FinOpsEnabled reports whether the FinOps subsystem is on. Binaries built
+// with the nofinops tag never enable it.
+func (c *Config) FinOpsEnabled() bool {
+	return !buildNoFinOps && (c == nil || !c.Disable.FinOps)
+}
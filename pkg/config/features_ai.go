@@ -0,0 +1,5 @@
+//go:build !noai
+
+package config
+
+const buildNoAI = false
@@ -0,0 +1,5 @@
+//go:build !nofinops
+
+package config
+
+const buildNoFinOps = false
@@ -0,0 +1,7 @@
+//go:build noai
+
+package config
+
+// buildNoAI is set by the noai build tag, which also leaves pkg/ai and the
+// code that uses it out of the binary
+const buildNoAI = true
@@ -0,0 +1,7 @@
+//go:build nofinops
+
+package config
+
+// buildNoFinOps is set by the nofinops build tag, which also leaves the
+// report FinOps analysis out of the binary
+const buildNoFinOps = true
@@ -0,0 +1,7 @@
+//go:build nosecurity
+
+package config
+
+// buildNoSecurity is set by the nosecurity build tag, which also leaves
+// pkg/security and the code that uses it out of the binary
+const buildNoSecurity = true
@@ -0,0 +1,5 @@
+//go:build !nosecurity
+
+package config
+
+const buildNoSecurity = false
@@ -0,0 +1,23 @@
+package config
+
+import "testing"
+
+func TestSubsystemsEnabled(t *testing.T) {
+	cfg := NewDefaultConfig()
+	if !cfg.AIEnabled() || !cfg.SecurityEnabled() || !cfg.FinOpsEnabled() {
+		t.Fatal("every subsystem should be enabled by default")
+	}
+
+	t.Setenv("K13D_NO_AI", "true")
+	t.Setenv("K13D_NO_SECURITY", "1")
+	t.Setenv("K13D_NO_FINOPS", "yes")
+	applyEnvOverrides(cfg)
+	if cfg.AIEnabled() || cfg.SecurityEnabled() || cfg.FinOpsEnabled() {
+		t.Errorf("Disable = %+v, want every subsystem disabled by the env overrides", cfg.Disable)
+	}
+
+	var nilCfg *Config
+	if !nilCfg.AIEnabled() || !nilCfg.SecurityEnabled() || !nilCfg.FinOpsEnabled() {
+		t.Error("a nil config enables every subsystem")
+	}
+}
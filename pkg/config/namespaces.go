@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// NamespaceExcluded reports whether ns matches one of the ExcludedNamespaces
+// patterns. Patterns are exact names or shell globs such as "cattle-*".
+func (c *Config) NamespaceExcluded(ns string) bool {
+	if c == nil || ns == "" {
+		return false
+	}
+	for _, pattern := range c.ExcludedNamespaces {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == ns {
+			return true
+		}
+		if ok, err := path.Match(pattern, ns); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// CompileNamespaceRegex compiles a namespace filter such as
+// "^team-.*-prod$". An empty expr returns nil, which matches every
+// namespace.
+func CompileNamespaceRegex(expr string) (*regexp.Regexp, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace regex %q: %w", expr, err)
+	}
+	return re, nil
+}
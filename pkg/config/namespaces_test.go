@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNamespaceExcluded(t *testing.T) {
+	cfg := &Config{ExcludedNamespaces: []string{"kube-system", " kube-public ", "cattle-*", "[bad"}}
+	tests := map[string]bool{
+		"kube-system":         true,
+		"kube-public":         true,
+		"cattle-system":       true,
+		"default":             false,
+		"kube-system-tenant":  false,
+		"":                    false,
+		"[bad":                true,
+		"my-cattle-namespace": false,
+	}
+	for ns, want := range tests {
+		if got := cfg.NamespaceExcluded(ns); got != want {
+			t.Errorf("NamespaceExcluded(%q) = %v, want %v", ns, got, want)
+		}
+	}
+
+	var nilCfg *Config
+	if nilCfg.NamespaceExcluded("kube-system") {
+		t.Error("a nil config excludes nothing")
+	}
+}
+
+func TestExcludedNamespaces_EnvOverride(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.ExcludedNamespaces = []string{"from-file"}
+	t.Setenv("K13D_EXCLUDED_NAMESPACES", "kube-system, kube-public,,")
+	applyEnvOverrides(cfg)
+	if len(cfg.ExcludedNamespaces) != 2 || cfg.ExcludedNamespaces[0] != "kube-system" || cfg.ExcludedNamespaces[1] != "kube-public" {
+		t.Errorf("ExcludedNamespaces = %q, want [kube-system kube-public]", cfg.ExcludedNamespaces)
+	}
+}
+
+func TestCompileNamespaceRegex(t *testing.T) {
+	re, err := CompileNamespaceRegex("^team-.*-prod$")
+	if err != nil {
+		t.Fatalf("CompileNamespaceRegex() error = %v", err)
+	}
+	if !re.MatchString("team-payments-prod") || re.MatchString("team-payments-staging") {
+		t.Errorf("regex %q matched the wrong namespaces", re)
+	}
+
+	if re, err := CompileNamespaceRegex(" "); re != nil || err != nil {
+		t.Errorf("CompileNamespaceRegex(blank) = %v, %v; want nil, nil", re, err)
+	}
+
+	_, err = CompileNamespaceRegex("team-(prod")
+	if err == nil || !strings.Contains(err.Error(), `invalid namespace regex "team-(prod"`) {
+		t.Errorf("CompileNamespaceRegex() error = %v, want an invalid regex error", err)
+	}
+}
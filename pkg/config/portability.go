@@ -0,0 +1,247 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RedactedValue replaces secrets in an exported config. Importing a config
+// keeps the current value of every setting that holds it.
+const RedactedValue = "<redacted>"
+
+// secretKeySuffixes end the YAML keys whose values are secrets, e.g.
+// api_key, bind_password, webhook_secret, or GITHUB_TOKEN in an MCP env
+var secretKeySuffixes = []string{"key", "apikey", "secret", "password", "passwd", "token", "credentials"}
+
+// isSecretConfigKey reports whether the value under a YAML key is a secret.
+// Notification webhook URLs embed their token, so they count too.
+func isSecretConfigKey(key string) bool {
+	key = strings.ToLower(key)
+	if key == "webhook_url" {
+		return true
+	}
+	parts := strings.FieldsFunc(key, func(r rune) bool { return r == '_' || r == '-' })
+	if len(parts) == 0 {
+		return false
+	}
+	last := parts[len(parts)-1]
+	for _, suffix := range secretKeySuffixes {
+		if last == suffix {
+			return true
+		}
+	}
+	return false
+}
+
+// redactSecretNodes replaces non-empty secret scalars under node with
+// RedactedValue
+func redactSecretNodes(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			redactSecretNodes(child)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if value.Kind == yaml.ScalarNode && value.Value != "" && isSecretConfigKey(key.Value) {
+				value.Value, value.Tag, value.Style = RedactedValue, "!!str", 0
+				continue
+			}
+			redactSecretNodes(value)
+		}
+	}
+}
+
+// listIdentityKeys name the fields that identify a list item, such as a
+// model profile or MCP server, when matching it against the current config
+var listIdentityKeys = []string{"name", "id"}
+
+// listItemIdentity returns what identifies a list item when restoring its
+// secrets: its name or id, or else every non-secret scalar field, plus its
+// endpoint so a secret never moves to another endpoint. It returns "" for
+// items that cannot be identified.
+func listItemIdentity(item *yaml.Node) string {
+	if item == nil || item.Kind != yaml.MappingNode {
+		return ""
+	}
+	fields := make(map[string]string)
+	for i := 0; i+1 < len(item.Content); i += 2 {
+		key, value := item.Content[i].Value, item.Content[i+1]
+		if value.Kind == yaml.ScalarNode && !isSecretConfigKey(key) {
+			fields[key] = value.Value
+		}
+	}
+
+	var parts []string
+	for _, key := range listIdentityKeys {
+		if v, ok := fields[key]; ok && v != "" {
+			parts = append(parts, key+"="+v)
+			if endpoint, ok := fields["endpoint"]; ok {
+				parts = append(parts, "endpoint="+endpoint)
+			}
+			return strings.Join(parts, "\n")
+		}
+	}
+	for key, v := range fields {
+		parts = append(parts, key+"="+v)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "\n")
+}
+
+// secretRestorer fills in redacted secrets from the current config and
+// records those it had to leave empty
+type secretRestorer struct {
+	warnings []string
+}
+
+// restore replaces each RedactedValue under node with the value at the same
+// path in current. List items are matched by listItemIdentity, not by
+// position. A secret without a match is left empty with a warning.
+func (r *secretRestorer) restore(node, current *yaml.Node, path string) {
+	if current != nil && current.Kind == yaml.DocumentNode && len(current.Content) > 0 {
+		current = current.Content[0]
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			r.restore(child, current, path)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			var match *yaml.Node
+			if id := listItemIdentity(child); id != "" && current != nil && current.Kind == yaml.SequenceNode {
+				for _, c := range current.Content {
+					if listItemIdentity(c) == id {
+						match = c
+						break
+					}
+				}
+			}
+			r.restore(child, match, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			var match *yaml.Node
+			if current != nil && current.Kind == yaml.MappingNode {
+				for j := 0; j+1 < len(current.Content); j += 2 {
+					if current.Content[j].Value == key {
+						match = current.Content[j+1]
+						break
+					}
+				}
+			}
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			r.restore(node.Content[i+1], match, childPath)
+		}
+	case yaml.ScalarNode:
+		if node.Value != RedactedValue {
+			return
+		}
+		node.Value, node.Tag, node.Style = "", "!!str", 0
+		if current != nil && current.Kind == yaml.ScalarNode && current.Value != "" {
+			node.Value = current.Value
+			return
+		}
+		r.warnings = append(r.warnings, fmt.Sprintf("%s: no matching secret in the current config; left empty", path))
+	}
+}
+
+// Export returns c as YAML for --export-config. Secrets such as API keys,
+// passwords, and tokens are replaced with RedactedValue unless
+// includeSecrets is set.
+func (c *Config) Export(includeSecrets bool) ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(c); err != nil {
+		return nil, err
+	}
+	if !includeSecrets {
+		redactSecretNodes(&node)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("# k13d configuration exported with --export-config\n")
+	if !includeSecrets {
+		buf.WriteString("# Secrets are " + RedactedValue + "; importing keeps the current values\n")
+	}
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ImportConfig parses an exported config. Redacted secrets take their value
+// from current, the YAML of the config being replaced, which may be nil. The
+// returned warnings name the secrets that had no match and were left empty.
+func ImportConfig(data, current []byte) (*Config, []string, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if node.Kind == 0 {
+		return nil, nil, fmt.Errorf("config is empty")
+	}
+
+	var currentNode *yaml.Node
+	if len(current) > 0 {
+		currentNode = &yaml.Node{}
+		// An unreadable current config only loses its secrets
+		if err := yaml.Unmarshal(current, currentNode); err != nil {
+			currentNode = nil
+		}
+	}
+	var restorer secretRestorer
+	restorer.restore(&node, currentNode, "")
+
+	cfg := NewDefaultConfig()
+	if err := node.Decode(cfg); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	return cfg, restorer.warnings, nil
+}
+
+// ImportConfigFile replaces the config file with the config exported to src
+// (--import-config). The previous file is kept as config.yaml.bak. It
+// returns the path written and ImportConfig's warnings.
+func ImportConfigFile(src string) (string, []string, error) {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return "", nil, err
+	}
+
+	path := GetConfigPath()
+	current, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", nil, err
+	}
+
+	cfg, warnings, err := ImportConfig(data, current)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if current != nil {
+		if err := os.WriteFile(path+".bak", current, 0600); err != nil {
+			return "", nil, fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+	if err := cfg.Save(); err != nil {
+		return "", nil, err
+	}
+	return path, warnings, nil
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsSecretConfigKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"api_key":               true,
+		"bind_password":         true,
+		"webhook_secret":        true,
+		"personal_access_token": true,
+		"GITHUB_TOKEN":          true,
+		"webhook_url":           true,
+		"max_tokens":            false,
+		"token_duration":        false,
+		"key_file":              false,
+		"secret_reveal":         false,
+		"model":                 false,
+	} {
+		if got := isSecretConfigKey(key); got != want {
+			t.Errorf("isSecretConfigKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestExportRedactsSecrets(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.LLM.APIKey = "sk-live-123"
+	cfg.LLM.Model = "gpt-4o"
+	cfg.Authorization.JWT.Secret = "jwt-secret"
+	cfg.MCP.Servers = append(cfg.MCP.Servers, MCPServer{Name: "github", Env: map[string]string{"GITHUB_TOKEN": "ghp_abc", "LOG": "debug"}})
+
+	data, err := cfg.Export(false)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	out := string(data)
+	for _, secret := range []string{"sk-live-123", "jwt-secret", "ghp_abc"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("exported config leaks %q:\n%s", secret, out)
+		}
+	}
+	if !strings.Contains(out, "gpt-4o") || !strings.Contains(out, "LOG: debug") || !strings.Contains(out, RedactedValue) {
+		t.Errorf("exported config lost settings or redaction markers:\n%s", out)
+	}
+
+	withSecrets, err := cfg.Export(true)
+	if err != nil {
+		t.Fatalf("Export(true) error = %v", err)
+	}
+	if !strings.Contains(string(withSecrets), "sk-live-123") {
+		t.Error("Export(true) should include secrets")
+	}
+}
+
+func TestImportConfigKeepsCurrentSecrets(t *testing.T) {
+	source := NewDefaultConfig()
+	source.LLM.APIKey = "exported-key"
+	source.LLM.Model = "claude-sonnet-4"
+	source.GitHub.WebhookSecret = "hook"
+	exported, err := source.Export(false)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	current := []byte("llm:\n  api_key: local-key\n  model: old\n")
+	cfg, warnings, err := ImportConfig(exported, current)
+	if err != nil {
+		t.Fatalf("ImportConfig() error = %v", err)
+	}
+	if cfg.LLM.Model != "claude-sonnet-4" {
+		t.Errorf("Model = %q, want the imported model", cfg.LLM.Model)
+	}
+	if cfg.LLM.APIKey != "local-key" {
+		t.Errorf("APIKey = %q, want the current key kept", cfg.LLM.APIKey)
+	}
+	if cfg.GitHub.WebhookSecret != "" {
+		t.Errorf("WebhookSecret = %q, want empty when there is no current value", cfg.GitHub.WebhookSecret)
+	}
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "github_automation.webhook_secret:") {
+		t.Errorf("warnings = %v, want one for github_automation.webhook_secret", warnings)
+	}
+
+	if _, _, err := ImportConfig([]byte("llm: [unclosed"), nil); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
+
+func TestImportConfigMatchesListItemsByName(t *testing.T) {
+	current := []byte(`models:
+  - name: openai-prod
+    provider: openai
+    endpoint: https://api.openai.com/v1
+    api_key: sk-OPENAI-SECRET
+  - name: claude
+    provider: anthropic
+    api_key: sk-ANTHROPIC-SECRET
+`)
+	// Reordered, with a new first entry and a moved endpoint
+	exported := []byte(`models:
+  - name: team-proxy
+    provider: openai
+    endpoint: https://llm-proxy.vendor.example/v1
+    api_key: ` + RedactedValue + `
+  - name: claude
+    provider: anthropic
+    api_key: ` + RedactedValue + `
+  - name: openai-prod
+    provider: openai
+    endpoint: https://other.example/v1
+    api_key: ` + RedactedValue + `
+`)
+
+	cfg, warnings, err := ImportConfig(exported, current)
+	if err != nil {
+		t.Fatalf("ImportConfig() error = %v", err)
+	}
+	if len(cfg.Models) != 3 {
+		t.Fatalf("got %d models, want 3", len(cfg.Models))
+	}
+	if cfg.Models[0].APIKey != "" {
+		t.Errorf("team-proxy key = %q, want empty: it has no match", cfg.Models[0].APIKey)
+	}
+	if cfg.Models[1].APIKey != "sk-ANTHROPIC-SECRET" {
+		t.Errorf("claude key = %q, want it restored by name", cfg.Models[1].APIKey)
+	}
+	if cfg.Models[2].APIKey != "" {
+		t.Errorf("openai-prod key = %q, want empty: its endpoint changed", cfg.Models[2].APIKey)
+	}
+	if len(warnings) != 2 || !strings.HasPrefix(warnings[0], "models[0].api_key:") || !strings.HasPrefix(warnings[1], "models[2].api_key:") {
+		t.Errorf("warnings = %v, want models[0] and models[2]", warnings)
+	}
+}
+
+func TestImportConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	t.Setenv("K13D_CONFIG", path)
+	if err := os.WriteFile(path, []byte("llm:\n  api_key: local-key\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(dir, "export.yaml")
+	if err := os.WriteFile(src, []byte("llm:\n  provider: ollama\n  api_key: "+RedactedValue+"\nlanguage: ko\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	written, _, err := ImportConfigFile(src)
+	if err != nil {
+		t.Fatalf("ImportConfigFile() error = %v", err)
+	}
+	if written != path {
+		t.Errorf("ImportConfigFile() wrote %s, want %s", written, path)
+	}
+	if backup, err := os.ReadFile(path + ".bak"); err != nil || !strings.Contains(string(backup), "local-key") {
+		t.Errorf("backup = %q, %v", backup, err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.LLM.Provider != "ollama" || cfg.Language != "ko" || cfg.LLM.APIKey != "local-key" {
+		t.Errorf("imported config = provider %q, language %q, api key %q", cfg.LLM.Provider, cfg.Language, cfg.LLM.APIKey)
+	}
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// portForwardsFile holds the TUI's port-forwards that were active at exit,
+// next to config.yaml.
+const portForwardsFile = "port-forwards.yaml"
+
+// PortForward is a kubectl port-forward to a pod or service.
+type PortForward struct {
+	Context    string `yaml:"context,omitempty"`
+	Namespace  string `yaml:"namespace"`
+	Kind       string `yaml:"kind"` // "pod" or "svc"
+	Name       string `yaml:"name"`
+	LocalPort  string `yaml:"local_port"`
+	RemotePort string `yaml:"remote_port"`
+}
+
+// Target returns the kubectl port-forward target, e.g. "svc/api".
+func (p PortForward) Target() string {
+	return p.Kind + "/" + p.Name
+}
+
+// String returns a short label such as "localhost:8080 -> payments/svc/api:80".
+func (p PortForward) String() string {
+	return fmt.Sprintf("localhost:%s -> %s/%s:%s", p.LocalPort, p.Namespace, p.Target(), p.RemotePort)
+}
+
+// PortForwardsState is the port-forwards.yaml file.
+type PortForwardsState struct {
+	PortForwards []PortForward `yaml:"port_forwards"`
+}
+
+// LoadPortForwards reads the port-forwards saved at the last exit. A missing
+// file yields an empty list.
+func LoadPortForwards() (*PortForwardsState, error) {
+	configDir, err := getConfigDirFunc()
+	if err != nil {
+		return &PortForwardsState{}, nil
+	}
+
+	data, err := os.ReadFile(resolveConfigReadPath(configDir, portForwardsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &PortForwardsState{}, nil
+		}
+		return nil, err
+	}
+
+	var state PortForwardsState
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", portForwardsFile, err)
+	}
+	return &state, nil
+}
+
+// SavePortForwards writes the port-forwards to the config directory.
+func SavePortForwards(state *PortForwardsState) error {
+	configDir, err := getConfigDirFunc()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(configDir, portForwardsFile), data, 0600)
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPortForwards_SaveAndLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	origGetConfigDir := getConfigDirFunc
+	getConfigDirFunc = func() (string, error) { return tmpDir, nil }
+	defer func() { getConfigDirFunc = origGetConfigDir }()
+
+	state, err := LoadPortForwards()
+	if err != nil || len(state.PortForwards) != 0 {
+		t.Fatalf("LoadPortForwards() with no file = %+v, %v; want empty", state, err)
+	}
+
+	want := &PortForwardsState{PortForwards: []PortForward{
+		{Context: "prod", Namespace: "payments", Kind: "svc", Name: "api", LocalPort: "8080", RemotePort: "80"},
+		{Context: "prod", Namespace: "payments", Kind: "pod", Name: "db-0", LocalPort: "5432", RemotePort: "5432"},
+	}}
+	if err := SavePortForwards(want); err != nil {
+		t.Fatalf("SavePortForwards() error = %v", err)
+	}
+	got, err := LoadPortForwards()
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadPortForwards() = %+v, %v; want %+v", got, err, want)
+	}
+	if s := want.PortForwards[0].String(); s != "localhost:8080 -> payments/svc/api:80" {
+		t.Errorf("String() = %q", s)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "port-forwards.yaml"), []byte("port_forwards: [unclosed"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPortForwards(); err == nil || !strings.Contains(err.Error(), "invalid port-forwards.yaml") {
+		t.Errorf("LoadPortForwards() with corrupt file error = %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Preflight check names, for PreflightConfig.Checks
+const (
+	PreflightKubernetes = "kubernetes"
+	PreflightRBAC       = "rbac"
+	PreflightLLM        = "llm"
+	PreflightDatabase   = "database"
+)
+
+// PreflightChecks lists every preflight check in the order they run
+var PreflightChecks = []string{PreflightKubernetes, PreflightRBAC, PreflightLLM, PreflightDatabase}
+
+// DefaultPreflightTimeout bounds each preflight check when Timeout is empty
+const DefaultPreflightTimeout = 10 * time.Second
+
+// PreflightConfig controls the startup self-check
+type PreflightConfig struct {
+	// Enabled runs the checks on every start (default: false)
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// Checks limits the checks to these names (kubernetes, rbac, llm,
+	// database); empty runs all of them
+	Checks []string `yaml:"checks" json:"checks"`
+	// ContinueOnFailure starts k13d even when a check fails; otherwise it
+	// exits after printing the results
+	ContinueOnFailure bool `yaml:"continue_on_failure" json:"continue_on_failure"`
+	// Timeout bounds each check, as a Go duration such as "5s" (default: 10s)
+	Timeout string `yaml:"timeout" json:"timeout"`
+}
+
+// Runs reports whether the named check is enabled by Checks
+func (c PreflightConfig) Runs(check string) bool {
+	return len(c.Checks) == 0 || slices.ContainsFunc(c.Checks, func(name string) bool {
+		return strings.EqualFold(strings.TrimSpace(name), check)
+	})
+}
+
+// CheckTimeout parses Timeout, returning DefaultPreflightTimeout when it is
+// empty
+func (c PreflightConfig) CheckTimeout() (time.Duration, error) {
+	v := strings.TrimSpace(c.Timeout)
+	if v == "" {
+		return DefaultPreflightTimeout, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid preflight timeout %q: %w", c.Timeout, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid preflight timeout %q: must be positive", c.Timeout)
+	}
+	return d, nil
+}
+
+// Validate rejects unknown check names and an invalid timeout
+func (c PreflightConfig) Validate() error {
+	for _, name := range c.Checks {
+		if !slices.Contains(PreflightChecks, strings.ToLower(strings.TrimSpace(name))) {
+			return fmt.Errorf("unknown preflight check %q (valid: %s)", name, strings.Join(PreflightChecks, ", "))
+		}
+	}
+	_, err := c.CheckTimeout()
+	return err
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreflightConfig(t *testing.T) {
+	var all PreflightConfig
+	for _, check := range PreflightChecks {
+		if !all.Runs(check) {
+			t.Errorf("empty Checks should run %s", check)
+		}
+	}
+	if d, err := all.CheckTimeout(); err != nil || d != DefaultPreflightTimeout {
+		t.Errorf("CheckTimeout() = %v, %v, want default", d, err)
+	}
+
+	some := PreflightConfig{Checks: []string{"Kubernetes", " llm"}, Timeout: "3s"}
+	if !some.Runs(PreflightKubernetes) || !some.Runs(PreflightLLM) || some.Runs(PreflightRBAC) {
+		t.Errorf("Runs() does not follow Checks %v", some.Checks)
+	}
+	if d, err := some.CheckTimeout(); err != nil || d != 3*time.Second {
+		t.Errorf("CheckTimeout() = %v, %v, want 3s", d, err)
+	}
+	if err := some.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	for _, bad := range []PreflightConfig{{Checks: []string{"dns"}}, {Timeout: "soon"}, {Timeout: "0s"}} {
+		if bad.Validate() == nil {
+			t.Errorf("Validate(%+v) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProfileNames returns the names of the profiles in the config file, sorted
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyProfile overlays the named profile on the config. A profile holds any
+// top-level config keys, e.g. llm, models, active_model, mcp, web.auth_mode,
+// and reports.default_sections; keys it sets replace the base values, lists
+// included, and everything else is kept. The profile is applied in full or,
+// on error, not at all. Setting active_model without llm switches the LLM to
+// that model profile.
+//
+// While a profile is active Save refuses to write, so the profile's settings
+// never leak into the base config.
+func (c *Config) ApplyProfile(name string) error {
+	node, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return fmt.Errorf("config profile %q not found: the config file has no profiles", name)
+		}
+		return fmt.Errorf("config profile %q not found (available: %v)", name, c.ProfileNames())
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("config profile %q must be a mapping of config keys", name)
+	}
+	keys := make([]string, 0, len(node.Content)/2)
+	for i := 0; i < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i].Value)
+	}
+	if slices.Contains(keys, "profiles") {
+		return fmt.Errorf("config profile %q must not define profiles", name)
+	}
+
+	// Decode onto a copy so a bad value leaves the config untouched
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	next := &Config{}
+	if err := yaml.Unmarshal(data, next); err != nil {
+		return err
+	}
+	if err := node.Decode(next); err != nil {
+		return fmt.Errorf("config profile %q: %w", name, err)
+	}
+	if slices.Contains(keys, "active_model") && !slices.Contains(keys, "llm") {
+		if !next.SetActiveModel(next.ActiveModel) {
+			return fmt.Errorf("config profile %q: active_model %q is not one of its models", name, next.ActiveModel)
+		}
+	}
+
+	next.ActiveProfile = name
+	*c = *next
+	return nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// sessionStateFile is the TUI session state written on exit, next to
+// config.yaml.
+const sessionStateFile = "state.yaml"
+
+// SessionState is the TUI position remembered across restarts when
+// restore_session is enabled.
+type SessionState struct {
+	Context   string `yaml:"context,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"` // Empty means all namespaces
+	Resource  string `yaml:"resource,omitempty"`
+}
+
+// LoadSessionState reads the saved session state. A missing or unreadable
+// file yields nil, which callers treat as "nothing to restore".
+func LoadSessionState() *SessionState {
+	configDir, err := getConfigDirFunc()
+	if err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(resolveConfigReadPath(configDir, sessionStateFile))
+	if err != nil {
+		return nil
+	}
+
+	var state SessionState
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil
+	}
+	return &state
+}
+
+// SaveSessionState writes the session state to the config directory.
+func SaveSessionState(state *SessionState) error {
+	configDir, err := getConfigDirFunc()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(configDir, sessionStateFile), data, 0600)
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSessionState_SaveAndLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	origGetConfigDir := getConfigDirFunc
+	getConfigDirFunc = func() (string, error) { return tmpDir, nil }
+	defer func() { getConfigDirFunc = origGetConfigDir }()
+
+	if got := LoadSessionState(); got != nil {
+		t.Fatalf("LoadSessionState() with no file = %+v, want nil", got)
+	}
+
+	want := &SessionState{Context: "prod", Namespace: "payments", Resource: "deployments"}
+	if err := SaveSessionState(want); err != nil {
+		t.Fatalf("SaveSessionState() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "state.yaml")); err != nil {
+		t.Fatalf("state.yaml not written: %v", err)
+	}
+
+	got := LoadSessionState()
+	if got == nil || *got != *want {
+		t.Errorf("LoadSessionState() = %+v, want %+v", got, want)
+	}
+
+	// All namespaces is stored as an empty namespace
+	if err := SaveSessionState(&SessionState{Context: "prod", Resource: "pods"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := LoadSessionState(); got == nil || got.Namespace != "" || got.Resource != "pods" {
+		t.Errorf("LoadSessionState() = %+v, want all namespaces on pods", got)
+	}
+}
+
+func TestLoadSessionState_Corrupt(t *testing.T) {
+	tmpDir := t.TempDir()
+	origGetConfigDir := getConfigDirFunc
+	getConfigDirFunc = func() (string, error) { return tmpDir, nil }
+	defer func() { getConfigDirFunc = origGetConfigDir }()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "state.yaml"), []byte("context: [unclosed"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := LoadSessionState(); got != nil {
+		t.Errorf("LoadSessionState() with corrupt file = %+v, want nil", got)
+	}
+}
+
+func TestRestoreSession_EnvOverride(t *testing.T) {
+	if !NewDefaultConfig().RestoreSession {
+		t.Fatal("RestoreSession should default to true")
+	}
+
+	cfg := NewDefaultConfig()
+	t.Setenv("K13D_RESTORE_SESSION", "false")
+	applyEnvOverrides(cfg)
+	if cfg.RestoreSession {
+		t.Error("K13D_RESTORE_SESSION=false should disable session restore")
+	}
+}
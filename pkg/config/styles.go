@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
@@ -42,6 +43,8 @@ type K13sStyles struct {
 	Views     ViewStyles     `yaml:"views"`
 	Dialog    DialogStyle    `yaml:"dialog"`
 	StatusBar StatusBarStyle `yaml:"statusBar"`
+	Palette   PaletteStyle   `yaml:"palette"`
+	Report    ReportStyle    `yaml:"report"`
 }
 
 // BodyStyle defines the main application background
@@ -115,6 +118,42 @@ type StatusBarStyle struct {
 	ErrorColor Color `yaml:"errorColor"`
 }
 
+// PaletteStyle defines the accent colors used for inline text and widget chrome
+// (header, status bar, table cells, prompts). Empty fields fall back to the
+// dark theme's palette.
+type PaletteStyle struct {
+	Accent        Color `yaml:"accent"`        // Context names, command prompt, links
+	Highlight     Color `yaml:"highlight"`     // AI panel border and prompt
+	Success       Color `yaml:"success"`       // Running/Ready status
+	Warning       Color `yaml:"warning"`       // Pending status, table headers
+	Error         Color `yaml:"error"`         // Failed status, offline indicators
+	Info          Color `yaml:"info"`          // Resource name, sorted column
+	Muted         Color `yaml:"muted"`         // Labels and secondary hints
+	Text          Color `yaml:"text"`          // Primary text
+	TextDim       Color `yaml:"textDim"`       // Secondary text
+	CellText      Color `yaml:"cellText"`      // Default table cell text
+	SelectionText Color `yaml:"selectionText"` // Text on the selected table row
+	Ink           Color `yaml:"ink"`           // Text drawn on the status bar background
+	Background    Color `yaml:"background"`    // Dropdown and popup background
+	Surface       Color `yaml:"surface"`       // Highlighted row background
+}
+
+// ReportStyle defines the colors used by the HTML report stylesheet.
+// Reports stay print-friendly, so every theme renders on a light page.
+type ReportStyle struct {
+	Text          Color `yaml:"text"`
+	Heading       Color `yaml:"heading"`
+	SubHeading    Color `yaml:"subHeading"`
+	Accent        Color `yaml:"accent"`
+	TableHeaderBg Color `yaml:"tableHeaderBg"`
+	TableHeaderFg Color `yaml:"tableHeaderFg"`
+	Surface       Color `yaml:"surface"`
+	Border        Color `yaml:"border"`
+	Success       Color `yaml:"success"`
+	Warning       Color `yaml:"warning"`
+	Danger        Color `yaml:"danger"`
+}
+
 // StatusColorConfig defines colors for resource status
 type StatusColorConfig struct {
 	Running    Color `yaml:"running"`
@@ -190,10 +229,264 @@ func DefaultStyles() *StyleConfig {
 				BgColor:    "#6272a4",
 				ErrorColor: "#ff5555",
 			},
+			Palette: DefaultPalette(),
+			Report:  DefaultReportStyle(),
+		},
+	}
+}
+
+// DefaultPalette returns the Tokyo Night palette used by the TUI chrome.
+func DefaultPalette() PaletteStyle {
+	return PaletteStyle{
+		Accent:        "#7aa2f7",
+		Highlight:     "#bb9af7",
+		Success:       "#9ece6a",
+		Warning:       "#e0af68",
+		Error:         "#f7768e",
+		Info:          "#7dcfff",
+		Muted:         "#565f89",
+		Text:          "#c0caf5",
+		TextDim:       "#a9b1d6",
+		CellText:      "white",
+		SelectionText: "white",
+		Ink:           "#1a1b26",
+		Background:    "#1a1b26",
+		Surface:       "#292e42",
+	}
+}
+
+// DefaultReportStyle returns the HTML report colors.
+func DefaultReportStyle() ReportStyle {
+	return ReportStyle{
+		Text:          "#333333",
+		Heading:       "#1a1b26",
+		SubHeading:    "#24283b",
+		Accent:        "#7aa2f7",
+		TableHeaderBg: "#24283b",
+		TableHeaderFg: "#ffffff",
+		Surface:       "#f8f9fa",
+		Border:        "#dddddd",
+		Success:       "#28a745",
+		Warning:       "#ffc107",
+		Danger:        "#dc3545",
+	}
+}
+
+// Built-in theme names selectable via --theme or the `theme` config key.
+const (
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+)
+
+// BuiltInThemes returns the theme presets shipped with k13d.
+func BuiltInThemes() map[string]*StyleConfig {
+	return map[string]*StyleConfig{
+		ThemeDark:         DefaultStyles(),
+		ThemeLight:        lightTheme(),
+		ThemeHighContrast: highContrastTheme(),
+	}
+}
+
+// ThemeNames returns the built-in theme names in display order.
+func ThemeNames() []string {
+	return []string{ThemeDark, ThemeLight, ThemeHighContrast}
+}
+
+// LoadTheme resolves a theme by name. Empty, "default", and "dark" select the
+// dark theme; other names are matched against built-in presets first and then
+// against skin files in the config directory's skins/ folder.
+func LoadTheme(name string) (*StyleConfig, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || name == "default" {
+		name = ThemeDark
+	}
+	if theme, ok := BuiltInThemes()[name]; ok {
+		return theme, nil
+	}
+
+	configDir, err := getConfigDirFunc()
+	if err != nil {
+		return DefaultStyles(), err
+	}
+	if !pathExists(resolveConfigReadPath(configDir, "skins", name+".yaml")) {
+		return DefaultStyles(), fmt.Errorf("unknown theme %q (built-in: %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	return LoadStyles(name)
+}
+
+func lightTheme() *StyleConfig {
+	// Tokyo Night Day: readable on terminals with a light background
+	return &StyleConfig{
+		K13s: K13sStyles{
+			Body: BodyStyle{
+				FgColor: "#3760bf",
+				BgColor: "#e1e2e7",
+			},
+			Frame: FrameStyle{
+				BorderColor:      "#a8aecb",
+				FocusBorderColor: "#2e7de9",
+				TitleColor:       "#3760bf",
+				FocusTitleColor:  "#2e7de9",
+			},
+			Views: ViewStyles{
+				Table: TableStyle{
+					Header:      CellStyle{FgColor: "#8c6c3e", BgColor: "#e1e2e7", Bold: true},
+					RowOdd:      CellStyle{FgColor: "#3760bf", BgColor: "#e1e2e7"},
+					RowEven:     CellStyle{FgColor: "#3760bf", BgColor: "#d0d5e3"},
+					RowSelected: CellStyle{FgColor: "#ffffff", BgColor: "#2e7de9"},
+					RowHover:    CellStyle{FgColor: "#3760bf", BgColor: "#c4c8da"},
+				},
+				Log: LogStyle{
+					FgColor:      "#3760bf",
+					BgColor:      "#e1e2e7",
+					ErrorColor:   "#f52a65",
+					WarningColor: "#8c6c3e",
+					InfoColor:    "#007197",
+				},
+				Charts: ChartStyle{
+					Default: "#9854f1",
+					CPU:     "#007197",
+					Memory:  "#9854f1",
+					Network: "#587539",
+				},
+			},
+			Dialog: DialogStyle{
+				FgColor:       "#3760bf",
+				BgColor:       "#d0d5e3",
+				ButtonFgColor: "#3760bf",
+				ButtonBgColor: "#a8aecb",
+				ButtonFocusFg: "#ffffff",
+				ButtonFocusBg: "#2e7de9",
+			},
+			StatusBar: StatusBarStyle{
+				FgColor:    "#ffffff",
+				BgColor:    "#2e7de9",
+				ErrorColor: "#f52a65",
+			},
+			Palette: lightPalette(),
+			Report:  DefaultReportStyle(),
 		},
 	}
 }
 
+func lightPalette() PaletteStyle {
+	return PaletteStyle{
+		Accent:        "#2e7de9",
+		Highlight:     "#9854f1",
+		Success:       "#587539",
+		Warning:       "#8c6c3e",
+		Error:         "#f52a65",
+		Info:          "#007197",
+		Muted:         "#6172b0",
+		Text:          "#3760bf",
+		TextDim:       "#6172b0",
+		CellText:      "#3760bf",
+		SelectionText: "#ffffff",
+		Ink:           "#ffffff",
+		Background:    "#e1e2e7",
+		Surface:       "#c4c8da",
+	}
+}
+
+func highContrastTheme() *StyleConfig {
+	// Pure black/white with saturated accents for low-vision users
+	return &StyleConfig{
+		K13s: K13sStyles{
+			Body: BodyStyle{
+				FgColor: "#ffffff",
+				BgColor: "#000000",
+			},
+			Frame: FrameStyle{
+				BorderColor:      "#ffffff",
+				FocusBorderColor: "#ffff00",
+				TitleColor:       "#ffffff",
+				FocusTitleColor:  "#ffff00",
+			},
+			Views: ViewStyles{
+				Table: TableStyle{
+					Header:      CellStyle{FgColor: "#ffff00", BgColor: "#000000", Bold: true},
+					RowOdd:      CellStyle{FgColor: "#ffffff", BgColor: "#000000"},
+					RowEven:     CellStyle{FgColor: "#ffffff", BgColor: "#000000"},
+					RowSelected: CellStyle{FgColor: "#000000", BgColor: "#ffff00", Bold: true},
+					RowHover:    CellStyle{FgColor: "#000000", BgColor: "#00ffff"},
+				},
+				Log: LogStyle{
+					FgColor:      "#ffffff",
+					BgColor:      "#000000",
+					ErrorColor:   "#ff0000",
+					WarningColor: "#ffff00",
+					InfoColor:    "#00ffff",
+				},
+				Charts: ChartStyle{
+					Default: "#ffffff",
+					CPU:     "#00ffff",
+					Memory:  "#ff00ff",
+					Network: "#00ff00",
+				},
+			},
+			Dialog: DialogStyle{
+				FgColor:       "#ffffff",
+				BgColor:       "#000000",
+				ButtonFgColor: "#000000",
+				ButtonBgColor: "#ffffff",
+				ButtonFocusFg: "#000000",
+				ButtonFocusBg: "#ffff00",
+			},
+			StatusBar: StatusBarStyle{
+				FgColor:    "#000000",
+				BgColor:    "#ffff00",
+				ErrorColor: "#ff0000",
+			},
+			Palette: PaletteStyle{
+				Accent:        "#00ffff",
+				Highlight:     "#ff00ff",
+				Success:       "#00ff00",
+				Warning:       "#ffff00",
+				Error:         "#ff0000",
+				Info:          "#00ffff",
+				Muted:         "#d0d0d0",
+				Text:          "#ffffff",
+				TextDim:       "#ffffff",
+				CellText:      "#ffffff",
+				SelectionText: "#000000",
+				Ink:           "#000000",
+				Background:    "#000000",
+				Surface:       "#0000aa",
+			},
+			Report: ReportStyle{
+				Text:          "#000000",
+				Heading:       "#000000",
+				SubHeading:    "#000000",
+				Accent:        "#0000cc",
+				TableHeaderBg: "#000000",
+				TableHeaderFg: "#ffffff",
+				Surface:       "#ffffff",
+				Border:        "#000000",
+				Success:       "#006400",
+				Warning:       "#8a4b00",
+				Danger:        "#b00000",
+			},
+		},
+	}
+}
+
+// fillMissingColors copies colors from def into every empty Color field of
+// dst, recursing into nested style structs. It lets skin files and older
+// skins omit sections (such as palette) without blanking the UI.
+func fillMissingColors(dst, def reflect.Value) {
+	switch dst.Kind() {
+	case reflect.Struct:
+		for i := 0; i < dst.NumField(); i++ {
+			fillMissingColors(dst.Field(i), def.Field(i))
+		}
+	case reflect.String:
+		if dst.CanSet() && dst.String() == "" {
+			dst.SetString(def.String())
+		}
+	}
+}
+
 // LoadStyles loads style configuration from a skin file
 func LoadStyles(skinName string) (*StyleConfig, error) {
 	if skinName == "" {
@@ -218,6 +511,8 @@ func LoadStyles(skinName string) (*StyleConfig, error) {
 	if err := yaml.Unmarshal(data, &styles); err != nil {
 		return DefaultStyles(), nil
 	}
+	fillMissingColors(reflect.ValueOf(&styles.K13s.Palette).Elem(), reflect.ValueOf(DefaultPalette()))
+	fillMissingColors(reflect.ValueOf(&styles.K13s.Report).Elem(), reflect.ValueOf(DefaultReportStyle()))
 
 	return &styles, nil
 }
@@ -471,6 +766,8 @@ func ollamaSkin() *StyleConfig {
 				BgColor:    "#000000", // colors.primary - pure black status bar
 				ErrorColor: "#ff5f56", // colors.terminal-red - error indicator
 			},
+			Palette: lightPalette(),
+			Report:  DefaultReportStyle(),
 		},
 	}
 }
@@ -479,14 +776,22 @@ func ollamaSkin() *StyleConfig {
 // It first checks context-skins.yaml for a mapping, then tries built-in skins,
 // and finally falls back to the user's custom skin file or default styles.
 func LoadStylesForContext(contextName string) (*StyleConfig, error) {
+	return LoadStylesForContextWithTheme(contextName, "")
+}
+
+// LoadStylesForContextWithTheme is like LoadStylesForContext but uses the named
+// theme instead of the default styles when no context mapping applies.
+func LoadStylesForContextWithTheme(contextName, theme string) (*StyleConfig, error) {
+	base, themeErr := LoadTheme(theme)
+
 	contextSkins, err := LoadContextSkins()
 	if err != nil {
-		return DefaultStyles(), nil
+		return base, themeErr
 	}
 
 	skinName := contextSkins.GetSkinForContext(contextName)
 	if skinName == "default" {
-		return DefaultStyles(), nil
+		return base, themeErr
 	}
 
 	// Check built-in skins first
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -409,3 +410,77 @@ func TestLoadStylesForContext(t *testing.T) {
 			styles.K13s.Frame.BorderColor, defaults.K13s.Frame.BorderColor)
 	}
 }
+
+func TestLoadTheme_BuiltIns(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantBody  Color
+		wantError bool
+	}{
+		{name: "", wantBody: DefaultStyles().K13s.Body.BgColor},
+		{name: "default", wantBody: DefaultStyles().K13s.Body.BgColor},
+		{name: "dark", wantBody: DefaultStyles().K13s.Body.BgColor},
+		{name: "Light", wantBody: lightTheme().K13s.Body.BgColor},
+		{name: "high-contrast", wantBody: "#000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			styles, err := LoadTheme(tt.name)
+			if err != nil {
+				t.Fatalf("LoadTheme(%q) error = %v", tt.name, err)
+			}
+			if styles.K13s.Body.BgColor != tt.wantBody {
+				t.Errorf("LoadTheme(%q) body bg = %q, want %q", tt.name, styles.K13s.Body.BgColor, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestLoadTheme_Unknown(t *testing.T) {
+	tmpDir := t.TempDir()
+	origGetConfigDir := getConfigDirFunc
+	getConfigDirFunc = func() (string, error) { return tmpDir, nil }
+	defer func() { getConfigDirFunc = origGetConfigDir }()
+
+	styles, err := LoadTheme("solarized")
+	if err == nil {
+		t.Fatal("expected error for unknown theme")
+	}
+	if styles == nil || styles.K13s.Body.BgColor != DefaultStyles().K13s.Body.BgColor {
+		t.Error("unknown theme should fall back to the dark theme")
+	}
+}
+
+func TestBuiltInThemes_CompletePalettes(t *testing.T) {
+	for _, name := range ThemeNames() {
+		styles, ok := BuiltInThemes()[name]
+		if !ok {
+			t.Fatalf("ThemeNames() lists %q but BuiltInThemes() does not define it", name)
+		}
+		p := styles.K13s.Palette
+		for field, c := range map[string]Color{
+			"accent": p.Accent, "success": p.Success, "warning": p.Warning,
+			"error": p.Error, "text": p.Text, "cellText": p.CellText,
+		} {
+			if c == "" {
+				t.Errorf("theme %q palette.%s is empty", name, field)
+			}
+		}
+		if styles.K13s.Report.Text == "" || styles.K13s.Report.Accent == "" {
+			t.Errorf("theme %q has an incomplete report style", name)
+		}
+	}
+}
+
+func TestFillMissingColors(t *testing.T) {
+	p := PaletteStyle{Accent: "#123456"}
+	fillMissingColors(reflect.ValueOf(&p).Elem(), reflect.ValueOf(DefaultPalette()))
+
+	if p.Accent != "#123456" {
+		t.Errorf("Accent = %q, want explicit value preserved", p.Accent)
+	}
+	if p.Success != DefaultPalette().Success {
+		t.Errorf("Success = %q, want default %q", p.Success, DefaultPalette().Success)
+	}
+}
@@ -16,6 +16,7 @@ type ActionType string
 
 const (
 	ActionTypeView     ActionType = "view"     // Read-only operations (excluded from audit by default)
+	ActionTypeRead     ActionType = "read"     // Describe/YAML/log views of one object (recorded when audit_reads is on)
 	ActionTypeMutation ActionType = "mutation" // Create, Update, Delete operations
 	ActionTypeLLM      ActionType = "llm"      // AI/LLM tool executions
 	ActionTypeAuth     ActionType = "auth"     // Authentication related
@@ -235,17 +236,18 @@ func GetAuditLogs() ([]map[string]interface{}, error) {
 
 // AuditFilter specifies filter criteria for audit log queries
 type AuditFilter struct {
-	Limit      int
-	User       string
-	Action     string
-	ActionType ActionType
-	Resource   string
-	K8sUser    string
-	K8sContext string
-	Source     string
-	OnlyLLM    bool
-	OnlyErrors bool
-	Since      time.Time
+	Limit       int
+	User        string
+	Action      string
+	ActionType  ActionType
+	Resource    string
+	K8sUser     string
+	K8sContext  string
+	Source      string
+	OnlyLLM     bool
+	OnlyErrors  bool
+	OnlySuccess bool
+	Since       time.Time
 }
 
 // GetAuditLogsFiltered retrieves audit logs with filters
@@ -298,6 +300,9 @@ func GetAuditLogsFiltered(filter AuditFilter) ([]map[string]interface{}, error)
 	if filter.OnlyErrors {
 		query += " AND success = 0"
 	}
+	if filter.OnlySuccess {
+		query += " AND success = 1"
+	}
 	if !filter.Since.IsZero() {
 		query += " AND timestamp >= ?"
 		args = append(args, filter.Since)
@@ -469,6 +474,7 @@ type SecurityScanFilter struct {
 	Namespace   string
 	ScanType    string
 	RiskLevel   string
+	Source      string
 	Since       time.Time
 	MinScore    float64
 	MaxScore    float64
@@ -506,6 +512,10 @@ func GetSecurityScans(filter SecurityScanFilter) ([]SecurityScanRecord, error) {
 		query += " AND risk_level = ?"
 		args = append(args, filter.RiskLevel)
 	}
+	if filter.Source != "" {
+		query += " AND source = ?"
+		args = append(args, filter.Source)
+	}
 	if !filter.Since.IsZero() {
 		query += " AND scan_time >= ?"
 		args = append(args, filter.Since)
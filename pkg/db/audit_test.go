@@ -211,6 +211,11 @@ func TestAuditFilteredQueries(t *testing.T) {
 			filter:   AuditFilter{OnlyErrors: true},
 			expected: 1,
 		},
+		{
+			name:     "Filter only successes",
+			filter:   AuditFilter{OnlySuccess: true},
+			expected: 4,
+		},
 		{
 			name:     "Filter by resource pattern",
 			filter:   AuditFilter{Resource: "deployment"},
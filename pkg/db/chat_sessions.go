@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -46,7 +47,11 @@ func InitChatSessionsTable() error {
 	if DB == nil {
 		return ErrDBNotInitialized
 	}
+	return initChatSessionsTable(context.Background(), DB)
+}
 
+// initChatSessionsTable creates the chat_sessions and chat_messages tables on q
+func initChatSessionsTable(ctx context.Context, q schemaExecer) error {
 	sessionsQuery := `
 	CREATE TABLE IF NOT EXISTS chat_sessions (
 		id TEXT PRIMARY KEY,
@@ -58,7 +63,7 @@ func InitChatSessionsTable() error {
 		message_count INTEGER DEFAULT 0
 	);`
 
-	if _, err := DB.Exec(sessionsQuery); err != nil {
+	if _, err := q.ExecContext(ctx, sessionsQuery); err != nil {
 		return fmt.Errorf("failed to create chat_sessions table: %w", err)
 	}
 
@@ -75,7 +80,7 @@ func InitChatSessionsTable() error {
 		FOREIGN KEY (session_id) REFERENCES chat_sessions(id) ON DELETE CASCADE
 	);`
 
-	if _, err := DB.Exec(messagesQuery); err != nil {
+	if _, err := q.ExecContext(ctx, messagesQuery); err != nil {
 		return fmt.Errorf("failed to create chat_messages table: %w", err)
 	}
 
@@ -84,8 +89,8 @@ func InitChatSessionsTable() error {
 		"CREATE INDEX IF NOT EXISTS idx_chat_sessions_updated ON chat_sessions(updated_at DESC);",
 		"CREATE INDEX IF NOT EXISTS idx_chat_messages_session ON chat_messages(session_id, sort_order ASC);",
 	}
-	for _, q := range indexes {
-		if _, err := DB.Exec(q); err != nil {
+	for _, index := range indexes {
+		if _, err := q.ExecContext(ctx, index); err != nil {
 			fmt.Printf("Warning: chat index creation: %v\n", err)
 		}
 	}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
@@ -52,20 +53,42 @@ func Init(dbPath string) error {
 	})
 }
 
-// InitWithConfig initializes database with configuration
+// InitWithConfig initializes database with configuration, creating missing
+// tables and applying pending schema migrations
 func InitWithConfig(cfg DBConfig) error {
 	dbMu.Lock()
 	defer dbMu.Unlock()
 
-	var db *sql.DB
-	var err error
+	db, dbType, err := openDB(cfg)
+	if err != nil {
+		return err
+	}
+	currentDBType = dbType
 
-	currentDBType = cfg.Type
-	if currentDBType == "" {
-		currentDBType = DBTypeSQLite
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	switch currentDBType {
+	DB = db
+	if err := migrate(); err != nil {
+		_ = db.Close()
+		DB = nil
+		return err
+	}
+	return nil
+}
+
+// openDB opens the database described by cfg without touching the schema
+func openDB(cfg DBConfig) (*sql.DB, DBType, error) {
+	dbType := cfg.Type
+	if dbType == "" {
+		dbType = DBTypeSQLite
+	}
+
+	var db *sql.DB
+	var err error
+	switch dbType {
 	case DBTypeSQLite:
 		db, err = initSQLite(cfg.Path)
 	case DBTypePostgres:
@@ -73,19 +96,12 @@ func InitWithConfig(cfg DBConfig) error {
 	case DBTypeMariaDB, DBTypeMySQL:
 		db, err = initMySQL(cfg)
 	default:
-		return fmt.Errorf("unsupported database type: %s", cfg.Type)
+		return nil, "", fmt.Errorf("unsupported database type: %s", cfg.Type)
 	}
-
 	if err != nil {
-		return err
+		return nil, "", err
 	}
-
-	if err := db.Ping(); err != nil {
-		return fmt.Errorf("failed to ping database: %w", err)
-	}
-
-	DB = db
-	return createTables()
+	return db, dbType, nil
 }
 
 // DefaultDBPath returns the default SQLite database path using XDG config directory
@@ -152,9 +168,9 @@ func GetDBType() DBType {
 	return currentDBType
 }
 
-func createTables() error {
+func createTables(ctx context.Context, q schemaExecer) error {
 	// Migrate existing tables first
-	if err := migrateAuditLogsTable(); err != nil {
+	if err := migrateAuditLogsTable(ctx, q); err != nil {
 		// Log but don't fail - we'll create new table if needed
 		fmt.Printf("Warning: migration check failed: %v\n", err)
 	}
@@ -256,7 +272,7 @@ func createTables() error {
 			reviewer_user TEXT DEFAULT ''
 		);`
 	}
-	if _, err := DB.Exec(auditQuery); err != nil {
+	if _, err := q.ExecContext(ctx, auditQuery); err != nil {
 		return fmt.Errorf("failed to create audit_logs table: %w", err)
 	}
 
@@ -342,7 +358,7 @@ func createTables() error {
 			source TEXT DEFAULT ''
 		);`
 	}
-	if _, err := DB.Exec(securityQuery); err != nil {
+	if _, err := q.ExecContext(ctx, securityQuery); err != nil {
 		// Ignore if table exists
 		if !strings.Contains(err.Error(), "already exists") {
 			return fmt.Errorf("failed to create security_scans table: %w", err)
@@ -350,48 +366,41 @@ func createTables() error {
 	}
 
 	// Create user_locks table (for emergency user locking)
-	if err := createUserLocksTable(); err != nil {
+	if err := createUserLocksTable(ctx, q); err != nil {
 		fmt.Printf("Warning: failed to create user_locks table: %v\n", err)
 	}
 
 	// Create access_requests table (for access request workflow)
-	if err := createAccessRequestsTable(); err != nil {
+	if err := createAccessRequestsTable(ctx, q); err != nil {
 		fmt.Printf("Warning: failed to create access_requests table: %v\n", err)
 	}
 
-	// Create indexes
-	indexQueries := getIndexQueries()
-	for _, q := range indexQueries {
-		if _, err := DB.Exec(q); err != nil {
-			// Log but don't fail — index may already exist (MySQL doesn't support IF NOT EXISTS)
-			fmt.Printf("Warning: index creation: %v\n", err)
-		}
-	}
+	// Indexes are created by the versioned migrations in migrations.go
 
 	// Create llm_usage table for token tracking
-	if err := InitLLMUsageTable(); err != nil {
+	if err := initLLMUsageTable(ctx, q); err != nil {
 		// Log but don't fail - non-critical feature
 		fmt.Printf("Warning: failed to create llm_usage table: %v\n", err)
 	}
 
 	// Create model_profiles table for LLM model configuration
-	if err := InitModelProfilesTable(); err != nil {
+	if err := initModelProfilesTable(ctx, q); err != nil {
 		// Log but don't fail - non-critical feature
 		fmt.Printf("Warning: failed to create model_profiles table: %v\n", err)
 	}
 
 	// Create web_settings table for persistent web UI settings
-	if err := InitWebSettingsTable(); err != nil {
+	if err := initWebSettingsTable(ctx, q); err != nil {
 		fmt.Printf("Warning: failed to create web_settings table: %v\n", err)
 	}
 
 	// Create custom_roles table for user-defined RBAC roles
-	if err := InitCustomRolesTable(); err != nil {
+	if err := initCustomRolesTable(ctx, q); err != nil {
 		fmt.Printf("Warning: failed to create custom_roles table: %v\n", err)
 	}
 
 	// Create chat_sessions and chat_messages tables for AI conversation history
-	if err := InitChatSessionsTable(); err != nil {
+	if err := initChatSessionsTable(ctx, q); err != nil {
 		fmt.Printf("Warning: failed to create chat_sessions tables: %v\n", err)
 	}
 
@@ -445,21 +454,18 @@ func Close() error {
 }
 
 // migrateAuditLogsTable adds missing columns to existing audit_logs table
-func migrateAuditLogsTable() error {
-	if DB == nil {
-		return nil
-	}
+func migrateAuditLogsTable(ctx context.Context, q schemaExecer) error {
 
 	// Check if table exists by trying to query it
-	rows, err := DB.Query("SELECT * FROM audit_logs LIMIT 0")
+	rows, err := q.QueryContext(ctx, "SELECT * FROM audit_logs LIMIT 0")
 	if err != nil {
 		// Table doesn't exist, will be created fresh
 		return nil
 	}
-	defer rows.Close()
 
-	// Get existing columns
+	// Get existing columns, closing rows before the ALTERs below reuse q
 	columns, err := rows.Columns()
+	rows.Close()
 	if err != nil {
 		return err
 	}
@@ -517,7 +523,7 @@ func migrateAuditLogsTable() error {
 		}
 
 		query := fmt.Sprintf("ALTER TABLE audit_logs ADD COLUMN %s %s", col.name, colDef)
-		if _, err := DB.Exec(query); err != nil {
+		if _, err := q.ExecContext(ctx, query); err != nil {
 			// Column might already exist or other error - log but continue
 			fmt.Printf("Warning: could not add column %s: %v\n", col.name, err)
 		}
@@ -527,7 +533,7 @@ func migrateAuditLogsTable() error {
 }
 
 // createUserLocksTable creates the user_locks table for emergency user locking
-func createUserLocksTable() error {
+func createUserLocksTable(ctx context.Context, q schemaExecer) error {
 	var query string
 	switch currentDBType {
 	case DBTypePostgres:
@@ -556,12 +562,12 @@ func createUserLocksTable() error {
 		);`
 	}
 
-	_, err := DB.Exec(query)
+	_, err := q.ExecContext(ctx, query)
 	return err
 }
 
 // createAccessRequestsTable creates the access_requests table for access request workflow
-func createAccessRequestsTable() error {
+func createAccessRequestsTable(ctx context.Context, q schemaExecer) error {
 	var query string
 	switch currentDBType {
 	case DBTypePostgres:
@@ -614,6 +620,6 @@ func createAccessRequestsTable() error {
 		);`
 	}
 
-	_, err := DB.Exec(query)
+	_, err := q.ExecContext(ctx, query)
 	return err
 }
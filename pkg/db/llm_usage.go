@@ -73,7 +73,11 @@ func InitLLMUsageTable() error {
 	if DB == nil {
 		return fmt.Errorf("database not initialized")
 	}
+	return initLLMUsageTable(context.Background(), DB)
+}
 
+// initLLMUsageTable creates the llm_usage table on q
+func initLLMUsageTable(ctx context.Context, q schemaExecer) error {
 	query := `
 	CREATE TABLE IF NOT EXISTS llm_usage (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -98,7 +102,7 @@ func InitLLMUsageTable() error {
 	CREATE INDEX IF NOT EXISTS idx_llm_usage_provider ON llm_usage(provider);
 	`
 
-	_, err := DB.Exec(query)
+	_, err := q.ExecContext(ctx, query)
 	return err
 }
 
@@ -0,0 +1,233 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// migrationLockKey identifies the schema migration lock, so k13d instances
+// sharing a Postgres or MySQL database upgrade it one at a time
+const (
+	migrationLockKey     = 0x6b313364 // "k13d"
+	migrationLockName    = "k13d_schema_migrations"
+	migrationLockTimeout = 60 * time.Second
+)
+
+// mysqlErrDupKeyName is MySQL's "Duplicate key name" error for CREATE INDEX
+const mysqlErrDupKeyName = 1061
+
+// schemaMigration is one versioned schema change. Migrations run in version
+// order inside a transaction and are recorded in schema_migrations, so each
+// runs once per database. Never edit a released migration; append a new one.
+type schemaMigration struct {
+	Version     int
+	Description string
+	Statements  func(dbType DBType) []string
+}
+
+// schemaMigrations is the ordered schema history. Tables and the audit_logs
+// columns are created idempotently by createTables before these run.
+var schemaMigrations = []schemaMigration{
+	{
+		Version:     1,
+		Description: "audit and security scan indexes",
+		Statements:  func(DBType) []string { return getIndexQueries() },
+	},
+	{
+		Version:     2,
+		Description: "k8s user, authorization, and access request indexes",
+		Statements: func(dbType DBType) []string {
+			switch dbType {
+			case DBTypeMariaDB, DBTypeMySQL:
+				return []string{
+					"CREATE INDEX idx_audit_k8s_user ON audit_logs(k8s_user);",
+					"CREATE INDEX idx_audit_authz_decision ON audit_logs(authz_decision);",
+					"CREATE INDEX idx_access_requests_state ON access_requests(state);",
+					"CREATE INDEX idx_access_requests_user ON access_requests(requested_by);",
+				}
+			default:
+				return []string{
+					"CREATE INDEX IF NOT EXISTS idx_audit_k8s_user ON audit_logs(k8s_user);",
+					"CREATE INDEX IF NOT EXISTS idx_audit_authz_decision ON audit_logs(authz_decision);",
+					"CREATE INDEX IF NOT EXISTS idx_access_requests_state ON access_requests(state);",
+					"CREATE INDEX IF NOT EXISTS idx_access_requests_user ON access_requests(requested_by);",
+				}
+			}
+		},
+	},
+}
+
+// schemaExecer runs schema statements; both *sql.DB and the locked *sql.Conn
+// that migrate holds satisfy it
+type schemaExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// LatestSchemaVersion returns the schema version this build migrates to
+func LatestSchemaVersion() int {
+	return schemaMigrations[len(schemaMigrations)-1].Version
+}
+
+// migrate creates missing tables and applies pending schema migrations while
+// holding the migration lock. It refuses a database migrated by a newer k13d.
+func migrate() error {
+	ctx := context.Background()
+	conn, err := DB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open migration connection: %w", err)
+	}
+	defer conn.Close()
+
+	unlock, err := lockMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := createTables(ctx, conn); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, schemaMigrationsTableQuery()); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	current, err := readSchemaVersion(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if latest := LatestSchemaVersion(); current > latest {
+		return fmt.Errorf("database schema version %d is newer than this k13d supports (%d); upgrade k13d", current, latest)
+	}
+
+	for _, m := range schemaMigrations {
+		if m.Version <= current {
+			continue
+		}
+		if err := applyMigration(ctx, conn, m); err != nil {
+			return fmt.Errorf("schema migration %d (%s) failed: %w", m.Version, m.Description, err)
+		}
+	}
+	return nil
+}
+
+// lockMigrations takes a session-level lock on Postgres and MySQL; SQLite
+// serializes writers itself
+func lockMigrations(ctx context.Context, conn *sql.Conn) (func(), error) {
+	switch currentDBType {
+	case DBTypePostgres:
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+			return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		return func() { _, _ = conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockKey) }, nil
+	case DBTypeMariaDB, DBTypeMySQL:
+		var got sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", migrationLockName, int(migrationLockTimeout.Seconds())).Scan(&got); err != nil {
+			return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		if got.Int64 != 1 {
+			return nil, fmt.Errorf("timed out after %s waiting for another k13d to finish migrating the database", migrationLockTimeout)
+		}
+		return func() { _, _ = conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", migrationLockName) }, nil
+	default:
+		return func() {}, nil
+	}
+}
+
+func schemaMigrationsTableQuery() string {
+	switch currentDBType {
+	case DBTypePostgres:
+		return `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			description VARCHAR(255) DEFAULT '',
+			applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);`
+	case DBTypeMariaDB, DBTypeMySQL:
+		return `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			description VARCHAR(255) DEFAULT '',
+			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;`
+	default: // SQLite
+		return `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			description TEXT DEFAULT '',
+			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);`
+	}
+}
+
+// queryer is satisfied by *sql.DB and *sql.Conn
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// readSchemaVersion returns the highest applied migration, or 0 when none
+// has been recorded
+func readSchemaVersion(ctx context.Context, q queryer) (int, error) {
+	var version sql.NullInt64
+	if err := q.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_migrations").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return int(version.Int64), nil
+}
+
+func applyMigration(ctx context.Context, conn *sql.Conn, m schemaMigration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, stmt := range m.Statements(currentDBType) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			// MySQL has no CREATE INDEX IF NOT EXISTS, and databases from
+			// before versioned migrations already have the indexes
+			var myErr *mysql.MySQLError
+			if errors.As(err, &myErr) && myErr.Number == mysqlErrDupKeyName {
+				continue
+			}
+			return err
+		}
+	}
+
+	insert := "INSERT INTO schema_migrations (version, description) VALUES (?, ?)"
+	if currentDBType == DBTypePostgres {
+		insert = "INSERT INTO schema_migrations (version, description) VALUES ($1, $2)"
+	}
+	if _, err := tx.ExecContext(ctx, insert, m.Version, m.Description); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ReadSchemaVersion connects to the database described by cfg without
+// migrating it and returns its schema version, 0 for a database that
+// predates versioned migrations
+func ReadSchemaVersion(cfg DBConfig) (int, error) {
+	db, _, err := openDB(cfg)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	version, err := readSchemaVersion(ctx, db)
+	if err != nil {
+		// A reachable database without schema_migrations is unversioned
+		if pingErr := db.PingContext(ctx); pingErr != nil {
+			return 0, fmt.Errorf("failed to connect to database: %w", pingErr)
+		}
+		return 0, nil
+	}
+	return version, nil
+}
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMigrate_SQLite(t *testing.T) {
+	cfg := DBConfig{Type: DBTypeSQLite, Path: filepath.Join(t.TempDir(), "migrate.db")}
+	if err := InitWithConfig(cfg); err != nil {
+		t.Fatalf("InitWithConfig() error = %v", err)
+	}
+
+	var applied int
+	if err := DB.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&applied); err != nil {
+		t.Fatalf("schema_migrations not created: %v", err)
+	}
+	if applied != len(schemaMigrations) {
+		t.Errorf("applied %d migrations, want %d", applied, len(schemaMigrations))
+	}
+	var index string
+	if err := DB.QueryRow("SELECT name FROM sqlite_master WHERE type='index' AND name='idx_access_requests_state'").Scan(&index); err != nil {
+		t.Errorf("migration index not created: %v", err)
+	}
+	_ = Close()
+
+	// Reopening applies nothing twice
+	if err := InitWithConfig(cfg); err != nil {
+		t.Fatalf("second InitWithConfig() error = %v", err)
+	}
+	if err := DB.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&applied); err != nil || applied != len(schemaMigrations) {
+		t.Errorf("after reopen applied = %d (err %v), want %d", applied, err, len(schemaMigrations))
+	}
+
+	// A database from a newer k13d is refused
+	if _, err := DB.Exec("INSERT INTO schema_migrations (version, description) VALUES (?, ?)", LatestSchemaVersion()+1, "future"); err != nil {
+		t.Fatal(err)
+	}
+	_ = Close()
+	version, err := ReadSchemaVersion(cfg)
+	if err != nil || version != LatestSchemaVersion()+1 {
+		t.Errorf("ReadSchemaVersion() = %d, %v; want %d", version, err, LatestSchemaVersion()+1)
+	}
+	err = InitWithConfig(cfg)
+	if err == nil || !strings.Contains(err.Error(), "newer than this k13d") {
+		t.Errorf("InitWithConfig() on a newer schema error = %v", err)
+	}
+	if DB != nil {
+		t.Error("DB should be nil after a refused migration")
+	}
+}
+
+func TestMigrate_RunsOnLockedConn(t *testing.T) {
+	conn, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "single.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An audit_logs table from an old release exercises the column upgrade
+	if _, err := conn.Exec("CREATE TABLE audit_logs (id INTEGER PRIMARY KEY, timestamp DATETIME, user TEXT, action TEXT, resource TEXT, details TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	// With one pooled connection, any statement that bypasses the locked
+	// conn blocks forever
+	conn.SetMaxOpenConns(1)
+
+	prevDB, prevType := DB, currentDBType
+	DB, currentDBType = conn, DBTypeSQLite
+	t.Cleanup(func() {
+		_ = conn.Close()
+		DB, currentDBType = prevDB, prevType
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- migrate() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("migrate() error = %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("migrate() used the connection pool outside the locked conn")
+	}
+
+	var count int
+	if err := DB.QueryRow("SELECT COUNT(*) FROM audit_logs WHERE k8s_user = ''").Scan(&count); err != nil {
+		t.Errorf("audit_logs was not upgraded: %v", err)
+	}
+}
+
+func TestReadSchemaVersion_Unversioned(t *testing.T) {
+	cfg := DBConfig{Type: DBTypeSQLite, Path: filepath.Join(t.TempDir(), "legacy.db")}
+	version, err := ReadSchemaVersion(cfg)
+	if err != nil || version != 0 {
+		t.Errorf("ReadSchemaVersion() = %d, %v; want 0 for a database without schema_migrations", version, err)
+	}
+}
+
+func TestSchemaMigrations_Ordered(t *testing.T) {
+	for i, m := range schemaMigrations {
+		if m.Version != i+1 {
+			t.Errorf("schemaMigrations[%d].Version = %d, want %d", i, m.Version, i+1)
+		}
+		for _, dbType := range []DBType{DBTypeSQLite, DBTypePostgres, DBTypeMySQL} {
+			if len(m.Statements(dbType)) == 0 {
+				t.Errorf("migration %d has no statements for %s", m.Version, dbType)
+			}
+		}
+	}
+}
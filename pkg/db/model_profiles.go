@@ -42,7 +42,11 @@ func InitModelProfilesTable() error {
 	if DB == nil {
 		return ErrDBNotInitialized
 	}
+	return initModelProfilesTable(context.Background(), DB)
+}
 
+// initModelProfilesTable creates the model_profiles table on q
+func initModelProfilesTable(ctx context.Context, q schemaExecer) error {
 	query := `
 	CREATE TABLE IF NOT EXISTS model_profiles (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -65,7 +69,7 @@ func InitModelProfilesTable() error {
 	CREATE INDEX IF NOT EXISTS idx_model_profiles_active ON model_profiles(is_active);
 	`
 
-	_, err := DB.Exec(query)
+	_, err := q.ExecContext(ctx, query)
 	return err
 }
 
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -16,7 +17,11 @@ func InitCustomRolesTable() error {
 	if DB == nil {
 		return fmt.Errorf("database not initialized")
 	}
+	return initCustomRolesTable(context.Background(), DB)
+}
 
+// initCustomRolesTable creates the custom_roles table on q
+func initCustomRolesTable(ctx context.Context, q schemaExecer) error {
 	var query string
 	switch currentDBType {
 	case DBTypePostgres:
@@ -45,7 +50,7 @@ func InitCustomRolesTable() error {
 		);`
 	}
 
-	_, err := DB.Exec(query)
+	_, err := q.ExecContext(ctx, query)
 	return err
 }
 
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -20,7 +21,11 @@ func InitWebSettingsTable() error {
 	if DB == nil {
 		return ErrDBNotInitialized
 	}
+	return initWebSettingsTable(context.Background(), DB)
+}
 
+// initWebSettingsTable creates the web_settings table on q
+func initWebSettingsTable(ctx context.Context, q schemaExecer) error {
 	query := `
 	CREATE TABLE IF NOT EXISTS web_settings (
 		key TEXT PRIMARY KEY,
@@ -28,7 +33,7 @@ func InitWebSettingsTable() error {
 		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 	);`
 
-	_, err := DB.Exec(query)
+	_, err := q.ExecContext(ctx, query)
 	return err
 }
 
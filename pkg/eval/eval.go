@@ -129,3 +129,26 @@ func RunEval(ctx context.Context, provider providers.Provider, task Task) EvalRe
 
 	return result
 }
+
+// pingPrompt is the request PingProvider sends; it asks for the shortest
+// possible answer to keep the check cheap
+const pingPrompt = "Reply with OK."
+
+// PingProvider sends one tiny request to provider and returns how long it
+// took. IsReady only checks configuration, so this is what catches a wrong
+// API key, model name, or endpoint before a long run. The answer itself is
+// not checked.
+func PingProvider(ctx context.Context, provider providers.Provider, timeout time.Duration) (time.Duration, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	_, err := provider.AskNonStreaming(ctx, pingPrompt)
+	elapsed := time.Since(start)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return elapsed, fmt.Errorf("no answer within %s: %w", timeout, err)
+	}
+	return elapsed, err
+}
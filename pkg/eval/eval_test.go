@@ -0,0 +1,57 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai/providers"
+)
+
+// pingTestProvider answers AskNonStreaming with err, or waits for the
+// context when block is set
+type pingTestProvider struct {
+	err    error
+	block  bool
+	prompt string
+}
+
+func (p *pingTestProvider) Name() string { return "test" }
+func (p *pingTestProvider) Ask(ctx context.Context, prompt string, callback func(string)) error {
+	return nil
+}
+func (p *pingTestProvider) AskNonStreaming(ctx context.Context, prompt string) (string, error) {
+	p.prompt = prompt
+	if p.block {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+	return "OK", p.err
+}
+func (p *pingTestProvider) IsReady() bool                                    { return true }
+func (p *pingTestProvider) GetModel() string                                 { return "test-model" }
+func (p *pingTestProvider) ListModels(ctx context.Context) ([]string, error) { return nil, nil }
+func (p *pingTestProvider) Capabilities() providers.Capabilities             { return providers.Capabilities{} }
+
+func TestPingProvider(t *testing.T) {
+	ok := &pingTestProvider{}
+	if _, err := PingProvider(context.Background(), ok, time.Second); err != nil {
+		t.Errorf("PingProvider() error = %v", err)
+	}
+	if ok.prompt != pingPrompt {
+		t.Errorf("PingProvider() sent %q, want %q", ok.prompt, pingPrompt)
+	}
+
+	rejected := &pingTestProvider{err: errors.New("API error (status 401): invalid api key")}
+	if _, err := PingProvider(context.Background(), rejected, time.Second); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("PingProvider() error = %v, want the provider error", err)
+	}
+
+	hung := &pingTestProvider{block: true}
+	_, err := PingProvider(context.Background(), hung, 20*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "no answer within 20ms") || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PingProvider() error = %v, want a timeout", err)
+	}
+}
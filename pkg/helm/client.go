@@ -20,6 +20,8 @@ type Client struct {
 	settings   *cli.EnvSettings
 	namespace  string
 	kubeconfig string
+	// newConfig replaces the Kubernetes-backed action configuration in tests
+	newConfig func(namespace string) (*action.Configuration, error)
 }
 
 // Release represents a Helm release
@@ -67,11 +69,26 @@ func NewClient(namespace string, kubeconfig string) *Client {
 	}
 }
 
+// SetKubeContext makes the client use the named kubeconfig context instead
+// of the current one. It must be called before the first request.
+func (c *Client) SetKubeContext(name string) {
+	c.settings.KubeContext = name
+}
+
 // getActionConfig creates a new action configuration
 func (c *Client) getActionConfig(namespace string) (*action.Configuration, error) {
 	if namespace == "" {
 		namespace = c.namespace
 	}
+	return c.newActionConfig(namespace)
+}
+
+// newActionConfig creates an action configuration for namespace; an empty
+// namespace reads the release storage of all namespaces
+func (c *Client) newActionConfig(namespace string) (*action.Configuration, error) {
+	if c.newConfig != nil {
+		return c.newConfig(namespace)
+	}
 
 	actionConfig := new(action.Configuration)
 	err := actionConfig.Init(c.settings.RESTClientGetter(), namespace, os.Getenv("HELM_DRIVER"), func(format string, v ...interface{}) {
@@ -85,12 +102,15 @@ func (c *Client) getActionConfig(namespace string) (*action.Configuration, error
 
 // ListReleases lists all Helm releases
 func (c *Client) ListReleases(ctx context.Context, namespace string, allNamespaces bool) ([]Release, error) {
-	ns := namespace
+	var (
+		actionConfig *action.Configuration
+		err          error
+	)
 	if allNamespaces {
-		ns = ""
+		actionConfig, err = c.newActionConfig("")
+	} else {
+		actionConfig, err = c.getActionConfig(namespace)
 	}
-
-	actionConfig, err := c.getActionConfig(ns)
 	if err != nil {
 		return nil, err
 	}
@@ -287,6 +307,40 @@ func (c *Client) UpgradeRelease(ctx context.Context, name string, chartRef strin
 	}, nil
 }
 
+// UpdateReleaseValues upgrades a release to a new revision of the chart it
+// already runs, with values replacing its user-supplied values, so changes
+// go through Helm rather than drifting from the release
+func (c *Client) UpdateReleaseValues(ctx context.Context, name string, namespace string, values map[string]interface{}) (*Release, error) {
+	actionConfig, err := c.getActionConfig(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := action.NewGet(actionConfig).Run(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release %s: %w", name, err)
+	}
+
+	upgradeAction := action.NewUpgrade(actionConfig)
+	upgradeAction.Namespace = current.Namespace
+	upgradeAction.Timeout = 5 * time.Minute
+
+	r, err := upgradeAction.RunWithContext(ctx, name, current.Chart, values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade release: %w", err)
+	}
+
+	return &Release{
+		Name:       r.Name,
+		Namespace:  r.Namespace,
+		Revision:   r.Version,
+		Status:     string(r.Info.Status),
+		Chart:      r.Chart.Metadata.Name + "-" + r.Chart.Metadata.Version,
+		AppVersion: r.Chart.Metadata.AppVersion,
+		Updated:    r.Info.LastDeployed.Time,
+	}, nil
+}
+
 // UninstallRelease uninstalls a release
 func (c *Client) UninstallRelease(ctx context.Context, name string, namespace string, keepHistory bool) error {
 	actionConfig, err := c.getActionConfig(namespace)
@@ -1,14 +1,24 @@
 package helm
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/repo"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	helmtime "helm.sh/helm/v3/pkg/time"
 )
 
 // TestNewClient verifies constructor defaults and overrides
@@ -455,3 +465,88 @@ func createTestSettings(tmpDir string) *cli.EnvSettings {
 	_ = os.MkdirAll(filepath.Join(tmpDir, "cache"), 0755)
 	return settings
 }
+
+// memoryClient returns a client whose releases live in an in-memory store
+// holding releases
+func memoryClient(t *testing.T, releases ...*release.Release) *Client {
+	t.Helper()
+	mem := driver.NewMemory()
+	store := storage.Init(mem)
+	for _, r := range releases {
+		mem.SetNamespace(r.Namespace)
+		if err := store.Create(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := NewClient("", "")
+	c.newConfig = func(namespace string) (*action.Configuration, error) {
+		mem.SetNamespace(namespace)
+		return &action.Configuration{
+			Releases:     store,
+			KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+			Capabilities: chartutil.DefaultCapabilities,
+			Log:          func(string, ...interface{}) {},
+		}, nil
+	}
+	return c
+}
+
+func testRelease(name, namespace string, values map[string]interface{}) *release.Release {
+	return &release.Release{
+		Name:      name,
+		Namespace: namespace,
+		Version:   1,
+		Info:      &release.Info{Status: release.StatusDeployed, LastDeployed: helmtime.Now()},
+		Chart: &chart.Chart{Metadata: &chart.Metadata{
+			APIVersion: chart.APIVersionV2,
+			Name:       "web",
+			Version:    "1.2.0",
+			AppVersion: "2.0",
+		}},
+		Config: values,
+	}
+}
+
+func TestListReleases_AllNamespaces(t *testing.T) {
+	c := memoryClient(t, testRelease("api", "payments", nil), testRelease("site", "default", nil))
+
+	all, err := c.ListReleases(context.Background(), "", true)
+	if err != nil {
+		t.Fatalf("ListReleases(all) error = %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("ListReleases(all) = %+v, want both releases", all)
+	}
+
+	one, err := c.ListReleases(context.Background(), "payments", false)
+	if err != nil {
+		t.Fatalf("ListReleases(payments) error = %v", err)
+	}
+	if len(one) != 1 || one[0].Name != "api" || one[0].Chart != "web-1.2.0" {
+		t.Errorf("ListReleases(payments) = %+v, want api", one)
+	}
+}
+
+func TestUpdateReleaseValues(t *testing.T) {
+	c := memoryClient(t, testRelease("api", "payments", map[string]interface{}{"replicas": 1}))
+
+	r, err := c.UpdateReleaseValues(context.Background(), "api", "payments", map[string]interface{}{"replicas": 3})
+	if err != nil {
+		t.Fatalf("UpdateReleaseValues() error = %v", err)
+	}
+	if r.Revision != 2 || r.Chart != "web-1.2.0" {
+		t.Errorf("UpdateReleaseValues() = %+v, want revision 2 of the same chart", r)
+	}
+
+	values, err := c.GetReleaseValues(context.Background(), "api", "payments", false)
+	if err != nil {
+		t.Fatalf("GetReleaseValues() error = %v", err)
+	}
+	if values["replicas"] != 3 {
+		t.Errorf("values = %v, want replicas 3", values)
+	}
+
+	if _, err := c.UpdateReleaseValues(context.Background(), "missing", "payments", nil); err == nil {
+		t.Error("UpdateReleaseValues() of a missing release should fail")
+	}
+}
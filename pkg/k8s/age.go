@@ -0,0 +1,75 @@
+package k8s
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatAge renders a duration as a compact k9s-style age in its largest
+// whole unit: "45s", "12m", "5h", or "3d". Negative durations, from clock
+// skew between the client and the API server, render as "0s".
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(max(d, 0).Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// FormatAgeSince renders the age of t with FormatAge, or "<unknown>" when t
+// is unset
+func FormatAgeSince(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return FormatAge(time.Since(t))
+}
+
+// ParseAge parses an age rendered by FormatAge or a compound duration such
+// as "2d3h" or "72h3m2s", so age columns sort by time rather than text.
+// Text after the first space, such as "5m (running)", is ignored. It
+// reports false for values that are not ages, such as "<none>".
+func ParseAge(s string) (time.Duration, bool) {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), " ")
+	if s == "" {
+		return 0, false
+	}
+
+	var total time.Duration
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return 0, false
+		}
+		n, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return 0, false
+		}
+		s = s[i:]
+
+		var unit time.Duration
+		switch {
+		case strings.HasPrefix(s, "ms"):
+			unit, s = time.Millisecond, s[2:]
+		case s[0] == 's':
+			unit, s = time.Second, s[1:]
+		case s[0] == 'm':
+			unit, s = time.Minute, s[1:]
+		case s[0] == 'h':
+			unit, s = time.Hour, s[1:]
+		case s[0] == 'd':
+			unit, s = 24*time.Hour, s[1:]
+		default:
+			return 0, false
+		}
+		total += time.Duration(n * float64(unit))
+	}
+	return total, true
+}
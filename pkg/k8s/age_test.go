@@ -0,0 +1,67 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{-5 * time.Second, "0s"},
+		{0, "0s"},
+		{45 * time.Second, "45s"},
+		{12*time.Minute + 59*time.Second, "12m"},
+		{5*time.Hour + 30*time.Minute, "5h"},
+		{72*time.Hour + 3*time.Minute + 2*time.Second, "3d"},
+		{400 * 24 * time.Hour, "400d"},
+	}
+	for _, tt := range tests {
+		if got := FormatAge(tt.d); got != tt.want {
+			t.Errorf("FormatAge(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+
+	if got := FormatAgeSince(time.Time{}); got != "<unknown>" {
+		t.Errorf("FormatAgeSince(zero) = %q, want <unknown>", got)
+	}
+	if got := FormatAgeSince(time.Now().Add(-2 * time.Hour)); got != "2h" {
+		t.Errorf("FormatAgeSince(2h ago) = %q, want 2h", got)
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"3d", 72 * time.Hour, true},
+		{"12m", 12 * time.Minute, true},
+		{"2d3h", 51 * time.Hour, true},
+		{"72h3m2s", 72*time.Hour + 3*time.Minute + 2*time.Second, true},
+		{"1.5s", 1500 * time.Millisecond, true},
+		{"350ms", 350 * time.Millisecond, true},
+		{" 5m (running)", 5 * time.Minute, true},
+		{"", 0, false},
+		{"-", 0, false},
+		{"<none>", 0, false},
+		{"5x", 0, false},
+		{"12", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseAge(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseAge(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// Ages sort by time, not text
+	a, _ := ParseAge(FormatAge(3 * 24 * time.Hour))
+	b, _ := ParseAge(FormatAge(5 * time.Hour))
+	if a <= b {
+		t.Errorf("ParseAge(3d) = %v, should exceed ParseAge(5h) = %v", a, b)
+	}
+}
@@ -0,0 +1,282 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// BlastRadiusItem counts objects of one kind that a delete takes with it,
+// e.g. {Kind: "pods", Count: 3}
+type BlastRadiusItem struct {
+	Kind  string
+	Count int
+}
+
+// FormatBlastRadius joins items as "2 replicasets, 1 pod", skipping kinds
+// with no objects, or returns "" when nothing cascades
+func FormatBlastRadius(items []BlastRadiusItem) string {
+	var parts []string
+	for _, item := range items {
+		switch {
+		case item.Count == 1:
+			parts = append(parts, "1 "+strings.TrimSuffix(item.Kind, "s"))
+		case item.Count > 1:
+			parts = append(parts, fmt.Sprintf("%d %s", item.Count, item.Kind))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// MergeBlastRadius adds up the counts of several deletes, keeping the order
+// in which kinds first appear
+func MergeBlastRadius(radii ...[]BlastRadiusItem) []BlastRadiusItem {
+	var merged []BlastRadiusItem
+	index := map[string]int{}
+	for _, items := range radii {
+		for _, item := range items {
+			if i, ok := index[item.Kind]; ok {
+				merged[i].Count += item.Count
+				continue
+			}
+			index[item.Kind] = len(merged)
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
+// ownedBy reports whether refs name one of owners as an owner
+func ownedBy(refs []metav1.OwnerReference, owners map[types.UID]bool) bool {
+	for _, ref := range refs {
+		if owners[ref.UID] {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteBlastRadius returns what deleting the named resource cascades to:
+// the ReplicaSets and pods of a deployment, the pods of other controllers,
+// the jobs and pods of a cronjob, or the main objects in a namespace.
+// Resources that own nothing return no items.
+func (c *Client) DeleteBlastRadius(ctx context.Context, resource, namespace, name string) ([]BlastRadiusItem, error) {
+	opts := metav1.ListOptions{}
+	apps := c.clientset().AppsV1()
+	core := c.clientset().CoreV1()
+	batch := c.clientset().BatchV1()
+
+	// ownedPods counts the pods in namespace owned by any of owners
+	ownedPods := func(owners map[types.UID]bool) (int, error) {
+		if len(owners) == 0 {
+			return 0, nil
+		}
+		pods, err := core.Pods(namespace).List(ctx, opts)
+		if err != nil {
+			return 0, err
+		}
+		n := 0
+		for _, pod := range pods.Items {
+			if ownedBy(pod.OwnerReferences, owners) {
+				n++
+			}
+		}
+		return n, nil
+	}
+	// podsOf returns the pods owned by the controller with uid
+	podsOf := func(uid types.UID) ([]BlastRadiusItem, error) {
+		n, err := ownedPods(map[types.UID]bool{uid: true})
+		if err != nil {
+			return nil, err
+		}
+		return []BlastRadiusItem{{Kind: "pods", Count: n}}, nil
+	}
+
+	switch resource {
+	case "deployments", "deployment", "deploy":
+		deploy, err := apps.Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		rsList, err := apps.ReplicaSets(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		owned := map[types.UID]bool{}
+		for _, rs := range rsList.Items {
+			if ownedBy(rs.OwnerReferences, map[types.UID]bool{deploy.UID: true}) {
+				owned[rs.UID] = true
+			}
+		}
+		pods, err := ownedPods(owned)
+		if err != nil {
+			return nil, err
+		}
+		return []BlastRadiusItem{{Kind: "replicasets", Count: len(owned)}, {Kind: "pods", Count: pods}}, nil
+
+	case "statefulsets", "statefulset", "sts":
+		sts, err := apps.StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return podsOf(sts.UID)
+
+	case "daemonsets", "daemonset", "ds":
+		ds, err := apps.DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return podsOf(ds.UID)
+
+	case "replicasets", "replicaset", "rs":
+		rs, err := apps.ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return podsOf(rs.UID)
+
+	case "replicationcontrollers", "replicationcontroller", "rc":
+		rc, err := core.ReplicationControllers(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return podsOf(rc.UID)
+
+	case "jobs", "job":
+		job, err := batch.Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return podsOf(job.UID)
+
+	case "cronjobs", "cronjob", "cj":
+		cj, err := batch.CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		jobs, err := batch.Jobs(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		owned := map[types.UID]bool{}
+		for _, job := range jobs.Items {
+			if ownedBy(job.OwnerReferences, map[types.UID]bool{cj.UID: true}) {
+				owned[job.UID] = true
+			}
+		}
+		pods, err := ownedPods(owned)
+		if err != nil {
+			return nil, err
+		}
+		return []BlastRadiusItem{{Kind: "jobs", Count: len(owned)}, {Kind: "pods", Count: pods}}, nil
+
+	case "namespaces", "namespace", "ns":
+		return c.namespaceBlastRadius(ctx, name)
+	}
+	return nil, nil
+}
+
+// namespaceBlastRadius counts the main objects deleting namespace destroys.
+// Kinds that cannot be listed are left out rather than failing the count.
+func (c *Client) namespaceBlastRadius(ctx context.Context, namespace string) ([]BlastRadiusItem, error) {
+	opts := metav1.ListOptions{}
+	apps := c.clientset().AppsV1()
+	core := c.clientset().CoreV1()
+	batch := c.clientset().BatchV1()
+
+	counters := []struct {
+		kind  string
+		count func() (int, error)
+	}{
+		{"deployments", func() (int, error) {
+			l, err := apps.Deployments(namespace).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"statefulsets", func() (int, error) {
+			l, err := apps.StatefulSets(namespace).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"daemonsets", func() (int, error) {
+			l, err := apps.DaemonSets(namespace).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"jobs", func() (int, error) {
+			l, err := batch.Jobs(namespace).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"cronjobs", func() (int, error) {
+			l, err := batch.CronJobs(namespace).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"pods", func() (int, error) {
+			l, err := core.Pods(namespace).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"services", func() (int, error) {
+			l, err := core.Services(namespace).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"configmaps", func() (int, error) {
+			l, err := core.ConfigMaps(namespace).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"secrets", func() (int, error) {
+			l, err := core.Secrets(namespace).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"persistentvolumeclaims", func() (int, error) {
+			l, err := core.PersistentVolumeClaims(namespace).List(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+	}
+
+	var (
+		items   []BlastRadiusItem
+		lastErr error
+	)
+	for _, counter := range counters {
+		n, err := counter.count()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		items = append(items, BlastRadiusItem{Kind: counter.kind, Count: n})
+	}
+	if len(items) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return items, nil
+}
@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func ownedMeta(name, namespace string, uid, owner types.UID) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{Name: name, Namespace: namespace, UID: uid}
+	if owner != "" {
+		meta.OwnerReferences = []metav1.OwnerReference{{UID: owner, Name: "owner"}}
+	}
+	return meta
+}
+
+func TestDeleteBlastRadius(t *testing.T) {
+	c := &Client{Clientset: fake.NewClientset( //nolint:staticcheck
+		&appsv1.Deployment{ObjectMeta: ownedMeta("web", "default", "deploy-web", "")},
+		&appsv1.ReplicaSet{ObjectMeta: ownedMeta("web-1", "default", "rs-1", "deploy-web")},
+		&appsv1.ReplicaSet{ObjectMeta: ownedMeta("web-2", "default", "rs-2", "deploy-web")},
+		&appsv1.ReplicaSet{ObjectMeta: ownedMeta("other", "default", "rs-3", "deploy-other")},
+		&corev1.Pod{ObjectMeta: ownedMeta("web-1-a", "default", "p1", "rs-1")},
+		&corev1.Pod{ObjectMeta: ownedMeta("web-2-a", "default", "p2", "rs-2")},
+		&corev1.Pod{ObjectMeta: ownedMeta("web-2-b", "default", "p3", "rs-2")},
+		&corev1.Pod{ObjectMeta: ownedMeta("other-a", "default", "p4", "rs-3")},
+		&batchv1.CronJob{ObjectMeta: ownedMeta("backup", "default", "cj-1", "")},
+		&batchv1.Job{ObjectMeta: ownedMeta("backup-1", "default", "job-1", "cj-1")},
+		&corev1.Pod{ObjectMeta: ownedMeta("backup-1-a", "default", "p5", "job-1")},
+		&corev1.Secret{ObjectMeta: ownedMeta("token", "default", "s1", "")},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	)}
+	ctx := context.Background()
+
+	tests := []struct {
+		resource, namespace, name string
+		want                      string
+	}{
+		{"deploy", "default", "web", "2 replicasets, 3 pods"},
+		{"replicasets", "default", "other", "1 pod"},
+		{"cronjobs", "default", "backup", "1 job, 1 pod"},
+		{"namespaces", "", "default", "1 deployment, 1 job, 1 cronjob, 5 pods, 1 secret"},
+		{"configmaps", "default", "anything", ""},
+	}
+	for _, tt := range tests {
+		items, err := c.DeleteBlastRadius(ctx, tt.resource, tt.namespace, tt.name)
+		if err != nil {
+			t.Errorf("DeleteBlastRadius(%s %s) error = %v", tt.resource, tt.name, err)
+			continue
+		}
+		if got := FormatBlastRadius(items); got != tt.want {
+			t.Errorf("DeleteBlastRadius(%s %s) = %q, want %q", tt.resource, tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := c.DeleteBlastRadius(ctx, "deployments", "default", "missing"); err == nil {
+		t.Error("expected an error for a missing deployment")
+	}
+}
+
+func TestMergeBlastRadius(t *testing.T) {
+	merged := MergeBlastRadius(
+		[]BlastRadiusItem{{Kind: "replicasets", Count: 1}, {Kind: "pods", Count: 2}},
+		nil,
+		[]BlastRadiusItem{{Kind: "pods", Count: 3}, {Kind: "jobs", Count: 1}},
+	)
+	if got := FormatBlastRadius(merged); got != "1 replicaset, 5 pods, 1 job" {
+		t.Errorf("MergeBlastRadius() = %q", got)
+	}
+}
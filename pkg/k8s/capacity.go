@@ -0,0 +1,193 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeUnderUtilizedPercent is the CPU and memory utilization below which a
+// node is reported as under-utilized. Utilization is measured usage when
+// metrics-server is available and pod requests otherwise.
+const NodeUnderUtilizedPercent = 20
+
+// Node capacity states
+const (
+	NodeCapacityOK            = "OK"
+	NodeCapacityOverCommitted = "OverCommitted" // requests or limits exceed allocatable
+	NodeCapacityUnderUtilized = "UnderUtilized" // CPU and memory both below NodeUnderUtilizedPercent
+)
+
+// NodeCapacity compares a node's allocatable CPU and memory with the requests
+// and limits of the pods bound to it and, when metrics-server is available,
+// its actual usage.
+type NodeCapacity struct {
+	Name string
+
+	CPUAllocatableMilli int64
+	CPURequestedMilli   int64
+	CPULimitsMilli      int64
+	CPUUsedMilli        int64
+
+	MemAllocatableMB int64
+	MemRequestedMB   int64
+	MemLimitsMB      int64
+	MemUsedMB        int64
+
+	Pods        int
+	PodCapacity int64
+
+	UsageAvailable bool     // CPUUsedMilli and MemUsedMB come from metrics-server
+	Status         string   // NodeCapacityOK, NodeCapacityOverCommitted, or NodeCapacityUnderUtilized
+	Reasons        []string // why the node is over-committed or under-utilized
+}
+
+// CapacityPercent returns value as a whole percentage of total, or 0 when
+// total is unknown
+func CapacityPercent(value, total int64) int {
+	if total <= 0 {
+		return 0
+	}
+	return int(value * 100 / total)
+}
+
+// GetNodeCapacity returns the capacity of every node, sorted by name. Usage
+// is left out when metrics-server is unavailable.
+func (c *Client) GetNodeCapacity(ctx context.Context) ([]NodeCapacity, error) {
+	nodes, err := c.clientset().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.clientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	usage, err := c.GetNodeMetrics(ctx)
+	if err != nil {
+		usage = nil
+	}
+	return ComputeNodeCapacity(nodes.Items, pods.Items, usage), nil
+}
+
+// ComputeNodeCapacity sums the requests and limits of the non-terminated pods
+// bound to each node, the way kubectl describe node does, and classifies the
+// node. usage maps node names to [CPU millicores, memory MB] from
+// GetNodeMetrics and may be nil.
+func ComputeNodeCapacity(nodes []corev1.Node, pods []corev1.Pod, usage map[string][]int64) []NodeCapacity {
+	byNode := make(map[string]*NodeCapacity, len(nodes))
+	result := make([]NodeCapacity, len(nodes))
+	for i, node := range nodes {
+		nc := &result[i]
+		nc.Name = node.Name
+		nc.CPUAllocatableMilli = node.Status.Allocatable.Cpu().MilliValue()
+		nc.MemAllocatableMB = node.Status.Allocatable.Memory().Value() / 1024 / 1024
+		nc.PodCapacity = node.Status.Allocatable.Pods().Value()
+		if metric, ok := usage[node.Name]; ok && len(metric) >= 2 {
+			nc.UsageAvailable = true
+			nc.CPUUsedMilli, nc.MemUsedMB = metric[0], metric[1]
+		}
+		byNode[node.Name] = nc
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		nc, ok := byNode[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		req, lim := podRequestsAndLimits(pod)
+		nc.Pods++
+		nc.CPURequestedMilli += req.Cpu().MilliValue()
+		nc.CPULimitsMilli += lim.Cpu().MilliValue()
+		nc.MemRequestedMB += req.Memory().Value() / 1024 / 1024
+		nc.MemLimitsMB += lim.Memory().Value() / 1024 / 1024
+	}
+
+	for i := range result {
+		classifyNodeCapacity(&result[i])
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// podRequestsAndLimits returns the pod's effective CPU and memory requests and
+// limits: the sum over its containers, at least the largest init container,
+// plus the pod overhead.
+func podRequestsAndLimits(pod *corev1.Pod) (corev1.ResourceList, corev1.ResourceList) {
+	reqs, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	addTo := func(total, list corev1.ResourceList) {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			if q, ok := list[name]; ok {
+				sum := q.DeepCopy()
+				if prev, ok := total[name]; ok {
+					sum.Add(prev)
+				}
+				total[name] = sum
+			}
+		}
+	}
+	maxOf := func(total, list corev1.ResourceList) {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			if q, ok := list[name]; ok {
+				if prev, ok := total[name]; !ok || q.Cmp(prev) > 0 {
+					total[name] = q.DeepCopy()
+				}
+			}
+		}
+	}
+
+	for _, ctr := range pod.Spec.Containers {
+		addTo(reqs, ctr.Resources.Requests)
+		addTo(limits, ctr.Resources.Limits)
+	}
+	for _, ctr := range pod.Spec.InitContainers {
+		maxOf(reqs, ctr.Resources.Requests)
+		maxOf(limits, ctr.Resources.Limits)
+	}
+	addTo(reqs, pod.Spec.Overhead)
+	addTo(limits, pod.Spec.Overhead)
+	return reqs, limits
+}
+
+// classifyNodeCapacity sets the node's Status and Reasons
+func classifyNodeCapacity(nc *NodeCapacity) {
+	nc.Status = NodeCapacityOK
+	nc.Reasons = nil
+
+	check := func(resource string, requested, limits, allocatable int64) {
+		if allocatable <= 0 {
+			return
+		}
+		if requested > allocatable {
+			nc.Reasons = append(nc.Reasons, fmt.Sprintf("%s requests at %d%% of allocatable", resource, CapacityPercent(requested, allocatable)))
+		}
+		if limits > allocatable {
+			nc.Reasons = append(nc.Reasons, fmt.Sprintf("%s limits at %d%% of allocatable", resource, CapacityPercent(limits, allocatable)))
+		}
+	}
+	check("CPU", nc.CPURequestedMilli, nc.CPULimitsMilli, nc.CPUAllocatableMilli)
+	check("memory", nc.MemRequestedMB, nc.MemLimitsMB, nc.MemAllocatableMB)
+	if len(nc.Reasons) > 0 {
+		nc.Status = NodeCapacityOverCommitted
+		return
+	}
+
+	if nc.CPUAllocatableMilli <= 0 || nc.MemAllocatableMB <= 0 {
+		return
+	}
+	cpu, mem, basis := nc.CPURequestedMilli, nc.MemRequestedMB, "requested"
+	if nc.UsageAvailable {
+		cpu, mem, basis = nc.CPUUsedMilli, nc.MemUsedMB, "used"
+	}
+	cpuPct, memPct := CapacityPercent(cpu, nc.CPUAllocatableMilli), CapacityPercent(mem, nc.MemAllocatableMB)
+	if cpuPct < NodeUnderUtilizedPercent && memPct < NodeUnderUtilizedPercent {
+		nc.Status = NodeCapacityUnderUtilized
+		nc.Reasons = []string{fmt.Sprintf("CPU %d%% and memory %d%% %s", cpuPct, memPct, basis)}
+	}
+}
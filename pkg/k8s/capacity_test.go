@@ -0,0 +1,116 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func capacityNode(name, cpu, mem string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(mem),
+			corev1.ResourcePods:   resource.MustParse("110"),
+		}},
+	}
+}
+
+func capacityPod(name, node string, phase corev1.PodPhase, req, lim corev1.ResourceList) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{{
+				Name:      "app",
+				Resources: corev1.ResourceRequirements{Requests: req, Limits: lim},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func cpuMem(cpu, mem string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(mem),
+	}
+}
+
+func TestComputeNodeCapacity(t *testing.T) {
+	nodes := []corev1.Node{
+		*capacityNode("busy", "4", "8Gi"),
+		*capacityNode("idle", "4", "8Gi"),
+		*capacityNode("normal", "4", "8Gi"),
+	}
+	withInit := capacityPod("with-init", "normal", corev1.PodPending, cpuMem("1", "2Gi"), nil)
+	withInit.Spec.InitContainers = []corev1.Container{{
+		Name:      "migrate",
+		Resources: corev1.ResourceRequirements{Requests: cpuMem("2", "1Gi")},
+	}}
+	pods := []corev1.Pod{
+		*capacityPod("api", "busy", corev1.PodRunning, cpuMem("2", "4Gi"), cpuMem("4", "12Gi")),
+		*capacityPod("worker", "busy", corev1.PodRunning, cpuMem("1", "2Gi"), cpuMem("2", "2Gi")),
+		*capacityPod("done", "busy", corev1.PodSucceeded, cpuMem("4", "8Gi"), nil),
+		*capacityPod("small", "idle", corev1.PodRunning, cpuMem("100m", "256Mi"), nil),
+		*withInit,
+		*capacityPod("unscheduled", "", corev1.PodPending, cpuMem("8", "8Gi"), nil),
+	}
+	usage := map[string][]int64{"normal": {1500, 3072}}
+
+	got := ComputeNodeCapacity(nodes, pods, usage)
+	if len(got) != 3 {
+		t.Fatalf("ComputeNodeCapacity() returned %d nodes, want 3", len(got))
+	}
+
+	busy := got[0]
+	if busy.Name != "busy" || busy.Pods != 2 || busy.CPURequestedMilli != 3000 || busy.CPULimitsMilli != 6000 ||
+		busy.MemRequestedMB != 6144 || busy.MemLimitsMB != 14336 || busy.PodCapacity != 110 {
+		t.Errorf("busy = %+v, want 2 pods, 3000m/6000m CPU, 6144/14336 MB", busy)
+	}
+	if busy.Status != NodeCapacityOverCommitted || len(busy.Reasons) != 2 {
+		t.Errorf("busy status = %s %v, want OverCommitted on CPU and memory limits", busy.Status, busy.Reasons)
+	}
+
+	idle := got[1]
+	if idle.Status != NodeCapacityUnderUtilized || idle.UsageAvailable {
+		t.Errorf("idle = %+v, want UnderUtilized from requests", idle)
+	}
+	if len(idle.Reasons) != 1 || idle.Reasons[0] != "CPU 2% and memory 3% requested" {
+		t.Errorf("idle reasons = %v", idle.Reasons)
+	}
+
+	// The init container's 2 CPUs outweigh the app container's 1
+	normal := got[2]
+	if normal.CPURequestedMilli != 2000 || normal.MemRequestedMB != 2048 {
+		t.Errorf("normal requests = %dm/%dMB, want 2000m/2048MB", normal.CPURequestedMilli, normal.MemRequestedMB)
+	}
+	if !normal.UsageAvailable || normal.CPUUsedMilli != 1500 || normal.Status != NodeCapacityOK {
+		t.Errorf("normal = %+v, want OK with measured usage", normal)
+	}
+}
+
+func TestGetNodeCapacity(t *testing.T) {
+	c := &Client{Clientset: fake.NewClientset( //nolint:staticcheck
+		capacityNode("node-1", "2", "4Gi"),
+		capacityPod("web", "node-1", corev1.PodRunning, cpuMem("1", "1Gi"), nil),
+	)}
+
+	// A context selector must not narrow the pods counted against a node
+	ctx := WithListSelector(context.Background(), ListSelector{Label: "app=none"})
+	got, err := c.GetNodeCapacity(ctx)
+	if err != nil {
+		t.Fatalf("GetNodeCapacity() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Pods != 1 || got[0].CPURequestedMilli != 1000 || got[0].UsageAvailable {
+		t.Errorf("GetNodeCapacity() = %+v, want node-1 with one 1-CPU pod and no usage", got)
+	}
+	if got[0].Status != NodeCapacityOK {
+		t.Errorf("status = %s, want OK at 50%% CPU requested", got[0].Status)
+	}
+}
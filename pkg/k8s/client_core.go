@@ -19,6 +19,15 @@ type Client struct {
 	Config    *rest.Config
 	Metrics   *metricsv1beta1.MetricsV1beta1Client
 
+	// ConfigSource records where the REST config came from: --kubeconfig,
+	// KUBECONFIG, ~/.kube/config, or in-cluster.
+	ConfigSource string
+	opts         ClientOptions
+
+	// activeContext is the context chosen by SwitchContext; empty means the
+	// kubeconfig's current-context.
+	activeContext string
+
 	// Optional overrides used by hermetic tests to avoid reading the caller's
 	// local kubeconfig for simple context/namespace metadata lookups.
 	ContextsOverride         []string
@@ -26,22 +35,26 @@ type Client struct {
 	CurrentClusterOverride   string
 	CurrentUserOverride      string
 	CurrentNamespaceOverride string
+
+	// contextClientsets caches the clientset of each context queried by
+	// SummarizeContexts, so refreshes reuse their transports. Guarded by mu.
+	contextClientsets map[string]kubernetes.Interface
 }
 
+// NewClient creates a client using ClientOptionsFromEnv, so --kubeconfig
+// (K13D_KUBECONFIG) and KUBECONFIG are honored before in-cluster detection.
 func NewClient() (*Client, error) {
-	// Try in-cluster config first (for running inside Kubernetes)
-	config, err := rest.InClusterConfig()
+	return NewClientWithOptions(ClientOptionsFromEnv())
+}
+
+// NewClientWithOptions creates a client, resolving credentials in the order
+// documented on ClientOptions.
+func NewClientWithOptions(opts ClientOptions) (*Client, error) {
+	config, source, err := ResolveRESTConfig(opts)
 	if err != nil {
-		// Fall back to kubeconfig (for running outside Kubernetes)
-		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-		configOverrides := &clientcmd.ConfigOverrides{}
-		kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
-
-		config, err = kubeConfig.ClientConfig()
-		if err != nil {
-			return nil, err
-		}
+		return nil, err
 	}
+	opts.applyRateLimits(config)
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
@@ -59,19 +72,37 @@ func NewClient() (*Client, error) {
 	}
 
 	return &Client{
-		Clientset: clientset,
-		Dynamic:   dynamicClient,
-		Config:    config,
-		Metrics:   metricsClient,
+		Clientset:    clientset,
+		Dynamic:      dynamicClient,
+		Config:       config,
+		Metrics:      metricsClient,
+		ConfigSource: source,
+		opts:         opts,
 	}, nil
 }
 
-func (c *Client) SwitchContext(contextName string) error {
-	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+// KubeconfigLoadingRules returns the kubeconfig loading rules this client was
+// created with, so context listing and switching read the same files.
+func (c *Client) KubeconfigLoadingRules() *clientcmd.ClientConfigLoadingRules {
+	return c.opts.LoadingRules()
+}
+
+// restConfigForContext builds a REST config for a kubeconfig context without
+// changing the client's active context.
+func (c *Client) restConfigForContext(contextName string) (*rest.Config, error) {
+	loadingRules := c.KubeconfigLoadingRules()
 	configOverrides := &clientcmd.ConfigOverrides{CurrentContext: contextName}
 	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
-
 	config, err := kubeConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	c.opts.applyRateLimits(config)
+	return config, nil
+}
+
+func (c *Client) SwitchContext(contextName string) error {
+	config, err := c.restConfigForContext(contextName)
 	if err != nil {
 		return err
 	}
@@ -96,10 +127,18 @@ func (c *Client) SwitchContext(contextName string) error {
 	c.Dynamic = dynamicClient
 	c.Config = config
 	c.Metrics = metricsClient
+	c.activeContext = contextName
 	c.mu.Unlock()
 	return nil
 }
 
+// contextOverrides returns kubeconfig overrides selecting the active context.
+func (c *Client) contextOverrides() *clientcmd.ConfigOverrides {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return &clientcmd.ConfigOverrides{CurrentContext: c.activeContext}
+}
+
 // clientset returns the Kubernetes clientset with read-lock protection.
 // This must be used instead of directly accessing c.Clientset from methods
 // that may run concurrently with SwitchContext.
@@ -139,8 +178,8 @@ func (c *Client) GetRestConfig() (*rest.Config, error) {
 	if cfg := c.restConfig(); cfg != nil {
 		return cfg, nil
 	}
-	// Fallback: load from default config
-	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	// Fallback: load from the client's kubeconfig
+	loadingRules := c.KubeconfigLoadingRules()
 	configOverrides := &clientcmd.ConfigOverrides{}
 	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
 	return kubeConfig.ClientConfig()
@@ -19,8 +19,8 @@ func (c *Client) GetContextInfo() (ctxName, cluster, user string, err error) {
 		return c.CurrentContextOverride, c.CurrentClusterOverride, c.CurrentUserOverride, nil
 	}
 
-	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-	configOverrides := &clientcmd.ConfigOverrides{}
+	loadingRules := c.KubeconfigLoadingRules()
+	configOverrides := c.contextOverrides()
 	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
 
 	rawConfig, err := kubeConfig.RawConfig()
@@ -29,6 +29,9 @@ func (c *Client) GetContextInfo() (ctxName, cluster, user string, err error) {
 	}
 
 	ctxName = rawConfig.CurrentContext
+	if configOverrides.CurrentContext != "" {
+		ctxName = configOverrides.CurrentContext
+	}
 	if ctx, ok := rawConfig.Contexts[ctxName]; ok {
 		cluster = ctx.Cluster
 		user = ctx.AuthInfo
@@ -47,9 +50,8 @@ func (c *Client) GetCurrentNamespace() string {
 		return c.CurrentNamespaceOverride
 	}
 
-	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-	configOverrides := &clientcmd.ConfigOverrides{}
-	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
+	loadingRules := c.KubeconfigLoadingRules()
+	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, c.contextOverrides())
 	ns, _, _ := kubeConfig.Namespace()
 	return ns
 }
@@ -69,7 +71,7 @@ func (c *Client) ListNamespaces(ctx context.Context) ([]corev1.Namespace, error)
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	ns, err := c.clientset().CoreV1().Namespaces().List(ctxWithTimeout, metav1.ListOptions{})
+	ns, err := c.clientset().CoreV1().Namespaces().List(ctxWithTimeout, listOptions(ctx))
 	if err != nil {
 		log.Errorf("ListNamespaces: ERROR: %v", err)
 		return nil, err
@@ -80,7 +82,7 @@ func (c *Client) ListNamespaces(ctx context.Context) ([]corev1.Namespace, error)
 }
 
 func (c *Client) ListNodes(ctx context.Context) ([]corev1.Node, error) {
-	nodes, err := c.clientset().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	nodes, err := c.clientset().CoreV1().Nodes().List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +98,7 @@ func (c *Client) ListContexts() ([]string, string, error) {
 		return contexts, c.CurrentContextOverride, nil
 	}
 
-	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules := c.KubeconfigLoadingRules()
 	config, err := loadingRules.Load()
 	if err != nil {
 		return nil, "", err
@@ -105,7 +107,11 @@ func (c *Client) ListContexts() ([]string, string, error) {
 	for name := range config.Contexts {
 		contexts = append(contexts, name)
 	}
-	return contexts, config.CurrentContext, nil
+	current := config.CurrentContext
+	if active := c.contextOverrides().CurrentContext; active != "" {
+		current = active
+	}
+	return contexts, current, nil
 }
 
 // DrainNode cordons and drains a node
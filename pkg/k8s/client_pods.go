@@ -24,7 +24,7 @@ func (c *Client) ListPods(ctx context.Context, namespace string) ([]corev1.Pod,
 	defer cancel()
 
 	log.Infof("ListPods: calling c.clientset().CoreV1().Pods(%s).List", namespace)
-	pods, err := c.clientset().CoreV1().Pods(namespace).List(ctxWithTimeout, metav1.ListOptions{})
+	pods, err := c.clientset().CoreV1().Pods(namespace).List(ctxWithTimeout, listOptions(ctx))
 	if err != nil {
 		log.Errorf("ListPods: ERROR: %v", err)
 		return nil, err
@@ -61,6 +61,29 @@ func (c *Client) GetPodLogsStream(ctx context.Context, namespace, name string) (
 	return req.Stream(ctx)
 }
 
+// GetPodLogsSince gets the logs a container wrote at or after since, e.g.
+// since its last restart
+func (c *Client) GetPodLogsSince(ctx context.Context, namespace, name, container string, since time.Time) (string, error) {
+	sinceTime := metav1.NewTime(since)
+	opts := &corev1.PodLogOptions{
+		Container: container,
+		SinceTime: &sinceTime,
+	}
+	req := c.clientset().CoreV1().Pods(namespace).GetLogs(name, opts)
+	podLogs, err := req.Stream(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer podLogs.Close()
+
+	buf := new(strings.Builder)
+	_, err = io.Copy(buf, podLogs)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 // GetPodLogsPrevious gets logs from the previous container instance
 func (c *Client) GetPodLogsPrevious(ctx context.Context, namespace, name, container string, tailLines int64) (string, error) {
 	previous := true
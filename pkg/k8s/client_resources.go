@@ -23,7 +23,7 @@ import (
 )
 
 func (c *Client) ListServices(ctx context.Context, namespace string) ([]corev1.Service, error) {
-	svcs, err := c.clientset().CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	svcs, err := c.clientset().CoreV1().Services(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +31,7 @@ func (c *Client) ListServices(ctx context.Context, namespace string) ([]corev1.S
 }
 
 func (c *Client) ListConfigMaps(ctx context.Context, namespace string) ([]corev1.ConfigMap, error) {
-	cms, err := c.clientset().CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	cms, err := c.clientset().CoreV1().ConfigMaps(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +39,7 @@ func (c *Client) ListConfigMaps(ctx context.Context, namespace string) ([]corev1
 }
 
 func (c *Client) ListSecrets(ctx context.Context, namespace string) ([]corev1.Secret, error) {
-	secrets, err := c.clientset().CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	secrets, err := c.clientset().CoreV1().Secrets(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +47,7 @@ func (c *Client) ListSecrets(ctx context.Context, namespace string) ([]corev1.Se
 }
 
 func (c *Client) ListIngresses(ctx context.Context, namespace string) ([]networkingv1.Ingress, error) {
-	ings, err := c.clientset().NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	ings, err := c.clientset().NetworkingV1().Ingresses(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +55,7 @@ func (c *Client) ListIngresses(ctx context.Context, namespace string) ([]network
 }
 
 func (c *Client) ListEvents(ctx context.Context, namespace string) ([]corev1.Event, error) {
-	events, err := c.clientset().CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	events, err := c.clientset().CoreV1().Events(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +63,7 @@ func (c *Client) ListEvents(ctx context.Context, namespace string) ([]corev1.Eve
 }
 
 func (c *Client) ListRoles(ctx context.Context, namespace string) ([]rbacv1.Role, error) {
-	roles, err := c.clientset().RbacV1().Roles(namespace).List(ctx, metav1.ListOptions{})
+	roles, err := c.clientset().RbacV1().Roles(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +71,7 @@ func (c *Client) ListRoles(ctx context.Context, namespace string) ([]rbacv1.Role
 }
 
 func (c *Client) ListRoleBindings(ctx context.Context, namespace string) ([]rbacv1.RoleBinding, error) {
-	rb, err := c.clientset().RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+	rb, err := c.clientset().RbacV1().RoleBindings(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +79,7 @@ func (c *Client) ListRoleBindings(ctx context.Context, namespace string) ([]rbac
 }
 
 func (c *Client) ListClusterRoles(ctx context.Context) ([]rbacv1.ClusterRole, error) {
-	roles, err := c.clientset().RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	roles, err := c.clientset().RbacV1().ClusterRoles().List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +87,7 @@ func (c *Client) ListClusterRoles(ctx context.Context) ([]rbacv1.ClusterRole, er
 }
 
 func (c *Client) ListClusterRoleBindings(ctx context.Context) ([]rbacv1.ClusterRoleBinding, error) {
-	crb, err := c.clientset().RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	crb, err := c.clientset().RbacV1().ClusterRoleBindings().List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +95,7 @@ func (c *Client) ListClusterRoleBindings(ctx context.Context) ([]rbacv1.ClusterR
 }
 
 func (c *Client) ListPersistentVolumes(ctx context.Context) ([]corev1.PersistentVolume, error) {
-	pv, err := c.clientset().CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	pv, err := c.clientset().CoreV1().PersistentVolumes().List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +103,7 @@ func (c *Client) ListPersistentVolumes(ctx context.Context) ([]corev1.Persistent
 }
 
 func (c *Client) ListPersistentVolumeClaims(ctx context.Context, namespace string) ([]corev1.PersistentVolumeClaim, error) {
-	pvc, err := c.clientset().CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	pvc, err := c.clientset().CoreV1().PersistentVolumeClaims(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +111,7 @@ func (c *Client) ListPersistentVolumeClaims(ctx context.Context, namespace strin
 }
 
 func (c *Client) ListStorageClasses(ctx context.Context) ([]storagev1.StorageClass, error) {
-	sc, err := c.clientset().StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	sc, err := c.clientset().StorageV1().StorageClasses().List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +119,7 @@ func (c *Client) ListStorageClasses(ctx context.Context) ([]storagev1.StorageCla
 }
 
 func (c *Client) ListServiceAccounts(ctx context.Context, namespace string) ([]corev1.ServiceAccount, error) {
-	sa, err := c.clientset().CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+	sa, err := c.clientset().CoreV1().ServiceAccounts(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +127,7 @@ func (c *Client) ListServiceAccounts(ctx context.Context, namespace string) ([]c
 }
 
 func (c *Client) ListHorizontalPodAutoscalers(ctx context.Context, namespace string) ([]autoscalingv2.HorizontalPodAutoscaler, error) {
-	hpas, err := c.clientset().AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+	hpas, err := c.clientset().AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -135,7 +135,7 @@ func (c *Client) ListHorizontalPodAutoscalers(ctx context.Context, namespace str
 }
 
 func (c *Client) ListNetworkPolicies(ctx context.Context, namespace string) ([]networkingv1.NetworkPolicy, error) {
-	netpols, err := c.clientset().NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+	netpols, err := c.clientset().NetworkingV1().NetworkPolicies(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -225,7 +225,7 @@ func (c *Client) GetResourceContext(ctx context.Context, ns, name, resource stri
 // WatchResource starts a Watch on the given resource type.
 // Uses typed clientset methods for fake clientset test compatibility.
 func (c *Client) WatchResource(ctx context.Context, resource, namespace string) (watch.Interface, error) {
-	opts := metav1.ListOptions{}
+	opts := listOptions(ctx)
 	switch strings.ToLower(resource) {
 	// Core resources
 	case "pods":
@@ -412,9 +412,9 @@ func (c *Client) ListDynamicResource(ctx context.Context, gvr schema.GroupVersio
 	var err error
 
 	if namespace == "" {
-		uList, err = c.dynamicClient().Resource(gvr).List(ctx, metav1.ListOptions{})
+		uList, err = c.dynamicClient().Resource(gvr).List(ctx, listOptions(ctx))
 	} else {
-		uList, err = c.dynamicClient().Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		uList, err = c.dynamicClient().Resource(gvr).Namespace(namespace).List(ctx, listOptions(ctx))
 	}
 	if err != nil {
 		return nil, err
@@ -442,9 +442,9 @@ func (c *Client) ListCustomResources(ctx context.Context, crdInfo *CRDInfo, name
 	var err error
 
 	if crdInfo.Namespaced && namespace != "" {
-		list, err = c.dynamicClient().Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		list, err = c.dynamicClient().Resource(gvr).Namespace(namespace).List(ctx, listOptions(ctx))
 	} else {
-		list, err = c.dynamicClient().Resource(gvr).List(ctx, metav1.ListOptions{})
+		list, err = c.dynamicClient().Resource(gvr).List(ctx, listOptions(ctx))
 	}
 
 	if err != nil {
@@ -495,7 +495,7 @@ func (c *Client) ListCRDs(ctx context.Context) ([]apiextv1.CustomResourceDefinit
 		Version:  "v1",
 		Resource: "customresourcedefinitions",
 	}
-	list, err := c.dynamicClient().Resource(gvr).List(ctx, metav1.ListOptions{})
+	list, err := c.dynamicClient().Resource(gvr).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -820,7 +820,7 @@ func resolvePathRecursive(current interface{}, path string) interface{} {
 }
 
 func (c *Client) ListReplicationControllers(ctx context.Context, namespace string) ([]corev1.ReplicationController, error) {
-	opts := metav1.ListOptions{}
+	opts := listOptions(ctx)
 	if namespace == "" {
 		list, err := c.clientset().CoreV1().ReplicationControllers("").List(ctx, opts)
 		if err != nil {
@@ -836,7 +836,7 @@ func (c *Client) ListReplicationControllers(ctx context.Context, namespace strin
 }
 
 func (c *Client) ListEndpoints(ctx context.Context, namespace string) ([]corev1.Endpoints, error) { //nolint:staticcheck
-	opts := metav1.ListOptions{}
+	opts := listOptions(ctx)
 	if namespace == "" {
 		list, err := c.clientset().CoreV1().Endpoints("").List(ctx, opts)
 		if err != nil {
@@ -852,7 +852,7 @@ func (c *Client) ListEndpoints(ctx context.Context, namespace string) ([]corev1.
 }
 
 func (c *Client) ListPodDisruptionBudgets(ctx context.Context, namespace string) ([]policyv1.PodDisruptionBudget, error) {
-	opts := metav1.ListOptions{}
+	opts := listOptions(ctx)
 	if namespace == "" {
 		list, err := c.clientset().PolicyV1().PodDisruptionBudgets("").List(ctx, opts)
 		if err != nil {
@@ -868,7 +868,7 @@ func (c *Client) ListPodDisruptionBudgets(ctx context.Context, namespace string)
 }
 
 func (c *Client) ListLimitRanges(ctx context.Context, namespace string) ([]corev1.LimitRange, error) {
-	opts := metav1.ListOptions{}
+	opts := listOptions(ctx)
 	if namespace == "" {
 		list, err := c.clientset().CoreV1().LimitRanges("").List(ctx, opts)
 		if err != nil {
@@ -884,7 +884,7 @@ func (c *Client) ListLimitRanges(ctx context.Context, namespace string) ([]corev
 }
 
 func (c *Client) ListResourceQuotas(ctx context.Context, namespace string) ([]corev1.ResourceQuota, error) {
-	opts := metav1.ListOptions{}
+	opts := listOptions(ctx)
 	if namespace == "" {
 		list, err := c.clientset().CoreV1().ResourceQuotas("").List(ctx, opts)
 		if err != nil {
@@ -900,7 +900,7 @@ func (c *Client) ListResourceQuotas(ctx context.Context, namespace string) ([]co
 }
 
 func (c *Client) ListHPAs(ctx context.Context, namespace string) ([]autoscalingv2.HorizontalPodAutoscaler, error) {
-	opts := metav1.ListOptions{}
+	opts := listOptions(ctx)
 	if namespace == "" {
 		list, err := c.clientset().AutoscalingV2().HorizontalPodAutoscalers("").List(ctx, opts)
 		if err != nil {
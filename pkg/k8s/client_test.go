@@ -618,3 +618,33 @@ var (
 	_ ContextManager  = (*Client)(nil)
 	_ ClientInterface = (*Client)(nil)
 )
+
+func TestSetCronJobSuspend(t *testing.T) {
+	ctx := context.Background()
+	c := &Client{Clientset: fake.NewClientset( //nolint:staticcheck
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ops"},
+			Spec:       batchv1.CronJobSpec{Schedule: "0 2 * * *"},
+		},
+	)}
+
+	for _, suspend := range []bool{true, false} {
+		if err := c.SetCronJobSuspend(ctx, "ops", "backup", suspend); err != nil {
+			t.Fatalf("SetCronJobSuspend(%t) error = %v", suspend, err)
+		}
+		cj, err := c.Clientset.BatchV1().CronJobs("ops").Get(ctx, "backup", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cj.Spec.Suspend == nil || *cj.Spec.Suspend != suspend {
+			t.Errorf("spec.suspend = %v, want %t", cj.Spec.Suspend, suspend)
+		}
+		if cj.Spec.Schedule != "0 2 * * *" {
+			t.Errorf("schedule changed to %q", cj.Spec.Schedule)
+		}
+	}
+
+	if err := c.SetCronJobSuspend(ctx, "ops", "missing", true); err == nil {
+		t.Error("expected an error for a missing cronjob")
+	}
+}
@@ -14,7 +14,7 @@ import (
 )
 
 func (c *Client) ListDeployments(ctx context.Context, namespace string) ([]appsv1.Deployment, error) {
-	deps, err := c.clientset().AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	deps, err := c.clientset().AppsV1().Deployments(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -22,7 +22,7 @@ func (c *Client) ListDeployments(ctx context.Context, namespace string) ([]appsv
 }
 
 func (c *Client) ListStatefulSets(ctx context.Context, namespace string) ([]appsv1.StatefulSet, error) {
-	stses, err := c.clientset().AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	stses, err := c.clientset().AppsV1().StatefulSets(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +30,7 @@ func (c *Client) ListStatefulSets(ctx context.Context, namespace string) ([]apps
 }
 
 func (c *Client) ListDaemonSets(ctx context.Context, namespace string) ([]appsv1.DaemonSet, error) {
-	dss, err := c.clientset().AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	dss, err := c.clientset().AppsV1().DaemonSets(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,7 @@ func (c *Client) ListDaemonSets(ctx context.Context, namespace string) ([]appsv1
 }
 
 func (c *Client) ListJobs(ctx context.Context, namespace string) ([]batchv1.Job, error) {
-	jobs, err := c.clientset().BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	jobs, err := c.clientset().BatchV1().Jobs(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +46,7 @@ func (c *Client) ListJobs(ctx context.Context, namespace string) ([]batchv1.Job,
 }
 
 func (c *Client) ListCronJobs(ctx context.Context, namespace string) ([]batchv1.CronJob, error) {
-	cjs, err := c.clientset().BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	cjs, err := c.clientset().BatchV1().CronJobs(namespace).List(ctx, listOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +54,7 @@ func (c *Client) ListCronJobs(ctx context.Context, namespace string) ([]batchv1.
 }
 
 func (c *Client) ListReplicaSets(ctx context.Context, namespace string) ([]appsv1.ReplicaSet, error) {
-	opts := metav1.ListOptions{}
+	opts := listOptions(ctx)
 	if namespace == "" {
 		list, err := c.clientset().AppsV1().ReplicaSets("").List(ctx, opts)
 		if err != nil {
@@ -214,7 +214,7 @@ func (c *Client) GetDeploymentReplicaSets(ctx context.Context, namespace, name s
 			"replicas":  rs.Status.Replicas,
 			"ready":     rs.Status.ReadyReplicas,
 			"available": rs.Status.AvailableReplicas,
-			"age":       time.Since(rs.CreationTimestamp.Time).Round(time.Second).String(),
+			"age":       FormatAgeSince(rs.CreationTimestamp.Time),
 		})
 	}
 	return result, nil
@@ -258,3 +258,11 @@ func (c *Client) TriggerCronJob(ctx context.Context, namespace, name string) (*b
 
 	return c.clientset().BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
 }
+
+// SetCronJobSuspend sets spec.suspend on a CronJob. While suspended it
+// schedules no new Jobs; running Jobs are left alone.
+func (c *Client) SetCronJobSuspend(ctx context.Context, namespace, name string, suspend bool) error {
+	patch := fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend)
+	_, err := c.clientset().BatchV1().CronJobs(namespace).Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	return err
+}
@@ -0,0 +1,191 @@
+package k8s
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Kinds a ConfigRef can point at
+const (
+	ConfigRefSecret    = "Secret"
+	ConfigRefConfigMap = "ConfigMap"
+)
+
+// Ways a pod spec refers to a Secret or ConfigMap, in the order
+// ConfigConsumer.Via lists them
+const (
+	ConfigViaEnv        = "env"
+	ConfigViaEnvFrom    = "envFrom"
+	ConfigViaVolume     = "volume"
+	ConfigViaPullSecret = "imagePullSecret"
+)
+
+var configViaOrder = []string{ConfigViaEnv, ConfigViaEnvFrom, ConfigViaVolume, ConfigViaPullSecret}
+
+// ConfigRef is one reference of a pod spec to a Secret or ConfigMap
+type ConfigRef struct {
+	Kind string // ConfigRefSecret or ConfigRefConfigMap
+	Name string
+	Via  string // ConfigViaEnv, ConfigViaEnvFrom, ConfigViaVolume, or ConfigViaPullSecret
+}
+
+// ConfigConsumer is a pod or workload whose pod spec refers to a Secret or
+// ConfigMap
+type ConfigConsumer struct {
+	Kind      string // Deployment, StatefulSet, DaemonSet, CronJob, Job, or Pod
+	Namespace string
+	Name      string
+	// Via lists how the spec refers to it, e.g. ["env", "volume"]
+	Via []string
+}
+
+// PodSpecConfigRefs returns every Secret and ConfigMap reference in spec:
+// env valueFrom, envFrom, secret, configMap and projected volumes, and image
+// pull secrets, of init, regular, and ephemeral containers alike.
+func PodSpecConfigRefs(spec *corev1.PodSpec) []ConfigRef {
+	var refs []ConfigRef
+	add := func(kind, name, via string) {
+		if name != "" {
+			refs = append(refs, ConfigRef{Kind: kind, Name: name, Via: via})
+		}
+	}
+	scanContainer := func(envFrom []corev1.EnvFromSource, env []corev1.EnvVar) {
+		for _, from := range envFrom {
+			if from.SecretRef != nil {
+				add(ConfigRefSecret, from.SecretRef.Name, ConfigViaEnvFrom)
+			}
+			if from.ConfigMapRef != nil {
+				add(ConfigRefConfigMap, from.ConfigMapRef.Name, ConfigViaEnvFrom)
+			}
+		}
+		for _, e := range env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			if e.ValueFrom.SecretKeyRef != nil {
+				add(ConfigRefSecret, e.ValueFrom.SecretKeyRef.Name, ConfigViaEnv)
+			}
+			if e.ValueFrom.ConfigMapKeyRef != nil {
+				add(ConfigRefConfigMap, e.ValueFrom.ConfigMapKeyRef.Name, ConfigViaEnv)
+			}
+		}
+	}
+
+	for _, c := range spec.InitContainers {
+		scanContainer(c.EnvFrom, c.Env)
+	}
+	for _, c := range spec.Containers {
+		scanContainer(c.EnvFrom, c.Env)
+	}
+	for _, c := range spec.EphemeralContainers {
+		scanContainer(c.EnvFrom, c.Env)
+	}
+	for _, v := range spec.Volumes {
+		switch {
+		case v.Secret != nil:
+			add(ConfigRefSecret, v.Secret.SecretName, ConfigViaVolume)
+		case v.ConfigMap != nil:
+			add(ConfigRefConfigMap, v.ConfigMap.Name, ConfigViaVolume)
+		case v.Projected != nil:
+			for _, src := range v.Projected.Sources {
+				if src.Secret != nil {
+					add(ConfigRefSecret, src.Secret.Name, ConfigViaVolume)
+				}
+				if src.ConfigMap != nil {
+					add(ConfigRefConfigMap, src.ConfigMap.Name, ConfigViaVolume)
+				}
+			}
+		}
+	}
+	for _, ref := range spec.ImagePullSecrets {
+		add(ConfigRefSecret, ref.Name, ConfigViaPullSecret)
+	}
+	return refs
+}
+
+// PodSpecReferences returns how spec refers to the Secret or ConfigMap
+// (kind is ConfigRefSecret or ConfigRefConfigMap) with name, in the order
+// env, envFrom, volume, imagePullSecret, or nil when it does not
+func PodSpecReferences(spec *corev1.PodSpec, kind, name string) []string {
+	found := map[string]bool{}
+	for _, ref := range PodSpecConfigRefs(spec) {
+		if ref.Kind == kind && ref.Name == name {
+			found[ref.Via] = true
+		}
+	}
+	var via []string
+	for _, v := range configViaOrder {
+		if found[v] {
+			via = append(via, v)
+		}
+	}
+	return via
+}
+
+// FindConfigConsumers returns the workloads and pods in namespace whose pod
+// spec refers to the named Secret or ConfigMap (kind is ConfigRefSecret or
+// ConfigRefConfigMap). Controllers come first, in the order Deployment,
+// StatefulSet, DaemonSet, CronJob, Job, then pods. Jobs a CronJob created
+// are left out since the CronJob already covers them.
+func (c *Client) FindConfigConsumers(ctx context.Context, kind, namespace, name string) ([]ConfigConsumer, error) {
+	opts := metav1.ListOptions{}
+	apps := c.clientset().AppsV1()
+	batch := c.clientset().BatchV1()
+
+	var consumers []ConfigConsumer
+	check := func(ownerKind, ownerName string, spec *corev1.PodSpec) {
+		if via := PodSpecReferences(spec, kind, name); len(via) > 0 {
+			consumers = append(consumers, ConfigConsumer{Kind: ownerKind, Namespace: namespace, Name: ownerName, Via: via})
+		}
+	}
+
+	deploys, err := apps.Deployments(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range deploys.Items {
+		check("Deployment", deploys.Items[i].Name, &deploys.Items[i].Spec.Template.Spec)
+	}
+	statefulSets, err := apps.StatefulSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range statefulSets.Items {
+		check("StatefulSet", statefulSets.Items[i].Name, &statefulSets.Items[i].Spec.Template.Spec)
+	}
+	daemonSets, err := apps.DaemonSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range daemonSets.Items {
+		check("DaemonSet", daemonSets.Items[i].Name, &daemonSets.Items[i].Spec.Template.Spec)
+	}
+	cronJobs, err := batch.CronJobs(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range cronJobs.Items {
+		check("CronJob", cronJobs.Items[i].Name, &cronJobs.Items[i].Spec.JobTemplate.Spec.Template.Spec)
+	}
+	jobs, err := batch.Jobs(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range jobs.Items {
+		if slices.ContainsFunc(jobs.Items[i].OwnerReferences, func(ref metav1.OwnerReference) bool { return ref.Kind == "CronJob" }) {
+			continue
+		}
+		check("Job", jobs.Items[i].Name, &jobs.Items[i].Spec.Template.Spec)
+	}
+	pods, err := c.clientset().CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		check("Pod", pods.Items[i].Name, &pods.Items[i].Spec)
+	}
+	return consumers, nil
+}
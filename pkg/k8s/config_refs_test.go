@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func configRefsTestSpec() corev1.PodSpec {
+	return corev1.PodSpec{
+		InitContainers: []corev1.Container{{
+			Name:    "migrate",
+			EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "db-creds"}}}},
+		}},
+		Containers: []corev1.Container{{
+			Name: "app",
+			Env: []corev1.EnvVar{
+				{Name: "PLAIN", Value: "x"},
+				{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "db-creds"}, Key: "password"}}},
+				{Name: "MODE", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}, Key: "mode"}}},
+			},
+		}},
+		Volumes: []corev1.Volume{
+			{Name: "tls", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "app-tls"}}},
+			{Name: "all", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+				{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "db-creds"}}},
+				{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}}},
+			}}}},
+			{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+	}
+}
+
+func TestPodSpecReferences(t *testing.T) {
+	spec := configRefsTestSpec()
+	tests := []struct {
+		kind, name string
+		want       []string
+	}{
+		{ConfigRefSecret, "db-creds", []string{ConfigViaEnv, ConfigViaEnvFrom, ConfigViaVolume}},
+		{ConfigRefSecret, "app-tls", []string{ConfigViaVolume}},
+		{ConfigRefSecret, "registry", []string{ConfigViaPullSecret}},
+		{ConfigRefConfigMap, "app-config", []string{ConfigViaEnv, ConfigViaVolume}},
+		// Same name, other kind
+		{ConfigRefConfigMap, "db-creds", nil},
+		{ConfigRefSecret, "unused", nil},
+	}
+	for _, tt := range tests {
+		if got := PodSpecReferences(&spec, tt.kind, tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PodSpecReferences(%s, %s) = %v, want %v", tt.kind, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindConfigConsumers(t *testing.T) {
+	spec := configRefsTestSpec()
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "default"}
+	}
+	cronJobMeta := meta("nightly-123")
+	cronJobMeta.OwnerReferences = []metav1.OwnerReference{{Kind: "CronJob", Name: "nightly"}}
+
+	c := &Client{Clientset: fake.NewClientset( //nolint:staticcheck
+		&appsv1.Deployment{ObjectMeta: meta("api"), Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: spec}}},
+		&appsv1.Deployment{ObjectMeta: meta("web")},
+		&batchv1.CronJob{ObjectMeta: meta("nightly"), Spec: batchv1.CronJobSpec{JobTemplate: batchv1.JobTemplateSpec{
+			Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: spec}}}}},
+		&batchv1.Job{ObjectMeta: cronJobMeta, Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: spec}}},
+		&corev1.Pod{ObjectMeta: meta("api-abc"), Spec: spec},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other-ns", Namespace: "prod"}, Spec: spec},
+	)}
+
+	consumers, err := c.FindConfigConsumers(context.Background(), ConfigRefSecret, "default", "app-tls")
+	if err != nil {
+		t.Fatalf("FindConfigConsumers() error = %v", err)
+	}
+	want := []ConfigConsumer{
+		{Kind: "Deployment", Namespace: "default", Name: "api", Via: []string{ConfigViaVolume}},
+		{Kind: "CronJob", Namespace: "default", Name: "nightly", Via: []string{ConfigViaVolume}},
+		{Kind: "Pod", Namespace: "default", Name: "api-abc", Via: []string{ConfigViaVolume}},
+	}
+	if !reflect.DeepEqual(consumers, want) {
+		t.Errorf("FindConfigConsumers() = %+v, want %+v", consumers, want)
+	}
+
+	consumers, err = c.FindConfigConsumers(context.Background(), ConfigRefConfigMap, "default", "missing")
+	if err != nil {
+		t.Fatalf("FindConfigConsumers() error = %v", err)
+	}
+	if len(consumers) != 0 {
+		t.Errorf("FindConfigConsumers(missing) = %+v, want none", consumers)
+	}
+}
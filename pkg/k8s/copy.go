@@ -0,0 +1,269 @@
+package k8s
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// ErrNoRESTConfig is returned by exec-based operations when the client has no
+// REST config (e.g. fake clients in tests). Callers may fall back to kubectl.
+var ErrNoRESTConfig = errors.New("no REST config available for pod exec")
+
+// CopyProgress receives the running number of file bytes transferred.
+type CopyProgress func(bytes int64)
+
+// CopyFromPod copies remotePath, a file or directory, out of a pod to
+// localPath, like `kubectl cp ns/pod:remotePath localPath`. The remote entry is
+// renamed to localPath. It streams a tar archive from `tar cf -` in the
+// container, so the image must ship tar. Symlinks and special files are
+// skipped. It returns the number of file bytes written.
+func (c *Client) CopyFromPod(ctx context.Context, namespace, pod, container, remotePath, localPath string, progress CopyProgress) (int64, error) {
+	remotePath = path.Clean(remotePath)
+	dir, base := path.Dir(remotePath), path.Base(remotePath)
+	if base == "/" || base == "." {
+		return 0, fmt.Errorf("cannot copy %q: specify a file or directory below /", remotePath)
+	}
+
+	pr, pw := io.Pipe()
+	var stderr bytes.Buffer
+	execErr := make(chan error, 1)
+	go func() {
+		err := c.execStream(ctx, namespace, pod, container, []string{"tar", "cf", "-", "-C", dir, base}, nil, pw, &stderr)
+		_ = pw.CloseWithError(err)
+		execErr <- err
+	}()
+
+	n, err := untarTo(pr, base, localPath, progress)
+	_ = pr.CloseWithError(err)
+	if xerr := <-execErr; xerr != nil {
+		return n, execError("tar in pod", xerr, &stderr)
+	}
+	if err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// CopyToPod copies localPath, a file or directory, into a pod at remotePath,
+// like `kubectl cp localPath ns/pod:remotePath`. The archive is extracted with
+// `tar xf -` in the container, so the image must ship tar. It returns the
+// number of file bytes sent.
+func (c *Client) CopyToPod(ctx context.Context, namespace, pod, container, localPath, remotePath string, progress CopyProgress) (int64, error) {
+	if _, err := os.Stat(localPath); err != nil {
+		return 0, err
+	}
+	remotePath = path.Clean(remotePath)
+	dir, base := path.Dir(remotePath), path.Base(remotePath)
+	if base == "/" || base == "." {
+		return 0, fmt.Errorf("cannot copy to %q: specify a destination file or directory name", remotePath)
+	}
+
+	pr, pw := io.Pipe()
+	var n int64
+	tarErr := make(chan error, 1)
+	go func() {
+		var err error
+		n, err = writeTar(pw, localPath, base, progress)
+		_ = pw.CloseWithError(err)
+		tarErr <- err
+	}()
+
+	var stderr bytes.Buffer
+	err := c.execStream(ctx, namespace, pod, container, []string{"tar", "xf", "-", "-C", dir}, pr, io.Discard, &stderr)
+	_ = pr.CloseWithError(err)
+	werr := <-tarErr
+	if err != nil {
+		return n, execError("tar in pod", err, &stderr)
+	}
+	if werr != nil {
+		return n, werr
+	}
+	return n, nil
+}
+
+// execStream runs command in the container without a TTY, wiring the given
+// streams. A nil stdin disables the stdin stream.
+func (c *Client) execStream(ctx context.Context, namespace, pod, container string, command []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	cfg := c.restConfig()
+	if cfg == nil {
+		return ErrNoRESTConfig
+	}
+	if container == "" {
+		p, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get pod: %w", err)
+		}
+		container = defaultContainer(p)
+	}
+
+	req := c.clientset().CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod).
+		Namespace(namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(cfg, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+	return executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
+
+// defaultContainer returns the container kubectl would pick: the one named by
+// the kubectl.kubernetes.io/default-container annotation, else the first.
+func defaultContainer(pod *corev1.Pod) string {
+	if name := pod.Annotations["kubectl.kubernetes.io/default-container"]; name != "" {
+		return name
+	}
+	if len(pod.Spec.Containers) > 0 {
+		return pod.Spec.Containers[0].Name
+	}
+	return ""
+}
+
+func execError(what string, err error, stderr *bytes.Buffer) error {
+	if errors.Is(err, ErrNoRESTConfig) {
+		return err
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return fmt.Errorf("%s failed: %w: %s", what, err, msg)
+	}
+	return fmt.Errorf("%s failed: %w", what, err)
+}
+
+// writeTar archives src (a file or directory) into w, naming the top-level
+// entry destName. Only regular files and directories are included.
+func writeTar(w io.Writer, src, destName string, progress CopyProgress) (int64, error) {
+	tw := tar.NewWriter(w)
+	var total int64
+
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(destName, filepath.ToSlash(rel))
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		n, err := io.Copy(tw, f)
+		total += n
+		if progress != nil {
+			progress(total)
+		}
+		return err
+	})
+	if err != nil {
+		return total, err
+	}
+	return total, tw.Close()
+}
+
+// untarTo extracts an archive whose top-level entry is srcBase into dest,
+// renaming srcBase to dest. Entries outside srcBase or escaping dest are
+// rejected.
+func untarTo(r io.Reader, srcBase, dest string, progress CopyProgress) (int64, error) {
+	tr := tar.NewReader(r)
+	var total int64
+	found := false
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return total, fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		name := path.Clean(hdr.Name)
+		if name != srcBase && !strings.HasPrefix(name, srcBase+"/") {
+			return total, fmt.Errorf("unexpected archive entry %q", hdr.Name)
+		}
+		target := filepath.Join(dest, filepath.FromSlash(strings.TrimPrefix(name, srcBase)))
+		if rel, err := filepath.Rel(dest, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return total, fmt.Errorf("archive entry %q escapes the destination", hdr.Name)
+		}
+		found = true
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return total, err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return total, err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, hdr.FileInfo().Mode().Perm())
+			if err != nil {
+				return total, err
+			}
+			n, err := io.Copy(f, tr)
+			total += n
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return total, err
+			}
+			if progress != nil {
+				progress(total)
+			}
+		default:
+			// Symlinks, devices, and other special files are skipped
+		}
+	}
+
+	if !found {
+		return total, fmt.Errorf("%s: no such file or directory in the archive", srcBase)
+	}
+	return total, nil
+}
@@ -0,0 +1,119 @@
+package k8s
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestWriteTarUntarRoundTrip_Directory(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "conf")
+	if err := os.MkdirAll(filepath.Join(src, "nested"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "app.yaml"), []byte("port: 8080\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "nested", "run.sh"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	sent, err := writeTar(&buf, src, "etc-app", nil)
+	if err != nil {
+		t.Fatalf("writeTar() error = %v", err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "restored")
+	var lastProgress int64
+	got, err := untarTo(&buf, "etc-app", dest, func(n int64) { lastProgress = n })
+	if err != nil {
+		t.Fatalf("untarTo() error = %v", err)
+	}
+	if got != sent || lastProgress != sent {
+		t.Errorf("bytes: sent %d, received %d, progress %d", sent, got, lastProgress)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dest, "app.yaml"))
+	if err != nil || string(data) != "port: 8080\n" {
+		t.Errorf("app.yaml = %q, %v", data, err)
+	}
+	info, err := os.Stat(filepath.Join(dest, "nested", "run.sh"))
+	if err != nil {
+		t.Fatalf("nested/run.sh missing: %v", err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("run.sh mode = %v, want executable", info.Mode().Perm())
+	}
+}
+
+func TestWriteTarUntarRoundTrip_File(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "dump.sql")
+	if err := os.WriteFile(src, []byte("SELECT 1;"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := writeTar(&buf, src, "backup.sql", nil); err != nil {
+		t.Fatalf("writeTar() error = %v", err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "local.sql")
+	if _, err := untarTo(&buf, "backup.sql", dest, nil); err != nil {
+		t.Fatalf("untarTo() error = %v", err)
+	}
+	data, err := os.ReadFile(dest)
+	if err != nil || string(data) != "SELECT 1;" {
+		t.Errorf("local.sql = %q, %v", data, err)
+	}
+}
+
+func TestUntarTo_RejectsEscapingEntries(t *testing.T) {
+	for _, name := range []string{"other/file", "data/../../evil"} {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+		_, _ = tw.Write([]byte("x"))
+		_ = tw.Close()
+
+		dest := filepath.Join(t.TempDir(), "out")
+		if _, err := untarTo(&buf, "data", dest, nil); err == nil {
+			t.Errorf("untarTo() accepted entry %q", name)
+		}
+	}
+}
+
+func TestUntarTo_EmptyArchive(t *testing.T) {
+	var buf bytes.Buffer
+	_ = tar.NewWriter(&buf).Close()
+
+	if _, err := untarTo(&buf, "missing", t.TempDir(), nil); err == nil {
+		t.Error("expected an error for an archive without the requested entry")
+	}
+}
+
+func TestCopyFromPod_NoRESTConfig(t *testing.T) {
+	c := &Client{}
+	_, err := c.CopyFromPod(context.Background(), "default", "web-0", "", "/etc/hosts", filepath.Join(t.TempDir(), "hosts"), nil)
+	if !errors.Is(err, ErrNoRESTConfig) {
+		t.Fatalf("CopyFromPod() error = %v, want ErrNoRESTConfig", err)
+	}
+}
+
+func TestDefaultContainer(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}, {Name: "sidecar"}}}}
+	if got := defaultContainer(pod); got != "app" {
+		t.Errorf("defaultContainer() = %q, want app", got)
+	}
+
+	pod.Annotations = map[string]string{"kubectl.kubernetes.io/default-container": "sidecar"}
+	if got := defaultContainer(pod); got != "sidecar" {
+		t.Errorf("defaultContainer() = %q, want sidecar (annotation)", got)
+	}
+}
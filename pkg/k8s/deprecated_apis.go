@@ -0,0 +1,340 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// Deprecation states of an API version at the server version
+const (
+	APIDeprecated = "Deprecated" // Still served, removed in a later release
+	APIRemoved    = "Removed"    // No longer served; manifests using it fail to apply
+)
+
+// Where a deprecated API use was found
+const (
+	DeprecationSourceLive     = "live"
+	DeprecationSourceManifest = "manifest"
+)
+
+// DeprecatedAPI is an API version of a kind that Kubernetes deprecated and
+// removes in RemovedIn
+type DeprecatedAPI struct {
+	APIVersion   string `json:"api_version"` // e.g. "extensions/v1beta1"
+	Kind         string `json:"kind"`
+	Resource     string `json:"resource"`              // Plural resource name, e.g. "ingresses"
+	Namespaced   bool   `json:"namespaced"`            // Whether the kind is namespace-scoped
+	Replacement  string `json:"replacement,omitempty"` // Empty when the kind has no successor
+	DeprecatedIn string `json:"deprecated_in"`         // e.g. "v1.14"
+	RemovedIn    string `json:"removed_in"`
+}
+
+// DeprecatedAPIs are the deprecated versions of persisted kinds, from the
+// Kubernetes deprecated API migration guide. Review-style APIs such as
+// TokenReview are left out because they are never stored.
+var DeprecatedAPIs = []DeprecatedAPI{
+	{"extensions/v1beta1", "Deployment", "deployments", true, "apps/v1", "v1.9", "v1.16"},
+	{"apps/v1beta1", "Deployment", "deployments", true, "apps/v1", "v1.9", "v1.16"},
+	{"apps/v1beta2", "Deployment", "deployments", true, "apps/v1", "v1.9", "v1.16"},
+	{"apps/v1beta1", "StatefulSet", "statefulsets", true, "apps/v1", "v1.9", "v1.16"},
+	{"apps/v1beta2", "StatefulSet", "statefulsets", true, "apps/v1", "v1.9", "v1.16"},
+	{"extensions/v1beta1", "DaemonSet", "daemonsets", true, "apps/v1", "v1.9", "v1.16"},
+	{"apps/v1beta2", "DaemonSet", "daemonsets", true, "apps/v1", "v1.9", "v1.16"},
+	{"extensions/v1beta1", "ReplicaSet", "replicasets", true, "apps/v1", "v1.9", "v1.16"},
+	{"apps/v1beta2", "ReplicaSet", "replicasets", true, "apps/v1", "v1.9", "v1.16"},
+	{"extensions/v1beta1", "NetworkPolicy", "networkpolicies", true, "networking.k8s.io/v1", "v1.9", "v1.16"},
+	{"extensions/v1beta1", "PodSecurityPolicy", "podsecuritypolicies", false, "policy/v1beta1", "v1.10", "v1.16"},
+	{"extensions/v1beta1", "Ingress", "ingresses", true, "networking.k8s.io/v1", "v1.14", "v1.22"},
+	{"networking.k8s.io/v1beta1", "Ingress", "ingresses", true, "networking.k8s.io/v1", "v1.19", "v1.22"},
+	{"networking.k8s.io/v1beta1", "IngressClass", "ingressclasses", false, "networking.k8s.io/v1", "v1.19", "v1.22"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRole", "clusterroles", false, "rbac.authorization.k8s.io/v1", "v1.17", "v1.22"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRoleBinding", "clusterrolebindings", false, "rbac.authorization.k8s.io/v1", "v1.17", "v1.22"},
+	{"rbac.authorization.k8s.io/v1beta1", "Role", "roles", true, "rbac.authorization.k8s.io/v1", "v1.17", "v1.22"},
+	{"rbac.authorization.k8s.io/v1beta1", "RoleBinding", "rolebindings", true, "rbac.authorization.k8s.io/v1", "v1.17", "v1.22"},
+	{"apiextensions.k8s.io/v1beta1", "CustomResourceDefinition", "customresourcedefinitions", false, "apiextensions.k8s.io/v1", "v1.16", "v1.22"},
+	{"admissionregistration.k8s.io/v1beta1", "MutatingWebhookConfiguration", "mutatingwebhookconfigurations", false, "admissionregistration.k8s.io/v1", "v1.16", "v1.22"},
+	{"admissionregistration.k8s.io/v1beta1", "ValidatingWebhookConfiguration", "validatingwebhookconfigurations", false, "admissionregistration.k8s.io/v1", "v1.16", "v1.22"},
+	{"apiregistration.k8s.io/v1beta1", "APIService", "apiservices", false, "apiregistration.k8s.io/v1", "v1.19", "v1.22"},
+	{"scheduling.k8s.io/v1beta1", "PriorityClass", "priorityclasses", false, "scheduling.k8s.io/v1", "v1.14", "v1.22"},
+	{"storage.k8s.io/v1beta1", "CSIDriver", "csidrivers", false, "storage.k8s.io/v1", "v1.19", "v1.22"},
+	{"storage.k8s.io/v1beta1", "CSINode", "csinodes", false, "storage.k8s.io/v1", "v1.17", "v1.22"},
+	{"storage.k8s.io/v1beta1", "StorageClass", "storageclasses", false, "storage.k8s.io/v1", "v1.19", "v1.22"},
+	{"storage.k8s.io/v1beta1", "VolumeAttachment", "volumeattachments", false, "storage.k8s.io/v1", "v1.19", "v1.22"},
+	{"certificates.k8s.io/v1beta1", "CertificateSigningRequest", "certificatesigningrequests", false, "certificates.k8s.io/v1", "v1.19", "v1.22"},
+	{"coordination.k8s.io/v1beta1", "Lease", "leases", true, "coordination.k8s.io/v1", "v1.14", "v1.22"},
+	{"batch/v1beta1", "CronJob", "cronjobs", true, "batch/v1", "v1.21", "v1.25"},
+	{"discovery.k8s.io/v1beta1", "EndpointSlice", "endpointslices", true, "discovery.k8s.io/v1", "v1.21", "v1.25"},
+	{"autoscaling/v2beta1", "HorizontalPodAutoscaler", "horizontalpodautoscalers", true, "autoscaling/v2", "v1.22", "v1.25"},
+	{"policy/v1beta1", "PodDisruptionBudget", "poddisruptionbudgets", true, "policy/v1", "v1.21", "v1.25"},
+	{"policy/v1beta1", "PodSecurityPolicy", "podsecuritypolicies", false, "", "v1.21", "v1.25"},
+	{"node.k8s.io/v1beta1", "RuntimeClass", "runtimeclasses", false, "node.k8s.io/v1", "v1.20", "v1.25"},
+	{"autoscaling/v2beta2", "HorizontalPodAutoscaler", "horizontalpodautoscalers", true, "autoscaling/v2", "v1.23", "v1.26"},
+	{"flowcontrol.apiserver.k8s.io/v1beta1", "FlowSchema", "flowschemas", false, "flowcontrol.apiserver.k8s.io/v1beta3", "v1.23", "v1.26"},
+	{"flowcontrol.apiserver.k8s.io/v1beta1", "PriorityLevelConfiguration", "prioritylevelconfigurations", false, "flowcontrol.apiserver.k8s.io/v1beta3", "v1.23", "v1.26"},
+	{"storage.k8s.io/v1beta1", "CSIStorageCapacity", "csistoragecapacities", true, "storage.k8s.io/v1", "v1.24", "v1.27"},
+	{"flowcontrol.apiserver.k8s.io/v1beta2", "FlowSchema", "flowschemas", false, "flowcontrol.apiserver.k8s.io/v1", "v1.26", "v1.29"},
+	{"flowcontrol.apiserver.k8s.io/v1beta2", "PriorityLevelConfiguration", "prioritylevelconfigurations", false, "flowcontrol.apiserver.k8s.io/v1", "v1.26", "v1.29"},
+	{"flowcontrol.apiserver.k8s.io/v1beta3", "FlowSchema", "flowschemas", false, "flowcontrol.apiserver.k8s.io/v1", "v1.29", "v1.32"},
+	{"flowcontrol.apiserver.k8s.io/v1beta3", "PriorityLevelConfiguration", "prioritylevelconfigurations", false, "flowcontrol.apiserver.k8s.io/v1", "v1.29", "v1.32"},
+}
+
+// LookupDeprecatedAPI returns the deprecation of apiVersion for kind, if any
+func LookupDeprecatedAPI(apiVersion, kind string) (DeprecatedAPI, bool) {
+	for _, d := range DeprecatedAPIs {
+		if d.APIVersion == apiVersion && d.Kind == kind {
+			return d, true
+		}
+	}
+	return DeprecatedAPI{}, false
+}
+
+// StatusAt returns APIRemoved or APIDeprecated at server, or "" when the
+// API is not deprecated yet there. A nil server counts as deprecated.
+func (d DeprecatedAPI) StatusAt(server *version.Version) string {
+	if server == nil {
+		return APIDeprecated
+	}
+	if server.AtLeast(version.MustParseGeneric(d.RemovedIn)) {
+		return APIRemoved
+	}
+	if server.AtLeast(version.MustParseGeneric(d.DeprecatedIn)) {
+		return APIDeprecated
+	}
+	return ""
+}
+
+// DeprecatedAPIUse is one object that uses a deprecated API version
+type DeprecatedAPIUse struct {
+	DeprecatedAPI
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"` // Empty for cluster-scoped objects
+	Status    string `json:"status"`              // APIDeprecated or APIRemoved
+	Source    string `json:"source"`              // DeprecationSourceLive or DeprecationSourceManifest
+	// Via is how a live object was found to use the version: the field
+	// managers that wrote it with the version, and "last-applied" when
+	// kubectl apply recorded it
+	Via  string `json:"via,omitempty"`
+	File string `json:"file,omitempty"` // Manifest path relative to the scanned directory
+}
+
+// DeprecatedAPIScan lists the deprecated API versions in use
+type DeprecatedAPIScan struct {
+	ServerVersion string             `json:"server_version,omitempty"` // Empty when it could not be read
+	Uses          []DeprecatedAPIUse `json:"uses"`
+	Deprecated    int                `json:"deprecated"`
+	Removed       int                `json:"removed"`
+	// Errors are the kinds that could not be listed and the manifests that
+	// could not be read; the scan is incomplete without them
+	Errors []string `json:"errors,omitempty"`
+}
+
+// DetectDeprecatedAPIs finds objects that use API versions deprecated or
+// removed at the server's version. The API server converts objects to any
+// served version, so a live object's own apiVersion says nothing; instead
+// the versions recorded in its managed fields and kubectl's last-applied
+// annotation are checked, which is what its clients actually send. With
+// manifestDir set the manifests under it are checked as well. namespace
+// limits the live scan to one namespace and skips cluster-scoped kinds.
+func (c *Client) DetectDeprecatedAPIs(ctx context.Context, namespace, manifestDir string) (*DeprecatedAPIScan, error) {
+	if c.Clientset == nil || c.dynamicClient() == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+	scan := &DeprecatedAPIScan{Uses: []DeprecatedAPIUse{}}
+	var server *version.Version
+	if info, err := c.Clientset.Discovery().ServerVersion(); err == nil {
+		if server, err = version.ParseGeneric(info.GitVersion); err == nil {
+			scan.ServerVersion = info.GitVersion
+		}
+	}
+	if server == nil {
+		scan.Errors = append(scan.Errors, "server version unknown; every deprecated API is reported")
+	}
+
+	for _, kind := range deprecatedKinds() {
+		if namespace != "" && !kind.namespaced {
+			continue
+		}
+		gvr, ok := c.servedVersion(kind.resource, kind.listVersions)
+		if !ok {
+			continue
+		}
+		list, err := c.dynamicClient().Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				scan.Errors = append(scan.Errors, fmt.Sprintf("%s: %v", kind.resource, err))
+			}
+			continue
+		}
+		for i := range list.Items {
+			scan.Uses = append(scan.Uses, liveDeprecatedAPIUses(&list.Items[i], kind.kind, server)...)
+		}
+	}
+
+	if manifestDir != "" {
+		files, failed, err := LoadManifestDir(manifestDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range failed {
+			scan.Errors = append(scan.Errors, fmt.Sprintf("%s: %s", f.File, f.Err))
+		}
+		for _, f := range files {
+			d, ok := LookupDeprecatedAPI(f.Object.GetAPIVersion(), f.Object.GetKind())
+			if !ok {
+				continue
+			}
+			status := d.StatusAt(server)
+			if status == "" {
+				continue
+			}
+			scan.Uses = append(scan.Uses, DeprecatedAPIUse{
+				DeprecatedAPI: d,
+				Name:          f.Object.GetName(),
+				Namespace:     f.Object.GetNamespace(),
+				Status:        status,
+				Source:        DeprecationSourceManifest,
+				File:          f.File,
+			})
+		}
+	}
+
+	sortDeprecatedAPIUses(scan.Uses)
+	for _, u := range scan.Uses {
+		if u.Status == APIRemoved {
+			scan.Removed++
+		} else {
+			scan.Deprecated++
+		}
+	}
+	return scan, nil
+}
+
+// deprecatedKind is one kind of the deprecation table with the versions
+// to list it by, current ones first
+type deprecatedKind struct {
+	kind         string
+	resource     string
+	namespaced   bool
+	listVersions []string
+}
+
+func deprecatedKinds() []deprecatedKind {
+	var kinds []deprecatedKind
+	index := map[string]int{}
+	for _, d := range DeprecatedAPIs {
+		i, ok := index[d.Resource]
+		if !ok {
+			i = len(kinds)
+			index[d.Resource] = i
+			kinds = append(kinds, deprecatedKind{kind: d.Kind, resource: d.Resource, namespaced: d.Namespaced})
+		}
+		for _, v := range []string{d.Replacement, d.APIVersion} {
+			if v != "" && !slices.Contains(kinds[i].listVersions, v) {
+				kinds[i].listVersions = append(kinds[i].listVersions, v)
+			}
+		}
+	}
+	for i := range kinds {
+		kind := kinds[i].kind
+		sort.SliceStable(kinds[i].listVersions, func(a, b int) bool {
+			_, aOld := LookupDeprecatedAPI(kinds[i].listVersions[a], kind)
+			_, bOld := LookupDeprecatedAPI(kinds[i].listVersions[b], kind)
+			return !aOld && bOld
+		})
+	}
+	return kinds
+}
+
+// servedVersion returns the first of versions the server serves resource in
+func (c *Client) servedVersion(resource string, versions []string) (schema.GroupVersionResource, bool) {
+	for _, v := range versions {
+		list, err := c.Clientset.Discovery().ServerResourcesForGroupVersion(v)
+		if err != nil || list == nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if r.Name == resource {
+				gv, _ := schema.ParseGroupVersion(v)
+				return gv.WithResource(resource), true
+			}
+		}
+	}
+	return schema.GroupVersionResource{}, false
+}
+
+// liveDeprecatedAPIUses returns the deprecated versions obj was written
+// with, one use per version
+func liveDeprecatedAPIUses(obj *unstructured.Unstructured, kind string, server *version.Version) []DeprecatedAPIUse {
+	via := map[string][]string{}
+	var versions []string
+	add := func(apiVersion, how string) {
+		if _, ok := LookupDeprecatedAPI(apiVersion, kind); !ok {
+			return
+		}
+		if _, seen := via[apiVersion]; !seen {
+			versions = append(versions, apiVersion)
+		}
+		if !slices.Contains(via[apiVersion], how) {
+			via[apiVersion] = append(via[apiVersion], how)
+		}
+	}
+	for _, f := range obj.GetManagedFields() {
+		add(f.APIVersion, f.Manager)
+	}
+	if applied := obj.GetAnnotations()["kubectl.kubernetes.io/last-applied-configuration"]; applied != "" {
+		var manifest struct {
+			APIVersion string `json:"apiVersion"`
+		}
+		if json.Unmarshal([]byte(applied), &manifest) == nil {
+			add(manifest.APIVersion, "last-applied")
+		}
+	}
+
+	var uses []DeprecatedAPIUse
+	for _, v := range versions {
+		d, _ := LookupDeprecatedAPI(v, kind)
+		status := d.StatusAt(server)
+		if status == "" {
+			continue
+		}
+		uses = append(uses, DeprecatedAPIUse{
+			DeprecatedAPI: d,
+			Name:          obj.GetName(),
+			Namespace:     obj.GetNamespace(),
+			Status:        status,
+			Source:        DeprecationSourceLive,
+			Via:           strings.Join(via[v], ", "),
+		})
+	}
+	return uses
+}
+
+// sortDeprecatedAPIUses puts removed APIs first, then the soonest removals
+func sortDeprecatedAPIUses(uses []DeprecatedAPIUse) {
+	sort.SliceStable(uses, func(i, j int) bool {
+		a, b := uses[i], uses[j]
+		if (a.Status == APIRemoved) != (b.Status == APIRemoved) {
+			return a.Status == APIRemoved
+		}
+		if a.RemovedIn != b.RemovedIn {
+			return version.MustParseGeneric(a.RemovedIn).LessThan(version.MustParseGeneric(b.RemovedIn))
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
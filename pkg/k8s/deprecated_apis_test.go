@@ -0,0 +1,117 @@
+package k8s
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+	apiversion "k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeprecatedAPIStatusAt(t *testing.T) {
+	ingress, ok := LookupDeprecatedAPI("networking.k8s.io/v1beta1", "Ingress")
+	if !ok || ingress.Replacement != "networking.k8s.io/v1" {
+		t.Fatalf("LookupDeprecatedAPI() = %+v, %v", ingress, ok)
+	}
+	for server, want := range map[string]string{
+		"v1.18.0":         "",
+		"v1.19.4":         APIDeprecated,
+		"v1.22.0-eks-123": APIRemoved,
+		"v1.30.1":         APIRemoved,
+	} {
+		if got := ingress.StatusAt(version.MustParseGeneric(server)); got != want {
+			t.Errorf("StatusAt(%s) = %q, want %q", server, got, want)
+		}
+	}
+	if _, ok := LookupDeprecatedAPI("networking.k8s.io/v1", "Ingress"); ok {
+		t.Error("networking.k8s.io/v1 Ingress is not deprecated")
+	}
+
+	// Every kind is listed by a version that is not deprecated first
+	for _, kind := range deprecatedKinds() {
+		if kind.kind == "PodSecurityPolicy" {
+			continue
+		}
+		if _, old := LookupDeprecatedAPI(kind.listVersions[0], kind.kind); old {
+			t.Errorf("%s is listed by deprecated %s first", kind.kind, kind.listVersions[0])
+		}
+	}
+}
+
+func TestDetectDeprecatedAPIs(t *testing.T) {
+	dir := t.TempDir()
+	manifest := "apiVersion: autoscaling/v2beta2\nkind: HorizontalPodAutoscaler\nmetadata:\n  name: web\n  namespace: shop\n" +
+		"---\napiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n"
+	if err := os.WriteFile(filepath.Join(dir, "web.yaml"), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	object := func(apiVersion, kind, name string, fields []metav1.ManagedFieldsEntry, applied string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(apiVersion)
+		obj.SetKind(kind)
+		obj.SetName(name)
+		obj.SetNamespace("shop")
+		obj.SetManagedFields(fields)
+		if applied != "" {
+			obj.SetAnnotations(map[string]string{"kubectl.kubernetes.io/last-applied-configuration": applied})
+		}
+		return obj
+	}
+	ingresses := schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}
+	cronjobs := schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{ingresses: "IngressList", cronjobs: "CronJobList"},
+		object("networking.k8s.io/v1", "Ingress", "shop", []metav1.ManagedFieldsEntry{
+			{Manager: "helm", APIVersion: "networking.k8s.io/v1beta1"},
+			{Manager: "nginx-ingress-controller", APIVersion: "networking.k8s.io/v1"},
+		}, ""),
+		object("batch/v1", "CronJob", "cleanup", []metav1.ManagedFieldsEntry{{Manager: "kubectl-client-side-apply", APIVersion: "batch/v1"}},
+			`{"apiVersion":"batch/v1beta1","kind":"CronJob"}`),
+		object("batch/v1", "CronJob", "current", []metav1.ManagedFieldsEntry{{Manager: "kubectl", APIVersion: "batch/v1"}}, ""),
+	)
+
+	clientset := fake.NewSimpleClientset()
+	clientset.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "networking.k8s.io/v1", APIResources: []metav1.APIResource{{Name: "ingresses", Namespaced: true, Kind: "Ingress"}}},
+		{GroupVersion: "batch/v1", APIResources: []metav1.APIResource{{Name: "cronjobs", Namespaced: true, Kind: "CronJob"}}},
+	}
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &apiversion.Info{GitVersion: "v1.24.3"}
+	c := &Client{Clientset: clientset, Dynamic: dyn}
+
+	scan, err := c.DetectDeprecatedAPIs(context.Background(), "", dir)
+	if err != nil {
+		t.Fatalf("DetectDeprecatedAPIs() error = %v", err)
+	}
+	if scan.ServerVersion != "v1.24.3" || len(scan.Uses) != 3 || scan.Removed != 1 || scan.Deprecated != 2 {
+		t.Fatalf("scan = %+v, want 1 removed and 2 deprecated uses at v1.24.3", scan)
+	}
+	// Removed first, then the soonest removal
+	if u := scan.Uses[0]; u.Kind != "Ingress" || u.Status != APIRemoved || u.Via != "helm" || u.Source != DeprecationSourceLive {
+		t.Errorf("uses[0] = %+v, want the removed Ingress written by helm", u)
+	}
+	if u := scan.Uses[1]; u.Name != "cleanup" || u.RemovedIn != "v1.25" || u.Via != "last-applied" {
+		t.Errorf("uses[1] = %+v, want the CronJob applied with batch/v1beta1", u)
+	}
+	if u := scan.Uses[2]; u.Kind != "HorizontalPodAutoscaler" || u.Source != DeprecationSourceManifest || u.File != "web.yaml" || u.Replacement != "autoscaling/v2" {
+		t.Errorf("uses[2] = %+v, want the manifest HPA", u)
+	}
+
+	// A scan of another namespace finds nothing
+	scan, err = c.DetectDeprecatedAPIs(context.Background(), "other", "")
+	if err != nil || len(scan.Uses) != 0 {
+		t.Errorf("namespace scan = %+v, %v; want no uses", scan, err)
+	}
+	if len(scan.Errors) != 0 {
+		t.Errorf("unexpected scan errors: %q", scan.Errors)
+	}
+}
@@ -0,0 +1,430 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Drift states of a manifest object
+const (
+	DriftInSync  = "InSync"
+	DriftDrifted = "Drifted"
+	DriftMissing = "Missing" // In the manifests but not in the cluster
+	DriftError   = "Error"
+)
+
+// Field drift types, from the point of view of the live object
+const (
+	FieldAdded   = "added"   // Set live but not in the manifest
+	FieldRemoved = "removed" // In the manifest but not set live
+	FieldChanged = "changed"
+)
+
+// FieldDrift is one field whose live value differs from the manifest
+type FieldDrift struct {
+	Path    string `json:"path"`              // e.g. "spec.template.spec.containers[web].image"
+	Type    string `json:"type"`              // FieldAdded, FieldRemoved, or FieldChanged
+	Desired string `json:"desired,omitempty"` // Empty for added fields
+	Live    string `json:"live,omitempty"`    // Empty for removed fields
+}
+
+// ResourceDrift compares one manifest object with the live cluster
+type ResourceDrift struct {
+	File      string       `json:"file"` // Manifest path relative to the scanned directory
+	Kind      string       `json:"kind"`
+	Name      string       `json:"name"`
+	Namespace string       `json:"namespace,omitempty"` // Empty for cluster-scoped objects
+	Resource  string       `json:"resource,omitempty"`  // Plural resource name, e.g. "deployments"
+	Status    string       `json:"status"`              // DriftInSync, DriftDrifted, DriftMissing, or DriftError
+	Fields    []FieldDrift `json:"fields,omitempty"`
+	// Exact is false when the server could not default the manifest (dry-run
+	// failed), so only fields the manifest sets were compared
+	Exact bool   `json:"exact"`
+	Err   string `json:"error,omitempty"`
+}
+
+// Server-managed metadata never compared for drift
+var driftIgnoredMetadata = []string{
+	"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields",
+	"selfLink", "deletionTimestamp", "deletionGracePeriodSeconds",
+}
+
+// Annotations written by kubectl and controllers rather than by users
+var driftIgnoredAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"deployment.kubernetes.io/revision",
+}
+
+// redactedDriftValue replaces Secret values in drift output
+const redactedDriftValue = "(redacted)"
+
+// manifestExtensions are the files LoadManifestDir reads
+var manifestExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// ManifestFile is one object read from a manifest directory
+type ManifestFile struct {
+	File   string
+	Object *unstructured.Unstructured
+}
+
+// LoadManifestDir reads every YAML and JSON manifest under dir, skipping
+// hidden directories such as .git. Files that fail to parse are returned as
+// DriftError entries so one bad file does not hide the rest.
+func LoadManifestDir(dir string) ([]ManifestFile, []ResourceDrift, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read manifest directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	var files []ManifestFile
+	var failed []ResourceDrift
+	err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !manifestExtensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			failed = append(failed, ResourceDrift{File: rel, Status: DriftError, Err: err.Error()})
+			return nil
+		}
+		// Skip YAML that is not a Kubernetes object, such as Helm's Chart.yaml
+		if !bytes.Contains(data, []byte("kind")) {
+			return nil
+		}
+		objs, err := ParseManifest(data)
+		if err != nil {
+			failed = append(failed, ResourceDrift{File: rel, Status: DriftError, Err: err.Error()})
+			return nil
+		}
+		for _, obj := range objs {
+			// Kustomize inputs are not cluster objects
+			if obj.GetKind() == "Kustomization" && strings.HasPrefix(obj.GetAPIVersion(), "kustomize.config.k8s.io/") {
+				continue
+			}
+			files = append(files, ManifestFile{File: rel, Object: obj})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to scan manifest directory: %w", err)
+	}
+	return files, failed, nil
+}
+
+// DetectDrift compares every manifest under dir with the live cluster.
+// Namespaced objects without a namespace use defaultNamespace.
+func (c *Client) DetectDrift(ctx context.Context, dir, defaultNamespace string) ([]ResourceDrift, error) {
+	files, drifts, err := LoadManifestDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 && len(drifts) == 0 {
+		return nil, fmt.Errorf("no manifests found in %s", dir)
+	}
+	for _, f := range files {
+		drift := c.objectDrift(ctx, f.Object.DeepCopy(), defaultNamespace)
+		drift.File = f.File
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
+
+// objectDrift compares one manifest object with its live counterpart. The
+// manifest is first sent as a server-side dry-run update so defaults and
+// mutating webhooks apply to it as they did to the live object; only real
+// differences remain. When the dry-run fails (e.g. no update permission)
+// just the fields the manifest sets are compared.
+func (c *Client) objectDrift(ctx context.Context, obj *unstructured.Unstructured, defaultNamespace string) ResourceDrift {
+	drift := ResourceDrift{Kind: obj.GetKind(), Name: obj.GetName()}
+	fail := func(err error) ResourceDrift {
+		drift.Status = DriftError
+		drift.Err = err.Error()
+		return drift
+	}
+	if c.dynamicClient() == nil {
+		return fail(fmt.Errorf("dynamic client not initialized"))
+	}
+
+	resource, namespace, err := c.ManifestTarget(obj, defaultNamespace)
+	if err != nil {
+		return fail(err)
+	}
+	drift.Resource = resource
+	drift.Namespace = namespace
+
+	gvr, _ := c.getGVRForKind(obj.GetAPIVersion(), obj.GetKind())
+	resourceClient := c.dynamicClient().Resource(gvr).Namespace(namespace)
+	if namespace != "" {
+		obj.SetNamespace(namespace)
+	}
+
+	live, err := resourceClient.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		drift.Status = DriftMissing
+		return drift
+	}
+	if err != nil {
+		return fail(fmt.Errorf("failed to get %s/%s: %w", obj.GetKind(), obj.GetName(), err))
+	}
+
+	desired := obj
+	dryRun := obj.DeepCopy()
+	dryRun.SetResourceVersion(live.GetResourceVersion())
+	if defaulted, err := resourceClient.Update(ctx, dryRun, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}}); err == nil {
+		desired = defaulted
+		drift.Exact = true
+	}
+
+	drift.Fields = CompareManifest(desired, live, drift.Exact)
+	drift.Status = DriftInSync
+	if len(drift.Fields) > 0 {
+		drift.Status = DriftDrifted
+	}
+	return drift
+}
+
+// CompareManifest lists the fields where live differs from desired, sorted
+// by path, after dropping status and server-managed metadata from both.
+// With exact unset, fields present only on the live object are ignored, so
+// server defaults are not reported as drift.
+func CompareManifest(desired, live *unstructured.Unstructured, exact bool) []FieldDrift {
+	d := normalizeForDrift(desired)
+	l := normalizeForDrift(live)
+
+	var fields []FieldDrift
+	compareDriftValues("", d, l, exact, &fields)
+	if desired.GetKind() == "Secret" {
+		for i := range fields {
+			if strings.HasPrefix(fields[i].Path, "data") || strings.HasPrefix(fields[i].Path, "stringData") {
+				if fields[i].Desired != "" {
+					fields[i].Desired = redactedDriftValue
+				}
+				if fields[i].Live != "" {
+					fields[i].Live = redactedDriftValue
+				}
+			}
+		}
+	}
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].Path < fields[j].Path })
+	return fields
+}
+
+func normalizeForDrift(obj *unstructured.Unstructured) map[string]interface{} {
+	out := obj.DeepCopy().Object
+	delete(out, "status")
+	if meta, ok := out["metadata"].(map[string]interface{}); ok {
+		for _, key := range driftIgnoredMetadata {
+			delete(meta, key)
+		}
+		if annotations, ok := meta["annotations"].(map[string]interface{}); ok {
+			for _, key := range driftIgnoredAnnotations {
+				delete(annotations, key)
+			}
+			if len(annotations) == 0 {
+				delete(meta, "annotations")
+			}
+		}
+	}
+	return out
+}
+
+func compareDriftValues(path string, desired, live interface{}, exact bool, fields *[]FieldDrift) {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(d))
+		for key := range d {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childPath := joinDriftPath(path, key)
+			lv, ok := l[key]
+			if !ok {
+				if !isEmptyDriftValue(d[key]) {
+					*fields = append(*fields, FieldDrift{Path: childPath, Type: FieldRemoved, Desired: formatDriftValue(d[key])})
+				}
+				continue
+			}
+			compareDriftValues(childPath, d[key], lv, exact, fields)
+		}
+		if exact {
+			for key, lv := range l {
+				if _, ok := d[key]; !ok && !isEmptyDriftValue(lv) {
+					*fields = append(*fields, FieldDrift{Path: joinDriftPath(path, key), Type: FieldAdded, Live: formatDriftValue(lv)})
+				}
+			}
+		}
+		return
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok {
+			break
+		}
+		compareDriftLists(path, d, l, exact, fields)
+		return
+	}
+
+	if !driftValuesEqual(desired, live) {
+		*fields = append(*fields, FieldDrift{Path: path, Type: FieldChanged, Desired: formatDriftValue(desired), Live: formatDriftValue(live)})
+	}
+}
+
+// compareDriftLists matches list items by their "name" field when every
+// item has one, as containers, env vars, and ports do, and by index
+// otherwise. Like map keys, live-only items count only when exact is set.
+func compareDriftLists(path string, desired, live []interface{}, exact bool, fields *[]FieldDrift) {
+	dNames, dOK := driftListNames(desired)
+	lNames, lOK := driftListNames(live)
+	if dOK && lOK {
+		liveByName := make(map[string]interface{}, len(live))
+		for i, name := range lNames {
+			liveByName[name] = live[i]
+		}
+		desiredNames := make(map[string]bool, len(desired))
+		for i, name := range dNames {
+			desiredNames[name] = true
+			childPath := fmt.Sprintf("%s[%s]", path, name)
+			lv, ok := liveByName[name]
+			if !ok {
+				*fields = append(*fields, FieldDrift{Path: childPath, Type: FieldRemoved, Desired: formatDriftValue(desired[i])})
+				continue
+			}
+			compareDriftValues(childPath, desired[i], lv, exact, fields)
+		}
+		for i, name := range lNames {
+			if exact && !desiredNames[name] {
+				*fields = append(*fields, FieldDrift{Path: fmt.Sprintf("%s[%s]", path, name), Type: FieldAdded, Live: formatDriftValue(live[i])})
+			}
+		}
+		return
+	}
+
+	for i := 0; i < max(len(desired), len(live)); i++ {
+		childPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(live):
+			*fields = append(*fields, FieldDrift{Path: childPath, Type: FieldRemoved, Desired: formatDriftValue(desired[i])})
+		case i >= len(desired):
+			if !exact {
+				continue
+			}
+			*fields = append(*fields, FieldDrift{Path: childPath, Type: FieldAdded, Live: formatDriftValue(live[i])})
+		default:
+			compareDriftValues(childPath, desired[i], live[i], exact, fields)
+		}
+	}
+}
+
+func driftListNames(items []interface{}) ([]string, bool) {
+	if len(items) == 0 {
+		return nil, false
+	}
+	names := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := m["name"].(string)
+		if !ok || name == "" || seen[name] {
+			return nil, false
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, true
+}
+
+func joinDriftPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	if strings.ContainsAny(key, "./") {
+		return fmt.Sprintf("%s[%s]", path, key)
+	}
+	return path + "." + key
+}
+
+// driftValuesEqual compares scalars, treating manifest numbers (float64)
+// and live numbers (int64) as equal when their values match
+func driftValuesEqual(a, b interface{}) bool {
+	if af, ok := driftNumber(a); ok {
+		if bf, ok := driftNumber(b); ok {
+			return af == bf
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func driftNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func isEmptyDriftValue(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(t) == 0
+	case []interface{}:
+		return len(t) == 0
+	}
+	return false
+}
+
+// formatDriftValue renders a value on one line, cutting long ones short
+func formatDriftValue(v interface{}) string {
+	var s string
+	switch t := v.(type) {
+	case string:
+		s = t
+	case map[string]interface{}, []interface{}:
+		b, _ := json.Marshal(t)
+		s = string(b)
+	default:
+		s = fmt.Sprint(t)
+	}
+	if len(s) > 80 {
+		s = s[:77] + "..."
+	}
+	return s
+}
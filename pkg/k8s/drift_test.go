@@ -0,0 +1,179 @@
+package k8s
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func driftObject(t *testing.T, manifest string) *unstructured.Unstructured {
+	t.Helper()
+	objs, err := ParseManifest([]byte(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return objs[0]
+}
+
+func TestCompareManifest(t *testing.T) {
+	desired := driftObject(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: default
+  labels: {app: web}
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.27
+        env:
+        - {name: MODE, value: prod}
+      - name: sidecar
+        image: envoy:1.30
+`)
+	live := driftObject(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: default
+  labels: {app: web, hotfix: "true"}
+  uid: abc
+  resourceVersion: "42"
+  generation: 7
+  annotations:
+    deployment.kubernetes.io/revision: "7"
+spec:
+  replicas: 5
+  progressDeadlineSeconds: 600
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.27-debug
+        env:
+        - {name: MODE, value: prod}
+status:
+  replicas: 5
+`)
+
+	want := map[string]FieldDrift{
+		"metadata.labels.hotfix":                   {Type: FieldAdded, Live: "true"},
+		"spec.progressDeadlineSeconds":             {Type: FieldAdded, Live: "600"},
+		"spec.replicas":                            {Type: FieldChanged, Desired: "3", Live: "5"},
+		"spec.template.spec.containers[sidecar]":   {Type: FieldRemoved},
+		"spec.template.spec.containers[web].image": {Type: FieldChanged, Desired: "nginx:1.27", Live: "nginx:1.27-debug"},
+	}
+	fields := CompareManifest(desired, live, true)
+	if len(fields) != len(want) {
+		t.Fatalf("CompareManifest() = %+v, want %d fields", fields, len(want))
+	}
+	for _, f := range fields {
+		w, ok := want[f.Path]
+		if !ok || f.Type != w.Type || (w.Live != "" && f.Live != w.Live) || (w.Desired != "" && f.Desired != w.Desired) {
+			t.Errorf("unexpected field %+v", f)
+		}
+	}
+
+	// Without exact defaulting, live-only fields are not drift
+	for _, f := range CompareManifest(desired, live, false) {
+		if f.Type == FieldAdded {
+			t.Errorf("inexact comparison reported added field %+v", f)
+		}
+	}
+}
+
+func TestCompareManifest_RedactsSecrets(t *testing.T) {
+	desired := driftObject(t, "apiVersion: v1\nkind: Secret\nmetadata:\n  name: db\ndata:\n  password: b2xk\n")
+	live := driftObject(t, "apiVersion: v1\nkind: Secret\nmetadata:\n  name: db\ndata:\n  password: bmV3\n")
+	fields := CompareManifest(desired, live, true)
+	if len(fields) != 1 || fields[0].Desired != redactedDriftValue || fields[0].Live != redactedDriftValue {
+		t.Errorf("CompareManifest() = %+v, want one redacted change", fields)
+	}
+}
+
+func TestDetectDrift(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("app/config.yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app\ndata:\n  mode: prod\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: same\ndata:\n  a: b\n")
+	write("app/missing.yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: gone\n")
+	write("broken.yaml", "apiVersion: v1\nkind: [\n")
+	write("Chart.yaml", "apiVersion: v2\nname: app\n")
+	write(".git/config.yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: ignored\n")
+
+	live := func(name string, data map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": name, "namespace": "default", "resourceVersion": "1"},
+			"data":       data,
+		}}
+	}
+	gvrs := map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), gvrs,
+		live("app", map[string]interface{}{"mode": "debug"}),
+		live("same", map[string]interface{}{"a": "b"}),
+	)
+	// The fake client does not honor dry-run, so echo the object back as
+	// the API server would without persisting it
+	var dryRuns int
+	dyn.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		update := action.(k8stesting.UpdateActionImpl)
+		if len(update.UpdateOptions.DryRun) == 0 || update.UpdateOptions.DryRun[0] != metav1.DryRunAll {
+			t.Error("drift detection must only send dry-run updates")
+		}
+		dryRuns++
+		return true, update.GetObject(), nil
+	})
+	c := &Client{Dynamic: dyn}
+
+	drifts, err := c.DetectDrift(context.Background(), dir, "default")
+	if err != nil {
+		t.Fatalf("DetectDrift() error = %v", err)
+	}
+	byName := map[string]ResourceDrift{}
+	for _, d := range drifts {
+		byName[d.File+"/"+d.Name] = d
+	}
+	if len(drifts) != 4 {
+		t.Fatalf("DetectDrift() = %+v, want 4 entries", drifts)
+	}
+	if d := byName["app/config.yaml/app"]; d.Status != DriftDrifted || !d.Exact || len(d.Fields) != 1 || d.Fields[0].Path != "data.mode" {
+		t.Errorf("app = %+v, want data.mode drift", d)
+	}
+	if d := byName["app/config.yaml/same"]; d.Status != DriftInSync {
+		t.Errorf("same = %+v, want in sync", d)
+	}
+	if d := byName["app/missing.yaml/gone"]; d.Status != DriftMissing {
+		t.Errorf("gone = %+v, want missing", d)
+	}
+	if d := byName["broken.yaml/"]; d.Status != DriftError || d.Err == "" {
+		t.Errorf("broken.yaml = %+v, want a parse error", d)
+	}
+	if dryRuns != 2 {
+		t.Errorf("dry-run updates = %d, want 2", dryRuns)
+	}
+
+	if _, err := c.DetectDrift(context.Background(), filepath.Join(dir, "nope"), "default"); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkloadContainer is a container in a workload's pod template
+type WorkloadContainer struct {
+	Name  string
+	Image string
+	Init  bool // True for init containers
+}
+
+// WorkloadContainers returns the init and regular containers of a
+// deployment, statefulset, or daemonset pod template, init containers first.
+func (c *Client) WorkloadContainers(ctx context.Context, namespace, kind, name string) ([]WorkloadContainer, error) {
+	var spec corev1.PodSpec
+	switch kind {
+	case "deployments", "deploy":
+		dep, err := c.clientset().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment: %w", err)
+		}
+		spec = dep.Spec.Template.Spec
+	case "statefulsets", "sts":
+		sts, err := c.clientset().AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statefulset: %w", err)
+		}
+		spec = sts.Spec.Template.Spec
+	case "daemonsets", "ds":
+		ds, err := c.clientset().AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get daemonset: %w", err)
+		}
+		spec = ds.Spec.Template.Spec
+	default:
+		return nil, fmt.Errorf("unsupported workload kind: %s", kind)
+	}
+
+	containers := make([]WorkloadContainer, 0, len(spec.InitContainers)+len(spec.Containers))
+	for _, ctr := range spec.InitContainers {
+		containers = append(containers, WorkloadContainer{Name: ctr.Name, Image: ctr.Image, Init: true})
+	}
+	for _, ctr := range spec.Containers {
+		containers = append(containers, WorkloadContainer{Name: ctr.Name, Image: ctr.Image})
+	}
+	return containers, nil
+}
+
+// AllContainers returns the init, regular, and ephemeral containers of a
+// pod spec, in that order. Ephemeral debug containers share the Container
+// fields, so image and security checks can treat them alike.
+func AllContainers(spec *corev1.PodSpec) []corev1.Container {
+	containers := make([]corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers)+len(spec.EphemeralContainers))
+	containers = append(containers, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for _, ec := range spec.EphemeralContainers {
+		containers = append(containers, corev1.Container(ec.EphemeralContainerCommon))
+	}
+	return containers
+}
+
+// Image reference grammar, following the distribution/reference rules:
+// [registry[:port]/]path[:tag][@digest]
+var (
+	imageDomainPattern = `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(?:\.(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))*(?::[0-9]+)?`
+	imagePathPattern   = `[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*`
+	imageRefRegexp     = regexp.MustCompile(`^(?:` + imageDomainPattern + `/)?` + imagePathPattern + `(?:/` + imagePathPattern + `)*` +
+		`(?::[\w][\w.-]{0,127})?` +
+		`(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?$`)
+)
+
+// maxImageNameLength is the longest repository name registries accept
+const maxImageNameLength = 255
+
+// ValidateImageReference checks that ref is a well-formed container image
+// reference such as "nginx:1.27", "ghcr.io/org/app:v2", or
+// "registry:5000/app@sha256:<hex>". It does not check that the image exists.
+func ValidateImageReference(ref string) error {
+	if ref == "" {
+		return fmt.Errorf("image reference is empty")
+	}
+	if strings.ContainsAny(ref, " \t\n") {
+		return fmt.Errorf("image reference %q contains whitespace", ref)
+	}
+	if !imageRefRegexp.MatchString(ref) {
+		if imageRefRegexp.MatchString(strings.ToLower(ref)) {
+			return fmt.Errorf("invalid image reference %q: repository name must be lowercase", ref)
+		}
+		return fmt.Errorf("invalid image reference %q: expected [registry/]name[:tag][@digest]", ref)
+	}
+
+	repo := ref
+	if i := strings.Index(repo, "@"); i >= 0 {
+		repo = repo[:i]
+	}
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo = repo[:i]
+	}
+	if len(repo) > maxImageNameLength {
+		return fmt.Errorf("invalid image reference: repository name longer than %d characters", maxImageNameLength)
+	}
+	return nil
+}
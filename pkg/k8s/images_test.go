@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWorkloadContainers(t *testing.T) {
+	c := &Client{Clientset: fake.NewClientset( //nolint:staticcheck
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "migrate", Image: "app:1.0"}},
+				Containers: []corev1.Container{
+					{Name: "app", Image: "app:1.0"},
+					{Name: "proxy", Image: "envoyproxy/envoy:v1.30"},
+				},
+			}}},
+		},
+	)}
+
+	containers, err := c.WorkloadContainers(context.Background(), "default", "deploy", "web")
+	if err != nil {
+		t.Fatalf("WorkloadContainers() error = %v", err)
+	}
+	want := []WorkloadContainer{
+		{Name: "migrate", Image: "app:1.0", Init: true},
+		{Name: "app", Image: "app:1.0"},
+		{Name: "proxy", Image: "envoyproxy/envoy:v1.30"},
+	}
+	if len(containers) != len(want) {
+		t.Fatalf("WorkloadContainers() = %+v, want %+v", containers, want)
+	}
+	for i := range want {
+		if containers[i] != want[i] {
+			t.Errorf("containers[%d] = %+v, want %+v", i, containers[i], want[i])
+		}
+	}
+
+	if _, err := c.WorkloadContainers(context.Background(), "default", "replicasets", "web"); err == nil {
+		t.Error("expected an error for an unsupported kind")
+	}
+	if _, err := c.WorkloadContainers(context.Background(), "default", "deployments", "missing"); err == nil {
+		t.Error("expected an error for a missing deployment")
+	}
+}
+
+func TestAllContainers(t *testing.T) {
+	spec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "migrate", Image: "app:1.0"}},
+		Containers:     []corev1.Container{{Name: "app", Image: "app:1.0"}},
+		EphemeralContainers: []corev1.EphemeralContainer{{EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name: "debugger", Image: "busybox:1.36",
+		}}},
+	}
+
+	var got []string
+	for _, c := range AllContainers(spec) {
+		got = append(got, c.Name+"="+c.Image)
+	}
+	if want := "migrate=app:1.0 app=app:1.0 debugger=busybox:1.36"; strings.Join(got, " ") != want {
+		t.Errorf("AllContainers() = %v, want %s", got, want)
+	}
+}
+
+func TestValidateImageReference(t *testing.T) {
+	valid := []string{
+		"nginx",
+		"nginx:1.27",
+		"nginx:1.27-alpine",
+		"library/nginx:latest",
+		"ghcr.io/org/team/app:v2.0.1",
+		"localhost:5000/app:dev",
+		"registry.example.com:443/app_name/sub-path:TAG_1",
+		"app@sha256:" + strings.Repeat("a", 64),
+		"ghcr.io/org/app:v2@sha256:" + strings.Repeat("0", 64),
+	}
+	for _, ref := range valid {
+		if err := ValidateImageReference(ref); err != nil {
+			t.Errorf("ValidateImageReference(%q) = %v, want nil", ref, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"":                                "empty",
+		"nginx 1.27":                      "whitespace",
+		"Nginx:1.27":                      "lowercase",
+		"nginx:":                          "expected",
+		"nginx:-bad":                      "expected",
+		"nginx@sha256:abc":                "expected",
+		"ghcr.io//app":                    "expected",
+		"app:" + strings.Repeat("t", 129): "expected",
+		strings.Repeat("a", 256):          "longer than",
+	}
+	for ref, wantErr := range invalid {
+		err := ValidateImageReference(ref)
+		if err == nil {
+			t.Errorf("ValidateImageReference(%q) = nil, want error", ref)
+			continue
+		}
+		if !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("ValidateImageReference(%q) = %v, want it to mention %q", ref, err, wantErr)
+		}
+	}
+}
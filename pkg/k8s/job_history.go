@@ -0,0 +1,205 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Job run states
+const (
+	JobRunComplete  = "Complete"
+	JobRunFailed    = "Failed"
+	JobRunRunning   = "Running"
+	JobRunPending   = "Pending"
+	JobRunSuspended = "Suspended"
+)
+
+// JobRun summarizes one Job created by a CronJob
+type JobRun struct {
+	Name        string
+	Status      string // JobRunComplete, JobRunFailed, JobRunRunning, JobRunPending, or JobRunSuspended
+	StartTime   time.Time
+	EndTime     time.Time     // Zero while the job has not finished
+	Duration    time.Duration // Start to end, or to now while running
+	Completions int32
+	Succeeded   int32
+	Failed      int32
+	// ExitInfo explains a failure or a stuck run, e.g.
+	// "BackoffLimitExceeded; main exited 1 (Error)"
+	ExitInfo string
+	Pods     []string // The job's pods, newest first
+}
+
+// GetCronJobHistory returns the Jobs owned by a CronJob, newest first, with
+// the exit status of their pods. The context's list selector is ignored so a
+// TUI filter cannot hide runs.
+func (c *Client) GetCronJobHistory(ctx context.Context, namespace, name string) ([]JobRun, error) {
+	cj, err := c.clientset().BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cronjob: %w", err)
+	}
+	jobList, err := c.clientset().BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	var names []string
+	for _, job := range jobList.Items {
+		if isOwnedByCronJob(&job, cj) {
+			names = append(names, job.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	// The job controller labels every pod with job-name
+	podList, err := c.clientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("job-name in (%s)", strings.Join(names, ",")),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	return BuildJobRuns(cj, jobList.Items, podList.Items, time.Now()), nil
+}
+
+// BuildJobRuns summarizes the jobs owned by cj, newest first. Durations of
+// unfinished jobs are measured up to now.
+func BuildJobRuns(cj *batchv1.CronJob, jobs []batchv1.Job, pods []corev1.Pod, now time.Time) []JobRun {
+	podsByJob := make(map[string][]corev1.Pod)
+	for _, pod := range pods {
+		for _, ref := range pod.OwnerReferences {
+			if ref.Kind == "Job" {
+				podsByJob[ref.Name] = append(podsByJob[ref.Name], pod)
+			}
+		}
+	}
+
+	var runs []JobRun
+	for i := range jobs {
+		job := &jobs[i]
+		if !isOwnedByCronJob(job, cj) {
+			continue
+		}
+		jobPods := podsByJob[job.Name]
+		sort.Slice(jobPods, func(i, j int) bool {
+			return jobPods[i].CreationTimestamp.After(jobPods[j].CreationTimestamp.Time)
+		})
+		runs = append(runs, buildJobRun(job, jobPods, now))
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		if !runs[i].StartTime.Equal(runs[j].StartTime) {
+			return runs[i].StartTime.After(runs[j].StartTime)
+		}
+		return runs[i].Name > runs[j].Name
+	})
+	return runs
+}
+
+func isOwnedByCronJob(job *batchv1.Job, cj *batchv1.CronJob) bool {
+	for _, ref := range job.OwnerReferences {
+		if ref.Kind != "CronJob" || ref.Name != cj.Name {
+			continue
+		}
+		if ref.UID != "" && cj.UID != "" && ref.UID != cj.UID {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// buildJobRun summarizes a job; pods must be sorted newest first
+func buildJobRun(job *batchv1.Job, pods []corev1.Pod, now time.Time) JobRun {
+	run := JobRun{
+		Name:        job.Name,
+		Status:      JobRunPending,
+		StartTime:   job.CreationTimestamp.Time,
+		Completions: 1,
+		Succeeded:   job.Status.Succeeded,
+		Failed:      job.Status.Failed,
+	}
+	if job.Spec.Completions != nil {
+		run.Completions = *job.Spec.Completions
+	}
+	if job.Status.StartTime != nil {
+		run.StartTime = job.Status.StartTime.Time
+	}
+	for _, pod := range pods {
+		run.Pods = append(run.Pods, pod.Name)
+	}
+
+	var failedReason string
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			run.Status = JobRunComplete
+			run.EndTime = cond.LastTransitionTime.Time
+		case batchv1.JobFailed:
+			run.Status = JobRunFailed
+			run.EndTime = cond.LastTransitionTime.Time
+			failedReason = cond.Reason
+		case batchv1.JobSuspended:
+			if run.Status == JobRunPending {
+				run.Status = JobRunSuspended
+			}
+		}
+	}
+	if run.Status == JobRunComplete && job.Status.CompletionTime != nil {
+		run.EndTime = job.Status.CompletionTime.Time
+	}
+	if run.Status == JobRunPending && job.Status.Active > 0 {
+		run.Status = JobRunRunning
+	}
+
+	end := run.EndTime
+	if end.IsZero() {
+		end = now
+	}
+	if !run.StartTime.IsZero() && end.After(run.StartTime) {
+		run.Duration = end.Sub(run.StartTime)
+	}
+
+	if run.Status != JobRunComplete {
+		var info []string
+		if failedReason != "" {
+			info = append(info, failedReason)
+		}
+		if detail := podExitInfo(pods); detail != "" {
+			info = append(info, detail)
+		}
+		run.ExitInfo = strings.Join(info, "; ")
+	}
+	return run
+}
+
+// podExitInfo describes why the newest pod with a problem failed or is
+// stuck: a non-zero container exit, a waiting reason such as
+// ImagePullBackOff, or a pod-level reason such as DeadlineExceeded
+func podExitInfo(pods []corev1.Pod) string {
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if t := cs.State.Terminated; t != nil && t.ExitCode != 0 {
+				return fmt.Sprintf("%s exited %d (%s)", cs.Name, t.ExitCode, t.Reason)
+			}
+			if w := cs.State.Waiting; w != nil && w.Reason != "" && w.Reason != "ContainerCreating" {
+				return fmt.Sprintf("%s %s", cs.Name, w.Reason)
+			}
+		}
+		if pod.Status.Phase == corev1.PodFailed && pod.Status.Reason != "" {
+			return pod.Status.Reason
+		}
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func historyJob(name, owner string, start time.Time, conds ...batchv1.JobCondition) *batchv1.Job {
+	startTime := metav1.NewTime(start)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: owner}},
+		},
+		Status: batchv1.JobStatus{StartTime: &startTime, Conditions: conds},
+	}
+}
+
+func historyPod(name, job string, created time.Time, state corev1.ContainerState) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            map[string]string{"job-name": job},
+			CreationTimestamp: metav1.NewTime(created),
+			OwnerReferences:   []metav1.OwnerReference{{Kind: "Job", Name: job}},
+		},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "main", State: state}}},
+	}
+}
+
+func jobCondition(t batchv1.JobConditionType, reason string, at time.Time) batchv1.JobCondition {
+	return batchv1.JobCondition{Type: t, Status: corev1.ConditionTrue, Reason: reason, LastTransitionTime: metav1.NewTime(at)}
+}
+
+func TestBuildJobRuns(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cj := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"}}
+
+	done := historyJob("backup-1", "backup", now.Add(-2*time.Hour), jobCondition(batchv1.JobComplete, "", now.Add(-2*time.Hour+90*time.Second)))
+	failed := historyJob("backup-2", "backup", now.Add(-time.Hour), jobCondition(batchv1.JobFailed, "BackoffLimitExceeded", now.Add(-time.Hour+time.Minute)))
+	running := historyJob("backup-3", "backup", now.Add(-5*time.Minute))
+	running.Status.Active = 1
+	other := historyJob("report-1", "report", now)
+	jobs := []batchv1.Job{*done, *failed, *running, *other}
+
+	exited := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}}
+	pods := []corev1.Pod{
+		*historyPod("backup-2-old", "backup-2", now.Add(-time.Hour), exited),
+		*historyPod("backup-2-new", "backup-2", now.Add(-30*time.Minute), exited),
+		*historyPod("backup-3-a", "backup-3", now.Add(-5*time.Minute),
+			corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}),
+	}
+
+	runs := BuildJobRuns(cj, jobs, pods, now)
+	if len(runs) != 3 {
+		t.Fatalf("BuildJobRuns() returned %d runs, want 3 owned by backup", len(runs))
+	}
+	if runs[0].Name != "backup-3" || runs[1].Name != "backup-2" || runs[2].Name != "backup-1" {
+		t.Errorf("runs = %s, %s, %s; want newest first", runs[0].Name, runs[1].Name, runs[2].Name)
+	}
+
+	if r := runs[0]; r.Status != JobRunRunning || r.Duration != 5*time.Minute || r.ExitInfo != "main ImagePullBackOff" {
+		t.Errorf("running run = %+v", r)
+	}
+	if r := runs[1]; r.Status != JobRunFailed || r.Duration != time.Minute ||
+		r.ExitInfo != "BackoffLimitExceeded; main exited 1 (Error)" || len(r.Pods) != 2 || r.Pods[0] != "backup-2-new" {
+		t.Errorf("failed run = %+v", r)
+	}
+	if r := runs[2]; r.Status != JobRunComplete || r.Duration != 90*time.Second || r.ExitInfo != "" {
+		t.Errorf("complete run = %+v", r)
+	}
+}
+
+func TestGetCronJobHistory(t *testing.T) {
+	now := time.Now()
+	c := &Client{Clientset: fake.NewClientset( //nolint:staticcheck
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"}},
+		historyJob("backup-1", "backup", now.Add(-time.Hour), jobCondition(batchv1.JobComplete, "", now.Add(-time.Hour+time.Minute))),
+		historyJob("backup-manual", "other", now),
+		historyPod("backup-1-abc", "backup-1", now.Add(-time.Hour), corev1.ContainerState{}),
+	)}
+
+	// A context selector must not hide runs
+	ctx := WithListSelector(context.Background(), ListSelector{Label: "app=none"})
+	runs, err := c.GetCronJobHistory(ctx, "default", "backup")
+	if err != nil {
+		t.Fatalf("GetCronJobHistory() error = %v", err)
+	}
+	if len(runs) != 1 || runs[0].Name != "backup-1" || runs[0].Status != JobRunComplete ||
+		len(runs[0].Pods) != 1 || runs[0].Pods[0] != "backup-1-abc" {
+		t.Errorf("GetCronJobHistory() = %+v, want backup-1 complete with one pod", runs)
+	}
+
+	if _, err := c.GetCronJobHistory(ctx, "default", "missing"); err == nil {
+		t.Error("expected an error for a missing cronjob")
+	}
+}
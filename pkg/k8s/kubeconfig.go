@@ -0,0 +1,157 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Environment variables consulted by ClientOptionsFromEnv.
+const (
+	// EnvKubeconfig holds an explicit kubeconfig path, set by --kubeconfig.
+	// Like KUBECONFIG it may list several files separated by the OS path
+	// list separator.
+	EnvKubeconfig = "K13D_KUBECONFIG"
+	// EnvQPS, EnvBurst, and EnvMaxConcurrency tune client-side rate limiting
+	// and how many namespaces bulk listing queries at once.
+	EnvQPS            = "K13D_KUBE_QPS"
+	EnvBurst          = "K13D_KUBE_BURST"
+	EnvMaxConcurrency = "K13D_KUBE_MAX_CONCURRENCY"
+)
+
+// Config sources reported by Client.ConfigSource.
+const (
+	ConfigSourceFlag      = "--kubeconfig"
+	ConfigSourceEnv       = "KUBECONFIG"
+	ConfigSourceDefault   = "~/.kube/config"
+	ConfigSourceInCluster = "in-cluster"
+)
+
+// ErrNoKubeConfig is returned when no kubeconfig file exists and the process
+// is not running inside a Kubernetes pod.
+var ErrNoKubeConfig = errors.New("no Kubernetes configuration found: pass --kubeconfig, set KUBECONFIG, create ~/.kube/config, or run inside a cluster with a service account")
+
+// inClusterConfigFunc is swapped out by tests to simulate running in a pod.
+var inClusterConfigFunc = rest.InClusterConfig
+
+// ClientOptions controls how NewClientWithOptions resolves cluster credentials.
+//
+// Precedence:
+//  1. Kubeconfig (--kubeconfig / K13D_KUBECONFIG), which must exist
+//  2. in-cluster service account, when PreferInCluster is set (the web
+//     server sets it, since it usually runs in a pod)
+//  3. KUBECONFIG (multi-path) or ~/.kube/config, when any listed file exists
+//  4. in-cluster service account
+//
+// QPS, Burst, and MaxConcurrency fall back to DefaultQPS, DefaultBurst, and
+// DefaultMaxConcurrency when zero.
+type ClientOptions struct {
+	Kubeconfig      string
+	PreferInCluster bool
+	QPS             float32 // Client-side request rate limit per second
+	Burst           int     // Requests allowed above QPS in a short burst
+	MaxConcurrency  int     // Namespaces ForEachNamespace queries at once
+}
+
+// ClientOptionsFromEnv builds ClientOptions from K13D_KUBECONFIG,
+// K13D_KUBE_QPS, K13D_KUBE_BURST, and K13D_KUBE_MAX_CONCURRENCY.
+func ClientOptionsFromEnv() ClientOptions {
+	opts := ClientOptions{Kubeconfig: strings.TrimSpace(os.Getenv(EnvKubeconfig))}
+	if v, err := strconv.ParseFloat(os.Getenv(EnvQPS), 32); err == nil && v > 0 {
+		opts.QPS = float32(v)
+	}
+	if v, err := strconv.Atoi(os.Getenv(EnvBurst)); err == nil && v > 0 {
+		opts.Burst = v
+	}
+	if v, err := strconv.Atoi(os.Getenv(EnvMaxConcurrency)); err == nil && v > 0 {
+		opts.MaxConcurrency = v
+	}
+	return opts
+}
+
+// LoadingRules returns the kubeconfig loading rules for these options: the
+// explicit Kubeconfig paths when set, otherwise client-go's defaults
+// (KUBECONFIG, then ~/.kube/config).
+func (o ClientOptions) LoadingRules() *clientcmd.ClientConfigLoadingRules {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if paths := o.kubeconfigPaths(); len(paths) > 0 {
+		rules.Precedence = paths
+	}
+	return rules
+}
+
+func (o ClientOptions) kubeconfigPaths() []string {
+	var paths []string
+	for _, p := range filepath.SplitList(o.Kubeconfig) {
+		if p = strings.TrimSpace(p); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// ResolveRESTConfig picks the REST config according to the ClientOptions
+// precedence and reports which source was used. Everything that talks to
+// the cluster for one client, e.g. the web server's token validator, uses it
+// so they agree on the cluster.
+func ResolveRESTConfig(opts ClientOptions) (*rest.Config, string, error) {
+	rules := opts.LoadingRules()
+
+	if paths := opts.kubeconfigPaths(); len(paths) > 0 {
+		for _, p := range paths {
+			if _, err := os.Stat(p); err != nil {
+				return nil, "", fmt.Errorf("kubeconfig %q: %w", p, err)
+			}
+		}
+		config, err := clientConfigFromRules(rules).ClientConfig()
+		if err != nil {
+			return nil, "", fmt.Errorf("load kubeconfig %s: %w", opts.Kubeconfig, err)
+		}
+		return config, ConfigSourceFlag, nil
+	}
+
+	if opts.PreferInCluster {
+		if config, err := inClusterConfigFunc(); err == nil {
+			return config, ConfigSourceInCluster, nil
+		}
+	}
+
+	source := ConfigSourceDefault
+	if os.Getenv(clientcmd.RecommendedConfigPathEnvVar) != "" {
+		source = ConfigSourceEnv
+	}
+	if anyFileExists(rules.Precedence) {
+		config, err := clientConfigFromRules(rules).ClientConfig()
+		if err != nil {
+			return nil, "", fmt.Errorf("load kubeconfig from %s: %w", source, err)
+		}
+		return config, source, nil
+	}
+
+	if config, err := inClusterConfigFunc(); err == nil {
+		return config, ConfigSourceInCluster, nil
+	}
+	return nil, "", ErrNoKubeConfig
+}
+
+func clientConfigFromRules(rules *clientcmd.ClientConfigLoadingRules) clientcmd.ClientConfig {
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{})
+}
+
+func anyFileExists(paths []string) bool {
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		if _, err := os.Stat(p); err == nil {
+			return true
+		}
+	}
+	return false
+}
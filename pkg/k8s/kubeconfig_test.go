@@ -0,0 +1,214 @@
+package k8s
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func writeTestKubeconfig(t *testing.T, dir, name, server string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	data := `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: ` + server + `
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: abc
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func stubInCluster(t *testing.T, available bool) {
+	t.Helper()
+	orig := inClusterConfigFunc
+	inClusterConfigFunc = func() (*rest.Config, error) {
+		if !available {
+			return nil, rest.ErrNotInCluster
+		}
+		return &rest.Config{Host: "https://in-cluster"}, nil
+	}
+	t.Cleanup(func() { inClusterConfigFunc = orig })
+}
+
+func TestResolveRESTConfig_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	flagPath := writeTestKubeconfig(t, dir, "flag.yaml", "https://flag")
+	envPath := writeTestKubeconfig(t, dir, "env.yaml", "https://env")
+	missing := filepath.Join(dir, "missing.yaml")
+
+	tests := []struct {
+		name            string
+		opts            ClientOptions
+		kubeconfigEnv   string
+		inCluster       bool
+		wantHost        string
+		wantSource      string
+		wantErr         bool
+		wantNoConfigErr bool
+	}{
+		{
+			name:       "explicit kubeconfig wins over in-cluster",
+			opts:       ClientOptions{Kubeconfig: flagPath, PreferInCluster: true},
+			inCluster:  true,
+			wantHost:   "https://flag",
+			wantSource: ConfigSourceFlag,
+		},
+		{
+			name:    "explicit kubeconfig must exist",
+			opts:    ClientOptions{Kubeconfig: missing},
+			wantErr: true,
+		},
+		{
+			name:          "KUBECONFIG multi-path",
+			kubeconfigEnv: missing + string(os.PathListSeparator) + envPath,
+			wantHost:      "https://env",
+			wantSource:    ConfigSourceEnv,
+		},
+		{
+			name:          "KUBECONFIG beats in-cluster by default",
+			kubeconfigEnv: envPath,
+			inCluster:     true,
+			wantHost:      "https://env",
+			wantSource:    ConfigSourceEnv,
+		},
+		{
+			name:          "prefer in-cluster beats KUBECONFIG",
+			opts:          ClientOptions{PreferInCluster: true},
+			kubeconfigEnv: envPath,
+			inCluster:     true,
+			wantHost:      "https://in-cluster",
+			wantSource:    ConfigSourceInCluster,
+		},
+		{
+			name:          "in-cluster when no kubeconfig exists",
+			kubeconfigEnv: missing,
+			inCluster:     true,
+			wantHost:      "https://in-cluster",
+			wantSource:    ConfigSourceInCluster,
+		},
+		{
+			name:            "clear error when nothing is available",
+			kubeconfigEnv:   missing,
+			wantErr:         true,
+			wantNoConfigErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KUBECONFIG", tt.kubeconfigEnv)
+			stubInCluster(t, tt.inCluster)
+
+			cfg, source, err := ResolveRESTConfig(tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got config from %s", source)
+				}
+				if tt.wantNoConfigErr && !errors.Is(err, ErrNoKubeConfig) {
+					t.Errorf("error = %v, want ErrNoKubeConfig", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveRESTConfig() error = %v", err)
+			}
+			if cfg.Host != tt.wantHost {
+				t.Errorf("host = %q, want %q", cfg.Host, tt.wantHost)
+			}
+			if source != tt.wantSource {
+				t.Errorf("source = %q, want %q", source, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestClientOptionsFromEnv(t *testing.T) {
+	t.Setenv(EnvKubeconfig, " /tmp/a.yaml ")
+
+	opts := ClientOptionsFromEnv()
+	if opts.Kubeconfig != "/tmp/a.yaml" {
+		t.Errorf("Kubeconfig = %q, want /tmp/a.yaml", opts.Kubeconfig)
+	}
+	if opts.PreferInCluster {
+		t.Error("PreferInCluster = true, want false outside the web server")
+	}
+	if rules := opts.LoadingRules(); len(rules.Precedence) != 1 || rules.Precedence[0] != "/tmp/a.yaml" {
+		t.Errorf("LoadingRules().Precedence = %v, want [/tmp/a.yaml]", rules.Precedence)
+	}
+}
+
+func TestSwitchContext_UpdatesContextInfo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	data := `apiVersion: v1
+kind: Config
+clusters:
+- name: a
+  cluster:
+    server: https://a
+- name: b
+  cluster:
+    server: https://b
+contexts:
+- name: ctx-a
+  context:
+    cluster: a
+    user: u
+    namespace: team-a
+- name: ctx-b
+  context:
+    cluster: b
+    user: u
+    namespace: team-b
+current-context: ctx-a
+users:
+- name: u
+  user:
+    token: abc
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	stubInCluster(t, false)
+
+	client, err := NewClientWithOptions(ClientOptions{Kubeconfig: path})
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	if ctx, _ := client.GetCurrentContext(); ctx != "ctx-a" {
+		t.Fatalf("initial context = %q, want ctx-a", ctx)
+	}
+
+	if err := client.SwitchContext("ctx-b"); err != nil {
+		t.Fatalf("SwitchContext() error = %v", err)
+	}
+	ctx, cluster, _, err := client.GetContextInfo()
+	if err != nil || ctx != "ctx-b" || cluster != "b" {
+		t.Errorf("GetContextInfo() = %q, %q, %v; want ctx-b, b", ctx, cluster, err)
+	}
+	if ns := client.GetCurrentNamespace(); ns != "team-b" {
+		t.Errorf("GetCurrentNamespace() = %q, want team-b", ns)
+	}
+	if _, current, _ := client.ListContexts(); current != "ctx-b" {
+		t.Errorf("ListContexts() current = %q, want ctx-b", current)
+	}
+	if client.Config.Host != "https://b" {
+		t.Errorf("Config.Host = %q, want https://b", client.Config.Host)
+	}
+}
@@ -0,0 +1,155 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+)
+
+// ManifestChange describes what applying one manifest object does.
+type ManifestChange struct {
+	Kind      string
+	Name      string
+	Namespace string // Empty for cluster-scoped objects
+	Resource  string // Plural resource name, e.g. "deployments"
+
+	// Current is the live object before the apply, nil when it is created
+	Current *unstructured.Unstructured
+	// Result is the object as stored (or, in dry-run, as it would be stored)
+	Result *unstructured.Unstructured
+	Err    error
+}
+
+// ParseManifest splits a multi-document YAML or JSON manifest into objects.
+// Empty documents are skipped and "kind: List" documents are expanded.
+func ParseManifest(data []byte) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	var objs []*unstructured.Unstructured
+	for doc := 1; ; doc++ {
+		var raw map[string]interface{}
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("document %d: %w", doc, err)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{Object: raw}
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("document %d: %w", doc, err)
+			}
+			for i := range list.Items {
+				objs = append(objs, &list.Items[i])
+			}
+			continue
+		}
+		objs = append(objs, obj)
+	}
+
+	for i, obj := range objs {
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+			return nil, fmt.Errorf("object %d: apiVersion and kind are required", i+1)
+		}
+		if obj.GetName() == "" {
+			return nil, fmt.Errorf("object %d (%s): metadata.name is required", i+1, obj.GetKind())
+		}
+	}
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("manifest contains no objects")
+	}
+	return objs, nil
+}
+
+// ManifestTarget returns the resource and namespace an object applies to,
+// using defaultNamespace ("default" when empty) for namespaced objects
+// that do not set one.
+func (c *Client) ManifestTarget(obj *unstructured.Unstructured, defaultNamespace string) (resource, namespace string, err error) {
+	gvr, err := c.getGVRForKind(obj.GetAPIVersion(), obj.GetKind())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to determine resource type: %w", err)
+	}
+	if isNamespacedResource(obj.GetKind()) {
+		namespace = obj.GetNamespace()
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+		if namespace == "" {
+			namespace = "default"
+		}
+	}
+	return gvr.Resource, namespace, nil
+}
+
+// ApplyManifest creates or updates each object in order. With dryRun set the
+// API server validates, defaults, and admits the objects without persisting
+// them, so Result shows exactly what a real apply would store. A failing
+// object records its error in Err and does not stop the remaining ones.
+func (c *Client) ApplyManifest(ctx context.Context, objs []*unstructured.Unstructured, defaultNamespace string, dryRun bool) []ManifestChange {
+	changes := make([]ManifestChange, 0, len(objs))
+	for _, obj := range objs {
+		changes = append(changes, c.applyManifestObject(ctx, obj.DeepCopy(), defaultNamespace, dryRun))
+	}
+	return changes
+}
+
+func (c *Client) applyManifestObject(ctx context.Context, obj *unstructured.Unstructured, defaultNamespace string, dryRun bool) ManifestChange {
+	change := ManifestChange{Kind: obj.GetKind(), Name: obj.GetName()}
+	if c.dynamicClient() == nil {
+		change.Err = fmt.Errorf("dynamic client not initialized")
+		return change
+	}
+
+	resource, namespace, err := c.ManifestTarget(obj, defaultNamespace)
+	if err != nil {
+		change.Err = err
+		return change
+	}
+	change.Resource = resource
+	change.Namespace = namespace
+
+	gvr, _ := c.getGVRForKind(obj.GetAPIVersion(), obj.GetKind())
+	var resourceClient dynamic.ResourceInterface
+	if namespace != "" {
+		obj.SetNamespace(namespace)
+		resourceClient = c.dynamicClient().Resource(gvr).Namespace(namespace)
+	} else {
+		resourceClient = c.dynamicClient().Resource(gvr)
+	}
+
+	var dryRunOpt []string
+	if dryRun {
+		dryRunOpt = []string{metav1.DryRunAll}
+	}
+
+	existing, err := resourceClient.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	switch {
+	case err == nil:
+		change.Current = existing
+		obj.SetResourceVersion(existing.GetResourceVersion())
+		change.Result, err = resourceClient.Update(ctx, obj, metav1.UpdateOptions{DryRun: dryRunOpt})
+		if err != nil {
+			change.Err = fmt.Errorf("failed to update %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+	case apierrors.IsNotFound(err):
+		change.Result, err = resourceClient.Create(ctx, obj, metav1.CreateOptions{DryRun: dryRunOpt})
+		if err != nil {
+			change.Err = fmt.Errorf("failed to create %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+	default:
+		change.Err = fmt.Errorf("failed to get %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	return change
+}
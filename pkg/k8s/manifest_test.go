@@ -0,0 +1,144 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+const testManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+data:
+  mode: prod
+---
+# comment-only document
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Namespace
+  metadata:
+    name: team-a
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: web
+    namespace: team-a
+`
+
+func TestParseManifest(t *testing.T) {
+	objs, err := ParseManifest([]byte(testManifest))
+	if err != nil {
+		t.Fatalf("ParseManifest: %v", err)
+	}
+	var got []string
+	for _, obj := range objs {
+		got = append(got, obj.GetKind()+"/"+obj.GetName())
+	}
+	want := "ConfigMap/app-config,Namespace/team-a,Service/web"
+	if strings.Join(got, ",") != want {
+		t.Errorf("objects = %v, want %s", got, want)
+	}
+}
+
+func TestParseManifest_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":        "---\n",
+		"missing kind": "apiVersion: v1\nmetadata:\n  name: x\n",
+		"missing name": "apiVersion: v1\nkind: ConfigMap\nmetadata: {}\n",
+		"bad yaml":     "apiVersion: v1\nkind: [\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseManifest([]byte(data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestManifestTarget(t *testing.T) {
+	c := &Client{}
+	objs, _ := ParseManifest([]byte(testManifest))
+
+	resource, ns, _ := c.ManifestTarget(objs[0], "")
+	if resource != "configmaps" || ns != "default" {
+		t.Errorf("ConfigMap target = %s in %q, want configmaps in default", resource, ns)
+	}
+	resource, ns, _ = c.ManifestTarget(objs[1], "staging")
+	if resource != "namespaces" || ns != "" {
+		t.Errorf("Namespace target = %s in %q, want cluster-scoped namespaces", resource, ns)
+	}
+	_, ns, _ = c.ManifestTarget(objs[2], "staging")
+	if ns != "team-a" {
+		t.Errorf("Service namespace = %q, want team-a from metadata", ns)
+	}
+}
+
+func TestApplyManifest_CreateAndUpdate(t *testing.T) {
+	existing := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "app-config", "namespace": "default"},
+		"data":       map[string]interface{}{"mode": "dev"},
+	}}
+	gvrs := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+		{Version: "v1", Resource: "services"}:   "ServiceList",
+	}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), gvrs, existing)
+	c := &Client{Dynamic: dyn}
+
+	objs, err := ParseManifest([]byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+data:
+  mode: prod
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes := c.ApplyManifest(context.Background(), objs, "default", false)
+	if len(changes) != 2 {
+		t.Fatalf("len(changes) = %d, want 2", len(changes))
+	}
+	if changes[0].Err != nil || changes[0].Current == nil {
+		t.Errorf("ConfigMap change = %+v, want update of existing object", changes[0])
+	}
+	if changes[1].Err != nil || changes[1].Current != nil || changes[1].Namespace != "default" {
+		t.Errorf("Service change = %+v, want create in default", changes[1])
+	}
+
+	cm, err := dyn.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).
+		Namespace("default").Get(context.Background(), "app-config", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode, _, _ := unstructured.NestedString(cm.Object, "data", "mode"); mode != "prod" {
+		t.Errorf("data.mode = %q, want prod", mode)
+	}
+}
+
+func TestApplyManifest_NoDynamicClient(t *testing.T) {
+	objs, _ := ParseManifest([]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: x\n"))
+	changes := (&Client{}).ApplyManifest(context.Background(), objs, "", true)
+	if len(changes) != 1 || changes[0].Err == nil {
+		t.Errorf("changes = %+v, want one error", changes)
+	}
+}
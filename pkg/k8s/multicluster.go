@@ -0,0 +1,145 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// multiClusterWorkers caps how many clusters SummarizeContexts queries at once
+const multiClusterWorkers = 4
+
+// ClusterSummary is a point-in-time health summary of one kubeconfig context
+type ClusterSummary struct {
+	Context       string
+	NodesTotal    int
+	NodesReady    int
+	PodsTotal     int
+	PodsRunning   int // Running with every container ready
+	PodsSucceeded int // Completed pods, e.g. finished Jobs
+	PodsFailed    int
+	Warnings      int   // Warning events the API server still retains
+	Err           error // Set when the cluster could not be queried
+}
+
+// SummarizeContexts queries each kubeconfig context for node, pod, and warning
+// event counts. Clusters are queried concurrently and each gets at most
+// timeout; an unreachable cluster is reported through its Err field instead
+// of failing the whole call. Results are in the order of contexts.
+func (c *Client) SummarizeContexts(ctx context.Context, contexts []string, timeout time.Duration) []ClusterSummary {
+	summaries := make([]ClusterSummary, len(contexts))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, multiClusterWorkers)
+
+	for i, name := range contexts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			cctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			cs, err := c.clientsetForContext(name)
+			if err != nil {
+				summaries[i] = ClusterSummary{Context: name, Err: err}
+				return
+			}
+			summaries[i] = summarizeCluster(cctx, cs)
+			summaries[i].Context = name
+		}()
+	}
+	wg.Wait()
+	return summaries
+}
+
+// clientsetForContext returns a clientset for a context without switching the
+// client's active context. Clientsets are created once per context and reused.
+func (c *Client) clientsetForContext(name string) (kubernetes.Interface, error) {
+	c.mu.RLock()
+	cs, ok := c.contextClientsets[name]
+	c.mu.RUnlock()
+	if ok {
+		return cs, nil
+	}
+
+	config, err := c.restConfigForContext(name)
+	if err != nil {
+		return nil, err
+	}
+	cs, err = kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Keep the clientset of a concurrent caller that got here first
+	if existing, ok := c.contextClientsets[name]; ok {
+		return existing, nil
+	}
+	if c.contextClientsets == nil {
+		c.contextClientsets = make(map[string]kubernetes.Interface)
+	}
+	c.contextClientsets[name] = cs
+	return cs, nil
+}
+
+func summarizeCluster(ctx context.Context, cs kubernetes.Interface) ClusterSummary {
+	var summary ClusterSummary
+
+	nodes, err := cs.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		summary.Err = err
+		return summary
+	}
+	summary.NodesTotal = len(nodes.Items)
+	for _, n := range nodes.Items {
+		for _, cond := range n.Status.Conditions {
+			if cond.Type == corev1.NodeReady && cond.Status == corev1.ConditionTrue {
+				summary.NodesReady++
+				break
+			}
+		}
+	}
+
+	pods, err := cs.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		summary.Err = err
+		return summary
+	}
+	summary.PodsTotal = len(pods.Items)
+	for _, p := range pods.Items {
+		switch p.Status.Phase {
+		case corev1.PodRunning:
+			if podContainersReady(&p) {
+				summary.PodsRunning++
+			}
+		case corev1.PodSucceeded:
+			summary.PodsSucceeded++
+		case corev1.PodFailed:
+			summary.PodsFailed++
+		}
+	}
+
+	// Event access is often narrower than pod access; counts stay at zero then
+	events, err := cs.CoreV1().Events("").List(ctx, metav1.ListOptions{FieldSelector: "type=Warning"})
+	if err == nil {
+		summary.Warnings = len(events.Items)
+	}
+	return summary
+}
+
+func podContainersReady(p *corev1.Pod) bool {
+	for _, cs := range p.Status.ContainerStatuses {
+		if !cs.Ready {
+			return false
+		}
+	}
+	return true
+}
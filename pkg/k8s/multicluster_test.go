@@ -0,0 +1,97 @@
+package k8s
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSummarizeContexts(t *testing.T) {
+	// Contexts without a test clientset fall through to an empty kubeconfig
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+
+	node := func(name string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: ready},
+			}},
+		}
+	}
+	pod := func(name string, phase corev1.PodPhase, ready bool) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status: corev1.PodStatus{
+				Phase:             phase,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app", Ready: ready}},
+			},
+		}
+	}
+
+	c := &Client{contextClientsets: map[string]kubernetes.Interface{
+		"prod": fake.NewClientset( //nolint:staticcheck
+			node("n1", corev1.ConditionTrue),
+			node("n2", corev1.ConditionFalse),
+			pod("web", corev1.PodRunning, true),
+			pod("api", corev1.PodRunning, false),
+			pod("job", corev1.PodSucceeded, false),
+			pod("bad", corev1.PodFailed, false),
+			&corev1.Event{
+				ObjectMeta: metav1.ObjectMeta{Name: "api.1", Namespace: "default"},
+				Type:       corev1.EventTypeWarning,
+				Reason:     "BackOff",
+			},
+		),
+		"staging": fake.NewClientset(node("s1", corev1.ConditionTrue)), //nolint:staticcheck
+	}}
+
+	got := c.SummarizeContexts(context.Background(), []string{"staging", "prod", "gone"}, 5*time.Second)
+	if len(got) != 3 {
+		t.Fatalf("got %d summaries, want 3", len(got))
+	}
+
+	if got[0].Context != "staging" || got[0].NodesTotal != 1 || got[0].NodesReady != 1 || got[0].Err != nil {
+		t.Errorf("staging summary = %+v", got[0])
+	}
+
+	prod := got[1]
+	if prod.Context != "prod" || prod.Err != nil {
+		t.Fatalf("prod summary = %+v", prod)
+	}
+	if prod.NodesTotal != 2 || prod.NodesReady != 1 {
+		t.Errorf("prod nodes = %d/%d, want 1/2 ready", prod.NodesReady, prod.NodesTotal)
+	}
+	if prod.PodsTotal != 4 || prod.PodsRunning != 1 || prod.PodsSucceeded != 1 || prod.PodsFailed != 1 {
+		t.Errorf("prod pods = %+v", prod)
+	}
+	if prod.Warnings != 1 {
+		t.Errorf("prod warnings = %d, want 1", prod.Warnings)
+	}
+
+	if got[2].Context != "gone" || got[2].Err == nil {
+		t.Errorf("unknown context should report an error, got %+v", got[2])
+	}
+}
+
+func TestClientsetForContext_Cached(t *testing.T) {
+	path := writeTestKubeconfig(t, t.TempDir(), "config", "https://cluster.example")
+	c := &Client{opts: ClientOptions{Kubeconfig: path}}
+
+	first, err := c.clientsetForContext("test")
+	if err != nil {
+		t.Fatalf("clientsetForContext() error = %v", err)
+	}
+	second, err := c.clientsetForContext("test")
+	if err != nil {
+		t.Fatalf("clientsetForContext() error = %v", err)
+	}
+	if first != second {
+		t.Error("expected the clientset to be reused for the same context")
+	}
+}
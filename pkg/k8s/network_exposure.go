@@ -0,0 +1,167 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// IngressDefaultPath is the path shown for an Ingress default backend
+const IngressDefaultPath = "(default)"
+
+// IngressRoute is one host and path of an Ingress and the backend it sends
+// traffic to
+type IngressRoute struct {
+	Host    string // "*" when the rule matches every host
+	Path    string
+	Backend string // "service:port", or "Kind/name" for a resource backend
+	TLS     bool   // Host is listed in the Ingress TLS section
+}
+
+// IngressRoutes returns the routes of ing in rule order, followed by its
+// default backend. A TLS entry without hosts covers every host.
+func IngressRoutes(ing *networkingv1.Ingress) []IngressRoute {
+	tlsHost := func(host string) bool {
+		for _, tls := range ing.Spec.TLS {
+			if len(tls.Hosts) == 0 || slices.Contains(tls.Hosts, host) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var routes []IngressRoute
+	for _, rule := range ing.Spec.Rules {
+		host := rule.Host
+		if host == "" {
+			host = "*"
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, p := range rule.HTTP.Paths {
+			path := p.Path
+			if path == "" {
+				path = "/"
+			}
+			routes = append(routes, IngressRoute{
+				Host:    host,
+				Path:    path,
+				Backend: IngressBackendName(p.Backend),
+				TLS:     tlsHost(rule.Host),
+			})
+		}
+	}
+	if ing.Spec.DefaultBackend != nil {
+		routes = append(routes, IngressRoute{
+			Host:    "*",
+			Path:    IngressDefaultPath,
+			Backend: IngressBackendName(*ing.Spec.DefaultBackend),
+			TLS:     tlsHost(""),
+		})
+	}
+	return routes
+}
+
+// IngressBackendName formats an Ingress backend as "service:port", or as
+// "Kind/name" for a resource backend
+func IngressBackendName(b networkingv1.IngressBackend) string {
+	switch {
+	case b.Service != nil:
+		port := b.Service.Port.Name
+		if port == "" {
+			port = fmt.Sprintf("%d", b.Service.Port.Number)
+		}
+		return b.Service.Name + ":" + port
+	case b.Resource != nil:
+		return b.Resource.Kind + "/" + b.Resource.Name
+	}
+	return ""
+}
+
+// PolicyPodSelector formats the pod selector of policy, or "<all>" when it
+// selects every pod in the namespace
+func PolicyPodSelector(policy *networkingv1.NetworkPolicy) string {
+	selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+	if err != nil {
+		return "<invalid>"
+	}
+	if s := selector.String(); s != "" {
+		return s
+	}
+	return "<all>"
+}
+
+// PodPolicyCoverage lists the NetworkPolicies that select a pod. A pod no
+// policy selects accepts traffic from anywhere.
+type PodPolicyCoverage struct {
+	Namespace string
+	Pod       string
+	Policies  []string
+}
+
+// Covered reports whether at least one NetworkPolicy selects the pod
+func (p PodPolicyCoverage) Covered() bool {
+	return len(p.Policies) > 0
+}
+
+// NetworkPolicyCoverage matches policies against the pods in their
+// namespace. Finished pods are left out. Results are sorted by namespace and
+// pod; policies with an invalid selector select nothing.
+func NetworkPolicyCoverage(pods []corev1.Pod, policies []networkingv1.NetworkPolicy) []PodPolicyCoverage {
+	var coverage []PodPolicyCoverage
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		c := PodPolicyCoverage{Namespace: pod.Namespace, Pod: pod.Name}
+		for j := range policies {
+			policy := &policies[j]
+			if policy.Namespace != pod.Namespace {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+			if err == nil && selector.Matches(labels.Set(pod.Labels)) {
+				c.Policies = append(c.Policies, policy.Name)
+			}
+		}
+		coverage = append(coverage, c)
+	}
+	sort.SliceStable(coverage, func(i, j int) bool {
+		if coverage[i].Namespace != coverage[j].Namespace {
+			return coverage[i].Namespace < coverage[j].Namespace
+		}
+		return coverage[i].Pod < coverage[j].Pod
+	})
+	return coverage
+}
+
+// GetIngressRoutes returns the routes of the named Ingress
+func (c *Client) GetIngressRoutes(ctx context.Context, namespace, name string) ([]IngressRoute, error) {
+	ing, err := c.clientset().NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return IngressRoutes(ing), nil
+}
+
+// GetNetworkPolicyCoverage returns which NetworkPolicies select each pod in
+// namespace
+func (c *Client) GetNetworkPolicyCoverage(ctx context.Context, namespace string) ([]PodPolicyCoverage, error) {
+	pods, err := c.clientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	policies, err := c.clientset().NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return NetworkPolicyCoverage(pods.Items, policies.Items), nil
+}
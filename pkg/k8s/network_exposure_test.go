@@ -0,0 +1,120 @@
+package k8s
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestIngressRoutes(t *testing.T) {
+	prefix := networkingv1.PathTypePrefix
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{"shop.example.com"}}},
+			Rules: []networkingv1.IngressRule{
+				{Host: "shop.example.com", IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/api", PathType: &prefix, Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "api", Port: networkingv1.ServiceBackendPort{Number: 8080}}}},
+						{PathType: &prefix, Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "frontend", Port: networkingv1.ServiceBackendPort{Name: "http"}}}},
+					},
+				}}},
+				{IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/static", PathType: &prefix, Backend: networkingv1.IngressBackend{Resource: &corev1.TypedLocalObjectReference{Kind: "StorageBucket", Name: "assets"}}},
+					},
+				}}},
+			},
+			DefaultBackend: &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "fallback", Port: networkingv1.ServiceBackendPort{Number: 80}}},
+		},
+	}
+
+	want := []IngressRoute{
+		{Host: "shop.example.com", Path: "/api", Backend: "api:8080", TLS: true},
+		{Host: "shop.example.com", Path: "/", Backend: "frontend:http", TLS: true},
+		{Host: "*", Path: "/static", Backend: "StorageBucket/assets"},
+		{Host: "*", Path: IngressDefaultPath, Backend: "fallback:80"},
+	}
+	if got := IngressRoutes(ing); !slices.Equal(got, want) {
+		t.Errorf("IngressRoutes() = %+v, want %+v", got, want)
+	}
+}
+
+func testPolicy(name string, selector map[string]string) networkingv1.NetworkPolicy {
+	return networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       networkingv1.NetworkPolicySpec{PodSelector: metav1.LabelSelector{MatchLabels: selector}},
+	}
+}
+
+func testLabeledPod(name, namespace string, labels map[string]string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestNetworkPolicyCoverage(t *testing.T) {
+	pods := []corev1.Pod{
+		*testLabeledPod("web-1", "default", map[string]string{"app": "web"}, corev1.PodRunning),
+		*testLabeledPod("db-0", "default", map[string]string{"app": "db"}, corev1.PodRunning),
+		*testLabeledPod("batch-x", "default", map[string]string{"app": "batch"}, corev1.PodSucceeded),
+		*testLabeledPod("web-2", "other", map[string]string{"app": "web"}, corev1.PodRunning),
+	}
+	policies := []networkingv1.NetworkPolicy{
+		testPolicy("allow-web", map[string]string{"app": "web"}),
+		testPolicy("default-deny", nil),
+	}
+	policies[1].Namespace = "other"
+
+	got := NetworkPolicyCoverage(pods, policies)
+	want := []PodPolicyCoverage{
+		{Namespace: "default", Pod: "db-0"},
+		{Namespace: "default", Pod: "web-1", Policies: []string{"allow-web"}},
+		{Namespace: "other", Pod: "web-2", Policies: []string{"default-deny"}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("NetworkPolicyCoverage() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i].Namespace != want[i].Namespace || got[i].Pod != want[i].Pod || !slices.Equal(got[i].Policies, want[i].Policies) {
+			t.Errorf("coverage %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if got[0].Covered() || !got[1].Covered() {
+		t.Errorf("Covered() wrong for %+v", got[:2])
+	}
+}
+
+func TestPolicyPodSelector(t *testing.T) {
+	all := testPolicy("deny", nil)
+	if got := PolicyPodSelector(&all); got != "<all>" {
+		t.Errorf("PolicyPodSelector(empty) = %q, want <all>", got)
+	}
+	web := testPolicy("web", map[string]string{"tier": "front", "app": "web"})
+	if got := PolicyPodSelector(&web); got != "app=web,tier=front" {
+		t.Errorf("PolicyPodSelector() = %q, want app=web,tier=front", got)
+	}
+}
+
+func TestGetNetworkPolicyCoverage(t *testing.T) {
+	policy := testPolicy("allow-web", map[string]string{"app": "web"})
+	c := &Client{Clientset: fake.NewClientset( //nolint:staticcheck
+		testLabeledPod("web-1", "default", map[string]string{"app": "web"}, corev1.PodRunning),
+		testLabeledPod("db-0", "default", map[string]string{"app": "db"}, corev1.PodRunning),
+		&policy,
+	)}
+
+	coverage, err := c.GetNetworkPolicyCoverage(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("GetNetworkPolicyCoverage() error = %v", err)
+	}
+	if len(coverage) != 2 || coverage[0].Covered() || !coverage[1].Covered() {
+		t.Errorf("GetNetworkPolicyCoverage() = %+v, want db-0 uncovered and web-1 covered", coverage)
+	}
+}
@@ -0,0 +1,195 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// DisruptionBudgetWarning describes a PodDisruptionBudget that an action
+// would breach
+type DisruptionBudgetWarning struct {
+	Namespace string
+	Name      string
+	Reason    string
+}
+
+func (w DisruptionBudgetWarning) String() string {
+	return fmt.Sprintf("%s/%s: %s", w.Namespace, w.Name, w.Reason)
+}
+
+// pdbMatches reports whether pdb selects pods with podLabels. In policy/v1 a
+// nil selector matches no pods and an empty one matches every pod in the
+// namespace.
+func pdbMatches(pdb *policyv1.PodDisruptionBudget, podLabels map[string]string) bool {
+	if pdb.Spec.Selector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(podLabels))
+}
+
+// pdbBudget describes what pdb requires, e.g. "minAvailable 2, 3 healthy"
+func pdbBudget(pdb *policyv1.PodDisruptionBudget) string {
+	var parts []string
+	if pdb.Spec.MinAvailable != nil {
+		parts = append(parts, "minAvailable "+pdb.Spec.MinAvailable.String())
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		parts = append(parts, "maxUnavailable "+pdb.Spec.MaxUnavailable.String())
+	}
+	parts = append(parts, fmt.Sprintf("%d healthy", pdb.Status.CurrentHealthy))
+	return strings.Join(parts, ", ")
+}
+
+func pluralPods(n int32) string {
+	if n == 1 {
+		return "1 pod"
+	}
+	return fmt.Sprintf("%d pods", n)
+}
+
+func disruptionsAllowedText(n int32) string {
+	if n == 1 {
+		return "1 disruption is allowed"
+	}
+	return fmt.Sprintf("%d disruptions are allowed", n)
+}
+
+// ScaleBudgetWarnings returns the budgets in pdbs that scaling a workload
+// whose pods carry podLabels from current to desired replicas would breach,
+// i.e. the scale-down removes more pods than the budget's disruptionsAllowed.
+// Scaling is not blocked by PodDisruptionBudgets, so this is the only check.
+func ScaleBudgetWarnings(pdbs []policyv1.PodDisruptionBudget, podLabels map[string]string, current, desired int32) []DisruptionBudgetWarning {
+	removed := current - desired
+	if removed <= 0 {
+		return nil
+	}
+	var warnings []DisruptionBudgetWarning
+	for i := range pdbs {
+		pdb := &pdbs[i]
+		if !pdbMatches(pdb, podLabels) || removed <= pdb.Status.DisruptionsAllowed {
+			continue
+		}
+		warnings = append(warnings, DisruptionBudgetWarning{
+			Namespace: pdb.Namespace,
+			Name:      pdb.Name,
+			Reason: fmt.Sprintf("scaling down removes %s but only %s (%s)",
+				pluralPods(removed), disruptionsAllowedText(pdb.Status.DisruptionsAllowed), pdbBudget(pdb)),
+		})
+	}
+	return warnings
+}
+
+// drainSkipsPod reports whether a drain leaves pod on the node: DaemonSet
+// pods are recreated in place, mirror pods belong to the kubelet, and
+// finished pods hold no budget
+func drainSkipsPod(pod *corev1.Pod) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return true
+	}
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// DrainBudgetWarnings returns the budgets in pdbs that evicting the drainable
+// pods among nodePods would breach, i.e. more of a budget's pods run on the
+// node than its disruptionsAllowed
+func DrainBudgetWarnings(pdbs []policyv1.PodDisruptionBudget, nodePods []corev1.Pod) []DisruptionBudgetWarning {
+	var warnings []DisruptionBudgetWarning
+	for i := range pdbs {
+		pdb := &pdbs[i]
+		var evicted int32
+		for j := range nodePods {
+			pod := &nodePods[j]
+			if pod.Namespace == pdb.Namespace && !drainSkipsPod(pod) && pdbMatches(pdb, pod.Labels) {
+				evicted++
+			}
+		}
+		if evicted == 0 || evicted <= pdb.Status.DisruptionsAllowed {
+			continue
+		}
+		warnings = append(warnings, DisruptionBudgetWarning{
+			Namespace: pdb.Namespace,
+			Name:      pdb.Name,
+			Reason: fmt.Sprintf("draining evicts %s but only %s (%s)",
+				pluralPods(evicted), disruptionsAllowedText(pdb.Status.DisruptionsAllowed), pdbBudget(pdb)),
+		})
+	}
+	return warnings
+}
+
+// CheckScaleDisruptionBudgets returns the PodDisruptionBudgets that scaling
+// the deployment, statefulset, or replicaset to replicas would breach
+func (c *Client) CheckScaleDisruptionBudgets(ctx context.Context, kind, namespace, name string, replicas int32) ([]DisruptionBudgetWarning, error) {
+	var (
+		current   int32 = 1
+		specCount *int32
+		podLabels map[string]string
+	)
+	switch kind {
+	case "deployments", "deployment", "deploy":
+		deploy, err := c.clientset().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		specCount, podLabels = deploy.Spec.Replicas, deploy.Spec.Template.Labels
+	case "statefulsets", "statefulset", "sts":
+		sts, err := c.clientset().AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		specCount, podLabels = sts.Spec.Replicas, sts.Spec.Template.Labels
+	case "replicasets", "replicaset", "rs":
+		rs, err := c.clientset().AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		specCount, podLabels = rs.Spec.Replicas, rs.Spec.Template.Labels
+	default:
+		return nil, fmt.Errorf("cannot scale %s", kind)
+	}
+	if specCount != nil {
+		current = *specCount
+	}
+	if replicas >= current {
+		return nil, nil
+	}
+
+	pdbs, err := c.ListPodDisruptionBudgets(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return ScaleBudgetWarnings(pdbs, podLabels, current, replicas), nil
+}
+
+// CheckDrainDisruptionBudgets returns the PodDisruptionBudgets that draining
+// nodeName would breach
+func (c *Client) CheckDrainDisruptionBudgets(ctx context.Context, nodeName string) ([]DisruptionBudgetWarning, error) {
+	pods, err := c.clientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", nodeName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, nil
+	}
+	pdbs, err := c.ListPodDisruptionBudgets(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return DrainBudgetWarnings(pdbs, pods.Items), nil
+}
@@ -0,0 +1,113 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testPDB(name string, selector map[string]string, minAvailable int, allowed int32) policyv1.PodDisruptionBudget {
+	minAvail := intstr.FromInt(minAvailable)
+	return policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvail,
+			Selector:     &metav1.LabelSelector{MatchLabels: selector},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{CurrentHealthy: 3, DisruptionsAllowed: allowed},
+	}
+}
+
+func TestScaleBudgetWarnings(t *testing.T) {
+	pdbs := []policyv1.PodDisruptionBudget{
+		testPDB("web-pdb", map[string]string{"app": "web"}, 2, 1),
+		testPDB("api-pdb", map[string]string{"app": "api"}, 0, 0),
+	}
+	podLabels := map[string]string{"app": "web", "tier": "frontend"}
+
+	if got := ScaleBudgetWarnings(pdbs, podLabels, 3, 2); len(got) != 0 {
+		t.Errorf("removing one pod within budget warned: %v", got)
+	}
+	if got := ScaleBudgetWarnings(pdbs, podLabels, 3, 5); len(got) != 0 {
+		t.Errorf("scaling up warned: %v", got)
+	}
+
+	got := ScaleBudgetWarnings(pdbs, podLabels, 3, 1)
+	if len(got) != 1 || got[0].Name != "web-pdb" {
+		t.Fatalf("ScaleBudgetWarnings() = %v, want only web-pdb", got)
+	}
+	want := "default/web-pdb: scaling down removes 2 pods but only 1 disruption is allowed (minAvailable 2, 3 healthy)"
+	if got[0].String() != want {
+		t.Errorf("warning = %q, want %q", got[0].String(), want)
+	}
+
+	// A nil selector matches nothing, an empty one matches every pod
+	pdbs[0].Spec.Selector = nil
+	pdbs[1].Spec.Selector = &metav1.LabelSelector{}
+	got = ScaleBudgetWarnings(pdbs, podLabels, 3, 2)
+	if len(got) != 1 || got[0].Name != "api-pdb" {
+		t.Errorf("selector semantics: got %v, want only api-pdb", got)
+	}
+}
+
+func TestDrainBudgetWarnings(t *testing.T) {
+	pod := func(name, ns, app string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Labels: map[string]string{"app": app}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	daemon := pod("agent", "default", "web")
+	daemon.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent"}}
+	done := pod("job", "default", "web")
+	done.Status.Phase = corev1.PodSucceeded
+
+	pdbs := []policyv1.PodDisruptionBudget{testPDB("web-pdb", map[string]string{"app": "web"}, 2, 1)}
+	nodePods := []corev1.Pod{pod("web-1", "default", "web"), daemon, done, pod("web-2", "other", "web")}
+	if got := DrainBudgetWarnings(pdbs, nodePods); len(got) != 0 {
+		t.Errorf("one evictable pod within budget warned: %v", got)
+	}
+
+	nodePods = append(nodePods, pod("web-3", "default", "web"))
+	got := DrainBudgetWarnings(pdbs, nodePods)
+	if len(got) != 1 || !strings.Contains(got[0].Reason, "draining evicts 2 pods but only 1 disruption is allowed") {
+		t.Errorf("DrainBudgetWarnings() = %v, want web-pdb breached by 2 pods", got)
+	}
+}
+
+func TestCheckScaleDisruptionBudgets(t *testing.T) {
+	replicas := int32(3)
+	pdb := testPDB("web-pdb", map[string]string{"app": "web"}, 2, 1)
+	c := &Client{Clientset: fake.NewClientset( //nolint:staticcheck
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}}},
+			},
+		},
+		&pdb,
+	)}
+	ctx := context.Background()
+
+	got, err := c.CheckScaleDisruptionBudgets(ctx, "deploy", "default", "web", 0)
+	if err != nil {
+		t.Fatalf("CheckScaleDisruptionBudgets() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "web-pdb" {
+		t.Errorf("scale to 0 = %v, want web-pdb", got)
+	}
+	if got, err := c.CheckScaleDisruptionBudgets(ctx, "deployments", "default", "web", 2); err != nil || len(got) != 0 {
+		t.Errorf("scale to 2 = %v, %v; want no warnings", got, err)
+	}
+	if _, err := c.CheckScaleDisruptionBudgets(ctx, "daemonsets", "default", "web", 0); err == nil {
+		t.Error("expected an error for a kind that cannot scale")
+	}
+}
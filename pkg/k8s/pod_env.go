@@ -0,0 +1,230 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EnvVarInfo is one environment variable of a container. Variables set from
+// a ConfigMap or Secret carry the reference in Source and, once resolved, the
+// referenced value in Value.
+type EnvVarInfo struct {
+	Container string
+	Name      string
+	Value     string
+	// Source describes where a non-literal value comes from, e.g.
+	// "secret:db-creds/password" or "field:metadata.name"; "" for literals
+	Source string
+	// SecretName and SecretKey are set when the value comes from a Secret,
+	// ConfigMapName and ConfigMapKey when it comes from a ConfigMap. envFrom
+	// entries set only the name.
+	SecretName    string
+	SecretKey     string
+	ConfigMapName string
+	ConfigMapKey  string
+	// Resolved reports whether Value holds the referenced value
+	Resolved bool
+}
+
+// FromSecret reports whether the variable's value is read from a Secret
+func (e EnvVarInfo) FromSecret() bool {
+	return e.SecretName != ""
+}
+
+// VolumeMountInfo is one volume mount of a container
+type VolumeMountInfo struct {
+	Container string
+	Name      string
+	MountPath string
+	SubPath   string
+	ReadOnly  bool
+	// Source describes the backing volume, e.g. "secret:tls" or "pvc:data"
+	Source string
+}
+
+// podContainers returns the init and regular containers of pod, naming init
+// containers "<name> (init)"
+func podContainers(pod *corev1.Pod) ([]corev1.Container, []string) {
+	var (
+		containers []corev1.Container
+		names      []string
+	)
+	for _, c := range pod.Spec.InitContainers {
+		containers = append(containers, c)
+		names = append(names, c.Name+" (init)")
+	}
+	for _, c := range pod.Spec.Containers {
+		containers = append(containers, c)
+		names = append(names, c.Name)
+	}
+	return containers, names
+}
+
+func optionalSuffix(optional *bool) string {
+	if optional != nil && *optional {
+		return " (optional)"
+	}
+	return ""
+}
+
+// PodEnvVars lists the environment of every container in pod, init
+// containers first. envFrom sources appear as one "<prefix>*" entry each.
+// Referenced values are not resolved.
+func PodEnvVars(pod *corev1.Pod) []EnvVarInfo {
+	var vars []EnvVarInfo
+	containers, names := podContainers(pod)
+	for i, c := range containers {
+		for _, from := range c.EnvFrom {
+			info := EnvVarInfo{Container: names[i], Name: from.Prefix + "*"}
+			switch {
+			case from.SecretRef != nil:
+				info.Source = "envFrom secret:" + from.SecretRef.Name + optionalSuffix(from.SecretRef.Optional)
+				info.SecretName = from.SecretRef.Name
+			case from.ConfigMapRef != nil:
+				info.Source = "envFrom configmap:" + from.ConfigMapRef.Name + optionalSuffix(from.ConfigMapRef.Optional)
+				info.ConfigMapName = from.ConfigMapRef.Name
+			}
+			vars = append(vars, info)
+		}
+		for _, env := range c.Env {
+			info := EnvVarInfo{Container: names[i], Name: env.Name, Value: env.Value}
+			if from := env.ValueFrom; from != nil {
+				switch {
+				case from.SecretKeyRef != nil:
+					ref := from.SecretKeyRef
+					info.Source = fmt.Sprintf("secret:%s/%s%s", ref.Name, ref.Key, optionalSuffix(ref.Optional))
+					info.SecretName, info.SecretKey = ref.Name, ref.Key
+				case from.ConfigMapKeyRef != nil:
+					ref := from.ConfigMapKeyRef
+					info.Source = fmt.Sprintf("configmap:%s/%s%s", ref.Name, ref.Key, optionalSuffix(ref.Optional))
+					info.ConfigMapName, info.ConfigMapKey = ref.Name, ref.Key
+				case from.FieldRef != nil:
+					info.Source = "field:" + from.FieldRef.FieldPath
+				case from.ResourceFieldRef != nil:
+					info.Source = "resource:" + from.ResourceFieldRef.Resource
+				}
+			}
+			vars = append(vars, info)
+		}
+	}
+	return vars
+}
+
+// volumeSource describes the backing of a pod volume
+func volumeSource(v corev1.Volume) string {
+	switch {
+	case v.Secret != nil:
+		return "secret:" + v.Secret.SecretName
+	case v.ConfigMap != nil:
+		return "configmap:" + v.ConfigMap.Name
+	case v.PersistentVolumeClaim != nil:
+		return "pvc:" + v.PersistentVolumeClaim.ClaimName
+	case v.EmptyDir != nil:
+		if v.EmptyDir.Medium == corev1.StorageMediumMemory {
+			return "emptyDir (memory)"
+		}
+		return "emptyDir"
+	case v.HostPath != nil:
+		return "hostPath:" + v.HostPath.Path
+	case v.Projected != nil:
+		return "projected"
+	case v.DownwardAPI != nil:
+		return "downwardAPI"
+	case v.CSI != nil:
+		return "csi:" + v.CSI.Driver
+	case v.Ephemeral != nil:
+		return "ephemeral"
+	case v.NFS != nil:
+		return fmt.Sprintf("nfs:%s:%s", v.NFS.Server, v.NFS.Path)
+	}
+	return "other"
+}
+
+// PodVolumeMounts lists the volume mounts of every container in pod, init
+// containers first, with the volume each mount is backed by
+func PodVolumeMounts(pod *corev1.Pod) []VolumeMountInfo {
+	sources := make(map[string]string, len(pod.Spec.Volumes))
+	for _, v := range pod.Spec.Volumes {
+		sources[v.Name] = volumeSource(v)
+	}
+
+	var mounts []VolumeMountInfo
+	containers, names := podContainers(pod)
+	for i, c := range containers {
+		for _, m := range c.VolumeMounts {
+			source, ok := sources[m.Name]
+			if !ok {
+				source = "missing volume"
+			}
+			mounts = append(mounts, VolumeMountInfo{
+				Container: names[i],
+				Name:      m.Name,
+				MountPath: m.MountPath,
+				SubPath:   m.SubPath,
+				ReadOnly:  m.ReadOnly,
+				Source:    source,
+			})
+		}
+	}
+	return mounts
+}
+
+// GetPodEnvironment returns the environment variables and volume mounts of
+// the named pod. Values referenced from ConfigMaps are resolved where the
+// ConfigMap can be read; values from Secrets are left for ResolveSecretEnv.
+func (c *Client) GetPodEnvironment(ctx context.Context, namespace, name string) ([]EnvVarInfo, []VolumeMountInfo, error) {
+	pod, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vars := PodEnvVars(pod)
+	configMaps := map[string]map[string]string{}
+	for i, v := range vars {
+		if v.ConfigMapKey == "" {
+			continue
+		}
+		data, ok := configMaps[v.ConfigMapName]
+		if !ok {
+			// A missing or unreadable ConfigMap only leaves its values unresolved
+			if cm, err := c.clientset().CoreV1().ConfigMaps(namespace).Get(ctx, v.ConfigMapName, metav1.GetOptions{}); err == nil {
+				data = cm.Data
+			}
+			configMaps[v.ConfigMapName] = data
+		}
+		if value, ok := data[v.ConfigMapKey]; ok {
+			vars[i].Value, vars[i].Resolved = value, true
+		}
+	}
+
+	return vars, PodVolumeMounts(pod), nil
+}
+
+// ResolveSecretEnv returns a copy of vars with the values read from the
+// Secrets in namespace filled in. Only Secrets listed in allowed are read;
+// keys missing from a Secret stay unresolved.
+func (c *Client) ResolveSecretEnv(ctx context.Context, namespace string, vars []EnvVarInfo, allowed map[string]bool) ([]EnvVarInfo, error) {
+	resolved := append([]EnvVarInfo(nil), vars...)
+	secrets := map[string]map[string][]byte{}
+	for i, v := range resolved {
+		if v.SecretKey == "" || !allowed[v.SecretName] {
+			continue
+		}
+		data, ok := secrets[v.SecretName]
+		if !ok {
+			secret, err := c.clientset().CoreV1().Secrets(namespace).Get(ctx, v.SecretName, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to read secret %s: %w", v.SecretName, err)
+			}
+			data = secret.Data
+			secrets[v.SecretName] = data
+		}
+		if value, ok := data[v.SecretKey]; ok {
+			resolved[i].Value, resolved[i].Resolved = string(value), true
+		}
+	}
+	return resolved, nil
+}
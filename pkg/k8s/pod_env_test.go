@@ -0,0 +1,123 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func envTestPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				Name: "migrate",
+				Env:  []corev1.EnvVar{{Name: "MODE", Value: "up"}},
+			}},
+			Containers: []corev1.Container{{
+				Name: "app",
+				EnvFrom: []corev1.EnvFromSource{
+					{Prefix: "CFG_", ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}}},
+				},
+				Env: []corev1.EnvVar{
+					{Name: "LOG_LEVEL", Value: "debug"},
+					{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "db-creds"}, Key: "password"}}},
+					{Name: "REGION", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}, Key: "region"}}},
+					{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "tls", MountPath: "/etc/tls", ReadOnly: true},
+					{Name: "data", MountPath: "/data"},
+					{Name: "gone", MountPath: "/gone"},
+				},
+			}},
+			Volumes: []corev1.Volume{
+				{Name: "tls", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "web-tls"}}},
+				{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data"}}},
+			},
+		},
+	}
+}
+
+func TestPodEnvVars(t *testing.T) {
+	vars := PodEnvVars(envTestPod())
+	want := []struct{ container, name, source string }{
+		{"migrate (init)", "MODE", ""},
+		{"app", "CFG_*", "envFrom configmap:app-config"},
+		{"app", "LOG_LEVEL", ""},
+		{"app", "DB_PASSWORD", "secret:db-creds/password"},
+		{"app", "REGION", "configmap:app-config/region"},
+		{"app", "POD_NAME", "field:metadata.name"},
+	}
+	if len(vars) != len(want) {
+		t.Fatalf("PodEnvVars() returned %d vars, want %d: %+v", len(vars), len(want), vars)
+	}
+	for i, w := range want {
+		if vars[i].Container != w.container || vars[i].Name != w.name || vars[i].Source != w.source {
+			t.Errorf("var %d = %+v, want %+v", i, vars[i], w)
+		}
+	}
+	if !vars[3].FromSecret() || vars[3].Value != "" {
+		t.Errorf("DB_PASSWORD = %+v, want an unresolved secret reference", vars[3])
+	}
+}
+
+func TestPodVolumeMounts(t *testing.T) {
+	mounts := PodVolumeMounts(envTestPod())
+	want := map[string]string{"tls": "secret:web-tls", "data": "pvc:web-data", "gone": "missing volume"}
+	if len(mounts) != len(want) {
+		t.Fatalf("PodVolumeMounts() = %+v", mounts)
+	}
+	for _, m := range mounts {
+		if m.Source != want[m.Name] {
+			t.Errorf("mount %s source = %q, want %q", m.Name, m.Source, want[m.Name])
+		}
+	}
+	if !mounts[0].ReadOnly || mounts[1].ReadOnly {
+		t.Errorf("ReadOnly flags = %v, %v", mounts[0].ReadOnly, mounts[1].ReadOnly)
+	}
+}
+
+func TestGetPodEnvironmentAndResolveSecretEnv(t *testing.T) {
+	c := &Client{Clientset: fake.NewClientset( //nolint:staticcheck
+		envTestPod(),
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "default"}, Data: map[string]string{"region": "eu-west-1"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db-creds", Namespace: "default"}, Data: map[string][]byte{"password": []byte("s3cret")}},
+	)}
+	ctx := context.Background()
+
+	vars, mounts, err := c.GetPodEnvironment(ctx, "default", "web")
+	if err != nil {
+		t.Fatalf("GetPodEnvironment() error = %v", err)
+	}
+	if len(mounts) != 3 {
+		t.Errorf("got %d mounts, want 3", len(mounts))
+	}
+	if vars[4].Value != "eu-west-1" || !vars[4].Resolved {
+		t.Errorf("REGION = %+v, want the ConfigMap value", vars[4])
+	}
+	if vars[3].Resolved {
+		t.Error("secret values must not be resolved by GetPodEnvironment")
+	}
+
+	unrevealed, err := c.ResolveSecretEnv(ctx, "default", vars, nil)
+	if err != nil || unrevealed[3].Resolved {
+		t.Errorf("ResolveSecretEnv without allowed secrets = %+v, %v", unrevealed[3], err)
+	}
+	resolved, err := c.ResolveSecretEnv(ctx, "default", vars, map[string]bool{"db-creds": true})
+	if err != nil {
+		t.Fatalf("ResolveSecretEnv() error = %v", err)
+	}
+	if resolved[3].Value != "s3cret" || vars[3].Value != "" {
+		t.Errorf("ResolveSecretEnv() = %q, original = %q", resolved[3].Value, vars[3].Value)
+	}
+
+	if _, _, err := c.GetPodEnvironment(ctx, "default", "missing"); err == nil {
+		t.Error("expected an error for a missing pod")
+	}
+}
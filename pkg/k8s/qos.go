@@ -0,0 +1,77 @@
+package k8s
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodQOSClass returns the pod's QoS class. It uses status.qosClass when the
+// API server has set it and otherwise computes it from the container
+// requests and limits, following the kubelet rules:
+//   - BestEffort: no container sets a CPU or memory request or limit
+//   - Guaranteed: every container limits CPU and memory, and requests equal limits
+//   - Burstable: anything else
+func PodQOSClass(pod *corev1.Pod) corev1.PodQOSClass {
+	if pod.Status.QOSClass != "" {
+		return pod.Status.QOSClass
+	}
+
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+	guaranteed := true
+
+	containers := make([]corev1.Container, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	containers = append(containers, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+	for _, ctr := range containers {
+		// The API server defaults a missing request to the container's limit
+		req := ctr.Resources.Requests.DeepCopy()
+		for name, lim := range ctr.Resources.Limits {
+			if _, ok := req[name]; !ok {
+				if req == nil {
+					req = corev1.ResourceList{}
+				}
+				req[name] = lim
+			}
+		}
+		addQOSResources(requests, req)
+		limited := addQOSResources(limits, ctr.Resources.Limits)
+		if !limited[corev1.ResourceCPU] || !limited[corev1.ResourceMemory] {
+			guaranteed = false
+		}
+	}
+
+	if len(requests) == 0 && len(limits) == 0 {
+		return corev1.PodQOSBestEffort
+	}
+	if guaranteed {
+		for name, req := range requests {
+			if lim, ok := limits[name]; !ok || lim.Cmp(req) != 0 {
+				guaranteed = false
+				break
+			}
+		}
+	}
+	if guaranteed && len(requests) == len(limits) {
+		return corev1.PodQOSGuaranteed
+	}
+	return corev1.PodQOSBurstable
+}
+
+// addQOSResources adds the positive CPU and memory quantities of list to
+// total and reports which of them were set.
+func addQOSResources(total, list corev1.ResourceList) map[corev1.ResourceName]bool {
+	found := map[corev1.ResourceName]bool{}
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		q, ok := list[name]
+		if !ok || q.Sign() <= 0 {
+			continue
+		}
+		found[name] = true
+		sum := q.DeepCopy()
+		if prev, ok := total[name]; ok {
+			sum.Add(prev)
+		}
+		total[name] = sum
+	}
+	return found
+}
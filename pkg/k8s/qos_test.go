@@ -0,0 +1,96 @@
+package k8s
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestPodQOSClass(t *testing.T) {
+	resources := func(req, lim corev1.ResourceList) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: req, Limits: lim}
+	}
+	cpuMem := func(cpu, mem string) corev1.ResourceList {
+		return corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(mem),
+		}
+	}
+	podWith := func(containers ...corev1.ResourceRequirements) *corev1.Pod {
+		pod := &corev1.Pod{}
+		for _, r := range containers {
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Resources: r})
+		}
+		return pod
+	}
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want corev1.PodQOSClass
+	}{
+		{
+			name: "no resources",
+			pod:  podWith(corev1.ResourceRequirements{}),
+			want: corev1.PodQOSBestEffort,
+		},
+		{
+			name: "only non-compute resources",
+			pod: podWith(resources(corev1.ResourceList{
+				corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+			}, nil)),
+			want: corev1.PodQOSBestEffort,
+		},
+		{
+			name: "limits equal requests",
+			pod:  podWith(resources(cpuMem("500m", "256Mi"), cpuMem("500m", "256Mi"))),
+			want: corev1.PodQOSGuaranteed,
+		},
+		{
+			name: "limits only default requests",
+			pod:  podWith(resources(nil, cpuMem("1", "1Gi"))),
+			want: corev1.PodQOSGuaranteed,
+		},
+		{
+			name: "requests below limits",
+			pod:  podWith(resources(cpuMem("250m", "256Mi"), cpuMem("500m", "256Mi"))),
+			want: corev1.PodQOSBurstable,
+		},
+		{
+			name: "requests only",
+			pod:  podWith(resources(cpuMem("250m", "256Mi"), nil)),
+			want: corev1.PodQOSBurstable,
+		},
+		{
+			name: "one container without limits",
+			pod: podWith(
+				resources(cpuMem("500m", "256Mi"), cpuMem("500m", "256Mi")),
+				corev1.ResourceRequirements{},
+			),
+			want: corev1.PodQOSBurstable,
+		},
+		{
+			name: "missing memory limit",
+			pod: podWith(resources(nil, corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			})),
+			want: corev1.PodQOSBurstable,
+		},
+		{
+			name: "status wins",
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{QOSClass: corev1.PodQOSGuaranteed},
+			},
+			want: corev1.PodQOSGuaranteed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PodQOSClass(tt.pod); got != tt.want {
+				t.Errorf("PodQOSClass() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
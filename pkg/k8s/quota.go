@@ -0,0 +1,121 @@
+package k8s
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// QuotaNearLimitPercent is the usage percentage at which a ResourceQuota
+// resource is reported as close to exhaustion.
+const QuotaNearLimitPercent = 90.0
+
+// Quota status values returned by QuotaStatus.
+const (
+	QuotaStatusOK        = "OK"
+	QuotaStatusNearLimit = "NearLimit"
+	QuotaStatusExceeded  = "Exceeded"
+)
+
+// QuotaUsage is a single resource line of a ResourceQuota: hard vs used.
+type QuotaUsage struct {
+	Resource string  `json:"resource"`
+	Used     string  `json:"used"`
+	Hard     string  `json:"hard"`
+	Percent  float64 `json:"percent"`
+}
+
+// LimitRangeLimit is the min/max/default set for one resource of one
+// LimitRange item type (Container, Pod, PersistentVolumeClaim).
+type LimitRangeLimit struct {
+	Type           string `json:"type"`
+	Resource       string `json:"resource"`
+	Min            string `json:"min,omitempty"`
+	Max            string `json:"max,omitempty"`
+	Default        string `json:"default,omitempty"`
+	DefaultRequest string `json:"default_request,omitempty"`
+}
+
+// ResourceQuotaUsage returns hard vs used for every resource in the quota's
+// status, sorted by resource name. Resources without a usage entry count as 0.
+func ResourceQuotaUsage(rq corev1.ResourceQuota) []QuotaUsage {
+	usage := make([]QuotaUsage, 0, len(rq.Status.Hard))
+	for name, hard := range rq.Status.Hard {
+		used := rq.Status.Used[name]
+		u := QuotaUsage{
+			Resource: string(name),
+			Used:     used.String(),
+			Hard:     hard.String(),
+		}
+		if h := hard.AsApproximateFloat64(); h > 0 {
+			u.Percent = used.AsApproximateFloat64() / h * 100
+		} else if !used.IsZero() {
+			u.Percent = 100
+		}
+		usage = append(usage, u)
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Resource < usage[j].Resource })
+	return usage
+}
+
+// MaxQuotaPercent returns the highest usage percentage across all resources.
+func MaxQuotaPercent(usage []QuotaUsage) float64 {
+	maxPct := 0.0
+	for _, u := range usage {
+		if u.Percent > maxPct {
+			maxPct = u.Percent
+		}
+	}
+	return maxPct
+}
+
+// QuotaStatus classifies a usage percentage as OK, NearLimit, or Exceeded.
+func QuotaStatus(percent float64) string {
+	switch {
+	case percent >= 100:
+		return QuotaStatusExceeded
+	case percent >= QuotaNearLimitPercent:
+		return QuotaStatusNearLimit
+	default:
+		return QuotaStatusOK
+	}
+}
+
+// LimitRangeLimits flattens a LimitRange into one entry per (type, resource),
+// sorted by type and then resource name.
+func LimitRangeLimits(lr corev1.LimitRange) []LimitRangeLimit {
+	var limits []LimitRangeLimit
+	for _, item := range lr.Spec.Limits {
+		resources := map[corev1.ResourceName]*LimitRangeLimit{}
+		get := func(name corev1.ResourceName) *LimitRangeLimit {
+			if l, ok := resources[name]; ok {
+				return l
+			}
+			l := &LimitRangeLimit{Type: string(item.Type), Resource: string(name)}
+			resources[name] = l
+			return l
+		}
+		for name, q := range item.Min {
+			get(name).Min = q.String()
+		}
+		for name, q := range item.Max {
+			get(name).Max = q.String()
+		}
+		for name, q := range item.Default {
+			get(name).Default = q.String()
+		}
+		for name, q := range item.DefaultRequest {
+			get(name).DefaultRequest = q.String()
+		}
+		for _, l := range resources {
+			limits = append(limits, *l)
+		}
+	}
+	sort.Slice(limits, func(i, j int) bool {
+		if limits[i].Type != limits[j].Type {
+			return limits[i].Type < limits[j].Type
+		}
+		return limits[i].Resource < limits[j].Resource
+	})
+	return limits
+}
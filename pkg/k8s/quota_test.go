@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestResourceQuotaUsage(t *testing.T) {
+	rq := corev1.ResourceQuota{
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{
+				corev1.ResourceRequestsCPU:    resource.MustParse("4"),
+				corev1.ResourceRequestsMemory: resource.MustParse("8Gi"),
+				corev1.ResourcePods:           resource.MustParse("10"),
+			},
+			Used: corev1.ResourceList{
+				corev1.ResourceRequestsCPU:    resource.MustParse("3800m"),
+				corev1.ResourceRequestsMemory: resource.MustParse("2Gi"),
+			},
+		},
+	}
+
+	usage := ResourceQuotaUsage(rq)
+	if len(usage) != 3 {
+		t.Fatalf("len(usage) = %d, want 3", len(usage))
+	}
+	if usage[0].Resource != "pods" || usage[1].Resource != "requests.cpu" || usage[2].Resource != "requests.memory" {
+		t.Errorf("usage not sorted by resource: %+v", usage)
+	}
+	if usage[0].Used != "0" || usage[0].Percent != 0 {
+		t.Errorf("pods usage = %+v, want 0 used", usage[0])
+	}
+	if usage[1].Percent != 95 {
+		t.Errorf("requests.cpu percent = %v, want 95", usage[1].Percent)
+	}
+	if got := MaxQuotaPercent(usage); got != 95 {
+		t.Errorf("MaxQuotaPercent() = %v, want 95", got)
+	}
+}
+
+func TestQuotaStatus(t *testing.T) {
+	tests := []struct {
+		percent float64
+		want    string
+	}{
+		{0, QuotaStatusOK},
+		{89.9, QuotaStatusOK},
+		{90, QuotaStatusNearLimit},
+		{99.9, QuotaStatusNearLimit},
+		{100, QuotaStatusExceeded},
+		{150, QuotaStatusExceeded},
+	}
+	for _, tt := range tests {
+		if got := QuotaStatus(tt.percent); got != tt.want {
+			t.Errorf("QuotaStatus(%v) = %q, want %q", tt.percent, got, tt.want)
+		}
+	}
+}
+
+func TestLimitRangeLimits(t *testing.T) {
+	lr := corev1.LimitRange{
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{
+				{
+					Type:           corev1.LimitTypeContainer,
+					Default:        corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("512Mi")},
+					DefaultRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+					Max:            corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+				{
+					Type: corev1.LimitTypePod,
+					Max:  corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+				},
+			},
+		},
+	}
+
+	limits := LimitRangeLimits(lr)
+	if len(limits) != 3 {
+		t.Fatalf("len(limits) = %d, want 3: %+v", len(limits), limits)
+	}
+	cpu := limits[0]
+	if cpu.Type != "Container" || cpu.Resource != "cpu" || cpu.Default != "500m" || cpu.DefaultRequest != "100m" || cpu.Max != "2" {
+		t.Errorf("container cpu limit = %+v", cpu)
+	}
+	if limits[1].Resource != "memory" || limits[1].Default != "512Mi" {
+		t.Errorf("container memory limit = %+v", limits[1])
+	}
+	if limits[2].Type != "Pod" || limits[2].Max != "4Gi" {
+		t.Errorf("pod memory limit = %+v", limits[2])
+	}
+}
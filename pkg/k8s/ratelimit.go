@@ -0,0 +1,66 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/client-go/rest"
+)
+
+// Defaults for ClientOptions rate limiting. client-go's own defaults (5 QPS,
+// burst 10) throttle report generation and multi-namespace listing on large
+// clusters.
+const (
+	DefaultQPS            float32 = 50
+	DefaultBurst                  = 100
+	DefaultMaxConcurrency         = 8
+)
+
+// applyRateLimits sets QPS and Burst on config from the options, using the
+// defaults for unset values.
+func (o ClientOptions) applyRateLimits(config *rest.Config) {
+	config.QPS = o.QPS
+	if config.QPS <= 0 {
+		config.QPS = DefaultQPS
+	}
+	config.Burst = o.Burst
+	if config.Burst <= 0 {
+		config.Burst = DefaultBurst
+	}
+}
+
+// MaxConcurrency returns how many namespaces ForEachNamespace queries at once
+func (c *Client) MaxConcurrency() int {
+	if c.opts.MaxConcurrency > 0 {
+		return c.opts.MaxConcurrency
+	}
+	return DefaultMaxConcurrency
+}
+
+// ForEachNamespace calls fn for every namespace with at most MaxConcurrency
+// calls in flight, so bulk listing across many namespaces neither runs one
+// namespace at a time nor floods the API server. It returns when every call
+// has finished; calls not yet started when ctx is cancelled are skipped.
+func (c *Client) ForEachNamespace(ctx context.Context, namespaces []string, fn func(ctx context.Context, namespace string)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, c.MaxConcurrency())
+
+	for _, ns := range namespaces {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		// Both cases can be ready at once; re-check so cancellation wins
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(ctx, ns)
+		}()
+	}
+	wg.Wait()
+}
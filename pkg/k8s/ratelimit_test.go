@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestClientOptionsFromEnv_RateLimits(t *testing.T) {
+	t.Setenv(EnvQPS, "25.5")
+	t.Setenv(EnvBurst, "40")
+	t.Setenv(EnvMaxConcurrency, "3")
+
+	opts := ClientOptionsFromEnv()
+	if opts.QPS != 25.5 || opts.Burst != 40 || opts.MaxConcurrency != 3 {
+		t.Errorf("got QPS=%v Burst=%d MaxConcurrency=%d, want 25.5/40/3", opts.QPS, opts.Burst, opts.MaxConcurrency)
+	}
+
+	t.Setenv(EnvQPS, "-1")
+	t.Setenv(EnvBurst, "lots")
+	opts = ClientOptionsFromEnv()
+	if opts.QPS != 0 || opts.Burst != 0 {
+		t.Errorf("invalid values should be ignored, got QPS=%v Burst=%d", opts.QPS, opts.Burst)
+	}
+}
+
+func TestApplyRateLimits(t *testing.T) {
+	config := &rest.Config{}
+	ClientOptions{}.applyRateLimits(config)
+	if config.QPS != DefaultQPS || config.Burst != DefaultBurst {
+		t.Errorf("defaults: got QPS=%v Burst=%d", config.QPS, config.Burst)
+	}
+
+	ClientOptions{QPS: 7, Burst: 9}.applyRateLimits(config)
+	if config.QPS != 7 || config.Burst != 9 {
+		t.Errorf("explicit: got QPS=%v Burst=%d, want 7/9", config.QPS, config.Burst)
+	}
+}
+
+func TestNewClientWithOptions_AppliesRateLimits(t *testing.T) {
+	stubInCluster(t, false)
+	path := writeTestKubeconfig(t, t.TempDir(), "config", "https://kube.example")
+
+	c, err := NewClientWithOptions(ClientOptions{Kubeconfig: path, QPS: 12, Burst: 34})
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	if c.Config.QPS != 12 || c.Config.Burst != 34 {
+		t.Errorf("rest config QPS=%v Burst=%d, want 12/34", c.Config.QPS, c.Config.Burst)
+	}
+}
+
+func TestForEachNamespace_BoundsConcurrency(t *testing.T) {
+	c := &Client{opts: ClientOptions{MaxConcurrency: 2}}
+	namespaces := []string{"a", "b", "c", "d", "e", "f"}
+
+	var inFlight, peak int32
+	var mu sync.Mutex
+	var seen []string
+	c.ForEachNamespace(context.Background(), namespaces, func(ctx context.Context, ns string) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+
+		mu.Lock()
+		seen = append(seen, ns)
+		mu.Unlock()
+	})
+
+	if peak > 2 {
+		t.Errorf("peak concurrency = %d, want <= 2", peak)
+	}
+	sort.Strings(seen)
+	if len(seen) != len(namespaces) {
+		t.Fatalf("visited %v, want all of %v", seen, namespaces)
+	}
+}
+
+func TestForEachNamespace_StopsOnCancel(t *testing.T) {
+	c := &Client{opts: ClientOptions{MaxConcurrency: 1}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls int32
+	c.ForEachNamespace(ctx, []string{"a", "b", "c"}, func(ctx context.Context, ns string) {
+		atomic.AddInt32(&calls, 1)
+		cancel()
+	})
+
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 after cancel", calls)
+	}
+}
+
+func TestMaxConcurrency_Default(t *testing.T) {
+	if got := (&Client{}).MaxConcurrency(); got != DefaultMaxConcurrency {
+		t.Errorf("MaxConcurrency() = %d, want %d", got, DefaultMaxConcurrency)
+	}
+}
@@ -0,0 +1,139 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ListSelector narrows List and Watch calls to objects matching a label
+// selector, a field selector, or both. The zero value matches everything.
+type ListSelector struct {
+	Label string
+	Field string
+}
+
+// IsZero reports whether the selector matches everything
+func (s ListSelector) IsZero() bool {
+	return s.Label == "" && s.Field == ""
+}
+
+// String joins the label and field parts the way they were typed
+func (s ListSelector) String() string {
+	switch {
+	case s.Label != "" && s.Field != "":
+		return s.Label + "," + s.Field
+	case s.Field != "":
+		return s.Field
+	}
+	return s.Label
+}
+
+// fieldSelectorPrefixes mark a selector term as a field selector. Anything
+// else is a label selector term.
+var fieldSelectorPrefixes = []string{"metadata.", "spec.", "status.", "involvedObject.", "source."}
+
+// ParseListSelector parses filter text such as "app=nginx",
+// "tier in (web,api),env!=dev", or "status.phase=Running,spec.nodeName=node-1"
+// into a label and field selector. Terms on metadata., spec., status.,
+// involvedObject., and source. paths form the field selector.
+//
+// Unless labelsOnly is set, text is only treated as a selector when at least
+// one term compares a value (=, ==, !=, in, notin), so plain words stay
+// substring filters. It reports false when text is not a valid selector.
+func ParseListSelector(text string, labelsOnly bool) (ListSelector, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ListSelector{}, false
+	}
+
+	var labelTerms, fieldTerms []string
+	compares := false
+	for _, term := range splitSelectorTerms(text) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			return ListSelector{}, false
+		}
+		key := term
+		if i := strings.IndexAny(term, "!= "); i >= 0 {
+			key = term[:i]
+			compares = compares || strings.ContainsAny(term[i:], "=") ||
+				strings.Contains(term[i:], " in ") || strings.Contains(term[i:], " notin ")
+		}
+		if !labelsOnly && isFieldSelectorKey(key) {
+			fieldTerms = append(fieldTerms, term)
+		} else {
+			labelTerms = append(labelTerms, term)
+		}
+	}
+	if !labelsOnly && !compares {
+		return ListSelector{}, false
+	}
+
+	var sel ListSelector
+	if len(labelTerms) > 0 {
+		parsed, err := labels.Parse(strings.Join(labelTerms, ","))
+		if err != nil {
+			return ListSelector{}, false
+		}
+		sel.Label = parsed.String()
+	}
+	if len(fieldTerms) > 0 {
+		parsed, err := fields.ParseSelector(strings.Join(fieldTerms, ","))
+		if err != nil {
+			return ListSelector{}, false
+		}
+		sel.Field = parsed.String()
+	}
+	return sel, true
+}
+
+// splitSelectorTerms splits on commas outside the parentheses of set-based
+// terms like "tier in (web,api)".
+func splitSelectorTerms(text string) []string {
+	var terms []string
+	depth, start := 0, 0
+	for i, r := range text {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, text[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(terms, text[start:])
+}
+
+func isFieldSelectorKey(key string) bool {
+	for _, prefix := range fieldSelectorPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type listSelectorKey struct{}
+
+// WithListSelector returns a context whose List and Watch calls on this
+// client only return objects matching sel.
+func WithListSelector(ctx context.Context, sel ListSelector) context.Context {
+	if sel.IsZero() {
+		return ctx
+	}
+	return context.WithValue(ctx, listSelectorKey{}, sel)
+}
+
+// listOptions returns the list options for ctx, carrying its selector
+func listOptions(ctx context.Context) metav1.ListOptions {
+	sel, _ := ctx.Value(listSelectorKey{}).(ListSelector)
+	return metav1.ListOptions{LabelSelector: sel.Label, FieldSelector: sel.Field}
+}
@@ -0,0 +1,75 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseListSelector(t *testing.T) {
+	tests := []struct {
+		text       string
+		labelsOnly bool
+		want       ListSelector
+		wantOK     bool
+	}{
+		{text: "app=nginx", want: ListSelector{Label: "app=nginx"}, wantOK: true},
+		{text: "app.kubernetes.io/name==web", want: ListSelector{Label: "app.kubernetes.io/name==web"}, wantOK: true},
+		{text: "tier in (web,api),env!=dev", want: ListSelector{Label: "env!=dev,tier in (api,web)"}, wantOK: true},
+		{text: "status.phase=Running", want: ListSelector{Field: "status.phase=Running"}, wantOK: true},
+		{text: "app=nginx,spec.nodeName=node-1", want: ListSelector{Label: "app=nginx", Field: "spec.nodeName=node-1"}, wantOK: true},
+		{text: "tier", labelsOnly: true, want: ListSelector{Label: "tier"}, wantOK: true},
+
+		// Plain words and invalid syntax stay substring filters
+		{text: "nginx"},
+		{text: "!canary"},
+		{text: "10.0.0.1"},
+		{text: "app=nginx web"},
+		{text: "tier in (web"},
+		{text: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := ParseListSelector(tt.text, tt.labelsOnly)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseListSelector(%q) ok = %v, want %v", tt.text, ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("ParseListSelector(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListPodsHonorsContextSelector(t *testing.T) {
+	c := &Client{Clientset: fake.NewClientset( //nolint:staticcheck
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+	)}
+
+	all, err := c.ListPods(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("ListPods() error = %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("ListPods() without selector returned %d pods, want 2", len(all))
+	}
+
+	ctx := WithListSelector(context.Background(), ListSelector{Label: "app=web"})
+	pods, err := c.ListPods(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListPods() error = %v", err)
+	}
+	if len(pods) != 1 || pods[0].Name != "web-1" {
+		t.Errorf("ListPods() with app=web = %v, want only web-1", pods)
+	}
+
+	opts := listOptions(WithListSelector(context.Background(), ListSelector{Label: "app=web", Field: "status.phase=Running"}))
+	if opts.LabelSelector != "app=web" || opts.FieldSelector != "status.phase=Running" {
+		t.Errorf("listOptions() = %+v, want both selectors", opts)
+	}
+}
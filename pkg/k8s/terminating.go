@@ -0,0 +1,39 @@
+package k8s
+
+import (
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodStuckTerminating reports whether pod was deleted and is still present
+// after its grace period, and by how long it has overrun. The API server sets
+// deletionTimestamp to the deletion time plus the grace period, so a pod
+// listed past it is usually held by a finalizer or an unreachable node.
+func PodStuckTerminating(pod *corev1.Pod, now time.Time) (time.Duration, bool) {
+	if pod.DeletionTimestamp == nil {
+		return 0, false
+	}
+	overrun := now.Sub(pod.DeletionTimestamp.Time)
+	return overrun, overrun > 0
+}
+
+// PodTerminatingStatus returns "Terminating (stuck 5m)" for a pod stuck past
+// its grace period, "Terminating" for one still within it, and "" for a pod
+// that has not been deleted
+func PodTerminatingStatus(pod *corev1.Pod, now time.Time) string {
+	if pod.DeletionTimestamp == nil {
+		return ""
+	}
+	if overrun, stuck := PodStuckTerminating(pod, now); stuck {
+		return "Terminating (stuck " + FormatAge(overrun) + ")"
+	}
+	return "Terminating"
+}
+
+// IsStuckTerminatingStatus reports whether status was rendered by
+// PodTerminatingStatus for a stuck pod
+func IsStuckTerminatingStatus(status string) bool {
+	return strings.HasPrefix(status, "Terminating (stuck")
+}
@@ -0,0 +1,43 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodTerminatingStatus(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	deletedAt := func(t time.Time) *corev1.Pod {
+		ts := metav1.NewTime(t)
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &ts}}
+	}
+
+	tests := []struct {
+		name  string
+		pod   *corev1.Pod
+		want  string
+		stuck bool
+	}{
+		{"not deleted", &corev1.Pod{}, "", false},
+		{"within grace period", deletedAt(now.Add(20 * time.Second)), "Terminating", false},
+		{"past grace period", deletedAt(now.Add(-7 * time.Minute)), "Terminating (stuck 7m)", true},
+		{"days past grace period", deletedAt(now.Add(-50 * time.Hour)), "Terminating (stuck 2d)", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PodTerminatingStatus(tt.pod, now)
+			if got != tt.want {
+				t.Errorf("PodTerminatingStatus() = %q, want %q", got, tt.want)
+			}
+			if _, stuck := PodStuckTerminating(tt.pod, now); stuck != tt.stuck {
+				t.Errorf("PodStuckTerminating() stuck = %v, want %v", stuck, tt.stuck)
+			}
+			if IsStuckTerminatingStatus(got) != tt.stuck {
+				t.Errorf("IsStuckTerminatingStatus(%q) = %v, want %v", got, !tt.stuck, tt.stuck)
+			}
+		})
+	}
+}
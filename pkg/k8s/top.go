@@ -0,0 +1,119 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsapi "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// PodUsage is one pod's measured CPU and memory from metrics-server
+type PodUsage struct {
+	Namespace string
+	Name      string
+	Node      string // empty when the pod is no longer listed
+	CPUMilli  int64
+	MemMB     int64
+}
+
+// NodeUsage is one node's measured CPU and memory with a rollup of the pod
+// usage measured on it
+type NodeUsage struct {
+	Name string
+
+	CPUMilli            int64
+	CPUAllocatableMilli int64
+	MemMB               int64
+	MemAllocatableMB    int64
+	UsageAvailable      bool // CPUMilli and MemMB come from metrics-server
+
+	Pods        int // pods with metrics bound to the node
+	PodCPUMilli int64
+	PodMemMB    int64
+}
+
+// TopUsage is a kubectl top style snapshot of the whole cluster
+type TopUsage struct {
+	Pods  []PodUsage
+	Nodes []NodeUsage
+}
+
+// GetTopUsage returns the measured usage of every pod in all namespaces and
+// every node. It needs metrics-server.
+func (c *Client) GetTopUsage(ctx context.Context) (*TopUsage, error) {
+	if c.Metrics == nil {
+		return nil, fmt.Errorf("metrics client not initialized")
+	}
+	podMetrics, err := c.metricsClient().PodMetricses("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.clientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := c.clientset().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	nodeUsage, err := c.GetNodeMetrics(ctx)
+	if err != nil {
+		nodeUsage = nil
+	}
+	usage := ComputeTopUsage(podMetrics.Items, pods.Items, nodes.Items, nodeUsage)
+	return &usage, nil
+}
+
+// ComputeTopUsage sums container usage per pod, places each pod on its node
+// and rolls the pod usage up per node. nodeUsage maps node names to
+// [CPU millicores, memory MB] from GetNodeMetrics and may be nil. Pods and
+// nodes are sorted by name.
+func ComputeTopUsage(podMetrics []metricsapi.PodMetrics, pods []corev1.Pod, nodes []corev1.Node, nodeUsage map[string][]int64) TopUsage {
+	nodeOf := make(map[string]string, len(pods))
+	for _, pod := range pods {
+		nodeOf[pod.Namespace+"/"+pod.Name] = pod.Spec.NodeName
+	}
+
+	result := TopUsage{
+		Pods:  make([]PodUsage, 0, len(podMetrics)),
+		Nodes: make([]NodeUsage, len(nodes)),
+	}
+	byNode := make(map[string]*NodeUsage, len(nodes))
+	for i, node := range nodes {
+		nu := &result.Nodes[i]
+		nu.Name = node.Name
+		nu.CPUAllocatableMilli = node.Status.Allocatable.Cpu().MilliValue()
+		nu.MemAllocatableMB = node.Status.Allocatable.Memory().Value() / 1024 / 1024
+		if metric, ok := nodeUsage[node.Name]; ok && len(metric) >= 2 {
+			nu.UsageAvailable = true
+			nu.CPUMilli, nu.MemMB = metric[0], metric[1]
+		}
+		byNode[node.Name] = nu
+	}
+
+	for _, pm := range podMetrics {
+		pu := PodUsage{Namespace: pm.Namespace, Name: pm.Name, Node: nodeOf[pm.Namespace+"/"+pm.Name]}
+		for _, container := range pm.Containers {
+			pu.CPUMilli += container.Usage.Cpu().MilliValue()
+			pu.MemMB += container.Usage.Memory().Value() / 1024 / 1024
+		}
+		result.Pods = append(result.Pods, pu)
+		if nu, ok := byNode[pu.Node]; ok {
+			nu.Pods++
+			nu.PodCPUMilli += pu.CPUMilli
+			nu.PodMemMB += pu.MemMB
+		}
+	}
+
+	sort.Slice(result.Pods, func(i, j int) bool {
+		if result.Pods[i].Namespace != result.Pods[j].Namespace {
+			return result.Pods[i].Namespace < result.Pods[j].Namespace
+		}
+		return result.Pods[i].Name < result.Pods[j].Name
+	})
+	sort.Slice(result.Nodes, func(i, j int) bool { return result.Nodes[i].Name < result.Nodes[j].Name })
+	return result
+}
@@ -0,0 +1,61 @@
+package k8s
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsapi "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+func topPodMetrics(namespace, name string, containers ...corev1.ResourceList) metricsapi.PodMetrics {
+	pm := metricsapi.PodMetrics{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	for _, usage := range containers {
+		pm.Containers = append(pm.Containers, metricsapi.ContainerMetrics{Usage: usage})
+	}
+	return pm
+}
+
+func TestComputeTopUsage(t *testing.T) {
+	nodes := []corev1.Node{*capacityNode("node-b", "4", "8Gi"), *capacityNode("node-a", "2", "4Gi")}
+	apiDefault := capacityPod("api", "node-a", corev1.PodRunning, nil, nil)
+	apiProd := capacityPod("api", "node-b", corev1.PodRunning, nil, nil)
+	apiProd.Namespace = "prod"
+	pods := []corev1.Pod{*apiDefault, *apiProd}
+	podMetrics := []metricsapi.PodMetrics{
+		topPodMetrics("prod", "api", cpuMem("500m", "512Mi"), cpuMem("250m", "256Mi")),
+		topPodMetrics("default", "api", cpuMem("100m", "128Mi")),
+		topPodMetrics("default", "gone", cpuMem("50m", "64Mi")),
+	}
+
+	got := ComputeTopUsage(podMetrics, pods, nodes, map[string][]int64{"node-a": {900, 2048}})
+
+	// Same-named pods in different namespaces stay apart
+	if len(got.Pods) != 3 {
+		t.Fatalf("Pods = %+v, want 3", got.Pods)
+	}
+	want := []PodUsage{
+		{Namespace: "default", Name: "api", Node: "node-a", CPUMilli: 100, MemMB: 128},
+		{Namespace: "default", Name: "gone", CPUMilli: 50, MemMB: 64},
+		{Namespace: "prod", Name: "api", Node: "node-b", CPUMilli: 750, MemMB: 768},
+	}
+	for i := range want {
+		if got.Pods[i] != want[i] {
+			t.Errorf("Pods[%d] = %+v, want %+v", i, got.Pods[i], want[i])
+		}
+	}
+
+	if len(got.Nodes) != 2 || got.Nodes[0].Name != "node-a" {
+		t.Fatalf("Nodes = %+v, want node-a and node-b sorted", got.Nodes)
+	}
+	a, b := got.Nodes[0], got.Nodes[1]
+	if !a.UsageAvailable || a.CPUMilli != 900 || a.MemMB != 2048 || a.CPUAllocatableMilli != 2000 || a.MemAllocatableMB != 4096 {
+		t.Errorf("node-a = %+v, want measured 900m/2048MB of 2000m/4096MB", a)
+	}
+	if a.Pods != 1 || a.PodCPUMilli != 100 || a.PodMemMB != 128 {
+		t.Errorf("node-a rollup = %d pods %dm/%dMB, want 1 pod 100m/128MB", a.Pods, a.PodCPUMilli, a.PodMemMB)
+	}
+	if b.UsageAvailable || b.Pods != 1 || b.PodCPUMilli != 750 || b.PodMemMB != 768 {
+		t.Errorf("node-b = %+v, want no node metrics and a 750m/768MB rollup", b)
+	}
+}
@@ -0,0 +1,156 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClaimStatusNotFound is the status of a claim a pod refers to that does not
+// exist
+const ClaimStatusNotFound = "NotFound"
+
+// ClaimIssueOrphaned marks a bound claim that no pod uses
+const ClaimIssueOrphaned = "Orphaned"
+
+// PodVolumeClaim is a PersistentVolumeClaim used by one of a pod's volumes
+type PodVolumeClaim struct {
+	Volume string
+	Claim  string
+	// Ephemeral is set for claims created from a generic ephemeral volume
+	Ephemeral    bool
+	Status       string // Claim phase, or ClaimStatusNotFound
+	Capacity     string
+	AccessModes  string
+	StorageClass string
+	// PersistentVolume is the name of the bound PersistentVolume
+	PersistentVolume string
+}
+
+// PodClaimRefs returns the claims pod's volumes refer to, in volume order.
+// Generic ephemeral volumes use the claim "<pod>-<volume>" Kubernetes creates
+// for them. Only Volume, Claim, and Ephemeral are set.
+func PodClaimRefs(pod *corev1.Pod) []PodVolumeClaim {
+	var refs []PodVolumeClaim
+	for _, v := range pod.Spec.Volumes {
+		switch {
+		case v.PersistentVolumeClaim != nil:
+			refs = append(refs, PodVolumeClaim{Volume: v.Name, Claim: v.PersistentVolumeClaim.ClaimName})
+		case v.Ephemeral != nil:
+			refs = append(refs, PodVolumeClaim{Volume: v.Name, Claim: pod.Name + "-" + v.Name, Ephemeral: true})
+		}
+	}
+	return refs
+}
+
+// fillClaimStatus copies the status, capacity, and binding of pvc into ref
+func fillClaimStatus(ref *PodVolumeClaim, pvc *corev1.PersistentVolumeClaim) {
+	ref.Status = string(pvc.Status.Phase)
+	if storage, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		ref.Capacity = storage.String()
+	} else if storage, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		// Pending claims have no capacity yet; show what they ask for
+		ref.Capacity = storage.String()
+	}
+	ref.AccessModes = ClaimAccessModes(pvc)
+	if pvc.Spec.StorageClassName != nil {
+		ref.StorageClass = *pvc.Spec.StorageClassName
+	}
+	ref.PersistentVolume = pvc.Spec.VolumeName
+}
+
+// ClaimAccessModes returns the short access modes of pvc, e.g. "RWO,ROX"
+func ClaimAccessModes(pvc *corev1.PersistentVolumeClaim) string {
+	modes := pvc.Status.AccessModes
+	if len(modes) == 0 {
+		modes = pvc.Spec.AccessModes
+	}
+	short := make([]string, 0, len(modes))
+	for _, m := range modes {
+		switch m {
+		case corev1.ReadWriteOnce:
+			short = append(short, "RWO")
+		case corev1.ReadOnlyMany:
+			short = append(short, "ROX")
+		case corev1.ReadWriteMany:
+			short = append(short, "RWX")
+		case corev1.ReadWriteOncePod:
+			short = append(short, "RWOP")
+		default:
+			short = append(short, string(m))
+		}
+	}
+	return strings.Join(short, ",")
+}
+
+// GetPodVolumeClaims returns the claims used by the named pod with their
+// status, capacity, and bound PersistentVolume. Claims that do not exist are
+// reported with ClaimStatusNotFound.
+func (c *Client) GetPodVolumeClaims(ctx context.Context, namespace, name string) ([]PodVolumeClaim, error) {
+	pod, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	claims := PodClaimRefs(pod)
+	for i := range claims {
+		pvc, err := c.clientset().CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claims[i].Claim, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			claims[i].Status = ClaimStatusNotFound
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		fillClaimStatus(&claims[i], pvc)
+	}
+	return claims, nil
+}
+
+// VolumeClaimIssue is a claim worth a look: one that is not Bound, or a
+// bound claim that no pod uses
+type VolumeClaimIssue struct {
+	Namespace string
+	PodVolumeClaim
+	// Issue is the claim phase (Pending, Lost) or ClaimIssueOrphaned
+	Issue string
+}
+
+// VolumeClaimIssues returns the claims in pvcs that are Pending or Lost, and
+// the bound claims none of pods refers to, sorted by namespace and claim.
+// pods must cover every namespace of pvcs.
+func VolumeClaimIssues(pvcs []corev1.PersistentVolumeClaim, pods []corev1.Pod) []VolumeClaimIssue {
+	used := make(map[string]bool)
+	for i := range pods {
+		for _, ref := range PodClaimRefs(&pods[i]) {
+			used[pods[i].Namespace+"/"+ref.Claim] = true
+		}
+	}
+
+	var issues []VolumeClaimIssue
+	for i := range pvcs {
+		pvc := &pvcs[i]
+		issue := VolumeClaimIssue{Namespace: pvc.Namespace, PodVolumeClaim: PodVolumeClaim{Claim: pvc.Name}}
+		fillClaimStatus(&issue.PodVolumeClaim, pvc)
+		switch {
+		case pvc.Status.Phase == corev1.ClaimPending || pvc.Status.Phase == corev1.ClaimLost:
+			issue.Issue = string(pvc.Status.Phase)
+		case !used[pvc.Namespace+"/"+pvc.Name]:
+			issue.Issue = ClaimIssueOrphaned
+		default:
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Namespace != issues[j].Namespace {
+			return issues[i].Namespace < issues[j].Namespace
+		}
+		return issues[i].Claim < issues[j].Claim
+	})
+	return issues
+}
@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testClaim(name string, phase corev1.PersistentVolumeClaimPhase, pv string) *corev1.PersistentVolumeClaim {
+	fast := "fast"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: &fast,
+			VolumeName:       pv,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+	if phase == corev1.ClaimBound {
+		pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}
+	}
+	return pvc
+}
+
+func claimTestPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+				{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-db-0"}}},
+				{Name: "scratch", VolumeSource: corev1.VolumeSource{Ephemeral: &corev1.EphemeralVolumeSource{}}},
+				{Name: "gone", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "missing"}}},
+			},
+		},
+	}
+}
+
+func TestGetPodVolumeClaims(t *testing.T) {
+	c := &Client{Clientset: fake.NewClientset( //nolint:staticcheck
+		claimTestPod(),
+		testClaim("data-db-0", corev1.ClaimBound, "pv-data"),
+		testClaim("db-0-scratch", corev1.ClaimPending, ""),
+	)}
+
+	claims, err := c.GetPodVolumeClaims(context.Background(), "default", "db-0")
+	if err != nil {
+		t.Fatalf("GetPodVolumeClaims() error = %v", err)
+	}
+	want := []PodVolumeClaim{
+		{Volume: "data", Claim: "data-db-0", Status: "Bound", Capacity: "10Gi", AccessModes: "RWO", StorageClass: "fast", PersistentVolume: "pv-data"},
+		{Volume: "scratch", Claim: "db-0-scratch", Ephemeral: true, Status: "Pending", Capacity: "5Gi", AccessModes: "RWO", StorageClass: "fast"},
+		{Volume: "gone", Claim: "missing", Status: ClaimStatusNotFound},
+	}
+	if len(claims) != len(want) {
+		t.Fatalf("GetPodVolumeClaims() = %+v, want %d claims", claims, len(want))
+	}
+	for i := range want {
+		if claims[i] != want[i] {
+			t.Errorf("claim %d = %+v, want %+v", i, claims[i], want[i])
+		}
+	}
+}
+
+func TestVolumeClaimIssues(t *testing.T) {
+	pvcs := []corev1.PersistentVolumeClaim{
+		*testClaim("data-db-0", corev1.ClaimBound, "pv-data"),
+		*testClaim("old-data", corev1.ClaimBound, "pv-old"),
+		*testClaim("waiting", corev1.ClaimPending, ""),
+		*testClaim("broken", corev1.ClaimLost, "pv-gone"),
+	}
+	issues := VolumeClaimIssues(pvcs, []corev1.Pod{*claimTestPod()})
+
+	want := map[string]string{"old-data": ClaimIssueOrphaned, "waiting": "Pending", "broken": "Lost"}
+	if len(issues) != len(want) {
+		t.Fatalf("VolumeClaimIssues() = %+v, want %d issues", issues, len(want))
+	}
+	for _, issue := range issues {
+		if want[issue.Claim] != issue.Issue {
+			t.Errorf("issue for %s = %q, want %q", issue.Claim, issue.Issue, want[issue.Claim])
+		}
+	}
+	if issues[0].Claim != "broken" {
+		t.Errorf("issues not sorted by claim: %+v", issues)
+	}
+}
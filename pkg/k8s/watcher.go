@@ -2,11 +2,13 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
 	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/log"
 	"k8s.io/apimachinery/pkg/watch"
 )
 
@@ -24,6 +26,7 @@ type WatcherConfig struct {
 	RelistInterval   time.Duration // How often to do a full re-list (default: 30s)
 	DebounceInterval time.Duration // Debounce window for watch events (default: 250ms)
 	FallbackInterval time.Duration // Polling interval when watch fails (default: 5s)
+	MaxBackoff       time.Duration // Longest wait between reconnect attempts (default: 60s)
 }
 
 // DefaultWatcherConfig returns sensible defaults.
@@ -32,9 +35,14 @@ func DefaultWatcherConfig() WatcherConfig {
 		RelistInterval:   30 * time.Second,
 		DebounceInterval: 250 * time.Millisecond,
 		FallbackInterval: 5 * time.Second,
+		MaxBackoff:       60 * time.Second,
 	}
 }
 
+// errWatchClosed reports that the API server ended the watch, which it does
+// routinely when the watch times out or the server restarts
+var errWatchClosed = errors.New("watch channel closed")
+
 // ResourceWatcher watches a Kubernetes resource and notifies on changes.
 // It implements the hybrid pattern: Watch API for delta events + periodic
 // full re-list for consistency.
@@ -46,7 +54,8 @@ type ResourceWatcher struct {
 	state   WatchState
 	stateMu sync.RWMutex
 
-	onChange func() // Callback when data changes (triggers refresh)
+	onChange      func()           // Callback when data changes (triggers refresh)
+	onStateChange func(WatchState) // Optional callback when the watch goes live or falls back
 
 	stopCh  chan struct{}
 	stopped bool
@@ -70,6 +79,9 @@ func NewResourceWatcher(client *Client, resource, namespace string,
 	if cfg.FallbackInterval == 0 {
 		cfg.FallbackInterval = 5 * time.Second
 	}
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = 60 * time.Second
+	}
 
 	return &ResourceWatcher{
 		client:    client,
@@ -82,6 +94,12 @@ func NewResourceWatcher(client *Client, resource, namespace string,
 	}
 }
 
+// OnStateChange registers fn to be called from the watch goroutine whenever
+// the state changes while the watcher runs. Must be called before Start.
+func (w *ResourceWatcher) OnStateChange(fn func(WatchState)) {
+	w.onStateChange = fn
+}
+
 // Start begins the watch loop in a goroutine.
 func (w *ResourceWatcher) Start(ctx context.Context) {
 	go w.run(ctx)
@@ -107,8 +125,12 @@ func (w *ResourceWatcher) State() WatchState {
 
 func (w *ResourceWatcher) setState(s WatchState) {
 	w.stateMu.Lock()
+	changed := w.state != s
 	w.state = s
 	w.stateMu.Unlock()
+	if changed && w.onStateChange != nil && !w.isStopped() {
+		w.onStateChange(s)
+	}
 }
 
 func (w *ResourceWatcher) isStopped() bool {
@@ -121,29 +143,66 @@ func (w *ResourceWatcher) isStopped() bool {
 }
 
 // run is the main loop that alternates between watch and fallback polling.
+// A watch the server closes after running healthily is re-established at
+// once; failures fall back to polling and retry the watch with exponential
+// backoff. Every reconnect triggers a full re-list so events missed while
+// disconnected still reach the table.
 func (w *ResourceWatcher) run(ctx context.Context) {
+	failures := 0
+	resync := false
 	for {
 		if w.isStopped() || ctx.Err() != nil {
 			return
 		}
 
-		err := w.watchLoop(ctx)
-		if err != nil {
-			if w.isStopped() || ctx.Err() != nil {
-				return
-			}
-			w.logger.Warn("Watch failed, falling back to polling",
-				"resource", w.resource, "error", err)
-			w.setState(WatchStateFallback)
+		started := time.Now()
+		err := w.watchLoop(ctx, resync)
+		if w.isStopped() || ctx.Err() != nil {
+			return
+		}
+		resync = true
 
-			// Fallback: poll until we can retry watch
-			w.pollLoop(ctx)
+		// A watch that stayed up for a while was healthy; start the backoff over
+		if time.Since(started) >= w.cfg.FallbackInterval {
+			failures = 0
+		}
+		if errors.Is(err, errWatchClosed) && failures == 0 {
+			log.Debugf("Watch %s (namespace %q) closed by the server, reconnecting", w.resource, w.namespace)
+			failures++
+			continue
 		}
+
+		failures++
+		backoff := w.backoff(failures)
+		w.logger.Warn("Watch failed, falling back to polling",
+			"resource", w.resource, "error", err)
+		log.Debugf("Watch %s (namespace %q) failed (attempt %d), polling every %s and reconnecting in %s: %v",
+			w.resource, w.namespace, failures, w.cfg.FallbackInterval, backoff, err)
+		w.setState(WatchStateFallback)
+
+		// Fallback: poll until we can retry watch
+		w.pollLoop(ctx, backoff)
+		log.Debugf("Watch %s (namespace %q) reconnecting (attempt %d)", w.resource, w.namespace, failures+1)
+	}
+}
+
+// backoff returns how long to poll before reconnect attempt n+1: the
+// fallback interval doubled per consecutive failure, capped at MaxBackoff
+func (w *ResourceWatcher) backoff(failures int) time.Duration {
+	d := w.cfg.FallbackInterval
+	for i := 1; i < failures && d < w.cfg.MaxBackoff; i++ {
+		d *= 2
 	}
+	if d > w.cfg.MaxBackoff {
+		d = w.cfg.MaxBackoff
+	}
+	return d
 }
 
 // watchLoop runs the Watch API loop with debouncing and periodic re-list.
-func (w *ResourceWatcher) watchLoop(ctx context.Context) error {
+// When resync is set the caller is reconnecting, so a full re-list is
+// triggered as soon as the watch is established.
+func (w *ResourceWatcher) watchLoop(ctx context.Context, resync bool) error {
 	watcher, err := w.client.WatchResource(ctx, w.resource, w.namespace)
 	if err != nil {
 		return fmt.Errorf("failed to start watch: %w", err)
@@ -151,6 +210,10 @@ func (w *ResourceWatcher) watchLoop(ctx context.Context) error {
 	defer watcher.Stop()
 
 	w.setState(WatchStateActive)
+	log.Debugf("Watch %s (namespace %q) established", w.resource, w.namespace)
+	if resync && !w.isStopped() && w.onChange != nil {
+		w.onChange()
+	}
 
 	// Debounce timer to coalesce rapid events
 	var debounceTimer *time.Timer
@@ -175,7 +238,7 @@ func (w *ResourceWatcher) watchLoop(ctx context.Context) error {
 
 		case event, ok := <-watcher.ResultChan():
 			if !ok {
-				return fmt.Errorf("watch channel closed")
+				return errWatchClosed
 			}
 			if event.Type == watch.Error {
 				return fmt.Errorf("watch error event received")
@@ -206,14 +269,14 @@ func (w *ResourceWatcher) watchLoop(ctx context.Context) error {
 }
 
 // pollLoop provides fallback polling when Watch is unavailable.
-// Returns periodically to allow the outer loop to retry Watch.
-func (w *ResourceWatcher) pollLoop(ctx context.Context) {
+// Returns after roughly d to allow the outer loop to retry Watch.
+func (w *ResourceWatcher) pollLoop(ctx context.Context, d time.Duration) {
 	ticker := time.NewTicker(w.cfg.FallbackInterval)
 	defer ticker.Stop()
 
 	// Track iterations to know when to retry watch
 	iterations := 0
-	maxIterations := int(w.cfg.RelistInterval / w.cfg.FallbackInterval)
+	maxIterations := int(d / w.cfg.FallbackInterval)
 	if maxIterations < 1 {
 		maxIterations = 1
 	}
@@ -11,7 +11,9 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func testLogger() *slog.Logger {
@@ -308,6 +310,119 @@ func TestResourceWatcher_ContextCancel(t *testing.T) {
 	}
 }
 
+func TestResourceWatcher_ReconnectResyncs(t *testing.T) {
+	fakeClient := fake.NewClientset() //nolint:staticcheck
+	watchers := make(chan *watch.FakeWatcher, 4)
+	fakeClient.PrependWatchReactor("pods", func(k8stesting.Action) (bool, watch.Interface, error) {
+		fw := watch.NewFake()
+		watchers <- fw
+		return true, fw, nil
+	})
+	client := &Client{Clientset: fakeClient}
+
+	var called int32
+	onChange := func() { atomic.AddInt32(&called, 1) }
+	cfg := WatcherConfig{
+		RelistInterval:   10 * time.Second,
+		DebounceInterval: 10 * time.Millisecond,
+		FallbackInterval: time.Second,
+	}
+	w := NewResourceWatcher(client, "pods", "default", onChange, testLogger(), cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w.Start(ctx)
+	defer w.Stop()
+
+	var first *watch.FakeWatcher
+	select {
+	case first = <-watchers:
+	case <-time.After(2 * time.Second):
+		t.Fatal("watch was not started")
+	}
+	if n := atomic.LoadInt32(&called); n != 0 {
+		t.Fatalf("onChange called %d times before any event, want 0", n)
+	}
+
+	// The server closing the watch reconnects without waiting for a poll
+	first.Stop()
+	select {
+	case <-watchers:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("watch was not re-established after the server closed it")
+	}
+
+	deadline := time.After(2 * time.Second)
+	for atomic.LoadInt32(&called) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("reconnect did not trigger a full re-list")
+		default:
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if w.State() != WatchStateActive {
+		t.Errorf("expected WatchStateActive after reconnect, got %d", w.State())
+	}
+}
+
+func TestResourceWatcher_RecoversAfterErrors(t *testing.T) {
+	fakeClient := fake.NewClientset() //nolint:staticcheck
+	var attempts int32
+	fakeClient.PrependWatchReactor("pods", func(k8stesting.Action) (bool, watch.Interface, error) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			return true, nil, fmt.Errorf("connection refused")
+		}
+		return true, watch.NewFake(), nil
+	})
+	client := &Client{Clientset: fakeClient}
+
+	states := make(chan WatchState, 8)
+	cfg := WatcherConfig{
+		RelistInterval:   time.Second,
+		DebounceInterval: 10 * time.Millisecond,
+		FallbackInterval: 20 * time.Millisecond,
+		MaxBackoff:       40 * time.Millisecond,
+	}
+	w := NewResourceWatcher(client, "pods", "default", func() {}, testLogger(), cfg)
+	w.OnStateChange(func(s WatchState) { states <- s })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w.Start(ctx)
+	defer w.Stop()
+
+	for _, want := range []WatchState{WatchStateFallback, WatchStateActive} {
+		select {
+		case got := <-states:
+			if got != want {
+				t.Fatalf("state change = %d, want %d", got, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("no state change to %d", want)
+		}
+	}
+	if n := atomic.LoadInt32(&attempts); n != 3 {
+		t.Errorf("watch attempts = %d, want 3", n)
+	}
+}
+
+func TestResourceWatcher_Backoff(t *testing.T) {
+	w := NewResourceWatcher(nil, "pods", "", nil, testLogger(), WatcherConfig{
+		FallbackInterval: 5 * time.Second,
+		MaxBackoff:       30 * time.Second,
+	})
+	for failures, want := range map[int]time.Duration{
+		1: 5 * time.Second,
+		2: 10 * time.Second,
+		3: 20 * time.Second,
+		4: 30 * time.Second,
+		9: 30 * time.Second,
+	} {
+		if got := w.backoff(failures); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", failures, got, want)
+		}
+	}
+}
+
 func TestWatchResource_SupportedTypes(t *testing.T) {
 	fakeClient := fake.NewClientset() //nolint:staticcheck
 	client := &Client{Clientset: fakeClient}
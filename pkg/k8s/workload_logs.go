@@ -0,0 +1,219 @@
+package k8s
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxWorkloadLogPods caps how many pods GetWorkloadLogs reads, and
+// workloadLogWorkers how many it reads at once.
+const (
+	maxWorkloadLogPods = 50
+	workloadLogWorkers = 5
+)
+
+// WorkloadLogOptions controls GetWorkloadLogs
+type WorkloadLogOptions struct {
+	TailLines int64  // Lines to read from the end of each pod's log (default: 100)
+	Grep      string // Case-insensitive regular expression; only matching lines are kept
+}
+
+// WorkloadLogLine is one log line from one of a workload's pods
+type WorkloadLogLine struct {
+	Pod  string
+	Time time.Time // Zero when the line had no parseable timestamp
+	Text string
+}
+
+// WorkloadLogs is the merged log of a workload's pods
+type WorkloadLogs struct {
+	Pods      []string // Pods that were read, sorted by name
+	Truncated bool     // True when the workload had more than maxWorkloadLogPods pods
+	Lines     []WorkloadLogLine
+	Errors    map[string]error // Per-pod read errors, keyed by pod name
+}
+
+// WorkloadPods returns the pods selected by a deployment, statefulset,
+// daemonset, replicaset, or job, sorted by name.
+func (c *Client) WorkloadPods(ctx context.Context, namespace, kind, name string) ([]corev1.Pod, error) {
+	var selector *metav1.LabelSelector
+	switch kind {
+	case "deployments", "deploy":
+		dep, err := c.clientset().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment: %w", err)
+		}
+		selector = dep.Spec.Selector
+	case "statefulsets", "sts":
+		sts, err := c.clientset().AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statefulset: %w", err)
+		}
+		selector = sts.Spec.Selector
+	case "daemonsets", "ds":
+		ds, err := c.clientset().AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get daemonset: %w", err)
+		}
+		selector = ds.Spec.Selector
+	case "replicasets", "rs":
+		rs, err := c.clientset().AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get replicaset: %w", err)
+		}
+		selector = rs.Spec.Selector
+	case "jobs", "job":
+		job, err := c.clientset().BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job: %w", err)
+		}
+		selector = job.Spec.Selector
+	default:
+		return nil, fmt.Errorf("unsupported workload kind: %s", kind)
+	}
+
+	podList, err := c.clientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(selector),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	pods := podList.Items
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	return pods, nil
+}
+
+// GetWorkloadLogs reads the tail of every pod of a workload and merges the
+// lines in timestamp order. The API server cannot grep logs, so opts.Grep is
+// applied while each log streams in and only matching lines are kept. Pods
+// that fail to return logs are reported in Errors rather than failing the
+// whole call.
+func (c *Client) GetWorkloadLogs(ctx context.Context, namespace, kind, name string, opts WorkloadLogOptions) (*WorkloadLogs, error) {
+	var grep *regexp.Regexp
+	if opts.Grep != "" {
+		re, err := regexp.Compile("(?i)" + opts.Grep)
+		if err != nil {
+			return nil, fmt.Errorf("invalid grep pattern: %w", err)
+		}
+		grep = re
+	}
+	if opts.TailLines <= 0 {
+		opts.TailLines = 100
+	}
+
+	pods, err := c.WorkloadPods(ctx, namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WorkloadLogs{Errors: make(map[string]error)}
+	if len(pods) > maxWorkloadLogPods {
+		pods = pods[:maxWorkloadLogPods]
+		result.Truncated = true
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, workloadLogWorkers)
+	)
+	for i := range pods {
+		pod := &pods[i]
+		result.Pods = append(result.Pods, pod.Name)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			lines, err := c.readPodLogLines(ctx, pod, opts.TailLines, grep)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Errors[pod.Name] = err
+				return
+			}
+			result.Lines = append(result.Lines, lines...)
+		}()
+	}
+	wg.Wait()
+
+	sort.SliceStable(result.Lines, func(i, j int) bool {
+		a, b := result.Lines[i], result.Lines[j]
+		if !a.Time.Equal(b.Time) {
+			return a.Time.Before(b.Time)
+		}
+		return a.Pod < b.Pod
+	})
+	return result, nil
+}
+
+// readPodLogLines streams the tail of a pod's default container log with
+// timestamps and keeps the lines that match grep (all lines when nil).
+func (c *Client) readPodLogLines(ctx context.Context, pod *corev1.Pod, tailLines int64, grep *regexp.Regexp) ([]WorkloadLogLine, error) {
+	stream, err := c.clientset().CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  defaultContainer(pod),
+		TailLines:  &tailLines,
+		Timestamps: true,
+	}).Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	var lines []WorkloadLogLine
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		ts, text := splitLogTimestamp(scanner.Text())
+		if grep != nil && !grep.MatchString(text) {
+			continue
+		}
+		lines = append(lines, WorkloadLogLine{Pod: pod.Name, Time: ts, Text: text})
+	}
+	return lines, scanner.Err()
+}
+
+// splitLogTimestamp separates the RFC3339 timestamp the API server prepends
+// when PodLogOptions.Timestamps is set.
+func splitLogTimestamp(line string) (time.Time, string) {
+	prefix, rest, ok := strings.Cut(line, " ")
+	if !ok {
+		prefix, rest = line, ""
+	}
+	ts, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return time.Time{}, line
+	}
+	return ts, rest
+}
+
+// Format renders the merged log as "pod | line" rows, pod names padded to a
+// common width, followed by a note for each pod that could not be read.
+func (l *WorkloadLogs) Format() string {
+	width := 0
+	for _, pod := range l.Pods {
+		width = max(width, len(pod))
+	}
+
+	var b strings.Builder
+	for _, line := range l.Lines {
+		fmt.Fprintf(&b, "%-*s | %s\n", width, line.Pod, line.Text)
+	}
+	for _, pod := range l.Pods {
+		if err, ok := l.Errors[pod]; ok {
+			fmt.Fprintf(&b, "%-*s | error reading logs: %v\n", width, pod, err)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
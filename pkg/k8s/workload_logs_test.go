@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newWorkloadLogsTestClient() *Client {
+	labels := map[string]string{"app": "web"}
+	pod := func(name string, podLabels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: podLabels},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		}
+	}
+	return &Client{Clientset: fake.NewClientset( //nolint:staticcheck
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+		},
+		pod("web-b", labels),
+		pod("web-a", labels),
+		pod("db-0", map[string]string{"app": "db"}),
+	)}
+}
+
+func TestWorkloadPods(t *testing.T) {
+	c := newWorkloadLogsTestClient()
+
+	pods, err := c.WorkloadPods(context.Background(), "default", "deploy", "web")
+	if err != nil {
+		t.Fatalf("WorkloadPods() error = %v", err)
+	}
+	if len(pods) != 2 || pods[0].Name != "web-a" || pods[1].Name != "web-b" {
+		t.Errorf("WorkloadPods() = %v, want [web-a web-b]", podNames(pods))
+	}
+
+	if _, err := c.WorkloadPods(context.Background(), "default", "cronjobs", "web"); err == nil {
+		t.Error("expected an error for an unsupported kind")
+	}
+}
+
+func TestGetWorkloadLogs(t *testing.T) {
+	c := newWorkloadLogsTestClient()
+	ctx := context.Background()
+
+	// The fake clientset returns "fake logs" for every pod
+	logs, err := c.GetWorkloadLogs(ctx, "default", "deployments", "web", WorkloadLogOptions{})
+	if err != nil {
+		t.Fatalf("GetWorkloadLogs() error = %v", err)
+	}
+	if len(logs.Pods) != 2 || len(logs.Lines) != 2 {
+		t.Fatalf("got %d pods, %d lines; want 2, 2", len(logs.Pods), len(logs.Lines))
+	}
+	want := "web-a | fake logs\nweb-b | fake logs"
+	if got := logs.Format(); got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+
+	logs, err = c.GetWorkloadLogs(ctx, "default", "deployments", "web", WorkloadLogOptions{Grep: "FAKE"})
+	if err != nil || len(logs.Lines) != 2 {
+		t.Errorf("case-insensitive grep: lines = %d, err = %v; want 2", len(logs.Lines), err)
+	}
+	logs, err = c.GetWorkloadLogs(ctx, "default", "deployments", "web", WorkloadLogOptions{Grep: "timeout"})
+	if err != nil || len(logs.Lines) != 0 {
+		t.Errorf("non-matching grep: lines = %d, err = %v; want 0", len(logs.Lines), err)
+	}
+
+	if _, err := c.GetWorkloadLogs(ctx, "default", "deployments", "web", WorkloadLogOptions{Grep: "("}); err == nil {
+		t.Error("expected an error for an invalid grep pattern")
+	}
+}
+
+func TestSplitLogTimestamp(t *testing.T) {
+	ts, text := splitLogTimestamp("2026-10-16T09:12:03.512345678Z GET /healthz 200")
+	if text != "GET /healthz 200" {
+		t.Errorf("text = %q", text)
+	}
+	if want := time.Date(2026, 10, 16, 9, 12, 3, 512345678, time.UTC); !ts.Equal(want) {
+		t.Errorf("time = %v, want %v", ts, want)
+	}
+
+	ts, text = splitLogTimestamp("no timestamp here")
+	if !ts.IsZero() || text != "no timestamp here" {
+		t.Errorf("got %v, %q; want zero time and the full line", ts, text)
+	}
+}
+
+func TestWorkloadLogsFormat_MergesByTimeAndReportsErrors(t *testing.T) {
+	base := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	logs := &WorkloadLogs{
+		Pods: []string{"api-0", "api-10"},
+		Lines: []WorkloadLogLine{
+			{Pod: "api-10", Time: base, Text: "first"},
+			{Pod: "api-0", Time: base.Add(time.Second), Text: "second"},
+		},
+		Errors: map[string]error{"api-0": errors.New("container is waiting")},
+	}
+
+	got := logs.Format()
+	want := strings.Join([]string{
+		"api-10 | first",
+		"api-0  | second",
+		"api-0  | error reading logs: container is waiting",
+	}, "\n")
+	if got != want {
+		t.Errorf("Format() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func podNames(pods []corev1.Pod) []string {
+	names := make([]string, len(pods))
+	for i, p := range pods {
+		names[i] = p.Name
+	}
+	return names
+}
@@ -1,12 +1,17 @@
 package log
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 type Level int
@@ -18,11 +23,37 @@ const (
 	LevelError
 )
 
+// String returns the lowercase level name used in log output
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// Format selects how log lines are written
+type Format int
+
+const (
+	FormatText Format = iota // [LEVEL] message with timestamp and caller prefix
+	FormatJSON               // one JSON object per line for log aggregation
+)
+
 var (
-	logger       *log.Logger
-	currentLevel Level = LevelInfo // Default level
+	logger        *log.Logger
+	currentLevel  Level  = LevelInfo // Default level
+	currentFormat Format = FormatText
 )
 
+// jsonMu serializes JSON writes to the logger's writer
+var jsonMu sync.Mutex
+
 func Init(appName string) error {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
@@ -52,42 +83,103 @@ func Init(appName string) error {
 	return nil
 }
 
-// SetLevel sets the current logging level from a string (debug, info, warn, error)
-func SetLevel(levelStr string) {
-	switch strings.ToLower(levelStr) {
+// AddOutput mirrors log output to w in addition to the log files, e.g. to
+// stderr so container log collectors pick it up.
+func AddOutput(w io.Writer) {
+	if logger == nil {
+		logger = log.New(w, "", log.LstdFlags|log.Lshortfile)
+		return
+	}
+	logger.SetOutput(io.MultiWriter(logger.Writer(), w))
+}
+
+// ParseLevel parses a level name (debug, info, warn, error)
+func ParseLevel(levelStr string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(levelStr)) {
 	case "debug":
-		currentLevel = LevelDebug
+		return LevelDebug, nil
 	case "info":
-		currentLevel = LevelInfo
+		return LevelInfo, nil
 	case "warn", "warning":
-		currentLevel = LevelWarn
+		return LevelWarn, nil
 	case "error":
-		currentLevel = LevelError
-	default:
-		currentLevel = LevelInfo
+		return LevelError, nil
 	}
+	return LevelInfo, fmt.Errorf("invalid log level %q: must be debug, info, warn, or error", levelStr)
 }
 
-func Infof(format string, v ...any) {
-	if logger != nil && currentLevel <= LevelInfo {
-		_ = logger.Output(2, fmt.Sprintf("[INFO] "+format, v...))
+// SetLevel sets the current logging level from a string (debug, info, warn, error)
+func SetLevel(levelStr string) {
+	currentLevel, _ = ParseLevel(levelStr)
+}
+
+// ParseFormat parses a log format name (text, json); empty means text
+func ParseFormat(formatStr string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(formatStr)) {
+	case "", "text":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
 	}
+	return FormatText, fmt.Errorf("invalid log format %q: must be text or json", formatStr)
 }
 
-func Errorf(format string, v ...any) {
-	if logger != nil && currentLevel <= LevelError {
-		_ = logger.Output(2, fmt.Sprintf("[ERROR] "+format, v...))
+// SetFormat sets the output format from a string (text, json)
+func SetFormat(formatStr string) {
+	currentFormat, _ = ParseFormat(formatStr)
+}
+
+// jsonEntry is one line of JSON log output
+type jsonEntry struct {
+	Time   string `json:"time"`
+	Level  string `json:"level"`
+	Msg    string `json:"msg"`
+	Caller string `json:"caller,omitempty"`
+}
+
+// output writes msg at level; calldepth 3 attributes it to the caller of
+// the exported logging function.
+func output(level Level, format string, v ...any) {
+	if logger == nil || currentLevel > level {
+		return
+	}
+	msg := fmt.Sprintf(format, v...)
+
+	if currentFormat != FormatJSON {
+		_ = logger.Output(3, "["+strings.ToUpper(level.String())+"] "+msg)
+		return
+	}
+
+	entry := jsonEntry{
+		Time:  time.Now().Format(time.RFC3339Nano),
+		Level: level.String(),
+		Msg:   msg,
+	}
+	if _, file, line, ok := runtime.Caller(2); ok {
+		entry.Caller = filepath.Base(file) + ":" + strconv.Itoa(line)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
 	}
+
+	jsonMu.Lock()
+	defer jsonMu.Unlock()
+	_, _ = logger.Writer().Write(append(data, '\n'))
+}
+
+func Infof(format string, v ...any) {
+	output(LevelInfo, format, v...)
+}
+
+func Errorf(format string, v ...any) {
+	output(LevelError, format, v...)
 }
 
 func Debugf(format string, v ...any) {
-	if logger != nil && currentLevel <= LevelDebug {
-		_ = logger.Output(2, fmt.Sprintf("[DEBUG] "+format, v...))
-	}
+	output(LevelDebug, format, v...)
 }
 
 func Warnf(format string, v ...any) {
-	if logger != nil && currentLevel <= LevelWarn {
-		_ = logger.Output(2, fmt.Sprintf("[WARN] "+format, v...))
-	}
+	output(LevelWarn, format, v...)
 }
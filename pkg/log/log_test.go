@@ -2,6 +2,7 @@ package log
 
 import (
 	"bytes"
+	"encoding/json"
 	"log"
 	"strings"
 	"testing"
@@ -145,3 +146,66 @@ func TestLevelConstants(t *testing.T) {
 		t.Error("LevelWarn should be less than LevelError")
 	}
 }
+
+func TestParseLevel_Invalid(t *testing.T) {
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("ParseLevel(verbose) should fail")
+	}
+	if lvl, err := ParseLevel(" Warn "); err != nil || lvl != LevelWarn {
+		t.Errorf("ParseLevel(Warn) = %v, %v; want warn", lvl, err)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{"", FormatText, false},
+		{"text", FormatText, false},
+		{"JSON", FormatJSON, false},
+		{"logfmt", FormatText, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFormat(%q) = %v, %v; want %v, err=%v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	setupTestLogger(t, &buf)
+	currentFormat = FormatJSON
+	defer func() { currentFormat = FormatText }()
+
+	Warnf("watch %s reconnecting", "pods")
+
+	var entry map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("output is not JSON: %v (%q)", err, buf.String())
+	}
+	if entry["level"] != "warn" || entry["msg"] != "watch pods reconnecting" {
+		t.Errorf("entry = %v", entry)
+	}
+	if !strings.HasPrefix(entry["caller"], "log_test.go:") {
+		t.Errorf("caller = %q, want log_test.go:<line>", entry["caller"])
+	}
+	if entry["time"] == "" {
+		t.Error("time should be set")
+	}
+}
+
+func TestAddOutput(t *testing.T) {
+	var files, mirror bytes.Buffer
+	setupTestLogger(t, &files)
+
+	AddOutput(&mirror)
+	Infof("ready")
+
+	if !strings.Contains(files.String(), "[INFO] ready") || !strings.Contains(mirror.String(), "[INFO] ready") {
+		t.Errorf("expected both writers to receive the line: files=%q mirror=%q", files.String(), mirror.String())
+	}
+}
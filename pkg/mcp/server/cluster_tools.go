@@ -0,0 +1,443 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+// Bounds for the read-only cluster tools
+const (
+	defaultListLimit   = 50
+	maxListLimit       = 500
+	defaultLogTail     = 100
+	maxLogTail         = 5000
+	maxLogBytes        = 1 << 20
+	clusterToolTimeout = 30 * time.Second
+)
+
+// ClusterTools returns read-only tools backed by client: list_resources,
+// get_resource_yaml, and get_pod_logs. They only issue get, list, and log
+// requests and answer in JSON, so agents get bounded, structured access
+// without a path to mutate the cluster.
+func ClusterTools(client *k8s.Client) []*Tool {
+	return []*Tool{
+		ListResourcesTool(client),
+		GetResourceYAMLTool(client),
+		GetPodLogsTool(client),
+	}
+}
+
+// ListResourcesTool returns the list_resources tool
+func ListResourcesTool(client *k8s.Client) *Tool {
+	return &Tool{
+		Name:        "list_resources",
+		Description: "Lists pods, deployments, services, or events as JSON summaries, one page at a time. Read-only. Pass the returned continue token to get the next page.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"kind": map[string]interface{}{
+					"type":        "string",
+					"description": "Resource kind to list",
+					"enum":        []string{"pods", "deployments", "services", "events"},
+				},
+				"namespace": map[string]interface{}{
+					"type":        "string",
+					"description": "Namespace; omit or use 'all' for all namespaces",
+				},
+				"label_selector": map[string]interface{}{
+					"type":        "string",
+					"description": "Label selector (e.g., 'app=nginx')",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Items per page (default: %d, max: %d)", defaultListLimit, maxListLimit),
+				},
+				"continue": map[string]interface{}{
+					"type":        "string",
+					"description": "Continue token from the previous page",
+				},
+			},
+			"required": []string{"kind"},
+		},
+		Handler: func(ctx context.Context, args map[string]interface{}) (string, error) {
+			return listResources(ctx, client, args)
+		},
+		ReadOnly: true,
+	}
+}
+
+// GetResourceYAMLTool returns the get_resource_yaml tool
+func GetResourceYAMLTool(client *k8s.Client) *Tool {
+	return &Tool{
+		Name:        "get_resource_yaml",
+		Description: "Returns the YAML manifest of one Kubernetes resource, without managed fields, as JSON. Read-only. Secrets are not available.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"resource": map[string]interface{}{
+					"type":        "string",
+					"description": "Resource type (pods, deployments, services, configmaps, nodes, etc.)",
+				},
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Resource name",
+				},
+				"namespace": map[string]interface{}{
+					"type":        "string",
+					"description": "Namespace (ignored for cluster-scoped resources)",
+				},
+			},
+			"required": []string{"resource", "name"},
+		},
+		Handler: func(ctx context.Context, args map[string]interface{}) (string, error) {
+			return getResourceYAML(ctx, client, args)
+		},
+		ReadOnly: true,
+	}
+}
+
+// GetPodLogsTool returns the get_pod_logs tool
+func GetPodLogsTool(client *k8s.Client) *Tool {
+	return &Tool{
+		Name:        "get_pod_logs",
+		Description: "Returns recent logs of a pod container as JSON. Read-only. Output is capped at 1 MiB.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"pod": map[string]interface{}{
+					"type":        "string",
+					"description": "Pod name",
+				},
+				"namespace": map[string]interface{}{
+					"type":        "string",
+					"description": "Namespace (default: default)",
+				},
+				"container": map[string]interface{}{
+					"type":        "string",
+					"description": "Container name (if pod has multiple containers)",
+				},
+				"tail": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Number of lines from the end (default: %d, max: %d)", defaultLogTail, maxLogTail),
+				},
+				"since": map[string]interface{}{
+					"type":        "string",
+					"description": "Only logs newer than this duration, e.g. 10m or 1h",
+				},
+				"previous": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Show logs from previous terminated container",
+				},
+			},
+			"required": []string{"pod"},
+		},
+		Handler: func(ctx context.Context, args map[string]interface{}) (string, error) {
+			return getPodLogs(ctx, client, args)
+		},
+		ReadOnly: true,
+	}
+}
+
+// resourceList is the list_resources result
+type resourceList struct {
+	Kind      string `json:"kind"`
+	Items     any    `json:"items"`
+	Continue  string `json:"continue,omitempty"`
+	Remaining *int64 `json:"remaining,omitempty"`
+}
+
+type podSummary struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Phase     string `json:"phase"`
+	Ready     string `json:"ready"`
+	Restarts  int32  `json:"restarts"`
+	Node      string `json:"node,omitempty"`
+	IP        string `json:"ip,omitempty"`
+	Created   string `json:"created"`
+}
+
+type deploymentSummary struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace"`
+	Replicas  int32    `json:"replicas"`
+	Ready     int32    `json:"ready"`
+	Updated   int32    `json:"updated"`
+	Available int32    `json:"available"`
+	Images    []string `json:"images"`
+	Created   string   `json:"created"`
+}
+
+type serviceSummary struct {
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	Type       string            `json:"type"`
+	ClusterIP  string            `json:"cluster_ip,omitempty"`
+	ExternalIP []string          `json:"external_ips,omitempty"`
+	Ports      []string          `json:"ports"`
+	Selector   map[string]string `json:"selector,omitempty"`
+	Created    string            `json:"created"`
+}
+
+type eventSummary struct {
+	Namespace string `json:"namespace"`
+	Type      string `json:"type"`
+	Reason    string `json:"reason"`
+	Object    string `json:"object"`
+	Message   string `json:"message"`
+	Count     int32  `json:"count"`
+	LastSeen  string `json:"last_seen"`
+}
+
+func listResources(ctx context.Context, client *k8s.Client, args map[string]interface{}) (string, error) {
+	kind, _ := args["kind"].(string)
+	namespace, _ := args["namespace"].(string)
+	selector, _ := args["label_selector"].(string)
+	cont, _ := args["continue"].(string)
+	limit, _ := args["limit"].(float64) // JSON numbers are float64
+
+	if namespace == "all" {
+		namespace = ""
+	}
+	opts := metav1.ListOptions{
+		LabelSelector: selector,
+		Limit:         defaultListLimit,
+		Continue:      cont,
+	}
+	if limit > 0 {
+		opts.Limit = min(int64(limit), maxListLimit)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, clusterToolTimeout)
+	defer cancel()
+	cs := client.Clientset
+	result := resourceList{Kind: kind}
+	var meta metav1.ListMeta
+
+	switch kind {
+	case "pods":
+		list, err := cs.CoreV1().Pods(namespace).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		items := make([]podSummary, 0, len(list.Items))
+		for _, p := range list.Items {
+			ready, restarts := 0, int32(0)
+			for _, cst := range p.Status.ContainerStatuses {
+				if cst.Ready {
+					ready++
+				}
+				restarts += cst.RestartCount
+			}
+			items = append(items, podSummary{
+				Name:      p.Name,
+				Namespace: p.Namespace,
+				Phase:     string(p.Status.Phase),
+				Ready:     fmt.Sprintf("%d/%d", ready, len(p.Spec.Containers)),
+				Restarts:  restarts,
+				Node:      p.Spec.NodeName,
+				IP:        p.Status.PodIP,
+				Created:   p.CreationTimestamp.Format(time.RFC3339),
+			})
+		}
+		result.Items, meta = items, list.ListMeta
+	case "deployments":
+		list, err := cs.AppsV1().Deployments(namespace).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		items := make([]deploymentSummary, 0, len(list.Items))
+		for _, d := range list.Items {
+			replicas := int32(1)
+			if d.Spec.Replicas != nil {
+				replicas = *d.Spec.Replicas
+			}
+			var images []string
+			for _, c := range d.Spec.Template.Spec.Containers {
+				images = append(images, c.Image)
+			}
+			items = append(items, deploymentSummary{
+				Name:      d.Name,
+				Namespace: d.Namespace,
+				Replicas:  replicas,
+				Ready:     d.Status.ReadyReplicas,
+				Updated:   d.Status.UpdatedReplicas,
+				Available: d.Status.AvailableReplicas,
+				Images:    images,
+				Created:   d.CreationTimestamp.Format(time.RFC3339),
+			})
+		}
+		result.Items, meta = items, list.ListMeta
+	case "services":
+		list, err := cs.CoreV1().Services(namespace).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		items := make([]serviceSummary, 0, len(list.Items))
+		for _, s := range list.Items {
+			ports := make([]string, 0, len(s.Spec.Ports))
+			for _, p := range s.Spec.Ports {
+				ports = append(ports, fmt.Sprintf("%d/%s", p.Port, p.Protocol))
+			}
+			externalIPs := append([]string{}, s.Spec.ExternalIPs...)
+			for _, ing := range s.Status.LoadBalancer.Ingress {
+				if ing.IP != "" {
+					externalIPs = append(externalIPs, ing.IP)
+				} else if ing.Hostname != "" {
+					externalIPs = append(externalIPs, ing.Hostname)
+				}
+			}
+			items = append(items, serviceSummary{
+				Name:       s.Name,
+				Namespace:  s.Namespace,
+				Type:       string(s.Spec.Type),
+				ClusterIP:  s.Spec.ClusterIP,
+				ExternalIP: externalIPs,
+				Ports:      ports,
+				Selector:   s.Spec.Selector,
+				Created:    s.CreationTimestamp.Format(time.RFC3339),
+			})
+		}
+		result.Items, meta = items, list.ListMeta
+	case "events":
+		list, err := cs.CoreV1().Events(namespace).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		items := make([]eventSummary, 0, len(list.Items))
+		for _, e := range list.Items {
+			items = append(items, eventSummary{
+				Namespace: e.Namespace,
+				Type:      e.Type,
+				Reason:    e.Reason,
+				Object:    e.InvolvedObject.Kind + "/" + e.InvolvedObject.Name,
+				Message:   e.Message,
+				Count:     e.Count,
+				LastSeen:  eventLastSeen(e).Format(time.RFC3339),
+			})
+		}
+		result.Items, meta = items, list.ListMeta
+	default:
+		return "", fmt.Errorf("unsupported kind %q (use pods, deployments, services, or events)", kind)
+	}
+
+	result.Continue = meta.Continue
+	result.Remaining = meta.RemainingItemCount
+	return toJSON(result)
+}
+
+// eventLastSeen returns when an event last occurred; newer events may only
+// set EventTime
+func eventLastSeen(e corev1.Event) time.Time {
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp.Time
+	}
+	if !e.EventTime.IsZero() {
+		return e.EventTime.Time
+	}
+	return e.CreationTimestamp.Time
+}
+
+func getResourceYAML(ctx context.Context, client *k8s.Client, args map[string]interface{}) (string, error) {
+	resource, _ := args["resource"].(string)
+	name, _ := args["name"].(string)
+	namespace, _ := args["namespace"].(string)
+
+	if resource == "" || name == "" {
+		return "", fmt.Errorf("resource and name are required")
+	}
+	gvr, ok := client.GetGVR(strings.ToLower(resource))
+	if !ok {
+		return "", fmt.Errorf("unknown resource: %s", resource)
+	}
+	if gvr.Resource == "secrets" {
+		return "", fmt.Errorf("secrets are not available through MCP")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, clusterToolTimeout)
+	defer cancel()
+	manifest, err := client.GetResourceYAML(ctx, namespace, name, gvr)
+	if err != nil {
+		return "", err
+	}
+	return toJSON(map[string]string{
+		"resource":  gvr.Resource,
+		"name":      name,
+		"namespace": namespace,
+		"yaml":      manifest,
+	})
+}
+
+func getPodLogs(ctx context.Context, client *k8s.Client, args map[string]interface{}) (string, error) {
+	pod, _ := args["pod"].(string)
+	namespace, _ := args["namespace"].(string)
+	container, _ := args["container"].(string)
+	tail, _ := args["tail"].(float64) // JSON numbers are float64
+	since, _ := args["since"].(string)
+	previous, _ := args["previous"].(bool)
+
+	if pod == "" {
+		return "", fmt.Errorf("pod is required")
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	tailLines := int64(defaultLogTail)
+	if tail > 0 {
+		tailLines = min(int64(tail), maxLogTail)
+	}
+	limitBytes := int64(maxLogBytes)
+	opts := &corev1.PodLogOptions{
+		Container:  container,
+		Previous:   previous,
+		TailLines:  &tailLines,
+		LimitBytes: &limitBytes,
+	}
+	if since != "" {
+		d, err := time.ParseDuration(since)
+		if err != nil || d <= 0 {
+			return "", fmt.Errorf("invalid since %q: use a positive duration such as 10m", since)
+		}
+		// SinceSeconds is whole seconds; round up so "500ms" is not 0
+		seconds := int64(math.Ceil(d.Seconds()))
+		opts.SinceSeconds = &seconds
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, clusterToolTimeout)
+	defer cancel()
+	stream, err := client.Clientset.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+	data, err := io.ReadAll(io.LimitReader(stream, maxLogBytes))
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(map[string]interface{}{
+		"pod":       pod,
+		"namespace": namespace,
+		"container": container,
+		"lines":     tailLines,
+		"logs":      string(data),
+	})
+}
+
+func toJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
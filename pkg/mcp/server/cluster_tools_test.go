@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+func TestClusterTools(t *testing.T) {
+	pod := func(name, app string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", Labels: map[string]string{"app": app}},
+			Spec:       corev1.PodSpec{NodeName: "worker-1", Containers: []corev1.Container{{Name: "app"}}},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Ready: true, RestartCount: 2}},
+			},
+		}
+	}
+	clientset := fake.NewClientset( //nolint:staticcheck
+		pod("web-0", "web"),
+		pod("api-0", "api"),
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "web-0.1", Namespace: "shop"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-0"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			Count:          3,
+		},
+	)
+	client := &k8s.Client{Clientset: clientset}
+
+	tools := map[string]*Tool{}
+	for _, tool := range ClusterTools(client) {
+		tools[tool.Name] = tool
+	}
+	for _, name := range []string{"list_resources", "get_resource_yaml", "get_pod_logs"} {
+		if tools[name] == nil || tools[name].Handler == nil {
+			t.Fatalf("missing tool %s", name)
+		}
+	}
+	ctx := context.Background()
+
+	out, err := tools["list_resources"].Handler(ctx, map[string]interface{}{"kind": "pods", "namespace": "shop", "label_selector": "app=web"})
+	if err != nil {
+		t.Fatalf("list pods: %v", err)
+	}
+	var pods struct {
+		Kind  string       `json:"kind"`
+		Items []podSummary `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(out), &pods); err != nil {
+		t.Fatalf("list pods output %q: %v", out, err)
+	}
+	if pods.Kind != "pods" || len(pods.Items) != 1 || pods.Items[0].Name != "web-0" || pods.Items[0].Ready != "1/1" || pods.Items[0].Restarts != 2 {
+		t.Errorf("pods = %+v, want web-0 only", pods)
+	}
+
+	out, err = tools["list_resources"].Handler(ctx, map[string]interface{}{"kind": "events", "namespace": "all"})
+	if err != nil || !strings.Contains(out, `"object":"Pod/web-0"`) || !strings.Contains(out, `"reason":"BackOff"`) {
+		t.Errorf("list events = %s, %v", out, err)
+	}
+	if _, err := tools["list_resources"].Handler(ctx, map[string]interface{}{"kind": "secrets"}); err == nil {
+		t.Error("listing secrets should be rejected")
+	}
+
+	if _, err := tools["get_resource_yaml"].Handler(ctx, map[string]interface{}{"resource": "secrets", "name": "db"}); err == nil {
+		t.Error("secret YAML should be rejected")
+	}
+
+	out, err = tools["get_pod_logs"].Handler(ctx, map[string]interface{}{"pod": "web-0", "namespace": "shop", "tail": float64(20000)})
+	var logs map[string]interface{}
+	if err != nil || json.Unmarshal([]byte(out), &logs) != nil {
+		t.Fatalf("get_pod_logs = %q, %v", out, err)
+	}
+	if logs["lines"] != float64(maxLogTail) || logs["logs"] == "" {
+		t.Errorf("logs = %v, want the tail capped at %d", logs, maxLogTail)
+	}
+	if _, err := tools["get_pod_logs"].Handler(ctx, map[string]interface{}{"pod": "web-0", "since": "yesterday"}); err == nil {
+		t.Error("invalid since should be rejected")
+	}
+	if _, err := tools["get_pod_logs"].Handler(ctx, map[string]interface{}{"pod": "web-0", "namespace": "shop", "since": "500ms"}); err != nil {
+		t.Fatalf("get_pod_logs since=500ms: %v", err)
+	}
+	actions := clientset.Actions()
+	opts, _ := actions[len(actions)-1].(k8stesting.GenericAction).GetValue().(*corev1.PodLogOptions)
+	if opts == nil || opts.SinceSeconds == nil || *opts.SinceSeconds != 1 {
+		t.Errorf("since=500ms log options = %+v, want SinceSeconds 1", opts)
+	}
+}
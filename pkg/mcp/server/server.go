@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
+	"sort"
 	"sync"
 	"sync/atomic"
 )
@@ -18,8 +20,13 @@ type Server struct {
 	scanner *bufio.Scanner
 	mu      sync.Mutex
 	tools   map[string]*Tool
+	policy  ToolPolicy
 	running atomic.Bool
 
+	// HTTP+SSE sessions by ID; see SSEHandler
+	sessionsMu sync.Mutex
+	sessions   map[string]*sseSession
+
 	// Server info
 	name    string
 	version string
@@ -31,6 +38,30 @@ type Tool struct {
 	Description string                 `json:"description"`
 	InputSchema map[string]interface{} `json:"inputSchema"`
 	Handler     ToolHandler            `json:"-"`
+	// ReadOnly marks tools that cannot change the cluster; a read-only
+	// ToolPolicy registers only these
+	ReadOnly bool `json:"-"`
+}
+
+// ToolPolicy decides which tools RegisterTool accepts
+type ToolPolicy struct {
+	// Allowed, when set, is the only tool names accepted
+	Allowed []string
+	// Disabled tool names are never accepted
+	Disabled []string
+	// ReadOnly accepts only tools marked ReadOnly
+	ReadOnly bool
+}
+
+// Allows reports whether the policy accepts tool
+func (p ToolPolicy) Allows(tool *Tool) bool {
+	if len(p.Allowed) > 0 && !slices.Contains(p.Allowed, tool.Name) {
+		return false
+	}
+	if slices.Contains(p.Disabled, tool.Name) {
+		return false
+	}
+	return !p.ReadOnly || tool.ReadOnly
 }
 
 // ToolHandler is a function that executes a tool
@@ -137,9 +168,29 @@ func NewWithIO(name, version string, stdin io.Reader, stdout io.Writer) *Server
 	}
 }
 
-// RegisterTool adds a tool to the server
-func (s *Server) RegisterTool(tool *Tool) {
+// SetToolPolicy limits the tools later RegisterTool calls accept
+func (s *Server) SetToolPolicy(policy ToolPolicy) {
+	s.policy = policy
+}
+
+// RegisterTool adds a tool to the server, reporting false when the tool
+// policy rejects it
+func (s *Server) RegisterTool(tool *Tool) bool {
+	if !s.policy.Allows(tool) {
+		return false
+	}
 	s.tools[tool.Name] = tool
+	return true
+}
+
+// ToolNames returns the names of the registered tools, sorted
+func (s *Server) ToolNames() []string {
+	names := make([]string, 0, len(s.tools))
+	for name := range s.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // Run starts the MCP server and processes requests
@@ -174,34 +225,38 @@ func (s *Server) Run(ctx context.Context) error {
 
 		var req JSONRPCRequest
 		if err := json.Unmarshal(line, &req); err != nil {
-			s.sendError(nil, -32700, "Parse error", err.Error())
+			s.send(errorResponse(nil, -32700, "Parse error", err.Error()))
 			continue
 		}
 
-		s.handleRequest(ctx, &req)
+		if resp := s.handleRequest(ctx, &req); resp != nil {
+			s.send(resp)
+		}
 	}
 }
 
-// handleRequest processes a single JSON-RPC request
-func (s *Server) handleRequest(ctx context.Context, req *JSONRPCRequest) {
+// handleRequest processes a single JSON-RPC request, returning the response
+// or nil for notifications
+func (s *Server) handleRequest(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
 	switch req.Method {
 	case "initialize":
-		s.handleInitialize(req)
+		return s.handleInitialize(req)
 	case "notifications/initialized":
 		// No response needed for notifications
+		return nil
 	case "tools/list":
-		s.handleListTools(req)
+		return s.handleListTools(req)
 	case "tools/call":
-		s.handleCallTool(ctx, req)
+		return s.handleCallTool(ctx, req)
 	case "ping":
-		s.sendResult(req.ID, map[string]interface{}{})
+		return resultResponse(req.ID, map[string]interface{}{})
 	default:
-		s.sendError(req.ID, -32601, "Method not found", req.Method)
+		return errorResponse(req.ID, -32601, "Method not found", req.Method)
 	}
 }
 
 // handleInitialize handles the initialize request
-func (s *Server) handleInitialize(req *JSONRPCRequest) {
+func (s *Server) handleInitialize(req *JSONRPCRequest) *JSONRPCResponse {
 	result := InitializeResult{
 		ProtocolVersion: "2024-11-05",
 		Capabilities: Capabilities{
@@ -214,11 +269,11 @@ func (s *Server) handleInitialize(req *JSONRPCRequest) {
 			Version: s.version,
 		},
 	}
-	s.sendResult(req.ID, result)
+	return resultResponse(req.ID, result)
 }
 
 // handleListTools handles the tools/list request
-func (s *Server) handleListTools(req *JSONRPCRequest) {
+func (s *Server) handleListTools(req *JSONRPCRequest) *JSONRPCResponse {
 	tools := make([]ToolDefinition, 0, len(s.tools))
 	for _, t := range s.tools {
 		tools = append(tools, ToolDefinition{
@@ -227,21 +282,19 @@ func (s *Server) handleListTools(req *JSONRPCRequest) {
 			InputSchema: t.InputSchema,
 		})
 	}
-	s.sendResult(req.ID, ListToolsResult{Tools: tools})
+	return resultResponse(req.ID, ListToolsResult{Tools: tools})
 }
 
 // handleCallTool handles the tools/call request
-func (s *Server) handleCallTool(ctx context.Context, req *JSONRPCRequest) {
+func (s *Server) handleCallTool(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
 	var params CallToolParams
 	if err := json.Unmarshal(req.Params, &params); err != nil {
-		s.sendError(req.ID, -32602, "Invalid params", err.Error())
-		return
+		return errorResponse(req.ID, -32602, "Invalid params", err.Error())
 	}
 
 	tool, ok := s.tools[params.Name]
 	if !ok {
-		s.sendError(req.ID, -32602, "Unknown tool", params.Name)
-		return
+		return errorResponse(req.ID, -32602, "Unknown tool", params.Name)
 	}
 
 	output, err := tool.Handler(ctx, params.Arguments)
@@ -259,21 +312,21 @@ func (s *Server) handleCallTool(ctx context.Context, req *JSONRPCRequest) {
 		}
 	}
 
-	s.sendResult(req.ID, result)
+	return resultResponse(req.ID, result)
 }
 
-// sendResult sends a successful response
-func (s *Server) sendResult(id json.RawMessage, result interface{}) {
-	s.send(&JSONRPCResponse{
+// resultResponse builds a successful response
+func resultResponse(id json.RawMessage, result interface{}) *JSONRPCResponse {
+	return &JSONRPCResponse{
 		JSONRPC: "2.0",
 		ID:      id,
 		Result:  result,
-	})
+	}
 }
 
-// sendError sends an error response
-func (s *Server) sendError(id json.RawMessage, code int, message string, data interface{}) {
-	s.send(&JSONRPCResponse{
+// errorResponse builds an error response
+func errorResponse(id json.RawMessage, code int, message string, data interface{}) *JSONRPCResponse {
+	return &JSONRPCResponse{
 		JSONRPC: "2.0",
 		ID:      id,
 		Error: &JSONRPCError{
@@ -281,7 +334,7 @@ func (s *Server) sendError(id json.RawMessage, code int, message string, data in
 			Message: message,
 			Data:    data,
 		},
-	})
+	}
 }
 
 // send writes a response to stdout
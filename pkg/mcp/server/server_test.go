@@ -65,6 +65,36 @@ func TestRegisterTool(t *testing.T) {
 	}
 }
 
+func TestRegisterToolPolicy(t *testing.T) {
+	tools := append(DefaultTools(), KubectlGetTool(), KubectlApplyTool(), ReportTool(nil))
+
+	tests := []struct {
+		name   string
+		policy ToolPolicy
+		want   string
+	}{
+		{"no policy", ToolPolicy{}, "bash,generate_report,kubectl,kubectl_apply,kubectl_get"},
+		{"read-only", ToolPolicy{ReadOnly: true}, "generate_report,kubectl_get"},
+		{"allowlist", ToolPolicy{Allowed: []string{"kubectl", "generate_report"}}, "generate_report,kubectl"},
+		{"disabled", ToolPolicy{Disabled: []string{"bash", "kubectl_apply"}}, "generate_report,kubectl,kubectl_get"},
+		{"allowlist with read-only", ToolPolicy{Allowed: []string{"kubectl", "kubectl_get"}, ReadOnly: true}, "kubectl_get"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New("test", "1.0")
+			s.SetToolPolicy(tt.policy)
+			for _, tool := range tools {
+				if got := s.RegisterTool(tool); got != tt.policy.Allows(tool) {
+					t.Errorf("RegisterTool(%s) = %v, want %v", tool.Name, got, !got)
+				}
+			}
+			if got := strings.Join(s.ToolNames(), ","); got != tt.want {
+				t.Errorf("tools = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestHandleInitialize(t *testing.T) {
 	stdin := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{},"clientInfo":{"name":"test"}}}
 `)
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// sseKeepAlive is how often an idle event stream gets a comment line,
+	// so proxies do not close it
+	sseKeepAlive = 30 * time.Second
+	// maxSSEMessageBytes bounds a posted JSON-RPC message, as the stdio
+	// scanner buffer does
+	maxSSEMessageBytes = 10 * 1024 * 1024
+)
+
+// sseSession is one client's event stream
+type sseSession struct {
+	ctx       context.Context
+	responses chan *JSONRPCResponse
+}
+
+// SSEHandler serves MCP over the HTTP+SSE transport of protocol version
+// 2024-11-05. GET /sse opens an event stream whose first "endpoint" event
+// names the URL to POST JSON-RPC messages to; responses arrive on the stream
+// as "message" events.
+//
+// Every request must send token as a bearer token; an empty token rejects
+// all requests. Requests from a browser page on another origin are rejected,
+// and with localOnly so are requests for a Host other than localhost, which
+// stops DNS rebinding from reaching a server bound to 127.0.0.1.
+func (s *Server) SSEHandler(token string, localOnly bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sse", s.handleSSE)
+	mux.HandleFunc("/message", s.handleSSEMessage)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" && !isLoopbackOrigin(origin) {
+			http.Error(w, "forbidden origin", http.StatusForbidden)
+			return
+		}
+		if localOnly && !isLoopbackHost(r.Host) {
+			http.Error(w, "forbidden host", http.StatusForbidden)
+			return
+		}
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// ServeSSE serves SSEHandler on addr until ctx is done. An addr on a
+// loopback host also gets SSEHandler's localhost Host check.
+func (s *Server) ServeSSE(ctx context.Context, addr, token string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s.SSEHandler(token, isLoopbackHost(host)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return ctx.Err()
+}
+
+// GenerateToken returns a random bearer token for the HTTP transport
+func GenerateToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// isLoopbackHost reports whether host, with or without a port, names
+// localhost or a loopback address
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// isLoopbackOrigin reports whether a browser Origin header is a localhost page
+func isLoopbackOrigin(origin string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && isLoopbackHost(u.Host)
+}
+
+func (s *Server) handleSSE(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	id := newSessionID()
+	session := &sseSession{ctx: r.Context(), responses: make(chan *JSONRPCResponse, 16)}
+	s.sessionsMu.Lock()
+	if s.sessions == nil {
+		s.sessions = make(map[string]*sseSession)
+	}
+	s.sessions[id] = session
+	s.sessionsMu.Unlock()
+	defer func() {
+		s.sessionsMu.Lock()
+		delete(s.sessions, id)
+		s.sessionsMu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	_, _ = fmt.Fprintf(w, "event: endpoint\ndata: /message?sessionId=%s\n\n", id)
+	flusher.Flush()
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			_, _ = io.WriteString(w, ": ping\n\n")
+			flusher.Flush()
+		case resp := <-session.responses:
+			data, err := json.Marshal(resp)
+			if err != nil {
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Server) handleSSEMessage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.sessionsMu.Lock()
+	session := s.sessions[r.URL.Query().Get("sessionId")]
+	s.sessionsMu.Unlock()
+	if session == nil {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+
+	var req JSONRPCRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxSSEMessageBytes)).Decode(&req); err != nil {
+		http.Error(w, "parse error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+
+	// Tool calls can outlast the POST, so they run for the stream's lifetime
+	go func() {
+		resp := s.handleRequest(session.ctx, &req)
+		if resp == nil {
+			return
+		}
+		select {
+		case session.responses <- resp:
+		case <-session.ctx.Done():
+		}
+	}()
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readSSEEvent reads one event from an SSE stream, skipping comments
+func readSSEEvent(t *testing.T, r *bufio.Reader) (event, data string) {
+	t.Helper()
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading event stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "" && event != "":
+			return event, data
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestSSEHandler(t *testing.T) {
+	s := New("test", "1.0")
+	s.RegisterTool(&Tool{
+		Name:        "echo",
+		InputSchema: map[string]interface{}{"type": "object"},
+		Handler: func(ctx context.Context, args map[string]interface{}) (string, error) {
+			return "hello", nil
+		},
+	})
+	ts := httptest.NewServer(s.SSEHandler("secret", false))
+	defer ts.Close()
+
+	// Requests without the token are rejected
+	resp, err := http.Get(ts.URL + "/sse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("unauthenticated status = %d, want 401", resp.StatusCode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	do := func(method, path, body string) *http.Response {
+		req, _ := http.NewRequestWithContext(ctx, method, ts.URL+path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	stream := do(http.MethodGet, "/sse", "")
+	defer stream.Body.Close()
+	if ct := stream.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	events := bufio.NewReader(stream.Body)
+	event, endpoint := readSSEEvent(t, events)
+	if event != "endpoint" || !strings.HasPrefix(endpoint, "/message?sessionId=") {
+		t.Fatalf("first event = %s %q, want endpoint", event, endpoint)
+	}
+
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`, `"protocolVersion":"2024-11-05"`},
+		{`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`, `"name":"echo"`},
+		{`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo"}}`, `"text":"hello"`},
+	}
+	for _, tt := range tests {
+		resp := do(http.MethodPost, endpoint, tt.body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("POST status = %d, want 202", resp.StatusCode)
+		}
+		event, data := readSSEEvent(t, events)
+		if event != "message" || !strings.Contains(data, tt.want) {
+			t.Errorf("response to %s = %s %s, want %s", tt.body, event, data, tt.want)
+		}
+		var rpc JSONRPCResponse
+		if err := json.Unmarshal([]byte(data), &rpc); err != nil || rpc.Error != nil {
+			t.Errorf("response %s: err=%v rpcErr=%v", data, err, rpc.Error)
+		}
+	}
+
+	// Unknown sessions are rejected
+	resp = do(http.MethodPost, "/message?sessionId=nope", `{"jsonrpc":"2.0","id":4,"method":"ping"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown session status = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestSSEHandler_RejectsForeignHostAndOrigin(t *testing.T) {
+	s := New("test", "1.0")
+	tests := []struct {
+		name      string
+		token     string
+		localOnly bool
+		host      string
+		origin    string
+		want      int
+	}{
+		{name: "empty token rejects everything", token: "", host: "localhost:8090", want: http.StatusUnauthorized},
+		{name: "rebound host on a local server", token: "secret", localOnly: true, host: "evil.example:8090", want: http.StatusForbidden},
+		{name: "foreign origin", token: "secret", host: "mcp.example:8090", origin: "https://evil.example", want: http.StatusForbidden},
+		{name: "localhost origin", token: "secret", localOnly: true, host: "127.0.0.1:8090", origin: "http://localhost:3000", want: http.StatusNotFound},
+		{name: "remote host without origin", token: "secret", host: "mcp.example:8090", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// An unknown session answers 404 once the request passes the checks
+			req := httptest.NewRequest(http.MethodPost, "/message?sessionId=nope", strings.NewReader("{}"))
+			req.Host = tt.host
+			req.Header.Set("Authorization", "Bearer secret")
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+			s.SSEHandler(tt.token, tt.localOnly).ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -45,7 +45,7 @@ func KubectlTool() *Tool {
 func KubectlGetTool() *Tool {
 	return &Tool{
 		Name:        "kubectl_get",
-		Description: "Get Kubernetes resources. Returns a list of resources with their status.",
+		Description: "Get Kubernetes resources. Returns a list of resources with their status. Secrets are not available.",
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -73,7 +73,8 @@ func KubectlGetTool() *Tool {
 			},
 			"required": []string{"resource"},
 		},
-		Handler: kubectlGetHandler,
+		Handler:  kubectlGetHandler,
+		ReadOnly: true,
 	}
 }
 
@@ -100,7 +101,8 @@ func KubectlDescribeTool() *Tool {
 			},
 			"required": []string{"resource", "name"},
 		},
-		Handler: kubectlDescribeHandler,
+		Handler:  kubectlDescribeHandler,
+		ReadOnly: true,
 	}
 }
 
@@ -135,7 +137,8 @@ func KubectlLogsTool() *Tool {
 			},
 			"required": []string{"pod"},
 		},
-		Handler: kubectlLogsHandler,
+		Handler:  kubectlLogsHandler,
+		ReadOnly: true,
 	}
 }
 
@@ -166,6 +169,42 @@ func KubectlApplyTool() *Tool {
 	}
 }
 
+// ReportRenderer generates a cluster report of the comma-separated
+// sections (empty for the configured defaults) in the given format
+type ReportRenderer func(ctx context.Context, sections, format string) (string, error)
+
+// ReportTool returns the generate_report tool, which returns the cluster
+// assessment report produced by render. It is not in DefaultTools because it
+// needs a Kubernetes client; see web.NewStandaloneReportGenerator.
+func ReportTool(render ReportRenderer) *Tool {
+	return &Tool{
+		Name:        "generate_report",
+		Description: "Generates a structured Kubernetes cluster assessment report: node health, namespaces, workloads, events, security checks, FinOps cost analysis, node capacity, configuration drift, and deprecated API versions in use, with an overall health score. Use it for a cluster-wide health overview instead of many kubectl calls.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"sections": map[string]interface{}{
+					"type":        "string",
+					"description": "Comma-separated sections to include: nodes, namespaces, workloads, events, security, security_full, finops, metrics, capacity, drift, deprecated_apis. Omit for the configured default sections.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format (default: json)",
+					"enum":        []string{"json", "csv", "html"},
+				},
+			},
+		},
+		Handler: func(ctx context.Context, args map[string]interface{}) (string, error) {
+			sections, _ := args["sections"].(string)
+			format, _ := args["format"].(string)
+			ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+			defer cancel()
+			return render(ctx, sections, format)
+		},
+		ReadOnly: true,
+	}
+}
+
 // BashTool returns the bash tool
 func BashTool() *Tool {
 	return &Tool{
@@ -237,6 +276,11 @@ func kubectlGetHandler(ctx context.Context, args map[string]interface{}) (string
 	if resource == "" {
 		return "", fmt.Errorf("resource is required")
 	}
+	// kubectl_get is registered under the read-only policy, so it must not
+	// hand out Secret data any more than get_resource_yaml does
+	if namesSecrets(resource) || (strings.Contains(name, "/") && namesSecrets(name)) {
+		return "", fmt.Errorf("secrets are not available through MCP")
+	}
 
 	cmdArgs := []string{"get", resource}
 	if name != "" {
@@ -257,6 +301,19 @@ func kubectlGetHandler(ctx context.Context, args map[string]interface{}) (string
 	return runKubectlArgs(ctx, cmdArgs, 30*time.Second)
 }
 
+// namesSecrets reports whether a kubectl resource argument such as
+// "secrets", "Secret.v1", "secret/db" or "pods,secrets" includes Secrets
+func namesSecrets(arg string) bool {
+	for _, part := range strings.Split(strings.ToLower(arg), ",") {
+		kind, _, _ := strings.Cut(strings.TrimSpace(part), "/")
+		kind, _, _ = strings.Cut(kind, ".")
+		if kind == "secret" || kind == "secrets" {
+			return true
+		}
+	}
+	return false
+}
+
 func kubectlDescribeHandler(ctx context.Context, args map[string]interface{}) (string, error) {
 	resource, _ := args["resource"].(string)
 	name, _ := args["name"].(string)
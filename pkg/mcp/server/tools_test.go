@@ -1,6 +1,8 @@
 package server
 
 import (
+	"context"
+	"strings"
 	"testing"
 )
 
@@ -108,6 +110,26 @@ func TestKubectlGetTool(t *testing.T) {
 	}
 }
 
+func TestKubectlGetRejectsSecrets(t *testing.T) {
+	tool := KubectlGetTool()
+	for _, args := range []map[string]interface{}{
+		{"resource": "secrets"},
+		{"resource": "Secret"},
+		{"resource": "secrets.v1", "namespace": "all"},
+		{"resource": "pods,secrets"},
+		{"resource": "pods", "name": "secret/db"},
+	} {
+		if _, err := tool.Handler(context.Background(), args); err == nil || !strings.Contains(err.Error(), "secrets are not available") {
+			t.Errorf("kubectl_get %v error = %v, want secrets refused", args, err)
+		}
+	}
+	for _, arg := range []string{"pods", "secretstores", "pods,svc", "sealedsecrets.bitnami.com"} {
+		if namesSecrets(arg) {
+			t.Errorf("namesSecrets(%q) = true, want false", arg)
+		}
+	}
+}
+
 func TestKubectlDescribeTool(t *testing.T) {
 	tool := KubectlDescribeTool()
 
@@ -196,3 +218,32 @@ func TestToolSchemaType(t *testing.T) {
 		}
 	}
 }
+
+func TestReportTool(t *testing.T) {
+	var gotSections, gotFormat string
+	tool := ReportTool(func(ctx context.Context, sections, format string) (string, error) {
+		gotSections, gotFormat = sections, format
+		return `{"health_score":90}`, nil
+	})
+
+	if tool.Name != "generate_report" || tool.Description == "" {
+		t.Errorf("tool = %q %q", tool.Name, tool.Description)
+	}
+	props, ok := tool.InputSchema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("InputSchema properties not found")
+	}
+	for _, name := range []string{"sections", "format"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("%s property not found in InputSchema", name)
+		}
+	}
+
+	out, err := tool.Handler(context.Background(), map[string]interface{}{"sections": "nodes,finops", "format": "csv"})
+	if err != nil || out != `{"health_score":90}` {
+		t.Fatalf("Handler() = %q, %v", out, err)
+	}
+	if gotSections != "nodes,finops" || gotFormat != "csv" {
+		t.Errorf("render got sections %q, format %q", gotSections, gotFormat)
+	}
+}
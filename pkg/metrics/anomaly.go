@@ -0,0 +1,250 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+)
+
+// Anomaly kinds
+const (
+	AnomalyMemoryTrend   = "memory_trend"
+	AnomalyPodDrop       = "pod_drop"
+	AnomalyCPUSaturation = "cpu_saturation"
+)
+
+// Anomaly severities
+const (
+	AnomalyWarning  = "warning"
+	AnomalyCritical = "critical"
+)
+
+// Default anomaly thresholds, used for zero config.AnomalyConfig fields
+const (
+	defaultMemoryGrowthPercent  = 20
+	defaultPodDropPercent       = 30
+	defaultCPUSaturationPercent = 90
+	defaultCPUSaturationMinutes = 15
+)
+
+const (
+	// memoryTrendMinPoints and memoryTrendMinFit keep a few noisy samples
+	// from passing for a trend: the fitted line must explain most of the
+	// variation (R²)
+	memoryTrendMinPoints = 6
+	memoryTrendMinFit    = 0.8
+	// memoryFullCriticalHours makes a memory trend critical when the line
+	// reaches the cluster's capacity within this many hours
+	memoryFullCriticalHours = 24
+	// podDropMinPods ignores drops of fewer pods, e.g. 2 to 1
+	podDropMinPods = 5
+)
+
+// Anomaly is something in the cluster metrics history worth a look
+type Anomaly struct {
+	Kind     string    `json:"kind"`
+	Severity string    `json:"severity"`
+	Message  string    `json:"message"`
+	At       time.Time `json:"at"` // The sample the anomaly was last seen in
+}
+
+// DetectAnomalies checks the cluster metrics samples, in any order, for
+// memory usage that rises steadily (a linear fit over all samples), the
+// largest sudden fall in running pods between two samples, and the longest
+// run of CPU usage near capacity. Samples of several contexts or namespaces
+// must be filtered out first.
+func DetectAnomalies(samples []db.ClusterMetrics, cfg config.AnomalyConfig) []Anomaly {
+	if len(samples) < 2 {
+		return nil
+	}
+	points := make([]db.ClusterMetrics, len(samples))
+	copy(points, samples)
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Timestamp.Before(points[j].Timestamp)
+	})
+
+	var anomalies []Anomaly
+	if a, ok := detectMemoryTrend(points, orDefault(cfg.MemoryGrowthPercent, defaultMemoryGrowthPercent)); ok {
+		anomalies = append(anomalies, a)
+	}
+	if a, ok := detectPodDrop(points, orDefault(cfg.PodDropPercent, defaultPodDropPercent)); ok {
+		anomalies = append(anomalies, a)
+	}
+	minutes := cfg.CPUSaturationMinutes
+	if minutes <= 0 {
+		minutes = defaultCPUSaturationMinutes
+	}
+	if a, ok := detectCPUSaturation(points, orDefault(cfg.CPUSaturationPercent, defaultCPUSaturationPercent), time.Duration(minutes)*time.Minute); ok {
+		anomalies = append(anomalies, a)
+	}
+	return anomalies
+}
+
+func orDefault(v, def float64) float64 {
+	if v <= 0 {
+		return def
+	}
+	return v
+}
+
+// detectMemoryTrend fits a line to used memory over time and flags it when
+// the line rises by growthPercent or more across the samples
+func detectMemoryTrend(points []db.ClusterMetrics, growthPercent float64) (Anomaly, bool) {
+	if len(points) < memoryTrendMinPoints {
+		return Anomaly{}, false
+	}
+	first := points[0].Timestamp
+	n := float64(len(points))
+	var sumX, sumY float64
+	for _, p := range points {
+		sumX += p.Timestamp.Sub(first).Hours()
+		sumY += float64(p.UsedMemoryMB)
+	}
+	meanX, meanY := sumX/n, sumY/n
+	var sxx, sxy, syy float64
+	for _, p := range points {
+		dx := p.Timestamp.Sub(first).Hours() - meanX
+		dy := float64(p.UsedMemoryMB) - meanY
+		sxx += dx * dx
+		sxy += dx * dy
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 || sxy <= 0 {
+		return Anomaly{}, false
+	}
+	slope := sxy / sxx // MB per hour
+	if fit := sxy * sxy / (sxx * syy); fit < memoryTrendMinFit {
+		return Anomaly{}, false
+	}
+
+	last := points[len(points)-1]
+	span := last.Timestamp.Sub(first)
+	start := meanY - slope*meanX
+	end := start + slope*span.Hours()
+	if start <= 0 || (end-start)/start*100 < growthPercent {
+		return Anomaly{}, false
+	}
+
+	a := Anomaly{
+		Kind:     AnomalyMemoryTrend,
+		Severity: AnomalyWarning,
+		Message: fmt.Sprintf("Memory usage rose steadily from %.0f MB to %.0f MB over %s (+%.0f MB/h); check for a memory leak",
+			start, end, formatSpan(span), slope),
+		At: last.Timestamp,
+	}
+	if last.TotalMemoryMB > last.UsedMemoryMB {
+		hoursLeft := float64(last.TotalMemoryMB-last.UsedMemoryMB) / slope
+		if hoursLeft <= memoryFullCriticalHours {
+			a.Severity = AnomalyCritical
+			a.Message += fmt.Sprintf(", capacity reached in about %s at this rate", formatSpan(time.Duration(hoursLeft*float64(time.Hour))))
+		}
+	}
+	return a, true
+}
+
+// detectPodDrop flags the largest fall in running pods between two samples
+// of dropPercent or more
+func detectPodDrop(points []db.ClusterMetrics, dropPercent float64) (Anomaly, bool) {
+	var (
+		worst      Anomaly
+		worstShare float64
+	)
+	for i := 1; i < len(points); i++ {
+		prev, cur := points[i-1].RunningPods, points[i].RunningPods
+		drop := prev - cur
+		if drop < podDropMinPods {
+			continue
+		}
+		share := float64(drop) / float64(prev) * 100
+		if share < dropPercent || share <= worstShare {
+			continue
+		}
+		worstShare = share
+		worst = Anomaly{
+			Kind:     AnomalyPodDrop,
+			Severity: AnomalyWarning,
+			Message: fmt.Sprintf("Running pods dropped from %d to %d (-%.0f%%) at %s",
+				prev, cur, share, points[i].Timestamp.Format("15:04")),
+			At: points[i].Timestamp,
+		}
+		if share >= 50 {
+			worst.Severity = AnomalyCritical
+		}
+	}
+	if worstShare == 0 {
+		return Anomaly{}, false
+	}
+	if now := points[len(points)-1].RunningPods; !worst.At.Equal(points[len(points)-1].Timestamp) {
+		worst.Message += fmt.Sprintf(", now %d", now)
+	}
+	return worst, true
+}
+
+// detectCPUSaturation flags the longest run of samples with CPU usage at or
+// above percent of capacity that lasts minDuration or more
+func detectCPUSaturation(points []db.ClusterMetrics, percent float64, minDuration time.Duration) (Anomaly, bool) {
+	var (
+		bestStart, bestEnd = -1, -1
+		runStart           = -1
+	)
+	usage := func(p db.ClusterMetrics) float64 {
+		if p.TotalCPUMillis <= 0 {
+			return 0
+		}
+		return float64(p.UsedCPUMillis) / float64(p.TotalCPUMillis) * 100
+	}
+	for i, p := range points {
+		if usage(p) < percent {
+			runStart = -1
+			continue
+		}
+		if runStart < 0 {
+			runStart = i
+		}
+		if bestStart < 0 || p.Timestamp.Sub(points[runStart].Timestamp) > points[bestEnd].Timestamp.Sub(points[bestStart].Timestamp) {
+			bestStart, bestEnd = runStart, i
+		}
+	}
+	if bestStart < 0 {
+		return Anomaly{}, false
+	}
+	span := points[bestEnd].Timestamp.Sub(points[bestStart].Timestamp)
+	if span < minDuration {
+		return Anomaly{}, false
+	}
+
+	var peak float64
+	for _, p := range points[bestStart : bestEnd+1] {
+		peak = max(peak, usage(p))
+	}
+	a := Anomaly{
+		Kind:     AnomalyCPUSaturation,
+		Severity: AnomalyWarning,
+		Message: fmt.Sprintf("CPU usage at or above %.0f%% of capacity for %s (peak %.0f%%)",
+			percent, formatSpan(span), peak),
+		At: points[bestEnd].Timestamp,
+	}
+	if bestEnd == len(points)-1 {
+		a.Severity = AnomalyCritical
+		a.Message += ", still ongoing"
+	} else {
+		a.Message += ", until " + points[bestEnd].Timestamp.Format("15:04")
+	}
+	return a, true
+}
+
+// formatSpan renders d as "45m", "6h", or "6h30m"
+func formatSpan(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dh%dm", h, m)
+}
@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+)
+
+// anomalySamples returns n samples five minutes apart, newest first as the
+// store returns them, filled in by fill
+func anomalySamples(n int, fill func(i int, m *db.ClusterMetrics)) []db.ClusterMetrics {
+	base := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	samples := make([]db.ClusterMetrics, n)
+	for i := range samples {
+		m := db.ClusterMetrics{
+			Timestamp:      base.Add(time.Duration(i) * 5 * time.Minute),
+			RunningPods:    100,
+			TotalCPUMillis: 10000,
+			UsedCPUMillis:  4000,
+			TotalMemoryMB:  100000,
+			UsedMemoryMB:   10000,
+		}
+		fill(i, &m)
+		samples[n-1-i] = m
+	}
+	return samples
+}
+
+func anomalyKinds(anomalies []Anomaly) []string {
+	kinds := make([]string, len(anomalies))
+	for i, a := range anomalies {
+		kinds[i] = a.Kind
+	}
+	return kinds
+}
+
+func TestDetectAnomalies_Quiet(t *testing.T) {
+	samples := anomalySamples(24, func(i int, m *db.ClusterMetrics) {
+		// Noise around a flat line
+		m.UsedMemoryMB += int64(i%3) * 200
+		m.RunningPods -= i % 2
+	})
+	if got := DetectAnomalies(samples, config.AnomalyConfig{}); len(got) != 0 {
+		t.Errorf("DetectAnomalies() = %+v, want none", got)
+	}
+	if got := DetectAnomalies(nil, config.AnomalyConfig{}); got != nil {
+		t.Errorf("DetectAnomalies(nil) = %+v, want nil", got)
+	}
+}
+
+func TestDetectAnomalies_MemoryTrend(t *testing.T) {
+	samples := anomalySamples(12, func(i int, m *db.ClusterMetrics) {
+		m.UsedMemoryMB = 10000 + int64(i)*500
+		m.TotalMemoryMB = 1000000
+	})
+	got := DetectAnomalies(samples, config.AnomalyConfig{})
+	if len(got) != 1 || got[0].Kind != AnomalyMemoryTrend || got[0].Severity != AnomalyWarning {
+		t.Fatalf("DetectAnomalies() = %+v, want one memory trend warning", got)
+	}
+	if !strings.Contains(got[0].Message, "from 10000 MB to 15500 MB over 55m (+6000 MB/h)") {
+		t.Errorf("Message = %q", got[0].Message)
+	}
+
+	// The same trend on a cluster about to run out of memory
+	full := anomalySamples(12, func(i int, m *db.ClusterMetrics) {
+		m.UsedMemoryMB = 10000 + int64(i)*500
+		m.TotalMemoryMB = 20000
+	})
+	got = DetectAnomalies(full, config.AnomalyConfig{})
+	if len(got) != 1 || got[0].Severity != AnomalyCritical || !strings.Contains(got[0].Message, "capacity reached in about 45m") {
+		t.Errorf("DetectAnomalies() = %+v, want a critical trend with the time left", got)
+	}
+
+	// A higher threshold does not flag it
+	if got := DetectAnomalies(samples, config.AnomalyConfig{MemoryGrowthPercent: 80}); len(got) != 0 {
+		t.Errorf("DetectAnomalies(80%%) = %+v, want none", got)
+	}
+}
+
+func TestDetectAnomalies_PodDrop(t *testing.T) {
+	samples := anomalySamples(10, func(i int, m *db.ClusterMetrics) {
+		switch {
+		case i == 4:
+			m.RunningPods = 60
+		case i > 4:
+			m.RunningPods = 90
+		}
+	})
+	got := DetectAnomalies(samples, config.AnomalyConfig{})
+	if len(got) != 1 || got[0].Kind != AnomalyPodDrop || got[0].Severity != AnomalyWarning {
+		t.Fatalf("DetectAnomalies() = %+v, want one pod drop", got)
+	}
+	if want := "Running pods dropped from 100 to 60 (-40%) at 08:20, now 90"; got[0].Message != want {
+		t.Errorf("Message = %q, want %q", got[0].Message, want)
+	}
+
+	// Small clusters: 4 to 1 is 75% but only 3 pods
+	small := anomalySamples(4, func(i int, m *db.ClusterMetrics) {
+		m.RunningPods = 4
+		if i == 3 {
+			m.RunningPods = 1
+		}
+	})
+	if got := DetectAnomalies(small, config.AnomalyConfig{}); len(got) != 0 {
+		t.Errorf("DetectAnomalies() = %+v, want none for a drop of 3 pods", got)
+	}
+}
+
+func TestDetectAnomalies_CPUSaturation(t *testing.T) {
+	// 08:10-08:35 saturated, then back to normal
+	samples := anomalySamples(12, func(i int, m *db.ClusterMetrics) {
+		if i >= 2 && i <= 7 {
+			m.UsedCPUMillis = 9500
+		}
+	})
+	got := DetectAnomalies(samples, config.AnomalyConfig{})
+	if len(got) != 1 || got[0].Kind != AnomalyCPUSaturation || got[0].Severity != AnomalyWarning {
+		t.Fatalf("DetectAnomalies() = %+v, want one CPU saturation warning", got)
+	}
+	if want := "CPU usage at or above 90% of capacity for 25m (peak 95%), until 08:35"; got[0].Message != want {
+		t.Errorf("Message = %q, want %q", got[0].Message, want)
+	}
+	if got := DetectAnomalies(samples, config.AnomalyConfig{CPUSaturationMinutes: 30}); len(got) != 0 {
+		t.Errorf("DetectAnomalies(30m) = %+v, want none", got)
+	}
+
+	ongoing := anomalySamples(6, func(i int, m *db.ClusterMetrics) {
+		m.UsedCPUMillis = 9800
+	})
+	got = DetectAnomalies(ongoing, config.AnomalyConfig{})
+	if len(got) != 1 || got[0].Severity != AnomalyCritical || !strings.HasSuffix(got[0].Message, "still ongoing") {
+		t.Errorf("DetectAnomalies() = %v, want critical ongoing saturation", anomalyKinds(got))
+	}
+}
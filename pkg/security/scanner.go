@@ -282,10 +282,7 @@ func (s *Scanner) scanImages(ctx context.Context, namespace string) (*ImageVulnS
 	// Collect unique images
 	imageMap := make(map[string][]struct{ namespace, pod string })
 	for _, pod := range pods.Items {
-		for _, container := range pod.Spec.Containers {
-			imageMap[container.Image] = append(imageMap[container.Image], struct{ namespace, pod string }{pod.Namespace, pod.Name})
-		}
-		for _, container := range pod.Spec.InitContainers {
+		for _, container := range k8s.AllContainers(&pod.Spec) {
 			imageMap[container.Image] = append(imageMap[container.Image], struct{ namespace, pod string }{pod.Namespace, pod.Name})
 		}
 	}
@@ -417,7 +414,33 @@ func (s *Scanner) checkPodSecurity(ctx context.Context, namespace string) ([]Pod
 		// Skip system namespaces for some checks
 		isSystemNS := isSystemNamespace(pod.Namespace)
 
-		for _, container := range pod.Spec.Containers {
+		// Check for host namespaces
+		if pod.Spec.HostPID {
+			issues = append(issues, PodSecurityIssue{
+				Namespace:   pod.Namespace,
+				Pod:         pod.Name,
+				Issue:       "Pod uses host PID namespace",
+				Severity:    "HIGH",
+				Remediation: "Set hostPID to false unless absolutely required",
+			})
+		}
+
+		if pod.Spec.HostNetwork {
+			if !isSystemNS {
+				issues = append(issues, PodSecurityIssue{
+					Namespace:   pod.Namespace,
+					Pod:         pod.Name,
+					Issue:       "Pod uses host network",
+					Severity:    "MEDIUM",
+					Remediation: "Set hostNetwork to false unless absolutely required",
+				})
+			}
+		}
+
+		// Init and ephemeral debug containers run with the pod's privileges
+		// too. Ephemeral containers cannot set resources.
+		firstEphemeral := len(pod.Spec.InitContainers) + len(pod.Spec.Containers)
+		for i, container := range k8s.AllContainers(&pod.Spec) {
 			sc := container.SecurityContext
 
 			// Check for privileged containers
@@ -448,29 +471,6 @@ func (s *Scanner) checkPodSecurity(ctx context.Context, namespace string) ([]Pod
 				}
 			}
 
-			// Check for host namespaces
-			if pod.Spec.HostPID {
-				issues = append(issues, PodSecurityIssue{
-					Namespace:   pod.Namespace,
-					Pod:         pod.Name,
-					Issue:       "Pod uses host PID namespace",
-					Severity:    "HIGH",
-					Remediation: "Set hostPID to false unless absolutely required",
-				})
-			}
-
-			if pod.Spec.HostNetwork {
-				if !isSystemNS {
-					issues = append(issues, PodSecurityIssue{
-						Namespace:   pod.Namespace,
-						Pod:         pod.Name,
-						Issue:       "Pod uses host network",
-						Severity:    "MEDIUM",
-						Remediation: "Set hostNetwork to false unless absolutely required",
-					})
-				}
-			}
-
 			// Check for capability additions
 			if sc != nil && sc.Capabilities != nil {
 				for _, cap := range sc.Capabilities.Add {
@@ -488,7 +488,7 @@ func (s *Scanner) checkPodSecurity(ctx context.Context, namespace string) ([]Pod
 			}
 
 			// Check for missing resource limits
-			if container.Resources.Limits.Cpu().IsZero() || container.Resources.Limits.Memory().IsZero() {
+			if i < firstEphemeral && (container.Resources.Limits.Cpu().IsZero() || container.Resources.Limits.Memory().IsZero()) {
 				if !isSystemNS {
 					issues = append(issues, PodSecurityIssue{
 						Namespace:   pod.Namespace,
@@ -794,7 +794,7 @@ func (s *Scanner) performBasicCISChecks(ctx context.Context, namespace string) (
 					if isSystemNamespace(pod.Namespace) {
 						continue
 					}
-					for _, c := range pod.Spec.Containers {
+					for _, c := range k8s.AllContainers(&pod.Spec) {
 						if c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
 							return false
 						}
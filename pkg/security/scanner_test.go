@@ -3,6 +3,7 @@ package security
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -721,6 +722,50 @@ func TestCheckPodSecurity(t *testing.T) {
 	}
 }
 
+func TestCheckPodSecurity_InitAndEphemeralContainers(t *testing.T) {
+	limits := corev1.ResourceRequirements{Limits: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("128Mi"),
+	}}
+	nonRoot := &corev1.SecurityContext{RunAsNonRoot: boolPtr(true)}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "myapp"},
+		Spec: corev1.PodSpec{
+			HostPID: true,
+			InitContainers: []corev1.Container{{
+				Name: "setup", Image: "busybox:1.36", Resources: limits,
+				SecurityContext: &corev1.SecurityContext{Privileged: boolPtr(true), RunAsNonRoot: boolPtr(true)},
+			}},
+			Containers: []corev1.Container{
+				{Name: "app", Image: "nginx:1.25", Resources: limits, SecurityContext: nonRoot},
+				{Name: "sidecar", Image: "envoyproxy/envoy:v1.30", Resources: limits, SecurityContext: nonRoot},
+			},
+			EphemeralContainers: []corev1.EphemeralContainer{
+				{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger", Image: "nicolaka/netshoot:v0.13"}},
+			},
+		},
+	}
+
+	scanner := &Scanner{k8sClient: &k8s.Client{Clientset: buildFakeClientsetFromPods(pod)}}
+	issues, err := scanner.checkPodSecurity(context.Background(), "")
+	if err != nil {
+		t.Fatalf("checkPodSecurity() error: %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.Container+": "+issue.Issue)
+	}
+	want := []string{
+		": Pod uses host PID namespace",
+		"setup: Container running in privileged mode",
+		"debugger: Container may run as root",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("issues =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
 func containsCI(s, substr string) bool {
 	return len(s) >= len(substr) &&
 		(contains(s, substr) || contains(lower(s), lower(substr)))
@@ -1050,6 +1095,9 @@ func TestScanImages_NoTrivy(t *testing.T) {
 				InitContainers: []corev1.Container{
 					{Name: "init1", Image: "busybox:1.36"},
 				},
+				EphemeralContainers: []corev1.EphemeralContainer{
+					{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger", Image: "nicolaka/netshoot:v0.13"}},
+				},
 			},
 		},
 		{
@@ -1071,8 +1119,8 @@ func TestScanImages_NoTrivy(t *testing.T) {
 		t.Fatalf("scanImages() error: %v", err)
 	}
 
-	if summary.TotalImages != 3 {
-		t.Errorf("TotalImages = %d, want 3 (nginx, redis, busybox)", summary.TotalImages)
+	if summary.TotalImages != 4 {
+		t.Errorf("TotalImages = %d, want 4 (nginx, redis, busybox, netshoot)", summary.TotalImages)
 	}
 	if summary.ScannedImages != 0 {
 		t.Errorf("ScannedImages = %d, want 0 (no trivy)", summary.ScannedImages)
@@ -3,6 +3,8 @@ package testutil
 import (
 	"context"
 	"sync"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai/providers"
 )
 
 // MockLLMProvider is a reusable mock for LLM providers.
@@ -45,6 +47,11 @@ func (m *MockLLMProvider) ListModels(_ context.Context) ([]string, error) {
 	return m.ModelsValue, m.ModelsError
 }
 
+// Capabilities reports streaming, and tools when SupportsTools is set.
+func (m *MockLLMProvider) Capabilities() providers.Capabilities {
+	return providers.Capabilities{Streaming: true, Tools: m.SupportsTools}
+}
+
 // Ask simulates a streaming LLM call.
 func (m *MockLLMProvider) Ask(_ context.Context, prompt string, callback func(string)) error {
 	m.mu.Lock()
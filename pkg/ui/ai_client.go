@@ -0,0 +1,31 @@
+//go:build !noai
+
+package ui
+
+import (
+	"context"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai"
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai/safety"
+	aitools "github.com/cloudbro-kube-ai/k13d/pkg/ai/tools"
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+// llmClient is the AI assistant's client; builds with the noai tag replace it
+// with a stub that is never ready
+type llmClient = ai.Client
+
+func newLLMClient(cfg *config.LLMConfig) (*llmClient, error) {
+	return ai.NewClient(cfg)
+}
+
+// withAIQueueNotice calls notify when a request waits for a free AI slot
+func withAIQueueNotice(ctx context.Context, notify func()) context.Context {
+	return ai.WithQueueNotice(ctx, notify)
+}
+
+// installToolGuard enforces tool restrictions again at execution, after any
+// approval
+func (a *App) installToolGuard() {
+	aitools.SetCommandGuard(safety.ToolGuard(a.currentToolApprovalPolicy))
+}
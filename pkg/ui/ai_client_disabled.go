@@ -0,0 +1,72 @@
+//go:build noai
+
+package ui
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+// Builds with the noai tag leave out pkg/ai and its providers. The app never
+// has an AI client, the AI panel stays hidden, and the entry points below
+// only report that the assistant is missing.
+
+// errAINotBuilt is returned by AI entry points in noai builds
+var errAINotBuilt = errors.New("the AI assistant is not included in this build (noai)")
+
+type llmClient struct{}
+
+// IsReady always reports false in noai builds
+func (c *llmClient) IsReady() bool { return false }
+
+// GetProvider is empty in noai builds
+func (c *llmClient) GetProvider() string { return "" }
+
+// GetModel is empty in noai builds
+func (c *llmClient) GetModel() string { return "" }
+
+// SupportsTools always reports false in noai builds
+func (c *llmClient) SupportsTools() bool { return false }
+
+// Ask is unavailable in noai builds
+func (c *llmClient) Ask(ctx context.Context, prompt string, callback func(string)) error {
+	return errAINotBuilt
+}
+
+func newLLMClient(cfg *config.LLMConfig) (*llmClient, error) {
+	return nil, errAINotBuilt
+}
+
+func withAIQueueNotice(ctx context.Context, notify func()) context.Context {
+	return ctx
+}
+
+func (a *App) installToolGuard() {}
+
+func (a *App) handleAICommand(input string) bool { return false }
+
+func (a *App) askAI(question string) {
+	a.flashMsg(errAINotBuilt.Error(), true)
+}
+
+func (a *App) askAIWithEvidence(question, evidence string) {
+	a.flashMsg(errAINotBuilt.Error(), true)
+}
+
+func (a *App) showSettings() {
+	a.flashMsg(errAINotBuilt.Error(), true)
+}
+
+func (a *App) toggleApproveReads() {
+	a.flashMsg(errAINotBuilt.Error(), true)
+}
+
+func (a *App) approveToolCall(approved bool) {}
+
+func (a *App) executeDecision(idx int) {}
+
+func (a *App) executeAllDecisions() {}
+
+func (a *App) clearPendingDecisions() {}
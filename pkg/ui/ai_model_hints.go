@@ -1,9 +1,12 @@
+//go:build !noai
+
 package ui
 
 import (
 	"fmt"
 	"strings"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai/providers"
 	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 )
 
@@ -25,6 +28,8 @@ API Key: %s  Endpoint: %s
 		map[bool]string{true: "[green]Set[white]", false: "[red]Not set[white]"}[hasAPIKey],
 		map[bool]string{true: "[green]" + endpoint + "[white]", false: "[gray](default)[white]"}[endpoint != ""])
 
+	infoText += buildCapabilitiesText(providers.CapabilitiesFor(provider, model))
+
 	if provider == "ollama" {
 		infoText += fmt.Sprintf(" [yellow]Tools Required:[white] %s\n", ollamaModelToolsHint(model))
 	}
@@ -32,6 +37,25 @@ API Key: %s  Endpoint: %s
 	return infoText
 }
 
+// buildCapabilitiesText renders a provider's feature matrix as one line of
+// the settings info
+func buildCapabilitiesText(caps providers.Capabilities) string {
+	mark := map[bool]string{true: "[green]✓[white]", false: "[red]✗[white]"}
+	text := fmt.Sprintf(" Features: streaming %s  tools %s  vision %s", mark[caps.Streaming], mark[caps.Tools], mark[caps.Vision])
+	if !caps.Tools {
+		text += "  [gray](agentic tools disabled)[white]"
+	}
+	return text + "\n"
+}
+
+// aiToolsUnavailableNote explains why a question is answered in chat mode
+func aiToolsUnavailableNote(provider string) string {
+	if provider == "" {
+		provider = "The current provider"
+	}
+	return fmt.Sprintf("%s does not support tool calling, so the assistant cannot run kubectl or other tools. Commands it suggests are offered for approval instead.", provider)
+}
+
 func buildToolApprovalInfoText(policy config.ToolApprovalPolicy) string {
 	policy = effectiveUIToolApprovalPolicy(policy)
 
@@ -41,12 +65,14 @@ func buildToolApprovalInfoText(policy config.ToolApprovalPolicy) string {
  Dangerous commands blocked: %s
  Unknown approval required: %s
  Approval timeout: [yellow]%ds[white]
- Hard blocks: [red]interactive kubectl, bash-wrapped kubectl/helm, blocked patterns[white]
+ Safe tools (read-only kubectl only): %s
+ Hard blocks: [red]interactive kubectl, bash-wrapped kubectl/helm, blocked patterns, verb/resource restrictions[white]
 `,
 		map[bool]string{true: "[green]On[white]", false: "[red]Off[white]"}[policy.AutoApproveReadOnly],
 		map[bool]string{true: "[green]On[white]", false: "[red]Off[white]"}[policy.RequireApprovalForWrite],
 		map[bool]string{true: "[green]On[white]", false: "[red]Off[white]"}[policy.BlockDangerous],
 		map[bool]string{true: "[green]On[white]", false: "[red]Off[white]"}[policy.RequireApprovalForUnknown],
 		policy.ApprovalTimeoutSeconds,
+		map[bool]string{true: "[green]On[white]", false: "[red]Off[white]"}[policy.SafeTools],
 	)
 }
@@ -1,3 +1,5 @@
+//go:build !noai
+
 package ui
 
 import (
@@ -35,8 +37,10 @@ func effectiveUIToolApprovalPolicy(policy config.ToolApprovalPolicy) config.Tool
 		!policy.RequireApprovalForWrite &&
 		!policy.RequireApprovalForUnknown &&
 		!policy.BlockDangerous &&
+		policy.AutoApprove == "" &&
 		policy.ApprovalTimeoutSeconds == 0 &&
-		len(policy.BlockedPatterns) == 0 {
+		len(policy.BlockedPatterns) == 0 &&
+		!policy.HasToolRestrictions() {
 		return defaults
 	}
 
@@ -88,6 +92,12 @@ func (a *App) evaluateAIToolDecision(toolName, command string) *safety.Decision
 		}
 	}
 
+	// The session's "approve all reads" switch, from the approval modal or
+	// :approve-reads, skips the prompt for reads only
+	if decision.Allowed && decision.IsRead() && a.approveReads.Load() {
+		decision.RequiresApproval = false
+	}
+
 	if toolName == "bash" {
 		decision.RequiresApproval = true
 		decision.Warnings = appendWarningIfMissing(decision.Warnings, "Bash is discouraged in k13d AI Assistant. Prefer kubectl whenever possible.")
@@ -108,3 +118,15 @@ func (a *App) getToolApprovalTimeout() time.Duration {
 	}
 	return time.Duration(seconds) * time.Second
 }
+
+// toggleApproveReads turns the session's "approve all reads" switch on or off
+// (:approve-reads). Commands that change the cluster still ask.
+func (a *App) toggleApproveReads() {
+	if a.approveReads.Load() {
+		a.approveReads.Store(false)
+		a.flashMsg("Read-only AI tool calls ask for approval again", false)
+		return
+	}
+	a.approveReads.Store(true)
+	a.flashMsg("Approving read-only AI tool calls for this session; writes still ask (:approve-reads to undo)", false)
+}
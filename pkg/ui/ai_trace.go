@@ -0,0 +1,330 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"gopkg.in/yaml.v3"
+)
+
+// AI trace step types, as in the benchmark's trace.yaml
+const (
+	aiTracePrompt   = "prompt"
+	aiTraceToolCall = "tool_call"
+	aiTraceResponse = "response"
+)
+
+// AI tool call decisions recorded in the trace
+const (
+	aiTraceAuto     = "auto"
+	aiTraceApproved = "approved"
+	aiTraceDenied   = "denied"
+	aiTraceBlocked  = "blocked"
+	aiTraceTimeout  = "timeout"
+)
+
+const (
+	// maxAITraceSteps keeps the oldest steps from growing the trace without
+	// bound in a long session
+	maxAITraceSteps = 1000
+	// maxAITraceOutput caps the content and tool output kept per step
+	maxAITraceOutput = 64 * 1024
+)
+
+// aiTraceStep is one prompt, tool call, or response of the AI session
+type aiTraceStep struct {
+	Type     string
+	At       time.Time
+	Content  string // Prompt or response text
+	Tool     string
+	Args     string // Raw JSON arguments
+	Command  string
+	Decision string
+	Output   string
+	Error    bool
+	Took     time.Duration // From the decision to the result
+	done     bool          // The tool call has its result
+}
+
+// aiTraceFile is the exported trace; the keys match the benchmark's
+// --save-trace output, plus command, decision, error, and duration
+type aiTraceFile struct {
+	Steps      []aiTraceFileStep `yaml:"steps,omitempty"`
+	TotalSteps int               `yaml:"totalsteps,omitempty"`
+	ToolCalls  int               `yaml:"toolcalls,omitempty"`
+}
+
+type aiTraceFileStep struct {
+	Type      string `yaml:"type"`
+	Content   string `yaml:"content,omitempty"`
+	ToolName  string `yaml:"toolname,omitempty"`
+	ToolArgs  string `yaml:"toolargs,omitempty"`
+	ToolOut   string `yaml:"toolout,omitempty"`
+	Timestamp string `yaml:"timestamp"`
+	Command   string `yaml:"command,omitempty"`
+	Decision  string `yaml:"decision,omitempty"`
+	Error     bool   `yaml:"error,omitempty"`
+	Duration  string `yaml:"duration,omitempty"`
+}
+
+// addAITraceStep appends step, dropping the oldest steps past the limit
+func (a *App) addAITraceStep(step aiTraceStep) {
+	step.Content = truncateAITrace(step.Content)
+	step.Output = truncateAITrace(step.Output)
+	if step.At.IsZero() {
+		step.At = time.Now()
+	}
+
+	a.aiMx.Lock()
+	defer a.aiMx.Unlock()
+	a.aiTrace = append(a.aiTrace, step)
+	if len(a.aiTrace) > maxAITraceSteps {
+		a.aiTrace = a.aiTrace[len(a.aiTrace)-maxAITraceSteps:]
+	}
+}
+
+// traceAIToolDecision records a tool call the AI asked for and what became
+// of it. The result is added by traceAIToolResult.
+func (a *App) traceAIToolDecision(toolName, args, command, decision string) {
+	a.addAITraceStep(aiTraceStep{
+		Type:     aiTraceToolCall,
+		Tool:     toolName,
+		Args:     args,
+		Command:  command,
+		Decision: decision,
+	})
+}
+
+// traceAIToolResult completes the last tool call still waiting for its
+// result. Tool calls run one at a time, so it is the one that just ran.
+func (a *App) traceAIToolResult(toolName, result string, isError bool) {
+	now := time.Now()
+	a.aiMx.Lock()
+	defer a.aiMx.Unlock()
+	for i := len(a.aiTrace) - 1; i >= 0; i-- {
+		step := &a.aiTrace[i]
+		if step.Type != aiTraceToolCall || step.done || step.Tool != toolName {
+			continue
+		}
+		step.Output = truncateAITrace(result)
+		step.Error = isError
+		step.done = true
+		if step.Decision == aiTraceAuto || step.Decision == aiTraceApproved {
+			step.Took = now.Sub(step.At)
+		}
+		return
+	}
+}
+
+// snapshotAITrace returns a copy of the trace, oldest first
+func (a *App) snapshotAITrace() []aiTraceStep {
+	a.aiMx.RLock()
+	defer a.aiMx.RUnlock()
+	steps := make([]aiTraceStep, len(a.aiTrace))
+	copy(steps, a.aiTrace)
+	return steps
+}
+
+func truncateAITrace(s string) string {
+	if len(s) <= maxAITraceOutput {
+		return s
+	}
+	return s[:maxAITraceOutput] + "\n...[truncated]"
+}
+
+// buildAITraceFile converts the trace to its exported form
+func buildAITraceFile(steps []aiTraceStep) aiTraceFile {
+	file := aiTraceFile{TotalSteps: len(steps)}
+	for _, step := range steps {
+		out := aiTraceFileStep{
+			Type:      step.Type,
+			Content:   step.Content,
+			ToolName:  step.Tool,
+			ToolArgs:  step.Args,
+			ToolOut:   step.Output,
+			Timestamp: step.At.Format(time.RFC3339Nano),
+			Command:   step.Command,
+			Decision:  step.Decision,
+			Error:     step.Error,
+		}
+		if step.Type == aiTraceToolCall {
+			file.ToolCalls++
+			if step.Took > 0 {
+				out.Duration = step.Took.Round(time.Millisecond).String()
+			}
+		}
+		file.Steps = append(file.Steps, out)
+	}
+	return file
+}
+
+// formatAITrace renders the trace as numbered steps with their timing,
+// each tool call followed by the start of its output
+func formatAITrace(steps []aiTraceStep) string {
+	if len(steps) == 0 {
+		return "[gray]No AI activity in this session yet. Ask the assistant something, then run :trace again.[-]"
+	}
+
+	var (
+		b        strings.Builder
+		calls    int
+		toolTime time.Duration
+	)
+	for _, step := range steps {
+		if step.Type == aiTraceToolCall {
+			calls++
+			toolTime += step.Took
+		}
+	}
+	fmt.Fprintf(&b, "[gray]%d steps, %d tool calls, %s in tools[-]\n\n", len(steps), calls, toolTime.Round(time.Millisecond))
+
+	for i, step := range steps {
+		fmt.Fprintf(&b, "[gray]%3d  %s[-]  ", i+1, step.At.Format("15:04:05.000"))
+		switch step.Type {
+		case aiTracePrompt:
+			b.WriteString("[#7aa2f7::b]Prompt[-::-]  ")
+			b.WriteString(tview.Escape(trimAIBlock(step.Content, 200)))
+		case aiTraceResponse:
+			if step.Error {
+				b.WriteString("[#f7768e::b]Error[-::-]  ")
+			} else {
+				b.WriteString("[#9ece6a::b]Response[-::-]  ")
+			}
+			b.WriteString(tview.Escape(trimAIBlock(step.Content, 200)))
+		default:
+			color := "[#9ece6a]"
+			if step.Error || !step.done {
+				color = "[#f7768e]"
+			}
+			fmt.Fprintf(&b, "%s%s[-]  %s", color, tview.Escape(step.Tool), tview.Escape(trimAIBlock(step.Command, 200)))
+			fmt.Fprintf(&b, "\n                     [gray]%s", step.Decision)
+			if step.Took > 0 {
+				fmt.Fprintf(&b, ", %s", step.Took.Round(time.Millisecond))
+			}
+			if !step.done {
+				b.WriteString(", no result")
+			}
+			b.WriteString("[-]")
+			if step.done {
+				b.WriteString("\n" + indentAITrace(tview.Escape(trimAIBlock(summarizeAIToolResult(step.Output), 300))))
+			}
+		}
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
+
+func indentAITrace(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = "                     " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseTraceArgs parses ":trace [--out file.yaml]"
+func parseTraceArgs(args []string) (string, error) {
+	var out string
+	for i := 0; i < len(args); i++ {
+		flag, value, hasValue := strings.Cut(args[i], "=")
+		if !hasValue {
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("%s needs a value", flag)
+			}
+			i++
+			value = args[i]
+		}
+		switch flag {
+		case "--out", "-o":
+			out = value
+		default:
+			return "", fmt.Errorf("unknown trace option %q (use --out)", flag)
+		}
+	}
+	return out, nil
+}
+
+// saveAITrace writes the trace as YAML to path, or to a timestamped file
+// when path is empty, and returns the path
+func saveAITrace(steps []aiTraceStep, path string) (string, error) {
+	if path == "" {
+		path = fmt.Sprintf("k13d-ai-trace-%s.yaml", time.Now().Format("20060102-150405"))
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(buildAITraceFile(steps)); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// showAITrace lists the AI session's prompts, tool calls, and responses in
+// order (:trace). s saves the trace as YAML, r reloads it.
+func (a *App) showAITrace(out string) {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true)
+	view.SetBorder(true).
+		SetTitle(" AI Trace [gray](s:save r:reload Esc:close)[white] ").
+		SetTitleAlign(tview.AlignLeft)
+
+	var steps []aiTraceStep
+	load := func() {
+		steps = a.snapshotAITrace()
+		view.SetText(formatAITrace(steps))
+		view.ScrollToEnd()
+	}
+
+	save := func() {
+		if len(steps) == 0 {
+			a.flashMsg("AI trace is empty", true)
+			return
+		}
+		path, err := saveAITrace(steps, out)
+		if err != nil {
+			a.flashMsg(fmt.Sprintf("Failed to save AI trace: %v", err), true)
+			return
+		}
+		a.flashMsg(fmt.Sprintf("Saved AI trace (%d steps) to %s", len(steps), path), false)
+	}
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.closeModal("ai-trace")
+			a.SetFocus(a.table)
+			return nil
+		}
+		if event.Key() == tcell.KeyRune {
+			switch event.Rune() {
+			case 's':
+				save()
+				return nil
+			case 'r':
+				load()
+				return nil
+			case 'q':
+				a.closeModal("ai-trace")
+				a.SetFocus(a.table)
+				return nil
+			}
+		}
+		return event
+	})
+
+	load()
+	a.showModal("ai-trace", centered(view, 120, 36), true)
+	a.SetFocus(view)
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestAITraceRecordsToolCalls(t *testing.T) {
+	app := NewTestApp(TestAppConfig{SkipBackgroundLoading: true, SkipBriefing: true})
+
+	app.addAITraceStep(aiTraceStep{Type: aiTracePrompt, Content: "why is api failing?"})
+	app.traceAIToolDecision("kubectl", `{"command":"get pods"}`, "kubectl get pods", aiTraceAuto)
+	time.Sleep(5 * time.Millisecond)
+	app.traceAIToolResult("kubectl", "api-1  CrashLoopBackOff", false)
+	app.traceAIToolDecision("kubectl", `{"command":"delete pod api-1"}`, "kubectl delete pod api-1", aiTraceDenied)
+	app.traceAIToolResult("kubectl", "Tool execution cancelled by user", true)
+	app.addAITraceStep(aiTraceStep{Type: aiTraceResponse, Content: "The api pod is crash looping."})
+
+	steps := app.snapshotAITrace()
+	if len(steps) != 4 {
+		t.Fatalf("trace has %d steps, want 4", len(steps))
+	}
+	if got := steps[1]; !got.done || got.Output != "api-1  CrashLoopBackOff" || got.Took < 5*time.Millisecond {
+		t.Errorf("auto step = %+v, want its output and duration", got)
+	}
+	if got := steps[2]; !got.done || !got.Error || got.Took != 0 {
+		t.Errorf("denied step = %+v, want an error without duration", got)
+	}
+
+	text := formatAITrace(steps)
+	for _, want := range []string{"4 steps, 2 tool calls", "kubectl get pods", "auto, ", "denied", "CrashLoopBackOff", "Response"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("formatAITrace() missing %q:\n%s", want, text)
+		}
+	}
+
+	app.resetAIConversation()
+	if got := app.snapshotAITrace(); len(got) != 0 {
+		t.Errorf("trace after reset = %+v, want empty", got)
+	}
+}
+
+func TestSaveAITrace(t *testing.T) {
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	steps := []aiTraceStep{
+		{Type: aiTracePrompt, At: at, Content: "list pods"},
+		{Type: aiTraceToolCall, At: at.Add(time.Second), Tool: "kubectl", Args: `{"command":"get pods"}`, Command: "kubectl get pods", Decision: aiTraceApproved, Output: "api-1 Running", Took: 1500 * time.Millisecond, done: true},
+		{Type: aiTraceResponse, At: at.Add(3 * time.Second), Content: "One pod is running."},
+	}
+
+	path, err := saveAITrace(steps, filepath.Join(t.TempDir(), "trace.yaml"))
+	if err != nil {
+		t.Fatalf("saveAITrace() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got aiTraceFile
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatalf("trace is not valid YAML: %v\n%s", err, data)
+	}
+	if got.TotalSteps != 3 || got.ToolCalls != 1 || len(got.Steps) != 3 {
+		t.Fatalf("trace = %+v, want 3 steps and 1 tool call", got)
+	}
+	call := got.Steps[1]
+	if call.ToolName != "kubectl" || call.ToolOut != "api-1 Running" || call.Decision != aiTraceApproved || call.Duration != "1.5s" || call.Timestamp != "2026-10-01T08:00:01Z" {
+		t.Errorf("tool call step = %+v", call)
+	}
+	for _, key := range []string{"toolname:", "toolargs:", "toolout:", "totalsteps:"} {
+		if !strings.Contains(string(data), key) {
+			t.Errorf("trace is missing the %s key:\n%s", key, data)
+		}
+	}
+
+	if _, err := parseTraceArgs([]string{"--out"}); err == nil {
+		t.Error("parseTraceArgs(--out) should need a value")
+	}
+	if out, err := parseTraceArgs([]string{"-o=run.yaml"}); err != nil || out != "run.yaml" {
+		t.Errorf("parseTraceArgs(-o=run.yaml) = %q, %v", out, err)
+	}
+}
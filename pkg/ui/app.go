@@ -5,12 +5,12 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"regexp"
 	"runtime/debug"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/cloudbro-kube-ai/k13d/pkg/ai"
 	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/cloudbro-kube-ai/k13d/pkg/i18n"
 	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
@@ -88,6 +88,21 @@ var commands = []struct {
 	{"alias", "aliases", "Show command aliases", "action"},
 	{"plugins", "plugin", "Show plugins", "action"},
 	{"pulse", "pu", "Cluster health pulse", "action"},
+	{"clusters", "mc", "Multi-cluster overview", "action"},
+	{"audit", "audits", "Browse recent audit entries", "action"},
+	{"changelog", "cl", "AI summary of this session's cluster changes", "action"},
+	{"trace", "tr", "AI tool calls of this session with timing", "action"},
+	{"node-capacity", "ncap", "Node allocatable vs requested vs usage", "action"},
+	{"top", "tp", "Live top CPU and memory consumers", "action"},
+	{"netcov", "npc", "NetworkPolicy coverage of pods", "action"},
+	{"favorites", "fav", "Pinned resources and views", "action"},
+	{"approve-reads", "ar", "Approve read-only AI tool calls for this session", "action"},
+	{"excluded", "exns", "Show or hide the excluded_namespaces", "action"},
+	{"nsre", "ns-regex", "Limit all-namespace views to a namespace regex", "action"},
+	{"drift", "dr", "Compare a manifest directory with the live cluster", "action"},
+	{"helm", "hr", "Helm releases: values, manifest, edit, rollback, uninstall", "action"},
+	{"upgrade-check", "uc", "Deprecated API versions in use before an upgrade", "action"},
+	{"new", "create", "Create a pod, deployment, or job", "action"},
 	{"xray", "xr", "XRay resource hierarchy", "action"},
 	{"applications", "app", "Application-centric view", "action"},
 }
@@ -99,7 +114,7 @@ type App struct {
 	// Core
 	config   *config.Config
 	k8s      *k8s.Client
-	aiClient *ai.Client
+	aiClient *llmClient
 
 	// UI components
 	pages        *tview.Pages
@@ -108,6 +123,7 @@ type App struct {
 	table        *tview.Table
 	contentFlex  *tview.Flex
 	statusBar    *tview.TextView
+	summaryBar   *tview.TextView // Status counts of the current view
 	flash        *tview.TextView
 	cmdInput     *tview.InputField
 	cmdHint      *tview.TextView // Autocomplete hint (dimmed)
@@ -126,12 +142,14 @@ type App struct {
 	namespaces          []string
 	recentNamespaces    []string // Recently used namespaces (most recent first)
 	maxRecentNamespaces int      // Max number of recent namespaces to track
+	showExcludedNS      bool     // Show namespaces hidden by excluded_namespaces (:excluded)
 	showAIPanel         bool
 	aiPanelWidth        int               // Width of the right-side AI panel in columns
 	aiPanelRestoreWidth int               // Split width to restore after leaving full-size mode
 	aiPanelFullscreen   bool              // True when the AI panel temporarily owns the content area
 	filterText          string            // Current filter text
 	filterRegex         bool              // True if filter is regex (e.g., /pattern/)
+	namespaceRegex      *regexp.Regexp    // Limits all-namespace views (--namespace-regex, :nsre)
 	tableHeaders        []string          // Original headers
 	tableRows           [][]string        // Original rows (unfiltered)
 	apiResources        []k8s.APIResource // Cached API resources from cluster
@@ -149,6 +167,12 @@ type App struct {
 	// Port-forward tracking (protected by pfMx)
 	pfMx         sync.Mutex
 	portForwards []*portForwardInfo
+	// savedForwards are saved port-forwards of other contexts, written back
+	// to port-forwards.yaml on exit
+	savedForwards []config.PortForward
+	// restorePortForwards saves port-forwards on exit and offers the saved
+	// ones after the first draw
+	restorePortForwards bool
 
 	// Navigation history (protected by navMx)
 	navMx           sync.Mutex
@@ -175,15 +199,20 @@ type App struct {
 	// Context management (k9s pattern for graceful shutdown)
 	appCtx     context.Context    // Root application context
 	appCancel  context.CancelFunc // Cancels all operations on Stop()
+	startedAt  time.Time          // Session start; :changelog covers mutations since then
 	cancelFn   context.CancelFunc // Refresh-specific cancellation
 	cancelLock sync.Mutex         // Protects cancelFn updates
 
+	// Session persistence: write state.yaml when Run returns
+	saveSessionOnExit bool
+
 	// AI tool approval state (protected by aiMx)
 	aiMx                  sync.RWMutex
 	pendingDecisions      []PendingDecision
 	pendingToolApproval   chan bool
 	aiConversationTurns   int
 	aiConversationHistory []aiConversationMessage
+	aiTrace               []aiTraceStep // Prompts, tool calls, and responses for :trace
 	attachedAIContext     aiAttachedSelection
 	toolApprovalFocus     tview.Primitive
 	currentToolCallInfo   struct {
@@ -191,6 +220,12 @@ type App struct {
 		Args    string
 		Command string
 	}
+	approveReads atomic.Bool // Session "approve all reads" switch (:approve-reads or A in the approval modal)
+
+	// Open modals, for pausing watch refreshes (protected by modalMu)
+	modalMu         sync.Mutex
+	openModals      map[string]bool
+	refreshDeferred bool // A watch refresh was held back while a modal was open
 
 	// Watch state (protected by watchMu)
 	watcher     *k8s.ResourceWatcher // Active resource watcher (nil when inactive)
@@ -200,15 +235,20 @@ type App struct {
 	// Logger
 	logger *slog.Logger
 
+	// pendingClipboard holds text to send to the terminal as OSC 52 on the
+	// next draw
+	pendingClipboard atomic.Pointer[string]
+
 	// Test mode flags
 	skipBriefing bool // Skip briefing panel in test mode to prevent pulse animation blocking
 	useSimScreen bool // True when using SimulationScreen (Suspend not supported)
 
 	// Extensibility configs (k9s pattern)
-	customAliases *config.AliasConfig // User-defined resource aliases
-	viewsConfig   *config.ViewConfig  // Per-resource view settings (sort defaults)
-	plugins       *config.PluginsFile // Plugin definitions
-	styles        *config.StyleConfig // Per-context skin/theme
+	customAliases *config.AliasConfig     // User-defined resource aliases
+	viewsConfig   *config.ViewConfig      // Per-resource view settings (sort defaults)
+	plugins       *config.PluginsFile     // Plugin definitions
+	styles        *config.StyleConfig     // Per-context skin/theme
+	favorites     *config.FavoritesConfig // Pinned resources and views
 
 	// RBAC authorization (Teleport-inspired)
 	tuiRole      string // TUI user role (default: "admin" for backward compatibility)
@@ -270,6 +310,13 @@ func NewApp() *App {
 
 // NewAppWithNamespace creates a new TUI application with initial namespace
 func NewAppWithNamespace(initialNamespace string) *App {
+	return NewAppWithOptions(AppOptions{Namespace: initialNamespace})
+}
+
+// NewAppWithOptions creates a new TUI application, optionally restoring the
+// last session (see AppOptions).
+func NewAppWithOptions(opts AppOptions) *App {
+	initialNamespace := opts.Namespace
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
 	}))
@@ -281,20 +328,39 @@ func NewAppWithNamespace(initialNamespace string) *App {
 	}
 	i18n.SetLanguage(cfg.Language)
 
-	k8sClient, err := k8s.NewClient()
+	k8sOpts := k8s.ClientOptionsFromEnv()
+	k8sOpts.QPS = cfg.Kubernetes.QPS
+	k8sOpts.Burst = cfg.Kubernetes.Burst
+	k8sOpts.MaxConcurrency = cfg.Kubernetes.MaxConcurrency
+	k8sClient, err := k8s.NewClientWithOptions(k8sOpts)
 	if err != nil {
 		logger.Warn("K8s client initialization failed", "error", err)
 	}
 
-	aiClient, aiErr := ai.NewClient(&cfg.LLM)
-	if aiErr != nil {
-		logger.Warn("AI client initialization failed", "error", aiErr)
+	// --no-ai skips the LLM client and starts with the AI panel hidden
+	var aiClient *llmClient
+	if cfg.AIEnabled() {
+		var aiErr error
+		aiClient, aiErr = newLLMClient(&cfg.LLM)
+		if aiErr != nil {
+			logger.Warn("AI client initialization failed", "error", aiErr)
+		}
 	}
 
 	if initialNamespace == "all" {
 		initialNamespace = ""
 	}
 
+	initialResource := "pods"
+	if opts.RestoreSession && cfg.RestoreSession {
+		if state := restoreSession(k8sClient, logger); state != nil {
+			initialNamespace = state.Namespace
+			if state.Resource != "" {
+				initialResource = state.Resource
+			}
+		}
+	}
+
 	appCtx, appCancel := context.WithCancel(context.Background())
 
 	app := &App{
@@ -302,12 +368,13 @@ func NewAppWithNamespace(initialNamespace string) *App {
 		config:              cfg,
 		k8s:                 k8sClient,
 		aiClient:            aiClient,
-		currentResource:     "pods",
+		currentResource:     initialResource,
 		currentNamespace:    initialNamespace,
+		namespaceRegex:      opts.NamespaceRegex,
 		namespaces:          []string{""},
 		recentNamespaces:    make([]string, 0),
 		maxRecentNamespaces: 9,
-		showAIPanel:         true,
+		showAIPanel:         cfg.AIEnabled(),
 		aiPanelWidth:        defaultAIPanelWidth,
 		selectedRows:        make(map[int]bool),
 		sortColumn:          -1,
@@ -319,6 +386,9 @@ func NewAppWithNamespace(initialNamespace string) *App {
 		logger:              logger,
 		appCtx:              appCtx,
 		appCancel:           appCancel,
+		startedAt:           time.Now(),
+		saveSessionOnExit:   opts.SaveSession && cfg.RestoreSession,
+		restorePortForwards: opts.SaveSession && cfg.RestorePortForwards,
 	}
 
 	if aliases, err := config.LoadAliases(); err == nil {
@@ -330,18 +400,29 @@ func NewAppWithNamespace(initialNamespace string) *App {
 	if plugins, err := config.LoadPlugins(); err == nil {
 		app.plugins = plugins
 	}
+	if favorites, err := config.LoadFavorites(); err == nil {
+		app.favorites = favorites
+	} else {
+		logger.Warn("Failed to load favorites", "error", err)
+	}
 
 	if k8sClient != nil {
 		if ctxName, err := k8sClient.GetCurrentContext(); err == nil && ctxName != "" {
-			if styles, err := config.LoadStylesForContext(ctxName); err == nil {
+			if styles, err := config.LoadStylesForContextWithTheme(ctxName, cfg.Theme); err == nil {
 				app.styles = styles
 			}
 		}
 	}
 	if app.styles == nil {
-		app.styles = config.DefaultStyles()
+		styles, err := config.LoadTheme(cfg.Theme)
+		if err != nil {
+			logger.Warn("Failed to load theme, using default", "theme", cfg.Theme, "error", err)
+		}
+		app.styles = styles
 	}
 
+	app.installToolGuard()
+
 	app.setupUI()
 	app.setupKeybindings()
 
@@ -382,9 +463,13 @@ func (a *App) Run() error {
 			a.briefing.stopPulseAnimation()
 		}
 		atomic.StoreInt32(&a.running, 0)
+		if a.saveSessionOnExit {
+			a.saveSession()
+		}
 	}()
 
 	a.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		a.flushClipboard(screen)
 		now := time.Now().UnixNano()
 		if atomic.CompareAndSwapInt32(&a.needsSync, 1, 0) {
 			screen.Sync()
@@ -423,6 +508,9 @@ func (a *App) Run() error {
 		if a.briefing != nil && a.briefing.IsVisible() {
 			a.briefing.startPulse()
 		}
+		if a.restorePortForwards {
+			a.safeGo("portforward-restore", a.offerPortForwardRestore)
+		}
 	})
 
 	a.logger.Info("Starting k13d TUI")
@@ -445,6 +533,9 @@ func (a *App) Stop() {
 	if a.briefing != nil {
 		a.briefing.stopPulseAnimation()
 	}
+	if a.restorePortForwards {
+		a.savePortForwards()
+	}
 	a.cleanupPortForwards()
 	if a.Application != nil {
 		a.Application.Stop()
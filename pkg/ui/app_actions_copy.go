@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/rivo/tview"
+)
+
+// podCopyRequest describes a file transfer between the local machine and a pod
+type podCopyRequest struct {
+	Namespace  string
+	Pod        string
+	Container  string // empty selects the pod's default container
+	Upload     bool   // true copies LocalPath into the pod, false copies RemotePath out
+	LocalPath  string
+	RemotePath string
+}
+
+// normalize trims the paths and fills in defaults. A download without a local
+// path lands in the current directory under the remote base name.
+func (r *podCopyRequest) normalize() error {
+	r.Container = strings.TrimSpace(r.Container)
+	r.LocalPath = strings.TrimSpace(r.LocalPath)
+	r.RemotePath = strings.TrimSpace(r.RemotePath)
+
+	if r.RemotePath == "" {
+		return errors.New("remote path is required")
+	}
+	if !path.IsAbs(r.RemotePath) {
+		return fmt.Errorf("remote path %q must be absolute", r.RemotePath)
+	}
+	if r.LocalPath == "" {
+		if r.Upload {
+			return errors.New("local path is required")
+		}
+		r.LocalPath = path.Base(path.Clean(r.RemotePath))
+	}
+	return nil
+}
+
+// podRef renders the pod side in kubectl cp notation (ns/pod:path)
+func (r podCopyRequest) podRef() string {
+	return fmt.Sprintf("%s/%s:%s", r.Namespace, r.Pod, r.RemotePath)
+}
+
+// describe returns "source -> destination" for flash and audit messages
+func (r podCopyRequest) describe() string {
+	if r.Upload {
+		return fmt.Sprintf("%s -> %s", r.LocalPath, r.podRef())
+	}
+	return fmt.Sprintf("%s -> %s", r.podRef(), r.LocalPath)
+}
+
+// kubectlArgs returns the equivalent `kubectl cp` arguments, used as a
+// fallback when the client cannot exec into pods directly.
+func (r podCopyRequest) kubectlArgs() []string {
+	args := []string{"cp"}
+	if r.Container != "" {
+		args = append(args, "-c", r.Container)
+	}
+	if r.Upload {
+		return append(args, r.LocalPath, r.podRef())
+	}
+	return append(args, r.podRef(), r.LocalPath)
+}
+
+// formatTransferBytes renders a byte count with a binary unit
+func formatTransferBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// copyFiles shows the file transfer dialog for the selected pod (Shift+X)
+func (a *App) copyFiles() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	if resource != "pods" && resource != "po" {
+		a.flashMsg("File copy is only available for pods. Navigate to pods view first using :pods", true)
+		return
+	}
+
+	// RBAC check: copying runs tar through pod exec
+	if !a.checkTUIPermission("pods", "exec") {
+		return
+	}
+
+	row, _ := a.table.GetSelection()
+	if row <= 0 {
+		return
+	}
+
+	req := podCopyRequest{
+		Namespace: a.getTableCellText(row, 0),
+		Pod:       a.getTableCellText(row, 1),
+	}
+
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Copy Files: %s/%s ", req.Namespace, req.Pod))
+
+	form.AddDropDown("Direction:", []string{"From pod", "To pod"}, 0, func(_ string, index int) {
+		req.Upload = index == 1
+	})
+	form.AddInputField("Container:", "", 30, nil, func(text string) {
+		req.Container = text
+	})
+	form.AddInputField("Remote path:", "", 40, nil, func(text string) {
+		req.RemotePath = text
+	})
+	form.AddInputField("Local path:", "", 40, nil, func(text string) {
+		req.LocalPath = text
+	})
+	form.AddButton("Copy", func() {
+		if err := req.normalize(); err != nil {
+			a.flashMsg(err.Error(), true)
+			return
+		}
+		a.closeModal("pod-copy")
+		a.SetFocus(a.table)
+
+		r := req
+		a.safeGo("copyFiles", func() { a.runPodCopy(r) })
+	})
+	form.AddButton("Cancel", func() {
+		a.closeModal("pod-copy")
+		a.SetFocus(a.table)
+	})
+
+	a.showModal("pod-copy", centered(form, 64, 15), true)
+}
+
+// runPodCopy performs the transfer, reporting progress in the flash bar, and
+// records the result in the audit log.
+func (a *App) runPodCopy(req podCopyRequest) {
+	ctx, cancel := context.WithTimeout(a.getAppContext(), 30*time.Minute)
+	defer cancel()
+
+	desc := req.describe()
+	a.flashMsg(fmt.Sprintf("Copying %s...", desc), false)
+
+	// Throttle progress updates so large transfers don't flood the UI
+	var lastUpdate atomic.Int64
+	progress := func(n int64) {
+		now := time.Now().UnixNano()
+		if last := lastUpdate.Load(); now-last < int64(500*time.Millisecond) || !lastUpdate.CompareAndSwap(last, now) {
+			return
+		}
+		a.flashMsg(fmt.Sprintf("Copying %s... %s", desc, formatTransferBytes(n)), false)
+	}
+
+	var n int64
+	var err error
+	if req.Upload {
+		n, err = a.k8s.CopyToPod(ctx, req.Namespace, req.Pod, req.Container, req.LocalPath, req.RemotePath, progress)
+	} else {
+		var local string
+		if local, err = filepath.Abs(req.LocalPath); err == nil {
+			n, err = a.k8s.CopyFromPod(ctx, req.Namespace, req.Pod, req.Container, req.RemotePath, local, progress)
+		}
+	}
+	if errors.Is(err, k8s.ErrNoRESTConfig) {
+		// No direct exec available; let kubectl do the transfer
+		var output []byte
+		output, err = exec.CommandContext(ctx, "kubectl", req.kubectlArgs()...).CombinedOutput()
+		if err != nil && len(output) > 0 {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+		}
+		n = -1
+	}
+
+	resourcePath := fmt.Sprintf("%s/pod/%s", req.Namespace, req.Pod)
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Copy failed: %v", err), true)
+		a.recordTUIAudit("copy", resourcePath, fmt.Sprintf("Failed to copy %s", desc), false, err.Error())
+		return
+	}
+
+	size := ""
+	if n >= 0 {
+		size = fmt.Sprintf(" (%s)", formatTransferBytes(n))
+	}
+	a.flashMsg(fmt.Sprintf("Copied %s%s", desc, size), false)
+	a.recordTUIAudit("copy", resourcePath, fmt.Sprintf("Copied %s%s", desc, size), true, "")
+}
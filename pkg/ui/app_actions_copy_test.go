@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPodCopyRequestNormalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       podCopyRequest
+		wantLocal string
+		wantErr   bool
+	}{
+		{name: "download defaults local path", req: podCopyRequest{RemotePath: " /var/log/app/ "}, wantLocal: "app"},
+		{name: "download keeps local path", req: podCopyRequest{RemotePath: "/etc/hosts", LocalPath: "./hosts.txt"}, wantLocal: "./hosts.txt"},
+		{name: "upload needs local path", req: podCopyRequest{Upload: true, RemotePath: "/tmp/x"}, wantErr: true},
+		{name: "remote path required", req: podCopyRequest{LocalPath: "x"}, wantErr: true},
+		{name: "remote path must be absolute", req: podCopyRequest{RemotePath: "tmp/x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			err := req.normalize()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && req.LocalPath != tt.wantLocal {
+				t.Errorf("LocalPath = %q, want %q", req.LocalPath, tt.wantLocal)
+			}
+		})
+	}
+}
+
+func TestPodCopyRequestKubectlArgs(t *testing.T) {
+	download := podCopyRequest{Namespace: "prod", Pod: "web-0", RemotePath: "/etc/app", LocalPath: "app"}
+	if got, want := download.kubectlArgs(), []string{"cp", "prod/web-0:/etc/app", "app"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("download kubectlArgs() = %v, want %v", got, want)
+	}
+	if got, want := download.describe(), "prod/web-0:/etc/app -> app"; got != want {
+		t.Errorf("describe() = %q, want %q", got, want)
+	}
+
+	upload := podCopyRequest{Namespace: "prod", Pod: "web-0", Container: "nginx", Upload: true, LocalPath: "nginx.conf", RemotePath: "/etc/nginx/nginx.conf"}
+	if got, want := upload.kubectlArgs(), []string{"cp", "-c", "nginx", "nginx.conf", "prod/web-0:/etc/nginx/nginx.conf"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("upload kubectlArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatTransferBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+	}
+	for n, want := range tests {
+		if got := formatTransferBytes(n); got != want {
+			t.Errorf("formatTransferBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -64,7 +64,7 @@ func (a *App) getTUIUser() string {
 
 // recordTUIAudit records an audit entry for TUI actions with k8s context
 func (a *App) recordTUIAudit(action, resource, details string, success bool, errMsg string) {
-	entry := db.AuditEntry{
+	a.recordTUIAuditEntry(db.AuditEntry{
 		User:       a.getTUIUser(),
 		Action:     action,
 		Resource:   resource,
@@ -73,8 +73,41 @@ func (a *App) recordTUIAudit(action, resource, details string, success bool, err
 		Source:     "tui",
 		Success:    success,
 		ErrorMsg:   errMsg,
+	})
+}
+
+// recordTUIRead audits a describe, YAML, or log view of one object when
+// audit_reads covers its resource type
+func (a *App) recordTUIRead(action, resource, namespace, name string) {
+	if a.k8s != nil {
+		if gvr, ok := a.k8s.GetGVR(resource); ok {
+			resource = gvr.Resource
+		}
+	}
+	if a.config == nil || !a.config.AuditReads.Covers(resource) {
+		return
 	}
 
+	target := resource + "/" + name
+	if namespace != "" {
+		target = resource + "/" + namespace + "/" + name
+	}
+	a.recordTUIAuditEntry(db.AuditEntry{
+		User:            a.getTUIUser(),
+		Action:          action,
+		Resource:        target,
+		Details:         fmt.Sprintf("Viewed %s of %s", action, target),
+		ActionType:      db.ActionTypeRead,
+		Source:          "tui",
+		Namespace:       namespace,
+		Success:         true,
+		TargetResource:  resource + "/" + name,
+		TargetNamespace: namespace,
+	})
+}
+
+// recordTUIAuditEntry fills in the k8s context and namespace and records entry
+func (a *App) recordTUIAuditEntry(entry db.AuditEntry) {
 	// Get k8s context info
 	if a.k8s != nil {
 		ctxName, cluster, user, err := a.k8s.GetContextInfo()
@@ -85,10 +118,12 @@ func (a *App) recordTUIAudit(action, resource, details string, success bool, err
 		}
 	}
 
-	// Get current namespace
-	a.mx.RLock()
-	entry.Namespace = a.currentNamespace
-	a.mx.RUnlock()
+	// Default to the current namespace
+	if entry.Namespace == "" {
+		a.mx.RLock()
+		entry.Namespace = a.currentNamespace
+		a.mx.RUnlock()
+	}
 
 	_ = db.RecordAudit(entry)
 }
@@ -124,6 +159,7 @@ func drainSignalChannel(ch chan os.Signal) {
 
 // showModal adds a modal page with a full terminal sync to prevent ghosting
 func (a *App) showModal(name string, p tview.Primitive, resize bool) {
+	a.trackModal(name, true)
 	a.pages.AddPage(name, p, resize, true)
 	a.requestSync()
 }
@@ -132,6 +168,7 @@ func (a *App) showModal(name string, p tview.Primitive, resize bool) {
 func (a *App) closeModal(name string) {
 	a.pages.RemovePage(name)
 	a.requestSync()
+	a.trackModal(name, false)
 }
 
 // getTableCellText safely retrieves cell text, returning "" if cell is nil.
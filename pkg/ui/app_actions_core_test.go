@@ -1,8 +1,11 @@
 package ui
 
 import (
+	"path/filepath"
 	"sync/atomic"
 	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
 )
 
 func TestSafeSuspendRequestsSyncAfterReturn(t *testing.T) {
@@ -28,3 +31,44 @@ func TestSafeSuspendRequestsSyncAfterReturn(t *testing.T) {
 		t.Fatalf("expected safeSuspend to request a screen sync, got %d", got)
 	}
 }
+
+func TestRecordTUIRead(t *testing.T) {
+	if err := db.Init(filepath.Join(t.TempDir(), "audit.db")); err != nil {
+		t.Fatalf("Failed to init test DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	app := NewTestApp(TestAppConfig{
+		UseSimulationScreen:   true,
+		Screen:                createTestScreen(t),
+		SkipBackgroundLoading: true,
+		SkipBriefing:          true,
+	})
+	reads := func() []map[string]interface{} {
+		t.Helper()
+		logs, err := db.GetAuditLogsFiltered(db.AuditFilter{ActionType: db.ActionTypeRead})
+		if err != nil {
+			t.Fatalf("GetAuditLogsFiltered() error = %v", err)
+		}
+		return logs
+	}
+
+	// Off by default
+	app.recordTUIRead("yaml", "secrets", "default", "db-creds")
+	if n := len(reads()); n != 0 {
+		t.Fatalf("expected no read audits by default, got %d", n)
+	}
+
+	app.config.AuditReads.Enabled = true
+	app.config.AuditReads.Resources = []string{"secrets"}
+	app.recordTUIRead("describe", "pods", "default", "web")
+	app.recordTUIRead("yaml", "secrets", "default", "db-creds")
+
+	logs := reads()
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 read audit for secrets only, got %d", len(logs))
+	}
+	if logs[0]["action"] != "yaml" || logs[0]["resource"] != "secrets/default/db-creds" || logs[0]["namespace"] != "default" {
+		t.Errorf("unexpected audit entry: %v", logs[0])
+	}
+}
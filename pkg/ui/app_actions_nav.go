@@ -2,16 +2,63 @@ package ui
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"sort"
 	"strings"
 
-	"github.com/cloudbro-kube-ai/k13d/pkg/ai"
 	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
-// showContextSwitcher displays context selection dialog
+// contextSwitcherEntry is one context in the context switcher
+type contextSwitcherEntry struct {
+	Name    string
+	Alias   string // Display name; Name when the context has no alias
+	Group   string
+	Current bool
+}
+
+// contextSwitcherEntries returns the contexts matching filter, in config
+// group order with ungrouped contexts last, sorted by display name within a
+// group. filter matches the name, alias, or group, ignoring case.
+func contextSwitcherEntries(cfg *config.Config, contexts []string, current, filter string) []contextSwitcherEntry {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	type ranked struct {
+		entry contextSwitcherEntry
+		group int
+	}
+	var matched []ranked
+	for _, name := range contexts {
+		index, group := cfg.ContextGroup(name)
+		if index < 0 {
+			index = math.MaxInt
+		}
+		entry := contextSwitcherEntry{Name: name, Alias: cfg.ContextAlias(name), Group: group, Current: name == current}
+		if filter != "" &&
+			!strings.Contains(strings.ToLower(entry.Name), filter) &&
+			!strings.Contains(strings.ToLower(entry.Alias), filter) &&
+			!strings.Contains(strings.ToLower(entry.Group), filter) {
+			continue
+		}
+		matched = append(matched, ranked{entry, index})
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].group != matched[j].group {
+			return matched[i].group < matched[j].group
+		}
+		return matched[i].entry.Alias < matched[j].entry.Alias
+	})
+	entries := make([]contextSwitcherEntry, len(matched))
+	for i, m := range matched {
+		entries[i] = m.entry
+	}
+	return entries
+}
+
+// showContextSwitcher displays the context selection dialog. Contexts are
+// grouped and aliased per the contexts config, and typing filters them.
 func (a *App) showContextSwitcher() {
 	if a.k8s == nil {
 		a.flashMsg("K8s client not available", true)
@@ -24,67 +71,139 @@ func (a *App) showContextSwitcher() {
 		return
 	}
 
-	list := tview.NewList()
-	list.SetBorder(true).SetTitle(" Switch Context (Enter to select, Esc to cancel) ")
-
-	for i, ctx := range contexts {
-		prefix := "  "
-		if ctx == currentCtx {
-			prefix = "* "
+	filterInput := tview.NewInputField().SetLabel(" Filter: ").SetFieldWidth(0)
+	table := tview.NewTable().SetBorders(false).SetSelectable(true, false)
+	grouped := a.config != nil && len(a.config.Contexts.Groups) > 0
+
+	// rowEntries maps table rows to contexts; group header rows map to nil
+	var rowEntries []*contextSwitcherEntry
+	render := func() {
+		entries := contextSwitcherEntries(a.config, contexts, currentCtx, filterInput.GetText())
+		table.Clear()
+		rowEntries = rowEntries[:0]
+		selectRow := -1
+		lastGroup := "\x00"
+		for i := range entries {
+			entry := &entries[i]
+			if grouped && entry.Group != lastGroup {
+				header := entry.Group
+				if header == "" {
+					header = "other"
+				}
+				table.SetCell(len(rowEntries), 0, tview.NewTableCell("[cyan::b]"+tview.Escape(header)+"[-::-]").SetSelectable(false))
+				rowEntries = append(rowEntries, nil)
+				lastGroup = entry.Group
+			}
+			text := "  " + tview.Escape(entry.Alias)
+			if entry.Current {
+				text = "[green]* " + tview.Escape(entry.Alias) + "[-]"
+			}
+			if entry.Alias != entry.Name {
+				text += " [gray](" + tview.Escape(entry.Name) + ")[-]"
+			}
+			if entry.Current || selectRow < 0 {
+				selectRow = len(rowEntries)
+			}
+			table.SetCell(len(rowEntries), 0, tview.NewTableCell(text).SetExpansion(1))
+			rowEntries = append(rowEntries, entry)
 		}
-		list.AddItem(prefix+ctx, "", rune('1'+i), nil)
+		if selectRow < 0 {
+			table.SetCell(0, 0, tview.NewTableCell("[gray]No matching contexts[-]").SetSelectable(false))
+			return
+		}
+		table.Select(selectRow, 0)
 	}
 
-	list.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
-		selectedCtx := contexts[index]
+	closeSwitcher := func() {
 		a.closeModal("context-switcher")
 		a.SetFocus(a.table)
+	}
 
+	selectContext := func() {
+		row, _ := table.GetSelection()
+		if row < 0 || row >= len(rowEntries) || rowEntries[row] == nil {
+			return
+		}
+		selectedCtx := rowEntries[row].Name
+		closeSwitcher()
 		if selectedCtx == currentCtx {
 			return
 		}
+		a.safeGo("switchContext", func() { a.switchToContext(selectedCtx) })
+	}
 
-		a.safeGo("switchContext", func() {
-			a.flashMsg(fmt.Sprintf("Switching to context: %s...", selectedCtx), false)
+	filterInput.SetChangedFunc(func(string) { render() })
+	// Keys other than text editing go to the list, so typing always filters
+	filterInput.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeSwitcher()
+			return nil
+		case tcell.KeyEnter:
+			selectContext()
+			return nil
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn, tcell.KeyHome, tcell.KeyEnd:
+			if handler := table.InputHandler(); handler != nil {
+				handler(event, func(p tview.Primitive) {})
+			}
+			return nil
+		}
+		return event
+	})
 
-			// Stop watcher before switching (it holds old cluster connection)
-			a.stopWatch()
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(filterInput, 1, 0, true).
+		AddItem(table, 0, 1, false)
+	flex.SetBorder(true).SetTitle(" Switch Context (type to filter, Enter to select, Esc to cancel) ")
 
-			err := a.k8s.SwitchContext(selectedCtx)
-			if err != nil {
-				a.flashMsg(fmt.Sprintf("Failed to switch context: %v", err), true)
-				return
-			}
+	render()
+	height := len(contexts) + 4
+	if grouped {
+		height += len(a.config.Contexts.Groups) + 1
+	}
+	a.showModal("context-switcher", centered(flex, 80, min(height, 30)), true)
+	a.SetFocus(filterInput)
+}
 
-			// Reset namespace to new context's default and clear cached namespace list
-			newNs := a.k8s.GetCurrentNamespace()
-			a.mx.Lock()
-			a.currentNamespace = newNs
-			a.namespaces = nil
-			a.mx.Unlock()
+// switchToContext makes contextName the active cluster, resets the namespace
+// to that context's default, and reloads the current view. Call it off the UI
+// goroutine.
+func (a *App) switchToContext(contextName string) {
+	a.flashMsg(fmt.Sprintf("Switching to context: %s...", contextName), false)
 
-			a.flashMsg(fmt.Sprintf("Switched to context: %s", selectedCtx), false)
-			a.updateHeader()
-			a.refresh()
+	// Stop watcher before switching (it holds old cluster connection)
+	a.stopWatch()
 
-			// Restart watcher for new cluster
-			a.startWatch()
-		})
-	})
+	err := a.k8s.SwitchContext(contextName)
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to switch context: %v", err), true)
+		return
+	}
 
-	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEsc {
-			a.closeModal("context-switcher")
-			a.SetFocus(a.table)
-			return nil
-		}
-		return event
-	})
+	// Reset namespace to new context's default and clear cached namespace list
+	newNs := a.k8s.GetCurrentNamespace()
+	a.mx.Lock()
+	a.currentNamespace = newNs
+	a.namespaces = nil
+	a.mx.Unlock()
+	if a.briefing != nil {
+		a.briefing.Invalidate()
+	}
+
+	a.flashMsg(fmt.Sprintf("Switched to context: %s", contextName), false)
+	a.updateHeader()
+	a.refresh()
 
-	a.showModal("context-switcher", centered(list, 60, min(len(contexts)+4, 20)), true)
+	// Restart watcher for new cluster
+	a.startWatch()
 }
 
 func (a *App) toggleAIPanel() {
+	if !a.config.AIEnabled() {
+		a.flashMsg("AI assistant is disabled (--no-ai)", true)
+		return
+	}
+
 	a.mx.Lock()
 	a.showAIPanel = !a.showAIPanel
 	show := a.showAIPanel
@@ -225,11 +344,12 @@ func (a *App) showSortPicker() {
 
 	list := tview.NewList()
 	list.SetBorder(true).SetTitle(" Sort By ")
-	list.SetBackgroundColor(tcell.NewRGBColor(26, 27, 38))       // #1a1b26
-	list.SetMainTextColor(tcell.NewRGBColor(192, 202, 245))      // #c0caf5
-	list.SetSecondaryTextColor(tcell.NewRGBColor(169, 177, 214)) // #a9b1d6
-	list.SetSelectedBackgroundColor(tcell.NewRGBColor(41, 46, 66))
-	list.SetSelectedTextColor(tcell.NewRGBColor(122, 162, 247)) // #7aa2f7
+	p, def := a.palette(), config.DefaultPalette()
+	list.SetBackgroundColor(themeColor(p.Background, def.Background))
+	list.SetMainTextColor(themeColor(p.Text, def.Text))
+	list.SetSecondaryTextColor(themeColor(p.TextDim, def.TextDim))
+	list.SetSelectedBackgroundColor(themeColor(p.Surface, def.Surface))
+	list.SetSelectedTextColor(themeColor(p.Accent, def.Accent))
 
 	for i, h := range headers {
 		label := h
@@ -303,7 +423,20 @@ func (a *App) defaultTableCellColor(row, col int) tcell.Color {
 	if a.isStatusColumn(col) {
 		return a.statusColor(a.getTableCellText(row, col))
 	}
-	return tcell.ColorWhite
+	return themeColor(a.palette().CellText, config.DefaultPalette().CellText)
+}
+
+// rowStatus returns the STATUS cell of row, or "" when the view has none
+func (a *App) rowStatus(row int) string {
+	a.mx.RLock()
+	headers := a.tableHeaders
+	a.mx.RUnlock()
+	for col, header := range headers {
+		if strings.EqualFold(strings.TrimSpace(header), "STATUS") {
+			return a.getTableCellText(row, col)
+		}
+	}
+	return ""
 }
 
 func (a *App) isStatusColumn(col int) bool {
@@ -383,6 +516,7 @@ func (a *App) updateRowSelection(row int) {
 	isSelected := a.selectedRows[row]
 	a.mx.RUnlock()
 	isAIContext := a.rowMatchesAttachedAIContext(row)
+	p, def := a.palette(), config.DefaultPalette()
 
 	colCount := a.table.GetColumnCount()
 	for col := 0; col < colCount; col++ {
@@ -392,11 +526,11 @@ func (a *App) updateRowSelection(row int) {
 			textColor := a.defaultTableCellColor(row, col)
 			if isSelected {
 				background = tcell.ColorDarkCyan
-				textColor = tcell.ColorWhite
+				textColor = themeColor(p.SelectionText, def.SelectionText)
 			} else if isAIContext {
-				background = tcell.NewRGBColor(41, 46, 66)
-				if textColor == tcell.ColorWhite {
-					textColor = tcell.NewRGBColor(198, 208, 245)
+				background = themeColor(p.Surface, def.Surface)
+				if textColor == themeColor(p.CellText, def.CellText) {
+					textColor = themeColor(p.Text, def.Text)
 				}
 			}
 			cell.SetBackgroundColor(background)
@@ -431,17 +565,33 @@ func (a *App) toggleBriefing() {
 	}
 }
 
-// aiBriefing generates an AI-enhanced briefing (Ctrl+I)
+// aiBriefing shows an AI-enhanced briefing (Ctrl+I). The briefing is cached
+// per context and namespace; pressing Ctrl+I while it is on screen asks the
+// LLM for a fresh one.
 func (a *App) aiBriefing() {
 	if a.briefing == nil {
 		return
 	}
 
+	force := a.briefing.IsVisible() && a.briefing.IsShowingAI()
 	if !a.briefing.IsVisible() {
 		a.briefing.Toggle()
 	}
 
-	a.safeGo("briefing-ai", func() { a.briefing.UpdateWithAI() })
+	a.safeGo("briefing-ai", func() { a.briefing.UpdateWithAI(force) })
+}
+
+// refreshAIBriefing regenerates the AI briefing, bypassing the cache
+func (a *App) refreshAIBriefing() {
+	if a.briefing == nil {
+		return
+	}
+
+	if !a.briefing.IsVisible() {
+		a.briefing.Toggle()
+	}
+
+	a.safeGo("briefing-ai", func() { a.briefing.UpdateWithAI(true) })
 }
 
 // showModelSelector displays a modal for switching AI model profiles
@@ -502,6 +652,10 @@ func (a *App) showModelSelector() {
 
 // switchModel switches to a named AI model profile
 func (a *App) switchModel(name string) {
+	if !a.config.AIEnabled() {
+		a.flashMsg("AI assistant is disabled (--no-ai)", true)
+		return
+	}
 	if err := a.reloadConfigFromDisk(); err != nil {
 		a.flashMsg(fmt.Sprintf("Failed to reload config: %v", err), true)
 		return
@@ -524,7 +678,7 @@ func (a *App) switchModel(name string) {
 	}
 
 	// Reinitialize AI client with new model
-	newClient, err := ai.NewClient(&a.config.LLM)
+	newClient, err := newLLMClient(&a.config.LLM)
 	if err != nil {
 		a.flashMsg(fmt.Sprintf("Failed to initialize model '%s': %v. Check your API keys and model configuration.", name, err), true)
 		return
@@ -626,17 +780,17 @@ func (a *App) executePlugin(name string, plugin config.PluginConfig) {
 	if plugin.Confirm {
 		expandedArgs := plugin.ExpandArgs(ctx)
 		cmdStr := plugin.Command + " " + strings.Join(expandedArgs, " ")
-		modal := tview.NewModal().
-			SetText(fmt.Sprintf("Run plugin '%s'?\n\n%s", name, cmdStr)).
-			AddButtons([]string{"Cancel", "Execute"}).
-			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-				a.closeModal("plugin-confirm")
-				a.SetFocus(a.table)
-				if buttonLabel == "Execute" {
-					a.safeGo("runPlugin-"+name, func() { a.runPlugin(name, plugin, ctx) })
-				}
-			})
-		a.showModal("plugin-confirm", modal, true)
+		// The plugin asked for a confirmation, so relaxed contexts ask too
+		a.confirmChange(changeConfirm{
+			id:     "plugin-confirm",
+			text:   fmt.Sprintf("Run plugin '%s'?\n\n%s", name, cmdStr),
+			label:  "Execute",
+			name:   name,
+			always: true,
+			run: func() {
+				a.safeGo("runPlugin-"+name, func() { a.runPlugin(name, plugin, ctx) })
+			},
+		})
 		return
 	}
 
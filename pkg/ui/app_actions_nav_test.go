@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+func TestContextSwitcherEntries(t *testing.T) {
+	cfg := &config.Config{Contexts: config.ContextsConfig{
+		Aliases: map[string]string{"arn:aws:eks:eu-west-1:1:cluster/payments": "prod-payments"},
+		Groups: []config.ContextGroup{
+			{Name: "prod", Contexts: []string{"prod-*"}},
+			{Name: "staging", Contexts: []string{"staging-*"}},
+		},
+	}}
+	contexts := []string{"kind-dev", "staging-eu", "prod-us", "arn:aws:eks:eu-west-1:1:cluster/payments", "minikube"}
+
+	entries := contextSwitcherEntries(cfg, contexts, "staging-eu", "")
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Group+"/"+e.Alias)
+	}
+	want := []string{"prod/prod-payments", "prod/prod-us", "staging/staging-eu", "/kind-dev", "/minikube"}
+	if len(got) != len(want) {
+		t.Fatalf("contextSwitcherEntries() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("contextSwitcherEntries() = %v, want %v", got, want)
+		}
+	}
+	if !entries[2].Current || entries[0].Name != "arn:aws:eks:eu-west-1:1:cluster/payments" {
+		t.Errorf("entries = %+v, want staging-eu current and the alias resolved", entries)
+	}
+
+	// The filter matches names, aliases, and groups
+	for filter, count := range map[string]int{"PAYMENTS": 1, "cluster/": 1, "prod": 2, "kube": 1, "none": 0} {
+		if got := contextSwitcherEntries(cfg, contexts, "", filter); len(got) != count {
+			t.Errorf("filter %q matched %d contexts, want %d", filter, len(got), count)
+		}
+	}
+
+	// Without config the list is sorted by name
+	plain := contextSwitcherEntries(nil, []string{"b", "a"}, "", "")
+	if plain[0].Name != "a" || plain[0].Alias != "a" {
+		t.Errorf("unconfigured entries = %+v, want sorted by name", plain)
+	}
+}
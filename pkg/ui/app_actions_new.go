@@ -0,0 +1,258 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"gopkg.in/yaml.v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// newResourceKinds are the kinds the :new wizard can scaffold
+var newResourceKinds = []string{"pod", "deployment", "job"}
+
+// newResourceKind resolves a kind or one of its aliases as typed after :new
+func newResourceKind(s string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "pod", "pods", "po":
+		return "pod", true
+	case "deployment", "deployments", "deploy", "dp":
+		return "deployment", true
+	case "job", "jobs":
+		return "job", true
+	}
+	return "", false
+}
+
+// newResourceSpec is what the :new wizard collects
+type newResourceSpec struct {
+	Kind      string // pod, deployment, or job
+	Name      string
+	Namespace string
+	Image     string
+	Replicas  string // deployment only; empty means 1
+	Port      string // optional container port
+	Command   string // optional, split on whitespace
+}
+
+// manifest validates the spec and renders it as a YAML manifest
+func (s newResourceSpec) manifest() (string, error) {
+	if errs := validation.IsDNS1123Subdomain(s.Name); len(errs) > 0 {
+		return "", fmt.Errorf("invalid name %q: %s", s.Name, errs[0])
+	}
+	if errs := validation.IsDNS1123Label(s.Namespace); len(errs) > 0 {
+		return "", fmt.Errorf("invalid namespace %q: %s", s.Namespace, errs[0])
+	}
+	if s.Image == "" {
+		return "", errors.New("image is required")
+	}
+
+	container := map[string]any{"name": s.Name, "image": s.Image}
+	if s.Port != "" {
+		port, err := strconv.Atoi(s.Port)
+		if err != nil || port < 1 || port > 65535 {
+			return "", fmt.Errorf("invalid port %q", s.Port)
+		}
+		container["ports"] = []any{map[string]any{"containerPort": port}}
+	}
+	if cmd := strings.Fields(s.Command); len(cmd) > 0 {
+		container["command"] = cmd
+	}
+
+	labels := map[string]any{"app": s.Name}
+	podSpec := map[string]any{"containers": []any{container}}
+	obj := map[string]any{
+		"metadata": map[string]any{"name": s.Name, "namespace": s.Namespace, "labels": labels},
+	}
+
+	switch s.Kind {
+	case "pod":
+		obj["apiVersion"], obj["kind"] = "v1", "Pod"
+		obj["spec"] = podSpec
+	case "deployment":
+		replicas := 1
+		if s.Replicas != "" {
+			n, err := strconv.Atoi(s.Replicas)
+			if err != nil || n < 0 {
+				return "", fmt.Errorf("invalid replicas %q", s.Replicas)
+			}
+			replicas = n
+		}
+		obj["apiVersion"], obj["kind"] = "apps/v1", "Deployment"
+		obj["spec"] = map[string]any{
+			"replicas": replicas,
+			"selector": map[string]any{"matchLabels": labels},
+			"template": map[string]any{
+				"metadata": map[string]any{"labels": labels},
+				"spec":     podSpec,
+			},
+		}
+	case "job":
+		podSpec["restartPolicy"] = "Never"
+		obj["apiVersion"], obj["kind"] = "batch/v1", "Job"
+		obj["spec"] = map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{"labels": labels},
+				"spec":     podSpec,
+			},
+		}
+	default:
+		return "", fmt.Errorf("unsupported kind %q (supported: %s)", s.Kind, strings.Join(newResourceKinds, ", "))
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(obj); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// showNewResourceWizard collects the fields for a new pod, deployment, or job
+// (:new [kind]), previews the manifest, and applies it on confirmation.
+func (a *App) showNewResourceWizard(kind string) {
+	if kind == "" {
+		kind = "pod"
+	}
+	a.mx.RLock()
+	ns := a.currentNamespace
+	a.mx.RUnlock()
+	if ns == "" {
+		ns = "default"
+	}
+
+	spec := newResourceSpec{Kind: kind, Namespace: ns}
+	kindIndex := 0
+	for i, k := range newResourceKinds {
+		if k == kind {
+			kindIndex = i
+		}
+	}
+
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle(" New Resource ")
+
+	form.AddDropDown("Kind:", newResourceKinds, kindIndex, func(option string, _ int) {
+		spec.Kind = option
+	})
+	form.AddInputField("Name:", "", 40, nil, func(text string) {
+		spec.Name = strings.TrimSpace(text)
+	})
+	form.AddInputField("Namespace:", ns, 40, nil, func(text string) {
+		spec.Namespace = strings.TrimSpace(text)
+	})
+	form.AddInputField("Image:", "", 40, nil, func(text string) {
+		spec.Image = strings.TrimSpace(text)
+	})
+	form.AddInputField("Replicas (deployment):", "1", 6, nil, func(text string) {
+		spec.Replicas = strings.TrimSpace(text)
+	})
+	form.AddInputField("Port (optional):", "", 6, nil, func(text string) {
+		spec.Port = strings.TrimSpace(text)
+	})
+	form.AddInputField("Command (optional):", "", 40, nil, func(text string) {
+		spec.Command = text
+	})
+	form.AddButton("Preview", func() {
+		manifest, err := spec.manifest()
+		if err != nil {
+			a.flashMsg(err.Error(), true)
+			return
+		}
+		a.showNewResourcePreview(spec, manifest, form)
+	})
+	form.AddButton("Cancel", func() {
+		a.closeModal("new-resource")
+		a.SetFocus(a.table)
+	})
+	form.SetCancelFunc(func() {
+		a.closeModal("new-resource")
+		a.SetFocus(a.table)
+	})
+
+	a.showModal("new-resource", centered(form, 70, 21), true)
+	a.SetFocus(form)
+}
+
+// showNewResourcePreview shows the generated manifest over the wizard form.
+// Enter or 'a' applies it; Esc returns to the form to make changes.
+func (a *App) showNewResourcePreview(spec newResourceSpec, manifest string, form *tview.Form) {
+	preview := tview.NewTextView().
+		SetDynamicColors(false).
+		SetScrollable(true).
+		SetText(manifest)
+	preview.SetBorder(true).
+		SetTitle(fmt.Sprintf(" New %s %s/%s (Enter/a:apply  Esc:back) ", spec.Kind, spec.Namespace, spec.Name)).
+		SetTitleAlign(tview.AlignLeft)
+
+	preview.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEsc:
+			a.closeModal("new-resource-preview")
+			a.SetFocus(form)
+			return nil
+		case event.Key() == tcell.KeyEnter || (event.Key() == tcell.KeyRune && event.Rune() == 'a'):
+			a.closeModal("new-resource-preview")
+			a.closeModal("new-resource")
+			a.SetFocus(a.table)
+			a.safeGo("createResource", func() { a.createNewResource(spec, manifest) })
+			return nil
+		}
+		return event
+	})
+
+	a.showModal("new-resource-preview", centered(preview, 80, 30), true)
+	a.SetFocus(preview)
+}
+
+// createNewResource applies the wizard's manifest. It refuses to touch an
+// existing object, since ApplyYAML would otherwise overwrite it.
+func (a *App) createNewResource(spec newResourceSpec, manifest string) {
+	resource := spec.Kind + "s"
+	if !a.checkTUIPermission(resource, "create") {
+		return
+	}
+	resourcePath := fmt.Sprintf("%s/%s/%s", resource, spec.Namespace, spec.Name)
+
+	ctx, cancel := context.WithTimeout(a.getAppContext(), 30*time.Second)
+	defer cancel()
+
+	var err error
+	cs := a.k8s.Clientset
+	switch spec.Kind {
+	case "pod":
+		_, err = cs.CoreV1().Pods(spec.Namespace).Get(ctx, spec.Name, metav1.GetOptions{})
+	case "deployment":
+		_, err = cs.AppsV1().Deployments(spec.Namespace).Get(ctx, spec.Name, metav1.GetOptions{})
+	case "job":
+		_, err = cs.BatchV1().Jobs(spec.Namespace).Get(ctx, spec.Name, metav1.GetOptions{})
+	}
+	if err == nil {
+		a.flashMsg(fmt.Sprintf("%s %s/%s already exists", spec.Kind, spec.Namespace, spec.Name), true)
+		return
+	}
+	if !apierrors.IsNotFound(err) {
+		a.flashMsg(fmt.Sprintf("Failed to check %s %s/%s: %v", spec.Kind, spec.Namespace, spec.Name, err), true)
+		return
+	}
+
+	result, err := a.k8s.ApplyYAML(ctx, manifest, spec.Namespace, false)
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to create %s: %v", spec.Kind, err), true)
+		a.recordTUIAudit("create", resourcePath, fmt.Sprintf("Failed to create %s %s", spec.Kind, spec.Name), false, err.Error())
+		return
+	}
+	a.flashMsg(result, false)
+	a.recordTUIAudit("create", resourcePath, fmt.Sprintf("Created %s %s (image %s)", spec.Kind, spec.Name, spec.Image), true, "")
+	a.navigateTo(resource, spec.Namespace, "")
+}
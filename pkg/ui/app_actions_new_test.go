@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestNewResourceKind(t *testing.T) {
+	for input, want := range map[string]string{"po": "pod", "Deploy": "deployment", "dp": "deployment", "jobs": "job"} {
+		if got, ok := newResourceKind(input); !ok || got != want {
+			t.Errorf("newResourceKind(%q) = %q, %v; want %q", input, got, ok, want)
+		}
+	}
+	if _, ok := newResourceKind("service"); ok {
+		t.Error("newResourceKind(service) should be unsupported")
+	}
+}
+
+func TestNewResourceSpecManifest(t *testing.T) {
+	spec := newResourceSpec{Kind: "deployment", Name: "web", Namespace: "dev", Image: "nginx:1.27", Replicas: "3", Port: "8080"}
+	manifest, err := spec.manifest()
+	if err != nil {
+		t.Fatalf("manifest() error = %v", err)
+	}
+	var dep appsv1.Deployment
+	if err := yaml.Unmarshal([]byte(manifest), &dep); err != nil {
+		t.Fatalf("manifest is not a Deployment: %v\n%s", err, manifest)
+	}
+	if dep.Kind != "Deployment" || dep.Name != "web" || dep.Namespace != "dev" || *dep.Spec.Replicas != 3 {
+		t.Errorf("deployment = %s %s/%s replicas %d", dep.Kind, dep.Namespace, dep.Name, *dep.Spec.Replicas)
+	}
+	if dep.Spec.Selector.MatchLabels["app"] != "web" || dep.Spec.Template.Labels["app"] != "web" {
+		t.Errorf("selector %v does not match template labels %v", dep.Spec.Selector.MatchLabels, dep.Spec.Template.Labels)
+	}
+	if c := dep.Spec.Template.Spec.Containers[0]; c.Image != "nginx:1.27" || c.Ports[0].ContainerPort != 8080 {
+		t.Errorf("container = %+v", c)
+	}
+
+	spec = newResourceSpec{Kind: "job", Name: "migrate", Namespace: "dev", Image: "busybox", Command: "sh -c true"}
+	manifest, err = spec.manifest()
+	if err != nil {
+		t.Fatalf("manifest() error = %v", err)
+	}
+	var job batchv1.Job
+	if err := yaml.Unmarshal([]byte(manifest), &job); err != nil {
+		t.Fatalf("manifest is not a Job: %v", err)
+	}
+	if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("job restartPolicy = %q, want Never", job.Spec.Template.Spec.RestartPolicy)
+	}
+	if got := strings.Join(job.Spec.Template.Spec.Containers[0].Command, " "); got != "sh -c true" {
+		t.Errorf("job command = %q", got)
+	}
+}
+
+func TestNewResourceSpecManifest_Validation(t *testing.T) {
+	base := newResourceSpec{Kind: "pod", Name: "debug", Namespace: "default", Image: "busybox"}
+	tests := []struct {
+		name    string
+		mutate  func(*newResourceSpec)
+		wantErr string
+	}{
+		{"bad name", func(s *newResourceSpec) { s.Name = "Debug_Pod" }, "invalid name"},
+		{"missing image", func(s *newResourceSpec) { s.Image = "" }, "image is required"},
+		{"bad port", func(s *newResourceSpec) { s.Port = "99999" }, "invalid port"},
+		{"bad replicas", func(s *newResourceSpec) { s.Kind = "deployment"; s.Replicas = "-1" }, "invalid replicas"},
+		{"unsupported kind", func(s *newResourceSpec) { s.Kind = "service" }, "unsupported kind"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := base
+			tt.mutate(&spec)
+			if _, err := spec.manifest(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("manifest() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// budgetWarningText appends the PodDisruptionBudgets an action would breach
+// to its confirmation prompt
+func budgetWarningText(prompt string, warnings []k8s.DisruptionBudgetWarning) string {
+	if len(warnings) == 0 {
+		return prompt
+	}
+	var b strings.Builder
+	b.WriteString(prompt)
+	b.WriteString("\n\n[red]This would breach PodDisruptionBudgets:[white]\n")
+	for _, w := range warnings {
+		b.WriteString("\n• " + tview.Escape(w.String()))
+	}
+	return b.String()
+}
+
+// confirmBudgetBreach asks before an action that breaches the given
+// PodDisruptionBudgets and runs proceed only if confirmed
+func (a *App) confirmBudgetBreach(name, prompt string, warnings []k8s.DisruptionBudgetWarning, confirmLabel string, proceed func()) {
+	modal := tview.NewModal().
+		SetText(budgetWarningText(prompt, warnings)).
+		AddButtons([]string{"Cancel", confirmLabel}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.closeModal(name)
+			a.SetFocus(a.table)
+
+			if buttonLabel == confirmLabel {
+				proceed()
+			}
+		})
+
+	modal.SetBackgroundColor(tcell.ColorDarkRed)
+	a.showModal(name, modal, true)
+}
+
+// drainNode cordons the selected node and deletes its pods (Shift+D on nodes),
+// after warning about PodDisruptionBudgets the drain would breach
+func (a *App) drainNode() {
+	// Same permission as the web /api/node/drain route
+	if !a.checkTUIPermission("nodes", "edit") {
+		return
+	}
+
+	row, _ := a.table.GetSelection()
+	if row <= 0 {
+		return
+	}
+	name := a.getTableCellText(row, 0)
+
+	a.safeGo("drainBudgetCheck", func() {
+		var warnings []k8s.DisruptionBudgetWarning
+		if a.k8s != nil {
+			ctx, cancel := context.WithTimeout(a.getAppContext(), 10*time.Second)
+			warnings, _ = a.k8s.CheckDrainDisruptionBudgets(ctx, name)
+			cancel()
+		}
+
+		a.QueueUpdateDraw(func() { a.confirmDrain(name, warnings) })
+	})
+}
+
+// confirmDrain asks before draining name, as the context's safety level
+// says. A drain that breaches PodDisruptionBudgets asks even in relaxed
+// contexts.
+func (a *App) confirmDrain(name string, warnings []k8s.DisruptionBudgetWarning) {
+	prompt := fmt.Sprintf("[red]Drain node?[white]\n\n%s\n\nThe node is cordoned and its pods, except DaemonSet and mirror pods, are deleted.", name)
+	a.confirmChange(changeConfirm{
+		id:     "drain-confirm",
+		text:   budgetWarningText(prompt, warnings),
+		label:  "Drain",
+		name:   name,
+		danger: true,
+		always: len(warnings) > 0,
+		run: func() {
+			a.safeGo("drainNode", func() {
+				ctx, cancel := context.WithTimeout(a.getAppContext(), 2*time.Minute)
+				defer cancel()
+
+				a.flashMsg(fmt.Sprintf("Draining node %s...", name), false)
+
+				resourcePath := "node/" + name
+				if err := a.k8s.DrainNode(ctx, name, 0); err != nil {
+					a.flashMsg(fmt.Sprintf("Drain failed: %v", err), true)
+					a.recordTUIAudit("drain", resourcePath, fmt.Sprintf("Failed to drain node %s", name), false, err.Error())
+					return
+				}
+
+				a.flashMsg(fmt.Sprintf("Drained node %s", name), false)
+				a.recordTUIAudit("drain", resourcePath, fmt.Sprintf("Drained node %s", name), true, "")
+				a.refresh()
+			})
+		},
+	})
+}
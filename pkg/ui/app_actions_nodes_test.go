@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/rivo/tview"
+)
+
+func TestBudgetWarningText(t *testing.T) {
+	if got := budgetWarningText("Drain node?", nil); got != "Drain node?" {
+		t.Errorf("without warnings = %q, want the prompt unchanged", got)
+	}
+
+	got := budgetWarningText("Drain node?", []k8s.DisruptionBudgetWarning{
+		{Namespace: "default", Name: "web-pdb", Reason: "draining evicts 2 pods but only 1 disruption is allowed"},
+	})
+	if !strings.HasPrefix(got, "Drain node?") || !strings.Contains(got, "PodDisruptionBudgets") ||
+		!strings.Contains(got, "default/web-pdb: draining evicts 2 pods") {
+		t.Errorf("budgetWarningText() = %q", got)
+	}
+}
+
+func TestConfirmDrain_SafetyLevels(t *testing.T) {
+	app := NewTestApp(TestAppConfig{SkipBackgroundLoading: true, SkipBriefing: true})
+	setLevel := func(level string) {
+		app.config = &config.Config{Contexts: config.ContextsConfig{
+			Safety: []config.ContextSafety{{Contexts: []string{"*"}, Level: level}},
+		}}
+	}
+
+	// Strict contexts type the node name instead of pressing a button
+	setLevel(config.SafetyStrict)
+	app.confirmDrain("node-1", nil)
+	if !hasTestPage(app, "drain-confirm") {
+		t.Fatal("strict context did not ask before draining")
+	}
+	if _, front := app.pages.GetFrontPage(); front == nil {
+		t.Error("no confirmation page in front")
+	} else if _, isModal := front.(*tview.Modal); isModal {
+		t.Error("strict context drain should ask to type the node name, not show a button modal")
+	}
+	app.closeModal("drain-confirm")
+
+	// Breaching a budget asks even in relaxed contexts
+	setLevel(config.SafetyRelaxed)
+	app.confirmDrain("node-1", []k8s.DisruptionBudgetWarning{{Namespace: "default", Name: "web-pdb"}})
+	if !hasTestPage(app, "drain-confirm") {
+		t.Error("relaxed context should still ask before a drain that breaches a budget")
+	}
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	corev1 "k8s.io/api/core/v1"
@@ -19,10 +21,27 @@ import (
 // portForwardInfo tracks a running port-forward process
 type portForwardInfo struct {
 	Cmd        *exec.Cmd
+	Context    string
 	Namespace  string
+	Kind       string // "pod" or "svc"
 	Name       string
 	LocalPort  string
 	RemotePort string
+
+	done   chan struct{} // Closed once the process has exited
+	stderr bytes.Buffer  // kubectl's error output; read only after done
+}
+
+// spec returns what is needed to start the port-forward again
+func (pf *portForwardInfo) spec() config.PortForward {
+	return config.PortForward{
+		Context:    pf.Context,
+		Namespace:  pf.Namespace,
+		Kind:       pf.Kind,
+		Name:       pf.Name,
+		LocalPort:  pf.LocalPort,
+		RemotePort: pf.RemotePort,
+	}
 }
 
 type podContainerEntry struct {
@@ -134,7 +153,7 @@ func (a *App) showPodContainers() {
 			list := tview.NewList()
 			list.ShowSecondaryText(true)
 			list.SetBorder(true).
-				SetTitle(fmt.Sprintf(" Containers: %s/%s [gray](l logs, p previous, Esc close)[white] ", ns, name))
+				SetTitle(fmt.Sprintf(" Containers: %s/%s [gray](l logs, p previous, r since restart, Esc close)[white] ", ns, name))
 
 			for _, entry := range entries {
 				containerName := entry.Name
@@ -175,6 +194,15 @@ func (a *App) showPodContainers() {
 						a.showLogsForContainer(ns, name, entries[index].Name, true)
 						return nil
 					}
+				case 'r':
+					index := list.GetCurrentItem()
+					if index >= 0 && index < len(entries) {
+						a.closeModal("pod-containers")
+						a.SetFocus(a.table)
+						container := entries[index].Name
+						a.safeGo("showLogsSinceRestart", func() { a.loadLogsSinceRestart(ns, name, container) })
+						return nil
+					}
 				}
 				return event
 			})
@@ -196,16 +224,13 @@ func (a *App) showPodContainers() {
 	a.safeGo("showPodContainers", run)
 }
 
-// showLogs shows logs for selected pod with Vim-style navigation
+// showLogs shows logs for selected pod with Vim-style navigation, or the
+// merged logs of all pods for a selected workload
 func (a *App) showLogs() {
 	a.mx.RLock()
 	resource := a.currentResource
 	a.mx.RUnlock()
 
-	if resource != "pods" && resource != "po" {
-		return
-	}
-
 	row, _ := a.table.GetSelection()
 	if row <= 0 {
 		return
@@ -214,6 +239,14 @@ func (a *App) showLogs() {
 	ns := a.getTableCellText(row, 0)
 	name := a.getTableCellText(row, 1)
 
+	if containsResource(logWorkloadResources, resource) {
+		a.showWorkloadLogsForm(ns, name, resource)
+		return
+	}
+	if resource != "pods" && resource != "po" {
+		return
+	}
+
 	a.showLogsForContainer(ns, name, "", false)
 }
 
@@ -228,10 +261,11 @@ func (a *App) showLogsForContainer(ns, name, container string, previous bool) {
 
 	// Use VimViewer for Vim-style navigation and search
 	logView := NewVimViewer(a, "logs",
-		fmt.Sprintf("%s [gray](Esc:close /search s:autoscroll w:wrap m:mark)[white] ", title))
+		fmt.Sprintf("%s [gray](Esc:close /search s:autoscroll w:wrap c:color m:mark)[white] ", title))
 	logView.isLogView = true
 	logView.autoScroll = true
 	logView.textWrap = true
+	logView.enableLogHighlight(a.config)
 
 	logView.SetContent("[yellow]Loading...[white]")
 	logView.updateTitle()
@@ -256,6 +290,9 @@ func (a *App) showLogsForContainer(ns, name, container string, previous bool) {
 		} else {
 			logs, err = a.k8s.GetPodLogs(ctx, ns, name, container, 100)
 		}
+		if err == nil {
+			a.recordTUIRead("logs", "pods", ns, name)
+		}
 		a.QueueUpdateDraw(func() {
 			if err != nil {
 				logView.SetContent(fmt.Sprintf("[red]Error: %v", err))
@@ -538,26 +575,44 @@ func (a *App) startPortForward(ns, name, resource, localPort, remotePort string)
 		resourceType = "svc"
 	}
 
-	target := fmt.Sprintf("%s/%s", resourceType, name)
-	portMap := fmt.Sprintf("%s:%s", localPort, remotePort)
-
 	a.flashMsg(fmt.Sprintf("Starting port forward %s -> %s:%s", localPort, name, remotePort), false)
 
-	cmd := exec.Command("kubectl", "port-forward", "-n", ns, target, portMap)
-	err := cmd.Start()
+	pf, err := a.launchPortForward(config.PortForward{
+		Context:    a.getCurrentContext(),
+		Namespace:  ns,
+		Kind:       resourceType,
+		Name:       name,
+		LocalPort:  localPort,
+		RemotePort: remotePort,
+	})
 	if err != nil {
 		a.flashMsg(fmt.Sprintf("Port forward failed: %v", err), true)
 		return
 	}
 
-	// Track the port-forward process
+	a.flashMsg(fmt.Sprintf("Port forward active: localhost:%s -> %s:%s (PID: %d)", localPort, name, remotePort, pf.Cmd.Process.Pid), false)
+}
+
+// launchPortForward starts kubectl port-forward for fwd and tracks the
+// process until it exits
+func (a *App) launchPortForward(fwd config.PortForward) (*portForwardInfo, error) {
+	cmd := exec.Command("kubectl", "port-forward", "-n", fwd.Namespace, fwd.Target(), fwd.LocalPort+":"+fwd.RemotePort)
 	pf := &portForwardInfo{
 		Cmd:        cmd,
-		Namespace:  ns,
-		Name:       name,
-		LocalPort:  localPort,
-		RemotePort: remotePort,
+		Context:    fwd.Context,
+		Namespace:  fwd.Namespace,
+		Kind:       fwd.Kind,
+		Name:       fwd.Name,
+		LocalPort:  fwd.LocalPort,
+		RemotePort: fwd.RemotePort,
+		done:       make(chan struct{}),
+	}
+	cmd.Stderr = &pf.stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
 	}
+
+	// Track the port-forward process
 	a.pfMx.Lock()
 	a.portForwards = append(a.portForwards, pf)
 	a.pfMx.Unlock()
@@ -573,9 +628,9 @@ func (a *App) startPortForward(ns, name, resource, localPort, remotePort string)
 			}
 		}
 		a.pfMx.Unlock()
+		close(pf.done)
 	})
-
-	a.flashMsg(fmt.Sprintf("Port forward active: localhost:%s -> %s:%s (PID: %d)", localPort, name, remotePort, cmd.Process.Pid), false)
+	return pf, nil
 }
 
 // showPortForwards displays active port-forward processes
@@ -702,35 +757,35 @@ func (a *App) killPod() {
 	ns := a.getTableCellText(row, 0)
 	name := a.getTableCellText(row, 1)
 
-	modal := tview.NewModal().
-		SetText(fmt.Sprintf("[red]Kill pod?[white]\n\n%s/%s\n\nThis will force delete the pod.", ns, name)).
-		AddButtons([]string{"Cancel", "Kill"}).
-		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			a.closeModal("kill-confirm")
-			a.SetFocus(a.table)
-
-			if buttonLabel == "Kill" {
-				a.safeGo("killPod", func() {
-					ctx, cancel := context.WithTimeout(a.getAppContext(), 30*time.Second)
-					defer cancel()
+	a.confirmChange(changeConfirm{
+		id:     "kill-confirm",
+		text:   fmt.Sprintf("[red]Kill pod?[white]\n\n%s/%s\n\nThis will force delete the pod.", ns, name),
+		label:  "Kill",
+		name:   name,
+		danger: true,
+		run: func() {
+			a.safeGo("killPod", func() { a.forceDeletePod(ns, name) })
+		},
+	})
+}
 
-					a.flashMsg(fmt.Sprintf("Killing pod %s/%s...", ns, name), false)
+// forceDeletePod deletes a pod with grace period 0, for kill and for pods
+// stuck terminating
+func (a *App) forceDeletePod(ns, name string) {
+	ctx, cancel := context.WithTimeout(a.getAppContext(), 30*time.Second)
+	defer cancel()
 
-					resourcePath := fmt.Sprintf("%s/pod/%s", ns, name)
-					err := a.k8s.DeletePodForce(ctx, ns, name)
-					if err != nil {
-						a.flashMsg(fmt.Sprintf("Kill failed: %v", err), true)
-						a.recordTUIAudit("kill", resourcePath, fmt.Sprintf("Failed to force delete pod %s", name), false, err.Error())
-						return
-					}
+	a.flashMsg(fmt.Sprintf("Killing pod %s/%s...", ns, name), false)
 
-					a.flashMsg(fmt.Sprintf("Killed pod %s/%s", ns, name), false)
-					a.recordTUIAudit("kill", resourcePath, fmt.Sprintf("Force deleted pod %s", name), true, "")
-					a.refresh()
-				})
-			}
-		})
+	resourcePath := fmt.Sprintf("%s/pod/%s", ns, name)
+	err := a.k8s.DeletePodForce(ctx, ns, name)
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Kill failed: %v", err), true)
+		a.recordTUIAudit("kill", resourcePath, fmt.Sprintf("Failed to force delete pod %s", name), false, err.Error())
+		return
+	}
 
-	modal.SetBackgroundColor(tcell.ColorDarkRed)
-	a.showModal("kill-confirm", modal, true)
+	a.flashMsg(fmt.Sprintf("Killed pod %s/%s", ns, name), false)
+	a.recordTUIAudit("kill", resourcePath, fmt.Sprintf("Force deleted pod %s", name), true, "")
+	a.refresh()
 }
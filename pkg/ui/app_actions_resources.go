@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -46,42 +50,136 @@ func (a *App) confirmDelete() {
 		name = a.getTableCellText(row, 1)
 	}
 
-	// Create confirmation modal
-	modal := tview.NewModal().
-		SetText(fmt.Sprintf("[red]Delete %s?[white]\n\n%s/%s\n\nThis action cannot be undone.", resource, ns, name)).
-		AddButtons([]string{"Cancel", "Delete"}).
-		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			a.closeModal("delete-confirm")
-			a.SetFocus(a.table)
+	text := fmt.Sprintf("[red]Delete %s?[white]\n\n%s/%s\n\nThis action cannot be undone.", resource, ns, name)
+	stuck := false
+	// A pod stuck terminating ignores another graceful delete
+	if (resource == "pods" || resource == "po") && k8s.IsStuckTerminatingStatus(a.rowStatus(row)) {
+		text = fmt.Sprintf("[red]Pod stuck terminating[white]\n\n%s/%s\n\nIt is past its grace period. Force Delete removes it without waiting for the kubelet; finalizers still block removal.", ns, name)
+		stuck = true
+	}
 
-			if buttonLabel == "Delete" {
-				a.safeGo("deleteResource", func() { a.deleteResource(ns, name, resource) })
-			}
+	confirm := changeConfirm{
+		id:     "delete-confirm",
+		label:  "Delete",
+		name:   name,
+		danger: true,
+		always: resource == "namespaces" || resource == "ns",
+		run: func() {
+			a.safeGo("deleteResource", func() { a.deleteResource(ns, name, resource) })
+		},
+	}
+	if stuck {
+		confirm.label = "Force Delete"
+		confirm.run = func() {
+			a.safeGo("forceDeletePod", func() { a.forceDeletePod(ns, name) })
+		}
+	}
+	show := func(text string) {
+		confirm.text = text
+		a.confirmChange(confirm)
+	}
+
+	if stuck || !deleteCascades(resource) || a.k8s == nil || a.skipsConfirm(confirm) {
+		show(text)
+		return
+	}
+
+	// Count what the delete cascades to before asking
+	a.safeGo("deleteBlastRadius", func() {
+		ctx, cancel := context.WithTimeout(a.getAppContext(), 5*time.Second)
+		items, err := a.k8s.DeleteBlastRadius(ctx, resource, ns, name)
+		cancel()
+		a.QueueUpdateDraw(func() {
+			show(text + deleteBlastRadiusText(resource, items, err))
 		})
+	})
+}
+
+// deleteCascades reports whether deleting resource also deletes the objects
+// it owns: controllers garbage-collect their pods, and a namespace takes
+// everything in it
+func deleteCascades(resource string) bool {
+	switch resource {
+	case "deployments", "deploy", "statefulsets", "sts", "daemonsets", "ds",
+		"replicasets", "rs", "replicationcontrollers", "rc", "jobs", "job",
+		"cronjobs", "cj", "namespaces", "ns":
+		return true
+	}
+	return false
+}
+
+// deleteBlastRadiusText lists what a delete cascades to, for the delete
+// confirmation. Namespaces are always called out.
+func deleteBlastRadiusText(resource string, items []k8s.BlastRadiusItem, err error) string {
+	radius := k8s.FormatBlastRadius(items)
+	if resource == "namespaces" || resource == "ns" {
+		text := "\n\n[red::b]Deleting a namespace destroys EVERYTHING in it.[-::-]"
+		switch {
+		case err != nil:
+			return text + fmt.Sprintf("\n[yellow]Could not count its contents: %s[white]", tview.Escape(err.Error()))
+		case radius != "":
+			return text + "\nIncluding: " + radius
+		}
+		return text
+	}
+	switch {
+	case err != nil:
+		return fmt.Sprintf("\n\n[yellow]Could not count the objects it owns: %s[white]", tview.Escape(err.Error()))
+	case radius != "":
+		return "\n\n[yellow]Also deletes:[white] " + radius
+	}
+	return ""
+}
+
+// deleteTarget is one selected resource to delete
+type deleteTarget struct{ ns, name string }
 
-	modal.SetBackgroundColor(tcell.ColorDarkRed)
+// bulkDeleteListLimit is how many names the bulk delete confirmation lists
+const bulkDeleteListLimit = 8
 
-	a.showModal("delete-confirm", modal, true)
+// bulkDeleteText asks to delete items, listing the first
+// bulkDeleteListLimit of them
+func bulkDeleteText(resource string, items []deleteTarget) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[red]Delete %d %s?[white]\n\n", len(items), resource)
+	for i, item := range items {
+		if i == bulkDeleteListLimit {
+			fmt.Fprintf(&b, "... and %d more\n", len(items)-i)
+			break
+		}
+		name := item.name
+		if item.ns != "" {
+			name = item.ns + "/" + item.name
+		}
+		b.WriteString(tview.Escape(name) + "\n")
+	}
+	b.WriteString("\nThis action cannot be undone.")
+	return b.String()
 }
 
-// confirmDeleteMultiple confirms deletion of multiple selected resources (k9s style)
+// confirmDeleteMultiple confirms deletion of multiple selected resources (k9s style).
+// Selections above bulk_delete_confirm_threshold, or any selection in a
+// strict context, must type the count.
 func (a *App) confirmDeleteMultiple() {
 	a.mx.RLock()
 	resource := a.currentResource
 	selectedCount := len(a.selectedRows)
-	selectedRowsCopy := make(map[int]bool)
-	for k, v := range a.selectedRows {
-		selectedRowsCopy[k] = v
+	rows := make([]int, 0, selectedCount)
+	for row, selected := range a.selectedRows {
+		if selected {
+			rows = append(rows, row)
+		}
 	}
 	a.mx.RUnlock()
 
 	if selectedCount == 0 {
 		return
 	}
+	sort.Ints(rows)
 
 	// Build list of resources to delete
-	var items []struct{ ns, name string }
-	for row := range selectedRowsCopy {
+	var items []deleteTarget
+	for _, row := range rows {
 		var ns, name string
 		switch resource {
 		case "nodes", "no", "namespaces", "ns":
@@ -91,32 +189,78 @@ func (a *App) confirmDeleteMultiple() {
 			name = strings.TrimSpace(tview.TranslateANSI(a.getTableCellText(row, 1)))
 		}
 		if name != "" {
-			items = append(items, struct{ ns, name string }{ns, name})
+			items = append(items, deleteTarget{ns, name})
 		}
 	}
+	if len(items) == 0 {
+		return
+	}
 
-	// Create confirmation modal
-	modal := tview.NewModal().
-		SetText(fmt.Sprintf("[red]Delete %d %s?[white]\n\nThis action cannot be undone.", len(items), resource)).
-		AddButtons([]string{"Cancel", "Delete All"}).
-		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			a.closeModal("delete-confirm")
-			a.SetFocus(a.table)
-
-			if buttonLabel == "Delete All" {
-				a.safeGo("deleteResource-batch", func() {
-					for _, item := range items {
-						a.deleteResource(item.ns, item.name, resource)
-					}
-					a.clearSelections()
-					a.refresh()
-				})
+	deleteAll := func() {
+		a.safeGo("deleteResource-batch", func() {
+			for _, item := range items {
+				a.deleteResource(item.ns, item.name, resource)
 			}
+			a.clearSelections()
+			a.refresh()
 		})
+	}
+
+	text := bulkDeleteText(resource, items)
+	show := func(text string) {
+		if a.config.BulkDeleteNeedsTyping(len(items)) || a.safetyLevel() == config.SafetyStrict {
+			a.showTypedDeleteConfirm(text, len(items), deleteAll)
+			return
+		}
+
+		modal := tview.NewModal().
+			SetText(text).
+			AddButtons([]string{"Cancel", "Delete All"}).
+			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+				a.closeModal("delete-confirm")
+				a.SetFocus(a.table)
 
-	modal.SetBackgroundColor(tcell.ColorDarkRed)
+				if buttonLabel == "Delete All" {
+					deleteAll()
+				}
+			})
 
-	a.showModal("delete-confirm", modal, true)
+		modal.SetBackgroundColor(tcell.ColorDarkRed)
+
+		a.showModal("delete-confirm", modal, true)
+	}
+
+	if !deleteCascades(resource) || a.k8s == nil {
+		show(text)
+		return
+	}
+
+	// Add up what every selected delete cascades to
+	a.safeGo("deleteBlastRadius-batch", func() {
+		ctx, cancel := context.WithTimeout(a.getAppContext(), 10*time.Second)
+		defer cancel()
+		var (
+			radii   [][]k8s.BlastRadiusItem
+			lastErr error
+		)
+		for _, item := range items {
+			radius, err := a.k8s.DeleteBlastRadius(ctx, resource, item.ns, item.name)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			radii = append(radii, radius)
+		}
+		a.QueueUpdateDraw(func() {
+			show(text + deleteBlastRadiusText(resource, k8s.MergeBlastRadius(radii...), lastErr))
+		})
+	})
+}
+
+// showTypedDeleteConfirm asks to type count before a bulk delete, so a
+// stray Enter cannot delete a large selection
+func (a *App) showTypedDeleteConfirm(text string, count int, deleteAll func()) {
+	a.showTypedConfirm("delete-confirm", " Confirm Delete ", text, strconv.Itoa(count), "Count:", "Delete All", tview.InputFieldInteger, nil, deleteAll)
 }
 
 // deleteResource deletes the specified resource
@@ -225,11 +369,12 @@ func (a *App) showYAML() {
 	isSecret := resource == "secrets" || resource == "sec"
 	title := fmt.Sprintf(" YAML: %s/%s [gray](Esc:close /search n/N:next/prev Ctrl+D/U:scroll)[white] ", resource, name)
 	if isSecret {
-		title = fmt.Sprintf(" YAML: %s/%s [gray](Esc:close /search x:decode)[white] ", resource, name)
+		title = fmt.Sprintf(" YAML: %s/%s [gray](Esc:close /search)[white] ", resource, name)
 	}
 	yamlView := NewVimViewer(a, "yaml", title)
 	if isSecret {
 		yamlView.isSecretView = true
+		yamlView.secretPath = ns + "/" + name
 		yamlView.updateTitle()
 	}
 
@@ -252,14 +397,16 @@ func (a *App) showYAML() {
 		}
 
 		yaml, err := a.k8s.GetResourceYAML(ctx, ns, name, gvr)
+		if err == nil {
+			a.recordTUIRead("yaml", resource, ns, name)
+		}
 		a.QueueUpdateDraw(func() {
 			if err != nil {
 				yamlView.SetContent(fmt.Sprintf("[red]Error: %v", err))
+			} else if isSecret {
+				yamlView.setSecretYAML(yaml)
 			} else {
 				yamlView.SetContent(yaml)
-				if isSecret {
-					yamlView.rawYAML = yaml
-				}
 			}
 		})
 	})
@@ -316,6 +463,7 @@ func (a *App) showDescribe() {
 			})
 			return
 		}
+		a.recordTUIRead("describe", resource, ns, name)
 
 		a.QueueUpdateDraw(func() {
 			descView.SetContent(output)
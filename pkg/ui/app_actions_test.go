@@ -1,12 +1,14 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 )
 
 func TestGetCommandDescription(t *testing.T) {
@@ -127,6 +129,21 @@ func TestBuildLLMInfoTextIncludesOllamaWarning(t *testing.T) {
 	}
 }
 
+func TestBuildLLMInfoTextShowsFeatureMatrix(t *testing.T) {
+	got := buildLLMInfoText("bedrock", "anthropic.claude-3-sonnet-20240229-v1:0", "", true)
+	if !strings.Contains(got, "Features: streaming [red]✗[white]  tools [green]✓[white]  vision [green]✓[white]") {
+		t.Fatalf("expected bedrock feature matrix, got %q", got)
+	}
+	if strings.Contains(got, "agentic tools disabled") {
+		t.Fatalf("did not expect the tools note for a tool-capable provider, got %q", got)
+	}
+
+	got = buildLLMInfoText("custom", "model", "", false)
+	if !strings.Contains(got, "agentic tools disabled") {
+		t.Fatalf("expected a note for a provider without tool support, got %q", got)
+	}
+}
+
 func TestBuildLLMInfoTextOmitsOllamaWarningForOpenAI(t *testing.T) {
 	got := buildLLMInfoText("openai", "gpt-4o", "https://api.openai.com/v1", true)
 	if strings.Contains(got, "Tools Required") {
@@ -157,6 +174,22 @@ func TestBuildToolApprovalInfoTextReflectsPolicy(t *testing.T) {
 	}
 }
 
+func TestEvaluateAIToolDecision_SafeToolsBlocksMutations(t *testing.T) {
+	app := CreateMinimalTestApp()
+	app.config.Authorization.ToolApproval.SafeTools = true
+
+	if decision := app.evaluateAIToolDecision("kubectl", "get pods -n default"); !decision.Allowed {
+		t.Fatalf("expected read-only kubectl to stay allowed in safe tools mode, got %q", decision.BlockReason)
+	}
+	decision := app.evaluateAIToolDecision("kubectl", "delete pod nginx")
+	if decision.Allowed {
+		t.Fatal("expected kubectl delete to be blocked in safe tools mode")
+	}
+	if !strings.Contains(decision.BlockReason, "safe tools") {
+		t.Fatalf("expected block reason to mention safe tools, got %q", decision.BlockReason)
+	}
+}
+
 func TestEvaluateAIToolDecision_ReadOnlyCanSkipApproval(t *testing.T) {
 	app := CreateMinimalTestApp()
 	app.config.Authorization.ToolApproval.AutoApproveReadOnly = true
@@ -170,6 +203,27 @@ func TestEvaluateAIToolDecision_ReadOnlyCanSkipApproval(t *testing.T) {
 	}
 }
 
+func TestEvaluateAIToolDecision_ApproveReadsSession(t *testing.T) {
+	app := CreateMinimalTestApp()
+
+	if decision := app.evaluateAIToolDecision("kubectl", "kubectl get pods -n default"); !decision.RequiresApproval {
+		t.Fatal("expected read-only kubectl command to require approval by default")
+	}
+
+	app.toggleApproveReads()
+	if decision := app.evaluateAIToolDecision("kubectl", "kubectl get pods -n default"); decision.RequiresApproval {
+		t.Fatal("expected read-only kubectl command to skip approval after approve-reads")
+	}
+	if decision := app.evaluateAIToolDecision("kubectl", "kubectl scale deployment nginx --replicas=2"); !decision.RequiresApproval {
+		t.Fatal("expected write kubectl command to still require approval after approve-reads")
+	}
+
+	app.toggleApproveReads()
+	if decision := app.evaluateAIToolDecision("kubectl", "kubectl get pods -n default"); !decision.RequiresApproval {
+		t.Fatal("expected read-only kubectl command to require approval again after toggling approve-reads off")
+	}
+}
+
 func TestEvaluateAIToolDecision_WriteCanSkipApproval(t *testing.T) {
 	app := CreateMinimalTestApp()
 	app.config.Authorization.ToolApproval.RequireApprovalForWrite = false
@@ -228,3 +282,48 @@ func TestGetToolApprovalTimeoutUsesConfigPolicy(t *testing.T) {
 		t.Fatalf("getToolApprovalTimeout() = %v, want %v", got, 123*time.Second)
 	}
 }
+
+func TestDeleteBlastRadiusText(t *testing.T) {
+	items := []k8s.BlastRadiusItem{{Kind: "replicasets", Count: 2}, {Kind: "pods", Count: 6}}
+
+	if got := deleteBlastRadiusText("deployments", items, nil); !strings.Contains(got, "Also deletes:[white] 2 replicasets, 6 pods") {
+		t.Errorf("deployment text = %q", got)
+	}
+	if got := deleteBlastRadiusText("deployments", []k8s.BlastRadiusItem{{Kind: "pods", Count: 0}}, nil); got != "" {
+		t.Errorf("nothing owned should add no text, got %q", got)
+	}
+
+	// A namespace is always called out, even when counting fails
+	got := deleteBlastRadiusText("namespaces", []k8s.BlastRadiusItem{{Kind: "pods", Count: 1}}, nil)
+	if !strings.Contains(got, "destroys EVERYTHING") || !strings.Contains(got, "Including: 1 pod") {
+		t.Errorf("namespace text = %q", got)
+	}
+	got = deleteBlastRadiusText("ns", nil, errors.New("forbidden"))
+	if !strings.Contains(got, "destroys EVERYTHING") || !strings.Contains(got, "forbidden") {
+		t.Errorf("namespace text on error = %q", got)
+	}
+
+	if deleteCascades("configmaps") || !deleteCascades("deploy") || !deleteCascades("namespaces") {
+		t.Error("deleteCascades() should cover controllers and namespaces only")
+	}
+}
+
+func TestBulkDeleteText(t *testing.T) {
+	var items []deleteTarget
+	for i := 0; i < bulkDeleteListLimit+3; i++ {
+		items = append(items, deleteTarget{ns: "prod", name: fmt.Sprintf("web-%d", i)})
+	}
+
+	got := bulkDeleteText("pods", items)
+	if !strings.Contains(got, "Delete 11 pods?") || !strings.Contains(got, "prod/web-0\n") || !strings.Contains(got, "prod/web-7\n") {
+		t.Errorf("bulk delete text = %q", got)
+	}
+	if strings.Contains(got, "web-8") || !strings.Contains(got, "... and 3 more") {
+		t.Errorf("bulk delete text should list only the first %d names: %q", bulkDeleteListLimit, got)
+	}
+
+	// Cluster-scoped names have no namespace prefix
+	if got := bulkDeleteText("nodes", []deleteTarget{{name: "node-1"}}); !strings.Contains(got, "\n\nnode-1\n") {
+		t.Errorf("node text = %q", got)
+	}
+}
@@ -1,10 +1,15 @@
 package ui
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"os/exec"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	"github.com/rivo/tview"
 )
 
@@ -27,36 +32,95 @@ func (a *App) triggerCronJob() {
 	ns := a.getTableCellText(row, 0)
 	name := a.getTableCellText(row, 1)
 
-	modal := tview.NewModal().
-		SetText(fmt.Sprintf("Trigger CronJob?\n\n%s/%s\n\nThis will create a new job from this cronjob.", ns, name)).
-		AddButtons([]string{"Cancel", "Trigger"}).
-		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			a.closeModal("trigger-confirm")
-			a.SetFocus(a.table)
-
-			if buttonLabel == "Trigger" {
-				a.safeGo("triggerCronJob", func() {
-					a.flashMsg(fmt.Sprintf("Triggering cronjob %s/%s...", ns, name), false)
-
-					// Use kubectl to create job from cronjob
-					jobName := fmt.Sprintf("%s-manual-%d", name, time.Now().Unix())
-					resourcePath := fmt.Sprintf("%s/cronjob/%s", ns, name)
-					cmd := exec.Command("kubectl", "create", "job", jobName, "--from=cronjob/"+name, "-n", ns)
-					output, err := cmd.CombinedOutput()
-					if err != nil {
-						a.flashMsg(fmt.Sprintf("Trigger failed: %s", string(output)), true)
-						a.recordTUIAudit("trigger", resourcePath, fmt.Sprintf("Failed to trigger cronjob %s", name), false, string(output))
-						return
-					}
-
-					a.flashMsg(fmt.Sprintf("Created job %s from cronjob %s", jobName, name), false)
-					a.recordTUIAudit("trigger", resourcePath, fmt.Sprintf("Triggered cronjob %s, created job %s", name, jobName), true, "")
-					a.refresh()
-				})
-			}
-		})
+	a.confirmChange(changeConfirm{
+		id:    "trigger-confirm",
+		text:  fmt.Sprintf("Trigger CronJob?\n\n%s/%s\n\nThis will create a new job from this cronjob.", ns, name),
+		label: "Trigger",
+		name:  name,
+		run: func() {
+			a.safeGo("triggerCronJob", func() {
+				a.flashMsg(fmt.Sprintf("Triggering cronjob %s/%s...", ns, name), false)
+
+				// Use kubectl to create job from cronjob
+				jobName := fmt.Sprintf("%s-manual-%d", name, time.Now().Unix())
+				resourcePath := fmt.Sprintf("%s/cronjob/%s", ns, name)
+				cmd := exec.Command("kubectl", "create", "job", jobName, "--from=cronjob/"+name, "-n", ns)
+				output, err := cmd.CombinedOutput()
+				if err != nil {
+					a.flashMsg(fmt.Sprintf("Trigger failed: %s", string(output)), true)
+					a.recordTUIAudit("trigger", resourcePath, fmt.Sprintf("Failed to trigger cronjob %s", name), false, string(output))
+					return
+				}
+
+				a.flashMsg(fmt.Sprintf("Created job %s from cronjob %s", jobName, name), false)
+				a.recordTUIAudit("trigger", resourcePath, fmt.Sprintf("Triggered cronjob %s, created job %s", name, jobName), true, "")
+				a.refresh()
+			})
+		},
+	})
+}
+
+// toggleCronJobSuspend suspends or resumes the selected cronjob (k9s s key
+// on cronjobs). A suspended cronjob schedules no new jobs.
+func (a *App) toggleCronJobSuspend() {
+	a.mx.RLock()
+	resource := a.currentResource
+	headers := append([]string(nil), a.tableHeaders...)
+	a.mx.RUnlock()
+
+	if resource != "cronjobs" && resource != "cj" {
+		a.flashMsg("Suspend is only available for cronjobs. Navigate to cronjobs view first using :cronjobs", true)
+		return
+	}
 
-	a.showModal("trigger-confirm", modal, true)
+	// RBAC check
+	if !a.checkTUIPermission("cronjobs", "edit") {
+		return
+	}
+
+	row, _ := a.table.GetSelection()
+	if row <= 0 {
+		return
+	}
+
+	ns := a.getTableCellText(row, 0)
+	name := a.getTableCellText(row, 1)
+	suspended := false
+	for col, header := range headers {
+		if header == "SUSPEND" {
+			suspended = strings.EqualFold(a.getTableCellText(row, col), "True")
+		}
+	}
+
+	verb, done, effect := "Suspend", "Suspended", "No new jobs will be scheduled until it is resumed.\nRunning jobs are not affected."
+	if suspended {
+		verb, done, effect = "Resume", "Resumed", "Jobs will be scheduled again from the next run time."
+	}
+	action := strings.ToLower(verb)
+
+	a.confirmChange(changeConfirm{
+		id:    "suspend-confirm",
+		text:  fmt.Sprintf("%s CronJob?\n\n%s/%s\n\n%s", verb, ns, name, effect),
+		label: verb,
+		name:  name,
+		run: func() {
+			a.safeGo("toggleCronJobSuspend", func() {
+				ctx, cancel := context.WithTimeout(a.getAppContext(), 15*time.Second)
+				defer cancel()
+
+				resourcePath := fmt.Sprintf("%s/cronjob/%s", ns, name)
+				if err := a.k8s.SetCronJobSuspend(ctx, ns, name, !suspended); err != nil {
+					a.flashMsg(fmt.Sprintf("%s failed: %v", verb, err), true)
+					a.recordTUIAudit(action, resourcePath, fmt.Sprintf("Failed to %s cronjob %s", action, name), false, err.Error())
+					return
+				}
+
+				a.flashMsg(fmt.Sprintf("%s cronjob %s/%s", done, ns, name), false)
+				a.recordTUIAudit(action, resourcePath, fmt.Sprintf("%s cronjob %s", done, name), true, "")
+				a.refresh()
+			})
+		},
+	})
 }
 
 // scaleResource scales a deployment/statefulset (k9s Shift+S key)
@@ -115,30 +179,62 @@ func (a *App) scaleResource() {
 		a.closeModal("scale-dialog")
 		a.SetFocus(a.table)
 
-		a.safeGo("scaleResource", func() {
-			a.flashMsg(fmt.Sprintf("Scaling %s/%s to %s replicas...", ns, name, replicas), false)
-
-			resourceType := resource
-			if resourceType == "deploy" {
-				resourceType = "deployment"
-			} else if resourceType == "sts" {
-				resourceType = "statefulset"
-			} else if resourceType == "rs" {
-				resourceType = "replicaset"
-			}
-
-			resourcePath := fmt.Sprintf("%s/%s/%s", ns, resourceType, name)
-			cmd := exec.Command("kubectl", "scale", resourceType, name, "-n", ns, "--replicas="+replicas)
-			output, err := cmd.CombinedOutput()
-			if err != nil {
-				a.flashMsg(fmt.Sprintf("Scale failed: %s", string(output)), true)
-				a.recordTUIAudit("scale", resourcePath, fmt.Sprintf("Failed to scale to %s replicas", replicas), false, string(output))
-				return
-			}
+		resourceType := resource
+		if resourceType == "deploy" {
+			resourceType = "deployment"
+		} else if resourceType == "sts" {
+			resourceType = "statefulset"
+		} else if resourceType == "rs" {
+			resourceType = "replicaset"
+		}
+		resourcePath := fmt.Sprintf("%s/%s/%s", ns, resourceType, name)
+
+		doScale := func() {
+			a.safeGo("scaleResource", func() {
+				a.flashMsg(fmt.Sprintf("Scaling %s/%s to %s replicas...", ns, name, replicas), false)
+
+				cmd := exec.Command("kubectl", "scale", resourceType, name, "-n", ns, "--replicas="+replicas)
+				output, err := cmd.CombinedOutput()
+				if err != nil {
+					a.flashMsg(fmt.Sprintf("Scale failed: %s", string(output)), true)
+					a.recordTUIAudit("scale", resourcePath, fmt.Sprintf("Failed to scale to %s replicas", replicas), false, string(output))
+					return
+				}
+
+				a.flashMsg(fmt.Sprintf("Scaled %s/%s to %s replicas", ns, name, replicas), false)
+				a.recordTUIAudit("scale", resourcePath, fmt.Sprintf("Scaled to %s replicas", replicas), true, "")
+				a.refresh()
+			})
+		}
 
-			a.flashMsg(fmt.Sprintf("Scaled %s/%s to %s replicas", ns, name, replicas), false)
-			a.recordTUIAudit("scale", resourcePath, fmt.Sprintf("Scaled to %s replicas", replicas), true, "")
-			a.refresh()
+		// Warn before a scale-down that breaches a PodDisruptionBudget; a
+		// failed check does not block the scale
+		checkBudgets := func() {
+			a.safeGo("scaleBudgetCheck", func() {
+				var warnings []k8s.DisruptionBudgetWarning
+				if a.k8s != nil {
+					ctx, cancel := context.WithTimeout(a.getAppContext(), 10*time.Second)
+					warnings, _ = a.k8s.CheckScaleDisruptionBudgets(ctx, resource, ns, name, int32(replicaCount))
+					cancel()
+				}
+				if len(warnings) == 0 {
+					doScale()
+					return
+				}
+				a.QueueUpdateDraw(func() {
+					a.confirmBudgetBreach("scale-budget-confirm",
+						fmt.Sprintf("Scale %s/%s to %d replicas?", ns, name, replicaCount),
+						warnings, "Scale anyway", doScale)
+				})
+			})
+		}
+		a.confirmChange(changeConfirm{
+			id:         "scale-confirm",
+			text:       fmt.Sprintf("Scale %s?\n\n%s/%s to %d replicas", resourceType, ns, name, replicaCount),
+			label:      "Scale",
+			name:       name,
+			strictOnly: true,
+			run:        checkBudgets,
 		})
 	})
 	form.AddButton("Cancel", func() {
@@ -179,41 +275,315 @@ func (a *App) restartResource() {
 	ns := a.getTableCellText(row, 0)
 	name := a.getTableCellText(row, 1)
 
-	modal := tview.NewModal().
-		SetText(fmt.Sprintf("Restart %s?\n\n%s/%s\n\nThis will trigger a rolling restart.", resource, ns, name)).
-		AddButtons([]string{"Cancel", "Restart"}).
-		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			a.closeModal("restart-confirm")
-			a.SetFocus(a.table)
-
-			if buttonLabel == "Restart" {
-				a.safeGo("restartResource", func() {
-					a.flashMsg(fmt.Sprintf("Restarting %s/%s...", ns, name), false)
-
-					resourceType := resource
-					if resourceType == "deploy" {
-						resourceType = "deployment"
-					} else if resourceType == "sts" {
-						resourceType = "statefulset"
-					} else if resourceType == "ds" {
-						resourceType = "daemonset"
-					}
-
-					resourcePath := fmt.Sprintf("%s/%s/%s", ns, resourceType, name)
-					cmd := exec.Command("kubectl", "rollout", "restart", resourceType, name, "-n", ns)
-					output, err := cmd.CombinedOutput()
-					if err != nil {
-						a.flashMsg(fmt.Sprintf("Restart failed: %s", string(output)), true)
-						a.recordTUIAudit("restart", resourcePath, fmt.Sprintf("Failed to rollout restart %s", name), false, string(output))
-						return
-					}
-
-					a.flashMsg(fmt.Sprintf("Restarted %s/%s", ns, name), false)
-					a.recordTUIAudit("restart", resourcePath, fmt.Sprintf("Rollout restart %s", name), true, "")
-					a.refresh()
-				})
+	a.confirmChange(changeConfirm{
+		id:    "restart-confirm",
+		text:  fmt.Sprintf("Restart %s?\n\n%s/%s\n\nThis will trigger a rolling restart.", resource, ns, name),
+		label: "Restart",
+		name:  name,
+		run: func() {
+			a.safeGo("restartResource", func() {
+				a.flashMsg(fmt.Sprintf("Restarting %s/%s...", ns, name), false)
+
+				resourceType := resource
+				if resourceType == "deploy" {
+					resourceType = "deployment"
+				} else if resourceType == "sts" {
+					resourceType = "statefulset"
+				} else if resourceType == "ds" {
+					resourceType = "daemonset"
+				}
+
+				resourcePath := fmt.Sprintf("%s/%s/%s", ns, resourceType, name)
+				cmd := exec.Command("kubectl", "rollout", "restart", resourceType, name, "-n", ns)
+				output, err := cmd.CombinedOutput()
+				if err != nil {
+					a.flashMsg(fmt.Sprintf("Restart failed: %s", string(output)), true)
+					a.recordTUIAudit("restart", resourcePath, fmt.Sprintf("Failed to rollout restart %s", name), false, string(output))
+					return
+				}
+
+				a.flashMsg(fmt.Sprintf("Restarted %s/%s", ns, name), false)
+				a.recordTUIAudit("restart", resourcePath, fmt.Sprintf("Rollout restart %s", name), true, "")
+				a.refresh()
+			})
+		},
+	})
+}
+
+// setImageKinds maps the workload views that support set image to the kind
+// name kubectl expects
+var setImageKinds = map[string]string{
+	"deployments": "deployment", "deploy": "deployment",
+	"statefulsets": "statefulset", "sts": "statefulset",
+	"daemonsets": "daemonset", "ds": "daemonset",
+}
+
+// rolloutFollowTimeout bounds how long followRollout waits for a rollout
+const rolloutFollowTimeout = 5 * time.Minute
+
+// setImage changes the image of one container of a deployment, statefulset,
+// or daemonset (k9s i key) and then follows the rollout
+func (a *App) setImage() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	kind, ok := setImageKinds[resource]
+	if !ok {
+		a.flashMsg("Set image is only available for deployments, statefulsets, and daemonsets. Navigate to one of these resources first.", true)
+		return
+	}
+
+	// RBAC check: changing the pod template is an edit
+	if !a.checkTUIPermission(resource, "edit") {
+		return
+	}
+
+	row, _ := a.table.GetSelection()
+	if row <= 0 {
+		return
+	}
+
+	ns := a.getTableCellText(row, 0)
+	name := a.getTableCellText(row, 1)
+
+	a.safeGo("setImage-containers", func() {
+		ctx, cancel := context.WithTimeout(a.getAppContext(), 10*time.Second)
+		defer cancel()
+
+		containers, err := a.k8s.WorkloadContainers(ctx, ns, resource, name)
+		a.QueueUpdateDraw(func() {
+			switch {
+			case err != nil:
+				a.flashMsg(fmt.Sprintf("Failed to list containers: %v", err), true)
+			case len(containers) == 0:
+				a.flashMsg(fmt.Sprintf("No containers found in %s/%s", kind, name), true)
+			default:
+				a.showSetImageForm(ns, name, kind, containers)
 			}
 		})
+	})
+}
+
+// showSetImageForm lets the user pick a container and enter its new image,
+// prefilled with the current one
+func (a *App) showSetImageForm(ns, name, kind string, containers []k8s.WorkloadContainer) {
+	options := make([]string, len(containers))
+	for i, ctr := range containers {
+		options[i] = ctr.Name
+		if ctr.Init {
+			options[i] += " (init)"
+		}
+	}
+
+	selected := 0
+	image := containers[0].Image
+
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Set Image: %s/%s/%s ", ns, kind, name))
+
+	imageField := tview.NewInputField().
+		SetLabel("Image:").
+		SetText(image).
+		SetFieldWidth(50).
+		SetChangedFunc(func(text string) {
+			image = text
+		})
+	form.AddDropDown("Container:", options, 0, func(_ string, index int) {
+		if index < 0 || index == selected {
+			return
+		}
+		selected = index
+		imageField.SetText(containers[index].Image)
+	})
+	form.AddFormItem(imageField)
+	form.AddButton("Update", func() {
+		ctr := containers[selected]
+		newImage := strings.TrimSpace(image)
+		if err := k8s.ValidateImageReference(newImage); err != nil {
+			a.flashMsg(err.Error(), true)
+			return
+		}
+		if newImage == ctr.Image {
+			a.flashMsg(fmt.Sprintf("Container %s already uses %s", ctr.Name, newImage), true)
+			return
+		}
+		a.closeModal("set-image-dialog")
+		a.confirmSetImage(ns, name, kind, ctr, newImage)
+	})
+	form.AddButton("Cancel", func() {
+		a.closeModal("set-image-dialog")
+		a.SetFocus(a.table)
+	})
+
+	a.showModal("set-image-dialog", centered(form, 72, 11), true)
+}
+
+// confirmSetImage asks for confirmation, runs kubectl set image, and follows
+// the resulting rollout
+func (a *App) confirmSetImage(ns, name, kind string, ctr k8s.WorkloadContainer, image string) {
+	a.confirmChange(changeConfirm{
+		id: "set-image-confirm",
+		text: fmt.Sprintf("Set image?\n\n%s/%s/%s\ncontainer: %s\n\n%s\n-> %s\n\nThis will trigger a rolling update.",
+			ns, kind, name, ctr.Name, ctr.Image, image),
+		label: "Set image",
+		name:  name,
+		run: func() {
+			a.safeGo("setImage", func() {
+				a.flashMsg(fmt.Sprintf("Setting image of %s/%s...", kind, name), false)
+
+				resourcePath := fmt.Sprintf("%s/%s/%s", ns, kind, name)
+				details := fmt.Sprintf("Set image of container %s from %s to %s", ctr.Name, ctr.Image, image)
+				cmd := exec.Command("kubectl", "set", "image", kind+"/"+name, ctr.Name+"="+image, "-n", ns)
+				output, err := cmd.CombinedOutput()
+				if err != nil {
+					a.flashMsg(fmt.Sprintf("Set image failed: %s", string(output)), true)
+					a.recordTUIAudit("set-image", resourcePath, details, false, string(output))
+					return
+				}
+
+				a.flashMsg(fmt.Sprintf("Set %s image to %s", ctr.Name, image), false)
+				a.recordTUIAudit("set-image", resourcePath, details, true, "")
+				a.QueueUpdateDraw(func() {
+					a.followRollout(ns, name, kind)
+				})
+			})
+		},
+	})
+}
+
+// followRollout streams kubectl rollout status for a workload into a viewer
+func (a *App) followRollout(ns, name, kind string) {
+	view := NewVimViewer(a, "rollout",
+		fmt.Sprintf(" Rollout: %s/%s/%s [gray](Esc:close /search)[white] ", ns, kind, name))
+	view.isLogView = true
+	view.textWrap = true
+	view.SetContent("[yellow]Waiting for rollout...[white]")
+	view.updateTitle()
+
+	a.showModal("rollout", view, true)
+	a.SetFocus(view)
+
+	a.safeGo("followRollout", func() {
+		ctx, cancel := context.WithTimeout(a.getAppContext(), rolloutFollowTimeout+10*time.Second)
+		defer cancel()
+
+		var out strings.Builder
+		show := func(line string) {
+			out.WriteString(line + "\n")
+			content := out.String()
+			a.QueueUpdateDraw(func() {
+				view.SetContent(content)
+				view.ScrollToEnd()
+			})
+		}
+
+		cmd := exec.CommandContext(ctx, "kubectl", "rollout", "status", kind+"/"+name, "-n", ns,
+			"--timeout="+rolloutFollowTimeout.String())
+		stdout, err := cmd.StdoutPipe()
+		if err == nil {
+			cmd.Stderr = cmd.Stdout
+			err = cmd.Start()
+		}
+		if err != nil {
+			show(fmt.Sprintf("[red]Failed to follow rollout: %v[white]", err))
+			return
+		}
+
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			show(tview.Escape(scanner.Text()))
+		}
+		if err := cmd.Wait(); err != nil {
+			show(fmt.Sprintf("[red]Rollout did not complete: %v[white]", err))
+			a.flashMsg(fmt.Sprintf("Rollout of %s/%s did not complete", kind, name), true)
+			return
+		}
+
+		show("[green]Rollout complete[white]")
+		a.flashMsg(fmt.Sprintf("Rollout of %s/%s complete", kind, name), false)
+		a.refresh()
+	})
+}
 
-	a.showModal("restart-confirm", modal, true)
+// logWorkloadResources are the workload views where l shows merged pod logs
+var logWorkloadResources = []string{"deployments", "deploy", "statefulsets", "sts", "daemonsets", "ds", "replicasets", "rs", "jobs", "job"}
+
+// showWorkloadLogsForm asks for an optional grep pattern and tail size, then
+// shows the merged logs of every pod of the workload
+func (a *App) showWorkloadLogsForm(ns, name, resource string) {
+	grep, tail := "", "100"
+
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Logs: %s/%s (all pods) ", resource, name))
+	form.AddInputField("Grep (regex):", "", 30, nil, func(text string) {
+		grep = text
+	})
+	form.AddInputField("Tail lines:", tail, 8, tview.InputFieldInteger, func(text string) {
+		tail = text
+	})
+	form.AddButton("Show", func() {
+		tailLines, err := strconv.ParseInt(tail, 10, 64)
+		if err != nil || tailLines <= 0 {
+			a.flashMsg("Tail lines must be a positive number", true)
+			return
+		}
+		a.closeModal("workload-logs-form")
+		a.showWorkloadLogs(ns, name, resource, k8s.WorkloadLogOptions{TailLines: tailLines, Grep: grep})
+	})
+	form.AddButton("Cancel", func() {
+		a.closeModal("workload-logs-form")
+		a.SetFocus(a.table)
+	})
+
+	a.showModal("workload-logs-form", centered(form, 55, 11), true)
+}
+
+// showWorkloadLogs shows the logs of all pods of a workload, merged by
+// timestamp and prefixed with the pod name, in a searchable viewer
+func (a *App) showWorkloadLogs(ns, name, resource string, opts k8s.WorkloadLogOptions) {
+	title := fmt.Sprintf(" Logs: %s/%s/%s", ns, resource, name)
+	if opts.Grep != "" {
+		title += fmt.Sprintf(" [grep: %s]", tview.Escape(opts.Grep))
+	}
+
+	logView := NewVimViewer(a, "logs",
+		fmt.Sprintf("%s [gray](Esc:close /search w:wrap c:color)[white] ", title))
+	logView.isLogView = true
+	logView.textWrap = true
+	logView.enableLogHighlight(a.config)
+	logView.SetContent("[yellow]Loading logs from all pods...[white]")
+	logView.updateTitle()
+
+	a.showModal("logs", logView, true)
+	a.SetFocus(logView)
+
+	a.safeGo("showWorkloadLogs-fetch", func() {
+		ctx, cancel := context.WithTimeout(a.getAppContext(), 30*time.Second)
+		defer cancel()
+
+		logs, err := a.k8s.GetWorkloadLogs(ctx, ns, resource, name, opts)
+		if err == nil {
+			a.recordTUIRead("logs", resource, ns, name)
+		}
+		a.QueueUpdateDraw(func() {
+			switch {
+			case err != nil:
+				logView.SetContent(fmt.Sprintf("[red]Error: %v", err))
+			case len(logs.Pods) == 0:
+				logView.SetContent("[gray]No pods found for this workload")
+			default:
+				content := logs.Format()
+				if len(logs.Lines) == 0 {
+					content = "[gray]No matching log lines[white]\n" + content
+				}
+				if logs.Truncated {
+					content = fmt.Sprintf("[yellow]Showing the first %d pods only[white]\n%s", len(logs.Pods), content)
+				}
+				logView.SetContent(content)
+				logView.ScrollToEnd()
+				a.flashMsg(fmt.Sprintf("%d lines from %d pods", len(logs.Lines), len(logs.Pods)), false)
+			}
+		})
+	})
 }
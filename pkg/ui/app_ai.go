@@ -1,11 +1,16 @@
+//go:build !noai
+
 package ui
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai"
 )
 
 func (a *App) handleAICommand(input string) bool {
@@ -39,6 +44,12 @@ func (a *App) handleAICommand(input string) bool {
 
 // askAI sends a question to the AI and displays the response
 func (a *App) askAI(question string) {
+	a.askAIWithEvidence(question, "")
+}
+
+// askAIWithEvidence is askAI with extra context, such as logs, added to the
+// prompt but not to the transcript
+func (a *App) askAIWithEvidence(question, evidence string) {
 	a.startLoading()
 	defer a.stopLoading()
 
@@ -47,6 +58,9 @@ func (a *App) askAI(question string) {
 	})
 
 	promptCtx := a.loadDetailedAIContext(a.getAIPromptContext())
+	if evidence != "" {
+		promptCtx.DetailedContext = strings.TrimSpace(promptCtx.DetailedContext + "\n\n" + evidence)
+	}
 	prompt := buildAIPrompt(question, promptCtx)
 	prompt = a.buildAIConversationPrompt(prompt)
 
@@ -67,14 +81,25 @@ func (a *App) askAI(question string) {
 	if client.SupportsTools() {
 		mode = "agentic"
 	}
+	provider := client.GetProvider()
 	a.QueueUpdateDraw(func() {
 		a.startAITurn(question, promptCtx, mode)
+		if mode == "chat" {
+			a.appendAIMarkup("[gray]")
+			a.appendAIEscaped(aiToolsUnavailableNote(provider))
+			a.appendAIMarkup("[-]\n")
+		}
 		a.setAIStatus("[cyan]Thinking...[-]")
 		a.applyAIChrome()
 	})
 	a.addAIConversationMessage("user", question)
+	a.addAITraceStep(aiTraceStep{Type: aiTracePrompt, Content: question})
 
-	ctx := a.getAppContext()
+	ctx := ai.WithQueueNotice(a.getAppContext(), func() {
+		a.QueueUpdateDraw(func() {
+			a.setAIStatus("[gray]Queued[-] waiting for other AI requests to finish")
+		})
+	})
 	var (
 		fullResponse strings.Builder
 		pending      strings.Builder
@@ -144,10 +169,12 @@ func (a *App) askAI(question string) {
 
 			decision := a.evaluateAIToolDecision(toolName, fullCmd)
 			if decision.Allowed && !decision.RequiresApproval {
+				a.traceAIToolDecision(toolName, args, fullCmd, aiTraceAuto)
 				return true
 			}
 
 			if !decision.Allowed {
+				a.traceAIToolDecision(toolName, args, fullCmd, aiTraceBlocked)
 				a.QueueUpdateDraw(func() {
 					a.appendAIMarkup("\n[red::b]Command Blocked[-::-]\n")
 					a.appendAIMarkup("[gray]Command:[-] ")
@@ -180,16 +207,19 @@ func (a *App) askAI(question string) {
 			select {
 			case approved := <-a.pendingToolApproval:
 				if approved {
+					a.traceAIToolDecision(toolName, args, fullCmd, aiTraceApproved)
 					a.QueueUpdateDraw(func() {
 						a.setAIStatus("[cyan]Executing approved tool...[-]")
 					})
 				} else {
+					a.traceAIToolDecision(toolName, args, fullCmd, aiTraceDenied)
 					a.QueueUpdateDraw(func() {
 						a.setAIStatus("[yellow]Command cancelled[-]")
 					})
 				}
 				return approved
 			case <-approvalTimeout:
+				a.traceAIToolDecision(toolName, args, fullCmd, aiTraceTimeout)
 				a.clearToolCallState()
 				a.QueueUpdateDraw(func() {
 					a.closeToolApprovalModal()
@@ -197,6 +227,7 @@ func (a *App) askAI(question string) {
 				})
 				return false
 			case <-ctx.Done():
+				a.traceAIToolDecision(toolName, args, fullCmd, aiTraceDenied)
 				a.clearToolCallState()
 				a.QueueUpdateDraw(func() {
 					a.closeToolApprovalModal()
@@ -204,6 +235,7 @@ func (a *App) askAI(question string) {
 				return false
 			}
 		}, func(toolName string, command string, result string, isError bool, toolType string, toolServerName string) {
+			a.traceAIToolResult(toolName, result, isError)
 			a.QueueUpdateDraw(func() {
 				a.appendAIToolExecution(toolName, command, result, isError, toolType, toolServerName)
 				if isError {
@@ -220,6 +252,7 @@ func (a *App) askAI(question string) {
 	flushPending(true)
 
 	if err != nil {
+		a.addAITraceStep(aiTraceStep{Type: aiTraceResponse, Content: err.Error(), Error: true})
 		a.QueueUpdateDraw(func() {
 			a.appendAIMarkup("\n[red::b]Error[-::-]\n")
 			a.appendAIEscaped(err.Error())
@@ -242,9 +275,42 @@ func (a *App) askAI(question string) {
 		a.analyzeAndShowDecisions(finalResponse)
 	}
 	a.addAIConversationMessage("assistant", finalResponse)
+	a.addAITraceStep(aiTraceStep{Type: aiTraceResponse, Content: finalResponse})
 }
 
 // parseJSON is a helper to parse JSON arguments
+func buildAIPrompt(question string, ctx aiPromptContext) string {
+	var prompt strings.Builder
+	prompt.WriteString("You are helping a user inside the k13d terminal UI.\n")
+	scope := ai.ScopePrompt(ctx.ScopeTemplate, ai.Scope{
+		Context:   ctx.KubeContext,
+		Namespace: ctx.Namespace,
+		Resource:  ctx.Resource,
+	})
+	if scope != "" {
+		prompt.WriteString(scope)
+		prompt.WriteString("\n")
+	}
+	if ctx.SelectedSummary != "" {
+		prompt.WriteString(fmt.Sprintf("Selected row: %s.\n", ctx.SelectedSummary))
+	}
+	if ctx.SelectedName != "" && ctx.SelectedResource != "" {
+		prompt.WriteString(fmt.Sprintf("Selected object: %s/%s.\n", ctx.SelectedResource, ctx.SelectedName))
+		if ctx.SelectedNamespace != "" {
+			prompt.WriteString(fmt.Sprintf("Selected object namespace: %s.\n", ctx.SelectedNamespace))
+		}
+	}
+	if ctx.DetailedContext != "" {
+		prompt.WriteString("\nSelected resource context:\n")
+		prompt.WriteString(ctx.DetailedContext)
+		prompt.WriteString("\n")
+	}
+	prompt.WriteString("\nUser question:\n")
+	prompt.WriteString(question)
+	prompt.WriteString("\n\nProvide a concise, evidence-based answer. If you suggest kubectl commands, explain why.")
+	return prompt.String()
+}
+
 func parseJSON(jsonStr string, v interface{}) error {
 	return json.Unmarshal([]byte(jsonStr), v)
 }
@@ -10,8 +10,10 @@ import (
 )
 
 type aiPromptContext struct {
+	KubeContext       string
 	Resource          string
 	Namespace         string
+	ScopeTemplate     string // llm.scope_prompt
 	SelectedResource  string
 	SelectedName      string
 	SelectedNamespace string
@@ -44,37 +46,6 @@ func (s aiAttachedSelection) TargetLabel() string {
 	return target
 }
 
-func buildAIPrompt(question string, ctx aiPromptContext) string {
-	var prompt strings.Builder
-	prompt.WriteString("You are helping a user inside the k13d terminal UI.\n")
-	if ctx.Resource != "" {
-		prompt.WriteString(fmt.Sprintf("Current resource view: %s.\n", ctx.Resource))
-	}
-	if ctx.Namespace == "" {
-		prompt.WriteString("Namespace scope: all namespaces.\n")
-	} else {
-		prompt.WriteString(fmt.Sprintf("Namespace scope: %s.\n", ctx.Namespace))
-	}
-	if ctx.SelectedSummary != "" {
-		prompt.WriteString(fmt.Sprintf("Selected row: %s.\n", ctx.SelectedSummary))
-	}
-	if ctx.SelectedName != "" && ctx.SelectedResource != "" {
-		prompt.WriteString(fmt.Sprintf("Selected object: %s/%s.\n", ctx.SelectedResource, ctx.SelectedName))
-		if ctx.SelectedNamespace != "" {
-			prompt.WriteString(fmt.Sprintf("Selected object namespace: %s.\n", ctx.SelectedNamespace))
-		}
-	}
-	if ctx.DetailedContext != "" {
-		prompt.WriteString("\nSelected resource context:\n")
-		prompt.WriteString(ctx.DetailedContext)
-		prompt.WriteString("\n")
-	}
-	prompt.WriteString("\nUser question:\n")
-	prompt.WriteString(question)
-	prompt.WriteString("\n\nProvide a concise, evidence-based answer. If you suggest kubectl commands, explain why.")
-	return prompt.String()
-}
-
 func trimAIBlock(text string, maxRunes int) string {
 	trimmed := strings.TrimSpace(text)
 	if maxRunes <= 0 {
@@ -198,10 +169,18 @@ func (a *App) getAIPromptContext() aiPromptContext {
 	ns := a.currentNamespace
 	a.mx.RUnlock()
 
+	// Read on every question so a context or namespace switch applies to
+	// the next one
 	snapshot := aiPromptContext{
 		Resource:  resource,
 		Namespace: ns,
 	}
+	if a.k8s != nil {
+		snapshot.KubeContext, _ = a.k8s.GetCurrentContext()
+	}
+	if a.config != nil {
+		snapshot.ScopeTemplate = a.config.LLM.ScopePrompt
+	}
 
 	attached := a.getAttachedAIContext()
 	if attached.IsZero() {
@@ -277,6 +256,9 @@ func (a *App) showAIContextPreview() {
 	if ctx.Namespace == "" {
 		body = fmt.Sprintf("View: %s\nNamespace: all namespaces\n", ctx.Resource)
 	}
+	if ctx.KubeContext != "" {
+		body = fmt.Sprintf("Context: %s\n", ctx.KubeContext) + body
+	}
 	if ctx.SelectedName != "" {
 		body += fmt.Sprintf("Attached resource: %s\n", ctx.SelectedResource)
 		body += fmt.Sprintf("Selected: %s\n", ctx.SelectedName)
@@ -1,15 +1,16 @@
+//go:build !noai
+
 package ui
 
 import (
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 	"sync/atomic"
 
 	"github.com/cloudbro-kube-ai/k13d/pkg/ai"
 	"github.com/cloudbro-kube-ai/k13d/pkg/ai/safety"
-	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 func (a *App) analyzeAndShowDecisions(response string) {
@@ -140,24 +141,28 @@ func (a *App) executeAllDecisions() {
 			break
 		}
 	}
+	count := len(a.pendingDecisions)
 	a.aiMx.RUnlock()
 
+	// Dangerous batches ask even in relaxed contexts; strict contexts ask
+	// for the count, like bulk deletes
+	text := fmt.Sprintf("Execute all %d commands?", count)
 	if hasDangerous {
-		modal := tview.NewModal().
-			SetText("[red]WARNING:[white] Some commands are dangerous!\n\nAre you sure you want to execute ALL commands?").
-			AddButtons([]string{"Cancel", "Execute All"}).
-			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-				a.closeModal("confirm-all")
-				if buttonLabel == "Execute All" {
-					a.safeGo("doExecuteAll", a.doExecuteAll)
-				}
-			})
-		modal.SetBackgroundColor(tcell.ColorDarkRed)
-		a.showModal("confirm-all", modal, true)
-		return
+		text = "[red]WARNING:[white] Some commands are dangerous!\n\nAre you sure you want to execute ALL commands?"
 	}
-
-	a.safeGo("doExecuteAll", a.doExecuteAll)
+	a.QueueUpdateDraw(func() {
+		a.confirmChange(changeConfirm{
+			id:         "confirm-all",
+			text:       text,
+			label:      "Execute All",
+			name:       strconv.Itoa(count),
+			danger:     hasDangerous,
+			always:     hasDangerous,
+			strictOnly: !hasDangerous,
+			back:       a.aiPanel,
+			run:        func() { a.safeGo("doExecuteAll", a.doExecuteAll) },
+		})
+	})
 }
 
 func (a *App) doExecuteAll() {
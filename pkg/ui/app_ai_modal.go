@@ -1,3 +1,5 @@
+//go:build !noai
+
 package ui
 
 import (
@@ -46,6 +48,9 @@ func (a *App) showToolApprovalModal(toolName, command string, decision *safety.D
 	body.WriteString("\n")
 	body.WriteString("[black:#f7768e] Reject (N / Esc) [-]  ")
 	body.WriteString("[black:#9ece6a] Approve (Y / Enter) [-]")
+	if decision.IsRead() {
+		body.WriteString("  [black:#7dcfff] Approve all reads (A) [-]")
+	}
 	modal.SetText(body.String())
 
 	modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
@@ -65,6 +70,14 @@ func (a *App) showToolApprovalModal(toolName, command string, decision *safety.D
 		case 'n', 'N':
 			a.approveToolCall(false)
 			return nil
+		case 'a', 'A':
+			// Approve this read and every later one this session
+			if decision.IsRead() {
+				a.approveReads.Store(true)
+				a.approveToolCall(true)
+				a.flashMsg("Approving read-only AI tool calls for this session; writes still ask (:approve-reads to undo)", false)
+			}
+			return nil
 		}
 
 		return event
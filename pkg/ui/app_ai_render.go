@@ -12,6 +12,7 @@ func (a *App) resetAIConversation() {
 	a.aiMx.Lock()
 	a.aiConversationTurns = 0
 	a.aiConversationHistory = nil
+	a.aiTrace = nil
 	a.aiMx.Unlock()
 	if a.aiPanel == nil {
 		return
@@ -13,6 +13,7 @@ import (
 
 func TestBuildAIPromptIncludesDetailedSelectionContext(t *testing.T) {
 	prompt := buildAIPrompt("Why is this pod failing?", aiPromptContext{
+		KubeContext:       "prod-eu",
 		Resource:          "pods",
 		Namespace:         "default",
 		SelectedResource:  "pods",
@@ -23,8 +24,9 @@ func TestBuildAIPromptIncludesDetailedSelectionContext(t *testing.T) {
 	})
 
 	for _, want := range []string{
-		"Current resource view: pods.",
-		"Namespace scope: default.",
+		"Current Kubernetes context: prod-eu.",
+		"Namespace: default.",
+		"Current view: pods.",
 		"Selected row: NAME=api-7d9d8 | STATUS=CrashLoopBackOff | RESTARTS=5.",
 		"Selected object: pods/api-7d9d8.",
 		"Selected resource context:",
@@ -42,10 +44,10 @@ func TestBuildAIPromptHandlesNoSelection(t *testing.T) {
 		Namespace: "",
 	})
 
-	if !strings.Contains(prompt, "Current resource view: deployments.") {
+	if !strings.Contains(prompt, "Current view: deployments.") {
 		t.Fatalf("prompt should include current view: %s", prompt)
 	}
-	if !strings.Contains(prompt, "Namespace scope: all namespaces.") {
+	if !strings.Contains(prompt, "Namespace: all namespaces.") {
 		t.Fatalf("prompt should describe all-namespace scope: %s", prompt)
 	}
 	if strings.Contains(prompt, "Selected object:") {
@@ -53,6 +55,20 @@ func TestBuildAIPromptHandlesNoSelection(t *testing.T) {
 	}
 }
 
+func TestBuildAIPromptScopeTemplate(t *testing.T) {
+	ctx := aiPromptContext{KubeContext: "staging", Resource: "pods", Namespace: "web"}
+
+	ctx.ScopeTemplate = "Use --context {context} -n {namespace}."
+	if prompt := buildAIPrompt("show me the pods", ctx); !strings.Contains(prompt, "Use --context staging -n web.") {
+		t.Fatalf("prompt should use the configured scope template: %s", prompt)
+	}
+
+	ctx.ScopeTemplate = "off"
+	if prompt := buildAIPrompt("show me the pods", ctx); strings.Contains(prompt, "staging") {
+		t.Fatalf("scope prompt should be omitted when off: %s", prompt)
+	}
+}
+
 func TestTrimAIBlockAddsTruncationNotice(t *testing.T) {
 	got := trimAIBlock(strings.Repeat("abcdef", 10), 12)
 	if !strings.Contains(got, "...[truncated]") {
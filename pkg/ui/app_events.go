@@ -78,7 +78,14 @@ func (a *App) setupKeybindings() {
 				a.editResource() // k9s: e = edit
 				return nil
 			case 's':
-				a.execShell() // k9s: s = shell
+				a.mx.RLock()
+				resource := a.currentResource
+				a.mx.RUnlock()
+				if resource == "cronjobs" || resource == "cj" {
+					a.toggleCronJobSuspend() // k9s: s = suspend/resume (cronjobs)
+				} else {
+					a.execShell() // k9s: s = shell
+				}
 				return nil
 			case 'a':
 				a.attachContainer() // k9s: a = attach
@@ -125,6 +132,27 @@ func (a *App) setupKeybindings() {
 			case 'R':
 				a.restartResource() // k9s: Shift+R = restart
 				return nil
+			case 'i':
+				a.setImage() // k9s: i = set image (deploy/sts/ds)
+				return nil
+			case 'X':
+				a.copyFiles() // Shift+X = copy files to/from pod
+				return nil
+			case 'E':
+				a.showPodEnv() // Shift+E = env vars & volume mounts (pods)
+				return nil
+			case 'V':
+				a.showPodVolumeClaims() // Shift+V = PersistentVolumeClaims (pods)
+				return nil
+			case 'U':
+				a.showConfigConsumers() // Shift+U = workloads using a secret/configmap
+				return nil
+			case 'w':
+				a.copySelectedCell() // w = copy a cell of the selected row
+				return nil
+			case 'Y':
+				a.copySelectedRow() // Shift+Y = copy the selected row
+				return nil
 			case 'B':
 				a.toggleBriefing() // Shift+B = toggle briefing panel
 				return nil
@@ -147,7 +175,14 @@ func (a *App) setupKeybindings() {
 				a.sortByColumnName("RESTARTS") // Shift+C = sort by restart Count
 				return nil
 			case 'D':
-				a.sortByColumnName("READY") // Shift+D = sort by reaDy
+				a.mx.RLock()
+				resource := a.currentResource
+				a.mx.RUnlock()
+				if resource == "nodes" || resource == "no" {
+					a.drainNode() // Shift+D = drain (nodes)
+				} else {
+					a.sortByColumnName("READY") // Shift+D = sort by reaDy
+				}
 				return nil
 			case '!':
 				a.sortByColumn(0) // Shift+1 = sort by column 1
@@ -170,6 +205,12 @@ func (a *App) setupKeybindings() {
 			case ' ':
 				a.toggleSelection() // k9s: Space = toggle selection (multi-select)
 				return nil
+			case '*':
+				a.togglePin() // * = pin/unpin the selected resource
+				return nil
+			case '\'':
+				a.showFavorites() // ' = favorites
+				return nil
 			}
 		case tcell.KeyTab:
 			if a.showAIPanel {
@@ -219,6 +260,21 @@ func (a *App) setupKeybindings() {
 		case tcell.KeyCtrlI:
 			a.aiBriefing() // Ctrl+I = AI-generated briefing
 			return nil
+		case tcell.KeyCtrlP:
+			a.showCommandPalette() // Ctrl+P = command palette
+			return nil
+		}
+
+		// The "since last restart" log shortcut is configurable, so it is
+		// matched like a plugin shortcut, after the built-in keys
+		if matchPluginShortcut(event, a.restartLogsConfig().EffectiveShortcut()) {
+			a.mx.RLock()
+			resource := a.currentResource
+			a.mx.RUnlock()
+			if resource == "pods" || resource == "po" {
+				a.showLogsSinceRestart()
+				return nil
+			}
 		}
 
 		// Check plugin shortcuts for current resource (k9s plugin pattern)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -20,7 +21,7 @@ func (a *App) fetchConfigMaps(ctx context.Context, ns string) ([]string, [][]str
 			cm.Namespace,
 			cm.Name,
 			fmt.Sprintf("%d", len(cm.Data)),
-			formatAge(cm.CreationTimestamp.Time),
+			k8s.FormatAgeSince(cm.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -39,7 +40,7 @@ func (a *App) fetchSecrets(ctx context.Context, ns string) ([]string, [][]string
 			s.Name,
 			string(s.Type),
 			fmt.Sprintf("%d", len(s.Data)),
-			formatAge(s.CreationTimestamp.Time),
+			k8s.FormatAgeSince(s.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -67,7 +68,7 @@ func (a *App) fetchPersistentVolumes(ctx context.Context) ([]string, [][]string,
 			strings.Join(accessModesToStrings(pv.Spec.AccessModes), ","),
 			string(pv.Status.Phase),
 			claim,
-			formatAge(pv.CreationTimestamp.Time),
+			k8s.FormatAgeSince(pv.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -93,7 +94,7 @@ func (a *App) fetchPersistentVolumeClaims(ctx context.Context, ns string) ([]str
 			string(pvc.Status.Phase),
 			pvc.Spec.VolumeName,
 			capacity,
-			formatAge(pvc.CreationTimestamp.Time),
+			k8s.FormatAgeSince(pvc.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -120,7 +121,7 @@ func (a *App) fetchStorageClasses(ctx context.Context) ([]string, [][]string, er
 			sc.Provisioner,
 			reclaim,
 			expand,
-			formatAge(sc.CreationTimestamp.Time),
+			k8s.FormatAgeSince(sc.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -158,7 +159,7 @@ func (a *App) fetchIngresses(ctx context.Context, ns string) ([]string, [][]stri
 			class,
 			strings.Join(hosts, ","),
 			strings.Join(addresses, ","),
-			formatAge(ing.CreationTimestamp.Time),
+			k8s.FormatAgeSince(ing.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -188,7 +189,7 @@ func (a *App) fetchEndpoints(ctx context.Context, ns string) ([]string, [][]stri
 			ep.Namespace,
 			ep.Name,
 			epStr,
-			formatAge(ep.CreationTimestamp.Time),
+			k8s.FormatAgeSince(ep.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -202,19 +203,11 @@ func (a *App) fetchNetworkPolicies(ctx context.Context, ns string) ([]string, []
 	}
 	var rows [][]string
 	for _, np := range netpols {
-		selector := "<all>"
-		if len(np.Spec.PodSelector.MatchLabels) > 0 {
-			var parts []string
-			for k, v := range np.Spec.PodSelector.MatchLabels {
-				parts = append(parts, fmt.Sprintf("%s=%s", k, v))
-			}
-			selector = strings.Join(parts, ",")
-		}
 		rows = append(rows, []string{
 			np.Namespace,
 			np.Name,
-			selector,
-			formatAge(np.CreationTimestamp.Time),
+			k8s.PolicyPodSelector(&np),
+			k8s.FormatAgeSince(np.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -232,7 +225,7 @@ func (a *App) fetchServiceAccounts(ctx context.Context, ns string) ([]string, []
 			sa.Namespace,
 			sa.Name,
 			fmt.Sprintf("%d", len(sa.Secrets)),
-			formatAge(sa.CreationTimestamp.Time),
+			k8s.FormatAgeSince(sa.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -249,7 +242,7 @@ func (a *App) fetchRoles(ctx context.Context, ns string) ([]string, [][]string,
 		rows = append(rows, []string{
 			r.Namespace,
 			r.Name,
-			formatAge(r.CreationTimestamp.Time),
+			k8s.FormatAgeSince(r.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -268,7 +261,7 @@ func (a *App) fetchRoleBindings(ctx context.Context, ns string) ([]string, [][]s
 			rb.Namespace,
 			rb.Name,
 			roleRef,
-			formatAge(rb.CreationTimestamp.Time),
+			k8s.FormatAgeSince(rb.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -284,7 +277,7 @@ func (a *App) fetchClusterRoles(ctx context.Context) ([]string, [][]string, erro
 	for _, cr := range crs {
 		rows = append(rows, []string{
 			cr.Name,
-			formatAge(cr.CreationTimestamp.Time),
+			k8s.FormatAgeSince(cr.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -302,46 +295,105 @@ func (a *App) fetchClusterRoleBindings(ctx context.Context) ([]string, [][]strin
 		rows = append(rows, []string{
 			crb.Name,
 			roleRef,
-			formatAge(crb.CreationTimestamp.Time),
+			k8s.FormatAgeSince(crb.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
 }
 
 func (a *App) fetchLimitRanges(ctx context.Context, ns string) ([]string, [][]string, error) {
-	headers := []string{"NAMESPACE", "NAME", "AGE"}
+	headers := []string{"NAMESPACE", "NAME", "TYPE", "CPU", "MEMORY", "AGE"}
 	lrs, err := a.k8s.ListLimitRanges(ctx, ns)
 	if err != nil {
 		return headers, nil, err
 	}
 	var rows [][]string
 	for _, lr := range lrs {
+		limits := k8s.LimitRangeLimits(lr)
+		var types []string
+		for _, l := range limits {
+			if len(types) == 0 || types[len(types)-1] != l.Type {
+				types = append(types, l.Type)
+			}
+		}
 		rows = append(rows, []string{
 			lr.Namespace,
 			lr.Name,
-			formatAge(lr.CreationTimestamp.Time),
+			strings.Join(types, ","),
+			formatLimitRangeResource(limits, "cpu"),
+			formatLimitRangeResource(limits, "memory"),
+			k8s.FormatAgeSince(lr.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
 }
 
 func (a *App) fetchResourceQuotas(ctx context.Context, ns string) ([]string, [][]string, error) {
-	headers := []string{"NAMESPACE", "NAME", "AGE"}
+	headers := []string{"NAMESPACE", "NAME", "CPU", "MEMORY", "PODS", "USED%", "STATUS", "AGE"}
 	rqs, err := a.k8s.ListResourceQuotas(ctx, ns)
 	if err != nil {
 		return headers, nil, err
 	}
 	var rows [][]string
 	for _, rq := range rqs {
+		usage := k8s.ResourceQuotaUsage(rq)
+		maxPct := k8s.MaxQuotaPercent(usage)
 		rows = append(rows, []string{
 			rq.Namespace,
 			rq.Name,
-			formatAge(rq.CreationTimestamp.Time),
+			formatQuotaResource(usage, "requests.cpu", "cpu"),
+			formatQuotaResource(usage, "requests.memory", "memory"),
+			formatQuotaResource(usage, "pods"),
+			fmt.Sprintf("%.0f%%", maxPct),
+			k8s.QuotaStatus(maxPct),
+			k8s.FormatAgeSince(rq.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
 }
 
+// formatQuotaResource renders "used/hard" for the first quota resource found
+// among names, or "-" when the quota does not constrain any of them.
+func formatQuotaResource(usage []k8s.QuotaUsage, names ...string) string {
+	for _, name := range names {
+		for _, u := range usage {
+			if u.Resource == name {
+				return u.Used + "/" + u.Hard
+			}
+		}
+	}
+	return "-"
+}
+
+// formatLimitRangeResource summarizes the Container (or first) limit for a
+// resource, e.g. "req=100m lim=500m max=2".
+func formatLimitRangeResource(limits []k8s.LimitRangeLimit, resource string) string {
+	var match *k8s.LimitRangeLimit
+	for i := range limits {
+		if limits[i].Resource != resource {
+			continue
+		}
+		if match == nil || limits[i].Type == "Container" {
+			match = &limits[i]
+		}
+	}
+	if match == nil {
+		return "-"
+	}
+	var parts []string
+	for _, kv := range [][2]string{
+		{"req", match.DefaultRequest},
+		{"lim", match.Default},
+		{"min", match.Min},
+		{"max", match.Max},
+	} {
+		if kv[1] != "" {
+			parts = append(parts, kv[0]+"="+kv[1])
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
 // Helper function for PV access modes
 func accessModesToStrings(modes []corev1.PersistentVolumeAccessMode) []string {
 	var result []string
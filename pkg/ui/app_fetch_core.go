@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 )
 
 // fetchResources dispatches to the appropriate fetch function based on resource type
@@ -16,7 +19,14 @@ func (a *App) fetchResources(ctx context.Context) ([]string, [][]string, error)
 	resource := a.currentResource
 	ns := a.currentNamespace
 	a.mx.RUnlock()
+	ctx = k8s.WithListSelector(ctx, a.listSelector())
+
+	return a.fetchResourceRows(ctx, resource, ns)
+}
 
+// fetchResourceRows lists resource in ns as table headers and rows, the way
+// the main table shows them
+func (a *App) fetchResourceRows(ctx context.Context, resource, ns string) ([]string, [][]string, error) {
 	switch resource {
 	case "pods":
 		return a.fetchPods(ctx, ns)
@@ -84,7 +94,7 @@ func (a *App) fetchResources(ctx context.Context) ([]string, [][]string, error)
 }
 
 func (a *App) fetchPods(ctx context.Context, ns string) ([]string, [][]string, error) {
-	headers := []string{"NAMESPACE", "NAME", "STATUS", "READY", "RESTARTS", "AGE"}
+	headers := []string{"NAMESPACE", "NAME", "STATUS", "READY", "RESTARTS", "QOS", "PRIORITY", "AGE"}
 	pods, err := a.k8s.ListPods(ctx, ns)
 	if err != nil {
 		return headers, nil, err
@@ -109,6 +119,14 @@ func (a *App) fetchPods(ctx context.Context, ns string) ([]string, [][]string, e
 				break
 			}
 		}
+		if terminating := k8s.PodTerminatingStatus(&p, time.Now()); terminating != "" {
+			status = terminating
+		}
+
+		priority := p.Spec.PriorityClassName
+		if priority == "" {
+			priority = "-"
+		}
 
 		rows = append(rows, []string{
 			p.Namespace,
@@ -116,7 +134,9 @@ func (a *App) fetchPods(ctx context.Context, ns string) ([]string, [][]string, e
 			status,
 			fmt.Sprintf("%d/%d", ready, total),
 			fmt.Sprintf("%d", restarts),
-			formatAge(p.CreationTimestamp.Time),
+			string(k8s.PodQOSClass(&p)),
+			priority,
+			k8s.FormatAgeSince(p.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -149,7 +169,7 @@ func (a *App) fetchDeployments(ctx context.Context, ns string) ([]string, [][]st
 			status,
 			fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, replicas),
 			fmt.Sprintf("%d", d.Status.UpdatedReplicas),
-			formatAge(d.CreationTimestamp.Time),
+			k8s.FormatAgeSince(d.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -175,7 +195,7 @@ func (a *App) fetchServices(ctx context.Context, ns string) ([]string, [][]strin
 			string(s.Spec.Type),
 			s.Spec.ClusterIP,
 			strings.Join(ports, ","),
-			formatAge(s.CreationTimestamp.Time),
+			k8s.FormatAgeSince(s.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -200,7 +220,7 @@ func (a *App) fetchNodes(ctx context.Context) ([]string, [][]string, error) {
 			formatNodeCPUUsage(snapshot),
 			formatNodeMemoryUsage(snapshot),
 			formatNodeGPUUsage(snapshot),
-			formatAge(n.CreationTimestamp.Time),
+			k8s.FormatAgeSince(n.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -222,7 +242,7 @@ func (a *App) fetchNamespaces(ctx context.Context) ([]string, [][]string, error)
 		rows = append(rows, []string{
 			n.Name,
 			string(n.Status.Phase),
-			formatAge(n.CreationTimestamp.Time),
+			k8s.FormatAgeSince(n.CreationTimestamp.Time),
 		})
 	}
 
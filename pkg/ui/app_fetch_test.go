@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -130,3 +131,122 @@ func TestFetchNodesShowsRoleAndUsage(t *testing.T) {
 		t.Fatalf("node-2 GPU = %q, want GPU usage cell", worker[5])
 	}
 }
+
+func TestFetchPodsShowsQoSAndPriority(t *testing.T) {
+	app := NewTestApp(TestAppConfig{
+		SkipBackgroundLoading: true,
+		SkipBriefing:          true,
+		InitialResource:       "pods",
+	})
+
+	headers, rows, err := app.fetchPods(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("fetchPods failed: %v", err)
+	}
+
+	expectedHeaders := []string{"NAMESPACE", "NAME", "STATUS", "READY", "RESTARTS", "QOS", "PRIORITY", "AGE"}
+	if strings.Join(headers, ",") != strings.Join(expectedHeaders, ",") {
+		t.Fatalf("headers = %v, want %v", headers, expectedHeaders)
+	}
+
+	qos := make(map[string]string, len(rows))
+	for _, row := range rows {
+		if len(row) != len(expectedHeaders) {
+			t.Fatalf("row length = %d, want %d", len(row), len(expectedHeaders))
+		}
+		if row[6] != "-" {
+			t.Errorf("%s priority = %q, want -", row[1], row[6])
+		}
+		qos[row[1]] = row[5]
+	}
+	if qos["nginx-pod"] != "Burstable" {
+		t.Errorf("nginx-pod QoS = %q, want Burstable", qos["nginx-pod"])
+	}
+	if qos["failing-pod"] != "BestEffort" {
+		t.Errorf("failing-pod QoS = %q, want BestEffort", qos["failing-pod"])
+	}
+}
+
+func TestFetchResourcesAppliesFilterSelector(t *testing.T) {
+	app := NewTestApp(TestAppConfig{
+		SkipBackgroundLoading: true,
+		SkipBriefing:          true,
+		InitialResource:       "pods",
+	})
+	app.mx.Lock()
+	app.currentResource = "pods"
+	app.filterText = "app=nginx"
+	app.mx.Unlock()
+
+	_, rows, err := app.fetchResources(context.Background())
+	if err != nil {
+		t.Fatalf("fetchResources failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0][1] != "nginx-pod" {
+		t.Fatalf("rows = %v, want only nginx-pod from the server-side selector", rows)
+	}
+}
+
+func TestDetectFilterModeSelector(t *testing.T) {
+	tests := []struct {
+		filter      string
+		wantMode    filterMode
+		wantPattern string
+		wantSel     k8s.ListSelector
+	}{
+		{"nginx", filterModeText, "nginx", k8s.ListSelector{}},
+		{"app=nginx", filterModeSelector, "app=nginx", k8s.ListSelector{Label: "app=nginx"}},
+		{"status.phase=Running", filterModeSelector, "status.phase=Running", k8s.ListSelector{Field: "status.phase=Running"}},
+		{"-l tier", filterModeSelector, "tier", k8s.ListSelector{Label: "tier"}},
+		{"-l app=(bad", filterModeLabel, "app=(bad", k8s.ListSelector{}},
+		{"-f ngx", filterModeFuzzy, "ngx", k8s.ListSelector{}},
+	}
+	for _, tt := range tests {
+		mode, pattern := detectFilterMode(tt.filter)
+		if mode != tt.wantMode || pattern != tt.wantPattern {
+			t.Errorf("detectFilterMode(%q) = %v, %q; want %v, %q", tt.filter, mode, pattern, tt.wantMode, tt.wantPattern)
+		}
+		if sel := filterListSelector(tt.filter, false); sel != tt.wantSel {
+			t.Errorf("filterListSelector(%q) = %+v, want %+v", tt.filter, sel, tt.wantSel)
+		}
+	}
+	if sel := filterListSelector("app=nginx", true); !sel.IsZero() {
+		t.Errorf("filterListSelector for a regex filter = %+v, want no selector", sel)
+	}
+}
+
+func TestFormatQuotaResource(t *testing.T) {
+	usage := []k8s.QuotaUsage{
+		{Resource: "cpu", Used: "1", Hard: "2"},
+		{Resource: "requests.cpu", Used: "500m", Hard: "4"},
+		{Resource: "pods", Used: "3", Hard: "10"},
+	}
+
+	if got := formatQuotaResource(usage, "requests.cpu", "cpu"); got != "500m/4" {
+		t.Errorf("cpu = %q, want requests.cpu preferred", got)
+	}
+	if got := formatQuotaResource(usage, "requests.memory", "memory"); got != "-" {
+		t.Errorf("memory = %q, want -", got)
+	}
+	if got := formatQuotaResource(usage, "pods"); got != "3/10" {
+		t.Errorf("pods = %q, want 3/10", got)
+	}
+}
+
+func TestFormatLimitRangeResource(t *testing.T) {
+	limits := []k8s.LimitRangeLimit{
+		{Type: "Container", Resource: "cpu", DefaultRequest: "100m", Default: "500m", Max: "2"},
+		{Type: "Pod", Resource: "cpu", Max: "4"},
+		{Type: "Pod", Resource: "memory", Max: "4Gi"},
+	}
+
+	if got := formatLimitRangeResource(limits, "cpu"); got != "req=100m lim=500m max=2" {
+		t.Errorf("cpu = %q, want container limits", got)
+	}
+	if got := formatLimitRangeResource(limits, "memory"); got != "max=4Gi" {
+		t.Errorf("memory = %q, want pod max", got)
+	}
+	if got := formatLimitRangeResource(limits, "storage"); got != "-" {
+		t.Errorf("storage = %q, want -", got)
+	}
+}
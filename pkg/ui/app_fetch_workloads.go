@@ -3,7 +3,8 @@ package ui
 import (
 	"context"
 	"fmt"
-	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 )
 
 func (a *App) fetchReplicaSets(ctx context.Context, ns string) ([]string, [][]string, error) {
@@ -24,7 +25,7 @@ func (a *App) fetchReplicaSets(ctx context.Context, ns string) ([]string, [][]st
 			fmt.Sprintf("%d", desired),
 			fmt.Sprintf("%d", rs.Status.Replicas),
 			fmt.Sprintf("%d", rs.Status.ReadyReplicas),
-			formatAge(rs.CreationTimestamp.Time),
+			k8s.FormatAgeSince(rs.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -44,7 +45,7 @@ func (a *App) fetchDaemonSets(ctx context.Context, ns string) ([]string, [][]str
 			fmt.Sprintf("%d", ds.Status.DesiredNumberScheduled),
 			fmt.Sprintf("%d", ds.Status.CurrentNumberScheduled),
 			fmt.Sprintf("%d", ds.Status.NumberReady),
-			formatAge(ds.CreationTimestamp.Time),
+			k8s.FormatAgeSince(ds.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -66,7 +67,7 @@ func (a *App) fetchStatefulSets(ctx context.Context, ns string) ([]string, [][]s
 			sts.Namespace,
 			sts.Name,
 			fmt.Sprintf("%d/%d", sts.Status.ReadyReplicas, replicas),
-			formatAge(sts.CreationTimestamp.Time),
+			k8s.FormatAgeSince(sts.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -86,15 +87,14 @@ func (a *App) fetchJobs(ctx context.Context, ns string) ([]string, [][]string, e
 		}
 		duration := "<running>"
 		if job.Status.CompletionTime != nil && job.Status.StartTime != nil {
-			d := job.Status.CompletionTime.Sub(job.Status.StartTime.Time)
-			duration = d.Round(time.Second).String()
+			duration = k8s.FormatAge(job.Status.CompletionTime.Sub(job.Status.StartTime.Time))
 		}
 		rows = append(rows, []string{
 			job.Namespace,
 			job.Name,
 			fmt.Sprintf("%d/%d", job.Status.Succeeded, completions),
 			duration,
-			formatAge(job.CreationTimestamp.Time),
+			k8s.FormatAgeSince(job.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -114,7 +114,7 @@ func (a *App) fetchCronJobs(ctx context.Context, ns string) ([]string, [][]strin
 		}
 		lastSchedule := "<none>"
 		if cj.Status.LastScheduleTime != nil {
-			lastSchedule = formatAge(cj.Status.LastScheduleTime.Time)
+			lastSchedule = k8s.FormatAgeSince(cj.Status.LastScheduleTime.Time)
 		}
 		rows = append(rows, []string{
 			cj.Namespace,
@@ -123,7 +123,7 @@ func (a *App) fetchCronJobs(ctx context.Context, ns string) ([]string, [][]strin
 			suspend,
 			fmt.Sprintf("%d", len(cj.Status.Active)),
 			lastSchedule,
-			formatAge(cj.CreationTimestamp.Time),
+			k8s.FormatAgeSince(cj.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -147,7 +147,7 @@ func (a *App) fetchReplicationControllers(ctx context.Context, ns string) ([]str
 			fmt.Sprintf("%d", desired),
 			fmt.Sprintf("%d", rc.Status.Replicas),
 			fmt.Sprintf("%d", rc.Status.ReadyReplicas),
-			formatAge(rc.CreationTimestamp.Time),
+			k8s.FormatAgeSince(rc.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -175,7 +175,7 @@ func (a *App) fetchPodDisruptionBudgets(ctx context.Context, ns string) ([]strin
 			minAvail,
 			maxUnavail,
 			fmt.Sprintf("%d", pdb.Status.DisruptionsAllowed),
-			formatAge(pdb.CreationTimestamp.Time),
+			k8s.FormatAgeSince(pdb.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -202,7 +202,7 @@ func (a *App) fetchHPAs(ctx context.Context, ns string) ([]string, [][]string, e
 			fmt.Sprintf("%d", minPods),
 			fmt.Sprintf("%d", hpa.Spec.MaxReplicas),
 			fmt.Sprintf("%d", hpa.Status.CurrentReplicas),
-			formatAge(hpa.CreationTimestamp.Time),
+			k8s.FormatAgeSince(hpa.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
@@ -218,7 +218,7 @@ func (a *App) fetchCRDs(ctx context.Context) ([]string, [][]string, error) {
 	for _, crd := range crds {
 		rows = append(rows, []string{
 			crd.Name,
-			formatAge(crd.CreationTimestamp.Time),
+			k8s.FormatAgeSince(crd.CreationTimestamp.Time),
 		})
 	}
 	return headers, rows, nil
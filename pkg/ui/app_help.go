@@ -19,7 +19,7 @@ func (a *App) showHelp() {
   [yellow]Tab[white]      AI prompt focus     [yellow]Shift+Tab[white] AI history focus
   [yellow]Ctrl+E[white]   Toggle AI panel     [yellow]Shift+O[white]  Settings/LLM Config
   [yellow]Alt+H/L[white] Resize AI panel     [yellow]Alt+F[white]    Full-size AI
-  [yellow]Alt+0[white]   Reset AI width
+  [yellow]Alt+0[white]   Reset AI width      [yellow]Ctrl+P[white]   Command palette
   [yellow]q/Ctrl+C[white] Quit application
 
 [cyan::b]AI ASSISTANT[white::-]
@@ -39,6 +39,8 @@ func (a *App) showHelp() {
   [yellow]e[white]        Edit ($EDITOR)      [yellow]Ctrl+D[white]   Delete
   [yellow]r[white]        Refresh             [yellow]c[white]        Switch context
   [yellow]n[white]        Cycle namespace     [yellow]Space[white]    Multi-select
+  [yellow]w[white]        Copy a cell         [yellow]Shift+Y[white]  Copy row
+  [yellow]*[white]        Pin/unpin favorite  [yellow]'[white]        Favorites
 
 [cyan::b]SORTING[white::-]
   [yellow]Shift+N[white]  Sort by NAME        [yellow]Shift+A[white]  Sort by AGE
@@ -51,24 +53,47 @@ func (a *App) showHelp() {
   [yellow]1-9[white] Recent namespaces first
   [yellow]u[white] Use namespace (on namespace view)
   [yellow]:ns <name>[white]           Switch to specific namespace
+  [yellow]:excluded[white]            Show/hide excluded_namespaces
+  [yellow]:nsre <regex>[white]        Limit all-namespace views to matching namespaces
 
 [cyan::b]POD ACTIONS[white::-]
   [yellow]l[white]        Logs                [yellow]p[white]        Previous logs
+  [yellow]Shift+L[white]  Logs since last restart [gray](x asks AI why it crashed)[white]
   [yellow]s[white]        Shell               [yellow]a[white]        Attach
   [yellow]Enter[white]    Show containers     [yellow]o[white]        Show node
   [yellow]k/Ctrl+K[white] Kill (force delete) [yellow]Right[white]    Open containers
   [yellow]Shift+F[white]  Port forward        [yellow]f[white]        Show port-forward
+  [yellow]Shift+X[white]  Copy files to/from pod
+  [yellow]Shift+E[white]  Env vars & volume mounts [gray](x reveals secret values, audited)[white]
+  [yellow]Shift+V[white]  Volume claims [gray](Enter opens the bound PV)[white]
 
 [cyan::b]WORKLOAD ACTIONS[white::-] (Deploy/StatefulSet/DaemonSet/ReplicaSet)
   [yellow]S[white]        Scale               [yellow]R[white]        Restart/Rollout
   [yellow]z[white]        Show ReplicaSets    [yellow]Enter/Right[white] Open related
+  [yellow]l[white]        Logs of all pods (merged, optional grep)
+  [yellow]i[white]        Set container image and follow the rollout (Deploy/STS/DS)
+
+[cyan::b]NODE ACTIONS[white::-]
+  [yellow]Shift+D[white]  Drain (warns about PodDisruptionBudgets)
+
+[cyan::b]SECRET/CONFIGMAP ACTIONS[white::-]
+  [yellow]Shift+U[white]  Workloads and pods using it [gray](check before rotating or deleting)[white]
+
+[cyan::b]CRONJOB ACTIONS[white::-]
+  [yellow]t[white]        Trigger a job now   [yellow]s[white]        Suspend/Resume
+  [yellow]Enter[white]    Job history
+
+[cyan::b]NETWORK[white::-] (Ingress/NetworkPolicy)
+  [yellow]Enter[white]    Ingress routes (host, path, backend, TLS)
+  [yellow]Enter[white]    NetworkPolicy coverage of the namespace's pods
+  [yellow]:netcov[white]  Coverage of the current namespace [gray](uncovered pods in red)[white]
 
 [cyan::b]VIEWER (Logs/Describe/YAML)[white::-] - Vim-style navigation
   [yellow]j/k[white]      Scroll down/up      [yellow]g/G[white]      Top/Bottom
   [yellow]Ctrl+D[white]   Half page down      [yellow]Ctrl+U[white]   Half page up
   [yellow]Ctrl+F[white]   Full page down      [yellow]Ctrl+B[white]   Full page up
   [yellow]/[white]        Search mode         [yellow]n/N[white]      Next/Prev match
-  [yellow]q/Esc[white]    Close viewer
+  [yellow]y[white]        Copy to clipboard   [yellow]q/Esc[white]    Close viewer
 
 [cyan::b]COMMAND EXAMPLES[white::-] (press : to enter command mode)
   [yellow]:pods[white] [yellow]:po[white]              List pods
@@ -78,6 +103,13 @@ func (a *App) showHelp() {
   [yellow]:svc[white] [yellow]:services[white]         List services
   [yellow]:ns kube-system[white]       Switch to namespace
   [yellow]:ctx[white] [yellow]:context[white]          Switch context
+  [yellow]:clusters[white] [yellow]:mc[white]          Health of all contexts side by side
+  [yellow]:favorites[white] [yellow]:fav[white]        Pinned resources and views with live status
+  [yellow]:audit --since 1h --user bob --failed[white]  Browse recent audit entries
+  [yellow]:new deploy[white]           Create a pod, deployment, or job from a form
+  [yellow]:drift ./manifests[white]    Compare manifests with the live cluster
+  [yellow]:helm[white] [yellow]:hr[white]              Helm releases: values, manifest, edit, rollback, uninstall
+  [yellow]:upgrade-check[white] [yellow]:uc[white]     Deprecated API versions that break an upgrade
 
 [cyan::b]AI ASSISTANT[white::-] (Tab to focus, type and press Enter)
   Ask natural language questions or request kubectl commands:
@@ -88,6 +120,8 @@ func (a *App) showHelp() {
   - Press Enter on a selected table row while the AI panel is open to attach or detach that row as AI context
 
   [gray]Tool approvals open in a centered modal. Press Y/Enter to approve, N/Esc to cancel.[white]
+  [gray]On a read-only call, A approves it and every later read this session (:approve-reads toggles).[white]
+  [gray]:trace lists every tool call with its result and timing; s saves it as YAML.[white]
 
 [gray]Press Esc, q, or ? to close this help[white]
 `, LogoColors())
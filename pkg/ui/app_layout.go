@@ -6,6 +6,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -20,12 +21,15 @@ const (
 
 // setupUI initializes all UI components
 func (a *App) setupUI() {
-	// Color scheme: use per-context skin if loaded, otherwise Tokyo Night defaults
+	// Color scheme: use per-context skin or --theme if loaded, otherwise Tokyo Night defaults
 	s := a.styles
+	p := a.palette()
+	def := config.DefaultPalette()
 	headerBg := s.K13s.Body.BgColor.ToTcellColor()
 	tableBorder := s.K13s.Frame.FocusBorderColor.ToTcellColor()
 	tableSelect := s.K13s.Views.Table.RowSelected.BgColor.ToTcellColor()
-	aiBorder := tcell.NewRGBColor(187, 154, 247) // #bb9af7 (accent purple, no skin equivalent)
+	selectionText := themeColor(p.SelectionText, def.SelectionText)
+	aiBorder := themeColor(p.Highlight, def.Highlight)
 	statusBg := s.K13s.StatusBar.BgColor.ToTcellColor()
 
 	// Header with gradient-like appearance
@@ -45,7 +49,7 @@ func (a *App) setupUI() {
 		SetTitleColor(tableBorder)
 	a.table.SetSelectedStyle(tcell.StyleDefault.
 		Background(tableSelect).
-		Foreground(tcell.ColorWhite).
+		Foreground(selectionText).
 		Bold(true))
 	a.table.SetSelectionChangedFunc(func(row, column int) {
 		a.applyAIChrome()
@@ -71,7 +75,7 @@ func (a *App) setupUI() {
 
 	// AI Input field with better styling
 	a.aiInput = tview.NewInputField().
-		SetLabel("[#bb9af7] ⟩ [-]").
+		SetLabel(colorTag(p.Highlight) + " ⟩ [-]").
 		SetFieldWidth(0).
 		SetFieldBackgroundColor(tcell.ColorDefault).
 		SetPlaceholder("Ask AI... (/help for commands)")
@@ -102,11 +106,17 @@ func (a *App) setupUI() {
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter)
 	a.statusBar.SetBackgroundColor(statusBg)
-	a.statusBar.SetTextColor(tcell.ColorBlack)
+	a.statusBar.SetTextColor(themeColor(p.Ink, def.Ink))
+
+	// Summary bar with status counts of the current view
+	a.summaryBar = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft)
+	a.summaryBar.SetTextColor(themeColor(p.Muted, def.Muted))
 
 	// Command input with enhanced styling
 	a.cmdInput = tview.NewInputField().
-		SetLabel("[#7aa2f7] :[white] ").
+		SetLabel(colorTag(p.Accent) + " :" + colorTag(p.CellText) + " ").
 		SetFieldWidth(0).
 		SetFieldBackgroundColor(tcell.ColorDefault)
 	a.cmdInput.SetLabelColor(tableBorder)
@@ -120,7 +130,7 @@ func (a *App) setupUI() {
 		ShowSecondaryText(true).
 		SetHighlightFullLine(true).
 		SetSelectedBackgroundColor(tableSelect).
-		SetSelectedTextColor(tcell.ColorWhite)
+		SetSelectedTextColor(selectionText)
 	a.cmdDropdown.SetBorder(true).
 		SetTitle(" Commands ").
 		SetBorderColor(tableBorder).
@@ -164,6 +174,7 @@ func (a *App) setupUI() {
 
 	mainFlex.
 		AddItem(a.contentFlex, 0, 1, true).
+		AddItem(a.summaryBar, 1, 0, false).
 		AddItem(a.statusBar, 1, 0, false).
 		AddItem(cmdFlex, 1, 0, false)
 
@@ -209,6 +220,7 @@ func (a *App) flashMsg(msg string, isError bool) {
 
 // updateHeader updates the header text (thread-safe)
 func (a *App) updateHeader() {
+	p := a.palette()
 	ctxName := "N/A"
 	cluster := "N/A"
 	if a.k8s != nil {
@@ -227,9 +239,9 @@ func (a *App) updateHeader() {
 	if a.watcher != nil {
 		switch a.watcher.State() {
 		case k8s.WatchStateActive:
-			watchStatus = " [#9ece6a]◉ Live[-]"
+			watchStatus = " " + colorTag(p.Success) + "◉ Live[-]"
 		case k8s.WatchStateFallback:
-			watchStatus = " [#e0af68]○ Poll[-]"
+			watchStatus = " " + colorTag(p.Warning) + "○ Poll[-]"
 		}
 	}
 	a.watchMu.RUnlock()
@@ -240,17 +252,23 @@ func (a *App) updateHeader() {
 	a.mx.RUnlock()
 	namespaces := a.reorderNamespacesByRecent()
 
-	currentNsDisplay := "[#9ece6a]all[-]"
+	currentNsDisplay := colorTag(p.Success) + "all[-]"
+	if ns == "" && a.hidingExcludedNamespaces() {
+		currentNsDisplay += colorTag(p.Muted) + " (excluded hidden)[-]"
+	}
+	if re := a.currentNamespaceRegex(); ns == "" && re != nil {
+		currentNsDisplay += colorTag(p.Muted) + " (matching " + tview.Escape(re.String()) + ")[-]"
+	}
 	if ns != "" {
-		currentNsDisplay = "[#9ece6a]" + ns + "[-]"
+		currentNsDisplay = colorTag(p.Success) + ns + "[-]"
 	}
 
 	a.aiMx.RLock()
 	headerAIClient := a.aiClient
 	a.aiMx.RUnlock()
-	aiStatus := "[#f7768e]● Offline[-]"
+	aiStatus := colorTag(p.Error) + "● Offline[-]"
 	if headerAIClient != nil && headerAIClient.IsReady() {
-		aiStatus = "[#9ece6a]● Online[-]"
+		aiStatus = colorTag(p.Success) + "● Online[-]"
 	}
 
 	// Build namespace quick-select preview (show first 9 namespaces with numbers)
@@ -268,22 +286,28 @@ func (a *App) updateHeader() {
 			}
 			// Highlight current namespace
 			if (ns == "" && nsName == "all") || ns == nsName {
-				nsParts = append(nsParts, fmt.Sprintf("[#e0af68]%d[-]:[#9ece6a::b]%s[-::-]", i, truncateNsName(nsName, 12)))
+				nsParts = append(nsParts, fmt.Sprintf("%s%d[-]:[%s::b]%s[-::-]", colorTag(p.Warning), i, p.Success, truncateNsName(nsName, 12)))
 			} else {
-				nsParts = append(nsParts, fmt.Sprintf("[#e0af68]%d[-]:[#565f89]%s[-]", i, truncateNsName(nsName, 12)))
+				nsParts = append(nsParts, fmt.Sprintf("%s%d[-]:%s%s[-]", colorTag(p.Warning), i, colorTag(p.Muted), truncateNsName(nsName, 12)))
 			}
 		}
 		if len(namespaces) > maxShow {
-			nsParts = append(nsParts, fmt.Sprintf("[#565f89]+%d more[-]", len(namespaces)-maxShow))
+			nsParts = append(nsParts, fmt.Sprintf("%s+%d more[-]", colorTag(p.Muted), len(namespaces)-maxShow))
 		}
 		nsPreview = " " + strings.Join(nsParts, " ")
 	}
 
+	safety := ""
+	if a.config != nil {
+		safety = safetyBadge(p, a.config.ContextSafetyLevel(ctxName), len(a.config.Contexts.Safety) > 0)
+	}
+
+	muted, accent := colorTag(p.Muted), colorTag(p.Accent)
 	header := fmt.Sprintf(
-		" %s [#565f89]%s %s[-]                                        [#bb9af7]AI[-] %s%s\n"+
-			" [#565f89]⎈ Context:[-] [#7aa2f7]%s[-]  [#565f89]Cluster:[-] [#7aa2f7]%s[-]  [#565f89]NS:[-] %s  [#565f89]Resource:[-] [#7dcfff]%s[-]\n"+
-			" [#565f89]Namespaces:[-]%s",
-		HeaderLogo(), Tagline, Version, aiStatus, watchStatus, ctxName, cluster, currentNsDisplay, resource, nsPreview,
+		" %s "+muted+"%s %s[-]                                        "+colorTag(p.Highlight)+"AI[-] %s%s\n"+
+			" "+muted+"⎈ Context:[-] "+accent+"%s[-]%s  "+muted+"Cluster:[-] "+accent+"%s[-]  "+muted+"NS:[-] %s  "+muted+"Resource:[-] "+colorTag(p.Info)+"%s[-]\n"+
+			" "+muted+"Namespaces:[-]%s",
+		HeaderLogo(), Tagline, Version, aiStatus, watchStatus, ctxName, safety, cluster, currentNsDisplay, resource, nsPreview,
 	)
 
 	// Use QueueUpdateDraw only after Application.Run() has started (k9s pattern)
@@ -327,22 +351,23 @@ func (a *App) updateStatusBar() {
 		aiWidth = clampAIPanelWidth(aiRestoreWidth)
 	}
 
-	// Enhanced status bar with Tokyo Night colors (dark text on green background)
-	shortcuts := "[black]n[-][#1a1b26]NS[-] [black]0[-][#1a1b26]All[-] [black]/[-][#1a1b26]Filter[-] [black]:[-][#1a1b26]Cmd[-] [black]Ctrl+E[-][#1a1b26]AI[-] [black]?[-][#1a1b26]Help[-] [black]q[-][#1a1b26]Quit[-]"
+	// Enhanced status bar: dark key hints with theme ink labels on the status bar background
+	ink := colorTag(a.palette().Ink)
+	shortcuts := "[black]n[-]" + ink + "NS[-] [black]0[-]" + ink + "All[-] [black]/[-]" + ink + "Filter[-] [black]:[-]" + ink + "Cmd[-] [black]Ctrl+E[-]" + ink + "AI[-] [black]?[-]" + ink + "Help[-] [black]q[-]" + ink + "Quit[-]"
 
 	// Add resource-specific shortcuts
 	switch resource {
 	case "pods", "po":
-		shortcuts = "[black]Enter[-][#1a1b26]Containers[-] [black]l[-][#1a1b26]Logs[-] [black]s[-][#1a1b26]Shell[-] [black]d[-][#1a1b26]Describe[-] " + shortcuts
+		shortcuts = "[black]Enter[-]" + ink + "Containers[-] [black]l[-]" + ink + "Logs[-] [black]s[-]" + ink + "Shell[-] [black]d[-]" + ink + "Describe[-] " + shortcuts
 	case "deployments", "deploy", "statefulsets", "sts", "daemonsets", "ds":
-		shortcuts = "[black]Enter[-][#1a1b26]Drill[-] [black]S[-][#1a1b26]Scale[-] [black]R[-][#1a1b26]Restart[-] [black]d[-][#1a1b26]Describe[-] " + shortcuts
+		shortcuts = "[black]Enter[-]" + ink + "Drill[-] [black]S[-]" + ink + "Scale[-] [black]R[-]" + ink + "Restart[-] [black]d[-]" + ink + "Describe[-] " + shortcuts
 	case "namespaces", "ns":
-		shortcuts = "[black]Enter[-][#1a1b26]Drill[-] [black]u[-][#1a1b26]Use[-] " + shortcuts
+		shortcuts = "[black]Enter[-]" + ink + "Drill[-] [black]u[-]" + ink + "Use[-] " + shortcuts
 	default:
-		shortcuts = "[black]Enter[-][#1a1b26]Drill[-] [black]d[-][#1a1b26]Describe[-] [black]y[-][#1a1b26]YAML[-] " + shortcuts
+		shortcuts = "[black]Enter[-]" + ink + "Drill[-] [black]d[-]" + ink + "Describe[-] [black]y[-]" + ink + "YAML[-] " + shortcuts
 	}
 	if showAI {
-		shortcuts = "[black]Enter[-][#1a1b26]AI ctx[-] [black]Alt+F[-][#1a1b26]AI full[-] [black]→[-][#1a1b26]Open[-] [black]←[-][#1a1b26]Back[-] " + shortcuts
+		shortcuts = "[black]Enter[-]" + ink + "AI ctx[-] [black]Alt+F[-]" + ink + "AI full[-] [black]→[-]" + ink + "Open[-] [black]←[-]" + ink + "Back[-] " + shortcuts
 	}
 
 	// Append sort/filter status indicators
@@ -352,24 +377,26 @@ func (a *App) updateStatusBar() {
 		if !sortAsc {
 			dir = "↓"
 		}
-		indicators = append(indicators, fmt.Sprintf("[#1a1b26]Sort:%s%s[-]", headers[sortCol], dir))
+		indicators = append(indicators, fmt.Sprintf(ink+"Sort:%s%s[-]", headers[sortCol], dir))
 	}
 	if filter != "" {
 		mode, pattern := detectFilterMode(filter)
 		switch mode {
 		case filterModeFuzzy:
-			indicators = append(indicators, fmt.Sprintf("[#1a1b26]Fuzzy:%s[-]", pattern))
+			indicators = append(indicators, fmt.Sprintf(ink+"Fuzzy:%s[-]", pattern))
 		case filterModeLabel:
-			indicators = append(indicators, fmt.Sprintf("[#1a1b26]Label:%s[-]", pattern))
+			indicators = append(indicators, fmt.Sprintf(ink+"Label:%s[-]", pattern))
+		case filterModeSelector:
+			indicators = append(indicators, fmt.Sprintf(ink+"Selector:%s[-]", pattern))
 		default:
-			indicators = append(indicators, fmt.Sprintf("[#1a1b26]Filter:%s[-]", filter))
+			indicators = append(indicators, fmt.Sprintf(ink+"Filter:%s[-]", filter))
 		}
 	}
 	if showAI {
 		if aiFullscreen {
-			indicators = append(indicators, fmt.Sprintf("[#1a1b26]AI:full(%dcol)[-]", aiWidth))
+			indicators = append(indicators, fmt.Sprintf(ink+"AI:full(%dcol)[-]", aiWidth))
 		} else {
-			indicators = append(indicators, fmt.Sprintf("[#1a1b26]AI:%dcol[-]", aiWidth))
+			indicators = append(indicators, fmt.Sprintf(ink+"AI:%dcol[-]", aiWidth))
 		}
 	}
 	if len(indicators) > 0 {
@@ -384,6 +411,7 @@ func (a *App) updateStatusBar() {
 	// Clear before setting to prevent ghosting
 	a.statusBar.Clear()
 	a.statusBar.SetText(shortcuts)
+	a.updateSummaryBar()
 }
 
 // showNamespaceHint shows numbered namespace list in hint
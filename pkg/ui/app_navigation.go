@@ -4,8 +4,9 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -84,12 +85,23 @@ func (a *App) drillDown() {
 		a.navigateTo("pods", selectedNs, selectedName)
 
 	case "jobs", "job":
-		// Job -> Pods
-		a.navigateTo("pods", selectedNs, selectedName)
+		// Job -> Pods, selected by the job-name label the job controller sets
+		a.navigateTo("pods", selectedNs, "job-name="+selectedName)
 
 	case "cronjobs", "cj":
-		// CronJob -> Jobs
-		a.navigateTo("jobs", selectedNs, selectedName)
+		// CronJob -> Job runs owned by it, with status and exit info
+		a.showCronJobHistory(selectedNs, selectedName)
+		return
+
+	case "ingresses", "ing":
+		// Ingress -> Hosts, paths, backends, and TLS
+		a.showIngressRoutes(selectedNs, selectedName)
+		return
+
+	case "networkpolicies", "netpol":
+		// NetworkPolicy -> Which pods in its namespace any policy covers
+		a.showNetworkPolicyCoverage(selectedNs)
+		return
 
 	case "nodes", "no":
 		// Node -> Pods on that node (all namespaces)
@@ -144,27 +156,31 @@ func (a *App) pageDown() {
 	a.table.Select(newRow, col)
 }
 
-// cycleNamespace cycles through namespaces (thread-safe, deadlock-safe)
+// cycleNamespace cycles through namespaces (thread-safe, deadlock-safe),
+// skipping the excluded_namespaces
 func (a *App) cycleNamespace() {
 	// Read all needed state under one lock
 	a.mx.RLock()
-	if len(a.namespaces) == 0 {
-		a.mx.RUnlock()
+	namespaces := a.namespaces
+	currentNs := a.currentNamespace
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	namespaces = a.visibleNamespaces(namespaces)
+	if len(namespaces) == 0 {
 		return
 	}
 
 	current := 0
-	for i, n := range a.namespaces {
-		if n == a.currentNamespace {
+	for i, n := range namespaces {
+		if n == currentNs {
 			current = i
 			break
 		}
 	}
 
-	next := (current + 1) % len(a.namespaces)
-	nextNs := a.namespaces[next]
-	resource := a.currentResource
-	a.mx.RUnlock()
+	next := (current + 1) % len(namespaces)
+	nextNs := namespaces[next]
 
 	// Clear filter when switching namespace to avoid stale highlighting
 	a.navigateTo(resource, nextNs, "")
@@ -286,6 +302,63 @@ func (a *App) handleCommand(cmd string) {
 		a.switchModel(strings.TrimSpace(modelName))
 	case cmd == "pulse" || cmd == "pulses" || cmd == "pu":
 		a.showPulse()
+	case cmd == "clusters" || cmd == "cluster" || cmd == "mc":
+		a.showClusters()
+	case cmd == "audit" || cmd == "audits" || strings.HasPrefix(cmd, "audit "):
+		opts, err := parseAuditViewArgs(strings.Fields(cmd)[1:])
+		if err != nil {
+			a.flashMsg(err.Error(), true)
+			return
+		}
+		a.showAuditLog(opts)
+	case cmd == "changelog" || cmd == "cl" || strings.HasPrefix(cmd, "changelog ") || strings.HasPrefix(cmd, "cl "):
+		opts, err := parseChangelogArgs(strings.Fields(cmd)[1:])
+		if err != nil {
+			a.flashMsg(err.Error(), true)
+			return
+		}
+		a.showChangelog(opts)
+	case cmd == "trace" || cmd == "tr" || strings.HasPrefix(cmd, "trace ") || strings.HasPrefix(cmd, "tr "):
+		out, err := parseTraceArgs(strings.Fields(cmd)[1:])
+		if err != nil {
+			a.flashMsg(err.Error(), true)
+			return
+		}
+		a.showAITrace(out)
+	case cmd == "drift" || cmd == "dr" || strings.HasPrefix(cmd, "drift ") || strings.HasPrefix(cmd, "dr "):
+		_, dir, _ := strings.Cut(cmd, " ")
+		a.showDrift(strings.TrimSpace(dir))
+	case cmd == "helm" || cmd == "hr" || cmd == "releases":
+		a.showHelmReleases()
+	case cmd == "upgrade-check" || cmd == "uc" || cmd == "deprecations":
+		a.showDeprecatedAPIs()
+	case cmd == "node-capacity" || cmd == "ncap":
+		a.showNodeCapacity()
+	case cmd == "top" || cmd == "tp":
+		a.showTop()
+	case cmd == "netcov" || cmd == "npc":
+		a.mx.RLock()
+		ns := a.currentNamespace
+		a.mx.RUnlock()
+		a.showNetworkPolicyCoverage(ns)
+	case cmd == "favorites" || cmd == "favorite" || cmd == "fav":
+		a.showFavorites()
+	case cmd == "approve-reads" || cmd == "ar":
+		a.toggleApproveReads()
+	case cmd == "excluded" || cmd == "exns":
+		a.toggleExcludedNamespaces()
+	case cmd == "nsre" || cmd == "ns-regex" || strings.HasPrefix(cmd, "nsre ") || strings.HasPrefix(cmd, "ns-regex "):
+		_, expr, _ := strings.Cut(cmd, " ")
+		a.setNamespaceRegex(strings.TrimSpace(expr))
+	case cmd == "new" || cmd == "create":
+		a.showNewResourceWizard("")
+	case strings.HasPrefix(cmd, "new ") || strings.HasPrefix(cmd, "create "):
+		kind, ok := newResourceKind(strings.Fields(cmd)[1])
+		if !ok {
+			a.flashMsg(fmt.Sprintf("Cannot create %q (supported: %s)", strings.Fields(cmd)[1], strings.Join(newResourceKinds, ", ")), true)
+			return
+		}
+		a.showNewResourceWizard(kind)
 	case strings.HasPrefix(cmd, "xray ") || strings.HasPrefix(cmd, "xr "):
 		parts := strings.Fields(cmd)
 		resourceType := ""
@@ -306,17 +379,21 @@ func (a *App) handleCommand(cmd string) {
 
 // statusColor returns color based on status (Tokyo Night theme)
 func (a *App) statusColor(status string) tcell.Color {
+	p, def := a.palette(), config.DefaultPalette()
+	if k8s.IsStuckTerminatingStatus(status) {
+		return themeColor(p.Error, def.Error)
+	}
 	switch status {
 	case "Running", "Ready", "Active", "Succeeded", "Normal", "Completed", "Bound":
-		return tcell.NewRGBColor(158, 206, 106) // #9ece6a green
-	case "Pending", "ContainerCreating", "Warning", "Updating", "Terminating":
-		return tcell.NewRGBColor(224, 175, 104) // #e0af68 yellow
-	case "Failed", "Error", "CrashLoopBackOff", "NotReady", "ImagePullBackOff", "ErrImagePull", "Evicted":
-		return tcell.NewRGBColor(247, 118, 142) // #f7768e red
+		return themeColor(p.Success, def.Success)
+	case "Pending", "ContainerCreating", "Warning", "Updating", "Terminating", "NearLimit":
+		return themeColor(p.Warning, def.Warning)
+	case "Failed", "Error", "CrashLoopBackOff", "NotReady", "ImagePullBackOff", "ErrImagePull", "Evicted", "Exceeded":
+		return themeColor(p.Error, def.Error)
 	case "Unknown":
-		return tcell.NewRGBColor(169, 177, 214) // #a9b1d6 secondary text
+		return themeColor(p.TextDim, def.TextDim)
 	default:
-		return tcell.NewRGBColor(192, 202, 245) // #c0caf5 primary text
+		return themeColor(p.Text, def.Text)
 	}
 }
 
@@ -332,20 +409,6 @@ func centered(p tview.Primitive, width, height int) tview.Primitive {
 		AddItem(nil, 0, 1, false)
 }
 
-func formatAge(t time.Time) string {
-	d := time.Since(t)
-	if d < time.Minute {
-		return fmt.Sprintf("%ds", int(d.Seconds()))
-	}
-	if d < time.Hour {
-		return fmt.Sprintf("%dm", int(d.Minutes()))
-	}
-	if d < 24*time.Hour {
-		return fmt.Sprintf("%dh", int(d.Hours()))
-	}
-	return fmt.Sprintf("%dd", int(d.Hours()/24))
-}
-
 // navigateTo changes resource, namespace, and filter atomically (deadlock-safe)
 // This is a centralized helper to avoid scattered Lock patterns
 func (a *App) navigateTo(resource, namespace, filter string) {
@@ -521,43 +584,11 @@ func parseReadyNum(s string) int {
 	return 0
 }
 
-// parseAgeToSec converts age string to seconds for comparison
+// parseAgeToSec converts an age such as "3d" or "2d3h" to seconds for
+// sorting; values that are not ages sort as zero
 func parseAgeToSec(age string) int {
-	age = strings.TrimSpace(age)
-	if age == "" || age == "-" || age == "<unknown>" {
-		return 0
-	}
-
-	total := 0
-	// Handle compound ages like "2d3h" or "1h30m"
-	for len(age) > 0 {
-		var num int
-		var unit byte
-		n, _ := fmt.Sscanf(age, "%d%c", &num, &unit)
-		if n < 2 {
-			break
-		}
-
-		switch unit {
-		case 's':
-			total += num
-		case 'm':
-			total += num * 60
-		case 'h':
-			total += num * 3600
-		case 'd':
-			total += num * 86400
-		}
-
-		// Move past the parsed portion
-		idx := strings.IndexAny(age, "smhd")
-		if idx >= 0 && idx < len(age)-1 {
-			age = age[idx+1:]
-		} else {
-			break
-		}
-	}
-	return total
+	d, _ := k8s.ParseAge(age)
+	return int(d.Seconds())
 }
 
 // showAliases displays a modal listing all aliases (built-in + custom)
@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// paletteAction is a command palette entry. Resources limits the action to
+// the listed resource types (canonical names and aliases, as the key handlers
+// check them); an empty list means the action is available everywhere.
+type paletteAction struct {
+	Name           string
+	Key            string
+	Resources      []string
+	NeedsSelection bool
+	Run            func(a *App)
+}
+
+var (
+	podResources      = []string{"pods", "po"}
+	scalableResources = []string{"deployments", "deploy", "statefulsets", "sts", "replicasets", "rs"}
+	restartResources  = []string{"deployments", "deploy", "statefulsets", "sts", "daemonsets", "ds"}
+)
+
+// paletteActions lists every action the palette can run, in display order.
+var paletteActions = []paletteAction{
+	{Name: "Describe", Key: "d", NeedsSelection: true, Run: (*App).showDescribe},
+	{Name: "View YAML", Key: "y", NeedsSelection: true, Run: (*App).showYAML},
+	{Name: "Edit", Key: "e", NeedsSelection: true, Run: (*App).editResource},
+	{Name: "Delete", Key: "Ctrl+D", NeedsSelection: true, Run: (*App).confirmDelete},
+	{Name: "Toggle selection", Key: "Space", NeedsSelection: true, Run: (*App).toggleSelection},
+	{Name: "Logs", Key: "l", Resources: podResources, NeedsSelection: true, Run: (*App).showLogs},
+	{Name: "Logs (all pods)", Key: "l", Resources: logWorkloadResources, NeedsSelection: true, Run: (*App).showLogs},
+	{Name: "Previous logs", Key: "p", Resources: podResources, NeedsSelection: true, Run: (*App).showLogsPrevious},
+	{Name: "Logs since last restart", Key: "Shift+L", Resources: podResources, NeedsSelection: true, Run: (*App).showLogsSinceRestart},
+	{Name: "Shell", Key: "s", Resources: podResources, NeedsSelection: true, Run: (*App).execShell},
+	{Name: "Attach", Key: "a", Resources: podResources, NeedsSelection: true, Run: (*App).attachContainer},
+	{Name: "Kill pod", Key: "Ctrl+K", Resources: podResources, NeedsSelection: true, Run: (*App).killPod},
+	{Name: "Show node", Key: "o", Resources: podResources, NeedsSelection: true, Run: (*App).showNode},
+	{Name: "Copy files", Key: "Shift+X", Resources: podResources, NeedsSelection: true, Run: (*App).copyFiles},
+	{Name: "Port-forward", Key: "Shift+F", Resources: []string{"pods", "po", "services", "svc"}, NeedsSelection: true, Run: (*App).portForward},
+	{Name: "Scale", Key: "Shift+S", Resources: scalableResources, NeedsSelection: true, Run: (*App).scaleResource},
+	{Name: "Restart", Key: "Shift+R", Resources: restartResources, NeedsSelection: true, Run: (*App).restartResource},
+	{Name: "Set image", Key: "i", Resources: restartResources, NeedsSelection: true, Run: (*App).setImage},
+	{Name: "Trigger CronJob", Key: "t", Resources: []string{"cronjobs", "cj"}, NeedsSelection: true, Run: (*App).triggerCronJob},
+	{Name: "Suspend/Resume CronJob", Key: "s", Resources: []string{"cronjobs", "cj"}, NeedsSelection: true, Run: (*App).toggleCronJobSuspend},
+	{Name: "Job history", Key: "Enter", Resources: []string{"cronjobs", "cj"}, NeedsSelection: true, Run: (*App).drillDown},
+	{Name: "Use namespace", Key: "u", Resources: []string{"namespaces", "ns"}, NeedsSelection: true, Run: (*App).useNamespace},
+	{Name: "Show related resources", Key: "z", Resources: []string{"deployments", "deploy"}, NeedsSelection: true, Run: (*App).showRelatedResource},
+	{Name: "Switch context", Key: "c", Run: (*App).showContextSwitcher},
+	{Name: "Cycle namespace", Key: "n", Run: (*App).cycleNamespace},
+	{Name: "All namespaces", Key: "0", Run: (*App).switchToAllNamespaces},
+	{Name: "Refresh", Key: "r", Run: func(a *App) {
+		a.stopWatch()
+		a.safeGo("refresh-and-watch", func() {
+			a.refresh()
+			a.startWatch()
+		})
+	}},
+	{Name: "Filter", Key: "/", Run: (*App).startFilter},
+	{Name: "Command mode", Key: ":", Run: func(a *App) { a.SetFocus(a.cmdInput) }},
+	{Name: "Active port-forwards", Key: "f", Run: (*App).showPortForwards},
+	{Name: "Settings", Key: "Shift+O", Run: (*App).showSettings},
+	{Name: "Toggle AI panel", Key: "Ctrl+E", Run: (*App).toggleAIPanel},
+	{Name: "AI briefing", Key: "Ctrl+I", Run: (*App).aiBriefing},
+	{Name: "Refresh AI briefing", Key: "Ctrl+I twice", Run: (*App).refreshAIBriefing},
+	{Name: "Toggle briefing panel", Key: "Shift+B", Run: (*App).toggleBriefing},
+	{Name: "Help", Key: "?", Run: (*App).showHelp},
+	{Name: "About", Key: "Shift+I", Run: (*App).showAbout},
+	{Name: "Quit", Key: "q", Run: (*App).Stop},
+}
+
+// availablePaletteActions returns the actions valid for the given resource
+// and selection state.
+func availablePaletteActions(resource string, hasSelection bool) []paletteAction {
+	var out []paletteAction
+	for _, action := range paletteActions {
+		if action.NeedsSelection && !hasSelection {
+			continue
+		}
+		if len(action.Resources) > 0 && !containsResource(action.Resources, resource) {
+			continue
+		}
+		out = append(out, action)
+	}
+	return out
+}
+
+func containsResource(resources []string, resource string) bool {
+	for _, r := range resources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// filterPaletteActions fuzzy-matches query against action names, best match
+// first. An empty query keeps the original order.
+func filterPaletteActions(actions []paletteAction, query string) []paletteAction {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return actions
+	}
+
+	type scored struct {
+		action paletteAction
+		score  int
+	}
+	var matches []scored
+	for _, action := range actions {
+		if ok, score, _ := FuzzyMatch(query, action.Name); ok {
+			matches = append(matches, scored{action, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	out := make([]paletteAction, len(matches))
+	for i, m := range matches {
+		out[i] = m.action
+	}
+	return out
+}
+
+// showCommandPalette opens a fuzzy-searchable list of the actions available
+// for the current resource and selection (Ctrl+P).
+func (a *App) showCommandPalette() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	row, _ := a.table.GetSelection()
+	actions := availablePaletteActions(resource, row > 0)
+	actions = append(actions, a.pluginPaletteActions(resource, row > 0)...)
+
+	input := tview.NewInputField().SetLabel("> ").SetFieldWidth(0)
+	list := tview.NewList().ShowSecondaryText(false).SetHighlightFullLine(true)
+
+	var shown []paletteAction
+	render := func(query string) {
+		shown = filterPaletteActions(actions, query)
+		list.Clear()
+		for _, action := range shown {
+			pad := strings.Repeat(" ", max(1, 28-len(action.Name)))
+			list.AddItem(highlightFuzzyMatch(action.Name, query)+pad+"[gray]"+action.Key+"[-]", "", 0, nil)
+		}
+	}
+	render("")
+
+	closePalette := func() {
+		a.closeModal("command-palette")
+		a.SetFocus(a.table)
+	}
+	runSelected := func() {
+		idx := list.GetCurrentItem()
+		if idx < 0 || idx >= len(shown) {
+			return
+		}
+		action := shown[idx]
+		closePalette()
+		action.Run(a)
+	}
+
+	input.SetChangedFunc(render)
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closePalette()
+			return nil
+		case tcell.KeyEnter:
+			runSelected()
+			return nil
+		case tcell.KeyDown, tcell.KeyCtrlN, tcell.KeyTab:
+			if n := list.GetItemCount(); n > 0 {
+				list.SetCurrentItem((list.GetCurrentItem() + 1) % n)
+			}
+			return nil
+		case tcell.KeyUp, tcell.KeyCtrlP, tcell.KeyBacktab:
+			if n := list.GetItemCount(); n > 0 {
+				list.SetCurrentItem((list.GetCurrentItem() - 1 + n) % n)
+			}
+			return nil
+		}
+		return event
+	})
+
+	title := " Command Palette "
+	if resource != "" {
+		title = fmt.Sprintf(" Command Palette: %s (Enter to run, Esc to cancel) ", resource)
+	}
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	layout.SetBorder(true).SetTitle(title)
+
+	a.showModal("command-palette", centered(layout, 70, min(len(actions)+4, 22)), true)
+	a.SetFocus(input)
+}
+
+// pluginPaletteActions exposes user plugins scoped to the current resource.
+func (a *App) pluginPaletteActions(resource string, hasSelection bool) []paletteAction {
+	if a.plugins == nil || !hasSelection {
+		return nil
+	}
+
+	plugins := a.plugins.GetPluginsForScope(resource)
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]paletteAction, 0, len(names))
+	for _, name := range names {
+		plugin := plugins[name]
+		label := plugin.Description
+		if label == "" {
+			label = name
+		}
+		out = append(out, paletteAction{
+			Name: "Plugin: " + label,
+			Key:  plugin.ShortCut,
+			Run: func(a *App) {
+				a.safeGo("executePlugin-"+name, func() { a.executePlugin(name, plugin) })
+			},
+		})
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package ui
+
+import "testing"
+
+func paletteNames(actions []paletteAction) map[string]bool {
+	names := make(map[string]bool, len(actions))
+	for _, a := range actions {
+		names[a.Name] = true
+	}
+	return names
+}
+
+func TestAvailablePaletteActions(t *testing.T) {
+	tests := []struct {
+		name         string
+		resource     string
+		hasSelection bool
+		want         []string
+		wantAbsent   []string
+	}{
+		{
+			name:         "pods with selection",
+			resource:     "pods",
+			hasSelection: true,
+			want:         []string{"Describe", "Logs", "Shell", "Port-forward", "Switch context"},
+			wantAbsent:   []string{"Scale", "Restart", "Trigger CronJob", "Use namespace", "Logs (all pods)"},
+		},
+		{
+			name:         "deployments with selection",
+			resource:     "deployments",
+			hasSelection: true,
+			want:         []string{"Describe", "Scale", "Restart", "Show related resources", "Logs (all pods)"},
+			wantAbsent:   []string{"Logs", "Shell", "Port-forward"},
+		},
+		{
+			name:         "alias resource",
+			resource:     "sts",
+			hasSelection: true,
+			want:         []string{"Scale", "Restart"},
+		},
+		{
+			name:       "no selection hides row actions",
+			resource:   "pods",
+			want:       []string{"Switch context", "Refresh", "Settings", "Help"},
+			wantAbsent: []string{"Describe", "Logs", "Delete"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := paletteNames(availablePaletteActions(tt.resource, tt.hasSelection))
+			for _, name := range tt.want {
+				if !got[name] {
+					t.Errorf("missing action %q", name)
+				}
+			}
+			for _, name := range tt.wantAbsent {
+				if got[name] {
+					t.Errorf("unexpected action %q", name)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterPaletteActions(t *testing.T) {
+	actions := availablePaletteActions("deployments", true)
+
+	if got := filterPaletteActions(actions, ""); len(got) != len(actions) {
+		t.Errorf("empty query returned %d actions, want %d", len(got), len(actions))
+	}
+
+	got := filterPaletteActions(actions, "rst")
+	if len(got) == 0 || got[0].Name != "Restart" {
+		t.Errorf("filter(rst) first = %v, want Restart", got)
+	}
+
+	got = filterPaletteActions(actions, "xyz")
+	if len(got) != 0 {
+		t.Errorf("filter(xyz) = %v, want no matches", got)
+	}
+
+	got = filterPaletteActions(actions, "swc")
+	if len(got) == 0 || got[0].Name != "Switch context" {
+		t.Errorf("filter(swc) first = %v, want Switch context", got)
+	}
+}
+
+func TestPaletteActionsHaveHandlers(t *testing.T) {
+	for _, action := range paletteActions {
+		if action.Run == nil || action.Name == "" || action.Key == "" {
+			t.Errorf("incomplete palette action: %+v", action)
+		}
+	}
+}
@@ -86,6 +86,14 @@ func (a *App) refresh() {
 		return
 	}
 
+	a.mx.RLock()
+	allNamespaces := a.currentNamespace == ""
+	a.mx.RUnlock()
+	if allNamespaces {
+		rows = a.hideExcludedNamespaceRows(headers, rows)
+		rows = filterNamespaceRegexRows(a.currentNamespaceRegex(), headers, rows)
+	}
+
 	a.mx.Lock()
 	a.tableHeaders = headers
 	a.tableRows = rows
@@ -122,7 +130,7 @@ func (a *App) startFilter() {
 	a.mx.RUnlock()
 
 	a.cmdInput.SetLabel(" / ")
-	a.cmdHint.SetText("[gray]Filter: text | /regex/ | -f fuzzy | -l label=value | Esc to clear")
+	a.cmdHint.SetText("[gray]Filter: text | /regex/ | -f fuzzy | app=nginx or status.phase=Running (server-side) | Esc to clear")
 	a.cmdInput.SetText(currentFilter)
 	a.SetFocus(a.cmdInput)
 
@@ -144,6 +152,7 @@ func (a *App) startFilter() {
 		switch event.Key() {
 		case tcell.KeyEnter:
 			text := a.cmdInput.GetText()
+			before := a.listSelector()
 			a.mx.Lock()
 			if strings.HasPrefix(text, "/") && strings.HasSuffix(text, "/") && len(text) > 2 {
 				a.filterText = text[1 : len(text)-1]
@@ -157,8 +166,10 @@ func (a *App) startFilter() {
 			a.cmdHint.SetText("")
 			a.restoreAutocompleteHandler()
 			a.SetFocus(a.table)
+			a.reloadOnSelectorChange(before)
 			return nil
 		case tcell.KeyEsc:
+			before := a.listSelector()
 			a.mx.Lock()
 			a.filterText = ""
 			a.filterRegex = false
@@ -169,6 +180,7 @@ func (a *App) startFilter() {
 			a.applyFilterText("")
 			a.restoreAutocompleteHandler()
 			a.SetFocus(a.table)
+			a.reloadOnSelectorChange(before)
 			return nil
 		}
 		return event
@@ -224,6 +236,9 @@ func (a *App) applyFilterText(filter string) {
 		} else {
 			filteredRows = rows
 		}
+	case filterModeSelector:
+		// The API server already applied the selector when listing
+		filteredRows = rows
 	default:
 		filteredRows = nil
 	}
@@ -300,6 +315,8 @@ func (a *App) applyFilterText(filter string) {
 				filterInfo = fmt.Sprintf(" [fuzzy: %s]", pattern)
 			case filterModeLabel:
 				filterInfo = fmt.Sprintf(" [label: %s]", pattern)
+			case filterModeSelector:
+				filterInfo = fmt.Sprintf(" [selector: %s]", pattern)
 			default:
 				if isRegex {
 					filterInfo = fmt.Sprintf(" [regex: %s]", filterPattern)
@@ -313,6 +330,7 @@ func (a *App) applyFilterText(filter string) {
 			a.table.Select(dataRowOffset, 0)
 		}
 		a.refreshTableDecorations()
+		a.updateSummaryBar()
 		a.requestSync()
 	})
 	a.updateStatusBar()
@@ -369,13 +387,21 @@ func (a *App) startWatch() {
 	if parentCtx == nil {
 		parentCtx = context.Background()
 	}
-	ctx, cancel := context.WithCancel(parentCtx)
+	ctx, cancel := context.WithCancel(k8s.WithListSelector(parentCtx, a.listSelector()))
 	a.watchCancel = cancel
 	onChange := func() {
+		if a.deferRefreshForModal() {
+			return
+		}
 		a.safeGo("watch-refresh", func() { a.refresh() })
 	}
 	cfg := k8s.DefaultWatcherConfig()
 	a.watcher = k8s.NewResourceWatcher(a.k8s, resource, namespace, onChange, a.logger, cfg)
+	// Keep the Live/Poll indicator in step with reconnects. The header is
+	// redrawn on its own goroutine because it takes watchMu.
+	a.watcher.OnStateChange(func(k8s.WatchState) {
+		a.safeGo("watch-state", a.updateHeader)
+	})
 	a.watcher.Start(ctx)
 	a.logger.Info("Started watch", "resource", resource, "namespace", namespace)
 	a.watchMu.Unlock()
@@ -506,7 +532,7 @@ func (a *App) reorderNamespacesByRecent() []string {
 	recent := make([]string, len(a.recentNamespaces))
 	copy(recent, a.recentNamespaces)
 	a.mx.RUnlock()
-	return reorderNamespaceListByRecent(allNamespaces, recent)
+	return reorderNamespaceListByRecent(a.visibleNamespaces(allNamespaces), recent)
 }
 
 // addRecentNamespace adds a namespace to the recent list
@@ -562,6 +588,7 @@ const (
 	filterModeText filterMode = iota
 	filterModeFuzzy
 	filterModeLabel
+	filterModeSelector // label/field selector applied server-side
 )
 
 // detectFilterMode parses a filter string
@@ -569,12 +596,53 @@ func detectFilterMode(filter string) (filterMode, string) {
 	if strings.HasPrefix(filter, "-f ") {
 		return filterModeFuzzy, strings.TrimPrefix(filter, "-f ")
 	}
-	if strings.HasPrefix(filter, "-l ") {
-		return filterModeLabel, strings.TrimPrefix(filter, "-l ")
+	if expr, ok := strings.CutPrefix(filter, "-l "); ok {
+		if _, ok := k8s.ParseListSelector(expr, true); ok {
+			return filterModeSelector, expr
+		}
+		return filterModeLabel, expr
+	}
+	if _, ok := k8s.ParseListSelector(filter, false); ok {
+		return filterModeSelector, filter
 	}
 	return filterModeText, filter
 }
 
+// filterListSelector returns the label/field selector a committed filter
+// asks the API server to apply, or the zero selector for client-side filters.
+// "-l expr" is always a label selector; other text is a selector only when it
+// compares a value, such as "app=nginx" or "status.phase=Running".
+func filterListSelector(filter string, isRegex bool) k8s.ListSelector {
+	if isRegex {
+		return k8s.ListSelector{}
+	}
+	if expr, ok := strings.CutPrefix(filter, "-l "); ok {
+		sel, _ := k8s.ParseListSelector(expr, true)
+		return sel
+	}
+	sel, _ := k8s.ParseListSelector(filter, false)
+	return sel
+}
+
+// listSelector returns the selector of the current filter
+func (a *App) listSelector() k8s.ListSelector {
+	a.mx.RLock()
+	defer a.mx.RUnlock()
+	return filterListSelector(a.filterText, a.filterRegex)
+}
+
+// reloadOnSelectorChange re-lists and re-watches the current resource when
+// the committed filter changed the server-side selector from before.
+func (a *App) reloadOnSelectorChange(before k8s.ListSelector) {
+	if a.listSelector() == before {
+		return
+	}
+	a.safeGo("filter-selector", func() {
+		a.refresh()
+		a.startWatch()
+	})
+}
+
 // nameColumnIndex returns the index of the name column
 func nameColumnIndex(resource string) int {
 	switch resource {
@@ -1,3 +1,5 @@
+//go:build !noai
+
 package ui
 
 import (
@@ -60,7 +62,7 @@ func (a *App) showSettings() {
 		)
 	}
 
-	providers := []string{"openai", "ollama", "upstage", "gemini", "anthropic", "bedrock", "azopenai"}
+	providers := []string{"openai", "ollama", "upstage", "gemini", "vertexai", "anthropic", "bedrock", "azopenai"}
 	providerIndex := 0
 	for i, p := range providers {
 		if p == provider {
@@ -188,14 +190,16 @@ func (a *App) showSettings() {
 				a.config.LLM.APIKey = apiKey
 				hasAPIKey = true
 			}
-			a.config.Authorization.ToolApproval = config.ToolApprovalPolicy{
-				AutoApproveReadOnly:       autoApproveReadOnly,
-				RequireApprovalForWrite:   requireApprovalForWrite,
-				RequireApprovalForUnknown: requireApprovalForUnknown,
-				BlockDangerous:            blockDangerous,
-				BlockedPatterns:           append([]string(nil), a.config.Authorization.ToolApproval.BlockedPatterns...),
-				ApprovalTimeoutSeconds:    timeout,
-			}
+			// Start from the current policy so settings not shown here
+			// (blocked patterns, verb and resource restrictions) are kept
+			policy := a.config.Authorization.ToolApproval
+			policy.AutoApproveReadOnly = autoApproveReadOnly
+			policy.RequireApprovalForWrite = requireApprovalForWrite
+			policy.RequireApprovalForUnknown = requireApprovalForUnknown
+			policy.BlockDangerous = blockDangerous
+			policy.BlockedPatterns = append([]string(nil), policy.BlockedPatterns...)
+			policy.ApprovalTimeoutSeconds = timeout
+			a.config.Authorization.ToolApproval = policy
 			a.config.SyncActiveModelProfileFromLLM()
 
 			if err := a.config.Save(); err != nil {
@@ -205,16 +209,18 @@ func (a *App) showSettings() {
 				return
 			}
 
-			newClient, err := ai.NewClient(&a.config.LLM)
-			if err != nil {
-				a.QueueUpdateDraw(func() {
-					statusView.SetText(fmt.Sprintf("[yellow]●[white] Saved, but client init failed: %s", err))
-				})
-				return
+			if a.config.AIEnabled() {
+				newClient, err := ai.NewClient(&a.config.LLM)
+				if err != nil {
+					a.QueueUpdateDraw(func() {
+						statusView.SetText(fmt.Sprintf("[yellow]●[white] Saved, but client init failed: %s", err))
+					})
+					return
+				}
+				a.aiMx.Lock()
+				a.aiClient = newClient
+				a.aiMx.Unlock()
 			}
-			a.aiMx.Lock()
-			a.aiClient = newClient
-			a.aiMx.Unlock()
 
 			a.QueueUpdateDraw(func() {
 				statusView.SetText("[green]●[white] Configuration saved! Press 'Test Connection' to verify")
@@ -268,14 +274,14 @@ func (a *App) showSettings() {
 	})
 
 	flex := tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(infoView, 12, 0, false).
+		AddItem(infoView, 13, 0, false).
 		AddItem(statusView, 2, 0, false).
 		AddItem(form, 0, 1, true)
 
 	flex.SetBorder(true).SetTitle(" Settings (Esc to close) ")
 	flex.SetBackgroundColor(tcell.ColorDefault)
 
-	a.showModal("settings", centered(flex, 88, 38), true)
+	a.showModal("settings", centered(flex, 88, 39), true)
 	a.SetFocus(form)
 
 	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
@@ -184,6 +184,7 @@ func TestStatusColor(t *testing.T) {
 		{"Warning", yellowColor},
 		{"Updating", yellowColor},
 		{"Terminating", yellowColor},
+		{"Terminating (stuck 5m)", redColor},
 		{"Failed", redColor},
 		{"Error", redColor},
 		{"CrashLoopBackOff", redColor},
@@ -191,6 +192,8 @@ func TestStatusColor(t *testing.T) {
 		{"ImagePullBackOff", redColor},
 		{"ErrImagePull", redColor},
 		{"Evicted", redColor},
+		{"NearLimit", yellowColor},
+		{"Exceeded", redColor},
 		{"Unknown", secondaryColor},
 		{"SomeRandomStatus", primaryColor},
 	}
@@ -309,7 +312,7 @@ func TestGetCompletionsExtended(t *testing.T) {
 		{
 			name:     "multiple matches with d",
 			input:    "d",
-			expected: []string{"deployments", "daemonsets"},
+			expected: []string{"deployments", "daemonsets", "drift"},
 		},
 		{
 			name:     "events",
@@ -319,6 +322,11 @@ func TestGetCompletionsExtended(t *testing.T) {
 		{
 			name:     "nodes",
 			input:    "no",
+			expected: []string{"nodes", "node-capacity"},
+		},
+		{
+			name:     "nodes full name",
+			input:    "nodes",
 			expected: []string{"nodes"},
 		},
 	}
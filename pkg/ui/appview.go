@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	appsv1 "k8s.io/api/apps/v1"
@@ -33,8 +34,9 @@ type AppView struct {
 
 // NewAppView creates a new application-centric view
 func NewAppView(app *App, namespace string) *AppView {
+	p := app.palette()
 	root := tview.NewTreeNode("Applications").
-		SetColor(tcell.NewRGBColor(122, 162, 247)) // #7aa2f7 blue
+		SetColor(themeColor(p.Accent, config.DefaultPalette().Accent))
 
 	tree := tview.NewTreeView().
 		SetRoot(root).
@@ -72,7 +74,7 @@ func (v *AppView) Refresh(ctx context.Context, namespace string) {
 	ingresses, _ := k.ListIngresses(ctx, namespace)
 
 	groups := BuildAppGroups(pods, deployments, statefulSets, daemonSets, services, configMaps, secrets, ingresses)
-	addAppGroupsToTree(v.root, groups)
+	addAppGroupsToTree(v.root, groups, v.app.palette())
 
 	if len(v.root.GetChildren()) == 0 {
 		v.root.AddChild(tview.NewTreeNode("[gray]No resources found[white]"))
@@ -262,7 +264,8 @@ func calculateAppStatus(g *AppGroup) string {
 }
 
 // addAppGroupsToTree populates the tview tree with application groups
-func addAppGroupsToTree(parent *tview.TreeNode, groups []AppGroup) {
+func addAppGroupsToTree(parent *tview.TreeNode, groups []AppGroup, p config.PaletteStyle) {
+	def := config.DefaultPalette()
 	// Resource kinds in display order
 	kindOrder := []string{"Deployment", "StatefulSet", "DaemonSet", "Service", "ConfigMap", "Secret", "Ingress"}
 
@@ -293,13 +296,13 @@ func addAppGroupsToTree(parent *tview.TreeNode, groups []AppGroup) {
 		// Color based on status
 		switch g.Status {
 		case "healthy":
-			groupNode.SetColor(tcell.NewRGBColor(158, 206, 106)) // green
+			groupNode.SetColor(themeColor(p.Success, def.Success))
 		case "degraded":
-			groupNode.SetColor(tcell.NewRGBColor(224, 175, 104)) // yellow
+			groupNode.SetColor(themeColor(p.Warning, def.Warning))
 		case "failing":
-			groupNode.SetColor(tcell.NewRGBColor(247, 118, 142)) // red
+			groupNode.SetColor(themeColor(p.Error, def.Error))
 		default:
-			groupNode.SetColor(tcell.NewRGBColor(192, 202, 245)) // primary text
+			groupNode.SetColor(themeColor(p.Text, def.Text))
 		}
 
 		// Add resources as children in consistent order
@@ -313,7 +316,7 @@ func addAppGroupsToTree(parent *tview.TreeNode, groups []AppGroup) {
 				text := fmt.Sprintf("%s: %s", kind, name)
 				child := tview.NewTreeNode(text).
 					SetSelectable(true).
-					SetColor(tcell.NewRGBColor(192, 202, 245)) // primary text
+					SetColor(themeColor(p.Text, def.Text))
 				groupNode.AddChild(child)
 			}
 		}
@@ -340,7 +343,7 @@ func addAppGroupsToTree(parent *tview.TreeNode, groups []AppGroup) {
 				text := fmt.Sprintf("%s: %s", kind, name)
 				child := tview.NewTreeNode(text).
 					SetSelectable(true).
-					SetColor(tcell.NewRGBColor(192, 202, 245))
+					SetColor(themeColor(p.Text, def.Text))
 				groupNode.AddChild(child)
 			}
 		}
@@ -0,0 +1,354 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// auditViewLimit caps how many entries :audit loads
+const auditViewLimit = 500
+
+// defaultAuditSince is the :audit time window when --since is not given
+const defaultAuditSince = 24 * time.Hour
+
+var auditColumns = []string{"TIME", "USER", "SOURCE", "ACTION", "RESOURCE", "STATUS"}
+
+// auditSortKeys maps the Shift+letter sort keys of the :audit view to columns
+var auditSortKeys = map[rune]int{'T': 0, 'U': 1, 'O': 2, 'A': 3, 'R': 4, 'S': 5}
+
+// auditViewOptions are the :audit filters
+type auditViewOptions struct {
+	Since  time.Duration
+	User   string
+	Status string // "", "failed", or "success"
+}
+
+// parseAuditViewArgs parses the arguments of ":audit [--since 1h] [--user name]
+// [--failed|--success]". Durations also accept a "d" suffix for days.
+func parseAuditViewArgs(args []string) (auditViewOptions, error) {
+	opts := auditViewOptions{Since: defaultAuditSince}
+	for i := 0; i < len(args); i++ {
+		flag, value, hasValue := strings.Cut(args[i], "=")
+		needValue := func() (string, error) {
+			if hasValue {
+				return value, nil
+			}
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("%s needs a value", flag)
+			}
+			i++
+			return args[i], nil
+		}
+
+		switch flag {
+		case "--since", "-s":
+			v, err := needValue()
+			if err != nil {
+				return opts, err
+			}
+			d, err := parseAuditSince(v)
+			if err != nil {
+				return opts, err
+			}
+			opts.Since = d
+		case "--user", "-u":
+			v, err := needValue()
+			if err != nil {
+				return opts, err
+			}
+			opts.User = v
+		case "--failed":
+			opts.Status = "failed"
+		case "--success":
+			opts.Status = "success"
+		default:
+			return opts, fmt.Errorf("unknown audit option %q (use --since, --user, --failed, --success)", args[i])
+		}
+	}
+	return opts, nil
+}
+
+// parseAuditSince parses a positive duration such as "30m", "12h", or "7d"
+func parseAuditSince(s string) (time.Duration, error) {
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --since %q", s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --since %q", s)
+		}
+		d = parsed
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("--since must be positive, got %q", s)
+	}
+	return d, nil
+}
+
+// filter builds the database query for the options at time now
+func (o auditViewOptions) filter(now time.Time) db.AuditFilter {
+	return db.AuditFilter{
+		Limit:       auditViewLimit,
+		User:        o.User,
+		Since:       now.Add(-o.Since),
+		OnlyErrors:  o.Status == "failed",
+		OnlySuccess: o.Status == "success",
+	}
+}
+
+// describe summarizes the options for the view title
+func (o auditViewOptions) describe() string {
+	parts := []string{"last " + formatAuditSince(o.Since)}
+	if o.User != "" {
+		parts = append(parts, "user "+o.User)
+	}
+	if o.Status != "" {
+		parts = append(parts, o.Status)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatAuditSince(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// auditCellText returns the sortable plain text of one audit column
+func auditCellText(entry map[string]interface{}, col int) string {
+	switch col {
+	case 0:
+		if ts, ok := entry["timestamp"].(time.Time); ok {
+			return ts.Local().Format("2006-01-02 15:04:05")
+		}
+		return ""
+	case 1:
+		return auditString(entry, "user")
+	case 2:
+		return auditString(entry, "source")
+	case 3:
+		return auditString(entry, "action")
+	case 4:
+		return auditString(entry, "resource")
+	case 5:
+		switch {
+		case auditString(entry, "authz_decision") == "denied":
+			return "denied"
+		case entry["success"] == true:
+			return "ok"
+		default:
+			return "failed"
+		}
+	}
+	return ""
+}
+
+func auditString(entry map[string]interface{}, key string) string {
+	s, _ := entry[key].(string)
+	return s
+}
+
+// sortAuditEntries sorts entries by a column; time sorts chronologically
+func sortAuditEntries(entries []map[string]interface{}, col int, ascending bool) {
+	less := func(x, y map[string]interface{}) bool {
+		if col == 0 {
+			tx, _ := x["timestamp"].(time.Time)
+			ty, _ := y["timestamp"].(time.Time)
+			return tx.Before(ty)
+		}
+		return strings.ToLower(auditCellText(x, col)) < strings.ToLower(auditCellText(y, col))
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if ascending {
+			return less(entries[i], entries[j])
+		}
+		return less(entries[j], entries[i])
+	})
+}
+
+// formatAuditDetail renders every recorded field of an entry, one per line
+func formatAuditDetail(entry map[string]interface{}) string {
+	keys := make([]string, 0, len(entry))
+	for k := range entry {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		value := entry[k]
+		if ts, ok := value.(time.Time); ok {
+			value = ts.Local().Format(time.RFC3339)
+		}
+		fmt.Fprintf(&b, "[yellow]%s:[white] %s\n", k, tview.Escape(fmt.Sprint(value)))
+	}
+	return b.String()
+}
+
+// showAuditLog browses recent audit entries (:audit). Enter shows the full
+// entry, f cycles the status filter, and Shift+T/U/O/A/R/S sort by a column
+// (again to reverse).
+func (a *App) showAuditLog(opts auditViewOptions) {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	var (
+		entries   []map[string]interface{}
+		sortCol   = 0
+		ascending = false
+	)
+
+	render := func() {
+		table.Clear()
+		table.SetTitle(fmt.Sprintf(" Audit: %s (%d) [gray](Enter:details f:status Shift+T/U/O/A/R/S:sort r:refresh Esc:close)[white] ",
+			tview.Escape(opts.describe()), len(entries)))
+		for col, header := range auditColumns {
+			if col == sortCol {
+				header += map[bool]string{true: "↑", false: "↓"}[ascending]
+			}
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorYellow).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false).
+				SetExpansion(1))
+		}
+		if len(entries) == 0 {
+			table.SetCell(1, 0, tview.NewTableCell("[gray]No audit entries match[white]").SetSelectable(false))
+			return
+		}
+		for i, entry := range entries {
+			for col := range auditColumns {
+				text := tview.Escape(auditCellText(entry, col))
+				if col == 5 {
+					color := map[string]string{"ok": "[green]", "failed": "[red]", "denied": "[orange]"}[text]
+					text = color + text + "[white]"
+				}
+				table.SetCell(i+1, col, tview.NewTableCell(text).SetExpansion(1).SetMaxWidth(60))
+			}
+		}
+		table.Select(1, 0)
+	}
+
+	load := func(filter db.AuditFilter) {
+		result, err := db.GetAuditLogsFiltered(filter)
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				entries = nil
+				render()
+				table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("[red]Failed to query audit log: %v[white]", err)).SetSelectable(false))
+				return
+			}
+			if db.DB == nil {
+				entries = nil
+				render()
+				table.SetCell(1, 0, tview.NewTableCell("[gray]Audit database is not available (enable_audit)[white]").SetSelectable(false))
+				return
+			}
+			entries = result
+			sortAuditEntries(entries, sortCol, ascending)
+			render()
+		})
+	}
+
+	refresh := func() {
+		filter := opts.filter(time.Now())
+		a.safeGo("audit-refresh", func() { load(filter) })
+	}
+
+	closeView := func() {
+		a.closeModal("audit")
+		a.SetFocus(a.table)
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeView()
+			return nil
+		case tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			if row >= 1 && row <= len(entries) {
+				a.showAuditDetail(entries[row-1], table)
+			}
+			return nil
+		case tcell.KeyRune:
+			if col, ok := auditSortKeys[event.Rune()]; ok {
+				if col == sortCol {
+					ascending = !ascending
+				} else {
+					sortCol, ascending = col, col != 0
+				}
+				sortAuditEntries(entries, sortCol, ascending)
+				render()
+				return nil
+			}
+			switch event.Rune() {
+			case 'f':
+				opts.Status = map[string]string{"": "failed", "failed": "success", "success": ""}[opts.Status]
+				refresh()
+				return nil
+			case 'r':
+				refresh()
+				return nil
+			case 'q':
+				closeView()
+				return nil
+			}
+		}
+		return event
+	})
+
+	render()
+	table.SetCell(1, 0, tview.NewTableCell("[gray]Loading audit log...[white]").SetSelectable(false))
+	a.showModal("audit", centered(table, 140, 30), true)
+	a.SetFocus(table)
+
+	refresh()
+}
+
+// showAuditDetail shows every field of one audit entry above the audit table
+func (a *App) showAuditDetail(entry map[string]interface{}, back tview.Primitive) {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText(formatAuditDetail(entry))
+	view.SetBorder(true).
+		SetTitle(" Audit Entry [gray](Esc:back)[white] ").
+		SetTitleAlign(tview.AlignLeft)
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc || (event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+			a.closeModal("audit-detail")
+			a.SetFocus(back)
+			return nil
+		}
+		return event
+	})
+
+	a.showModal("audit-detail", centered(view, 100, 28), true)
+	a.SetFocus(view)
+}
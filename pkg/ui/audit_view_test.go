@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAuditViewArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    auditViewOptions
+		wantErr bool
+	}{
+		{name: "defaults", args: nil, want: auditViewOptions{Since: defaultAuditSince}},
+		{name: "since and user", args: []string{"--since", "1h", "--user", "alice"}, want: auditViewOptions{Since: time.Hour, User: "alice"}},
+		{name: "equals form and days", args: []string{"--since=7d", "--user=bob", "--failed"}, want: auditViewOptions{Since: 7 * 24 * time.Hour, User: "bob", Status: "failed"}},
+		{name: "success", args: []string{"--success"}, want: auditViewOptions{Since: defaultAuditSince, Status: "success"}},
+		{name: "missing value", args: []string{"--user"}, wantErr: true},
+		{name: "bad duration", args: []string{"--since", "soon"}, wantErr: true},
+		{name: "negative duration", args: []string{"--since", "-1h"}, wantErr: true},
+		{name: "unknown option", args: []string{"--verbose"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAuditViewArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAuditViewArgs(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseAuditViewArgs(%v) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditViewOptionsFilter(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	f := auditViewOptions{Since: time.Hour, User: "alice", Status: "failed"}.filter(now)
+	if !f.Since.Equal(now.Add(-time.Hour)) || f.User != "alice" || !f.OnlyErrors || f.OnlySuccess || f.Limit != auditViewLimit {
+		t.Errorf("filter() = %+v", f)
+	}
+
+	for d, want := range map[time.Duration]string{
+		30 * time.Minute: "30m",
+		2 * time.Hour:    "2h",
+		90 * time.Minute: "1h30m",
+		48 * time.Hour:   "2d",
+		45 * time.Second: "45s",
+	} {
+		if got := formatAuditSince(d); got != want {
+			t.Errorf("formatAuditSince(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestSortAuditEntries(t *testing.T) {
+	t0 := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	entries := []map[string]interface{}{
+		{"timestamp": t0.Add(time.Minute), "user": "bob", "success": true},
+		{"timestamp": t0, "user": "Alice", "success": false},
+		{"timestamp": t0.Add(2 * time.Minute), "user": "carol", "success": false, "authz_decision": "denied"},
+	}
+
+	sortAuditEntries(entries, 0, false)
+	if entries[0]["user"] != "carol" || entries[2]["user"] != "Alice" {
+		t.Errorf("newest first order = %v, %v, %v", entries[0]["user"], entries[1]["user"], entries[2]["user"])
+	}
+
+	sortAuditEntries(entries, 1, true)
+	if entries[0]["user"] != "Alice" || entries[1]["user"] != "bob" {
+		t.Errorf("user order = %v, %v, %v", entries[0]["user"], entries[1]["user"], entries[2]["user"])
+	}
+
+	wantStatus := map[string]string{"Alice": "failed", "bob": "ok", "carol": "denied"}
+	for _, e := range entries {
+		if got := auditCellText(e, 5); got != wantStatus[e["user"].(string)] {
+			t.Errorf("status of %v = %q, want %q", e["user"], got, wantStatus[e["user"].(string)])
+		}
+	}
+}
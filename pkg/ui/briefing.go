@@ -1,12 +1,17 @@
 package ui
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+	"github.com/cloudbro-kube-ai/k13d/pkg/metrics"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	corev1 "k8s.io/api/core/v1"
@@ -32,6 +37,17 @@ type BriefingData struct {
 	ClusterName      string
 }
 
+const (
+	// briefingMaxAlerts is how many alerts the briefing shows
+	briefingMaxAlerts = 3
+	// briefingDataTTL is how long fetched cluster data is reused when the
+	// panel is shown again
+	briefingDataTTL = 30 * time.Second
+	// briefingAITTL is how long an AI briefing is reused before Ctrl+I asks
+	// the LLM again
+	briefingAITTL = 5 * time.Minute
+)
+
 // BriefingPanel displays a natural language cluster health summary
 type BriefingPanel struct {
 	*tview.TextView
@@ -43,6 +59,22 @@ type BriefingPanel struct {
 	data        *BriefingData
 	mu          sync.RWMutex
 	stopPulse   chan struct{}
+
+	// Cache keyed by context/namespace so toggling the panel and repeating
+	// Ctrl+I neither re-query the cluster nor re-bill the LLM.
+	dataKey   string
+	dataAt    time.Time
+	aiText    string
+	aiKey     string
+	aiAt      time.Time
+	aiShowing bool // AI text is on screen; suppresses the data view redraw
+
+	// Trend lines: samples taken on each update for samplesKey, merged with
+	// the metrics store history into trend
+	samples      briefingTrend
+	samplesKey   string
+	trend        briefingTrend
+	metricsStore *db.MetricsStore
 }
 
 // NewBriefingPanel creates a new briefing panel
@@ -79,6 +111,10 @@ func (b *BriefingPanel) Toggle() {
 
 	if visible {
 		b.startPulse()
+		key := b.cacheKey()
+		if b.showCached(key, time.Now()) {
+			return
+		}
 		go func() {
 			defer func() {
 				if r := recover(); r != nil {
@@ -101,8 +137,80 @@ func (b *BriefingPanel) IsVisible() bool {
 	return b.visible
 }
 
+// IsShowingAI reports whether the panel currently shows an AI briefing
+func (b *BriefingPanel) IsShowingAI() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.aiShowing
+}
+
+// Invalidate drops the cached data and AI briefing, e.g. after a context switch
+func (b *BriefingPanel) Invalidate() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.dataKey, b.dataAt = "", time.Time{}
+	b.aiText, b.aiKey, b.aiAt = "", "", time.Time{}
+	b.aiShowing = false
+	b.samples, b.samplesKey, b.trend = nil, "", nil
+}
+
+// cacheKey identifies the scope of the cached briefing: context and namespace
+func (b *BriefingPanel) cacheKey() string {
+	b.app.mx.RLock()
+	ns := b.app.currentNamespace
+	b.app.mx.RUnlock()
+
+	ctxName := ""
+	if b.app.k8s != nil {
+		ctxName, _, _, _ = b.app.k8s.GetContextInfo()
+	}
+	return ctxName + "/" + ns
+}
+
+// cachedData returns the cached briefing data for key if it is still fresh
+func (b *BriefingPanel) cachedData(key string, now time.Time) (*BriefingData, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.data == nil || b.dataKey != key || now.Sub(b.dataAt) > briefingDataTTL {
+		return nil, false
+	}
+	return b.data, true
+}
+
+// cachedAI returns the cached AI briefing for key if it is still fresh
+func (b *BriefingPanel) cachedAI(key string, now time.Time) (string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.aiText == "" || b.aiKey != key || now.Sub(b.aiAt) > briefingAITTL {
+		return "", false
+	}
+	return b.aiText, true
+}
+
+// showCached redraws the panel from the cache without any API or LLM call.
+// It returns false when the cached data is missing or stale.
+func (b *BriefingPanel) showCached(key string, now time.Time) bool {
+	if _, ok := b.cachedData(key, now); !ok {
+		return false
+	}
+
+	if b.IsShowingAI() {
+		if text, ok := b.cachedAI(key, now); ok {
+			b.app.QueueUpdateDraw(func() { b.SetText(" " + text) })
+			return true
+		}
+		b.mu.Lock()
+		b.aiShowing = false
+		b.mu.Unlock()
+	}
+
+	b.updateDisplay()
+	return true
+}
+
 // Update fetches cluster data and updates the briefing text
 func (b *BriefingPanel) Update(ctx context.Context) error {
+	key := b.cacheKey()
 	data, err := b.fetchData(ctx)
 	if err != nil {
 		b.app.QueueUpdateDraw(func() {
@@ -111,8 +219,34 @@ func (b *BriefingPanel) Update(ctx context.Context) error {
 		return err
 	}
 
+	now := time.Now()
+	stored := b.storedMetrics(ctx, data.ContextName, data.Namespace, now)
+	anomalyCfg := config.AnomalyConfig{Briefing: true}
+	if b.app.config != nil {
+		anomalyCfg = b.app.config.Reports.Anomalies
+	}
+	if anomalyCfg.Briefing {
+		data.Alerts = withAnomalyAlerts(data.Alerts, metrics.DetectAnomalies(stored, anomalyCfg))
+	}
+
 	b.mu.Lock()
 	b.data = data
+	b.dataKey, b.dataAt = key, now
+	if b.samplesKey != key {
+		b.samples, b.samplesKey = nil, key
+	}
+	b.samples = addTrendSample(b.samples, trendSample{
+		At:            now,
+		CPUPercent:    data.CPUPercent,
+		MemoryPercent: data.MemoryPercent,
+		Pods:          data.TotalPods,
+	})
+	b.trend = mergeTrend(clusterMetricsTrend(stored), b.samples)
+	// Fall back to the data view once the AI briefing is stale or was
+	// generated for another context or namespace
+	if b.aiShowing && (b.aiKey != key || now.Sub(b.aiAt) > briefingAITTL) {
+		b.aiShowing = false
+	}
 	b.mu.Unlock()
 
 	b.updateDisplay()
@@ -316,14 +450,45 @@ func (b *BriefingPanel) fetchData(ctx context.Context) (*BriefingData, error) {
 	data.HealthScore = calculateHealthScore(data)
 	data.HealthStatus = healthStatusFromScore(data.HealthScore)
 
-	// Limit alerts to top 3
-	if len(data.Alerts) > 3 {
-		data.Alerts = data.Alerts[:3]
+	// Limit alerts to top briefingMaxAlerts
+	if len(data.Alerts) > briefingMaxAlerts {
+		data.Alerts = data.Alerts[:briefingMaxAlerts]
 	}
 
 	return data, nil
 }
 
+// withAnomalyAlerts adds the metrics history anomalies to the briefing
+// alerts, critical ones first. Alerts stay capped at briefingMaxAlerts, but
+// the first anomaly always gets a slot so a slow memory leak is not crowded
+// out by failing pods.
+func withAnomalyAlerts(alerts []string, anomalies []metrics.Anomaly) []string {
+	if len(anomalies) == 0 {
+		return alerts
+	}
+	sorted := slices.Clone(anomalies)
+	slices.SortStableFunc(sorted, func(a, b metrics.Anomaly) int {
+		return cmp.Compare(anomalyRank(a.Severity), anomalyRank(b.Severity))
+	})
+	keep := min(len(alerts), briefingMaxAlerts-1)
+	merged := slices.Clone(alerts[:keep])
+	for _, a := range sorted {
+		merged = append(merged, a.Message)
+	}
+	merged = append(merged, alerts[keep:]...)
+	if len(merged) > briefingMaxAlerts {
+		merged = merged[:briefingMaxAlerts]
+	}
+	return merged
+}
+
+func anomalyRank(severity string) int {
+	if severity == metrics.AnomalyCritical {
+		return 0
+	}
+	return 1
+}
+
 // calculateHealthScore computes a health score from 0-100
 func calculateHealthScore(data *BriefingData) int {
 	score := 100.0
@@ -387,9 +552,11 @@ func (b *BriefingPanel) updateDisplay() {
 	data := b.data
 	pulseIdx := b.pulseIdx
 	pulseChars := b.pulseChars
+	showingAI := b.aiShowing
+	trend := b.trend
 	b.mu.RUnlock()
 
-	if data == nil {
+	if data == nil || showingAI {
 		return
 	}
 
@@ -402,6 +569,11 @@ func (b *BriefingPanel) updateDisplay() {
 
 	// Pod summary
 	sb.WriteString(fmt.Sprintf(" • %d pods", data.TotalPods))
+	if len(trend) > 1 {
+		pods := trend.pods()
+		lo, hi := valueRange(pods)
+		sb.WriteString(trendSpark(pods, lo, hi))
+	}
 	if data.RunningPods < data.TotalPods {
 		sb.WriteString(fmt.Sprintf(" (%d running)", data.RunningPods))
 	}
@@ -430,7 +602,12 @@ func (b *BriefingPanel) updateDisplay() {
 		} else if data.MemoryPercent > 80 {
 			memColor = "[yellow]"
 		}
-		sb.WriteString(fmt.Sprintf("Resources: %s%.0f%% CPU[white], %s%.0f%% memory[white]", cpuColor, data.CPUPercent, memColor, data.MemoryPercent))
+		cpuTrend, memTrend := "", ""
+		if len(trend) > 1 {
+			cpuTrend = trendSpark(trend.cpu(), 0, 100)
+			memTrend = trendSpark(trend.memory(), 0, 100)
+		}
+		sb.WriteString(fmt.Sprintf("Resources: %s%.0f%% CPU[white]%s, %s%.0f%% memory[white]%s", cpuColor, data.CPUPercent, cpuTrend, memColor, data.MemoryPercent, memTrend))
 	} else {
 		sb.WriteString("[gray]Resources: metrics unavailable[white]")
 	}
@@ -468,6 +645,11 @@ func (b *BriefingPanel) updateDisplay() {
 	})
 }
 
+// trendSpark renders a briefing trend line, with a leading space
+func trendSpark(values []float64, lo, hi float64) string {
+	return " [darkcyan]" + sparkline(values, lo, hi, briefingTrendPoints) + "[white]"
+}
+
 // getHealthColor returns the color tag for a health status
 func getHealthColor(status string) string {
 	switch status {
@@ -543,24 +725,43 @@ func capitalizeFirst(s string) string {
 	return strings.ToUpper(s[:1]) + s[1:]
 }
 
-// UpdateWithAI requests an AI-generated briefing (Shift+B)
-func (b *BriefingPanel) UpdateWithAI() {
+// UpdateWithAI shows an AI-generated briefing (Ctrl+I). A cached briefing
+// for the same context and namespace is reused unless force is set.
+func (b *BriefingPanel) UpdateWithAI(force bool) {
+	key := b.cacheKey()
+	if !force {
+		if text, ok := b.cachedAI(key, time.Now()); ok {
+			b.mu.Lock()
+			b.aiShowing = true
+			b.mu.Unlock()
+			b.app.QueueUpdateDraw(func() { b.SetText(" " + text) })
+			return
+		}
+	}
+
 	if b.app.aiClient == nil || !b.app.aiClient.IsReady() {
 		b.app.flashMsg("AI not available for briefing", true)
 		return
 	}
 
+	b.mu.Lock()
+	b.aiShowing = true
+	b.mu.Unlock()
 	b.app.QueueUpdateDraw(func() {
 		b.SetText(" [cyan]Generating AI briefing...[white]")
 	})
 
 	ctx := b.app.getAppContext()
-	data, err := b.fetchData(ctx)
-	if err != nil {
-		b.app.QueueUpdateDraw(func() {
-			b.SetText(fmt.Sprintf(" [red]Error: %v[white]", err))
-		})
-		return
+	data, ok := b.cachedData(key, time.Now())
+	if !ok {
+		var err error
+		data, err = b.fetchData(ctx)
+		if err != nil {
+			b.app.QueueUpdateDraw(func() {
+				b.SetText(fmt.Sprintf(" [red]Error: %v[white]", err))
+			})
+			return
+		}
 	}
 
 	prompt := fmt.Sprintf(`You are a Kubernetes cluster health assistant. Generate a 3-line briefing based on this data:
@@ -587,10 +788,16 @@ Example format:
 	)
 
 	var response strings.Builder
-	err = b.app.aiClient.Ask(ctx, prompt, func(chunk string) {
+	ctx = withAIQueueNotice(ctx, func() {
+		b.app.QueueUpdateDraw(func() {
+			b.SetText(" [gray]AI briefing queued behind other AI requests...[white]")
+		})
+	})
+	err := b.app.aiClient.Ask(ctx, prompt, func(chunk string) {
 		response.WriteString(chunk)
+		text := response.String()
 		b.app.QueueUpdateDraw(func() {
-			b.SetText(" " + response.String())
+			b.SetText(" " + text)
 		})
 	})
 
@@ -598,5 +805,10 @@ Example format:
 		b.app.QueueUpdateDraw(func() {
 			b.SetText(fmt.Sprintf(" [red]AI Error: %v[white]", err))
 		})
+		return
 	}
+
+	b.mu.Lock()
+	b.aiText, b.aiKey, b.aiAt = response.String(), key, time.Now()
+	b.mu.Unlock()
 }
@@ -1,7 +1,11 @@
 package ui
 
 import (
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/metrics"
 )
 
 func TestCalculateHealthScore(t *testing.T) {
@@ -188,3 +192,84 @@ func TestBriefingData(t *testing.T) {
 		t.Errorf("Alerts length = %d, want 2", len(data.Alerts))
 	}
 }
+
+func TestBriefingCacheFreshness(t *testing.T) {
+	now := time.Now()
+	b := &BriefingPanel{
+		data:    &BriefingData{TotalPods: 3},
+		dataKey: "prod/default",
+		dataAt:  now,
+		aiText:  "All good",
+		aiKey:   "prod/default",
+		aiAt:    now,
+	}
+
+	if _, ok := b.cachedData("prod/default", now.Add(10*time.Second)); !ok {
+		t.Error("data fetched 10s ago should be reused")
+	}
+	if _, ok := b.cachedData("prod/default", now.Add(briefingDataTTL+time.Second)); ok {
+		t.Error("data older than the TTL should be refetched")
+	}
+	if _, ok := b.cachedData("prod/kube-system", now); ok {
+		t.Error("data for another namespace should not be reused")
+	}
+
+	if text, ok := b.cachedAI("prod/default", now.Add(time.Minute)); !ok || text != "All good" {
+		t.Errorf("cachedAI() = %q, %v; want the cached briefing", text, ok)
+	}
+	if _, ok := b.cachedAI("prod/default", now.Add(briefingAITTL+time.Second)); ok {
+		t.Error("AI briefing older than the TTL should be regenerated")
+	}
+	if _, ok := b.cachedAI("staging/default", now); ok {
+		t.Error("AI briefing for another context should not be reused")
+	}
+}
+
+func TestBriefingInvalidate(t *testing.T) {
+	now := time.Now()
+	b := &BriefingPanel{
+		data:      &BriefingData{},
+		dataKey:   "prod/default",
+		dataAt:    now,
+		aiText:    "All good",
+		aiKey:     "prod/default",
+		aiAt:      now,
+		aiShowing: true,
+	}
+
+	b.Invalidate()
+
+	if _, ok := b.cachedData("prod/default", now); ok {
+		t.Error("Invalidate() should drop cached data")
+	}
+	if _, ok := b.cachedAI("prod/default", now); ok {
+		t.Error("Invalidate() should drop the cached AI briefing")
+	}
+	if b.IsShowingAI() {
+		t.Error("Invalidate() should return the panel to the data view")
+	}
+}
+
+func TestWithAnomalyAlerts(t *testing.T) {
+	leak := metrics.Anomaly{Severity: metrics.AnomalyWarning, Message: "Memory usage rose steadily"}
+	cpu := metrics.Anomaly{Severity: metrics.AnomalyCritical, Message: "CPU usage at or above 90%"}
+
+	tests := []struct {
+		name      string
+		alerts    []string
+		anomalies []metrics.Anomaly
+		want      []string
+	}{
+		{"no anomalies", []string{"a"}, nil, []string{"a"}},
+		{"critical first", nil, []metrics.Anomaly{leak, cpu}, []string{cpu.Message, leak.Message}},
+		{"after alerts", []string{"a"}, []metrics.Anomaly{leak}, []string{"a", leak.Message}},
+		{"keeps a slot when full", []string{"a", "b", "c"}, []metrics.Anomaly{leak, cpu}, []string{"a", "b", cpu.Message}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withAnomalyAlerts(tt.alerts, tt.anomalies); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withAnomalyAlerts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// changelogLimit caps how many mutations :changelog summarizes
+const changelogLimit = 200
+
+// changelogOptions are the :changelog arguments
+type changelogOptions struct {
+	Since  time.Duration // 0 means since the TUI session started
+	Output string        // markdown file written by s; empty picks a timestamped name
+}
+
+// parseChangelogArgs parses ":changelog [--since 2h] [--out file.md]"
+func parseChangelogArgs(args []string) (changelogOptions, error) {
+	var opts changelogOptions
+	for i := 0; i < len(args); i++ {
+		flag, value, hasValue := strings.Cut(args[i], "=")
+		if !hasValue {
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("%s needs a value", flag)
+			}
+			i++
+			value = args[i]
+		}
+
+		switch flag {
+		case "--since", "-s":
+			d, err := parseAuditSince(value)
+			if err != nil {
+				return opts, err
+			}
+			opts.Since = d
+		case "--out", "-o":
+			opts.Output = value
+		default:
+			return opts, fmt.Errorf("unknown changelog option %q (use --since, --out)", flag)
+		}
+	}
+	return opts, nil
+}
+
+// changelogFilter selects this TUI user's mutations since from
+func (a *App) changelogFilter(from time.Time) db.AuditFilter {
+	return db.AuditFilter{
+		Limit:      changelogLimit,
+		User:       a.getTUIUser(),
+		ActionType: db.ActionTypeMutation,
+		Source:     "tui",
+		Since:      from,
+	}
+}
+
+// changelogLine renders one mutation as a plain fact for the AI prompt
+func changelogLine(entry map[string]interface{}) string {
+	var b strings.Builder
+	b.WriteString(auditCellText(entry, 0))
+	b.WriteString(" " + auditString(entry, "action"))
+	if res := auditString(entry, "resource"); res != "" {
+		b.WriteString(" " + res)
+	}
+	if ns := auditString(entry, "namespace"); ns != "" {
+		b.WriteString(" in namespace " + ns)
+	}
+	if details := auditString(entry, "details"); details != "" {
+		b.WriteString(": " + details)
+	}
+	if status := auditCellText(entry, 5); status != "ok" {
+		b.WriteString(" [" + status)
+		if msg := auditString(entry, "error_msg"); msg != "" {
+			b.WriteString(": " + msg)
+		}
+		b.WriteString("]")
+	}
+	return b.String()
+}
+
+// buildChangelogPrompt asks the AI to turn mutations, oldest first, into a
+// post-mortem change list
+func buildChangelogPrompt(entries []map[string]interface{}) string {
+	var b strings.Builder
+	b.WriteString(`You are writing the change log of a Kubernetes incident response session for a post-mortem.
+Summarize the cluster mutations below as a concise markdown bullet list in chronological order.
+- One bullet per logical change, in past tense (e.g. "Scaled deployment/web in prod to 5 replicas")
+- Merge repeated actions on the same object into one bullet
+- Call out failed or denied actions explicitly
+- Give a reason only when the recorded details state one; never invent reasons
+Output only the bullet list.
+
+Mutations:
+`)
+	for _, entry := range entries {
+		b.WriteString("- " + changelogLine(entry) + "\n")
+	}
+	return b.String()
+}
+
+// formatChangelogMarkdown renders the exportable change log: the AI summary
+// followed by the raw audit trail it was written from
+func formatChangelogMarkdown(summary string, entries []map[string]interface{}, from, to time.Time, kubeContext string) string {
+	failed := 0
+	for _, entry := range entries {
+		if auditCellText(entry, 5) != "ok" {
+			failed++
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("# Cluster Change Log\n\n")
+	fmt.Fprintf(&b, "- **Period:** %s – %s\n", from.Local().Format("2006-01-02 15:04"), to.Local().Format("2006-01-02 15:04"))
+	if kubeContext != "" {
+		fmt.Fprintf(&b, "- **Context:** %s\n", kubeContext)
+	}
+	fmt.Fprintf(&b, "- **Changes:** %d", len(entries))
+	if failed > 0 {
+		fmt.Fprintf(&b, " (%d failed)", failed)
+	}
+	b.WriteString("\n\n## Summary\n\n")
+	if summary = strings.TrimSpace(summary); summary == "" {
+		summary = "_AI summary unavailable._"
+	}
+	b.WriteString(summary + "\n\n## Audit Trail\n\n")
+	if len(entries) == 0 {
+		b.WriteString("_No cluster changes were recorded._\n")
+		return b.String()
+	}
+
+	cell := func(s string) string {
+		return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+	}
+	b.WriteString("| Time | Action | Resource | Namespace | Result |\n|---|---|---|---|---|\n")
+	for _, entry := range entries {
+		result := auditCellText(entry, 5)
+		if msg := auditString(entry, "error_msg"); msg != "" {
+			result += ": " + msg
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+			auditCellText(entry, 0),
+			cell(auditString(entry, "action")),
+			cell(auditString(entry, "resource")),
+			cell(auditString(entry, "namespace")),
+			cell(result))
+	}
+	return b.String()
+}
+
+// showChangelog summarizes the session's cluster mutations with the AI
+// (:changelog). s saves the markdown, r regenerates the summary.
+func (a *App) showChangelog(opts changelogOptions) {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true)
+	view.SetBorder(true).
+		SetTitle(" Change Log [gray](s:save r:regenerate Esc:close)[white] ").
+		SetTitleAlign(tview.AlignLeft)
+
+	from := a.startedAt
+	if opts.Since > 0 {
+		from = time.Now().Add(-opts.Since)
+	}
+
+	var (
+		markdown string
+		entries  []map[string]interface{}
+		gen      int // bumped by r so a superseded summary stops drawing
+	)
+	kubeContext := ""
+	if a.k8s != nil {
+		kubeContext, _ = a.k8s.GetCurrentContext()
+	}
+
+	generate := func() {
+		gen++
+		id := gen
+		a.safeGo("changelog", func() {
+			result, err := db.GetAuditLogsFiltered(a.changelogFilter(from))
+			if err != nil || db.DB == nil {
+				msg := "Audit database is not available (enable_audit)"
+				if err != nil {
+					msg = fmt.Sprintf("Failed to query audit log: %v", err)
+				}
+				a.QueueUpdateDraw(func() { view.SetText("[red]" + tview.Escape(msg) + "[white]") })
+				return
+			}
+			// The audit query returns newest first
+			sortAuditEntries(result, 0, true)
+			now := time.Now()
+
+			publish := func(summary string) {
+				md := formatChangelogMarkdown(summary, result, from, now, kubeContext)
+				a.QueueUpdateDraw(func() {
+					if id != gen {
+						return
+					}
+					entries, markdown = result, md
+					view.SetText(tview.Escape(md))
+				})
+			}
+
+			a.aiMx.RLock()
+			client := a.aiClient
+			a.aiMx.RUnlock()
+			if len(result) == 0 || client == nil || !client.IsReady() {
+				publish("")
+				return
+			}
+
+			publish("_Generating summary..._")
+			ctx := withAIQueueNotice(a.appCtx, func() {
+				publish("_Summary queued behind other AI requests..._")
+			})
+			var summary strings.Builder
+			err = client.Ask(ctx, buildChangelogPrompt(result), func(chunk string) {
+				summary.WriteString(chunk)
+				publish(summary.String())
+			})
+			if err != nil {
+				publish(fmt.Sprintf("_AI summary failed: %v_", err))
+			}
+		})
+	}
+
+	save := func() {
+		if markdown == "" {
+			a.flashMsg("Change log is still loading", true)
+			return
+		}
+		path := opts.Output
+		if path == "" {
+			path = fmt.Sprintf("k13d-changelog-%s.md", time.Now().Format("20060102-150405"))
+		}
+		if err := os.WriteFile(path, []byte(markdown), 0o600); err != nil {
+			a.flashMsg(fmt.Sprintf("Failed to save change log: %v", err), true)
+			return
+		}
+		a.flashMsg(fmt.Sprintf("Saved change log (%d changes) to %s", len(entries), path), false)
+	}
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.closeModal("changelog")
+			a.SetFocus(a.table)
+			return nil
+		}
+		if event.Key() == tcell.KeyRune {
+			switch event.Rune() {
+			case 's':
+				save()
+				return nil
+			case 'r':
+				markdown = ""
+				view.SetText("[gray]Loading cluster changes...[white]")
+				generate()
+				return nil
+			case 'q':
+				a.closeModal("changelog")
+				a.SetFocus(a.table)
+				return nil
+			}
+		}
+		return event
+	})
+
+	view.SetText("[gray]Loading cluster changes...[white]")
+	a.showModal("changelog", centered(view, 110, 32), true)
+	a.SetFocus(view)
+	generate()
+}
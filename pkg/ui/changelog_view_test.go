@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+)
+
+func TestParseChangelogArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    changelogOptions
+		wantErr bool
+	}{
+		{name: "session default", args: nil, want: changelogOptions{}},
+		{name: "since and out", args: []string{"--since", "2h", "--out", "incident.md"}, want: changelogOptions{Since: 2 * time.Hour, Output: "incident.md"}},
+		{name: "equals form", args: []string{"--since=1d", "-o=pm.md"}, want: changelogOptions{Since: 24 * time.Hour, Output: "pm.md"}},
+		{name: "missing value", args: []string{"--out"}, wantErr: true},
+		{name: "bad duration", args: []string{"--since", "soon"}, wantErr: true},
+		{name: "unknown option", args: []string{"--user", "bob"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseChangelogArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseChangelogArgs(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseChangelogArgs(%v) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangelogCollectsSessionMutations(t *testing.T) {
+	if err := db.Init(filepath.Join(t.TempDir(), "audit.db")); err != nil {
+		t.Fatalf("Failed to init test DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	app := NewTestApp(TestAppConfig{
+		SkipBackgroundLoading: true,
+		SkipBriefing:          true,
+		InitialNamespace:      "prod",
+	})
+	app.recordTUIAudit("scale", "deployment/web", "Scaled to 5 replicas", true, "")
+	app.recordTUIAudit("delete", "pod/web-0", "Deleted stuck pod", false, "forbidden")
+	// Another user's change and a web change stay out of this session's log
+	_ = db.RecordAudit(db.AuditEntry{User: "bob", Action: "restart", Resource: "deployments/prod/api", ActionType: db.ActionTypeMutation, Source: "tui"})
+	_ = db.RecordAudit(db.AuditEntry{User: app.getTUIUser(), Action: "restart", Resource: "deployments/prod/api", ActionType: db.ActionTypeMutation, Source: "web"})
+
+	entries, err := db.GetAuditLogsFiltered(app.changelogFilter(app.startedAt.Add(-time.Second)))
+	if err != nil {
+		t.Fatalf("GetAuditLogsFiltered() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("changelog entries = %d, want 2: %v", len(entries), entries)
+	}
+	sortAuditEntries(entries, 0, true)
+
+	prompt := buildChangelogPrompt(entries)
+	for _, want := range []string{"scale deployment/web in namespace prod: Scaled to 5 replicas", "delete pod/web-0 in namespace prod: Deleted stuck pod [failed: forbidden]"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(prompt, "deployments/prod/api") {
+		t.Errorf("prompt includes changes outside the session:\n%s", prompt)
+	}
+
+	md := formatChangelogMarkdown("- Scaled web to 5", entries, app.startedAt, time.Now(), "prod-cluster")
+	for _, want := range []string{
+		"# Cluster Change Log",
+		"- **Context:** prod-cluster",
+		"- **Changes:** 2 (1 failed)",
+		"## Summary\n\n- Scaled web to 5",
+		"| scale | deployment/web | prod | ok |",
+		"| delete | pod/web-0 | prod | failed: forbidden |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	if md := formatChangelogMarkdown("", nil, app.startedAt, time.Now(), ""); !strings.Contains(md, "_AI summary unavailable._") || !strings.Contains(md, "_No cluster changes were recorded._") {
+		t.Errorf("empty change log = %q", md)
+	}
+}
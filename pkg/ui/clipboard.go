@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// clipboardLookPathFunc finds native clipboard tools; tests replace it
+var clipboardLookPathFunc = exec.LookPath
+
+// nativeClipboardCommand returns the local clipboard tool to run alongside
+// OSC 52, or nil over SSH, where a tool would fill the remote machine's
+// clipboard rather than the user's.
+func nativeClipboardCommand(goos string, getenv func(string) string, lookPath func(string) (string, error)) []string {
+	if getenv("SSH_CONNECTION") != "" || getenv("SSH_TTY") != "" {
+		return nil
+	}
+	var candidates [][]string
+	switch goos {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip.exe"}}
+	default:
+		if getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		if getenv("DISPLAY") != "" {
+			candidates = append(candidates,
+				[]string{"xclip", "-selection", "clipboard"},
+				[]string{"xsel", "--clipboard", "--input"})
+		}
+		// WSL shares the Windows clipboard
+		candidates = append(candidates, []string{"clip.exe"})
+	}
+	for _, cmd := range candidates {
+		if _, err := lookPath(cmd[0]); err == nil {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// plainText strips the tview color and style tags a table cell or viewer
+// renders with, leaving the text as shown on screen
+func plainText(s string) string {
+	return tview.NewTextView().SetDynamicColors(true).SetText(s).GetText(true)
+}
+
+// copyToClipboard sends text to the system clipboard as an OSC 52 escape,
+// which terminals honor even over SSH, and through a native clipboard tool
+// when k13d runs locally. what describes the text in the confirmation flash.
+func (a *App) copyToClipboard(what, text string) {
+	if text == "" {
+		a.flashMsg("Nothing to copy", true)
+		return
+	}
+	// The escape goes out through the screen on the next draw, which the
+	// flash message below triggers
+	a.pendingClipboard.Store(&text)
+
+	cmd := nativeClipboardCommand(runtime.GOOS, os.Getenv, clipboardLookPathFunc)
+	if cmd == nil {
+		a.flashMsg(fmt.Sprintf("Copied %s to clipboard", what), false)
+		return
+	}
+	a.safeGo("copyToClipboard", func() {
+		ctx, cancel := context.WithTimeout(a.getAppContext(), 5*time.Second)
+		defer cancel()
+		c := exec.CommandContext(ctx, cmd[0], cmd[1:]...)
+		c.Stdin = strings.NewReader(text)
+		if err := c.Run(); err != nil {
+			a.logger.Debug("native clipboard copy failed", "command", cmd[0], "error", err)
+		}
+		a.flashMsg(fmt.Sprintf("Copied %s to clipboard", what), false)
+	})
+}
+
+// flushClipboard writes a pending clipboard copy to the terminal. It runs
+// before each draw, on the UI goroutine that owns the screen.
+func (a *App) flushClipboard(screen tcell.Screen) {
+	if text := a.pendingClipboard.Swap(nil); text != nil {
+		screen.SetClipboard([]byte(*text))
+	}
+}
+
+// selectedRowCells returns the headers and plain-text cells of the selected
+// table row
+func (a *App) selectedRowCells() ([]string, []string) {
+	row, _ := a.table.GetSelection()
+	if row <= 0 {
+		return nil, nil
+	}
+	a.mx.RLock()
+	headers := append([]string(nil), a.tableHeaders...)
+	a.mx.RUnlock()
+
+	cells := make([]string, a.table.GetColumnCount())
+	for col := range cells {
+		cells[col] = strings.TrimSpace(plainText(a.getTableCellText(row, col)))
+	}
+	return headers, cells
+}
+
+// copySelectedRow copies every cell of the selected row, tab-separated (Shift+Y)
+func (a *App) copySelectedRow() {
+	_, cells := a.selectedRowCells()
+	if len(cells) == 0 {
+		return
+	}
+	a.copyToClipboard("row", strings.Join(cells, "\t"))
+}
+
+// copySelectedCell lists the selected row's cells and copies the chosen one
+// (w). The table selects whole rows, so the column is picked here, starting
+// on NAME.
+func (a *App) copySelectedCell() {
+	headers, cells := a.selectedRowCells()
+	if len(cells) == 0 {
+		return
+	}
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true).SetTitle(" Copy Cell [gray](Enter:copy Esc:cancel)[white] ")
+	closeList := func() {
+		a.closeModal("copy-cell")
+		a.SetFocus(a.table)
+	}
+	width := 20
+	for col, text := range cells {
+		header := fmt.Sprintf("COLUMN %d", col+1)
+		if col < len(headers) {
+			header = headers[col]
+		}
+		label := fmt.Sprintf("[yellow]%-12s[white] %s", tview.Escape(header), tview.Escape(text))
+		width = max(width, len(header)+len(text)+8)
+		var shortcut rune
+		if col < 9 {
+			shortcut = rune('1' + col)
+		}
+		list.AddItem(label, "", shortcut, func() {
+			closeList()
+			a.copyToClipboard(fmt.Sprintf("%s %q", strings.ToLower(header), text), text)
+		})
+		if strings.EqualFold(header, "NAME") {
+			list.SetCurrentItem(col)
+		}
+	}
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc || (event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+			closeList()
+			return nil
+		}
+		return event
+	})
+
+	a.showModal("copy-cell", centered(list, min(width, 100), len(cells)+2), true)
+	a.SetFocus(list)
+}
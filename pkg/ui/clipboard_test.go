@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+func TestNativeClipboardCommand(t *testing.T) {
+	installed := func(names ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, n := range names {
+				if n == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+	}
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	tests := []struct {
+		name  string
+		goos  string
+		env   map[string]string
+		tools []string
+		want  string
+	}{
+		{"macOS", "darwin", nil, []string{"pbcopy"}, "pbcopy"},
+		{"X11 prefers xclip", "linux", map[string]string{"DISPLAY": ":0"}, []string{"xclip", "xsel"}, "xclip -selection clipboard"},
+		{"X11 falls back to xsel", "linux", map[string]string{"DISPLAY": ":0"}, []string{"xsel"}, "xsel --clipboard --input"},
+		{"Wayland", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, []string{"wl-copy", "xclip"}, "wl-copy"},
+		{"no display skips X tools", "linux", nil, []string{"xclip"}, ""},
+		{"WSL", "linux", nil, []string{"clip.exe"}, "clip.exe"},
+		{"SSH uses OSC 52 only", "darwin", map[string]string{"SSH_CONNECTION": "10.0.0.1 22 10.0.0.2 22"}, []string{"pbcopy"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(nativeClipboardCommand(tt.goos, env(tt.env), installed(tt.tools...)), " ")
+			if got != tt.want {
+				t.Errorf("nativeClipboardCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopySelectedRow(t *testing.T) {
+	origLookPath := clipboardLookPathFunc
+	clipboardLookPathFunc = func(string) (string, error) { return "", errors.New("not found") }
+	defer func() { clipboardLookPathFunc = origLookPath }()
+
+	app := NewTestApp(TestAppConfig{SkipBriefing: true})
+	app.table.Clear()
+	app.tableHeaders = []string{"NAMESPACE", "NAME", "STATUS", "IP"}
+	for col, text := range []string{"NAMESPACE", "NAME", "STATUS", "IP"} {
+		app.table.SetCell(0, col, tview.NewTableCell(text))
+	}
+	for col, text := range []string{"default", "[black:yellow]web[-:-]-0", "[green]Running[white]", "10.0.0.7"} {
+		app.table.SetCell(1, col, tview.NewTableCell(text))
+	}
+	app.table.Select(1, 0)
+
+	app.copySelectedRow()
+	got := app.pendingClipboard.Load()
+	if got == nil || *got != "default\tweb-0\tRunning\t10.0.0.7" {
+		t.Fatalf("copied row = %v, want plain tab-separated cells", got)
+	}
+
+	app.table.Select(0, 0)
+	app.pendingClipboard.Store(nil)
+	app.copySelectedRow()
+	if app.pendingClipboard.Load() != nil {
+		t.Error("copying the header row should copy nothing")
+	}
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// defaultMultiClusterTimeout bounds each cluster's query when
+// multi_cluster.timeout_seconds is unset.
+const defaultMultiClusterTimeout = 10 * time.Second
+
+var clusterColumns = []string{"CONTEXT", "NODES", "PODS", "WARNINGS", "HEALTH", "ERROR"}
+
+// clusterHealthScore rates a cluster with the briefing panel's weights.
+// Completed pods are left out so finished Jobs do not lower the score.
+func clusterHealthScore(s k8s.ClusterSummary) int {
+	return calculateHealthScore(&BriefingData{
+		TotalPods:   s.PodsTotal - s.PodsSucceeded,
+		RunningPods: s.PodsRunning,
+		TotalNodes:  s.NodesTotal,
+		ReadyNodes:  s.NodesReady,
+	})
+}
+
+// clusterSummaryCells renders one row of the :clusters table. The active
+// context is marked with "*".
+func clusterSummaryCells(s k8s.ClusterSummary, currentContext string) []string {
+	name := "  " + s.Context
+	if s.Context == currentContext {
+		name = "* " + s.Context
+	}
+	if s.Err != nil {
+		return []string{name, "-", "-", "-", "[red]unreachable[white]", s.Err.Error()}
+	}
+
+	score := clusterHealthScore(s)
+	warnings := fmt.Sprintf("%d", s.Warnings)
+	if s.Warnings > 0 {
+		warnings = "[yellow]" + warnings + "[white]"
+	}
+	return []string{
+		name,
+		fmt.Sprintf("%d/%d", s.NodesReady, s.NodesTotal),
+		fmt.Sprintf("%d/%d", s.PodsRunning, s.PodsTotal-s.PodsSucceeded),
+		warnings,
+		fmt.Sprintf("%s%d%%[white]", getHealthColor(healthStatusFromScore(score)), score),
+		"",
+	}
+}
+
+// multiClusterContexts returns the contexts listed in multi_cluster.contexts,
+// or every kubeconfig context when none are configured.
+func (a *App) multiClusterContexts() ([]string, string, error) {
+	all, current, err := a.k8s.ListContexts()
+	if err != nil {
+		return nil, "", err
+	}
+	if a.config != nil && len(a.config.MultiCluster.Contexts) > 0 {
+		return append([]string(nil), a.config.MultiCluster.Contexts...), current, nil
+	}
+	sort.Strings(all)
+	return all, current, nil
+}
+
+// showClusters displays health for several kubeconfig contexts side by side.
+// Enter switches the active context to the selected cluster.
+func (a *App) showClusters() {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetBorder(true).
+		SetTitle(" Clusters (Enter:switch  r:refresh  Esc:close) ").
+		SetTitleAlign(tview.AlignLeft)
+
+	for col, header := range clusterColumns {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false).
+			SetExpansion(1))
+	}
+
+	var (
+		summaries      []k8s.ClusterSummary
+		currentContext string
+	)
+
+	load := func() {
+		a.QueueUpdateDraw(func() {
+			for row := table.GetRowCount() - 1; row > 0; row-- {
+				table.RemoveRow(row)
+			}
+			table.SetCell(1, 0, tview.NewTableCell("[gray]Loading clusters...[white]").SetSelectable(false))
+		})
+
+		contexts, current, err := a.multiClusterContexts()
+		if err != nil {
+			a.QueueUpdateDraw(func() {
+				table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("[red]Failed to list contexts: %v[white]", err)).SetSelectable(false))
+			})
+			return
+		}
+
+		timeout := defaultMultiClusterTimeout
+		if a.config != nil && a.config.MultiCluster.TimeoutSeconds > 0 {
+			timeout = time.Duration(a.config.MultiCluster.TimeoutSeconds) * time.Second
+		}
+		result := a.k8s.SummarizeContexts(a.getAppContext(), contexts, timeout)
+
+		a.QueueUpdateDraw(func() {
+			summaries, currentContext = result, current
+			table.RemoveRow(1)
+			for i, s := range result {
+				for col, text := range clusterSummaryCells(s, current) {
+					table.SetCell(i+1, col, tview.NewTableCell(text).SetExpansion(1))
+				}
+			}
+			if len(result) > 0 {
+				table.Select(1, 0)
+			}
+		})
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.closeModal("clusters")
+			a.SetFocus(a.table)
+			return nil
+		case tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			if row < 1 || row > len(summaries) {
+				return nil
+			}
+			target := summaries[row-1].Context
+			a.closeModal("clusters")
+			a.SetFocus(a.table)
+			if target != currentContext {
+				a.safeGo("switchContext", func() { a.switchToContext(target) })
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'r':
+				a.safeGo("clusters-refresh", load)
+				return nil
+			case 'q':
+				a.closeModal("clusters")
+				a.SetFocus(a.table)
+				return nil
+			}
+		}
+		return event
+	})
+
+	a.showModal("clusters", centered(table, 110, 20), true)
+	a.SetFocus(table)
+
+	a.safeGo("clusters-initial", load)
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+func TestClusterSummaryCells(t *testing.T) {
+	healthy := k8s.ClusterSummary{
+		Context:       "prod",
+		NodesTotal:    3,
+		NodesReady:    3,
+		PodsTotal:     12,
+		PodsRunning:   10,
+		PodsSucceeded: 2,
+	}
+	cells := clusterSummaryCells(healthy, "prod")
+	if len(cells) != len(clusterColumns) {
+		t.Fatalf("got %d cells, want %d", len(cells), len(clusterColumns))
+	}
+	if cells[0] != "* prod" {
+		t.Errorf("context cell = %q, want current marker", cells[0])
+	}
+	if cells[1] != "3/3" || cells[2] != "10/10" {
+		t.Errorf("nodes/pods = %q %q, want 3/3 10/10 (completed pods excluded)", cells[1], cells[2])
+	}
+	if !strings.Contains(cells[4], "[green]100%") {
+		t.Errorf("health cell = %q, want green 100%%", cells[4])
+	}
+
+	degraded := k8s.ClusterSummary{Context: "staging", NodesTotal: 2, NodesReady: 1, PodsTotal: 4, Warnings: 7}
+	cells = clusterSummaryCells(degraded, "prod")
+	if cells[0] != "  staging" {
+		t.Errorf("context cell = %q, want no marker", cells[0])
+	}
+	if !strings.Contains(cells[3], "7") || !strings.Contains(cells[4], "[red]") {
+		t.Errorf("warnings/health = %q %q, want 7 warnings and red health", cells[3], cells[4])
+	}
+
+	cells = clusterSummaryCells(k8s.ClusterSummary{Context: "edge", Err: errors.New("connection refused")}, "prod")
+	if !strings.Contains(cells[4], "unreachable") || cells[5] != "connection refused" {
+		t.Errorf("unreachable row = %q", cells)
+	}
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var configConsumerColumns = []string{"KIND", "NAME", "VIA"}
+
+// consumerResources maps a ConfigConsumer kind to the view that lists it
+var consumerResources = map[string]string{
+	"Deployment":  "deployments",
+	"StatefulSet": "statefulsets",
+	"DaemonSet":   "daemonsets",
+	"CronJob":     "cronjobs",
+	"Job":         "jobs",
+	"Pod":         "pods",
+}
+
+// configConsumerRows returns the table cells for the consumers of a Secret
+// or ConfigMap
+func configConsumerRows(consumers []k8s.ConfigConsumer) [][]string {
+	rows := make([][]string, 0, len(consumers))
+	for _, c := range consumers {
+		rows = append(rows, []string{c.Kind, c.Name, strings.Join(c.Via, ",")})
+	}
+	return rows
+}
+
+// showConfigConsumers lists the workloads and pods that refer to the selected
+// Secret or ConfigMap (Shift+U), so it can be rotated or deleted without
+// breaking them. Enter opens the consumer.
+func (a *App) showConfigConsumers() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	var kind string
+	switch resource {
+	case "secrets", "sec":
+		kind = k8s.ConfigRefSecret
+	case "configmaps", "cm":
+		kind = k8s.ConfigRefConfigMap
+	default:
+		a.flashMsg("Usage view is only available for secrets and configmaps", true)
+		return
+	}
+
+	row, _ := a.table.GetSelection()
+	if row <= 0 {
+		return
+	}
+	ns := a.getTableCellText(row, 0)
+	name := a.getTableCellText(row, 1)
+
+	table := tview.NewTable().SetBorders(false).SetSelectable(true, false).SetFixed(1, 0)
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(fmt.Sprintf(" %s %s/%s used by [gray](Enter:open r:refresh Esc:close)[white] ", kind, ns, name))
+
+	var consumers []k8s.ConfigConsumer
+
+	load := func() {
+		a.safeGo("config-consumers", func() {
+			if a.k8s == nil {
+				a.QueueUpdateDraw(func() {
+					table.SetCell(1, 0, tview.NewTableCell("[red]Not connected to a cluster[white]").SetSelectable(false))
+				})
+				return
+			}
+			ctx, cancel := context.WithTimeout(a.getAppContext(), 15*time.Second)
+			defer cancel()
+			loaded, err := a.k8s.FindConfigConsumers(ctx, kind, ns, name)
+			a.QueueUpdateDraw(func() {
+				if err != nil {
+					table.Clear()
+					table.SetCell(0, 0, tview.NewTableCell(fmt.Sprintf("[red]Failed to scan %s for references: %s[white]", ns, tview.Escape(err.Error()))).SetSelectable(false))
+					return
+				}
+				consumers = loaded
+				fillPodEnvTable(table, configConsumerColumns, configConsumerRows(consumers),
+					fmt.Sprintf("No workload or pod in %s refers to this %s", ns, kind))
+			})
+		})
+	}
+
+	closeView := func() {
+		a.closeModal("config-consumers")
+		a.SetFocus(a.table)
+	}
+
+	open := func() {
+		sel, _ := table.GetSelection()
+		if sel <= 0 || sel > len(consumers) {
+			return
+		}
+		consumer := consumers[sel-1]
+		closeView()
+		a.navigateTo(consumerResources[consumer.Kind], consumer.Namespace, consumer.Name)
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeView()
+			return nil
+		case tcell.KeyEnter:
+			open()
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'r':
+				load()
+				return nil
+			case 'q':
+				closeView()
+				return nil
+			}
+		}
+		return event
+	})
+
+	fillPodEnvTable(table, configConsumerColumns, nil, "Scanning workloads...")
+	a.showModal("config-consumers", centered(table, 100, 16), true)
+	a.SetFocus(table)
+	load()
+}
@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+func TestConfigConsumerRows(t *testing.T) {
+	rows := configConsumerRows([]k8s.ConfigConsumer{
+		{Kind: "Deployment", Namespace: "default", Name: "api", Via: []string{k8s.ConfigViaEnv, k8s.ConfigViaVolume}},
+		{Kind: "Pod", Namespace: "default", Name: "api-abc", Via: []string{k8s.ConfigViaPullSecret}},
+	})
+	want := [][]string{
+		{"Deployment", "api", "env,volume"},
+		{"Pod", "api-abc", "imagePullSecret"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("configConsumerRows() = %v, want %v", rows, want)
+	}
+
+	for _, kind := range []string{"Deployment", "StatefulSet", "DaemonSet", "CronJob", "Job", "Pod"} {
+		if consumerResources[kind] == "" {
+			t.Errorf("consumerResources has no view for %s", kind)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// changeConfirm is the confirmation before a cluster change, shown as the
+// current context's safety level (contexts.safety) asks
+type changeConfirm struct {
+	id     string // Modal name
+	text   string
+	label  string // Confirm button
+	name   string // What strict contexts must type, e.g. the resource name
+	danger bool   // Red background
+	// always asks even in relaxed contexts, e.g. for namespace deletes
+	always bool
+	// strictOnly asks only in strict contexts, e.g. for scaling
+	strictOnly bool
+	// back gets the focus when the dialog closes; nil is the main table
+	back tview.Primitive
+	run  func()
+}
+
+// safetyLevel returns the safety level of the current context
+func (a *App) safetyLevel() string {
+	return a.config.ContextSafetyLevel(a.getCurrentContext())
+}
+
+// skipsConfirm reports whether c runs without asking in the current context
+func (a *App) skipsConfirm(c changeConfirm) bool {
+	switch a.safetyLevel() {
+	case config.SafetyRelaxed:
+		return !c.always
+	case config.SafetyStrict:
+		return false
+	}
+	return c.strictOnly
+}
+
+// confirmChange asks before running c.run: not at all in relaxed contexts,
+// with a button in normal ones, and by typing c.name in strict ones
+func (a *App) confirmChange(c changeConfirm) {
+	if a.skipsConfirm(c) {
+		c.run()
+		return
+	}
+	if c.name != "" && a.safetyLevel() == config.SafetyStrict {
+		a.showTypedConfirm(c.id, fmt.Sprintf(" Confirm %s ", c.label), c.text, c.name, "Name:", c.label, nil, c.back, c.run)
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(c.text).
+		AddButtons([]string{"Cancel", c.label}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.closeModal(c.id)
+			a.SetFocus(a.focusOrTable(c.back))
+			if buttonLabel == c.label {
+				c.run()
+			}
+		})
+	if c.danger {
+		modal.SetBackgroundColor(tcell.ColorDarkRed)
+	}
+	a.showModal(c.id, modal, true)
+}
+
+// focusOrTable returns back, or the main table when back is nil
+func (a *App) focusOrTable(back tview.Primitive) tview.Primitive {
+	if back == nil {
+		return a.table
+	}
+	return back
+}
+
+// showTypedConfirm asks to type want before running run, so a stray Enter
+// cannot confirm the change. back gets the focus afterwards; nil is the main
+// table.
+func (a *App) showTypedConfirm(id, title, text, want, fieldLabel, label string, accept func(string, rune) bool, back tview.Primitive, run func()) {
+	text += fmt.Sprintf("\n\nType [::b]%s[::-] to confirm.", tview.Escape(want))
+
+	textView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(text)
+	textView.SetBackgroundColor(tcell.ColorDarkRed)
+
+	cancel := func() {
+		a.closeModal(id)
+		a.SetFocus(a.focusOrTable(back))
+	}
+
+	var typed string
+	form := tview.NewForm()
+	form.SetBackgroundColor(tcell.ColorDarkRed)
+	form.AddInputField(fieldLabel, "", max(10, min(len(want)+2, 40)), accept, func(value string) {
+		typed = value
+	})
+	form.AddButton(label, func() {
+		if strings.TrimSpace(typed) != want {
+			a.flashMsg(fmt.Sprintf("Type %s to confirm", want), true)
+			return
+		}
+		cancel()
+		run()
+	})
+	form.AddButton("Cancel", cancel)
+	form.SetCancelFunc(cancel)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(textView, 0, 1, false).
+		AddItem(form, 5, 0, true)
+	layout.SetBorder(true).SetTitle(title).SetBackgroundColor(tcell.ColorDarkRed)
+
+	height := strings.Count(text, "\n") + 9
+	a.showModal(id, centered(layout, 70, height), true)
+}
+
+// safetyBadge labels the safety level of the context in the header. The
+// normal level is only shown when contexts.safety has rules.
+func safetyBadge(p config.PaletteStyle, level string, configured bool) string {
+	switch {
+	case level == config.SafetyRelaxed:
+		return "  " + colorTag(p.Warning) + "◇ relaxed[-]"
+	case level == config.SafetyStrict:
+		return "  " + colorTag(p.Error) + "◆ strict[-]"
+	case configured:
+		return "  " + colorTag(p.Muted) + "◇ normal[-]"
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+func TestConfirmChange_SafetyLevels(t *testing.T) {
+	app := NewTestApp(TestAppConfig{
+		SkipBackgroundLoading: true,
+		SkipBriefing:          true,
+	})
+	setLevel := func(level string) {
+		app.config = &config.Config{Contexts: config.ContextsConfig{
+			Safety: []config.ContextSafety{{Contexts: []string{"*"}, Level: level}},
+		}}
+	}
+
+	tests := []struct {
+		level  string
+		change changeConfirm
+		asks   bool
+	}{
+		{config.SafetyRelaxed, changeConfirm{}, false},
+		{config.SafetyRelaxed, changeConfirm{always: true}, true},
+		{config.SafetyNormal, changeConfirm{}, true},
+		{config.SafetyNormal, changeConfirm{strictOnly: true}, false},
+		{config.SafetyStrict, changeConfirm{strictOnly: true}, true},
+	}
+	for _, tt := range tests {
+		setLevel(tt.level)
+		ran := false
+		tt.change.id = "test-confirm"
+		tt.change.label = "Delete"
+		tt.change.run = func() { ran = true }
+		app.confirmChange(tt.change)
+
+		asked := hasTestPage(app, "test-confirm")
+		if asked != tt.asks || ran == tt.asks {
+			t.Errorf("%s %+v: asked = %v, ran = %v; want asked = %v", tt.level, tt.change, asked, ran, tt.asks)
+		}
+		app.closeModal("test-confirm")
+	}
+}
+
+func TestSafetyBadge(t *testing.T) {
+	p := config.DefaultPalette()
+	if got := safetyBadge(p, config.SafetyStrict, true); !strings.Contains(got, "strict") {
+		t.Errorf("strict badge = %q", got)
+	}
+	if got := safetyBadge(p, config.SafetyRelaxed, false); !strings.Contains(got, "relaxed") {
+		t.Errorf("relaxed badge = %q", got)
+	}
+	if got := safetyBadge(p, config.SafetyNormal, false); got != "" {
+		t.Errorf("normal badge without rules = %q, want none", got)
+	}
+	if got := safetyBadge(p, config.SafetyNormal, true); !strings.Contains(got, "normal") {
+		t.Errorf("normal badge with rules = %q", got)
+	}
+}
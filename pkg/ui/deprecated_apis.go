@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var deprecatedAPIColumns = []string{"STATUS", "KIND", "NAMESPACE", "NAME", "API VERSION", "REPLACEMENT", "REMOVED IN", "FOUND IN"}
+
+// deprecatedAPIRow returns the table cells for one deprecated API use
+func deprecatedAPIRow(u k8s.DeprecatedAPIUse) []string {
+	status := "[yellow]" + u.Status + "[white]"
+	if u.Status == k8s.APIRemoved {
+		status = "[red]" + u.Status + "[white]"
+	}
+	replacement := u.Replacement
+	if replacement == "" {
+		replacement = "none"
+	}
+	found := u.File
+	if u.Source == k8s.DeprecationSourceLive {
+		found = "live (" + u.Via + ")"
+	}
+	return []string{
+		status,
+		tview.Escape(u.Kind),
+		tview.Escape(dashIfEmpty(u.Namespace)),
+		tview.Escape(u.Name),
+		tview.Escape(u.APIVersion),
+		tview.Escape(replacement),
+		u.RemovedIn,
+		tview.Escape(found),
+	}
+}
+
+// showDeprecatedAPIs lists the objects written with API versions deprecated
+// or removed at the server's version, plus the manifests under
+// drift.manifest_dir that use them. r rescans.
+func (a *App) showDeprecatedAPIs() {
+	dir := ""
+	if a.config != nil {
+		dir = a.config.Drift.ManifestDir
+	}
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	render := func(scan *k8s.DeprecatedAPIScan, err error) {
+		table.Clear()
+		title := " Deprecated APIs "
+		if scan != nil {
+			title = fmt.Sprintf(" Deprecated APIs: server %s ([red]%d removed[white], [yellow]%d deprecated[white]) ",
+				tview.Escape(dashIfEmpty(scan.ServerVersion)), scan.Removed, scan.Deprecated)
+		}
+		table.SetTitle(title + "[gray](r:rescan Esc:close)[white] ")
+		for col, header := range deprecatedAPIColumns {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorYellow).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+		}
+		switch {
+		case err != nil:
+			table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("[red]Deprecated API scan failed: %s[white]", tview.Escape(err.Error()))).SetSelectable(false))
+			return
+		case scan == nil:
+			return
+		}
+		row := 1
+		for _, u := range scan.Uses {
+			for col, text := range deprecatedAPIRow(u) {
+				cell := tview.NewTableCell(text)
+				if col == len(deprecatedAPIColumns)-1 {
+					cell.SetExpansion(1)
+				}
+				table.SetCell(row, col, cell)
+			}
+			row++
+		}
+		if len(scan.Uses) == 0 {
+			table.SetCell(row, 0, tview.NewTableCell("[green]No deprecated API versions in use[white]").SetSelectable(false))
+			row++
+		}
+		for _, e := range scan.Errors {
+			table.SetCell(row, 0, tview.NewTableCell("[gray]"+tview.Escape(e)+"[white]").SetSelectable(false))
+			row++
+		}
+		table.Select(1, 0)
+	}
+
+	refresh := func() {
+		a.safeGo("deprecatedAPIs", func() {
+			if a.k8s == nil {
+				a.QueueUpdateDraw(func() { render(nil, fmt.Errorf("not connected to a cluster")) })
+				return
+			}
+			ctx, cancel := context.WithTimeout(a.appCtx, 60*time.Second)
+			defer cancel()
+			scan, err := a.k8s.DetectDeprecatedAPIs(ctx, "", dir)
+			a.QueueUpdateDraw(func() { render(scan, err) })
+		})
+	}
+
+	closeView := func() {
+		a.closeModal("deprecated-apis")
+		a.SetFocus(a.table)
+	}
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeView()
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'r':
+				refresh()
+				return nil
+			case 'q':
+				closeView()
+				return nil
+			}
+		}
+		return event
+	})
+
+	render(nil, nil)
+	table.SetCell(1, 0, tview.NewTableCell("[gray]Checking live resources for deprecated API versions...[white]").SetSelectable(false))
+	a.showModal("deprecated-apis", centered(table, 160, 25), true)
+	a.SetFocus(table)
+	refresh()
+}
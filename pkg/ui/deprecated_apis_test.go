@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+func TestDeprecatedAPIRow(t *testing.T) {
+	api, _ := k8s.LookupDeprecatedAPI("policy/v1beta1", "PodSecurityPolicy")
+	u := k8s.DeprecatedAPIUse{
+		DeprecatedAPI: api,
+		Name:          "restricted",
+		Status:        k8s.APIRemoved,
+		Source:        k8s.DeprecationSourceLive,
+		Via:           "helm, last-applied",
+	}
+
+	want := []string{"[red]Removed[white]", "PodSecurityPolicy", "-", "restricted", "policy/v1beta1", "none", "v1.25", "live (helm, last-applied)"}
+	got := deprecatedAPIRow(u)
+	if len(got) != len(deprecatedAPIColumns) {
+		t.Fatalf("deprecatedAPIRow() returned %d cells, want %d", len(got), len(deprecatedAPIColumns))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s = %q, want %q", deprecatedAPIColumns[i], got[i], want[i])
+		}
+	}
+
+	u.Source, u.File, u.Status = k8s.DeprecationSourceManifest, "policy/psp.yaml", k8s.APIDeprecated
+	if got := deprecatedAPIRow(u); got[0] != "[yellow]Deprecated[white]" || got[7] != "policy/psp.yaml" {
+		t.Errorf("deprecatedAPIRow() = %q, want a deprecated manifest use", got)
+	}
+
+	app := NewTestApp(TestAppConfig{SkipBackgroundLoading: true, SkipBriefing: true})
+	app.showDeprecatedAPIs()
+	if !hasTestPage(app, "deprecated-apis") {
+		t.Error(":upgrade-check did not open the deprecated APIs view")
+	}
+}
@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var driftColumns = []string{"STATUS", "KIND", "NAMESPACE", "NAME", "FIELDS", "FILE"}
+
+// driftRow returns the table cells for one manifest object
+func driftRow(d k8s.ResourceDrift) []string {
+	status := map[string]string{
+		k8s.DriftInSync:  "[green]",
+		k8s.DriftDrifted: "[yellow]",
+		k8s.DriftMissing: "[red]",
+		k8s.DriftError:   "[red]",
+	}[d.Status] + d.Status + "[white]"
+
+	fields := "-"
+	switch {
+	case d.Err != "":
+		fields = d.Err
+	case len(d.Fields) > 0:
+		fields = fmt.Sprintf("%d", len(d.Fields))
+		if !d.Exact {
+			fields += " (manifest fields only)"
+		}
+	}
+
+	namespace := d.Namespace
+	if namespace == "" {
+		namespace = "-"
+	}
+	return []string{
+		status,
+		tview.Escape(d.Kind),
+		tview.Escape(namespace),
+		tview.Escape(d.Name),
+		tview.Escape(fields),
+		tview.Escape(d.File),
+	}
+}
+
+// driftDetail renders the field-level differences of one object, prefixed
+// ~ for changed, + for set only live, and - for set only in the manifest
+func driftDetail(d k8s.ResourceDrift) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, " [yellow::b]%s %s[white::-]\n", tview.Escape(d.Kind), tview.Escape(d.Name))
+	fmt.Fprintf(&sb, " [gray]File:[white] %s\n\n", tview.Escape(d.File))
+	switch {
+	case d.Err != "":
+		fmt.Fprintf(&sb, " [red]%s[white]\n", tview.Escape(d.Err))
+	case d.Status == k8s.DriftMissing:
+		sb.WriteString(" [red]Not found in the cluster[white]\n")
+	case len(d.Fields) == 0:
+		sb.WriteString(" [green]Live object matches the manifest[white]\n")
+	}
+	for _, f := range d.Fields {
+		path := tview.Escape(f.Path)
+		switch f.Type {
+		case k8s.FieldChanged:
+			fmt.Fprintf(&sb, " [yellow]~ %s[white]: %s → %s\n", path, tview.Escape(f.Desired), tview.Escape(f.Live))
+		case k8s.FieldAdded:
+			fmt.Fprintf(&sb, " [green]+ %s[white]: %s\n", path, tview.Escape(f.Live))
+		case k8s.FieldRemoved:
+			fmt.Fprintf(&sb, " [red]- %s[white]: %s\n", path, tview.Escape(f.Desired))
+		}
+	}
+	if !d.Exact && len(d.Fields) > 0 {
+		sb.WriteString("\n [gray]The server could not default this manifest, so only fields it sets were compared[white]\n")
+	}
+	return sb.String()
+}
+
+// showDrift compares the manifests under dir with the live cluster, falling
+// back to the configured drift directory. Enter shows the selected object's
+// field differences and r rescans.
+func (a *App) showDrift(dir string) {
+	namespace := ""
+	if a.config != nil {
+		if dir == "" {
+			dir = a.config.Drift.ManifestDir
+		}
+		namespace = a.config.Drift.DefaultNamespace
+	}
+	if dir == "" {
+		a.flashMsg("Usage: :drift <manifest dir> (or set drift.manifest_dir in config)", true)
+		return
+	}
+	if namespace == "" {
+		a.mx.RLock()
+		namespace = a.currentNamespace
+		a.mx.RUnlock()
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	var drifts []k8s.ResourceDrift
+	render := func(result []k8s.ResourceDrift, err error) {
+		drifts = result
+		table.Clear()
+		drifted := 0
+		for _, d := range result {
+			if d.Status != k8s.DriftInSync {
+				drifted++
+			}
+		}
+		table.SetTitle(fmt.Sprintf(" Drift: %s (%d objects, %d out of sync) [gray](Enter:fields r:rescan Esc:close)[white] ",
+			tview.Escape(dir), len(result), drifted))
+		for col, header := range driftColumns {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorYellow).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+		}
+		switch {
+		case err != nil:
+			table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("[red]Drift detection failed: %s[white]", tview.Escape(err.Error()))).SetSelectable(false))
+			return
+		case len(result) == 0:
+			table.SetCell(1, 0, tview.NewTableCell("[gray]No manifests found[white]").SetSelectable(false))
+			return
+		}
+		for i, d := range result {
+			for col, text := range driftRow(d) {
+				cell := tview.NewTableCell(text).SetReference(i)
+				if col == len(driftColumns)-1 {
+					cell.SetExpansion(1)
+				}
+				table.SetCell(i+1, col, cell)
+			}
+		}
+		table.Select(1, 0)
+	}
+
+	refresh := func() {
+		a.safeGo("drift", func() {
+			if a.k8s == nil {
+				a.QueueUpdateDraw(func() { render(nil, fmt.Errorf("not connected to a cluster")) })
+				return
+			}
+			ctx, cancel := context.WithTimeout(a.appCtx, 60*time.Second)
+			defer cancel()
+			result, err := a.k8s.DetectDrift(ctx, dir, namespace)
+			a.QueueUpdateDraw(func() { render(result, err) })
+		})
+	}
+
+	closeView := func() {
+		a.closeModal("drift")
+		a.SetFocus(a.table)
+	}
+	showFields := func() {
+		row, _ := table.GetSelection()
+		i, ok := table.GetCell(row, 0).GetReference().(int)
+		if !ok || i >= len(drifts) {
+			return
+		}
+		detail := tview.NewTextView().
+			SetDynamicColors(true).
+			SetScrollable(true).
+			SetWrap(true)
+		detail.SetBorder(true).SetTitle(" Drift Fields (Esc:back) ")
+		detail.SetText(driftDetail(drifts[i]))
+		detail.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			if event.Key() == tcell.KeyEsc || (event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+				a.closeModal("drift-fields")
+				a.SetFocus(table)
+				return nil
+			}
+			return event
+		})
+		a.showModal("drift-fields", centered(detail, 130, 25), true)
+		a.SetFocus(detail)
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeView()
+			return nil
+		case tcell.KeyEnter:
+			showFields()
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'r':
+				refresh()
+				return nil
+			case 'q':
+				closeView()
+				return nil
+			}
+		}
+		return event
+	})
+
+	render(nil, nil)
+	table.SetCell(1, 0, tview.NewTableCell("[gray]Comparing manifests with the cluster...[white]").SetSelectable(false))
+	a.showModal("drift", centered(table, 150, 25), true)
+	a.SetFocus(table)
+	refresh()
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+func TestDriftRow(t *testing.T) {
+	d := k8s.ResourceDrift{
+		File:   "apps/web.yaml",
+		Kind:   "Deployment",
+		Name:   "web",
+		Status: k8s.DriftDrifted,
+		Fields: []k8s.FieldDrift{{Path: "spec.replicas", Type: k8s.FieldChanged, Desired: "3", Live: "5"}},
+	}
+
+	want := []string{"[yellow]Drifted[white]", "Deployment", "-", "web", "1 (manifest fields only)", "apps/web.yaml"}
+	got := driftRow(d)
+	if len(got) != len(driftColumns) {
+		t.Fatalf("driftRow() returned %d cells, want %d", len(got), len(driftColumns))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s = %q, want %q", driftColumns[i], got[i], want[i])
+		}
+	}
+
+	d.Exact, d.Namespace = true, "prod"
+	if got := driftRow(d); got[2] != "prod" || got[4] != "1" {
+		t.Errorf("driftRow() = %q, want namespace prod and 1 field", got)
+	}
+}
+
+func TestDriftDetail(t *testing.T) {
+	detail := driftDetail(k8s.ResourceDrift{
+		Kind:  "Deployment",
+		Name:  "web",
+		Exact: true,
+		Fields: []k8s.FieldDrift{
+			{Path: "spec.replicas", Type: k8s.FieldChanged, Desired: "3", Live: "5"},
+			{Path: "metadata.labels.hotfix", Type: k8s.FieldAdded, Live: "true"},
+			{Path: "spec.template.spec.containers[sidecar]", Type: k8s.FieldRemoved, Desired: "{...}"},
+		},
+	})
+	for _, want := range []string{
+		"~ spec.replicas[white]: 3 → 5",
+		"+ metadata.labels.hotfix[white]: true",
+		"- spec.template.spec.containers[sidecar[][white]",
+	} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("driftDetail() missing %q in:\n%s", want, detail)
+		}
+	}
+}
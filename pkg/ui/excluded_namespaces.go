@@ -0,0 +1,80 @@
+package ui
+
+import "github.com/cloudbro-kube-ai/k13d/pkg/config"
+
+// hidingExcludedNamespaces reports whether the excluded_namespaces are
+// currently hidden, i.e. some are configured and :excluded has not shown them
+func (a *App) hidingExcludedNamespaces() bool {
+	if a.config == nil || len(a.config.ExcludedNamespaces) == 0 {
+		return false
+	}
+	a.mx.RLock()
+	defer a.mx.RUnlock()
+	return !a.showExcludedNS
+}
+
+// visibleNamespaces drops the excluded_namespaces from a namespace list while
+// they are hidden, and the namespaces not matching the namespace regex. The
+// "" (all namespaces) entry is always kept.
+func (a *App) visibleNamespaces(namespaces []string) []string {
+	namespaces = filterNamespacesByRegex(a.currentNamespaceRegex(), namespaces)
+	if !a.hidingExcludedNamespaces() {
+		return namespaces
+	}
+	return filterExcludedNamespaces(a.config, namespaces)
+}
+
+func filterExcludedNamespaces(cfg *config.Config, namespaces []string) []string {
+	visible := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		if !cfg.NamespaceExcluded(ns) {
+			visible = append(visible, ns)
+		}
+	}
+	return visible
+}
+
+// hideExcludedNamespaceRows drops rows of an all-namespaces listing whose
+// NAMESPACE column is excluded, while the excluded_namespaces are hidden.
+// Cluster-scoped listings have no NAMESPACE column and are returned as is.
+func (a *App) hideExcludedNamespaceRows(headers []string, rows [][]string) [][]string {
+	if !a.hidingExcludedNamespaces() {
+		return rows
+	}
+	return filterExcludedNamespaceRows(a.config, headers, rows)
+}
+
+func filterExcludedNamespaceRows(cfg *config.Config, headers []string, rows [][]string) [][]string {
+	if len(headers) == 0 || headers[0] != "NAMESPACE" {
+		return rows
+	}
+	visible := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		if len(row) > 0 && cfg.NamespaceExcluded(row[0]) {
+			continue
+		}
+		visible = append(visible, row)
+	}
+	return visible
+}
+
+// toggleExcludedNamespaces temporarily shows or hides the namespaces listed
+// in excluded_namespaces (:excluded). An explicit :ns always works.
+func (a *App) toggleExcludedNamespaces() {
+	if a.config == nil || len(a.config.ExcludedNamespaces) == 0 {
+		a.flashMsg("No excluded_namespaces configured", true)
+		return
+	}
+	a.mx.Lock()
+	a.showExcludedNS = !a.showExcludedNS
+	show := a.showExcludedNS
+	a.mx.Unlock()
+
+	if show {
+		a.flashMsg("Showing excluded namespaces", false)
+	} else {
+		a.flashMsg("Hiding excluded namespaces", false)
+	}
+	a.updateHeader()
+	a.safeGo("excluded-refresh", a.refresh)
+}
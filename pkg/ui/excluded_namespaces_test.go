@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+func TestFilterExcludedNamespaces(t *testing.T) {
+	cfg := &config.Config{ExcludedNamespaces: []string{"kube-*"}}
+
+	got := filterExcludedNamespaces(cfg, []string{"", "default", "kube-system", "kube-public", "team-a"})
+	if len(got) != 3 || got[0] != "" || got[1] != "default" || got[2] != "team-a" {
+		t.Errorf("filterExcludedNamespaces() = %q, want [\"\" default team-a]", got)
+	}
+
+	rows := [][]string{{"default", "web"}, {"kube-system", "coredns"}}
+	if got := filterExcludedNamespaceRows(cfg, []string{"NAMESPACE", "NAME"}, rows); len(got) != 1 || got[0][1] != "web" {
+		t.Errorf("filterExcludedNamespaceRows() = %q, want only default/web", got)
+	}
+	// Cluster-scoped listings are untouched even when a name matches
+	nodeRows := [][]string{{"kube-node-1", "Ready"}}
+	if got := filterExcludedNamespaceRows(cfg, []string{"NAME", "STATUS"}, nodeRows); len(got) != 1 {
+		t.Errorf("cluster-scoped rows filtered: %q", got)
+	}
+}
+
+func TestToggleExcludedNamespaces(t *testing.T) {
+	app := NewTestApp(TestAppConfig{
+		SkipBackgroundLoading: true,
+		SkipBriefing:          true,
+	})
+	app.config = &config.Config{ExcludedNamespaces: []string{"kube-system"}}
+
+	namespaces := []string{"", "default", "kube-system"}
+	if got := app.visibleNamespaces(namespaces); len(got) != 2 {
+		t.Fatalf("visibleNamespaces() = %q, want kube-system hidden", got)
+	}
+
+	app.toggleExcludedNamespaces()
+	if got := app.visibleNamespaces(namespaces); len(got) != 3 {
+		t.Errorf("after :excluded visibleNamespaces() = %q, want every namespace", got)
+	}
+
+	app.toggleExcludedNamespaces()
+	if !app.hidingExcludedNamespaces() {
+		t.Error("a second :excluded should hide the namespaces again")
+	}
+}
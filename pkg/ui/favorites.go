@@ -0,0 +1,290 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var favoriteColumns = []string{"FAVORITE", "CONTEXT", "STATUS", "READY"}
+
+// favoriteStatus is the live state of one favorite
+type favoriteStatus struct {
+	Status string // STATUS column of the pinned resource, "NotFound", or "" for views
+	Detail string // READY column of the pinned resource, or the item count of a view
+	Err    error
+}
+
+// selectedFavorite returns the selected row as a favorite, or the current
+// view when the table has no rows
+func (a *App) selectedFavorite() config.Favorite {
+	a.mx.RLock()
+	fav := config.Favorite{Namespace: a.currentNamespace, Resource: a.currentResource}
+	a.mx.RUnlock()
+	fav.Context = a.getCurrentContext()
+
+	row, _ := a.table.GetSelection()
+	if candidate := a.aiSelectionCandidateForRow(row); !candidate.IsZero() {
+		fav.Name = candidate.Name
+		fav.Namespace = ""
+		if nameColumnIndex(fav.Resource) != 0 {
+			fav.Namespace = candidate.Namespace
+		}
+	}
+	return fav
+}
+
+// togglePin pins the selected resource to the favorites, or unpins it
+// (* key)
+func (a *App) togglePin() {
+	a.pinFavorite(a.selectedFavorite())
+}
+
+// pinFavorite toggles fav in the favorites and saves them
+func (a *App) pinFavorite(fav config.Favorite) {
+	if a.favorites == nil {
+		a.favorites = &config.FavoritesConfig{}
+	}
+	pinned := a.favorites.Toggle(fav)
+	if err := config.SaveFavorites(a.favorites); err != nil {
+		a.favorites.Toggle(fav)
+		a.flashMsg(fmt.Sprintf("Failed to save favorites: %v", err), true)
+		return
+	}
+	if pinned {
+		a.flashMsg(fmt.Sprintf("Pinned %s (' opens favorites)", fav), false)
+		return
+	}
+	a.flashMsg(fmt.Sprintf("Unpinned %s", fav), false)
+}
+
+// loadFavoriteStatuses looks up the live state of each favorite in the
+// current context. Favorites sharing a resource and namespace share one
+// list call; favorites of other contexts are left empty.
+func (a *App) loadFavoriteStatuses(ctx context.Context, favs []config.Favorite) []favoriteStatus {
+	statuses := make([]favoriteStatus, len(favs))
+	if a.k8s == nil {
+		return statuses
+	}
+	current := a.getCurrentContext()
+
+	type listResult struct {
+		headers []string
+		rows    [][]string
+		err     error
+	}
+	lists := map[string]listResult{}
+	for i, fav := range favs {
+		if fav.Context != "" && fav.Context != current {
+			continue
+		}
+		key := fav.Resource + "/" + fav.Namespace
+		list, ok := lists[key]
+		if !ok {
+			list.headers, list.rows, list.err = a.fetchResourceRows(ctx, fav.Resource, fav.Namespace)
+			lists[key] = list
+		}
+		if list.err != nil {
+			statuses[i].Err = list.err
+			continue
+		}
+		statuses[i] = favoriteRowStatus(fav, list.headers, list.rows)
+	}
+	return statuses
+}
+
+// favoriteRowStatus finds fav in a resource list. A pinned view reports its
+// item count.
+func favoriteRowStatus(fav config.Favorite, headers []string, rows [][]string) favoriteStatus {
+	if fav.Name == "" {
+		return favoriteStatus{Detail: fmt.Sprintf("%d items", len(rows))}
+	}
+
+	column := func(row []string, name string) string {
+		for i, header := range headers {
+			if strings.EqualFold(strings.TrimSpace(header), name) && i < len(row) {
+				return row[i]
+			}
+		}
+		return ""
+	}
+	nameIdx := nameColumnIndex(fav.Resource)
+	for _, row := range rows {
+		if nameIdx >= len(row) || row[nameIdx] != fav.Name {
+			continue
+		}
+		if nameIdx != 0 && fav.Namespace != "" && row[0] != fav.Namespace {
+			continue
+		}
+		status := column(row, "STATUS")
+		if status == "" {
+			status = "Present"
+		}
+		return favoriteStatus{Status: status, Detail: column(row, "READY")}
+	}
+	return favoriteStatus{Status: "NotFound"}
+}
+
+// openFavorite switches to the favorite's context if needed and shows its
+// view, filtered to the pinned resource
+func (a *App) openFavorite(fav config.Favorite) {
+	a.safeGo("open-favorite", func() {
+		if fav.Context != "" && a.k8s != nil && fav.Context != a.getCurrentContext() {
+			a.switchToContext(fav.Context)
+			if a.getCurrentContext() != fav.Context {
+				return
+			}
+		}
+		a.navigateTo(fav.Resource, fav.Namespace, fav.Name)
+	})
+}
+
+// showFavorites lists the pinned resources and views with their current
+// status (' key or :favorites). Enter jumps to the selected favorite.
+func (a *App) showFavorites() {
+	if a.favorites == nil {
+		a.favorites = &config.FavoritesConfig{}
+	}
+	current := a.getCurrentContext()
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	render := func(statuses []favoriteStatus) {
+		favs := a.favorites.Favorites
+		row, _ := table.GetSelection()
+		table.Clear()
+		table.SetTitle(fmt.Sprintf(" Favorites (%d) [gray](Enter:open d:unpin v:pin current view r:refresh Esc:close)[white] ", len(favs)))
+		for col, header := range favoriteColumns {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorYellow).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+		}
+		if len(favs) == 0 {
+			table.SetCell(1, 0, tview.NewTableCell("[gray]Nothing pinned yet. Press * on a resource to pin it.[white]").SetSelectable(false))
+			return
+		}
+		for i, fav := range favs {
+			status, detail := "...", ""
+			statusColor := tcell.ColorGray
+			if statuses != nil {
+				st := statuses[i]
+				status, detail = st.Status, st.Detail
+				statusColor = a.statusColor(st.Status)
+				switch {
+				case st.Err != nil:
+					status, detail = "Error", st.Err.Error()
+					statusColor = a.statusColor("Error")
+				case fav.Context != "" && fav.Context != current:
+					status = "-"
+				case st.Status == "NotFound":
+					statusColor = a.statusColor("Failed")
+				}
+			}
+			ctxName := fav.Context
+			if ctxName == "" {
+				ctxName = "-"
+			}
+			table.SetCell(i+1, 0, tview.NewTableCell(tview.Escape(fav.String())).SetReference(i))
+			table.SetCell(i+1, 1, tview.NewTableCell(tview.Escape(ctxName)))
+			table.SetCell(i+1, 2, tview.NewTableCell(tview.Escape(status)).SetTextColor(statusColor))
+			table.SetCell(i+1, 3, tview.NewTableCell(tview.Escape(detail)).SetExpansion(1))
+		}
+		table.Select(max(1, min(row, len(favs))), 0)
+	}
+
+	refresh := func() {
+		favs := append([]config.Favorite(nil), a.favorites.Favorites...)
+		a.safeGo("favorites-status", func() {
+			ctx, cancel := context.WithTimeout(a.appCtx, 15*time.Second)
+			defer cancel()
+			statuses := a.loadFavoriteStatuses(ctx, favs)
+			a.QueueUpdateDraw(func() {
+				// Skip results for a list changed while loading
+				if len(statuses) == len(a.favorites.Favorites) {
+					render(statuses)
+				}
+			})
+		})
+	}
+
+	closeView := func() {
+		a.closeModal("favorites")
+		a.SetFocus(a.table)
+	}
+	selected := func() (int, bool) {
+		row, _ := table.GetSelection()
+		cell := table.GetCell(row, 0)
+		if cell == nil {
+			return 0, false
+		}
+		i, ok := cell.GetReference().(int)
+		return i, ok && i < len(a.favorites.Favorites)
+	}
+	save := func() {
+		if err := config.SaveFavorites(a.favorites); err != nil {
+			a.flashMsg(fmt.Sprintf("Failed to save favorites: %v", err), true)
+		}
+		render(nil)
+		refresh()
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeView()
+			return nil
+		case tcell.KeyEnter:
+			if i, ok := selected(); ok {
+				fav := a.favorites.Favorites[i]
+				closeView()
+				a.openFavorite(fav)
+			}
+			return nil
+		case tcell.KeyDelete:
+			if i, ok := selected(); ok {
+				a.favorites.Remove(i)
+				save()
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'd':
+				if i, ok := selected(); ok {
+					a.favorites.Remove(i)
+					save()
+				}
+				return nil
+			case 'v':
+				a.mx.RLock()
+				view := config.Favorite{Context: current, Namespace: a.currentNamespace, Resource: a.currentResource}
+				a.mx.RUnlock()
+				a.favorites.Toggle(view)
+				save()
+				return nil
+			case 'r':
+				render(nil)
+				refresh()
+				return nil
+			case 'q', '\'':
+				closeView()
+				return nil
+			}
+		}
+		return event
+	})
+
+	render(nil)
+	a.showModal("favorites", centered(table, 110, 20), true)
+	a.SetFocus(table)
+	refresh()
+}
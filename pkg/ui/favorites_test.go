@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+func TestFavoriteRowStatus(t *testing.T) {
+	headers := []string{"NAMESPACE", "NAME", "STATUS", "READY", "AGE"}
+	rows := [][]string{
+		{"payments", "api", "Ready", "3/3", "2d"},
+		{"staging", "api", "NotReady", "0/1", "1d"},
+	}
+
+	tests := []struct {
+		name string
+		fav  config.Favorite
+		want favoriteStatus
+	}{
+		{"pinned resource", config.Favorite{Namespace: "staging", Resource: "deployments", Name: "api"}, favoriteStatus{Status: "NotReady", Detail: "0/1"}},
+		{"missing resource", config.Favorite{Namespace: "payments", Resource: "deployments", Name: "web"}, favoriteStatus{Status: "NotFound"}},
+		{"pinned view", config.Favorite{Resource: "deployments"}, favoriteStatus{Detail: "2 items"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := favoriteRowStatus(tt.fav, headers, rows); got != tt.want {
+				t.Errorf("favoriteRowStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	// Resources without a STATUS column are reported as present
+	got := favoriteRowStatus(config.Favorite{Resource: "nodes", Name: "node-1"}, []string{"NAME", "ROLES"}, [][]string{{"node-1", "worker"}})
+	if got.Status != "Present" {
+		t.Errorf("favoriteRowStatus() without STATUS = %+v, want Present", got)
+	}
+}
+
+func TestFavorites_PinAndStatus(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	app := NewTestApp(TestAppConfig{
+		UseSimulationScreen:   true,
+		Screen:                createTestScreen(t),
+		SkipBackgroundLoading: true,
+		SkipBriefing:          true,
+	})
+
+	failing := config.Favorite{Context: "test-context", Namespace: "default", Resource: "pods", Name: "failing-pod"}
+	deploy := config.Favorite{Context: "test-context", Namespace: "default", Resource: "deployments", Name: "nginx-deployment"}
+	other := config.Favorite{Context: "prod-context", Namespace: "default", Resource: "pods", Name: "nginx-pod"}
+	app.pinFavorite(failing)
+	app.pinFavorite(deploy)
+	app.pinFavorite(other)
+
+	saved, err := config.LoadFavorites()
+	if err != nil || len(saved.Favorites) != 3 {
+		t.Fatalf("LoadFavorites() = %+v, %v, want 3 favorites", saved, err)
+	}
+
+	statuses := app.loadFavoriteStatuses(context.Background(), saved.Favorites)
+	if statuses[0].Status != "Failed" {
+		t.Errorf("failing-pod status = %+v, want Failed", statuses[0])
+	}
+	if statuses[1].Status != "Ready" || statuses[1].Detail != "3/3" {
+		t.Errorf("nginx-deployment status = %+v, want Ready 3/3", statuses[1])
+	}
+	if statuses[2] != (favoriteStatus{}) {
+		t.Errorf("favorite in another context = %+v, want no status", statuses[2])
+	}
+
+	// Pinning again unpins
+	app.pinFavorite(deploy)
+	if saved, _ := config.LoadFavorites(); len(saved.Favorites) != 2 {
+		t.Errorf("after unpin = %+v, want 2 favorites", saved.Favorites)
+	}
+}
+
+func TestSelectedFavorite_EmptyTablePinsView(t *testing.T) {
+	app := NewTestApp(TestAppConfig{
+		UseSimulationScreen:   true,
+		Screen:                createTestScreen(t),
+		SkipBackgroundLoading: true,
+		SkipBriefing:          true,
+		InitialResource:       "deployments",
+		InitialNamespace:      "payments",
+	})
+
+	got := app.selectedFavorite()
+	want := config.Favorite{Context: "test-context", Namespace: "payments", Resource: "deployments"}
+	if got != want {
+		t.Errorf("selectedFavorite() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,491 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/helm"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// helmReleases is the part of the Helm client the :helm view uses
+type helmReleases interface {
+	ListReleases(ctx context.Context, namespace string, allNamespaces bool) ([]helm.Release, error)
+	GetReleaseHistory(ctx context.Context, name string, namespace string) ([]helm.ReleaseHistory, error)
+	GetReleaseValues(ctx context.Context, name string, namespace string, allValues bool) (map[string]interface{}, error)
+	GetReleaseManifest(ctx context.Context, name string, namespace string) (string, error)
+	UpdateReleaseValues(ctx context.Context, name string, namespace string, values map[string]interface{}) (*helm.Release, error)
+	RollbackRelease(ctx context.Context, name string, namespace string, revision int) error
+	UninstallRelease(ctx context.Context, name string, namespace string, keepHistory bool) error
+}
+
+// newHelmClient returns the Helm client for a kubeconfig context, reading
+// releases from Helm's storage secrets or configmaps. Tests replace it.
+var newHelmClient = func(kubeContext string) helmReleases {
+	c := helm.NewClient("", "")
+	if kubeContext != "" {
+		c.SetKubeContext(kubeContext)
+	}
+	return c
+}
+
+var (
+	helmReleaseColumns = []string{"NAMESPACE", "NAME", "CHART", "APP VERSION", "STATUS", "REVISION", "UPDATED"}
+	helmHistoryColumns = []string{"REVISION", "STATUS", "CHART", "APP VERSION", "UPDATED", "DESCRIPTION"}
+)
+
+// helmStatusColor colors a release status the way pod phases are colored
+func helmStatusColor(status string) string {
+	switch {
+	case status == "deployed":
+		return "[green]"
+	case status == "failed":
+		return "[red]"
+	case strings.HasPrefix(status, "pending"), status == "uninstalling":
+		return "[yellow]"
+	}
+	return "[gray]"
+}
+
+// helmReleaseRow returns the table cells for one release
+func helmReleaseRow(r helm.Release) []string {
+	return []string{
+		tview.Escape(r.Namespace),
+		tview.Escape(r.Name),
+		tview.Escape(r.Chart),
+		tview.Escape(r.AppVersion),
+		helmStatusColor(r.Status) + r.Status + "[white]",
+		fmt.Sprintf("%d", r.Revision),
+		k8s.FormatAgeSince(r.Updated),
+	}
+}
+
+// helmHistoryRow returns the table cells for one revision
+func helmHistoryRow(h helm.ReleaseHistory) []string {
+	return []string{
+		fmt.Sprintf("%d", h.Revision),
+		helmStatusColor(h.Status) + h.Status + "[white]",
+		tview.Escape(h.Chart),
+		tview.Escape(h.AppVersion),
+		k8s.FormatAgeSince(h.Updated),
+		tview.Escape(h.Description),
+	}
+}
+
+// helmEditor returns the editor command for values, as kubectl edit picks it
+func helmEditor() []string {
+	for _, env := range []string{"KUBE_EDITOR", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// showHelmReleases lists the Helm releases of the current namespace, or of
+// all namespaces (:helm). Enter or v shows the release's values, m its
+// manifest, e edits its values and upgrades it, h lists its revisions to
+// roll back to, and u uninstalls it.
+func (a *App) showHelmReleases() {
+	a.mx.RLock()
+	ns := a.currentNamespace
+	a.mx.RUnlock()
+	client := newHelmClient(a.getCurrentContext())
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	scope := ns
+	if scope == "" {
+		scope = "all namespaces"
+	}
+	render := func(releases []helm.Release, err error) {
+		selected, _ := table.GetSelection()
+		table.Clear()
+		table.SetTitle(fmt.Sprintf(" Helm Releases: %s (%d) [gray](Enter/v:values m:manifest e:edit h:history u:uninstall r:refresh Esc:close)[white] ",
+			tview.Escape(scope), len(releases)))
+		for col, header := range helmReleaseColumns {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorYellow).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+		}
+		switch {
+		case err != nil:
+			table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("[red]Failed to list Helm releases: %s[white]", tview.Escape(err.Error()))).SetSelectable(false))
+			return
+		case len(releases) == 0:
+			table.SetCell(1, 0, tview.NewTableCell("[gray]No Helm releases found[white]").SetSelectable(false))
+			return
+		}
+		for i, r := range releases {
+			for col, text := range helmReleaseRow(r) {
+				table.SetCell(i+1, col, tview.NewTableCell(text).SetReference(r))
+			}
+		}
+		table.Select(min(max(selected, 1), len(releases)), 0)
+	}
+
+	refresh := func() {
+		a.safeGo("helm-releases", func() {
+			ctx, cancel := context.WithTimeout(a.getAppContext(), 15*time.Second)
+			defer cancel()
+			releases, err := client.ListReleases(ctx, ns, ns == "")
+			a.QueueUpdateDraw(func() { render(releases, err) })
+		})
+	}
+
+	selected := func() (helm.Release, bool) {
+		row, _ := table.GetSelection()
+		r, ok := table.GetCell(row, 0).GetReference().(helm.Release)
+		return r, ok
+	}
+
+	// showText opens values or the manifest over the list
+	showText := func(kind string, r helm.Release, fetch func(ctx context.Context) (string, error)) {
+		viewer := NewVimViewer(a, "helm-text", fmt.Sprintf(" Helm %s: %s/%s [gray](Esc:close /search n/N:next/prev)[white] ",
+			kind, tview.Escape(r.Namespace), tview.Escape(r.Name)))
+		viewer.back = table
+		viewer.SetContent("[yellow]Loading...[white]")
+		a.showModal("helm-text", viewer, true)
+		a.SetFocus(viewer)
+		a.safeGo("helm-text", func() {
+			ctx, cancel := context.WithTimeout(a.getAppContext(), 15*time.Second)
+			defer cancel()
+			text, err := fetch(ctx)
+			if err == nil {
+				a.recordTUIRead("helm-"+strings.ToLower(kind), "helm", r.Namespace, r.Name)
+			}
+			a.QueueUpdateDraw(func() {
+				if err != nil {
+					viewer.SetContent(fmt.Sprintf("[red]Error: %v", err))
+					return
+				}
+				if strings.TrimSpace(text) == "" {
+					text = "# (empty)"
+				}
+				viewer.SetContent(text)
+			})
+		})
+	}
+	showValues := func() {
+		r, ok := selected()
+		if !ok {
+			return
+		}
+		showText("Values", r, func(ctx context.Context) (string, error) {
+			values, err := client.GetReleaseValues(ctx, r.Name, r.Namespace, false)
+			if err != nil {
+				return "", err
+			}
+			return helm.ValuesToYAML(values)
+		})
+	}
+
+	closeView := func() {
+		a.closeModal("helm-releases")
+		a.SetFocus(a.table)
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeView()
+			return nil
+		case tcell.KeyEnter:
+			showValues()
+			return nil
+		case tcell.KeyCtrlD:
+			if r, ok := selected(); ok {
+				a.uninstallHelmRelease(client, r, table, refresh)
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'v':
+				showValues()
+			case 'm':
+				if r, ok := selected(); ok {
+					showText("Manifest", r, func(ctx context.Context) (string, error) {
+						return client.GetReleaseManifest(ctx, r.Name, r.Namespace)
+					})
+				}
+			case 'e':
+				if r, ok := selected(); ok {
+					a.editHelmValues(client, r, table, refresh)
+				}
+			case 'h':
+				if r, ok := selected(); ok {
+					a.showHelmHistory(client, r, table, refresh)
+				}
+			case 'u':
+				if r, ok := selected(); ok {
+					a.uninstallHelmRelease(client, r, table, refresh)
+				}
+			case 'r':
+				refresh()
+			case 'q':
+				closeView()
+			default:
+				return event
+			}
+			return nil
+		}
+		return event
+	})
+
+	render(nil, nil)
+	table.SetCell(1, 0, tview.NewTableCell("[gray]Loading Helm releases...[white]").SetSelectable(false))
+	a.showModal("helm-releases", centered(table, 150, 30), true)
+	a.SetFocus(table)
+	refresh()
+}
+
+// showHelmHistory lists the revisions of a release; Enter rolls back to the
+// selected one after confirmation
+func (a *App) showHelmHistory(client helmReleases, r helm.Release, back tview.Primitive, done func()) {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Helm History: %s/%s [gray](Enter:rollback Esc:back)[white] ", tview.Escape(r.Namespace), tview.Escape(r.Name))).
+		SetTitleAlign(tview.AlignLeft)
+	for col, header := range helmHistoryColumns {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+	}
+	table.SetCell(1, 0, tview.NewTableCell("[gray]Loading revisions...[white]").SetSelectable(false))
+
+	closeView := func() {
+		a.closeModal("helm-history")
+		a.SetFocus(back)
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEsc, event.Key() == tcell.KeyRune && event.Rune() == 'q':
+			closeView()
+			return nil
+		case event.Key() == tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			h, ok := table.GetCell(row, 0).GetReference().(helm.ReleaseHistory)
+			if !ok {
+				return nil
+			}
+			if h.Revision == r.Revision {
+				a.flashMsg(fmt.Sprintf("%s is already at revision %d", r.Name, h.Revision), true)
+				return nil
+			}
+			a.rollbackHelmRelease(client, r, h.Revision, table, func() {
+				closeView()
+				done()
+			})
+			return nil
+		}
+		return event
+	})
+
+	a.showModal("helm-history", centered(table, 140, 18), true)
+	a.SetFocus(table)
+
+	a.safeGo("helm-history", func() {
+		ctx, cancel := context.WithTimeout(a.getAppContext(), 15*time.Second)
+		defer cancel()
+		history, err := client.GetReleaseHistory(ctx, r.Name, r.Namespace)
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("[red]Failed to load history: %s[white]", tview.Escape(err.Error()))).SetSelectable(false))
+				return
+			}
+			// Newest revision first
+			for i := range history {
+				h := history[len(history)-1-i]
+				for col, text := range helmHistoryRow(h) {
+					cell := tview.NewTableCell(text).SetReference(h)
+					if col == len(helmHistoryColumns)-1 {
+						cell.SetExpansion(1)
+					}
+					table.SetCell(i+1, col, cell)
+				}
+			}
+			if len(history) > 0 {
+				table.Select(1, 0)
+			}
+		})
+	})
+}
+
+// rollbackHelmRelease rolls r back to revision after confirmation and
+// records the outcome in the audit log
+func (a *App) rollbackHelmRelease(client helmReleases, r helm.Release, revision int, back tview.Primitive, done func()) {
+	if !a.checkTUIPermission("helm", "edit") {
+		return
+	}
+	target := fmt.Sprintf("helm/%s/%s", r.Namespace, r.Name)
+	a.confirmChange(changeConfirm{
+		id:    "helm-rollback-confirm",
+		text:  fmt.Sprintf("Roll back Helm release %s in %s from revision %d to %d?", r.Name, r.Namespace, r.Revision, revision),
+		label: "Rollback",
+		name:  r.Name,
+		back:  back,
+		run: func() {
+			a.flashMsg(fmt.Sprintf("Rolling back %s to revision %d...", r.Name, revision), false)
+			a.safeGo("helm-rollback", func() {
+				ctx, cancel := context.WithTimeout(a.getAppContext(), 6*time.Minute)
+				defer cancel()
+				details := fmt.Sprintf("Rolled back Helm release %s from revision %d to %d", r.Name, r.Revision, revision)
+				if err := client.RollbackRelease(ctx, r.Name, r.Namespace, revision); err != nil {
+					a.recordTUIAudit("helm_rollback", target, details, false, err.Error())
+					a.QueueUpdateDraw(func() { a.flashMsg(fmt.Sprintf("Rollback failed: %v", err), true) })
+					return
+				}
+				a.recordTUIAudit("helm_rollback", target, details, true, "")
+				a.QueueUpdateDraw(func() {
+					a.flashMsg(fmt.Sprintf("Rolled back %s to revision %d", r.Name, revision), false)
+					done()
+				})
+			})
+		},
+	})
+}
+
+// uninstallHelmRelease uninstalls r after confirmation, which even relaxed
+// contexts ask for since it deletes every resource of the release
+func (a *App) uninstallHelmRelease(client helmReleases, r helm.Release, back tview.Primitive, done func()) {
+	if !a.checkTUIPermission("helm", "delete") {
+		return
+	}
+	target := fmt.Sprintf("helm/%s/%s", r.Namespace, r.Name)
+	a.confirmChange(changeConfirm{
+		id:     "helm-uninstall-confirm",
+		text:   fmt.Sprintf("Uninstall Helm release %s in %s?\n\nThis deletes all of its resources.", r.Name, r.Namespace),
+		label:  "Uninstall",
+		name:   r.Name,
+		danger: true,
+		always: true,
+		back:   back,
+		run: func() {
+			a.safeGo("helm-uninstall", func() {
+				ctx, cancel := context.WithTimeout(a.getAppContext(), 5*time.Minute)
+				defer cancel()
+				details := fmt.Sprintf("Uninstalled Helm release %s (%s)", r.Name, r.Chart)
+				if err := client.UninstallRelease(ctx, r.Name, r.Namespace, false); err != nil {
+					a.recordTUIAudit("helm_uninstall", target, details, false, err.Error())
+					a.QueueUpdateDraw(func() { a.flashMsg(fmt.Sprintf("Uninstall failed: %v", err), true) })
+					return
+				}
+				a.recordTUIAudit("helm_uninstall", target, details, true, "")
+				a.QueueUpdateDraw(func() {
+					a.flashMsg(fmt.Sprintf("Uninstalled %s", r.Name), false)
+					done()
+				})
+			})
+		},
+	})
+}
+
+// editHelmValues opens the release's user-supplied values in the editor and,
+// when they changed, upgrades the release to a new revision of the same
+// chart with them after confirmation
+func (a *App) editHelmValues(client helmReleases, r helm.Release, back tview.Primitive, done func()) {
+	if !a.checkTUIPermission("helm", "edit") {
+		return
+	}
+	ctx, cancel := context.WithTimeout(a.getAppContext(), 15*time.Second)
+	values, err := client.GetReleaseValues(ctx, r.Name, r.Namespace, false)
+	cancel()
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to get values: %v", err), true)
+		return
+	}
+	original, err := helm.ValuesToYAML(values)
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to render values: %v", err), true)
+		return
+	}
+
+	file, err := os.CreateTemp("", fmt.Sprintf("k13d-helm-%s-*.yaml", r.Name))
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to create temp file: %v", err), true)
+		return
+	}
+	path := file.Name()
+	defer os.Remove(path)
+	header := fmt.Sprintf("# Values of Helm release %s/%s (%s, revision %d).\n# Saving a change upgrades the release with these values.\n", r.Namespace, r.Name, r.Chart, r.Revision)
+	_, err = file.WriteString(header + original)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to write values: %v", err), true)
+		return
+	}
+
+	editor := helmEditor()
+	a.safeSuspend(func() {
+		cmd := exec.Command(editor[0], append(editor[1:], path)...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "\nEditor failed: %v\nPress Enter to return...\n", err)
+			_, _ = bufio.NewReader(os.Stdin).ReadString('\n')
+		}
+	})
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Failed to read values: %v", err), true)
+		return
+	}
+	newValues, err := helm.YAMLToValues(string(edited))
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Invalid values YAML, release not changed: %v", err), true)
+		return
+	}
+	if updated, _ := helm.ValuesToYAML(newValues); updated == original {
+		a.flashMsg("Values not changed", false)
+		return
+	}
+
+	target := fmt.Sprintf("helm/%s/%s", r.Namespace, r.Name)
+	a.confirmChange(changeConfirm{
+		id:    "helm-upgrade-confirm",
+		text:  fmt.Sprintf("Upgrade Helm release %s in %s with the edited values?\n\nThis creates revision %d of %s.", r.Name, r.Namespace, r.Revision+1, r.Chart),
+		label: "Upgrade",
+		name:  r.Name,
+		back:  back,
+		run: func() {
+			a.flashMsg(fmt.Sprintf("Upgrading %s...", r.Name), false)
+			a.safeGo("helm-upgrade", func() {
+				ctx, cancel := context.WithTimeout(a.getAppContext(), 6*time.Minute)
+				defer cancel()
+				details := fmt.Sprintf("Upgraded Helm release %s with edited values", r.Name)
+				upgraded, err := client.UpdateReleaseValues(ctx, r.Name, r.Namespace, newValues)
+				if err != nil {
+					a.recordTUIAudit("helm_upgrade", target, details, false, err.Error())
+					a.QueueUpdateDraw(func() { a.flashMsg(fmt.Sprintf("Upgrade failed: %v", err), true) })
+					return
+				}
+				a.recordTUIAudit("helm_upgrade", target, fmt.Sprintf("%s (revision %d)", details, upgraded.Revision), true, "")
+				a.QueueUpdateDraw(func() {
+					a.flashMsg(fmt.Sprintf("Upgraded %s to revision %d", r.Name, upgraded.Revision), false)
+					done()
+				})
+			})
+		},
+	})
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/helm"
+)
+
+// fakeHelm records the changes the :helm view asks for
+type fakeHelm struct {
+	mu          sync.Mutex
+	rolledBack  int
+	uninstalled string
+}
+
+func (f *fakeHelm) ListReleases(ctx context.Context, namespace string, allNamespaces bool) ([]helm.Release, error) {
+	return nil, nil
+}
+func (f *fakeHelm) GetReleaseHistory(ctx context.Context, name string, namespace string) ([]helm.ReleaseHistory, error) {
+	return nil, nil
+}
+func (f *fakeHelm) GetReleaseValues(ctx context.Context, name string, namespace string, allValues bool) (map[string]interface{}, error) {
+	return nil, nil
+}
+func (f *fakeHelm) GetReleaseManifest(ctx context.Context, name string, namespace string) (string, error) {
+	return "", nil
+}
+func (f *fakeHelm) UpdateReleaseValues(ctx context.Context, name string, namespace string, values map[string]interface{}) (*helm.Release, error) {
+	return &helm.Release{Name: name, Namespace: namespace}, nil
+}
+func (f *fakeHelm) RollbackRelease(ctx context.Context, name string, namespace string, revision int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rolledBack = revision
+	return nil
+}
+func (f *fakeHelm) UninstallRelease(ctx context.Context, name string, namespace string, keepHistory bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.uninstalled = name
+	return nil
+}
+
+func TestHelmReleaseRow(t *testing.T) {
+	row := helmReleaseRow(helm.Release{
+		Namespace:  "payments",
+		Name:       "api",
+		Chart:      "web-1.2.0",
+		AppVersion: "2.0",
+		Status:     "failed",
+		Revision:   4,
+		Updated:    time.Now().Add(-2 * time.Hour),
+	})
+	if len(row) != len(helmReleaseColumns) {
+		t.Fatalf("got %d cells, want %d", len(row), len(helmReleaseColumns))
+	}
+	if row[2] != "web-1.2.0" || row[4] != "[red]failed[white]" || row[5] != "4" || row[6] != "2h" {
+		t.Errorf("helmReleaseRow() = %q", row)
+	}
+	if got := helmStatusColor("pending-upgrade"); got != "[yellow]" {
+		t.Errorf("helmStatusColor(pending-upgrade) = %q, want yellow", got)
+	}
+
+	t.Setenv("KUBE_EDITOR", "")
+	t.Setenv("EDITOR", "code --wait")
+	if got := helmEditor(); strings.Join(got, " ") != "code --wait" {
+		t.Errorf("helmEditor() = %q, want the EDITOR command", got)
+	}
+}
+
+func TestHelmReleaseActions(t *testing.T) {
+	app := NewTestApp(TestAppConfig{SkipBackgroundLoading: true, SkipBriefing: true})
+	app.config = &config.Config{Contexts: config.ContextsConfig{
+		Safety: []config.ContextSafety{{Contexts: []string{"*"}, Level: config.SafetyRelaxed}},
+	}}
+	client := &fakeHelm{}
+	r := helm.Release{Namespace: "payments", Name: "api", Chart: "web-1.2.0", Revision: 4}
+
+	// Relaxed contexts roll back without asking
+	done := make(chan struct{})
+	app.rollbackHelmRelease(client, r, 2, nil, func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("rollback did not finish")
+	}
+	client.mu.Lock()
+	if client.rolledBack != 2 {
+		t.Errorf("rolled back to revision %d, want 2", client.rolledBack)
+	}
+	client.mu.Unlock()
+
+	// Uninstalling still asks
+	app.uninstallHelmRelease(client, r, nil, func() {})
+	if !hasTestPage(app, "helm-uninstall-confirm") {
+		t.Error("uninstall should ask for confirmation even in a relaxed context")
+	}
+	client.mu.Lock()
+	if client.uninstalled != "" {
+		t.Errorf("uninstalled %q before confirmation", client.uninstalled)
+	}
+	client.mu.Unlock()
+
+	found := false
+	for _, c := range commands {
+		found = found || c.name == "helm"
+	}
+	if !found {
+		t.Error(":helm is missing from the command list")
+	}
+}
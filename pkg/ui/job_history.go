@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var jobHistoryColumns = []string{"JOB", "STATUS", "COMPLETIONS", "STARTED", "DURATION", "EXIT INFO"}
+
+// jobHistoryRow returns the table cells for one run
+func jobHistoryRow(run k8s.JobRun) []string {
+	status := map[string]string{
+		k8s.JobRunComplete:  "[green]",
+		k8s.JobRunFailed:    "[red]",
+		k8s.JobRunRunning:   "[yellow]",
+		k8s.JobRunSuspended: "[gray]",
+	}[run.Status] + run.Status + "[white]"
+
+	started, duration := "-", "-"
+	if !run.StartTime.IsZero() {
+		started = k8s.FormatAgeSince(run.StartTime) + " ago"
+	}
+	if run.Duration > 0 {
+		duration = k8s.FormatAge(run.Duration)
+		if run.EndTime.IsZero() {
+			duration += " (running)"
+		}
+	}
+
+	return []string{
+		tview.Escape(run.Name),
+		status,
+		fmt.Sprintf("%d/%d", run.Succeeded, run.Completions),
+		started,
+		duration,
+		tview.Escape(run.ExitInfo),
+	}
+}
+
+// showCronJobHistory lists the Jobs a CronJob created, matched by owner
+// reference, with their outcome and exit details. Enter opens the merged
+// logs of the selected job's pods and p lists its pods.
+func (a *App) showCronJobHistory(ns, name string) {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	render := func(runs []k8s.JobRun, err error) {
+		table.Clear()
+		failed := 0
+		for _, run := range runs {
+			if run.Status == k8s.JobRunFailed {
+				failed++
+			}
+		}
+		table.SetTitle(fmt.Sprintf(" CronJob History: %s/%s (%d runs, %d failed) [gray](Enter:logs p:pods r:refresh Esc:close)[white] ",
+			ns, name, len(runs), failed))
+		for col, header := range jobHistoryColumns {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorYellow).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+		}
+		switch {
+		case err != nil:
+			table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("[red]Failed to load job history: %s[white]", tview.Escape(err.Error()))).SetSelectable(false))
+			return
+		case len(runs) == 0:
+			table.SetCell(1, 0, tview.NewTableCell("[gray]No jobs found for this cronjob[white]").SetSelectable(false))
+			return
+		}
+		for i, run := range runs {
+			for col, text := range jobHistoryRow(run) {
+				cell := tview.NewTableCell(text).SetReference(run.Name)
+				if col == len(jobHistoryColumns)-1 {
+					cell.SetExpansion(1)
+				}
+				table.SetCell(i+1, col, cell)
+			}
+		}
+		table.Select(1, 0)
+	}
+
+	refresh := func() {
+		a.safeGo("cronjob-history", func() {
+			if a.k8s == nil {
+				a.QueueUpdateDraw(func() { render(nil, fmt.Errorf("not connected to a cluster")) })
+				return
+			}
+			ctx, cancel := context.WithTimeout(a.appCtx, 15*time.Second)
+			defer cancel()
+			runs, err := a.k8s.GetCronJobHistory(ctx, ns, name)
+			a.QueueUpdateDraw(func() { render(runs, err) })
+		})
+	}
+
+	closeView := func() {
+		a.closeModal("cronjob-history")
+		a.SetFocus(a.table)
+	}
+	selectedJob := func() string {
+		row, _ := table.GetSelection()
+		job, _ := table.GetCell(row, 0).GetReference().(string)
+		return job
+	}
+	// The log viewer returns focus to the main table, so the history closes
+	// first rather than staying behind it
+	showLogs := func() {
+		if job := selectedJob(); job != "" {
+			closeView()
+			a.showWorkloadLogs(ns, job, "jobs", k8s.WorkloadLogOptions{TailLines: 100})
+		}
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeView()
+			return nil
+		case tcell.KeyEnter:
+			showLogs()
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'l':
+				showLogs()
+				return nil
+			case 'p':
+				if job := selectedJob(); job != "" {
+					closeView()
+					a.navigateTo("pods", ns, "job-name="+job)
+				}
+				return nil
+			case 'r':
+				refresh()
+				return nil
+			case 'q':
+				closeView()
+				return nil
+			}
+		}
+		return event
+	})
+
+	render(nil, nil)
+	table.SetCell(1, 0, tview.NewTableCell("[gray]Loading job history...[white]").SetSelectable(false))
+	a.showModal("cronjob-history", centered(table, 150, 25), true)
+	a.SetFocus(table)
+	refresh()
+}
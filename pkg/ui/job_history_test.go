@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+func TestJobHistoryRow(t *testing.T) {
+	run := k8s.JobRun{
+		Name:        "backup-2",
+		Status:      k8s.JobRunFailed,
+		StartTime:   time.Now().Add(-2 * time.Hour),
+		EndTime:     time.Now().Add(-2*time.Hour + 90*time.Second),
+		Duration:    90 * time.Second,
+		Completions: 1,
+		Failed:      3,
+		ExitInfo:    "BackoffLimitExceeded; main exited 1 (Error)",
+	}
+
+	want := []string{"backup-2", "[red]Failed[white]", "0/1", "2h ago", "1m", "BackoffLimitExceeded; main exited 1 (Error)"}
+	got := jobHistoryRow(run)
+	if len(got) != len(jobHistoryColumns) {
+		t.Fatalf("jobHistoryRow() returned %d cells, want %d", len(got), len(jobHistoryColumns))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s = %q, want %q", jobHistoryColumns[i], got[i], want[i])
+		}
+	}
+
+	run.Status, run.EndTime, run.Duration = k8s.JobRunRunning, time.Time{}, 5*time.Minute
+	if got := jobHistoryRow(run); got[4] != "5m (running)" {
+		t.Errorf("running duration = %q, want 5m (running)", got[4])
+	}
+}
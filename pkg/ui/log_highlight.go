@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+// defaultLogHighlightColor colors custom patterns without a color
+const defaultLogHighlightColor = "aqua"
+
+// searchHighlightColor marks search matches; it wins over log colors
+const searchHighlightColor = "black:yellow"
+
+// builtinLogHighlights color common severity tokens, HTTP 5xx statuses, and
+// stack trace lines. Earlier rules win where matches overlap.
+var builtinLogHighlights = []config.LogHighlightPattern{
+	{Pattern: `(?i)\b(?:fatal|panic|critical|crit|emerg(?:ency)?)\b|^F\d{4}\b`, Color: "red::b"},
+	{Pattern: `(?i)\b(?:error|err|exception)\b|^E\d{4}\b`, Color: "red"},
+	{Pattern: `(?i)\b(?:warn|warning)\b|^W\d{4}\b`, Color: "yellow"},
+	{Pattern: `HTTP/[\d.]+"? +5\d\d\b|(?i)\bstatus(?:_?code)?["=: ]+5\d\d\b`, Color: "red"},
+	{Pattern: `(?:\bat [\w$.<>/]+\(.*|\bFile ".+", line \d+.*|\bgoroutine \d+ \[.*|\S+\.go:\d+ \+0x[0-9a-f]+|Traceback \(most recent call last\):.*|\bCaused by: .*)$`, Color: "fuchsia"},
+}
+
+// logHighlightRule is one compiled highlight pattern
+type logHighlightRule struct {
+	re    *regexp.Regexp
+	color string
+}
+
+// logHighlighter adds tview color tags to log lines
+type logHighlighter struct {
+	rules []logHighlightRule
+}
+
+// newLogHighlighter compiles the custom patterns from cfg followed by the
+// built-in ones. Invalid custom patterns are skipped and returned as errors.
+func newLogHighlighter(cfg config.LogHighlightConfig) (*logHighlighter, []error) {
+	h := &logHighlighter{}
+	var errs []error
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid log highlight pattern %q: %w", p.Pattern, err))
+			continue
+		}
+		color := p.Color
+		if color == "" {
+			color = defaultLogHighlightColor
+		}
+		h.rules = append(h.rules, logHighlightRule{re: re, color: color})
+	}
+	for _, p := range builtinLogHighlights {
+		h.rules = append(h.rules, logHighlightRule{re: regexp.MustCompile(p.Pattern), color: p.Color})
+	}
+	return h, errs
+}
+
+// highlight colors content line by line. Matches of search, if set, are
+// marked first so they stay visible on colored text.
+func (h *logHighlighter) highlight(content string, search *regexp.Regexp) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = h.highlightLine(line, search)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// logSpan is a colored byte range of a line
+type logSpan struct {
+	start, end int
+	color      string
+}
+
+func (h *logHighlighter) highlightLine(line string, search *regexp.Regexp) string {
+	if line == "" {
+		return line
+	}
+
+	var spans []logSpan
+	add := func(re *regexp.Regexp, color string) {
+		for _, m := range re.FindAllStringIndex(line, -1) {
+			if m[0] == m[1] {
+				continue
+			}
+			overlaps := false
+			for _, s := range spans {
+				if m[0] < s.end && s.start < m[1] {
+					overlaps = true
+					break
+				}
+			}
+			if !overlaps {
+				spans = append(spans, logSpan{m[0], m[1], color})
+			}
+		}
+	}
+	if search != nil {
+		add(search, searchHighlightColor)
+	}
+	for _, r := range h.rules {
+		add(r.re, r.color)
+	}
+	if len(spans) == 0 {
+		return line
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	var b strings.Builder
+	pos := 0
+	for _, s := range spans {
+		b.WriteString(line[pos:s.start])
+		b.WriteString("[" + s.color + "]" + line[s.start:s.end] + "[-:-:-]")
+		pos = s.end
+	}
+	b.WriteString(line[pos:])
+	return b.String()
+}
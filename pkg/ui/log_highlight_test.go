@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestLogHighlighter_Builtins(t *testing.T) {
+	h, errs := newLogHighlighter(config.LogHighlightConfig{})
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"error level", "2024-01-01 level=error msg=boom", "2024-01-01 level=[red]error[-:-:-] msg=boom"},
+		{"bracketed error", "[ERROR] failed to connect", "[[red]ERROR[-:-:-]] failed to connect"},
+		{"warning", "WARN disk almost full", "[yellow]WARN[-:-:-] disk almost full"},
+		{"fatal", "FATAL: out of memory", "[red::b]FATAL[-:-:-]: out of memory"},
+		{"klog error", "E0612 10:00:00.000 1 reflector.go:1] list failed", "[red]E0612[-:-:-] 10:00:00.000 1 reflector.go:1] list failed"},
+		{"http 5xx", `10.0.0.1 - - "GET /api HTTP/1.1" 503 12`, `10.0.0.1 - - "GET /api [red]HTTP/1.1" 503[-:-:-] 12`},
+		{"http 2xx", `10.0.0.1 - - "GET /api HTTP/1.1" 200 12`, `10.0.0.1 - - "GET /api HTTP/1.1" 200 12`},
+		{"status field", "request done status=500", "request done [red]status=500[-:-:-]"},
+		{"java frame", "    at com.example.Foo.bar(Foo.java:42)", "    [fuchsia]at com.example.Foo.bar(Foo.java:42)[-:-:-]"},
+		{"python frame", `  File "/app/main.py", line 7, in <module>`, `  [fuchsia]File "/app/main.py", line 7, in <module>[-:-:-]`},
+		{"go frame", "\t/app/main.go:42 +0x1d", "\t[fuchsia]/app/main.go:42 +0x1d[-:-:-]"},
+		{"plain", "server started on :8080", "server started on :8080"},
+		{"stderr is not err", "writing to stderr", "writing to stderr"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.highlightLine(tt.line, nil); got != tt.want {
+				t.Errorf("highlightLine(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogHighlighter_CustomPatternsAndSearch(t *testing.T) {
+	h, errs := newLogHighlighter(config.LogHighlightConfig{Patterns: []config.LogHighlightPattern{
+		{Pattern: `OOMKilled`, Color: "orange"},
+		{Pattern: `req-\d+`},
+		{Pattern: `[bad`},
+	}})
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "[bad") {
+		t.Errorf("errors = %v, want the invalid pattern reported", errs)
+	}
+
+	got := h.highlightLine("error: OOMKilled req-42", nil)
+	want := "[red]error[-:-:-]: [orange]OOMKilled[-:-:-] [aqua]req-42[-:-:-]"
+	if got != want {
+		t.Errorf("highlightLine() = %q, want %q", got, want)
+	}
+
+	// Search matches win over log colors
+	got = h.highlight("an error here\nok", regexp.MustCompile(`(?i)error here`))
+	if got != "an [black:yellow]error here[-:-:-]\nok" {
+		t.Errorf("highlight() with search = %q", got)
+	}
+}
+
+func TestVimViewer_ColorToggle(t *testing.T) {
+	v := NewVimViewer(nil, "logs", " Logs ")
+	v.isLogView = true
+	v.enableLogHighlight(&config.Config{})
+	v.SetContent("ERROR boom")
+
+	if !v.colorLogs || !strings.Contains(v.GetText(false), "[red]ERROR") {
+		t.Fatalf("log view should start colored, got %q", v.GetText(false))
+	}
+	v.updateTitle()
+	if !strings.Contains(v.TextView.GetTitle(), "color") {
+		t.Errorf("title = %q, want the color flag", v.TextView.GetTitle())
+	}
+
+	v.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone), func(tview.Primitive) {})
+	if v.colorLogs || v.GetText(false) != "ERROR boom" {
+		t.Errorf("c should turn colors off, got %q", v.GetText(false))
+	}
+
+	// Disabled in config starts without colors
+	v = NewVimViewer(nil, "logs", " Logs ")
+	v.isLogView = true
+	v.enableLogHighlight(&config.Config{LogHighlight: config.LogHighlightConfig{Disabled: true}})
+	v.SetContent("ERROR boom")
+	if v.colorLogs || v.GetText(false) != "ERROR boom" {
+		t.Errorf("disabled highlighting should show plain text, got %q", v.GetText(false))
+	}
+}
@@ -0,0 +1,42 @@
+package ui
+
+// trackModal records a modal page as open or closed. When the last modal
+// closes and a watch refresh was held back meanwhile, the table refreshes
+// once.
+func (a *App) trackModal(name string, open bool) {
+	a.modalMu.Lock()
+	if open {
+		if a.openModals == nil {
+			a.openModals = make(map[string]bool)
+		}
+		a.openModals[name] = true
+		a.modalMu.Unlock()
+		return
+	}
+	delete(a.openModals, name)
+	resume := len(a.openModals) == 0 && a.refreshDeferred
+	if resume {
+		a.refreshDeferred = false
+	}
+	a.modalMu.Unlock()
+
+	if resume {
+		a.safeGo("deferred-refresh", func() { a.refresh() })
+	}
+}
+
+// deferRefreshForModal reports whether a watch refresh should wait because
+// a modal is open and pause_refresh_in_modals is set, and remembers to run
+// it when the modal closes
+func (a *App) deferRefreshForModal() bool {
+	if a.config == nil || !a.config.PauseRefreshInModals {
+		return false
+	}
+	a.modalMu.Lock()
+	defer a.modalMu.Unlock()
+	if len(a.openModals) == 0 {
+		return false
+	}
+	a.refreshDeferred = true
+	return true
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+func TestDeferRefreshForModal(t *testing.T) {
+	app := NewTestApp(TestAppConfig{
+		UseSimulationScreen:   true,
+		Screen:                createTestScreen(t),
+		SkipBackgroundLoading: true,
+		SkipBriefing:          true,
+	})
+
+	if app.deferRefreshForModal() {
+		t.Fatal("refresh should not wait without an open modal")
+	}
+
+	app.showModal("logs", tview.NewTextView(), true)
+	app.showModal("describe", tview.NewTextView(), true)
+	if !app.deferRefreshForModal() {
+		t.Fatal("refresh should wait while a modal is open")
+	}
+
+	app.closeModal("describe")
+	app.modalMu.Lock()
+	deferred := app.refreshDeferred
+	app.modalMu.Unlock()
+	if !deferred {
+		t.Error("the held-back refresh should wait for the last modal to close")
+	}
+
+	app.closeModal("logs")
+	app.modalMu.Lock()
+	deferred = app.refreshDeferred
+	app.modalMu.Unlock()
+	if deferred {
+		t.Error("closing the last modal should run the held-back refresh")
+	}
+
+	app.config.PauseRefreshInModals = false
+	app.showModal("logs", tview.NewTextView(), true)
+	if app.deferRefreshForModal() {
+		t.Error("refresh should not wait when pause_refresh_in_modals is off")
+	}
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+// currentNamespaceRegex returns the regex limiting all-namespace views
+// (--namespace-regex or :nsre), or nil
+func (a *App) currentNamespaceRegex() *regexp.Regexp {
+	a.mx.RLock()
+	defer a.mx.RUnlock()
+	return a.namespaceRegex
+}
+
+// filterNamespacesByRegex keeps the namespaces matching re. The "" (all
+// namespaces) entry is always kept.
+func filterNamespacesByRegex(re *regexp.Regexp, namespaces []string) []string {
+	if re == nil {
+		return namespaces
+	}
+	matching := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		if ns == "" || re.MatchString(ns) {
+			matching = append(matching, ns)
+		}
+	}
+	return matching
+}
+
+// filterNamespaceRegexRows drops rows of an all-namespaces listing whose
+// NAMESPACE column does not match re. Cluster-scoped listings have no
+// NAMESPACE column and are returned as is.
+func filterNamespaceRegexRows(re *regexp.Regexp, headers []string, rows [][]string) [][]string {
+	if re == nil || len(headers) == 0 || headers[0] != "NAMESPACE" {
+		return rows
+	}
+	matching := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		if len(row) > 0 && !re.MatchString(row[0]) {
+			continue
+		}
+		matching = append(matching, row)
+	}
+	return matching
+}
+
+// setNamespaceRegex limits all-namespace views to the namespaces matching
+// expr (:nsre <regex>); an empty expr shows every namespace again. An
+// explicit :ns always works.
+func (a *App) setNamespaceRegex(expr string) {
+	re, err := config.CompileNamespaceRegex(expr)
+	if err != nil {
+		a.flashMsg(err.Error(), true)
+		return
+	}
+	a.mx.Lock()
+	a.namespaceRegex = re
+	a.mx.Unlock()
+
+	if re == nil {
+		a.flashMsg("Showing all namespaces", false)
+	} else {
+		a.flashMsg(fmt.Sprintf("Showing namespaces matching %s", re), false)
+	}
+	a.updateHeader()
+	a.safeGo("nsre-refresh", a.refresh)
+}
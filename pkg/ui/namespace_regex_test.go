@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestFilterNamespaceRegex(t *testing.T) {
+	re := regexp.MustCompile(`^team-.*-prod$`)
+
+	got := filterNamespacesByRegex(re, []string{"", "default", "team-a-prod", "team-a-staging"})
+	if len(got) != 2 || got[0] != "" || got[1] != "team-a-prod" {
+		t.Errorf("filterNamespacesByRegex() = %q, want [\"\" team-a-prod]", got)
+	}
+
+	rows := [][]string{{"team-a-prod", "web"}, {"team-a-staging", "web"}}
+	if got := filterNamespaceRegexRows(re, []string{"NAMESPACE", "NAME"}, rows); len(got) != 1 || got[0][0] != "team-a-prod" {
+		t.Errorf("filterNamespaceRegexRows() = %q, want only team-a-prod/web", got)
+	}
+	nodeRows := [][]string{{"node-1", "Ready"}}
+	if got := filterNamespaceRegexRows(re, []string{"NAME", "STATUS"}, nodeRows); len(got) != 1 {
+		t.Errorf("cluster-scoped rows filtered: %q", got)
+	}
+}
+
+func TestSetNamespaceRegex(t *testing.T) {
+	app := NewTestApp(TestAppConfig{
+		SkipBackgroundLoading: true,
+		SkipBriefing:          true,
+	})
+	namespaces := []string{"", "default", "team-a-prod"}
+
+	app.setNamespaceRegex(`^team-.*-prod$`)
+	if got := app.visibleNamespaces(namespaces); len(got) != 2 {
+		t.Fatalf("visibleNamespaces() = %q, want default hidden", got)
+	}
+
+	// An invalid regex keeps the current one
+	app.setNamespaceRegex(`team-(prod`)
+	if re := app.currentNamespaceRegex(); re == nil || re.String() != `^team-.*-prod$` {
+		t.Errorf("after an invalid :nsre regex = %v, want the previous one", re)
+	}
+
+	app.setNamespaceRegex("")
+	if got := app.visibleNamespaces(namespaces); len(got) != 3 {
+		t.Errorf("after :nsre visibleNamespaces() = %q, want every namespace", got)
+	}
+}
@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var ingressRouteColumns = []string{"HOST", "PATH", "BACKEND", "TLS"}
+
+// ingressRouteRows returns the table cells for an Ingress's routes
+func ingressRouteRows(routes []k8s.IngressRoute) [][]string {
+	rows := make([][]string, 0, len(routes))
+	for _, r := range routes {
+		tls := "no"
+		if r.TLS {
+			tls = "yes"
+		}
+		rows = append(rows, []string{r.Host, r.Path, dashIfEmpty(r.Backend), tls})
+	}
+	return rows
+}
+
+var policyCoverageColumns = []string{"NAMESPACE", "POD", "STATUS", "POLICIES"}
+
+// policyCoverageRows returns the table cells for pod NetworkPolicy coverage
+// and how many of the pods are covered
+func policyCoverageRows(coverage []k8s.PodPolicyCoverage) ([][]string, int) {
+	rows := make([][]string, 0, len(coverage))
+	covered := 0
+	for _, c := range coverage {
+		status := "Uncovered"
+		if c.Covered() {
+			status = "Covered"
+			covered++
+		}
+		rows = append(rows, []string{c.Namespace, c.Pod, status, dashIfEmpty(strings.Join(c.Policies, ", "))})
+	}
+	return rows, covered
+}
+
+// showIngressRoutes lists the hosts, paths, backends, and TLS of an Ingress
+// (Enter on ingresses). Enter opens the backend Service.
+func (a *App) showIngressRoutes(ns, name string) {
+	table := tview.NewTable().SetBorders(false).SetSelectable(true, false).SetFixed(1, 0)
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(fmt.Sprintf(" Ingress Routes %s/%s [gray](Enter:open service d:describe r:refresh Esc:close)[white] ", ns, name))
+
+	var routes []k8s.IngressRoute
+
+	load := func() {
+		a.safeGo("ingress-routes", func() {
+			if a.k8s == nil {
+				a.QueueUpdateDraw(func() {
+					table.SetCell(1, 0, tview.NewTableCell("[red]Not connected to a cluster[white]").SetSelectable(false))
+				})
+				return
+			}
+			ctx, cancel := context.WithTimeout(a.getAppContext(), 10*time.Second)
+			defer cancel()
+			loaded, err := a.k8s.GetIngressRoutes(ctx, ns, name)
+			a.QueueUpdateDraw(func() {
+				if err != nil {
+					table.Clear()
+					table.SetCell(0, 0, tview.NewTableCell(fmt.Sprintf("[red]Failed to load ingress %s/%s: %s[white]", ns, name, tview.Escape(err.Error()))).SetSelectable(false))
+					return
+				}
+				routes = loaded
+				fillPodEnvTable(table, ingressRouteColumns, ingressRouteRows(routes), "No rules or default backend")
+			})
+		})
+	}
+
+	closeView := func() {
+		a.closeModal("ingress-routes")
+		a.SetFocus(a.table)
+	}
+
+	open := func() {
+		sel, _ := table.GetSelection()
+		if sel <= 0 || sel > len(routes) {
+			return
+		}
+		service, _, ok := strings.Cut(routes[sel-1].Backend, ":")
+		if !ok {
+			a.flashMsg(fmt.Sprintf("%s is not a Service backend", routes[sel-1].Backend), true)
+			return
+		}
+		closeView()
+		a.navigateTo("services", ns, service)
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeView()
+			return nil
+		case tcell.KeyEnter:
+			open()
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'd':
+				closeView()
+				a.showDescribe()
+				return nil
+			case 'r':
+				load()
+				return nil
+			case 'q':
+				closeView()
+				return nil
+			}
+		}
+		return event
+	})
+
+	fillPodEnvTable(table, ingressRouteColumns, nil, "Loading...")
+	a.showModal("ingress-routes", centered(table, 110, 16), true)
+	a.SetFocus(table)
+	load()
+}
+
+// showNetworkPolicyCoverage lists the pods of ns, or of every namespace when
+// ns is empty, with the NetworkPolicies that select them (Enter on
+// networkpolicies, :netcov). Pods no policy selects are shown in red; Enter
+// opens the pod.
+func (a *App) showNetworkPolicyCoverage(ns string) {
+	scope := ns
+	if scope == "" {
+		scope = "all namespaces"
+	}
+	table := tview.NewTable().SetBorders(false).SetSelectable(true, false).SetFixed(1, 0)
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(fmt.Sprintf(" NetworkPolicy Coverage: %s [gray](Enter:open pod r:refresh Esc:close)[white] ", scope))
+
+	var coverage []k8s.PodPolicyCoverage
+
+	load := func() {
+		a.safeGo("netpol-coverage", func() {
+			if a.k8s == nil {
+				a.QueueUpdateDraw(func() {
+					table.SetCell(1, 0, tview.NewTableCell("[red]Not connected to a cluster[white]").SetSelectable(false))
+				})
+				return
+			}
+			ctx, cancel := context.WithTimeout(a.getAppContext(), 10*time.Second)
+			defer cancel()
+			loaded, err := a.k8s.GetNetworkPolicyCoverage(ctx, ns)
+			a.QueueUpdateDraw(func() {
+				if err != nil {
+					table.Clear()
+					table.SetCell(0, 0, tview.NewTableCell(fmt.Sprintf("[red]Failed to load NetworkPolicy coverage: %s[white]", tview.Escape(err.Error()))).SetSelectable(false))
+					return
+				}
+				coverage = loaded
+				rows, covered := policyCoverageRows(coverage)
+				fillPodEnvTable(table, policyCoverageColumns, rows, "No running pods")
+				for i, c := range coverage {
+					if !c.Covered() {
+						for col := range policyCoverageColumns {
+							table.GetCell(i+1, col).SetTextColor(tcell.ColorRed)
+						}
+					}
+				}
+				table.SetTitle(fmt.Sprintf(" NetworkPolicy Coverage: %s (%d/%d pods covered) [gray](Enter:open pod r:refresh Esc:close)[white] ",
+					scope, covered, len(coverage)))
+			})
+		})
+	}
+
+	closeView := func() {
+		a.closeModal("netpol-coverage")
+		a.SetFocus(a.table)
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeView()
+			return nil
+		case tcell.KeyEnter:
+			sel, _ := table.GetSelection()
+			if sel > 0 && sel <= len(coverage) {
+				pod := coverage[sel-1]
+				closeView()
+				a.navigateTo("pods", pod.Namespace, pod.Pod)
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'r':
+				load()
+				return nil
+			case 'q':
+				closeView()
+				return nil
+			}
+		}
+		return event
+	})
+
+	fillPodEnvTable(table, policyCoverageColumns, nil, "Loading...")
+	a.showModal("netpol-coverage", centered(table, 110, 20), true)
+	a.SetFocus(table)
+	load()
+}
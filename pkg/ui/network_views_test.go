@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+func TestIngressRouteRows(t *testing.T) {
+	rows := ingressRouteRows([]k8s.IngressRoute{
+		{Host: "shop.example.com", Path: "/", Backend: "web:80", TLS: true},
+		{Host: "*", Path: k8s.IngressDefaultPath},
+	})
+	want := [][]string{
+		{"shop.example.com", "/", "web:80", "yes"},
+		{"*", "(default)", "-", "no"},
+	}
+	for i := range want {
+		if !slices.Equal(rows[i], want[i]) {
+			t.Errorf("ingressRouteRows() row %d = %v, want %v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestPolicyCoverageRows(t *testing.T) {
+	rows, covered := policyCoverageRows([]k8s.PodPolicyCoverage{
+		{Namespace: "shop", Pod: "web-1", Policies: []string{"allow-web", "deny-all"}},
+		{Namespace: "shop", Pod: "worker-1"},
+	})
+	if covered != 1 {
+		t.Errorf("policyCoverageRows() covered = %d, want 1", covered)
+	}
+	want := [][]string{
+		{"shop", "web-1", "Covered", "allow-web, deny-all"},
+		{"shop", "worker-1", "Uncovered", "-"},
+	}
+	for i := range want {
+		if !slices.Equal(rows[i], want[i]) {
+			t.Errorf("policyCoverageRows() row %d = %v, want %v", i, rows[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var nodeCapacityColumns = []string{"NODE", "CPU ALLOC", "CPU REQ", "CPU LIM", "CPU USED", "MEM ALLOC", "MEM REQ", "MEM LIM", "MEM USED", "PODS", "STATUS", "REASON"}
+
+// formatCapacityCell renders value and its share of allocatable, e.g.
+// "1.5c 37%", coloring shares above 100% red
+func formatCapacityCell(value, allocatable int64, formatter func(int64) string) string {
+	pct := k8s.CapacityPercent(value, allocatable)
+	text := fmt.Sprintf("%s %d%%", formatter(value), pct)
+	if pct > 100 {
+		return "[red]" + text + "[white]"
+	}
+	return text
+}
+
+// nodeCapacityRow returns the table cells for one node
+func nodeCapacityRow(nc k8s.NodeCapacity) []string {
+	cpuUsed, memUsed := "-", "-"
+	if nc.UsageAvailable {
+		cpuUsed = formatCapacityCell(nc.CPUUsedMilli, nc.CPUAllocatableMilli, formatCPUCoreValue)
+		memUsed = formatCapacityCell(nc.MemUsedMB, nc.MemAllocatableMB, formatMemoryValueMB)
+	}
+	pods := fmt.Sprintf("%d", nc.Pods)
+	if nc.PodCapacity > 0 {
+		pods = fmt.Sprintf("%d/%d", nc.Pods, nc.PodCapacity)
+	}
+	status := map[string]string{
+		k8s.NodeCapacityOverCommitted: "[red]",
+		k8s.NodeCapacityUnderUtilized: "[yellow]",
+		k8s.NodeCapacityOK:            "[green]",
+	}[nc.Status] + nc.Status + "[white]"
+
+	return []string{
+		tview.Escape(nc.Name),
+		formatCPUCoreValue(nc.CPUAllocatableMilli),
+		formatCapacityCell(nc.CPURequestedMilli, nc.CPUAllocatableMilli, formatCPUCoreValue),
+		formatCapacityCell(nc.CPULimitsMilli, nc.CPUAllocatableMilli, formatCPUCoreValue),
+		cpuUsed,
+		formatMemoryValueMB(nc.MemAllocatableMB),
+		formatCapacityCell(nc.MemRequestedMB, nc.MemAllocatableMB, formatMemoryValueMB),
+		formatCapacityCell(nc.MemLimitsMB, nc.MemAllocatableMB, formatMemoryValueMB),
+		memUsed,
+		pods,
+		status,
+		tview.Escape(strings.Join(nc.Reasons, "; ")),
+	}
+}
+
+// showNodeCapacity compares each node's allocatable CPU and memory with the
+// requests and limits of its pods and measured usage (:node-capacity), so
+// over-committed and under-utilized nodes stand out.
+func (a *App) showNodeCapacity() {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	render := func(nodes []k8s.NodeCapacity, err error) {
+		table.Clear()
+		over, under := 0, 0
+		for _, nc := range nodes {
+			switch nc.Status {
+			case k8s.NodeCapacityOverCommitted:
+				over++
+			case k8s.NodeCapacityUnderUtilized:
+				under++
+			}
+		}
+		table.SetTitle(fmt.Sprintf(" Node Capacity (%d nodes, %d over-committed, %d under-utilized) [gray](Enter:pods r:refresh Esc:close)[white] ",
+			len(nodes), over, under))
+		for col, header := range nodeCapacityColumns {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorYellow).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+		}
+		switch {
+		case err != nil:
+			table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("[red]Failed to load node capacity: %s[white]", tview.Escape(err.Error()))).SetSelectable(false))
+			return
+		case len(nodes) == 0:
+			table.SetCell(1, 0, tview.NewTableCell("[gray]No nodes found[white]").SetSelectable(false))
+			return
+		}
+		for i, nc := range nodes {
+			for col, text := range nodeCapacityRow(nc) {
+				cell := tview.NewTableCell(text).SetReference(nc.Name)
+				if col == len(nodeCapacityColumns)-1 {
+					cell.SetExpansion(1)
+				}
+				table.SetCell(i+1, col, cell)
+			}
+		}
+		table.Select(1, 0)
+	}
+
+	refresh := func() {
+		a.safeGo("node-capacity", func() {
+			if a.k8s == nil {
+				a.QueueUpdateDraw(func() { render(nil, fmt.Errorf("not connected to a cluster")) })
+				return
+			}
+			ctx, cancel := context.WithTimeout(a.appCtx, 15*time.Second)
+			defer cancel()
+			nodes, err := a.k8s.GetNodeCapacity(ctx)
+			a.QueueUpdateDraw(func() { render(nodes, err) })
+		})
+	}
+
+	closeView := func() {
+		a.closeModal("node-capacity")
+		a.SetFocus(a.table)
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeView()
+			return nil
+		case tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			if name, ok := table.GetCell(row, 0).GetReference().(string); ok && name != "" {
+				closeView()
+				// Lists the node's pods through a server-side field selector
+				a.navigateTo("pods", "", "spec.nodeName="+name)
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'r':
+				refresh()
+				return nil
+			case 'q':
+				closeView()
+				return nil
+			}
+		}
+		return event
+	})
+
+	render(nil, nil)
+	table.SetCell(1, 0, tview.NewTableCell("[gray]Loading node capacity...[white]").SetSelectable(false))
+	a.showModal("node-capacity", centered(table, 170, 30), true)
+	a.SetFocus(table)
+	refresh()
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+func TestNodeCapacityRow(t *testing.T) {
+	nc := k8s.NodeCapacity{
+		Name:                "node-1",
+		CPUAllocatableMilli: 4000,
+		CPURequestedMilli:   1500,
+		CPULimitsMilli:      6000,
+		MemAllocatableMB:    8192,
+		MemRequestedMB:      2048,
+		MemLimitsMB:         4096,
+		Pods:                12,
+		PodCapacity:         110,
+		Status:              k8s.NodeCapacityOverCommitted,
+		Reasons:             []string{"CPU limits at 150% of allocatable"},
+	}
+
+	want := []string{
+		"node-1", "4c", "1.5c 37%", "[red]6c 150%[white]", "-",
+		"8Gi", "2Gi 25%", "4Gi 50%", "-",
+		"12/110", "[red]OverCommitted[white]", "CPU limits at 150% of allocatable",
+	}
+	got := nodeCapacityRow(nc)
+	if len(got) != len(nodeCapacityColumns) {
+		t.Fatalf("nodeCapacityRow() returned %d cells, want %d", len(got), len(nodeCapacityColumns))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s = %q, want %q", nodeCapacityColumns[i], got[i], want[i])
+		}
+	}
+
+	nc.UsageAvailable, nc.CPUUsedMilli, nc.MemUsedMB = true, 500, 1024
+	got = nodeCapacityRow(nc)
+	if got[4] != "0.5c 12%" || got[8] != "1Gi 12%" {
+		t.Errorf("usage cells = %q, %q; want measured usage", got[4], got[8])
+	}
+}
@@ -0,0 +1,239 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var (
+	podEnvColumns   = []string{"CONTAINER", "NAME", "VALUE", "SOURCE"}
+	podMountColumns = []string{"CONTAINER", "VOLUME", "MOUNT PATH", "RO", "SOURCE"}
+)
+
+// podEnvValue renders the VALUE cell of an environment variable. Values read
+// from Secrets stay masked until revealed; references that could not be
+// resolved, and envFrom entries, show "-".
+func podEnvValue(v k8s.EnvVarInfo, revealed bool) string {
+	switch {
+	case strings.HasPrefix(v.Source, "envFrom "):
+		return "-"
+	case v.FromSecret() && !revealed:
+		return secretMask
+	case v.Source == "" || v.Resolved:
+		return v.Value
+	}
+	return "-"
+}
+
+// podEnvRows returns the table cells for a pod's environment variables
+func podEnvRows(vars []k8s.EnvVarInfo, revealed bool) [][]string {
+	rows := make([][]string, 0, len(vars))
+	for _, v := range vars {
+		source := v.Source
+		if source == "" {
+			source = "literal"
+		}
+		rows = append(rows, []string{v.Container, v.Name, podEnvValue(v, revealed), source})
+	}
+	return rows
+}
+
+// podMountRows returns the table cells for a pod's volume mounts
+func podMountRows(mounts []k8s.VolumeMountInfo) [][]string {
+	rows := make([][]string, 0, len(mounts))
+	for _, m := range mounts {
+		path := m.MountPath
+		if m.SubPath != "" {
+			path += " (subPath " + m.SubPath + ")"
+		}
+		readOnly := "no"
+		if m.ReadOnly {
+			readOnly = "yes"
+		}
+		rows = append(rows, []string{m.Container, m.Name, path, readOnly, m.Source})
+	}
+	return rows
+}
+
+// podEnvSecrets returns the names of the Secrets that env values are read from
+func podEnvSecrets(vars []k8s.EnvVarInfo) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, v := range vars {
+		if v.SecretKey != "" && !seen[v.SecretName] {
+			seen[v.SecretName] = true
+			names = append(names, v.SecretName)
+		}
+	}
+	return names
+}
+
+// fillPodEnvTable replaces the contents of table with a header and rows, or
+// with empty when there are no rows
+func fillPodEnvTable(table *tview.Table, columns []string, rows [][]string, empty string) {
+	table.Clear()
+	for col, header := range columns {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+	}
+	if len(rows) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("[gray]"+empty+"[white]").SetSelectable(false))
+		return
+	}
+	for i, row := range rows {
+		for col, text := range row {
+			cell := tview.NewTableCell(tview.Escape(text))
+			if col == len(row)-1 {
+				cell.SetExpansion(1)
+			}
+			table.SetCell(i+1, col, cell)
+		}
+	}
+	table.Select(1, 0)
+}
+
+// showPodEnv shows the environment variables and volume mounts of the
+// selected pod's containers (Shift+E on pods). Values read from Secrets are
+// masked until revealed with x, which goes through the secret reveal policy
+// and is audited per Secret.
+func (a *App) showPodEnv() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	if resource != "pods" && resource != "po" {
+		a.flashMsg("Env & mounts view is only available for pods. Navigate to pods view first using :pods", true)
+		return
+	}
+
+	row, _ := a.table.GetSelection()
+	if row <= 0 {
+		return
+	}
+	ns := a.getTableCellText(row, 0)
+	name := a.getTableCellText(row, 1)
+
+	envTable := tview.NewTable().SetBorders(false).SetSelectable(true, false).SetFixed(1, 0)
+	envTable.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	mountTable := tview.NewTable().SetBorders(false).SetSelectable(true, false).SetFixed(1, 0)
+	mountTable.SetBorder(true).SetTitleAlign(tview.AlignLeft).SetTitle(" Volume Mounts ")
+
+	var (
+		vars     []k8s.EnvVarInfo
+		revealed bool
+	)
+
+	render := func() {
+		state := "[green][masked][white] [gray](x:reveal)[white]"
+		if revealed {
+			state = "[red][revealed][white] [gray](x:mask)[white]"
+		}
+		if len(podEnvSecrets(vars)) == 0 {
+			state = ""
+		}
+		envTable.SetTitle(fmt.Sprintf(" Env %s/%s %s [gray](Tab:switch r:refresh Esc:close)[white] ", ns, name, state))
+		fillPodEnvTable(envTable, podEnvColumns, podEnvRows(vars, revealed), "No environment variables")
+	}
+
+	load := func() {
+		a.safeGo("pod-env", func() {
+			if a.k8s == nil {
+				a.QueueUpdateDraw(func() {
+					envTable.SetCell(1, 0, tview.NewTableCell("[red]Not connected to a cluster[white]").SetSelectable(false))
+				})
+				return
+			}
+			ctx, cancel := context.WithTimeout(a.getAppContext(), 10*time.Second)
+			defer cancel()
+			loaded, mounts, err := a.k8s.GetPodEnvironment(ctx, ns, name)
+			if err == nil && revealed {
+				allowed := map[string]bool{}
+				for _, secret := range podEnvSecrets(loaded) {
+					allowed[secret] = true
+				}
+				loaded, err = a.k8s.ResolveSecretEnv(ctx, ns, loaded, allowed)
+			}
+			a.QueueUpdateDraw(func() {
+				if err != nil {
+					envTable.Clear()
+					envTable.SetCell(0, 0, tview.NewTableCell(fmt.Sprintf("[red]Failed to load pod %s/%s: %s[white]", ns, name, tview.Escape(err.Error()))).SetSelectable(false))
+					return
+				}
+				vars = loaded
+				render()
+				fillPodEnvTable(mountTable, podMountColumns, podMountRows(mounts), "No volume mounts")
+			})
+		})
+	}
+
+	toggleReveal := func() {
+		secrets := podEnvSecrets(vars)
+		if len(secrets) == 0 {
+			return
+		}
+		if revealed {
+			revealed = false
+			load()
+			return
+		}
+		// Every Secret is checked and audited on its own
+		for _, secret := range secrets {
+			if !a.revealSecret(ns + "/" + secret) {
+				return
+			}
+		}
+		revealed = true
+		load()
+	}
+
+	closeView := func() {
+		a.closeModal("pod-env")
+		a.SetFocus(a.table)
+	}
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(envTable, 0, 3, true).
+		AddItem(mountTable, 0, 2, false)
+
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeView()
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			if envTable.HasFocus() {
+				a.SetFocus(mountTable)
+			} else {
+				a.SetFocus(envTable)
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'x':
+				toggleReveal()
+				return nil
+			case 'r':
+				load()
+				return nil
+			case 'q':
+				closeView()
+				return nil
+			}
+		}
+		return event
+	})
+
+	render()
+	envTable.SetCell(1, 0, tview.NewTableCell("[gray]Loading...[white]").SetSelectable(false))
+	a.showModal("pod-env", centered(flex, 150, 36), true)
+	a.SetFocus(envTable)
+	load()
+}
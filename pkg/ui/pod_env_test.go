@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+func TestPodEnvRows(t *testing.T) {
+	vars := []k8s.EnvVarInfo{
+		{Container: "app", Name: "LOG_LEVEL", Value: "debug"},
+		{Container: "app", Name: "DB_PASSWORD", Value: "s3cret", Source: "secret:db-creds/password", SecretName: "db-creds", SecretKey: "password", Resolved: true},
+		{Container: "app", Name: "REGION", Source: "configmap:app-config/region", ConfigMapName: "app-config", ConfigMapKey: "region"},
+		{Container: "app", Name: "SEC_*", Source: "envFrom secret:extra", SecretName: "extra"},
+	}
+
+	masked := podEnvRows(vars, false)
+	if masked[0][2] != "debug" || masked[0][3] != "literal" {
+		t.Errorf("literal row = %v", masked[0])
+	}
+	if masked[1][2] != secretMask {
+		t.Errorf("secret value not masked: %v", masked[1])
+	}
+	if masked[2][2] != "-" {
+		t.Errorf("unresolved configmap value = %q, want -", masked[2][2])
+	}
+	if masked[3][2] != "-" {
+		t.Errorf("envFrom value = %q, want -", masked[3][2])
+	}
+
+	if revealed := podEnvRows(vars, true); revealed[1][2] != "s3cret" {
+		t.Errorf("revealed secret value = %q", revealed[1][2])
+	}
+
+	if got := podEnvSecrets(vars); len(got) != 1 || got[0] != "db-creds" {
+		t.Errorf("podEnvSecrets() = %v, want [db-creds]", got)
+	}
+}
+
+func TestPodMountRows(t *testing.T) {
+	rows := podMountRows([]k8s.VolumeMountInfo{
+		{Container: "app", Name: "config", MountPath: "/etc/app/app.yaml", SubPath: "app.yaml", ReadOnly: true, Source: "configmap:app"},
+	})
+	want := []string{"app", "config", "/etc/app/app.yaml (subPath app.yaml)", "yes", "configmap:app"}
+	for i := range want {
+		if rows[0][i] != want[i] {
+			t.Errorf("podMountRows() = %v, want %v", rows[0], want)
+			break
+		}
+	}
+}
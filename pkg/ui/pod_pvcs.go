@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var podClaimColumns = []string{"VOLUME", "CLAIM", "STATUS", "CAPACITY", "ACCESS", "STORAGECLASS", "PV"}
+
+// podClaimRows returns the table cells for a pod's volume claims
+func podClaimRows(claims []k8s.PodVolumeClaim) [][]string {
+	rows := make([][]string, 0, len(claims))
+	for _, c := range claims {
+		volume := c.Volume
+		if c.Ephemeral {
+			volume += " (ephemeral)"
+		}
+		rows = append(rows, []string{
+			volume,
+			c.Claim,
+			c.Status,
+			dashIfEmpty(c.Capacity),
+			dashIfEmpty(c.AccessModes),
+			dashIfEmpty(c.StorageClass),
+			dashIfEmpty(c.PersistentVolume),
+		})
+	}
+	return rows
+}
+
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// showPodVolumeClaims lists the PersistentVolumeClaims used by the selected
+// pod (Shift+V on pods). Enter opens the bound PersistentVolume, or the claim
+// itself while it is not bound.
+func (a *App) showPodVolumeClaims() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	if resource != "pods" && resource != "po" {
+		a.flashMsg("Volume claims view is only available for pods. Navigate to pods view first using :pods", true)
+		return
+	}
+
+	row, _ := a.table.GetSelection()
+	if row <= 0 {
+		return
+	}
+	ns := a.getTableCellText(row, 0)
+	name := a.getTableCellText(row, 1)
+
+	table := tview.NewTable().SetBorders(false).SetSelectable(true, false).SetFixed(1, 0)
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(fmt.Sprintf(" Volume Claims %s/%s [gray](Enter:open PV r:refresh Esc:close)[white] ", ns, name))
+
+	var claims []k8s.PodVolumeClaim
+
+	load := func() {
+		a.safeGo("pod-pvcs", func() {
+			if a.k8s == nil {
+				a.QueueUpdateDraw(func() {
+					table.SetCell(1, 0, tview.NewTableCell("[red]Not connected to a cluster[white]").SetSelectable(false))
+				})
+				return
+			}
+			ctx, cancel := context.WithTimeout(a.getAppContext(), 10*time.Second)
+			defer cancel()
+			loaded, err := a.k8s.GetPodVolumeClaims(ctx, ns, name)
+			a.QueueUpdateDraw(func() {
+				if err != nil {
+					table.Clear()
+					table.SetCell(0, 0, tview.NewTableCell(fmt.Sprintf("[red]Failed to load pod %s/%s: %s[white]", ns, name, tview.Escape(err.Error()))).SetSelectable(false))
+					return
+				}
+				claims = loaded
+				fillPodEnvTable(table, podClaimColumns, podClaimRows(claims), "No PersistentVolumeClaims")
+			})
+		})
+	}
+
+	closeView := func() {
+		a.closeModal("pod-pvcs")
+		a.SetFocus(a.table)
+	}
+
+	open := func() {
+		sel, _ := table.GetSelection()
+		if sel <= 0 || sel > len(claims) {
+			return
+		}
+		claim := claims[sel-1]
+		switch {
+		case claim.PersistentVolume != "":
+			closeView()
+			a.navigateTo("persistentvolumes", "", claim.PersistentVolume)
+		case claim.Status != k8s.ClaimStatusNotFound:
+			closeView()
+			a.navigateTo("persistentvolumeclaims", ns, claim.Claim)
+		default:
+			a.flashMsg(fmt.Sprintf("Claim %s/%s does not exist", ns, claim.Claim), true)
+		}
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeView()
+			return nil
+		case tcell.KeyEnter:
+			open()
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'r':
+				load()
+				return nil
+			case 'q':
+				closeView()
+				return nil
+			}
+		}
+		return event
+	})
+
+	fillPodEnvTable(table, podClaimColumns, nil, "Loading...")
+	a.showModal("pod-pvcs", centered(table, 130, 16), true)
+	a.SetFocus(table)
+	load()
+}
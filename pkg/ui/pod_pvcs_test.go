@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+func TestPodClaimRows(t *testing.T) {
+	rows := podClaimRows([]k8s.PodVolumeClaim{
+		{Volume: "data", Claim: "data-db-0", Status: "Bound", Capacity: "10Gi", AccessModes: "RWO", StorageClass: "fast", PersistentVolume: "pv-data"},
+		{Volume: "scratch", Claim: "db-0-scratch", Ephemeral: true, Status: "Pending", Capacity: "5Gi", AccessModes: "RWO"},
+		{Volume: "gone", Claim: "missing", Status: k8s.ClaimStatusNotFound},
+	})
+	want := [][]string{
+		{"data", "data-db-0", "Bound", "10Gi", "RWO", "fast", "pv-data"},
+		{"scratch (ephemeral)", "db-0-scratch", "Pending", "5Gi", "RWO", "-", "-"},
+		{"gone", "missing", "NotFound", "-", "-", "-", "-"},
+	}
+	for i := range want {
+		for j := range want[i] {
+			if rows[i][j] != want[i][j] {
+				t.Errorf("podClaimRows() row %d = %v, want %v", i, rows[i], want[i])
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/rivo/tview"
+)
+
+// portForwardRestoreWait is how long a restarted port-forward must keep
+// running to count as restored. kubectl exits within it when the target is
+// gone or the local port is taken.
+var portForwardRestoreWait = 2 * time.Second
+
+// portForwardResult is the outcome of restarting one saved port-forward
+type portForwardResult struct {
+	Forward config.PortForward
+	Err     error
+}
+
+// savePortForwards writes the active port-forwards, plus the saved ones of
+// other contexts, to port-forwards.yaml
+func (a *App) savePortForwards() {
+	a.pfMx.Lock()
+	state := &config.PortForwardsState{PortForwards: append([]config.PortForward{}, a.savedForwards...)}
+	for _, pf := range a.portForwards {
+		state.PortForwards = append(state.PortForwards, pf.spec())
+	}
+	a.pfMx.Unlock()
+
+	if err := config.SavePortForwards(state); err != nil && a.logger != nil {
+		a.logger.Warn("Failed to save port-forwards", "error", err)
+	}
+}
+
+// splitSavedForwards separates the saved port-forwards of context from the
+// others. Forwards saved without a context belong to every context.
+func splitSavedForwards(saved []config.PortForward, context string) (current, other []config.PortForward) {
+	for _, fwd := range saved {
+		if fwd.Context == "" || fwd.Context == context {
+			current = append(current, fwd)
+		} else {
+			other = append(other, fwd)
+		}
+	}
+	return current, other
+}
+
+// offerPortForwardRestore asks whether to restart the port-forwards saved
+// for the current context at the last exit
+func (a *App) offerPortForwardRestore() {
+	state, err := config.LoadPortForwards()
+	if err != nil {
+		a.flashMsg(fmt.Sprintf("Saved port-forwards not loaded: %v", err), true)
+		return
+	}
+	forwards, other := splitSavedForwards(state.PortForwards, a.getCurrentContext())
+	a.pfMx.Lock()
+	a.savedForwards = other
+	a.pfMx.Unlock()
+	if len(forwards) == 0 {
+		return
+	}
+
+	lines := make([]string, len(forwards))
+	for i, fwd := range forwards {
+		lines[i] = fwd.String()
+	}
+	text := fmt.Sprintf("Restore %d port-forward(s) from the last session?\n\n%s", len(forwards), strings.Join(lines, "\n"))
+
+	a.QueueUpdateDraw(func() {
+		modal := tview.NewModal().
+			SetText(text).
+			AddButtons([]string{"Restore", "Discard"}).
+			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+				a.closeModal("portforward-restore")
+				a.SetFocus(a.table)
+				if buttonLabel != "Restore" {
+					return
+				}
+				a.safeGo("portforward-restore-start", func() {
+					results := a.restartPortForwards(forwards)
+					a.QueueUpdateDraw(func() { a.showPortForwardResults(results) })
+				})
+			})
+		a.showModal("portforward-restore", modal, true)
+	})
+}
+
+// restartPortForwards starts each forward and waits portForwardRestoreWait
+// for the ones that fail right away
+func (a *App) restartPortForwards(forwards []config.PortForward) []portForwardResult {
+	a.flashMsg(fmt.Sprintf("Restoring %d port-forward(s)...", len(forwards)), false)
+
+	results := make([]portForwardResult, len(forwards))
+	started := make([]*portForwardInfo, len(forwards))
+	for i, fwd := range forwards {
+		results[i].Forward = fwd
+		started[i], results[i].Err = a.launchPortForward(fwd)
+	}
+
+	timer := time.NewTimer(portForwardRestoreWait)
+	defer timer.Stop()
+	expired := false
+	for i, pf := range started {
+		if pf == nil {
+			continue
+		}
+		if !expired {
+			select {
+			case <-pf.done:
+			case <-timer.C:
+				expired = true
+			}
+		}
+		select {
+		case <-pf.done:
+			results[i].Err = portForwardExitError(pf)
+		default:
+		}
+	}
+	return results
+}
+
+// portForwardExitError describes why an exited port-forward stopped, from
+// the last line kubectl wrote to stderr
+func portForwardExitError(pf *portForwardInfo) error {
+	out := strings.TrimSpace(pf.stderr.String())
+	if i := strings.LastIndex(out, "\n"); i >= 0 {
+		out = out[i+1:]
+	}
+	if out == "" {
+		out = "kubectl port-forward exited"
+	}
+	return fmt.Errorf("%s", out)
+}
+
+// showPortForwardResults lists which saved port-forwards were restored and
+// which failed
+func (a *App) showPortForwardResults(results []portForwardResult) {
+	var sb strings.Builder
+	restored := 0
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(&sb, "[red]✗[-] %s\n    %s\n", tview.Escape(r.Forward.String()), tview.Escape(r.Err.Error()))
+			continue
+		}
+		restored++
+		fmt.Fprintf(&sb, "[green]✓[-] %s\n", tview.Escape(r.Forward.String()))
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Restored %d of %d port-forward(s)\n\n%s", restored, len(results), sb.String())).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(int, string) {
+			a.closeModal("portforward-results")
+			a.SetFocus(a.table)
+		})
+	a.showModal("portforward-results", modal, true)
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+func TestSavePortForwards(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	other := config.PortForward{Context: "staging", Namespace: "web", Kind: "svc", Name: "frontend", LocalPort: "3000", RemotePort: "80"}
+	app := &App{
+		portForwards: []*portForwardInfo{
+			{Context: "prod", Namespace: "payments", Kind: "svc", Name: "api", LocalPort: "8080", RemotePort: "80"},
+		},
+		savedForwards: []config.PortForward{other},
+	}
+	app.savePortForwards()
+
+	state, err := config.LoadPortForwards()
+	if err != nil {
+		t.Fatalf("LoadPortForwards() error = %v", err)
+	}
+	want := []config.PortForward{
+		other,
+		{Context: "prod", Namespace: "payments", Kind: "svc", Name: "api", LocalPort: "8080", RemotePort: "80"},
+	}
+	if !reflect.DeepEqual(state.PortForwards, want) {
+		t.Errorf("saved port-forwards = %+v, want %+v", state.PortForwards, want)
+	}
+
+	current, rest := splitSavedForwards(append(state.PortForwards, config.PortForward{Namespace: "default", Kind: "pod", Name: "x"}), "prod")
+	if len(current) != 2 || current[0].Name != "api" || current[1].Name != "x" || len(rest) != 1 || rest[0] != other {
+		t.Errorf("splitSavedForwards() = %+v, %+v", current, rest)
+	}
+}
+
+func TestRestartPortForwards(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as kubectl")
+	}
+	// A kubectl that fails for pod/gone and keeps running otherwise
+	bin := t.TempDir()
+	script := "#!/bin/sh\ncase \"$4\" in\npod/gone) echo 'error: pods \"gone\" not found' >&2; exit 1;;\nesac\nexec sleep 30\n"
+	if err := os.WriteFile(filepath.Join(bin, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	orig := portForwardRestoreWait
+	portForwardRestoreWait = 500 * time.Millisecond
+	defer func() { portForwardRestoreWait = orig }()
+
+	app := NewTestApp(TestAppConfig{
+		SkipBackgroundLoading: true,
+		SkipBriefing:          true,
+	})
+	defer app.cleanupPortForwards()
+
+	results := app.restartPortForwards([]config.PortForward{
+		{Namespace: "payments", Kind: "svc", Name: "api", LocalPort: "8080", RemotePort: "80"},
+		{Namespace: "payments", Kind: "pod", Name: "gone", LocalPort: "9090", RemotePort: "9090"},
+	})
+	if len(results) != 2 || results[0].Err != nil {
+		t.Fatalf("results = %+v, want svc/api restored", results)
+	}
+	if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), `pods "gone" not found`) {
+		t.Errorf("pod/gone error = %v, want kubectl's error", results[1].Err)
+	}
+
+	app.pfMx.Lock()
+	active := len(app.portForwards)
+	app.pfMx.Unlock()
+	if active != 1 {
+		t.Errorf("%d port-forwards tracked, want only the restored one", active)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -369,22 +370,12 @@ func truncatePulseString(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
-// formatPulseAge formats a time as a human-readable age
+// formatPulseAge formats a time as a compact age, or "" when unset
 func formatPulseAge(t time.Time) string {
 	if t.IsZero() {
 		return ""
 	}
-	d := time.Since(t)
-	if d < time.Minute {
-		return fmt.Sprintf("%ds", int(d.Seconds()))
-	}
-	if d < time.Hour {
-		return fmt.Sprintf("%dm", int(d.Minutes()))
-	}
-	if d < 24*time.Hour {
-		return fmt.Sprintf("%dh", int(d.Hours()))
-	}
-	return fmt.Sprintf("%dd", int(d.Hours()/24))
+	return k8s.FormatAgeSince(t)
 }
 
 // showPulse displays the Cluster Pulse modal
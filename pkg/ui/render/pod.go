@@ -2,7 +2,9 @@ package render
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	"github.com/gdamore/tcell/v2"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -102,9 +104,9 @@ func (p *Pod) readyCount(pod *corev1.Pod) string {
 func (p *Pod) phase(pod *corev1.Pod) string {
 	status := string(pod.Status.Phase)
 
-	// Check for terminating
-	if pod.DeletionTimestamp != nil {
-		return "Terminating"
+	// Check for terminating, flagging pods past their grace period
+	if terminating := k8s.PodTerminatingStatus(pod, time.Now()); terminating != "" {
+		return terminating
 	}
 
 	// Check init container statuses
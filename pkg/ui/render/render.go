@@ -3,10 +3,10 @@
 package render
 
 import (
-	"fmt"
 	"strings"
 	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -134,7 +134,7 @@ func (r *BaseRenderer) ColorerFunc() ColorerFunc {
 
 // Age formatting utilities
 
-// FormatAge formats a time.Time as a human-readable age string by computing duration since now.
+// FormatAge formats a time.Time as a compact age string by computing duration since now.
 // This is the timestamp-based variant used by renderers (e.g., Pod, Deployment).
 // For the duration-based variant, see resources.FormatAge.
 func FormatAge(t time.Time) string {
@@ -144,51 +144,13 @@ func FormatAge(t time.Time) string {
 	return FormatDuration(time.Since(t))
 }
 
-// FormatDuration formats a duration as a human-readable string.
+// FormatDuration formats a duration as a compact age such as "3d" or "12m"
+// (see k8s.FormatAge).
 func FormatDuration(d time.Duration) string {
 	if d < 0 {
 		return "<invalid>"
 	}
-
-	d = d.Round(time.Second)
-	days := int(d.Hours() / 24)
-	hours := int(d.Hours()) % 24
-	minutes := int(d.Minutes()) % 60
-	seconds := int(d.Seconds()) % 60
-
-	switch {
-	case days >= 365:
-		years := days / 365
-		months := (days % 365) / 30
-		if months > 0 {
-			return fmt.Sprintf("%dy%dM", years, months)
-		}
-		return fmt.Sprintf("%dy", years)
-	case days >= 30:
-		months := days / 30
-		remainDays := days % 30
-		if remainDays > 0 {
-			return fmt.Sprintf("%dM%dd", months, remainDays)
-		}
-		return fmt.Sprintf("%dM", months)
-	case days > 0:
-		if hours > 0 {
-			return fmt.Sprintf("%dd%dh", days, hours)
-		}
-		return fmt.Sprintf("%dd", days)
-	case hours > 0:
-		if minutes > 0 {
-			return fmt.Sprintf("%dh%dm", hours, minutes)
-		}
-		return fmt.Sprintf("%dh", hours)
-	case minutes > 0:
-		if seconds > 0 {
-			return fmt.Sprintf("%dm%ds", minutes, seconds)
-		}
-		return fmt.Sprintf("%dm", minutes)
-	default:
-		return fmt.Sprintf("%ds", seconds)
-	}
+	return k8s.FormatAge(d)
 }
 
 // Status color utilities
@@ -203,6 +165,8 @@ func StatusColor(status string) tcell.Color {
 		return tcell.ColorYellow
 	case s == "failed", s == "error", s == "crashloopbackoff", s == "imagepullbackoff":
 		return tcell.ColorRed
+	case strings.HasPrefix(s, "terminating (stuck"):
+		return tcell.ColorRed
 	case s == "terminating", s == "evicted":
 		return tcell.ColorOrange
 	case s == "completed", s == "succeeded":
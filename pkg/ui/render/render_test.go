@@ -60,13 +60,13 @@ func TestFormatDuration(t *testing.T) {
 		{"zero", 0, "0s"},
 		{"seconds", 45 * time.Second, "45s"},
 		{"minutes", 5 * time.Minute, "5m"},
-		{"minutes and seconds", 5*time.Minute + 30*time.Second, "5m30s"},
+		{"minutes and seconds", 5*time.Minute + 30*time.Second, "5m"},
 		{"hours", 2 * time.Hour, "2h"},
-		{"hours and minutes", 2*time.Hour + 30*time.Minute, "2h30m"},
+		{"hours and minutes", 2*time.Hour + 30*time.Minute, "2h"},
 		{"days", 3 * 24 * time.Hour, "3d"},
-		{"days and hours", 3*24*time.Hour + 12*time.Hour, "3d12h"},
-		{"months", 45 * 24 * time.Hour, "1M15d"},
-		{"years", 400 * 24 * time.Hour, "1y1M"},
+		{"days and hours", 3*24*time.Hour + 12*time.Hour, "3d"},
+		{"months", 45 * 24 * time.Hour, "45d"},
+		{"years", 400 * 24 * time.Hour, "400d"},
 	}
 
 	for _, tt := range tests {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -33,6 +34,7 @@ type tableRendererConfig struct {
 	isStatusCol  func(col int) bool              // nil-safe: defaults to false
 	headerStyler func(col int, header string) (displayHeader string, color tcell.Color)
 	cellStyler   func(rowIdx int, row []string, col int, text string) (displayText string, color tcell.Color)
+	cellColor    tcell.Color // text color for non-status cells; ColorDefault means white
 }
 
 // dataRowOffset is the index of the first data row (header occupies row 0).
@@ -176,6 +178,9 @@ func cellKeyAt(table *tview.Table, dataIdx int, resource string) string {
 func writeRowCells(table *tview.Table, tableRow int, row []string, cfg tableRendererConfig, prev []string) {
 	for c, text := range row {
 		color := tcell.ColorWhite
+		if cfg.cellColor != tcell.ColorDefault {
+			color = cfg.cellColor
+		}
 		if cfg.isStatusCol != nil && cfg.isStatusCol(c) {
 			if cfg.statusColor != nil {
 				color = cfg.statusColor(text)
@@ -246,8 +251,9 @@ func (a *App) applyDiffRender() {
 // rendererConfig builds the per-render configuration used by syncDataRows,
 // reusing the App's status-color and sort-arrow logic.
 func (a *App) rendererConfig(resource string, headers []string, sortCol int, sortAsc bool) tableRendererConfig {
-	headerColor := tcell.NewRGBColor(224, 175, 104)
-	sortColor := tcell.NewRGBColor(125, 207, 255)
+	p, def := a.palette(), config.DefaultPalette()
+	headerColor := themeColor(p.Warning, def.Warning)
+	sortColor := themeColor(p.Info, def.Info)
 	headerBg := tcell.NewRGBColor(36, 40, 59)
 
 	return tableRendererConfig{
@@ -255,6 +261,7 @@ func (a *App) rendererConfig(resource string, headers []string, sortCol int, sor
 		headers:     headers,
 		statusColor: a.statusColor,
 		isStatusCol: a.isStatusColumn,
+		cellColor:   themeColor(p.CellText, def.CellText),
 		headerStyler: func(col int, header string) (string, tcell.Color) {
 			display := header
 			color := headerColor
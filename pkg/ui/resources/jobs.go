@@ -39,7 +39,7 @@ func GetJobsView(ctx context.Context, client *k8s.Client, namespace, filter stri
 			if job.Status.CompletionTime != nil {
 				end = job.Status.CompletionTime.Time
 			}
-			duration = formatDuration(end.Sub(start))
+			duration = FormatAge(end.Sub(start))
 		}
 
 		// Status color
@@ -64,16 +64,3 @@ func GetJobsView(ctx context.Context, client *k8s.Client, namespace, filter stri
 
 	return ResourceView{Headers: headers, Rows: rows}, nil
 }
-
-func formatDuration(d time.Duration) string {
-	if d < time.Minute {
-		return fmt.Sprintf("%ds", int(d.Seconds()))
-	}
-	if d < time.Hour {
-		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
-	}
-	if d < 24*time.Hour {
-		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
-	}
-	return fmt.Sprintf("%dd%dh", int(d.Hours()/24), int(d.Hours())%24)
-}
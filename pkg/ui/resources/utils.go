@@ -7,27 +7,15 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 )
 
-// FormatAge formats a duration into a human-readable age string.
-// This is the duration-based variant used by resource table views.
-// For the time-based variant that computes duration from a timestamp, see render.FormatAge.
-// Examples: "5d", "12h", "30m", "45s"
+// FormatAge formats a duration as a compact age such as "5d", "12h", "30m",
+// or "45s". It is the duration-based variant of render.FormatAge; both use
+// k8s.FormatAge so every table renders ages alike.
 func FormatAge(dur time.Duration) string {
-	if dur < 0 {
-		return "0s"
-	}
-	if dur.Hours() >= 24 {
-		days := int(dur.Hours() / 24)
-		return fmt.Sprintf("%dd", days)
-	}
-	if dur.Hours() >= 1 {
-		return fmt.Sprintf("%dh", int(dur.Hours()))
-	}
-	if dur.Minutes() >= 1 {
-		return fmt.Sprintf("%dm", int(dur.Minutes()))
-	}
-	return fmt.Sprintf("%ds", int(dur.Seconds()))
+	return k8s.FormatAge(dur)
 }
 
 // FormatAgeSince calculates the age from a given time until now.
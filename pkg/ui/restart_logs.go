@@ -0,0 +1,254 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxCrashEvidence bounds each log excerpt sent to the AI with a crash
+const maxCrashEvidence = 6000
+
+// restartInfo is when a container last restarted and how the instance
+// before it ended
+type restartInfo struct {
+	Container string
+	Restarts  int32
+	Since     time.Time // when the current instance started; zero if it has not
+	Waiting   string    // e.g. CrashLoopBackOff while no instance runs
+	Reason    string    // why the previous instance ended, e.g. OOMKilled
+	ExitCode  int32
+	Ended     time.Time
+}
+
+// lastRestart finds the last restart of container, or of the pod's most
+// recently restarted container when container is empty. It reports false
+// when the container has never restarted.
+func lastRestart(pod *corev1.Pod, container string) (restartInfo, bool) {
+	var (
+		best  corev1.ContainerStatus
+		ended time.Time
+		found bool
+	)
+	for _, status := range pod.Status.ContainerStatuses {
+		if container != "" && status.Name != container {
+			continue
+		}
+		if status.RestartCount == 0 {
+			continue
+		}
+		var at time.Time
+		if t := status.LastTerminationState.Terminated; t != nil {
+			at = t.FinishedAt.Time
+		}
+		if !found || at.After(ended) {
+			best, ended, found = status, at, true
+		}
+	}
+	if !found {
+		return restartInfo{}, false
+	}
+
+	info := restartInfo{Container: best.Name, Restarts: best.RestartCount, Ended: ended}
+	if t := best.LastTerminationState.Terminated; t != nil {
+		info.Reason = t.Reason
+		info.ExitCode = t.ExitCode
+	}
+	switch {
+	case best.State.Running != nil:
+		info.Since = best.State.Running.StartedAt.Time
+	case best.State.Terminated != nil:
+		info.Since = best.State.Terminated.StartedAt.Time
+	case best.State.Waiting != nil:
+		info.Waiting = best.State.Waiting.Reason
+	}
+	return info, true
+}
+
+// summary describes the restart in one line, e.g. for the AI context
+func (r restartInfo) summary() string {
+	s := fmt.Sprintf("container %s restarted %d times", r.Container, r.Restarts)
+	if r.Reason != "" || r.ExitCode != 0 {
+		s += fmt.Sprintf(", last exit %d (%s)", r.ExitCode, dashIfEmpty(r.Reason))
+	}
+	if !r.Ended.IsZero() {
+		s += " at " + r.Ended.UTC().Format(time.RFC3339)
+	}
+	if r.Waiting != "" {
+		s += ", now " + r.Waiting
+	}
+	return s
+}
+
+// formatRestartLogs lays out the crashed instance's log above the log of
+// the current one
+func formatRestartLogs(r restartInfo, previous, current string, prevErr, curErr error, previousLines int64) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "────────── %s ──────────\n", r.summary())
+	fmt.Fprintf(&b, "────────── previous instance, last %d lines ──────────\n", previousLines)
+	switch {
+	case prevErr != nil:
+		fmt.Fprintf(&b, "Error: %v\n", prevErr)
+	case strings.TrimSpace(previous) == "":
+		b.WriteString("No logs available\n")
+	default:
+		b.WriteString(strings.TrimRight(previous, "\n") + "\n")
+	}
+
+	if r.Since.IsZero() {
+		fmt.Fprintf(&b, "────────── not running again yet (%s) ──────────\n", dashIfEmpty(r.Waiting))
+		return b.String()
+	}
+	fmt.Fprintf(&b, "────────── since restart at %s (%s ago) ──────────\n",
+		r.Since.Local().Format("15:04:05"), k8s.FormatAgeSince(r.Since))
+	switch {
+	case curErr != nil:
+		fmt.Fprintf(&b, "Error: %v\n", curErr)
+	case strings.TrimSpace(current) == "":
+		b.WriteString("No logs since the restart\n")
+	default:
+		b.WriteString(strings.TrimRight(current, "\n") + "\n")
+	}
+	return b.String()
+}
+
+// crashEvidence is the log excerpt sent to the AI with a crash question;
+// the ends of the logs matter most, so longer logs keep their tail
+func crashEvidence(r restartInfo, previous, current string) string {
+	tail := func(s string) string {
+		s = strings.TrimSpace(s)
+		if runes := []rune(s); len(runes) > maxCrashEvidence {
+			return "...[truncated]\n" + string(runes[len(runes)-maxCrashEvidence:])
+		}
+		return s
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Restart: %s.\n", r.summary())
+	if previous != "" {
+		fmt.Fprintf(&b, "\nLogs of the crashed container instance:\n%s\n", tail(previous))
+	}
+	if current != "" {
+		fmt.Fprintf(&b, "\nLogs since the restart:\n%s\n", tail(current))
+	}
+	return b.String()
+}
+
+// restartLogsConfig returns the restart_logs settings; defaults without a config
+func (a *App) restartLogsConfig() config.RestartLogsConfig {
+	if a.config == nil {
+		return config.RestartLogsConfig{}
+	}
+	return a.config.RestartLogs
+}
+
+// showLogsSinceRestart shows the selected pod's logs since its last restart
+// together with the end of the crashed container's logs
+func (a *App) showLogsSinceRestart() {
+	a.mx.RLock()
+	resource := a.currentResource
+	a.mx.RUnlock()
+
+	if resource != "pods" && resource != "po" {
+		a.flashMsg("Logs since restart are only available for pods. Navigate to pods view first using :pods", true)
+		return
+	}
+
+	row, _ := a.table.GetSelection()
+	if row <= 0 {
+		return
+	}
+	ns := a.getTableCellText(row, 0)
+	name := a.getTableCellText(row, 1)
+
+	a.safeGo("showLogsSinceRestart", func() { a.loadLogsSinceRestart(ns, name, "") })
+}
+
+// loadLogsSinceRestart fetches the logs of container, or of the pod's most
+// recently restarted container, and opens them in the log viewer
+func (a *App) loadLogsSinceRestart(ns, name, container string) {
+	ctx, cancel := context.WithTimeout(a.getAppContext(), 30*time.Second)
+	defer cancel()
+
+	pod, err := a.k8s.Clientset.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		a.QueueUpdateDraw(func() {
+			a.flashMsg(fmt.Sprintf("Failed to get pod: %v", err), true)
+		})
+		return
+	}
+	info, ok := lastRestart(pod, container)
+	if !ok {
+		a.QueueUpdateDraw(func() {
+			a.flashMsg(fmt.Sprintf("%s/%s has not restarted; press l for its logs", ns, name), false)
+		})
+		return
+	}
+
+	cfg := a.restartLogsConfig()
+	lines := cfg.EffectivePreviousLines()
+	previous, prevErr := a.k8s.GetPodLogsPrevious(ctx, ns, name, info.Container, lines)
+	var (
+		current string
+		curErr  error
+	)
+	if !info.Since.IsZero() {
+		current, curErr = a.k8s.GetPodLogsSince(ctx, ns, name, info.Container, info.Since)
+	}
+	if prevErr == nil || curErr == nil {
+		a.recordTUIRead("logs", "pods", ns, name)
+	}
+
+	explain := func() {
+		a.explainCrash(ns, name, info, previous, current)
+	}
+	a.QueueUpdateDraw(func() {
+		title := fmt.Sprintf(" Logs since restart: %s/%s [%s]", ns, name, info.Container)
+		logView := NewVimViewer(a, "logs",
+			fmt.Sprintf("%s [gray](Esc:close x:explain /search s:autoscroll w:wrap c:color)[white] ", title))
+		logView.isLogView = true
+		logView.textWrap = true
+		logView.enableLogHighlight(a.config)
+		logView.explain = explain
+		logView.SetContent(formatRestartLogs(info, previous, current, prevErr, curErr, lines))
+		logView.updateTitle()
+		a.showModal("logs", logView, true)
+		a.SetFocus(logView)
+		logView.ScrollToEnd()
+	})
+
+	if cfg.Explain && a.config.AIEnabled() {
+		explain()
+	}
+}
+
+// explainCrash attaches the pod to the AI panel and asks why the container
+// crashed, with the logs around the restart as evidence
+func (a *App) explainCrash(ns, name string, info restartInfo, previous, current string) {
+	if !a.config.AIEnabled() {
+		a.flashMsg("AI assistant is disabled (--no-ai)", true)
+		return
+	}
+
+	a.aiMx.Lock()
+	a.attachedAIContext = aiAttachedSelection{Resource: "pods", Namespace: ns, Name: name, Summary: info.summary()}
+	a.aiMx.Unlock()
+
+	a.mx.RLock()
+	show := a.showAIPanel
+	a.mx.RUnlock()
+	if !show {
+		a.toggleAIPanel()
+	}
+
+	question := fmt.Sprintf("Why did container %s of pod %s/%s crash, and how do I fix it?", info.Container, ns, name)
+	evidence := crashEvidence(info, previous, current)
+	a.flashMsg(fmt.Sprintf("Asking AI why %s crashed; the answer appears in the AI panel", info.Container), false)
+	a.safeGo("explainCrash", func() { a.askAIWithEvidence(question, evidence) })
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/gdamore/tcell/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func crashedPod(started, ended time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "sidecar"}, {Name: "api"}}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+			{
+				Name:         "sidecar",
+				RestartCount: 1,
+				State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(started.Add(-time.Hour))}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 137, Reason: "OOMKilled", FinishedAt: metav1.NewTime(ended.Add(-time.Hour)),
+				}},
+			},
+			{
+				Name:         "api",
+				RestartCount: 4,
+				State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(started)}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1, Reason: "Error", FinishedAt: metav1.NewTime(ended),
+				}},
+			},
+		}},
+	}
+}
+
+func TestLastRestart(t *testing.T) {
+	ended := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	pod := crashedPod(ended.Add(10*time.Second), ended)
+
+	info, ok := lastRestart(pod, "")
+	if !ok || info.Container != "api" || !info.Since.Equal(ended.Add(10*time.Second)) || info.ExitCode != 1 {
+		t.Fatalf("lastRestart() = %+v, %v; want the most recent restart of api", info, ok)
+	}
+	if got := info.summary(); got != "container api restarted 4 times, last exit 1 (Error) at 2026-10-01T08:00:00Z" {
+		t.Errorf("summary() = %q", got)
+	}
+
+	info, ok = lastRestart(pod, "sidecar")
+	if !ok || info.Reason != "OOMKilled" {
+		t.Errorf("lastRestart(sidecar) = %+v, %v", info, ok)
+	}
+
+	// CrashLoopBackOff: nothing runs since the restart yet
+	pod.Status.ContainerStatuses[1].State = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}
+	info, _ = lastRestart(pod, "api")
+	if !info.Since.IsZero() || info.Waiting != "CrashLoopBackOff" {
+		t.Errorf("waiting lastRestart() = %+v", info)
+	}
+	text := formatRestartLogs(info, "panic: boom\n", "", nil, nil, 100)
+	if !strings.Contains(text, "panic: boom") || !strings.Contains(text, "not running again yet (CrashLoopBackOff)") {
+		t.Errorf("formatRestartLogs() =\n%s", text)
+	}
+
+	pod.Status.ContainerStatuses[0].RestartCount = 0
+	pod.Status.ContainerStatuses[1].RestartCount = 0
+	if _, ok := lastRestart(pod, ""); ok {
+		t.Error("lastRestart() reported a restart for a pod that never restarted")
+	}
+
+	evidence := crashEvidence(info, strings.Repeat("x", maxCrashEvidence)+"panic: boom", "")
+	if !strings.HasSuffix(strings.TrimSpace(evidence), "panic: boom") || !strings.Contains(evidence, "[truncated]") {
+		t.Errorf("crashEvidence() should keep the end of long logs:\n%.200s", evidence)
+	}
+}
+
+func TestShowLogsSinceRestart(t *testing.T) {
+	app := NewTestApp(TestAppConfig{SkipBackgroundLoading: true, SkipBriefing: true})
+	app.config = &config.Config{RestartLogs: config.RestartLogsConfig{Shortcut: "Alt-r"}}
+	pod := crashedPod(time.Now().Add(-3*time.Minute), time.Now().Add(-3*time.Minute))
+	if _, err := app.k8s.Clientset.CoreV1().Pods("default").Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	app.loadLogsSinceRestart("default", "api-1", "")
+	if !hasTestPage(app, "logs") {
+		t.Fatal("logs since restart did not open the log viewer")
+	}
+	if !matchPluginShortcut(tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModAlt), app.restartLogsConfig().EffectiveShortcut()) {
+		t.Error("the configured shortcut does not match Alt+R")
+	}
+	if got := (config.RestartLogsConfig{}).EffectiveShortcut(); got != config.DefaultRestartLogsShortcut {
+		t.Errorf("default shortcut = %q", got)
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// secretMask replaces Secret data values until they are explicitly revealed
+const secretMask = "********"
+
+// effectiveTUIRole returns the role used for TUI policy decisions
+func (a *App) effectiveTUIRole() string {
+	if a.tuiRole != "" {
+		return a.tuiRole
+	}
+	if a.config != nil && a.config.Authorization.DefaultTUIRole != "" {
+		return a.config.Authorization.DefaultTUIRole
+	}
+	return "admin"
+}
+
+// revealSecret decides whether the current user may see the decoded values of
+// the Secret at secretPath (namespace/name) and records the attempt in the
+// audit log either way. It returns false, with a flash error, when the
+// secret_reveal policy or RBAC denies the reveal.
+func (a *App) revealSecret(secretPath string) bool {
+	resourcePath := "secrets/" + strings.TrimPrefix(secretPath, "/")
+
+	if a.config != nil {
+		role := a.effectiveTUIRole()
+		if !a.config.Authorization.SecretReveal.AllowsRole(role) {
+			reason := "secret reveal is disabled by configuration"
+			if !a.config.Authorization.SecretReveal.Disabled {
+				reason = fmt.Sprintf("role %s may not reveal secret values", role)
+			}
+			a.flashMsg(fmt.Sprintf("Permission denied: %s", reason), true)
+			a.recordTUIAudit("secret_reveal", resourcePath, fmt.Sprintf("Denied reveal of secret %s", secretPath), false, reason)
+			return false
+		}
+	}
+
+	// checkTUIPermission flashes and audits its own denial
+	if !a.checkTUIPermission("secrets", "reveal") {
+		return false
+	}
+
+	a.recordTUIAudit("secret_reveal", resourcePath, fmt.Sprintf("Revealed decoded values of secret %s", secretPath), true, "")
+	return true
+}
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+)
+
+func secretRevealTestYAML() string {
+	return `apiVersion: v1
+kind: Secret
+metadata:
+  name: db-creds
+  namespace: default
+data:
+  password: ` + base64.StdEncoding.EncodeToString([]byte("s3cret!")) + `
+type: Opaque
+`
+}
+
+func newSecretRevealTestApp(t *testing.T) *App {
+	t.Helper()
+	if err := db.Init(filepath.Join(t.TempDir(), "audit.db")); err != nil {
+		t.Fatalf("Failed to init test DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return NewTestApp(TestAppConfig{
+		UseSimulationScreen:   true,
+		Screen:                createTestScreen(t),
+		SkipBackgroundLoading: true,
+		SkipBriefing:          true,
+	})
+}
+
+func secretRevealAudits(t *testing.T) []map[string]interface{} {
+	t.Helper()
+	logs, err := db.GetAuditLogsFiltered(db.AuditFilter{Action: "secret_reveal"})
+	if err != nil {
+		t.Fatalf("GetAuditLogsFiltered() error = %v", err)
+	}
+	return logs
+}
+
+func TestMaskSecretYAML(t *testing.T) {
+	masked := maskSecretYAML(secretRevealTestYAML())
+
+	if strings.Contains(masked, base64.StdEncoding.EncodeToString([]byte("s3cret!"))) {
+		t.Errorf("masked YAML still contains the encoded value:\n%s", masked)
+	}
+	if !strings.Contains(masked, "password: "+secretMask) {
+		t.Errorf("expected masked password, got:\n%s", masked)
+	}
+	if !strings.Contains(masked, "name: db-creds") || !strings.Contains(masked, "type: Opaque") {
+		t.Errorf("metadata outside data: should be untouched, got:\n%s", masked)
+	}
+}
+
+func TestMaskSecretYAML_LastAppliedConfiguration(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString([]byte("s3cret!"))
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{
+			name: "block scalar",
+			yaml: `apiVersion: v1
+kind: Secret
+metadata:
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: |
+      {"apiVersion":"v1","data":{"password":"` + encoded + `"},"kind":"Secret","stringData":{"token":"plain-token"}}
+  name: db-creds
+data:
+  password: ` + encoded + `
+type: Opaque
+`,
+		},
+		{
+			name: "quoted scalar",
+			yaml: `apiVersion: v1
+kind: Secret
+metadata:
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{"apiVersion":"v1","data":{"password":"` + encoded + `"},
+      "stringData":{"token":"plain-token"}}'
+  name: db-creds
+data:
+  password: ` + encoded + `
+type: Opaque
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			masked := maskSecretYAML(tt.yaml)
+
+			if strings.Contains(masked, encoded) || strings.Contains(masked, "plain-token") {
+				t.Errorf("masked YAML still contains secret values:\n%s", masked)
+			}
+			if !strings.Contains(masked, "kubectl.kubernetes.io/last-applied-configuration: "+secretMask) {
+				t.Errorf("expected masked annotation, got:\n%s", masked)
+			}
+			if !strings.Contains(masked, "  name: db-creds") || !strings.Contains(masked, "password: "+secretMask) {
+				t.Errorf("lines after the annotation should be kept, got:\n%s", masked)
+			}
+		})
+	}
+}
+
+func TestSecretReveal_AuditsReveal(t *testing.T) {
+	app := newSecretRevealTestApp(t)
+	v := NewVimViewer(app, "yaml", " YAML ")
+	v.isSecretView = true
+	v.secretPath = "default/db-creds"
+	v.setSecretYAML(secretRevealTestYAML())
+
+	if v.secretDecoded || strings.Contains(v.GetText(false), "s3cret!") {
+		t.Fatal("secret values should be masked by default")
+	}
+
+	v.toggleSecretDecode()
+	if !v.secretDecoded || !strings.Contains(v.GetText(false), "password: s3cret!") {
+		t.Fatalf("expected revealed value, got:\n%s", v.GetText(false))
+	}
+
+	logs := secretRevealAudits(t)
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 secret_reveal audit entry, got %d", len(logs))
+	}
+	if logs[0]["resource"] != "secrets/default/db-creds" {
+		t.Errorf("resource = %v, want secrets/default/db-creds", logs[0]["resource"])
+	}
+	if logs[0]["user"] != app.getTUIUser() {
+		t.Errorf("user = %v, want %s", logs[0]["user"], app.getTUIUser())
+	}
+	if logs[0]["success"] != true {
+		t.Errorf("success = %v, want true", logs[0]["success"])
+	}
+
+	// Masking again is not a reveal and is not audited
+	v.toggleSecretDecode()
+	if v.secretDecoded || strings.Contains(v.GetText(false), "s3cret!") {
+		t.Fatal("second toggle should mask the values again")
+	}
+	if n := len(secretRevealAudits(t)); n != 1 {
+		t.Errorf("expected masking to add no audit entries, got %d", n)
+	}
+}
+
+func TestSecretReveal_DeniedByPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		disable bool
+		denied  []string
+		role    string
+	}{
+		{name: "disabled", disable: true},
+		{name: "denied role", denied: []string{"viewer"}, role: "viewer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newSecretRevealTestApp(t)
+			app.config.Authorization.SecretReveal.Disabled = tt.disable
+			app.config.Authorization.SecretReveal.DeniedRoles = tt.denied
+			app.tuiRole = tt.role
+
+			v := NewVimViewer(app, "yaml", " YAML ")
+			v.isSecretView = true
+			v.secretPath = "default/db-creds"
+			v.setSecretYAML(secretRevealTestYAML())
+			v.toggleSecretDecode()
+
+			if v.secretDecoded || strings.Contains(v.GetText(false), "s3cret!") {
+				t.Fatal("reveal should be blocked by the secret_reveal policy")
+			}
+			logs := secretRevealAudits(t)
+			if len(logs) != 1 || logs[0]["success"] != false {
+				t.Fatalf("expected one failed secret_reveal audit entry, got %v", logs)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"log/slog"
+	"regexp"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+// AppOptions controls how NewAppWithOptions starts the TUI.
+type AppOptions struct {
+	// Namespace is the initial namespace; "" or "all" means all namespaces.
+	Namespace string
+	// NamespaceRegex, if set, limits all-namespace views to the namespaces
+	// it matches (--namespace-regex).
+	NamespaceRegex *regexp.Regexp
+	// RestoreSession reopens the last saved context, namespace, and resource
+	// view instead of Namespace. Callers leave it off when -n/-A was given.
+	RestoreSession bool
+	// SaveSession writes the current context, namespace, and resource view
+	// to state.yaml when the TUI exits.
+	SaveSession bool
+}
+
+// restoreSession loads the saved session and switches the client to its
+// context when that context still exists. It returns nil when there is
+// nothing to restore.
+func restoreSession(client *k8s.Client, logger *slog.Logger) *config.SessionState {
+	state := config.LoadSessionState()
+	if state == nil {
+		return nil
+	}
+	if client == nil || state.Context == "" {
+		return state
+	}
+
+	contexts, current, err := client.ListContexts()
+	if err != nil || state.Context == current {
+		return state
+	}
+	for _, name := range contexts {
+		if name != state.Context {
+			continue
+		}
+		if err := client.SwitchContext(state.Context); err != nil {
+			logger.Warn("Failed to restore context", "context", state.Context, "error", err)
+			return nil
+		}
+		return state
+	}
+
+	// The saved context is gone; its namespace and view may not apply either.
+	logger.Info("Saved context no longer exists, starting fresh", "context", state.Context)
+	return nil
+}
+
+// sessionState captures the current context, namespace, and resource view.
+func (a *App) sessionState() *config.SessionState {
+	a.mx.RLock()
+	state := &config.SessionState{
+		Namespace: a.currentNamespace,
+		Resource:  a.currentResource,
+	}
+	a.mx.RUnlock()
+
+	if a.k8s != nil {
+		if ctx, err := a.k8s.GetCurrentContext(); err == nil {
+			state.Context = ctx
+		}
+	}
+	return state
+}
+
+// saveSession persists the session state for the next launch.
+func (a *App) saveSession() {
+	if err := config.SaveSessionState(a.sessionState()); err != nil && a.logger != nil {
+		a.logger.Warn("Failed to save session state", "error", err)
+	}
+}
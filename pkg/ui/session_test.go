@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+func TestSaveSession_RoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	app := &App{
+		k8s:              &k8s.Client{CurrentContextOverride: "staging", ContextsOverride: []string{"prod", "staging"}},
+		currentNamespace: "payments",
+		currentResource:  "deployments",
+	}
+	app.saveSession()
+
+	got := config.LoadSessionState()
+	want := config.SessionState{Context: "staging", Namespace: "payments", Resource: "deployments"}
+	if got == nil || *got != want {
+		t.Fatalf("LoadSessionState() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRestoreSession(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name    string
+		saved   *config.SessionState
+		client  *k8s.Client
+		wantNil bool
+	}{
+		{
+			name:    "no saved state",
+			wantNil: true,
+		},
+		{
+			name:  "same context",
+			saved: &config.SessionState{Context: "prod", Namespace: "payments", Resource: "deployments"},
+			client: &k8s.Client{
+				CurrentContextOverride: "prod",
+				ContextsOverride:       []string{"prod", "staging"},
+			},
+		},
+		{
+			name:  "no client keeps namespace and view",
+			saved: &config.SessionState{Context: "prod", Namespace: "payments", Resource: "pods"},
+		},
+		{
+			name:  "saved context removed from kubeconfig",
+			saved: &config.SessionState{Context: "old-cluster", Namespace: "payments", Resource: "pods"},
+			client: &k8s.Client{
+				CurrentContextOverride: "prod",
+				ContextsOverride:       []string{"prod"},
+			},
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			if tt.saved != nil {
+				if err := config.SaveSessionState(tt.saved); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got := restoreSession(tt.client, logger)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("restoreSession() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.saved {
+				t.Errorf("restoreSession() = %+v, want %+v", got, tt.saved)
+			}
+		})
+	}
+}
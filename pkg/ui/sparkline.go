@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+)
+
+// sparkBlocks are the bar heights of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+const (
+	// briefingTrendWindow is how far back the briefing trend lines reach
+	briefingTrendWindow = time.Hour
+	// briefingTrendPoints is the number of bars in a briefing trend line
+	briefingTrendPoints = 20
+	// briefingTrendStep is the minimum spacing of the samples the briefing
+	// takes itself; a refresh within it replaces the latest sample
+	briefingTrendStep = 30 * time.Second
+)
+
+// sparkline renders the last width values as block characters scaled
+// between lo and hi. Values outside the range are clamped; when hi <= lo
+// every bar is drawn at mid height.
+func sparkline(values []float64, lo, hi float64, width int) string {
+	if width > 0 && len(values) > width {
+		values = values[len(values)-width:]
+	}
+	top := len(sparkBlocks) - 1
+	bars := make([]rune, len(values))
+	for i, v := range values {
+		idx := top / 2
+		if hi > lo {
+			idx = min(max(int((v-lo)/(hi-lo)*float64(top)+0.5), 0), top)
+		}
+		bars[i] = sparkBlocks[idx]
+	}
+	return string(bars)
+}
+
+// valueRange returns the smallest and largest of values
+func valueRange(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values[1:] {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	return lo, hi
+}
+
+// trendSample is one point of the briefing trend lines
+type trendSample struct {
+	At            time.Time
+	CPUPercent    float64
+	MemoryPercent float64
+	Pods          int
+}
+
+// briefingTrend holds the samples behind the briefing sparklines
+type briefingTrend []trendSample
+
+// cpu, memory and pods return one series of the trend, oldest first
+func (t briefingTrend) cpu() []float64 {
+	values := make([]float64, len(t))
+	for i, s := range t {
+		values[i] = s.CPUPercent
+	}
+	return values
+}
+
+func (t briefingTrend) memory() []float64 {
+	values := make([]float64, len(t))
+	for i, s := range t {
+		values[i] = s.MemoryPercent
+	}
+	return values
+}
+
+func (t briefingTrend) pods() []float64 {
+	values := make([]float64, len(t))
+	for i, s := range t {
+		values[i] = float64(s.Pods)
+	}
+	return values
+}
+
+// addTrendSample appends s to the samples the briefing took itself. A sample
+// within briefingTrendStep of the latest one replaces it, so frequent view
+// refreshes don't squeeze the window. Samples older than
+// briefingTrendWindow are dropped.
+func addTrendSample(samples briefingTrend, s trendSample) briefingTrend {
+	if n := len(samples); n > 0 && s.At.Sub(samples[n-1].At) < briefingTrendStep {
+		samples[n-1] = s
+	} else {
+		samples = append(samples, s)
+	}
+	cutoff := s.At.Add(-briefingTrendWindow)
+	for len(samples) > 0 && samples[0].At.Before(cutoff) {
+		samples = samples[1:]
+	}
+	return samples
+}
+
+// mergeTrend combines the metrics store history with the briefing's own
+// samples, which cover the time since the last stored point, and keeps the
+// newest briefingTrendPoints
+func mergeTrend(stored, live briefingTrend) briefingTrend {
+	merged := append(briefingTrend(nil), stored...)
+	sort.Slice(merged, func(i, j int) bool { return merged[i].At.Before(merged[j].At) })
+	var last time.Time
+	if len(merged) > 0 {
+		last = merged[len(merged)-1].At
+	}
+	for _, s := range live {
+		if s.At.After(last) {
+			merged = append(merged, s)
+		}
+	}
+	if len(merged) > briefingTrendPoints {
+		merged = merged[len(merged)-briefingTrendPoints:]
+	}
+	return merged
+}
+
+// clusterMetricsTrend converts metrics store rows to trend samples
+func clusterMetricsTrend(rows []db.ClusterMetrics) briefingTrend {
+	trend := make(briefingTrend, 0, len(rows))
+	for _, m := range rows {
+		s := trendSample{At: m.Timestamp, Pods: m.TotalPods}
+		if m.TotalCPUMillis > 0 {
+			s.CPUPercent = float64(m.UsedCPUMillis) / float64(m.TotalCPUMillis) * 100
+		}
+		if m.TotalMemoryMB > 0 {
+			s.MemoryPercent = float64(m.UsedMemoryMB) / float64(m.TotalMemoryMB) * 100
+		}
+		trend = append(trend, s)
+	}
+	return trend
+}
+
+// storedMetrics reads the recent cluster metrics that the web server's
+// collector saved for contextName and namespace. It returns nothing when the
+// database is not available.
+func (b *BriefingPanel) storedMetrics(ctx context.Context, contextName, namespace string, now time.Time) []db.ClusterMetrics {
+	if db.DB == nil {
+		return nil
+	}
+	b.mu.Lock()
+	if b.metricsStore == nil {
+		// A store that cannot be opened only costs the history
+		b.metricsStore, _ = db.NewMetricsStore()
+	}
+	store := b.metricsStore
+	b.mu.Unlock()
+	if store == nil {
+		return nil
+	}
+
+	rows, err := store.GetClusterMetrics(ctx, contextName, now.Add(-briefingTrendWindow), now, briefingTrendPoints)
+	if err != nil {
+		return nil
+	}
+	var scoped []db.ClusterMetrics
+	for _, m := range rows {
+		if m.Namespace == namespace {
+			scoped = append(scoped, m)
+		}
+	}
+	return scoped
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		lo, hi float64
+		width  int
+		want   string
+	}{
+		{"full range", []float64{0, 50, 100}, 0, 100, 0, "▁▅█"},
+		{"clamped", []float64{-10, 150}, 0, 100, 0, "▁█"},
+		{"flat range", []float64{7, 7, 7}, 7, 7, 0, "▄▄▄"},
+		{"keeps the newest", []float64{0, 0, 100, 100}, 0, 100, 2, "██"},
+		{"empty", nil, 0, 100, 10, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sparkline(tt.values, tt.lo, tt.hi, tt.width); got != tt.want {
+				t.Errorf("sparkline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValueRange(t *testing.T) {
+	lo, hi := valueRange([]float64{12, 4, 30, 9})
+	if lo != 4 || hi != 30 {
+		t.Errorf("valueRange() = %v, %v; want 4, 30", lo, hi)
+	}
+}
+
+func TestAddTrendSample(t *testing.T) {
+	start := time.Now()
+	var samples briefingTrend
+	samples = addTrendSample(samples, trendSample{At: start, Pods: 1})
+	samples = addTrendSample(samples, trendSample{At: start.Add(5 * time.Second), Pods: 2})
+	if len(samples) != 1 || samples[0].Pods != 2 {
+		t.Fatalf("a refresh within the step should replace the latest sample, got %+v", samples)
+	}
+
+	samples = addTrendSample(samples, trendSample{At: start.Add(time.Minute), Pods: 3})
+	if len(samples) != 2 {
+		t.Fatalf("a refresh after the step should add a sample, got %d", len(samples))
+	}
+
+	samples = addTrendSample(samples, trendSample{At: start.Add(briefingTrendWindow + 10*time.Second), Pods: 4})
+	if len(samples) != 2 || samples[0].Pods != 3 {
+		t.Errorf("samples older than the window should be dropped, got %+v", samples)
+	}
+}
+
+func TestMergeTrend(t *testing.T) {
+	now := time.Now()
+	// The metrics store returns newest first
+	stored := clusterMetricsTrend([]db.ClusterMetrics{
+		{Timestamp: now.Add(-time.Minute), TotalPods: 11, TotalCPUMillis: 4000, UsedCPUMillis: 1000, TotalMemoryMB: 1000, UsedMemoryMB: 500},
+		{Timestamp: now.Add(-2 * time.Minute), TotalPods: 10},
+	})
+	live := briefingTrend{
+		{At: now.Add(-90 * time.Second), Pods: 99}, // covered by the store
+		{At: now, Pods: 12},
+	}
+
+	trend := mergeTrend(stored, live)
+	pods := trend.pods()
+	if len(pods) != 3 || pods[0] != 10 || pods[1] != 11 || pods[2] != 12 {
+		t.Fatalf("mergeTrend() pods = %v, want [10 11 12]", pods)
+	}
+	if trend[1].CPUPercent != 25 || trend[1].MemoryPercent != 50 {
+		t.Errorf("stored sample = %.0f%% CPU, %.0f%% memory; want 25%%, 50%%", trend[1].CPUPercent, trend[1].MemoryPercent)
+	}
+
+	var many briefingTrend
+	for i := range briefingTrendPoints + 5 {
+		many = append(many, trendSample{At: now.Add(time.Duration(i) * time.Minute), Pods: i})
+	}
+	if got := mergeTrend(nil, many); len(got) != briefingTrendPoints || got[0].Pods != 5 {
+		t.Errorf("mergeTrend() should keep the newest %d points, got %d starting at %d", briefingTrendPoints, len(got), got[0].Pods)
+	}
+}
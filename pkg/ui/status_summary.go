@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxSummaryStatuses is how many statuses the summary bar names before
+// folding the rest into "N other"
+const maxSummaryStatuses = 6
+
+// statusCount is the number of rows with one status
+type statusCount struct {
+	Status string
+	Count  int
+}
+
+// statusColumnIndex returns the STATUS (or PHASE) column of headers, or -1
+func statusColumnIndex(headers []string) int {
+	for _, name := range []string{"STATUS", "PHASE"} {
+		for i, h := range headers {
+			if strings.EqualFold(h, name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// countStatuses counts rows by the status column, most common first. It
+// returns nil when headers have no status column.
+func countStatuses(headers []string, rows [][]string) []statusCount {
+	col := statusColumnIndex(headers)
+	if col < 0 {
+		return nil
+	}
+	counts := make(map[string]int)
+	for _, row := range rows {
+		status := "Unknown"
+		if col < len(row) && strings.TrimSpace(row[col]) != "" {
+			status = strings.TrimSpace(row[col])
+		}
+		counts[status]++
+	}
+	result := make([]statusCount, 0, len(counts))
+	for status, n := range counts {
+		result = append(result, statusCount{Status: status, Count: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Status < result[j].Status
+	})
+	return result
+}
+
+// statusSummaryText builds the summary bar text, e.g. "pods: 120 Running,
+// 3 Pending, 1 Failed in all namespaces". color returns the tview color tag
+// for a status, or "" for none. shown is the number of rows the filter
+// leaves, or -1 without a filter.
+func statusSummaryText(resource, namespace string, headers []string, rows [][]string, shown int, color func(string) string) string {
+	if resource == "" {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, " %s: ", resource)
+
+	counts := countStatuses(headers, rows)
+	if len(counts) == 0 {
+		fmt.Fprintf(&b, "%d", len(rows))
+	} else {
+		parts := make([]string, 0, maxSummaryStatuses+1)
+		other := 0
+		for i, c := range counts {
+			if i >= maxSummaryStatuses {
+				other += c.Count
+				continue
+			}
+			text := fmt.Sprintf("%d %s", c.Count, c.Status)
+			if tag := color(c.Status); tag != "" {
+				text = tag + text + "[-]"
+			}
+			parts = append(parts, text)
+		}
+		if other > 0 {
+			parts = append(parts, fmt.Sprintf("%d other", other))
+		}
+		fmt.Fprintf(&b, "%s (%d total)", strings.Join(parts, ", "), len(rows))
+	}
+
+	if namespace == "" {
+		b.WriteString(" in all namespaces")
+	} else {
+		fmt.Fprintf(&b, " in %s", namespace)
+	}
+	if shown >= 0 {
+		fmt.Fprintf(&b, " · %d shown", shown)
+	}
+	return b.String()
+}
+
+// updateSummaryBar shows the status counts of the loaded rows of the
+// current view. Call it on the UI goroutine after the table changes.
+func (a *App) updateSummaryBar() {
+	if a.summaryBar == nil {
+		return
+	}
+	a.mx.RLock()
+	resource := a.currentResource
+	namespace := a.currentNamespace
+	headers := a.tableHeaders
+	rows := a.tableRows
+	filter := a.filterText
+	a.mx.RUnlock()
+
+	shown := -1
+	if filter != "" {
+		shown = max(a.table.GetRowCount()-dataRowOffset, 0)
+	}
+	a.summaryBar.SetText(statusSummaryText(resource, namespace, headers, rows, shown, func(status string) string {
+		css := a.statusColor(status).CSS()
+		if css == "" {
+			return ""
+		}
+		return "[" + css + "]"
+	}))
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCountStatuses(t *testing.T) {
+	headers := []string{"NAMESPACE", "NAME", "STATUS", "READY"}
+	rows := [][]string{
+		{"default", "web-1", "Running", "1/1"},
+		{"default", "web-2", "Running", "1/1"},
+		{"default", "job-x", "Failed", "0/1"},
+		{"default", "db-0", "Pending", "0/1"},
+		{"default", "cache", "", "0/1"},
+		{"default", "api", "Running", "1/1"},
+	}
+	want := []statusCount{{"Running", 3}, {"Failed", 1}, {"Pending", 1}, {"Unknown", 1}}
+	if got := countStatuses(headers, rows); !slices.Equal(got, want) {
+		t.Errorf("countStatuses() = %v, want %v", got, want)
+	}
+	if got := countStatuses([]string{"NAME", "DATA"}, rows); got != nil {
+		t.Errorf("countStatuses(no status column) = %v, want nil", got)
+	}
+}
+
+func TestStatusSummaryText(t *testing.T) {
+	headers := []string{"NAME", "STATUS"}
+	rows := [][]string{{"a", "Running"}, {"b", "Running"}, {"c", "Failed"}}
+	noColor := func(string) string { return "" }
+
+	tests := []struct {
+		name      string
+		resource  string
+		namespace string
+		headers   []string
+		shown     int
+		color     func(string) string
+		want      string
+	}{
+		{"all namespaces", "pods", "", headers, -1, noColor, " pods: 2 Running, 1 Failed (3 total) in all namespaces"},
+		{"filtered", "pods", "default", headers, 1, noColor, " pods: 2 Running, 1 Failed (3 total) in default · 1 shown"},
+		{"colored", "pods", "default", headers, -1, func(s string) string {
+			if s == "Failed" {
+				return "[red]"
+			}
+			return ""
+		}, " pods: 2 Running, [red]1 Failed[-] (3 total) in default"},
+		{"no status column", "configmaps", "kube-system", []string{"NAME", "DATA"}, -1, noColor, " configmaps: 3 in kube-system"},
+		{"no resource", "", "", headers, -1, noColor, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusSummaryText(tt.resource, tt.namespace, tt.headers, rows, tt.shown, tt.color); got != tt.want {
+				t.Errorf("statusSummaryText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusSummaryTextFoldsRareStatuses(t *testing.T) {
+	var rows [][]string
+	for _, s := range []string{"A", "A", "B", "C", "D", "E", "F", "G", "H"} {
+		rows = append(rows, []string{"x", s})
+	}
+	got := statusSummaryText("pods", "ns", []string{"NAME", "STATUS"}, rows, -1, func(string) string { return "" })
+	want := " pods: 2 A, 1 B, 1 C, 1 D, 1 E, 1 F, 2 other (9 total) in ns"
+	if got != want {
+		t.Errorf("statusSummaryText() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/gdamore/tcell/v2"
+)
+
+// palette returns the active theme palette. Apps built without styles (tests,
+// partially-initialized apps) get the dark theme's palette.
+func (a *App) palette() config.PaletteStyle {
+	if a == nil || a.styles == nil {
+		return config.DefaultPalette()
+	}
+	return a.styles.K13s.Palette
+}
+
+// themeColor converts a palette color, falling back to the dark theme value
+// when the active skin leaves it empty.
+func themeColor(c, fallback config.Color) tcell.Color {
+	if c == "" {
+		c = fallback
+	}
+	return c.ToTcellColor()
+}
+
+// colorTag wraps a palette color in a tview dynamic color tag, e.g. "[#7aa2f7]".
+func colorTag(c config.Color) string {
+	if c == "" {
+		return "[-]"
+	}
+	return "[" + string(c) + "]"
+}
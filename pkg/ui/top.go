@@ -0,0 +1,257 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// topRefreshInterval is how often the :top view polls metrics-server, which
+// itself scrapes every 15s by default
+const topRefreshInterval = 5 * time.Second
+
+var (
+	topPodColumns  = []string{"NAMESPACE", "POD", "NODE", "CPU", "MEMORY"}
+	topNodeColumns = []string{"NODE", "CPU", "MEMORY", "PODS", "POD CPU", "POD MEMORY"}
+)
+
+// nodeTopCPU and nodeTopMem return the node's measured usage, or the rollup
+// of its pods when metrics-server has no node metrics
+func nodeTopCPU(nu k8s.NodeUsage) int64 {
+	if nu.UsageAvailable {
+		return nu.CPUMilli
+	}
+	return nu.PodCPUMilli
+}
+
+func nodeTopMem(nu k8s.NodeUsage) int64 {
+	if nu.UsageAvailable {
+		return nu.MemMB
+	}
+	return nu.PodMemMB
+}
+
+// sortTopUsage orders pods and nodes from the largest CPU or memory consumer
+// down, keeping the name order from k8s.ComputeTopUsage for ties
+func sortTopUsage(usage *k8s.TopUsage, byMemory bool) {
+	sort.SliceStable(usage.Pods, func(i, j int) bool {
+		if byMemory {
+			return usage.Pods[i].MemMB > usage.Pods[j].MemMB
+		}
+		return usage.Pods[i].CPUMilli > usage.Pods[j].CPUMilli
+	})
+	sort.SliceStable(usage.Nodes, func(i, j int) bool {
+		if byMemory {
+			return nodeTopMem(usage.Nodes[i]) > nodeTopMem(usage.Nodes[j])
+		}
+		return nodeTopCPU(usage.Nodes[i]) > nodeTopCPU(usage.Nodes[j])
+	})
+}
+
+// formatCPUMilli renders millicores the way kubectl top does, e.g. "250m"
+func formatCPUMilli(milli int64) string {
+	return fmt.Sprintf("%dm", milli)
+}
+
+// topPodRow returns the table cells for one pod
+func topPodRow(pu k8s.PodUsage) []string {
+	node := pu.Node
+	if node == "" {
+		node = "-"
+	}
+	return []string{
+		tview.Escape(pu.Namespace),
+		tview.Escape(pu.Name),
+		tview.Escape(node),
+		formatCPUMilli(pu.CPUMilli),
+		formatMemoryValueMB(pu.MemMB),
+	}
+}
+
+// topNodeRow returns the table cells for one node, with usage as a share of
+// allocatable when metrics-server reports the node
+func topNodeRow(nu k8s.NodeUsage) []string {
+	cpu, mem := "-", "-"
+	if nu.UsageAvailable {
+		cpu = formatCapacityCell(nu.CPUMilli, nu.CPUAllocatableMilli, formatCPUMilli)
+		mem = formatCapacityCell(nu.MemMB, nu.MemAllocatableMB, formatMemoryValueMB)
+	}
+	return []string{
+		tview.Escape(nu.Name),
+		cpu,
+		mem,
+		fmt.Sprintf("%d", nu.Pods),
+		formatCPUMilli(nu.PodCPUMilli),
+		formatMemoryValueMB(nu.PodMemMB),
+	}
+}
+
+// showTop shows the top CPU and memory consumers across all namespaces
+// (:top), kubectl top style, with a Pods tab and a Nodes tab that rolls pod
+// usage up per node. It refreshes every topRefreshInterval while open.
+func (a *App) showTop() {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	var (
+		usage    *k8s.TopUsage
+		loadErr  error
+		showNode bool
+		byMemory bool
+	)
+
+	// render redraws the current tab; it runs on the UI goroutine only
+	render := func() {
+		selected, _ := table.GetSelection()
+		table.Clear()
+
+		tabs := "[yellow::b]Pods[-::-] | Nodes"
+		columns := topPodColumns
+		if showNode {
+			tabs = "Pods | [yellow::b]Nodes[-::-]"
+			columns = topNodeColumns
+		}
+		sortBy := "CPU"
+		if byMemory {
+			sortBy = "memory"
+		}
+		table.SetTitle(fmt.Sprintf(" Top: %s (by %s, every %s) [gray](Tab:switch c:cpu m:memory Enter:pods r:refresh Esc:close)[white] ",
+			tabs, sortBy, topRefreshInterval))
+		for col, header := range columns {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorYellow).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+		}
+
+		switch {
+		case loadErr != nil:
+			table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("[red]Failed to load metrics (is metrics-server installed?): %s[white]", tview.Escape(loadErr.Error()))).SetSelectable(false))
+			return
+		case usage == nil:
+			table.SetCell(1, 0, tview.NewTableCell("[gray]Loading metrics...[white]").SetSelectable(false))
+			return
+		}
+
+		rows := 0
+		if showNode {
+			for i, nu := range usage.Nodes {
+				for col, text := range topNodeRow(nu) {
+					table.SetCell(i+1, col, tview.NewTableCell(text).SetReference(nu))
+				}
+			}
+			rows = len(usage.Nodes)
+		} else {
+			for i, pu := range usage.Pods {
+				for col, text := range topPodRow(pu) {
+					table.SetCell(i+1, col, tview.NewTableCell(text).SetReference(pu))
+				}
+			}
+			rows = len(usage.Pods)
+		}
+		if rows == 0 {
+			table.SetCell(1, 0, tview.NewTableCell("[gray]No metrics reported[white]").SetSelectable(false))
+			return
+		}
+		// Keep the cursor in place across live refreshes
+		table.Select(min(max(selected, 1), rows), 0)
+	}
+
+	refresh := func() {
+		a.safeGo("top", func() {
+			var (
+				next *k8s.TopUsage
+				err  error
+			)
+			if a.k8s == nil {
+				err = fmt.Errorf("not connected to a cluster")
+			} else {
+				ctx, cancel := context.WithTimeout(a.getAppContext(), 15*time.Second)
+				next, err = a.k8s.GetTopUsage(ctx)
+				cancel()
+			}
+			a.QueueUpdateDraw(func() {
+				if err == nil {
+					sortTopUsage(next, byMemory)
+				}
+				usage, loadErr = next, err
+				render()
+			})
+		})
+	}
+
+	viewCtx, stopRefresh := context.WithCancel(a.getAppContext())
+	closeView := func() {
+		stopRefresh()
+		a.closeModal("top")
+		a.SetFocus(a.table)
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeView()
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			showNode = !showNode
+			table.Select(1, 0)
+			render()
+			return nil
+		case tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			switch ref := table.GetCell(row, 0).GetReference().(type) {
+			case k8s.PodUsage:
+				closeView()
+				a.navigateTo("pods", ref.Namespace, ref.Name)
+			case k8s.NodeUsage:
+				closeView()
+				// Lists the node's pods through a server-side field selector
+				a.navigateTo("pods", "", "spec.nodeName="+ref.Name)
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'c', 'm':
+				byMemory = event.Rune() == 'm'
+				if usage != nil {
+					sortTopUsage(usage, byMemory)
+				}
+				render()
+				return nil
+			case 'r':
+				refresh()
+				return nil
+			case 'q':
+				closeView()
+				return nil
+			}
+		}
+		return event
+	})
+
+	render()
+	a.showModal("top", centered(table, 130, 30), true)
+	a.SetFocus(table)
+	refresh()
+
+	a.safeGo("top-ticker", func() {
+		ticker := time.NewTicker(topRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-viewCtx.Done():
+				return
+			case <-ticker.C:
+				refresh()
+			}
+		}
+	})
+}
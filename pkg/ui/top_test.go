@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+func TestSortTopUsage(t *testing.T) {
+	usage := &k8s.TopUsage{
+		Pods: []k8s.PodUsage{
+			{Namespace: "a", Name: "small", CPUMilli: 10, MemMB: 900},
+			{Namespace: "b", Name: "busy", CPUMilli: 800, MemMB: 100},
+			{Namespace: "c", Name: "mid", CPUMilli: 300, MemMB: 300},
+		},
+		Nodes: []k8s.NodeUsage{
+			{Name: "measured", UsageAvailable: true, CPUMilli: 500, MemMB: 1000, PodCPUMilli: 5000},
+			{Name: "rollup", PodCPUMilli: 900, PodMemMB: 200},
+		},
+	}
+
+	sortTopUsage(usage, false)
+	if got := usage.Pods[0].Name + "," + usage.Pods[1].Name + "," + usage.Pods[2].Name; got != "busy,mid,small" {
+		t.Errorf("pods by CPU = %s, want busy,mid,small", got)
+	}
+	// Nodes without metrics fall back to the rollup of their pods
+	if usage.Nodes[0].Name != "rollup" {
+		t.Errorf("nodes by CPU = %+v, want rollup first", usage.Nodes)
+	}
+
+	sortTopUsage(usage, true)
+	if got := usage.Pods[0].Name + "," + usage.Pods[1].Name + "," + usage.Pods[2].Name; got != "small,mid,busy" {
+		t.Errorf("pods by memory = %s, want small,mid,busy", got)
+	}
+	if usage.Nodes[0].Name != "measured" {
+		t.Errorf("nodes by memory = %+v, want measured first", usage.Nodes)
+	}
+}
+
+func TestTopRows(t *testing.T) {
+	pod := topPodRow(k8s.PodUsage{Namespace: "default", Name: "api", CPUMilli: 250, MemMB: 512})
+	want := []string{"default", "api", "-", "250m", "512Mi"}
+	if len(pod) != len(topPodColumns) {
+		t.Fatalf("topPodRow() returned %d cells, want %d", len(pod), len(topPodColumns))
+	}
+	for i := range want {
+		if pod[i] != want[i] {
+			t.Errorf("%s = %q, want %q", topPodColumns[i], pod[i], want[i])
+		}
+	}
+
+	nu := k8s.NodeUsage{Name: "node-1", CPUAllocatableMilli: 4000, MemAllocatableMB: 8192, Pods: 3, PodCPUMilli: 1200, PodMemMB: 2048}
+	node := topNodeRow(nu)
+	want = []string{"node-1", "-", "-", "3", "1200m", "2Gi"}
+	if len(node) != len(topNodeColumns) {
+		t.Fatalf("topNodeRow() returned %d cells, want %d", len(node), len(topNodeColumns))
+	}
+	for i := range want {
+		if node[i] != want[i] {
+			t.Errorf("%s = %q, want %q", topNodeColumns[i], node[i], want[i])
+		}
+	}
+
+	nu.UsageAvailable, nu.CPUMilli, nu.MemMB = true, 1500, 4096
+	node = topNodeRow(nu)
+	if node[1] != "1500m 37%" || node[2] != "4Gi 50%" {
+		t.Errorf("usage cells = %q, %q; want measured usage of allocatable", node[1], node[2])
+	}
+}
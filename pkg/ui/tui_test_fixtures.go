@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
@@ -94,6 +95,7 @@ func NewTestApp(cfg TestAppConfig) *App {
 		aiInputHistoryIdx:   -1,
 		pendingToolApproval: make(chan bool, 1),
 		logger:              logger,
+		startedAt:           time.Now(),
 		mx:                  sync.RWMutex{},
 		navMx:               sync.Mutex{},
 		aiMx:                sync.RWMutex{},
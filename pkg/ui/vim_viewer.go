@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -29,13 +30,22 @@ type VimViewer struct {
 
 	// Secret decode toggle
 	isSecretView  bool   // True when viewing a Secret resource
-	secretDecoded bool   // True when base64 values are decoded
+	secretDecoded bool   // True when values are revealed (decoded)
 	rawYAML       string // Original YAML content for secret toggle
+	secretPath    string // namespace/name of the Secret, for reveal audit
 
 	// Log viewer enhancements
-	isLogView  bool // True when viewing logs
-	autoScroll bool // Toggle with 's'
-	textWrap   bool // Toggle with 'w'
+	isLogView   bool            // True when viewing logs
+	autoScroll  bool            // Toggle with 's'
+	textWrap    bool            // Toggle with 'w'
+	highlighter *logHighlighter // Colors severities and stack traces, nil when off
+	colorLogs   bool            // Toggle with 'c'
+
+	// back gets the focus when the viewer closes; nil is the main table
+	back tview.Primitive
+	// explain, when set, is run by x after the viewer closes, e.g. to ask
+	// the AI about a crash
+	explain func()
 }
 
 // NewVimViewer creates a new viewer with Vim-style keybindings
@@ -68,7 +78,31 @@ func (v *VimViewer) SetContent(content string) {
 	v.totalLines = len(v.lines)
 	v.mu.Unlock()
 	v.TextView.Clear()
-	v.TextView.SetText(content)
+	v.render()
+}
+
+// enableLogHighlight colors the log view with the built-in and configured
+// patterns; invalid patterns are reported and skipped
+func (v *VimViewer) enableLogHighlight(cfg *config.Config) {
+	var hlCfg config.LogHighlightConfig
+	if cfg != nil {
+		hlCfg = cfg.LogHighlight
+	}
+	h, errs := newLogHighlighter(hlCfg)
+	if len(errs) > 0 && v.app != nil {
+		v.app.flashMsg(errs[0].Error(), true)
+	}
+	v.highlighter = h
+	v.colorLogs = !hlCfg.Disabled
+}
+
+// render shows the content with search matches and, in a log view, log colors
+func (v *VimViewer) render() {
+	if v.isLogView && v.colorLogs && v.highlighter != nil {
+		v.TextView.SetText(v.highlighter.highlight(v.content, v.searchRegex))
+		return
+	}
+	v.highlightMatches()
 }
 
 // setupInputCapture configures Vim-style keybindings
@@ -156,12 +190,29 @@ func (v *VimViewer) setupInputCapture() {
 				}
 				return nil
 
+			case 'y':
+				// Copy the buffer as shown (masked or revealed for Secrets)
+				if v.app != nil {
+					what := map[string]string{"yaml": "YAML", "describe": "describe output", "logs": "logs"}[v.pageName]
+					if what == "" {
+						what = v.pageName + " output"
+					}
+					v.app.copyToClipboard(what, v.TextView.GetText(true))
+				}
+				return nil
+
 			case 'x':
 				// Toggle secret base64 decode
 				if v.isSecretView {
 					v.toggleSecretDecode()
 					return nil
 				}
+				// Explain the logs with AI
+				if v.explain != nil {
+					v.close()
+					v.explain()
+					return nil
+				}
 
 			case 's':
 				// Toggle auto-scroll for log view
@@ -192,10 +243,21 @@ func (v *VimViewer) setupInputCapture() {
 					return nil
 				}
 
+			case 'c':
+				// Toggle log highlighting
+				if v.isLogView && v.highlighter != nil {
+					v.colorLogs = !v.colorLogs
+					v.render()
+					v.updateTitle()
+					return nil
+				}
+
 			case 'm':
 				// Insert visual separator mark in log view
 				if v.isLogView {
-					current := v.TextView.GetText(false)
+					v.mu.RLock()
+					current := v.content
+					v.mu.RUnlock()
 					separator := "\n────────── mark ──────────\n"
 					v.SetContent(current + separator)
 					v.ScrollToEnd()
@@ -211,22 +273,94 @@ func (v *VimViewer) setupInputCapture() {
 // close closes the viewer and returns focus to the table
 func (v *VimViewer) close() {
 	v.app.closeModal(v.pageName)
-	v.app.SetFocus(v.app.table)
+	v.app.SetFocus(v.app.focusOrTable(v.back))
+}
+
+// setSecretYAML stores the Secret manifest and shows it with values masked
+func (v *VimViewer) setSecretYAML(yamlContent string) {
+	v.rawYAML = yamlContent
+	v.secretDecoded = false
+	v.SetContent(maskSecretYAML(yamlContent))
+	v.updateTitle()
 }
 
-// toggleSecretDecode switches between encoded/decoded Secret values
+// toggleSecretDecode switches between masked and revealed Secret values.
+// Revealing goes through the app's secret reveal policy and is audited.
 func (v *VimViewer) toggleSecretDecode() {
+	if v.rawYAML == "" {
+		return
+	}
+	if !v.secretDecoded && v.app != nil && !v.app.revealSecret(v.secretPath) {
+		return
+	}
 	v.secretDecoded = !v.secretDecoded
 	if v.secretDecoded {
 		v.SetContent(decodeSecretYAML(v.rawYAML))
 	} else {
-		v.SetContent(v.rawYAML)
+		v.SetContent(maskSecretYAML(v.rawYAML))
 	}
 	v.updateTitle()
 }
 
+// lastAppliedAnnotation holds the manifest kubectl apply last sent, which for
+// a Secret includes its data and stringData values
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration:"
+
+// maskSecretYAML replaces every value in the data: section and the
+// last-applied-configuration annotation with secretMask
+func maskSecretYAML(yamlContent string) string {
+	masked := mapSecretData(yamlContent, func(string) (string, bool) {
+		return secretMask, true
+	})
+	return maskLastApplied(masked)
+}
+
+// maskLastApplied replaces the value of the last-applied-configuration
+// annotation, including any continuation lines of a block or wrapped
+// scalar, with secretMask
+func maskLastApplied(yamlContent string) string {
+	lines := strings.Split(yamlContent, "\n")
+	var result []string
+	skipIndent := -1
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		// Drop the rest of the annotation value
+		if skipIndent >= 0 {
+			if trimmed == "" || indent > skipIndent {
+				continue
+			}
+			skipIndent = -1
+		}
+
+		if strings.HasPrefix(trimmed, lastAppliedAnnotation) {
+			result = append(result, line[:indent]+lastAppliedAnnotation+" "+secretMask)
+			skipIndent = indent
+			continue
+		}
+
+		result = append(result, line)
+	}
+
+	return strings.Join(result, "\n")
+}
+
 // decodeSecretYAML finds base64-encoded values in the data: section and decodes them
 func decodeSecretYAML(yamlContent string) string {
+	return mapSecretData(yamlContent, func(value string) (string, bool) {
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", false
+		}
+		return string(decoded), true
+	})
+}
+
+// mapSecretData rewrites the values of key: value pairs in the data: section
+// with fn. Lines for which fn returns false are kept unchanged.
+func mapSecretData(yamlContent string, fn func(value string) (string, bool)) string {
 	lines := strings.Split(yamlContent, "\n")
 	var result []string
 	inDataSection := false
@@ -264,10 +398,8 @@ func decodeSecretYAML(yamlContent string) string {
 			if len(parts) == 2 {
 				value := strings.TrimSpace(parts[1])
 				if value != "" && value != "|" && value != ">" {
-					decoded, err := base64.StdEncoding.DecodeString(value)
-					if err == nil {
-						// Replace with decoded value, mark it
-						result = append(result, fmt.Sprintf("%s: %s", parts[0], string(decoded)))
+					if replaced, ok := fn(value); ok {
+						result = append(result, fmt.Sprintf("%s: %s", parts[0], replaced))
 						continue
 					}
 				}
@@ -401,7 +533,7 @@ func (v *VimViewer) executeSearch(pattern string) {
 	}
 
 	// Update display with highlighted matches
-	v.highlightMatches()
+	v.render()
 
 	// Jump to first match
 	if len(v.searchMatches) > 0 {
@@ -433,7 +565,7 @@ func (v *VimViewer) clearSearch() {
 	v.searchRegex = nil
 	v.searchMatches = nil
 	v.currentMatch = -1
-	v.TextView.SetText(v.content)
+	v.render()
 	v.updateTitle()
 }
 
@@ -492,9 +624,10 @@ func (v *VimViewer) updateTitle() {
 	// Secret decode indicator
 	if v.isSecretView {
 		if v.secretDecoded {
-			suffix += " [green][decoded][white]"
+			suffix += " [red][revealed][white] [gray](x:mask)[white]"
+		} else {
+			suffix += " [green][masked][white] [gray](x:reveal)[white]"
 		}
-		suffix += " [gray](x:toggle decode)[white]"
 	}
 
 	// Log viewer flags
@@ -506,6 +639,9 @@ func (v *VimViewer) updateTitle() {
 		if v.textWrap {
 			flags = append(flags, "wrap")
 		}
+		if v.colorLogs && v.highlighter != nil {
+			flags = append(flags, "color")
+		}
 		if len(flags) > 0 {
 			suffix += " [yellow][" + strings.Join(flags, ",") + "][white]"
 		}
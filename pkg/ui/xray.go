@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	appsv1 "k8s.io/api/apps/v1"
@@ -29,7 +30,7 @@ type XRayNode struct {
 // NewXRayView creates a new XRay view showing resource ownership hierarchy
 func NewXRayView(app *App, resourceType, namespace string) *XRayView {
 	root := tview.NewTreeNode(fmt.Sprintf("XRay: %s", resourceType)).
-		SetColor(tcell.NewRGBColor(122, 162, 247)) // #7aa2f7 blue
+		SetColor(themeColor(app.palette().Accent, config.DefaultPalette().Accent))
 
 	tree := tview.NewTreeView().
 		SetRoot(root).
@@ -121,7 +122,7 @@ func (x *XRayView) buildDeploymentTree(ctx context.Context, k interface{}, names
 	}
 
 	tree := BuildDeploymentTree(deps, rsList, pods)
-	addXRayNodesToTree(x.root, tree)
+	addXRayNodesToTree(x.root, tree, x.app.palette())
 }
 
 // buildStatefulSetTree creates: StatefulSet → Pod hierarchy
@@ -140,7 +141,7 @@ func (x *XRayView) buildStatefulSetTree(ctx context.Context, k interface{}, name
 	}
 
 	tree := BuildStatefulSetTree(stses, pods)
-	addXRayNodesToTree(x.root, tree)
+	addXRayNodesToTree(x.root, tree, x.app.palette())
 }
 
 // buildJobTree creates: Job → Pod hierarchy
@@ -159,7 +160,7 @@ func (x *XRayView) buildJobTree(ctx context.Context, k interface{}, namespace st
 	}
 
 	tree := BuildJobTree(jobs, pods)
-	addXRayNodesToTree(x.root, tree)
+	addXRayNodesToTree(x.root, tree, x.app.palette())
 }
 
 // buildCronJobTree creates: CronJob → Job → Pod hierarchy
@@ -183,7 +184,7 @@ func (x *XRayView) buildCronJobTree(ctx context.Context, k interface{}, namespac
 	}
 
 	tree := BuildCronJobTree(cjs, jobs, pods)
-	addXRayNodesToTree(x.root, tree)
+	addXRayNodesToTree(x.root, tree, x.app.palette())
 }
 
 // buildDaemonSetTree creates: DaemonSet → Pod hierarchy
@@ -202,7 +203,7 @@ func (x *XRayView) buildDaemonSetTree(ctx context.Context, k interface{}, namesp
 	}
 
 	tree := BuildDaemonSetTree(dss, pods)
-	addXRayNodesToTree(x.root, tree)
+	addXRayNodesToTree(x.root, tree, x.app.palette())
 }
 
 // --- Tree building functions (exported for testing) ---
@@ -423,7 +424,8 @@ func jobStatusText(job batchv1.Job) string {
 }
 
 // addXRayNodesToTree recursively adds XRayNode children to a tview.TreeNode
-func addXRayNodesToTree(parent *tview.TreeNode, nodes []*XRayNode) {
+func addXRayNodesToTree(parent *tview.TreeNode, nodes []*XRayNode, p config.PaletteStyle) {
+	def := config.DefaultPalette()
 	for _, n := range nodes {
 		child := tview.NewTreeNode(n.Text).
 			SetSelectable(true).
@@ -431,16 +433,16 @@ func addXRayNodesToTree(parent *tview.TreeNode, nodes []*XRayNode) {
 
 		// Color the tree node based on content
 		if strings.Contains(n.Text, "[red]") {
-			child.SetColor(tcell.NewRGBColor(247, 118, 142)) // red
+			child.SetColor(themeColor(p.Error, def.Error))
 		} else if strings.Contains(n.Text, "[yellow]") {
-			child.SetColor(tcell.NewRGBColor(224, 175, 104)) // yellow
+			child.SetColor(themeColor(p.Warning, def.Warning))
 		} else if strings.Contains(n.Text, "[green]") {
-			child.SetColor(tcell.NewRGBColor(158, 206, 106)) // green
+			child.SetColor(themeColor(p.Success, def.Success))
 		} else {
-			child.SetColor(tcell.NewRGBColor(192, 202, 245)) // primary text
+			child.SetColor(themeColor(p.Text, def.Text))
 		}
 
-		addXRayNodesToTree(child, n.Children)
+		addXRayNodesToTree(child, n.Children, p)
 		parent.AddChild(child)
 	}
 }
@@ -1,3 +1,5 @@
+//go:build !noai
+
 package web
 
 import (
@@ -101,3 +103,20 @@ func appendIfMissing(items []string, want string) []string {
 	}
 	return append(items, want)
 }
+
+// ==========================================
+// Command Classification
+// ==========================================
+
+// classifyCommand categorizes a kubectl command for safety.
+// This function now uses the unified safety.Classifier which provides
+// consistent classification across TUI and Web UI, including detection
+// of piped commands, chained commands, and file redirects.
+//
+// Deprecated: For new code, use safety.Classify() directly for full classification
+// or safety.Evaluate() for policy-based decisions.
+func classifyCommand(command string) string {
+	// Use unified classifier from safety package
+	classification := safety.Classify(command)
+	return classification.Category
+}
@@ -107,6 +107,7 @@ func getServerMux(s *Server) http.Handler {
 	mux.HandleFunc("/api/metrics/collect", s.authManager.AuthMiddleware(s.handleMetricsCollectNow))
 	mux.HandleFunc("/api/security/scan", s.authManager.AuthMiddleware(s.handleSecurityScan))
 	mux.HandleFunc("/api/security/scan/quick", s.authManager.AuthMiddleware(s.handleSecurityQuickScan))
+	mux.HandleFunc("/api/security/scan/latest", s.authManager.AuthMiddleware(s.handleSecurityScanLatest))
 	mux.HandleFunc("/api/security/scans", s.authManager.AuthMiddleware(s.handleSecurityScanHistory))
 	mux.HandleFunc("/api/security/scans/stats", s.authManager.AuthMiddleware(s.handleSecurityScanStats))
 	mux.HandleFunc("/api/portforward/start", s.authManager.AuthMiddleware(s.handlePortForwardStart))
@@ -209,6 +210,7 @@ func TestAPIEndpointsExist(t *testing.T) {
 		// Security scan endpoints
 		{http.MethodGet, "/api/security/scan", "", []int{http.StatusOK}, "Security scan"},
 		{http.MethodGet, "/api/security/scan/quick", "", []int{http.StatusOK}, "Quick security scan"},
+		{http.MethodGet, "/api/security/scan/latest", "", []int{http.StatusOK}, "Latest scheduled security scan"},
 		{http.MethodGet, "/api/security/scans", "", []int{http.StatusOK}, "Security scan history"},
 		{http.MethodGet, "/api/security/scans/stats", "", []int{http.StatusOK}, "Security scan stats"},
 
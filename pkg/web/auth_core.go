@@ -39,8 +39,15 @@ type Session struct {
 	Source    string    `json:"source"` // local, ldap
 	CreatedAt time.Time `json:"created_at"`
 	ExpiresAt time.Time `json:"expires_at"`
+	// LastActivity is the time of the last request made by the user, as
+	// opposed to background polling; zero means CreatedAt
+	LastActivity time.Time `json:"last_activity"`
 }
 
+// backgroundRequestHeader marks requests the web UI sends on its own, such as
+// auto-refresh polling, so they do not count as activity for IdleTimeout
+const backgroundRequestHeader = "X-K13D-Background"
+
 // AuthManager handles authentication
 type AuthManager struct {
 	users          map[string]*User     // username -> User
@@ -63,6 +70,8 @@ type AuthManager struct {
 type AuthConfig struct {
 	Enabled         bool          `yaml:"enabled" json:"enabled"`
 	SessionDuration time.Duration `yaml:"session_duration" json:"session_duration"`
+	// IdleTimeout ends sessions without user activity for this long (0 disables)
+	IdleTimeout     time.Duration `yaml:"idle_timeout" json:"idle_timeout"`
 	DefaultAdmin    string        `yaml:"default_admin" json:"default_admin"`
 	DefaultPassword string        `yaml:"default_password" json:"-"`
 	LDAP            *LDAPConfig   `yaml:"ldap" json:"ldap"`
@@ -186,22 +195,50 @@ func (am *AuthManager) GetAuthMode() string {
 
 // ValidateSession checks if a session ID is valid and returns the session
 func (am *AuthManager) ValidateSession(sessionID string) (*Session, error) {
-	am.mu.RLock()
-	defer am.mu.RUnlock()
+	return am.validateSession(sessionID, true)
+}
+
+// validateSession checks a session against its lifetime and idle timeout,
+// recording activity when active is set
+func (am *AuthManager) validateSession(sessionID string, active bool) (*Session, error) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
 
 	session, ok := am.sessions[sessionID]
 	if !ok {
 		return nil, fmt.Errorf("session not found")
 	}
 
-	if time.Now().After(session.ExpiresAt) {
+	now := time.Now()
+	if err := am.checkSessionExpiry(session, now); err != nil {
 		delete(am.sessions, sessionID)
-		return nil, fmt.Errorf("session expired")
+		return nil, err
+	}
+	if active {
+		session.LastActivity = now
 	}
 
 	return session, nil
 }
 
+// checkSessionExpiry reports whether a session has passed its absolute
+// lifetime or its idle timeout
+func (am *AuthManager) checkSessionExpiry(session *Session, now time.Time) error {
+	if now.After(session.ExpiresAt) {
+		return fmt.Errorf("session expired")
+	}
+	if am.config.IdleTimeout > 0 {
+		lastActivity := session.LastActivity
+		if lastActivity.IsZero() {
+			lastActivity = session.CreatedAt
+		}
+		if now.Sub(lastActivity) > am.config.IdleTimeout {
+			return fmt.Errorf("session idle timeout")
+		}
+	}
+	return nil
+}
+
 // InvalidateSession removes a session
 func (am *AuthManager) InvalidateSession(sessionID string) {
 	am.mu.Lock()
@@ -280,6 +317,7 @@ func (am *AuthManager) AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 			WriteError(w, NewAPIError(ErrCodeUnauthorized, "Unauthorized"))
 			return
 		}
+		active := r.Header.Get(backgroundRequestHeader) == ""
 
 		// Try JWT validation first (Teleport-inspired short-lived tokens)
 		if am.jwtManager != nil {
@@ -291,6 +329,15 @@ func (am *AuthManager) AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 					return
 				}
 
+				// The login session behind the JWT enforces logout, the idle
+				// timeout and the absolute lifetime
+				if claims.SessionID != "" {
+					if _, err := am.validateSession(claims.SessionID, active); err != nil {
+						WriteError(w, NewAPIError(ErrCodeUnauthorized, "Unauthorized: "+err.Error()))
+						return
+					}
+				}
+
 				r.Header.Set("X-User-ID", claims.Subject)
 				r.Header.Set("X-Username", claims.Username)
 				r.Header.Set("X-User-Role", claims.Role)
@@ -309,7 +356,7 @@ func (am *AuthManager) AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 			// JWT validation failed, fall through to opaque session
 		}
 
-		session, err := am.ValidateSession(sessionID)
+		session, err := am.validateSession(sessionID, active)
 		if err != nil {
 			WriteError(w, NewAPIError(ErrCodeUnauthorized, "Unauthorized: "+err.Error()))
 			return
@@ -362,6 +409,7 @@ func (am *AuthManager) HandleAuthStatus(w http.ResponseWriter, r *http.Request)
 		"oidc_configured":  am.oidcProvider != nil,
 		"token_available":  am.tokenValidator != nil,
 		"session_duration": am.config.SessionDuration.String(),
+		"idle_timeout":     am.config.IdleTimeout.String(),
 		"total_users":      len(am.users),
 		"active_sessions":  len(am.sessions),
 		"environment":      environment,
@@ -454,12 +502,12 @@ func (am *AuthManager) cleanupExpiredSessions() {
 	defer am.mu.Unlock()
 	now := time.Now()
 	for id, session := range am.sessions {
-		if now.After(session.ExpiresAt) {
+		if am.checkSessionExpiry(session, now) != nil {
 			delete(am.sessions, id)
 		}
 	}
 	for token, session := range am.tokenSessions {
-		if now.After(session.ExpiresAt) {
+		if am.checkSessionExpiry(session, now) != nil {
 			delete(am.tokenSessions, token)
 		}
 	}
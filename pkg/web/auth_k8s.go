@@ -9,6 +9,7 @@ import (
 
 	"encoding/json"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	authv1 "k8s.io/api/authentication/v1"
 	authzv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,23 +37,22 @@ type K8sTokenValidator struct {
 	kubeconfigUser string
 }
 
-// NewK8sTokenValidator creates a new K8s token validator
+// NewK8sTokenValidator creates a new K8s token validator. It resolves the
+// cluster like the web server's Kubernetes client, so tokens are reviewed by
+// the cluster the server shows.
 func NewK8sTokenValidator() (*K8sTokenValidator, error) {
-	var config *rest.Config
-	var environment RuntimeEnvironment
-	var kubeconfigUser string
+	opts := webClientOptions()
+	config, source, err := k8s.ResolveRESTConfig(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
 
-	// Try in-cluster config first
-	config, err := rest.InClusterConfig()
-	if err == nil {
-		environment = RuntimeInCluster
-	} else {
-		// Fallback to kubeconfig for local development
+	environment := RuntimeInCluster
+	var kubeconfigUser string
+	if source != k8s.ConfigSourceInCluster {
+		// Local development with a kubeconfig
 		environment = RuntimeLocal
-		config, kubeconfigUser, err = loadKubeconfigWithUser()
-		if err != nil {
-			return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
-		}
+		kubeconfigUser = currentKubeconfigUser(opts)
 	}
 
 	clientset, err := kubernetes.NewForConfig(config)
@@ -68,37 +68,20 @@ func NewK8sTokenValidator() (*K8sTokenValidator, error) {
 	}, nil
 }
 
-// loadKubeconfigWithUser loads kubeconfig and extracts current user/context info
-func loadKubeconfigWithUser() (*rest.Config, string, error) {
-	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-	configOverrides := &clientcmd.ConfigOverrides{}
-	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
-
-	// Get the raw config to extract user info
+// currentKubeconfigUser returns the user of the kubeconfig's current
+// context, or the context name when it has none
+func currentKubeconfigUser(opts k8s.ClientOptions) string {
+	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(opts.LoadingRules(), &clientcmd.ConfigOverrides{})
 	rawConfig, err := kubeConfig.RawConfig()
 	if err != nil {
-		return nil, "", err
+		return ""
 	}
 
-	// Get current context and user
 	currentContext := rawConfig.CurrentContext
-	var username string
-	if ctx, ok := rawConfig.Contexts[currentContext]; ok {
-		username = ctx.AuthInfo
-		// If username is a path or complex, simplify it
-		if username == "" {
-			username = currentContext
-		}
-	} else {
-		username = currentContext
-	}
-
-	config, err := kubeConfig.ClientConfig()
-	if err != nil {
-		return nil, "", err
+	if ctx, ok := rawConfig.Contexts[currentContext]; ok && ctx.AuthInfo != "" {
+		return ctx.AuthInfo
 	}
-
-	return config, username, nil
+	return currentContext
 }
 
 // GetEnvironment returns the runtime environment
@@ -167,14 +150,19 @@ func (r *kubeTokenAccessReviewer) CanI(ctx context.Context, attrs authzv1.Resour
 	return result.Status.Allowed, nil
 }
 
+// roleRank orders roles by privilege: admin > user > custom > viewer.
+// Custom roles sit above viewer because mapping a group to one is a
+// deliberate grant, and below user because they are usually narrower.
 func roleRank(role string) int {
 	switch role {
 	case "admin":
-		return 3
+		return 4
 	case "user":
-		return 2
-	default:
+		return 3
+	case "viewer", "":
 		return 1
+	default:
+		return 2
 	}
 }
 
@@ -371,15 +359,19 @@ func buildTokenAccessReviewer(baseConfig *rest.Config, token string) (tokenAcces
 
 // ValidateK8sToken validates a Kubernetes service account token
 func (am *AuthManager) ValidateK8sToken(ctx context.Context, token string) (*Session, error) {
-	// Check cache first
-	am.mu.RLock()
+	// Check cache first. An entry past its lifetime or idle timeout is
+	// dropped, so the token is reviewed by the API server again.
+	am.mu.Lock()
 	if session, exists := am.tokenSessions[token]; exists {
-		if time.Now().Before(session.ExpiresAt) {
-			am.mu.RUnlock()
+		now := time.Now()
+		if am.checkSessionExpiry(session, now) == nil {
+			session.LastActivity = now
+			am.mu.Unlock()
 			return session, nil
 		}
+		delete(am.tokenSessions, token)
 	}
-	am.mu.RUnlock()
+	am.mu.Unlock()
 
 	// Validate with K8s API
 	if am.tokenValidator == nil {
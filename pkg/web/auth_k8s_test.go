@@ -3,8 +3,11 @@ package web
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	authzv1 "k8s.io/api/authorization/v1"
 )
 
@@ -117,3 +120,42 @@ func TestDetermineRoleFromTokenAccess_Error(t *testing.T) {
 		t.Fatal("expected determineRoleFromTokenAccess to return an error")
 	}
 }
+
+func TestNewK8sTokenValidator_UsesWebClientConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	data := `apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: alice
+current-context: dev
+users:
+- name: alice
+  user:
+    token: abc
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(k8s.EnvKubeconfig, path)
+
+	if !webClientOptions().PreferInCluster {
+		t.Error("the web server should prefer the in-cluster service account")
+	}
+	validator, err := NewK8sTokenValidator()
+	if err != nil {
+		t.Fatalf("NewK8sTokenValidator() error = %v", err)
+	}
+	if validator.GetEnvironment() != RuntimeLocal || validator.GetKubeconfigUser() != "alice" {
+		t.Errorf("environment %q, user %q; want local, alice", validator.GetEnvironment(), validator.GetKubeconfigUser())
+	}
+	if validator.restConfig.Host != "https://dev.example" {
+		t.Errorf("validator host = %q, want the --kubeconfig cluster", validator.restConfig.Host)
+	}
+}
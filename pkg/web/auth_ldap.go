@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/go-ldap/ldap/v3"
 )
 
@@ -33,6 +35,9 @@ type LDAPConfig struct {
 	UsernameAttr      string   `yaml:"username_attr" json:"username_attr"`             // e.g., "uid" or "sAMAccountName"
 	EmailAttr         string   `yaml:"email_attr" json:"email_attr"`                   // e.g., "mail"
 	DisplayNameAttr   string   `yaml:"display_name_attr" json:"display_name_attr"`     // e.g., "cn" or "displayName"
+	// GroupMappings maps a group DN or CN to a role; the highest-privilege match wins
+	GroupMappings map[string]string `yaml:"group_mappings" json:"group_mappings"`
+	DefaultRole   string            `yaml:"default_role" json:"default_role"` // Role for users matching no group (default: viewer)
 }
 
 // LDAPProvider handles LDAP authentication
@@ -48,6 +53,7 @@ type LDAPUser struct {
 	DisplayName string   `json:"display_name"`
 	DN          string   `json:"dn"`
 	Groups      []string `json:"groups"`
+	GroupDNs    []string `json:"group_dns,omitempty"`
 	Role        string   `json:"role"`
 }
 
@@ -66,6 +72,9 @@ func NewLDAPProvider(cfg *LDAPConfig) *LDAPProvider {
 	if cfg.DisplayNameAttr == "" {
 		cfg.DisplayNameAttr = "cn"
 	}
+	if cfg.DefaultRole == "" {
+		cfg.DefaultRole = "viewer"
+	}
 	if cfg.Port == 0 {
 		if cfg.UseTLS {
 			cfg.Port = 636
@@ -182,14 +191,15 @@ func (p *LDAPProvider) Authenticate(username, password string) (*LDAPUser, error
 	}
 
 	// Get user groups
-	groups, err := p.getUserGroups(conn, userDN)
+	groups, groupDNs, err := p.getUserGroups(conn, userDN)
 	if err != nil {
 		// Log error but don't fail authentication
 		groups = []string{}
+		groupDNs = []string{}
 	}
 
-	// Determine role based on group membership
-	role := p.determineRole(groups)
+	// Determine role based on group membership (mappings may use either DN or CN)
+	role := p.determineRole(append(append([]string{}, groups...), groupDNs...))
 
 	ldapUser := &LDAPUser{
 		Username:    userEntry.GetAttributeValue(p.config.UsernameAttr),
@@ -197,16 +207,17 @@ func (p *LDAPProvider) Authenticate(username, password string) (*LDAPUser, error
 		DisplayName: userEntry.GetAttributeValue(p.config.DisplayNameAttr),
 		DN:          userDN,
 		Groups:      groups,
+		GroupDNs:    groupDNs,
 		Role:        role,
 	}
 
 	return ldapUser, nil
 }
 
-// getUserGroups retrieves the groups a user belongs to
-func (p *LDAPProvider) getUserGroups(conn *ldap.Conn, userDN string) ([]string, error) {
+// getUserGroups retrieves the CNs and DNs of the groups a user belongs to
+func (p *LDAPProvider) getUserGroups(conn *ldap.Conn, userDN string) ([]string, []string, error) {
 	if p.config.GroupSearchBase == "" {
-		return []string{}, nil
+		return []string{}, []string{}, nil
 	}
 
 	searchFilter := p.config.GroupSearchFilter
@@ -227,52 +238,136 @@ func (p *LDAPProvider) getUserGroups(conn *ldap.Conn, userDN string) ([]string,
 
 	result, err := conn.Search(searchReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search for groups: %w", err)
+		return nil, nil, fmt.Errorf("failed to search for groups: %w", err)
 	}
 
 	groups := make([]string, 0, len(result.Entries))
+	groupDNs := make([]string, 0, len(result.Entries))
 	for _, entry := range result.Entries {
 		cn := entry.GetAttributeValue("cn")
 		if cn != "" {
 			groups = append(groups, cn)
 		}
+		if entry.DN != "" {
+			groupDNs = append(groupDNs, entry.DN)
+		}
 	}
 
-	return groups, nil
+	return groups, groupDNs, nil
 }
 
-// determineRole determines the user's role based on group membership
+// determineRole determines the user's role based on group membership.
+// Explicit group mappings and the admin/user/viewer group lists are all
+// considered; the highest-privilege match wins (see roleRank). Two custom
+// roles rank equally, so the one mapped from the alphabetically first group
+// wins. Users matching nothing get the configured default role.
 func (p *LDAPProvider) determineRole(groups []string) string {
 	groupSet := make(map[string]bool)
 	for _, g := range groups {
-		groupSet[strings.ToLower(g)] = true
+		groupSet[normalizeLDAPGroup(g)] = true
 	}
 
-	// Check admin groups first (highest priority)
-	for _, adminGroup := range p.config.AdminGroups {
-		if groupSet[strings.ToLower(adminGroup)] {
-			return "admin"
+	role := ""
+	matched := func(candidate string) {
+		if role == "" || roleRank(candidate) > roleRank(role) {
+			role = candidate
 		}
 	}
 
-	// Check user groups
-	for _, userGroup := range p.config.UserGroups {
-		if groupSet[strings.ToLower(userGroup)] {
-			return "user"
+	// Sort mapping keys so the first-matching custom role is deterministic
+	mappedGroups := make([]string, 0, len(p.config.GroupMappings))
+	for group := range p.config.GroupMappings {
+		mappedGroups = append(mappedGroups, group)
+	}
+	sort.Strings(mappedGroups)
+	for _, group := range mappedGroups {
+		if groupSet[normalizeLDAPGroup(group)] {
+			if mapped := normalizeLDAPRole(p.config.GroupMappings[group]); mapped != "" {
+				matched(mapped)
+			}
 		}
 	}
 
-	// Check viewer groups
+	for _, adminGroup := range p.config.AdminGroups {
+		if groupSet[normalizeLDAPGroup(adminGroup)] {
+			matched("admin")
+		}
+	}
+	for _, userGroup := range p.config.UserGroups {
+		if groupSet[normalizeLDAPGroup(userGroup)] {
+			matched("user")
+		}
+	}
 	for _, viewerGroup := range p.config.ViewerGroups {
-		if groupSet[strings.ToLower(viewerGroup)] {
-			return "viewer"
+		if groupSet[normalizeLDAPGroup(viewerGroup)] {
+			matched("viewer")
 		}
 	}
 
-	// Default to viewer if no specific group matched but user authenticated
+	if role != "" {
+		return role
+	}
+	if p.config.DefaultRole != "" {
+		return normalizeLDAPRole(p.config.DefaultRole)
+	}
 	return "viewer"
 }
 
+// normalizeLDAPGroup lowercases a group name or DN and strips whitespace
+// around DN components so "CN=Ops, DC=example" matches "cn=ops,dc=example".
+func normalizeLDAPGroup(group string) string {
+	parts := strings.Split(strings.TrimSpace(group), ",")
+	for i, part := range parts {
+		parts[i] = strings.ToLower(strings.TrimSpace(part))
+	}
+	return strings.Join(parts, ",")
+}
+
+// normalizeLDAPRole maps role aliases onto the authorizer's role names.
+// "editor" is accepted for the built-in "user" role.
+func normalizeLDAPRole(role string) string {
+	role = strings.TrimSpace(role)
+	if strings.EqualFold(role, "editor") {
+		return "user"
+	}
+	return role
+}
+
+// LDAPConfigFromYAML converts the config.yaml LDAP section into an LDAPConfig.
+// It returns nil when LDAP is not enabled.
+func LDAPConfigFromYAML(cfg config.LDAPConfigYAML) *LDAPConfig {
+	if !cfg.Enabled {
+		return nil
+	}
+	mappings := make(map[string]string, len(cfg.GroupMappings))
+	for group, role := range cfg.GroupMappings {
+		mappings[group] = role
+	}
+	return &LDAPConfig{
+		Enabled:           cfg.Enabled,
+		Host:              cfg.Host,
+		Port:              cfg.Port,
+		UseTLS:            cfg.UseTLS,
+		StartTLS:          cfg.StartTLS,
+		InsecureSkipTLS:   cfg.InsecureSkipTLS,
+		BindDN:            cfg.BindDN,
+		BindPassword:      cfg.BindPassword,
+		BaseDN:            cfg.BaseDN,
+		UserSearchFilter:  cfg.UserSearchFilter,
+		UserSearchBase:    cfg.UserSearchBase,
+		GroupSearchBase:   cfg.GroupSearchBase,
+		GroupSearchFilter: cfg.GroupSearchFilter,
+		AdminGroups:       append([]string(nil), cfg.AdminGroups...),
+		UserGroups:        append([]string(nil), cfg.UserGroups...),
+		ViewerGroups:      append([]string(nil), cfg.ViewerGroups...),
+		GroupMappings:     mappings,
+		DefaultRole:       cfg.DefaultRole,
+		UsernameAttr:      cfg.UsernameAttr,
+		EmailAttr:         cfg.EmailAttr,
+		DisplayNameAttr:   cfg.DisplayNameAttr,
+	}
+}
+
 // TestConnection tests the LDAP connection
 func (p *LDAPProvider) TestConnection() error {
 	p.mu.RLock()
@@ -331,6 +426,8 @@ func (p *LDAPProvider) GetConfig() *LDAPConfig {
 		AdminGroups:       p.config.AdminGroups,
 		UserGroups:        p.config.UserGroups,
 		ViewerGroups:      p.config.ViewerGroups,
+		GroupMappings:     p.config.GroupMappings,
+		DefaultRole:       p.config.DefaultRole,
 		UsernameAttr:      p.config.UsernameAttr,
 		EmailAttr:         p.config.EmailAttr,
 		DisplayNameAttr:   p.config.DisplayNameAttr,
@@ -349,6 +446,7 @@ func (am *AuthManager) AuthenticateLDAP(username, password string) (*Session, er
 	if err != nil {
 		return nil, err
 	}
+	ldapUser.Role = am.resolveLDAPRole(ldapUser.Role)
 
 	// Create or update local user cache
 	user, exists := am.users[username]
@@ -385,6 +483,28 @@ func (am *AuthManager) AuthenticateLDAP(username, password string) (*Session, er
 	return session, nil
 }
 
+// resolveLDAPRole falls back to the provider's default role when a group
+// mapping names a role the authorizer does not know about.
+func (am *AuthManager) resolveLDAPRole(role string) string {
+	if am.isKnownRole(role) {
+		return role
+	}
+	if am.ldapProvider != nil {
+		if fallback := normalizeLDAPRole(am.ldapProvider.GetConfig().DefaultRole); am.isKnownRole(fallback) {
+			return fallback
+		}
+	}
+	return "viewer"
+}
+
+// isKnownRole reports whether role is a built-in role or a registered custom role.
+func (am *AuthManager) isKnownRole(role string) bool {
+	if role == "admin" || role == "user" || role == "viewer" {
+		return true
+	}
+	return role != "" && am.roleValidator != nil && am.roleValidator(role)
+}
+
 // IsLDAPEnabled returns whether LDAP is enabled
 func (am *AuthManager) IsLDAPEnabled() bool {
 	return am.ldapProvider != nil && am.ldapProvider.IsEnabled()
@@ -419,14 +539,16 @@ func (am *AuthManager) HandleLDAPStatus(w http.ResponseWriter, r *http.Request)
 
 	ldapConfig := am.GetLDAPConfig()
 	_ = json.NewEncoder(w).Encode(map[string]interface{}{
-		"enabled":      true,
-		"host":         ldapConfig.Host,
-		"port":         ldapConfig.Port,
-		"use_tls":      ldapConfig.UseTLS,
-		"base_dn":      ldapConfig.BaseDN,
-		"admin_groups": ldapConfig.AdminGroups,
-		"user_groups":  ldapConfig.UserGroups,
-		"config":       ldapConfig,
+		"enabled":        true,
+		"host":           ldapConfig.Host,
+		"port":           ldapConfig.Port,
+		"use_tls":        ldapConfig.UseTLS,
+		"base_dn":        ldapConfig.BaseDN,
+		"admin_groups":   ldapConfig.AdminGroups,
+		"user_groups":    ldapConfig.UserGroups,
+		"group_mappings": ldapConfig.GroupMappings,
+		"default_role":   ldapConfig.DefaultRole,
+		"config":         ldapConfig,
 	})
 }
 
@@ -110,6 +110,7 @@ func (am *AuthManager) Authenticate(username, password string) (*Session, error)
 	if am.ldapProvider != nil && am.ldapProvider.IsEnabled() {
 		ldapUser, err := am.ldapProvider.Authenticate(username, password)
 		if err == nil {
+			ldapUser.Role = am.resolveLDAPRole(ldapUser.Role)
 			// LDAP auth successful - create or update local user cache
 			user, exists := am.users[username]
 			if !exists {
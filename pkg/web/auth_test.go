@@ -211,6 +211,113 @@ func TestAuthManager_InvalidateSession(t *testing.T) {
 	}
 }
 
+func TestAuthManager_SessionIdleTimeout(t *testing.T) {
+	am := NewAuthManager(&AuthConfig{
+		Quiet:           true,
+		Enabled:         true,
+		SessionDuration: time.Hour,
+		IdleTimeout:     30 * time.Minute,
+		AuthMode:        "local",
+		DefaultAdmin:    "admin",
+		DefaultPassword: "admin",
+	})
+	defer am.StopCleanup()
+
+	session, err := am.Authenticate("admin", "admin")
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	setLastActivity := func(d time.Duration) {
+		am.mu.Lock()
+		am.sessions[session.ID].LastActivity = time.Now().Add(-d)
+		am.mu.Unlock()
+	}
+	handler := am.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	request := func(background bool) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/k8s/pods", nil)
+		req.Header.Set("Authorization", "Bearer "+session.ID)
+		if background {
+			req.Header.Set(backgroundRequestHeader, "1")
+		}
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w.Code
+	}
+
+	// Background polling is authorized but does not reset the idle clock
+	setLastActivity(20 * time.Minute)
+	if code := request(true); code != http.StatusOK {
+		t.Fatalf("background request status = %d, want 200", code)
+	}
+	setLastActivity(29 * time.Minute)
+	if code := request(false); code != http.StatusOK {
+		t.Fatalf("active request status = %d, want 200", code)
+	}
+	am.mu.RLock()
+	idle := time.Since(am.sessions[session.ID].LastActivity)
+	am.mu.RUnlock()
+	if idle > time.Minute {
+		t.Errorf("active request did not record activity, idle for %s", idle)
+	}
+
+	setLastActivity(31 * time.Minute)
+	if code := request(false); code != http.StatusUnauthorized {
+		t.Errorf("request after idle timeout status = %d, want 401", code)
+	}
+	if _, err := am.ValidateSession(session.ID); err == nil {
+		t.Error("idle session should be removed")
+	}
+}
+
+func TestAuthManager_JWTRequiresLiveSession(t *testing.T) {
+	am := NewAuthManager(&AuthConfig{
+		Quiet:           true,
+		Enabled:         true,
+		SessionDuration: time.Hour,
+		AuthMode:        "local",
+		DefaultAdmin:    "admin",
+		DefaultPassword: "admin",
+	})
+	defer am.StopCleanup()
+
+	session, err := am.Authenticate("admin", "admin")
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	jwtToken, err := am.jwtManager.GenerateToken(JWTClaims{
+		Subject:   session.UserID,
+		Username:  session.Username,
+		Role:      session.Role,
+		SessionID: session.ID,
+	})
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	handler := am.AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	request := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/api/k8s/pods", nil)
+		req.Header.Set("Authorization", "Bearer "+jwtToken)
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w.Code
+	}
+
+	if code := request(); code != http.StatusOK {
+		t.Fatalf("JWT request status = %d, want 200", code)
+	}
+	// The session's absolute lifetime also bounds the JWT
+	am.mu.Lock()
+	am.sessions[session.ID].ExpiresAt = time.Now().Add(-time.Second)
+	am.mu.Unlock()
+	if code := request(); code != http.StatusUnauthorized {
+		t.Errorf("JWT request after session expiry status = %d, want 401", code)
+	}
+}
+
 func TestAuthManager_HandleLogin(t *testing.T) {
 	am := NewAuthManager(&AuthConfig{
 		Quiet:           true,
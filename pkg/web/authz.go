@@ -89,6 +89,8 @@ type RoleDefinition struct {
 type Authorizer struct {
 	roles     map[string]*RoleDefinition
 	featureMx sync.RWMutex
+	// disabled features are denied to every role, e.g. for --no-ai
+	disabled map[Feature]bool
 }
 
 // NewAuthorizer creates a new Authorizer with default roles
@@ -220,6 +222,10 @@ func (az *Authorizer) IsFeatureAllowed(role string, feature Feature) bool {
 	az.featureMx.RLock()
 	defer az.featureMx.RUnlock()
 
+	if az.disabled[feature] {
+		return false
+	}
+
 	roleDef, exists := az.roles[role]
 	if !exists {
 		return false
@@ -251,10 +257,33 @@ func (az *Authorizer) GetFeaturePermissions(role string) map[Feature]bool {
 	return perms
 }
 
+// DisableFeature denies feature to every role, including admin, because
+// the subsystem behind it is turned off on this server
+func (az *Authorizer) DisableFeature(feature Feature) {
+	az.featureMx.Lock()
+	defer az.featureMx.Unlock()
+	if az.disabled == nil {
+		az.disabled = make(map[Feature]bool)
+	}
+	az.disabled[feature] = true
+}
+
+// FeatureDisabled reports whether feature was turned off with DisableFeature
+func (az *Authorizer) FeatureDisabled(feature Feature) bool {
+	az.featureMx.RLock()
+	defer az.featureMx.RUnlock()
+	return az.disabled[feature]
+}
+
 // FeatureMiddleware creates HTTP middleware that checks feature-level access
 func (az *Authorizer) FeatureMiddleware(feature Feature) func(http.HandlerFunc) http.HandlerFunc {
 	return func(next http.HandlerFunc) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
+			if az.FeatureDisabled(feature) {
+				WriteError(w, NewAPIError(ErrCodeNotFound, fmt.Sprintf("Feature %s is disabled on this server", feature)))
+				return
+			}
+
 			role := r.Header.Get("X-User-Role")
 			if role == "" {
 				role = "viewer"
@@ -377,39 +406,13 @@ func matchesPattern(pattern, value string) bool {
 func (az *Authorizer) AuthzMiddleware(resource string, action Action) func(http.HandlerFunc) http.HandlerFunc {
 	return func(next http.HandlerFunc) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
-			role := r.Header.Get("X-User-Role")
-			username := r.Header.Get("X-Username")
-
-			// If no role is set, default to viewer (most restrictive)
-			if role == "" {
-				role = "viewer"
-			}
-
 			// Extract namespace from request (query param or body)
 			namespace := r.URL.Query().Get("namespace")
 			if namespace == "" {
 				namespace = r.URL.Query().Get("ns")
 			}
 
-			allowed, reason := az.IsAllowed(role, resource, action, namespace)
-			if !allowed {
-				// Record authorization denial in audit log
-				_ = db.RecordAudit(db.AuditEntry{
-					User:            username,
-					Action:          "authz_denied",
-					Resource:        resource,
-					Details:         reason,
-					ActionType:      db.ActionTypeAuthzDenied,
-					Source:          "web",
-					ClientIP:        r.RemoteAddr,
-					Success:         false,
-					ErrorMsg:        reason,
-					RequestedAction: string(action),
-					TargetResource:  resource,
-					TargetNamespace: namespace,
-					AuthzDecision:   "denied",
-				})
-
+			if allowed, reason := az.authorizeRequest(r, resource, action, namespace); !allowed {
 				WriteError(w, NewAPIError(ErrCodeForbidden, fmt.Sprintf("Forbidden: %s", reason)))
 				return
 			}
@@ -418,3 +421,33 @@ func (az *Authorizer) AuthzMiddleware(resource string, action Action) func(http.
 		}
 	}
 }
+
+// authorizeRequest checks the request's role against resource, action, and
+// namespace, recording a denial in the audit log.
+func (az *Authorizer) authorizeRequest(r *http.Request, resource string, action Action, namespace string) (bool, string) {
+	// If no role is set, default to viewer (most restrictive)
+	role := r.Header.Get("X-User-Role")
+	if role == "" {
+		role = "viewer"
+	}
+
+	allowed, reason := az.IsAllowed(role, resource, action, namespace)
+	if !allowed {
+		_ = db.RecordAudit(db.AuditEntry{
+			User:            r.Header.Get("X-Username"),
+			Action:          "authz_denied",
+			Resource:        resource,
+			Details:         reason,
+			ActionType:      db.ActionTypeAuthzDenied,
+			Source:          "web",
+			ClientIP:        r.RemoteAddr,
+			Success:         false,
+			ErrorMsg:        reason,
+			RequestedAction: string(action),
+			TargetResource:  resource,
+			TargetNamespace: namespace,
+			AuthzDecision:   "denied",
+		})
+	}
+	return allowed, reason
+}
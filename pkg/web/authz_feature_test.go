@@ -196,6 +196,26 @@ func TestFeatureMiddleware_AdminAllowed(t *testing.T) {
 	}
 }
 
+func TestFeatureMiddleware_DisabledFeature(t *testing.T) {
+	az := NewAuthorizer()
+	az.DisableFeature(FeatureAIAssistant)
+	handler := az.FeatureMiddleware(FeatureAIAssistant)(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-User-Role", "admin")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for admin accessing a disabled feature, got %d", rec.Code)
+	}
+	if perms := az.GetFeaturePermissions("admin"); perms[FeatureAIAssistant] || !perms[FeatureDashboard] {
+		t.Errorf("admin permissions = %v, want only the disabled feature denied", perms)
+	}
+}
+
 // ==================== Role CRUD Tests ====================
 
 func TestDeleteRole_BuiltIn(t *testing.T) {
@@ -780,6 +800,39 @@ func TestHandleToolApprovalSettings_TimeoutBounds(t *testing.T) {
 	}
 }
 
+func TestHandleToolApprovalSettings_AutoApprove(t *testing.T) {
+	s := setupRoleTestServer(t)
+	put := func(body string) int {
+		req := httptest.NewRequest(http.MethodPut, "/api/settings/tool-approval", strings.NewReader(body))
+		req.Header.Set("X-User-Role", "admin")
+		w := httptest.NewRecorder()
+		s.handleToolApprovalSettings(w, req)
+		return w.Code
+	}
+
+	if code := put(`{"auto_approve": "reads"}`); code != http.StatusOK {
+		t.Fatalf("PUT auto_approve=reads status = %d", code)
+	}
+	if decision := s.getToolApprovalDecision("kubectl scale deploy/web --replicas=2"); !decision.RequiresApproval {
+		t.Error("reads mode should ask for a write")
+	}
+
+	// A body without auto_approve keeps the mode
+	if code := put(`{"approval_timeout_seconds": 30}`); code != http.StatusOK {
+		t.Fatalf("PUT status = %d", code)
+	}
+	s.aiMu.RLock()
+	mode := s.cfg.Authorization.ToolApproval.AutoApprove
+	s.aiMu.RUnlock()
+	if mode != "reads" {
+		t.Errorf("auto_approve = %q after a partial update, want reads", mode)
+	}
+
+	if code := put(`{"auto_approve": "everything"}`); code != http.StatusBadRequest {
+		t.Errorf("PUT invalid auto_approve status = %d, want 400", code)
+	}
+}
+
 func TestGetToolApprovalDecision_ReadOnlyCanRequireApproval(t *testing.T) {
 	s := setupRoleTestServer(t)
 	s.cfg.Authorization.ToolApproval.AutoApproveReadOnly = false
@@ -1272,3 +1325,35 @@ func TestHandleToolApprovalSettings_UpdateAffectsRuntimeDecisions(t *testing.T)
 		t.Fatalf("getToolApprovalTimeout() = %v, want %v", got, 45*time.Second)
 	}
 }
+
+func TestHandleToolApprovalSettings_KeepsRestrictionsNotInBody(t *testing.T) {
+	s := setupRoleTestServer(t)
+	s.cfg.Authorization.ToolApproval.SafeTools = true
+	s.cfg.Authorization.ToolApproval.DeniedResources = []string{"secrets"}
+
+	// The settings page only sends the approval fields
+	policyJSON := `{
+		"auto_approve_read_only": true,
+		"denied_resources": ["configmaps"],
+		"approval_timeout_seconds": 30
+	}`
+	req := httptest.NewRequest(http.MethodPut, "/api/settings/tool-approval", strings.NewReader(policyJSON))
+	req.Header.Set("X-User-Role", "admin")
+	w := httptest.NewRecorder()
+
+	s.handleToolApprovalSettings(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	policy := s.currentToolApprovalPolicy()
+	if !policy.SafeTools {
+		t.Error("safe_tools should be kept when the request omits it")
+	}
+	if len(policy.DeniedResources) != 1 || policy.DeniedResources[0] != "configmaps" {
+		t.Errorf("DeniedResources = %v, want the value from the request", policy.DeniedResources)
+	}
+	if decision := s.getToolApprovalDecision("kubectl delete pod web"); decision.Allowed {
+		t.Error("expected safe tools to keep blocking kubectl delete after the update")
+	}
+}
@@ -1,3 +1,5 @@
+//go:build !noai
+
 package web
 
 import (
@@ -20,6 +22,7 @@ type LLMCapabilities struct {
 	ToolCalling    bool   `json:"tool_calling"`
 	JSONMode       bool   `json:"json_mode"`
 	Streaming      bool   `json:"streaming"`
+	Vision         bool   `json:"vision"`
 	MaxTokens      int    `json:"max_tokens,omitempty"`
 	Recommendation string `json:"recommendation,omitempty"`
 }
@@ -162,7 +165,7 @@ func (s *Server) handleLLMSettings(w http.ResponseWriter, r *http.Request) {
 			WriteError(w, apiErr)
 			return
 		}
-		if ready {
+		if ready && s.cfg.AIEnabled() {
 			s.aiClient = newClient
 		} else {
 			s.aiClient = nil
@@ -353,6 +356,8 @@ func (s *Server) handleLLMStatus(w http.ResponseWriter, r *http.Request) {
 			status["default_endpoint"] = "http://localhost:4000"
 		case "gemini":
 			status["default_endpoint"] = "https://generativelanguage.googleapis.com/v1beta"
+		case "vertexai":
+			status["default_endpoint"] = "(Vertex AI regional endpoint)"
 		case "ollama":
 			status["default_endpoint"] = "http://localhost:11434"
 		case "anthropic":
@@ -385,6 +390,25 @@ func buildEffectiveUserMessage(message string, contextText string) string {
 	return baseMessage + "\n\nContext from selected resources:\n" + contextText
 }
 
+// scopeUserMessage prefixes message with llm.scope_prompt rendered for the
+// current cluster context and the namespace and view the request came from
+func (s *Server) scopeUserMessage(req ChatRequest, message string) string {
+	tmpl := ""
+	if s.cfg != nil {
+		tmpl = s.cfg.LLM.ScopePrompt
+	}
+	kubeContext, _, _ := s.getK8sContextInfo()
+	scope := ai.ScopePrompt(tmpl, ai.Scope{
+		Context:   kubeContext,
+		Namespace: strings.TrimSpace(req.Namespace),
+		Resource:  strings.TrimSpace(req.Resource),
+	})
+	if scope == "" {
+		return message
+	}
+	return scope + "\n\n" + message
+}
+
 type webAssistantStreamFilter struct {
 	suppressNextToolChunk bool
 	seenAssistantText     bool
@@ -629,7 +653,7 @@ func (s *Server) handleAgenticChat(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Build message with conversation history and language instruction
-	effectiveMessage := buildEffectiveUserMessage(req.Message, req.Context)
+	effectiveMessage := s.scopeUserMessage(req, buildEffectiveUserMessage(req.Message, req.Context))
 	message := effectiveMessage
 	var currentSessionID string
 
@@ -771,7 +795,7 @@ func (s *Server) handleAgenticChatJSONMode(w http.ResponseWriter, r *http.Reques
 	if req.Language != "" && req.Language != "en" {
 		langInstruction = getLanguageInstruction(req.Language)
 	}
-	effectiveMessage := buildEffectiveUserMessage(req.Message, req.Context)
+	effectiveMessage := s.scopeUserMessage(req, buildEffectiveUserMessage(req.Message, req.Context))
 
 	// Create a prompt that instructs the LLM to respond in JSON format
 	langPart := ""
@@ -899,7 +923,7 @@ func (s *Server) handleSimpleChatMode(w http.ResponseWriter, r *http.Request, re
 	if req.Language != "" && req.Language != "en" {
 		langInstruction = getLanguageInstruction(req.Language)
 	}
-	effectiveMessage := buildEffectiveUserMessage(req.Message, req.Context)
+	effectiveMessage := s.scopeUserMessage(req, buildEffectiveUserMessage(req.Message, req.Context))
 
 	// Create a helpful prompt for Kubernetes assistance
 	langPart := ""
@@ -977,6 +1001,9 @@ func getLLMCapabilities(client *ai.Client, provider string) LLMCapabilities {
 	}
 
 	caps.ToolCalling = client.SupportsTools()
+	features := client.Capabilities()
+	caps.Streaming = features.Streaming
+	caps.Vision = features.Vision
 
 	// Determine JSON mode support based on provider
 	switch provider {
@@ -996,7 +1023,7 @@ func getLLMCapabilities(client *ai.Client, provider string) LLMCapabilities {
 		} else {
 			caps.Recommendation = "Consider using Claude 3 Opus, Sonnet, or Haiku for tool calling support"
 		}
-	case "gemini":
+	case "gemini", "vertexai":
 		caps.JSONMode = true
 		caps.MaxTokens = 32000
 		if caps.ToolCalling {
@@ -1048,19 +1075,6 @@ func getLLMCapabilities(client *ai.Client, provider string) LLMCapabilities {
 	return caps
 }
 
-// WriteEvent writes an SSE event with a specific event type
-func (s *SSEWriter) WriteEvent(event string, data string) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	_, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, data)
-	if err != nil {
-		return err
-	}
-	s.flusher.Flush()
-	return nil
-}
-
 // handleAvailableModels fetches available models from the current LLM provider.
 // GET: uses the existing AI client.
 // POST: accepts provider/api_key/endpoint in request body (avoids API key in URL).
@@ -1166,6 +1166,21 @@ func TestBuildEffectiveUserMessage(t *testing.T) {
 	})
 }
 
+func TestScopeUserMessage(t *testing.T) {
+	s := &Server{cfg: &config.Config{}}
+	req := ChatRequest{Namespace: "payments", Resource: "deployments"}
+
+	got := s.scopeUserMessage(req, "show me the pods")
+	if !strings.Contains(got, "Namespace: payments. Current view: deployments.") || !strings.HasSuffix(got, "\n\nshow me the pods") {
+		t.Fatalf("expected scope prompt before the message, got %q", got)
+	}
+
+	s.cfg.LLM.ScopePrompt = "off"
+	if got := s.scopeUserMessage(req, "show me the pods"); got != "show me the pods" {
+		t.Fatalf("expected message unchanged when scope_prompt is off, got %q", got)
+	}
+}
+
 func TestWebAssistantStreamFilter(t *testing.T) {
 	filter := &webAssistantStreamFilter{}
 
@@ -0,0 +1,367 @@
+package web
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/pmezard/go-difflib/difflib"
+	goyaml "gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// maxManifestBytes caps uploaded and fetched manifests
+const maxManifestBytes = 1 << 20
+
+// manifestFetchClient downloads manifests for POST /api/k8s/apply/manifest.
+// It only dials public addresses so a URL cannot reach the cluster network
+// or the node's metadata endpoints. It dials directly, without the
+// environment's proxy, since the address check would only see the proxy's.
+// Tests replace it.
+var manifestFetchClient = &http.Client{
+	Timeout: 15 * time.Second,
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: rejectPrivateDial,
+		}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if req.URL.Scheme != "https" {
+			return fmt.Errorf("redirect to non-HTTPS URL refused")
+		}
+		if len(via) >= 5 {
+			return fmt.Errorf("too many redirects")
+		}
+		return nil
+	},
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which some
+// clouds use for internal services
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// rejectPrivateDial refuses connections to loopback, private, shared
+// (CGNAT), and link-local addresses. It runs after DNS resolution, so
+// rebinding cannot bypass it.
+func rejectPrivateDial(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("private or loopback addresses are not allowed")
+	}
+	return nil
+}
+
+// ManifestApplyRequest is the JSON form of POST /api/k8s/apply/manifest.
+// Exactly one of URL and YAML is set; uploads use multipart/form-data with
+// a "file" part and the other fields as form values.
+type ManifestApplyRequest struct {
+	URL       string `json:"url"`
+	YAML      string `json:"yaml"`
+	Namespace string `json:"namespace"`
+	// Confirm applies the manifest; without it only a dry-run runs
+	Confirm bool `json:"confirm"`
+	// Digest must echo the preview's digest when Confirm is set, so the
+	// applied manifest is the one whose diff was reviewed
+	Digest string `json:"digest"`
+}
+
+// ManifestObjectResult reports one object of an applied or previewed manifest
+type ManifestObjectResult struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Action    string `json:"action"` // create, update, or unchanged
+	Diff      string `json:"diff,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ManifestApplyResponse is returned for both the dry-run and the apply
+type ManifestApplyResponse struct {
+	Source  string                 `json:"source"` // upload, inline, or the URL
+	Digest  string                 `json:"digest"`
+	DryRun  bool                   `json:"dryRun"`
+	Applied bool                   `json:"applied"` // Every object applied without error
+	Objects []ManifestObjectResult `json:"objects"`
+}
+
+// handleManifestApply handles POST /api/k8s/apply/manifest. The first call
+// runs a server-side dry-run and returns a per-object diff; calling again
+// with confirm and the returned digest applies the manifest.
+func (s *Server) handleManifestApply(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	req, data, source, err := readManifestRequest(w, r)
+	if err != nil {
+		WriteError(w, NewAPIError(ErrCodeBadRequest, err.Error()))
+		return
+	}
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+
+	objs, err := k8s.ParseManifest(data)
+	if err != nil {
+		WriteError(w, NewAPIError(ErrCodeValidation, "Invalid manifest: "+err.Error()))
+		return
+	}
+
+	username := r.Header.Get("X-Username")
+	if username == "" {
+		username = "anonymous"
+	}
+	role := r.Header.Get("X-User-Role")
+	if role == "" {
+		role = "viewer"
+	}
+
+	// Every object must be allowed before anything is sent to the cluster
+	for _, obj := range objs {
+		resource, namespace, err := s.k8sClient.ManifestTarget(obj, req.Namespace)
+		if err != nil {
+			WriteError(w, NewAPIError(ErrCodeValidation, fmt.Sprintf("%s/%s: %v", obj.GetKind(), obj.GetName(), err)))
+			return
+		}
+		if allowed, reason := s.authorizer.IsAllowed(role, resource, ActionApply, namespace); !allowed {
+			target := strings.ToLower(obj.GetKind()) + "/" + obj.GetName()
+			_ = db.RecordAudit(db.AuditEntry{
+				User:            username,
+				Action:          "authz_denied",
+				ActionType:      db.ActionTypeAuthzDenied,
+				Resource:        target,
+				Details:         reason,
+				Source:          "web",
+				ClientIP:        r.RemoteAddr,
+				ErrorMsg:        reason,
+				RequestedAction: string(ActionApply),
+				TargetResource:  target,
+				TargetNamespace: namespace,
+				AuthzDecision:   "denied",
+			})
+			WriteError(w, NewAPIError(ErrCodeForbidden, fmt.Sprintf("Forbidden: %s", reason)))
+			return
+		}
+	}
+
+	if req.Confirm && req.Digest != digest {
+		WriteError(w, NewAPIError(ErrCodeConflict, "Manifest differs from the previewed one; run the dry-run again and review the diff"))
+		return
+	}
+
+	dryRun := !req.Confirm
+	changes := s.k8sClient.ApplyManifest(r.Context(), objs, req.Namespace, dryRun)
+
+	resp := ManifestApplyResponse{
+		Source:  source,
+		Digest:  digest,
+		DryRun:  dryRun,
+		Applied: !dryRun,
+		Objects: make([]ManifestObjectResult, 0, len(changes)),
+	}
+	for _, change := range changes {
+		result := manifestObjectResult(change)
+		resp.Objects = append(resp.Objects, result)
+		if change.Err != nil {
+			resp.Applied = false
+		}
+
+		actionType := db.ActionTypeMutation
+		if dryRun {
+			actionType = db.ActionTypeView
+		}
+		target := strings.ToLower(change.Kind) + "/" + change.Name
+		_ = db.RecordAudit(db.AuditEntry{
+			User:            username,
+			Action:          "apply",
+			ActionType:      actionType,
+			Resource:        target,
+			Details:         fmt.Sprintf("source=%s, digest=%s, action=%s, dryRun=%v", source, digest[:12], result.Action, dryRun),
+			Namespace:       change.Namespace,
+			Source:          "web",
+			ClientIP:        r.RemoteAddr,
+			Success:         change.Err == nil,
+			ErrorMsg:        result.Error,
+			RequestedAction: string(ActionApply),
+			TargetResource:  target,
+			TargetNamespace: change.Namespace,
+			AuthzDecision:   "allowed",
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// readManifestRequest extracts the request options and manifest bytes from
+// a multipart upload or a JSON body carrying inline YAML or a URL.
+func readManifestRequest(w http.ResponseWriter, r *http.Request) (ManifestApplyRequest, []byte, string, error) {
+	var req ManifestApplyRequest
+	r.Body = http.MaxBytesReader(w, r.Body, maxManifestBytes+64<<10)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		if err := r.ParseMultipartForm(maxManifestBytes); err != nil {
+			return req, nil, "", fmt.Errorf("invalid upload: %w", err)
+		}
+		req.Namespace = r.FormValue("namespace")
+		req.Confirm = r.FormValue("confirm") == "true"
+		req.Digest = r.FormValue("digest")
+
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			return req, nil, "", fmt.Errorf("manifest file is required in the \"file\" field")
+		}
+		defer file.Close()
+		data, err := readLimited(file)
+		return req, data, "upload", err
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return req, nil, "", fmt.Errorf("invalid request body: %w", err)
+	}
+	switch {
+	case req.URL != "" && req.YAML != "":
+		return req, nil, "", fmt.Errorf("set either url or yaml, not both")
+	case req.URL != "":
+		data, err := fetchManifest(r.Context(), req.URL)
+		return req, data, req.URL, err
+	case req.YAML != "":
+		if len(req.YAML) > maxManifestBytes {
+			return req, nil, "", fmt.Errorf("manifest exceeds %d bytes", maxManifestBytes)
+		}
+		return req, []byte(req.YAML), "inline", nil
+	default:
+		return req, nil, "", fmt.Errorf("a manifest url, yaml, or file upload is required")
+	}
+}
+
+// fetchManifest downloads a manifest over HTTPS from a public address
+func fetchManifest(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("malformed manifest URL")
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("only HTTPS manifest URLs are allowed")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := manifestFetchClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch manifest: %s", resp.Status)
+	}
+	return readLimited(resp.Body)
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxManifestBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if len(data) > maxManifestBytes {
+		return nil, fmt.Errorf("manifest exceeds %d bytes", maxManifestBytes)
+	}
+	return data, nil
+}
+
+// manifestObjectResult summarizes a change with a unified diff between the
+// live object and the API server's result
+func manifestObjectResult(change k8s.ManifestChange) ManifestObjectResult {
+	result := ManifestObjectResult{
+		Kind:      change.Kind,
+		Name:      change.Name,
+		Namespace: change.Namespace,
+		Action:    "update",
+	}
+	if change.Current == nil {
+		result.Action = "create"
+	}
+	if change.Err != nil {
+		result.Error = change.Err.Error()
+	}
+	if change.Result == nil {
+		return result
+	}
+
+	before := ""
+	if change.Current != nil {
+		before = diffableYAML(change.Current)
+	}
+	after := diffableYAML(change.Result)
+	if before == after {
+		result.Action = "unchanged"
+		return result
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(before),
+		B:        difflib.SplitLines(after),
+		FromFile: "live",
+		ToFile:   "applied",
+		Context:  3,
+	})
+	if err == nil {
+		result.Diff = diff
+	}
+	return result
+}
+
+// diffableYAML renders an object without server bookkeeping fields and with
+// Secret values replaced by a short hash, so diffs show which keys changed
+// without exposing their contents.
+func diffableYAML(obj *unstructured.Unstructured) string {
+	clean := obj.DeepCopy()
+	clean.SetManagedFields(nil)
+	clean.SetResourceVersion("")
+	clean.SetGeneration(0)
+	clean.SetUID("")
+	unstructured.RemoveNestedField(clean.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(clean.Object, "status")
+	if clean.GetKind() == "Secret" {
+		for _, field := range []string{"data", "stringData"} {
+			values, found, _ := unstructured.NestedMap(clean.Object, field)
+			if !found {
+				continue
+			}
+			for k, v := range values {
+				h := sha256.Sum256([]byte(fmt.Sprint(v)))
+				values[k] = "<redacted sha256:" + hex.EncodeToString(h[:4]) + ">"
+			}
+			_ = unstructured.SetNestedMap(clean.Object, values, field)
+		}
+	}
+
+	out, err := goyaml.Marshal(clean.Object)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
@@ -0,0 +1,222 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+const applyTestManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+  namespace: default
+data:
+  mode: prod
+`
+
+func setupManifestApplyServer(t *testing.T) *Server {
+	t.Helper()
+	existing := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "app-config", "namespace": "default"},
+		"data":       map[string]interface{}{"mode": "dev"},
+	}}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"},
+		existing)
+
+	return &Server{
+		cfg:        &config.Config{Language: "en"},
+		k8sClient:  &k8s.Client{Dynamic: dyn},
+		authorizer: NewAuthorizer(),
+	}
+}
+
+func postManifest(t *testing.T, s *Server, role string, body ManifestApplyRequest) (*httptest.ResponseRecorder, ManifestApplyResponse) {
+	t.Helper()
+	data, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/api/k8s/apply/manifest", bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Username", "alice")
+	req.Header.Set("X-User-Role", role)
+	w := httptest.NewRecorder()
+	s.handleManifestApply(w, req)
+
+	var resp ManifestApplyResponse
+	if w.Code == http.StatusOK {
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+	}
+	return w, resp
+}
+
+func TestHandleManifestApply_PreviewThenConfirm(t *testing.T) {
+	s := setupManifestApplyServer(t)
+
+	w, preview := postManifest(t, s, "user", ManifestApplyRequest{YAML: applyTestManifest})
+	if w.Code != http.StatusOK {
+		t.Fatalf("preview status = %d: %s", w.Code, w.Body.String())
+	}
+	if !preview.DryRun || preview.Applied || preview.Source != "inline" || preview.Digest == "" {
+		t.Fatalf("preview = %+v", preview)
+	}
+	if len(preview.Objects) != 1 || preview.Objects[0].Action != "update" {
+		t.Fatalf("objects = %+v, want one update", preview.Objects)
+	}
+	diff := preview.Objects[0].Diff
+	if !strings.Contains(diff, "-  mode: dev") || !strings.Contains(diff, "+  mode: prod") {
+		t.Errorf("diff does not show the data change:\n%s", diff)
+	}
+
+	w, _ = postManifest(t, s, "user", ManifestApplyRequest{YAML: applyTestManifest + "  extra: x\n", Confirm: true, Digest: preview.Digest})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("confirm with a changed manifest: status = %d, want 409", w.Code)
+	}
+
+	w, applied := postManifest(t, s, "user", ManifestApplyRequest{YAML: applyTestManifest, Confirm: true, Digest: preview.Digest})
+	if w.Code != http.StatusOK {
+		t.Fatalf("confirm status = %d: %s", w.Code, w.Body.String())
+	}
+	if applied.DryRun || !applied.Applied {
+		t.Errorf("applied = %+v, want applied without dry-run", applied)
+	}
+}
+
+func TestHandleManifestApply_ViewerForbidden(t *testing.T) {
+	s := setupManifestApplyServer(t)
+	w, _ := postManifest(t, s, "viewer", ManifestApplyRequest{YAML: applyTestManifest})
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", w.Code)
+	}
+}
+
+func TestHandleManifestApply_BadRequests(t *testing.T) {
+	s := setupManifestApplyServer(t)
+	tests := map[string]ManifestApplyRequest{
+		"no manifest":    {},
+		"url and yaml":   {URL: "https://example.com/a.yaml", YAML: applyTestManifest},
+		"http url":       {URL: "http://example.com/a.yaml"},
+		"invalid object": {YAML: "apiVersion: v1\nkind: ConfigMap\n"},
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			w, _ := postManifest(t, s, "admin", body)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleManifestApply_URL(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(applyTestManifest))
+	}))
+	defer ts.Close()
+
+	orig := manifestFetchClient
+	manifestFetchClient = ts.Client()
+	t.Cleanup(func() { manifestFetchClient = orig })
+
+	s := setupManifestApplyServer(t)
+	w, resp := postManifest(t, s, "user", ManifestApplyRequest{URL: ts.URL + "/app.yaml"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Source != ts.URL+"/app.yaml" || len(resp.Objects) != 1 {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestHandleManifestApply_Upload(t *testing.T) {
+	s := setupManifestApplyServer(t)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, _ := mw.CreateFormFile("file", "app.yaml")
+	_, _ = part.Write([]byte(applyTestManifest))
+	_ = mw.WriteField("namespace", "default")
+	_ = mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/k8s/apply/manifest", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("X-User-Role", "user")
+	w := httptest.NewRecorder()
+	s.handleManifestApply(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	var resp ManifestApplyResponse
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Source != "upload" || !resp.DryRun {
+		t.Errorf("resp = %+v, want upload dry-run", resp)
+	}
+}
+
+func TestFetchManifest_RejectsPrivateAddresses(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(applyTestManifest))
+	}))
+	defer ts.Close()
+
+	// The default client must refuse the loopback test server
+	if _, err := fetchManifest(t.Context(), ts.URL); err == nil {
+		t.Error("expected loopback URL to be refused")
+	}
+}
+
+func TestRejectPrivateDial(t *testing.T) {
+	for addr, blocked := range map[string]bool{
+		"127.0.0.1:443":       true,
+		"10.0.0.5:443":        true,
+		"100.64.0.1:443":      true, // CGNAT
+		"100.127.255.254:443": true,
+		"169.254.169.254:80":  true,
+		"[::1]:443":           true,
+		"100.128.0.1:443":     false,
+		"93.184.216.34:443":   false,
+	} {
+		if err := rejectPrivateDial("tcp", addr, nil); (err != nil) != blocked {
+			t.Errorf("rejectPrivateDial(%s) = %v, want blocked %v", addr, err, blocked)
+		}
+	}
+
+	// A proxy would be dialed instead of the target, skipping the check
+	if manifestFetchClient.Transport.(*http.Transport).Proxy != nil {
+		t.Error("manifestFetchClient must not use a proxy")
+	}
+}
+
+func TestDiffableYAML_RedactsSecrets(t *testing.T) {
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"name": "db", "resourceVersion": "7"},
+		"data":       map[string]interface{}{"password": "aHVudGVyMg=="},
+	}}
+	out := diffableYAML(secret)
+	if strings.Contains(out, "aHVudGVyMg==") {
+		t.Errorf("secret value leaked:\n%s", out)
+	}
+	if !strings.Contains(out, "password: <redacted sha256:") {
+		t.Errorf("expected redacted password key:\n%s", out)
+	}
+	if strings.Contains(out, "resourceVersion") {
+		t.Errorf("resourceVersion should be stripped:\n%s", out)
+	}
+}
@@ -545,6 +545,32 @@ func (s *Server) handleK8sResource(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// recordReadAudit audits a YAML or log view of one object when audit_reads
+// covers its resource type
+func (s *Server) recordReadAudit(r *http.Request, action, resource, namespace, name string) {
+	if s.cfg == nil || !s.cfg.AuditReads.Covers(resource) {
+		return
+	}
+	username := r.Header.Get("X-Username")
+	if username == "" {
+		username = "anonymous"
+	}
+	target := resource + "/" + name
+	_ = db.RecordAudit(db.AuditEntry{
+		User:            username,
+		Action:          action,
+		ActionType:      db.ActionTypeRead,
+		Resource:        target,
+		Details:         fmt.Sprintf("Viewed %s of %s in namespace %s", action, target, namespace),
+		Namespace:       namespace,
+		Source:          "web",
+		ClientIP:        r.RemoteAddr,
+		Success:         true,
+		TargetResource:  target,
+		TargetNamespace: namespace,
+	})
+}
+
 // handleResourceYAML returns YAML for a single resource
 func (s *Server) handleResourceYAML(w http.ResponseWriter, r *http.Request, resource, namespace, name string) {
 	// Map resource names to GVR
@@ -559,6 +585,7 @@ func (s *Server) handleResourceYAML(w http.ResponseWriter, r *http.Request, reso
 		writeK8sError(w, fmt.Errorf("failed to get YAML: %w", err))
 		return
 	}
+	s.recordReadAudit(r, "yaml", gvr.Resource, namespace, name)
 
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	_, _ = w.Write([]byte(yaml))
@@ -816,6 +843,7 @@ func (s *Server) handlePodLogs(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		defer stream.Close()
+		s.recordReadAudit(r, "logs", "pods", namespace, podName)
 
 		scanner := bufio.NewScanner(stream)
 		for scanner.Scan() {
@@ -831,6 +859,7 @@ func (s *Server) handlePodLogs(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		defer stream.Close()
+		s.recordReadAudit(r, "logs", "pods", namespace, podName)
 
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		scanner := bufio.NewScanner(stream)
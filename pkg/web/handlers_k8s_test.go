@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -1846,3 +1847,30 @@ func TestHandleGlobalSearch_MethodNotAllowed(t *testing.T) {
 func strPtr(v string) *string {
 	return &v
 }
+
+func TestRecordReadAudit(t *testing.T) {
+	if err := db.Init(filepath.Join(t.TempDir(), "audit.db")); err != nil {
+		t.Fatalf("Failed to init DB: %v", err)
+	}
+	defer db.Close()
+
+	server := &Server{cfg: &config.Config{}}
+	req := httptest.NewRequest(http.MethodGet, "/api/k8s/secrets?name=db&format=yaml", nil)
+	req.Header.Set("X-Username", "alice")
+
+	server.recordReadAudit(req, "yaml", "secrets", "default", "db")
+	server.cfg.AuditReads = config.AuditReadsConfig{Enabled: true, Resources: []string{"secrets"}}
+	server.recordReadAudit(req, "logs", "pods", "default", "web")
+	server.recordReadAudit(req, "yaml", "secrets", "default", "db")
+
+	logs, err := db.GetAuditLogsFiltered(db.AuditFilter{ActionType: db.ActionTypeRead})
+	if err != nil {
+		t.Fatalf("GetAuditLogsFiltered() error = %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 read audit entry, got %d", len(logs))
+	}
+	if logs[0]["user"] != "alice" || logs[0]["resource"] != "secrets/db" {
+		t.Errorf("unexpected audit entry: %v", logs[0])
+	}
+}
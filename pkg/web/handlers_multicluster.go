@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
@@ -22,13 +23,22 @@ type ContextsResponse struct {
 	CurrentContext string        `json:"currentContext"`
 }
 
+// kubeconfigLoadingRules returns the loading rules of the server's k8s
+// client so the context list matches the kubeconfig the client was built from.
+func (s *Server) kubeconfigLoadingRules() *clientcmd.ClientConfigLoadingRules {
+	if s.k8sClient != nil {
+		return s.k8sClient.KubeconfigLoadingRules()
+	}
+	return k8s.ClientOptionsFromEnv().LoadingRules()
+}
+
 func (s *Server) handleContexts(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		writeMethodNotAllowed(w)
 		return
 	}
 
-	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules := s.kubeconfigLoadingRules()
 	config, err := loadingRules.Load()
 	if err != nil {
 		WriteError(w, NewAPIError(ErrCodeInternalError, "Failed to load kubeconfig: "+err.Error()))
@@ -72,7 +82,7 @@ func (s *Server) handleContextSwitch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Verify context exists
-	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules := s.kubeconfigLoadingRules()
 	config, err := loadingRules.Load()
 	if err != nil {
 		WriteError(w, NewAPIError(ErrCodeInternalError, "Failed to load kubeconfig: "+err.Error()))
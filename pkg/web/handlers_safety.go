@@ -1,8 +1,11 @@
+//go:build !noai
+
 package web
 
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -30,11 +33,25 @@ func (s *Server) handleToolApprovalSettings(w http.ResponseWriter, r *http.Reque
 			return
 		}
 
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			WriteError(w, NewAPIError(ErrCodeBadRequest, "Invalid request body"))
+			return
+		}
 		var policy config.ToolApprovalPolicy
-		if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &policy); err != nil {
 			WriteError(w, NewAPIError(ErrCodeBadRequest, "Invalid request body"))
 			return
 		}
+		_ = json.Unmarshal(body, &fields)
+
+		switch policy.AutoApprove {
+		case "", config.AutoApproveReads, config.AutoApproveAll:
+		default:
+			WriteError(w, NewAPIError(ErrCodeBadRequest, "auto_approve must be empty, reads, or all"))
+			return
+		}
 
 		// Validate timeout bounds
 		if policy.ApprovalTimeoutSeconds <= 0 {
@@ -51,6 +68,7 @@ func (s *Server) handleToolApprovalSettings(w http.ResponseWriter, r *http.Reque
 
 		// Update in-memory config
 		s.aiMu.Lock()
+		keepToolRestrictions(&policy, s.cfg.Authorization.ToolApproval, fields)
 		s.cfg.Authorization.ToolApproval = policy
 		s.aiMu.Unlock()
 
@@ -65,7 +83,7 @@ func (s *Server) handleToolApprovalSettings(w http.ResponseWriter, r *http.Reque
 			User:     username,
 			Action:   "update_tool_approval_settings",
 			Resource: "settings",
-			Details:  fmt.Sprintf("AutoApproveReadOnly=%v, BlockDangerous=%v, Timeout=%ds", policy.AutoApproveReadOnly, policy.BlockDangerous, policy.ApprovalTimeoutSeconds),
+			Details:  fmt.Sprintf("AutoApproveReadOnly=%v, BlockDangerous=%v, SafeTools=%v, Timeout=%ds", policy.AutoApproveReadOnly, policy.BlockDangerous, policy.SafeTools, policy.ApprovalTimeoutSeconds),
 		})
 
 		w.Header().Set("Content-Type", "application/json")
@@ -91,11 +109,39 @@ func (s *Server) getToolApprovalTimeout() time.Duration {
 }
 
 func (s *Server) getToolApprovalDecision(command string) *safety.Decision {
+	return safety.NewPolicyEnforcer(s.currentToolApprovalPolicy()).Evaluate(command)
+}
+
+// currentToolApprovalPolicy returns the effective tool approval policy
+func (s *Server) currentToolApprovalPolicy() config.ToolApprovalPolicy {
 	s.aiMu.RLock()
-	policy := effectiveToolApprovalPolicy(s.cfg.Authorization.ToolApproval)
-	s.aiMu.RUnlock()
+	defer s.aiMu.RUnlock()
+	return effectiveToolApprovalPolicy(s.cfg.Authorization.ToolApproval)
+}
 
-	return safety.NewPolicyEnforcer(policy).Evaluate(command)
+// keepToolRestrictions copies each verb and resource restriction, and the
+// auto_approve mode, that the request body did not set from current into
+// policy, so settings clients that only send the approval fields cannot clear
+// them by accident.
+func keepToolRestrictions(policy *config.ToolApprovalPolicy, current config.ToolApprovalPolicy, fields map[string]json.RawMessage) {
+	if _, ok := fields["safe_tools"]; !ok {
+		policy.SafeTools = current.SafeTools
+	}
+	if _, ok := fields["allowed_verbs"]; !ok {
+		policy.AllowedVerbs = current.AllowedVerbs
+	}
+	if _, ok := fields["denied_verbs"]; !ok {
+		policy.DeniedVerbs = current.DeniedVerbs
+	}
+	if _, ok := fields["allowed_resources"]; !ok {
+		policy.AllowedResources = current.AllowedResources
+	}
+	if _, ok := fields["denied_resources"]; !ok {
+		policy.DeniedResources = current.DeniedResources
+	}
+	if _, ok := fields["auto_approve"]; !ok {
+		policy.AutoApprove = current.AutoApprove
+	}
 }
 
 func effectiveToolApprovalPolicy(policy config.ToolApprovalPolicy) config.ToolApprovalPolicy {
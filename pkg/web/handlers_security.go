@@ -1,3 +1,5 @@
+//go:build !nosecurity
+
 package web
 
 import (
@@ -140,6 +142,29 @@ func (s *Server) recordSecurityScan(result *security.ScanResult, namespace, scan
 	_ = db.RecordSecurityScan(record)
 }
 
+// handleSecurityScanLatest returns the scan schedule and the latest scheduled
+// scan result without running a scan
+func (s *Server) handleSecurityScanLatest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	resp := map[string]interface{}{
+		"schedule": SecurityScanStatus{},
+	}
+	if s.securityScheduler != nil {
+		resp["schedule"] = s.securityScheduler.Status()
+		if latest := s.securityScheduler.Latest(0, false); latest != nil {
+			resp["scan"] = latest
+		}
+	}
+
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
 func (s *Server) handleSecurityScanHistory(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		writeMethodNotAllowed(w)
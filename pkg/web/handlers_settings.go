@@ -334,7 +334,7 @@ func (s *Server) handleActiveModel(w http.ResponseWriter, r *http.Request) {
 			WriteErrorSimple(w, http.StatusInternalServerError, fmt.Sprintf("Failed to create AI client: %v", err))
 			return
 		}
-		if ready {
+		if ready && s.cfg.AIEnabled() {
 			s.aiClient = newClient
 		} else {
 			s.aiClient = nil
@@ -485,9 +485,7 @@ func (s *Server) handleMCPServers(w http.ResponseWriter, r *http.Request) {
 
 			if configChanged && existing.Enabled {
 				_ = s.mcpClient.Disconnect(req.Name)
-				if s.aiClient != nil {
-					s.aiClient.GetToolRegistry().UnregisterMCPTools(req.Name)
-				}
+				s.unregisterMCPTools(req.Name)
 			}
 
 			// Update fields
@@ -554,16 +552,12 @@ func (s *Server) handleMCPServers(w http.ResponseWriter, r *http.Request) {
 			}
 			// Disconnect and unregister tools
 			_ = s.mcpClient.Disconnect(req.Name)
-			if s.aiClient != nil {
-				s.aiClient.GetToolRegistry().UnregisterMCPTools(req.Name)
-			}
+			s.unregisterMCPTools(req.Name)
 
 		case "reconnect":
 			// Disconnect first
 			_ = s.mcpClient.Disconnect(req.Name)
-			if s.aiClient != nil {
-				s.aiClient.GetToolRegistry().UnregisterMCPTools(req.Name)
-			}
+			s.unregisterMCPTools(req.Name)
 			// Reconnect
 			for _, srv := range s.cfg.MCP.Servers {
 				if srv.Name == req.Name && srv.Enabled {
@@ -610,9 +604,7 @@ func (s *Server) handleMCPServers(w http.ResponseWriter, r *http.Request) {
 
 		// Disconnect first
 		_ = s.mcpClient.Disconnect(name)
-		if s.aiClient != nil {
-			s.aiClient.GetToolRegistry().UnregisterMCPTools(name)
-		}
+		s.unregisterMCPTools(name)
 
 		if !s.cfg.RemoveMCPServer(name) {
 			WriteErrorSimple(w, http.StatusNotFound, "Server not found")
@@ -662,19 +654,7 @@ func (s *Server) handleMCPTools(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Also include built-in tools
-	var builtinTools []map[string]interface{}
-	if s.aiClient != nil {
-		for _, t := range s.aiClient.VisibleTools() {
-			if t == nil || t.Type == "mcp" {
-				continue
-			}
-			builtinTools = append(builtinTools, map[string]interface{}{
-				"name":        t.Name,
-				"description": t.Description,
-				"type":        string(t.Type),
-			})
-		}
-	}
+	builtinTools := s.builtinToolInfo()
 
 	_ = json.NewEncoder(w).Encode(map[string]interface{}{
 		"mcp_tools":     tools,
@@ -801,9 +781,7 @@ func (s *Server) handleMCPProfiles(w http.ResponseWriter, r *http.Request) {
 			// Disconnect and unregister tools
 			for _, srv := range profile.Servers {
 				_ = s.mcpClient.Disconnect(srv.Name)
-				if s.aiClient != nil {
-					s.aiClient.GetToolRegistry().UnregisterMCPTools(srv.Name)
-				}
+				s.unregisterMCPTools(srv.Name)
 			}
 
 			// Record audit
@@ -6,7 +6,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/cloudbro-kube-ai/k13d/pkg/ai/safety"
 	"github.com/cloudbro-kube-ai/k13d/pkg/db"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -289,23 +288,6 @@ func formatLabelSelector(selector *metav1.LabelSelector) string {
 	return strings.Join(pairs, ",")
 }
 
-// ==========================================
-// Command Classification
-// ==========================================
-
-// classifyCommand categorizes a kubectl command for safety.
-// This function now uses the unified safety.Classifier which provides
-// consistent classification across TUI and Web UI, including detection
-// of piped commands, chained commands, and file redirects.
-//
-// Deprecated: For new code, use safety.Classify() directly for full classification
-// or safety.Evaluate() for policy-based decisions.
-func classifyCommand(command string) string {
-	// Use unified classifier from safety package
-	classification := safety.Classify(command)
-	return classification.Category
-}
-
 // ==========================================
 // Audit Logging Helpers
 // ==========================================
@@ -2,6 +2,8 @@ package web
 
 import (
 	"testing"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 )
 
 func TestNewLDAPProvider(t *testing.T) {
@@ -172,6 +174,102 @@ func TestLDAPProvider_DetermineRole(t *testing.T) {
 	}
 }
 
+func TestLDAPProvider_DetermineRole_GroupMappings(t *testing.T) {
+	cfg := &LDAPConfig{
+		Enabled:      true,
+		Host:         "ldap.example.com",
+		ViewerGroups: []string{"readonly"},
+		GroupMappings: map[string]string{
+			"cn=platform,ou=groups,dc=example,dc=com": "admin",
+			"developers": "editor",
+			"auditors":   "auditor",
+			"operators":  "operator",
+		},
+		DefaultRole: "user",
+	}
+
+	provider := NewLDAPProvider(cfg)
+
+	tests := []struct {
+		name     string
+		groups   []string
+		wantRole string
+	}{
+		{"group DN mapping", []string{"CN=Platform, OU=Groups, DC=example, DC=com"}, "admin"},
+		{"editor alias maps to user", []string{"developers"}, "user"},
+		{"custom role mapping", []string{"auditors"}, "auditor"},
+		{"highest privilege wins", []string{"readonly", "developers"}, "user"},
+		{"custom role outranks viewer", []string{"readonly", "auditors"}, "auditor"},
+		{"user outranks custom role", []string{"auditors", "developers"}, "user"},
+		{"custom role tie goes to first group", []string{"operators", "auditors"}, "auditor"},
+		{"unmapped user gets default role", []string{"unknown-group"}, "user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := provider.determineRole(tt.groups); got != tt.wantRole {
+				t.Errorf("determineRole(%v) = %s, want %s", tt.groups, got, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestAuthManager_ResolveLDAPRole(t *testing.T) {
+	am := NewAuthManager(&AuthConfig{
+		Enabled: true,
+		Quiet:   true,
+		LDAP: &LDAPConfig{
+			Enabled:     true,
+			Host:        "ldap.example.com",
+			DefaultRole: "user",
+		},
+	})
+
+	if got := am.resolveLDAPRole("admin"); got != "admin" {
+		t.Errorf("resolveLDAPRole(admin) = %s, want admin", got)
+	}
+	if got := am.resolveLDAPRole("auditor"); got != "user" {
+		t.Errorf("unknown role should fall back to default, got %s", got)
+	}
+
+	am.SetRoleValidator(func(role string) bool { return role == "auditor" })
+	if got := am.resolveLDAPRole("auditor"); got != "auditor" {
+		t.Errorf("registered custom role should be kept, got %s", got)
+	}
+
+	editor := NewAuthManager(&AuthConfig{
+		Enabled: true,
+		Quiet:   true,
+		LDAP: &LDAPConfig{
+			Enabled:     true,
+			Host:        "ldap.example.com",
+			DefaultRole: "editor",
+		},
+	})
+	if got := editor.resolveLDAPRole("auditor"); got != "user" {
+		t.Errorf("default role alias editor should fall back to user, got %s", got)
+	}
+}
+
+func TestLDAPConfigFromYAML(t *testing.T) {
+	if got := LDAPConfigFromYAML(config.LDAPConfigYAML{Enabled: false}); got != nil {
+		t.Fatal("expected nil config when LDAP is disabled")
+	}
+
+	got := LDAPConfigFromYAML(config.LDAPConfigYAML{
+		Enabled:       true,
+		Host:          "ldap.example.com",
+		GroupMappings: map[string]string{"ops": "admin"},
+		DefaultRole:   "viewer",
+	})
+	if got == nil || got.Host != "ldap.example.com" {
+		t.Fatalf("unexpected LDAP config: %+v", got)
+	}
+	if got.GroupMappings["ops"] != "admin" || got.DefaultRole != "viewer" {
+		t.Errorf("group mappings not carried over: %+v", got)
+	}
+}
+
 func TestLDAPProvider_TestConnection_NotEnabled(t *testing.T) {
 	cfg := &LDAPConfig{
 		Enabled: false,
@@ -1,18 +1,32 @@
+//go:build !noai
+
 package web
 
 import (
+	"net/http"
 	"strings"
 
 	"github.com/cloudbro-kube-ai/k13d/pkg/ai"
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai/safety"
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai/session"
+	aitools "github.com/cloudbro-kube-ai/k13d/pkg/ai/tools"
 	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/mcp"
+)
+
+// llmClient and chatSessionStore are the AI assistant's client and
+// conversation store; builds with the noai tag replace them with stubs
+type (
+	llmClient        = ai.Client
+	chatSessionStore = session.Store
 )
 
-func createUsableAIClient(cfg *config.LLMConfig) (*ai.Client, bool, error) {
+func createUsableAIClient(cfg *config.LLMConfig) (*llmClient, bool, error) {
 	if cfg == nil || strings.TrimSpace(cfg.Provider) == "" || strings.TrimSpace(cfg.Model) == "" {
 		return nil, false, nil
 	}
 
-	client, err := ai.NewClient(cfg)
+	client, err := ai.NewSharedClient(cfg)
 	if err != nil {
 		return nil, false, err
 	}
@@ -20,3 +34,84 @@ func createUsableAIClient(cfg *config.LLMConfig) (*ai.Client, bool, error) {
 	ready := client.IsReady()
 	return client, ready, nil
 }
+
+func newChatSessionStore() (*chatSessionStore, error) {
+	return session.NewStore()
+}
+
+// installToolGuard enforces tool restrictions again at execution, after any
+// approval
+func (s *Server) installToolGuard() {
+	aitools.SetCommandGuard(safety.ToolGuard(s.currentToolApprovalPolicy))
+}
+
+// registerAssistantRoutes sets up AI chat, session, and LLM runtime routes.
+func (s *Server) registerAssistantRoutes(mux *http.ServeMux) {
+	auth := s.authManager.AuthMiddleware
+	aiFeature := s.authorizer.FeatureMiddleware(FeatureAIAssistant)
+
+	// AI chat and tool approval (feature-gated)
+	mux.HandleFunc("/api/chat/agentic", auth(aiFeature(s.handleAgenticChat)))
+	mux.HandleFunc("/api/tool/approve", auth(aiFeature(s.handleToolApprove)))
+
+	// AI session management
+	mux.HandleFunc("/api/sessions", auth(s.handleSessions))
+	mux.HandleFunc("/api/sessions/", auth(s.handleSession))
+
+	// AI / LLM configuration and status
+	mux.HandleFunc("/api/settings/llm", auth(s.handleLLMSettings))
+	mux.HandleFunc("/api/settings/agent", auth(s.handleAgentSettings))
+	mux.HandleFunc("/api/settings/tool-approval", auth(s.handleToolApprovalSettings))
+	mux.HandleFunc("/api/llm/test", auth(s.handleLLMTest))
+	mux.HandleFunc("/api/llm/status", auth(s.handleLLMStatus))
+	mux.HandleFunc("/api/ai/ping", auth(s.handleAIPing))
+	mux.HandleFunc("/api/llm/ollama/status", auth(s.handleOllamaStatus))
+	mux.HandleFunc("/api/llm/ollama/pull", auth(s.handleOllamaPull))
+	mux.HandleFunc("/api/llm/available-models", auth(s.handleAvailableModels))
+	mux.HandleFunc("/api/safety/analyze", auth(s.handleSafetyAnalysis))
+}
+
+// registerMCPTools registers tools from an MCP server with the AI client
+func (s *Server) registerMCPTools(serverName string) {
+	if s.aiClient == nil {
+		return
+	}
+
+	mcpTools := s.mcpClient.GetAllTools()
+	registry := s.aiClient.GetToolRegistry()
+
+	// Set the MCP executor if not already set
+	registry.SetMCPExecutor(mcp.NewMCPToolExecutor(s.mcpClient))
+
+	for _, tool := range mcpTools {
+		if tool.ServerName == serverName {
+			registry.RegisterMCPTool(tool.Name, tool.Description, tool.ServerName, tool.InputSchema)
+		}
+	}
+}
+
+// unregisterMCPTools removes an MCP server's tools from the AI client
+func (s *Server) unregisterMCPTools(serverName string) {
+	if s.aiClient != nil {
+		s.aiClient.GetToolRegistry().UnregisterMCPTools(serverName)
+	}
+}
+
+// builtinToolInfo lists the AI client's visible built-in tools
+func (s *Server) builtinToolInfo() []map[string]interface{} {
+	if s.aiClient == nil {
+		return nil
+	}
+	var builtinTools []map[string]interface{}
+	for _, t := range s.aiClient.VisibleTools() {
+		if t == nil || t.Type == "mcp" {
+			continue
+		}
+		builtinTools = append(builtinTools, map[string]interface{}{
+			"name":        t.Name,
+			"description": t.Description,
+			"type":        string(t.Type),
+		})
+	}
+	return builtinTools
+}
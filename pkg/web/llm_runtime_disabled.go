@@ -0,0 +1,48 @@
+//go:build noai
+
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+// Builds with the noai tag leave out pkg/ai and its providers. The server
+// never has an AI client, so the assistant routes are not registered and
+// reports have no AI analysis.
+
+// errAINotBuilt is returned by AI entry points in noai builds
+var errAINotBuilt = errors.New("the AI assistant is not included in this build (noai)")
+
+type llmClient struct{}
+
+// IsReady always reports false in noai builds
+func (c *llmClient) IsReady() bool { return false }
+
+// AskNonStreaming is unavailable in noai builds
+func (c *llmClient) AskNonStreaming(ctx context.Context, prompt string) (string, error) {
+	return "", errAINotBuilt
+}
+
+type chatSessionStore struct{}
+
+func createUsableAIClient(cfg *config.LLMConfig) (*llmClient, bool, error) {
+	return nil, false, nil
+}
+
+func newChatSessionStore() (*chatSessionStore, error) {
+	return nil, errAINotBuilt
+}
+
+func (s *Server) installToolGuard() {}
+
+func (s *Server) registerAssistantRoutes(mux *http.ServeMux) {}
+
+func (s *Server) registerMCPTools(serverName string) {}
+
+func (s *Server) unregisterMCPTools(serverName string) {}
+
+func (s *Server) builtinToolInfo() []map[string]interface{} { return nil }
@@ -617,13 +617,7 @@ func (s *Server) handleCronJobSuspend(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := r.Context()
-	clientset := s.k8sClient.Clientset
-
-	// Patch CronJob
-	patch := fmt.Sprintf(`{"spec":{"suspend":%t}}`, req.Suspend)
-	_, err := clientset.BatchV1().CronJobs(req.Namespace).Patch(ctx, req.Name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
-	if err != nil {
+	if err := s.k8sClient.SetCronJobSuspend(r.Context(), req.Namespace, req.Name, req.Suspend); err != nil {
 		writeK8sError(w, fmt.Errorf("failed to update CronJob: %w", err))
 		return
 	}
@@ -11,6 +11,17 @@ func (rg *ReportGenerator) GenerateAIAnalysis(ctx context.Context, report *Compr
 		return "", fmt.Errorf("AI client not available")
 	}
 
+	analysis, err := rg.server.aiClient.AskNonStreaming(ctx, buildAIAnalysisPrompt(report))
+	if err != nil {
+		return "", err
+	}
+
+	return analysis, nil
+}
+
+// buildAIAnalysisPrompt summarizes the report for the LLM. The prompt is
+// also the AI analysis cache key, so it must depend only on report data.
+func buildAIAnalysisPrompt(report *ComprehensiveReport) string {
 	// Build cost optimization summary
 	var costOptSummary strings.Builder
 	for i, opt := range report.FinOpsAnalysis.CostOptimizations {
@@ -21,11 +32,11 @@ func (rg *ReportGenerator) GenerateAIAnalysis(ctx context.Context, report *Compr
 	}
 
 	// Build summary for AI with FinOps focus
-	prompt := fmt.Sprintf(`You are a Kubernetes and FinOps expert. Analyze this cluster state and provide a comprehensive professional report (max 600 words) with special focus on cost optimization.
+	return fmt.Sprintf(`You are a Kubernetes and FinOps expert. Analyze this cluster state and provide a comprehensive professional report (max 600 words) with special focus on cost optimization.
 
 Cluster Summary:
 - Nodes: %d total, %d ready, %d not ready
-- Pods: %d total, %d running, %d pending, %d failed
+- Pods: %d total, %d running, %d pending, %d failed, %d stuck terminating
 - Deployments: %d total, %d healthy
 - Services: %d
 - Health Score: %.1f%%
@@ -44,8 +55,10 @@ Security Concerns:
 - Privileged Pods: %d
 - Host Network Pods: %d
 - Root Containers: %d
+- Namespaces Without NetworkPolicy: %d
 
 Warning Events: %d
+%s
 
 Top Images Used:
 %s
@@ -63,7 +76,7 @@ Please provide:
 
 Be concise, actionable, and focus on ROI for each recommendation.`,
 		report.NodeSummary.Total, report.NodeSummary.Ready, report.NodeSummary.NotReady,
-		report.Workloads.TotalPods, report.Workloads.RunningPods, report.Workloads.PendingPods, report.Workloads.FailedPods,
+		report.Workloads.TotalPods, report.Workloads.RunningPods, report.Workloads.PendingPods, report.Workloads.FailedPods, report.Workloads.StuckTerminatingPods,
 		report.Workloads.TotalDeployments, report.Workloads.HealthyDeploys,
 		report.Workloads.TotalServices,
 		report.HealthScore,
@@ -74,16 +87,19 @@ Be concise, actionable, and focus on ROI for each recommendation.`,
 		report.FinOpsAnalysis.ResourceEfficiency.PodsWithoutLimits,
 		costOptSummary.String(),
 		report.SecurityInfo.PrivilegedPods, report.SecurityInfo.HostNetworkPods, report.SecurityInfo.RootContainers,
-		len(report.Events),
+		report.SecurityInfo.NamespacesWithoutNetworkPolicy,
+		report.EventStats.Warning,
+		formatEventCategories(report.EventStats.Categories),
 		formatTopImages(report.Images, 5),
 	)
+}
 
-	analysis, err := rg.server.aiClient.AskNonStreaming(ctx, prompt)
-	if err != nil {
-		return "", err
+func formatEventCategories(categories []EventCategoryCount) string {
+	var sb strings.Builder
+	for _, cc := range categories {
+		sb.WriteString(fmt.Sprintf("- %s: %d events, %d occurrences (%s)\n", cc.Category, cc.Events, cc.Occurrences, strings.Join(cc.Reasons, ", ")))
 	}
-
-	return analysis, nil
+	return sb.String()
 }
 
 func formatTopImages(images []ImageInfo, limit int) string {
@@ -0,0 +1,95 @@
+package web
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// aiAnalysisCacheTTL is how long a generated report analysis is reused, long
+// enough to cover previewing a report and then downloading it
+const aiAnalysisCacheTTL = 15 * time.Minute
+
+// aiAnalysisIDHeader returns the analysis ID from report endpoints that do
+// not respond with JSON
+const aiAnalysisIDHeader = "X-K13D-AI-Analysis-ID"
+
+type cachedAIAnalysis struct {
+	analysis  string
+	expiresAt time.Time
+}
+
+// aiAnalysisCache holds recent report analyses keyed by a hash of the
+// report summary they were generated from
+type aiAnalysisCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedAIAnalysis
+}
+
+func newAIAnalysisCache(ttl time.Duration) *aiAnalysisCache {
+	return &aiAnalysisCache{
+		ttl:     ttl,
+		entries: make(map[string]cachedAIAnalysis),
+	}
+}
+
+// aiAnalysisID derives the cache key from the analysis prompt, which holds
+// every report input the LLM sees
+func aiAnalysisID(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return "ai-" + hex.EncodeToString(sum[:])[:24]
+}
+
+func (c *aiAnalysisCache) get(id string, now time.Time) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[id]
+	if !ok {
+		return "", false
+	}
+	if now.After(entry.expiresAt) {
+		delete(c.entries, id)
+		return "", false
+	}
+	return entry.analysis, true
+}
+
+func (c *aiAnalysisCache) put(id, analysis string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[id] = cachedAIAnalysis{analysis: analysis, expiresAt: now.Add(c.ttl)}
+}
+
+// CachedAIAnalysis returns the AI analysis for a report and its ID, reusing a
+// cached analysis when possible. A previous analysis ID, if given and still
+// cached, is reused even when the cluster has changed since; otherwise an
+// analysis of an identical report summary is reused, and only then is the
+// LLM called.
+func (rg *ReportGenerator) CachedAIAnalysis(ctx context.Context, report *ComprehensiveReport, analysisID string) (string, string, error) {
+	now := time.Now()
+	if analysisID != "" {
+		if analysis, ok := rg.aiCache.get(analysisID, now); ok {
+			return analysis, analysisID, nil
+		}
+	}
+
+	id := aiAnalysisID(buildAIAnalysisPrompt(report))
+	if analysis, ok := rg.aiCache.get(id, now); ok {
+		return analysis, id, nil
+	}
+
+	analysis, err := rg.GenerateAIAnalysis(ctx, report)
+	if err != nil {
+		return "", "", err
+	}
+	rg.aiCache.put(id, analysis, time.Now())
+	return analysis, id, nil
+}
@@ -0,0 +1,104 @@
+package web
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/log"
+)
+
+const (
+	defaultReportTitle  = "K13d Cluster Assessment Report"
+	defaultReportFooter = "Generated by K13d - AI-Powered Kubernetes Dashboard"
+
+	// maxReportLogoLength bounds a logo data URI so a report stays printable
+	maxReportLogoLength = 512 * 1024
+)
+
+// ReportBranding replaces the HTML report's title, logo, and footer. Empty
+// fields fall back to the reports.branding config, then to the defaults.
+type ReportBranding struct {
+	Title  string
+	Logo   string // http(s) URL or data:image URI
+	Footer string
+}
+
+// overlay returns b with its empty fields taken from base
+func (b ReportBranding) overlay(base ReportBranding) ReportBranding {
+	if b.Title == "" {
+		b.Title = base.Title
+	}
+	if b.Logo == "" {
+		b.Logo = base.Logo
+	}
+	if b.Footer == "" {
+		b.Footer = base.Footer
+	}
+	return b
+}
+
+// validateReportLogo accepts http(s) URLs and base64 or URL-encoded
+// data:image URIs, which is all an <img> tag in a report needs.
+func validateReportLogo(logo string) error {
+	if len(logo) > maxReportLogoLength {
+		return fmt.Errorf("logo is larger than %d bytes", maxReportLogoLength)
+	}
+	if strings.HasPrefix(logo, "data:") {
+		if !strings.HasPrefix(logo, "data:image/") || !strings.Contains(logo, ",") {
+			return fmt.Errorf("logo data URI must be a data:image/... URI")
+		}
+		return nil
+	}
+	u, err := url.Parse(logo)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("logo must be an http(s) URL or a data:image URI")
+	}
+	return nil
+}
+
+// parseReportBranding reads the title, logo, and footer query parameters
+func parseReportBranding(query url.Values) (ReportBranding, error) {
+	b := ReportBranding{
+		Title:  strings.TrimSpace(query.Get("title")),
+		Logo:   strings.TrimSpace(query.Get("logo")),
+		Footer: strings.TrimSpace(query.Get("footer")),
+	}
+	if b.Logo != "" {
+		if err := validateReportLogo(b.Logo); err != nil {
+			return b, fmt.Errorf("invalid logo: %w", err)
+		}
+	}
+	return b, nil
+}
+
+// reportBranding resolves the branding for report: its own overrides, then
+// the reports.branding config, then the k13d defaults. An invalid logo in
+// config is skipped rather than failing the export.
+func (rg *ReportGenerator) reportBranding(report *ComprehensiveReport) ReportBranding {
+	var configured ReportBranding
+	if rg != nil && rg.server != nil && rg.server.cfg != nil {
+		c := rg.server.cfg.Reports.Branding
+		configured = ReportBranding{Title: c.Title, Logo: c.Logo, Footer: c.Footer}
+		if configured.Logo != "" {
+			if err := validateReportLogo(configured.Logo); err != nil {
+				log.Warnf("Ignoring reports.branding.logo: %v", err)
+				configured.Logo = ""
+			}
+		}
+	}
+	return report.Branding.
+		overlay(configured).
+		overlay(ReportBranding{Title: defaultReportTitle, Footer: defaultReportFooter})
+}
+
+// headerHTML renders the report heading with the optional logo
+func (b ReportBranding) headerHTML() string {
+	var sb strings.Builder
+	if b.Logo != "" {
+		sb.WriteString(fmt.Sprintf(`<img class="report-logo" src="%s" alt="">`, html.EscapeString(b.Logo)))
+	}
+	sb.WriteString(fmt.Sprintf(`<h1 id="top">%s</h1>`, html.EscapeString(b.Title)))
+	return sb.String()
+}
@@ -0,0 +1,63 @@
+package web
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// collectNodeCapacity fills the report's node capacity section and counts
+// over-committed and under-utilized nodes. This answers "can more pods fit"
+// and "can the cluster shrink", which allocatable alone does not.
+func (rg *ReportGenerator) collectNodeCapacity(ctx context.Context, report *ComprehensiveReport) {
+	nodes, err := rg.server.k8sClient.GetNodeCapacity(ctx)
+	if err != nil {
+		report.addWarning(fmt.Sprintf("could not compute node capacity: %v", err))
+		return
+	}
+	report.NodeCapacity = buildNodeCapacityInfos(nodes)
+	for _, info := range report.NodeCapacity {
+		switch info.Status {
+		case k8s.NodeCapacityOverCommitted:
+			report.NodeSummary.OverCommitted++
+		case k8s.NodeCapacityUnderUtilized:
+			report.NodeSummary.UnderUtilized++
+		}
+	}
+}
+
+func buildNodeCapacityInfos(nodes []k8s.NodeCapacity) []NodeCapacityInfo {
+	cpu := func(milli int64) string { return resource.NewMilliQuantity(milli, resource.DecimalSI).String() }
+	mem := func(mb int64) string { return resource.NewQuantity(mb*1024*1024, resource.BinarySI).String() }
+
+	infos := make([]NodeCapacityInfo, 0, len(nodes))
+	for _, nc := range nodes {
+		info := NodeCapacityInfo{
+			Name:                   nc.Name,
+			CPUAllocatable:         cpu(nc.CPUAllocatableMilli),
+			CPURequested:           cpu(nc.CPURequestedMilli),
+			CPURequestedPercent:    k8s.CapacityPercent(nc.CPURequestedMilli, nc.CPUAllocatableMilli),
+			CPULimits:              cpu(nc.CPULimitsMilli),
+			CPULimitsPercent:       k8s.CapacityPercent(nc.CPULimitsMilli, nc.CPUAllocatableMilli),
+			MemoryAllocatable:      mem(nc.MemAllocatableMB),
+			MemoryRequested:        mem(nc.MemRequestedMB),
+			MemoryRequestedPercent: k8s.CapacityPercent(nc.MemRequestedMB, nc.MemAllocatableMB),
+			MemoryLimits:           mem(nc.MemLimitsMB),
+			MemoryLimitsPercent:    k8s.CapacityPercent(nc.MemLimitsMB, nc.MemAllocatableMB),
+			Pods:                   nc.Pods,
+			PodCapacity:            nc.PodCapacity,
+			Status:                 nc.Status,
+			Reasons:                nc.Reasons,
+		}
+		if nc.UsageAvailable {
+			info.CPUUsed = cpu(nc.CPUUsedMilli)
+			info.CPUUsedPercent = k8s.CapacityPercent(nc.CPUUsedMilli, nc.CPUAllocatableMilli)
+			info.MemoryUsed = mem(nc.MemUsedMB)
+			info.MemoryUsedPercent = k8s.CapacityPercent(nc.MemUsedMB, nc.MemAllocatableMB)
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
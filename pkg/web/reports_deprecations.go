@@ -0,0 +1,58 @@
+package web
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+// generateDeprecatedAPIReport finds the objects and, when drift.manifest_dir
+// is set, the manifests that use API versions deprecated at the server's
+// version. A tenant report checks its namespace's objects only. It returns
+// nil and adds a warning when the scan fails.
+func (rg *ReportGenerator) generateDeprecatedAPIReport(ctx context.Context, report *ComprehensiveReport) *k8s.DeprecatedAPIScan {
+	manifestDir := ""
+	if rg.server.cfg != nil && report.Namespace == "" {
+		manifestDir = rg.server.cfg.Drift.ManifestDir
+	}
+	scan, err := rg.server.k8sClient.DetectDeprecatedAPIs(ctx, report.Namespace, manifestDir)
+	if err != nil {
+		report.addWarning(fmt.Sprintf("deprecated API scan failed: %v", err))
+		return nil
+	}
+
+	// Live objects in hidden namespaces are left out like everywhere else
+	uses := scan.Uses[:0]
+	scan.Deprecated, scan.Removed = 0, 0
+	for _, u := range scan.Uses {
+		if u.Source == k8s.DeprecationSourceLive && u.Namespace != "" && rg.reportNamespaceHidden(ctx, u.Namespace) {
+			continue
+		}
+		uses = append(uses, u)
+		if u.Status == k8s.APIRemoved {
+			scan.Removed++
+		} else {
+			scan.Deprecated++
+		}
+	}
+	scan.Uses = uses
+	return scan
+}
+
+// deprecatedAPIReplacement describes what to migrate a deprecated API use to
+func deprecatedAPIReplacement(u k8s.DeprecatedAPIUse) string {
+	if u.Replacement == "" {
+		return "no replacement"
+	}
+	return u.Replacement
+}
+
+// deprecatedAPILocation is where a deprecated API use was found: the
+// manifest file, or the field managers of a live object
+func deprecatedAPILocation(u k8s.DeprecatedAPIUse) string {
+	if u.Source == k8s.DeprecationSourceManifest {
+		return u.File
+	}
+	return "live (" + u.Via + ")"
+}
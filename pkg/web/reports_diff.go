@@ -0,0 +1,475 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+// maxDiffNamespaceCosts caps the namespaces listed under cost changes
+const maxDiffNamespaceCosts = 10
+
+// ReportDiff summarizes what changed between two saved reports
+type ReportDiff struct {
+	OldGeneratedAt time.Time `json:"old_generated_at"`
+	NewGeneratedAt time.Time `json:"new_generated_at"`
+
+	OldHealthScore float64 `json:"old_health_score"`
+	NewHealthScore float64 `json:"new_health_score"`
+
+	OldWorkloads WorkloadSummary `json:"old_workloads"`
+	NewWorkloads WorkloadSummary `json:"new_workloads"`
+
+	// Security is compared when both reports include a security section
+	SecurityCompared bool            `json:"security_compared"`
+	NewFindings      []ReportFinding `json:"new_findings,omitempty"`
+	ResolvedFindings []ReportFinding `json:"resolved_findings,omitempty"`
+
+	// Cost is compared when both reports include the FinOps section
+	CostCompared   bool                  `json:"cost_compared"`
+	OldMonthlyCost float64               `json:"old_monthly_cost"`
+	NewMonthlyCost float64               `json:"new_monthly_cost"`
+	NamespaceCosts []NamespaceCostChange `json:"namespace_costs,omitempty"`
+
+	// Pods are compared when both reports include the workloads section
+	PodsCompared    bool      `json:"pods_compared"`
+	NewFailedPods   []PodInfo `json:"new_failed_pods,omitempty"`
+	RecoveredPods   []PodInfo `json:"recovered_pods,omitempty"`
+	PodListsPartial bool      `json:"pod_lists_partial,omitempty"` // a report holds one page of pods
+}
+
+// ReportFinding is one security finding, keyed by everything but its
+// remediation text
+type ReportFinding struct {
+	Category string `json:"category"` // pod, rbac, or network
+	Severity string `json:"severity"`
+	Resource string `json:"resource"`
+	Issue    string `json:"issue"`
+}
+
+// NamespaceCostChange is the estimated monthly cost of a namespace in both
+// reports; a namespace missing from one report costs 0 there
+type NamespaceCostChange struct {
+	Namespace string  `json:"namespace"`
+	OldCost   float64 `json:"old_cost"`
+	NewCost   float64 `json:"new_cost"`
+}
+
+// Delta returns the cost change
+func (c NamespaceCostChange) Delta() float64 {
+	return c.NewCost - c.OldCost
+}
+
+// LoadReportFile reads a report saved as JSON from /api/reports
+func LoadReportFile(path string) (*ComprehensiveReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var report ComprehensiveReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("%s is not a JSON report: %w", path, err)
+	}
+	if report.GeneratedAt.IsZero() {
+		return nil, fmt.Errorf("%s is not a JSON report: generated_at is missing", path)
+	}
+	return &report, nil
+}
+
+// DiffReports compares an older report with a newer one. Sections missing
+// from either report are not compared.
+func DiffReports(older, newer *ComprehensiveReport) *ReportDiff {
+	oldSections, newSections := reportSectionsOrAll(older), reportSectionsOrAll(newer)
+	diff := &ReportDiff{
+		OldGeneratedAt: older.GeneratedAt,
+		NewGeneratedAt: newer.GeneratedAt,
+		OldHealthScore: older.HealthScore,
+		NewHealthScore: newer.HealthScore,
+		OldWorkloads:   older.Workloads,
+		NewWorkloads:   newer.Workloads,
+	}
+
+	if older.SecurityScan != nil && newer.SecurityScan != nil {
+		diff.SecurityCompared = true
+		oldFindings, newFindings := reportFindings(older.SecurityScan), reportFindings(newer.SecurityScan)
+		diff.NewFindings = findingsMissingFrom(newFindings, oldFindings)
+		diff.ResolvedFindings = findingsMissingFrom(oldFindings, newFindings)
+	}
+
+	if oldSections.FinOps && newSections.FinOps {
+		diff.CostCompared = true
+		diff.OldMonthlyCost = older.FinOpsAnalysis.TotalEstimatedMonthlyCost
+		diff.NewMonthlyCost = newer.FinOpsAnalysis.TotalEstimatedMonthlyCost
+		diff.NamespaceCosts = namespaceCostChanges(older.FinOpsAnalysis.CostByNamespace, newer.FinOpsAnalysis.CostByNamespace)
+	}
+
+	if oldSections.Workloads && newSections.Workloads {
+		diff.PodsCompared = true
+		diff.PodListsPartial = older.Pagination != nil || newer.Pagination != nil
+		oldFailed, newFailed := failedPods(older.Pods), failedPods(newer.Pods)
+		for _, key := range sortedKeys(newFailed) {
+			if _, ok := oldFailed[key]; !ok {
+				diff.NewFailedPods = append(diff.NewFailedPods, newFailed[key])
+			}
+		}
+		current := make(map[string]bool, len(newer.Pods))
+		for _, pod := range newer.Pods {
+			current[pod.Namespace+"/"+pod.Name] = true
+		}
+		for _, key := range sortedKeys(oldFailed) {
+			// Failed pods that were deleted since count as recovered too
+			if _, ok := newFailed[key]; !ok {
+				pod := oldFailed[key]
+				if !current[key] {
+					pod.Status = "Deleted"
+				}
+				diff.RecoveredPods = append(diff.RecoveredPods, pod)
+			}
+		}
+	}
+	return diff
+}
+
+// reportFindings flattens the pod, RBAC, and network issues of a scan
+func reportFindings(scan *SecurityScanReport) []ReportFinding {
+	var findings []ReportFinding
+	for _, i := range scan.PodSecurityIssues {
+		resource := i.Namespace + "/" + i.Pod
+		if i.Container != "" {
+			resource += "/" + i.Container
+		}
+		findings = append(findings, ReportFinding{Category: "pod", Severity: i.Severity, Resource: resource, Issue: i.Issue})
+	}
+	for _, i := range scan.RBACIssues {
+		resource := i.Kind + "/" + i.Name
+		if i.Namespace != "" {
+			resource = i.Namespace + "/" + resource
+		}
+		findings = append(findings, ReportFinding{Category: "rbac", Severity: i.Severity, Resource: resource, Issue: i.Issue})
+	}
+	for _, i := range scan.NetworkIssues {
+		findings = append(findings, ReportFinding{Category: "network", Severity: i.Severity, Resource: i.Namespace + "/" + i.Resource, Issue: i.Issue})
+	}
+	return findings
+}
+
+// findingsMissingFrom returns the findings of a that b does not have, most
+// severe first
+func findingsMissingFrom(a, b []ReportFinding) []ReportFinding {
+	seen := make(map[ReportFinding]bool, len(b))
+	for _, f := range b {
+		seen[f] = true
+	}
+	var missing []ReportFinding
+	for _, f := range a {
+		if !seen[f] {
+			missing = append(missing, f)
+			seen[f] = true // report duplicates once
+		}
+	}
+	sort.SliceStable(missing, func(i, j int) bool {
+		ri, rj := findingSeverityRank(missing[i].Severity), findingSeverityRank(missing[j].Severity)
+		if ri != rj {
+			return ri < rj
+		}
+		return missing[i].Resource < missing[j].Resource
+	})
+	return missing
+}
+
+func findingSeverityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return 0
+	case "high":
+		return 1
+	case "medium":
+		return 2
+	case "low":
+		return 3
+	default:
+		return 4
+	}
+}
+
+// namespaceCostChanges returns the namespaces whose cost changed, largest
+// change first
+func namespaceCostChanges(older, newer []NamespaceCost) []NamespaceCostChange {
+	byName := make(map[string]*NamespaceCostChange)
+	for _, c := range older {
+		byName[c.Namespace] = &NamespaceCostChange{Namespace: c.Namespace, OldCost: c.EstimatedCost}
+	}
+	for _, c := range newer {
+		if change, ok := byName[c.Namespace]; ok {
+			change.NewCost = c.EstimatedCost
+		} else {
+			byName[c.Namespace] = &NamespaceCostChange{Namespace: c.Namespace, NewCost: c.EstimatedCost}
+		}
+	}
+	var changes []NamespaceCostChange
+	for _, c := range byName {
+		if math.Abs(c.Delta()) >= 0.01 {
+			changes = append(changes, *c)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		di, dj := math.Abs(changes[i].Delta()), math.Abs(changes[j].Delta())
+		if di != dj {
+			return di > dj
+		}
+		return changes[i].Namespace < changes[j].Namespace
+	})
+	if len(changes) > maxDiffNamespaceCosts {
+		changes = changes[:maxDiffNamespaceCosts]
+	}
+	return changes
+}
+
+// isFailedPodStatus reports whether the report's HTML export marks a pod
+// status as failed
+func isFailedPodStatus(status string) bool {
+	switch status {
+	case "Failed", "CrashLoopBackOff", "Error":
+		return true
+	}
+	return k8s.IsStuckTerminatingStatus(status)
+}
+
+// failedPods returns the failed pods keyed by namespace/name
+func failedPods(pods []PodInfo) map[string]PodInfo {
+	failed := make(map[string]PodInfo)
+	for _, pod := range pods {
+		if isFailedPodStatus(pod.Status) {
+			failed[pod.Namespace+"/"+pod.Name] = pod
+		}
+	}
+	return failed
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// diffReportTime formats a report timestamp for the diff headings
+func diffReportTime(t time.Time) string {
+	return t.Format("2006-01-02 15:04 MST")
+}
+
+// signed formats a delta with an explicit sign
+func signed(format string, v float64) string {
+	if v > 0 {
+		return "+" + fmt.Sprintf(format, v)
+	}
+	return fmt.Sprintf(format, v)
+}
+
+// ExportDiffToMarkdown renders a report diff as markdown
+func ExportDiffToMarkdown(d *ReportDiff) string {
+	var sb strings.Builder
+	sb.WriteString("# Cluster Report Changes\n\n")
+	sb.WriteString(fmt.Sprintf("**From:** %s  \n**To:** %s\n\n", diffReportTime(d.OldGeneratedAt), diffReportTime(d.NewGeneratedAt)))
+
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString("| Metric | Before | After | Change |\n")
+	sb.WriteString("|--------|--------|-------|--------|\n")
+	sb.WriteString(fmt.Sprintf("| Health score | %.1f | %.1f | %s |\n", d.OldHealthScore, d.NewHealthScore, signed("%.1f", d.NewHealthScore-d.OldHealthScore)))
+	for _, row := range diffWorkloadRows(d) {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %s |\n", row.label, row.before, row.after, signed("%.0f", float64(row.after-row.before))))
+	}
+	if d.CostCompared {
+		sb.WriteString(fmt.Sprintf("| Est. monthly cost | $%.2f | $%.2f | %s |\n", d.OldMonthlyCost, d.NewMonthlyCost, signed("$%.2f", d.NewMonthlyCost-d.OldMonthlyCost)))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## Security Findings\n\n")
+	if !d.SecurityCompared {
+		sb.WriteString("_Not compared: a report has no security section._\n\n")
+	} else {
+		writeFindingsMarkdown(&sb, "New", d.NewFindings)
+		writeFindingsMarkdown(&sb, "Resolved", d.ResolvedFindings)
+	}
+
+	if d.CostCompared && len(d.NamespaceCosts) > 0 {
+		sb.WriteString("## Cost By Namespace\n\n")
+		sb.WriteString("| Namespace | Before | After | Change |\n")
+		sb.WriteString("|-----------|--------|-------|--------|\n")
+		for _, c := range d.NamespaceCosts {
+			sb.WriteString(fmt.Sprintf("| %s | $%.2f | $%.2f | %s |\n", c.Namespace, c.OldCost, c.NewCost, signed("$%.2f", c.Delta())))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Failed Pods\n\n")
+	if !d.PodsCompared {
+		sb.WriteString("_Not compared: a report has no workloads section._\n")
+		return sb.String()
+	}
+	if d.PodListsPartial {
+		sb.WriteString("_A report lists one page of pods; pods on other pages are not compared._\n\n")
+	}
+	writePodsMarkdown(&sb, "Newly failed", d.NewFailedPods)
+	writePodsMarkdown(&sb, "Recovered", d.RecoveredPods)
+	return sb.String()
+}
+
+func writeFindingsMarkdown(sb *strings.Builder, title string, findings []ReportFinding) {
+	sb.WriteString(fmt.Sprintf("### %s (%d)\n\n", title, len(findings)))
+	if len(findings) == 0 {
+		sb.WriteString("None.\n\n")
+		return
+	}
+	sb.WriteString("| Severity | Category | Resource | Issue |\n")
+	sb.WriteString("|----------|----------|----------|-------|\n")
+	for _, f := range findings {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", f.Severity, f.Category, f.Resource, f.Issue))
+	}
+	sb.WriteString("\n")
+}
+
+func writePodsMarkdown(sb *strings.Builder, title string, pods []PodInfo) {
+	sb.WriteString(fmt.Sprintf("### %s (%d)\n\n", title, len(pods)))
+	if len(pods) == 0 {
+		sb.WriteString("None.\n\n")
+		return
+	}
+	sb.WriteString("| Namespace | Pod | Status | Restarts |\n")
+	sb.WriteString("|-----------|-----|--------|----------|\n")
+	for _, p := range pods {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d |\n", p.Namespace, p.Name, p.Status, p.Restarts))
+	}
+	sb.WriteString("\n")
+}
+
+type diffWorkloadRow struct {
+	label         string
+	before, after int
+	better        int // 1 when more is better, -1 when fewer is, 0 for neither
+}
+
+func diffWorkloadRows(d *ReportDiff) []diffWorkloadRow {
+	return []diffWorkloadRow{
+		{"Pods", d.OldWorkloads.TotalPods, d.NewWorkloads.TotalPods, 0},
+		{"Running pods", d.OldWorkloads.RunningPods, d.NewWorkloads.RunningPods, 1},
+		{"Failed pods", d.OldWorkloads.FailedPods, d.NewWorkloads.FailedPods, -1},
+	}
+}
+
+// ExportDiffToHTML renders a report diff as a standalone HTML page in the
+// report theme
+func (rg *ReportGenerator) ExportDiffToHTML(d *ReportDiff) string {
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"UTF-8\">\n<title>Cluster Report Changes</title>\n<style>\n")
+	sb.WriteString(rg.reportThemeCSS())
+	sb.WriteString(`body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; margin: 40px; color: var(--k13d-text); line-height: 1.6; }
+h1 { color: var(--k13d-heading); border-bottom: 3px solid var(--k13d-accent); padding-bottom: 10px; }
+h2 { color: var(--k13d-subheading); margin-top: 32px; border-bottom: 2px solid var(--k13d-accent); padding-bottom: 6px; }
+table { width: 100%; border-collapse: collapse; margin: 15px 0; font-size: 12px; }
+th, td { padding: 8px 12px; text-align: left; border: 1px solid var(--k13d-border); }
+th { background: var(--k13d-table-header-bg); color: var(--k13d-table-header-fg); }
+.better { color: var(--k13d-success); font-weight: bold; }
+.worse { color: var(--k13d-danger); font-weight: bold; }
+`)
+	sb.WriteString("</style>\n</head>\n<body>\n<h1>Cluster Report Changes</h1>\n")
+	sb.WriteString(fmt.Sprintf("<p>From <strong>%s</strong> to <strong>%s</strong></p>\n",
+		html.EscapeString(diffReportTime(d.OldGeneratedAt)), html.EscapeString(diffReportTime(d.NewGeneratedAt))))
+
+	sb.WriteString("<h2>Summary</h2>\n<table><tr><th>Metric</th><th>Before</th><th>After</th><th>Change</th></tr>\n")
+	sb.WriteString(fmt.Sprintf("<tr><td>Health score</td><td>%.1f</td><td>%.1f</td>%s</tr>\n",
+		d.OldHealthScore, d.NewHealthScore, diffChangeCell(signed("%.1f", d.NewHealthScore-d.OldHealthScore), d.NewHealthScore-d.OldHealthScore, 1)))
+	for _, row := range diffWorkloadRows(d) {
+		delta := float64(row.after - row.before)
+		sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td><td>%d</td>%s</tr>\n",
+			row.label, row.before, row.after, diffChangeCell(signed("%.0f", delta), delta, row.better)))
+	}
+	if d.CostCompared {
+		delta := d.NewMonthlyCost - d.OldMonthlyCost
+		sb.WriteString(fmt.Sprintf("<tr><td>Est. monthly cost</td><td>$%.2f</td><td>$%.2f</td>%s</tr>\n",
+			d.OldMonthlyCost, d.NewMonthlyCost, diffChangeCell(signed("$%.2f", delta), delta, -1)))
+	}
+	sb.WriteString("</table>\n")
+
+	sb.WriteString("<h2>Security Findings</h2>\n")
+	if !d.SecurityCompared {
+		sb.WriteString("<p><em>Not compared: a report has no security section.</em></p>\n")
+	} else {
+		writeFindingsHTML(&sb, "New", d.NewFindings)
+		writeFindingsHTML(&sb, "Resolved", d.ResolvedFindings)
+	}
+
+	if d.CostCompared && len(d.NamespaceCosts) > 0 {
+		sb.WriteString("<h2>Cost By Namespace</h2>\n<table><tr><th>Namespace</th><th>Before</th><th>After</th><th>Change</th></tr>\n")
+		for _, c := range d.NamespaceCosts {
+			sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>$%.2f</td><td>$%.2f</td>%s</tr>\n",
+				html.EscapeString(c.Namespace), c.OldCost, c.NewCost, diffChangeCell(signed("$%.2f", c.Delta()), c.Delta(), -1)))
+		}
+		sb.WriteString("</table>\n")
+	}
+
+	sb.WriteString("<h2>Failed Pods</h2>\n")
+	if !d.PodsCompared {
+		sb.WriteString("<p><em>Not compared: a report has no workloads section.</em></p>\n")
+	} else {
+		if d.PodListsPartial {
+			sb.WriteString("<p><em>A report lists one page of pods; pods on other pages are not compared.</em></p>\n")
+		}
+		writePodsHTML(&sb, "Newly failed", d.NewFailedPods)
+		writePodsHTML(&sb, "Recovered", d.RecoveredPods)
+	}
+	sb.WriteString("</body></html>\n")
+	return sb.String()
+}
+
+// diffChangeCell renders a change as a table cell, colored by whether it is
+// an improvement. better is 1 when an increase improves, -1 when a decrease
+// does, and 0 to leave the cell uncolored.
+func diffChangeCell(text string, delta float64, better int) string {
+	class := ""
+	switch {
+	case better == 0 || delta == 0:
+	case (delta > 0) == (better > 0):
+		class = ` class="better"`
+	default:
+		class = ` class="worse"`
+	}
+	return fmt.Sprintf("<td%s>%s</td>", class, html.EscapeString(text))
+}
+
+func writeFindingsHTML(sb *strings.Builder, title string, findings []ReportFinding) {
+	sb.WriteString(fmt.Sprintf("<h3>%s (%d)</h3>\n", title, len(findings)))
+	if len(findings) == 0 {
+		sb.WriteString("<p>None.</p>\n")
+		return
+	}
+	sb.WriteString("<table><tr><th>Severity</th><th>Category</th><th>Resource</th><th>Issue</th></tr>\n")
+	for _, f := range findings {
+		sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			html.EscapeString(f.Severity), f.Category, html.EscapeString(f.Resource), html.EscapeString(f.Issue)))
+	}
+	sb.WriteString("</table>\n")
+}
+
+func writePodsHTML(sb *strings.Builder, title string, pods []PodInfo) {
+	sb.WriteString(fmt.Sprintf("<h3>%s (%d)</h3>\n", title, len(pods)))
+	if len(pods) == 0 {
+		sb.WriteString("<p>None.</p>\n")
+		return
+	}
+	sb.WriteString("<table><tr><th>Namespace</th><th>Pod</th><th>Status</th><th>Restarts</th></tr>\n")
+	for _, p := range pods {
+		sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td><td>%d</td></tr>\n",
+			html.EscapeString(p.Namespace), html.EscapeString(p.Name), html.EscapeString(p.Status), p.Restarts))
+	}
+	sb.WriteString("</table>\n")
+}
@@ -0,0 +1,66 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+// generateDriftReport compares drift.manifest_dir with the live resources.
+// It returns nil when no manifest directory is configured.
+func (rg *ReportGenerator) generateDriftReport(ctx context.Context) *DriftReport {
+	if rg.server.cfg == nil || rg.server.cfg.Drift.ManifestDir == "" {
+		return nil
+	}
+	cfg := rg.server.cfg.Drift
+	namespace := cfg.DefaultNamespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	report := &DriftReport{ManifestDir: cfg.ManifestDir, Resources: []k8s.ResourceDrift{}}
+	drifts, err := rg.server.k8sClient.DetectDrift(ctx, cfg.ManifestDir, namespace)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Resources = drifts
+	for _, d := range drifts {
+		switch d.Status {
+		case k8s.DriftInSync:
+			report.InSync++
+		case k8s.DriftDrifted:
+			report.Drifted++
+		case k8s.DriftMissing:
+			report.Missing++
+		case k8s.DriftError:
+			report.Errors++
+		}
+	}
+	return report
+}
+
+// driftFieldSummary describes an object's differences one per line:
+// ~ for changed, + for set only live, - for set only in the manifest
+func driftFieldSummary(d k8s.ResourceDrift) string {
+	switch {
+	case d.Err != "":
+		return d.Err
+	case d.Status == k8s.DriftMissing:
+		return "not found in the cluster"
+	}
+	lines := make([]string, 0, len(d.Fields))
+	for _, f := range d.Fields {
+		switch f.Type {
+		case k8s.FieldChanged:
+			lines = append(lines, fmt.Sprintf("~ %s: %s -> %s", f.Path, f.Desired, f.Live))
+		case k8s.FieldAdded:
+			lines = append(lines, fmt.Sprintf("+ %s: %s", f.Path, f.Live))
+		case k8s.FieldRemoved:
+			lines = append(lines, fmt.Sprintf("- %s: %s", f.Path, f.Desired))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
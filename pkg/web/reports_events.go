@@ -0,0 +1,170 @@
+package web
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// defaultReportEventLimit applies when no config is loaded
+const defaultReportEventLimit = 50
+
+// Event categories, in the order reports list them when counts tie
+const (
+	EventCategoryScheduling   = "scheduling"
+	EventCategoryImagePull    = "image-pull"
+	EventCategoryOOM          = "oom"
+	EventCategoryProbeFailure = "probe-failure"
+	EventCategoryOther        = "other"
+)
+
+var eventCategoryOrder = []string{
+	EventCategoryScheduling, EventCategoryImagePull, EventCategoryOOM,
+	EventCategoryProbeFailure, EventCategoryOther,
+}
+
+// reportEventCategory buckets a Warning event for reports by its reason.
+// Kubelet reports image pull failures under the generic "Failed" and
+// "BackOff" reasons, so the message is consulted for those.
+func reportEventCategory(event *corev1.Event) string {
+	msg := strings.ToLower(event.Message)
+	switch event.Reason {
+	case "FailedScheduling", "NotTriggerScaleUp", "Preempting", "Preempted":
+		return EventCategoryScheduling
+	case "ErrImagePull", "ImagePullBackOff", "ErrImageNeverPull", "InspectFailed":
+		return EventCategoryImagePull
+	case "OOMKilling", "OOMKilled", "SystemOOM":
+		return EventCategoryOOM
+	case "Unhealthy", "ProbeWarning":
+		return EventCategoryProbeFailure
+	}
+	switch {
+	case strings.Contains(msg, "pull image") || strings.Contains(msg, "pulling image") || strings.Contains(msg, "imagepullbackoff"):
+		return EventCategoryImagePull
+	case strings.Contains(msg, "oomkilled") || strings.Contains(msg, "out of memory"):
+		return EventCategoryOOM
+	case strings.Contains(msg, "probe failed"):
+		return EventCategoryProbeFailure
+	}
+	return EventCategoryOther
+}
+
+// eventLastSeen returns when an event last occurred, falling back to the
+// fields newer event producers fill instead of LastTimestamp.
+func eventLastSeen(e *corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.FirstTimestamp.Time
+	}
+}
+
+// buildReportEvents lists the most recent Warning events, then Normal events
+// when requested, up to opts.Limit. The returned stats count every event so
+// a limit never hides how many problems the cluster reported.
+func buildReportEvents(events []corev1.Event, opts ReportEventOptions) ([]EventInfo, ReportEventStats) {
+	stats := ReportEventStats{Limit: opts.Limit}
+	categories := make(map[string]*EventCategoryCount)
+
+	var candidates []corev1.Event
+	for _, event := range events {
+		switch event.Type {
+		case corev1.EventTypeWarning:
+			stats.Warning++
+			category := reportEventCategory(&event)
+			cc, ok := categories[category]
+			if !ok {
+				cc = &EventCategoryCount{Category: category}
+				categories[category] = cc
+			}
+			cc.Events++
+			cc.Occurrences += max(int(event.Count), 1)
+			if !containsString(cc.Reasons, event.Reason) {
+				cc.Reasons = append(cc.Reasons, event.Reason)
+			}
+			candidates = append(candidates, event)
+		case corev1.EventTypeNormal:
+			stats.Normal++
+			if opts.IncludeNormal {
+				candidates = append(candidates, event)
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		wi, wj := candidates[i].Type == corev1.EventTypeWarning, candidates[j].Type == corev1.EventTypeWarning
+		if wi != wj {
+			return wi
+		}
+		return eventLastSeen(&candidates[i]).After(eventLastSeen(&candidates[j]))
+	})
+	if opts.Limit > 0 && len(candidates) > opts.Limit {
+		stats.Omitted = len(candidates) - opts.Limit
+		candidates = candidates[:opts.Limit]
+	}
+
+	infos := make([]EventInfo, 0, len(candidates))
+	for _, event := range candidates {
+		info := EventInfo{
+			Type:      event.Type,
+			Reason:    event.Reason,
+			Object:    fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
+			Message:   event.Message,
+			Count:     int(event.Count),
+			FirstSeen: event.FirstTimestamp.Format(time.RFC3339),
+			LastSeen:  eventLastSeen(&event).Format(time.RFC3339),
+		}
+		if event.Type == corev1.EventTypeWarning {
+			info.Category = reportEventCategory(&event)
+		}
+		infos = append(infos, info)
+	}
+	stats.Listed = len(infos)
+
+	for _, name := range eventCategoryOrder {
+		if cc, ok := categories[name]; ok {
+			sort.Strings(cc.Reasons)
+			stats.Categories = append(stats.Categories, *cc)
+		}
+	}
+	sort.SliceStable(stats.Categories, func(i, j int) bool {
+		return stats.Categories[i].Occurrences > stats.Categories[j].Occurrences
+	})
+	return infos, stats
+}
+
+// defaultEventOptions returns the events settings from the reports config
+func (rg *ReportGenerator) defaultEventOptions() ReportEventOptions {
+	if rg.server == nil || rg.server.cfg == nil {
+		return ReportEventOptions{Limit: defaultReportEventLimit}
+	}
+	return ReportEventOptions{
+		Limit:         max(rg.server.cfg.Reports.EventLimit, 0),
+		IncludeNormal: rg.server.cfg.Reports.IncludeNormalEvents,
+	}
+}
+
+// parseReportEventOptions applies the event_limit and normal_events query
+// parameters over the configured defaults.
+func (rg *ReportGenerator) parseReportEventOptions(query url.Values) (*ReportEventOptions, error) {
+	opts := rg.defaultEventOptions()
+	if v := query.Get("event_limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid event_limit %q: must be a non-negative integer (0 lists all events)", v)
+		}
+		opts.Limit = limit
+	}
+	if v := query.Get("normal_events"); v != "" {
+		opts.IncludeNormal = v == "true"
+	}
+	return &opts, nil
+}
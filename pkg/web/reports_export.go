@@ -4,8 +4,13 @@ import (
 	"bytes"
 	"encoding/csv"
 	"fmt"
+	"html"
 	"strings"
 	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/cloudbro-kube-ai/k13d/pkg/metrics"
 )
 
 func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, error) {
@@ -14,26 +19,57 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 	sections := reportSectionsOrAll(report)
 
 	// Write header section
-	_ = writer.Write([]string{"K13d Cluster Report"})
+	if report.Namespace != "" {
+		_ = writer.Write([]string{"K13d Namespace Report"})
+		_ = writer.Write([]string{"Namespace:", report.Namespace})
+	} else {
+		_ = writer.Write([]string{"K13d Cluster Report"})
+	}
 	_ = writer.Write([]string{"Generated At:", report.GeneratedAt.Format(time.RFC3339)})
 	_ = writer.Write([]string{"Generated By:", report.GeneratedBy})
 	_ = writer.Write([]string{"Health Score:", fmt.Sprintf("%.1f%%", report.HealthScore)})
+	if page := report.Pagination; page != nil {
+		_ = writer.Write([]string{"Page:", fmt.Sprintf("offset %d, limit %d", page.Offset, page.Limit)})
+		if page.HasMore {
+			_ = writer.Write([]string{"Next Offset:", fmt.Sprintf("%d", page.NextOffset)})
+		}
+	}
 	_ = writer.Write([]string{""})
 
+	if len(report.Warnings) > 0 {
+		_ = writer.Write([]string{"=== WARNINGS (report may be incomplete) ==="})
+		for _, w := range report.Warnings {
+			_ = writer.Write([]string{w})
+		}
+		_ = writer.Write([]string{""})
+	}
+
 	// Cluster Summary
 	_ = writer.Write([]string{"=== CLUSTER SUMMARY ==="})
 	_ = writer.Write([]string{"Metric", "Value"})
-	_ = writer.Write([]string{"Total Nodes", fmt.Sprintf("%d", report.NodeSummary.Total)})
-	_ = writer.Write([]string{"Ready Nodes", fmt.Sprintf("%d", report.NodeSummary.Ready)})
+	if report.Namespace == "" {
+		_ = writer.Write([]string{"Total Nodes", fmt.Sprintf("%d", report.NodeSummary.Total)})
+		_ = writer.Write([]string{"Ready Nodes", fmt.Sprintf("%d", report.NodeSummary.Ready)})
+	}
 	_ = writer.Write([]string{"Total Pods", fmt.Sprintf("%d", report.Workloads.TotalPods)})
 	_ = writer.Write([]string{"Running Pods", fmt.Sprintf("%d", report.Workloads.RunningPods)})
 	_ = writer.Write([]string{"Pending Pods", fmt.Sprintf("%d", report.Workloads.PendingPods)})
 	_ = writer.Write([]string{"Failed Pods", fmt.Sprintf("%d", report.Workloads.FailedPods)})
+	_ = writer.Write([]string{"Stuck Terminating Pods", fmt.Sprintf("%d", report.Workloads.StuckTerminatingPods)})
 	_ = writer.Write([]string{"Total Deployments", fmt.Sprintf("%d", report.Workloads.TotalDeployments)})
 	_ = writer.Write([]string{"Healthy Deployments", fmt.Sprintf("%d", report.Workloads.HealthyDeploys)})
 	_ = writer.Write([]string{"Total Services", fmt.Sprintf("%d", report.Workloads.TotalServices)})
 	_ = writer.Write([]string{""})
 
+	if sections.Metrics && report.MetricsHistory != nil && len(report.MetricsHistory.Anomalies) > 0 {
+		_ = writer.Write([]string{"=== METRICS ANOMALIES ==="})
+		_ = writer.Write([]string{"Severity", "Kind", "Anomaly", "Last Seen"})
+		for _, a := range report.MetricsHistory.Anomalies {
+			_ = writer.Write([]string{a.Severity, a.Kind, a.Message, a.At})
+		}
+		_ = writer.Write([]string{""})
+	}
+
 	if sections.Nodes {
 		_ = writer.Write([]string{"=== NODES ==="})
 		_ = writer.Write([]string{"Name", "Status", "Roles", "CPU Capacity", "CPU Allocatable", "Memory Capacity", "Memory Allocatable", "Schedulable", "Warnings", "Taints", "IP"})
@@ -59,6 +95,28 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 		_ = writer.Write([]string{""})
 	}
 
+	if sections.Capacity && len(report.NodeCapacity) > 0 {
+		_ = writer.Write([]string{"=== NODE CAPACITY ==="})
+		_ = writer.Write([]string{"Name", "CPU Allocatable", "CPU Requested", "CPU Limits", "CPU Used", "Memory Allocatable", "Memory Requested", "Memory Limits", "Memory Used", "Pods", "Status", "Reasons"})
+		for _, nc := range report.NodeCapacity {
+			_ = writer.Write([]string{
+				nc.Name,
+				nc.CPUAllocatable,
+				capacityWithPercent(nc.CPURequested, nc.CPURequestedPercent),
+				capacityWithPercent(nc.CPULimits, nc.CPULimitsPercent),
+				capacityWithPercent(nc.CPUUsed, nc.CPUUsedPercent),
+				nc.MemoryAllocatable,
+				capacityWithPercent(nc.MemoryRequested, nc.MemoryRequestedPercent),
+				capacityWithPercent(nc.MemoryLimits, nc.MemoryLimitsPercent),
+				capacityWithPercent(nc.MemoryUsed, nc.MemoryUsedPercent),
+				fmt.Sprintf("%d/%d", nc.Pods, nc.PodCapacity),
+				nc.Status,
+				strings.Join(nc.Reasons, "; "),
+			})
+		}
+		_ = writer.Write([]string{""})
+	}
+
 	if sections.Namespaces {
 		_ = writer.Write([]string{"=== NAMESPACES ==="})
 		_ = writer.Write([]string{"Name", "Status", "Pods", "Deployments", "Services"})
@@ -72,11 +130,41 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 			})
 		}
 		_ = writer.Write([]string{""})
+
+		if len(report.ResourceQuotas) > 0 {
+			_ = writer.Write([]string{"=== RESOURCE QUOTAS ==="})
+			_ = writer.Write([]string{"Namespace", "Quota", "Resource", "Used", "Hard", "Used %", "Status"})
+			for _, q := range report.ResourceQuotas {
+				for _, u := range q.Usage {
+					_ = writer.Write([]string{
+						q.Namespace,
+						q.Name,
+						u.Resource,
+						u.Used,
+						u.Hard,
+						fmt.Sprintf("%.1f", u.Percent),
+						k8s.QuotaStatus(u.Percent),
+					})
+				}
+			}
+			_ = writer.Write([]string{""})
+		}
+
+		if len(report.LimitRanges) > 0 {
+			_ = writer.Write([]string{"=== LIMIT RANGES ==="})
+			_ = writer.Write([]string{"Namespace", "Name", "Type", "Resource", "Default Request", "Default Limit", "Min", "Max"})
+			for _, lr := range report.LimitRanges {
+				for _, l := range lr.Limits {
+					_ = writer.Write([]string{lr.Namespace, lr.Name, l.Type, l.Resource, l.DefaultRequest, l.Default, l.Min, l.Max})
+				}
+			}
+			_ = writer.Write([]string{""})
+		}
 	}
 
 	if sections.Workloads {
 		_ = writer.Write([]string{"=== PODS ==="})
-		_ = writer.Write([]string{"Name", "Namespace", "Status", "Ready", "Restarts", "Node", "IP", "Age"})
+		_ = writer.Write([]string{"Name", "Namespace", "Status", "Ready", "Restarts", "QoS Class", "Priority Class", "Node", "IP", "Age"})
 		for _, pod := range report.Pods {
 			_ = writer.Write([]string{
 				pod.Name,
@@ -84,6 +172,8 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 				pod.Status,
 				pod.Ready,
 				fmt.Sprintf("%d", pod.Restarts),
+				pod.QoSClass,
+				pod.PriorityClass,
 				pod.Node,
 				pod.IP,
 				pod.Age,
@@ -132,6 +222,41 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 			})
 		}
 		_ = writer.Write([]string{""})
+
+		if len(report.VolumeClaims) > 0 {
+			_ = writer.Write([]string{"=== VOLUME CLAIMS ==="})
+			_ = writer.Write([]string{"Namespace", "Claim", "Status", "Issue", "Capacity", "Storage Class", "Volume"})
+			for _, vc := range report.VolumeClaims {
+				_ = writer.Write([]string{vc.Namespace, vc.Name, vc.Status, vc.Issue, vc.Capacity, vc.StorageClass, vc.Volume})
+			}
+			_ = writer.Write([]string{""})
+		}
+
+		if len(report.Ingresses) > 0 {
+			_ = writer.Write([]string{"=== INGRESSES ==="})
+			_ = writer.Write([]string{"Namespace", "Name", "Class", "Host", "Path", "Backend", "TLS"})
+			for _, ing := range report.Ingresses {
+				_ = writer.Write([]string{ing.Namespace, ing.Name, ing.Class, ing.Host, ing.Path, ing.Backend, fmt.Sprintf("%t", ing.TLS)})
+			}
+			_ = writer.Write([]string{""})
+		}
+
+		if len(report.NetworkCoverage) > 0 {
+			_ = writer.Write([]string{"=== NETWORK POLICIES ==="})
+			_ = writer.Write([]string{"Namespace", "Name", "Pod Selector", "Policy Types", "Pods"})
+			for _, np := range report.NetworkPolicies {
+				_ = writer.Write([]string{np.Namespace, np.Name, np.PodSelector, strings.Join(np.PolicyTypes, ","), fmt.Sprintf("%d", np.Pods)})
+			}
+			_ = writer.Write([]string{""})
+
+			_ = writer.Write([]string{"=== NETWORK POLICY COVERAGE ==="})
+			_ = writer.Write([]string{"Namespace", "Policies", "Pods", "Covered Pods", "Status", "Uncovered Pods"})
+			for _, cov := range report.NetworkCoverage {
+				_ = writer.Write([]string{cov.Namespace, fmt.Sprintf("%d", cov.Policies), fmt.Sprintf("%d", cov.Pods),
+					fmt.Sprintf("%d", cov.CoveredPods), cov.Status, strings.Join(cov.UncoveredPods, " ")})
+			}
+			_ = writer.Write([]string{""})
+		}
 	}
 
 	if sections.SecurityBasic {
@@ -141,63 +266,22 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 		_ = writer.Write([]string{"Privileged Pods", fmt.Sprintf("%d", report.SecurityInfo.PrivilegedPods)})
 		_ = writer.Write([]string{"Host Network Pods", fmt.Sprintf("%d", report.SecurityInfo.HostNetworkPods)})
 		_ = writer.Write([]string{"Root Containers", fmt.Sprintf("%d", report.SecurityInfo.RootContainers)})
-		_ = writer.Write([]string{""})
-	}
-
-	if sections.FinOps {
-		_ = writer.Write([]string{"=== FINOPS COST ANALYSIS ==="})
-		_ = writer.Write([]string{"Metric", "Value"})
-		_ = writer.Write([]string{"Estimated Monthly Cost", fmt.Sprintf("$%.2f", report.FinOpsAnalysis.TotalEstimatedMonthlyCost)})
-		_ = writer.Write([]string{"Estimation Model", report.FinOpsAnalysis.EstimationModel})
-		_ = writer.Write([]string{"Metrics Source", report.FinOpsAnalysis.ResourceEfficiency.MetricsSource})
-		_ = writer.Write([]string{"Total CPU Requests", report.FinOpsAnalysis.ResourceEfficiency.TotalCPURequests})
-		_ = writer.Write([]string{"Total CPU Usage", report.FinOpsAnalysis.ResourceEfficiency.TotalCPUUsage})
-		_ = writer.Write([]string{"Total CPU Limits", report.FinOpsAnalysis.ResourceEfficiency.TotalCPULimits})
-		_ = writer.Write([]string{"Total Memory Requests", report.FinOpsAnalysis.ResourceEfficiency.TotalMemoryRequests})
-		_ = writer.Write([]string{"Total Memory Usage", report.FinOpsAnalysis.ResourceEfficiency.TotalMemoryUsage})
-		_ = writer.Write([]string{"Total Memory Limits", report.FinOpsAnalysis.ResourceEfficiency.TotalMemoryLimits})
-		_ = writer.Write([]string{"CPU Requests vs Allocatable", fmt.Sprintf("%.1f%%", report.FinOpsAnalysis.ResourceEfficiency.CPURequestsVsCapacity)})
-		_ = writer.Write([]string{"Memory Requests vs Allocatable", fmt.Sprintf("%.1f%%", report.FinOpsAnalysis.ResourceEfficiency.MemoryRequestsVsCapacity)})
-		_ = writer.Write([]string{"Pods Without Requests", fmt.Sprintf("%d", report.FinOpsAnalysis.ResourceEfficiency.PodsWithoutRequests)})
-		_ = writer.Write([]string{"Pods Without Limits", fmt.Sprintf("%d", report.FinOpsAnalysis.ResourceEfficiency.PodsWithoutLimits)})
-		for _, note := range report.FinOpsAnalysis.EstimationNotes {
-			_ = writer.Write([]string{"Note", note})
-		}
+		_ = writer.Write([]string{"Namespaces Without NetworkPolicy", fmt.Sprintf("%d", report.SecurityInfo.NamespacesWithoutNetworkPolicy)})
+		_ = writer.Write([]string{"Unreferenced Secrets", fmt.Sprintf("%d", report.SecurityInfo.UnreferencedSecrets)})
 		_ = writer.Write([]string{""})
 
-		if len(report.FinOpsAnalysis.CostByNamespace) > 0 {
-			_ = writer.Write([]string{"=== COST BY NAMESPACE ==="})
-			_ = writer.Write([]string{"Namespace", "Pods", "Running Pods", "CPU Requests", "CPU Usage", "Memory Requests", "Memory Usage", "Est. Cost/Month", "% of Total"})
-			for _, ns := range report.FinOpsAnalysis.CostByNamespace {
-				_ = writer.Write([]string{
-					ns.Namespace,
-					fmt.Sprintf("%d", ns.PodCount),
-					fmt.Sprintf("%d", ns.RunningPodCount),
-					ns.CPURequests,
-					ns.CPUUsage,
-					ns.MemoryRequests,
-					ns.MemoryUsage,
-					fmt.Sprintf("$%.2f", ns.EstimatedCost),
-					fmt.Sprintf("%.1f%%", ns.CostPercentage),
-				})
+		if len(report.UnreferencedSecrets) > 0 {
+			_ = writer.Write([]string{"=== UNREFERENCED SECRETS ==="})
+			_ = writer.Write([]string{"Namespace", "Name", "Type", "Age"})
+			for _, sec := range report.UnreferencedSecrets {
+				_ = writer.Write([]string{sec.Namespace, sec.Name, sec.Type, sec.Age})
 			}
 			_ = writer.Write([]string{""})
 		}
+	}
 
-		if len(report.FinOpsAnalysis.CostOptimizations) > 0 {
-			_ = writer.Write([]string{"=== COST OPTIMIZATION RECOMMENDATIONS ==="})
-			_ = writer.Write([]string{"Priority", "Category", "Description", "Impact", "Est. Saving/Month"})
-			for _, opt := range report.FinOpsAnalysis.CostOptimizations {
-				_ = writer.Write([]string{
-					opt.Priority,
-					opt.Category,
-					opt.Description,
-					opt.Impact,
-					fmt.Sprintf("$%.2f", opt.EstimatedSaving),
-				})
-			}
-			_ = writer.Write([]string{""})
-		}
+	if sections.FinOps {
+		writeFinOpsCSV(writer, &report.FinOpsAnalysis)
 	}
 
 	// Security Scan Results
@@ -264,10 +348,25 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 		}
 	}
 
-	// Warning Events
+	// Event categories count every Warning event, including ones past the limit
+	if sections.Events && len(report.EventStats.Categories) > 0 {
+		_ = writer.Write([]string{"=== WARNING EVENT CATEGORIES ==="})
+		_ = writer.Write([]string{"Category", "Events", "Occurrences", "Reasons"})
+		for _, cc := range report.EventStats.Categories {
+			_ = writer.Write([]string{
+				cc.Category,
+				fmt.Sprintf("%d", cc.Events),
+				fmt.Sprintf("%d", cc.Occurrences),
+				strings.Join(cc.Reasons, "; "),
+			})
+		}
+		_ = writer.Write([]string{""})
+	}
+
+	// Events
 	if sections.Events && len(report.Events) > 0 {
-		_ = writer.Write([]string{"=== WARNING EVENTS ==="})
-		_ = writer.Write([]string{"Type", "Reason", "Object", "Message", "Count", "Last Seen"})
+		_ = writer.Write([]string{"=== EVENTS ==="})
+		_ = writer.Write([]string{"Type", "Category", "Reason", "Object", "Message", "Count", "Last Seen"})
 		for _, event := range report.Events {
 			msg := event.Message
 			if len(msg) > 100 {
@@ -275,6 +374,7 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 			}
 			_ = writer.Write([]string{
 				event.Type,
+				event.Category,
 				event.Reason,
 				event.Object,
 				msg,
@@ -282,6 +382,42 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 				event.LastSeen,
 			})
 		}
+		if report.EventStats.Omitted > 0 {
+			_ = writer.Write([]string{fmt.Sprintf("%d more events not listed (event limit %d)", report.EventStats.Omitted, report.EventStats.Limit)})
+		}
+		_ = writer.Write([]string{""})
+	}
+
+	// Drift lists every manifest object that differs from the cluster
+	if sections.Drift && report.Drift != nil {
+		_ = writer.Write([]string{"=== CONFIGURATION DRIFT ==="})
+		_ = writer.Write([]string{"Manifest Dir:", report.Drift.ManifestDir})
+		if report.Drift.Error != "" {
+			_ = writer.Write([]string{"Error:", report.Drift.Error})
+		} else {
+			_ = writer.Write([]string{"Status", "Kind", "Namespace", "Name", "File", "Differences"})
+			for _, d := range report.Drift.Resources {
+				if d.Status == k8s.DriftInSync {
+					continue
+				}
+				_ = writer.Write([]string{d.Status, d.Kind, d.Namespace, d.Name, d.File, strings.ReplaceAll(driftFieldSummary(d), "\n", "; ")})
+			}
+		}
+		_ = writer.Write([]string{""})
+	}
+
+	// Deprecated APIs lists what breaks on upgrade, removed APIs first
+	if sections.DeprecatedAPIs && report.DeprecatedAPIs != nil {
+		scan := report.DeprecatedAPIs
+		_ = writer.Write([]string{"=== DEPRECATED APIS ==="})
+		_ = writer.Write([]string{"Server Version:", dashIfEmpty(scan.ServerVersion)})
+		_ = writer.Write([]string{"Status", "Kind", "Namespace", "Name", "API Version", "Replacement", "Deprecated In", "Removed In", "Found In"})
+		for _, u := range scan.Uses {
+			_ = writer.Write([]string{u.Status, u.Kind, u.Namespace, u.Name, u.APIVersion, deprecatedAPIReplacement(u), u.DeprecatedIn, u.RemovedIn, deprecatedAPILocation(u)})
+		}
+		for _, e := range scan.Errors {
+			_ = writer.Write([]string{"Error:", e})
+		}
 		_ = writer.Write([]string{""})
 	}
 
@@ -299,62 +435,174 @@ func (rg *ReportGenerator) ExportToCSV(report *ComprehensiveReport) ([]byte, err
 	return buf.Bytes(), writer.Error()
 }
 
+// ExportFinOpsToCSV exports a standalone FinOps report as CSV
+func (rg *ReportGenerator) ExportFinOpsToCSV(report *FinOpsReport) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	_ = writer.Write([]string{"K13d FinOps Report"})
+	_ = writer.Write([]string{"Generated At:", report.GeneratedAt.Format(time.RFC3339)})
+	_ = writer.Write([]string{"Generated By:", report.GeneratedBy})
+	_ = writer.Write([]string{""})
+	writeFinOpsCSV(writer, &report.FinOpsAnalysis)
+
+	if len(report.UnderutilizedResources) > 0 {
+		_ = writer.Write([]string{"=== UNDERUTILIZED RESOURCES ==="})
+		_ = writer.Write([]string{"Namespace", "Name", "Type", "CPU Usage % of Requests", "Memory Usage % of Requests", "Suggestion"})
+		for _, res := range report.UnderutilizedResources {
+			_ = writer.Write([]string{
+				res.Namespace,
+				res.Name,
+				res.ResourceType,
+				fmt.Sprintf("%.1f", res.CPUUsage),
+				fmt.Sprintf("%.1f", res.MemoryUsage),
+				res.Suggestion,
+			})
+		}
+		_ = writer.Write([]string{""})
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// writeFinOpsCSV writes the FinOps cost analysis tables
+func writeFinOpsCSV(writer *csv.Writer, analysis *FinOpsAnalysis) {
+	_ = writer.Write([]string{"=== FINOPS COST ANALYSIS ==="})
+	_ = writer.Write([]string{"Metric", "Value"})
+	_ = writer.Write([]string{"Estimated Monthly Cost", fmt.Sprintf("$%.2f", analysis.TotalEstimatedMonthlyCost)})
+	_ = writer.Write([]string{"Estimation Model", analysis.EstimationModel})
+	_ = writer.Write([]string{"Metrics Source", analysis.ResourceEfficiency.MetricsSource})
+	_ = writer.Write([]string{"Total CPU Requests", analysis.ResourceEfficiency.TotalCPURequests})
+	_ = writer.Write([]string{"Total CPU Usage", analysis.ResourceEfficiency.TotalCPUUsage})
+	_ = writer.Write([]string{"Total CPU Limits", analysis.ResourceEfficiency.TotalCPULimits})
+	_ = writer.Write([]string{"Total Memory Requests", analysis.ResourceEfficiency.TotalMemoryRequests})
+	_ = writer.Write([]string{"Total Memory Usage", analysis.ResourceEfficiency.TotalMemoryUsage})
+	_ = writer.Write([]string{"Total Memory Limits", analysis.ResourceEfficiency.TotalMemoryLimits})
+	_ = writer.Write([]string{"CPU Requests vs Allocatable", fmt.Sprintf("%.1f%%", analysis.ResourceEfficiency.CPURequestsVsCapacity)})
+	_ = writer.Write([]string{"Memory Requests vs Allocatable", fmt.Sprintf("%.1f%%", analysis.ResourceEfficiency.MemoryRequestsVsCapacity)})
+	_ = writer.Write([]string{"Pods Without Requests", fmt.Sprintf("%d", analysis.ResourceEfficiency.PodsWithoutRequests)})
+	_ = writer.Write([]string{"Pods Without Limits", fmt.Sprintf("%d", analysis.ResourceEfficiency.PodsWithoutLimits)})
+	for _, note := range analysis.EstimationNotes {
+		_ = writer.Write([]string{"Note", note})
+	}
+	_ = writer.Write([]string{""})
+
+	if len(analysis.CostByNamespace) > 0 {
+		_ = writer.Write([]string{"=== COST BY NAMESPACE ==="})
+		_ = writer.Write([]string{"Namespace", "Pods", "Running Pods", "CPU Requests", "CPU Usage", "Memory Requests", "Memory Usage", "Est. Cost/Month", "% of Total"})
+		for _, ns := range analysis.CostByNamespace {
+			_ = writer.Write([]string{
+				ns.Namespace,
+				fmt.Sprintf("%d", ns.PodCount),
+				fmt.Sprintf("%d", ns.RunningPodCount),
+				ns.CPURequests,
+				ns.CPUUsage,
+				ns.MemoryRequests,
+				ns.MemoryUsage,
+				fmt.Sprintf("$%.2f", ns.EstimatedCost),
+				fmt.Sprintf("%.1f%%", ns.CostPercentage),
+			})
+		}
+		_ = writer.Write([]string{""})
+	}
+
+	if len(analysis.CostByLabel) > 0 {
+		_ = writer.Write([]string{"=== COST BY LABEL: " + analysis.CostAllocationLabel + " ==="})
+		_ = writer.Write([]string{analysis.CostAllocationLabel, "Namespaces", "Pods", "Running Pods", "CPU Requests", "CPU Usage", "Memory Requests", "Memory Usage", "Est. Cost/Month", "% of Total"})
+		for _, lc := range analysis.CostByLabel {
+			_ = writer.Write([]string{
+				lc.Value,
+				strings.Join(lc.Namespaces, "; "),
+				fmt.Sprintf("%d", lc.PodCount),
+				fmt.Sprintf("%d", lc.RunningPodCount),
+				lc.CPURequests,
+				lc.CPUUsage,
+				lc.MemoryRequests,
+				lc.MemoryUsage,
+				fmt.Sprintf("$%.2f", lc.EstimatedCost),
+				fmt.Sprintf("%.1f%%", lc.CostPercentage),
+			})
+		}
+		_ = writer.Write([]string{""})
+	}
+
+	if len(analysis.CostOptimizations) > 0 {
+		_ = writer.Write([]string{"=== COST OPTIMIZATION RECOMMENDATIONS ==="})
+		_ = writer.Write([]string{"Priority", "Category", "Description", "Impact", "Est. Saving/Month"})
+		for _, opt := range analysis.CostOptimizations {
+			_ = writer.Write([]string{
+				opt.Priority,
+				opt.Category,
+				opt.Description,
+				opt.Impact,
+				fmt.Sprintf("$%.2f", opt.EstimatedSaving),
+			})
+		}
+		_ = writer.Write([]string{""})
+	}
+}
+
 // ExportToHTML generates HTML format for PDF conversion
 func (rg *ReportGenerator) ExportToHTML(report *ComprehensiveReport) string {
 	var sb strings.Builder
 	sections := reportSectionsOrAll(report)
+	branding := rg.reportBranding(report)
+	limits := rg.htmlLimits()
 
 	sb.WriteString(`<!DOCTYPE html>
 <html>
 <head>
 <meta charset="UTF-8">
-<title>K13d Cluster Assessment Report</title>
-<style>
-body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; margin: 40px; color: #333; line-height: 1.6; }
-h1 { color: #1a1b26; border-bottom: 3px solid #7aa2f7; padding-bottom: 10px; margin-bottom: 20px; }
-h2 { color: #24283b; margin-top: 40px; border-bottom: 2px solid #7aa2f7; padding-bottom: 8px; }
+`)
+	sb.WriteString(fmt.Sprintf("<title>%s</title>\n<style>\n", html.EscapeString(branding.Title)))
+	sb.WriteString(rg.reportThemeCSS())
+	sb.WriteString(`body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; margin: 40px; color: var(--k13d-text); line-height: 1.6; }
+h1 { color: var(--k13d-heading); border-bottom: 3px solid var(--k13d-accent); padding-bottom: 10px; margin-bottom: 20px; }
+h2 { color: var(--k13d-subheading); margin-top: 40px; border-bottom: 2px solid var(--k13d-accent); padding-bottom: 8px; }
 h2 a { color: inherit; text-decoration: none; }
-h2 a:hover { color: #7aa2f7; }
+h2 a:hover { color: var(--k13d-accent); }
 h3 { color: #414868; margin-top: 25px; }
 table { width: 100%; border-collapse: collapse; margin: 15px 0; font-size: 12px; }
-th, td { padding: 10px 12px; text-align: left; border: 1px solid #ddd; }
-th { background: #24283b; color: white; font-weight: 600; }
-tr:nth-child(even) { background: #f8f9fa; }
+th, td { padding: 10px 12px; text-align: left; border: 1px solid var(--k13d-border); }
+th { background: var(--k13d-table-header-bg); color: var(--k13d-table-header-fg); font-weight: 600; }
+tr:nth-child(even) { background: var(--k13d-surface); }
 tr:hover { background: #e9ecef; }
-.metric-card { display: inline-block; background: #f8f9fa; padding: 15px 25px; margin: 10px; border-radius: 8px; text-align: center; border: 1px solid #e0e0e0; }
-.metric-value { font-size: 28px; font-weight: bold; color: #7aa2f7; }
+.metric-card { display: inline-block; background: var(--k13d-surface); padding: 15px 25px; margin: 10px; border-radius: 8px; text-align: center; border: 1px solid #e0e0e0; }
+.metric-value { font-size: 28px; font-weight: bold; color: var(--k13d-accent); }
 .metric-label { font-size: 12px; color: #666; margin-top: 5px; }
-.health-score { font-size: 48px; font-weight: bold; color: #28a745; }
-.health-score.warning { color: #ffc107; }
-.health-score.critical { color: #dc3545; }
-.status-pass { color: #28a745; font-weight: bold; }
-.status-warn { color: #ffc107; font-weight: bold; }
-.status-fail { color: #dc3545; font-weight: bold; }
-.status-running { color: #28a745; font-weight: bold; }
-.status-pending { color: #ffc107; font-weight: bold; }
-.status-failed { color: #dc3545; font-weight: bold; }
-.ai-analysis { background: #f8f9fa; border-left: 4px solid #7aa2f7; padding: 20px; margin: 20px 0; white-space: pre-wrap; }
-.warning-box { background: #fff3cd; border: 1px solid #ffc107; border-left: 4px solid #ffc107; padding: 12px 15px; margin: 15px 0; border-radius: 4px; }
+.health-score { font-size: 48px; font-weight: bold; color: var(--k13d-success); }
+.health-score.warning { color: var(--k13d-warning); }
+.health-score.critical { color: var(--k13d-danger); }
+.status-pass { color: var(--k13d-success); font-weight: bold; }
+.status-warn { color: var(--k13d-warning); font-weight: bold; }
+.status-fail { color: var(--k13d-danger); font-weight: bold; }
+.status-running { color: var(--k13d-success); font-weight: bold; }
+.status-pending { color: var(--k13d-warning); font-weight: bold; }
+.status-failed { color: var(--k13d-danger); font-weight: bold; }
+.ai-analysis { background: var(--k13d-surface); border-left: 4px solid var(--k13d-accent); padding: 20px; margin: 20px 0; white-space: pre-wrap; }
+.warning-box { background: #fff3cd; border: 1px solid var(--k13d-warning); border-left: 4px solid var(--k13d-warning); padding: 12px 15px; margin: 15px 0; border-radius: 4px; }
 .info-box { background: #e7f3ff; border: 1px solid #7aa2f7; border-left: 4px solid #7aa2f7; padding: 12px 15px; margin: 15px 0; border-radius: 4px; }
 .cost-card { display: inline-block; background: #e8f5e9; padding: 15px 25px; margin: 10px; border-radius: 8px; text-align: center; border: 1px solid #4caf50; }
 .cost-value { font-size: 24px; font-weight: bold; color: #2e7d32; }
 .cost-label { font-size: 11px; color: #666; margin-top: 5px; }
-.priority-high { color: #dc3545; font-weight: bold; }
-.priority-medium { color: #ffc107; font-weight: bold; }
-.priority-low { color: #28a745; font-weight: bold; }
-.savings-badge { background: #28a745; color: white; padding: 3px 10px; border-radius: 4px; font-size: 12px; font-weight: 600; }
-.toc { background: #f8f9fa; border: 1px solid #e0e0e0; border-radius: 8px; padding: 20px 30px; margin: 30px 0; }
-.toc h3 { margin-top: 0; color: #24283b; border-bottom: 1px solid #ddd; padding-bottom: 10px; }
+.priority-high { color: var(--k13d-danger); font-weight: bold; }
+.priority-medium { color: var(--k13d-warning); font-weight: bold; }
+.priority-low { color: var(--k13d-success); font-weight: bold; }
+.savings-badge { background: var(--k13d-success); color: white; padding: 3px 10px; border-radius: 4px; font-size: 12px; font-weight: 600; }
+.toc { background: var(--k13d-surface); border: 1px solid #e0e0e0; border-radius: 8px; padding: 20px 30px; margin: 30px 0; }
+.toc h3 { margin-top: 0; color: var(--k13d-subheading); border-bottom: 1px solid var(--k13d-border); padding-bottom: 10px; }
 .toc ul { list-style: none; padding: 0; margin: 0; }
 .toc li { padding: 6px 0; }
-.toc a { color: #7aa2f7; text-decoration: none; font-weight: 500; }
+.toc a { color: var(--k13d-accent); text-decoration: none; font-weight: 500; }
 .toc a:hover { text-decoration: underline; }
 .toc .toc-subsection { margin-left: 20px; font-size: 13px; }
-.section-number { color: #7aa2f7; font-weight: bold; margin-right: 8px; }
-.back-to-top { font-size: 11px; color: #7aa2f7; text-decoration: none; float: right; }
+.section-number { color: var(--k13d-accent); font-weight: bold; margin-right: 8px; }
+.back-to-top { font-size: 11px; color: var(--k13d-accent); text-decoration: none; float: right; }
 .back-to-top:hover { text-decoration: underline; }
 .footer { margin-top: 50px; text-align: center; color: #999; font-size: 11px; padding-top: 20px; border-top: 1px solid #e0e0e0; }
-.report-meta { background: #f8f9fa; padding: 15px 20px; border-radius: 8px; margin-bottom: 30px; }
+.report-logo { display: block; max-height: 64px; max-width: 240px; margin-bottom: 10px; }
+.report-meta { background: var(--k13d-surface); padding: 15px 20px; border-radius: 8px; margin-bottom: 30px; }
 .report-meta p { margin: 5px 0; }
 @media print { body { margin: 20px; } .back-to-top { display: none; } }
 </style>
@@ -363,10 +611,13 @@ tr:hover { background: #e9ecef; }
 `)
 
 	// Header
-	sb.WriteString(`<h1 id="top">K13d Cluster Assessment Report</h1>`)
+	sb.WriteString(branding.headerHTML())
 	sb.WriteString(`<div class="report-meta">`)
 	sb.WriteString(fmt.Sprintf(`<p><strong>Report Generated:</strong> %s</p>`, report.GeneratedAt.Format("2006-01-02 15:04:05 MST")))
 	sb.WriteString(fmt.Sprintf(`<p><strong>Generated By:</strong> %s</p>`, report.GeneratedBy))
+	if report.Namespace != "" {
+		sb.WriteString(fmt.Sprintf(`<p><strong>Namespace:</strong> %s</p>`, html.EscapeString(report.Namespace)))
+	}
 	sb.WriteString(fmt.Sprintf(`<p><strong>Cluster Version:</strong> %s</p>`, report.ClusterInfo.ServerVersion))
 	sb.WriteString(`</div>`)
 
@@ -401,7 +652,7 @@ tr:hover { background: #e9ecef; }
 	if report.AIAnalysis != "" {
 		sb.WriteString(`<li><a href="#section-4"><span class="section-number">4.</span> AI Analysis</a></li>`)
 	}
-	if sections.Nodes || sections.Namespaces {
+	if sections.Nodes || sections.Namespaces || sections.Capacity {
 		sb.WriteString(`<li><a href="#section-5"><span class="section-number">5.</span> Cluster Infrastructure</a>`)
 		sb.WriteString(`<ul class="toc-subsection">`)
 		if sections.Nodes {
@@ -409,6 +660,12 @@ tr:hover { background: #e9ecef; }
 		}
 		if sections.Namespaces {
 			sb.WriteString(`<li><a href="#section-5-2">5.2 Namespaces</a></li>`)
+			if len(report.ResourceQuotas) > 0 || len(report.LimitRanges) > 0 {
+				sb.WriteString(`<li><a href="#section-5-3">5.3 Resource Quotas &amp; Limit Ranges</a></li>`)
+			}
+		}
+		if sections.Capacity && len(report.NodeCapacity) > 0 {
+			sb.WriteString(`<li><a href="#section-5-4">5.4 Node Capacity</a></li>`)
 		}
 		sb.WriteString(`</ul></li>`)
 	}
@@ -419,6 +676,15 @@ tr:hover { background: #e9ecef; }
 		sb.WriteString(`<li><a href="#section-6-2">6.2 Deployments</a></li>`)
 		sb.WriteString(`<li><a href="#section-6-3">6.3 Services</a></li>`)
 		sb.WriteString(`<li><a href="#section-6-4">6.4 Container Images</a></li>`)
+		if len(report.VolumeClaims) > 0 {
+			sb.WriteString(`<li><a href="#section-6-5">6.5 Volume Claims</a></li>`)
+		}
+		if len(report.Ingresses) > 0 {
+			sb.WriteString(`<li><a href="#section-6-6">6.6 Ingresses</a></li>`)
+		}
+		if len(report.NetworkCoverage) > 0 {
+			sb.WriteString(`<li><a href="#section-6-7">6.7 Network Policies</a></li>`)
+		}
 		sb.WriteString(`</ul></li>`)
 	}
 	if sections.FinOps {
@@ -426,18 +692,39 @@ tr:hover { background: #e9ecef; }
 		sb.WriteString(`<ul class="toc-subsection">`)
 		sb.WriteString(`<li><a href="#section-7-1">7.1 Resource Efficiency</a></li>`)
 		sb.WriteString(`<li><a href="#section-7-2">7.2 Cost by Namespace</a></li>`)
+		if len(report.FinOpsAnalysis.CostByLabel) > 0 {
+			sb.WriteString(fmt.Sprintf(`<li><a href="#section-7-2-1">7.2.1 Cost by %s</a></li>`, html.EscapeString(report.FinOpsAnalysis.CostAllocationLabel)))
+		}
 		sb.WriteString(`<li><a href="#section-7-3">7.3 Optimization Recommendations</a></li>`)
 		sb.WriteString(`</ul></li>`)
 	}
 	if sections.SecurityBasic {
-		sb.WriteString(`<li><a href="#section-8"><span class="section-number">8.</span> Security Summary</a></li>`)
+		sb.WriteString(`<li><a href="#section-8"><span class="section-number">8.</span> Security Summary</a>`)
+		if len(report.UnreferencedSecrets) > 0 {
+			sb.WriteString(`<ul class="toc-subsection"><li><a href="#section-8-1">8.1 Unreferenced Secrets</a></li></ul>`)
+		}
+		sb.WriteString(`</li>`)
 	}
 	if sections.Events && len(report.Events) > 0 {
-		sb.WriteString(`<li><a href="#section-9"><span class="section-number">9.</span> Warning Events</a></li>`)
+		sb.WriteString(`<li><a href="#section-9"><span class="section-number">9.</span> Events</a></li>`)
+	}
+	if sections.Drift && report.Drift != nil {
+		sb.WriteString(`<li><a href="#section-10"><span class="section-number">10.</span> Configuration Drift</a></li>`)
+	}
+	if sections.DeprecatedAPIs && report.DeprecatedAPIs != nil {
+		sb.WriteString(`<li><a href="#section-11"><span class="section-number">11.</span> Deprecated APIs</a></li>`)
 	}
 	sb.WriteString(`</ul>`)
 	sb.WriteString(`</div>`)
 
+	if len(report.Warnings) > 0 {
+		sb.WriteString(`<div class="warning-box"><strong>This report may be incomplete.</strong> Some data could not be read:<ul>`)
+		for _, w := range report.Warnings {
+			sb.WriteString(fmt.Sprintf(`<li>%s</li>`, html.EscapeString(w)))
+		}
+		sb.WriteString(`</ul></div>`)
+	}
+
 	// Section 1: Executive Summary
 	sb.WriteString(`<h2 id="section-1"><a href="#section-1"><span class="section-number">1.</span> Executive Summary</a><a href="#top" class="back-to-top">[Back to Top]</a></h2>`)
 
@@ -451,23 +738,31 @@ tr:hover { background: #e9ecef; }
 		healthClass = "warning"
 		healthStatus = "WARN"
 	}
+	scoreLabel := "Overall Cluster Health Score"
+	if report.Namespace != "" {
+		scoreLabel = "Namespace Health Score"
+	}
 	sb.WriteString(fmt.Sprintf(`<div style="text-align: center; margin: 30px 0;">
 <div class="health-score %s">%.0f%%</div>
-<div style="color: #666; margin-top: 10px;">Overall Cluster Health Score - <strong class="status-%s">%s</strong></div>
-</div>`, healthClass, report.HealthScore, strings.ToLower(healthStatus), healthStatus))
+<div style="color: #666; margin-top: 10px;">%s - <strong class="status-%s">%s</strong></div>
+</div>`, healthClass, report.HealthScore, scoreLabel, strings.ToLower(healthStatus), healthStatus))
 
-	// Summary Cards
+	// Summary Cards; a tenant report has no nodes and one namespace
 	sb.WriteString(`<div style="text-align: center;">`)
-	sb.WriteString(fmt.Sprintf(`<div class="metric-card"><div class="metric-value">%d</div><div class="metric-label">Total Nodes (%d Ready)</div></div>`,
-		report.NodeSummary.Total, report.NodeSummary.Ready))
+	if report.Namespace == "" {
+		sb.WriteString(fmt.Sprintf(`<div class="metric-card"><div class="metric-value">%d</div><div class="metric-label">Total Nodes (%d Ready)</div></div>`,
+			report.NodeSummary.Total, report.NodeSummary.Ready))
+	}
 	sb.WriteString(fmt.Sprintf(`<div class="metric-card"><div class="metric-value">%d</div><div class="metric-label">Total Pods (%d Running)</div></div>`,
 		report.Workloads.TotalPods, report.Workloads.RunningPods))
 	sb.WriteString(fmt.Sprintf(`<div class="metric-card"><div class="metric-value">%d</div><div class="metric-label">Deployments</div></div>`,
 		report.Workloads.TotalDeployments))
 	sb.WriteString(fmt.Sprintf(`<div class="metric-card"><div class="metric-value">%d</div><div class="metric-label">Services</div></div>`,
 		report.Workloads.TotalServices))
-	sb.WriteString(fmt.Sprintf(`<div class="metric-card"><div class="metric-value">%d</div><div class="metric-label">Namespaces</div></div>`,
-		report.NamespaceSummary.Total))
+	if report.Namespace == "" {
+		sb.WriteString(fmt.Sprintf(`<div class="metric-card"><div class="metric-value">%d</div><div class="metric-label">Namespaces</div></div>`,
+			report.NamespaceSummary.Total))
+	}
 	sb.WriteString(`</div>`)
 
 	// Section 2: Metrics History (if available)
@@ -487,6 +782,19 @@ tr:hover { background: #e9ecef; }
 			report.MetricsHistory.Summary.MaxMemoryUsage))
 		sb.WriteString(`</div>`)
 
+		if len(report.MetricsHistory.Anomalies) > 0 {
+			sb.WriteString(`<table><tr><th>Severity</th><th>Anomaly</th><th>Last Seen</th></tr>`)
+			for _, a := range report.MetricsHistory.Anomalies {
+				class := "status-warn"
+				if a.Severity == metrics.AnomalyCritical {
+					class = "status-fail"
+				}
+				sb.WriteString(fmt.Sprintf(`<tr><td class="%s">%s</td><td>%s</td><td>%s</td></tr>`,
+					class, strings.ToUpper(a.Severity), html.EscapeString(a.Message), a.At))
+			}
+			sb.WriteString(`</table>`)
+		}
+
 		// Resource usage table (sample every 6th point for readability)
 		sb.WriteString(`<table><tr><th>Timestamp</th><th>CPU (millicores)</th><th>Memory (MB)</th><th>Running Pods</th><th>Ready Nodes</th></tr>`)
 		for i, point := range report.MetricsHistory.ClusterMetrics {
@@ -654,7 +962,7 @@ tr:hover { background: #e9ecef; }
 		sb.WriteString(fmt.Sprintf(`<div class="ai-analysis">%s</div>`, report.AIAnalysis))
 	}
 
-	if sections.Nodes || sections.Namespaces {
+	if sections.Nodes || sections.Namespaces || sections.Capacity {
 		sb.WriteString(`<h2 id="section-5"><a href="#section-5"><span class="section-number">5.</span> Cluster Infrastructure</a><a href="#top" class="back-to-top">[Back to Top]</a></h2>`)
 	}
 
@@ -690,11 +998,81 @@ tr:hover { background: #e9ecef; }
 
 	if sections.Namespaces {
 		sb.WriteString(`<h3 id="section-5-2"><span class="section-number">5.2</span> Namespaces</h3>`)
-		sb.WriteString(fmt.Sprintf(`<p>Total: <strong>%d</strong> namespaces (%d Active)</p>`, report.NamespaceSummary.Total, report.NamespaceSummary.Active))
-		sb.WriteString(`<table><tr><th>Name</th><th>Status</th><th>Pods</th><th>Deployments</th><th>Services</th></tr>`)
+		sb.WriteString(fmt.Sprintf(`<p>Total: <strong>%d</strong> namespaces (%d Active, %d Near Quota)</p>`, report.NamespaceSummary.Total, report.NamespaceSummary.Active, report.NamespaceSummary.NearQuota))
+		if report.NamespaceSummary.Hidden > 0 {
+			sb.WriteString(fmt.Sprintf(`<p><em>%d excluded namespaces are not covered by this report</em></p>`, report.NamespaceSummary.Hidden))
+		}
+		if report.NamespaceSummary.Regex != "" {
+			sb.WriteString(fmt.Sprintf(`<p><em>Only namespaces matching <code>%s</code> are covered by this report</em></p>`, html.EscapeString(report.NamespaceSummary.Regex)))
+		}
+		sb.WriteString(`<table><tr><th>Name</th><th>Status</th><th>Pods</th><th>Deployments</th><th>Services</th><th>Quota</th></tr>`)
 		for _, ns := range report.Namespaces {
-			sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%d</td><td>%d</td><td>%d</td></tr>`,
-				ns.Name, ns.Status, ns.PodCount, ns.DeployCount, ns.ServiceCount))
+			quota := "<none>"
+			if ns.QuotaStatus != "" {
+				quota = fmt.Sprintf(`<span class="%s">%s</span>`, quotaStatusClass(ns.QuotaStatus), ns.QuotaStatus)
+			}
+			sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td>%s</td></tr>`,
+				ns.Name, ns.Status, ns.PodCount, ns.DeployCount, ns.ServiceCount, quota))
+		}
+		sb.WriteString(`</table>`)
+
+		if len(report.ResourceQuotas) > 0 || len(report.LimitRanges) > 0 {
+			sb.WriteString(`<h3 id="section-5-3"><span class="section-number">5.3</span> Resource Quotas &amp; Limit Ranges</h3>`)
+		}
+		if len(report.ResourceQuotas) > 0 {
+			if report.NamespaceSummary.NearQuota > 0 {
+				sb.WriteString(fmt.Sprintf(`<div class="warning-box"><strong>%d namespace(s)</strong> have a quota at or above %.0f%% usage. New workloads there may be rejected even when nodes have free capacity.</div>`,
+					report.NamespaceSummary.NearQuota, k8s.QuotaNearLimitPercent))
+			}
+			sb.WriteString(`<table><tr><th>Namespace</th><th>Quota</th><th>Resource</th><th>Used / Hard</th><th>Used %</th></tr>`)
+			for _, q := range report.ResourceQuotas {
+				for _, u := range q.Usage {
+					sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s / %s</td><td class="%s">%.1f%%</td></tr>`,
+						q.Namespace, q.Name, u.Resource, u.Used, u.Hard, quotaStatusClass(k8s.QuotaStatus(u.Percent)), u.Percent))
+				}
+			}
+			sb.WriteString(`</table>`)
+		}
+		if len(report.LimitRanges) > 0 {
+			sb.WriteString(`<table><tr><th>Namespace</th><th>Limit Range</th><th>Type</th><th>Resource</th><th>Default Request</th><th>Default Limit</th><th>Min</th><th>Max</th></tr>`)
+			for _, lr := range report.LimitRanges {
+				for _, l := range lr.Limits {
+					sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+						lr.Namespace, lr.Name, l.Type, l.Resource, dashIfEmpty(l.DefaultRequest), dashIfEmpty(l.Default), dashIfEmpty(l.Min), dashIfEmpty(l.Max)))
+				}
+			}
+			sb.WriteString(`</table>`)
+		}
+	}
+
+	if sections.Capacity && len(report.NodeCapacity) > 0 {
+		sb.WriteString(`<h3 id="section-5-4"><span class="section-number">5.4</span> Node Capacity</h3>`)
+		sb.WriteString(fmt.Sprintf(`<p>Allocatable compared with pod requests, limits, and usage: <strong>%d</strong> over-committed, <strong>%d</strong> under-utilized (below %d%% CPU and memory)</p>`,
+			report.NodeSummary.OverCommitted, report.NodeSummary.UnderUtilized, k8s.NodeUnderUtilizedPercent))
+		sb.WriteString(`<table><tr><th>Node</th><th>CPU Alloc</th><th>CPU Requested</th><th>CPU Limits</th><th>CPU Used</th><th>Mem Alloc</th><th>Mem Requested</th><th>Mem Limits</th><th>Mem Used</th><th>Pods</th><th>Status</th><th>Reasons</th></tr>`)
+		for _, nc := range report.NodeCapacity {
+			statusClass := "status-pass"
+			switch nc.Status {
+			case k8s.NodeCapacityOverCommitted:
+				statusClass = "status-fail"
+			case k8s.NodeCapacityUnderUtilized:
+				statusClass = "status-warn"
+			}
+			reasons := "-"
+			if len(nc.Reasons) > 0 {
+				reasons = html.EscapeString(strings.Join(nc.Reasons, "; "))
+			}
+			sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%d/%d</td><td class="%s">%s</td><td>%s</td></tr>`,
+				html.EscapeString(nc.Name),
+				nc.CPUAllocatable,
+				capacityWithPercent(nc.CPURequested, nc.CPURequestedPercent),
+				capacityWithPercent(nc.CPULimits, nc.CPULimitsPercent),
+				capacityWithPercent(nc.CPUUsed, nc.CPUUsedPercent),
+				nc.MemoryAllocatable,
+				capacityWithPercent(nc.MemoryRequested, nc.MemoryRequestedPercent),
+				capacityWithPercent(nc.MemoryLimits, nc.MemoryLimitsPercent),
+				capacityWithPercent(nc.MemoryUsed, nc.MemoryUsedPercent),
+				nc.Pods, nc.PodCapacity, statusClass, nc.Status, reasons))
 		}
 		sb.WriteString(`</table>`)
 	}
@@ -702,16 +1080,16 @@ tr:hover { background: #e9ecef; }
 	if sections.Workloads {
 		sb.WriteString(`<h2 id="section-6"><a href="#section-6"><span class="section-number">6.</span> Workloads</a><a href="#top" class="back-to-top">[Back to Top]</a></h2>`)
 
-		// 6.1 Pods (limit to first 50 for readability)
+		// 6.1 Pods (capped by reports.html_pod_limit for readability)
 		sb.WriteString(`<h3 id="section-6-1"><span class="section-number">6.1</span> Pods</h3>`)
-		sb.WriteString(fmt.Sprintf(`<p>Total: <strong>%d</strong> pods (%d Running, %d Pending, %d Failed)</p>`,
-			report.Workloads.TotalPods, report.Workloads.RunningPods, report.Workloads.PendingPods, report.Workloads.FailedPods))
-		if len(report.Pods) > 50 {
-			sb.WriteString(fmt.Sprintf(`<p><em>Showing first 50 of %d pods</em></p>`, len(report.Pods)))
+		sb.WriteString(fmt.Sprintf(`<p>Total: <strong>%d</strong> pods (%d Running, %d Pending, %d Failed, %d Stuck Terminating)</p>`,
+			report.Workloads.TotalPods, report.Workloads.RunningPods, report.Workloads.PendingPods, report.Workloads.FailedPods, report.Workloads.StuckTerminatingPods))
+		if limits.Pods > 0 && len(report.Pods) > limits.Pods {
+			sb.WriteString(fmt.Sprintf(`<p><em>Showing first %d of %d pods</em></p>`, limits.Pods, len(report.Pods)))
 		}
-		sb.WriteString(`<table><tr><th>Name</th><th>Namespace</th><th>Status</th><th>Ready</th><th>Restarts</th><th>Node</th><th>Age</th></tr>`)
+		sb.WriteString(`<table><tr><th>Name</th><th>Namespace</th><th>Status</th><th>Ready</th><th>Restarts</th><th>QoS</th><th>Priority Class</th><th>Node</th><th>Age</th></tr>`)
 		for i, pod := range report.Pods {
-			if i >= 50 {
+			if limits.Pods > 0 && i >= limits.Pods {
 				break
 			}
 			statusClass := "status-pass"
@@ -721,8 +1099,15 @@ tr:hover { background: #e9ecef; }
 			case "Failed", "CrashLoopBackOff", "Error":
 				statusClass = "status-fail"
 			}
-			sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td class="%s">%s</td><td>%s</td><td>%d</td><td>%s</td><td>%s</td></tr>`,
-				pod.Name, pod.Namespace, statusClass, pod.Status, pod.Ready, pod.Restarts, pod.Node, pod.Age))
+			if k8s.IsStuckTerminatingStatus(pod.Status) {
+				statusClass = "status-fail"
+			}
+			priorityClass := pod.PriorityClass
+			if priorityClass == "" {
+				priorityClass = "-"
+			}
+			sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td class="%s">%s</td><td>%s</td><td>%d</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+				pod.Name, pod.Namespace, statusClass, pod.Status, pod.Ready, pod.Restarts, pod.QoSClass, priorityClass, pod.Node, pod.Age))
 		}
 		sb.WriteString(`</table>`)
 
@@ -751,14 +1136,68 @@ tr:hover { background: #e9ecef; }
 		sb.WriteString(fmt.Sprintf(`<p>Total: <strong>%d</strong> unique images in use</p>`, len(report.Images)))
 		sb.WriteString(`<table><tr><th>Repository</th><th>Tag</th><th>Pod Count</th></tr>`)
 		for i, img := range report.Images {
-			if i >= 25 {
-				sb.WriteString(fmt.Sprintf(`<tr><td colspan="3"><em>... and %d more images</em></td></tr>`, len(report.Images)-25))
+			if limits.Images > 0 && i >= limits.Images {
+				sb.WriteString(fmt.Sprintf(`<tr><td colspan="3"><em>... and %d more images</em></td></tr>`, len(report.Images)-limits.Images))
 				break
 			}
 			sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%d</td></tr>`,
 				img.Repository, img.Tag, img.PodCount))
 		}
 		sb.WriteString(`</table>`)
+
+		// 6.5 Volume Claims
+		if len(report.VolumeClaims) > 0 {
+			sb.WriteString(`<h3 id="section-6-5"><span class="section-number">6.5</span> Volume Claims</h3>`)
+			sb.WriteString(fmt.Sprintf(`<p><strong>%d</strong> PersistentVolumeClaim(s) are Pending, Lost, or not used by any pod</p>`, len(report.VolumeClaims)))
+			sb.WriteString(`<table><tr><th>Namespace</th><th>Claim</th><th>Status</th><th>Issue</th><th>Capacity</th><th>Storage Class</th><th>Volume</th></tr>`)
+			for _, vc := range report.VolumeClaims {
+				sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td class="%s">%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+					html.EscapeString(vc.Namespace), html.EscapeString(vc.Name), vc.Status, volumeClaimIssueClass(vc.Issue), vc.Issue,
+					dashIfEmpty(vc.Capacity), html.EscapeString(dashIfEmpty(vc.StorageClass)), html.EscapeString(dashIfEmpty(vc.Volume))))
+			}
+			sb.WriteString(`</table>`)
+		}
+
+		// 6.6 Ingresses
+		if len(report.Ingresses) > 0 {
+			sb.WriteString(`<h3 id="section-6-6"><span class="section-number">6.6</span> Ingresses</h3>`)
+			sb.WriteString(`<p>Hosts and paths exposed outside the cluster, and the backends they route to</p>`)
+			sb.WriteString(`<table><tr><th>Namespace</th><th>Ingress</th><th>Class</th><th>Host</th><th>Path</th><th>Backend</th><th>TLS</th></tr>`)
+			for _, ing := range report.Ingresses {
+				tls, tlsClass := "No", "status-warn"
+				if ing.TLS {
+					tls, tlsClass = "Yes", "status-pass"
+				}
+				sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td class="%s">%s</td></tr>`,
+					html.EscapeString(ing.Namespace), html.EscapeString(ing.Name), html.EscapeString(dashIfEmpty(ing.Class)),
+					html.EscapeString(ing.Host), html.EscapeString(ing.Path), html.EscapeString(ing.Backend), tlsClass, tls))
+			}
+			sb.WriteString(`</table>`)
+		}
+
+		// 6.7 Network Policies
+		if len(report.NetworkCoverage) > 0 {
+			sb.WriteString(`<h3 id="section-6-7"><span class="section-number">6.7</span> Network Policies</h3>`)
+			if n := report.SecurityInfo.NamespacesWithoutNetworkPolicy; n > 0 {
+				sb.WriteString(fmt.Sprintf(`<div class="warning-box"><strong>Warning:</strong> %d namespace(s) run pods without any NetworkPolicy - all traffic to them is allowed</div>`, n))
+			}
+			sb.WriteString(`<table><tr><th>Namespace</th><th>Policies</th><th>Pods Covered</th><th>Status</th><th>Uncovered Pods</th></tr>`)
+			for _, cov := range report.NetworkCoverage {
+				sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%d</td><td>%d/%d</td><td class="%s">%s</td><td>%s</td></tr>`,
+					html.EscapeString(cov.Namespace), cov.Policies, cov.CoveredPods, cov.Pods,
+					networkCoverageClass(cov.Status), cov.Status, html.EscapeString(uncoveredPodsText(cov.UncoveredPods, 10))))
+			}
+			sb.WriteString(`</table>`)
+			if len(report.NetworkPolicies) > 0 {
+				sb.WriteString(`<table><tr><th>Namespace</th><th>Policy</th><th>Pod Selector</th><th>Policy Types</th><th>Pods</th></tr>`)
+				for _, np := range report.NetworkPolicies {
+					sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%d</td></tr>`,
+						html.EscapeString(np.Namespace), html.EscapeString(np.Name), html.EscapeString(np.PodSelector),
+						strings.Join(np.PolicyTypes, ", "), np.Pods))
+				}
+				sb.WriteString(`</table>`)
+			}
+		}
 	}
 
 	if sections.FinOps {
@@ -826,6 +1265,19 @@ tr:hover { background: #e9ecef; }
 			sb.WriteString(`</table>`)
 		}
 
+		if costs := report.FinOpsAnalysis.CostByLabel; len(costs) > 0 {
+			label := html.EscapeString(report.FinOpsAnalysis.CostAllocationLabel)
+			sb.WriteString(fmt.Sprintf(`<h3 id="section-7-2-1"><span class="section-number">7.2.1</span> Cost by %s</h3>`, label))
+			sb.WriteString(fmt.Sprintf(`<p>Pods grouped by their <code>%s</code> label across namespaces; pods without it are <em>%s</em>.</p>`, label, unallocatedCostLabel))
+			sb.WriteString(fmt.Sprintf(`<table><tr><th>%s</th><th>Namespaces</th><th>Pods</th><th>Running Pods</th><th>CPU Requests</th><th>CPU Usage</th><th>Memory Requests</th><th>Memory Usage</th><th>Est. Cost/Month</th><th>%% of Total</th></tr>`, label))
+			for _, lc := range costs {
+				sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%d</td><td>%d</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>$%.2f</td><td>%.1f%%</td></tr>`,
+					html.EscapeString(lc.Value), html.EscapeString(strings.Join(lc.Namespaces, ", ")), lc.PodCount, lc.RunningPodCount,
+					lc.CPURequests, lc.CPUUsage, lc.MemoryRequests, lc.MemoryUsage, lc.EstimatedCost, lc.CostPercentage))
+			}
+			sb.WriteString(`</table>`)
+		}
+
 		if len(report.FinOpsAnalysis.CostOptimizations) > 0 {
 			totalSavings := 0.0
 			for _, opt := range report.FinOpsAnalysis.CostOptimizations {
@@ -876,31 +1328,110 @@ tr:hover { background: #e9ecef; }
 			rootClass = "status-warn"
 		}
 		sb.WriteString(fmt.Sprintf(`<tr><td>Root Containers</td><td>%d</td><td class="%s">%s</td></tr>`, report.SecurityInfo.RootContainers, rootClass, rootStatus))
+		netpolStatus := "PASS"
+		netpolClass := "status-pass"
+		if report.SecurityInfo.NamespacesWithoutNetworkPolicy > 0 {
+			netpolStatus = "WARN"
+			netpolClass = "status-warn"
+		}
+		sb.WriteString(fmt.Sprintf(`<tr><td>Namespaces Without NetworkPolicy</td><td>%d</td><td class="%s">%s</td></tr>`, report.SecurityInfo.NamespacesWithoutNetworkPolicy, netpolClass, netpolStatus))
+		unrefStatus := "PASS"
+		unrefClass := "status-pass"
+		if report.SecurityInfo.UnreferencedSecrets > 0 {
+			unrefStatus = "WARN"
+			unrefClass = "status-warn"
+		}
+		sb.WriteString(fmt.Sprintf(`<tr><td>Unreferenced Secrets</td><td>%d</td><td class="%s">%s</td></tr>`, report.SecurityInfo.UnreferencedSecrets, unrefClass, unrefStatus))
 		sb.WriteString(`</table>`)
+
+		if len(report.UnreferencedSecrets) > 0 {
+			sb.WriteString(`<h3 id="section-8-1"><span class="section-number">8.1</span> Unreferenced Secrets</h3>`)
+			sb.WriteString(`<p>No workload, pod, Ingress, or service account in their namespace refers to these secrets. Check them with <code>Shift+U</code> in the TUI before deleting; a secret can still be read through the API.</p>`)
+			sb.WriteString(`<table><tr><th>Namespace</th><th>Secret</th><th>Type</th><th>Age</th></tr>`)
+			for _, sec := range report.UnreferencedSecrets {
+				sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+					html.EscapeString(sec.Namespace), html.EscapeString(sec.Name), html.EscapeString(sec.Type), sec.Age))
+			}
+			sb.WriteString(`</table>`)
+		}
 	}
 
 	if sections.Events && len(report.Events) > 0 {
-		sb.WriteString(`<h2 id="section-9"><a href="#section-9"><span class="section-number">9.</span> Warning Events</a><a href="#top" class="back-to-top">[Back to Top]</a></h2>`)
-		sb.WriteString(fmt.Sprintf(`<p>Recent warning events in the cluster (%d total):</p>`, len(report.Events)))
-		sb.WriteString(`<table><tr><th>Reason</th><th>Object</th><th>Message</th><th>Count</th></tr>`)
+		sb.WriteString(`<h2 id="section-9"><a href="#section-9"><span class="section-number">9.</span> Events</a><a href="#top" class="back-to-top">[Back to Top]</a></h2>`)
+		sb.WriteString(fmt.Sprintf(`<p>%d warning and %d normal events in the cluster; %d listed below.</p>`,
+			report.EventStats.Warning, report.EventStats.Normal, len(report.Events)))
+		if len(report.EventStats.Categories) > 0 {
+			sb.WriteString(`<table><tr><th>Category</th><th>Events</th><th>Occurrences</th><th>Reasons</th></tr>`)
+			for _, cc := range report.EventStats.Categories {
+				sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%d</td><td>%d</td><td>%s</td></tr>`,
+					cc.Category, cc.Events, cc.Occurrences, strings.Join(cc.Reasons, ", ")))
+			}
+			sb.WriteString(`</table>`)
+		}
+		sb.WriteString(`<table><tr><th>Type</th><th>Category</th><th>Reason</th><th>Object</th><th>Message</th><th>Count</th></tr>`)
 		for i, event := range report.Events {
-			if i >= 25 {
-				sb.WriteString(fmt.Sprintf(`<tr><td colspan="4"><em>... and %d more events</em></td></tr>`, len(report.Events)-25))
+			if limits.Events > 0 && i >= limits.Events {
+				sb.WriteString(fmt.Sprintf(`<tr><td colspan="6"><em>... and %d more events</em></td></tr>`, len(report.Events)-limits.Events+report.EventStats.Omitted))
 				break
 			}
 			msg := event.Message
 			if len(msg) > 100 {
 				msg = msg[:100] + "..."
 			}
-			sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%d</td></tr>`,
-				event.Reason, event.Object, msg, event.Count))
+			sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%d</td></tr>`,
+				event.Type, event.Category, event.Reason, event.Object, msg, event.Count))
 		}
 		sb.WriteString(`</table>`)
 	}
 
+	if sections.Drift && report.Drift != nil {
+		drift := report.Drift
+		sb.WriteString(`<h2 id="section-10"><a href="#section-10"><span class="section-number">10.</span> Configuration Drift</a><a href="#top" class="back-to-top">[Back to Top]</a></h2>`)
+		if drift.Error != "" {
+			sb.WriteString(fmt.Sprintf(`<p>Could not scan %s: %s</p>`, html.EscapeString(drift.ManifestDir), html.EscapeString(drift.Error)))
+		} else {
+			sb.WriteString(fmt.Sprintf(`<p>%d objects in %s: %d in sync, %d drifted, %d missing, %d errors.</p>`,
+				len(drift.Resources), html.EscapeString(drift.ManifestDir), drift.InSync, drift.Drifted, drift.Missing, drift.Errors))
+			sb.WriteString(`<table><tr><th>Status</th><th>Kind</th><th>Namespace</th><th>Name</th><th>File</th><th>Differences</th></tr>`)
+			for _, d := range drift.Resources {
+				if d.Status == k8s.DriftInSync {
+					continue
+				}
+				sb.WriteString(fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+					d.Status, html.EscapeString(d.Kind), html.EscapeString(d.Namespace), html.EscapeString(d.Name),
+					html.EscapeString(d.File), strings.ReplaceAll(html.EscapeString(driftFieldSummary(d)), "\n", "<br>")))
+			}
+			sb.WriteString(`</table>`)
+		}
+	}
+
+	if sections.DeprecatedAPIs && report.DeprecatedAPIs != nil {
+		scan := report.DeprecatedAPIs
+		sb.WriteString(`<h2 id="section-11"><a href="#section-11"><span class="section-number">11.</span> Deprecated APIs</a><a href="#top" class="back-to-top">[Back to Top]</a></h2>`)
+		sb.WriteString(fmt.Sprintf(`<p>Server version %s: <span class="status-fail">%d removed</span> and <span class="status-warn">%d deprecated</span> API versions in use. Removed versions fail to apply; migrate deprecated ones before upgrading past their removal release.</p>`,
+			html.EscapeString(dashIfEmpty(scan.ServerVersion)), scan.Removed, scan.Deprecated))
+		for _, e := range scan.Errors {
+			sb.WriteString(fmt.Sprintf(`<p><em>%s</em></p>`, html.EscapeString(e)))
+		}
+		if len(scan.Uses) > 0 {
+			sb.WriteString(`<table><tr><th>Status</th><th>Kind</th><th>Namespace</th><th>Name</th><th>API Version</th><th>Replacement</th><th>Deprecated In</th><th>Removed In</th><th>Found In</th></tr>`)
+			for _, u := range scan.Uses {
+				statusClass := "status-warn"
+				if u.Status == k8s.APIRemoved {
+					statusClass = "status-fail"
+				}
+				sb.WriteString(fmt.Sprintf(`<tr><td class="%s">%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+					statusClass, u.Status, html.EscapeString(u.Kind), html.EscapeString(u.Namespace), html.EscapeString(u.Name),
+					html.EscapeString(u.APIVersion), html.EscapeString(deprecatedAPIReplacement(u)), u.DeprecatedIn, u.RemovedIn,
+					html.EscapeString(deprecatedAPILocation(u))))
+			}
+			sb.WriteString(`</table>`)
+		}
+	}
+
 	// Footer
 	sb.WriteString(`<div class="footer">`)
-	sb.WriteString(`<p>Generated by K13d - AI-Powered Kubernetes Dashboard</p>`)
+	sb.WriteString(fmt.Sprintf(`<p>%s</p>`, html.EscapeString(branding.Footer)))
 	sb.WriteString(fmt.Sprintf(`<p>Report generated on %s</p>`, report.GeneratedAt.Format("2006-01-02 15:04:05 MST")))
 	sb.WriteString(`</div>`)
 	sb.WriteString(`</body></html>`)
@@ -908,4 +1439,47 @@ tr:hover { background: #e9ecef; }
 	return sb.String()
 }
 
-// HandleReports handles report-related API requests
+// reportThemeCSS renders the active theme's report colors as CSS custom
+// properties so HTML/PDF exports follow the configured --theme.
+func (rg *ReportGenerator) reportThemeCSS() string {
+	theme := ""
+	if rg != nil && rg.server != nil && rg.server.cfg != nil {
+		theme = rg.server.cfg.Theme
+	}
+	styles, _ := config.LoadTheme(theme)
+	r := styles.K13s.Report
+
+	vars := []struct {
+		name  string
+		value config.Color
+	}{
+		{"text", r.Text},
+		{"heading", r.Heading},
+		{"subheading", r.SubHeading},
+		{"accent", r.Accent},
+		{"table-header-bg", r.TableHeaderBg},
+		{"table-header-fg", r.TableHeaderFg},
+		{"surface", r.Surface},
+		{"border", r.Border},
+		{"success", r.Success},
+		{"warning", r.Warning},
+		{"danger", r.Danger},
+	}
+
+	var sb strings.Builder
+	sb.WriteString(":root {")
+	for _, v := range vars {
+		sb.WriteString(fmt.Sprintf(" --k13d-%s: %s;", v.name, v.value))
+	}
+	sb.WriteString(" }\n")
+	return sb.String()
+}
+
+// capacityWithPercent renders a quantity with its share of allocatable, or
+// "-" when it is unknown
+func capacityWithPercent(quantity string, percent int) string {
+	if quantity == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%d%%)", quantity, percent)
+}
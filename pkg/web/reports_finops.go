@@ -1,13 +1,60 @@
+//go:build !nofinops
+
 package web
 
 import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 )
 
+// GenerateFinOpsReport runs only the FinOps analysis. Besides the pods the
+// analysis reads, it lists just the services it checks for load balancers,
+// skipping events, security scans, and the rest of the cluster report.
+func (rg *ReportGenerator) GenerateFinOpsReport(ctx context.Context, username string) (*FinOpsReport, error) {
+	client := rg.server.k8sClient
+	namespaces, err := client.ListNamespaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list namespaces: %w", err)
+	}
+
+	// generateCostOptimizations reads pod phase counts and service types
+	names := make([]string, len(namespaces))
+	for i, ns := range namespaces {
+		names[i] = ns.Name
+	}
+	var mu sync.Mutex
+	report := &ComprehensiveReport{}
+	client.ForEachNamespace(ctx, names, func(ctx context.Context, ns string) {
+		pods, _ := client.ListPods(ctx, ns)
+		services, _ := client.ListServices(ctx, ns)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, pod := range pods {
+			switch pod.Status.Phase {
+			case corev1.PodPending:
+				report.Workloads.PendingPods++
+			case corev1.PodFailed:
+				report.Workloads.FailedPods++
+			}
+		}
+		for _, svc := range services {
+			report.Services = append(report.Services, ServiceInfo{Name: svc.Name, Namespace: svc.Namespace, Type: string(svc.Spec.Type)})
+		}
+	})
+
+	return &FinOpsReport{
+		GeneratedAt:    time.Now(),
+		GeneratedBy:    username,
+		FinOpsAnalysis: rg.generateFinOpsAnalysis(ctx, namespaces, report),
+	}, nil
+}
+
 func (rg *ReportGenerator) generateFinOpsAnalysis(ctx context.Context, namespaces []corev1.Namespace, report *ComprehensiveReport) FinOpsAnalysis {
 	analysis := FinOpsAnalysis{
 		EstimationModel:          "heuristic compute estimate from running pod requests with live metrics preferred",
@@ -17,14 +64,7 @@ func (rg *ReportGenerator) generateFinOpsAnalysis(ctx context.Context, namespace
 		OverprovisionedWorkloads: []OverprovisionedWorkload{},
 	}
 
-	// Approximate compute-only reference pricing.
-	// This is intentionally conservative and should be described as a heuristic,
-	// not a cloud billing replacement.
-	const cpuHourlyCost = 0.04
-	const memoryHourlyCost = 0.004
-	const monthlyHours = 730.0
 	const mib = int64(1024 * 1024)
-	const gib = float64(1024 * 1024 * 1024)
 
 	type podUsage struct {
 		cpuMilli  int64
@@ -100,16 +140,31 @@ func (rg *ReportGenerator) generateFinOpsAnalysis(ctx context.Context, namespace
 	var totalNodeCPUAllocatable, totalNodeMemAllocatable int64
 	var podsWithoutRequests, podsWithoutLimits int
 
-	nodes, _ := rg.server.k8sClient.ListNodes(ctx)
+	// A tenant report leaves node capacity out
+	var (
+		nodes []corev1.Node
+		err   error
+	)
+	if report.Namespace == "" {
+		nodes, err = rg.server.k8sClient.ListNodes(ctx)
+	}
+	if err != nil {
+		report.addWarning(reportListWarning("nodes", "", err))
+	}
 	for _, node := range nodes {
 		totalNodeCPUAllocatable += node.Status.Allocatable.Cpu().MilliValue()
 		totalNodeMemAllocatable += node.Status.Allocatable.Memory().Value()
 	}
 
 	nsCosts := make(map[string]*NamespaceCost)
+	labelKey := rg.costAllocationLabel()
+	labelCosts := make(map[string]*labelCostTotals)
 
 	for _, ns := range namespaces {
-		pods, _ := rg.server.k8sClient.ListPods(ctx, ns.Name)
+		pods, err := rg.server.k8sClient.ListPods(ctx, ns.Name)
+		if err != nil {
+			report.addWarning(reportListWarning("pods", ns.Name, err))
+		}
 
 		nsCost := &NamespaceCost{
 			Namespace: ns.Name,
@@ -121,6 +176,12 @@ func (rg *ReportGenerator) generateFinOpsAnalysis(ctx context.Context, namespace
 		var nsBillableCPU, nsBillableMem int64
 
 		for _, pod := range pods {
+			var group *labelCostTotals
+			if labelKey != "" {
+				group = labelCostGroup(labelCosts, pod.Labels[labelKey])
+				group.namespaces[pod.Namespace] = true
+				group.pods++
+			}
 			if pod.Status.Phase != corev1.PodRunning {
 				continue
 			}
@@ -183,6 +244,17 @@ func (rg *ReportGenerator) generateFinOpsAnalysis(ctx context.Context, namespace
 			}
 			nsBillableCPU += billableCPU
 			nsBillableMem += billableMem
+			if group != nil {
+				group.runningPods++
+				group.cpuRequests += podCPURequests
+				group.memRequests += podMemRequests
+				group.billableCPU += billableCPU
+				group.billableMem += billableMem
+				if usage.available {
+					group.cpuUsage += usage.cpuMilli
+					group.memUsage += usage.memBytes
+				}
+			}
 
 			if metricsSource == "live_metrics" && usage.available && podCPURequests > 0 && podMemRequests > 0 {
 				cpuPct := percentInt64(usage.cpuMilli, podCPURequests)
@@ -204,9 +276,7 @@ func (rg *ReportGenerator) generateFinOpsAnalysis(ctx context.Context, namespace
 		nsCost.MemoryRequests = formatGBFromBytes(nsMemRequests)
 		nsCost.CPUUsage = formatCoresFromMilli(nsCPUUsage)
 		nsCost.MemoryUsage = formatGBFromBytes(nsMemUsage)
-		nsCost.EstimatedCost = ((float64(nsBillableCPU) / 1000.0) * cpuHourlyCost) +
-			((float64(nsBillableMem) / gib) * memoryHourlyCost)
-		nsCost.EstimatedCost *= monthlyHours
+		nsCost.EstimatedCost = monthlyComputeCost(nsBillableCPU, nsBillableMem)
 
 		nsCosts[ns.Name] = nsCost
 	}
@@ -226,6 +296,10 @@ func (rg *ReportGenerator) generateFinOpsAnalysis(ctx context.Context, namespace
 	sort.Slice(analysis.CostByNamespace, func(i, j int) bool {
 		return analysis.CostByNamespace[i].EstimatedCost > analysis.CostByNamespace[j].EstimatedCost
 	})
+	if labelKey != "" {
+		analysis.CostAllocationLabel = labelKey
+		analysis.CostByLabel = buildLabelCosts(labelCosts, totalCost)
+	}
 	sort.Slice(analysis.UnderutilizedResources, func(i, j int) bool {
 		return analysis.UnderutilizedResources[i].CPUUsage < analysis.UnderutilizedResources[j].CPUUsage
 	})
@@ -295,7 +369,7 @@ func (rg *ReportGenerator) generateCostOptimizations(report *ComprehensiveReport
 		})
 	}
 
-	if analysis.ResourceEfficiency.CPURequestsVsCapacity < 30 && analysis.ResourceEfficiency.MemoryRequestsVsCapacity < 30 {
+	if report.Namespace == "" && analysis.ResourceEfficiency.CPURequestsVsCapacity < 30 && analysis.ResourceEfficiency.MemoryRequestsVsCapacity < 30 {
 		optimizations = append(optimizations, CostOptimization{
 			Category:        "Cluster Sizing",
 			Description:     fmt.Sprintf("Requested CPU is %.1f%% and requested memory is %.1f%% of allocatable node capacity", analysis.ResourceEfficiency.CPURequestsVsCapacity, analysis.ResourceEfficiency.MemoryRequestsVsCapacity),
@@ -344,6 +418,87 @@ func (rg *ReportGenerator) generateCostOptimizations(report *ComprehensiveReport
 	return optimizations
 }
 
+// Approximate compute-only reference pricing.
+// This is intentionally conservative and should be described as a heuristic,
+// not a cloud billing replacement.
+const (
+	cpuHourlyCost    = 0.04
+	memoryHourlyCost = 0.004
+	monthlyHours     = 730.0
+)
+
+// monthlyComputeCost estimates the monthly cost of billable CPU millicores
+// and memory bytes
+func monthlyComputeCost(cpuMilli, memBytes int64) float64 {
+	const gib = float64(1024 * 1024 * 1024)
+	hourly := (float64(cpuMilli)/1000.0)*cpuHourlyCost + (float64(memBytes)/gib)*memoryHourlyCost
+	return hourly * monthlyHours
+}
+
+// costAllocationLabel returns the configured pod label key cost is grouped by
+func (rg *ReportGenerator) costAllocationLabel() string {
+	if rg.server == nil || rg.server.cfg == nil {
+		return ""
+	}
+	return strings.TrimSpace(rg.server.cfg.Reports.CostAllocationLabel)
+}
+
+// labelCostTotals accumulates the pods sharing one cost allocation label value
+type labelCostTotals struct {
+	namespaces               map[string]bool
+	pods, runningPods        int
+	cpuRequests, memRequests int64
+	cpuUsage, memUsage       int64
+	billableCPU, billableMem int64
+}
+
+func labelCostGroup(groups map[string]*labelCostTotals, value string) *labelCostTotals {
+	if value == "" {
+		value = unallocatedCostLabel
+	}
+	group, ok := groups[value]
+	if !ok {
+		group = &labelCostTotals{namespaces: make(map[string]bool)}
+		groups[value] = group
+	}
+	return group
+}
+
+// buildLabelCosts turns the label groups into LabelCosts, most expensive
+// first, with each group's share of totalCost
+func buildLabelCosts(groups map[string]*labelCostTotals, totalCost float64) []LabelCost {
+	costs := make([]LabelCost, 0, len(groups))
+	for value, group := range groups {
+		namespaces := make([]string, 0, len(group.namespaces))
+		for ns := range group.namespaces {
+			namespaces = append(namespaces, ns)
+		}
+		sort.Strings(namespaces)
+		cost := LabelCost{
+			Value:           value,
+			Namespaces:      namespaces,
+			PodCount:        group.pods,
+			RunningPodCount: group.runningPods,
+			CPURequests:     formatCoresFromMilli(group.cpuRequests),
+			MemoryRequests:  formatGBFromBytes(group.memRequests),
+			CPUUsage:        formatCoresFromMilli(group.cpuUsage),
+			MemoryUsage:     formatGBFromBytes(group.memUsage),
+			EstimatedCost:   monthlyComputeCost(group.billableCPU, group.billableMem),
+		}
+		if totalCost > 0 {
+			cost.CostPercentage = cost.EstimatedCost / totalCost * 100
+		}
+		costs = append(costs, cost)
+	}
+	sort.Slice(costs, func(i, j int) bool {
+		if costs[i].EstimatedCost != costs[j].EstimatedCost {
+			return costs[i].EstimatedCost > costs[j].EstimatedCost
+		}
+		return costs[i].Value < costs[j].Value
+	})
+	return costs
+}
+
 func formatCoresFromMilli(milli int64) string {
 	return fmt.Sprintf("%.2f cores", float64(milli)/1000.0)
 }
@@ -0,0 +1,25 @@
+//go:build nofinops
+
+package web
+
+import (
+	"context"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// errFinOpsNotBuilt is returned by FinOps entry points in binaries built
+// with the nofinops tag
+var errFinOpsNotBuilt = errors.New("FinOps is not included in this build (nofinops)")
+
+// GenerateFinOpsReport is unavailable in nofinops builds
+func (rg *ReportGenerator) GenerateFinOpsReport(ctx context.Context, username string) (*FinOpsReport, error) {
+	return nil, errFinOpsNotBuilt
+}
+
+// generateFinOpsAnalysis is never reached in nofinops builds, since
+// config.FinOpsEnabled leaves the section out
+func (rg *ReportGenerator) generateFinOpsAnalysis(ctx context.Context, namespaces []corev1.Namespace, report *ComprehensiveReport) FinOpsAnalysis {
+	return FinOpsAnalysis{}
+}
@@ -3,20 +3,101 @@ package web
 import (
 	"context"
 	"fmt"
-	corev1 "k8s.io/api/core/v1"
+	"net/url"
+	"slices"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/cloudbro-kube-ai/k13d/pkg/log"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 )
 
+// namespaceResources holds the objects a report reads from one namespace
+type namespaceResources struct {
+	pods       []corev1.Pod
+	deploys    []appsv1.Deployment
+	services   []corev1.Service
+	configMaps []corev1.ConfigMap
+	secrets    []corev1.Secret
+	pvcs       []corev1.PersistentVolumeClaim
+	ingresses  []networkingv1.Ingress
+	netpols    []networkingv1.NetworkPolicy
+	// statefulSets, cronJobs, and serviceAccounts are only read to find the
+	// secrets nothing refers to
+	statefulSets    []appsv1.StatefulSet
+	cronJobs        []batchv1.CronJob
+	serviceAccounts []corev1.ServiceAccount
+	warnings        []string // failed list calls, see reportListWarning
+}
+
+// listNamespaceResources lists each namespace's objects once, querying up to
+// the client's MaxConcurrency namespaces in parallel. Results keep the order
+// of namespaces; list errors leave the affected slice empty and add a
+// warning.
+func (rg *ReportGenerator) listNamespaceResources(ctx context.Context, namespaces []corev1.Namespace, progress *reportProgressTracker) []namespaceResources {
+	names := make([]string, len(namespaces))
+	index := make(map[string]int, len(namespaces))
+	for i, ns := range namespaces {
+		names[i] = ns.Name
+		index[ns.Name] = i
+	}
+
+	results := make([]namespaceResources, len(namespaces))
+	client := rg.server.k8sClient
+	var done atomic.Int64
+	client.ForEachNamespace(ctx, names, func(ctx context.Context, ns string) {
+		res := &results[index[ns]]
+		check := func(resource string, err error) {
+			if err != nil {
+				res.warnings = append(res.warnings, reportListWarning(resource, ns, err))
+			}
+		}
+		var err error
+		res.pods, err = client.ListPods(ctx, ns)
+		check("pods", err)
+		res.deploys, err = client.ListDeployments(ctx, ns)
+		check("deployments", err)
+		res.services, err = client.ListServices(ctx, ns)
+		check("services", err)
+		res.configMaps, err = client.ListConfigMaps(ctx, ns)
+		check("configmaps", err)
+		res.secrets, err = client.ListSecrets(ctx, ns)
+		check("secrets", err)
+		res.pvcs, err = client.ListPersistentVolumeClaims(ctx, ns)
+		check("persistentvolumeclaims", err)
+		res.ingresses, err = client.ListIngresses(ctx, ns)
+		check("ingresses", err)
+		res.netpols, err = client.ListNetworkPolicies(ctx, ns)
+		check("networkpolicies", err)
+		res.statefulSets, err = client.ListStatefulSets(ctx, ns)
+		check("statefulsets", err)
+		res.cronJobs, err = client.ListCronJobs(ctx, ns)
+		check("cronjobs", err)
+		res.serviceAccounts, err = client.ListServiceAccounts(ctx, ns)
+		check("serviceaccounts", err)
+		progress.step("Listing namespace resources", int(done.Add(1)), len(names))
+	})
+	return results
+}
+
 // AllSections returns ReportSections with everything enabled.
 func AllSections() *ReportSections {
 	return &ReportSections{
 		Nodes: true, Namespaces: true, Workloads: true, Events: true,
-		SecurityBasic: true, FinOps: true, Metrics: true,
+		SecurityBasic: true, FinOps: true, Metrics: true, Capacity: true, Drift: true,
+		DeprecatedAPIs: true,
 	}
 }
 
+// reportSectionNames are the section names ParseSections accepts
+var reportSectionNames = []string{"nodes", "namespaces", "workloads", "events", "security", "security_full", "finops", "metrics", "capacity", "drift", "deprecated_apis"}
+
 // ParseSections parses a comma-separated sections string into ReportSections.
 // Returns nil (meaning all sections) if the input is empty.
 func ParseSections(s string) *ReportSections {
@@ -43,24 +124,73 @@ func ParseSections(s string) *ReportSections {
 			sec.FinOps = true
 		case "metrics":
 			sec.Metrics = true
+		case "capacity":
+			sec.Capacity = true
+		case "drift":
+			sec.Drift = true
+		case "deprecated_apis":
+			sec.DeprecatedAPIs = true
 		}
 	}
 	return sec
 }
 
+// requestedSections returns the sections named by the sections query
+// parameter or, when it is absent, the reports.default_sections config.
+// Unknown configured names are skipped; if none are valid, every section is
+// included.
+func (rg *ReportGenerator) requestedSections(query url.Values) *ReportSections {
+	if s := query.Get("sections"); s != "" {
+		return ParseSections(s)
+	}
+	if rg == nil || rg.server == nil || rg.server.cfg == nil || len(rg.server.cfg.Reports.DefaultSections) == 0 {
+		return nil
+	}
+
+	var valid []string
+	for _, name := range rg.server.cfg.Reports.DefaultSections {
+		name = strings.TrimSpace(name)
+		if !slices.Contains(reportSectionNames, name) {
+			log.Warnf("Ignoring unknown report section %q in reports.default_sections (valid: %s)", name, strings.Join(reportSectionNames, ", "))
+			continue
+		}
+		valid = append(valid, name)
+	}
+	return ParseSections(strings.Join(valid, ","))
+}
+
 // GenerateComprehensiveReport gathers all cluster data
 func (rg *ReportGenerator) GenerateComprehensiveReport(ctx context.Context, username string) (*ComprehensiveReport, error) {
-	return rg.GenerateReport(ctx, username, nil)
+	return rg.GenerateReport(ctx, username, nil, nil)
 }
 
 // GenerateReport gathers cluster data for the specified sections.
-// If sections is nil, all sections are included.
-func (rg *ReportGenerator) GenerateReport(ctx context.Context, username string, sections *ReportSections) (*ComprehensiveReport, error) {
-	included := normalizeReportSections(sections)
+// If sections is nil, all sections are included; if eventOpts is nil, the
+// reports config decides how many events are listed.
+func (rg *ReportGenerator) GenerateReport(ctx context.Context, username string, sections *ReportSections, eventOpts *ReportEventOptions) (*ComprehensiveReport, error) {
+	return rg.GenerateReportWithProgress(ctx, username, sections, eventOpts, nil)
+}
+
+// GenerateReportWithProgress is GenerateReport, calling progress as each
+// stage starts and as namespaces are listed. progress may be nil.
+func (rg *ReportGenerator) GenerateReportWithProgress(ctx context.Context, username string, sections *ReportSections, eventOpts *ReportEventOptions, progress ReportProgressFunc) (*ComprehensiveReport, error) {
+	included := rg.withoutDisabledSections(normalizeReportSections(sections))
+	tenant := requestedNamespaceScope(ctx).tenant
+	if tenant != "" {
+		// Nodes, their capacity and metrics, and the cluster's manifests
+		// are not the tenant's
+		included.Nodes, included.Capacity, included.Metrics, included.Drift = false, false, false, false
+	}
+	tracker := newReportProgressTracker(progress, rg, included)
+	if eventOpts == nil {
+		defaults := rg.defaultEventOptions()
+		eventOpts = &defaults
+	}
 	report := &ComprehensiveReport{
 		GeneratedAt:      time.Now(),
 		GeneratedBy:      username,
 		IncludedSections: included,
+		Namespace:        tenant,
 	}
 	report.ClusterInfo.Platform = "kubernetes"
 	if rg.server != nil && rg.server.k8sClient != nil && rg.server.k8sClient.Clientset != nil {
@@ -72,9 +202,19 @@ func (rg *ReportGenerator) GenerateReport(ctx context.Context, username string,
 		}
 	}
 
-	// Always get nodes (needed for health score and cluster info)
-	nodes, err := rg.server.k8sClient.ListNodes(ctx)
-	if err == nil {
+	// Always get nodes (needed for health score and cluster info), except
+	// for a tenant report
+	tracker.start("nodes", "Gathering nodes")
+	var (
+		nodes []corev1.Node
+		err   error
+	)
+	if tenant == "" {
+		nodes, err = rg.server.k8sClient.ListNodes(ctx)
+	}
+	if err != nil {
+		report.addWarning(reportListWarning("nodes", "", err))
+	} else {
 		report.NodeSummary.Total = len(nodes)
 		for _, node := range nodes {
 			info := NodeInfo{
@@ -141,11 +281,30 @@ func (rg *ReportGenerator) GenerateReport(ctx context.Context, username string,
 		}
 	}
 
+	// Node allocatable vs pod requests and limits vs usage
+	if included.Capacity {
+		tracker.start("capacity", "Comparing node capacity with requests and usage")
+		rg.collectNodeCapacity(ctx, report)
+	}
+
 	// Get namespaces
+	tracker.start("namespaces", "Gathering namespaces")
 	namespaces, err := rg.server.k8sClient.ListNamespaces(ctx)
-	if err == nil {
+	namespaces, report.NamespaceSummary.Hidden = rg.hideExcludedNamespaces(ctx, namespaces)
+	if re := requestedNamespaceScope(ctx).regex; re != nil && tenant == "" {
+		report.NamespaceSummary.Regex = re.String()
+	}
+	resources := rg.listNamespaceResources(ctx, namespaces, tracker)
+	for _, res := range resources {
+		for _, w := range res.warnings {
+			report.addWarning(w)
+		}
+	}
+	if err != nil {
+		report.addWarning(reportListWarning("namespaces", "", err))
+	} else {
 		report.NamespaceSummary.Total = len(namespaces)
-		for _, ns := range namespaces {
+		for i, ns := range namespaces {
 			info := NamespaceInfo{
 				Name:         ns.Name,
 				Status:       string(ns.Status.Phase),
@@ -157,26 +316,26 @@ func (rg *ReportGenerator) GenerateReport(ctx context.Context, username string,
 			}
 
 			// Count resources in namespace
-			pods, _ := rg.server.k8sClient.ListPods(ctx, ns.Name)
-			info.PodCount = len(pods)
-
-			deps, _ := rg.server.k8sClient.ListDeployments(ctx, ns.Name)
-			info.DeployCount = len(deps)
-
-			svcs, _ := rg.server.k8sClient.ListServices(ctx, ns.Name)
-			info.ServiceCount = len(svcs)
+			info.PodCount = len(resources[i].pods)
+			info.DeployCount = len(resources[i].deploys)
+			info.ServiceCount = len(resources[i].services)
 
 			report.Namespaces = append(report.Namespaces, info)
 		}
 	}
 
+	// Namespace ResourceQuotas and LimitRanges
+	if included.Namespaces {
+		rg.collectQuotas(ctx, report)
+	}
+
 	// Gather workload data
+	tracker.start("workloads", "Summarizing workloads")
 	imageCount := make(map[string]int)
 
-	for _, ns := range namespaces {
+	for _, res := range resources {
 		// Pods
-		pods, _ := rg.server.k8sClient.ListPods(ctx, ns.Name)
-		for _, pod := range pods {
+		for _, pod := range res.pods {
 			report.Workloads.TotalPods++
 
 			switch pod.Status.Phase {
@@ -187,6 +346,13 @@ func (rg *ReportGenerator) GenerateReport(ctx context.Context, username string,
 			case corev1.PodFailed:
 				report.Workloads.FailedPods++
 			}
+			status := string(pod.Status.Phase)
+			if terminating := k8s.PodTerminatingStatus(&pod, report.GeneratedAt); terminating != "" {
+				status = terminating
+				if k8s.IsStuckTerminatingStatus(terminating) {
+					report.Workloads.StuckTerminatingPods++
+				}
+			}
 
 			// Count restarts
 			restarts := 0
@@ -194,24 +360,27 @@ func (rg *ReportGenerator) GenerateReport(ctx context.Context, username string,
 				restarts += int(cs.RestartCount)
 			}
 
-			// Get images
+			// Images and security checks cover init and ephemeral containers
+			// too; an image counts once per pod
 			var images []string
-			for _, c := range pod.Spec.Containers {
-				images = append(images, c.Image)
-				imageCount[c.Image]++
-			}
-
-			// Security checks
-			for _, c := range pod.Spec.Containers {
+			privileged := false
+			for _, c := range k8s.AllContainers(&pod.Spec) {
+				if !slices.Contains(images, c.Image) {
+					images = append(images, c.Image)
+					imageCount[c.Image]++
+				}
 				if c.SecurityContext != nil {
 					if c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
-						report.SecurityInfo.PrivilegedPods++
+						privileged = true
 					}
 					if c.SecurityContext.RunAsUser != nil && *c.SecurityContext.RunAsUser == 0 {
 						report.SecurityInfo.RootContainers++
 					}
 				}
 			}
+			if privileged {
+				report.SecurityInfo.PrivilegedPods++
+			}
 			if pod.Spec.HostNetwork {
 				report.SecurityInfo.HostNetworkPods++
 			}
@@ -225,22 +394,23 @@ func (rg *ReportGenerator) GenerateReport(ctx context.Context, username string,
 			}
 
 			podInfo := PodInfo{
-				Name:      pod.Name,
-				Namespace: pod.Namespace,
-				Status:    string(pod.Status.Phase),
-				Ready:     fmt.Sprintf("%d/%d", ready, total),
-				Restarts:  restarts,
-				Node:      pod.Spec.NodeName,
-				IP:        pod.Status.PodIP,
-				Images:    images,
-				Age:       time.Since(pod.CreationTimestamp.Time).Round(time.Second).String(),
+				Name:          pod.Name,
+				Namespace:     pod.Namespace,
+				Status:        status,
+				Ready:         fmt.Sprintf("%d/%d", ready, total),
+				Restarts:      restarts,
+				QoSClass:      string(k8s.PodQOSClass(&pod)),
+				PriorityClass: pod.Spec.PriorityClassName,
+				Node:          pod.Spec.NodeName,
+				IP:            pod.Status.PodIP,
+				Images:        images,
+				Age:           k8s.FormatAgeSince(pod.CreationTimestamp.Time),
 			}
 			report.Pods = append(report.Pods, podInfo)
 		}
 
 		// Deployments
-		deps, _ := rg.server.k8sClient.ListDeployments(ctx, ns.Name)
-		for _, dep := range deps {
+		for _, dep := range res.deploys {
 			report.Workloads.TotalDeployments++
 
 			replicas := int32(1)
@@ -264,14 +434,13 @@ func (rg *ReportGenerator) GenerateReport(ctx context.Context, username string,
 				UpToDate:  int(dep.Status.UpdatedReplicas),
 				Available: int(dep.Status.AvailableReplicas),
 				Strategy:  strategy,
-				Age:       time.Since(dep.CreationTimestamp.Time).Round(time.Second).String(),
+				Age:       k8s.FormatAgeSince(dep.CreationTimestamp.Time),
 			}
 			report.Deployments = append(report.Deployments, depInfo)
 		}
 
 		// Services
-		svcs, _ := rg.server.k8sClient.ListServices(ctx, ns.Name)
-		for _, svc := range svcs {
+		for _, svc := range res.services {
 			report.Workloads.TotalServices++
 
 			ports := make([]string, len(svc.Spec.Ports))
@@ -301,17 +470,14 @@ func (rg *ReportGenerator) GenerateReport(ctx context.Context, username string,
 				ClusterIP:  svc.Spec.ClusterIP,
 				ExternalIP: externalIP,
 				Ports:      strings.Join(ports, ", "),
-				Age:        time.Since(svc.CreationTimestamp.Time).Round(time.Second).String(),
+				Age:        k8s.FormatAgeSince(svc.CreationTimestamp.Time),
 			}
 			report.Services = append(report.Services, svcInfo)
 		}
 
 		// ConfigMaps & Secrets count
-		configmaps, _ := rg.server.k8sClient.ListConfigMaps(ctx, ns.Name)
-		report.Workloads.TotalConfigMaps += len(configmaps)
-
-		secrets, _ := rg.server.k8sClient.ListSecrets(ctx, ns.Name)
-		report.SecurityInfo.Secrets += len(secrets)
+		report.Workloads.TotalConfigMaps += len(res.configMaps)
+		report.SecurityInfo.Secrets += len(res.secrets)
 	}
 
 	// Build image list
@@ -336,35 +502,41 @@ func (rg *ReportGenerator) GenerateReport(ctx context.Context, username string,
 		return report.Images[i].PodCount > report.Images[j].PodCount
 	})
 
-	// Get events (warnings only, last 50)
-	if included.Events {
-		events, _ := rg.server.k8sClient.ListEvents(ctx, "")
-		warningEvents := []EventInfo{}
-		for _, event := range events {
-			if event.Type == "Warning" {
-				warningEvents = append(warningEvents, EventInfo{
-					Type:      event.Type,
-					Reason:    event.Reason,
-					Object:    fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
-					Message:   event.Message,
-					Count:     int(event.Count),
-					FirstSeen: event.FirstTimestamp.Format(time.RFC3339),
-					LastSeen:  event.LastTimestamp.Format(time.RFC3339),
-				})
-			}
+	report.VolumeClaims = buildVolumeClaimInfos(resources)
+	report.UnreferencedSecrets = buildUnreferencedSecrets(resources)
+	report.SecurityInfo.UnreferencedSecrets = len(report.UnreferencedSecrets)
+	report.Ingresses = buildIngressInfos(resources)
+	report.NetworkPolicies, report.NetworkCoverage = buildNetworkPolicyInfos(resources)
+	for _, cov := range report.NetworkCoverage {
+		if cov.Status == networkCoverageNoPolicy {
+			report.SecurityInfo.NamespacesWithoutNetworkPolicy++
 		}
-		// Keep only last 50 warning events
-		if len(warningEvents) > 50 {
-			warningEvents = warningEvents[:50]
+	}
+
+	// Get events: every Warning is classified and counted, the list is capped
+	if included.Events {
+		tracker.start("events", "Gathering events")
+		events, err := rg.server.k8sClient.ListEvents(ctx, tenant)
+		if err != nil {
+			report.addWarning(reportListWarning("events", "", err))
 		}
-		report.Events = warningEvents
+		events = slices.DeleteFunc(events, func(e corev1.Event) bool {
+			return rg.reportNamespaceHidden(ctx, e.Namespace)
+		})
+		report.Events, report.EventStats = buildReportEvents(events, *eventOpts)
 	}
 
-	// Calculate health score
+	// Calculate health score; a tenant report scores its pods alone
 	report.HealthScore = calculateHealthScore(
 		report.NodeSummary.Ready, report.NodeSummary.Total,
 		report.Workloads.RunningPods, report.Workloads.TotalPods,
 	)
+	if tenant != "" {
+		report.HealthScore = 100
+		if total := report.Workloads.TotalPods; total > 0 {
+			report.HealthScore = float64(report.Workloads.RunningPods) / float64(total) * 100
+		}
+	}
 
 	// Set cluster info
 	report.ClusterInfo = ClusterInfo{
@@ -374,21 +546,41 @@ func (rg *ReportGenerator) GenerateReport(ctx context.Context, username string,
 
 	// Generate FinOps analysis
 	if included.FinOps {
+		tracker.start("finops", "Running FinOps analysis")
 		report.FinOpsAnalysis = rg.generateFinOpsAnalysis(ctx, namespaces, report)
 	}
 
+	// Compare the configured manifest directory with the live resources
+	if included.Drift {
+		tracker.start("drift", "Comparing manifests with the cluster")
+		report.Drift = rg.generateDriftReport(ctx)
+	}
+
+	// Find objects and manifests that use deprecated API versions
+	if included.DeprecatedAPIs {
+		tracker.start("deprecated_apis", "Checking for deprecated API versions")
+		report.DeprecatedAPIs = rg.generateDeprecatedAPIReport(ctx, report)
+	}
+
 	// Add metrics history if collector is available
 	if included.Metrics && rg.server.metricsCollector != nil {
+		tracker.start("metrics", "Loading metrics history")
 		report.MetricsHistory = rg.generateMetricsHistory(ctx)
 	}
 
 	// Run security scan if scanner is available
 	if included.SecurityBasic && rg.server.securityScanner != nil {
+		tracker.start("security", "Running security scan")
 		if included.SecurityFull {
 			report.SecurityScan = rg.generateFullSecurityScan(ctx)
 		} else {
 			report.SecurityScan = rg.generateSecurityScan(ctx)
 		}
+		if report.SecurityScan == nil {
+			report.addWarning("security scan failed; the security scan sections are missing")
+		} else if tenant != "" {
+			scopeSecurityScan(report.SecurityScan, tenant)
+		}
 	}
 
 	return report, nil
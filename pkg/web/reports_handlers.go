@@ -4,11 +4,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+// HandleReports handles report-related API requests
 func (rg *ReportGenerator) HandleReports(w http.ResponseWriter, r *http.Request) {
 	username := r.Header.Get("X-Username")
 	if username == "" {
@@ -18,63 +23,187 @@ func (rg *ReportGenerator) HandleReports(w http.ResponseWriter, r *http.Request)
 	format := r.URL.Query().Get("format") // json, csv, html
 	includeAI := r.URL.Query().Get("ai") == "true"
 	download := r.URL.Query().Get("download") == "true" // Force download (vs preview)
-	sections := ParseSections(r.URL.Query().Get("sections"))
+	sections := rg.requestedSections(r.URL.Query())
+	eventOpts, err := rg.parseReportEventOptions(r.URL.Query())
+	if err != nil {
+		WriteError(w, NewAPIError(ErrCodeBadRequest, err.Error()))
+		return
+	}
+	branding, err := parseReportBranding(r.URL.Query())
+	if err != nil {
+		WriteError(w, NewAPIError(ErrCodeBadRequest, err.Error()))
+		return
+	}
+	pageOpts, err := rg.parseReportPageOptions(r.URL.Query())
+	if err != nil {
+		WriteError(w, NewAPIError(ErrCodeBadRequest, err.Error()))
+		return
+	}
+	redaction, err := rg.requestedRedaction(r.URL.Query())
+	if err != nil {
+		WriteError(w, NewAPIError(ErrCodeBadRequest, err.Error()))
+		return
+	}
+	reportCtx, err := withRequestedNamespaces(r.Context(), r.URL.Query())
+	if err != nil {
+		WriteError(w, NewAPIError(ErrCodeBadRequest, err.Error()))
+		return
+	}
 
 	switch r.Method {
 	case http.MethodGet:
+		// Clients that accept text/event-stream get progress events and
+		// then the report in a final "report" event
+		var sse *SSEWriter
+		var progress ReportProgressFunc
+		if wantsReportStream(r) {
+			var ok bool
+			if sse, progress, ok = startReportStream(w); !ok {
+				return
+			}
+		}
+
 		// Generate report with selected sections
-		report, err := rg.GenerateReport(r.Context(), username, sections)
+		report, err := rg.GenerateReportWithProgress(reportCtx, username, sections, eventOpts, progress)
 		if err != nil {
+			if sse != nil {
+				writeReportStreamError(sse, err)
+				return
+			}
 			WriteError(w, NewAPIError(ErrCodeInternalError, err.Error()))
 			return
 		}
+		report.Branding = branding
 
-		// Add AI analysis if requested
+		// Add AI analysis if requested, reusing a recent one where possible
 		if includeAI {
-			analysis, err := rg.GenerateAIAnalysis(r.Context(), report)
-			if err == nil {
-				report.AIAnalysis = analysis
+			if progress != nil {
+				progress(ReportProgress{Stage: "ai", Message: "Running AI analysis", Percent: 100})
 			}
+			rg.addAIAnalysis(w, r, report)
 		}
 
+		// Redact last so the AI analysis text is covered too
+		rg.redactReport(report, redaction)
+
 		// Record audit
+		resource := "cluster"
+		if report.Namespace != "" {
+			resource = "namespace/" + report.Namespace
+		}
 		_ = db.RecordAudit(db.AuditEntry{
 			User:     username,
 			Action:   "generate_report",
-			Resource: "cluster",
+			Resource: resource,
 			Details:  fmt.Sprintf("Format: %s, AI: %v, Download: %v", format, includeAI, download),
 		})
 
+		// JSON and CSV exports can be paged; the HTML report applies its own
+		// display caps. Paging follows the AI analysis so it sees the whole report.
+		if format != "html" {
+			paginateReport(report, *pageOpts)
+		}
+
 		// Return in requested format
-		switch format {
-		case "csv":
-			csvData, err := rg.ExportToCSV(report)
-			if err != nil {
-				WriteError(w, NewAPIError(ErrCodeInternalError, err.Error()))
+		data, contentType, ext, err := rg.encodeReport(report, format)
+		if err != nil {
+			if sse != nil {
+				writeReportStreamError(sse, err)
 				return
 			}
-			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
-			if download {
-				w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=k13d-report-%s.csv", time.Now().Format("20060102-150405")))
-			}
-			_, _ = w.Write(csvData)
+			WriteError(w, NewAPIError(ErrCodeInternalError, err.Error()))
+			return
+		}
+		filename := fmt.Sprintf("k13d-report-%s.%s", time.Now().Format("20060102-150405"), ext)
+		if report.Namespace != "" {
+			filename = fmt.Sprintf("k13d-report-%s-%s.%s", report.Namespace, time.Now().Format("20060102-150405"), ext)
+		}
+		if sse != nil {
+			writeReportStreamEvent(sse, "report", reportStreamResult{
+				Format:       ext,
+				Filename:     filename,
+				AIAnalysisID: report.AIAnalysisID,
+				Content:      string(data),
+			})
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		if download && ext != "json" {
+			w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+		}
+		_, _ = w.Write(data)
 
-		case "html":
-			htmlData := rg.ExportToHTML(report)
-			w.Header().Set("Content-Type", "text/html; charset=utf-8")
-			if download {
-				w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=k13d-report-%s.html", time.Now().Format("20060102-150405")))
-			}
-			_, _ = w.Write([]byte(htmlData))
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
 
-		default: // json
-			w.Header().Set("Content-Type", "application/json")
-			_ = json.NewEncoder(w).Encode(report)
+// HandleNamespaceReport serves /api/reports/namespace/{ns}: a tenant report of
+// one namespace, taking the same query parameters as /api/reports. It leaves
+// out nodes and other cluster-wide data, so a role that may view the
+// namespace can generate it.
+func (rg *ReportGenerator) HandleNamespaceReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	ns := strings.TrimPrefix(r.URL.Path, "/api/reports/namespace/")
+	if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+		WriteError(w, NewAPIError(ErrCodeBadRequest, fmt.Sprintf("invalid namespace %q: %s", ns, strings.Join(errs, "; "))))
+		return
+	}
+	if az := rg.server.authorizer; az != nil {
+		if allowed, reason := az.authorizeRequest(r, "namespaces", ActionView, ns); !allowed {
+			WriteError(w, NewAPIError(ErrCodeForbidden, reason))
+			return
 		}
+	}
+	if _, err := rg.server.k8sClient.Clientset.CoreV1().Namespaces().Get(r.Context(), ns, metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			WriteError(w, NewAPIError(ErrCodeNotFound, fmt.Sprintf("namespace %q not found", ns)))
+			return
+		}
+		WriteError(w, NewAPIError(ErrCodeInternalError, err.Error()))
+		return
+	}
+
+	rg.HandleReports(w, r.WithContext(withTenantNamespace(r.Context(), ns)))
+}
 
+// encodeReport encodes report as csv, html, or (by default) json, returning
+// the body, its content type, and the file extension
+func (rg *ReportGenerator) encodeReport(report *ComprehensiveReport, format string) ([]byte, string, string, error) {
+	switch format {
+	case "csv":
+		csvData, err := rg.ExportToCSV(report)
+		if err != nil {
+			return nil, "", "", err
+		}
+		return csvData, "text/csv; charset=utf-8", "csv", nil
+	case "html":
+		return []byte(rg.ExportToHTML(report)), "text/html; charset=utf-8", "html", nil
 	default:
-		writeMethodNotAllowed(w)
+		data, err := json.Marshal(report)
+		if err != nil {
+			return nil, "", "", err
+		}
+		return append(data, '\n'), "application/json", "json", nil
+	}
+}
+
+// addAIAnalysis fills in the report's AI analysis, reusing the analysis
+// named by the ai_id query parameter or a cached one for the same report
+// summary. The analysis ID is also returned in a header for HTML and CSV
+// exports. Failures leave the report without an analysis.
+func (rg *ReportGenerator) addAIAnalysis(w http.ResponseWriter, r *http.Request, report *ComprehensiveReport) {
+	analysis, id, err := rg.CachedAIAnalysis(r.Context(), report, r.URL.Query().Get("ai_id"))
+	if err != nil {
+		return
 	}
+	report.AIAnalysis = analysis
+	report.AIAnalysisID = id
+	w.Header().Set(aiAnalysisIDHeader, id)
 }
 
 // HandleReportPreview handles report preview in a new window
@@ -85,22 +214,57 @@ func (rg *ReportGenerator) HandleReportPreview(w http.ResponseWriter, r *http.Re
 	}
 
 	includeAI := r.URL.Query().Get("ai") == "true"
-	sections := ParseSections(r.URL.Query().Get("sections"))
+	sections := rg.requestedSections(r.URL.Query())
+	eventOpts, err := rg.parseReportEventOptions(r.URL.Query())
+	if err != nil {
+		WriteError(w, NewAPIError(ErrCodeBadRequest, err.Error()))
+		return
+	}
+	branding, err := parseReportBranding(r.URL.Query())
+	if err != nil {
+		WriteError(w, NewAPIError(ErrCodeBadRequest, err.Error()))
+		return
+	}
+	redaction, err := rg.requestedRedaction(r.URL.Query())
+	if err != nil {
+		WriteError(w, NewAPIError(ErrCodeBadRequest, err.Error()))
+		return
+	}
+	reportCtx, err := withRequestedNamespaces(r.Context(), r.URL.Query())
+	if err != nil {
+		WriteError(w, NewAPIError(ErrCodeBadRequest, err.Error()))
+		return
+	}
+
+	var sse *SSEWriter
+	var progress ReportProgressFunc
+	if wantsReportStream(r) {
+		var ok bool
+		if sse, progress, ok = startReportStream(w); !ok {
+			return
+		}
+	}
 
 	// Generate report with selected sections
-	report, err := rg.GenerateReport(r.Context(), username, sections)
+	report, err := rg.GenerateReportWithProgress(reportCtx, username, sections, eventOpts, progress)
 	if err != nil {
+		if sse != nil {
+			writeReportStreamError(sse, err)
+			return
+		}
 		WriteError(w, NewAPIError(ErrCodeInternalError, err.Error()))
 		return
 	}
+	report.Branding = branding
 
-	// Add AI analysis if requested
+	// Add AI analysis if requested; the download after a preview reuses it
 	if includeAI {
-		analysis, err := rg.GenerateAIAnalysis(r.Context(), report)
-		if err == nil {
-			report.AIAnalysis = analysis
+		if progress != nil {
+			progress(ReportProgress{Stage: "ai", Message: "Running AI analysis", Percent: 100})
 		}
+		rg.addAIAnalysis(w, r, report)
 	}
+	rg.redactReport(report, redaction)
 
 	// Record audit
 	_ = db.RecordAudit(db.AuditEntry{
@@ -111,7 +275,76 @@ func (rg *ReportGenerator) HandleReportPreview(w http.ResponseWriter, r *http.Re
 	})
 
 	// Return HTML for preview (no Content-Disposition header)
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	htmlData := rg.ExportToHTML(report)
+	if sse != nil {
+		writeReportStreamEvent(sse, "report", reportStreamResult{
+			Format:       "html",
+			AIAnalysisID: report.AIAnalysisID,
+			Content:      htmlData,
+		})
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	_, _ = w.Write([]byte(htmlData))
 }
+
+// HandleFinOpsReport exports only the FinOps cost analysis as JSON or CSV,
+// for cost tracking without generating the full cluster report
+func (rg *ReportGenerator) HandleFinOpsReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+	if !rg.server.cfg.FinOpsEnabled() {
+		WriteError(w, NewAPIError(ErrCodeNotFound, "FinOps is disabled on this server"))
+		return
+	}
+	username := r.Header.Get("X-Username")
+	if username == "" {
+		username = "anonymous"
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		WriteError(w, NewAPIError(ErrCodeBadRequest, fmt.Sprintf("unsupported format %q (use json or csv)", format)))
+		return
+	}
+	download := r.URL.Query().Get("download") == "true"
+	redaction, err := rg.requestedRedaction(r.URL.Query())
+	if err != nil {
+		WriteError(w, NewAPIError(ErrCodeBadRequest, err.Error()))
+		return
+	}
+
+	report, err := rg.GenerateFinOpsReport(r.Context(), username)
+	if err != nil {
+		WriteError(w, NewAPIError(ErrCodeInternalError, err.Error()))
+		return
+	}
+	rg.redactReport(report, redaction)
+
+	_ = db.RecordAudit(db.AuditEntry{
+		User:     username,
+		Action:   "export_finops",
+		Resource: "cluster",
+		Details:  fmt.Sprintf("Format: %s, Download: %v", format, download),
+	})
+
+	if download {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=k13d-finops-%s.%s", time.Now().Format("20060102-150405"), format))
+	}
+	if format == "csv" {
+		csvData, err := rg.ExportFinOpsToCSV(report)
+		if err != nil {
+			WriteError(w, NewAPIError(ErrCodeInternalError, err.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		_, _ = w.Write(csvData)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
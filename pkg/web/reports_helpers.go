@@ -2,8 +2,11 @@ package web
 
 import (
 	"fmt"
+	"slices"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 func normalizeReportSections(sections *ReportSections) ReportSections {
@@ -18,6 +21,23 @@ func normalizeReportSections(sections *ReportSections) ReportSections {
 	return normalized
 }
 
+// withoutDisabledSections turns off the sections whose subsystem the config
+// disables (--no-security, --no-finops)
+func (rg *ReportGenerator) withoutDisabledSections(sections ReportSections) ReportSections {
+	var cfg *config.Config
+	if rg.server != nil {
+		cfg = rg.server.cfg
+	}
+	if !cfg.SecurityEnabled() {
+		sections.SecurityBasic = false
+		sections.SecurityFull = false
+	}
+	if !cfg.FinOpsEnabled() {
+		sections.FinOps = false
+	}
+	return sections
+}
+
 func reportSectionsOrAll(report *ComprehensiveReport) ReportSections {
 	if report == nil {
 		return *AllSections()
@@ -25,7 +45,8 @@ func reportSectionsOrAll(report *ComprehensiveReport) ReportSections {
 	sections := report.IncludedSections
 	if !sections.Nodes && !sections.Namespaces && !sections.Workloads &&
 		!sections.Events && !sections.SecurityBasic && !sections.SecurityFull &&
-		!sections.FinOps && !sections.Metrics {
+		!sections.FinOps && !sections.Metrics && !sections.Capacity && !sections.Drift &&
+		!sections.DeprecatedAPIs {
 		return *AllSections()
 	}
 	if sections.SecurityFull {
@@ -88,3 +109,26 @@ func nodeWarnings(node corev1.Node) (warnings []string, hasPressure bool) {
 
 	return warnings, hasPressure
 }
+
+// reportListWarning describes a failed list call for report.Warnings.
+// namespace is empty for cluster-wide lists.
+func reportListWarning(resource, namespace string, err error) string {
+	reason := err.Error()
+	switch {
+	case apierrors.IsForbidden(err):
+		reason = "forbidden"
+	case apierrors.IsUnauthorized(err):
+		reason = "unauthorized"
+	}
+	if namespace == "" {
+		return fmt.Sprintf("could not list %s: %s", resource, reason)
+	}
+	return fmt.Sprintf("could not list %s in namespace %s: %s", resource, namespace, reason)
+}
+
+// addWarning adds a warning to the report once
+func (r *ComprehensiveReport) addWarning(warning string) {
+	if !slices.Contains(r.Warnings, warning) {
+		r.Warnings = append(r.Warnings, warning)
+	}
+}
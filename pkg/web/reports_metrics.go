@@ -2,7 +2,12 @@ package web
 
 import (
 	"context"
+	"slices"
 	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+	"github.com/cloudbro-kube-ai/k13d/pkg/metrics"
 )
 
 func (rg *ReportGenerator) generateMetricsHistory(ctx context.Context) *MetricsHistory {
@@ -17,14 +22,14 @@ func (rg *ReportGenerator) generateMetricsHistory(ctx context.Context) *MetricsH
 	start := end.Add(-24 * time.Hour)
 
 	// Get cluster metrics for the last 24 hours
-	metrics, err := store.GetClusterMetrics(ctx, contextName, start, end, 100)
-	if err != nil || len(metrics) == 0 {
+	samples, err := store.GetClusterMetrics(ctx, contextName, start, end, 100)
+	if err != nil || len(samples) == 0 {
 		return nil
 	}
 
 	history := &MetricsHistory{
 		Period:     "24h",
-		DataPoints: len(metrics),
+		DataPoints: len(samples),
 	}
 
 	var totalCPU, totalMem int64
@@ -33,8 +38,8 @@ func (rg *ReportGenerator) generateMetricsHistory(ctx context.Context) *MetricsH
 	var maxPods int
 
 	// Convert to data points (reverse order to chronological)
-	for i := len(metrics) - 1; i >= 0; i-- {
-		m := metrics[i]
+	for i := len(samples) - 1; i >= 0; i-- {
+		m := samples[i]
 		point := ClusterMetricPoint{
 			Timestamp:   m.Timestamp.Format("2006-01-02 15:04"),
 			CPUUsage:    m.UsedCPUMillis,
@@ -60,17 +65,35 @@ func (rg *ReportGenerator) generateMetricsHistory(ctx context.Context) *MetricsH
 		}
 	}
 
-	if len(metrics) > 0 {
+	if len(samples) > 0 {
 		history.Summary = MetricsHistorySummary{
-			AvgCPUUsage:    totalCPU / int64(len(metrics)),
+			AvgCPUUsage:    totalCPU / int64(len(samples)),
 			MaxCPUUsage:    maxCPU,
-			AvgMemoryUsage: totalMem / int64(len(metrics)),
+			AvgMemoryUsage: totalMem / int64(len(samples)),
 			MaxMemoryUsage: maxMem,
-			AvgRunningPods: totalPods / float64(len(metrics)),
+			AvgRunningPods: totalPods / float64(len(samples)),
 			MaxRunningPods: maxPods,
 		}
 	}
 
+	var anomalyCfg config.AnomalyConfig
+	if rg.server.cfg != nil {
+		anomalyCfg = rg.server.cfg.Reports.Anomalies
+	}
+	// The collector stores one series per namespace filter; check the one
+	// the latest sample belongs to
+	series := slices.DeleteFunc(slices.Clone(samples), func(m db.ClusterMetrics) bool {
+		return m.Namespace != samples[0].Namespace
+	})
+	for _, a := range metrics.DetectAnomalies(series, anomalyCfg) {
+		history.Anomalies = append(history.Anomalies, MetricsAnomaly{
+			Kind:     a.Kind,
+			Severity: a.Severity,
+			Message:  a.Message,
+			At:       a.At.Format("2006-01-02 15:04"),
+		})
+	}
+
 	return history
 }
 
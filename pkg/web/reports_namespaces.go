@@ -0,0 +1,100 @@
+package web
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// reportNamespacesKey holds the reportNamespaceScope of a report context
+type reportNamespacesKey struct{}
+
+// reportNamespaceScope is the namespace selection a report request asked for
+type reportNamespaceScope struct {
+	// includeExcluded covers the excluded_namespaces (include_excluded=true)
+	includeExcluded bool
+	// regex limits the report to matching namespaces (namespace_regex)
+	regex *regexp.Regexp
+	// tenant is the only namespace of a tenant report, which leaves out
+	// cluster-wide data such as nodes; see withTenantNamespace
+	tenant string
+}
+
+// withRequestedNamespaces returns ctx marked with the namespaces a report
+// should cover: the excluded_namespaces too when the include_excluded query
+// parameter is true, and only those matching namespace_regex when it is set.
+// An invalid namespace_regex is an error. A tenant report keeps its
+// namespace and ignores both parameters.
+func withRequestedNamespaces(ctx context.Context, query url.Values) (context.Context, error) {
+	if requestedNamespaceScope(ctx).tenant != "" {
+		return ctx, nil
+	}
+	regex, err := config.CompileNamespaceRegex(query.Get("namespace_regex"))
+	if err != nil {
+		return ctx, err
+	}
+	scope := reportNamespaceScope{
+		includeExcluded: query.Get("include_excluded") == "true",
+		regex:           regex,
+	}
+	if scope == (reportNamespaceScope{}) {
+		return ctx, nil
+	}
+	return context.WithValue(ctx, reportNamespacesKey{}, scope), nil
+}
+
+// withTenantNamespace returns ctx marked for a tenant report of ns alone,
+// even when ns is in excluded_namespaces
+func withTenantNamespace(ctx context.Context, ns string) context.Context {
+	return context.WithValue(ctx, reportNamespacesKey{}, reportNamespaceScope{
+		includeExcluded: true,
+		regex:           regexp.MustCompile("^" + regexp.QuoteMeta(ns) + "$"),
+		tenant:          ns,
+	})
+}
+
+func requestedNamespaceScope(ctx context.Context) reportNamespaceScope {
+	scope, _ := ctx.Value(reportNamespacesKey{}).(reportNamespaceScope)
+	return scope
+}
+
+// reportNamespaceHidden reports whether a report generated under ctx leaves
+// out ns because it matches excluded_namespaces or does not match the
+// requested namespace_regex
+func (rg *ReportGenerator) reportNamespaceHidden(ctx context.Context, ns string) bool {
+	scope := requestedNamespaceScope(ctx)
+	if scope.regex != nil && ns != "" && !scope.regex.MatchString(ns) {
+		return true
+	}
+	return rg.namespaceExcluded(scope, ns)
+}
+
+func (rg *ReportGenerator) namespaceExcluded(scope reportNamespaceScope, ns string) bool {
+	if scope.includeExcluded {
+		return false
+	}
+	return rg.server != nil && rg.server.cfg.NamespaceExcluded(ns)
+}
+
+// hideExcludedNamespaces returns the namespaces a report covers and how many
+// of them excluded_namespaces left out. Namespaces not matching the
+// namespace_regex are dropped without being counted.
+func (rg *ReportGenerator) hideExcludedNamespaces(ctx context.Context, namespaces []corev1.Namespace) ([]corev1.Namespace, int) {
+	scope := requestedNamespaceScope(ctx)
+	visible := make([]corev1.Namespace, 0, len(namespaces))
+	hidden := 0
+	for _, ns := range namespaces {
+		if scope.regex != nil && !scope.regex.MatchString(ns.Name) {
+			continue
+		}
+		if rg.namespaceExcluded(scope, ns.Name) {
+			hidden++
+			continue
+		}
+		visible = append(visible, ns)
+	}
+	return visible, hidden
+}
@@ -0,0 +1,145 @@
+package web
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// NetworkCoverage statuses
+const (
+	networkCoverageCovered  = "Covered"
+	networkCoveragePartial  = "Partial"
+	networkCoverageNoPolicy = "NoPolicy"
+)
+
+// buildIngressInfos lists every host and path of the Ingresses, sorted by
+// namespace and name
+func buildIngressInfos(resources []namespaceResources) []IngressInfo {
+	var infos []IngressInfo
+	for _, res := range resources {
+		for i := range res.ingresses {
+			ing := &res.ingresses[i]
+			class := ""
+			if ing.Spec.IngressClassName != nil {
+				class = *ing.Spec.IngressClassName
+			}
+			for _, route := range k8s.IngressRoutes(ing) {
+				infos = append(infos, IngressInfo{
+					Namespace: ing.Namespace,
+					Name:      ing.Name,
+					Class:     class,
+					Host:      route.Host,
+					Path:      route.Path,
+					Backend:   route.Backend,
+					TLS:       route.TLS,
+				})
+			}
+		}
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		if infos[i].Namespace != infos[j].Namespace {
+			return infos[i].Namespace < infos[j].Namespace
+		}
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// buildNetworkPolicyInfos lists the NetworkPolicies with the number of pods
+// each selects, and the policy coverage of every namespace running pods
+func buildNetworkPolicyInfos(resources []namespaceResources) ([]NetworkPolicyInfo, []NetworkCoverage) {
+	var (
+		policies []NetworkPolicyInfo
+		coverage []NetworkCoverage
+	)
+	for _, res := range resources {
+		pods := k8s.NetworkPolicyCoverage(res.pods, res.netpols)
+		if len(pods) == 0 && len(res.netpols) == 0 {
+			continue
+		}
+
+		selected := make(map[string]int)
+		cov := NetworkCoverage{Policies: len(res.netpols), Pods: len(pods)}
+		for _, p := range pods {
+			cov.Namespace = p.Namespace
+			for _, name := range p.Policies {
+				selected[name]++
+			}
+			if p.Covered() {
+				cov.CoveredPods++
+			} else {
+				cov.UncoveredPods = append(cov.UncoveredPods, p.Pod)
+			}
+		}
+
+		for i := range res.netpols {
+			np := &res.netpols[i]
+			cov.Namespace = np.Namespace
+			policies = append(policies, NetworkPolicyInfo{
+				Namespace:   np.Namespace,
+				Name:        np.Name,
+				PodSelector: k8s.PolicyPodSelector(np),
+				PolicyTypes: networkPolicyTypes(np),
+				Pods:        selected[np.Name],
+			})
+		}
+
+		if cov.Pods == 0 {
+			continue
+		}
+		switch {
+		case cov.Policies == 0:
+			cov.Status = networkCoverageNoPolicy
+		case cov.CoveredPods < cov.Pods:
+			cov.Status = networkCoveragePartial
+		default:
+			cov.Status = networkCoverageCovered
+		}
+		coverage = append(coverage, cov)
+	}
+	return policies, coverage
+}
+
+// networkPolicyTypes returns the directions np restricts. Policies without
+// policyTypes restrict Ingress, and Egress too when they have egress rules.
+func networkPolicyTypes(np *networkingv1.NetworkPolicy) []string {
+	if len(np.Spec.PolicyTypes) == 0 {
+		types := []string{string(networkingv1.PolicyTypeIngress)}
+		if len(np.Spec.Egress) > 0 {
+			types = append(types, string(networkingv1.PolicyTypeEgress))
+		}
+		return types
+	}
+	types := make([]string, len(np.Spec.PolicyTypes))
+	for i, t := range np.Spec.PolicyTypes {
+		types[i] = string(t)
+	}
+	return types
+}
+
+// networkCoverageClass maps a coverage status to the report's status CSS
+// classes
+func networkCoverageClass(status string) string {
+	switch status {
+	case networkCoverageNoPolicy:
+		return "status-fail"
+	case networkCoveragePartial:
+		return "status-warn"
+	}
+	return "status-pass"
+}
+
+// uncoveredPodsText lists up to limit uncovered pods for the report tables
+func uncoveredPodsText(pods []string, limit int) string {
+	if len(pods) == 0 {
+		return "-"
+	}
+	if len(pods) <= limit {
+		return strings.Join(pods, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(pods[:limit], ", "), len(pods)-limit)
+}
@@ -0,0 +1,117 @@
+package web
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// HTML display caps that apply when no config is loaded
+const (
+	defaultHTMLPodLimit   = 50
+	defaultHTMLImageLimit = 25
+	defaultHTMLEventLimit = 25
+)
+
+// ReportPageOptions selects one page of a report's per-object lists
+type ReportPageOptions struct {
+	Offset int
+	Limit  int // 0 returns every item after Offset
+}
+
+// ReportPagination describes the page a JSON or CSV report holds. Pods,
+// deployments, services, and images are paged together with the same
+// offset and limit; summaries and counts always cover the whole cluster.
+type ReportPagination struct {
+	Offset           int  `json:"offset"`
+	Limit            int  `json:"limit"`
+	TotalPods        int  `json:"total_pods"`
+	TotalDeployments int  `json:"total_deployments"`
+	TotalServices    int  `json:"total_services"`
+	TotalImages      int  `json:"total_images"`
+	HasMore          bool `json:"has_more"`
+	NextOffset       int  `json:"next_offset,omitempty"`
+}
+
+// reportHTMLLimits holds the HTML report's display caps; 0 shows every item
+type reportHTMLLimits struct {
+	Pods   int
+	Images int
+	Events int
+}
+
+// htmlLimits returns the HTML display caps from the reports config
+func (rg *ReportGenerator) htmlLimits() reportHTMLLimits {
+	if rg == nil || rg.server == nil || rg.server.cfg == nil {
+		return reportHTMLLimits{Pods: defaultHTMLPodLimit, Images: defaultHTMLImageLimit, Events: defaultHTMLEventLimit}
+	}
+	cfg := rg.server.cfg.Reports
+	return reportHTMLLimits{
+		Pods:   max(cfg.HTMLPodLimit, 0),
+		Images: max(cfg.HTMLImageLimit, 0),
+		Events: max(cfg.HTMLEventLimit, 0),
+	}
+}
+
+// parseReportPageOptions applies the offset and limit query parameters over
+// the configured page size.
+func (rg *ReportGenerator) parseReportPageOptions(query url.Values) (*ReportPageOptions, error) {
+	opts := ReportPageOptions{}
+	if rg.server != nil && rg.server.cfg != nil {
+		opts.Limit = max(rg.server.cfg.Reports.PageSize, 0)
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid limit %q: must be a non-negative integer (0 returns every item)", v)
+		}
+		opts.Limit = limit
+	}
+	if v := query.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("invalid offset %q: must be a non-negative integer", v)
+		}
+		opts.Offset = offset
+	}
+	return &opts, nil
+}
+
+// paginateReport cuts the report's pods, deployments, services, and images
+// down to one page and records the page in report.Pagination. Without an
+// offset or limit the report is left whole.
+func paginateReport(report *ComprehensiveReport, opts ReportPageOptions) {
+	if opts.Offset == 0 && opts.Limit == 0 {
+		return
+	}
+	page := &ReportPagination{
+		Offset:           opts.Offset,
+		Limit:            opts.Limit,
+		TotalPods:        len(report.Pods),
+		TotalDeployments: len(report.Deployments),
+		TotalServices:    len(report.Services),
+		TotalImages:      len(report.Images),
+	}
+	largest := max(page.TotalPods, page.TotalDeployments, page.TotalServices, page.TotalImages)
+	if opts.Limit > 0 && opts.Offset+opts.Limit < largest {
+		page.HasMore = true
+		page.NextOffset = opts.Offset + opts.Limit
+	}
+
+	report.Pods = pageOf(report.Pods, opts)
+	report.Deployments = pageOf(report.Deployments, opts)
+	report.Services = pageOf(report.Services, opts)
+	report.Images = pageOf(report.Images, opts)
+	report.Pagination = page
+}
+
+func pageOf[T any](items []T, opts ReportPageOptions) []T {
+	if opts.Offset >= len(items) {
+		return []T{}
+	}
+	items = items[opts.Offset:]
+	if opts.Limit > 0 && opts.Limit < len(items) {
+		items = items[:opts.Limit]
+	}
+	return items
+}
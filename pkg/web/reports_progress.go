@@ -0,0 +1,146 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// ReportProgress is one step of report generation, e.g. stage "namespaces"
+// with message "Listing namespace resources (12/80)"
+type ReportProgress struct {
+	Stage   string `json:"stage"`
+	Message string `json:"message"`
+	Done    int    `json:"done,omitempty"`
+	Total   int    `json:"total,omitempty"`
+	Percent int    `json:"percent"`
+}
+
+// ReportProgressFunc receives progress while a report is generated. Calls are
+// serialized.
+type ReportProgressFunc func(ReportProgress)
+
+// reportProgressTracker turns stage starts and per-namespace steps into
+// ReportProgress with an overall percentage. A nil tracker or callback
+// reports nothing.
+type reportProgressTracker struct {
+	fn     ReportProgressFunc
+	stages []string
+	mu     sync.Mutex
+	stage  int
+}
+
+// newReportProgressTracker lists the stages GenerateReport will run for the
+// included sections, so the percentage reaches 100 only at the end
+func newReportProgressTracker(fn ReportProgressFunc, rg *ReportGenerator, included ReportSections) *reportProgressTracker {
+	if fn == nil {
+		return nil
+	}
+	stages := []string{"nodes"}
+	if included.Capacity {
+		stages = append(stages, "capacity")
+	}
+	stages = append(stages, "namespaces", "workloads")
+	if included.Events {
+		stages = append(stages, "events")
+	}
+	if included.FinOps {
+		stages = append(stages, "finops")
+	}
+	if included.Drift {
+		stages = append(stages, "drift")
+	}
+	if included.DeprecatedAPIs {
+		stages = append(stages, "deprecated_apis")
+	}
+	if included.Metrics && rg.server.metricsCollector != nil {
+		stages = append(stages, "metrics")
+	}
+	if included.SecurityBasic && rg.server.securityScanner != nil {
+		stages = append(stages, "security")
+	}
+	return &reportProgressTracker{fn: fn, stages: stages}
+}
+
+// start reports the beginning of stage
+func (t *reportProgressTracker) start(stage, message string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if i := slices.Index(t.stages, stage); i >= 0 {
+		t.stage = i
+	}
+	t.fn(ReportProgress{Stage: stage, Message: message, Percent: t.percent(0, 0)})
+}
+
+// step reports done of total items finished in the current stage
+func (t *reportProgressTracker) step(message string, done, total int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.fn(ReportProgress{
+		Stage:   t.stages[t.stage],
+		Message: fmt.Sprintf("%s (%d/%d)", message, done, total),
+		Done:    done,
+		Total:   total,
+		Percent: t.percent(done, total),
+	})
+}
+
+func (t *reportProgressTracker) percent(done, total int) int {
+	fraction := 0.0
+	if total > 0 {
+		fraction = float64(done) / float64(total)
+	}
+	return int((float64(t.stage) + fraction) * 100 / float64(len(t.stages)))
+}
+
+// wantsReportStream reports whether the client asked for report progress as
+// server-sent events
+func wantsReportStream(r *http.Request) bool {
+	return r.Header.Get("Accept") == "text/event-stream"
+}
+
+// startReportStream switches the response to server-sent events and returns
+// the writer and a progress callback that sends "progress" events
+func startReportStream(w http.ResponseWriter) (*SSEWriter, ReportProgressFunc, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		WriteError(w, NewAPIError(ErrCodeInternalError, "Streaming not supported"))
+		return nil, nil, false
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	sse := &SSEWriter{w: w, flusher: flusher}
+	progress := func(p ReportProgress) {
+		writeReportStreamEvent(sse, "progress", p)
+	}
+	return sse, progress, true
+}
+
+// reportStreamResult is the final "report" event of a streamed report
+type reportStreamResult struct {
+	Format       string `json:"format"`
+	Filename     string `json:"filename,omitempty"`
+	AIAnalysisID string `json:"ai_analysis_id,omitempty"`
+	Content      string `json:"content"`
+}
+
+func writeReportStreamEvent(sse *SSEWriter, event string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	_ = sse.WriteEvent(event, string(data))
+}
+
+func writeReportStreamError(sse *SSEWriter, err error) {
+	writeReportStreamEvent(sse, "error", map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,127 @@
+package web
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// collectQuotas fills the report's ResourceQuota and LimitRange sections and
+// flags namespaces whose quotas are close to exhaustion. Quotas, not node
+// capacity, are usually the effective limit in multi-tenant clusters.
+func (rg *ReportGenerator) collectQuotas(ctx context.Context, report *ComprehensiveReport) {
+	quotas, err := rg.server.k8sClient.ListResourceQuotas(ctx, "")
+	if err != nil {
+		report.addWarning(reportListWarning("resourcequotas", "", err))
+	} else {
+		quotas = slices.DeleteFunc(quotas, func(q corev1.ResourceQuota) bool {
+			return rg.reportNamespaceHidden(ctx, q.Namespace)
+		})
+		report.ResourceQuotas = buildResourceQuotaInfos(quotas)
+	}
+	limitRanges, err := rg.server.k8sClient.ListLimitRanges(ctx, "")
+	if err != nil {
+		report.addWarning(reportListWarning("limitranges", "", err))
+	} else {
+		limitRanges = slices.DeleteFunc(limitRanges, func(lr corev1.LimitRange) bool {
+			return rg.reportNamespaceHidden(ctx, lr.Namespace)
+		})
+		report.LimitRanges = buildLimitRangeInfos(limitRanges)
+	}
+
+	worst := make(map[string]string)
+	for _, q := range report.ResourceQuotas {
+		if quotaStatusRank(q.Status) > quotaStatusRank(worst[q.Namespace]) {
+			worst[q.Namespace] = q.Status
+		}
+	}
+	for i := range report.Namespaces {
+		status, ok := worst[report.Namespaces[i].Name]
+		if !ok {
+			continue
+		}
+		report.Namespaces[i].QuotaStatus = status
+		if status != k8s.QuotaStatusOK {
+			report.NamespaceSummary.NearQuota++
+		}
+	}
+}
+
+func buildResourceQuotaInfos(quotas []corev1.ResourceQuota) []ResourceQuotaInfo {
+	infos := make([]ResourceQuotaInfo, 0, len(quotas))
+	for _, rq := range quotas {
+		usage := k8s.ResourceQuotaUsage(rq)
+		maxPct := k8s.MaxQuotaPercent(usage)
+		infos = append(infos, ResourceQuotaInfo{
+			Namespace:  rq.Namespace,
+			Name:       rq.Name,
+			Usage:      usage,
+			MaxPercent: maxPct,
+			Status:     k8s.QuotaStatus(maxPct),
+		})
+	}
+	// Most constrained quotas first
+	sort.SliceStable(infos, func(i, j int) bool {
+		if infos[i].MaxPercent != infos[j].MaxPercent {
+			return infos[i].MaxPercent > infos[j].MaxPercent
+		}
+		if infos[i].Namespace != infos[j].Namespace {
+			return infos[i].Namespace < infos[j].Namespace
+		}
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+func buildLimitRangeInfos(limitRanges []corev1.LimitRange) []LimitRangeInfo {
+	infos := make([]LimitRangeInfo, 0, len(limitRanges))
+	for _, lr := range limitRanges {
+		infos = append(infos, LimitRangeInfo{
+			Namespace: lr.Namespace,
+			Name:      lr.Name,
+			Limits:    k8s.LimitRangeLimits(lr),
+		})
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		if infos[i].Namespace != infos[j].Namespace {
+			return infos[i].Namespace < infos[j].Namespace
+		}
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+func quotaStatusRank(status string) int {
+	switch status {
+	case k8s.QuotaStatusExceeded:
+		return 3
+	case k8s.QuotaStatusNearLimit:
+		return 2
+	case k8s.QuotaStatusOK:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// quotaStatusClass maps a quota status to the report's status CSS classes.
+func quotaStatusClass(status string) string {
+	switch status {
+	case k8s.QuotaStatusExceeded:
+		return "status-fail"
+	case k8s.QuotaStatusNearLimit:
+		return "status-warn"
+	default:
+		return "status-pass"
+	}
+}
+
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
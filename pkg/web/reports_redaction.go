@@ -0,0 +1,302 @@
+package web
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Report redaction categories, named in reports.redact and the redact query
+// parameter
+const (
+	redactIPs        = "ips"
+	redactNodes      = "nodes"
+	redactNamespaces = "namespaces"
+)
+
+var reportRedactionNames = []string{redactIPs, redactNodes, redactNamespaces}
+
+// ReportRedaction selects the identifiers a report replaces with pseudonyms
+type ReportRedaction struct {
+	IPs        bool
+	Nodes      bool
+	Namespaces bool
+}
+
+// Any reports whether anything is redacted
+func (r ReportRedaction) Any() bool {
+	return r.IPs || r.Nodes || r.Namespaces
+}
+
+// parseReportRedaction parses a comma-separated list of redaction
+// categories. "all" selects every category and "none" (or "") none.
+func parseReportRedaction(value string) (ReportRedaction, error) {
+	var r ReportRedaction
+	for _, name := range strings.Split(value, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "", "none":
+		case "all":
+			r = ReportRedaction{IPs: true, Nodes: true, Namespaces: true}
+		case redactIPs:
+			r.IPs = true
+		case redactNodes:
+			r.Nodes = true
+		case redactNamespaces:
+			r.Namespaces = true
+		default:
+			return r, fmt.Errorf("unknown redaction %q (valid: %s, all, none)", name, strings.Join(reportRedactionNames, ", "))
+		}
+	}
+	return r, nil
+}
+
+// requestedRedaction returns the redaction named by the redact query
+// parameter, or reports.redact when the request has none
+func (rg *ReportGenerator) requestedRedaction(query url.Values) (ReportRedaction, error) {
+	if query.Has("redact") {
+		return parseReportRedaction(query.Get("redact"))
+	}
+	if rg == nil || rg.server == nil || rg.server.cfg == nil {
+		return ReportRedaction{}, nil
+	}
+	r, err := parseReportRedaction(strings.Join(rg.server.cfg.Reports.Redact, ","))
+	if err != nil {
+		return ReportRedaction{}, fmt.Errorf("reports.redact: %w", err)
+	}
+	return r, nil
+}
+
+func newRedactionKey() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
+
+// redactReport replaces the selected identifiers everywhere in report, a
+// pointer to a report struct, with pseudonyms such as node-1a2b3c4d. The
+// same name always gets the same pseudonym under one key, so readers can
+// still correlate a node or namespace across sections, and lists, counts,
+// and free text such as event messages keep their shape.
+func (rg *ReportGenerator) redactReport(report any, opts ReportRedaction) {
+	if !opts.Any() {
+		return
+	}
+	key := rg.redactionKey
+	if rg.server != nil && rg.server.cfg != nil && rg.server.cfg.Reports.RedactionKey != "" {
+		key = []byte(rg.server.cfg.Reports.RedactionKey)
+	}
+	r := &reportRedactor{opts: opts, key: key, names: map[string]string{}}
+	v := reflect.ValueOf(report)
+	r.collect(v)
+	r.rewrite(v)
+}
+
+// reportRedactor maps the identifiers found in a report to pseudonyms
+type reportRedactor struct {
+	opts  ReportRedaction
+	key   []byte
+	names map[string]string // identifier -> pseudonym
+}
+
+// Report types whose Name field is a node or namespace name
+var (
+	nodeNameTypes = []reflect.Type{
+		reflect.TypeFor[NodeInfo](),
+		reflect.TypeFor[NodeCapacityInfo](),
+	}
+	namespaceNameTypes = []reflect.Type{
+		reflect.TypeFor[NamespaceInfo](),
+	}
+)
+
+// collect records the node names, namespaces, and IPs held in identifier
+// fields, so they can be found again in free text
+func (r *reportRedactor) collect(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			r.collect(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			r.collect(v.Index(i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			r.collect(iter.Value())
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get("json") == "-" {
+				continue
+			}
+			fv := v.Field(i)
+			if fv.Kind() != reflect.String {
+				r.collect(fv)
+				continue
+			}
+			value := fv.String()
+			switch {
+			case field.Name == "Name" && slices.Contains(nodeNameTypes, t):
+				r.add(redactNodes, value)
+			case field.Name == "Name" && slices.Contains(namespaceNameTypes, t):
+				r.add(redactNamespaces, value)
+			case field.Name == "Node" || field.Name == "NodeName":
+				r.add(redactNodes, value)
+			case strings.HasSuffix(field.Name, "Namespace"):
+				r.add(redactNamespaces, value)
+			case strings.HasSuffix(field.Name, "IP"):
+				for _, ip := range strings.Split(value, ",") {
+					r.add(redactIPs, strings.TrimSpace(ip))
+				}
+			case field.Name == "Object":
+				// Event objects read Kind/name
+				if kind, name, ok := strings.Cut(value, "/"); ok {
+					switch kind {
+					case "Node":
+						r.add(redactNodes, name)
+					case "Namespace":
+						r.add(redactNamespaces, name)
+					}
+				}
+			}
+		}
+	}
+}
+
+// add records value as an identifier of the category when it is redacted
+func (r *reportRedactor) add(category, value string) {
+	if value == "" || value == "-" || value == "<none>" || r.names[value] != "" {
+		return
+	}
+	switch category {
+	case redactNodes:
+		if r.opts.Nodes {
+			r.names[value] = r.pseudonym("node", value)
+		}
+	case redactNamespaces:
+		if r.opts.Namespaces {
+			r.names[value] = r.pseudonym("ns", value)
+		}
+	case redactIPs:
+		if r.opts.IPs && net.ParseIP(value) != nil {
+			r.names[value] = r.pseudonym("ip", value)
+		}
+	}
+}
+
+func (r *reportRedactor) pseudonym(prefix, value string) string {
+	mac := hmac.New(sha256.New, r.key)
+	mac.Write([]byte(prefix + ":" + value))
+	return prefix + "-" + hex.EncodeToString(mac.Sum(nil))[:8]
+}
+
+// rewrite replaces identifiers in every exported string, map key included
+func (r *reportRedactor) rewrite(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			r.rewrite(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			r.rewrite(v.Index(i))
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return
+		}
+		rewritten := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := reflect.New(v.Type().Key()).Elem()
+			key.Set(iter.Key())
+			r.rewrite(key)
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(iter.Value())
+			r.rewrite(value)
+			rewritten.SetMapIndex(key, value)
+		}
+		v.Set(rewritten)
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			if t.Field(i).IsExported() && t.Field(i).Tag.Get("json") != "-" {
+				r.rewrite(v.Field(i))
+			}
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(r.redact(v.String()))
+		}
+	}
+}
+
+// isIdentRune reports whether c can be part of a node name, namespace, or
+// IPv4 address
+func isIdentRune(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '-' || c == '.' || c == '_'
+}
+
+// redact replaces whole identifiers in s. Matching whole tokens keeps a
+// namespace named "app" from changing words such as "application".
+func (r *reportRedactor) redact(s string) string {
+	if s == "" {
+		return s
+	}
+	if p, ok := r.names[s]; ok {
+		return p
+	}
+	// IPv6 addresses contain ':', which ends a token, so replace them first
+	for name, p := range r.names {
+		if strings.Contains(name, ":") {
+			s = strings.ReplaceAll(s, name, p)
+		}
+	}
+
+	var sb strings.Builder
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		if !isIdentRune(runes[i]) {
+			sb.WriteRune(runes[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && isIdentRune(runes[j]) {
+			j++
+		}
+		sb.WriteString(r.redactToken(string(runes[i:j])))
+		i = j
+	}
+	return sb.String()
+}
+
+// redactToken returns the pseudonym for a token, allowing for trailing
+// punctuation as at the end of a sentence. Unknown IPv4 addresses in free
+// text are redacted too.
+func (r *reportRedactor) redactToken(token string) string {
+	trimmed := strings.TrimRight(token, ".-_")
+	suffix := token[len(trimmed):]
+	if p, ok := r.names[trimmed]; ok {
+		return p + suffix
+	}
+	if r.opts.IPs {
+		if ip := net.ParseIP(trimmed); ip != nil && ip.To4() != nil {
+			r.names[trimmed] = r.pseudonym("ip", trimmed)
+			return r.names[trimmed] + suffix
+		}
+	}
+	return token
+}
@@ -0,0 +1,81 @@
+package web
+
+import (
+	"sort"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// managedSecretTypes are secret types that controllers create and read
+// themselves, so no pod spec refers to them
+var managedSecretTypes = map[corev1.SecretType]bool{
+	corev1.SecretTypeServiceAccountToken: true,
+	corev1.SecretTypeBootstrapToken:      true,
+	"helm.sh/release.v1":                 true,
+}
+
+// buildUnreferencedSecrets lists the secrets that no pod, Deployment,
+// StatefulSet, or CronJob template, Ingress TLS section, or service account
+// image pull secret in their namespace refers to, sorted by namespace and
+// name. DaemonSets and Jobs are covered by their pods. Namespaces with a
+// failed list are skipped, since a forbidden list would make every secret
+// look unused.
+func buildUnreferencedSecrets(resources []namespaceResources) []UnreferencedSecretInfo {
+	var infos []UnreferencedSecretInfo
+	for _, res := range resources {
+		if len(res.secrets) == 0 || len(res.warnings) > 0 {
+			continue
+		}
+
+		used := map[string]bool{}
+		addSpec := func(spec *corev1.PodSpec) {
+			for _, ref := range k8s.PodSpecConfigRefs(spec) {
+				if ref.Kind == k8s.ConfigRefSecret {
+					used[ref.Name] = true
+				}
+			}
+		}
+		for i := range res.pods {
+			addSpec(&res.pods[i].Spec)
+		}
+		for i := range res.deploys {
+			addSpec(&res.deploys[i].Spec.Template.Spec)
+		}
+		for i := range res.statefulSets {
+			addSpec(&res.statefulSets[i].Spec.Template.Spec)
+		}
+		for i := range res.cronJobs {
+			addSpec(&res.cronJobs[i].Spec.JobTemplate.Spec.Template.Spec)
+		}
+		for _, ing := range res.ingresses {
+			for _, tls := range ing.Spec.TLS {
+				used[tls.SecretName] = true
+			}
+		}
+		for _, sa := range res.serviceAccounts {
+			for _, ref := range sa.ImagePullSecrets {
+				used[ref.Name] = true
+			}
+		}
+
+		for _, sec := range res.secrets {
+			if used[sec.Name] || managedSecretTypes[sec.Type] {
+				continue
+			}
+			infos = append(infos, UnreferencedSecretInfo{
+				Namespace: sec.Namespace,
+				Name:      sec.Name,
+				Type:      string(sec.Type),
+				Age:       k8s.FormatAgeSince(sec.CreationTimestamp.Time),
+			})
+		}
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		if infos[i].Namespace != infos[j].Namespace {
+			return infos[i].Namespace < infos[j].Namespace
+		}
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
@@ -1,18 +1,38 @@
+//go:build !nosecurity
+
 package web
 
 import (
 	"context"
+	"slices"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/security"
 )
 
+// scheduledSecurityScan returns the scheduled scan's result while it is newer
+// than the schedule interval, or nil when the report must scan itself. A full
+// report only reuses a full scan, and a tenant report scans its namespace.
+func (rg *ReportGenerator) scheduledSecurityScan(ctx context.Context, full bool) *security.ScanResult {
+	scheduler := rg.server.securityScheduler
+	if scheduler == nil || requestedNamespaceScope(ctx).tenant != "" {
+		return nil
+	}
+	return scheduler.Latest(scheduler.Interval(), full)
+}
+
 func (rg *ReportGenerator) generateSecurityScan(ctx context.Context) *SecurityScanReport {
 	if rg.server.securityScanner == nil {
 		return nil
 	}
 
-	// Run a quick scan (without image scanning for speed)
-	scanResult, err := rg.server.securityScanner.QuickScan(ctx, "")
-	if err != nil {
-		return nil
+	// Reuse a recent scheduled scan, or run a quick scan (without image
+	// scanning for speed)
+	scanResult := rg.scheduledSecurityScan(ctx, false)
+	if scanResult == nil {
+		var err error
+		if scanResult, err = rg.server.securityScanner.QuickScan(ctx, requestedNamespaceScope(ctx).tenant); err != nil {
+			return nil
+		}
 	}
 
 	report := &SecurityScanReport{
@@ -121,11 +141,15 @@ func (rg *ReportGenerator) generateFullSecurityScan(ctx context.Context) *Securi
 		return nil
 	}
 
-	// Run full scan (includes Trivy image vulnerability scanning)
-	scanResult, err := rg.server.securityScanner.Scan(ctx, "")
-	if err != nil {
-		// Fall back to quick scan
-		return rg.generateSecurityScan(ctx)
+	// Reuse a recent scheduled full scan, or run a full scan (includes Trivy
+	// image vulnerability scanning)
+	scanResult := rg.scheduledSecurityScan(ctx, true)
+	if scanResult == nil {
+		var err error
+		if scanResult, err = rg.server.securityScanner.Scan(ctx, requestedNamespaceScope(ctx).tenant); err != nil {
+			// Fall back to quick scan
+			return rg.generateSecurityScan(ctx)
+		}
 	}
 
 	report := &SecurityScanReport{
@@ -203,4 +227,20 @@ func (rg *ReportGenerator) generateFullSecurityScan(ctx context.Context) *Securi
 	return report
 }
 
-// generateFinOpsAnalysis analyzes cost and resource efficiency
+// scopeSecurityScan keeps the findings of a tenant report's namespace,
+// dropping cluster-scoped RBAC issues and the node CIS benchmark
+func scopeSecurityScan(scan *SecurityScanReport, ns string) {
+	scan.CISBenchmark = nil
+	scan.PodSecurityIssues = slices.DeleteFunc(scan.PodSecurityIssues, func(i PodSecurityIssueReport) bool {
+		return i.Namespace != ns
+	})
+	scan.RBACIssues = slices.DeleteFunc(scan.RBACIssues, func(i RBACIssueReport) bool {
+		return i.Namespace != ns
+	})
+	scan.NetworkIssues = slices.DeleteFunc(scan.NetworkIssues, func(i NetworkIssueReport) bool {
+		return i.Namespace != ns
+	})
+	scan.Recommendations = slices.DeleteFunc(scan.Recommendations, func(r SecurityRecommendationReport) bool {
+		return r.Category == "CIS Benchmark" || (r.Category == "RBAC" && len(scan.RBACIssues) == 0)
+	})
+}
@@ -0,0 +1,71 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+// NewStandaloneReportGenerator returns a report generator that runs without
+// the web server, e.g. for the MCP server's generate_report tool. Reports
+// follow the reports config but have no metrics history or AI analysis,
+// which depend on the web server's collectors and AI client.
+func NewStandaloneReportGenerator(cfg *config.Config, client *k8s.Client) *ReportGenerator {
+	server := &Server{cfg: cfg, k8sClient: client}
+	if cfg.SecurityEnabled() {
+		server.securityScanner = newClusterScanner(client)
+	}
+	return NewReportGenerator(server)
+}
+
+// RenderReport generates a report of the comma-separated sections, or of
+// reports.default_sections when sections is empty, and renders it as json
+// (the default), csv, or html. Unlike the sections query parameter, unknown
+// section names are an error. reports.redact applies as it does to
+// /api/reports.
+func (rg *ReportGenerator) RenderReport(ctx context.Context, username, sections, format string) (string, error) {
+	for _, name := range strings.Split(sections, ",") {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(reportSectionNames, name) {
+			return "", fmt.Errorf("unknown report section %q (valid: %s)", name, strings.Join(reportSectionNames, ", "))
+		}
+	}
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" && format != "html" {
+		return "", fmt.Errorf("unsupported format %q (use json, csv, or html)", format)
+	}
+
+	query := url.Values{"sections": {sections}}
+	eventOpts, err := rg.parseReportEventOptions(query)
+	if err != nil {
+		return "", err
+	}
+	redaction, err := rg.requestedRedaction(query)
+	if err != nil {
+		return "", err
+	}
+
+	report, err := rg.GenerateReport(ctx, username, rg.requestedSections(query), eventOpts)
+	if err != nil {
+		return "", err
+	}
+	rg.redactReport(report, redaction)
+
+	switch format {
+	case "csv":
+		data, err := rg.ExportToCSV(report)
+		return string(data), err
+	case "html":
+		return rg.ExportToHTML(report), nil
+	default:
+		data, err := json.MarshalIndent(report, "", "  ")
+		return string(data), err
+	}
+}
@@ -0,0 +1,43 @@
+package web
+
+import (
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// buildVolumeClaimInfos lists the claims that are Pending or Lost, and the
+// bound claims no pod in their namespace uses. Orphaned claims keep their
+// PersistentVolume, and usually its cost, until they are deleted.
+func buildVolumeClaimInfos(resources []namespaceResources) []VolumeClaimInfo {
+	var (
+		pvcs []corev1.PersistentVolumeClaim
+		pods []corev1.Pod
+	)
+	for _, res := range resources {
+		pvcs = append(pvcs, res.pvcs...)
+		pods = append(pods, res.pods...)
+	}
+
+	issues := k8s.VolumeClaimIssues(pvcs, pods)
+	infos := make([]VolumeClaimInfo, 0, len(issues))
+	for _, issue := range issues {
+		infos = append(infos, VolumeClaimInfo{
+			Namespace:    issue.Namespace,
+			Name:         issue.Claim,
+			Status:       issue.Status,
+			Issue:        issue.Issue,
+			Capacity:     issue.Capacity,
+			StorageClass: issue.StorageClass,
+			Volume:       issue.PersistentVolume,
+		})
+	}
+	return infos
+}
+
+// volumeClaimIssueClass maps a claim issue to the report's status CSS classes
+func volumeClaimIssueClass(issue string) string {
+	if issue == string(corev1.ClaimLost) {
+		return "status-fail"
+	}
+	return "status-warn"
+}
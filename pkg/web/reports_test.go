@@ -2,16 +2,37 @@ package web
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/ai"
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/cloudbro-kube-ai/k13d/tests/mocks/llmhttp"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiversion "k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestCalculateHealthScore(t *testing.T) {
@@ -289,9 +310,9 @@ func TestParseSections(t *testing.T) {
 		},
 		{
 			name:  "all sections combined",
-			input: "nodes,namespaces,workloads,events,security_full,finops,metrics",
+			input: "nodes,namespaces,workloads,events,security_full,finops,metrics,capacity,drift",
 			checkFn: func(s *ReportSections) bool {
-				return s.Nodes && s.Namespaces && s.Workloads && s.Events && s.SecurityBasic && s.SecurityFull && s.FinOps && s.Metrics
+				return s.Nodes && s.Namespaces && s.Workloads && s.Events && s.SecurityBasic && s.SecurityFull && s.FinOps && s.Metrics && s.Capacity && s.Drift
 			},
 			checkMsg: "all sections should be true",
 		},
@@ -324,12 +345,46 @@ func TestParseSections(t *testing.T) {
 	}
 }
 
+func TestRequestedSections(t *testing.T) {
+	// Without config an absent sections parameter means every section
+	if got := NewReportGenerator(nil).requestedSections(url.Values{}); got != nil {
+		t.Fatalf("requestedSections() without config = %+v, want nil", got)
+	}
+
+	cfg := &config.Config{Reports: config.ReportsConfig{
+		DefaultSections: []string{"nodes", " namespaces", "workloads", "trivy"},
+	}}
+	rg := NewReportGenerator(&Server{cfg: cfg})
+
+	got := rg.requestedSections(url.Values{})
+	if got == nil {
+		t.Fatal("requestedSections() = nil, want the configured default")
+	}
+	want := ReportSections{Nodes: true, Namespaces: true, Workloads: true}
+	if *got != want {
+		t.Errorf("requestedSections() = %+v, want %+v", *got, want)
+	}
+
+	// An explicit sections parameter overrides the default
+	got = rg.requestedSections(url.Values{"sections": {"security_full,metrics"}})
+	want = ReportSections{SecurityBasic: true, SecurityFull: true, Metrics: true}
+	if got == nil || *got != want {
+		t.Errorf("requestedSections(sections=security_full,metrics) = %+v, want %+v", got, want)
+	}
+
+	// A default with no valid names falls back to every section
+	cfg.Reports.DefaultSections = []string{"bogus"}
+	if got := rg.requestedSections(url.Values{}); got != nil {
+		t.Errorf("requestedSections() with only unknown defaults = %+v, want nil", got)
+	}
+}
+
 func TestAllSections(t *testing.T) {
 	s := AllSections()
 	if s == nil {
 		t.Fatal("AllSections() returned nil")
 	}
-	if !s.Nodes || !s.Namespaces || !s.Workloads || !s.Events || !s.SecurityBasic || !s.FinOps || !s.Metrics {
+	if !s.Nodes || !s.Namespaces || !s.Workloads || !s.Events || !s.SecurityBasic || !s.FinOps || !s.Metrics || !s.Capacity || !s.Drift {
 		t.Errorf("AllSections() should have all standard sections enabled, got %+v", s)
 	}
 	// SecurityFull should NOT be enabled by default (it's slow with Trivy)
@@ -412,7 +467,8 @@ func TestGenerateReport_AddsNodeWarningsAndFinOpsMetadata(t *testing.T) {
 		&corev1.Pod{
 			ObjectMeta: metav1.ObjectMeta{Name: "api-0", Namespace: "default"},
 			Spec: corev1.PodSpec{
-				NodeName: "node-a",
+				NodeName:          "node-a",
+				PriorityClassName: "high-priority",
 				Containers: []corev1.Container{
 					{
 						Name:  "api",
@@ -481,7 +537,7 @@ func TestGenerateReport_AddsNodeWarningsAndFinOpsMetadata(t *testing.T) {
 	}
 	rg := NewReportGenerator(server)
 
-	report, err := rg.GenerateReport(context.Background(), "tester", &ReportSections{Nodes: true, FinOps: true})
+	report, err := rg.GenerateReport(context.Background(), "tester", &ReportSections{Nodes: true, FinOps: true}, nil)
 	if err != nil {
 		t.Fatalf("GenerateReport() error = %v", err)
 	}
@@ -525,6 +581,604 @@ func TestGenerateReport_AddsNodeWarningsAndFinOpsMetadata(t *testing.T) {
 	if report.FinOpsAnalysis.ResourceEfficiency.PodsWithoutRequests != 1 {
 		t.Fatalf("pods without requests = %d, want 1", report.FinOpsAnalysis.ResourceEfficiency.PodsWithoutRequests)
 	}
+	podsByName := make(map[string]PodInfo, len(report.Pods))
+	for _, pod := range report.Pods {
+		podsByName[pod.Name] = pod
+	}
+	if api := podsByName["api-0"]; api.QoSClass != "Burstable" || api.PriorityClass != "high-priority" {
+		t.Fatalf("api-0 QoS/priority = %q/%q, want Burstable/high-priority", api.QoSClass, api.PriorityClass)
+	}
+	if worker := podsByName["worker-0"]; worker.QoSClass != "BestEffort" || worker.PriorityClass != "" {
+		t.Fatalf("worker-0 QoS/priority = %q/%q, want BestEffort/\"\"", worker.QoSClass, worker.PriorityClass)
+	}
+
+	report.IncludedSections.Workloads = true
+	csvBytes, err := rg.ExportToCSV(report)
+	if err != nil {
+		t.Fatalf("ExportToCSV() error = %v", err)
+	}
+	if !strings.Contains(string(csvBytes), "api-0,default,Running,1/1,0,Burstable,high-priority,node-a") {
+		t.Fatalf("CSV pods section is missing QoS and priority class:\n%s", csvBytes)
+	}
+	if html := rg.ExportToHTML(report); !strings.Contains(html, "<td>Burstable</td><td>high-priority</td>") {
+		t.Fatal("expected QoS and priority class columns in HTML pods table")
+	}
+}
+
+func TestGenerateReport_NodeCapacity(t *testing.T) {
+	node := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			}},
+		}
+	}
+	fakeClientset := fake.NewClientset( //nolint:staticcheck
+		node("busy"),
+		node("idle"),
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "batch-0", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName: "busy",
+				Containers: []corev1.Container{{
+					Name: "batch",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+						Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	)
+	rg := NewReportGenerator(&Server{k8sClient: &k8s.Client{Clientset: fakeClientset}})
+
+	report, err := rg.GenerateReport(context.Background(), "tester", ParseSections("capacity"), nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if len(report.NodeCapacity) != 2 {
+		t.Fatalf("len(report.NodeCapacity) = %d, want 2", len(report.NodeCapacity))
+	}
+	busy := report.NodeCapacity[0]
+	if busy.Name != "busy" || busy.Status != k8s.NodeCapacityOverCommitted || busy.CPURequested != "1500m" ||
+		busy.CPURequestedPercent != 75 || busy.CPULimitsPercent != 150 || busy.MemoryRequested != "1Gi" || busy.CPUUsed != "" {
+		t.Errorf("busy = %+v, want over-committed at 75%% CPU requested and 150%% limits", busy)
+	}
+	if idle := report.NodeCapacity[1]; idle.Status != k8s.NodeCapacityUnderUtilized || idle.Pods != 0 {
+		t.Errorf("idle = %+v, want under-utilized with no pods", idle)
+	}
+	if report.NodeSummary.OverCommitted != 1 || report.NodeSummary.UnderUtilized != 1 {
+		t.Errorf("NodeSummary = %+v, want one over-committed and one under-utilized node", report.NodeSummary)
+	}
+
+	csvBytes, err := rg.ExportToCSV(report)
+	if err != nil {
+		t.Fatalf("ExportToCSV() error = %v", err)
+	}
+	if !strings.Contains(string(csvBytes), "busy,2,1500m (75%),3 (150%),-,4Gi,1Gi (25%),2Gi (50%),-,1/110,OverCommitted,CPU limits at 150% of allocatable") {
+		t.Errorf("CSV is missing the node capacity row:\n%s", csvBytes)
+	}
+	html := rg.ExportToHTML(report)
+	if !strings.Contains(html, `5.4</span> Node Capacity`) || !strings.Contains(html, `<td class="status-fail">OverCommitted</td>`) {
+		t.Error("expected the node capacity table in the HTML report")
+	}
+	if strings.Contains(html, `5.1</span> Nodes`) {
+		t.Error("nodes table should stay out of a capacity-only report")
+	}
+}
+
+func TestGenerateReport_StuckTerminatingPods(t *testing.T) {
+	deleted := func(name string, at time.Time) *corev1.Pod {
+		ts := metav1.NewTime(at)
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", DeletionTimestamp: &ts},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	fakeClientset := fake.NewClientset( //nolint:staticcheck
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		deleted("zombie", time.Now().Add(-2*time.Hour)),
+		deleted("draining", time.Now().Add(time.Minute)),
+	)
+	rg := NewReportGenerator(&Server{k8sClient: &k8s.Client{Clientset: fakeClientset}})
+
+	report, err := rg.GenerateReport(context.Background(), "tester", ParseSections("workloads"), nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if report.Workloads.StuckTerminatingPods != 1 {
+		t.Errorf("StuckTerminatingPods = %d, want 1", report.Workloads.StuckTerminatingPods)
+	}
+	status := map[string]string{}
+	for _, pod := range report.Pods {
+		status[pod.Name] = pod.Status
+	}
+	if status["zombie"] != "Terminating (stuck 2h)" || status["draining"] != "Terminating" {
+		t.Errorf("pod statuses = %v, want zombie stuck 2h and draining terminating", status)
+	}
+	if html := rg.ExportToHTML(report); !strings.Contains(html, "1 Stuck Terminating") {
+		t.Error("expected the stuck terminating count in the HTML workload summary")
+	}
+}
+
+func TestGenerateReport_InitAndEphemeralContainers(t *testing.T) {
+	privileged, root := true, int64(0)
+	fakeClientset := fake.NewClientset( //nolint:staticcheck
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{
+					Name: "setup", Image: "busybox:1.36",
+					SecurityContext: &corev1.SecurityContext{Privileged: &privileged, RunAsUser: &root},
+				}},
+				Containers: []corev1.Container{
+					{Name: "app", Image: "nginx:1.25"},
+					{Name: "reload", Image: "busybox:1.36"},
+				},
+				EphemeralContainers: []corev1.EphemeralContainer{
+					{EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+						Name: "debugger", Image: "nicolaka/netshoot:v0.13",
+						SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+					}},
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	)
+	rg := NewReportGenerator(&Server{k8sClient: &k8s.Client{Clientset: fakeClientset}})
+
+	report, err := rg.GenerateReport(context.Background(), "tester", ParseSections("workloads"), nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if len(report.Pods) != 1 || strings.Join(report.Pods[0].Images, ",") != "busybox:1.36,nginx:1.25,nicolaka/netshoot:v0.13" {
+		t.Fatalf("pod images = %+v, want init, regular, and ephemeral images once each", report.Pods)
+	}
+	counts := map[string]int{}
+	for _, img := range report.Images {
+		counts[img.Image] = img.PodCount
+	}
+	if len(counts) != 3 || counts["busybox:1.36"] != 1 {
+		t.Errorf("image pod counts = %v, want 3 images used by 1 pod", counts)
+	}
+	if report.SecurityInfo.PrivilegedPods != 1 || report.SecurityInfo.RootContainers != 1 {
+		t.Errorf("privileged pods = %d, root containers = %d, want 1 and 1",
+			report.SecurityInfo.PrivilegedPods, report.SecurityInfo.RootContainers)
+	}
+}
+
+func TestGenerateReport_VolumeClaims(t *testing.T) {
+	claim := func(name string, phase corev1.PersistentVolumeClaimPhase, pv string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: pv},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: phase},
+		}
+	}
+	fakeClientset := fake.NewClientset( //nolint:staticcheck
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "default"},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-db-0"}}}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		claim("data-db-0", corev1.ClaimBound, "pv-1"),
+		claim("old-data", corev1.ClaimBound, "pv-2"),
+		claim("waiting", corev1.ClaimPending, ""),
+	)
+	rg := NewReportGenerator(&Server{k8sClient: &k8s.Client{Clientset: fakeClientset}})
+
+	report, err := rg.GenerateReport(context.Background(), "tester", ParseSections("workloads"), nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if len(report.VolumeClaims) != 2 ||
+		report.VolumeClaims[0].Name != "old-data" || report.VolumeClaims[0].Issue != k8s.ClaimIssueOrphaned || report.VolumeClaims[0].Volume != "pv-2" ||
+		report.VolumeClaims[1].Name != "waiting" || report.VolumeClaims[1].Issue != "Pending" {
+		t.Fatalf("VolumeClaims = %+v, want orphaned old-data and pending waiting", report.VolumeClaims)
+	}
+
+	csvData, err := rg.ExportToCSV(report)
+	if err != nil {
+		t.Fatalf("ExportToCSV() error = %v", err)
+	}
+	if !strings.Contains(string(csvData), "=== VOLUME CLAIMS ===") || !strings.Contains(string(csvData), "default,old-data,Bound,Orphaned") {
+		t.Errorf("CSV export is missing the volume claims section:\n%s", csvData)
+	}
+	if htmlOut := rg.ExportToHTML(report); !strings.Contains(htmlOut, `id="section-6-5"`) || !strings.Contains(htmlOut, "waiting") {
+		t.Error("HTML export is missing the volume claims section")
+	}
+}
+
+func TestExportMetricsAnomalies(t *testing.T) {
+	rg := NewReportGenerator(&Server{})
+	report := &ComprehensiveReport{
+		IncludedSections: *AllSections(),
+		MetricsHistory: &MetricsHistory{
+			Period:         "24h",
+			DataPoints:     1,
+			ClusterMetrics: []ClusterMetricPoint{{Timestamp: "2026-10-01 08:00"}},
+			Anomalies: []MetricsAnomaly{{
+				Kind: "pod_drop", Severity: "critical", At: "2026-10-01 08:20",
+				Message: "Running pods dropped from 100 to 40 (-60%) at 08:20",
+			}},
+		},
+	}
+
+	csvData, err := rg.ExportToCSV(report)
+	if err != nil {
+		t.Fatalf("ExportToCSV() error = %v", err)
+	}
+	if !strings.Contains(string(csvData), "=== METRICS ANOMALIES ===") || !strings.Contains(string(csvData), "critical,pod_drop,Running pods dropped") {
+		t.Errorf("CSV export is missing the anomalies:\n%s", csvData)
+	}
+	if htmlOut := rg.ExportToHTML(report); !strings.Contains(htmlOut, `<td class="status-fail">CRITICAL</td><td>Running pods dropped`) {
+		t.Error("HTML export is missing the anomalies")
+	}
+}
+
+func TestGenerateReport_UnreferencedSecrets(t *testing.T) {
+	secret := func(name string, typ corev1.SecretType) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Type: typ}
+	}
+	fakeClientset := fake.NewClientset( //nolint:staticcheck
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-abc", Namespace: "default"},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "api", EnvFrom: []corev1.EnvFromSource{
+				{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "api-env"}}}}}}},
+		},
+		&corev1.ServiceAccount{
+			ObjectMeta:       metav1.ObjectMeta{Name: "default", Namespace: "default"},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{{SecretName: "web-tls"}}},
+		},
+		secret("api-env", corev1.SecretTypeOpaque),
+		secret("registry", corev1.SecretTypeDockerConfigJson),
+		secret("web-tls", corev1.SecretTypeTLS),
+		secret("sh.helm.release.v1.api.v1", "helm.sh/release.v1"),
+		secret("old-creds", corev1.SecretTypeOpaque),
+	)
+	rg := NewReportGenerator(&Server{k8sClient: &k8s.Client{Clientset: fakeClientset}})
+
+	report, err := rg.GenerateReport(context.Background(), "tester", ParseSections("security"), nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if len(report.UnreferencedSecrets) != 1 || report.UnreferencedSecrets[0].Name != "old-creds" || report.SecurityInfo.UnreferencedSecrets != 1 {
+		t.Fatalf("UnreferencedSecrets = %+v, want only old-creds", report.UnreferencedSecrets)
+	}
+
+	csvData, err := rg.ExportToCSV(report)
+	if err != nil {
+		t.Fatalf("ExportToCSV() error = %v", err)
+	}
+	if !strings.Contains(string(csvData), "=== UNREFERENCED SECRETS ===") || !strings.Contains(string(csvData), "default,old-creds,Opaque") {
+		t.Errorf("CSV export is missing the unreferenced secrets section:\n%s", csvData)
+	}
+	if htmlOut := rg.ExportToHTML(report); !strings.Contains(htmlOut, `id="section-8-1"`) || !strings.Contains(htmlOut, "old-creds") {
+		t.Error("HTML export is missing the unreferenced secrets section")
+	}
+
+	// A namespace whose lists failed is not judged
+	fakeClientset.PrependReactor("list", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "serviceaccounts"}, "", fmt.Errorf("user cannot list"))
+	})
+	report, err = rg.GenerateReport(context.Background(), "tester", ParseSections("security"), nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if len(report.UnreferencedSecrets) != 0 {
+		t.Errorf("UnreferencedSecrets = %+v, want none when a list failed", report.UnreferencedSecrets)
+	}
+}
+
+func TestGenerateReport_NetworkExposure(t *testing.T) {
+	pod := func(ns, name, app string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Labels: map[string]string{"app": app}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	fakeClientset := fake.NewClientset( //nolint:staticcheck
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "open"}},
+		pod("shop", "web-1", "web"),
+		pod("shop", "worker-1", "worker"),
+		pod("open", "api-1", "api"),
+		&networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "allow-web", Namespace: "shop"},
+			Spec:       networkingv1.NetworkPolicySpec{PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+			Spec: networkingv1.IngressSpec{
+				TLS: []networkingv1.IngressTLS{{Hosts: []string{"shop.example.com"}}},
+				Rules: []networkingv1.IngressRule{{Host: "shop.example.com", IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{Path: "/",
+						Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "web", Port: networkingv1.ServiceBackendPort{Number: 80}}}}}},
+				}}},
+			},
+		},
+	)
+	rg := NewReportGenerator(&Server{k8sClient: &k8s.Client{Clientset: fakeClientset}})
+
+	report, err := rg.GenerateReport(context.Background(), "tester", ParseSections("workloads,security"), nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if len(report.Ingresses) != 1 || report.Ingresses[0].Backend != "web:80" || !report.Ingresses[0].TLS {
+		t.Errorf("Ingresses = %+v, want one TLS route to web:80", report.Ingresses)
+	}
+	if len(report.NetworkPolicies) != 1 || report.NetworkPolicies[0].Pods != 1 || report.NetworkPolicies[0].PodSelector != "app=web" {
+		t.Errorf("NetworkPolicies = %+v, want allow-web selecting one pod", report.NetworkPolicies)
+	}
+	coverage := make(map[string]NetworkCoverage)
+	for _, cov := range report.NetworkCoverage {
+		coverage[cov.Namespace] = cov
+	}
+	if cov := coverage["shop"]; cov.Status != networkCoveragePartial || len(cov.UncoveredPods) != 1 || cov.UncoveredPods[0] != "worker-1" {
+		t.Errorf("shop coverage = %+v, want Partial with worker-1 uncovered", cov)
+	}
+	if cov := coverage["open"]; cov.Status != networkCoverageNoPolicy {
+		t.Errorf("open coverage = %+v, want NoPolicy", cov)
+	}
+	if report.SecurityInfo.NamespacesWithoutNetworkPolicy != 1 {
+		t.Errorf("NamespacesWithoutNetworkPolicy = %d, want 1", report.SecurityInfo.NamespacesWithoutNetworkPolicy)
+	}
+
+	csvData, err := rg.ExportToCSV(report)
+	if err != nil {
+		t.Fatalf("ExportToCSV() error = %v", err)
+	}
+	for _, want := range []string{"=== INGRESSES ===", "shop,web,,shop.example.com,/,web:80,true", "=== NETWORK POLICY COVERAGE ===", "open,0,1,0,NoPolicy,api-1", "Namespaces Without NetworkPolicy,1"} {
+		if !strings.Contains(string(csvData), want) {
+			t.Errorf("CSV export is missing %q:\n%s", want, csvData)
+		}
+	}
+	htmlOut := rg.ExportToHTML(report)
+	for _, want := range []string{`id="section-6-6"`, `id="section-6-7"`, "Namespaces Without NetworkPolicy"} {
+		if !strings.Contains(htmlOut, want) {
+			t.Errorf("HTML export is missing %q", want)
+		}
+	}
+}
+
+func TestGenerateReport_DisabledSubsystems(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Disable.Security = true
+	cfg.Disable.FinOps = true
+	fakeClientset := fake.NewClientset() //nolint:staticcheck
+	rg := NewStandaloneReportGenerator(cfg, &k8s.Client{Clientset: fakeClientset})
+	if rg.server.securityScanner != nil {
+		t.Error("the security scanner should not be created when security is disabled")
+	}
+
+	report, err := rg.GenerateReport(context.Background(), "tester", ParseSections("nodes,security_full,finops"), nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if got := report.IncludedSections; !got.Nodes || got.SecurityBasic || got.SecurityFull || got.FinOps {
+		t.Errorf("IncludedSections = %+v, want only nodes", got)
+	}
+
+	rec := httptest.NewRecorder()
+	rg.HandleFinOpsReport(rec, httptest.NewRequest(http.MethodGet, "/api/reports/finops", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("FinOps report status = %d, want 404 when FinOps is disabled", rec.Code)
+	}
+}
+
+func TestGenerateReport_WarnsAboutFailedLists(t *testing.T) {
+	fakeClientset := fake.NewClientset( //nolint:staticcheck
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+	)
+	forbidden := func(resource string) error {
+		return apierrors.NewForbidden(schema.GroupResource{Resource: resource}, "", fmt.Errorf("user cannot list"))
+	}
+	fakeClientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "payments" {
+			return true, nil, forbidden("pods")
+		}
+		return false, nil, nil
+	})
+	fakeClientset.PrependReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, forbidden("nodes")
+	})
+	rg := NewStandaloneReportGenerator(config.NewDefaultConfig(), &k8s.Client{Clientset: fakeClientset})
+
+	report, err := rg.GenerateReport(context.Background(), "tester", ParseSections("nodes,workloads"), nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	want := []string{"could not list nodes: forbidden", "could not list pods in namespace payments: forbidden"}
+	for _, w := range want {
+		if !slices.Contains(report.Warnings, w) {
+			t.Errorf("Warnings = %q, want %q", report.Warnings, w)
+		}
+	}
+	if len(report.Pods) != 1 {
+		t.Errorf("Pods = %+v, want the readable shop pod", report.Pods)
+	}
+
+	page := rg.ExportToHTML(report)
+	if !strings.Contains(page, "This report may be incomplete") || !strings.Contains(page, "payments: forbidden") {
+		t.Error("HTML export should list the warnings")
+	}
+	data, err := rg.ExportToCSV(report)
+	if err != nil || !strings.Contains(string(data), "could not list nodes: forbidden") {
+		t.Errorf("CSV export should list the warnings, got %v", err)
+	}
+}
+
+func TestGenerateFinOpsReport(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			}}},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+	lb := func(name string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		}
+	}
+	fakeClientset := fake.NewClientset( //nolint:staticcheck
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		pod("web-0", corev1.PodRunning),
+		pod("web-1", corev1.PodPending),
+		lb("web"),
+		lb("api"),
+	)
+	rg := NewReportGenerator(&Server{k8sClient: &k8s.Client{Clientset: fakeClientset}})
+
+	report, err := rg.GenerateFinOpsReport(context.Background(), "finance")
+	if err != nil {
+		t.Fatalf("GenerateFinOpsReport() error = %v", err)
+	}
+	if report.GeneratedBy != "finance" || len(report.CostByNamespace) != 1 || report.CostByNamespace[0].RunningPodCount != 1 {
+		t.Fatalf("report = %+v, want one namespace with one running pod", report)
+	}
+	categories := map[string]bool{}
+	for _, opt := range report.CostOptimizations {
+		categories[opt.Category] = true
+	}
+	if !categories["Scheduling"] || !categories["Networking"] {
+		t.Errorf("optimizations = %+v, want the pending pod and LoadBalancer findings", report.CostOptimizations)
+	}
+	for _, action := range fakeClientset.Actions() {
+		if res := action.GetResource().Resource; res == "events" || res == "secrets" || res == "configmaps" {
+			t.Errorf("FinOps export listed %s", res)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	rg.HandleFinOpsReport(rec, httptest.NewRequest(http.MethodGet, "/api/reports/finops?format=csv&download=true", nil))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("CSV export = %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rec.Header().Get("Content-Disposition"), "k13d-finops-") {
+		t.Errorf("Content-Disposition = %q", rec.Header().Get("Content-Disposition"))
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"K13d FinOps Report", "=== COST BY NAMESPACE ===", "=== COST OPTIMIZATION RECOMMENDATIONS ==="} {
+		if !strings.Contains(body, want) {
+			t.Errorf("CSV missing %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "CLUSTER SUMMARY") {
+		t.Error("FinOps CSV should not include the cluster summary")
+	}
+
+	rec = httptest.NewRecorder()
+	rg.HandleFinOpsReport(rec, httptest.NewRequest(http.MethodGet, "/api/reports/finops", nil))
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("JSON export: %v", err)
+	}
+	if _, ok := decoded["cost_by_namespace"]; !ok || decoded["generated_by"] != "anonymous" {
+		t.Errorf("JSON export keys = %v, want flattened analysis fields", decoded)
+	}
+
+	rec = httptest.NewRecorder()
+	rg.HandleFinOpsReport(rec, httptest.NewRequest(http.MethodGet, "/api/reports/finops?format=html", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("format=html status = %d, want 400", rec.Code)
+	}
+}
+
+func TestGenerateFinOpsReport_CostByLabel(t *testing.T) {
+	pod := func(ns, name, team string) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if team != "" {
+			p.Labels = map[string]string{"team": team}
+		}
+		return p
+	}
+	fakeClientset := fake.NewClientset( //nolint:staticcheck
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "search"}},
+		pod("shop", "cart", "payments"),
+		pod("shop", "checkout", "payments"),
+		pod("search", "indexer", "payments"),
+		pod("search", "query", "discovery"),
+		pod("search", "debug", ""),
+	)
+	cfg := config.NewDefaultConfig()
+	rg := NewReportGenerator(&Server{cfg: cfg, k8sClient: &k8s.Client{Clientset: fakeClientset}})
+
+	report, err := rg.GenerateFinOpsReport(context.Background(), "finance")
+	if err != nil {
+		t.Fatalf("GenerateFinOpsReport() error = %v", err)
+	}
+	if report.CostByLabel != nil || report.CostAllocationLabel != "" {
+		t.Fatalf("CostByLabel = %+v without a configured label, want nil", report.CostByLabel)
+	}
+
+	cfg.Reports.CostAllocationLabel = "team"
+	report, err = rg.GenerateFinOpsReport(context.Background(), "finance")
+	if err != nil {
+		t.Fatalf("GenerateFinOpsReport() error = %v", err)
+	}
+	if report.CostAllocationLabel != "team" || len(report.CostByLabel) != 3 {
+		t.Fatalf("CostByLabel = %+v, want payments, discovery, and unallocated", report.CostByLabel)
+	}
+	payments := report.CostByLabel[0]
+	if payments.Value != "payments" || payments.RunningPodCount != 3 || strings.Join(payments.Namespaces, ",") != "search,shop" {
+		t.Errorf("top group = %+v, want payments with 3 pods across search and shop", payments)
+	}
+	var shares, total float64
+	groups := map[string]LabelCost{}
+	for _, lc := range report.CostByLabel {
+		groups[lc.Value] = lc
+		shares += lc.CostPercentage
+		total += lc.EstimatedCost
+	}
+	if groups[unallocatedCostLabel].PodCount != 1 || groups["discovery"].PodCount != 1 {
+		t.Errorf("groups = %+v, want one discovery and one unallocated pod", groups)
+	}
+	if diff := total - report.TotalEstimatedMonthlyCost; diff > 0.01 || diff < -0.01 || shares < 99.9 || shares > 100.1 {
+		t.Errorf("label costs total $%.2f (%.1f%%), want the namespace total $%.2f", total, shares, report.TotalEstimatedMonthlyCost)
+	}
+
+	csvBytes, err := rg.ExportFinOpsToCSV(report)
+	if err != nil {
+		t.Fatalf("ExportFinOpsToCSV() error = %v", err)
+	}
+	if !strings.Contains(string(csvBytes), "=== COST BY LABEL: team ===") {
+		t.Error("expected the cost by label table in the CSV export")
+	}
+	html := rg.ExportToHTML(&ComprehensiveReport{IncludedSections: ReportSections{FinOps: true}, FinOpsAnalysis: report.FinOpsAnalysis})
+	if !strings.Contains(html, "Cost by team") || !strings.Contains(html, "<td>payments</td>") {
+		t.Error("expected the cost by label table in the HTML export")
+	}
 }
 
 func TestReportExportsRespectIncludedSections(t *testing.T) {
@@ -593,3 +1247,913 @@ func containsReportString(values []string, target string) bool {
 	}
 	return false
 }
+
+func TestExportToHTML_UsesThemeColors(t *testing.T) {
+	report := &ComprehensiveReport{GeneratedAt: time.Now(), GeneratedBy: "tester"}
+
+	html := NewReportGenerator(nil).ExportToHTML(report)
+	if !strings.Contains(html, "--k13d-accent: #7aa2f7;") {
+		t.Error("expected default report accent color when no server config is set")
+	}
+
+	cfg := config.NewDefaultConfig()
+	cfg.Theme = config.ThemeHighContrast
+	html = NewReportGenerator(&Server{cfg: cfg}).ExportToHTML(report)
+	if !strings.Contains(html, "--k13d-accent: #0000cc;") {
+		t.Error("expected high-contrast accent color in themed report")
+	}
+	if !strings.Contains(html, "th { background: var(--k13d-table-header-bg)") {
+		t.Error("expected table header to use theme variables")
+	}
+}
+
+func TestExportToHTML_Branding(t *testing.T) {
+	report := &ComprehensiveReport{GeneratedAt: time.Now(), GeneratedBy: "tester"}
+
+	html := NewReportGenerator(nil).ExportToHTML(report)
+	if !strings.Contains(html, "<title>"+defaultReportTitle+"</title>") || !strings.Contains(html, defaultReportFooter) {
+		t.Error("expected default title and footer without branding")
+	}
+	if strings.Contains(html, `class="report-logo"`) {
+		t.Error("expected no logo without branding")
+	}
+
+	cfg := config.NewDefaultConfig()
+	cfg.Reports.Branding = config.ReportBrandingConfig{
+		Title:  "Acme Cluster Review",
+		Logo:   "javascript:alert(1)",
+		Footer: "Prepared by Acme Consulting",
+	}
+	rg := NewReportGenerator(&Server{cfg: cfg})
+	html = rg.ExportToHTML(report)
+	if !strings.Contains(html, `<h1 id="top">Acme Cluster Review</h1>`) || !strings.Contains(html, "Prepared by Acme Consulting") {
+		t.Error("expected configured title and footer")
+	}
+	if strings.Contains(html, "javascript:") {
+		t.Error("expected an invalid configured logo to be dropped")
+	}
+
+	// Per-report values win over config and are HTML-escaped
+	report.Branding = ReportBranding{Title: "<b>Q3</b> & more", Logo: "https://example.com/logo.png"}
+	html = rg.ExportToHTML(report)
+	if !strings.Contains(html, "<title>&lt;b&gt;Q3&lt;/b&gt; &amp; more</title>") {
+		t.Error("expected escaped per-report title")
+	}
+	if !strings.Contains(html, `<img class="report-logo" src="https://example.com/logo.png" alt="">`) {
+		t.Error("expected per-report logo")
+	}
+	if !strings.Contains(html, "Prepared by Acme Consulting") {
+		t.Error("expected configured footer when the report does not override it")
+	}
+}
+
+func TestParseReportBranding(t *testing.T) {
+	tests := []struct {
+		query   url.Values
+		wantErr bool
+	}{
+		{query: url.Values{"title": {"Acme"}, "footer": {"Confidential"}}},
+		{query: url.Values{"logo": {"https://cdn.example.com/acme.svg"}}},
+		{query: url.Values{"logo": {"data:image/png;base64,iVBORw0KGgo="}}},
+		{query: url.Values{"logo": {"javascript:alert(1)"}}, wantErr: true},
+		{query: url.Values{"logo": {"data:text/html;base64,PHNjcmlwdD4="}}, wantErr: true},
+		{query: url.Values{"logo": {"/relative/logo.png"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		_, err := parseReportBranding(tt.query)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseReportBranding(%v) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+		}
+	}
+}
+
+func TestGenerateReport_ResourceQuotasAndLimitRanges(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-b"},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		},
+		&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "team-a"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("2")},
+				Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1900m")},
+			},
+		},
+		&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "team-b"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+				Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("2")},
+			},
+		},
+		&corev1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "team-a"},
+			Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{
+				Type:    corev1.LimitTypeContainer,
+				Default: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			}}},
+		},
+	)
+
+	rg := NewReportGenerator(&Server{k8sClient: &k8s.Client{Clientset: fakeClientset}})
+	report, err := rg.GenerateReport(context.Background(), "tester", &ReportSections{Namespaces: true}, nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+
+	if len(report.ResourceQuotas) != 2 {
+		t.Fatalf("len(ResourceQuotas) = %d, want 2", len(report.ResourceQuotas))
+	}
+	if q := report.ResourceQuotas[0]; q.Namespace != "team-a" || q.Status != k8s.QuotaStatusNearLimit {
+		t.Errorf("first quota = %+v, want team-a near limit", q)
+	}
+	if report.NamespaceSummary.NearQuota != 1 {
+		t.Errorf("NamespaceSummary.NearQuota = %d, want 1", report.NamespaceSummary.NearQuota)
+	}
+	for _, ns := range report.Namespaces {
+		want := map[string]string{"team-a": k8s.QuotaStatusNearLimit, "team-b": k8s.QuotaStatusOK}[ns.Name]
+		if ns.QuotaStatus != want {
+			t.Errorf("namespace %s QuotaStatus = %q, want %q", ns.Name, ns.QuotaStatus, want)
+		}
+	}
+	if len(report.LimitRanges) != 1 || len(report.LimitRanges[0].Limits) != 1 {
+		t.Fatalf("LimitRanges = %+v, want one limit range with one limit", report.LimitRanges)
+	}
+
+	html := rg.ExportToHTML(report)
+	if !strings.Contains(html, "Resource Quotas &amp; Limit Ranges") {
+		t.Error("expected quota section in HTML export")
+	}
+	csvBytes, err := rg.ExportToCSV(report)
+	if err != nil {
+		t.Fatalf("ExportToCSV() error = %v", err)
+	}
+	if !strings.Contains(string(csvBytes), "=== RESOURCE QUOTAS ===") || !strings.Contains(string(csvBytes), "=== LIMIT RANGES ===") {
+		t.Error("expected quota and limit range sections in CSV export")
+	}
+}
+
+func TestBuildReportEvents_ClassifiesAndLimits(t *testing.T) {
+	now := time.Now()
+	event := func(typ, reason, message string, count int32, ago time.Duration) corev1.Event {
+		return corev1.Event{
+			Type:           typ,
+			Reason:         reason,
+			Message:        message,
+			Count:          count,
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: reason},
+			LastTimestamp:  metav1.NewTime(now.Add(-ago)),
+		}
+	}
+	events := []corev1.Event{
+		event(corev1.EventTypeWarning, "FailedScheduling", "0/3 nodes are available", 4, time.Hour),
+		event(corev1.EventTypeWarning, "Failed", "Failed to pull image \"web:bad\"", 2, 2*time.Hour),
+		event(corev1.EventTypeWarning, "Unhealthy", "Readiness probe failed: 503", 9, time.Minute),
+		event(corev1.EventTypeWarning, "OOMKilling", "Memory cgroup out of memory", 1, 3*time.Hour),
+		event(corev1.EventTypeWarning, "BackOff", "Back-off restarting failed container", 1, 4*time.Hour),
+		event(corev1.EventTypeNormal, "Scheduled", "Successfully assigned", 1, time.Second),
+	}
+
+	listed, stats := buildReportEvents(events, ReportEventOptions{Limit: 2})
+	if len(listed) != 2 || stats.Listed != 2 || stats.Omitted != 3 {
+		t.Fatalf("listed %d (stats %+v), want 2 listed and 3 omitted", len(listed), stats)
+	}
+	if listed[0].Reason != "Unhealthy" || listed[1].Reason != "FailedScheduling" {
+		t.Errorf("listed = %s, %s; want most recent warnings first", listed[0].Reason, listed[1].Reason)
+	}
+	if listed[0].Category != EventCategoryProbeFailure {
+		t.Errorf("Unhealthy category = %q, want %q", listed[0].Category, EventCategoryProbeFailure)
+	}
+	if stats.Warning != 5 || stats.Normal != 1 {
+		t.Errorf("stats warning/normal = %d/%d, want 5/1", stats.Warning, stats.Normal)
+	}
+
+	got := make(map[string]int)
+	for _, cc := range stats.Categories {
+		got[cc.Category] = cc.Occurrences
+	}
+	want := map[string]int{
+		EventCategoryProbeFailure: 9,
+		EventCategoryScheduling:   4,
+		EventCategoryImagePull:    2,
+		EventCategoryOOM:          1,
+		EventCategoryOther:        1,
+	}
+	for category, n := range want {
+		if got[category] != n {
+			t.Errorf("category %s occurrences = %d, want %d", category, got[category], n)
+		}
+	}
+	if stats.Categories[0].Category != EventCategoryProbeFailure {
+		t.Errorf("first category = %q, want the most frequent", stats.Categories[0].Category)
+	}
+
+	listed, stats = buildReportEvents(events, ReportEventOptions{IncludeNormal: true})
+	if len(listed) != 6 || stats.Omitted != 0 {
+		t.Fatalf("unlimited listing returned %d events, %d omitted", len(listed), stats.Omitted)
+	}
+	if last := listed[len(listed)-1]; last.Type != corev1.EventTypeNormal || last.Category != "" {
+		t.Errorf("last event = %+v, want the uncategorized Normal event after all warnings", last)
+	}
+}
+
+func TestParseReportEventOptions(t *testing.T) {
+	rg := NewReportGenerator(&Server{cfg: &config.Config{Reports: config.ReportsConfig{EventLimit: 20, IncludeNormalEvents: true}}})
+
+	opts, err := rg.parseReportEventOptions(map[string][]string{})
+	if err != nil || opts.Limit != 20 || !opts.IncludeNormal {
+		t.Fatalf("defaults = %+v, %v; want config values", opts, err)
+	}
+
+	opts, err = rg.parseReportEventOptions(map[string][]string{"event_limit": {"0"}, "normal_events": {"false"}})
+	if err != nil || opts.Limit != 0 || opts.IncludeNormal {
+		t.Fatalf("overrides = %+v, %v; want unlimited warnings only", opts, err)
+	}
+
+	if _, err := rg.parseReportEventOptions(map[string][]string{"event_limit": {"-1"}}); err == nil {
+		t.Error("negative event_limit should be rejected")
+	}
+}
+
+func TestCachedAIAnalysis_ReusesAnalysis(t *testing.T) {
+	mock := llmhttp.NewMockProviderServer("openai", "Cluster looks healthy.")
+	defer mock.Close()
+	client, err := ai.NewClient(mock.LLMConfig())
+	if err != nil {
+		t.Fatalf("failed to create AI client: %v", err)
+	}
+	s := setupAITestServer(t, false)
+	s.aiClient = client
+	rg := NewReportGenerator(s)
+
+	report := &ComprehensiveReport{HealthScore: 90}
+	analysis, id, err := rg.CachedAIAnalysis(context.Background(), report, "")
+	if err != nil || analysis != "Cluster looks healthy." || id == "" {
+		t.Fatalf("CachedAIAnalysis() = %q, %q, %v", analysis, id, err)
+	}
+
+	// Preview then download of the same report calls the LLM once
+	again, againID, err := rg.CachedAIAnalysis(context.Background(), &ComprehensiveReport{HealthScore: 90}, "")
+	if err != nil || again != analysis || againID != id {
+		t.Errorf("second call = %q, %q, %v; want cached %q", again, againID, err, id)
+	}
+	if n := mock.RequestCount(); n != 1 {
+		t.Errorf("LLM requests = %d, want 1", n)
+	}
+
+	// A known ID is reused even though the cluster changed
+	changed := &ComprehensiveReport{HealthScore: 50}
+	if _, reusedID, _ := rg.CachedAIAnalysis(context.Background(), changed, id); reusedID != id || mock.RequestCount() != 1 {
+		t.Errorf("ai_id reuse returned %q after %d requests", reusedID, mock.RequestCount())
+	}
+
+	// A changed report without an ID, or with an unknown one, is analyzed anew
+	if _, newID, _ := rg.CachedAIAnalysis(context.Background(), changed, "ai-unknown"); newID == id || mock.RequestCount() != 2 {
+		t.Errorf("changed report returned %q after %d requests", newID, mock.RequestCount())
+	}
+}
+
+func TestAIAnalysisCache_Expires(t *testing.T) {
+	cache := newAIAnalysisCache(time.Minute)
+	now := time.Now()
+	cache.put("ai-1", "analysis", now)
+
+	if got, ok := cache.get("ai-1", now.Add(30*time.Second)); !ok || got != "analysis" {
+		t.Errorf("get() before expiry = %q, %v", got, ok)
+	}
+	if _, ok := cache.get("ai-1", now.Add(2*time.Minute)); ok {
+		t.Error("get() after expiry should miss")
+	}
+}
+
+func TestPaginateReport(t *testing.T) {
+	newReport := func() *ComprehensiveReport {
+		report := &ComprehensiveReport{}
+		for i := 0; i < 5; i++ {
+			report.Pods = append(report.Pods, PodInfo{Name: fmt.Sprintf("pod-%d", i)})
+		}
+		report.Deployments = []DeploymentInfo{{Name: "web"}, {Name: "api"}}
+		report.Images = []ImageInfo{{Image: "nginx"}}
+		return report
+	}
+
+	report := newReport()
+	paginateReport(report, ReportPageOptions{})
+	if len(report.Pods) != 5 || report.Pagination != nil {
+		t.Fatalf("no paging options should leave the report whole, got %d pods, %+v", len(report.Pods), report.Pagination)
+	}
+
+	report = newReport()
+	paginateReport(report, ReportPageOptions{Offset: 2, Limit: 2})
+	if len(report.Pods) != 2 || report.Pods[0].Name != "pod-2" || len(report.Deployments) != 0 || len(report.Images) != 0 {
+		t.Errorf("page 2 = %d pods from %v, %d deployments, %d images", len(report.Pods), report.Pods, len(report.Deployments), len(report.Images))
+	}
+	if p := report.Pagination; p == nil || p.TotalPods != 5 || p.TotalDeployments != 2 || !p.HasMore || p.NextOffset != 4 {
+		t.Errorf("pagination = %+v, want totals and next offset 4", p)
+	}
+
+	report = newReport()
+	paginateReport(report, ReportPageOptions{Offset: 4, Limit: 2})
+	if len(report.Pods) != 1 || report.Pagination.HasMore || report.Pagination.NextOffset != 0 {
+		t.Errorf("last page = %d pods, %+v", len(report.Pods), report.Pagination)
+	}
+}
+
+func TestParseReportPageOptions(t *testing.T) {
+	rg := NewReportGenerator(&Server{cfg: &config.Config{Reports: config.ReportsConfig{PageSize: 500}}})
+
+	opts, err := rg.parseReportPageOptions(map[string][]string{})
+	if err != nil || opts.Limit != 500 || opts.Offset != 0 {
+		t.Fatalf("defaults = %+v, %v; want configured page size", opts, err)
+	}
+	opts, err = rg.parseReportPageOptions(map[string][]string{"limit": {"100"}, "offset": {"200"}})
+	if err != nil || opts.Limit != 100 || opts.Offset != 200 {
+		t.Fatalf("overrides = %+v, %v", opts, err)
+	}
+	for _, bad := range []map[string][]string{{"limit": {"-1"}}, {"offset": {"x"}}} {
+		if _, err := rg.parseReportPageOptions(bad); err == nil {
+			t.Errorf("%v should be rejected", bad)
+		}
+	}
+}
+
+func TestExportToHTML_ConfigurableLimits(t *testing.T) {
+	report := &ComprehensiveReport{
+		GeneratedAt:      time.Now(),
+		IncludedSections: ReportSections{Workloads: true},
+	}
+	for i := 0; i < 60; i++ {
+		report.Pods = append(report.Pods, PodInfo{Name: fmt.Sprintf("pod-%d", i)})
+	}
+
+	html := NewReportGenerator(nil).ExportToHTML(report)
+	if !strings.Contains(html, "Showing first 50 of 60 pods") || strings.Contains(html, "pod-50<") {
+		t.Error("expected the default 50 pod cap")
+	}
+
+	cfg := config.NewDefaultConfig()
+	cfg.Reports.HTMLPodLimit = 10
+	html = NewReportGenerator(&Server{cfg: cfg}).ExportToHTML(report)
+	if !strings.Contains(html, "Showing first 10 of 60 pods") || strings.Contains(html, "pod-10<") {
+		t.Error("expected the configured 10 pod cap")
+	}
+
+	cfg.Reports.HTMLPodLimit = 0
+	html = NewReportGenerator(&Server{cfg: cfg}).ExportToHTML(report)
+	if strings.Contains(html, "Showing first") || !strings.Contains(html, "pod-59<") {
+		t.Error("expected every pod with the cap disabled")
+	}
+}
+
+func TestGenerateDriftReport(t *testing.T) {
+	dir := t.TempDir()
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app\ndata:\n  mode: prod\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: gone\n"
+	if err := os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "app", "namespace": "apps", "resourceVersion": "1"},
+		"data":       map[string]interface{}{"mode": "debug"},
+	}}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"}, live)
+	// Echo dry-run updates back unpersisted, as the API server would
+	dyn.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, action.(k8stesting.UpdateActionImpl).GetObject(), nil
+	})
+
+	cfg := config.NewDefaultConfig()
+	rg := NewReportGenerator(&Server{cfg: cfg, k8sClient: &k8s.Client{Dynamic: dyn}})
+	if got := rg.generateDriftReport(context.Background()); got != nil {
+		t.Fatalf("generateDriftReport() without a manifest dir = %+v, want nil", got)
+	}
+
+	cfg.Drift = config.DriftConfig{ManifestDir: dir, DefaultNamespace: "apps"}
+	drift := rg.generateDriftReport(context.Background())
+	if drift == nil || drift.Error != "" {
+		t.Fatalf("generateDriftReport() = %+v, want a scan", drift)
+	}
+	if drift.Drifted != 1 || drift.Missing != 1 || len(drift.Resources) != 2 {
+		t.Errorf("generateDriftReport() = %+v, want 1 drifted and 1 missing", drift)
+	}
+
+	report := &ComprehensiveReport{IncludedSections: ReportSections{Drift: true}, Drift: drift}
+	html := rg.ExportToHTML(report)
+	if !strings.Contains(html, "Configuration Drift") || !strings.Contains(html, "~ data.mode: prod -&gt; debug") {
+		t.Error("expected the drift section with field differences in HTML export")
+	}
+	csvBytes, err := rg.ExportToCSV(report)
+	if err != nil {
+		t.Fatalf("ExportToCSV() error = %v", err)
+	}
+	if !strings.Contains(string(csvBytes), "=== CONFIGURATION DRIFT ===") || !strings.Contains(string(csvBytes), "not found in the cluster") {
+		t.Errorf("expected the drift section in CSV export, got:\n%s", csvBytes)
+	}
+
+	cfg.Drift.ManifestDir = filepath.Join(dir, "missing")
+	if drift := rg.generateDriftReport(context.Background()); drift == nil || drift.Error == "" {
+		t.Errorf("generateDriftReport() with a missing dir = %+v, want an error", drift)
+	}
+}
+
+func TestGenerateDeprecatedAPIReport(t *testing.T) {
+	cronjob := func(namespace string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("batch/v1")
+		obj.SetKind("CronJob")
+		obj.SetName("cleanup")
+		obj.SetNamespace(namespace)
+		obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "helm", APIVersion: "batch/v1beta1"}})
+		return obj
+	}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{{Group: "batch", Version: "v1", Resource: "cronjobs"}: "CronJobList"},
+		cronjob("apps"), cronjob("kube-system"))
+	clientset := fake.NewSimpleClientset()
+	clientset.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "batch/v1", APIResources: []metav1.APIResource{{Name: "cronjobs", Namespaced: true, Kind: "CronJob"}}},
+	}
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &apiversion.Info{GitVersion: "v1.25.0"}
+
+	cfg := &config.Config{ExcludedNamespaces: []string{"kube-*"}}
+	rg := NewReportGenerator(&Server{cfg: cfg, k8sClient: &k8s.Client{Clientset: clientset, Dynamic: dyn}})
+	report := &ComprehensiveReport{IncludedSections: ReportSections{DeprecatedAPIs: true}}
+	report.DeprecatedAPIs = rg.generateDeprecatedAPIReport(context.Background(), report)
+	scan := report.DeprecatedAPIs
+	if scan == nil || len(scan.Uses) != 1 || scan.Removed != 1 || scan.Uses[0].Namespace != "apps" {
+		t.Fatalf("generateDeprecatedAPIReport() = %+v, want the removed apps CronJob alone", scan)
+	}
+
+	html := rg.ExportToHTML(report)
+	if !strings.Contains(html, "Deprecated APIs") || !strings.Contains(html, "<td>batch/v1beta1</td><td>batch/v1</td>") {
+		t.Error("expected the deprecated API section with the replacement in HTML export")
+	}
+	csvBytes, err := rg.ExportToCSV(report)
+	if err != nil {
+		t.Fatalf("ExportToCSV() error = %v", err)
+	}
+	if !strings.Contains(string(csvBytes), "=== DEPRECATED APIS ===") || !strings.Contains(string(csvBytes), "live (helm)") {
+		t.Errorf("expected the deprecated API section in CSV export, got:\n%s", csvBytes)
+	}
+	if !ParseSections("deprecated_apis").DeprecatedAPIs || !AllSections().DeprecatedAPIs {
+		t.Error("deprecated_apis should parse and be part of every report")
+	}
+}
+
+func TestRedactReport(t *testing.T) {
+	newReport := func() *ComprehensiveReport {
+		return &ComprehensiveReport{
+			Nodes:      []NodeInfo{{Name: "worker-1", InternalIP: "10.0.0.5"}},
+			Namespaces: []NamespaceInfo{{Name: "payments"}, {Name: "app"}},
+			Pods: []PodInfo{
+				{Name: "api-0", Namespace: "payments", Node: "worker-1", IP: "10.1.0.7"},
+				{Name: "web-0", Namespace: "app", Node: "worker-1"},
+			},
+			Events: []EventInfo{{
+				Object:  "Pod/api-0",
+				Message: "Pod payments/api-0 on worker-1 (10.1.0.7) failed; probe to 192.168.1.9 timed out for application.",
+			}},
+			AIAnalysis: "Node worker-1 is under memory pressure.",
+		}
+	}
+	rg := NewReportGenerator(&Server{cfg: &config.Config{Reports: config.ReportsConfig{RedactionKey: "k"}}})
+
+	report := newReport()
+	rg.redactReport(report, ReportRedaction{IPs: true, Nodes: true, Namespaces: true})
+	node := report.Nodes[0].Name
+	if !strings.HasPrefix(node, "node-") || report.Pods[0].Node != node || report.Pods[1].Node != node {
+		t.Fatalf("node names = %q, %q, %q; want one consistent pseudonym", node, report.Pods[0].Node, report.Pods[1].Node)
+	}
+	ns := report.Namespaces[0].Name
+	if !strings.HasPrefix(ns, "ns-") || report.Pods[0].Namespace != ns || report.Pods[1].Namespace == ns {
+		t.Errorf("namespaces = %q, %q, %q", ns, report.Pods[0].Namespace, report.Pods[1].Namespace)
+	}
+	if len(report.Pods) != 2 || report.Pods[0].Name != "api-0" {
+		t.Errorf("pods = %+v, want names and count kept", report.Pods)
+	}
+	msg := report.Events[0].Message
+	for _, leak := range []string{"worker-1", "payments", "10.1.0.7", "192.168.1.9"} {
+		if strings.Contains(msg, leak) || strings.Contains(report.AIAnalysis, leak) {
+			t.Errorf("%q leaked: %q / %q", leak, msg, report.AIAnalysis)
+		}
+	}
+	if !strings.Contains(msg, report.Pods[0].IP+")") || !strings.Contains(msg, "application.") {
+		t.Errorf("message = %q, want the pod IP pseudonym and other words kept", msg)
+	}
+
+	// The same key yields the same pseudonyms across reports
+	again := newReport()
+	rg.redactReport(again, ReportRedaction{Nodes: true})
+	if again.Nodes[0].Name != node || again.Pods[0].Namespace != "payments" || again.Pods[0].IP != "10.1.0.7" {
+		t.Errorf("nodes-only redaction = %+v", again.Pods[0])
+	}
+}
+
+func TestRequestedRedaction(t *testing.T) {
+	rg := NewReportGenerator(&Server{cfg: &config.Config{Reports: config.ReportsConfig{Redact: []string{"ips"}}}})
+
+	r, err := rg.requestedRedaction(map[string][]string{})
+	if err != nil || r != (ReportRedaction{IPs: true}) {
+		t.Fatalf("config default = %+v, %v", r, err)
+	}
+	r, err = rg.requestedRedaction(map[string][]string{"redact": {"nodes,namespaces"}})
+	if err != nil || r != (ReportRedaction{Nodes: true, Namespaces: true}) {
+		t.Fatalf("override = %+v, %v", r, err)
+	}
+	if r, _ = rg.requestedRedaction(map[string][]string{"redact": {"none"}}); r.Any() {
+		t.Errorf("none = %+v, want nothing redacted", r)
+	}
+	if _, err = rg.requestedRedaction(map[string][]string{"redact": {"pods"}}); err == nil {
+		t.Error("unknown category should be rejected")
+	}
+}
+
+func TestRenderReport(t *testing.T) {
+	fakeClientset := fake.NewClientset( //nolint:staticcheck
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+	)
+	rg := NewStandaloneReportGenerator(config.NewDefaultConfig(), &k8s.Client{Clientset: fakeClientset})
+
+	out, err := rg.RenderReport(context.Background(), "mcp", "nodes,workloads", "")
+	if err != nil {
+		t.Fatalf("RenderReport() error = %v", err)
+	}
+	var report ComprehensiveReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("JSON output: %v", err)
+	}
+	if report.GeneratedBy != "mcp" || len(report.Nodes) != 1 || len(report.Pods) != 1 || report.IncludedSections.Events {
+		t.Errorf("report = %+v, want nodes and workloads only", report)
+	}
+
+	out, err = rg.RenderReport(context.Background(), "mcp", "nodes", "csv")
+	if err != nil || !strings.Contains(out, "worker-1") {
+		t.Errorf("CSV output = %q, %v", out, err)
+	}
+	if _, err := rg.RenderReport(context.Background(), "mcp", "nodes,pods", "json"); err == nil {
+		t.Error("unknown section should be rejected")
+	}
+	if _, err := rg.RenderReport(context.Background(), "mcp", "nodes", "pdf"); err == nil {
+		t.Error("unknown format should be rejected")
+	}
+}
+
+func TestHandleReports_StreamsProgress(t *testing.T) {
+	fakeClientset := fake.NewClientset( //nolint:staticcheck
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "web"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "shop"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+	)
+	rg := NewReportGenerator(&Server{k8sClient: &k8s.Client{Clientset: fakeClientset}})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/reports?format=json&sections=nodes,workloads,events", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	rec := httptest.NewRecorder()
+	rg.HandleReports(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+	var (
+		progress []ReportProgress
+		result   reportStreamResult
+	)
+	for _, block := range strings.Split(strings.TrimSpace(rec.Body.String()), "\n\n") {
+		event, data, _ := strings.Cut(block, "\n")
+		data = strings.TrimPrefix(data, "data: ")
+		switch event {
+		case "event: progress":
+			var p ReportProgress
+			if err := json.Unmarshal([]byte(data), &p); err != nil {
+				t.Fatalf("progress event %q: %v", data, err)
+			}
+			progress = append(progress, p)
+		case "event: report":
+			if err := json.Unmarshal([]byte(data), &result); err != nil {
+				t.Fatalf("report event: %v", err)
+			}
+		default:
+			t.Errorf("unexpected event %q", block)
+		}
+	}
+
+	stages := map[string]bool{}
+	last := 0
+	for _, p := range progress {
+		stages[p.Stage] = true
+		if p.Percent < last || p.Percent > 100 {
+			t.Errorf("percent went %d -> %d", last, p.Percent)
+		}
+		last = p.Percent
+	}
+	for _, stage := range []string{"nodes", "namespaces", "workloads", "events"} {
+		if !stages[stage] {
+			t.Errorf("no progress for stage %q in %+v", stage, progress)
+		}
+	}
+	if stages["finops"] || stages["capacity"] {
+		t.Errorf("progress for excluded sections: %+v", progress)
+	}
+	var counted bool
+	for _, p := range progress {
+		if p.Stage == "namespaces" && p.Total == 2 && p.Done == 2 && strings.HasSuffix(p.Message, "(2/2)") {
+			counted = true
+		}
+	}
+	if !counted {
+		t.Errorf("progress = %+v, want namespaces counted to 2/2", progress)
+	}
+
+	var report ComprehensiveReport
+	if result.Format != "json" || !strings.HasSuffix(result.Filename, ".json") {
+		t.Errorf("result = %s %s, want a json report", result.Format, result.Filename)
+	}
+	if err := json.Unmarshal([]byte(result.Content), &report); err != nil || len(report.Pods) != 1 {
+		t.Errorf("report content: err=%v pods=%d", err, len(report.Pods))
+	}
+
+	// Without the Accept header the report is returned as before
+	rec = httptest.NewRecorder()
+	rg.HandleReports(rec, httptest.NewRequest(http.MethodGet, "/api/reports?format=json&sections=nodes", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("plain request Content-Type = %q", ct)
+	}
+}
+
+func TestGenerateReport_ExcludedNamespaces(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}},
+		&corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: "system", Namespace: "kube-system"}},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "ev", Namespace: "kube-system"}, Type: corev1.EventTypeWarning, Reason: "BackOff"},
+	)
+	cfg := &config.Config{ExcludedNamespaces: []string{"kube-*"}}
+	rg := NewReportGenerator(&Server{cfg: cfg, k8sClient: &k8s.Client{Clientset: fakeClientset}})
+	sections := &ReportSections{Namespaces: true, Workloads: true, Events: true}
+
+	report, err := rg.GenerateReport(context.Background(), "tester", sections, nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if len(report.Namespaces) != 1 || report.Namespaces[0].Name != "team-a" || report.NamespaceSummary.Hidden != 1 {
+		t.Errorf("namespaces = %+v (hidden %d), want only team-a with 1 hidden", report.Namespaces, report.NamespaceSummary.Hidden)
+	}
+	if len(report.Pods) != 1 || report.Pods[0].Namespace != "team-a" {
+		t.Errorf("pods = %+v, want only team-a/web", report.Pods)
+	}
+	if len(report.ResourceQuotas) != 0 || len(report.Events) != 0 {
+		t.Errorf("quotas = %+v, events = %+v; want the kube-system ones hidden", report.ResourceQuotas, report.Events)
+	}
+	if !strings.Contains(rg.ExportToHTML(report), "1 excluded namespaces are not covered") {
+		t.Error("expected the HTML export to note the hidden namespaces")
+	}
+
+	// include_excluded=true reports them
+	ctx, err := withRequestedNamespaces(context.Background(), url.Values{"include_excluded": {"true"}})
+	if err != nil {
+		t.Fatalf("withRequestedNamespaces() error = %v", err)
+	}
+	report, err = rg.GenerateReport(ctx, "tester", sections, nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if len(report.Namespaces) != 2 || report.NamespaceSummary.Hidden != 0 || len(report.Pods) != 2 || len(report.Events) != 1 {
+		t.Errorf("include_excluded report: %d namespaces (hidden %d), %d pods, %d events; want 2, 0, 2, 1",
+			len(report.Namespaces), report.NamespaceSummary.Hidden, len(report.Pods), len(report.Events))
+	}
+}
+
+func TestGenerateReport_NamespaceRegex(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-payments-prod"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-payments-staging"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-legacy-prod"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-payments-prod"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-payments-staging"}},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "ev", Namespace: "team-payments-staging"}, Type: corev1.EventTypeWarning, Reason: "BackOff"},
+	)
+	cfg := &config.Config{ExcludedNamespaces: []string{"team-legacy-*"}}
+	rg := NewReportGenerator(&Server{cfg: cfg, k8sClient: &k8s.Client{Clientset: fakeClientset}})
+	sections := &ReportSections{Namespaces: true, Workloads: true, Events: true}
+
+	ctx, err := withRequestedNamespaces(context.Background(), url.Values{"namespace_regex": {"^team-.*-prod$"}})
+	if err != nil {
+		t.Fatalf("withRequestedNamespaces() error = %v", err)
+	}
+	report, err := rg.GenerateReport(ctx, "tester", sections, nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	// team-legacy-prod matches but stays excluded; staging is not counted as hidden
+	if len(report.Namespaces) != 1 || report.Namespaces[0].Name != "team-payments-prod" || report.NamespaceSummary.Hidden != 1 {
+		t.Errorf("namespaces = %+v (hidden %d), want only team-payments-prod with 1 hidden", report.Namespaces, report.NamespaceSummary.Hidden)
+	}
+	if len(report.Pods) != 1 || len(report.Events) != 0 {
+		t.Errorf("pods = %+v, events = %+v; want only team-payments-prod/api", report.Pods, report.Events)
+	}
+	if report.NamespaceSummary.Regex != "^team-.*-prod$" || !strings.Contains(rg.ExportToHTML(report), "Only namespaces matching <code>^team-.*-prod$</code>") {
+		t.Errorf("regex = %q, want it recorded and noted in the HTML export", report.NamespaceSummary.Regex)
+	}
+
+	// An invalid regex is rejected before the report is generated
+	rec := httptest.NewRecorder()
+	rg.HandleReports(rec, httptest.NewRequest(http.MethodGet, "/api/reports?format=json&namespace_regex=team-(prod", nil))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "invalid namespace regex") {
+		t.Errorf("invalid regex: status %d, body %s; want 400 naming the regex", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGenerateReport_TenantNamespace(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments-staging"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "payments"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "payments-staging"}},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "ev", Namespace: "payments-staging"}, Type: corev1.EventTypeWarning, Reason: "BackOff"},
+	)
+	// A tenant report covers its namespace even when it is excluded
+	cfg := &config.Config{ExcludedNamespaces: []string{"payments"}}
+	rg := NewReportGenerator(&Server{cfg: cfg, k8sClient: &k8s.Client{Clientset: fakeClientset}})
+	sections := &ReportSections{Nodes: true, Namespaces: true, Workloads: true, Events: true}
+
+	report, err := rg.GenerateReport(withTenantNamespace(context.Background(), "payments"), "tester", sections, nil)
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if report.Namespace != "payments" || len(report.Namespaces) != 1 || report.Namespaces[0].Name != "payments" {
+		t.Errorf("namespace = %q, namespaces = %+v; want only payments", report.Namespace, report.Namespaces)
+	}
+	if len(report.Pods) != 1 || len(report.Events) != 0 || report.HealthScore != 100 {
+		t.Errorf("pods = %+v, events = %+v, health = %.1f; want payments/api alone at 100%%", report.Pods, report.Events, report.HealthScore)
+	}
+	if len(report.Nodes) != 0 || report.NodeSummary.Total != 0 || report.IncludedSections.Nodes {
+		t.Errorf("nodes = %+v; a tenant report leaves nodes out", report.Nodes)
+	}
+	if html := rg.ExportToHTML(report); !strings.Contains(html, "Namespace Health Score") || strings.Contains(html, "Total Nodes") {
+		t.Error("expected the HTML export to present a namespace report without nodes")
+	}
+
+	rec := httptest.NewRecorder()
+	rg.HandleNamespaceReport(rec, httptest.NewRequest(http.MethodGet, "/api/reports/namespace/payments?format=csv&download=true", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "K13d Namespace Report") ||
+		!strings.Contains(rec.Header().Get("Content-Disposition"), "k13d-report-payments-") {
+		t.Errorf("tenant report: status %d, disposition %q", rec.Code, rec.Header().Get("Content-Disposition"))
+	}
+
+	for path, want := range map[string]int{
+		"/api/reports/namespace/Payments_1": http.StatusBadRequest,
+		"/api/reports/namespace/":           http.StatusBadRequest,
+		"/api/reports/namespace/missing":    http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		rg.HandleNamespaceReport(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Errorf("GET %s: status %d, want %d", path, rec.Code, want)
+		}
+	}
+}
+
+func TestScopeSecurityScan(t *testing.T) {
+	scan := &SecurityScanReport{
+		CISBenchmark:      &CISBenchmarkReport{},
+		PodSecurityIssues: []PodSecurityIssueReport{{Namespace: "payments"}, {Namespace: "billing"}},
+		RBACIssues:        []RBACIssueReport{{Kind: "ClusterRoleBinding", Name: "admins"}},
+		NetworkIssues:     []NetworkIssueReport{{Namespace: "payments"}},
+		Recommendations: []SecurityRecommendationReport{
+			{Category: "Pod Security"}, {Category: "RBAC"}, {Category: "CIS Benchmark"},
+		},
+	}
+	scopeSecurityScan(scan, "payments")
+	if scan.CISBenchmark != nil || len(scan.PodSecurityIssues) != 1 || len(scan.RBACIssues) != 0 || len(scan.NetworkIssues) != 1 {
+		t.Errorf("scoped scan = %+v; want the payments findings alone", scan)
+	}
+	if len(scan.Recommendations) != 1 || scan.Recommendations[0].Category != "Pod Security" {
+		t.Errorf("recommendations = %+v; want the cluster-wide ones dropped", scan.Recommendations)
+	}
+}
+
+func TestDiffReports(t *testing.T) {
+	older := &ComprehensiveReport{
+		GeneratedAt:      time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+		IncludedSections: ReportSections{Workloads: true, SecurityBasic: true, FinOps: true},
+		HealthScore:      92,
+		Workloads:        WorkloadSummary{TotalPods: 3, RunningPods: 2, FailedPods: 1},
+		Pods: []PodInfo{
+			{Name: "web-0", Namespace: "shop", Status: "Running"},
+			{Name: "job-1", Namespace: "shop", Status: "Failed"},
+			{Name: "api-0", Namespace: "shop", Status: "Running"},
+		},
+		SecurityScan: &SecurityScanReport{
+			PodSecurityIssues: []PodSecurityIssueReport{{Namespace: "shop", Pod: "web-0", Issue: "runs as root", Severity: "high"}},
+			RBACIssues:        []RBACIssueReport{{Kind: "ClusterRoleBinding", Name: "ci", Issue: "binds cluster-admin", Severity: "critical"}},
+		},
+		FinOpsAnalysis: FinOpsAnalysis{
+			TotalEstimatedMonthlyCost: 100,
+			CostByNamespace:           []NamespaceCost{{Namespace: "shop", EstimatedCost: 80}, {Namespace: "ops", EstimatedCost: 20}},
+		},
+	}
+	newer := &ComprehensiveReport{
+		GeneratedAt:      time.Date(2026, 10, 8, 9, 0, 0, 0, time.UTC),
+		IncludedSections: ReportSections{Workloads: true, SecurityBasic: true, FinOps: true},
+		HealthScore:      85.5,
+		Workloads:        WorkloadSummary{TotalPods: 2, RunningPods: 1, FailedPods: 1},
+		Pods: []PodInfo{
+			{Name: "web-0", Namespace: "shop", Status: "Running"},
+			{Name: "api-0", Namespace: "shop", Status: "CrashLoopBackOff", Restarts: 7},
+		},
+		SecurityScan: &SecurityScanReport{
+			PodSecurityIssues: []PodSecurityIssueReport{
+				{Namespace: "shop", Pod: "web-0", Issue: "runs as root", Severity: "high"},
+				{Namespace: "shop", Pod: "api-0", Container: "app", Issue: "privileged", Severity: "critical"},
+			},
+		},
+		FinOpsAnalysis: FinOpsAnalysis{
+			TotalEstimatedMonthlyCost: 130,
+			CostByNamespace:           []NamespaceCost{{Namespace: "shop", EstimatedCost: 80}, {Namespace: "ml", EstimatedCost: 50}},
+		},
+	}
+
+	diff := DiffReports(older, newer)
+	if !diff.SecurityCompared || !diff.CostCompared || !diff.PodsCompared {
+		t.Fatalf("compared = %v/%v/%v, want every section", diff.SecurityCompared, diff.CostCompared, diff.PodsCompared)
+	}
+	if len(diff.NewFindings) != 1 || diff.NewFindings[0].Resource != "shop/api-0/app" {
+		t.Errorf("NewFindings = %+v, want the privileged api-0 container", diff.NewFindings)
+	}
+	if len(diff.ResolvedFindings) != 1 || diff.ResolvedFindings[0].Category != "rbac" {
+		t.Errorf("ResolvedFindings = %+v, want the cluster-admin binding", diff.ResolvedFindings)
+	}
+	if len(diff.NewFailedPods) != 1 || diff.NewFailedPods[0].Name != "api-0" {
+		t.Errorf("NewFailedPods = %+v, want api-0", diff.NewFailedPods)
+	}
+	if len(diff.RecoveredPods) != 1 || diff.RecoveredPods[0].Status != "Deleted" {
+		t.Errorf("RecoveredPods = %+v, want the deleted job-1", diff.RecoveredPods)
+	}
+	wantCosts := []NamespaceCostChange{{Namespace: "ml", NewCost: 50}, {Namespace: "ops", OldCost: 20}}
+	if fmt.Sprint(diff.NamespaceCosts) != fmt.Sprint(wantCosts) {
+		t.Errorf("NamespaceCosts = %+v, want %+v", diff.NamespaceCosts, wantCosts)
+	}
+
+	md := ExportDiffToMarkdown(diff)
+	for _, want := range []string{
+		"| Health score | 92.0 | 85.5 | -6.5 |",
+		"| Est. monthly cost | $100.00 | $130.00 | +$30.00 |",
+		"### New (1)",
+		"| critical | pod | shop/api-0/app | privileged |",
+		"| shop | api-0 | CrashLoopBackOff | 7 |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	page := NewReportGenerator(nil).ExportDiffToHTML(diff)
+	if !strings.Contains(page, `<td class="worse">-6.5</td>`) || !strings.Contains(page, "shop/api-0/app") {
+		t.Errorf("HTML diff missing the health change or new finding:\n%s", page)
+	}
+}
+
+func TestDiffReports_SkipsMissingSections(t *testing.T) {
+	older := &ComprehensiveReport{GeneratedAt: time.Now(), IncludedSections: ReportSections{Nodes: true}}
+	newer := &ComprehensiveReport{GeneratedAt: time.Now(), IncludedSections: ReportSections{Nodes: true, FinOps: true}}
+	diff := DiffReports(older, newer)
+	if diff.SecurityCompared || diff.CostCompared || diff.PodsCompared {
+		t.Errorf("compared = %v/%v/%v, want none", diff.SecurityCompared, diff.CostCompared, diff.PodsCompared)
+	}
+	if md := ExportDiffToMarkdown(diff); !strings.Contains(md, "Not compared: a report has no security section") {
+		t.Errorf("markdown should note the missing security section:\n%s", md)
+	}
+}
+
+func TestLoadReportFile(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "report.json")
+	data, _ := json.Marshal(&ComprehensiveReport{GeneratedAt: time.Now(), HealthScore: 90})
+	if err := os.WriteFile(good, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if report, err := LoadReportFile(good); err != nil || report.HealthScore != 90 {
+		t.Errorf("LoadReportFile() = %+v, %v", report, err)
+	}
+
+	bad := filepath.Join(dir, "other.json")
+	if err := os.WriteFile(bad, []byte(`{"name": "x"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadReportFile(bad); err == nil {
+		t.Error("a JSON file without generated_at should be rejected")
+	}
+}
@@ -2,6 +2,8 @@ package web
 
 import (
 	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 )
 
 type ComprehensiveReport struct {
@@ -11,8 +13,11 @@ type ComprehensiveReport struct {
 	ClusterInfo      ClusterInfo         `json:"cluster_info"`
 	NodeSummary      NodeSummary         `json:"node_summary"`
 	Nodes            []NodeInfo          `json:"nodes"`
+	NodeCapacity     []NodeCapacityInfo  `json:"node_capacity,omitempty"`
 	NamespaceSummary NamespaceSummary    `json:"namespace_summary"`
 	Namespaces       []NamespaceInfo     `json:"namespaces"`
+	ResourceQuotas   []ResourceQuotaInfo `json:"resource_quotas,omitempty"`
+	LimitRanges      []LimitRangeInfo    `json:"limit_ranges,omitempty"`
 	Workloads        WorkloadSummary     `json:"workloads"`
 	Pods             []PodInfo           `json:"pods"`
 	Deployments      []DeploymentInfo    `json:"deployments"`
@@ -21,10 +26,35 @@ type ComprehensiveReport struct {
 	SecurityScan     *SecurityScanReport `json:"security_scan,omitempty"`
 	FinOpsAnalysis   FinOpsAnalysis      `json:"finops_analysis"`
 	Images           []ImageInfo         `json:"images"`
+	VolumeClaims     []VolumeClaimInfo   `json:"volume_claims,omitempty"`
+	Ingresses        []IngressInfo       `json:"ingresses,omitempty"`
+	NetworkPolicies  []NetworkPolicyInfo `json:"network_policies,omitempty"`
+	NetworkCoverage  []NetworkCoverage   `json:"network_coverage,omitempty"`
 	Events           []EventInfo         `json:"events"`
+	EventStats       ReportEventStats    `json:"event_stats"`
 	MetricsHistory   *MetricsHistory     `json:"metrics_history,omitempty"`
+	Drift            *DriftReport        `json:"drift,omitempty"`
 	AIAnalysis       string              `json:"ai_analysis,omitempty"`
+	AIAnalysisID     string              `json:"ai_analysis_id,omitempty"` // Pass as ai_id to reuse the analysis
 	HealthScore      float64             `json:"health_score"`
+	// UnreferencedSecrets are the secrets no workload, pod, Ingress, or
+	// service account in their namespace refers to
+	UnreferencedSecrets []UnreferencedSecretInfo `json:"unreferenced_secrets,omitempty"`
+	// Warnings name the data that could not be read, e.g. a list call
+	// RBAC forbids, so a partial report does not look complete
+	Warnings []string `json:"warnings,omitempty"`
+	// Pagination is set when the pod, deployment, service, and image lists
+	// hold one page rather than every item
+	Pagination *ReportPagination `json:"pagination,omitempty"`
+	// Namespace is set on a tenant report of that namespace alone, which
+	// leaves out nodes and other cluster-wide data
+	Namespace string `json:"namespace,omitempty"`
+	// DeprecatedAPIs lists the objects written with, and the manifests
+	// using, API versions deprecated or removed at the server's version
+	DeprecatedAPIs *k8s.DeprecatedAPIScan `json:"deprecated_apis,omitempty"`
+
+	// Branding overrides the HTML export's title, logo, and footer
+	Branding ReportBranding `json:"-"`
 }
 
 // SecurityScanReport contains results from security scanning tools
@@ -108,6 +138,17 @@ type MetricsHistory struct {
 	DataPoints     int                   `json:"data_points"`
 	ClusterMetrics []ClusterMetricPoint  `json:"cluster_metrics"`
 	Summary        MetricsHistorySummary `json:"summary"`
+	// Anomalies are the memory trends, pod drops, and CPU saturation found
+	// in the samples
+	Anomalies []MetricsAnomaly `json:"anomalies,omitempty"`
+}
+
+// MetricsAnomaly is a finding of the metrics history checks
+type MetricsAnomaly struct {
+	Kind     string `json:"kind"`     // memory_trend, pod_drop, or cpu_saturation
+	Severity string `json:"severity"` // warning or critical
+	Message  string `json:"message"`
+	At       string `json:"at"`
 }
 
 // ClusterMetricPoint is a single point in time-series
@@ -129,16 +170,28 @@ type MetricsHistorySummary struct {
 	MaxRunningPods int     `json:"max_running_pods"`
 }
 
+// FinOpsReport is the standalone cost export served by /api/reports/finops.
+// The analysis fields sit at the top level of its JSON.
+type FinOpsReport struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	GeneratedBy string    `json:"generated_by"`
+	FinOpsAnalysis
+}
+
 // FinOpsAnalysis contains cost optimization insights
 type FinOpsAnalysis struct {
-	TotalEstimatedMonthlyCost float64                   `json:"total_estimated_monthly_cost"`
-	EstimationModel           string                    `json:"estimation_model"`
-	EstimationNotes           []string                  `json:"estimation_notes,omitempty"`
-	CostByNamespace           []NamespaceCost           `json:"cost_by_namespace"`
-	ResourceEfficiency        ResourceEfficiencyInfo    `json:"resource_efficiency"`
-	CostOptimizations         []CostOptimization        `json:"cost_optimizations"`
-	UnderutilizedResources    []UnderutilizedResource   `json:"underutilized_resources"`
-	OverprovisionedWorkloads  []OverprovisionedWorkload `json:"overprovisioned_workloads"`
+	TotalEstimatedMonthlyCost float64         `json:"total_estimated_monthly_cost"`
+	EstimationModel           string          `json:"estimation_model"`
+	EstimationNotes           []string        `json:"estimation_notes,omitempty"`
+	CostByNamespace           []NamespaceCost `json:"cost_by_namespace"`
+	// CostAllocationLabel and CostByLabel are set when
+	// reports.cost_allocation_label is configured
+	CostAllocationLabel      string                    `json:"cost_allocation_label,omitempty"`
+	CostByLabel              []LabelCost               `json:"cost_by_label,omitempty"`
+	ResourceEfficiency       ResourceEfficiencyInfo    `json:"resource_efficiency"`
+	CostOptimizations        []CostOptimization        `json:"cost_optimizations"`
+	UnderutilizedResources   []UnderutilizedResource   `json:"underutilized_resources"`
+	OverprovisionedWorkloads []OverprovisionedWorkload `json:"overprovisioned_workloads"`
 }
 
 // NamespaceCost represents estimated cost per namespace
@@ -154,6 +207,24 @@ type NamespaceCost struct {
 	CostPercentage  float64 `json:"cost_percentage"`
 }
 
+// unallocatedCostLabel groups the cost of pods without the cost allocation label
+const unallocatedCostLabel = "unallocated"
+
+// LabelCost is the estimated cost of the pods sharing one value of the cost
+// allocation label, across every namespace they run in
+type LabelCost struct {
+	Value           string   `json:"value"` // unallocatedCostLabel for pods without the label
+	Namespaces      []string `json:"namespaces"`
+	PodCount        int      `json:"pod_count"`
+	RunningPodCount int      `json:"running_pod_count"`
+	CPURequests     string   `json:"cpu_requests"`
+	MemoryRequests  string   `json:"memory_requests"`
+	CPUUsage        string   `json:"cpu_usage"`
+	MemoryUsage     string   `json:"memory_usage"`
+	EstimatedCost   float64  `json:"estimated_cost"`
+	CostPercentage  float64  `json:"cost_percentage"`
+}
+
 // ResourceEfficiencyInfo contains resource utilization metrics
 type ResourceEfficiencyInfo struct {
 	TotalCPURequests         string  `json:"total_cpu_requests"`
@@ -216,6 +287,8 @@ type NodeSummary struct {
 	Unschedulable int `json:"unschedulable"`
 	Pressure      int `json:"pressure"`
 	WarningNodes  int `json:"warning_nodes"`
+	OverCommitted int `json:"over_committed"` // requests or limits exceed allocatable
+	UnderUtilized int `json:"under_utilized"` // CPU and memory below k8s.NodeUnderUtilizedPercent
 }
 
 type NodeInfo struct {
@@ -238,9 +311,40 @@ type NodeInfo struct {
 	Warnings          []string `json:"warnings,omitempty"`
 }
 
+// NodeCapacityInfo compares a node's allocatable CPU and memory with the
+// requests and limits of its pods and, when metrics-server is available,
+// actual usage. Percentages are of allocatable.
+type NodeCapacityInfo struct {
+	Name                   string   `json:"name"`
+	CPUAllocatable         string   `json:"cpu_allocatable"`
+	CPURequested           string   `json:"cpu_requested"`
+	CPURequestedPercent    int      `json:"cpu_requested_percent"`
+	CPULimits              string   `json:"cpu_limits"`
+	CPULimitsPercent       int      `json:"cpu_limits_percent"`
+	CPUUsed                string   `json:"cpu_used,omitempty"`
+	CPUUsedPercent         int      `json:"cpu_used_percent,omitempty"`
+	MemoryAllocatable      string   `json:"memory_allocatable"`
+	MemoryRequested        string   `json:"memory_requested"`
+	MemoryRequestedPercent int      `json:"memory_requested_percent"`
+	MemoryLimits           string   `json:"memory_limits"`
+	MemoryLimitsPercent    int      `json:"memory_limits_percent"`
+	MemoryUsed             string   `json:"memory_used,omitempty"`
+	MemoryUsedPercent      int      `json:"memory_used_percent,omitempty"`
+	Pods                   int      `json:"pods"`
+	PodCapacity            int64    `json:"pod_capacity"`
+	Status                 string   `json:"status"` // OK, OverCommitted, or UnderUtilized
+	Reasons                []string `json:"reasons,omitempty"`
+}
+
 type NamespaceSummary struct {
-	Total  int `json:"total"`
-	Active int `json:"active"`
+	Total     int `json:"total"`
+	Active    int `json:"active"`
+	NearQuota int `json:"near_quota"` // namespaces with a quota at or above k8s.QuotaNearLimitPercent
+	// Hidden counts namespaces left out by excluded_namespaces; the
+	// include_excluded query parameter reports them too
+	Hidden int `json:"hidden,omitempty"`
+	// Regex is the namespace_regex the report was limited to, if any
+	Regex string `json:"regex,omitempty"`
 }
 
 type NamespaceInfo struct {
@@ -250,30 +354,51 @@ type NamespaceInfo struct {
 	DeployCount  int    `json:"deploy_count"`
 	ServiceCount int    `json:"service_count"`
 	CreationTime string `json:"creation_time"`
+	QuotaStatus  string `json:"quota_status,omitempty"` // worst quota status: OK, NearLimit, Exceeded
+}
+
+// ResourceQuotaInfo is a namespace ResourceQuota with hard vs used per resource
+type ResourceQuotaInfo struct {
+	Namespace  string           `json:"namespace"`
+	Name       string           `json:"name"`
+	Usage      []k8s.QuotaUsage `json:"usage"`
+	MaxPercent float64          `json:"max_percent"`
+	Status     string           `json:"status"`
+}
+
+// LimitRangeInfo is a namespace LimitRange flattened to per-resource limits
+type LimitRangeInfo struct {
+	Namespace string                `json:"namespace"`
+	Name      string                `json:"name"`
+	Limits    []k8s.LimitRangeLimit `json:"limits"`
 }
 
 type WorkloadSummary struct {
-	TotalPods        int `json:"total_pods"`
-	RunningPods      int `json:"running_pods"`
-	PendingPods      int `json:"pending_pods"`
-	FailedPods       int `json:"failed_pods"`
-	TotalDeployments int `json:"total_deployments"`
-	HealthyDeploys   int `json:"healthy_deployments"`
-	TotalServices    int `json:"total_services"`
-	TotalConfigMaps  int `json:"total_configmaps"`
-	TotalSecrets     int `json:"total_secrets"`
+	TotalPods   int `json:"total_pods"`
+	RunningPods int `json:"running_pods"`
+	PendingPods int `json:"pending_pods"`
+	FailedPods  int `json:"failed_pods"`
+	// StuckTerminatingPods were deleted but remain past their grace period
+	StuckTerminatingPods int `json:"stuck_terminating_pods"`
+	TotalDeployments     int `json:"total_deployments"`
+	HealthyDeploys       int `json:"healthy_deployments"`
+	TotalServices        int `json:"total_services"`
+	TotalConfigMaps      int `json:"total_configmaps"`
+	TotalSecrets         int `json:"total_secrets"`
 }
 
 type PodInfo struct {
-	Name      string   `json:"name"`
-	Namespace string   `json:"namespace"`
-	Status    string   `json:"status"`
-	Ready     string   `json:"ready"`
-	Restarts  int      `json:"restarts"`
-	Node      string   `json:"node"`
-	IP        string   `json:"ip"`
-	Images    []string `json:"images"`
-	Age       string   `json:"age"`
+	Name          string   `json:"name"`
+	Namespace     string   `json:"namespace"`
+	Status        string   `json:"status"`
+	Ready         string   `json:"ready"`
+	Restarts      int      `json:"restarts"`
+	QoSClass      string   `json:"qos_class"`
+	PriorityClass string   `json:"priority_class,omitempty"`
+	Node          string   `json:"node"`
+	IP            string   `json:"ip"`
+	Images        []string `json:"images"`
+	Age           string   `json:"age"`
 }
 
 type DeploymentInfo struct {
@@ -303,9 +428,13 @@ type SecurityInfo struct {
 	ClusterRoles        int `json:"cluster_roles"`
 	ClusterRoleBindings int `json:"cluster_role_bindings"`
 	Secrets             int `json:"secrets"`
+	UnreferencedSecrets int `json:"unreferenced_secrets"`
 	PrivilegedPods      int `json:"privileged_pods"`
 	HostNetworkPods     int `json:"host_network_pods"`
 	RootContainers      int `json:"root_containers"`
+	// NamespacesWithoutNetworkPolicy counts namespaces running pods that no
+	// NetworkPolicy selects at all
+	NamespacesWithoutNetworkPolicy int `json:"namespaces_without_network_policy"`
 }
 
 type ImageInfo struct {
@@ -315,6 +444,57 @@ type ImageInfo struct {
 	PodCount   int    `json:"pod_count"`
 }
 
+// VolumeClaimInfo is a PersistentVolumeClaim that is Pending, Lost, or not
+// used by any pod
+type VolumeClaimInfo struct {
+	Namespace    string `json:"namespace"`
+	Name         string `json:"name"`
+	Status       string `json:"status"`
+	Issue        string `json:"issue"` // Pending, Lost, or Orphaned
+	Capacity     string `json:"capacity,omitempty"`
+	StorageClass string `json:"storage_class,omitempty"`
+	Volume       string `json:"volume,omitempty"` // Bound PersistentVolume
+}
+
+// UnreferencedSecretInfo is a Secret nothing in its namespace refers to
+type UnreferencedSecretInfo struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Age       string `json:"age"`
+}
+
+// IngressInfo is one host and path of an Ingress
+type IngressInfo struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Class     string `json:"class,omitempty"`
+	Host      string `json:"host"`
+	Path      string `json:"path"`
+	Backend   string `json:"backend"`
+	TLS       bool   `json:"tls"`
+}
+
+// NetworkPolicyInfo is a NetworkPolicy and how many pods it selects
+type NetworkPolicyInfo struct {
+	Namespace   string   `json:"namespace"`
+	Name        string   `json:"name"`
+	PodSelector string   `json:"pod_selector"`
+	PolicyTypes []string `json:"policy_types"`
+	Pods        int      `json:"pods"`
+}
+
+// NetworkCoverage is how many of a namespace's pods some NetworkPolicy
+// selects. Pods no policy selects accept traffic from anywhere.
+type NetworkCoverage struct {
+	Namespace     string   `json:"namespace"`
+	Policies      int      `json:"policies"`
+	Pods          int      `json:"pods"`
+	CoveredPods   int      `json:"covered_pods"`
+	UncoveredPods []string `json:"uncovered_pods,omitempty"`
+	Status        string   `json:"status"` // Covered, Partial, or NoPolicy
+}
+
 type EventInfo struct {
 	Type      string `json:"type"`
 	Reason    string `json:"reason"`
@@ -323,16 +503,60 @@ type EventInfo struct {
 	Count     int    `json:"count"`
 	FirstSeen string `json:"first_seen"`
 	LastSeen  string `json:"last_seen"`
+	Category  string `json:"category,omitempty"` // Warning events only; see reportEventCategory
+}
+
+// DriftReport compares the configured manifest directory with the cluster
+type DriftReport struct {
+	ManifestDir string              `json:"manifest_dir"`
+	InSync      int                 `json:"in_sync"`
+	Drifted     int                 `json:"drifted"`
+	Missing     int                 `json:"missing"`
+	Errors      int                 `json:"errors"`
+	Error       string              `json:"error,omitempty"` // Set when the directory could not be scanned
+	Resources   []k8s.ResourceDrift `json:"resources"`
+}
+
+// ReportEventOptions controls the events section of a report
+type ReportEventOptions struct {
+	Limit         int  // Max events listed; 0 lists them all
+	IncludeNormal bool // Also list Normal events after the Warnings
+}
+
+// ReportEventStats summarizes every event the report saw, including those
+// left out of Events by the limit.
+type ReportEventStats struct {
+	Warning    int                  `json:"warning"`
+	Normal     int                  `json:"normal"`
+	Listed     int                  `json:"listed"`
+	Omitted    int                  `json:"omitted"` // Events left out by the limit
+	Limit      int                  `json:"limit"`   // 0 means no limit
+	Categories []EventCategoryCount `json:"categories"`
+}
+
+// EventCategoryCount counts the Warning events of one category
+type EventCategoryCount struct {
+	Category    string   `json:"category"`
+	Events      int      `json:"events"`
+	Occurrences int      `json:"occurrences"` // Sum of the events' repeat counts
+	Reasons     []string `json:"reasons"`
 }
 
 // ReportGenerator handles report generation
 type ReportGenerator struct {
-	server *Server
+	server  *Server
+	aiCache *aiAnalysisCache
+	// redactionKey keys redaction pseudonyms when reports.redaction_key is unset
+	redactionKey []byte
 }
 
 // NewReportGenerator creates a new report generator
 func NewReportGenerator(server *Server) *ReportGenerator {
-	return &ReportGenerator{server: server}
+	return &ReportGenerator{
+		server:       server,
+		aiCache:      newAIAnalysisCache(aiAnalysisCacheTTL),
+		redactionKey: newRedactionKey(),
+	}
 }
 
 // ReportSections defines which sections to include in the report.
@@ -346,4 +570,8 @@ type ReportSections struct {
 	SecurityFull  bool `json:"security_full"`  // full scan with Trivy image vulnerability scanning
 	FinOps        bool `json:"finops"`
 	Metrics       bool `json:"metrics"`
+	Capacity      bool `json:"capacity"` // node allocatable vs requested vs usage
+	Drift         bool `json:"drift"`    // live resources vs drift.manifest_dir
+	// DeprecatedAPIs covers objects and manifests using deprecated API versions
+	DeprecatedAPIs bool `json:"deprecated_apis"`
 }
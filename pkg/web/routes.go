@@ -45,29 +45,14 @@ func (s *Server) registerAuthRoutes(mux *http.ServeMux) {
 // registerAIRoutes sets up AI assistant, LLM, MCP, and session routes.
 func (s *Server) registerAIRoutes(mux *http.ServeMux) {
 	auth := s.authManager.AuthMiddleware
-	aiFeature := s.authorizer.FeatureMiddleware(FeatureAIAssistant)
 
-	// AI chat and tool approval (feature-gated)
-	mux.HandleFunc("/api/chat/agentic", auth(aiFeature(s.handleAgenticChat)))
-	mux.HandleFunc("/api/tool/approve", auth(aiFeature(s.handleToolApprove)))
+	// AI chat, sessions, and LLM runtime (absent from noai builds)
+	s.registerAssistantRoutes(mux)
 
-	// AI session management
-	mux.HandleFunc("/api/sessions", auth(s.handleSessions))
-	mux.HandleFunc("/api/sessions/", auth(s.handleSession))
-
-	// AI / LLM configuration and status
+	// Settings and LLM usage
 	mux.HandleFunc("/api/settings", auth(s.handleSettings))
-	mux.HandleFunc("/api/settings/llm", auth(s.handleLLMSettings))
-	mux.HandleFunc("/api/settings/agent", auth(s.handleAgentSettings))
-	mux.HandleFunc("/api/settings/tool-approval", auth(s.handleToolApprovalSettings))
-	mux.HandleFunc("/api/llm/test", auth(s.handleLLMTest))
-	mux.HandleFunc("/api/llm/status", auth(s.handleLLMStatus))
-	mux.HandleFunc("/api/ai/ping", auth(s.handleAIPing))
-	mux.HandleFunc("/api/llm/ollama/status", auth(s.handleOllamaStatus))
-	mux.HandleFunc("/api/llm/ollama/pull", auth(s.handleOllamaPull))
 	mux.HandleFunc("/api/llm/usage", auth(s.handleLLMUsage))
 	mux.HandleFunc("/api/llm/usage/stats", auth(s.handleLLMUsageStats))
-	mux.HandleFunc("/api/llm/available-models", auth(s.handleAvailableModels))
 	mux.HandleFunc("/api/models", auth(s.handleModels))
 	mux.HandleFunc("/api/models/active", auth(s.handleActiveModel))
 
@@ -87,13 +72,13 @@ func (s *Server) registerK8sRoutes(mux *http.ServeMux) {
 	apply := s.authorizer.AuthzMiddleware("*", ActionApply)
 
 	mux.HandleFunc("/api/k8s/apply", auth(apply(s.handleYamlApply)))
+	mux.HandleFunc("/api/k8s/apply/manifest", auth(apply(s.handleManifestApply)))
 	mux.HandleFunc("/api/k8s/", auth(view(s.handleK8sResource)))
 	mux.HandleFunc("/api/crd/", auth(view(s.handleCustomResources)))
 	mux.HandleFunc("/api/overview", auth(s.handleClusterOverview))
 	mux.HandleFunc("/api/applications", auth(s.handleApplications))
 	mux.HandleFunc("/api/cost", auth(s.handleCostEstimate))
 	mux.HandleFunc("/api/search", auth(s.handleGlobalSearch))
-	mux.HandleFunc("/api/safety/analyze", auth(s.handleSafetyAnalysis))
 	mux.HandleFunc("/api/pulse", auth(s.handlePulse))
 	mux.HandleFunc("/api/xray", auth(s.handleXRay))
 	mux.HandleFunc("/api/diff", auth(s.handleResourceDiff))
@@ -116,7 +101,7 @@ func (s *Server) registerK8sRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/topology/", auth(s.authorizer.FeatureMiddleware(FeatureTopology)(s.handleTopology)))
 
 	// WebSocket terminal (feature-gated)
-	terminalHandler := NewTerminalHandler(s.k8sClient)
+	terminalHandler := NewTerminalHandler(s.k8sClient, s.authorizer)
 	mux.HandleFunc("/api/terminal/", auth(s.authorizer.FeatureMiddleware(FeatureTerminal)(terminalHandler.HandleTerminal)))
 	if s.experimental {
 		mux.HandleFunc("/api/tui/shell", auth(s.authorizer.FeatureMiddleware(FeatureHostTerminal)(s.HandleTUIShell)))
@@ -209,21 +194,8 @@ func (s *Server) registerMetricsRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/audit", auth(s.authorizer.FeatureMiddleware(FeatureAuditLogs)(s.handleAuditLogs)))
 	mux.HandleFunc("/api/reports", auth(s.authorizer.FeatureMiddleware(FeatureReports)(s.reportGenerator.HandleReports)))
 	mux.HandleFunc("/api/reports/preview", auth(s.authorizer.FeatureMiddleware(FeatureReports)(s.reportGenerator.HandleReportPreview)))
-}
-
-// registerSecurityRoutes sets up security scanning routes (feature-gated).
-func (s *Server) registerSecurityRoutes(mux *http.ServeMux) {
-	auth := s.authManager.AuthMiddleware
-	sec := s.authorizer.FeatureMiddleware(FeatureSecurityScan)
-
-	mux.HandleFunc("/api/security/scan", auth(sec(s.handleSecurityScan)))
-	mux.HandleFunc("/api/security/scan/quick", auth(sec(s.handleSecurityQuickScan)))
-	mux.HandleFunc("/api/security/scans", auth(sec(s.handleSecurityScanHistory)))
-	mux.HandleFunc("/api/security/scans/stats", auth(sec(s.handleSecurityScanStats)))
-	mux.HandleFunc("/api/security/scan/", auth(sec(s.handleSecurityScanDetail)))
-	mux.HandleFunc("/api/security/trivy/status", auth(sec(s.handleTrivyStatus)))
-	mux.HandleFunc("/api/security/trivy/install", auth(sec(s.handleTrivyInstall)))
-	mux.HandleFunc("/api/security/trivy/instructions", auth(sec(s.handleTrivyInstructions)))
+	mux.HandleFunc("/api/reports/finops", auth(s.authorizer.FeatureMiddleware(FeatureReports)(s.reportGenerator.HandleFinOpsReport)))
+	mux.HandleFunc("/api/reports/namespace/", auth(s.authorizer.FeatureMiddleware(FeatureReports)(s.reportGenerator.HandleNamespaceReport)))
 }
 
 // registerVisualizationRoutes sets up RBAC and network policy visualization routes.
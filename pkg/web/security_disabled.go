@@ -0,0 +1,43 @@
+//go:build nosecurity
+
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+)
+
+// Builds with the nosecurity tag leave out pkg/security and its Trivy and
+// kube-bench integrations. The server never has a scanner, so reports skip
+// the security sections and the /api/security routes are not registered.
+
+type clusterScanner struct{}
+
+func newClusterScanner(client *k8s.Client) *clusterScanner {
+	return nil
+}
+
+// SecurityScanScheduler is never started in nosecurity builds
+type SecurityScanScheduler struct{}
+
+// Stop is a no-op in nosecurity builds
+func (s *SecurityScanScheduler) Stop() {}
+
+func (s *Server) initSecurity(k8sClient *k8s.Client) {
+	fmt.Printf("  Security Scanner: Not included in this build (nosecurity)\n")
+}
+
+func (s *Server) registerSecurityRoutes(mux *http.ServeMux) {}
+
+func (rg *ReportGenerator) generateSecurityScan(ctx context.Context) *SecurityScanReport {
+	return nil
+}
+
+func (rg *ReportGenerator) generateFullSecurityScan(ctx context.Context) *SecurityScanReport {
+	return nil
+}
+
+func scopeSecurityScan(scan *SecurityScanReport, ns string) {}
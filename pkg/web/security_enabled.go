@@ -0,0 +1,65 @@
+//go:build !nosecurity
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
+	"github.com/cloudbro-kube-ai/k13d/pkg/security"
+)
+
+// clusterScanner is the security scanner behind reports and /api/security;
+// builds with the nosecurity tag replace it with an empty stub
+type clusterScanner = security.Scanner
+
+func newClusterScanner(client *k8s.Client) *clusterScanner {
+	return security.NewScanner(client)
+}
+
+// initSecurity creates the security scanner and, when security_scan.interval
+// is set, starts the scheduled scans
+func (s *Server) initSecurity(k8sClient *k8s.Client) {
+	if s.cfg.SecurityEnabled() {
+		s.securityScanner = newClusterScanner(k8sClient)
+		scannerInfo := "Basic checks"
+		if s.securityScanner.TrivyAvailable() {
+			scannerInfo += ", Trivy"
+		}
+		if s.securityScanner.KubeBenchAvailable() {
+			scannerInfo += ", kube-bench"
+		}
+		fmt.Printf("  Security Scanner: Ready (%s)\n", scannerInfo)
+	} else {
+		fmt.Printf("  Security Scanner: Disabled\n")
+	}
+
+	// Schedule background security scans
+	if interval, err := s.cfg.SecurityScan.ScanInterval(); err != nil {
+		fmt.Printf("  Security Scan Schedule: Disabled (%v)\n", err)
+	} else if interval > 0 && s.securityScanner != nil {
+		s.securityScheduler = NewSecurityScanScheduler(s.securityScanner, interval, s.cfg.SecurityScan.Full,
+			func(result *security.ScanResult, scanType string) {
+				s.recordSecurityScan(result, "", scanType, securityScanSource, securityScanSource)
+			})
+		s.securityScheduler.Start()
+		fmt.Printf("  Security Scan Schedule: Every %s (%s scan)\n", interval, s.securityScheduler.scanType())
+	}
+}
+
+// registerSecurityRoutes sets up security scanning routes (feature-gated).
+func (s *Server) registerSecurityRoutes(mux *http.ServeMux) {
+	auth := s.authManager.AuthMiddleware
+	sec := s.authorizer.FeatureMiddleware(FeatureSecurityScan)
+
+	mux.HandleFunc("/api/security/scan", auth(sec(s.handleSecurityScan)))
+	mux.HandleFunc("/api/security/scan/quick", auth(sec(s.handleSecurityQuickScan)))
+	mux.HandleFunc("/api/security/scan/latest", auth(sec(s.handleSecurityScanLatest)))
+	mux.HandleFunc("/api/security/scans", auth(sec(s.handleSecurityScanHistory)))
+	mux.HandleFunc("/api/security/scans/stats", auth(sec(s.handleSecurityScanStats)))
+	mux.HandleFunc("/api/security/scan/", auth(sec(s.handleSecurityScanDetail)))
+	mux.HandleFunc("/api/security/trivy/status", auth(sec(s.handleTrivyStatus)))
+	mux.HandleFunc("/api/security/trivy/install", auth(sec(s.handleTrivyInstall)))
+	mux.HandleFunc("/api/security/trivy/instructions", auth(sec(s.handleTrivyInstructions)))
+}
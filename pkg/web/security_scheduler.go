@@ -0,0 +1,230 @@
+//go:build !nosecurity
+
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+	"github.com/cloudbro-kube-ai/k13d/pkg/log"
+	"github.com/cloudbro-kube-ai/k13d/pkg/security"
+)
+
+const (
+	// securityScanTimeout bounds one scheduled scan; full scans pull every
+	// image through Trivy
+	securityScanTimeout = 30 * time.Minute
+	// securityScanSource marks scheduled scans in the security_scans table
+	securityScanSource = "scheduler"
+)
+
+// SecurityScanScheduler runs a cluster security scan at a fixed interval and
+// keeps the latest result, so reports and the dashboard show recent findings
+// without an expensive scan on every request.
+type SecurityScanScheduler struct {
+	interval time.Duration
+	full     bool
+	scan     func(ctx context.Context, full bool) (*security.ScanResult, error)
+	record   func(result *security.ScanResult, scanType string)
+
+	mu         sync.RWMutex
+	latest     *security.ScanResult
+	latestFull bool
+	lastRun    time.Time
+	nextRun    time.Time
+	lastErr    string
+	cancel     context.CancelFunc // Stops the schedule and any scan in progress
+	running    bool
+}
+
+// SecurityScanStatus describes the scan schedule for the API
+type SecurityScanStatus struct {
+	Enabled   bool       `json:"enabled"`
+	Interval  string     `json:"interval,omitempty"`
+	ScanType  string     `json:"scan_type,omitempty"` // full or quick
+	LastRun   *time.Time `json:"last_run,omitempty"`
+	NextRun   *time.Time `json:"next_run,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+// NewSecurityScanScheduler creates a scheduler that scans with scanner every
+// interval and records each result with record
+func NewSecurityScanScheduler(scanner *security.Scanner, interval time.Duration, full bool, record func(*security.ScanResult, string)) *SecurityScanScheduler {
+	return &SecurityScanScheduler{
+		interval: interval,
+		full:     full,
+		scan: func(ctx context.Context, full bool) (*security.ScanResult, error) {
+			if full {
+				return scanner.Scan(ctx, "")
+			}
+			return scanner.QuickScan(ctx, "")
+		},
+		record: record,
+	}
+}
+
+// scanType names the kind of scan the scheduler runs
+func (s *SecurityScanScheduler) scanType() string {
+	if s.full {
+		return "full"
+	}
+	return "quick"
+}
+
+// Start loads the latest stored scheduled scan and begins scanning: right
+// away when that scan is older than the interval, and then every interval
+func (s *SecurityScanScheduler) Start() {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = true
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.mu.Unlock()
+
+	s.loadStored()
+	log.Infof("Starting security scan scheduler (interval: %s, %s scans)", s.interval, s.scanType())
+
+	go func() {
+		first := time.Duration(0)
+		if last := s.lastRunTime(); !last.IsZero() {
+			first = max(s.interval-time.Since(last), 0)
+		}
+		timer := time.NewTimer(first)
+		defer timer.Stop()
+		s.setNextRun(time.Now().Add(first))
+
+		for {
+			select {
+			case <-timer.C:
+				s.RunOnce(ctx)
+				if ctx.Err() != nil {
+					return
+				}
+				timer.Reset(s.interval)
+				s.setNextRun(time.Now().Add(s.interval))
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops scheduled scans and cancels the one in progress, if any
+func (s *SecurityScanScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+	s.cancel()
+	s.running = false
+	log.Infof("Security scan scheduler stopped")
+}
+
+// RunOnce runs one scan, records it, and makes it the latest result. A
+// failed scan keeps the previous result and is reported in Status.
+func (s *SecurityScanScheduler) RunOnce(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, securityScanTimeout)
+	defer cancel()
+
+	result, err := s.scan(ctx, s.full)
+
+	s.mu.Lock()
+	s.lastRun = time.Now()
+	if err != nil {
+		s.lastErr = err.Error()
+		s.mu.Unlock()
+		log.Warnf("Scheduled security scan failed: %v", err)
+		return
+	}
+	s.latest, s.latestFull, s.lastErr = result, s.full, ""
+	s.mu.Unlock()
+
+	if s.record != nil {
+		s.record(result, s.scanType())
+	}
+}
+
+// Latest returns the latest scheduled scan result, or nil when there is none
+// newer than maxAge (0 accepts any age). With needFull only a full scan,
+// which includes image vulnerabilities, is returned.
+func (s *SecurityScanScheduler) Latest(maxAge time.Duration, needFull bool) *security.ScanResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.latest == nil || (needFull && !s.latestFull) {
+		return nil
+	}
+	if maxAge > 0 && time.Since(s.latest.ScanTime) > maxAge {
+		return nil
+	}
+	return s.latest
+}
+
+// Interval returns how often the scheduler scans
+func (s *SecurityScanScheduler) Interval() time.Duration {
+	return s.interval
+}
+
+// Status returns the schedule with the last and next run times
+func (s *SecurityScanScheduler) Status() SecurityScanStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	status := SecurityScanStatus{
+		Enabled:   true,
+		Interval:  s.interval.String(),
+		ScanType:  s.scanType(),
+		LastError: s.lastErr,
+	}
+	if !s.lastRun.IsZero() {
+		lastRun := s.lastRun
+		status.LastRun = &lastRun
+	}
+	if s.running && !s.nextRun.IsZero() {
+		nextRun := s.nextRun
+		status.NextRun = &nextRun
+	}
+	return status
+}
+
+func (s *SecurityScanScheduler) lastRunTime() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastRun
+}
+
+func (s *SecurityScanScheduler) setNextRun(t time.Time) {
+	s.mu.Lock()
+	s.nextRun = t
+	s.mu.Unlock()
+}
+
+// loadStored restores the latest scheduled scan from the database, so a
+// restart neither loses the result nor rescans before the interval is up
+func (s *SecurityScanScheduler) loadStored() {
+	scans, err := db.GetSecurityScans(db.SecurityScanFilter{Source: securityScanSource, Limit: 1})
+	if err != nil || len(scans) == 0 {
+		return
+	}
+	record, err := db.GetSecurityScanByID(scans[0].ID)
+	if err != nil || record == nil || record.ScanResult == "" {
+		return
+	}
+	var result security.ScanResult
+	if err := json.Unmarshal([]byte(record.ScanResult), &result); err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.latest, s.latestFull = &result, record.ScanType == "full"
+	s.lastRun = record.ScanTime
+	s.mu.Unlock()
+}
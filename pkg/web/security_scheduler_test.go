@@ -0,0 +1,132 @@
+//go:build !nosecurity
+
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
+	"github.com/cloudbro-kube-ai/k13d/pkg/security"
+)
+
+func TestSecurityScanScheduler_RunOnce(t *testing.T) {
+	scans := 0
+	var fail bool
+	var recorded []string
+	s := &SecurityScanScheduler{
+		interval: time.Hour,
+		scan: func(ctx context.Context, full bool) (*security.ScanResult, error) {
+			scans++
+			if fail {
+				return nil, errors.New("api unavailable")
+			}
+			return &security.ScanResult{ScanTime: time.Now(), OverallScore: float64(scans)}, nil
+		},
+		record: func(_ *security.ScanResult, scanType string) { recorded = append(recorded, scanType) },
+	}
+
+	if s.Latest(0, false) != nil {
+		t.Fatal("Latest() should be nil before the first scan")
+	}
+	s.RunOnce(context.Background())
+	latest := s.Latest(time.Hour, false)
+	if latest == nil || latest.OverallScore != 1 {
+		t.Fatalf("Latest() = %+v, want the first scan", latest)
+	}
+	if len(recorded) != 1 || recorded[0] != "quick" {
+		t.Errorf("recorded = %v, want one quick scan", recorded)
+	}
+	if s.Latest(0, true) != nil {
+		t.Error("a quick scan should not satisfy a full scan request")
+	}
+
+	// A failed scan keeps the previous result and reports the error
+	fail = true
+	s.RunOnce(context.Background())
+	if latest := s.Latest(0, false); latest == nil || latest.OverallScore != 1 {
+		t.Errorf("Latest() after a failure = %+v, want the previous scan", latest)
+	}
+	status := s.Status()
+	if status.LastError != "api unavailable" || status.LastRun == nil || status.Interval != "1h0m0s" {
+		t.Errorf("Status() = %+v", status)
+	}
+
+	// Results older than maxAge are not reused
+	s.latest.ScanTime = time.Now().Add(-2 * time.Hour)
+	if s.Latest(time.Hour, false) != nil {
+		t.Error("Latest() should skip a scan older than maxAge")
+	}
+}
+
+func TestSecurityScanScheduler_StopCancelsScan(t *testing.T) {
+	started := make(chan struct{})
+	done := make(chan error, 1)
+	s := &SecurityScanScheduler{
+		interval: time.Hour,
+		scan: func(ctx context.Context, full bool) (*security.ScanResult, error) {
+			close(started)
+			<-ctx.Done()
+			done <- ctx.Err()
+			return nil, ctx.Err()
+		},
+	}
+
+	s.Start()
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the first scan did not start")
+	}
+	s.Stop()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("scan context error = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop() did not cancel the running scan")
+	}
+}
+
+func TestSecurityScanScheduler_LoadsStoredScan(t *testing.T) {
+	if err := db.Init(filepath.Join(t.TempDir(), "scans.db")); err != nil {
+		t.Fatalf("Failed to init DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	result := &security.ScanResult{ScanTime: time.Now().Add(-time.Minute), ClusterName: "prod", OverallScore: 72, RiskLevel: "Medium"}
+	server := &Server{securityScanner: &security.Scanner{}}
+	server.recordSecurityScan(result, "", "full", securityScanSource, securityScanSource)
+
+	s := &SecurityScanScheduler{interval: time.Hour, full: true}
+	s.loadStored()
+	latest := s.Latest(time.Hour, true)
+	if latest == nil || latest.ClusterName != "prod" || latest.OverallScore != 72 {
+		t.Fatalf("Latest() = %+v, want the stored full scan", latest)
+	}
+	if s.Status().LastRun == nil {
+		t.Error("Status() should report the stored scan's time as the last run")
+	}
+
+	server.securityScheduler = s
+	w := httptest.NewRecorder()
+	server.handleSecurityScanLatest(w, httptest.NewRequest(http.MethodGet, "/api/security/scan/latest", nil))
+	var resp struct {
+		Schedule SecurityScanStatus   `json:"schedule"`
+		Scan     *security.ScanResult `json:"scan"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if !resp.Schedule.Enabled || resp.Schedule.ScanType != "full" || resp.Scan == nil || resp.Scan.OverallScore != 72 {
+		t.Errorf("latest response = %+v", resp)
+	}
+}
@@ -14,8 +14,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/cloudbro-kube-ai/k13d/pkg/ai"
-	"github.com/cloudbro-kube-ai/k13d/pkg/ai/session"
 	"github.com/cloudbro-kube-ai/k13d/pkg/automation"
 	"github.com/cloudbro-kube-ai/k13d/pkg/config"
 	"github.com/cloudbro-kube-ai/k13d/pkg/db"
@@ -23,7 +21,6 @@ import (
 	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	"github.com/cloudbro-kube-ai/k13d/pkg/mcp"
 	"github.com/cloudbro-kube-ai/k13d/pkg/metrics"
-	"github.com/cloudbro-kube-ai/k13d/pkg/security"
 	"github.com/cloudbro-kube-ai/k13d/pkg/web/frontendbundle"
 )
 
@@ -44,7 +41,7 @@ type VersionInfo struct {
 
 type Server struct {
 	cfg              *config.Config
-	aiClient         *ai.Client
+	aiClient         *llmClient
 	k8sClient        *k8s.Client
 	helmClient       *helm.Client
 	mcpClient        *mcp.Client
@@ -52,12 +49,16 @@ type Server struct {
 	authorizer       *Authorizer // RBAC authorizer (Teleport-inspired)
 	reportGenerator  *ReportGenerator
 	metricsCollector *metrics.Collector
-	securityScanner  *security.Scanner
-	sessionStore     *session.Store // AI conversation session storage
+	securityScanner  *clusterScanner
+	sessionStore     *chatSessionStore // AI conversation session storage
 	port             int
 	server           *http.Server
 	versionInfo      *VersionInfo
 
+	// securityScheduler runs scans at security_scan.interval; nil when
+	// scheduled scans are disabled
+	securityScheduler *SecurityScanScheduler
+
 	// Protects concurrent access to aiClient and cfg.LLM
 	aiMu sync.RWMutex
 
@@ -102,6 +103,8 @@ type ChatRequest struct {
 	Context   string `json:"context,omitempty"`    // Selected resource context for the AI prompt only
 	SessionID string `json:"session_id,omitempty"` // Session ID for conversation history
 	Language  string `json:"language,omitempty"`   // Display language preference (e.g., "ko", "en")
+	Namespace string `json:"namespace,omitempty"`  // Namespace selected in the UI (empty for all namespaces)
+	Resource  string `json:"resource,omitempty"`   // Resource view the question was asked from
 }
 
 type ChatResponse struct {
@@ -135,6 +138,19 @@ func (s *SSEWriter) Write(data string) error {
 	return nil
 }
 
+// WriteEvent writes an SSE event with a specific event type
+func (s *SSEWriter) WriteEvent(event string, data string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, data)
+	if err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
 func NewServer(cfg *config.Config, port int, versionInfo *VersionInfo) (*Server, error) {
 	// Default auth config: use audit flag to control auth
 	authConfig := &AuthConfig{
@@ -154,6 +170,7 @@ func NewServerWithAuth(cfg *config.Config, port int, authOpts *AuthOptions, vers
 		Enabled:         !authOpts.Disabled,
 		AuthMode:        authOpts.Mode,
 		SessionDuration: 24 * time.Hour,
+		LDAP:            LDAPConfigFromYAML(cfg.Authorization.LDAP),
 	}
 
 	// Set default admin credentials
@@ -176,12 +193,30 @@ func NewServerWithAuth(cfg *config.Config, port int, authOpts *AuthOptions, vers
 	return s, nil
 }
 
+// webClientOptions returns the Kubernetes client options of the web server.
+// It is usually deployed in-cluster, so the pod's service account wins over a
+// mounted kubeconfig unless --kubeconfig is given.
+func webClientOptions() k8s.ClientOptions {
+	opts := k8s.ClientOptionsFromEnv()
+	opts.PreferInCluster = true
+	return opts
+}
+
 // newServer contains the shared initialization logic for both constructors.
 func newServer(cfg *config.Config, port int, authConfig *AuthConfig, versionInfo *VersionInfo) (*Server, error) {
-	var aiClient *ai.Client
+	var aiClient *llmClient
 	var err error
 	runtimeInfo := config.GetRuntimeSourceInfo()
 
+	idleTimeout, maxLifetime, err := cfg.Web.Session.Timeouts()
+	if err != nil {
+		return nil, err
+	}
+	authConfig.IdleTimeout = idleTimeout
+	if maxLifetime > 0 {
+		authConfig.SessionDuration = maxLifetime
+	}
+
 	fmt.Printf("Starting k13d web server...\n")
 	fmt.Printf("  Config File: %s (%s)\n", runtimeInfo.ConfigPath, describeConfigFileStatus(runtimeInfo))
 	fmt.Printf("  Config Path Source: %s\n", runtimeInfo.ConfigPathSource)
@@ -196,9 +231,21 @@ func newServer(cfg *config.Config, port int, authConfig *AuthConfig, versionInfo
 	fmt.Printf("  LLM Settings: %s\n", describeLLMSource(runtimeInfo))
 	fmt.Printf("  LLM Provider: %s, Model: %s\n", cfg.LLM.Provider, cfg.LLM.Model)
 	fmt.Printf("  Login UI: %s\n", describeLoginUI(authConfig))
+	if authConfig.Enabled {
+		idle := "disabled"
+		if authConfig.IdleTimeout > 0 {
+			idle = authConfig.IdleTimeout.String()
+		}
+		fmt.Printf("  Sessions: max lifetime %s, idle timeout %s\n", authConfig.SessionDuration, idle)
+	}
 
-	aiClient, ready, err := createUsableAIClient(&cfg.LLM)
+	var ready bool
+	if cfg.AIEnabled() {
+		aiClient, ready, err = createUsableAIClient(&cfg.LLM)
+	}
 	switch {
+	case !cfg.AIEnabled():
+		fmt.Printf("  AI client: Disabled\n")
 	case err != nil:
 		fmt.Printf("  AI client creation failed: %v\n", err)
 		aiClient = nil
@@ -209,13 +256,18 @@ func newServer(cfg *config.Config, port int, authConfig *AuthConfig, versionInfo
 		fmt.Printf("  AI client: Not configured (missing endpoint or credentials)\n")
 	}
 
-	k8sClient, err := k8s.NewClient()
+	k8sOpts := webClientOptions()
+	// cfg already carries the K13D_KUBE_* overrides
+	k8sOpts.QPS = cfg.Kubernetes.QPS
+	k8sOpts.Burst = cfg.Kubernetes.Burst
+	k8sOpts.MaxConcurrency = cfg.Kubernetes.MaxConcurrency
+	k8sClient, err := k8s.NewClientWithOptions(k8sOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create k8s client: %w", err)
 	}
-	fmt.Printf("  K8s client: Ready\n")
+	fmt.Printf("  K8s client: Ready (config: %s)\n", k8sClient.ConfigSource)
 
-	// Initialize Helm client (uses default kubeconfig)
+	// Initialize Helm client (uses KUBECONFIG, which --kubeconfig also sets)
 	helmClient := helm.NewClient("", "")
 	fmt.Printf("  Helm client: Ready\n")
 
@@ -239,7 +291,7 @@ func newServer(cfg *config.Config, port int, authConfig *AuthConfig, versionInfo
 	}
 
 	// Initialize session store for AI conversation history
-	sessionStore, err := session.NewStore()
+	sessionStore, err := newChatSessionStore()
 	if err != nil {
 		fmt.Printf("  Session Store: Failed to initialize (%v)\n", err)
 	} else {
@@ -249,6 +301,15 @@ func newServer(cfg *config.Config, port int, authConfig *AuthConfig, versionInfo
 	// Initialize RBAC authorizer (Teleport-inspired)
 	authorizer := NewAuthorizer()
 	fmt.Printf("  RBAC Authorizer: Ready (roles: admin, user, viewer)\n")
+	if !cfg.AIEnabled() {
+		authorizer.DisableFeature(FeatureAIAssistant)
+	}
+	if !cfg.SecurityEnabled() {
+		authorizer.DisableFeature(FeatureSecurityScan)
+	}
+	if !cfg.FinOpsEnabled() {
+		authorizer.DisableFeature(FeatureCostEstimate)
+	}
 
 	// Initialize access request manager (Teleport-inspired)
 	accessReqManager := NewAccessRequestManager(30 * time.Minute)
@@ -278,6 +339,12 @@ func newServer(cfg *config.Config, port int, authConfig *AuthConfig, versionInfo
 		portForwardSessions:  make(map[string]*PortForwardSession),
 	}
 
+	// Enforce tool restrictions again at execution, after any approval
+	server.installToolGuard()
+	if cfg.Authorization.ToolApproval.SafeTools {
+		fmt.Printf("  AI Tools: Safe mode (read-only kubectl verbs)\n")
+	}
+
 	server.reportGenerator = NewReportGenerator(server)
 	fmt.Printf("  Reports: Ready\n")
 
@@ -324,16 +391,8 @@ func newServer(cfg *config.Config, port int, authConfig *AuthConfig, versionInfo
 		fmt.Printf("  Metrics Collector: Running (interval: 1m, retention: 7d)\n")
 	}
 
-	// Initialize security scanner
-	server.securityScanner = security.NewScanner(k8sClient)
-	scannerInfo := "Basic checks"
-	if server.securityScanner.TrivyAvailable() {
-		scannerInfo += ", Trivy"
-	}
-	if server.securityScanner.KubeBenchAvailable() {
-		scannerInfo += ", kube-bench"
-	}
-	fmt.Printf("  Security Scanner: Ready (%s)\n", scannerInfo)
+	// Initialize security scanner and scheduled scans
+	server.initSecurity(k8sClient)
 
 	// Set MCP reconnect callback to re-register tools when connection is restored
 	server.mcpClient.OnReconnect = func(serverName string) {
@@ -441,25 +500,6 @@ func (s *Server) initMCPServers() {
 	}
 }
 
-// registerMCPTools registers tools from an MCP server with the AI client
-func (s *Server) registerMCPTools(serverName string) {
-	if s.aiClient == nil {
-		return
-	}
-
-	mcpTools := s.mcpClient.GetAllTools()
-	registry := s.aiClient.GetToolRegistry()
-
-	// Set the MCP executor if not already set
-	registry.SetMCPExecutor(mcp.NewMCPToolExecutor(s.mcpClient))
-
-	for _, tool := range mcpTools {
-		if tool.ServerName == serverName {
-			registry.RegisterMCPTool(tool.Name, tool.Description, tool.ServerName, tool.InputSchema)
-		}
-	}
-}
-
 // recoveryMiddleware wraps a handler to catch and handle panics
 func recoveryMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -710,8 +750,14 @@ func (s *Server) Start() error {
 		),
 	)
 
+	tlsConfig, err := buildTLSConfig(s.cfg.Web.TLS)
+	if err != nil {
+		return fmt.Errorf("configure TLS: %w", err)
+	}
+
 	s.server = &http.Server{
 		Addr:              fmt.Sprintf(":%d", s.port),
+		TLSConfig:         tlsConfig,
 		Handler:           handler,
 		ReadHeaderTimeout: 10 * time.Second,
 		// ReadTimeout and WriteTimeout are intentionally 0 (no limit) to support
@@ -720,6 +766,11 @@ func (s *Server) Start() error {
 		IdleTimeout: 120 * time.Second,
 	}
 
+	if tlsConfig != nil {
+		fmt.Printf("\n  Web server started at https://localhost:%d\n", s.port)
+		// Certificates come from TLSConfig, which reloads rotated files
+		return s.server.ListenAndServeTLS("", "")
+	}
 	fmt.Printf("\n  Web server started at http://localhost:%d\n", s.port)
 	return s.server.ListenAndServe()
 }
@@ -786,6 +837,9 @@ func (s *Server) Stop() error {
 	if s.metricsCollector != nil {
 		s.metricsCollector.Stop()
 	}
+	if s.securityScheduler != nil {
+		s.securityScheduler.Stop()
+	}
 
 	// Stop notification manager
 	if s.notifManager != nil {
@@ -2574,7 +2574,14 @@ async function sendMessageAgentic(message, context = '') {
         const response = await fetchWithAuth('/api/chat/agentic', {
             method: 'POST',
             headers: { 'Content-Type': 'application/json' },
-            body: JSON.stringify({ message, context, language: currentLanguage, session_id: currentSessionId }),
+            body: JSON.stringify({
+                message,
+                context,
+                language: currentLanguage,
+                session_id: currentSessionId,
+                namespace: currentNamespace,
+                resource: currentResource
+            }),
             signal: signal
         });
 
@@ -5341,6 +5348,8 @@ function connectTerminalWebSocket() {
                 if (currentTerminal) currentTerminal.write(msg.data);
             } else if (msg.type === 'error') {
                 if (currentTerminal) currentTerminal.writeln('\x1b[31mError: ' + msg.data + '\x1b[0m');
+                // RBAC denials will not succeed on retry
+                if (String(msg.data).startsWith('Forbidden:')) terminalShouldReconnect = false;
             } else if (msg.type === 'pong') {
                 // Heartbeat response received
             }
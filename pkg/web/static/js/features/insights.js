@@ -689,12 +689,14 @@ function closeReportsModal() {
 function getReportSections() {
     const mapping = {
         'report-sec-workloads': 'workloads',
-        'report-sec-nodes': 'nodes,namespaces',
+        'report-sec-nodes': 'nodes,namespaces,capacity',
         'report-sec-security': 'security',
         'report-sec-trivy': 'security_full',
         'report-sec-finops': 'finops',
         'report-sec-events': 'events',
         'report-sec-metrics': 'metrics',
+        'report-sec-drift': 'drift',
+        'report-sec-deprecated': 'deprecated_apis',
     };
     const parts = [];
     for (const [id, value] of Object.entries(mapping)) {
@@ -707,6 +709,27 @@ function getReportIncludeAI() {
     return document.getElementById('report-sec-ai')?.checked ?? false;
 }
 
+// The last AI analysis generated for these report sections, so a download
+// after a preview reuses it instead of calling the LLM again
+let lastReportAI = null;
+
+function reportAIParam(sections, includeAI) {
+    if (!includeAI || !lastReportAI || lastReportAI.sections !== sections) return '';
+    return `&ai_id=${encodeURIComponent(lastReportAI.id)}`;
+}
+
+// Identifiers to hide in the report. Unchecked boxes send nothing, leaving
+// the server's reports.redact default in place.
+function reportRedactParam() {
+    const parts = ['ips', 'nodes', 'namespaces']
+        .filter(name => document.getElementById(`report-redact-${name}`)?.checked);
+    return parts.length ? `&redact=${parts.join(',')}` : '';
+}
+
+function rememberReportAI(sections, id) {
+    if (id) lastReportAI = { sections, id };
+}
+
 function reportSelectAll() {
     document.querySelectorAll('[id^="report-sec-"]').forEach(cb => cb.checked = true);
 }
@@ -715,6 +738,55 @@ function reportSelectNone() {
     document.querySelectorAll('[id^="report-sec-"]').forEach(cb => cb.checked = false);
 }
 
+// Fetch a report as server-sent events, showing generation progress in
+// statusEl. Resolves with the final report event: { format, filename,
+// ai_analysis_id, content }.
+async function fetchReportWithProgress(url, statusEl, label) {
+    const showProgress = (percent, message) => {
+        statusEl.innerHTML = `<div style="color: var(--accent-blue);">
+                    ${escapeHtml(label)}: ${escapeHtml(message)}
+                    <div style="height: 6px; background: var(--bg-tertiary); border-radius: 3px; margin-top: 6px; overflow: hidden;">
+                        <div style="height: 100%; width: ${percent}%; background: var(--accent-blue); transition: width 0.3s;"></div>
+                    </div>
+                </div>`;
+    };
+    showProgress(0, 'Starting...');
+
+    const resp = await fetchWithAuth(url, { headers: { 'Accept': 'text/event-stream' } });
+    if (!resp.ok) throw new Error('Failed to generate report');
+
+    const reader = resp.body.getReader();
+    const decoder = new TextDecoder();
+    let buffer = '';
+    while (true) {
+        const { done, value } = await reader.read();
+        if (done) break;
+        buffer += decoder.decode(value, { stream: true });
+
+        // Events are separated by a blank line and may span chunks
+        let end;
+        while ((end = buffer.indexOf('\n\n')) >= 0) {
+            const block = buffer.slice(0, end);
+            buffer = buffer.slice(end + 2);
+            let event = 'message';
+            let data = '';
+            for (const line of block.split('\n')) {
+                if (line.startsWith('event: ')) event = line.slice(7);
+                else if (line.startsWith('data: ')) data += line.slice(6);
+            }
+            const payload = JSON.parse(data);
+            if (event === 'progress') {
+                showProgress(payload.percent, payload.message);
+            } else if (event === 'error') {
+                throw new Error(payload.error);
+            } else if (event === 'report') {
+                return payload;
+            }
+        }
+    }
+    throw new Error('Report stream ended early');
+}
+
 // Preview report in new window
 async function previewReport() {
     const includeAI = getReportIncludeAI();
@@ -739,12 +811,11 @@ async function previewReport() {
             </div>`;
 
     try {
-        const url = `/api/reports/preview?ai=${includeAI}&sections=${encodeURIComponent(sections)}`;
-        const resp = await fetchWithAuth(url);
+        const url = `/api/reports/preview?ai=${includeAI}&sections=${encodeURIComponent(sections)}${reportAIParam(sections, includeAI)}${reportRedactParam()}`;
+        const result = await fetchReportWithProgress(url, statusEl, 'Generating report preview');
+        rememberReportAI(sections, result.ai_analysis_id);
 
-        if (!resp.ok) throw new Error('Failed to generate report');
-
-        const html = await resp.text();
+        const html = result.content;
 
         // Show preview inline using an iframe (avoids popup blocker issues)
         const previewEl = document.getElementById('report-preview');
@@ -791,13 +862,13 @@ async function downloadReport(format) {
             </div>`;
 
     try {
-        const url = `/api/reports?format=${format}&ai=${includeAI}&download=true&sections=${encodeURIComponent(sections)}`;
-        const resp = await fetchWithAuth(url);
-
-        if (!resp.ok) throw new Error('Failed to generate report');
+        const url = `/api/reports?format=${format}&ai=${includeAI}&download=true&sections=${encodeURIComponent(sections)}${reportAIParam(sections, includeAI)}${reportRedactParam()}`;
+        const result = await fetchReportWithProgress(url, statusEl, `Generating ${format.toUpperCase()} report`);
+        rememberReportAI(sections, result.ai_analysis_id);
 
-        const blob = await resp.blob();
-        const filename = resp.headers.get('Content-Disposition')?.match(/filename=(.+)/)?.[1]
+        const types = { csv: 'text/csv', html: 'text/html', json: 'application/json' };
+        const blob = new Blob([result.content], { type: types[format] || 'application/octet-stream' });
+        const filename = result.filename
             || `k13d-report-${new Date().toISOString().slice(0, 10)}.${format}`;
 
         // Trigger download
@@ -853,12 +924,13 @@ async function generateReport(format) {
     previewEl.innerHTML = '';
 
     try {
-        const url = `/api/reports?format=${format}&ai=${includeAI}&sections=${encodeURIComponent(sections)}`;
+        const url = `/api/reports?format=${format}&ai=${includeAI}&sections=${encodeURIComponent(sections)}${reportAIParam(sections, includeAI)}${reportRedactParam()}`;
 
         if (format === 'json') {
             // View JSON in preview
-            const resp = await fetchWithAuth(url);
-            const report = await resp.json();
+            const result = await fetchReportWithProgress(url, statusEl, 'Generating report');
+            const report = JSON.parse(result.content);
+            rememberReportAI(sections, report.ai_analysis_id);
 
             statusEl.innerHTML = `<div style="color: var(--accent-green);">
                         ✓ Report generated successfully at ${formatDateTime(report.generated_at)}
@@ -948,6 +1020,17 @@ async function generateReport(format) {
                                     `).join('')}
                                 </table>
                             </div>
+                            ${(report.finops_analysis?.cost_by_label || []).length ? `
+                            <div style="margin-top: 20px;">
+                                <h4 style="margin-bottom: 10px;">🏷️ Cost by ${escapeHtml(report.finops_analysis.cost_allocation_label)} (Top 5)</h4>
+                                <table style="width: 100%; font-size: 12px;">
+                                    <tr style="background: var(--bg-secondary);"><th style="padding: 8px;">${escapeHtml(report.finops_analysis.cost_allocation_label)}</th><th style="padding: 8px;">Namespaces</th><th style="padding: 8px;">Pods</th><th style="padding: 8px;">Est. Cost</th><th style="padding: 8px;">% of Total</th></tr>
+                                    ${report.finops_analysis.cost_by_label.slice(0, 5).map(lc => `
+                                        <tr><td style="padding: 8px;">${escapeHtml(lc.value)}</td><td style="padding: 8px;">${escapeHtml((lc.namespaces || []).join(', '))}</td><td style="padding: 8px;">${lc.pod_count}</td><td style="padding: 8px;">$${(lc.estimated_cost || 0).toFixed(2)}</td><td style="padding: 8px;">${(lc.cost_percentage || 0).toFixed(1)}%</td></tr>
+                                    `).join('')}
+                                </table>
+                            </div>
+                            ` : ''}
                             </div>
                             ` : ''}
 
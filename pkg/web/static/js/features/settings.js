@@ -566,7 +566,7 @@ async function loadLLMStatus() {
             statusText.textContent = 'Configuration Incomplete';
             statusText.style.color = 'var(--text-secondary)';
             const missing = [];
-            const providerNeedsAPIKey = !['ollama', 'bedrock', 'litellm'].includes(status.provider);
+            const providerNeedsAPIKey = !['ollama', 'bedrock', 'vertexai', 'litellm'].includes(status.provider);
             if (providerNeedsAPIKey && !status.has_api_key) missing.push('API key');
             if (!status.endpoint && !status.default_endpoint) missing.push('endpoint');
             statusDetail.textContent = missing.length > 0 ? 'Missing: ' + missing.join(', ') : 'Check configuration';
@@ -589,6 +589,7 @@ function updateEndpointPlaceholder(setDefaults = true) {
         'ollama': { placeholder: 'http://localhost:11434', hint: '(Required for Ollama)', model: 'gpt-oss:20b', apiKeyHint: '' },
         'gemini': { placeholder: 'https://generativelanguage.googleapis.com/v1beta', hint: '(Default: Gemini API)', model: 'gemini-2.5-flash', apiKeyHint: 'AIza...' },
         'anthropic': { placeholder: 'https://api.anthropic.com', hint: '(Default: Anthropic API)', model: 'claude-sonnet-4-6', apiKeyHint: 'sk-ant-...' },
+        'vertexai': { placeholder: '', hint: '(Uses Google Cloud credentials)', model: 'gemini-2.5-flash', apiKeyHint: '' },
         'bedrock': { placeholder: '', hint: '(Uses AWS credentials)', model: '', apiKeyHint: '' },
         'azopenai': { placeholder: 'https://your-resource.openai.azure.com', hint: '(Azure resource endpoint required)', model: '', apiKeyHint: '' }
     };
@@ -1044,6 +1045,13 @@ function applyFeaturePermissions() {
             item.style.display = 'none';
         }
     });
+    // The AI assistant is denied to this role or disabled on the server (--no-ai)
+    if (!hasFeature('ai_assistant')) {
+        ['ai-panel', 'resize-handle', 'ai-toggle-btn', 'ai-header-toggle-btn'].forEach(id => {
+            const el = document.getElementById(id);
+            if (el) el.style.display = 'none';
+        });
+    }
 }
 
 // === Roles Management ===
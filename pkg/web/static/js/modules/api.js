@@ -2,6 +2,15 @@
  * Unified API Fetch Wrapper with Error Handling
  */
 
+// Requests sent without recent keyboard or pointer input are marked as
+// background traffic (auto-refresh, metrics polling) so they do not keep an
+// idle session alive on the server.
+const USER_ACTIVITY_WINDOW_MS = 60 * 1000;
+let lastUserInputAt = Date.now();
+['keydown', 'pointerdown', 'wheel', 'touchstart'].forEach((type) => {
+    document.addEventListener(type, () => { lastUserInputAt = Date.now(); }, { capture: true, passive: true });
+});
+
 async function fetchWithAuth(url, options = {}) {
     const {
         silentErrors = false,
@@ -13,6 +22,9 @@ async function fetchWithAuth(url, options = {}) {
     if (window.authToken && window.authToken !== 'anonymous') {
         headers['Authorization'] = `Bearer ${window.authToken}`;
     }
+    if (Date.now() - lastUserInputAt > USER_ACTIVITY_WINDOW_MS) {
+        headers['X-K13D-Background'] = '1';
+    }
 
     try {
         const response = await fetch(url, { ...requestOptions, headers });
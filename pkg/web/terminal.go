@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cloudbro-kube-ai/k13d/pkg/db"
 	"github.com/cloudbro-kube-ai/k13d/pkg/k8s"
 	"github.com/gorilla/websocket"
 	corev1 "k8s.io/api/core/v1"
@@ -137,34 +138,54 @@ func (t *TerminalSession) Read(p []byte) (int, error) {
 	case "input":
 		return copy(p, msg.Data), nil
 	case "resize":
-		t.sizeChan <- remotecommand.TerminalSize{
-			Width:  msg.Cols,
-			Height: msg.Rows,
-		}
+		t.resize(remotecommand.TerminalSize{Width: msg.Cols, Height: msg.Rows})
+		return 0, nil
+	case "ping":
+		t.send(TerminalMessage{Type: "pong"})
 		return 0, nil
 	}
 
 	return 0, nil
 }
 
+// resize queues the latest terminal size without blocking the input reader.
+// A size the executor has not picked up yet is replaced, and empty sizes
+// (a hidden xterm.js panel) are ignored.
+func (t *TerminalSession) resize(size remotecommand.TerminalSize) {
+	if size.Width == 0 || size.Height == 0 {
+		return
+	}
+	for {
+		select {
+		case t.sizeChan <- size:
+			return
+		default:
+		}
+		select {
+		case <-t.sizeChan:
+		default:
+		}
+	}
+}
+
 // Write implements io.Writer for terminal output
 func (t *TerminalSession) Write(p []byte) (int, error) {
+	if err := t.send(TerminalMessage{Type: "output", Data: string(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// send writes one message to the client
+func (t *TerminalSession) send(msg TerminalMessage) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	msg := TerminalMessage{
-		Type: "output",
-		Data: string(p),
-	}
 	data, err := json.Marshal(msg)
 	if err != nil {
-		return 0, fmt.Errorf("failed to marshal terminal message: %w", err)
-	}
-
-	if err := t.conn.WriteMessage(websocket.TextMessage, data); err != nil {
-		return 0, err
+		return fmt.Errorf("failed to marshal terminal message: %w", err)
 	}
-	return len(p), nil
+	return t.conn.WriteMessage(websocket.TextMessage, data)
 }
 
 // Next implements remotecommand.TerminalSizeQueue
@@ -185,28 +206,19 @@ func (t *TerminalSession) Close() {
 
 // SendError sends an error message to the client
 func (t *TerminalSession) SendError(err error) {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-
-	msg := TerminalMessage{
-		Type: "error",
-		Data: err.Error(),
-	}
-	data, marshalErr := json.Marshal(msg)
-	if marshalErr != nil {
-		return
-	}
-	_ = t.conn.WriteMessage(websocket.TextMessage, data)
+	_ = t.send(TerminalMessage{Type: "error", Data: err.Error()})
 }
 
 // TerminalHandler handles WebSocket terminal connections
 type TerminalHandler struct {
-	k8sClient *k8s.Client
+	k8sClient  *k8s.Client
+	authorizer *Authorizer
 }
 
-// NewTerminalHandler creates a new terminal handler
-func NewTerminalHandler(k8sClient *k8s.Client) *TerminalHandler {
-	return &TerminalHandler{k8sClient: k8sClient}
+// NewTerminalHandler creates a new terminal handler. Sessions need the exec
+// action on pods in the target namespace; a nil authorizer skips that check.
+func NewTerminalHandler(k8sClient *k8s.Client, authorizer *Authorizer) *TerminalHandler {
+	return &TerminalHandler{k8sClient: k8sClient, authorizer: authorizer}
 }
 
 // HandleTerminal handles WebSocket terminal requests
@@ -224,6 +236,13 @@ func (h *TerminalHandler) HandleTerminal(w http.ResponseWriter, r *http.Request)
 	podName := parts[1]
 	container := r.URL.Query().Get("container")
 
+	// RBAC is checked before the upgrade; a denial is reported over the
+	// socket so the browser terminal can show why
+	allowed, reason := true, ""
+	if h.authorizer != nil {
+		allowed, reason = h.authorizer.authorizeRequest(r, "pods", ActionExec, namespace)
+	}
+
 	// Upgrade to WebSocket
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -233,6 +252,11 @@ func (h *TerminalHandler) HandleTerminal(w http.ResponseWriter, r *http.Request)
 	session := NewTerminalSession(conn)
 	defer session.Close()
 
+	if !allowed {
+		_ = session.send(TerminalMessage{Type: "error", Data: "Forbidden: " + reason})
+		return
+	}
+
 	// Get pod to find default container if not specified
 	if container == "" {
 		pod, err := h.k8sClient.Clientset.CoreV1().Pods(namespace).Get(r.Context(), podName, metav1.GetOptions{})
@@ -266,6 +290,22 @@ func (h *TerminalHandler) HandleTerminal(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	username := r.Header.Get("X-Username")
+	target := fmt.Sprintf("%s/%s", namespace, podName)
+	_ = db.RecordAudit(db.AuditEntry{
+		User:            username,
+		Action:          "terminal_session_start",
+		Resource:        "pod",
+		Details:         fmt.Sprintf("%s container=%s", target, container),
+		Source:          "web",
+		ClientIP:        r.RemoteAddr,
+		RequestedAction: string(ActionExec),
+		TargetResource:  "pod/" + podName,
+		TargetNamespace: namespace,
+		AuthzDecision:   "allowed",
+	})
+	started := time.Now()
+
 	// Run the terminal session
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 	defer cancel()
@@ -278,9 +318,22 @@ func (h *TerminalHandler) HandleTerminal(w http.ResponseWriter, r *http.Request)
 		TerminalSizeQueue: session,
 	})
 
+	end := db.AuditEntry{
+		User:            username,
+		Action:          "terminal_session_end",
+		Resource:        "pod",
+		Details:         fmt.Sprintf("%s container=%s duration=%s", target, container, time.Since(started).Round(time.Second)),
+		Source:          "web",
+		ClientIP:        r.RemoteAddr,
+		RequestedAction: string(ActionExec),
+		TargetResource:  "pod/" + podName,
+		TargetNamespace: namespace,
+	}
 	if err != nil {
+		end.ErrorMsg = err.Error()
 		session.SendError(fmt.Errorf("exec error: %v", err))
 	}
+	_ = db.RecordAudit(end)
 }
 
 // splitPath splits a URL path and removes the prefix
@@ -0,0 +1,93 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+func TestHandleTerminal_DeniesExecWithoutPermission(t *testing.T) {
+	handler := NewTerminalHandler(nil, NewAuthorizer())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Set("X-User-Role", "user")
+		r.Header.Set("X-Username", "alice")
+		handler.HandleTerminal(w, r)
+	}))
+	defer srv.Close()
+
+	// The built-in user role may not exec into kube-system
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/terminal/kube-system/coredns-0"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	var msg TerminalMessage
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	if msg.Type != "error" || !strings.HasPrefix(msg.Data, "Forbidden:") {
+		t.Errorf("message = %+v, want a Forbidden error", msg)
+	}
+}
+
+func TestTerminalSession_ResizeKeepsLatestSize(t *testing.T) {
+	session := &TerminalSession{
+		sizeChan: make(chan remotecommand.TerminalSize, 1),
+		doneChan: make(chan struct{}),
+	}
+
+	// Neither call may block while the executor is not reading sizes
+	session.resize(remotecommand.TerminalSize{Width: 80, Height: 24})
+	session.resize(remotecommand.TerminalSize{Width: 0, Height: 40})
+	session.resize(remotecommand.TerminalSize{Width: 120, Height: 40})
+
+	got := session.Next()
+	if got == nil || got.Width != 120 || got.Height != 40 {
+		t.Fatalf("Next() = %+v, want 120x40", got)
+	}
+
+	close(session.doneChan)
+	if got := session.Next(); got != nil {
+		t.Errorf("Next() after close = %+v, want nil", got)
+	}
+}
+
+func TestTerminalSession_AnswersPing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		session := NewTerminalSession(conn)
+		defer session.Close()
+		buf := make([]byte, 64)
+		_, _ = session.Read(buf)
+	}))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(TerminalMessage{Type: "ping"}); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var msg TerminalMessage
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	if msg.Type != "pong" {
+		t.Errorf("reply type = %q, want pong", msg.Type)
+	}
+}
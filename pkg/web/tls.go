@@ -0,0 +1,159 @@
+package web
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+	"github.com/cloudbro-kube-ai/k13d/pkg/log"
+)
+
+// certReloadInterval bounds how often the certificate files are checked for
+// changes during TLS handshakes
+const certReloadInterval = 10 * time.Second
+
+// certReloader serves a certificate loaded from PEM files and reloads it when
+// either file's modification time changes. Kubernetes secret mounts swap a
+// symlink on rotation, which os.Stat follows, so cert-manager renewals are
+// picked up without restarting the server.
+type certReloader struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	certMod   time.Time
+	keyMod    time.Time
+	lastCheck time.Time
+}
+
+// newCertReloader loads the initial certificate, failing when it is missing
+// or does not match the key
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, interval: certReloadInterval}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reload reads both files when their modification times differ from the
+// loaded certificate's. Callers must hold mu, except during construction.
+func (r *certReloader) reload() error {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return fmt.Errorf("stat TLS certificate: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return fmt.Errorf("stat TLS key: %w", err)
+	}
+	if r.cert != nil && certInfo.ModTime().Equal(r.certMod) && keyInfo.ModTime().Equal(r.keyMod) {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load TLS key pair: %w", err)
+	}
+	reloaded := r.cert != nil
+	r.cert, r.certMod, r.keyMod = &cert, certInfo.ModTime(), keyInfo.ModTime()
+	if reloaded {
+		log.Infof("Reloaded TLS certificate from %s", r.certFile)
+	}
+	return nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate. A failed reload keeps
+// serving the previous certificate, since rotation may be half-written.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now := time.Now(); now.Sub(r.lastCheck) >= r.interval {
+		r.lastCheck = now
+		if err := r.reload(); err != nil {
+			log.Warnf("TLS certificate reload failed, keeping the current certificate: %v", err)
+		}
+	}
+	return r.cert, nil
+}
+
+// generateSelfSignedCert creates an in-memory ECDSA certificate for
+// localhost, valid for one year
+func generateSelfSignedCert() (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("generate key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("generate serial number: %w", err)
+	}
+
+	dnsNames := []string{"localhost"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" && hostname != "localhost" {
+		dnsNames = append(dnsNames, hostname)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"k13d"}, CommonName: "k13d self-signed"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              dnsNames,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("create certificate: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("parse certificate: %w", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, nil
+}
+
+// buildTLSConfig returns the server TLS configuration, or nil when TLS is not
+// configured. Certificate files take precedence over SelfSigned.
+func buildTLSConfig(cfg config.WebTLSConfig) (*tls.Config, error) {
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, fmt.Errorf("both a TLS certificate and key are required")
+	}
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.CertFile != "" && cfg.KeyFile != "" {
+		reloader, err := newCertReloader(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.GetCertificate = reloader.GetCertificate
+		return tlsCfg, nil
+	}
+
+	cert, err := generateSelfSignedCert()
+	if err != nil {
+		return nil, fmt.Errorf("self-signed TLS certificate: %w", err)
+	}
+	log.Warnf("Serving a self-signed TLS certificate; browsers will warn. Use --tls-cert/--tls-key in production")
+	tlsCfg.Certificates = []tls.Certificate{cert}
+	return tlsCfg, nil
+}
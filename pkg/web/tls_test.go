@@ -0,0 +1,118 @@
+package web
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudbro-kube-ai/k13d/pkg/config"
+)
+
+// writeTestKeyPair writes a fresh self-signed pair and sets both files'
+// modification time to mod
+func writeTestKeyPair(t *testing.T, certFile, keyFile string, mod time.Time) *x509.Certificate {
+	t.Helper()
+	cert, err := generateSelfSignedCert()
+	if err != nil {
+		t.Fatalf("generateSelfSignedCert() error = %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	for path, data := range map[string][]byte{certFile: certPEM, keyFile: keyPEM} {
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatalf("WriteFile(%s) error = %v", path, err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatalf("Chtimes(%s) error = %v", path, err)
+		}
+	}
+	return cert.Leaf
+}
+
+func TestCertReloader_ReloadsOnChange(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	start := time.Now().Add(-time.Hour)
+	first := writeTestKeyPair(t, certFile, keyFile, start)
+
+	r, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("newCertReloader() error = %v", err)
+	}
+	r.interval = 0
+
+	got, err := r.GetCertificate(nil)
+	if err != nil || got.Leaf == nil || !got.Leaf.Equal(first) {
+		t.Fatalf("GetCertificate() = %v, %v; want the initial certificate", got, err)
+	}
+
+	// A rotated pair with a newer modification time replaces the old one
+	second := writeTestKeyPair(t, certFile, keyFile, start.Add(time.Minute))
+	got, _ = r.GetCertificate(nil)
+	if got.Leaf == nil || !got.Leaf.Equal(second) {
+		t.Fatal("GetCertificate() did not pick up the rotated certificate")
+	}
+
+	// A half-written rotation keeps serving the last good certificate
+	if err := os.WriteFile(keyFile, []byte("not a key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err = r.GetCertificate(nil)
+	if err != nil || got.Leaf == nil || !got.Leaf.Equal(second) {
+		t.Fatalf("GetCertificate() after a bad rotation = %v, %v; want the previous certificate", got, err)
+	}
+}
+
+func TestCertReloader_ThrottlesChecks(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	start := time.Now().Add(-time.Hour)
+	first := writeTestKeyPair(t, certFile, keyFile, start)
+
+	r, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("newCertReloader() error = %v", err)
+	}
+	_, _ = r.GetCertificate(nil)
+
+	writeTestKeyPair(t, certFile, keyFile, start.Add(time.Minute))
+	got, _ := r.GetCertificate(nil)
+	if !got.Leaf.Equal(first) {
+		t.Error("GetCertificate() reloaded before the check interval elapsed")
+	}
+}
+
+func TestBuildTLSConfig(t *testing.T) {
+	if cfg, err := buildTLSConfig(config.WebTLSConfig{}); cfg != nil || err != nil {
+		t.Errorf("buildTLSConfig(empty) = %v, %v; want plain HTTP", cfg, err)
+	}
+	if _, err := buildTLSConfig(config.WebTLSConfig{CertFile: "tls.crt", SelfSigned: true}); err == nil {
+		t.Error("buildTLSConfig() with a certificate but no key should fail")
+	}
+	if _, err := buildTLSConfig(config.WebTLSConfig{CertFile: "missing.crt", KeyFile: "missing.key"}); err == nil {
+		t.Error("buildTLSConfig() with missing files should fail")
+	}
+
+	cfg, err := buildTLSConfig(config.WebTLSConfig{SelfSigned: true})
+	if err != nil {
+		t.Fatalf("buildTLSConfig(self-signed) error = %v", err)
+	}
+	if cfg.MinVersion != tls.VersionTLS12 || len(cfg.Certificates) != 1 {
+		t.Fatalf("self-signed config = %+v", cfg)
+	}
+	leaf := cfg.Certificates[0].Leaf
+	if err := leaf.VerifyHostname("localhost"); err != nil {
+		t.Errorf("self-signed certificate does not cover localhost: %v", err)
+	}
+	if err := leaf.VerifyHostname("127.0.0.1"); err != nil {
+		t.Errorf("self-signed certificate does not cover 127.0.0.1: %v", err)
+	}
+}